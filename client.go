@@ -2,19 +2,25 @@ package loop
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/lightninglabs/aperture/lsat"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightninglabs/loop/sweep"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
 )
 
 var (
@@ -38,6 +44,37 @@ var (
 	// is too soon for us.
 	ErrExpiryTooFar = errors.New("swap expiry too far")
 
+	// ErrMaxLockedValue is returned when a swap would take the total value
+	// locked in pending swaps above our configured maximum.
+	ErrMaxLockedValue = errors.New("swap exceeds maximum locked value")
+
+	// ErrLoopInWalletFraction is returned when a loop in would commit more
+	// than our configured fraction of our on-chain funds to pending loop
+	// in htlcs.
+	ErrLoopInWalletFraction = errors.New("loop in exceeds maximum " +
+		"fraction of on-chain funds")
+
+	// ErrInvoiceHashMismatch is returned when the payment hash of the
+	// server's swap invoice does not match the hash of our swap.
+	ErrInvoiceHashMismatch = errors.New("swap invoice hash does not " +
+		"match swap hash")
+
+	// ErrInvoiceAmountMismatch is returned when the amounts of the
+	// server's invoices do not cover the swap amount that we requested.
+	ErrInvoiceAmountMismatch = errors.New("invoice amounts do not cover " +
+		"swap amount")
+
+	// ErrUnexpectedPrepayInvoice is returned when the server reports that
+	// it included the prepay amount in a loop out's swap invoice, but
+	// also provides a prepay invoice.
+	ErrUnexpectedPrepayInvoice = errors.New("server provided prepay " +
+		"invoice for batched prepay")
+
+	// ErrInvoiceDestMismatch is returned when the server's invoices do not
+	// pay to the node that we expect.
+	ErrInvoiceDestMismatch = errors.New("invoice destination does not " +
+		"match swap server")
+
 	// serverRPCTimeout is the maximum time a gRPC request to the server
 	// should be allowed to take.
 	serverRPCTimeout = 30 * time.Second
@@ -68,6 +105,33 @@ type Client struct {
 	resumeReady chan struct{}
 	wg          sync.WaitGroup
 
+	// idempotencyKeys holds the idempotency keys of the swaps that we are
+	// currently creating. Each key maps to a channel that is closed once
+	// the swap's creation has finished, so that concurrent retries of the
+	// same request wait for it rather than creating a second swap. It
+	// must be accessed with the idempotencyLock held.
+	idempotencyKeys map[string]chan struct{}
+	idempotencyLock sync.Mutex
+
+	// xpubLock serializes the creation of loop outs that sweep to our
+	// destination xpub, so that concurrent swaps do not derive the same
+	// address before either has marked its index as used.
+	xpubLock sync.Mutex
+
+	// lockedValueLock serializes the creation of swaps while we have a
+	// maximum locked value or loop in wallet fraction configured, so that
+	// concurrent requests cannot both pass our checks against them.
+	lockedValueLock sync.Mutex
+
+	// destXpub is the extended public key that loop out sweep addresses
+	// are derived from. It is nil if no xpub is configured.
+	destXpub *hdkeychain.ExtendedKey
+
+	// serverCache caches the swap server's terms and tracks the health
+	// of our connection to it. It is nil if the client was not created
+	// with NewClient.
+	serverCache *cachedSwapServerClient
+
 	clientConfig
 }
 
@@ -77,6 +141,17 @@ type ClientConfig struct {
 	// ServerAddress is the loop server to connect to.
 	ServerAddress string
 
+	// FailoverServerAddresses is an ordered list of loop servers that we
+	// fail over to if the server that we are using is unreachable. They
+	// must be endpoints of the same swap service as ServerAddress, because
+	// pending swaps are continued through whichever server is active.
+	FailoverServerAddresses []string
+
+	// ServerFailoverTimeout is the amount of time that a server must be
+	// unreachable for before we fail over to the next server. If zero,
+	// DefaultServerFailoverTimeout is used.
+	ServerFailoverTimeout time.Duration
+
 	// ProxyAddress is the SOCKS proxy that should be used to establish the
 	// connection.
 	ProxyAddress string
@@ -103,11 +178,123 @@ type ClientConfig struct {
 	// for a loop out swap. When greater than one, a multi-part payment may
 	// be attempted.
 	LoopOutMaxParts uint32
+
+	// LoopOutPaymentTimeout is the timeout for the off-chain swap and
+	// prepay payments of loop out swaps. Individual swaps may override
+	// it. If zero, DefaultPaymentTimeout is used.
+	LoopOutPaymentTimeout time.Duration
+
+	// BatchSweeps indicates whether the htlcs of loop out swaps should be
+	// swept together in batch transactions rather than individually.
+	BatchSweeps bool
+
+	// SweepFeeBumpBlocks is the number of blocks that we wait for a loop
+	// out sweep or loop in refund to confirm before we increase its fee
+	// rate. A zero value disables fee bumping.
+	SweepFeeBumpBlocks int32
+
+	// LoopInRefundConfTarget is the confirmation target that refunds of
+	// timed out loop in htlcs are published with. If zero,
+	// TimeoutTxConfTarget is used.
+	LoopInRefundConfTarget int32
+
+	// LoopInRefundMaxFee is the maximum fee that we pay to refund a timed
+	// out loop in htlc. If the fee required to reach our confirmation
+	// target is higher, the refund is published with this fee instead. If
+	// zero, no limit is applied.
+	LoopInRefundMaxFee btcutil.Amount
+
+	// MinPreimageRevealDelta is the minimum number of blocks that must
+	// remain before a loop out htlc expires for us to reveal our preimage
+	// and sweep it. If fewer blocks remain, the swap is failed rather than
+	// racing the server's timeout sweep. If zero, the default
+	// MinLoopOutPreimageRevealDelta is used.
+	MinPreimageRevealDelta int32
+
+	// DestXpub is an optional extended public key that loop out sweep
+	// addresses can be derived from, so that swaps can pay out to an
+	// external wallet without reusing addresses.
+	DestXpub string
+
+	// MaxLockedValue is the maximum total amount that may be locked in
+	// pending swaps at any one time. Swaps that would take us above this
+	// value are rejected. If zero, no limit is applied.
+	MaxLockedValue btcutil.Amount
+
+	// MaxLoopInWalletFraction is the maximum fraction of our on-chain
+	// funds that may be committed to the htlcs of pending loop ins at
+	// once, where our on-chain funds are our confirmed wallet balance plus
+	// the amount of those loop ins. This keeps funds in our wallet to pay
+	// fees, such as those of force closes. Loop ins that are published by
+	// an external wallet are not counted. If zero, no limit is applied.
+	MaxLoopInWalletFraction float64
+
+	// DBPassword is an optional password that our swap contracts are
+	// encrypted with on disk. If it is empty, the database must not have
+	// been encrypted previously.
+	DBPassword []byte
+
+	// TermsCacheTTL is the amount of time that we cache the swap server's
+	// terms for. If zero, terms are fetched from the server on every
+	// call.
+	TermsCacheTTL time.Duration
+
+	// ConfPolicy scales the htlc confirmations and sweep confirmation
+	// target of loop out swaps that do not set their own values with the
+	// swap amount. The zero value applies our defaults to all swaps.
+	ConfPolicy ConfPolicy
+
+	// NoTxLabels disables the labels that we add to the on-chain
+	// transactions of our swaps in lnd's wallet, which identify the type
+	// and hash of the swap that a transaction belongs to.
+	NoTxLabels bool
+
+	// MaxConcurrentSwaps is the maximum number of swaps that we execute at
+	// once. New swaps that are started while this many swaps are executing
+	// are queued until one of them completes. Pending swaps that are
+	// resumed on startup are never queued. If zero, the number of swaps
+	// that we execute at once is not limited.
+	MaxConcurrentSwaps int
+
+	// ForceStaleDB allows the client to start with a database that is older
+	// than the backup that was taken the last time that it was opened.
+	// This should only be set if the user is sure that the database is
+	// current, because running with a stale database may reveal preimages
+	// for swaps more than once.
+	ForceStaleDB bool
+
+	// Clock is the clock that the client uses to timestamp swaps and to
+	// time retries and expiries. If nil, the system clock is used.
+	Clock clock.Clock
+
+	// Rand is the source of randomness that swap preimages are generated
+	// from. If nil, a cryptographically secure source is used. This
+	// should only be set in tests, because predictable preimages allow
+	// anyone to claim our swaps.
+	Rand io.Reader
 }
 
 // NewClient returns a new instance to initiate swaps with.
 func NewClient(dbDir string, cfg *ClientConfig) (*Client, func(), error) {
-	store, err := loopdb.NewBoltSwapStore(dbDir, cfg.Lnd.ChainParams)
+	var storeOpts []loopdb.StoreOption
+	if cfg.ForceStaleDB {
+		storeOpts = append(storeOpts, loopdb.WithForceStaleDB())
+	}
+
+	var (
+		store loopdb.SwapStore
+		err   error
+	)
+	if len(cfg.DBPassword) > 0 {
+		store, err = loopdb.NewEncryptedBoltSwapStore(
+			dbDir, cfg.Lnd.ChainParams, cfg.DBPassword,
+			storeOpts...,
+		)
+	} else {
+		store, err = loopdb.NewBoltSwapStore(
+			dbDir, cfg.Lnd.ChainParams, storeOpts...,
+		)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -116,33 +303,147 @@ func NewClient(dbDir string, cfg *ClientConfig) (*Client, func(), error) {
 		return nil, nil, err
 	}
 
-	swapServerClient, err := newSwapServerClient(cfg, lsatStore)
+	primaryClient, err := newSwapServerClient(
+		cfg, cfg.ServerAddress, lsatStore,
+	)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	grpcClients := []*grpcSwapServerClient{primaryClient}
+	stopServerClients := func() {
+		for _, grpcClient := range grpcClients {
+			grpcClient.stop()
+		}
+	}
+
+	clientClock := cfg.Clock
+	if clientClock == nil {
+		clientClock = clock.NewDefaultClock()
+	}
+
+	// If we have servers to fail over to, we connect to each of them with
+	// its own lsat store, and dispatch our calls through a client that
+	// switches between them.
+	var server swapServerClient = primaryClient
+	if len(cfg.FailoverServerAddresses) > 0 {
+		servers := []*failoverServer{{
+			address: cfg.ServerAddress,
+			client:  primaryClient,
+		}}
+
+		for _, address := range cfg.FailoverServerAddresses {
+			failoverStore, err := lsat.NewFileStore(
+				failoverLsatDir(dbDir, address),
+			)
+			if err != nil {
+				stopServerClients()
+				return nil, nil, err
+			}
+
+			grpcClient, err := newSwapServerClient(
+				cfg, address, failoverStore,
+			)
+			if err != nil {
+				stopServerClients()
+				return nil, nil, err
+			}
+
+			grpcClients = append(grpcClients, grpcClient)
+			servers = append(servers, &failoverServer{
+				address: address,
+				client:  grpcClient,
+			})
+		}
+
+		failoverTimeout := cfg.ServerFailoverTimeout
+		if failoverTimeout == 0 {
+			failoverTimeout = DefaultServerFailoverTimeout
+		}
+
+		server = newFailoverSwapServerClient(
+			servers, failoverTimeout, clientClock,
+		)
+	}
+
+	clientRand := cfg.Rand
+	if clientRand == nil {
+		clientRand = rand.Reader
+	}
+
+	serverCache := newCachedSwapServerClient(
+		server, cfg.TermsCacheTTL, clientClock,
+	)
+
 	config := &clientConfig{
-		LndServices: cfg.Lnd,
-		Server:      swapServerClient,
-		Store:       store,
-		LsatStore:   lsatStore,
-		CreateExpiryTimer: func(d time.Duration) <-chan time.Time {
-			return time.NewTimer(d).C
-		},
-		LoopOutMaxParts: cfg.LoopOutMaxParts,
+		LndServices:       cfg.Lnd,
+		Server:            serverCache,
+		Store:             store,
+		LsatStore:         lsatStore,
+		CreateExpiryTimer: clientClock.TickAfter,
+		LoopOutMaxParts:   cfg.LoopOutMaxParts,
+		MaxLockedValue:    cfg.MaxLockedValue,
+		ConfPolicy:        cfg.ConfPolicy,
+		Clock:             clientClock,
+		Rand:              clientRand,
+
+		MaxLoopInWalletFraction: cfg.MaxLoopInWalletFraction,
 	}
 
 	sweeper := &sweep.Sweeper{
-		Lnd: cfg.Lnd,
+		Lnd:        cfg.Lnd,
+		NoTxLabels: cfg.NoTxLabels,
+	}
+
+	var batcher *sweep.Batcher
+	if cfg.BatchSweeps {
+		batcher, err = sweep.NewBatcher(
+			sweeper, store, cfg.SweepFeeBumpBlocks,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var destXpub *hdkeychain.ExtendedKey
+	if cfg.DestXpub != "" {
+		destXpub, err = parseDestXpub(cfg.DestXpub, cfg.Lnd.ChainParams)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	minPreimageRevealDelta := cfg.MinPreimageRevealDelta
+	if minPreimageRevealDelta == 0 {
+		minPreimageRevealDelta = MinLoopOutPreimageRevealDelta
+	}
+
+	loopOutPaymentTimeout := cfg.LoopOutPaymentTimeout
+	if loopOutPaymentTimeout == 0 {
+		loopOutPaymentTimeout = DefaultPaymentTimeout
+	}
+
+	refundConfTarget := cfg.LoopInRefundConfTarget
+	if refundConfTarget == 0 {
+		refundConfTarget = TimeoutTxConfTarget
 	}
 
 	executor := newExecutor(&executorConfig{
-		lnd:               cfg.Lnd,
-		store:             store,
-		sweeper:           sweeper,
-		createExpiryTimer: config.CreateExpiryTimer,
-		loopOutMaxParts:   cfg.LoopOutMaxParts,
-		cancelSwap:        swapServerClient.CancelLoopOutSwap,
+		lnd:                    cfg.Lnd,
+		store:                  store,
+		sweeper:                sweeper,
+		batcher:                batcher,
+		createExpiryTimer:      config.CreateExpiryTimer,
+		clock:                  clientClock,
+		loopOutMaxParts:        cfg.LoopOutMaxParts,
+		loopOutPaymentTimeout:  loopOutPaymentTimeout,
+		cancelSwap:             server.CancelLoopOutSwap,
+		abandonLoopOut:         server.AbandonLoopOutSwap,
+		sweepFeeBumpBlocks:     cfg.SweepFeeBumpBlocks,
+		refundConfTarget:       refundConfTarget,
+		refundMaxFee:           cfg.LoopInRefundMaxFee,
+		minPreimageRevealDelta: minPreimageRevealDelta,
+		maxConcurrentSwaps:     cfg.MaxConcurrentSwaps,
 	})
 
 	client := &Client{
@@ -152,80 +453,179 @@ func NewClient(dbDir string, cfg *ClientConfig) (*Client, func(), error) {
 		sweeper:      sweeper,
 		executor:     executor,
 		resumeReady:  make(chan struct{}),
+		destXpub:     destXpub,
+		serverCache:  serverCache,
+
+		idempotencyKeys: make(map[string]chan struct{}),
 	}
 
 	cleanup := func() {
-		swapServerClient.stop()
+		stopServerClients()
 	}
 
 	return client, cleanup, nil
 }
 
-// FetchSwaps returns all loop in and out swaps currently in the database.
-func (s *Client) FetchSwaps() ([]*SwapInfo, error) {
-	loopOutSwaps, err := s.Store.FetchLoopOutSwaps()
+// LockedValue returns the total amount that is currently locked in pending
+// swaps of all types.
+func (s *Client) LockedValue(ctx context.Context) (btcutil.Amount, error) {
+	swaps, err := s.FetchSwaps(ctx)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+
+	var total btcutil.Amount
+	for _, swp := range swaps {
+		if swp.State.Type() == loopdb.StateTypePending {
+			total += swp.AmountRequested
+		}
 	}
 
-	loopInSwaps, err := s.Store.FetchLoopInSwaps()
+	return total, nil
+}
+
+// checkLockedValue returns an error if adding a swap for the amount provided
+// would take the value locked in pending swaps above our maximum.
+func (s *Client) checkLockedValue(ctx context.Context,
+	amount btcutil.Amount) error {
+
+	locked, err := s.LockedValue(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	swaps := make([]*SwapInfo, 0, len(loopInSwaps)+len(loopOutSwaps))
+	if locked+amount > s.MaxLockedValue {
+		return fmt.Errorf("%w: %v locked in pending swaps, %v "+
+			"requested, maximum: %v", ErrMaxLockedValue, locked,
+			amount, s.MaxLockedValue)
+	}
 
-	for _, swp := range loopOutSwaps {
-		htlc, err := swap.NewHtlc(
-			GetHtlcScriptVersion(swp.Contract.ProtocolVersion),
-			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
-			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcP2WSH,
-			s.lndServices.ChainParams,
-		)
-		if err != nil {
-			return nil, err
+	return nil
+}
+
+// PendingLoopInValue returns the total amount requested by the loop ins
+// provided that are still pending and whose htlcs are funded by our wallet.
+func PendingLoopInValue(loopIn []*loopdb.LoopIn) btcutil.Amount {
+	var total btcutil.Amount
+	for _, in := range loopIn {
+		if in.Contract.ExternalHtlc {
+			continue
 		}
 
-		swaps = append(swaps, &SwapInfo{
-			SwapType:         swap.TypeOut,
-			SwapContract:     swp.Contract.SwapContract,
-			SwapStateData:    swp.State(),
-			SwapHash:         swp.Hash,
-			LastUpdate:       swp.LastUpdateTime(),
-			HtlcAddressP2WSH: htlc.Address,
-		})
-	}
-
-	for _, swp := range loopInSwaps {
-		htlcNP2WSH, err := swap.NewHtlc(
-			GetHtlcScriptVersion(swp.Contract.ProtocolVersion),
-			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
-			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcNP2WSH,
-			s.lndServices.ChainParams,
-		)
-		if err != nil {
-			return nil, err
+		if in.State().State.Type() == loopdb.StateTypePending {
+			total += in.Contract.AmountRequested
 		}
+	}
 
-		htlcP2WSH, err := swap.NewHtlc(
-			GetHtlcScriptVersion(swp.Contract.ProtocolVersion),
-			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
-			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcP2WSH,
-			s.lndServices.ChainParams,
-		)
+	return total
+}
+
+// AvailableLoopInValue returns the amount that may still be committed to
+// loop in htlcs without exceeding the fraction of our on-chain funds
+// provided, given our confirmed wallet balance and the amount already
+// committed to pending loop ins.
+func AvailableLoopInValue(walletBalance, pending btcutil.Amount,
+	fraction float64) btcutil.Amount {
+
+	allowed := btcutil.Amount(fraction * float64(walletBalance+pending))
+	if allowed <= pending {
+		return 0
+	}
+
+	return allowed - pending
+}
+
+// checkLoopInWalletFraction returns an error if adding a loop in for the
+// amount provided would commit more than our maximum fraction of our on-chain
+// funds to pending loop ins.
+func (s *Client) checkLoopInWalletFraction(ctx context.Context,
+	amount btcutil.Amount) error {
+
+	loopIns, err := s.Store.FetchLoopInSwaps()
+	if err != nil {
+		return err
+	}
+
+	balance, err := s.lndServices.Client.ConfirmedWalletBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := PendingLoopInValue(loopIns)
+	available := AvailableLoopInValue(
+		balance, pending, s.MaxLoopInWalletFraction,
+	)
+
+	if amount > available {
+		return fmt.Errorf("%w: %v in pending loop ins, %v confirmed "+
+			"in wallet, %v requested, maximum fraction: %v",
+			ErrLoopInWalletFraction, pending, balance, amount,
+			s.MaxLoopInWalletFraction)
+	}
+
+	return nil
+}
+
+// PendingValue returns the total amount requested by the swaps provided that
+// are still pending. For loop outs this covers our in-flight off-chain
+// payments and the on-chain htlc, for loop ins it covers the on-chain htlc.
+func PendingValue(loopOut []*loopdb.LoopOut,
+	loopIn []*loopdb.LoopIn) btcutil.Amount {
+
+	var total btcutil.Amount
+	for _, out := range loopOut {
+		if out.State().State.Type() == loopdb.StateTypePending {
+			total += out.Contract.AmountRequested
+		}
+	}
+
+	for _, in := range loopIn {
+		if in.State().State.Type() == loopdb.StateTypePending {
+			total += in.Contract.AmountRequested
+		}
+	}
+
+	return total
+}
+
+// newSwapConfig creates the config for a swap, using the client's clock and
+// source of randomness.
+func (s *Client) newSwapConfig() *swapConfig {
+	cfg := newSwapConfig(s.lndServices, s.Store, s.Server)
+	cfg.clock = s.Clock
+	cfg.rand = s.Rand
+
+	return cfg
+}
+
+// FetchSwaps returns all swaps currently in the database. The lookup is
+// aborted if the context provided is cancelled.
+func (s *Client) FetchSwaps(ctx context.Context) ([]*SwapInfo, error) {
+	return s.FilterSwaps(ctx, nil, nil)
+}
+
+// FilterSwaps returns the swaps in the database that have one of the swap
+// types provided and match the filter. All swap types are returned if no
+// types are provided, and a nil filter matches all swaps. The filter is
+// applied while the database is scanned, so that swaps that do not match are
+// not loaded in full.
+func (s *Client) FilterSwaps(ctx context.Context, swapTypes []swap.Type,
+	filter *loopdb.SwapFilter) ([]*SwapInfo, error) {
+
+	swapCfg := s.newSwapConfig()
+
+	var swaps []*SwapInfo
+	for _, driver := range registeredSwapDrivers() {
+		if !loopdb.HasSwapType(swapTypes, driver.swapType()) {
+			continue
+		}
+
+		driverSwaps, err := driver.fetchSwaps(ctx, swapCfg, filter)
 		if err != nil {
 			return nil, err
 		}
 
-		swaps = append(swaps, &SwapInfo{
-			SwapType:          swap.TypeIn,
-			SwapContract:      swp.Contract.SwapContract,
-			SwapStateData:     swp.State(),
-			SwapHash:          swp.Hash,
-			LastUpdate:        swp.LastUpdateTime(),
-			HtlcAddressP2WSH:  htlcP2WSH.Address,
-			HtlcAddressNP2WSH: htlcNP2WSH.Address,
-		})
+		swaps = append(swaps, driverSwaps...)
 	}
 
 	return swaps, nil
@@ -253,12 +653,7 @@ func (s *Client) Run(ctx context.Context,
 
 	// Query store before starting event loop to prevent new swaps from
 	// being treated as swaps that need to be resumed.
-	pendingLoopOutSwaps, err := s.Store.FetchLoopOutSwaps()
-	if err != nil {
-		return err
-	}
-
-	pendingLoopInSwaps, err := s.Store.FetchLoopInSwaps()
+	pendingSwaps, err := s.pendingSwaps(mainCtx)
 	if err != nil {
 		return err
 	}
@@ -268,7 +663,9 @@ func (s *Client) Run(ctx context.Context,
 	go func() {
 		defer s.wg.Done()
 
-		s.resumeSwaps(mainCtx, pendingLoopOutSwaps, pendingLoopInSwaps)
+		for _, swap := range pendingSwaps {
+			s.executor.resumeSwap(mainCtx, swap)
+		}
 
 		// Signal that new requests can be accepted. Otherwise the new
 		// swap could already have been added to the store and read in
@@ -306,37 +703,126 @@ func (s *Client) Run(ctx context.Context,
 	return err
 }
 
-// resumeSwaps restarts all pending swaps from the provided list.
-func (s *Client) resumeSwaps(ctx context.Context,
-	loopOutSwaps []*loopdb.LoopOut, loopInSwaps []*loopdb.LoopIn) {
-
-	swapCfg := newSwapConfig(s.lndServices, s.Store, s.Server)
+// pendingSwaps restores the pending swaps of every registered swap type from
+// the store so that they can be resumed.
+func (s *Client) pendingSwaps(ctx context.Context) ([]genericSwap, error) {
+	swapCfg := s.newSwapConfig()
 
-	for _, pend := range loopOutSwaps {
-		if pend.State().State.Type() != loopdb.StateTypePending {
-			continue
-		}
-		swap, err := resumeLoopOutSwap(ctx, swapCfg, pend)
+	var swaps []genericSwap
+	for _, driver := range registeredSwapDrivers() {
+		pending, err := driver.resumePending(ctx, swapCfg)
 		if err != nil {
-			log.Errorf("resuming loop out swap: %v", err)
-			continue
+			return nil, err
 		}
 
-		s.executor.initiateSwap(ctx, swap)
+		swaps = append(swaps, pending...)
 	}
 
-	for _, pend := range loopInSwaps {
-		if pend.State().State.Type() != loopdb.StateTypePending {
-			continue
-		}
-		swap, err := resumeLoopInSwap(ctx, swapCfg, pend)
-		if err != nil {
-			log.Errorf("resuming loop in swap: %v", err)
+	// If we checkpointed our swaps when we last shut down, we resume each
+	// swap from the execution phase that it had reached.
+	checkpoints, err := s.Store.FetchCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, swap := range swaps {
+		checkpoint, ok := checkpoints[swap.swapHash()]
+		if !ok {
 			continue
 		}
 
-		s.executor.initiateSwap(ctx, swap)
+		log.Infof("Resuming swap %v from checkpointed phase %v",
+			swap.swapHash(), checkpoint.Phase)
+
+		swap.setPhase(checkpoint.Phase)
+	}
+
+	return swaps, nil
+}
+
+// CheckpointSwaps records the execution phase that each of our in-flight
+// swaps has reached, so that they can be resumed from that phase after a
+// restart. It should be called before we shut down, while our swaps are still
+// executing. If our pending swaps have not all been resumed yet, the
+// checkpoints that we took when we last shut down are kept instead.
+func (s *Client) CheckpointSwaps() error {
+	select {
+	case <-s.resumeReady:
+	default:
+		return errors.New("pending swaps not yet resumed")
+	}
+
+	return s.executor.checkpoint()
+}
+
+// AbandonSwap stops execution of a pending swap and marks it as abandoned. This
+// is intended for swaps that are permanently stuck. Once abandoned, loop will
+// no longer attempt to sweep the swap's on-chain htlc, so any funds locked in
+// it need to be recovered manually.
+func (s *Client) AbandonSwap(ctx context.Context, hash lntypes.Hash) error {
+	// Wait for all pending swaps to be resumed, so that we do not mark a
+	// swap as abandoned while it is in the process of being resumed.
+	select {
+	case <-s.resumeReady:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	info, err := s.fetchSwapInfo(ctx, hash)
+	if err != nil {
+		return err
 	}
+
+	if info.State.Type() != loopdb.StateTypePending {
+		return fmt.Errorf("swap %v is not pending, state: %v", hash,
+			info.State)
+	}
+
+	log.Warnf("Abandoning swap %v in state %v", hash, info.State)
+
+	// Stop execution of the swap before we update its state, so that it
+	// cannot overwrite our update.
+	if err := s.executor.stopSwap(ctx, hash); err != nil {
+		return err
+	}
+
+	info.State = loopdb.StateFailAbandoned
+	info.LastUpdate = s.Clock.Now()
+
+	driver, err := swapDriverFor(info.SwapType)
+	if err != nil {
+		return err
+	}
+
+	if err := driver.abandon(ctx, s.executor, info); err != nil {
+		return err
+	}
+
+	select {
+	case s.executor.statusChan <- *info:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// fetchSwapInfo looks up a single swap in our store.
+func (s *Client) fetchSwapInfo(ctx context.Context,
+	hash lntypes.Hash) (*SwapInfo, error) {
+
+	swaps, err := s.FetchSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, swp := range swaps {
+		if swp.SwapHash == hash {
+			return swp, nil
+		}
+	}
+
+	return nil, fmt.Errorf("swap %v not found", hash)
 }
 
 // LoopOut initiates a loop out swap. It blocks until the swap is initiation
@@ -355,10 +841,88 @@ func (s *Client) LoopOut(globalCtx context.Context,
 		request.Amount, request.DestAddr, request.OutgoingChanSet,
 	)
 
+	// We fill in the values that the request leaves to us on a copy, so
+	// that the caller's request is not modified.
+	requestCopy := *request
+	request = &requestCopy
+
+	// Check our destination address before we contact the server, so that
+	// we do not pay for a swap that we cannot sweep.
+	if err := s.validateOutDest(request); err != nil {
+		return nil, err
+	}
+
 	if err := s.waitForInitialized(globalCtx); err != nil {
 		return nil, err
 	}
 
+	// If the request has an idempotency key, we check whether we already
+	// created a swap for it and return that swap if so.
+	if request.IdempotencyKey != "" {
+		existing, release, err := s.reserveIdempotencyKey(
+			globalCtx, request.IdempotencyKey, swap.TypeOut,
+			request.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil {
+			log.Infof("Returning existing loop out %v for "+
+				"idempotency key %v", existing.SwapHash,
+				request.IdempotencyKey)
+
+			return &LoopOutSwapInfo{
+				SwapHash:         existing.SwapHash,
+				HtlcAddressP2WSH: existing.HtlcAddressP2WSH,
+			}, nil
+		}
+		defer release()
+	}
+
+	// Check that this swap does not take us over our maximum locked
+	// value, holding our lock until the swap has been stored.
+	if s.MaxLockedValue != 0 {
+		s.lockedValueLock.Lock()
+		defer s.lockedValueLock.Unlock()
+
+		err := s.checkLockedValue(globalCtx, request.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If we sweep to our xpub, we derive our address now. We hold our
+	// lock until the swap has been stored and its index marked as used,
+	// so that concurrent swaps do not derive the same address.
+	if request.DestFromXpub {
+		s.xpubLock.Lock()
+		defer s.xpubLock.Unlock()
+
+		destAddr, index, err := s.nextXpubAddress()
+		if err != nil {
+			return nil, err
+		}
+
+		request.DestAddr = destAddr
+		request.destXpubIndex = index
+	}
+
+	// If the request does not set the number of htlc confirmations that
+	// we require or its sweep confirmation target, we use the values that
+	// our policy sets for its amount.
+	if request.HtlcConfirmations == 0 {
+		request.HtlcConfirmations = s.ConfPolicy.HtlcConfirmations(
+			request.Amount,
+		)
+	}
+
+	if request.SweepConfTarget == 0 {
+		request.SweepConfTarget = s.ConfPolicy.SweepConfTarget(
+			request.Amount, DefaultSweepConfTarget,
+		)
+	}
+
 	// Calculate htlc expiry height.
 	terms, err := s.Server.GetLoopOutTerms(globalCtx)
 	if err != nil {
@@ -373,8 +937,18 @@ func (s *Client) LoopOut(globalCtx context.Context,
 		return nil, err
 	}
 
+	// Make sure that we will actually be able to reveal our preimage
+	// before the htlc expires, otherwise the swap is bound to fail.
+	err = checkPreimageRevealDelta(
+		initiationHeight, request.Expiry,
+		s.executor.minPreimageRevealDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new swap object for this swap.
-	swapCfg := newSwapConfig(s.lndServices, s.Store, s.Server)
+	swapCfg := s.newSwapConfig()
 	initResult, err := newLoopOutSwap(
 		globalCtx, swapCfg, initiationHeight, request,
 	)
@@ -395,6 +969,137 @@ func (s *Client) LoopOut(globalCtx context.Context,
 	}, nil
 }
 
+// reserveIdempotencyKey returns the swap that was created with the idempotency
+// key provided, if there is one. Otherwise, the key is reserved for a new swap
+// until the release function returned is called. If the key is already
+// reserved by a concurrent request, we wait for that request to finish so
+// that we return the swap that it created.
+func (s *Client) reserveIdempotencyKey(ctx context.Context, key string,
+	swapType swap.Type, amount btcutil.Amount) (*SwapInfo, func(), error) {
+
+	for {
+		s.idempotencyLock.Lock()
+		done, ok := s.idempotencyKeys[key]
+		if !ok {
+			break
+		}
+		s.idempotencyLock.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	done := make(chan struct{})
+	s.idempotencyKeys[key] = done
+	s.idempotencyLock.Unlock()
+
+	release := func() {
+		s.idempotencyLock.Lock()
+		delete(s.idempotencyKeys, key)
+		s.idempotencyLock.Unlock()
+
+		close(done)
+	}
+
+	existing, err := s.fetchIdempotentSwap(ctx, key, swapType, amount)
+	if err != nil || existing != nil {
+		release()
+		return existing, nil, err
+	}
+
+	return nil, release, nil
+}
+
+// fetchIdempotentSwap returns the swap that was created with the idempotency
+// key provided, or nil if there is no such swap. An error is returned if the
+// key was used for a swap with a different type or amount, because this
+// indicates that the key was reused for a different request. Keys are looked
+// up regardless of the swap type requested, so that a reused key is rejected
+// before we contact the server.
+func (s *Client) fetchIdempotentSwap(ctx context.Context, key string,
+	swapType swap.Type, amount btcutil.Amount) (*SwapInfo, error) {
+
+	// Our store indexes idempotency keys, so we do not need to scan all
+	// of our swaps to find the swap that was created with the key.
+	hash, existingType, err := s.Store.LookupIdempotencyKey(key)
+	switch {
+	case err == loopdb.ErrIdempotencyKeyNotFound:
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	if existingType != swapType {
+		return nil, fmt.Errorf("idempotency key %v already used for "+
+			"%v swap %v", key, existingType, hash)
+	}
+
+	swaps, err := s.FilterSwaps(ctx, []swap.Type{existingType},
+		&loopdb.SwapFilter{
+			IdempotencyKey: key,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, swp := range swaps {
+		if swp.SwapHash != hash {
+			continue
+		}
+
+		if swp.AmountRequested != amount {
+			return nil, fmt.Errorf("idempotency key %v already "+
+				"used for %v swap %v of %v", key, swp.SwapType,
+				swp.SwapHash, swp.AmountRequested)
+		}
+
+		return swp, nil
+	}
+
+	return nil, fmt.Errorf("swap %v for idempotency key %v not found",
+		hash, key)
+}
+
+// validateOutDest checks the destination of a loop out request. Requests
+// either sweep to the address that they provide, or to an address derived
+// from our destination xpub.
+func (s *Client) validateOutDest(request *OutRequest) error {
+	if !request.DestFromXpub {
+		return ValidateDestAddr(
+			request.DestAddr, s.lndServices.ChainParams,
+		)
+	}
+
+	if request.DestAddr != nil {
+		return errors.New("dest addr and dest from xpub are mutually " +
+			"exclusive")
+	}
+
+	if s.destXpub == nil {
+		return ErrNoDestXpub
+	}
+
+	return nil
+}
+
+// checkPreimageRevealDelta checks that a loop out htlc that is created at the
+// height provided expires more than revealDelta blocks later, so that we have
+// time to reveal our preimage before the htlc expires.
+func checkPreimageRevealDelta(height, expiry, revealDelta int32) error {
+	if expiry-height <= revealDelta {
+		return fmt.Errorf("swap expiry of %v blocks does not exceed "+
+			"minimum preimage reveal delta of %v blocks",
+			expiry-height, revealDelta)
+	}
+
+	return nil
+}
+
 // getExpiry returns an absolute expiry height based on the sweep confirmation
 // target, constrained by the server terms.
 func (s *Client) getExpiry(height int32, terms *LoopOutTerms,
@@ -415,10 +1120,32 @@ func (s *Client) getExpiry(height int32, terms *LoopOutTerms,
 
 // LoopOutQuote takes a LoopOut amount and returns a break down of estimated
 // costs for the client. Both the swap server and the on-chain fee estimator
-// are queried to get to build the quote response.
+// are queried to get to build the quote response. The quote is added to our
+// quote history.
 func (s *Client) LoopOutQuote(ctx context.Context,
 	request *LoopOutQuoteRequest) (*LoopOutQuote, error) {
 
+	quote, err := s.loopOutQuote(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordQuote(&loopdb.Quote{
+		Type:         loopdb.QuoteTypeLoopOut,
+		Amount:       request.Amount,
+		SwapFee:      quote.SwapFee,
+		PrepayAmount: quote.PrepayAmount,
+		MinerFee:     quote.MinerFee,
+	})
+
+	return quote, nil
+}
+
+// loopOutQuote queries the swap server and our fee estimator for a loop out
+// quote.
+func (s *Client) loopOutQuote(ctx context.Context,
+	request *LoopOutQuoteRequest) (*LoopOutQuote, error) {
+
 	terms, err := s.Server.GetLoopOutTerms(ctx)
 	if err != nil {
 		return nil, err
@@ -440,6 +1167,7 @@ func (s *Client) LoopOutQuote(ctx context.Context,
 
 	quote, err := s.Server.GetLoopOutQuote(
 		ctx, request.Amount, expiry, request.SwapPublicationDeadline,
+		request.ServerHtlcConfTarget,
 	)
 	if err != nil {
 		return nil, err
@@ -469,10 +1197,11 @@ func (s *Client) LoopOutQuote(ctx context.Context,
 	}
 
 	return &LoopOutQuote{
-		SwapFee:         swapFee,
-		MinerFee:        minerFee,
-		PrepayAmount:    quote.PrepayAmount,
-		SwapPaymentDest: quote.SwapPaymentDest,
+		SwapFee:              swapFee,
+		MinerFee:             minerFee,
+		PrepayAmount:         quote.PrepayAmount,
+		SwapPaymentDest:      quote.SwapPaymentDest,
+		ServerHtlcConfTarget: quote.ServerHtlcConfTarget,
 	}, nil
 }
 
@@ -513,9 +1242,59 @@ func (s *Client) LoopIn(globalCtx context.Context,
 		return nil, err
 	}
 
+	// If the request has an idempotency key, we check whether we already
+	// created a swap for it and return that swap if so.
+	if request.IdempotencyKey != "" {
+		existing, release, err := s.reserveIdempotencyKey(
+			globalCtx, request.IdempotencyKey, swap.TypeIn,
+			request.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil {
+			log.Infof("Returning existing loop in %v for "+
+				"idempotency key %v", existing.SwapHash,
+				request.IdempotencyKey)
+
+			return &LoopInSwapInfo{
+				SwapHash:          existing.SwapHash,
+				HtlcAddressP2WSH:  existing.HtlcAddressP2WSH,
+				HtlcAddressNP2WSH: existing.HtlcAddressNP2WSH,
+			}, nil
+		}
+		defer release()
+	}
+
+	// Check that this swap does not take us over our maximum locked
+	// value or commit too much of our on-chain funds, holding our lock
+	// until the swap has been stored.
+	checkWalletFraction := s.MaxLoopInWalletFraction != 0 &&
+		!request.ExternalHtlc
+
+	if s.MaxLockedValue != 0 || checkWalletFraction {
+		s.lockedValueLock.Lock()
+		defer s.lockedValueLock.Unlock()
+	}
+
+	if s.MaxLockedValue != 0 {
+		err := s.checkLockedValue(globalCtx, request.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if checkWalletFraction {
+		err := s.checkLoopInWalletFraction(globalCtx, request.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create a new swap object for this swap.
 	initiationHeight := s.executor.height()
-	swapCfg := newSwapConfig(s.lndServices, s.Store, s.Server)
+	swapCfg := s.newSwapConfig()
 	initResult, err := newLoopInSwap(
 		globalCtx, swapCfg, initiationHeight, request,
 	)
@@ -540,10 +1319,37 @@ func (s *Client) LoopIn(globalCtx context.Context,
 
 // LoopInQuote takes an amount and returns a break down of estimated
 // costs for the client. Both the swap server and the on-chain fee estimator are
-// queried to get to build the quote response.
+// queried to get to build the quote response. The quote is added to our quote
+// history.
 func (s *Client) LoopInQuote(ctx context.Context,
 	request *LoopInQuoteRequest) (*LoopInQuote, error) {
 
+	quote, err := s.loopInQuote(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// We don't record our failure sentinel value as a miner fee estimate.
+	minerFee := quote.MinerFee
+	if minerFee == MinerFeeEstimationFailed {
+		minerFee = 0
+	}
+
+	s.recordQuote(&loopdb.Quote{
+		Type:     loopdb.QuoteTypeLoopIn,
+		Amount:   request.Amount,
+		SwapFee:  quote.SwapFee,
+		MinerFee: minerFee,
+	})
+
+	return quote, nil
+}
+
+// loopInQuote queries the swap server and our fee estimator for a loop in
+// quote.
+func (s *Client) loopInQuote(ctx context.Context,
+	request *LoopInQuoteRequest) (*LoopInQuote, error) {
+
 	// Retrieve current server terms to calculate swap fee.
 	terms, err := s.Server.GetLoopInTerms(ctx)
 	if err != nil {
@@ -566,12 +1372,26 @@ func (s *Client) LoopInQuote(ctx context.Context,
 
 	swapFee := quote.SwapFee
 
-	// We don't calculate the on-chain fee if the HTLC is going to be
-	// published externally.
+	// If the HTLC is going to be published externally, we only estimate
+	// the on-chain fee if a confirmation target was requested, because we
+	// cannot know how the htlc will be published otherwise.
 	if request.ExternalHtlc {
+		if request.HtlcConfTarget == 0 {
+			return &LoopInQuote{
+				SwapFee:  swapFee,
+				MinerFee: 0,
+			}, nil
+		}
+
+		minerFee, err := s.externalHtlcFee(ctx, request.HtlcConfTarget)
+		if err != nil {
+			return nil, err
+		}
+
 		return &LoopInQuote{
-			SwapFee:  swapFee,
-			MinerFee: 0,
+			SwapFee:   swapFee,
+			MinerFee:  minerFee,
+			CltvDelta: quote.CltvDelta,
 		}, nil
 	}
 
@@ -604,9 +1424,131 @@ func (s *Client) LoopInQuote(ctx context.Context,
 	}, nil
 }
 
+// externalHtlcFee estimates the on-chain fee for publishing a loop in htlc
+// from an external wallet with the confirmation target provided. We do not
+// know the external wallet's inputs, so we estimate the fee for a typical
+// transaction that spends a single p2wkh input to the htlc and a change
+// output.
+func (s *Client) externalHtlcFee(ctx context.Context,
+	confTarget int32) (btcutil.Amount, error) {
+
+	feeRate, err := s.lndServices.WalletKit.EstimateFee(ctx, confTarget)
+	if err != nil {
+		return 0, fmt.Errorf("estimate fee: %v", err)
+	}
+
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WKHOutput()
+
+	return feeRate.FeeForWeight(int64(weightEstimate.Weight())), nil
+}
+
+// recordQuote adds a quote to our quote history. Failure to record a quote is
+// not critical, so we only log it.
+func (s *Client) recordQuote(quote *loopdb.Quote) {
+	quote.Time = s.Clock.Now()
+
+	if err := s.Store.AddQuote(quote); err != nil {
+		log.Warnf("Could not record %v quote: %v", quote.Type, err)
+	}
+}
+
+// FetchQuoteHistory returns the quotes that we have received from the server.
+func (s *Client) FetchQuoteHistory(ctx context.Context) ([]*loopdb.Quote,
+	error) {
+
+	return s.Store.FetchQuotes(ctx)
+}
+
+// FetchLiquidityHistory returns the channel balance snapshots that were taken
+// at or after the time provided.
+func (s *Client) FetchLiquidityHistory(ctx context.Context,
+	since time.Time) ([]*loopdb.ChannelSnapshot, error) {
+
+	return s.Store.FetchLiquiditySnapshots(ctx, since)
+}
+
+// FetchAuditLog returns every swap state transition that was recorded in our
+// audit log.
+func (s *Client) FetchAuditLog(ctx context.Context) ([]*loopdb.AuditEntry,
+	error) {
+
+	return s.Store.FetchAuditLog(ctx)
+}
+
+// SaveSwapTemplate stores a swap template, replacing any existing template
+// with the same name.
+func (s *Client) SaveSwapTemplate(template *loopdb.SwapTemplate) error {
+	return s.Store.PutSwapTemplate(template)
+}
+
+// FetchSwapTemplates returns all of our swap templates, ordered by name.
+func (s *Client) FetchSwapTemplates() ([]*loopdb.SwapTemplate, error) {
+	return s.Store.FetchSwapTemplates()
+}
+
+// DeleteSwapTemplate deletes the swap template with the name provided.
+func (s *Client) DeleteSwapTemplate(name string) error {
+	return s.Store.DeleteSwapTemplate(name)
+}
+
+// PruneSwaps deletes all swaps that reached a final state before the time
+// provided from our store, and returns the hashes of the swaps deleted.
+func (s *Client) PruneSwaps(ctx context.Context, before time.Time) (
+	[]lntypes.Hash, error) {
+
+	return s.Store.PruneSwaps(ctx, before)
+}
+
+// ExportSwaps serializes all the swaps in our store to a portable, versioned
+// JSON format.
+func (s *Client) ExportSwaps(ctx context.Context) ([]byte, error) {
+	return loopdb.ExportSwaps(ctx, s.Store, s.lndServices.ChainParams)
+}
+
+// ImportSwaps adds the swaps in an export created by ExportSwaps to our store.
+// Pending swaps that are imported are only executed once the client has been
+// restarted.
+func (s *Client) ImportSwaps(ctx context.Context, data []byte) (
+	*loopdb.ImportResult, error) {
+
+	return loopdb.ImportSwaps(ctx, s.Store, s.lndServices.ChainParams, data)
+}
+
 // LoopInTerms returns the terms on which the server executes swaps.
 func (s *Client) LoopInTerms(ctx context.Context) (
 	*LoopInTerms, error) {
 
 	return s.Server.GetLoopInTerms(ctx)
 }
+
+// ServerHealth returns the health of our connection to the swap server,
+// based on the outcomes of our most recent calls to it.
+func (s *Client) ServerHealth() ServerHealth {
+	if s.serverCache == nil {
+		return ServerHealth{
+			ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		}
+	}
+
+	return s.serverCache.serverHealth()
+}
+
+// ServerFeatures returns the protocol version and optional features that we
+// negotiated with the swap server.
+func (s *Client) ServerFeatures(ctx context.Context) (*ServerFeatures, error) {
+	return s.Server.Features(ctx)
+}
+
+// CheckServerHealth returns an error if the swap server was unreachable on
+// our last call to it, calling the server again first to check whether it
+// has become reachable since.
+func (s *Client) CheckServerHealth(ctx context.Context) error {
+	if s.serverCache == nil {
+		return nil
+	}
+
+	return s.serverCache.checkHealth(ctx)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -20,8 +21,8 @@ import (
 )
 
 var (
-	testAddr, _ = btcutil.NewAddressScriptHash(
-		[]byte{123}, &chaincfg.TestNet3Params,
+	testAddr, _ = btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.TestNet3Params,
 	)
 
 	testRequest = &OutRequest{
@@ -108,7 +109,398 @@ func TestFailOffchain(t *testing.T) {
 	ctx.finish()
 }
 
+// TestAbandonSwap tests that a pending swap can be abandoned, which stops its
+// execution and marks it as failed. Since the swap is abandoned before we
+// reveal our preimage, we expect the server to be asked to release our
+// prepay, and the release to be recorded if it does.
+func TestAbandonSwap(t *testing.T) {
+	t.Run("prepay released", func(t *testing.T) {
+		testAbandonSwap(t, true)
+	})
+
+	t.Run("prepay not released", func(t *testing.T) {
+		testAbandonSwap(t, false)
+	})
+}
+
+func testAbandonSwap(t *testing.T, prepayReleased bool) {
+	defer test.Guard(t)()
+
+	ctx := createClientTestContext(t, nil)
+	ctx.serverMock.prepayReleased = prepayReleased
+
+	info, err := ctx.swapClient.LoopOut(context.Background(), testRequest)
+	require.NoError(t, err)
+
+	ctx.assertStored()
+	ctx.assertStatus(loopdb.StateInitiated)
+
+	ctx.AssertPaid(swapInvoiceDesc)
+	ctx.AssertPaid(prepayInvoiceDesc)
+	ctx.AssertRegisterConf(false, defaultConfirmations)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- ctx.swapClient.AbandonSwap(
+			context.Background(), info.SwapHash,
+		)
+	}()
+
+	require.Equal(t, info.SwapHash, <-ctx.serverMock.abandonSwap)
+
+	ctx.assertStoreFinished(loopdb.StateFailAbandoned)
+	ctx.assertStatus(loopdb.StateFailAbandoned)
+	require.NoError(t, <-errChan)
+
+	expectedReason := loopdb.FailureReasonNone
+	if prepayReleased {
+		expectedReason = loopdb.FailureReasonPrepayReleased
+	}
+
+	updates := ctx.store.loopOutUpdates[info.SwapHash]
+	require.Equal(
+		t, expectedReason, updates[len(updates)-1].FailureReason,
+	)
+
+	// Abandoning a swap that is no longer pending should fail.
+	err = ctx.swapClient.AbandonSwap(context.Background(), info.SwapHash)
+	require.Error(t, err)
+
+	ctx.finish()
+}
+
 // TestWrongAmount asserts that the client checks the server invoice amounts.
+// TestMaxLockedValue tests that swaps which exceed our maximum locked value
+// are rejected.
+func TestMaxLockedValue(t *testing.T) {
+	defer test.Guard(t)()
+
+	ctx := createClientTestContext(t, nil)
+	ctx.swapClient.MaxLockedValue = testRequest.Amount - 1
+
+	_, err := ctx.swapClient.LoopOut(context.Background(), testRequest)
+	require.True(t, errors.Is(err, ErrMaxLockedValue))
+
+	ctx.finish()
+}
+
+// TestCheckPreimageRevealDelta tests that we only accept loop out htlcs that
+// expire more than our minimum preimage reveal delta after they are created.
+func TestCheckPreimageRevealDelta(t *testing.T) {
+	const (
+		height      = 600
+		revealDelta = 20
+	)
+
+	tests := []struct {
+		name   string
+		expiry int32
+		err    bool
+	}{
+		{
+			name:   "just inside delta",
+			expiry: height + revealDelta + 1,
+		},
+		{
+			name:   "at delta",
+			expiry: height + revealDelta,
+			err:    true,
+		},
+		{
+			name:   "just outside delta",
+			expiry: height + revealDelta - 1,
+			err:    true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkPreimageRevealDelta(
+				height, testCase.expiry, revealDelta,
+			)
+			if testCase.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestPreimageRevealDelta tests that a loop out is rejected if the server's
+// minimum htlc expiry does not exceed our configured preimage reveal delta.
+func TestPreimageRevealDelta(t *testing.T) {
+	defer test.Guard(t)()
+
+	// Our loop out request's confirmation target is below the server's
+	// minimum cltv delta, so our htlc expires at exactly that delta. If
+	// our reveal delta is the same, we would have no blocks left to
+	// reveal our preimage, so the swap is rejected.
+	ctx := createClientTestContext(t, nil)
+	ctx.swapClient.executor.minPreimageRevealDelta =
+		testLoopOutMinOnChainCltvDelta
+
+	_, err := ctx.swapClient.LoopOut(context.Background(), testRequest)
+	require.Error(t, err)
+
+	ctx.finish()
+}
+
+// TestPendingValue tests calculation of the value locked in pending swaps.
+func TestPendingValue(t *testing.T) {
+	pendingOut := &loopdb.LoopOut{
+		Contract: &loopdb.LoopOutContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 1000,
+			},
+		},
+	}
+
+	completedOut := &loopdb.LoopOut{
+		Loop: loopdb.Loop{
+			Events: []*loopdb.LoopEvent{
+				{
+					SwapStateData: loopdb.SwapStateData{
+						State: loopdb.StateSuccess,
+					},
+				},
+			},
+		},
+		Contract: &loopdb.LoopOutContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 2000,
+			},
+		},
+	}
+
+	pendingIn := &loopdb.LoopIn{
+		Contract: &loopdb.LoopInContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 500,
+			},
+		},
+	}
+
+	value := PendingValue(
+		[]*loopdb.LoopOut{pendingOut, completedOut},
+		[]*loopdb.LoopIn{pendingIn},
+	)
+	require.Equal(t, btcutil.Amount(1500), value)
+}
+
+// TestAvailableLoopInValue tests calculation of the amount that may still be
+// committed to loop ins.
+func TestAvailableLoopInValue(t *testing.T) {
+	pendingIn := &loopdb.LoopIn{
+		Contract: &loopdb.LoopInContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 500,
+			},
+		},
+	}
+
+	externalIn := &loopdb.LoopIn{
+		Contract: &loopdb.LoopInContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 700,
+			},
+			ExternalHtlc: true,
+		},
+	}
+
+	pending := PendingLoopInValue([]*loopdb.LoopIn{pendingIn, externalIn})
+	require.Equal(t, btcutil.Amount(500), pending)
+
+	// Our on-chain funds are our wallet balance plus our pending loop in,
+	// so half of them is 1000, of which our pending loop in uses 500.
+	require.Equal(
+		t, btcutil.Amount(500), AvailableLoopInValue(1500, pending, 0.5),
+	)
+
+	// If our pending loop in already uses more than our fraction, none is
+	// available.
+	require.Equal(
+		t, btcutil.Amount(0), AvailableLoopInValue(500, pending, 0.2),
+	)
+}
+
+// TestIdempotentSwaps tests that retrying a loop out or loop in with the same
+// idempotency key returns the swap that was created for the key, and that
+// reusing a key for a different request fails.
+func TestIdempotentSwaps(t *testing.T) {
+	defer test.Guard(t)()
+
+	_, senderPubKey := test.CreateKey(1)
+	var senderKey [33]byte
+	copy(senderKey[:], senderPubKey.SerializeCompressed())
+
+	_, receiverPubKey := test.CreateKey(2)
+	var receiverKey [33]byte
+	copy(receiverKey[:], receiverPubKey.SerializeCompressed())
+
+	contract := func(preimage lntypes.Preimage,
+		key string) loopdb.SwapContract {
+
+		return loopdb.SwapContract{
+			Preimage:        preimage,
+			AmountRequested: testRequest.Amount,
+			CltvExpiry:      744,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			IdempotencyKey:  key,
+		}
+	}
+
+	// Start our client with a loop out that has already completed, so
+	// that it is not resumed.
+	outPreimage := lntypes.Preimage{1}
+	outHash := outPreimage.Hash()
+	loopOut := &loopdb.LoopOut{
+		Loop: loopdb.Loop{
+			Hash: outHash,
+			Events: []*loopdb.LoopEvent{
+				{
+					SwapStateData: loopdb.SwapStateData{
+						State: loopdb.StateSuccess,
+					},
+				},
+			},
+		},
+		Contract: &loopdb.LoopOutContract{
+			SwapContract: contract(outPreimage, "out-key"),
+			DestAddr:     testAddr,
+		},
+	}
+
+	ctx := createClientTestContext(t, []*loopdb.LoopOut{loopOut})
+
+	// Once our client has resumed its swaps, we add a loop in that was
+	// created with an idempotency key.
+	err := ctx.swapClient.waitForInitialized(context.Background())
+	require.NoError(t, err)
+
+	inPreimage := lntypes.Preimage{2}
+	inHash := inPreimage.Hash()
+	ctx.store.loopInSwaps[inHash] = &loopdb.LoopInContract{
+		SwapContract: contract(inPreimage, "in-key"),
+	}
+	ctx.store.loopInUpdates[inHash] = []loopdb.SwapStateData{}
+
+	// Retrying our requests with the same idempotency key returns the
+	// existing swaps without contacting the server.
+	outRequest := *testRequest
+	outRequest.IdempotencyKey = "out-key"
+
+	outInfo, err := ctx.swapClient.LoopOut(
+		context.Background(), &outRequest,
+	)
+	require.NoError(t, err)
+	require.Equal(t, outHash, outInfo.SwapHash)
+	require.NotNil(t, outInfo.HtlcAddressP2WSH)
+
+	inRequest := &LoopInRequest{
+		Amount:         testRequest.Amount,
+		IdempotencyKey: "in-key",
+	}
+
+	inInfo, err := ctx.swapClient.LoopIn(context.Background(), inRequest)
+	require.NoError(t, err)
+	require.Equal(t, inHash, inInfo.SwapHash)
+	require.NotNil(t, inInfo.HtlcAddressP2WSH)
+	require.NotNil(t, inInfo.HtlcAddressNP2WSH)
+
+	// Reusing a key for a request with a different amount or swap type
+	// fails before we contact the server.
+	outRequest.Amount++
+	_, err = ctx.swapClient.LoopOut(context.Background(), &outRequest)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "idempotency key out-key already used")
+
+	inRequest.IdempotencyKey = "out-key"
+	_, err = ctx.swapClient.LoopIn(context.Background(), inRequest)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "idempotency key out-key already used")
+
+	ctx.finish()
+}
+
+// TestReserveIdempotencyKey tests that a request waits for a concurrent
+// request with the same idempotency key to finish, and then returns the swap
+// that it created.
+func TestReserveIdempotencyKey(t *testing.T) {
+	defer test.Guard(t)()
+
+	ctx := createClientTestContext(t, nil)
+
+	err := ctx.swapClient.waitForInitialized(context.Background())
+	require.NoError(t, err)
+
+	// Reserve a key that has not been used yet.
+	existing, release, err := ctx.swapClient.reserveIdempotencyKey(
+		context.Background(), "key", swap.TypeIn, testRequest.Amount,
+	)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// A concurrent request with the same key waits for our reservation
+	// to be released.
+	type result struct {
+		existing *SwapInfo
+		err      error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		existing, _, err := ctx.swapClient.reserveIdempotencyKey(
+			context.Background(), "key", swap.TypeIn,
+			testRequest.Amount,
+		)
+		resultChan <- result{existing, err}
+	}()
+
+	select {
+	case <-resultChan:
+		t.Fatal("reservation not held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Create our swap and release the key. Our concurrent request should
+	// now return the swap that we created.
+	_, senderPubKey := test.CreateKey(1)
+	_, receiverPubKey := test.CreateKey(2)
+
+	contract := &loopdb.LoopInContract{
+		SwapContract: loopdb.SwapContract{
+			Preimage:        testPreimage,
+			AmountRequested: testRequest.Amount,
+			CltvExpiry:      744,
+			IdempotencyKey:  "key",
+		},
+	}
+	copy(contract.SenderKey[:], senderPubKey.SerializeCompressed())
+	copy(contract.ReceiverKey[:], receiverPubKey.SerializeCompressed())
+
+	hash := testPreimage.Hash()
+	ctx.store.loopInSwaps[hash] = contract
+	ctx.store.loopInUpdates[hash] = []loopdb.SwapStateData{}
+
+	release()
+
+	select {
+	case res := <-resultChan:
+		require.NoError(t, res.err)
+		require.NotNil(t, res.existing)
+		require.Equal(t, hash, res.existing.SwapHash)
+
+	case <-time.After(test.Timeout):
+		t.Fatal("reservation not released")
+	}
+
+	ctx.finish()
+}
+
 func TestFailWrongAmount(t *testing.T) {
 	defer test.Guard(t)()
 
@@ -250,6 +642,9 @@ func testResume(t *testing.T, confs uint32, expired, preimageRevealed,
 				MaxSwapFee:      60000,
 				MaxMinerFee:     50000,
 				ProtocolVersion: protocolVersion,
+				HtlcVersion: GetHtlcScriptVersion(
+					protocolVersion,
+				),
 			},
 		},
 		Loop: loopdb.Loop{
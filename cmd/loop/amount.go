@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil"
+)
+
+// amtUnit describes a unit that amounts may be expressed in on the command
+// line.
+type amtUnit struct {
+	// suffix is the suffix that identifies the unit.
+	suffix string
+
+	// decimals is the number of decimal places that the unit allows,
+	// which is the number of digits needed to express one satoshi.
+	decimals int
+}
+
+// amtUnits is the set of units that amounts may be expressed in. Amounts
+// without a suffix are expressed in satoshis. Longer suffixes are listed
+// first so that they are matched before their prefixes.
+var amtUnits = []amtUnit{
+	{suffix: "sats", decimals: 0},
+	{suffix: "sat", decimals: 0},
+	{suffix: "btc", decimals: 8},
+	{suffix: "m", decimals: 6},
+}
+
+// amtUsage describes the formats accepted by parseAmt, for use in flag
+// descriptions.
+const amtUsage = "expressed in satoshis, or with a btc suffix for " +
+	"bitcoin (eg. 0.01btc) or an m suffix for millions of satoshis " +
+	"(eg. 1.5m)"
+
+// parseAmt parses an amount that is expressed in satoshis, bitcoin or
+// millions of satoshis. Parsing is strict: the amount must be a non-negative
+// decimal number that is a whole number of satoshis, and may not exceed the
+// total bitcoin supply.
+func parseAmt(text string) (btcutil.Amount, error) {
+	number := strings.ToLower(text)
+	decimals := 0
+
+	for _, unit := range amtUnits {
+		if strings.HasSuffix(number, unit.suffix) {
+			number = strings.TrimSuffix(number, unit.suffix)
+			decimals = unit.decimals
+			break
+		}
+	}
+
+	parts := strings.Split(number, ".")
+	if len(parts) > 2 || !isDigits(parts[0]) {
+		return 0, fmt.Errorf("invalid amount: %v", text)
+	}
+
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+		if !isDigits(fraction) {
+			return 0, fmt.Errorf("invalid amount: %v", text)
+		}
+	}
+
+	if len(fraction) > decimals {
+		return 0, fmt.Errorf("invalid amount: %v, amounts must be "+
+			"a whole number of satoshis", text)
+	}
+
+	// Pad our fraction so that we can parse the amount as an integer
+	// number of satoshis. We limit our digits to those that are needed to
+	// express the total supply, so that we can't overflow.
+	sats := strings.TrimLeft(
+		parts[0]+fraction+strings.Repeat("0", decimals-len(fraction)),
+		"0",
+	)
+	if sats == "" {
+		return 0, nil
+	}
+
+	if len(sats) > len(strconv.FormatInt(btcutil.MaxSatoshi, 10)) {
+		return 0, fmt.Errorf("invalid amount: %v, exceeds maximum "+
+			"of %v", text, btcutil.Amount(btcutil.MaxSatoshi))
+	}
+
+	amt, err := strconv.ParseInt(sats, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %v", text)
+	}
+
+	if amt > btcutil.MaxSatoshi {
+		return 0, fmt.Errorf("invalid amount: %v, exceeds maximum "+
+			"of %v", text, btcutil.Amount(btcutil.MaxSatoshi))
+	}
+
+	return btcutil.Amount(amt), nil
+}
+
+// isDigits returns a boolean indicating whether a string is a non-empty
+// string of decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// satFields is the set of fields in our rpc responses that are expressed in
+// satoshis, but do not have a _sat suffix.
+var satFields = map[string]bool{
+	"amt":                    true,
+	"max_swap_routing_fee":   true,
+	"max_prepay_routing_fee": true,
+	"max_swap_fee":           true,
+	"max_prepay_amt":         true,
+	"max_miner_fee":          true,
+	"cost_server":            true,
+	"cost_onchain":           true,
+	"cost_offchain":          true,
+	"min_swap_amount":        true,
+	"max_swap_amount":        true,
+	"max_lsat_cost":          true,
+	"max_lsat_fee":           true,
+	"max_locked_value":       true,
+}
+
+// satLine matches a line of indented json output that contains a single
+// integer field.
+var satLine = regexp.MustCompile(`^(\s*)"([a-z0-9_]+)": "?(-?\d+)"?(,?)$`)
+
+// addBtcAmounts adds a field expressing each satoshi amount in a json
+// response in bitcoin, directly after the original field. We operate on the
+// indented output of our json marshaler, which places each integer field on
+// its own line, so that we preserve the field order of the response.
+func addBtcAmounts(jsonStr string) string {
+	lines := strings.Split(jsonStr, "\n")
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		match := satLine.FindStringSubmatch(line)
+		if match == nil {
+			out = append(out, line)
+			continue
+		}
+
+		indent, name, value, comma := match[1], match[2], match[3],
+			match[4]
+
+		if !satFields[name] && !strings.HasSuffix(name, "_sat") {
+			out = append(out, line)
+			continue
+		}
+
+		sats, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			out = append(out, line)
+			continue
+		}
+
+		// Our original field is no longer the last field in its
+		// object, so it needs a trailing comma.
+		out = append(out, strings.TrimSuffix(line, comma)+",")
+		out = append(out, fmt.Sprintf(`%v"%v_btc": "%v"%v`, indent,
+			strings.TrimSuffix(name, "_sat"), formatBtc(sats),
+			comma))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// formatBtc formats an amount in satoshis as bitcoin, always including all
+// eight decimal places.
+func formatBtc(sats int64) string {
+	sign := ""
+	if sats < 0 {
+		sign = "-"
+		sats = -sats
+	}
+
+	return fmt.Sprintf("%v%d.%08d", sign, sats/btcutil.SatoshiPerBitcoin,
+		sats%btcutil.SatoshiPerBitcoin)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseAmt tests parsing of amounts in each of our supported units.
+func TestParseAmt(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected btcutil.Amount
+		err      bool
+	}{
+		{text: "100000", expected: 100000},
+		{text: "100000sat", expected: 100000},
+		{text: "100000sats", expected: 100000},
+		{text: "0", expected: 0},
+		{text: "0.01btc", expected: 1000000},
+		{text: "0.01BTC", expected: 1000000},
+		{text: "1btc", expected: 100000000},
+		{text: "0.00000001btc", expected: 1},
+		{text: "1.5m", expected: 1500000},
+		{text: "2m", expected: 2000000},
+		{text: "0.000001m", expected: 1},
+		{text: "21000000btc", expected: btcutil.MaxSatoshi},
+		{text: "0.000000001btc", err: true},
+		{text: "0.0000001m", err: true},
+		{text: "1.5", err: true},
+		{text: "1.5sat", err: true},
+		{text: "-1", err: true},
+		{text: "+1", err: true},
+		{text: "1e6", err: true},
+		{text: ".5m", err: true},
+		{text: "1.m", err: true},
+		{text: "1.2.3btc", err: true},
+		{text: " 1", err: true},
+		{text: "btc", err: true},
+		{text: "", err: true},
+		{text: "1k", err: true},
+		{text: "21000001btc", err: true},
+		{text: "99999999999999999999999", err: true},
+	}
+
+	for _, test := range tests {
+		amt, err := parseAmt(test.text)
+		if test.err {
+			require.Error(t, err, test.text)
+			continue
+		}
+
+		require.NoError(t, err, test.text)
+		require.Equal(t, test.expected, amt, test.text)
+	}
+}
+
+// TestAddBtcAmounts tests adding bitcoin representations of satoshi amounts
+// to json output.
+func TestAddBtcAmounts(t *testing.T) {
+	in := `{
+    "amt": "150000000",
+    "htlc_confs": 1,
+    "swap_fee_sat": "5000",
+    "label": "amt"
+}`
+
+	expected := `{
+    "amt": "150000000",
+    "amt_btc": "1.50000000",
+    "htlc_confs": 1,
+    "swap_fee_sat": "5000",
+    "swap_fee_btc": "0.00005000",
+    "label": "amt"
+}`
+
+	require.Equal(t, expected, addBtcAmounts(in))
+
+	// If an amount is the last field in its object, it should not have a
+	// trailing comma and our new field should be the last one.
+	in = `{
+    "cost_server": "-100"
+}`
+
+	expected = `{
+    "cost_server": "-100",
+    "cost_server_btc": "-0.00000100"
+}`
+
+	require.Equal(t, expected, addBtcAmounts(in))
+}
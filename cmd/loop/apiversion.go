@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var apiVersionCommand = cli.Command{
+	Name:  "apiversion",
+	Usage: "show the version of the daemon's rpc api",
+	Description: "Shows the version of the rpc api that loopd serves, " +
+		"the rpcs that it supports and the deprecated elements of " +
+		"the api.",
+	Action: apiVersion,
+}
+
+func apiVersion(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetApiVersion(
+		context.Background(), &looprpc.GetApiVersionRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
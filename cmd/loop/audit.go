@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/urfave/cli"
+)
+
+var auditCommand = cli.Command{
+	Name:  "audit",
+	Usage: "show the audit log of swap state transitions",
+	Description: "Shows every swap state transition that loopd has " +
+		"recorded in its append-only audit log, along with the " +
+		"previous state of the swap and the component that " +
+		"triggered the transition. Entries are kept when swaps " +
+		"are pruned, so that failed swaps can be investigated " +
+		"without debug logs.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "only include transitions of the swap with this ID",
+		},
+		cli.DurationFlag{
+			Name: "since",
+			Usage: "only include transitions that happened within " +
+				"this amount of time (e.g. 24h). If not set, " +
+				"all transitions are included",
+		},
+	},
+	Action: audit,
+}
+
+func audit(ctx *cli.Context) error {
+	req := &looprpc.AuditLogRequest{}
+
+	if ctx.IsSet("id") {
+		id := ctx.String("id")
+		if len(id) != hex.EncodedLen(lntypes.HashSize) {
+			return fmt.Errorf("invalid swap ID")
+		}
+
+		idBytes, err := hex.DecodeString(id)
+		if err != nil {
+			return fmt.Errorf("cannot hex decode id: %v", err)
+		}
+
+		req.SwapHash = idBytes
+	}
+
+	if ctx.IsSet("since") {
+		req.StartTimeNs = time.Now().Add(
+			ctx.Duration("since") * -1,
+		).UnixNano()
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetAuditLog(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var costsCommand = cli.Command{
+	Name:  "costs",
+	Usage: "show the realized costs of completed swaps",
+	Description: "Shows the server, on-chain and off-chain fees that " +
+		"were paid for each swap that completed in a time range, " +
+		"along with the totals over all of them",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "only include swaps completed at or after this " +
+				"unix timestamp (in seconds)",
+		},
+		cli.Int64Flag{
+			Name: "end",
+			Usage: "only include swaps completed before this unix " +
+				"timestamp (in seconds)",
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "only include swaps of this type, either in " +
+				"or out",
+		},
+	},
+	Action: costs,
+}
+
+func costs(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	req := &looprpc.SwapCostsRequest{}
+
+	if ctx.IsSet("start") {
+		req.StartTimeNs = time.Unix(ctx.Int64("start"), 0).UnixNano()
+	}
+
+	if ctx.IsSet("end") {
+		req.EndTimeNs = time.Unix(ctx.Int64("end"), 0).UnixNano()
+	}
+
+	switch ctx.String("type") {
+	case "":
+
+	case "in":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_IN}
+
+	case "out":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_OUT}
+
+	default:
+		return fmt.Errorf("unknown swap type: %v, expected in or out",
+			ctx.String("type"))
+	}
+
+	resp, err := client.GetSwapCosts(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
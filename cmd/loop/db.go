@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var dbCommand = cli.Command{
+	Name:  "db",
+	Usage: "export and import loopd's swap database",
+	Description: "Exports loopd's swaps to a portable json file that can " +
+		"be imported by another loopd instance, for example when " +
+		"moving loopd to another machine or database backend.",
+	Subcommands: []cli.Command{
+		exportSwapsCommand,
+		importSwapsCommand,
+	},
+}
+
+var exportSwapsCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export all swaps to a json file",
+	ArgsUsage: "file",
+	Description: "Writes all of loopd's swaps, including their updates, " +
+		"to the file provided. The export contains the preimages " +
+		"and keys of the swaps, so it must be stored securely.",
+	Action: exportSwaps,
+}
+
+func exportSwaps(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "export")
+	}
+	path := ctx.Args().First()
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ExportSwaps(
+		context.Background(), &looprpc.ExportSwapsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, resp.Export, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Swaps exported to: %v\n", path)
+
+	return nil
+}
+
+var importSwapsCommand = cli.Command{
+	Name:      "import",
+	Usage:     "import swaps from a json file",
+	ArgsUsage: "file",
+	Description: "Adds the swaps in a file created by `loop db export` " +
+		"to loopd's database. The export is rejected if it has been " +
+		"modified or was created on another network. Swaps that " +
+		"already exist are skipped. Imported swaps that are still " +
+		"pending are resumed once loopd is restarted.",
+	Action: importSwaps,
+}
+
+func importSwaps(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "import")
+	}
+
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return errors.New("export file is empty")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ImportSwaps(
+		context.Background(), &looprpc.ImportSwapsRequest{
+			Export: data,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %v swaps, skipped %v existing swaps\n",
+		resp.Imported, resp.Skipped)
+
+	if resp.PendingImported > 0 {
+		fmt.Printf("Restart loopd to resume %v pending imported "+
+			"swaps\n", resp.PendingImported)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var debugLevelCommand = cli.Command{
+	Name:  "debuglevel",
+	Usage: "set or show the debug level of loopd",
+	Description: "Logging verbosity can be set daemon-wide, for example " +
+		"debug, or per sub-system, for example LQDY=trace,SWEEP=debug. " +
+		"Use --show to list the supported sub-systems.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "show",
+			Usage: "list the supported log sub-systems",
+		},
+		cli.StringFlag{
+			Name: "level",
+			Usage: "the level specification to apply to the " +
+				"daemon or its sub-systems",
+		},
+	},
+	Action: debugLevel,
+}
+
+func debugLevel(ctx *cli.Context) error {
+	if !ctx.Bool("show") && !ctx.IsSet("level") {
+		return cli.ShowCommandHelp(ctx, "debuglevel")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.DebugLevel(
+		context.Background(), &looprpc.DebugLevelRequest{
+			Show:      ctx.Bool("show"),
+			LevelSpec: ctx.String("level"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if resp.SubSystems != "" {
+		fmt.Printf("Supported subsystems: %v\n", resp.SubSystems)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var getConfigCommand = cli.Command{
+	Name:  "getconfig",
+	Usage: "show the daemon's effective configuration",
+	Description: "Shows the configuration that loopd is running with, " +
+		"after defaults and overrides have been applied. Secrets " +
+		"and paths to credentials are not included.",
+	Action: getConfig,
+}
+
+func getConfig(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetConfig(
+		context.Background(), &looprpc.GetConfigRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// defaultLang is the language that the cli's messages are written in. It has
+// no catalog, because messages are used as their own translation.
+const defaultLang = "en"
+
+var (
+	langFlag = cli.StringFlag{
+		Name:   "lang",
+		Usage:  "the language of human readable output",
+		Value:  defaultLang,
+		EnvVar: "LOOP_LANG",
+	}
+
+	// lang is the language that human readable output is translated to.
+	// Json output is never translated, so that scripts can rely on it.
+	lang = defaultLang
+
+	// catalogs holds the translations of our messages for every language
+	// other than our default, keyed by language and then by the message
+	// in our default language. Enum values are keyed by their rpc name.
+	// Messages that are missing from a catalog are printed untranslated.
+	catalogs = map[string]map[string]string{
+		"de": {
+			// Swap states.
+			"INITIATED":         "eingeleitet",
+			"PREIMAGE_REVEALED": "Preimage offengelegt",
+			"HTLC_PUBLISHED":    "HTLC veröffentlicht",
+			"SUCCESS":           "erfolgreich",
+			"FAILED":            "fehlgeschlagen",
+			"INVOICE_SETTLED":   "Rechnung beglichen",
+
+			// Swap types.
+			"LOOP_OUT": "Loop Out",
+			"LOOP_IN":  "Loop In",
+
+			// Failure reasons.
+			"FAILURE_REASON_NONE":               "keiner",
+			"FAILURE_REASON_OFFCHAIN":           "Off-chain-Zahlung fehlgeschlagen",
+			"FAILURE_REASON_TIMEOUT":            "Zeitüberschreitung",
+			"FAILURE_REASON_SWEEP_TIMEOUT":      "Zeitüberschreitung beim Sweep",
+			"FAILURE_REASON_INSUFFICIENT_VALUE": "unzureichender Betrag",
+			"FAILURE_REASON_TEMPORARY":          "vorübergehender Fehler",
+			"FAILURE_REASON_INCORRECT_AMOUNT":   "falscher HTLC-Betrag",
+			"FAILURE_REASON_ABANDONED":          "abgebrochen",
+
+			// Swap failure reasons.
+			"SWAP_FAILURE_REASON_NONE":               "keiner",
+			"SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT":   "Swap-Zahlung fehlgeschlagen",
+			"SWAP_FAILURE_REASON_HTLC_TIMEOUT":       "HTLC abgelaufen",
+			"SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED": "Sweep-Gebühr überschritten",
+			"SWAP_FAILURE_REASON_SERVER_REJECTED":    "vom Server abgelehnt",
+			"SWAP_FAILURE_REASON_PREPAY_FAILED":      "Vorauszahlung fehlgeschlagen",
+			"SWAP_FAILURE_REASON_PREPAY_RELEASED":    "Vorauszahlung freigegeben",
+
+			// Server failure reasons.
+			"SERVER_FAILURE_REASON_NONE":                "keiner",
+			"SERVER_FAILURE_REASON_UNKNOWN":             "unbekannt",
+			"SERVER_FAILURE_REASON_NO_HTLC":             "kein HTLC",
+			"SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT": "ungültiger HTLC-Betrag",
+			"SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT":    "Zeitüberschreitung der Off-chain-Zahlung",
+			"SERVER_FAILURE_REASON_TIMEOUT":             "Zeitüberschreitung",
+			"SERVER_FAILURE_REASON_SWAP_DEADLINE":       "Swap-Frist verstrichen",
+			"SERVER_FAILURE_REASON_HTLC_PUBLICATION":    "HTLC-Veröffentlichung fehlgeschlagen",
+
+			// Swap status.
+			"server: %v": "Server: %v",
+			"expiry in %v blocks, no return in %v blocks": "Ablauf in %v Blöcken, kein Zurück in %v Blöcken",
+			"cost: server %v, onchain %v, offchain %v":    "Kosten: Server %v, On-chain %v, Off-chain %v",
+			"replayed":                     "wiederholt",
+			"refunded in %v, fee %v":       "erstattet in %v, Gebühr %v",
+			"refund pending in %v, fee %v": "Erstattung ausstehend in %v, Gebühr %v",
+			"cancellable":                  "abbrechbar",
+
+			// Quotes.
+			"Send on-chain:":                    "On-chain senden:",
+			"Receive off-chain:":                "Off-chain empfangen:",
+			"Send off-chain:":                   "Off-chain senden:",
+			"Receive on-chain:":                 "On-chain empfangen:",
+			"Loop service fee:":                 "Loop-Servicegebühr:",
+			"Estimated on-chain fee:":           "Geschätzte On-chain-Gebühr:",
+			"Estimated total fee:":              "Geschätzte Gesamtgebühr:",
+			"Conf target:":                      "Bestätigungsziel:",
+			"Server conf target:":               "Bestätigungsziel des Servers:",
+			"CLTV expiry delta:":                "CLTV-Ablaufdelta:",
+			"No show penalty (prepay):":         "Strafe bei Nichterscheinen:",
+			"Publication deadline:":             "Veröffentlichungsfrist:",
+			"Max on-chain fee:":                 "Max. On-chain-Gebühr:",
+			"Max off-chain swap routing fee:":   "Max. Routinggebühr (Swap):",
+			"Max off-chain prepay routing fee:": "Max. Routinggebühr (Vorauszahlung):",
+			"CONTINUE SWAP? (y/n): ":            "SWAP FORTSETZEN? (y/n): ",
+			"CONTINUE SWAPS? (y/n): ":           "SWAPS FORTSETZEN? (y/n): ",
+		},
+		"es": {
+			// Swap states.
+			"INITIATED":         "iniciado",
+			"PREIMAGE_REVEALED": "preimagen revelada",
+			"HTLC_PUBLISHED":    "HTLC publicado",
+			"SUCCESS":           "completado",
+			"FAILED":            "fallido",
+			"INVOICE_SETTLED":   "factura liquidada",
+
+			// Swap types.
+			"LOOP_OUT": "Loop Out",
+			"LOOP_IN":  "Loop In",
+
+			// Failure reasons.
+			"FAILURE_REASON_NONE":               "ninguno",
+			"FAILURE_REASON_OFFCHAIN":           "pago off-chain fallido",
+			"FAILURE_REASON_TIMEOUT":            "tiempo agotado",
+			"FAILURE_REASON_SWEEP_TIMEOUT":      "tiempo agotado en el barrido",
+			"FAILURE_REASON_INSUFFICIENT_VALUE": "valor insuficiente",
+			"FAILURE_REASON_TEMPORARY":          "error temporal",
+			"FAILURE_REASON_INCORRECT_AMOUNT":   "importe de HTLC incorrecto",
+			"FAILURE_REASON_ABANDONED":          "abandonado",
+
+			// Swap failure reasons.
+			"SWAP_FAILURE_REASON_NONE":               "ninguno",
+			"SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT":   "pago del swap fallido",
+			"SWAP_FAILURE_REASON_HTLC_TIMEOUT":       "HTLC expirado",
+			"SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED": "comisión de barrido excedida",
+			"SWAP_FAILURE_REASON_SERVER_REJECTED":    "rechazado por el servidor",
+			"SWAP_FAILURE_REASON_PREPAY_FAILED":      "prepago fallido",
+			"SWAP_FAILURE_REASON_PREPAY_RELEASED":    "prepago liberado",
+
+			// Server failure reasons.
+			"SERVER_FAILURE_REASON_NONE":                "ninguno",
+			"SERVER_FAILURE_REASON_UNKNOWN":             "desconocido",
+			"SERVER_FAILURE_REASON_NO_HTLC":             "sin HTLC",
+			"SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT": "importe de HTLC no válido",
+			"SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT":    "tiempo agotado del pago off-chain",
+			"SERVER_FAILURE_REASON_TIMEOUT":             "tiempo agotado",
+			"SERVER_FAILURE_REASON_SWAP_DEADLINE":       "plazo del swap vencido",
+			"SERVER_FAILURE_REASON_HTLC_PUBLICATION":    "publicación del HTLC fallida",
+
+			// Swap status.
+			"server: %v": "servidor: %v",
+			"expiry in %v blocks, no return in %v blocks": "expiración en %v bloques, sin retorno en %v bloques",
+			"cost: server %v, onchain %v, offchain %v":    "coste: servidor %v, on-chain %v, off-chain %v",
+			"replayed":                     "reproducido",
+			"refunded in %v, fee %v":       "reembolsado en %v, comisión %v",
+			"refund pending in %v, fee %v": "reembolso pendiente en %v, comisión %v",
+			"cancellable":                  "cancelable",
+
+			// Quotes.
+			"Send on-chain:":                    "Enviar on-chain:",
+			"Receive off-chain:":                "Recibir off-chain:",
+			"Send off-chain:":                   "Enviar off-chain:",
+			"Receive on-chain:":                 "Recibir on-chain:",
+			"Loop service fee:":                 "Comisión del servicio Loop:",
+			"Estimated on-chain fee:":           "Comisión on-chain estimada:",
+			"Estimated total fee:":              "Comisión total estimada:",
+			"Conf target:":                      "Objetivo de confirmación:",
+			"Server conf target:":               "Objetivo del servidor:",
+			"CLTV expiry delta:":                "Delta de expiración CLTV:",
+			"No show penalty (prepay):":         "Penalización (prepago):",
+			"Publication deadline:":             "Plazo de publicación:",
+			"Max on-chain fee:":                 "Comisión on-chain máx.:",
+			"Max off-chain swap routing fee:":   "Enrutamiento máx. (swap):",
+			"Max off-chain prepay routing fee:": "Enrutamiento máx. (prepago):",
+			"CONTINUE SWAP? (y/n): ":            "¿CONTINUAR SWAP? (y/n): ",
+			"CONTINUE SWAPS? (y/n): ":           "¿CONTINUAR SWAPS? (y/n): ",
+		},
+	}
+)
+
+// supportedLangs returns all the languages that output can be translated to,
+// in alphabetical order.
+func supportedLangs() []string {
+	langs := []string{defaultLang}
+	for l := range catalogs {
+		langs = append(langs, l)
+	}
+
+	sort.Strings(langs)
+
+	return langs
+}
+
+// setLang sets the language that human readable output is translated to.
+func setLang(l string) error {
+	l = strings.ToLower(l)
+
+	if _, ok := catalogs[l]; !ok && l != defaultLang {
+		return fmt.Errorf("unsupported language: %v, expected one of: "+
+			"%v", l, strings.Join(supportedLangs(), ", "))
+	}
+
+	lang = l
+
+	return nil
+}
+
+// tr translates a message to our current language, falling back to the
+// message itself if it has no translation.
+func tr(msg string) string {
+	if translated, ok := catalogs[lang][msg]; ok {
+		return translated
+	}
+
+	return msg
+}
+
+// trf translates a format string to our current language and formats it with
+// the arguments provided.
+func trf(format string, args ...interface{}) string {
+	return fmt.Sprintf(tr(format), args...)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCatalogs tests that every catalog translates all of the states and
+// reasons that we display, and that translated format strings expect the same
+// number of arguments as the originals.
+func TestCatalogs(t *testing.T) {
+	enums := []map[int32]string{
+		looprpc.SwapState_name,
+		looprpc.SwapType_name,
+		looprpc.FailureReason_name,
+		looprpc.SwapFailureReason_name,
+		looprpc.ServerFailureReason_name,
+	}
+
+	for l, catalog := range catalogs {
+		for _, enum := range enums {
+			for _, name := range enum {
+				require.Contains(t, catalog, name, "language: %v", l)
+			}
+		}
+
+		for msg, translated := range catalog {
+			require.Equal(
+				t, strings.Count(msg, "%"),
+				strings.Count(translated, "%"),
+				"language: %v, message: %v", l, msg,
+			)
+		}
+	}
+}
+
+// TestTranslate tests selecting a language and translating messages to it.
+func TestTranslate(t *testing.T) {
+	defer func() {
+		lang = defaultLang
+	}()
+
+	require.Error(t, setLang("xx"))
+	require.Equal(t, defaultLang, lang)
+
+	// Our default language is not translated.
+	require.NoError(t, setLang(defaultLang))
+	require.Equal(t, "SUCCESS", tr("SUCCESS"))
+	require.Equal(t, "server: 1", trf("server: %v", 1))
+
+	require.NoError(t, setLang("DE"))
+	require.Equal(t, "erfolgreich", tr("SUCCESS"))
+	require.Equal(t, "Server: 1", trf("server: %v", 1))
+
+	// Messages that are missing from a catalog are not translated.
+	require.Equal(t, "unknown message", tr("unknown message"))
+}
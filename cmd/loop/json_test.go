@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestJSONMarshaler tests that our json output uses the field names of our
+// proto definitions, includes fields that have default values and prints
+// enums as their names.
+func TestJSONMarshaler(t *testing.T) {
+	swap := &looprpc.SwapStatus{
+		Amt:           100000,
+		Type:          looprpc.SwapType_LOOP_IN,
+		State:         looprpc.SwapState_FAILED,
+		FailureReason: looprpc.FailureReason_FAILURE_REASON_TIMEOUT,
+	}
+
+	jsonStr, err := newJSONMarshaler("").MarshalToString(swap)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &fields))
+
+	require.Equal(t, "100000", fields["amt"])
+	require.Equal(t, "LOOP_IN", fields["type"])
+	require.Equal(t, "FAILED", fields["state"])
+	require.Equal(t, "FAILURE_REASON_TIMEOUT", fields["failure_reason"])
+
+	// Fields that are not set are included with their default values,
+	// using the names from our proto definitions.
+	require.Contains(t, fields, "htlc_address_p2wsh")
+	require.Equal(t, "", fields["label"])
+	require.Equal(t, "0", fields["cost_server"])
+
+	// Marshaling the same response again produces the same output.
+	again, err := newJSONMarshaler("").MarshalToString(swap)
+	require.NoError(t, err)
+	require.Equal(t, jsonStr, again)
+}
+
+// mockTermsClient is a swap client that returns fixed swap terms.
+type mockTermsClient struct {
+	looprpc.SwapClientClient
+
+	loopOut *looprpc.OutTermsResponse
+	loopIn  *looprpc.InTermsResponse
+}
+
+// LoopOutTerms returns our fixed loop out terms.
+func (m *mockTermsClient) LoopOutTerms(context.Context, *looprpc.TermsRequest,
+	...grpc.CallOption) (*looprpc.OutTermsResponse, error) {
+
+	return m.loopOut, nil
+}
+
+// GetLoopInTerms returns our fixed loop in terms.
+func (m *mockTermsClient) GetLoopInTerms(context.Context,
+	*looprpc.TermsRequest, ...grpc.CallOption) (*looprpc.InTermsResponse,
+	error) {
+
+	return m.loopIn, nil
+}
+
+// TestTermsJSON tests the json output of the terms command.
+func TestTermsJSON(t *testing.T) {
+	client := &mockTermsClient{
+		loopOut: &looprpc.OutTermsResponse{
+			MinSwapAmount: 250000,
+			MaxSwapAmount: 5000000,
+			MinCltvDelta:  100,
+			MaxCltvDelta:  200,
+		},
+		loopIn: &looprpc.InTermsResponse{
+			MinSwapAmount: 250000,
+			MaxSwapAmount: 2000000,
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeTermsJSON(&out, client))
+
+	var terms struct {
+		LoopOut map[string]interface{} `json:"loop_out"`
+		LoopIn  map[string]interface{} `json:"loop_in"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &terms))
+
+	require.Equal(t, "250000", terms.LoopOut["min_swap_amount"])
+	require.Equal(t, "5000000", terms.LoopOut["max_swap_amount"])
+	require.Equal(t, float64(100), terms.LoopOut["min_cltv_delta"])
+	require.Equal(t, float64(200), terms.LoopOut["max_cltv_delta"])
+
+	require.Equal(t, "250000", terms.LoopIn["min_swap_amount"])
+	require.Equal(t, "2000000", terms.LoopIn["max_swap_amount"])
+
+	// Our output should only contain json, without the btc amounts that
+	// are added to our human readable output.
+	require.NotContains(t, out.String(), "btc")
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lightninglabs/loop/liquidity"
 	"github.com/lightninglabs/loop/looprpc"
@@ -59,6 +61,88 @@ var setLiquidityRuleCommand = cli.Command{
 			Usage: "the minimum percentage of outbound liquidity " +
 				"that we do not want to drop below.",
 		},
+		cli.IntFlag{
+			Name: "incoming_threshold_ppm",
+			Usage: "the minimum incoming liquidity expressed in " +
+				"parts per million of total capacity, which " +
+				"allows thresholds that are not whole " +
+				"percentages. May not be combined with " +
+				"incoming_threshold.",
+		},
+		cli.IntFlag{
+			Name: "outgoing_threshold_ppm",
+			Usage: "the minimum outgoing liquidity expressed in " +
+				"parts per million of total capacity. May " +
+				"not be combined with outgoing_threshold.",
+		},
+		cli.IntFlag{
+			Name: "sweep_conf_target",
+			Usage: "the confirmation target to use for the sweeps " +
+				"of swaps suggested for this rule, overriding " +
+				"the global sweep confirmation target.",
+		},
+		cli.IntFlag{
+			Name: "htlc_conf_target",
+			Usage: "the number of confirmations to require for the " +
+				"on chain htlc of swaps suggested for this rule.",
+		},
+		cli.DurationFlag{
+			Name: "min_imbalance_duration",
+			Usage: "the amount of time that the rule's thresholds " +
+				"must have been breached for before a swap " +
+				"is suggested, which requires loopd to take " +
+				"channel balance snapshots.",
+		},
+		cli.IntFlag{
+			Name: "min_reputation",
+			Usage: "the minimum percentage of swaps routed through " +
+				"the channel/peer that must have succeeded " +
+				"before autoloop will dispatch swaps of at " +
+				"least large_swap_amount.",
+		},
+		cli.Uint64Flag{
+			Name: "large_swap_amount",
+			Usage: "the swap amount in satoshis at or above " +
+				"which the rule's minimum reputation applies, " +
+				"if zero it applies to all swaps.",
+		},
+		cli.Uint64Flag{
+			Name: "fee_budget",
+			Usage: "the maximum amount in satoshis that swaps " +
+				"dispatched for this rule may spend on fees, " +
+				"in addition to the global autoloop budget.",
+		},
+		cli.DurationFlag{
+			Name: "fee_budget_period",
+			Usage: "the rolling period over which the rule's " +
+				"fee budget applies, if not set the budget " +
+				"applies to swaps since the global autoloop " +
+				"budget's start date.",
+		},
+		cli.IntFlag{
+			Name: "target_local",
+			Usage: "the percentage of total capacity that local " +
+				"balance should be kept at. Creates a target " +
+				"rule which suggests loop outs when local " +
+				"balance is above the tolerance band, and " +
+				"loop ins when it is below it. Target rules " +
+				"may only be set for peers, and may not be " +
+				"combined with thresholds or type.",
+		},
+		cli.IntFlag{
+			Name: "tolerance",
+			Usage: "the percentage of total capacity that local " +
+				"balance may move away from target_local " +
+				"before a swap is suggested.",
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "the type of swap to suggest for this rule, " +
+				"either out to acquire incoming liquidity or " +
+				"in to acquire outgoing liquidity. Loop in " +
+				"rules may only be set for peers.",
+			Value: "out",
+		},
 		cli.BoolFlag{
 			Name: "clear",
 			Usage: "remove the rule currently set for the " +
@@ -95,9 +179,8 @@ func setRule(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	// We need to set the full set of current parameters every time we call
-	// SetParameters. To allow users to set only individual fields on the
-	// cli, we lookup our current params, then update individual values.
+	// We lookup our current rules so that we can update the rule for this
+	// channel or peer and leave all other rules untouched.
 	params, err := client.GetLiquidityParams(
 		context.Background(), &looprpc.GetLiquidityParamsRequest{},
 	)
@@ -106,10 +189,22 @@ func setRule(ctx *cli.Context) error {
 	}
 
 	var (
-		inboundSet  = ctx.IsSet("incoming_threshold")
-		outboundSet = ctx.IsSet("outgoing_threshold")
-		ruleSet     bool
-		otherRules  []*looprpc.LiquidityRule
+		inboundSet   = ctx.IsSet("incoming_threshold")
+		outboundSet  = ctx.IsSet("outgoing_threshold")
+		inPPMSet     = ctx.IsSet("incoming_threshold_ppm")
+		outPPMSet    = ctx.IsSet("outgoing_threshold_ppm")
+		sweepSet     = ctx.IsSet("sweep_conf_target")
+		htlcSet      = ctx.IsSet("htlc_conf_target")
+		durationSet  = ctx.IsSet("min_imbalance_duration")
+		repSet       = ctx.IsSet("min_reputation")
+		largeSet     = ctx.IsSet("large_swap_amount")
+		budgetSet    = ctx.IsSet("fee_budget")
+		periodSet    = ctx.IsSet("fee_budget_period")
+		typeSet      = ctx.IsSet("type")
+		targetSet    = ctx.IsSet("target_local")
+		toleranceSet = ctx.IsSet("tolerance")
+		ruleSet      bool
+		otherRules   []*looprpc.LiquidityRule
 	)
 
 	// Run through our current set of rules and check whether we have a rule
@@ -142,28 +237,52 @@ func setRule(ctx *cli.Context) error {
 				"set at present", chanID)
 		}
 
-		if inboundSet || outboundSet {
+		if inboundSet || outboundSet || inPPMSet || outPPMSet ||
+			sweepSet || htlcSet || durationSet || repSet ||
+			largeSet || budgetSet || periodSet || typeSet ||
+			targetSet || toleranceSet {
+
 			return fmt.Errorf("do not set other flags with clear " +
 				"flag")
 		}
 
 		params.Rules = otherRules
-		_, err = client.SetLiquidityParams(
-			context.Background(),
-			&looprpc.SetLiquidityParamsRequest{
-				Parameters: params,
-			},
-		)
-		return err
+		return updateParams(client, params, "rules")
 	}
 
 	// If we are setting a rule for this channel (not clearing it), check
 	// that at least one value is set.
-	if !inboundSet && !outboundSet {
+	if !inboundSet && !outboundSet && !inPPMSet && !outPPMSet &&
+		!sweepSet && !htlcSet && !durationSet && !repSet &&
+		!largeSet && !budgetSet && !periodSet && !typeSet &&
+		!targetSet && !toleranceSet {
+
 		return fmt.Errorf("provide at least one flag to set rules or " +
 			"use the --clear flag to remove rules")
 	}
 
+	if (inboundSet && inPPMSet) || (outboundSet && outPPMSet) {
+		return fmt.Errorf("a threshold may be set as a percentage " +
+			"or in ppm, not both")
+	}
+
+	targetRule := targetSet || toleranceSet
+	if targetRule && (!targetSet || !toleranceSet) {
+		return fmt.Errorf("target_local and tolerance must be set " +
+			"together")
+	}
+
+	if periodSet && !budgetSet {
+		return fmt.Errorf("fee_budget_period requires fee_budget")
+	}
+
+	if targetRule && (inboundSet || outboundSet || inPPMSet ||
+		outPPMSet || typeSet) {
+
+		return fmt.Errorf("target rules may not set thresholds or " +
+			"a swap type")
+	}
+
 	// Create a new rule which will be used to overwrite our current rule.
 	newRule := &looprpc.LiquidityRule{
 		ChannelId: chanID,
@@ -186,18 +305,194 @@ func setRule(ctx *cli.Context) error {
 		)
 	}
 
+	if inPPMSet {
+		newRule.IncomingThresholdPpm = uint32(
+			ctx.Int("incoming_threshold_ppm"),
+		)
+	}
+
+	if outPPMSet {
+		newRule.OutgoingThresholdPpm = uint32(
+			ctx.Int("outgoing_threshold_ppm"),
+		)
+	}
+
+	if sweepSet {
+		newRule.SweepConfTarget = int32(ctx.Int("sweep_conf_target"))
+	}
+
+	if htlcSet {
+		newRule.HtlcConfTarget = int32(ctx.Int("htlc_conf_target"))
+	}
+
+	if durationSet {
+		newRule.MinImbalanceDurationSec = uint64(
+			ctx.Duration("min_imbalance_duration").Seconds(),
+		)
+	}
+
+	if repSet {
+		newRule.MinReputation = uint32(ctx.Int("min_reputation"))
+	}
+
+	if largeSet {
+		newRule.LargeSwapAmountSat = ctx.Uint64("large_swap_amount")
+	}
+
+	if budgetSet {
+		newRule.FeeBudgetSat = ctx.Uint64("fee_budget")
+	}
+
+	if periodSet {
+		newRule.FeeBudgetPeriodSec = uint64(
+			ctx.Duration("fee_budget_period").Seconds(),
+		)
+	}
+
+	if targetRule {
+		newRule.Type = looprpc.LiquidityRuleType_TARGET
+		newRule.TargetLocalPpm = uint32(
+			liquidity.PercentToPPM(ctx.Int("target_local")),
+		)
+		newRule.TolerancePpm = uint32(
+			liquidity.PercentToPPM(ctx.Int("tolerance")),
+		)
+	}
+
+	switch ctx.String("type") {
+	case "out":
+		newRule.SwapType = looprpc.SwapType_LOOP_OUT
+
+	case "in":
+		newRule.SwapType = looprpc.SwapType_LOOP_IN
+
+	default:
+		return fmt.Errorf("unknown swap type: %v", ctx.String("type"))
+	}
+
 	// Just set the rules on our current set of parameters and leave the
 	// other values untouched.
 	otherRules = append(otherRules, newRule)
 	params.Rules = otherRules
 
-	// Update our parameters to the existing set, plus our new rule.
-	_, err = client.SetLiquidityParams(
-		context.Background(),
-		&looprpc.SetLiquidityParamsRequest{
-			Parameters: params,
+	// Update our rules to the existing set, plus our new rule.
+	return updateParams(client, params, "rules")
+}
+
+var disqualifyCommand = cli.Command{
+	Name:      "disqualify",
+	Usage:     "disqualify a channel or peer from autoloop swaps",
+	ArgsUsage: "{shortchanid | peerpubkey}",
+	Description: "Prevents the liquidity manager from using a channel, " +
+		"or all of the channels with a peer, for swaps, even if they " +
+		"are covered by a rule.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "clear",
+			Usage: "allow the channel/peer to be used for swaps " +
+				"again.",
 		},
+	},
+	Action: disqualify,
+}
+
+func disqualify(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("please set a channel id or peer pubkey " +
+			"to disqualify")
+	}
+
+	var (
+		pubkey     route.Vertex
+		pubkeySet  bool
+		clearFlag  = ctx.Bool("clear")
+		channelArg = ctx.Args().First()
 	)
+	chanID, err := strconv.ParseUint(channelArg, 10, 64)
+	if err != nil {
+		pubkey, err = route.NewVertexFromStr(channelArg)
+		if err != nil {
+			return fmt.Errorf("please provide a valid pubkey: "+
+				"%v, or short channel ID", err)
+		}
+		pubkeySet = true
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// We lookup our current params so that we can update our set of
+	// disqualified channels or peers.
+	params, err := client.GetLiquidityParams(
+		context.Background(), &looprpc.GetLiquidityParamsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	if pubkeySet {
+		var peers [][]byte
+		for _, peer := range params.DisqualifiedPeers {
+			if bytes.Equal(peer, pubkey[:]) {
+				found = true
+				continue
+			}
+
+			peers = append(peers, peer)
+		}
+
+		if !clearFlag {
+			peers = append(peers, pubkey[:])
+		}
+		params.DisqualifiedPeers = peers
+	} else {
+		var channels []uint64
+		for _, channel := range params.DisqualifiedChannels {
+			if channel == chanID {
+				found = true
+				continue
+			}
+
+			channels = append(channels, channel)
+		}
+
+		if !clearFlag {
+			channels = append(channels, chanID)
+		}
+		params.DisqualifiedChannels = channels
+	}
+
+	if clearFlag && !found {
+		return fmt.Errorf("%v is not disqualified", channelArg)
+	}
+
+	return updateParams(
+		client, params, "disqualified_channels", "disqualified_peers",
+	)
+}
+
+// updateParams updates the liquidity parameter fields provided to their value
+// in the set of parameters provided. The update fails if the parameters have
+// been changed since they were fetched, so that we do not overwrite concurrent
+// changes.
+func updateParams(client looprpc.SwapClientClient,
+	params *looprpc.LiquidityParameters, fields ...string) error {
+
+	_, err := client.UpdateLiquidityParams(
+		context.Background(), &looprpc.UpdateLiquidityParamsRequest{
+			Parameters:   params,
+			UpdateFields: fields,
+			Version:      params.Version,
+		},
+	)
+	if status.Code(err) == codes.Aborted {
+		return errors.New("liquidity parameters were changed " +
+			"concurrently, please try again")
+	}
 
 	return err
 }
@@ -234,15 +529,16 @@ var setParamsCommand = cli.Command{
 				"volume that are are willing to pay in " +
 				"routing fees.",
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "maxprepay",
-			Usage: "the maximum no-show (prepay) in satoshis that " +
-				"swap suggestions should be limited to.",
+			Usage: "the maximum no-show (prepay) that swap " +
+				"suggestions should be limited to, " +
+				amtUsage,
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "maxminer",
-			Usage: "the maximum miner fee in satoshis that swap " +
-				"suggestions should be limited to.",
+			Usage: "the maximum miner fee that swap suggestions " +
+				"should be limited to, " + amtUsage,
 		},
 		cli.IntFlag{
 			Name: "sweepconf",
@@ -257,17 +553,34 @@ var setParamsCommand = cli.Command{
 				"previously had a failed swap will be " +
 				"included in suggestions.",
 		},
+		cli.Uint64Flag{
+			Name: "swapcooldown",
+			Usage: "the amount of time, in seconds, that " +
+				"should pass before a channel or peer that " +
+				"was part of a successful swap will be " +
+				"included in suggestions again. Set to zero " +
+				"to disable the cooldown.",
+		},
+		cli.Uint64Flag{
+			Name: "publicationdeadline",
+			Usage: "the amount of time, in seconds, that the " +
+				"server may wait before publishing the htlc " +
+				"of automatically dispatched loop outs. A " +
+				"later deadline may result in a lower swap " +
+				"fee. Set to zero to request immediate " +
+				"publication.",
+		},
 		cli.BoolFlag{
 			Name: "autoloop",
 			Usage: "set to true to enable automated dispatch " +
 				"of swaps, limited to the budget set by " +
 				"autobudget",
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "autobudget",
-			Usage: "the maximum amount of fees in satoshis that " +
+			Usage: "the maximum amount of fees that " +
 				"automatically dispatched loop out swaps may " +
-				"spend",
+				"spend, " + amtUsage,
 		},
 		cli.Uint64Flag{
 			Name: "budgetstart",
@@ -281,15 +594,69 @@ var setParamsCommand = cli.Command{
 				"dispatched swaps that we allow to be in " +
 				"flight",
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "minamt",
-			Usage: "the minimum amount in satoshis that the " +
-				"autoloop client will dispatch per-swap",
+			Usage: "the minimum amount that the autoloop " +
+				"client will dispatch per-swap, " + amtUsage,
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "maxamt",
-			Usage: "the maximum amount in satoshis that the " +
-				"autoloop client will dispatch per-swap",
+			Usage: "the maximum amount that the autoloop " +
+				"client will dispatch per-swap, " + amtUsage,
+		},
+		cli.BoolFlag{
+			Name: "preferrebalance",
+			Usage: "set to true to skip swaps for channel rules " +
+				"when a circular rebalance is estimated to " +
+				"be much cheaper",
+		},
+		cli.IntFlag{
+			Name: "localbalancetarget",
+			Usage: "enable easy autoloop mode, which keeps the " +
+				"node's total local balance at this " +
+				"percentage of its capacity by looping out " +
+				"from the fullest channels, rather than " +
+				"using rules. Must be between 5 and 95, set " +
+				"to zero to disable.",
+		},
+		cli.BoolFlag{
+			Name: "excludeinactive",
+			Usage: "set to true to exclude channels that are " +
+				"not currently active from autoloop, so that " +
+				"swaps are not suggested based on balances " +
+				"that can't be moved",
+		},
+		cli.BoolFlag{
+			Name: "rounduptominimum",
+			Usage: "set to true to suggest swaps of the server's " +
+				"minimum amount when a rule requires less " +
+				"than the minimum, if the larger swap does " +
+				"not breach the rule's other threshold",
+		},
+		cli.IntFlag{
+			Name: "maxsweepfeerate",
+			Usage: "the maximum estimated sweep fee rate, in " +
+				"sat/vByte, at which swaps are suggested. " +
+				"Unlike sweeplimit, this limit may be used " +
+				"with feepercent. Set to zero to disable.",
+		},
+		cli.StringFlag{
+			Name: "scheduledays",
+			Usage: "a comma separated list of the days of the " +
+				"week that autoloop may dispatch swaps on, " +
+				"for example: sat,sun. Set to an empty " +
+				"string to allow all days.",
+		},
+		cli.IntFlag{
+			Name: "schedulestart",
+			Usage: "the hour of the day in UTC, inclusive, from " +
+				"which autoloop may dispatch swaps.",
+		},
+		cli.IntFlag{
+			Name: "scheduleend",
+			Usage: "the hour of the day in UTC, exclusive, until " +
+				"which autoloop may dispatch swaps. Set equal " +
+				"to schedulestart to allow all hours.",
 		},
 	},
 	Action: setParams,
@@ -302,19 +669,16 @@ func setParams(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	// We need to set the full set of current parameters every time we call
-	// SetParameters. To allow users to set only individual fields on the
-	// cli, we lookup our current params, then update individual values.
-	params, err := client.GetLiquidityParams(
-		context.Background(), &looprpc.GetLiquidityParamsRequest{},
-	)
-	if err != nil {
-		return err
-	}
+	// We only send the fields that are set by cli flags, and let loopd
+	// merge them into its current parameters so that we do not overwrite
+	// concurrent changes to other parameters.
+	var (
+		params = &looprpc.LiquidityParameters{}
+		fields []string
 
-	var flagSet, categoriesSet, feePercentSet bool
+		categoriesSet, feePercentSet bool
+	)
 
-	// Update our existing parameters with the values provided by cli flags.
 	// Our fee categories and fee percentage are exclusive, so track which
 	// flags are set to ensure that we don't have nonsensical overlap.
 	if ctx.IsSet("maxswapfee") {
@@ -324,7 +688,7 @@ func setParams(ctx *cli.Context) error {
 			return err
 		}
 
-		flagSet = true
+		fields = append(fields, "max_swap_fee_ppm")
 		categoriesSet = true
 	}
 
@@ -332,7 +696,7 @@ func setParams(ctx *cli.Context) error {
 		satPerVByte := ctx.Int("sweeplimit")
 		params.SweepFeeRateSatPerVbyte = uint64(satPerVByte)
 
-		flagSet = true
+		fields = append(fields, "sweep_fee_rate_sat_per_vbyte")
 		categoriesSet = true
 	}
 
@@ -343,7 +707,7 @@ func setParams(ctx *cli.Context) error {
 			return err
 		}
 
-		flagSet = true
+		fields = append(fields, "fee_ppm")
 		feePercentSet = true
 	}
 
@@ -354,7 +718,7 @@ func setParams(ctx *cli.Context) error {
 			return err
 		}
 
-		flagSet = true
+		fields = append(fields, "max_routing_fee_ppm")
 		categoriesSet = true
 	}
 
@@ -365,97 +729,284 @@ func setParams(ctx *cli.Context) error {
 			return err
 		}
 
-		flagSet = true
+		fields = append(fields, "max_prepay_routing_fee_ppm")
 		categoriesSet = true
 	}
 
 	if ctx.IsSet("maxprepay") {
-		params.MaxPrepaySat = ctx.Uint64("maxprepay")
-		flagSet = true
+		amt, err := parseAmt(ctx.String("maxprepay"))
+		if err != nil {
+			return err
+		}
+
+		params.MaxPrepaySat = uint64(amt)
+		fields = append(fields, "max_prepay_sat")
 		categoriesSet = true
 	}
 
 	if ctx.IsSet("maxminer") {
-		params.MaxMinerFeeSat = ctx.Uint64("maxminer")
-		flagSet = true
+		amt, err := parseAmt(ctx.String("maxminer"))
+		if err != nil {
+			return err
+		}
+
+		params.MaxMinerFeeSat = uint64(amt)
+		fields = append(fields, "max_miner_fee_sat")
 		categoriesSet = true
 	}
 
 	if ctx.IsSet("sweepconf") {
 		params.SweepConfTarget = int32(ctx.Int("sweepconf"))
-		flagSet = true
+		fields = append(fields, "sweep_conf_target")
 	}
 
 	if ctx.IsSet("failurebackoff") {
 		params.FailureBackoffSec = ctx.Uint64("failurebackoff")
-		flagSet = true
+		fields = append(fields, "failure_backoff_sec")
+	}
+
+	if ctx.IsSet("swapcooldown") {
+		params.SwapCooldownSec = ctx.Uint64("swapcooldown")
+		fields = append(fields, "swap_cooldown_sec")
+	}
+
+	if ctx.IsSet("publicationdeadline") {
+		params.PublicationDeadlineSec = ctx.Uint64(
+			"publicationdeadline",
+		)
+		fields = append(fields, "publication_deadline_sec")
 	}
 
 	if ctx.IsSet("autoloop") {
 		params.Autoloop = ctx.Bool("autoloop")
-		flagSet = true
+		fields = append(fields, "autoloop")
 	}
 
 	if ctx.IsSet("autobudget") {
-		params.AutoloopBudgetSat = ctx.Uint64("autobudget")
-		flagSet = true
+		amt, err := parseAmt(ctx.String("autobudget"))
+		if err != nil {
+			return err
+		}
+
+		params.AutoloopBudgetSat = uint64(amt)
+		fields = append(fields, "autoloop_budget_sat")
 	}
 
 	if ctx.IsSet("budgetstart") {
 		params.AutoloopBudgetStartSec = ctx.Uint64("budgetstart")
-		flagSet = true
+		fields = append(fields, "autoloop_budget_start_sec")
 	}
 
 	if ctx.IsSet("autoinflight") {
 		params.AutoMaxInFlight = ctx.Uint64("autoinflight")
-		flagSet = true
+		fields = append(fields, "auto_max_in_flight")
 	}
 
 	if ctx.IsSet("minamt") {
-		params.MinSwapAmount = ctx.Uint64("minamt")
-		flagSet = true
+		amt, err := parseAmt(ctx.String("minamt"))
+		if err != nil {
+			return err
+		}
+
+		params.MinSwapAmount = uint64(amt)
+		fields = append(fields, "min_swap_amount")
 	}
 
 	if ctx.IsSet("maxamt") {
-		params.MaxSwapAmount = ctx.Uint64("maxamt")
-		flagSet = true
+		amt, err := parseAmt(ctx.String("maxamt"))
+		if err != nil {
+			return err
+		}
+
+		params.MaxSwapAmount = uint64(amt)
+		fields = append(fields, "max_swap_amount")
+	}
+
+	if ctx.IsSet("preferrebalance") {
+		params.PreferRebalance = ctx.Bool("preferrebalance")
+		fields = append(fields, "prefer_rebalance")
+	}
+
+	if ctx.IsSet("localbalancetarget") {
+		target := ctx.Int("localbalancetarget")
+		if target < 0 {
+			return fmt.Errorf("localbalancetarget must not be " +
+				"negative")
+		}
+
+		params.LocalBalanceTarget = uint32(target)
+		fields = append(fields, "local_balance_target")
+	}
+
+	if ctx.IsSet("excludeinactive") {
+		params.ExcludeInactiveChannels = ctx.Bool("excludeinactive")
+		fields = append(fields, "exclude_inactive_channels")
+	}
+
+	if ctx.IsSet("rounduptominimum") {
+		params.RoundUpToMinimum = ctx.Bool("rounduptominimum")
+		fields = append(fields, "round_up_to_minimum")
 	}
 
-	if !flagSet {
+	if ctx.IsSet("maxsweepfeerate") {
+		satPerVByte := ctx.Int("maxsweepfeerate")
+		if satPerVByte < 0 {
+			return fmt.Errorf("maxsweepfeerate must not be " +
+				"negative")
+		}
+
+		params.SweepFeeRateLimitSatPerVbyte = uint64(satPerVByte)
+		fields = append(fields, "sweep_fee_rate_limit_sat_per_vbyte")
+	}
+
+	if ctx.IsSet("scheduledays") {
+		params.ScheduleDays, err = parseScheduleDays(
+			ctx.String("scheduledays"),
+		)
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, "schedule_days")
+	}
+
+	if ctx.IsSet("schedulestart") {
+		hour := ctx.Int("schedulestart")
+		if hour < 0 {
+			return fmt.Errorf("schedulestart must not be negative")
+		}
+
+		params.ScheduleStartHour = uint32(hour)
+		fields = append(fields, "schedule_start_hour")
+	}
+
+	if ctx.IsSet("scheduleend") {
+		hour := ctx.Int("scheduleend")
+		if hour < 0 {
+			return fmt.Errorf("scheduleend must not be negative")
+		}
+
+		params.ScheduleEndHour = uint32(hour)
+		fields = append(fields, "schedule_end_hour")
+	}
+
+	if len(fields) == 0 {
 		return fmt.Errorf("at least one flag required to set params")
 	}
 
-	switch {
 	// Fail if fee params for both types of fee limit are set, since they
-	// cannot be used in conjunction.
-	case feePercentSet && categoriesSet:
+	// cannot be used in conjunction. If only one type is set, loopd unsets
+	// the other type so that it does not need to be manually updated.
+	if feePercentSet && categoriesSet {
 		return fmt.Errorf("feepercent cannot be set with specific " +
 			"fee category flags")
+	}
 
-	// If we are updating to fee percentage, we unset all other fee related
-	// params so that users do not need to manually unset them.
-	case feePercentSet:
-		params.SweepFeeRateSatPerVbyte = 0
-		params.MaxMinerFeeSat = 0
-		params.MaxPrepayRoutingFeePpm = 0
-		params.MaxPrepaySat = 0
-		params.MaxRoutingFeePpm = 0
-		params.MaxSwapFeePpm = 0
+	resp, err := client.UpdateLiquidityParams(
+		context.Background(), &looprpc.UpdateLiquidityParamsRequest{
+			Parameters:   params,
+			UpdateFields: fields,
+		},
+	)
+	if err != nil {
+		return err
+	}
 
-	// If we are setting any of our fee categories, unset fee percentage
-	// so that it does not need to be manually updated.
-	case categoriesSet:
-		params.FeePpm = 0
+	if resp.EffectiveSec != 0 {
+		fmt.Printf("Parameters increase autoloop fees and will take "+
+			"effect at %v, use loop cancelparams to cancel them\n",
+			time.Unix(int64(resp.EffectiveSec), 0))
+	}
+
+	return nil
+}
+
+var cancelParamsCommand = cli.Command{
+	Name:  "cancelparams",
+	Usage: "cancel a pending liquidity parameter change",
+	Description: "Cancels a parameter change that increases the fees " +
+		"autoloop may spend, and is waiting for loopd's change " +
+		"delay to pass before it takes effect. The current " +
+		"parameters are left unchanged.",
+	Action: cancelParams,
+}
 
+func cancelParams(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
 	}
-	// Update our parameters to our mutated values.
-	_, err = client.SetLiquidityParams(
-		context.Background(), &looprpc.SetLiquidityParamsRequest{
-			Parameters: params,
-		},
+	defer cleanup()
+
+	_, err = client.CancelLiquidityParams(
+		context.Background(), &looprpc.CancelLiquidityParamsRequest{},
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	fmt.Println("Pending parameter change cancelled")
+
+	return nil
+}
+
+var reputationCommand = cli.Command{
+	Name:  "reputation",
+	Usage: "show the swap reputations of our peers",
+	Description: "Displays the number of completed swaps routed " +
+		"through each peer, the percentage of them that succeeded, " +
+		"their average fees relative to the fees quoted and their " +
+		"average completion time.",
+	Action: getReputation,
+}
+
+func getReputation(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetPeerReputations(
+		context.Background(), &looprpc.GetPeerReputationsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var staleRulesCommand = cli.Command{
+	Name:  "stalerules",
+	Usage: "show autoloop rules for inactive channels and peers",
+	Description: "Displays the autoloop rules that are set for channels " +
+		"or peers that have been closed or disconnected for at " +
+		"least loopd's stale rule age. Rules can be removed with " +
+		"setrule --clear, or automatically by starting loopd with " +
+		"--prunestalerules.",
+	Action: getStaleRules,
+}
+
+func getStaleRules(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetStaleRules(
+		context.Background(), &looprpc.GetStaleRulesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
 }
 
 // ppmFromPercentage converts a percentage, expressed as a float, to parts
@@ -468,6 +1019,40 @@ func ppmFromPercentage(percentage float64) (uint64, error) {
 	return uint64(percentage / 100 * liquidity.FeeBase), nil
 }
 
+// parseScheduleDays parses a comma separated list of days of the week, which
+// may be expressed as three letter abbreviations or as numbers where 0 is
+// sunday.
+func parseScheduleDays(days string) ([]uint32, error) {
+	if strings.TrimSpace(days) == "" {
+		return nil, nil
+	}
+
+	abbreviations := make(map[string]time.Weekday)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String()[:3])
+		abbreviations[name] = day
+	}
+
+	var weekdays []uint32
+	for _, day := range strings.Split(days, ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+
+		if weekday, ok := abbreviations[day]; ok {
+			weekdays = append(weekdays, uint32(weekday))
+			continue
+		}
+
+		weekday, err := strconv.ParseUint(day, 10, 32)
+		if err != nil || weekday > uint64(time.Saturday) {
+			return nil, fmt.Errorf("unknown schedule day: %v", day)
+		}
+
+		weekdays = append(weekdays, uint32(weekday))
+	}
+
+	return weekdays, nil
+}
+
 var suggestSwapCommand = cli.Command{
 	Name:  "suggestswaps",
 	Usage: "show a list of suggested swaps",
@@ -506,3 +1091,93 @@ func suggestSwap(ctx *cli.Context) error {
 	return errors.New("no rules set for autolooper, please set rules " +
 		"using the setrule command")
 }
+
+var autoStatusCommand = cli.Command{
+	Name:  "autostatus",
+	Usage: "show the outcome of the most recent autoloop check",
+	Description: "Displays the time of the most recent autoloop check, " +
+		"the time that its outcome last changed, the reasons " +
+		"that swaps are currently not being suggested for each " +
+		"rule, a summary of the autoloop budget and recent " +
+		"autoloop events.",
+	Action: autoStatus,
+}
+
+func autoStatus(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetAutoloopStatus(
+		context.Background(), &looprpc.AutoloopStatusRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var autoTriggerCommand = cli.Command{
+	Name:  "autotrigger",
+	Usage: "perform an autoloop check immediately",
+	Description: "Performs an autoloop check immediately, rather than " +
+		"waiting for the next scheduled check, and displays its " +
+		"outcome. The check honors the configured budget and " +
+		"limits, and only dispatches swaps if autoloop is enabled.",
+	Action: autoTrigger,
+}
+
+func autoTrigger(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.TriggerAutoloopEvaluation(
+		context.Background(),
+		&looprpc.TriggerAutoloopEvaluationRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var budgetReportCommand = cli.Command{
+	Name:  "budgetreport",
+	Usage: "compare reserved autoloop fees to realized fees",
+	Description: "Displays the worst-case fees that automatically " +
+		"dispatched swaps reserved from the autoloop budget while " +
+		"they were in flight, compared to the fees that they " +
+		"realized once they completed, along with the fees that " +
+		"are currently reserved by in flight swaps.",
+	Action: budgetReport,
+}
+
+func budgetReport(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetBudgetReconciliation(
+		context.Background(), &looprpc.BudgetReconciliationRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var liquidityHistoryCommand = cli.Command{
+	Name:  "liquidityhistory",
+	Usage: "show the recorded balance history of channels",
+	Description: "Shows the channel balance snapshots that loopd has " +
+		"recorded, along with the lowest and highest local balance " +
+		"of each channel, so that persistent imbalances can be " +
+		"told apart from transient spikes. Snapshots are only " +
+		"recorded if loopd's liquiditysnapshotinterval is set.",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "since",
+			Usage: "only include snapshots taken within this " +
+				"amount of time (e.g. 24h). If not set, all " +
+				"stored snapshots are included",
+		},
+		cli.StringFlag{
+			Name: "channel",
+			Usage: "the comma-separated list of short channel " +
+				"IDs of the channels to show",
+		},
+	},
+	Action: liquidityHistory,
+}
+
+func liquidityHistory(ctx *cli.Context) error {
+	req := &looprpc.LiquidityHistoryRequest{}
+
+	if ctx.IsSet("since") {
+		req.StartTimeNs = time.Now().Add(
+			ctx.Duration("since") * -1,
+		).UnixNano()
+	}
+
+	if ctx.IsSet("channel") {
+		chanSet, err := parseChanSet(ctx.String("channel"))
+		if err != nil {
+			return err
+		}
+
+		req.ChannelIds = chanSet
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetLiquidityHistory(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
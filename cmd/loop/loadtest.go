@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var loadTestCommand = cli.Command{
+	Name:   "loadtest",
+	Usage:  "dispatch a large number of concurrent loop out swaps",
+	Hidden: true,
+	Description: `
+	Dispatches a number of concurrent loop out swaps (or quotes) and
+	reports the achieved throughput and request latency. This command is
+	intended to be used against a loopd instance that is connected to a
+	test or mock swap server, it must not be used on mainnet.
+	`,
+	ArgsUsage: "amt",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "amt",
+			Usage: "the amount in satoshis to loop out per swap",
+		},
+		cli.IntFlag{
+			Name:  "swaps",
+			Usage: "the total number of swaps to dispatch",
+			Value: 100,
+		},
+		cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "the number of swaps to dispatch concurrently",
+			Value: 10,
+		},
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks from the swap " +
+				"initiation height that the on-chain HTLC " +
+				"should be swept within",
+			Value: uint64(loop.DefaultSweepConfTarget),
+		},
+		cli.BoolFlag{
+			Name: "quote_only",
+			Usage: "only request quotes rather than dispatching " +
+				"swaps",
+		},
+	},
+	Action: loadTest,
+}
+
+func loadTest(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	var amtStr string
+	switch {
+	case ctx.IsSet("amt"):
+		amtStr = ctx.String("amt")
+	case ctx.NArg() > 0:
+		amtStr = args[0]
+	default:
+		// Show command help if no arguments and flags were provided.
+		return cli.ShowCommandHelp(ctx, "loadtest")
+	}
+
+	amt, err := parseAmt(amtStr)
+	if err != nil {
+		return err
+	}
+
+	swaps := ctx.Int("swaps")
+	concurrency := ctx.Int("concurrency")
+	if swaps <= 0 || concurrency <= 0 {
+		return errors.New("swaps and concurrency must be positive")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	dispatch := func() error {
+		return loadTestSwap(
+			client, amt, int32(ctx.Uint64("conf_target")),
+			ctx.Bool("quote_only"),
+		)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		latencies []time.Duration
+		failures  = make(map[string]int)
+		queue     = make(chan struct{}, swaps)
+	)
+
+	for i := 0; i < swaps; i++ {
+		queue <- struct{}{}
+	}
+	close(queue)
+
+	start := time.Now()
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range queue {
+				reqStart := time.Now()
+				err := dispatch()
+				latency := time.Since(reqStart)
+
+				mu.Lock()
+				if err != nil {
+					failures[err.Error()]++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	printLoadTestResults(swaps, elapsed, latencies, failures)
+
+	return nil
+}
+
+// loadTestSwap requests a quote for a loop out swap and dispatches the swap
+// if quoteOnly is false.
+func loadTestSwap(client looprpc.SwapClientClient, amt btcutil.Amount,
+	confTarget int32, quoteOnly bool) error {
+
+	// We request fast swaps so that the swaps are dispatched straight
+	// away.
+	quoteReq := &looprpc.QuoteRequest{
+		Amt:                     int64(amt),
+		ConfTarget:              confTarget,
+		SwapPublicationDeadline: uint64(time.Now().Unix()),
+	}
+	quote, err := client.LoopOutQuote(context.Background(), quoteReq)
+	if err != nil {
+		return err
+	}
+
+	if quoteOnly {
+		return nil
+	}
+
+	limits := getOutLimits(amt, quote)
+
+	_, err = client.LoopOut(context.Background(), &looprpc.LoopOutRequest{
+		Amt:                     int64(amt),
+		MaxMinerFee:             int64(limits.maxMinerFee),
+		MaxPrepayAmt:            int64(limits.maxPrepayAmt),
+		MaxSwapFee:              int64(limits.maxSwapFee),
+		MaxPrepayRoutingFee:     int64(limits.maxPrepayRoutingFee),
+		MaxSwapRoutingFee:       int64(limits.maxSwapRoutingFee),
+		SweepConfTarget:         confTarget,
+		HtlcConfirmations:       1,
+		SwapPublicationDeadline: quoteReq.SwapPublicationDeadline,
+		Initiator:               defaultInitiator,
+		SwapPaymentDest:         quote.SwapPaymentDest,
+	})
+
+	return err
+}
+
+// printLoadTestResults prints the throughput and latency percentiles of a
+// load test run.
+func printLoadTestResults(total int, elapsed time.Duration,
+	latencies []time.Duration, failures map[string]int) {
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	percentile := func(p int) time.Duration {
+		if len(latencies) == 0 {
+			return 0
+		}
+
+		return latencies[(len(latencies)-1)*p/100]
+	}
+
+	perMinute := float64(len(latencies)) / elapsed.Minutes()
+
+	fmt.Printf("Requests:         %v\n", total)
+	fmt.Printf("Succeeded:        %v\n", len(latencies))
+	fmt.Printf("Failed:           %v\n", total-len(latencies))
+	fmt.Printf("Duration:         %v\n", elapsed)
+	fmt.Printf("Swaps per minute: %.2f\n", perMinute)
+	fmt.Printf("Latency p50:      %v\n", percentile(50))
+	fmt.Printf("Latency p95:      %v\n", percentile(95))
+	fmt.Printf("Latency max:      %v\n", percentile(100))
+
+	if len(failures) == 0 {
+		return
+	}
+
+	fmt.Println("Failures:")
+	for reason, count := range failures {
+		fmt.Printf("  %v: %v\n", count, reason)
+	}
+}
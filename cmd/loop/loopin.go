@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/labels"
 	"github.com/lightninglabs/loop/looprpc"
@@ -21,7 +24,8 @@ var (
 		Name: "conf_target",
 		Usage: "the target number of blocks the on-chain " +
 			"htlc broadcast by the swap client should " +
-			"confirm within",
+			"confirm within, if not set a default is chosen " +
+			"based on the swap amount",
 	}
 
 	labelFlag = cli.StringFlag{
@@ -32,6 +36,36 @@ var (
 			labels.MaxLength, labels.Reserved),
 	}
 
+	idempotencyKeyFlag = cli.StringFlag{
+		Name: "idempotency_key",
+		Usage: "an optional key that identifies this request, if " +
+			"a swap was already created with this key it is " +
+			"returned instead of creating a new swap",
+	}
+
+	allowDuplicateFlag = cli.BoolFlag{
+		Name: "allow_duplicate",
+		Usage: "create the swap even if an identical swap was " +
+			"requested shortly before",
+	}
+
+	privateFlag = cli.BoolFlag{
+		Name: "private",
+		Usage: "include hop hints for our private channels in the " +
+			"swap invoice, so that the server can route to us " +
+			"over them",
+	}
+
+	routeHintsFlag = cli.StringFlag{
+		Name: "route_hints",
+		Usage: "route hints to include in the swap invoice, " +
+			"formatted as a json array of route hints, eg: " +
+			`'[{"hop_hints":[{"node_id":"<pubkey>",` +
+			`"chan_id":<scid>,"fee_base_msat":1000,` +
+			`"fee_proportional_millionths":1,` +
+			`"cltv_expiry_delta":40}]}]'`,
+	}
+
 	loopInCommand = cli.Command{
 		Name:      "in",
 		Usage:     "perform an on-chain to off-chain swap (loop in)",
@@ -47,19 +81,33 @@ var (
 		The external flag can be set to publish the on chain htlc 
 		independently. Note that this flag cannot be set with the 
 		conf_target flag.
+
+		The psbt flag can be set to fund the htlc from an external 
+		wallet. An unsigned psbt that pays the swap amount to the htlc 
+		is returned, which should be funded, signed and broadcast by 
+		the external wallet. This flag implies the external flag.
 		`,
 		Flags: []cli.Flag{
-			cli.Uint64Flag{
+			cli.StringFlag{
 				Name:  "amt",
-				Usage: "the amount in satoshis to loop in",
+				Usage: "the amount to loop in, " + amtUsage,
 			},
 			cli.BoolFlag{
 				Name:  "external",
 				Usage: "expect htlc to be published externally",
 			},
+			cli.BoolFlag{
+				Name: "psbt",
+				Usage: "return a psbt that funds the htlc from " +
+					"an external wallet",
+			},
 			confTargetFlag,
 			lastHopFlag,
+			privateFlag,
+			routeHintsFlag,
 			labelFlag,
+			idempotencyKeyFlag,
+			allowDuplicateFlag,
 			verboseFlag,
 		},
 		Action: loopIn,
@@ -92,7 +140,8 @@ func loopIn(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	external := ctx.Bool("external")
+	psbtFunding := ctx.Bool("psbt")
+	external := ctx.Bool("external") || psbtFunding
 	htlcConfTarget := int32(ctx.Uint64(confTargetFlag.Name))
 
 	// External and confirmation target are mutually exclusive; either the
@@ -133,6 +182,13 @@ func loopIn(ctx *cli.Context) error {
 			"amount")
 	}
 
+	// If no confirmation target was set, the daemon picks a default based
+	// on the swap amount. We use the target that we were quoted for, so
+	// that our fee limits match it.
+	if !external && htlcConfTarget == 0 {
+		htlcConfTarget = quote.ConfTarget
+	}
+
 	limits := getInLimits(quote)
 	err = displayInDetails(quoteReq, quote, ctx.Bool("verbose"))
 	if err != nil {
@@ -147,6 +203,8 @@ func loopIn(ctx *cli.Context) error {
 		HtlcConfTarget: htlcConfTarget,
 		Label:          label,
 		Initiator:      defaultInitiator,
+		IdempotencyKey: ctx.String(idempotencyKeyFlag.Name),
+		AllowDuplicate: ctx.Bool(allowDuplicateFlag.Name),
 	}
 
 	if ctx.IsSet(lastHopFlag.Name) {
@@ -160,11 +218,31 @@ func loopIn(ctx *cli.Context) error {
 		req.LastHop = lastHop[:]
 	}
 
+	if ctx.IsSet(routeHintsFlag.Name) {
+		err := json.Unmarshal(
+			[]byte(ctx.String(routeHintsFlag.Name)),
+			&req.RouteHints,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid route hints: %v", err)
+		}
+	}
+	req.Private = ctx.Bool(privateFlag.Name)
+
+	if psbtFunding {
+		return loopInPsbt(client, req)
+	}
+
 	resp, err := client.LoopIn(context.Background(), req)
 	if err != nil {
 		return err
 	}
 
+	if jsonOutput {
+		printRespJSON(resp)
+		return nil
+	}
+
 	fmt.Printf("Swap initiated\n")
 	fmt.Printf("ID:           %v\n", resp.Id)
 	if external {
@@ -179,3 +257,35 @@ func loopIn(ctx *cli.Context) error {
 
 	return nil
 }
+
+// loopInPsbt initiates a loop in that is funded by an external wallet, and
+// displays the psbt that funds its htlc.
+func loopInPsbt(client looprpc.SwapClientClient,
+	req *looprpc.LoopInRequest) error {
+
+	resp, err := client.NewLoopInPsbt(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		printRespJSON(resp)
+		return nil
+	}
+
+	fmt.Printf("Swap initiated\n")
+	fmt.Printf("ID:           %x\n", resp.IdBytes)
+	fmt.Printf("HTLC address (P2WSH): %v\n", resp.HtlcAddressP2Wsh)
+	fmt.Printf("HTLC amount: %v\n", btcutil.Amount(resp.Amt))
+	if resp.ServerMessage != "" {
+		fmt.Printf("Server message: %v\n", resp.ServerMessage)
+	}
+	fmt.Println()
+	fmt.Printf("Fund, sign and broadcast the following psbt from your "+
+		"external wallet:\n%v\n",
+		base64.StdEncoding.EncodeToString(resp.Psbt))
+	fmt.Println()
+	fmt.Printf("Run `loop monitor` to monitor progress.\n")
+
+	return nil
+}
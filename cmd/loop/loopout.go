@@ -8,13 +8,46 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/labels"
-	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/looprpc"
 	"github.com/urfave/cli"
 )
 
+var (
+	deadlineFlag = cli.DurationFlag{
+		Name: "deadline",
+		Usage: "the maximum amount of time (e.g. 2h) that the swap " +
+			"server may wait before publishing the swap HTLC " +
+			"on-chain, allowing it to batch HTLCs to save on " +
+			"chain fees. A later deadline might result in a " +
+			"lower swap fee. If not set, a deadline of " +
+			defaultSwapWaitTime.String() + " is used. May not " +
+			"be combined with --fast",
+	}
+
+	priorityFlag = cli.StringFlag{
+		Name: "priority",
+		Usage: "the execution priority of the swap, one of low, " +
+			"normal or urgent. Urgent swaps retry their " +
+			"off-chain payments for longer and sweep more " +
+			"aggressively, while low priority swaps give up " +
+			"sooner and sweep more cheaply",
+		Value: "normal",
+	}
+
+	serverConfTargetFlag = cli.Uint64Flag{
+		Name: "server_conf_target",
+		Usage: "the confirmation target that the swap server is " +
+			"asked to use when publishing the swap HTLC " +
+			"on-chain. A higher target allows the server to " +
+			"publish in a cheaper fee band and might result in " +
+			"a lower swap fee. If not set, no preference is " +
+			"expressed",
+	}
+)
+
 var loopOutCommand = cli.Command{
 	Name:      "out",
 	Usage:     "perform an off-chain to on-chain swap (looping out)",
@@ -26,7 +59,17 @@ var loopOutCommand = cli.Command{
 	The amount is to be specified in satoshis.
 
 	Optionally a BASE58/bech32 encoded bitcoin destination address may be
-	specified. If not specified, a new wallet address will be generated.`,
+	specified. If not specified, a new wallet address will be generated.
+
+	To drain several sets of channels at once, the loop out may instead be
+	split into multiple swaps with the --split flag, which is set once for
+	every swap. In this case the amount, channel and address arguments are
+	not used, and the command follows the progress of all the swaps until
+	they have completed.
+
+	A swap template that was saved with ` + "`loop templates save`" + ` may be
+	dispatched with --template. Any flags or arguments that are set override
+	the template's values.`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name: "channel",
@@ -39,29 +82,37 @@ var loopOutCommand = cli.Command{
 				"should be sent to, if let blank the funds " +
 				"will go to lnd's wallet",
 		},
-		cli.Uint64Flag{
+		cli.BoolFlag{
+			Name: "xpub",
+			Usage: "send the looped out funds to a fresh address " +
+				"derived from the xpub that loopd is " +
+				"configured with",
+		},
+		cli.StringFlag{
 			Name:  "amt",
-			Usage: "the amount in satoshis to loop out",
+			Usage: "the amount to loop out, " + amtUsage,
 		},
 		cli.Uint64Flag{
 			Name: "htlc_confs",
 			Usage: "the number of confirmations (in blocks) " +
 				"that we require for the htlc extended by " +
-				"the server before we reveal the preimage.",
-			Value: uint64(loopdb.DefaultLoopOutHtlcConfirmations),
+				"the server before we reveal the preimage. " +
+				"If not set, loopd's confirmation policy " +
+				"picks a value based on the swap amount",
 		},
 		cli.Uint64Flag{
 			Name: "conf_target",
 			Usage: "the number of blocks from the swap " +
 				"initiation height that the on-chain HTLC " +
-				"should be swept within",
-			Value: uint64(loop.DefaultSweepConfTarget),
+				"should be swept within. If not set, loopd's " +
+				"confirmation policy picks a target based " +
+				"on the swap amount",
 		},
-		cli.Int64Flag{
+		cli.StringFlag{
 			Name: "max_swap_routing_fee",
-			Usage: "the max off-chain swap routing fee in " +
-				"satoshis, if not specified, a default max " +
-				"fee will be used",
+			Usage: "the max off-chain swap routing fee, " +
+				amtUsage + ", if not specified, a default " +
+				"max fee will be used",
 		},
 		cli.BoolFlag{
 			Name: "fast",
@@ -73,52 +124,97 @@ var loopOutCommand = cli.Command{
 				"Not setting this flag therefore might " +
 				"result in a lower swap fee.",
 		},
+		deadlineFlag,
+		serverConfTargetFlag,
+		cli.Uint64Flag{
+			Name: "max_parts",
+			Usage: "the maximum number of parts that the " +
+				"off-chain swap and prepay payments may be " +
+				"split into. If not set, loopd's configured " +
+				"maximum is used",
+		},
+		cli.DurationFlag{
+			Name: "payment_timeout",
+			Usage: "the timeout for the off-chain swap and " +
+				"prepay payments (e.g. 45m), after which the " +
+				"swap fails if they have not succeeded. If " +
+				"not set, loopd's configured timeout is used",
+		},
+		priorityFlag,
+		splitFlag,
+		templateFlag,
 		labelFlag,
+		idempotencyKeyFlag,
+		allowDuplicateFlag,
 		verboseFlag,
 	},
 	Action: loopOut,
 }
 
 func loopOut(ctx *cli.Context) error {
+	if ctx.IsSet(splitFlag.Name) {
+		if ctx.IsSet(templateFlag.Name) {
+			return fmt.Errorf("cannot use a swap template with " +
+				"split swaps")
+		}
+
+		return loopOutSplit(ctx)
+	}
+
+	var template *looprpc.SwapTemplate
+	if ctx.IsSet(templateFlag.Name) {
+		var err error
+		template, err = getSwapTemplate(
+			ctx, ctx.String(templateFlag.Name),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	args := ctx.Args()
 
-	var amtStr string
+	var (
+		amt    btcutil.Amount
+		amtStr string
+	)
 	switch {
 	case ctx.IsSet("amt"):
 		amtStr = ctx.String("amt")
 	case ctx.NArg() > 0:
 		amtStr = args[0]
 		args = args.Tail()
+	case template != nil:
+		amt = btcutil.Amount(template.Amt)
 	default:
 		// Show command help if no arguments and flags were provided.
 		return cli.ShowCommandHelp(ctx, "out")
 	}
 
-	amt, err := parseAmt(amtStr)
-	if err != nil {
-		return err
+	if amtStr != "" {
+		var err error
+		amt, err = parseAmt(amtStr)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Parse outgoing channel set. Don't string split if the flag is empty.
 	// Otherwise strings.Split returns a slice of length one with an empty
 	// element.
-	var outgoingChanSet []uint64
-	if ctx.IsSet("channel") {
-		chanStrings := strings.Split(ctx.String("channel"), ",")
-		for _, chanString := range chanStrings {
-			chanID, err := strconv.ParseUint(chanString, 10, 64)
-			if err != nil {
-				return fmt.Errorf("error parsing channel id "+
-					"\"%v\"", chanString)
-			}
-			outgoingChanSet = append(outgoingChanSet, chanID)
+	var (
+		outgoingChanSet []uint64
+		err             error
+	)
+	switch {
+	case ctx.IsSet("channel"):
+		outgoingChanSet, err = parseChanSet(ctx.String("channel"))
+		if err != nil {
+			return err
 		}
-	}
 
-	// Validate our label early so that we can fail before getting a quote.
-	label := ctx.String(labelFlag.Name)
-	if err := labels.Validate(label); err != nil {
-		return err
+	case template != nil:
+		outgoingChanSet = template.OutgoingChanSet
 	}
 
 	var destAddr string
@@ -129,51 +225,64 @@ func loopOut(ctx *cli.Context) error {
 		destAddr = args.First()
 	}
 
-	client, cleanup, err := getClient(ctx)
+	destFromXpub := ctx.Bool("xpub")
+	if destFromXpub && destAddr != "" {
+		return fmt.Errorf("cannot set both a destination address " +
+			"and xpub")
+	}
+
+	if destAddr != "" {
+		if err := validateDestAddr(ctx, destAddr); err != nil {
+			return err
+		}
+	}
+
+	params, err := parseLoopOutParams(ctx)
 	if err != nil {
 		return err
 	}
-	defer cleanup()
 
-	// Set our maximum swap wait time. If a fast swap is requested we set
-	// it to now, otherwise to 30 minutes in the future.
-	fast := ctx.Bool("fast")
-	swapDeadline := time.Now()
-	if !fast {
-		swapDeadline = time.Now().Add(defaultSwapWaitTime)
+	if template != nil {
+		applySwapTemplate(ctx, template, params)
 	}
 
-	sweepConfTarget := int32(ctx.Uint64("conf_target"))
-	htlcConfs := int32(ctx.Uint64("htlc_confs"))
-	if htlcConfs == 0 {
-		return fmt.Errorf("at least 1 confirmation required for htlcs")
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
 	}
+	defer cleanup()
+
+	swapDeadline := time.Now().Add(params.swapWait)
+	sweepConfTarget := params.sweepConfTarget
+	serverConfTarget := params.serverConfTarget
 
 	quoteReq := &looprpc.QuoteRequest{
 		Amt:                     int64(amt),
 		ConfTarget:              sweepConfTarget,
 		SwapPublicationDeadline: uint64(swapDeadline.Unix()),
+		ServerConfTarget:        serverConfTarget,
 	}
 	quote, err := client.LoopOutQuote(context.Background(), quoteReq)
 	if err != nil {
 		return err
 	}
 
-	// Show a warning if a slow swap was requested.
-	warning := ""
-	if fast {
-		warning = "Fast swap requested."
-	} else {
-		warning = fmt.Sprintf("Regular swap speed requested, it "+
-			"might take up to %v for the swap to be executed.",
-			defaultSwapWaitTime)
-	}
+	warning := swapSpeedWarning(params.swapWait)
 
 	limits := getOutLimits(amt, quote)
 	// If configured, use the specified maximum swap routing fee.
-	if ctx.IsSet("max_swap_routing_fee") {
+	switch {
+	case ctx.IsSet("max_swap_routing_fee"):
+		limits.maxSwapRoutingFee, err = parseAmt(
+			ctx.String("max_swap_routing_fee"),
+		)
+		if err != nil {
+			return err
+		}
+
+	case template != nil && template.MaxSwapRoutingFee != 0:
 		limits.maxSwapRoutingFee = btcutil.Amount(
-			ctx.Int64("max_swap_routing_fee"),
+			template.MaxSwapRoutingFee,
 		)
 	}
 	err = displayOutDetails(
@@ -193,15 +302,28 @@ func loopOut(ctx *cli.Context) error {
 		MaxSwapRoutingFee:       int64(limits.maxSwapRoutingFee),
 		OutgoingChanSet:         outgoingChanSet,
 		SweepConfTarget:         sweepConfTarget,
-		HtlcConfirmations:       htlcConfs,
+		HtlcConfirmations:       params.htlcConfs,
 		SwapPublicationDeadline: uint64(swapDeadline.Unix()),
-		Label:                   label,
+		Label:                   params.label,
 		Initiator:               defaultInitiator,
+		IdempotencyKey:          ctx.String(idempotencyKeyFlag.Name),
+		AllowDuplicate:          ctx.Bool(allowDuplicateFlag.Name),
+		DestFromXpub:            destFromXpub,
+		PaymentTimeout:          uint32(params.paymentTimeout.Seconds()),
+		ServerConfTarget:        serverConfTarget,
+		SwapPaymentDest:         quote.SwapPaymentDest,
+		MaxParts:                uint32(ctx.Uint64("max_parts")),
+		Priority:                params.priority,
 	})
 	if err != nil {
 		return err
 	}
 
+	if jsonOutput {
+		printRespJSON(resp)
+		return nil
+	}
+
 	fmt.Printf("Swap initiated\n")
 	fmt.Printf("ID:             %x\n", resp.IdBytes)
 	fmt.Printf("HTLC address:   %v\n", resp.HtlcAddress) // nolint:staticcheck
@@ -213,3 +335,172 @@ func loopOut(ctx *cli.Context) error {
 
 	return nil
 }
+
+// parseChanSet parses a comma-separated list of short channel IDs.
+func parseChanSet(chanList string) ([]uint64, error) {
+	var chanSet []uint64
+	for _, chanString := range strings.Split(chanList, ",") {
+		chanID, err := strconv.ParseUint(chanString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing channel id "+
+				"\"%v\"", chanString)
+		}
+		chanSet = append(chanSet, chanID)
+	}
+
+	return chanSet, nil
+}
+
+// loopOutParams holds the parameters of the loop out command that are shared
+// by all of the swaps that it creates.
+type loopOutParams struct {
+	swapWait         time.Duration
+	sweepConfTarget  int32
+	htlcConfs        int32
+	paymentTimeout   time.Duration
+	serverConfTarget int32
+	label            string
+	priority         looprpc.SwapPriority
+}
+
+// parseLoopOutParams parses and validates the loop out command's parameters
+// that are shared by all of the swaps that it creates.
+func parseLoopOutParams(ctx *cli.Context) (*loopOutParams, error) {
+	// Validate our label early so that we can fail before getting a quote.
+	label := ctx.String(labelFlag.Name)
+	if err := labels.Validate(label); err != nil {
+		return nil, err
+	}
+
+	swapWait, err := getSwapWaitTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	htlcConfs := int32(ctx.Uint64("htlc_confs"))
+	if ctx.IsSet("htlc_confs") && htlcConfs == 0 {
+		return nil, fmt.Errorf("at least 1 confirmation required for " +
+			"htlcs")
+	}
+
+	paymentTimeout := ctx.Duration("payment_timeout")
+	if ctx.IsSet("payment_timeout") && paymentTimeout < time.Second {
+		return nil, fmt.Errorf("payment timeout must be at least one " +
+			"second")
+	}
+
+	priority, err := parsePriority(ctx.String(priorityFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &loopOutParams{
+		swapWait:         swapWait,
+		sweepConfTarget:  int32(ctx.Uint64("conf_target")),
+		htlcConfs:        htlcConfs,
+		paymentTimeout:   paymentTimeout,
+		serverConfTarget: int32(ctx.Uint64(serverConfTargetFlag.Name)),
+		label:            label,
+		priority:         priority,
+	}, nil
+}
+
+// applySwapTemplate fills in the loop out parameters that a swap template
+// provides, unless they were explicitly set on the command line.
+func applySwapTemplate(ctx *cli.Context, template *looprpc.SwapTemplate,
+	params *loopOutParams) {
+
+	if !ctx.IsSet("conf_target") && template.SweepConfTarget != 0 {
+		params.sweepConfTarget = template.SweepConfTarget
+	}
+
+	if !ctx.IsSet("htlc_confs") && template.HtlcConfirmations != 0 {
+		params.htlcConfs = template.HtlcConfirmations
+	}
+
+	if !ctx.IsSet(labelFlag.Name) {
+		params.label = template.Label
+	}
+}
+
+// validateDestAddr checks that a destination address is of a supported type
+// and belongs to the network that we are configured for, so that we can refuse
+// it before contacting the server.
+func validateDestAddr(ctx *cli.Context, addr string) error {
+	network := strings.ToLower(ctx.GlobalString("network"))
+	params, err := lndclient.Network(network).ChainParams()
+	if err != nil {
+		return err
+	}
+
+	destAddr, err := btcutil.DecodeAddress(addr, params)
+	if err != nil {
+		return fmt.Errorf("invalid destination address %v: %v", addr,
+			err)
+	}
+
+	if err := loop.ValidateDestAddr(destAddr, params); err != nil {
+		return fmt.Errorf("%v (use --network to set the network that "+
+			"loopd is running on)", err)
+	}
+
+	return nil
+}
+
+// parsePriority parses a swap priority from its name.
+func parsePriority(priority string) (looprpc.SwapPriority, error) {
+	switch priority {
+	case "low":
+		return looprpc.SwapPriority_PRIORITY_LOW, nil
+
+	case "normal":
+		return looprpc.SwapPriority_PRIORITY_NORMAL, nil
+
+	case "urgent":
+		return looprpc.SwapPriority_PRIORITY_URGENT, nil
+
+	default:
+		return 0, fmt.Errorf("unknown priority: %v, expected low, "+
+			"normal or urgent", priority)
+	}
+}
+
+// swapSpeedWarning returns the warning that is shown for the amount of time
+// that the server may wait before publishing the htlc of a loop out.
+func swapSpeedWarning(swapWait time.Duration) string {
+	if swapWait == 0 {
+		return "Fast swap requested."
+	}
+
+	return fmt.Sprintf("Regular swap speed requested, it might take up "+
+		"to %v for the swap to be executed.", swapWait)
+}
+
+// getSwapWaitTime returns the amount of time that the swap server may wait
+// before publishing the htlc of a loop out. If a fast swap is requested, we do
+// not allow the server to wait at all.
+func getSwapWaitTime(ctx *cli.Context) (time.Duration, error) {
+	fast := ctx.Bool("fast")
+	deadlineSet := ctx.IsSet(deadlineFlag.Name)
+
+	switch {
+	case fast && deadlineSet:
+		return 0, fmt.Errorf("fast and deadline flags are mutually " +
+			"exclusive")
+
+	case fast:
+		return 0, nil
+
+	case deadlineSet:
+		wait := ctx.Duration(deadlineFlag.Name)
+		if wait <= 0 {
+			return 0, fmt.Errorf("deadline must be positive, use " +
+				"--fast to swap immediately")
+		}
+
+		return wait, nil
+
+	default:
+		return defaultSwapWaitTime, nil
+	}
+}
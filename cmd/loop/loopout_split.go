@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/urfave/cli"
+)
+
+var splitFlag = cli.StringSliceFlag{
+	Name: "split",
+	Usage: "a swap that the loop out is split into, in the format " +
+		"amt:chan_id[,chan_id...][:addr]. The amount is " +
+		"specified in satoshis, and the channels are the short " +
+		"channel IDs that the swap drains. If no address is " +
+		"given, the funds go to lnd's wallet, or to an xpub " +
+		"address if --xpub is set. May be set multiple times, " +
+		"and may not be combined with the amount, --channel or " +
+		"--addr",
+}
+
+// parseSplit parses a split in the format amt:chan_id[,chan_id...][:addr].
+func parseSplit(split string) (*looprpc.LoopOutSplit, error) {
+	parts := strings.SplitN(split, ":", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("split \"%v\" must have the format "+
+			"amt:chan_id[,chan_id...][:addr]", split)
+	}
+
+	amt, err := parseAmt(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	chanSet, err := parseChanSet(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	loopOutSplit := &looprpc.LoopOutSplit{
+		Amt:             int64(amt),
+		OutgoingChanSet: chanSet,
+	}
+
+	if len(parts) == 3 {
+		loopOutSplit.Dest = parts[2]
+	}
+
+	return loopOutSplit, nil
+}
+
+// loopOutSplit performs a loop out that is split into multiple swaps, and
+// follows the progress of the swaps until they have all completed.
+func loopOutSplit(ctx *cli.Context) error {
+	if ctx.NArg() > 0 || ctx.IsSet("amt") || ctx.IsSet("channel") ||
+		ctx.IsSet("addr") {
+
+		return errors.New("split may not be combined with an amount, " +
+			"channel or address")
+	}
+
+	var splits []*looprpc.LoopOutSplit
+	for _, splitStr := range ctx.StringSlice(splitFlag.Name) {
+		split, err := parseSplit(splitStr)
+		if err != nil {
+			return err
+		}
+
+		// Splits without an address use our xpub if requested.
+		split.DestFromXpub = split.Dest == "" && ctx.Bool("xpub")
+
+		if split.Dest != "" {
+			if err := validateDestAddr(ctx, split.Dest); err != nil {
+				return err
+			}
+		}
+
+		splits = append(splits, split)
+	}
+
+	params, err := parseLoopOutParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	swapDeadline := time.Now().Add(params.swapWait)
+
+	// The fee limits of the request apply to each swap individually, so
+	// we quote every split and use the highest limits of all quotes.
+	var (
+		limits   = &outLimits{}
+		totalAmt btcutil.Amount
+		w        = infoOutput()
+	)
+	for i, split := range splits {
+		amt := btcutil.Amount(split.Amt)
+		totalAmt += amt
+
+		quoteReq := &looprpc.QuoteRequest{
+			Amt:                     split.Amt,
+			ConfTarget:              params.sweepConfTarget,
+			SwapPublicationDeadline: uint64(swapDeadline.Unix()),
+			ServerConfTarget:        params.serverConfTarget,
+		}
+		quote, err := client.LoopOutQuote(
+			context.Background(), quoteReq,
+		)
+		if err != nil {
+			return fmt.Errorf("split %v: %v", i, err)
+		}
+
+		fmt.Fprintf(w, "Split %v: %v over channels %v\n", i, amt,
+			split.OutgoingChanSet)
+		printQuoteOutResp(w, quoteReq, quote, ctx.Bool("verbose"))
+		fmt.Fprintln(w)
+
+		maxLimits(limits, getOutLimits(amt, quote))
+	}
+
+	if ctx.IsSet("max_swap_routing_fee") {
+		limits.maxSwapRoutingFee, err = parseAmt(
+			ctx.String("max_swap_routing_fee"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "Total amount of %v swaps: %v\n", len(splits),
+		totalAmt)
+	if ctx.Bool("verbose") {
+		fmt.Fprintln(w, "Fee limits per swap:")
+		fmt.Fprintf(w, satAmtFmt, tr("Max on-chain fee:"),
+			limits.maxMinerFee)
+		fmt.Fprintf(w, satAmtFmt,
+			tr("Max off-chain swap routing fee:"),
+			limits.maxSwapRoutingFee)
+		fmt.Fprintf(w, satAmtFmt,
+			tr("Max off-chain prepay routing fee:"),
+			limits.maxPrepayRoutingFee)
+	}
+	fmt.Fprintf(w, "\n%s\n\n", swapSpeedWarning(params.swapWait))
+	fmt.Fprint(w, tr("CONTINUE SWAPS? (y/n): "))
+
+	var answer string
+	fmt.Scanln(&answer)
+	if answer != "y" {
+		return errors.New("swaps canceled")
+	}
+
+	resp, err := client.LoopOut(context.Background(), &looprpc.LoopOutRequest{
+		MaxMinerFee:             int64(limits.maxMinerFee),
+		MaxPrepayAmt:            int64(limits.maxPrepayAmt),
+		MaxSwapFee:              int64(limits.maxSwapFee),
+		MaxPrepayRoutingFee:     int64(limits.maxPrepayRoutingFee),
+		MaxSwapRoutingFee:       int64(limits.maxSwapRoutingFee),
+		SweepConfTarget:         params.sweepConfTarget,
+		HtlcConfirmations:       params.htlcConfs,
+		SwapPublicationDeadline: uint64(swapDeadline.Unix()),
+		Label:                   params.label,
+		Initiator:               defaultInitiator,
+		IdempotencyKey:          ctx.String(idempotencyKeyFlag.Name),
+		PaymentTimeout:          uint32(params.paymentTimeout.Seconds()),
+		ServerConfTarget:        params.serverConfTarget,
+		MaxParts:                uint32(ctx.Uint64("max_parts")),
+		Priority:                params.priority,
+		Splits:                  splits,
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		printStreamJSON(resp)
+	}
+
+	swaps := make(map[lntypes.Hash]int, len(resp.SplitSwaps))
+	for i, swap := range resp.SplitSwaps {
+		hash, err := lntypes.MakeHash(swap.IdBytes)
+		if err != nil {
+			return err
+		}
+		swaps[hash] = i
+
+		if jsonOutput {
+			continue
+		}
+
+		fmt.Printf("Swap %v initiated\n", i)
+		fmt.Printf("ID:             %x\n", swap.IdBytes)
+		fmt.Printf("HTLC address:   %v\n", swap.HtlcAddressP2Wsh)
+		if swap.ServerMessage != "" {
+			fmt.Printf("Server message: %v\n", swap.ServerMessage)
+		}
+		fmt.Println()
+	}
+
+	return monitorSplits(client, swaps)
+}
+
+// maxLimits raises each of the limits provided to the corresponding limit in
+// other if it is higher.
+func maxLimits(limits, other *outLimits) {
+	maxAmt := func(a, b btcutil.Amount) btcutil.Amount {
+		if a > b {
+			return a
+		}
+
+		return b
+	}
+
+	limits.maxSwapRoutingFee = maxAmt(
+		limits.maxSwapRoutingFee, other.maxSwapRoutingFee,
+	)
+	limits.maxPrepayRoutingFee = maxAmt(
+		limits.maxPrepayRoutingFee, other.maxPrepayRoutingFee,
+	)
+	limits.maxMinerFee = maxAmt(limits.maxMinerFee, other.maxMinerFee)
+	limits.maxSwapFee = maxAmt(limits.maxSwapFee, other.maxSwapFee)
+	limits.maxPrepayAmt = maxAmt(limits.maxPrepayAmt, other.maxPrepayAmt)
+}
+
+// monitorSplits follows the progress of the swaps that a loop out was split
+// into, printing every update along with the combined progress of all swaps,
+// until all of the swaps have completed.
+func monitorSplits(client looprpc.SwapClientClient,
+	swaps map[lntypes.Hash]int) error {
+
+	stream, err := client.Monitor(
+		context.Background(), &looprpc.MonitorRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// When json output is requested, swap updates are printed as json and
+	// our progress is written to stderr.
+	w := infoOutput()
+
+	fmt.Fprintf(w, "Following progress of %v swaps, press ctrl-c to "+
+		"stop. Swaps continue to run in the background.\n", len(swaps))
+
+	var (
+		succeeded = make(map[lntypes.Hash]bool)
+		failed    = make(map[lntypes.Hash]bool)
+	)
+	for len(succeeded)+len(failed) < len(swaps) {
+		swap, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("recv: %v", err)
+		}
+
+		hash, err := lntypes.MakeHash(swap.IdBytes)
+		if err != nil {
+			return err
+		}
+
+		index, ok := swaps[hash]
+		if !ok {
+			continue
+		}
+
+		switch swap.State {
+		case looprpc.SwapState_SUCCESS:
+			succeeded[hash] = true
+
+		case looprpc.SwapState_FAILED:
+			failed[hash] = true
+		}
+
+		if !jsonOutput {
+			fmt.Printf("[swap %v] ", index)
+		}
+		logSwap(swap)
+		fmt.Fprintf(w, "Progress: %v succeeded, %v failed, %v "+
+			"pending\n", len(succeeded), len(failed),
+			len(swaps)-len(succeeded)-len(failed))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%v of %v swaps failed", len(failed),
+			len(swaps))
+	}
+
+	fmt.Fprintf(w, "All %v swaps succeeded\n", len(swaps))
+
+	return nil
+}
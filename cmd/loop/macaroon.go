@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var (
+	// readonlyPermissions are the permissions of a macaroon that can view,
+	// but not change, loopd's state.
+	readonlyPermissions = []string{
+		"swap:read", "terms:read", "auth:read", "suggestions:read",
+	}
+
+	// autoloopPermissions are the permissions of a macaroon that can
+	// manage autoloop, but not dispatch manual swaps.
+	autoloopPermissions = []string{
+		"swap:read", "terms:read", "suggestions:read",
+		"suggestions:write",
+	}
+)
+
+var bakeMacaroonCommand = cli.Command{
+	Name:      "bakemacaroon",
+	Usage:     "bake a macaroon with a restricted set of permissions",
+	ArgsUsage: "[entity:action ...]",
+	Description: `
+	Bakes a new macaroon that only grants the permissions provided, each
+	formatted as entity:action (for example swap:read). Instead of listing
+	permissions, the --readonly or --autoloop presets can be used to create
+	a macaroon that can only view loopd's state or only manage autoloop.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "readonly",
+			Usage: "bake a macaroon with only read permissions",
+		},
+		cli.BoolFlag{
+			Name: "autoloop",
+			Usage: "bake a macaroon that can only manage " +
+				"autoloop",
+		},
+		cli.StringFlag{
+			Name: "save_to",
+			Usage: "save the macaroon to this file rather than " +
+				"printing it as hex",
+		},
+	},
+	Action: bakeMacaroon,
+}
+
+func bakeMacaroon(ctx *cli.Context) error {
+	var permissions []string
+
+	switch {
+	case ctx.Bool("readonly") && ctx.Bool("autoloop"):
+		return errors.New("readonly and autoloop cannot both be set")
+
+	case ctx.Bool("readonly"):
+		permissions = readonlyPermissions
+
+	case ctx.Bool("autoloop"):
+		permissions = autoloopPermissions
+	}
+
+	permissions = append(permissions, ctx.Args()...)
+	if len(permissions) == 0 {
+		return cli.ShowCommandHelp(ctx, "bakemacaroon")
+	}
+
+	req := &looprpc.BakeMacaroonRequest{}
+	for _, perm := range permissions {
+		parts := strings.Split(perm, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid permission: %v, expected "+
+				"entity:action", perm)
+		}
+
+		req.Permissions = append(
+			req.Permissions, &looprpc.MacaroonPermission{
+				Entity: parts[0],
+				Action: parts[1],
+			},
+		)
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.BakeMacaroon(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet("save_to") {
+		fmt.Println(resp.Macaroon)
+		return nil
+	}
+
+	macBytes, err := hex.DecodeString(resp.Macaroon)
+	if err != nil {
+		return err
+	}
+
+	path := ctx.String("save_to")
+	if err := ioutil.WriteFile(path, macBytes, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Macaroon saved to %v\n", path)
+
+	return nil
+}
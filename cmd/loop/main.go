@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +27,7 @@ import (
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"gopkg.in/macaroon.v2"
 )
 
@@ -44,6 +45,16 @@ var (
 	// set this to 200MiB atm.
 	maxMsgRecvSize = grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200)
 
+	// keepaliveParams are the keepalive settings for our connection to
+	// loopd, which prevent idle long running streams such as monitor from
+	// being dropped. The ping interval must be above the minimum client
+	// ping interval that loopd is configured with.
+	keepaliveParams = keepalive.ClientParameters{
+		Time:                30 * time.Second,
+		Timeout:             20 * time.Second,
+		PermitWithoutStream: true,
+	}
+
 	// defaultMacaroonTimeout is the default macaroon timeout in seconds
 	// that we set when sending it over the line.
 	defaultMacaroonTimeout int64 = 60
@@ -78,6 +89,16 @@ var (
 		Name:  "verbose, v",
 		Usage: "show expanded details",
 	}
+	jsonFlag = cli.BoolFlag{
+		Name: "json",
+		Usage: "print machine-readable json rather than human " +
+			"readable text. Interactive prompts are written to " +
+			"stderr",
+	}
+
+	// jsonOutput is set when the json flag is provided, indicating that
+	// commands should print their responses as json.
+	jsonOutput bool
 )
 
 const (
@@ -98,26 +119,44 @@ const (
 )
 
 func printJSON(resp interface{}) {
+	if err := writeJSON(os.Stdout, resp); err != nil {
+		fatal(err)
+	}
+}
+
+// writeJSON writes an indented json encoding of the value provided to the
+// writer provided.
+func writeJSON(w io.Writer, resp interface{}) error {
 	b, err := json.Marshal(resp)
 	if err != nil {
-		fatal(err)
+		return err
 	}
 
 	var out bytes.Buffer
 	err = json.Indent(&out, b, "", "\t")
 	if err != nil {
-		fatal(err)
+		return err
 	}
 	out.WriteString("\n")
-	_, _ = out.WriteTo(os.Stdout)
+	_, err = out.WriteTo(w)
+
+	return err
 }
 
-func printRespJSON(resp proto.Message) {
-	jsonMarshaler := &jsonpb.Marshaler{
+// newJSONMarshaler returns the marshaler that we use to print rpc responses as
+// json. Fields are named as they are in our proto definitions, fields that
+// have their default value are included and enums are printed as their names,
+// so that scripts can rely on the format of our output.
+func newJSONMarshaler(indent string) *jsonpb.Marshaler {
+	return &jsonpb.Marshaler{
 		OrigName:     true,
 		EmitDefaults: true,
-		Indent:       "    ",
+		Indent:       indent,
 	}
+}
+
+func printRespJSON(resp proto.Message) {
+	jsonMarshaler := newJSONMarshaler("    ")
 
 	jsonStr, err := jsonMarshaler.MarshalToString(resp)
 	if err != nil {
@@ -125,9 +164,41 @@ func printRespJSON(resp proto.Message) {
 		return
 	}
 
+	// When json output is requested, we only print the fields of the
+	// response so that scripts can rely on a stable format.
+	if jsonOutput {
+		fmt.Println(jsonStr)
+		return
+	}
+
+	fmt.Println(addBtcAmounts(jsonStr))
+}
+
+// printStreamJSON prints a streamed response as json on a single line, so that
+// a stream of responses can be parsed as newline delimited json.
+func printStreamJSON(resp proto.Message) {
+	jsonMarshaler := newJSONMarshaler("")
+
+	jsonStr, err := jsonMarshaler.MarshalToString(resp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to decode response: ", err)
+		return
+	}
+
 	fmt.Println(jsonStr)
 }
 
+// infoOutput returns the writer for human readable text that accompanies
+// interactive prompts or json responses. When json output is requested, this
+// text is written to stderr so that stdout only contains json.
+func infoOutput() io.Writer {
+	if jsonOutput {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
 func fatal(err error) {
 	fmt.Fprintf(os.Stderr, "[loop] %v\n", err)
 	os.Exit(1)
@@ -149,12 +220,29 @@ func main() {
 		loopDirFlag,
 		tlsCertFlag,
 		macaroonPathFlag,
+		jsonFlag,
+		langFlag,
+	}
+	app.Before = func(ctx *cli.Context) error {
+		jsonOutput = ctx.GlobalBool(jsonFlag.Name)
+		return setLang(ctx.GlobalString(langFlag.Name))
 	}
 	app.Commands = []cli.Command{
 		loopOutCommand, loopInCommand, termsCommand,
 		monitorCommand, quoteCommand, listAuthCommand,
 		listSwapsCommand, swapInfoCommand, getLiquidityParamsCommand,
-		setLiquidityRuleCommand, suggestSwapCommand, setParamsCommand,
+		setLiquidityRuleCommand, suggestSwapCommand, autoStatusCommand,
+		autoTriggerCommand, budgetReportCommand,
+		setParamsCommand, cancelParamsCommand, reputationCommand,
+		staleRulesCommand, statsCommand,
+		abandonSwapCommand,
+		recoverCommand, pruneSwapsCommand, dbCommand,
+		getConfigCommand, serverHealthCommand, quoteHistoryCommand,
+		liquidityHistoryCommand, apiVersionCommand, auditCommand,
+		bakeMacaroonCommand,
+		debugLevelCommand, costsCommand, compareRebalanceCommand,
+		disqualifyCommand, supportBundleCommand, staticAddressCommand,
+		loadTestCommand, templatesCommand, testNotificationCommand,
 	}
 
 	err := app.Run(os.Args)
@@ -270,16 +358,18 @@ func getOutLimits(amt btcutil.Amount,
 func displayInDetails(req *looprpc.QuoteRequest,
 	resp *looprpc.InQuoteResponse, verbose bool) error {
 
+	w := infoOutput()
+
 	if req.ExternalHtlc {
-		fmt.Printf("On-chain fee for external loop in is not " +
-			"included.\nSufficient fees will need to be paid " +
-			"when constructing the transaction in the external " +
+		fmt.Fprintf(w, "On-chain fee for external loop in is not "+
+			"included.\nSufficient fees will need to be paid "+
+			"when constructing the transaction in the external "+
 			"wallet.\n\n")
 	}
 
-	printQuoteInResp(req, resp, verbose)
+	printQuoteInResp(w, req, resp, verbose)
 
-	fmt.Printf("\nCONTINUE SWAP? (y/n): ")
+	fmt.Fprintf(w, "\n%s", tr("CONTINUE SWAP? (y/n): "))
 
 	var answer string
 	fmt.Scanln(&answer)
@@ -293,25 +383,31 @@ func displayInDetails(req *looprpc.QuoteRequest,
 func displayOutDetails(l *outLimits, warning string, req *looprpc.QuoteRequest,
 	resp *looprpc.OutQuoteResponse, verbose bool) error {
 
-	printQuoteOutResp(req, resp, verbose)
+	w := infoOutput()
+
+	printQuoteOutResp(w, req, resp, verbose)
 
 	// Display fee limits.
 	if verbose {
-		fmt.Println()
-		fmt.Printf(satAmtFmt, "Max on-chain fee:", l.maxMinerFee)
-		fmt.Printf(satAmtFmt,
-			"Max off-chain swap routing fee:", l.maxSwapRoutingFee,
+		fmt.Fprintln(w)
+		fmt.Fprintf(
+			w, satAmtFmt, tr("Max on-chain fee:"), l.maxMinerFee,
+		)
+		fmt.Fprintf(w, satAmtFmt,
+			tr("Max off-chain swap routing fee:"),
+			l.maxSwapRoutingFee,
 		)
-		fmt.Printf(satAmtFmt, "Max off-chain prepay routing fee:",
+		fmt.Fprintf(w, satAmtFmt,
+			tr("Max off-chain prepay routing fee:"),
 			l.maxPrepayRoutingFee)
 	}
 
 	// show warning
 	if warning != "" {
-		fmt.Printf("\n%s\n\n", warning)
+		fmt.Fprintf(w, "\n%s\n\n", warning)
 	}
 
-	fmt.Printf("CONTINUE SWAP? (y/n): ")
+	fmt.Fprint(w, tr("CONTINUE SWAP? (y/n): "))
 
 	var answer string
 	fmt.Scanln(&answer)
@@ -322,31 +418,48 @@ func displayOutDetails(l *outLimits, warning string, req *looprpc.QuoteRequest,
 	return errors.New("swap canceled")
 }
 
-func parseAmt(text string) (btcutil.Amount, error) {
-	amtInt64, err := strconv.ParseInt(text, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid amt value")
+func logSwap(swap *looprpc.SwapStatus) {
+	if jsonOutput {
+		printStreamJSON(swap)
+		return
 	}
-	return btcutil.Amount(amtInt64), nil
-}
 
-func logSwap(swap *looprpc.SwapStatus) {
 	// If our swap failed, we add our failure reason to the state.
-	swapState := fmt.Sprintf("%v", swap.State)
+	swapState := tr(swap.State.String())
 	if swap.State == looprpc.SwapState_FAILED {
-		swapState = fmt.Sprintf("%v (%v)", swapState, swap.FailureReason)
+		swapState = fmt.Sprintf(
+			"%v (%v)", swapState, tr(swap.FailureReason.String()),
+		)
+
+		// If the client recorded a more specific reason for failing
+		// the swap, we include it.
+		reason := swap.SwapFailureReason
+		if reason != looprpc.SwapFailureReason_SWAP_FAILURE_REASON_NONE {
+			swapState = fmt.Sprintf(
+				"%v (%v)", swapState, tr(reason.String()),
+			)
+		}
+
+		// If the server reported why the swap failed, we include
+		// its reason as well.
+		serverReason := swap.ServerFailureReason
+		if serverReason != looprpc.ServerFailureReason_SERVER_FAILURE_REASON_NONE {
+			swapState = fmt.Sprintf("%v (%v)", swapState,
+				trf("server: %v", tr(serverReason.String())))
+		}
 	}
 
 	if swap.Type == looprpc.SwapType_LOOP_OUT {
 		fmt.Printf("%v %v %v %v - %v",
 			time.Unix(0, swap.LastUpdateTime).Format(time.RFC3339),
-			swap.Type, swapState, btcutil.Amount(swap.Amt),
-			swap.HtlcAddressP2Wsh,
+			tr(swap.Type.String()), swapState,
+			btcutil.Amount(swap.Amt), swap.HtlcAddressP2Wsh,
 		)
 	} else {
 		fmt.Printf("%v %v %v %v -",
 			time.Unix(0, swap.LastUpdateTime).Format(time.RFC3339),
-			swap.Type, swapState, btcutil.Amount(swap.Amt))
+			tr(swap.Type.String()), swapState,
+			btcutil.Amount(swap.Amt))
 		if swap.HtlcAddressP2Wsh != "" {
 			fmt.Printf(" P2WSH: %v", swap.HtlcAddressP2Wsh)
 		}
@@ -356,13 +469,45 @@ func logSwap(swap *looprpc.SwapStatus) {
 		}
 	}
 
+	if swap.BlocksUntilExpiry != 0 {
+		fmt.Printf(" (%v)", trf(
+			"expiry in %v blocks, no return in %v blocks",
+			swap.BlocksUntilExpiry, swap.BlocksUntilNoReturn,
+		))
+	}
+
 	if swap.State != looprpc.SwapState_INITIATED &&
 		swap.State != looprpc.SwapState_HTLC_PUBLISHED &&
 		swap.State != looprpc.SwapState_PREIMAGE_REVEALED {
 
-		fmt.Printf(" (cost: server %v, onchain %v, offchain %v)",
+		fmt.Printf(" (%v)", trf(
+			"cost: server %v, onchain %v, offchain %v",
 			swap.CostServer, swap.CostOnchain, swap.CostOffchain,
-		)
+		))
+	}
+
+	if swap.Cancellable {
+		fmt.Printf(" (%v)", tr("cancellable"))
+	}
+
+	// Let the user know that their funds are on the way back if their
+	// htlc is being refunded.
+	if refund := swap.Refund; refund != nil {
+		if refund.Confirmed {
+			fmt.Printf(" (%v)", trf(
+				"refunded in %v, fee %v", refund.Txid,
+				btcutil.Amount(refund.FeeSat),
+			))
+		} else {
+			fmt.Printf(" (%v)", trf(
+				"refund pending in %v, fee %v", refund.Txid,
+				btcutil.Amount(refund.FeeSat),
+			))
+		}
+	}
+
+	if swap.Replay {
+		fmt.Printf(" (%v)", tr("replayed"))
 	}
 
 	fmt.Println()
@@ -379,6 +524,7 @@ func getClientConn(address, tlsCertPath, macaroonPath string) (*grpc.ClientConn,
 
 	opts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(maxMsgRecvSize),
+		grpc.WithKeepaliveParams(keepaliveParams),
 		macOption,
 	}
 
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/lightninglabs/loop/looprpc"
 	"github.com/urfave/cli"
@@ -12,7 +13,33 @@ var monitorCommand = cli.Command{
 	Name:        "monitor",
 	Usage:       "monitor progress of any active swaps",
 	Description: "Allows the user to monitor progress of any active swaps",
-	Action:      monitor,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "start_sequence",
+			Usage: "the sequence number of the last update that " +
+				"was received, used to resume monitoring " +
+				"without missing any updates",
+		},
+		cli.Uint64Flag{
+			Name: "start_epoch",
+			Usage: "the epoch of the last update that was " +
+				"received, required for start_sequence to " +
+				"be resumed",
+		},
+		cli.BoolFlag{
+			Name: "legacy",
+			Usage: "receive all pending and recently completed " +
+				"swaps on startup, rather than a snapshot " +
+				"of pending swaps",
+		},
+		cli.DurationFlag{
+			Name: "since",
+			Usage: "replay the swaps that completed within this " +
+				"amount of time (e.g. 24h) from the database " +
+				"before switching to live updates",
+		},
+	},
+	Action: monitor,
 }
 
 func monitor(ctx *cli.Context) error {
@@ -22,14 +49,27 @@ func monitor(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	stream, err := client.Monitor(
-		context.Background(), &looprpc.MonitorRequest{})
+	req := &looprpc.MonitorRequest{
+		StartSequence: ctx.Uint64("start_sequence"),
+		StartEpoch:    ctx.Uint64("start_epoch"),
+		LegacyUpdates: ctx.Bool("legacy"),
+	}
+
+	if ctx.IsSet("since") {
+		req.ReplaySinceNs = time.Now().Add(
+			ctx.Duration("since") * -1,
+		).UnixNano()
+	}
+
+	stream, err := client.Monitor(context.Background(), req)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Note: offchain cost may report as 0 after loopd restart " +
-		"during swap\n")
+	// When json output is requested, our note is written to stderr so
+	// that every line printed to stdout is a json swap update.
+	fmt.Fprintf(infoOutput(), "Note: offchain cost may report as 0 "+
+		"after loopd restart during swap\n")
 
 	for {
 		swap, err := stream.Recv()
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var testNotificationCommand = cli.Command{
+	Name:  "testnotification",
+	Usage: "send a test event to the configured notification sinks",
+	Description: "Sends a test event to each of the notification sinks " +
+		"configured for loopd and shows whether it was delivered " +
+		"to each sink. The rate limit of the email, Telegram and " +
+		"Matrix sinks does not apply to test events.",
+	Action: testNotification,
+}
+
+func testNotification(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.TestNotification(
+		context.Background(), &looprpc.TestNotificationRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -18,12 +19,23 @@ var quoteCommand = cli.Command{
 }
 
 var quoteInCommand = cli.Command{
-	Name:        "in",
-	Usage:       "get a quote for the cost of a loop in swap",
-	ArgsUsage:   "amt",
-	Description: "Allows to determine the cost of a swap up front",
-	Flags:       []cli.Flag{confTargetFlag, verboseFlag},
-	Action:      quoteIn,
+	Name:      "in",
+	Usage:     "get a quote for the cost of a loop in swap",
+	ArgsUsage: "amt",
+	Description: "Allows to determine the cost of a swap up front. If " +
+		"the htlc is published externally, the on-chain fee is " +
+		"only estimated if --conf_target is set, assuming that " +
+		"the htlc is published with a single input and a change " +
+		"output.",
+	Flags: []cli.Flag{
+		confTargetFlag,
+		cli.BoolFlag{
+			Name:  "external",
+			Usage: "expect htlc to be published externally",
+		},
+		verboseFlag,
+	},
+	Action: quoteIn,
 }
 
 func quoteIn(ctx *cli.Context) error {
@@ -46,8 +58,9 @@ func quoteIn(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	quoteReq := &looprpc.QuoteRequest{
-		Amt:        int64(amt),
-		ConfTarget: int32(ctx.Uint64("conf_target")),
+		Amt:          int64(amt),
+		ConfTarget:   int32(ctx.Uint64("conf_target")),
+		ExternalHtlc: ctx.Bool("external"),
 	}
 	quoteResp, err := client.GetLoopInQuote(ctxb, quoteReq)
 	if err != nil {
@@ -66,7 +79,14 @@ func quoteIn(ctx *cli.Context) error {
 			"amount.\n")
 	}
 
-	printQuoteInResp(quoteReq, quoteResp, ctx.Bool("verbose"))
+	if jsonOutput {
+		printRespJSON(quoteResp)
+		return nil
+	}
+
+	printQuoteInResp(
+		os.Stdout, quoteReq, quoteResp, ctx.Bool("verbose"),
+	)
 	return nil
 }
 
@@ -93,6 +113,8 @@ var quoteOutCommand = cli.Command{
 				"setting this flag might result in a lower " +
 				"swap fee.",
 		},
+		deadlineFlag,
+		serverConfTargetFlag,
 		verboseFlag,
 	},
 	Action: quoteOut,
@@ -116,85 +138,119 @@ func quoteOut(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	fast := ctx.Bool("fast")
-	swapDeadline := time.Now()
-	if !fast {
-		swapDeadline = time.Now().Add(defaultSwapWaitTime)
+	swapWait, err := getSwapWaitTime(ctx)
+	if err != nil {
+		return err
 	}
+	swapDeadline := time.Now().Add(swapWait)
 
 	ctxb := context.Background()
 	quoteReq := &looprpc.QuoteRequest{
 		Amt:                     int64(amt),
 		ConfTarget:              int32(ctx.Uint64("conf_target")),
 		SwapPublicationDeadline: uint64(swapDeadline.Unix()),
+		ServerConfTarget: int32(
+			ctx.Uint64(serverConfTargetFlag.Name),
+		),
 	}
 	quoteResp, err := client.LoopOutQuote(ctxb, quoteReq)
 	if err != nil {
 		return err
 	}
 
-	printQuoteOutResp(quoteReq, quoteResp, ctx.Bool("verbose"))
+	if jsonOutput {
+		printRespJSON(quoteResp)
+		return nil
+	}
+
+	printQuoteOutResp(
+		os.Stdout, quoteReq, quoteResp, ctx.Bool("verbose"),
+	)
 	return nil
 }
 
-func printQuoteInResp(req *looprpc.QuoteRequest,
+func printQuoteInResp(w io.Writer, req *looprpc.QuoteRequest,
 	resp *looprpc.InQuoteResponse, verbose bool) {
 
 	totalFee := resp.HtlcPublishFeeSat + resp.SwapFeeSat
 
-	fmt.Printf(satAmtFmt, "Send on-chain:", req.Amt)
-	fmt.Printf(satAmtFmt, "Receive off-chain:", req.Amt-totalFee)
+	fmt.Fprintf(w, satAmtFmt, tr("Send on-chain:"), req.Amt)
+	fmt.Fprintf(w, satAmtFmt, tr("Receive off-chain:"), req.Amt-totalFee)
+
+	// If the htlc is external and no confirmation target was set, we do
+	// not know the miner fee, hence the total cost.
+	unknownFee := req.ExternalHtlc && req.ConfTarget == 0
 
 	switch {
-	case req.ExternalHtlc && !verbose:
-		// If it's external then we don't know the miner fee hence the
-		// total cost.
-		fmt.Printf(satAmtFmt, "Loop service fee:", resp.SwapFeeSat)
+	case unknownFee && !verbose:
+		fmt.Fprintf(
+			w, satAmtFmt, tr("Loop service fee:"), resp.SwapFeeSat,
+		)
 
-	case req.ExternalHtlc && verbose:
-		fmt.Printf(satAmtFmt, "Loop service fee:", resp.SwapFeeSat)
-		fmt.Println()
-		fmt.Printf(blkFmt, "CLTV expiry delta:", resp.CltvDelta)
+	case unknownFee && verbose:
+		fmt.Fprintf(
+			w, satAmtFmt, tr("Loop service fee:"), resp.SwapFeeSat,
+		)
+		fmt.Fprintln(w)
+		fmt.Fprintf(
+			w, blkFmt, tr("CLTV expiry delta:"), resp.CltvDelta,
+		)
 
 	case verbose:
-		fmt.Println()
-		fmt.Printf(
-			satAmtFmt, "Estimated on-chain fee:",
+		fmt.Fprintln(w)
+		fmt.Fprintf(
+			w, satAmtFmt, tr("Estimated on-chain fee:"),
 			resp.HtlcPublishFeeSat,
 		)
-		fmt.Printf(satAmtFmt, "Loop service fee:", resp.SwapFeeSat)
-		fmt.Printf(satAmtFmt, "Estimated total fee:", totalFee)
-		fmt.Println()
-		fmt.Printf(blkFmt, "Conf target:", resp.ConfTarget)
-		fmt.Printf(blkFmt, "CLTV expiry delta:", resp.CltvDelta)
+		fmt.Fprintf(
+			w, satAmtFmt, tr("Loop service fee:"), resp.SwapFeeSat,
+		)
+		fmt.Fprintf(w, satAmtFmt, tr("Estimated total fee:"), totalFee)
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, blkFmt, tr("Conf target:"), resp.ConfTarget)
+		fmt.Fprintf(
+			w, blkFmt, tr("CLTV expiry delta:"), resp.CltvDelta,
+		)
 	default:
-		fmt.Printf(satAmtFmt, "Estimated total fee:", totalFee)
+		fmt.Fprintf(w, satAmtFmt, tr("Estimated total fee:"), totalFee)
 	}
 }
 
-func printQuoteOutResp(req *looprpc.QuoteRequest,
+func printQuoteOutResp(w io.Writer, req *looprpc.QuoteRequest,
 	resp *looprpc.OutQuoteResponse, verbose bool) {
 
 	totalFee := resp.HtlcSweepFeeSat + resp.SwapFeeSat
 
-	fmt.Printf(satAmtFmt, "Send off-chain:", req.Amt)
-	fmt.Printf(satAmtFmt, "Receive on-chain:", req.Amt-totalFee)
+	fmt.Fprintf(w, satAmtFmt, tr("Send off-chain:"), req.Amt)
+	fmt.Fprintf(w, satAmtFmt, tr("Receive on-chain:"), req.Amt-totalFee)
 
 	if !verbose {
-		fmt.Printf(satAmtFmt, "Estimated total fee:", totalFee)
+		fmt.Fprintf(w, satAmtFmt, tr("Estimated total fee:"), totalFee)
 		return
 	}
 
-	fmt.Println()
-	fmt.Printf(satAmtFmt, "Estimated on-chain fee:", resp.HtlcSweepFeeSat)
-	fmt.Printf(satAmtFmt, "Loop service fee:", resp.SwapFeeSat)
-	fmt.Printf(satAmtFmt, "Estimated total fee:", totalFee)
-	fmt.Println()
-	fmt.Printf(satAmtFmt, "No show penalty (prepay):", resp.PrepayAmtSat)
-	fmt.Printf(blkFmt, "Conf target:", resp.ConfTarget)
-	fmt.Printf(blkFmt, "CLTV expiry delta:", resp.CltvDelta)
-	fmt.Printf("%-38s %s\n",
-		"Publication deadline:",
+	fmt.Fprintln(w)
+	fmt.Fprintf(
+		w, satAmtFmt, tr("Estimated on-chain fee:"),
+		resp.HtlcSweepFeeSat,
+	)
+	fmt.Fprintf(w, satAmtFmt, tr("Loop service fee:"), resp.SwapFeeSat)
+	fmt.Fprintf(w, satAmtFmt, tr("Estimated total fee:"), totalFee)
+	fmt.Fprintln(w)
+	fmt.Fprintf(
+		w, satAmtFmt, tr("No show penalty (prepay):"),
+		resp.PrepayAmtSat,
+	)
+	fmt.Fprintf(w, blkFmt, tr("Conf target:"), resp.ConfTarget)
+	if resp.ServerConfTarget != 0 {
+		fmt.Fprintf(
+			w, blkFmt, tr("Server conf target:"),
+			resp.ServerConfTarget,
+		)
+	}
+	fmt.Fprintf(w, blkFmt, tr("CLTV expiry delta:"), resp.CltvDelta)
+	fmt.Fprintf(w, "%-38s %s\n",
+		tr("Publication deadline:"),
 		time.Unix(int64(req.SwapPublicationDeadline), 0),
 	)
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var quoteHistoryCommand = cli.Command{
+	Name:  "quotehistory",
+	Usage: "show the quotes received from the server",
+	Description: "Shows the swap fee, prepay amount and miner fee " +
+		"estimate of every loop in and loop out quote that was " +
+		"received from the server in a time range",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "only include quotes received at or after this " +
+				"unix timestamp (in seconds)",
+		},
+		cli.Int64Flag{
+			Name: "end",
+			Usage: "only include quotes received before this unix " +
+				"timestamp (in seconds)",
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "only include quotes of this type, either in " +
+				"or out",
+		},
+	},
+	Action: quoteHistory,
+}
+
+func quoteHistory(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	req := &looprpc.QuoteHistoryRequest{}
+
+	if ctx.IsSet("start") {
+		req.StartTimeNs = time.Unix(ctx.Int64("start"), 0).UnixNano()
+	}
+
+	if ctx.IsSet("end") {
+		req.EndTimeNs = time.Unix(ctx.Int64("end"), 0).UnixNano()
+	}
+
+	switch ctx.String("type") {
+	case "":
+
+	case "in":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_IN}
+
+	case "out":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_OUT}
+
+	default:
+		return fmt.Errorf("unknown swap type: %v, expected in or out",
+			ctx.String("type"))
+	}
+
+	resp, err := client.GetQuoteHistory(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
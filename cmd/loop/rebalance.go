@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var compareRebalanceCommand = cli.Command{
+	Name:      "comparerebalance",
+	Usage:     "compare the cost of a swap with a circular rebalance",
+	ArgsUsage: "chanid amt",
+	Description: "Quotes a swap for the amount provided and compares its " +
+		"cost with the off-chain fees that lnd estimates for a " +
+		"circular rebalance that shifts the same balance in the " +
+		"channel provided",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "channel",
+			Usage: "the short channel id of the channel to compare",
+		},
+		cli.StringFlag{
+			Name:  "amt",
+			Usage: "the amount to shift, " + amtUsage,
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "the type of swap to compare with, either out " +
+				"to shift balance out of the channel or in to " +
+				"shift balance into it",
+			Value: "out",
+		},
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the confirmation target used for the swap's " +
+				"fee estimate",
+		},
+	},
+	Action: compareRebalance,
+}
+
+func compareRebalance(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	var chanID uint64
+	switch {
+	case ctx.IsSet("channel"):
+		chanID = ctx.Uint64("channel")
+
+	case args.Present():
+		_, err := fmt.Sscan(args.First(), &chanID)
+		if err != nil {
+			return fmt.Errorf("invalid channel id: %v", err)
+		}
+		args = args.Tail()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "comparerebalance")
+	}
+
+	var amtStr string
+	switch {
+	case ctx.IsSet("amt"):
+		amtStr = ctx.String("amt")
+
+	case args.Present():
+		amtStr = args.First()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "comparerebalance")
+	}
+
+	amt, err := parseAmt(amtStr)
+	if err != nil {
+		return err
+	}
+
+	req := &looprpc.CompareRebalanceRequest{
+		ChannelId:  chanID,
+		Amt:        int64(amt),
+		ConfTarget: int32(ctx.Uint64("conf_target")),
+	}
+
+	switch ctx.String("type") {
+	case "out":
+		req.Type = looprpc.SwapType_LOOP_OUT
+
+	case "in":
+		req.Type = looprpc.SwapType_LOOP_IN
+
+	default:
+		return fmt.Errorf("unknown swap type: %v",
+			ctx.String("type"))
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.CompareRebalance(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
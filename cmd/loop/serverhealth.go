@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var serverHealthCommand = cli.Command{
+	Name:  "serverhealth",
+	Usage: "show the health of the connection to the swap server",
+	Description: "Shows whether the swap server was reachable on loopd's " +
+		"most recent call to it, the times of its last calls that " +
+		"did and did not reach the server and the protocol version " +
+		"that it uses. Autoloop is paused while the server is " +
+		"unreachable.",
+	Action: serverHealth,
+}
+
+func serverHealth(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ServerHealth(
+		context.Background(), &looprpc.ServerHealthRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
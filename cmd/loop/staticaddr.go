@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var staticAddressCommand = cli.Command{
+	Name:  "static",
+	Usage: "manage the static loop in address",
+	Description: "Deposits to loopd's static address are automatically " +
+		"looped in once they have confirmed.",
+	Subcommands: []cli.Command{
+		newStaticAddressCommand,
+		listStaticDepositsCommand,
+	},
+}
+
+var newStaticAddressCommand = cli.Command{
+	Name:  "new",
+	Usage: "show the static address, creating it if necessary",
+	Description: "Returns loopd's static address, creating it if it " +
+		"does not exist yet. Once a deposit to this address has " +
+		"confirmed, a loop in swap is dispatched for its value, less " +
+		"the fees for publishing the swap's htlc. The address can be " +
+		"reused for any number of deposits.",
+	Action: newStaticAddress,
+}
+
+func newStaticAddress(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.NewStaticAddress(
+		context.Background(), &looprpc.NewStaticAddressRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listStaticDepositsCommand = cli.Command{
+	Name:  "deposits",
+	Usage: "list the deposits made to the static address",
+	Description: "Lists the deposits that have been made to loopd's " +
+		"static address, along with the loop in swaps that were " +
+		"dispatched for them.",
+	Action: listStaticDeposits,
+}
+
+func listStaticDeposits(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ListStaticDeposits(
+		context.Background(), &looprpc.ListStaticDepositsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
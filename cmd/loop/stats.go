@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var statsCommand = cli.Command{
+	Name:  "stats",
+	Usage: "show swap statistics aggregated by period",
+	Description: "Shows the number of swaps, volume, success rate, " +
+		"average fees, average completion time and the duration " +
+		"percentiles and histograms of each swap phase of the swaps " +
+		"in the local database, aggregated by day, week or month",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "period",
+			Usage: "the period to aggregate by: day, week or month",
+			Value: "day",
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "only include swaps of this type, either in " +
+				"or out",
+		},
+	},
+	Action: stats,
+}
+
+func stats(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	period, ok := looprpc.StatsPeriod_value[strings.ToUpper(
+		ctx.String("period"),
+	)]
+	if !ok {
+		return fmt.Errorf("unknown period: %v, expected day, week or "+
+			"month", ctx.String("period"))
+	}
+
+	req := &looprpc.SwapStatsRequest{
+		Period: looprpc.StatsPeriod(period),
+	}
+
+	switch ctx.String("type") {
+	case "":
+
+	case "in":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_IN}
+
+	case "out":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_OUT}
+
+	default:
+		return fmt.Errorf("unknown swap type: %v, expected in or out",
+			ctx.String("type"))
+	}
+
+	resp, err := client.GetSwapStats(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var supportBundleCommand = cli.Command{
+	Name:  "support-bundle",
+	Usage: "create an archive for attaching to bug reports",
+	Description: "Collects loopd's status, configuration, pending swaps, " +
+		"database statistics and the end of its log file into a " +
+		"single archive. Invoices, addresses and ip addresses are " +
+		"redacted from the logs, but please check the archive " +
+		"before sharing it.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "output",
+			Usage: "the path to write the archive to, if not set " +
+				"it is written to the current directory",
+		},
+		cli.Uint64Flag{
+			Name: "max_log_bytes",
+			Usage: "the maximum number of bytes from the end of " +
+				"loopd's log to include, if not set the last " +
+				"1 MiB is included",
+		},
+	},
+	Action: supportBundle,
+}
+
+func supportBundle(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetSupportBundle(
+		context.Background(), &looprpc.SupportBundleRequest{
+			MaxLogBytes: ctx.Uint64("max_log_bytes"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	path := resp.FileName
+	if ctx.IsSet("output") {
+		path = ctx.String("output")
+	}
+
+	if err := ioutil.WriteFile(path, resp.Archive, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Support bundle written to: %v\n", path)
+
+	return nil
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/lightninglabs/loop/looprpc"
 	"github.com/lightningnetwork/lnd/lntypes"
@@ -14,7 +17,41 @@ var listSwapsCommand = cli.Command{
 	Name:  "listswaps",
 	Usage: "list all swaps in the local database",
 	Description: "Allows the user to get a list of all swaps that are " +
-		"currently stored in the database",
+		"currently stored in the database, optionally filtered by " +
+		"type, state, label and initiation time",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "only list swaps of this type, either in or out",
+		},
+		cli.StringSliceFlag{
+			Name: "state",
+			Usage: "only list swaps in this state, may be set " +
+				"multiple times (eg. SUCCESS, FAILED)",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "only list swaps with a label containing this text",
+		},
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "only list swaps initiated at or after this " +
+				"unix timestamp (in seconds)",
+		},
+		cli.Int64Flag{
+			Name: "end",
+			Usage: "only list swaps initiated before this unix " +
+				"timestamp (in seconds)",
+		},
+		cli.Uint64Flag{
+			Name:  "offset",
+			Usage: "the number of matching swaps to skip",
+		},
+		cli.Uint64Flag{
+			Name:  "limit",
+			Usage: "the maximum number of swaps to list",
+		},
+	},
 	Action: listSwaps,
 }
 
@@ -25,9 +62,44 @@ func listSwaps(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	resp, err := client.ListSwaps(
-		context.Background(), &looprpc.ListSwapsRequest{},
-	)
+	req := &looprpc.ListSwapsRequest{
+		Label:  ctx.String("label"),
+		Offset: ctx.Uint64("offset"),
+		Limit:  ctx.Uint64("limit"),
+	}
+
+	switch ctx.String("type") {
+	case "":
+
+	case "in":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_IN}
+
+	case "out":
+		req.SwapTypes = []looprpc.SwapType{looprpc.SwapType_LOOP_OUT}
+
+	default:
+		return fmt.Errorf("unknown swap type: %v, expected in or out",
+			ctx.String("type"))
+	}
+
+	for _, state := range ctx.StringSlice("state") {
+		rpcState, ok := looprpc.SwapState_value[strings.ToUpper(state)]
+		if !ok {
+			return fmt.Errorf("unknown swap state: %v", state)
+		}
+
+		req.States = append(req.States, looprpc.SwapState(rpcState))
+	}
+
+	if ctx.IsSet("start") {
+		req.StartTimeNs = time.Unix(ctx.Int64("start"), 0).UnixNano()
+	}
+
+	if ctx.IsSet("end") {
+		req.EndTimeNs = time.Unix(ctx.Int64("end"), 0).UnixNano()
+	}
+
+	resp, err := client.ListSwaps(context.Background(), req)
 	if err != nil {
 		return err
 	}
@@ -90,3 +162,221 @@ func swapInfo(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var abandonSwapCommand = cli.Command{
+	Name:      "abandonswap",
+	Usage:     "abandon a swap that is permanently stuck",
+	ArgsUsage: "id",
+	Description: "Marks a pending swap as failed and stops loopd from " +
+		"executing it. WARNING: loopd will no longer sweep the " +
+		"swap's on-chain htlc, so any funds that are locked in it " +
+		"need to be recovered manually with `loop recover`. Only " +
+		"use this as a last resort for swaps that are permanently " +
+		"stuck.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "i_know_what_i_am_doing",
+			Usage: "confirm that you understand that abandoning a " +
+				"swap may lead to loss of funds",
+		},
+	},
+	Action: abandonSwap,
+}
+
+func abandonSwap(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "abandonswap")
+	}
+
+	id := ctx.Args().First()
+	if len(id) != hex.EncodedLen(lntypes.HashSize) {
+		return fmt.Errorf("invalid swap ID")
+	}
+	idBytes, err := hex.DecodeString(id)
+	if err != nil {
+		return fmt.Errorf("cannot hex decode id: %v", err)
+	}
+
+	if !ctx.Bool("i_know_what_i_am_doing") {
+		return fmt.Errorf("abandoning a swap stops loopd from " +
+			"sweeping its on-chain htlc, set " +
+			"--i_know_what_i_am_doing to confirm")
+	}
+
+	fmt.Printf("Abandon swap %v? Funds locked in its on-chain htlc will "+
+		"need to be recovered manually. (y/n): ", id)
+
+	var answer string
+	fmt.Scanln(&answer)
+	if answer != "y" {
+		return errors.New("abandon canceled")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.AbandonSwap(
+		context.Background(), &looprpc.AbandonSwapRequest{
+			Id:                idBytes,
+			IKnowWhatIAmDoing: true,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var recoverCommand = cli.Command{
+	Name:      "recover",
+	Usage:     "manually sweep the on-chain htlc of a swap",
+	ArgsUsage: "id",
+	Description: "Manually sweeps the on-chain htlc of a swap using the " +
+		"swap data in loopd's database, bypassing the swap's state " +
+		"machine. Loop out htlcs are swept with the swap preimage, " +
+		"loop in htlcs are reclaimed through the timeout path once " +
+		"they have expired. This is intended for recovery when the " +
+		"automatic sweep fails, for example due to corrupted swap " +
+		"state.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "addr",
+			Usage: "the address to sweep the htlc to, if not set " +
+				"an address is obtained from the lnd wallet",
+		},
+		cli.Uint64Flag{
+			Name:  "sat_per_vbyte",
+			Usage: "the fee rate in sat/vbyte to sweep with",
+		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "publish the sweep without asking for " +
+				"confirmation",
+		},
+	},
+	Action: recoverSweep,
+}
+
+func recoverSweep(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "recover")
+	}
+
+	id := ctx.Args().First()
+	if len(id) != hex.EncodedLen(lntypes.HashSize) {
+		return fmt.Errorf("invalid swap ID")
+	}
+	idBytes, err := hex.DecodeString(id)
+	if err != nil {
+		return fmt.Errorf("cannot hex decode id: %v", err)
+	}
+
+	if !ctx.IsSet("sat_per_vbyte") {
+		return errors.New("sat_per_vbyte required")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	req := &looprpc.RecoverSweepRequest{
+		Id:          idBytes,
+		Dest:        ctx.String("addr"),
+		SatPerVbyte: ctx.Uint64("sat_per_vbyte"),
+	}
+
+	// We first create the sweep without publishing it, so that the user
+	// can review it before it is published.
+	resp, err := client.RecoverSweep(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-36s %v\n", "HTLC outpoint:", resp.HtlcOutpoint)
+	fmt.Printf(satAmtFmt, "HTLC value:", resp.HtlcValueSat)
+	fmt.Printf(satAmtFmt, "Sweep fee:", resp.FeeSat)
+	fmt.Printf("%-36s %v\n", "Sweep address:", resp.Dest)
+
+	if !ctx.Bool("force") {
+		fmt.Printf("Publish sweep? (y/n): ")
+
+		var answer string
+		fmt.Scanln(&answer)
+		if answer != "y" {
+			return errors.New("recover canceled")
+		}
+	}
+
+	// Publish the sweep to the address that we displayed, so that we do
+	// not obtain a new wallet address.
+	req.Dest = resp.Dest
+	req.Publish = true
+
+	resp, err = client.RecoverSweep(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var pruneSwapsCommand = cli.Command{
+	Name:  "pruneswaps",
+	Usage: "delete old swaps from the local database",
+	Description: "Deletes all swaps that reached a final state more than " +
+		"the retention period ago from loopd's database. Pruned " +
+		"swaps are no longer listed, and funds that are left in " +
+		"their htlcs can no longer be recovered. To also reduce " +
+		"the size of the database file, run `loopd view --prune` " +
+		"while loopd is not running instead.",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "retention",
+			Usage: "the amount of time that swaps are kept for " +
+				"after they reach a final state, must be at " +
+				"least 168h",
+			Value: time.Hour * 24 * 90,
+		},
+	},
+	Action: pruneSwaps,
+}
+
+func pruneSwaps(ctx *cli.Context) error {
+	retention := ctx.Duration("retention")
+
+	fmt.Printf("Delete all swaps that completed more than %v ago? "+
+		"(y/n): ", retention)
+
+	var answer string
+	fmt.Scanln(&answer)
+	if answer != "y" {
+		return errors.New("prune canceled")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.PruneSwaps(
+		context.Background(), &looprpc.PruneSwapsRequest{
+			RetentionSec: uint64(retention.Seconds()),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pruned %v swaps\n", len(resp.PrunedSwaps))
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/urfave/cli"
+)
+
+var templateFlag = cli.StringFlag{
+	Name: "template",
+	Usage: "the name of a saved swap template to dispatch, flags and " +
+		"arguments that are set override the template's values",
+}
+
+var templatesCommand = cli.Command{
+	Name:  "templates",
+	Usage: "manage saved loop out templates",
+	Description: "Swap templates are named sets of loop out parameters " +
+		"that are stored by loopd, so that the same swap can be " +
+		"dispatched repeatedly with `loop out --template=name`.",
+	Subcommands: []cli.Command{
+		saveTemplateCommand,
+		listTemplatesCommand,
+		deleteTemplateCommand,
+	},
+}
+
+var saveTemplateCommand = cli.Command{
+	Name:      "save",
+	Usage:     "save a loop out template",
+	ArgsUsage: "name",
+	Description: "Saves a loop out template with the name provided, " +
+		"replacing any existing template with the same name. Names " +
+		"may contain letters, digits, dashes and underscores.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "amt",
+			Usage: "the amount to loop out, " + amtUsage,
+		},
+		cli.StringFlag{
+			Name: "channel",
+			Usage: "the comma-separated list of short " +
+				"channel IDs of the channels to loop out",
+		},
+		cli.StringFlag{
+			Name: "max_swap_routing_fee",
+			Usage: "the max off-chain swap routing fee, " +
+				amtUsage + ", if not specified, a default " +
+				"max fee will be used",
+		},
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks from the swap " +
+				"initiation height that the on-chain HTLC " +
+				"should be swept within",
+		},
+		cli.Uint64Flag{
+			Name: "htlc_confs",
+			Usage: "the number of confirmations (in blocks) " +
+				"that we require for the htlc extended by " +
+				"the server before we reveal the preimage",
+		},
+		labelFlag,
+	},
+	Action: saveTemplate,
+}
+
+func saveTemplate(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "save")
+	}
+
+	if !ctx.IsSet("amt") {
+		return errors.New("amt required")
+	}
+
+	amt, err := parseAmt(ctx.String("amt"))
+	if err != nil {
+		return err
+	}
+
+	template := &looprpc.SwapTemplate{
+		Name:              ctx.Args().First(),
+		Amt:               int64(amt),
+		SweepConfTarget:   int32(ctx.Uint64("conf_target")),
+		HtlcConfirmations: int32(ctx.Uint64("htlc_confs")),
+		Label:             ctx.String(labelFlag.Name),
+	}
+
+	if ctx.IsSet("channel") {
+		template.OutgoingChanSet, err = parseChanSet(
+			ctx.String("channel"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.IsSet("max_swap_routing_fee") {
+		fee, err := parseAmt(ctx.String("max_swap_routing_fee"))
+		if err != nil {
+			return err
+		}
+		template.MaxSwapRoutingFee = int64(fee)
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	_, err = client.SaveSwapTemplate(
+		context.Background(), &looprpc.SaveSwapTemplateRequest{
+			Template: template,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Swap template saved: %v\n", template.Name)
+
+	return nil
+}
+
+var listTemplatesCommand = cli.Command{
+	Name:   "list",
+	Usage:  "list all loop out templates",
+	Action: listTemplates,
+}
+
+func listTemplates(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ListSwapTemplates(
+		context.Background(), &looprpc.ListSwapTemplatesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var deleteTemplateCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete a loop out template",
+	ArgsUsage: "name",
+	Action:    deleteTemplate,
+}
+
+func deleteTemplate(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "delete")
+	}
+	name := ctx.Args().First()
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	_, err = client.DeleteSwapTemplate(
+		context.Background(), &looprpc.DeleteSwapTemplateRequest{
+			Name: name,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Swap template deleted: %v\n", name)
+
+	return nil
+}
+
+// getSwapTemplate looks up the saved swap template with the name provided.
+func getSwapTemplate(ctx *cli.Context, name string) (*looprpc.SwapTemplate,
+	error) {
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	resp, err := client.ListSwapTemplates(
+		context.Background(), &looprpc.ListSwapTemplatesRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range resp.Templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+
+	return nil, fmt.Errorf("swap template %v not found, use `loop "+
+		"templates list` to view saved templates", name)
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/protobuf-hex-display/json"
 	"github.com/urfave/cli"
 )
 
@@ -22,6 +25,10 @@ func terms(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
+	if jsonOutput {
+		return printTermsJSON(client)
+	}
+
 	printAmountRange := func(min, max int64) {
 		fmt.Printf("Amount: %d - %d\n",
 			btcutil.Amount(min), btcutil.Amount(max),
@@ -62,3 +69,47 @@ func terms(ctx *cli.Context) error {
 
 	return nil
 }
+
+// printTermsJSON prints the server's loop out and loop in terms as a single
+// json object.
+func printTermsJSON(client looprpc.SwapClientClient) error {
+	return writeTermsJSON(os.Stdout, client)
+}
+
+// writeTermsJSON writes the server's loop out and loop in terms as a single
+// json object to the writer provided.
+func writeTermsJSON(w io.Writer, client looprpc.SwapClientClient) error {
+	loopOutTerms, err := client.LoopOutTerms(
+		context.Background(), &looprpc.TermsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	loopInTerms, err := client.GetLoopInTerms(
+		context.Background(), &looprpc.TermsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonMarshaler := newJSONMarshaler("")
+
+	outJSON, err := jsonMarshaler.MarshalToString(loopOutTerms)
+	if err != nil {
+		return err
+	}
+
+	inJSON, err := jsonMarshaler.MarshalToString(loopInTerms)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, struct {
+		LoopOut json.RawMessage `json:"loop_out"`
+		LoopIn  json.RawMessage `json:"loop_in"`
+	}{
+		LoopOut: json.RawMessage(outJSON),
+		LoopIn:  json.RawMessage(inJSON),
+	})
+}
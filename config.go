@@ -1,11 +1,14 @@
 package loop
 
 import (
+	"io"
 	"time"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/aperture/lsat"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/clock"
 )
 
 // clientConfig contains config items for the swap client.
@@ -16,4 +19,10 @@ type clientConfig struct {
 	LsatStore         lsat.Store
 	CreateExpiryTimer func(expiry time.Duration) <-chan time.Time
 	LoopOutMaxParts   uint32
+	MaxLockedValue    btcutil.Amount
+	ConfPolicy        ConfPolicy
+	Clock             clock.Clock
+	Rand              io.Reader
+
+	MaxLoopInWalletFraction float64
 }
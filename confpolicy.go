@@ -0,0 +1,122 @@
+package loop
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+)
+
+var (
+	// DefaultConfPolicy is the confirmation policy that is used if none
+	// is configured. Small loop outs only wait for a single htlc
+	// confirmation, while large loop outs wait for three confirmations
+	// before revealing the preimage. Sweep confirmation targets are not
+	// scaled by default.
+	DefaultConfPolicy = ConfPolicy{
+		SmallAmount:            btcutil.Amount(250000),
+		SmallHtlcConfirmations: 1,
+		LargeAmount:            btcutil.Amount(1000000),
+		LargeHtlcConfirmations: 3,
+	}
+
+	// errConfPolicyAmounts is returned when the small amount threshold of
+	// a confirmation policy is above its large amount threshold.
+	errConfPolicyAmounts = errors.New("confirmation policy small amount " +
+		"must not exceed large amount")
+
+	// errConfPolicyNegative is returned when a confirmation policy has a
+	// negative number of confirmations or confirmation target.
+	errConfPolicyNegative = errors.New("confirmation policy values must " +
+		"not be negative")
+)
+
+// ConfPolicy scales the number of htlc confirmations and the sweep
+// confirmation target of loop out swaps with the swap amount. Swaps below
+// SmallAmount use the small values, and swaps of at least LargeAmount use the
+// large values. Swaps between the two thresholds, and any value that is zero,
+// use the default that would otherwise apply. The policy is only used for
+// swaps that do not set their own values.
+type ConfPolicy struct {
+	// SmallAmount is the swap amount below which the small values of the
+	// policy are used.
+	SmallAmount btcutil.Amount
+
+	// SmallHtlcConfirmations is the number of htlc confirmations that is
+	// required for swaps below SmallAmount.
+	SmallHtlcConfirmations int32
+
+	// SmallSweepConfTarget is the sweep confirmation target that is used
+	// for swaps below SmallAmount.
+	SmallSweepConfTarget int32
+
+	// LargeAmount is the swap amount at or above which the large values
+	// of the policy are used.
+	LargeAmount btcutil.Amount
+
+	// LargeHtlcConfirmations is the number of htlc confirmations that is
+	// required for swaps of at least LargeAmount.
+	LargeHtlcConfirmations int32
+
+	// LargeSweepConfTarget is the sweep confirmation target that is used
+	// for swaps of at least LargeAmount.
+	LargeSweepConfTarget int32
+}
+
+// Validate checks that a confirmation policy is sane.
+func (p ConfPolicy) Validate() error {
+	if p.SmallAmount > p.LargeAmount {
+		return errConfPolicyAmounts
+	}
+
+	if p.SmallHtlcConfirmations < 0 || p.LargeHtlcConfirmations < 0 ||
+		p.SmallSweepConfTarget < 0 || p.LargeSweepConfTarget < 0 {
+
+		return errConfPolicyNegative
+	}
+
+	return nil
+}
+
+// scale returns the small or large value provided for the amount, or the
+// default value if the amount falls between our thresholds or the selected
+// value is zero.
+func (p ConfPolicy) scale(amount btcutil.Amount, small, large,
+	defaultValue int32) int32 {
+
+	var value int32
+	switch {
+	case amount < p.SmallAmount:
+		value = small
+
+	case amount >= p.LargeAmount:
+		value = large
+	}
+
+	if value == 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// HtlcConfirmations returns the number of htlc confirmations that a loop out
+// of the amount provided requires.
+func (p ConfPolicy) HtlcConfirmations(amount btcutil.Amount) int32 {
+	return p.scale(
+		amount, p.SmallHtlcConfirmations, p.LargeHtlcConfirmations,
+		int32(loopdb.DefaultLoopOutHtlcConfirmations),
+	)
+}
+
+// SweepConfTarget returns the sweep confirmation target for a loop out of the
+// amount provided, falling back to the default target provided if the policy
+// does not set one for the amount.
+func (p ConfPolicy) SweepConfTarget(amount btcutil.Amount,
+	defaultTarget int32) int32 {
+
+	return p.scale(
+		amount, p.SmallSweepConfTarget, p.LargeSweepConfTarget,
+		defaultTarget,
+	)
+}
@@ -0,0 +1,72 @@
+package loop
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfPolicy tests scaling of confirmation values by swap amount.
+func TestConfPolicy(t *testing.T) {
+	policy := ConfPolicy{
+		SmallAmount:            1000,
+		SmallHtlcConfirmations: 1,
+		SmallSweepConfTarget:   20,
+		LargeAmount:            5000,
+		LargeHtlcConfirmations: 4,
+	}
+	require.NoError(t, policy.Validate())
+
+	defaultConfs := int32(loopdb.DefaultLoopOutHtlcConfirmations)
+
+	tests := []struct {
+		name          string
+		amount        btcutil.Amount
+		expectedConfs int32
+		expectedSweep int32
+	}{
+		{
+			name:          "small swap",
+			amount:        999,
+			expectedConfs: 1,
+			expectedSweep: 20,
+		},
+		{
+			name:          "medium swap",
+			amount:        1000,
+			expectedConfs: defaultConfs,
+			expectedSweep: DefaultSweepConfTarget,
+		},
+		{
+			name:   "large swap",
+			amount: 5000,
+			// The large sweep target is not set, so we expect the
+			// default.
+			expectedConfs: 4,
+			expectedSweep: DefaultSweepConfTarget,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t, testCase.expectedConfs,
+				policy.HtlcConfirmations(testCase.amount),
+			)
+			require.Equal(
+				t, testCase.expectedSweep,
+				policy.SweepConfTarget(
+					testCase.amount, DefaultSweepConfTarget,
+				),
+			)
+		})
+	}
+
+	// A policy with its thresholds reversed is invalid.
+	policy.SmallAmount = policy.LargeAmount + 1
+	require.Equal(t, errConfPolicyAmounts, policy.Validate())
+}
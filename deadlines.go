@@ -0,0 +1,71 @@
+package loop
+
+import (
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// SwapDeadlines describes the number of blocks that remain until the deadlines
+// of a pending swap are reached.
+type SwapDeadlines struct {
+	// BlocksUntilExpiry is the number of blocks that remain until the
+	// swap's on-chain htlc expires. It is zero if the htlc has expired.
+	BlocksUntilExpiry int32
+
+	// BlocksUntilNoReturn is the number of blocks that remain until the
+	// swap's point of no return. For loop out swaps, this is the last
+	// height at which we will reveal our preimage. For loop in swaps,
+	// this is the last height at which we will publish our htlc. It is
+	// zero once the swap has proceeded past this point, or once this
+	// height has been reached.
+	BlocksUntilNoReturn int32
+}
+
+// SwapDeadlines returns the number of blocks that remain until the deadlines
+// of the swap provided are reached. It returns nil if the swap is no longer
+// pending, or if we do not know the current block height yet.
+func (s *Client) SwapDeadlines(info *SwapInfo) *SwapDeadlines {
+	return swapDeadlines(
+		info, s.executor.height(), s.executor.minPreimageRevealDelta,
+	)
+}
+
+// swapDeadlines returns the number of blocks that remain until a swap's
+// deadlines at the height provided, using the minimum preimage reveal delta
+// provided for loop out swaps.
+func swapDeadlines(info *SwapInfo, height,
+	revealDelta int32) *SwapDeadlines {
+
+	if height == 0 || info.State.Type() != loopdb.StateTypePending {
+		return nil
+	}
+
+	var (
+		noReturnDelta  int32
+		beforeNoReturn = info.State == loopdb.StateInitiated
+	)
+
+	switch info.SwapType {
+	case swap.TypeOut:
+		noReturnDelta = revealDelta
+
+	case swap.TypeIn:
+		noReturnDelta = MinLoopInPublishDelta
+
+	default:
+		return nil
+	}
+
+	deadlines := &SwapDeadlines{}
+
+	if remaining := info.CltvExpiry - height; remaining > 0 {
+		deadlines.BlocksUntilExpiry = remaining
+	}
+
+	remaining := info.CltvExpiry - noReturnDelta - height
+	if beforeNoReturn && remaining > 0 {
+		deadlines.BlocksUntilNoReturn = remaining
+	}
+
+	return deadlines
+}
@@ -0,0 +1,122 @@
+package loop
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapDeadlines tests calculation of the number of blocks that remain
+// until a swap's deadlines.
+func TestSwapDeadlines(t *testing.T) {
+	const (
+		height      = 100
+		expiry      = 200
+		revealDelta = 20
+	)
+
+	newSwap := func(swapType swap.Type, state loopdb.SwapState,
+		expiry int32) *SwapInfo {
+
+		return &SwapInfo{
+			SwapType: swapType,
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+			},
+			SwapContract: loopdb.SwapContract{
+				CltvExpiry: expiry,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		swap      *SwapInfo
+		height    int32
+		deadlines *SwapDeadlines
+	}{
+		{
+			name: "height unknown",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateInitiated, expiry,
+			),
+			height:    0,
+			deadlines: nil,
+		},
+		{
+			name: "swap completed",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateSuccess, expiry,
+			),
+			height:    height,
+			deadlines: nil,
+		},
+		{
+			name: "loop out initiated",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateInitiated, expiry,
+			),
+			height: height,
+			deadlines: &SwapDeadlines{
+				BlocksUntilExpiry:   expiry - height,
+				BlocksUntilNoReturn: expiry - revealDelta - height,
+			},
+		},
+		{
+			name: "loop out preimage revealed",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StatePreimageRevealed,
+				expiry,
+			),
+			height: height,
+			deadlines: &SwapDeadlines{
+				BlocksUntilExpiry: expiry - height,
+			},
+		},
+		{
+			name: "loop out past reveal height",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateInitiated,
+				height+revealDelta-1,
+			),
+			height: height,
+			deadlines: &SwapDeadlines{
+				BlocksUntilExpiry: revealDelta - 1,
+			},
+		},
+		{
+			name: "loop in initiated",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateInitiated, expiry,
+			),
+			height: height,
+			deadlines: &SwapDeadlines{
+				BlocksUntilExpiry: expiry - height,
+				BlocksUntilNoReturn: expiry -
+					MinLoopInPublishDelta - height,
+			},
+		},
+		{
+			name: "loop in htlc expired",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateHtlcPublished,
+				height-1,
+			),
+			height:    height,
+			deadlines: &SwapDeadlines{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			deadlines := swapDeadlines(
+				test.swap, test.height, revealDelta,
+			)
+			require.Equal(t, test.deadlines, deadlines)
+		})
+	}
+}
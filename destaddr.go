@@ -0,0 +1,50 @@
+package loop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+)
+
+var (
+	// ErrDestAddrRequired is returned when a loop out does not have a
+	// destination address.
+	ErrDestAddrRequired = errors.New("destination address required")
+
+	// ErrDestAddrNetwork is returned when a loop out destination address
+	// does not belong to the network that we are running on.
+	ErrDestAddrNetwork = errors.New("destination address is not for the " +
+		"active network")
+
+	// ErrDestAddrType is returned when a loop out destination address is
+	// of a type that we do not sweep to.
+	ErrDestAddrType = errors.New("unsupported destination address type")
+)
+
+// ValidateDestAddr checks that a loop out destination address belongs to the
+// network provided, and that it is a native segwit p2wkh or p2wsh address.
+// Taproot addresses are not supported yet, because they cannot be decoded by
+// the version of btcutil that we use.
+func ValidateDestAddr(addr btcutil.Address, params *chaincfg.Params) error {
+	if addr == nil {
+		return ErrDestAddrRequired
+	}
+
+	if !addr.IsForNet(params) {
+		return fmt.Errorf("%w: %v, active network is %v",
+			ErrDestAddrNetwork, addr, params.Name)
+	}
+
+	switch addr.(type) {
+	case *btcutil.AddressWitnessPubKeyHash,
+		*btcutil.AddressWitnessScriptHash:
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %T, expected p2wkh or p2wsh",
+			ErrDestAddrType, addr)
+	}
+}
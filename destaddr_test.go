@@ -0,0 +1,74 @@
+package loop
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateDestAddr tests validation of loop out destination addresses.
+func TestValidateDestAddr(t *testing.T) {
+	p2wkh, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	p2wsh, err := btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	testnetP2wkh, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), &chaincfg.TestNet3Params,
+	)
+	require.NoError(t, err)
+
+	p2sh, err := btcutil.NewAddressScriptHash(
+		[]byte{1}, &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		addr btcutil.Address
+		err  error
+	}{
+		{
+			name: "p2wkh",
+			addr: p2wkh,
+		},
+		{
+			name: "p2wsh",
+			addr: p2wsh,
+		},
+		{
+			name: "no address",
+			addr: nil,
+			err:  ErrDestAddrRequired,
+		},
+		{
+			name: "wrong network",
+			addr: testnetP2wkh,
+			err:  ErrDestAddrNetwork,
+		},
+		{
+			name: "unsupported type",
+			addr: p2sh,
+			err:  ErrDestAddrType,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateDestAddr(
+				testCase.addr, &chaincfg.MainNetParams,
+			)
+			require.True(t, errors.Is(err, testCase.err))
+		})
+	}
+}
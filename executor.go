@@ -8,9 +8,12 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/sweep"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/queue"
 )
 
@@ -20,13 +23,67 @@ type executorConfig struct {
 
 	sweeper *sweep.Sweeper
 
+	// batcher is used to sweep loop out htlcs in batches. If it is nil,
+	// each swap publishes its own sweep transaction.
+	batcher *sweep.Batcher
+
 	store loopdb.SwapStore
 
 	createExpiryTimer func(expiry time.Duration) <-chan time.Time
 
+	// clock is used to time our retries.
+	clock clock.Clock
+
 	loopOutMaxParts uint32
 
+	loopOutPaymentTimeout time.Duration
+
 	cancelSwap func(ctx context.Context, details *outCancelDetails) error
+
+	// abandonLoopOut asks the server to cancel a loop out swap that we
+	// have abandoned, and reports whether it released the swap's prepay.
+	// If it is nil, we do not contact the server when swaps are abandoned.
+	abandonLoopOut func(ctx context.Context, hash lntypes.Hash,
+		paymentAddr [32]byte) (bool, error)
+
+	sweepFeeBumpBlocks int32
+
+	// refundConfTarget is the confirmation target for loop in refunds.
+	refundConfTarget int32
+
+	// refundMaxFee is the maximum fee that we pay for a loop in refund. If
+	// zero, no limit is applied.
+	refundMaxFee btcutil.Amount
+
+	// minPreimageRevealDelta is the minimum number of blocks that must
+	// remain before a loop out htlc expires for us to reveal our preimage.
+	minPreimageRevealDelta int32
+
+	// maxConcurrentSwaps is the maximum number of swaps that are executed
+	// at once. New swaps that are handed to the executor while this many
+	// are executing are queued, and started in the order that they were
+	// received as executing swaps complete. Resumed swaps are always
+	// started immediately, because they may need to act on the next block
+	// to stay safe, but they count towards the limit. If zero, swaps are
+	// never queued.
+	maxConcurrentSwaps int
+}
+
+// swapExecution tracks a swap that has been handed to the executor.
+type swapExecution struct {
+	swap genericSwap
+
+	// resumed indicates whether the swap was restored from our store
+	// rather than newly created. Resumed swaps are never queued.
+	resumed bool
+
+	// quit is closed to request that execution of the swap is stopped.
+	quit     chan struct{}
+	quitOnce sync.Once
+
+	// done is closed once execution of the swap has stopped, or once it
+	// is removed from our queue without being started.
+	done chan struct{}
 }
 
 // executor is responsible for executing swaps.
@@ -34,10 +91,24 @@ type executorConfig struct {
 // TODO(roasbeef): rename to SubSwapper
 type executor struct {
 	wg            sync.WaitGroup
-	newSwaps      chan genericSwap
+	newSwaps      chan *swapExecution
 	currentHeight uint32
 	ready         chan struct{}
 
+	// running contains all the swaps that have been handed to the
+	// executor and have not yet finished executing, keyed by swap hash.
+	running     map[lntypes.Hash]*swapExecution
+	runningLock sync.Mutex
+
+	// statusChan is the channel that swap updates are delivered on. It is
+	// set before the executor signals that it is ready.
+	statusChan chan<- SwapInfo
+
+	// stopQueued delivers swaps that have been stopped to the main loop,
+	// so that they can be removed from our queue if they have not yet
+	// been started.
+	stopQueued chan *swapExecution
+
 	executorConfig
 }
 
@@ -45,8 +116,10 @@ type executor struct {
 func newExecutor(cfg *executorConfig) *executor {
 	return &executor{
 		executorConfig: *cfg,
-		newSwaps:       make(chan genericSwap),
+		newSwaps:       make(chan *swapExecution),
 		ready:          make(chan struct{}),
+		running:        make(map[lntypes.Hash]*swapExecution),
+		stopQueued:     make(chan *swapExecution),
 	}
 }
 
@@ -74,7 +147,7 @@ func (s *executor) run(mainCtx context.Context,
 				// Give chain notifier some time to start and
 				// try to re-attempt block epoch subscription.
 				select {
-				case <-time.After(500 * time.Millisecond):
+				case <-s.clock.TickAfter(500 * time.Millisecond):
 					continue
 
 				case <-mainCtx.Done():
@@ -112,53 +185,71 @@ func (s *executor) run(mainCtx context.Context,
 	log.Infof("Starting event loop at height %v", height)
 
 	// Signal that executor being ready with an up to date block height.
+	s.statusChan = statusChan
 	close(s.ready)
 
 	// Use a map to administer the individual notification queues for the
-	// swaps.
+	// swaps. Each executing swap has a queue, so the size of this map is
+	// the number of swaps that are currently executing.
 	blockEpochQueues := make(map[int]*queue.ConcurrentQueue)
 
-	// On exit, stop all queue goroutines.
+	// queued holds the swaps that are waiting for an executing swap to
+	// complete before they are started, in the order they were received.
+	var queued []*swapExecution
+
+	// On exit, stop all queue goroutines and release any callers that are
+	// waiting on swaps that were never started.
 	defer func() {
 		for _, queue := range blockEpochQueues {
 			queue.Stop()
 		}
+
+		for _, execution := range queued {
+			s.removeRunning(execution)
+			close(execution.done)
+		}
 	}()
 
 	swapDoneChan := make(chan int)
 	nextSwapID := 0
+
+	// startSwap starts execution of a swap at our current height.
+	startSwap := func(execution *swapExecution) {
+		blockEpochQueues[nextSwapID] = s.startSwap(
+			mainCtx, execution, nextSwapID, height, swapDoneChan,
+		)
+		nextSwapID++
+	}
+
 	for {
 		select {
 
-		case newSwap := <-s.newSwaps:
-			queue := queue.NewConcurrentQueue(10)
-			queue.Start()
-			swapID := nextSwapID
-			blockEpochQueues[swapID] = queue
-
-			s.wg.Add(1)
-			go func() {
-				defer s.wg.Done()
-
-				err := newSwap.execute(mainCtx, &executeConfig{
-					statusChan:      statusChan,
-					sweeper:         s.sweeper,
-					blockEpochChan:  queue.ChanOut(),
-					timerFactory:    s.executorConfig.createExpiryTimer,
-					loopOutMaxParts: s.executorConfig.loopOutMaxParts,
-					cancelSwap:      s.executorConfig.cancelSwap,
-				}, height)
-				if err != nil && err != context.Canceled {
-					log.Errorf("Execute error: %v", err)
-				}
+		case execution := <-s.newSwaps:
+			if execution.resumed || s.maxConcurrentSwaps == 0 ||
+				len(blockEpochQueues) < s.maxConcurrentSwaps {
 
-				select {
-				case swapDoneChan <- swapID:
-				case <-mainCtx.Done():
+				startSwap(execution)
+				continue
+			}
+
+			log.Infof("Maximum of %v concurrent swaps executing, "+
+				"queueing swap: %v", s.maxConcurrentSwaps,
+				execution.swap.swapHash())
+
+			queued = append(queued, execution)
+
+		case stopped := <-s.stopQueued:
+			for i, execution := range queued {
+				if execution != stopped {
+					continue
 				}
-			}()
 
-			nextSwapID++
+				queued = append(queued[:i], queued[i+1:]...)
+				s.removeRunning(execution)
+				close(execution.done)
+
+				break
+			}
 
 		case doneID := <-swapDoneChan:
 			queue, ok := blockEpochQueues[doneID]
@@ -170,6 +261,25 @@ func (s *executor) run(mainCtx context.Context,
 			queue.Stop()
 			delete(blockEpochQueues, doneID)
 
+			// Now that a swap has completed, we can start the
+			// swaps that have been queued for the longest. We may
+			// be executing more than our maximum number of swaps
+			// if we resumed many, so we only start queued swaps
+			// once we are below it.
+			for len(queued) > 0 &&
+				len(blockEpochQueues) < s.maxConcurrentSwaps {
+
+				execution := queued[0]
+				queued[0] = nil
+				queued = queued[1:]
+
+				log.Infof("Starting queued swap: %v, %v swaps "+
+					"remain queued", execution.swap.swapHash(),
+					len(queued))
+
+				startSwap(execution)
+			}
+
 		case h := <-blockEpochChan:
 			setHeight(h)
 			for _, queue := range blockEpochQueues {
@@ -189,17 +299,182 @@ func (s *executor) run(mainCtx context.Context,
 	}
 }
 
-// initiateSwap delivers a new swap to the executor main loop.
+// startSwap starts the goroutines that execute a swap at the height provided,
+// returning the queue that block epochs should be delivered to the swap on.
+// The swap's ID is sent on the done channel once it has finished executing.
+func (s *executor) startSwap(mainCtx context.Context,
+	execution *swapExecution, swapID int, height int32,
+	swapDoneChan chan<- int) *queue.ConcurrentQueue {
+
+	queue := queue.NewConcurrentQueue(10)
+	queue.Start()
+
+	// Each swap is executed with its own context so that it can be
+	// stopped individually.
+	swapCtx, cancel := context.WithCancel(mainCtx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case <-execution.quit:
+			cancel()
+
+		case <-swapCtx.Done():
+		}
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(execution.done)
+		defer cancel()
+
+		err := execution.swap.execute(swapCtx, &executeConfig{
+			statusChan:             s.statusChan,
+			sweeper:                s.sweeper,
+			batcher:                s.batcher,
+			blockEpochChan:         queue.ChanOut(),
+			timerFactory:           s.createExpiryTimer,
+			loopOutMaxParts:        s.loopOutMaxParts,
+			loopOutPaymentTimeout:  s.loopOutPaymentTimeout,
+			cancelSwap:             s.cancelSwap,
+			sweepFeeBumpBlocks:     s.sweepFeeBumpBlocks,
+			refundConfTarget:       s.refundConfTarget,
+			refundMaxFee:           s.refundMaxFee,
+			minPreimageRevealDelta: s.minPreimageRevealDelta,
+		}, height)
+		if err != nil && err != context.Canceled {
+			log.Errorf("Execute error: %v", err)
+		}
+
+		s.removeRunning(execution)
+
+		select {
+		case swapDoneChan <- swapID:
+		case <-mainCtx.Done():
+		}
+	}()
+
+	return queue
+}
+
+// removeRunning removes a swap from our set of running swaps.
+func (s *executor) removeRunning(execution *swapExecution) {
+	s.runningLock.Lock()
+	delete(s.running, execution.swap.swapHash())
+	s.runningLock.Unlock()
+}
+
+// initiateSwap delivers a new swap to the executor main loop. The swap is
+// queued if we are already executing our maximum number of swaps.
 func (s *executor) initiateSwap(ctx context.Context,
 	swap genericSwap) {
 
+	s.deliverSwap(ctx, swap, false)
+}
+
+// resumeSwap delivers a swap that was restored from our store to the executor
+// main loop. The swap is started immediately, regardless of the number of
+// swaps that we are executing.
+func (s *executor) resumeSwap(ctx context.Context,
+	swap genericSwap) {
+
+	s.deliverSwap(ctx, swap, true)
+}
+
+// deliverSwap delivers a swap to the executor main loop.
+func (s *executor) deliverSwap(ctx context.Context, swap genericSwap,
+	resumed bool) {
+
+	execution := &swapExecution{
+		swap:    swap,
+		resumed: resumed,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	// Track the swap before we deliver it to the main loop, so that it
+	// can be stopped as soon as this function has returned.
+	s.runningLock.Lock()
+	s.running[swap.swapHash()] = execution
+	s.runningLock.Unlock()
+
 	select {
-	case s.newSwaps <- swap:
+	case s.newSwaps <- execution:
 	case <-ctx.Done():
-		return
+		s.runningLock.Lock()
+		delete(s.running, swap.swapHash())
+		s.runningLock.Unlock()
 	}
 }
 
+// stopSwap stops execution of a swap and waits for it to exit. If the swap is
+// not currently being executed, this function returns immediately.
+func (s *executor) stopSwap(ctx context.Context, hash lntypes.Hash) error {
+	s.runningLock.Lock()
+	execution, ok := s.running[hash]
+	s.runningLock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	execution.quitOnce.Do(func() {
+		close(execution.quit)
+	})
+
+	// If the swap is queued, our main loop removes it from the queue and
+	// marks it as done. If it is already executing, closing its quit
+	// channel stops it, so there is nothing for the main loop to do.
+	select {
+	case s.stopQueued <- execution:
+
+	case <-execution.done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-execution.done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkpoint records the execution phase that each of the swaps that have
+// been handed to the executor and have not yet finished executing has
+// reached, replacing the checkpoints that we took previously. Swaps that have
+// not reached any phase yet are not checkpointed.
+func (s *executor) checkpoint() error {
+	now := s.clock.Now()
+
+	s.runningLock.Lock()
+	checkpoints := make([]*loopdb.Checkpoint, 0, len(s.running))
+	for hash, execution := range s.running {
+		phase := execution.swap.executionPhase()
+		if phase == loopdb.PhaseNone {
+			continue
+		}
+
+		checkpoints = append(checkpoints, &loopdb.Checkpoint{
+			SwapHash: hash,
+			Phase:    phase,
+			Time:     now,
+		})
+	}
+	s.runningLock.Unlock()
+
+	log.Infof("Checkpointing %v in-flight swaps", len(checkpoints))
+
+	return s.store.StoreCheckpoints(checkpoints)
+}
+
 // height returns the current height known to the swap server.
 func (s *executor) height() int32 {
 	return int32(atomic.LoadUint32(&s.currentHeight))
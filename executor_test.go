@@ -0,0 +1,275 @@
+package loop
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// mockExecution is a swap that runs until it is released or stopped.
+type mockExecution struct {
+	// swapKit provides the execution phase of the swap.
+	swapKit
+
+	hash lntypes.Hash
+
+	// started is closed when the swap starts executing.
+	started chan struct{}
+
+	// release is closed to complete execution of the swap.
+	release chan struct{}
+}
+
+func newMockExecution(hash lntypes.Hash) *mockExecution {
+	return &mockExecution{
+		hash:    hash,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (m *mockExecution) execute(ctx context.Context, _ *executeConfig,
+	_ int32) error {
+
+	close(m.started)
+
+	select {
+	case <-m.release:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *mockExecution) swapHash() lntypes.Hash {
+	return m.hash
+}
+
+// requireStarted asserts that a swap has started executing.
+func requireStarted(t *testing.T, swap *mockExecution) {
+	t.Helper()
+
+	select {
+	case <-swap.started:
+	case <-time.After(test.Timeout):
+		t.Fatalf("swap: %v not started", swap.hash)
+	}
+}
+
+// requireNotStarted asserts that a swap has not started executing.
+func requireNotStarted(t *testing.T, swap *mockExecution) {
+	t.Helper()
+
+	select {
+	case <-swap.started:
+		t.Fatalf("swap: %v unexpectedly started", swap.hash)
+	default:
+	}
+}
+
+// TestExecutorMaxConcurrentSwaps tests that swaps are queued once our
+// maximum number of swaps are executing, and that queued swaps are started in
+// the order they were received.
+func TestExecutorMaxConcurrentSwaps(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	executor := newExecutor(&executorConfig{
+		lnd:                &lnd.LndServices,
+		maxConcurrentSwaps: 1,
+		clock:              clock.NewDefaultClock(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error)
+	go func() {
+		runErr <- executor.run(ctx, make(chan SwapInfo))
+	}()
+
+	select {
+	case <-executor.ready:
+	case <-time.After(test.Timeout):
+		t.Fatalf("executor not ready")
+	}
+
+	var (
+		swap1 = newMockExecution(lntypes.Hash{1})
+		swap2 = newMockExecution(lntypes.Hash{2})
+		swap3 = newMockExecution(lntypes.Hash{3})
+	)
+
+	// Our first swap is started immediately, and the following swaps are
+	// queued because we are executing our maximum number of swaps.
+	executor.initiateSwap(ctx, swap1)
+	requireStarted(t, swap1)
+
+	executor.initiateSwap(ctx, swap2)
+	executor.initiateSwap(ctx, swap3)
+	requireNotStarted(t, swap2)
+	requireNotStarted(t, swap3)
+
+	// Stopping a queued swap removes it from our queue without starting
+	// it.
+	require.NoError(t, executor.stopSwap(ctx, swap2.hash))
+	requireNotStarted(t, swap2)
+
+	// When our executing swap completes, the next swap in our queue is
+	// started.
+	close(swap1.release)
+	requireStarted(t, swap3)
+	requireNotStarted(t, swap2)
+
+	close(swap3.release)
+
+	cancel()
+	require.Equal(t, context.Canceled, <-runErr)
+
+	executor.waitFinished()
+}
+
+// TestExecutorResumedSwaps tests that resumed swaps are started immediately
+// even if we are executing our maximum number of swaps, and that they count
+// towards the limit for new swaps.
+func TestExecutorResumedSwaps(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	executor := newExecutor(&executorConfig{
+		lnd:                &lnd.LndServices,
+		maxConcurrentSwaps: 1,
+		clock:              clock.NewDefaultClock(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error)
+	go func() {
+		runErr <- executor.run(ctx, make(chan SwapInfo))
+	}()
+
+	select {
+	case <-executor.ready:
+	case <-time.After(test.Timeout):
+		t.Fatalf("executor not ready")
+	}
+
+	var (
+		resumed1 = newMockExecution(lntypes.Hash{1})
+		resumed2 = newMockExecution(lntypes.Hash{2})
+		newSwap  = newMockExecution(lntypes.Hash{3})
+	)
+
+	// Both of our resumed swaps are started, even though this exceeds our
+	// maximum number of swaps.
+	executor.resumeSwap(ctx, resumed1)
+	executor.resumeSwap(ctx, resumed2)
+	requireStarted(t, resumed1)
+	requireStarted(t, resumed2)
+
+	// A new swap is queued, and remains queued while we are at our
+	// maximum after one of our resumed swaps completes.
+	executor.initiateSwap(ctx, newSwap)
+	requireNotStarted(t, newSwap)
+
+	close(resumed1.release)
+	requireNotStarted(t, newSwap)
+
+	// Once we are below our maximum, the new swap is started.
+	close(resumed2.release)
+	requireStarted(t, newSwap)
+
+	close(newSwap.release)
+
+	cancel()
+	require.Equal(t, context.Canceled, <-runErr)
+
+	executor.waitFinished()
+}
+
+// TestExecutorCheckpoint tests that we checkpoint the execution phase of the
+// swaps that are handed to the executor, including swaps that are queued.
+func TestExecutorCheckpoint(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	store := newStoreMock(t)
+	testClock := clock.NewTestClock(testTime)
+
+	executor := newExecutor(&executorConfig{
+		lnd:                &lnd.LndServices,
+		store:              store,
+		maxConcurrentSwaps: 1,
+		clock:              testClock,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error)
+	go func() {
+		runErr <- executor.run(ctx, make(chan SwapInfo))
+	}()
+
+	select {
+	case <-executor.ready:
+	case <-time.After(test.Timeout):
+		t.Fatalf("executor not ready")
+	}
+
+	var (
+		swap1 = newMockExecution(lntypes.Hash{1})
+		swap2 = newMockExecution(lntypes.Hash{2})
+		swap3 = newMockExecution(lntypes.Hash{3})
+	)
+
+	// Our first swap is executing, and our second and third swaps are
+	// queued. Our third swap has not reached any phase yet, so it should
+	// not be checkpointed.
+	executor.initiateSwap(ctx, swap1)
+	requireStarted(t, swap1)
+
+	executor.initiateSwap(ctx, swap2)
+	executor.initiateSwap(ctx, swap3)
+
+	swap1.setPhase(loopdb.PhaseSweepPublished)
+	swap2.setPhase(loopdb.PhasePaymentSent)
+
+	require.NoError(t, executor.checkpoint())
+	require.Equal(t, map[lntypes.Hash]*loopdb.Checkpoint{
+		swap1.hash: {
+			SwapHash: swap1.hash,
+			Phase:    loopdb.PhaseSweepPublished,
+			Time:     testTime,
+		},
+		swap2.hash: {
+			SwapHash: swap2.hash,
+			Phase:    loopdb.PhasePaymentSent,
+			Time:     testTime,
+		},
+	}, store.checkpoints)
+
+	// Phases never move backwards.
+	swap1.setPhase(loopdb.PhasePaymentSent)
+	require.Equal(t, loopdb.PhaseSweepPublished, swap1.executionPhase())
+
+	// Once our first swap has completed, it should no longer be
+	// checkpointed.
+	close(swap1.release)
+	requireStarted(t, swap2)
+
+	require.NoError(t, executor.checkpoint())
+	require.Len(t, store.checkpoints, 1)
+	require.Contains(t, store.checkpoints, swap2.hash)
+
+	cancel()
+	require.Equal(t, context.Canceled, <-runErr)
+
+	executor.waitFinished()
+}
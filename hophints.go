@@ -0,0 +1,125 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+const (
+	// maxHopHints is the maximum number of hop hints that we include in
+	// our loop in invoices, which matches the limit that lnd uses for its
+	// own invoices.
+	maxHopHints = 20
+)
+
+var (
+	// ErrPrivateRouteHints is returned when a loop in request both asks us
+	// to select hop hints for our private channels and provides its own
+	// route hints.
+	ErrPrivateRouteHints = errors.New("private and route hints are " +
+		"mutually exclusive")
+
+	// ErrNoHopHints is returned when a loop in is restricted to private
+	// channels, but we do not have any private channels that the server
+	// could route the swap payment through.
+	ErrNoHopHints = errors.New("no active private channels with " +
+		"sufficient inbound liquidity")
+)
+
+// selectHopHints returns a route hint for each of our active private channels
+// that has enough inbound liquidity to receive the amount provided, up to
+// maxHopHints. If a last hop is set, only channels with that peer are
+// included. Each hint uses the routing policy that our peer has set for the
+// channel, because the server's payment will be forwarded to us by our peer.
+func selectHopHints(ctx context.Context, lnd lndclient.LightningClient,
+	amount btcutil.Amount, lastHop *route.Vertex) ([][]zpay32.HopHint,
+	error) {
+
+	channels, err := lnd.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var hints [][]zpay32.HopHint
+	for _, channel := range channels {
+		if len(hints) == maxHopHints {
+			break
+		}
+
+		if !channel.Private || !channel.Active {
+			continue
+		}
+
+		if channel.RemoteBalance < amount {
+			continue
+		}
+
+		if lastHop != nil && channel.PubKeyBytes != *lastHop {
+			continue
+		}
+
+		edge, err := lnd.GetChanInfo(ctx, channel.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Our peer's policy is the one for the direction of the
+		// channel that they advertise as a node.
+		policy := edge.Node1Policy
+		if edge.Node2 == channel.PubKeyBytes {
+			policy = edge.Node2Policy
+		}
+
+		// If our peer has not set a policy for the channel, or they
+		// have disabled it, we cannot produce a usable hint.
+		if policy == nil || policy.Disabled {
+			continue
+		}
+
+		hint, err := newHopHint(channel.PubKeyBytes, channel.ChannelID,
+			policy)
+		if err != nil {
+			return nil, err
+		}
+
+		hints = append(hints, []zpay32.HopHint{hint})
+	}
+
+	if len(hints) == 0 {
+		return nil, ErrNoHopHints
+	}
+
+	return hints, nil
+}
+
+// newHopHint creates a hop hint for a channel with the peer provided, using
+// the routing policy that the peer has set for the channel.
+func newHopHint(peer route.Vertex, chanID uint64,
+	policy *lndclient.RoutingPolicy) (zpay32.HopHint, error) {
+
+	pubkey, err := btcec.ParsePubKey(peer[:], btcec.S256())
+	if err != nil {
+		return zpay32.HopHint{}, err
+	}
+
+	if policy.TimeLockDelta > math.MaxUint16 {
+		return zpay32.HopHint{}, fmt.Errorf("time lock delta: %v "+
+			"exceeds maximum", policy.TimeLockDelta)
+	}
+
+	return zpay32.HopHint{
+		NodeID:                    pubkey,
+		ChannelID:                 chanID,
+		FeeBaseMSat:               uint32(policy.FeeBaseMsat),
+		FeeProportionalMillionths: uint32(policy.FeeRateMilliMsat),
+		CLTVExpiryDelta:           uint16(policy.TimeLockDelta),
+	}, nil
+}
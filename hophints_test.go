@@ -0,0 +1,109 @@
+package loop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectHopHints tests selection of hop hints for our private channels.
+func TestSelectHopHints(t *testing.T) {
+	_, peer1Key := test.CreateKey(1)
+	_, peer2Key := test.CreateKey(2)
+	_, ourKey := test.CreateKey(3)
+
+	var peer1, peer2, us route.Vertex
+	copy(peer1[:], peer1Key.SerializeCompressed())
+	copy(peer2[:], peer2Key.SerializeCompressed())
+	copy(us[:], ourKey.SerializeCompressed())
+
+	newChannel := func(id uint64, peer route.Vertex, private,
+		active bool, remote btcutil.Amount) lndclient.ChannelInfo {
+
+		return lndclient.ChannelInfo{
+			ChannelID:     id,
+			PubKeyBytes:   peer,
+			Private:       private,
+			Active:        active,
+			RemoteBalance: remote,
+		}
+	}
+
+	policy := &lndclient.RoutingPolicy{
+		TimeLockDelta:    40,
+		FeeBaseMsat:      1000,
+		FeeRateMilliMsat: 1,
+	}
+
+	var (
+		// Our first peer is node 2 on our private channel, so we
+		// expect their policy to be used.
+		privateChan = newChannel(1, peer1, true, true, 10000)
+
+		// Our second peer is node 1 on our private channel.
+		lastHopChan = newChannel(2, peer2, true, true, 10000)
+
+		publicChan   = newChannel(3, peer1, false, true, 10000)
+		inactiveChan = newChannel(4, peer1, true, false, 10000)
+		lowInbound   = newChannel(5, peer1, true, true, 100)
+	)
+
+	lnd := test.NewMockLnd()
+	lnd.Channels = []lndclient.ChannelInfo{
+		privateChan, lastHopChan, publicChan, inactiveChan, lowInbound,
+	}
+	lnd.ChannelEdges = map[uint64]*lndclient.ChannelEdge{
+		privateChan.ChannelID: {
+			Node1:       us,
+			Node2:       peer1,
+			Node2Policy: policy,
+		},
+		lastHopChan.ChannelID: {
+			Node1:       peer2,
+			Node2:       us,
+			Node1Policy: policy,
+		},
+	}
+
+	hint := func(peer route.Vertex, chanID uint64) []zpay32.HopHint {
+		pubkey, err := btcec.ParsePubKey(peer[:], btcec.S256())
+		require.NoError(t, err)
+
+		return []zpay32.HopHint{{
+			NodeID:                    pubkey,
+			ChannelID:                 chanID,
+			FeeBaseMSat:               1000,
+			FeeProportionalMillionths: 1,
+			CLTVExpiryDelta:           40,
+		}}
+	}
+
+	ctx := context.Background()
+
+	// With no last hop set, we expect a hint for each of our active
+	// private channels with sufficient inbound liquidity.
+	hints, err := selectHopHints(ctx, lnd.Client, 5000, nil)
+	require.NoError(t, err)
+	require.Equal(t, [][]zpay32.HopHint{
+		hint(peer1, privateChan.ChannelID),
+		hint(peer2, lastHopChan.ChannelID),
+	}, hints)
+
+	// If we set a last hop, we only expect the channel with that peer.
+	hints, err = selectHopHints(ctx, lnd.Client, 5000, &peer2)
+	require.NoError(t, err)
+	require.Equal(t, [][]zpay32.HopHint{
+		hint(peer2, lastHopChan.ChannelID),
+	}, hints)
+
+	// If none of our channels have enough inbound liquidity, we fail.
+	_, err = selectHopHints(ctx, lnd.Client, 20000, nil)
+	require.Equal(t, ErrNoHopHints, err)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
 )
 
 // OutRequest contains the required parameters for a loop out swap.
@@ -19,6 +20,13 @@ type OutRequest struct {
 	// Destination address for the swap.
 	DestAddr btcutil.Address
 
+	// DestFromXpub indicates that the swap should sweep to a fresh
+	// address derived from the client's destination xpub rather than to
+	// DestAddr, which must not be set. The address is derived when the
+	// swap is created, and its index is only marked as used once the swap
+	// has been stored, so that failed swaps do not use up indexes.
+	DestFromXpub bool
+
 	// MaxSwapRoutingFee is the maximum off-chain fee in msat that may be
 	// paid for payment to the server. This limit is applied during path
 	// finding. Typically this value is taken from the response of the
@@ -61,11 +69,13 @@ type OutRequest struct {
 	MaxMinerFee btcutil.Amount
 
 	// SweepConfTarget specifies the targeted confirmation target for the
-	// client sweep tx.
+	// client sweep tx. If zero, the target is set by the client's
+	// confirmation policy.
 	SweepConfTarget int32
 
 	// HtlcConfirmations specifies the number of confirmations we require
-	// for on chain loop out htlcs.
+	// for on chain loop out htlcs. If zero, the number is set by the
+	// client's confirmation policy.
 	HtlcConfirmations int32
 
 	// OutgoingChanSet optionally specifies the short channel ids of the
@@ -76,16 +86,53 @@ type OutRequest struct {
 	// delaying publication of the swap HTLC to save on chain fees.
 	SwapPublicationDeadline time.Time
 
+	// ServerHtlcConfTarget is an optional confirmation target that we
+	// would like the server to use when publishing the on-chain htlc. This
+	// is only a hint, the target that the server commits to is recorded
+	// with the swap. If zero, we express no preference.
+	ServerHtlcConfTarget int32
+
+	// SwapPaymentDest is the node pubkey that the server quoted as the
+	// destination of the swap payment. If set, the server's invoices must
+	// pay to this node. This value is typically taken from the response of
+	// the LoopOutQuote call.
+	SwapPaymentDest [33]byte
+
+	// MaxParts is an optional maximum number of parts that the off-chain
+	// swap and prepay payments may be split into. If zero, the client's
+	// configured maximum is used.
+	MaxParts uint32
+
 	// Expiry is the absolute expiry height of the on-chain htlc.
 	Expiry int32
 
 	// Label contains an optional label for the swap.
 	Label string
 
+	// IdempotencyKey is an optional client-generated key that identifies
+	// the request. If a swap was already created with the same key, it is
+	// returned instead of creating a new swap.
+	IdempotencyKey string
+
 	// Initiator is an optional string that identifies what software
 	// initiated the swap (loop CLI, autolooper, LiT UI and so on) and is
 	// appended to the user agent string.
 	Initiator string
+
+	// PaymentTimeout is an optional timeout for the off-chain swap and
+	// prepay payments. If zero, the client's configured payment timeout
+	// is used.
+	PaymentTimeout time.Duration
+
+	// Priority is the execution priority of the swap. It adjusts our
+	// default payment timeout, how aggressively we bump the fee of our
+	// sweep and how often we retry publishing it.
+	Priority loopdb.SwapPriority
+
+	// destXpubIndex is the index of our destination xpub that DestAddr was
+	// derived from, if the request sweeps to our xpub. It is set by the
+	// client.
+	destXpubIndex *loopdb.XpubIndex
 }
 
 // Out contains the full details of a loop out request. This includes things
@@ -119,10 +166,12 @@ type LoopOutQuoteRequest struct {
 	// delaying publication of the swap HTLC to save on chain fees.
 	SwapPublicationDeadline time.Time
 
-	// TODO: Add argument to specify confirmation target for server
-	// publishing htlc. This may influence the swap fee quote, because the
-	// server needs to pay more for faster confirmations.
-	//
+	// ServerHtlcConfTarget is an optional confirmation target that we
+	// would like the server to use when publishing the on-chain htlc. This
+	// may influence the swap fee quote, because the server needs to pay
+	// more for faster confirmations. If zero, we express no preference.
+	ServerHtlcConfTarget int32
+
 	// TODO: Add arguments to specify maximum total time locks for the
 	// off-chain swap payment and prepayment. This may influence the
 	// available routes and off-chain fee estimates. To apply these maximum
@@ -164,6 +213,11 @@ type LoopOutQuote struct {
 	// SwapPaymentDest is the node pubkey where to swap payment needs to be
 	// sent to.
 	SwapPaymentDest [33]byte
+
+	// ServerHtlcConfTarget is the confirmation target that the server
+	// quoted for publication of the on-chain htlc. This is zero if the
+	// server did not take our preference into account.
+	ServerHtlcConfTarget int32
 }
 
 // LoopInRequest contains the required parameters for the swap.
@@ -195,6 +249,17 @@ type LoopInRequest struct {
 	// payment.
 	LastHop *route.Vertex
 
+	// RouteHints optionally specifies route hints that are included in
+	// the swap and probe invoices, so that the server can route its
+	// payment to us over channels that are not announced.
+	RouteHints [][]zpay32.HopHint
+
+	// Private indicates that hop hints should be selected from our active
+	// private channels and included in the swap and probe invoices. If a
+	// last hop is set, only channels with that peer are used. This option
+	// may not be combined with RouteHints.
+	Private bool
+
 	// ExternalHtlc specifies whether the htlc is published by an external
 	// source.
 	ExternalHtlc bool
@@ -202,6 +267,11 @@ type LoopInRequest struct {
 	// Label contains an optional label for the swap.
 	Label string
 
+	// IdempotencyKey is an optional client-generated key that identifies
+	// the request. If a swap was already created with the same key, it is
+	// returned instead of creating a new swap.
+	IdempotencyKey string
+
 	// Initiator is an optional string that identifies what software
 	// initiated the swap (loop CLI, autolooper, LiT UI and so on) and is
 	// appended to the user agent string.
@@ -329,6 +399,40 @@ type SwapInfo struct {
 
 	// ExternalHtlc is set to true for external loop-in swaps.
 	ExternalHtlc bool
+
+	// ChannelFlow records how much of a loop out's swap payment flowed
+	// through its outgoing channel set. It is only set for successful loop
+	// out swaps that were restricted to a set of channels.
+	ChannelFlow *loopdb.ChannelFlow
+
+	// ServerHtlcConfTarget is the htlc publication confirmation target
+	// that the server committed to for a loop out swap. It is zero if we
+	// did not request a target or the server did not honour it.
+	ServerHtlcConfTarget int32
+
+	// MaxSwapRoutingFee is the maximum off-chain fee that we are willing
+	// to pay to route a loop out's swap payment. It is zero for loop in
+	// swaps.
+	MaxSwapRoutingFee btcutil.Amount
+
+	// MaxPrepayRoutingFee is the maximum off-chain fee that we are willing
+	// to pay to route a loop out's prepay payment. It is zero for loop in
+	// swaps.
+	MaxPrepayRoutingFee btcutil.Amount
+
+	// HtlcConfTarget is the confirmation target that we used to publish
+	// the on-chain htlc of a loop in swap. It is zero for loop out swaps
+	// and loop in swaps with an externally published htlc.
+	HtlcConfTarget int32
+
+	// RefundTx is the most recent refund that we published to reclaim the
+	// htlc of a timed out loop in. It is nil for loop out swaps, and loop
+	// in swaps that have not been refunded.
+	RefundTx *loopdb.RefundTx
+
+	// Milestones holds the times at which the swap reached each of the
+	// milestones that have been recorded for it.
+	Milestones loopdb.Milestones
 }
 
 // LastUpdate returns the last update time of the swap
@@ -23,6 +23,10 @@ const (
 	// autoIn is the label used for loop in swaps that are automatically
 	// dispatched.
 	autoIn = "autoloop-in"
+
+	// staticAddress is the label used for loop in swaps that are
+	// dispatched for deposits to our static address.
+	staticAddress = "static-address-in"
 )
 
 var (
@@ -44,6 +48,12 @@ func AutoloopLabel(swapType swap.Type) string {
 	return fmt.Sprintf("%v: %v", Reserved, autoIn)
 }
 
+// StaticAddressLabel returns a label with the reserved prefix that identifies
+// loop in swaps that were dispatched for deposits to our static address.
+func StaticAddressLabel() string {
+	return fmt.Sprintf("%v: %v", Reserved, staticAddress)
+}
+
 // Validate checks that a label is of appropriate length and is not in our list
 // of reserved labels.
 func Validate(label string) error {
@@ -7,16 +7,28 @@ const (
 	// transactions in the lnd backend.
 	loopdLabelPattern = "loopd -- %s(swap=%s)"
 
+	// loopdBatchLabelPattern is the pattern that loop uses to label
+	// on-chain transactions that are shared by multiple swaps.
+	loopdBatchLabelPattern = "loopd -- %s(batch=%d)"
+
 	// loopOutSweepSuccess is the label used for loop out swaps to sweep
 	// the HTLC in the success case.
 	loopOutSweepSuccess = "OutSweepSuccess"
 
+	// loopOutBatchSweep is the label used for transactions that sweep the
+	// HTLCs of multiple loop out swaps in the success case.
+	loopOutBatchSweep = "OutBatchSweep"
+
 	// loopInHtlc is the label used for loop in swaps to publish an HTLC.
 	loopInHtlc = "InHtlc"
 
 	// loopInTimeout is the label used for loop in swaps to sweep an HTLC
 	// that has timed out.
 	loopInSweepTimeout = "InSweepTimeout"
+
+	// recoverySweep is the label used for transactions that manually
+	// sweep the HTLC of a swap in recovery mode.
+	recoverySweep = "RecoverySweep"
 )
 
 // LoopOutSweepSuccess returns the label used for loop out swaps to sweep the
@@ -25,6 +37,12 @@ func LoopOutSweepSuccess(swapHash string) string {
 	return fmt.Sprintf(loopdLabelPattern, loopOutSweepSuccess, swapHash)
 }
 
+// LoopOutBatchSweep returns the label used for transactions that sweep the
+// HTLCs of multiple loop out swaps in the success case.
+func LoopOutBatchSweep(batchID uint64) string {
+	return fmt.Sprintf(loopdBatchLabelPattern, loopOutBatchSweep, batchID)
+}
+
 // LoopInHtlcLabel returns the label used for loop in swaps to publish an HTLC.
 func LoopInHtlcLabel(swapHash string) string {
 	return fmt.Sprintf(loopdLabelPattern, loopInHtlc, swapHash)
@@ -35,3 +53,9 @@ func LoopInHtlcLabel(swapHash string) string {
 func LoopInSweepTimeout(swapHash string) string {
 	return fmt.Sprintf(loopdLabelPattern, loopInSweepTimeout, swapHash)
 }
+
+// RecoverySweep returns the label used for transactions that manually sweep
+// the HTLC of a swap in recovery mode.
+func RecoverySweep(swapHash string) string {
+	return fmt.Sprintf(loopdLabelPattern, recoverySweep, swapHash)
+}
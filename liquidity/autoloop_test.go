@@ -156,6 +156,7 @@ func TestAutoLoopEnabled(t *testing.T) {
 			OutgoingChanSet: loopdb.ChannelSet{chanID1.ToUint64()},
 			Label:           labels.AutoloopLabel(swap.TypeOut),
 			Initiator:       autoloopSwapInitiator,
+			Priority:        loopdb.PriorityLow,
 		}
 
 		chan2Swap = &loop.OutRequest{
@@ -171,6 +172,7 @@ func TestAutoLoopEnabled(t *testing.T) {
 			OutgoingChanSet: loopdb.ChannelSet{chanID2.ToUint64()},
 			Label:           labels.AutoloopLabel(swap.TypeOut),
 			Initiator:       autoloopSwapInitiator,
+			Priority:        loopdb.PriorityLow,
 		}
 
 		loopOuts = []loopOutRequestResp{
@@ -273,6 +275,113 @@ func TestAutoLoopEnabled(t *testing.T) {
 	c.stop()
 }
 
+// TestAutoLoopResize tests the case where the server's maximum swap amount
+// decreases between our swap being suggested and dispatched. We expect the
+// swap to be resized to the new maximum and re-quoted rather than failing.
+func TestAutoLoopResize(t *testing.T) {
+	defer test.Guard(t)()
+
+	var (
+		channels = []lndclient.ChannelInfo{
+			channel1,
+		}
+
+		swapFeePPM   uint64 = 1000
+		routeFeePPM  uint64 = 1000
+		prepayFeePPM uint64 = 1000
+		prepayAmount        = btcutil.Amount(20000)
+		maxMiner            = btcutil.Amount(20000)
+
+		params = Parameters{
+			Autoloop:         true,
+			AutoFeeBudget:    100000,
+			AutoFeeStartDate: testTime,
+			MaxAutoInFlight:  1,
+			FailureBackOff:   time.Hour,
+			SweepConfTarget:  10,
+			FeeLimit: NewFeeCategoryLimit(
+				swapFeePPM, routeFeePPM, prepayFeePPM, maxMiner,
+				prepayAmount, 20000,
+			),
+			ChannelRules: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			},
+		}
+	)
+	c := newAutoloopTestCtx(t, params, channels, testRestrictions)
+	c.start()
+
+	var (
+		amt     = chan1Rec.Amount
+		resized = amt / 2
+
+		quote = &loop.LoopOutQuote{
+			SwapFee:      ppmToSat(amt, swapFeePPM),
+			PrepayAmount: prepayAmount - 10,
+			MinerFee:     maxMiner - 10,
+		}
+
+		resizedQuote = &loop.LoopOutQuote{
+			SwapFee:      ppmToSat(resized, swapFeePPM),
+			PrepayAmount: prepayAmount - 20,
+			MinerFee:     maxMiner - 20,
+		}
+
+		quotes = []quoteRequestResp{
+			{
+				request: &loop.LoopOutQuoteRequest{
+					Amount:          amt,
+					SweepConfTarget: params.SweepConfTarget,
+				},
+				quote: quote,
+			},
+		}
+
+		loopOuts = []loopOutRequestResp{
+			{
+				request: &loop.OutRequest{
+					Amount: resized,
+					MaxSwapRoutingFee: ppmToSat(
+						resized, routeFeePPM,
+					),
+					MaxPrepayRoutingFee: ppmToSat(
+						resizedQuote.PrepayAmount,
+						prepayFeePPM,
+					),
+					MaxSwapFee:      resizedQuote.SwapFee,
+					MaxPrepayAmount: resizedQuote.PrepayAmount,
+					MaxMinerFee:     maxMiner,
+					SweepConfTarget: params.SweepConfTarget,
+					OutgoingChanSet: loopdb.ChannelSet{
+						chanID1.ToUint64(),
+					},
+					Label: labels.AutoloopLabel(
+						swap.TypeOut,
+					),
+					Initiator: autoloopSwapInitiator,
+					Priority:  loopdb.PriorityLow,
+				},
+				response: &loop.LoopOutSwapInfo{
+					SwapHash: lntypes.Hash{1},
+				},
+				requote: &quoteRequestResp{
+					request: &loop.LoopOutQuoteRequest{
+						Amount: resized,
+					},
+					quote: resizedQuote,
+				},
+			},
+		}
+	)
+
+	// Tick our autolooper, lowering the server's maximum to our resized
+	// amount once our swap has been suggested. We expect our swap to be
+	// re-quoted and dispatched with the smaller amount.
+	c.autoloopDispatch(1, amt+1, resized, nil, quotes, loopOuts)
+
+	c.stop()
+}
+
 // TestCompositeRules tests the case where we have rules set on a per peer
 // and per channel basis, and perform swaps for both targets.
 func TestCompositeRules(t *testing.T) {
@@ -361,6 +470,7 @@ func TestCompositeRules(t *testing.T) {
 			},
 			Label:     labels.AutoloopLabel(swap.TypeOut),
 			Initiator: autoloopSwapInitiator,
+			Priority:  loopdb.PriorityLow,
 		}
 		// Create a quote for our single channel swap that is within
 		// our budget.
@@ -393,6 +503,7 @@ func TestCompositeRules(t *testing.T) {
 			OutgoingChanSet: loopdb.ChannelSet{chanID1.ToUint64()},
 			Label:           labels.AutoloopLabel(swap.TypeOut),
 			Initiator:       autoloopSwapInitiator,
+			Priority:        loopdb.PriorityLow,
 		}
 		quotes = []quoteRequestResp{
 			{
@@ -101,10 +101,12 @@ func newAutoloopTestCtx(t *testing.T, parameters Parameters,
 
 			return <-testCtx.loopOutRestrictions, nil
 		},
-		ListLoopOut: func() ([]*loopdb.LoopOut, error) {
+		ListLoopOut: func(_ context.Context) (
+			[]*loopdb.LoopOut, error) {
+
 			return <-testCtx.loopOuts, nil
 		},
-		ListLoopIn: func() ([]*loopdb.LoopIn, error) {
+		ListLoopIn: func(_ context.Context) ([]*loopdb.LoopIn, error) {
 			return <-testCtx.loopIns, nil
 		},
 		LoopOutQuote: func(_ context.Context,
@@ -171,10 +173,12 @@ type quoteRequestResp struct {
 }
 
 // loopOutRequestResp pairs an expected loop out request with the response we
-// would like the server to respond with.
+// would like the server to respond with. If requote is set, we expect the
+// swap to be re-quoted before it is dispatched.
 type loopOutRequestResp struct {
 	request  *loop.OutRequest
 	response *loop.LoopOutSwapInfo
+	requote  *quoteRequestResp
 }
 
 // autoloop walks our test context through the process of triggering our
@@ -187,6 +191,18 @@ func (c *autoloopTestCtx) autoloop(minAmt, maxAmt btcutil.Amount,
 	existingOut []*loopdb.LoopOut, quotes []quoteRequestResp,
 	expectedSwaps []loopOutRequestResp) {
 
+	c.autoloopDispatch(
+		minAmt, maxAmt, maxAmt, existingOut, quotes, expectedSwaps,
+	)
+}
+
+// autoloopDispatch walks our test context through an autoloop tick where the
+// server's maximum swap amount changes to dispatchMax between our swaps being
+// suggested and dispatched.
+func (c *autoloopTestCtx) autoloopDispatch(minAmt, maxAmt,
+	dispatchMax btcutil.Amount, existingOut []*loopdb.LoopOut,
+	quotes []quoteRequestResp, expectedSwaps []loopOutRequestResp) {
+
 	// Tick our autoloop ticker to force assessing whether we want to loop.
 	c.manager.cfg.AutoloopTicker.Force <- testTime
 
@@ -213,8 +229,23 @@ func (c *autoloopTestCtx) autoloop(minAmt, maxAmt btcutil.Amount,
 		c.quotes <- expected.quote
 	}
 
+	// If we expect swaps to be dispatched, the server's restrictions are
+	// queried again before dispatch.
+	if len(expectedSwaps) > 0 {
+		c.loopOutRestrictions <- NewRestrictions(minAmt, dispatchMax)
+	}
+
 	// Assert that we dispatch the expected set of swaps.
 	for _, expected := range expectedSwaps {
+		if expected.requote != nil {
+			request := <-c.quoteRequest
+			assert.Equal(
+				c.t, expected.requote.request.Amount,
+				request.Amount,
+			)
+			c.quotes <- expected.requote.quote
+		}
+
 		actual := <-c.outRequest
 
 		// Set our destination address to nil so that we do not need to
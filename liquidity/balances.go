@@ -10,13 +10,19 @@ import (
 // balances summarizes the state of the balances of a channel. Channel reserve,
 // fees and pending htlc balances are not included in these balances.
 type balances struct {
-	// capacity is the total capacity of the channel.
+	// capacity is the capacity of the channel that is available to its
+	// local and remote balances. This excludes the amount locked in
+	// pending htlcs and both parties' channel reserves, so that our
+	// thresholds are expressed in terms of balance that can actually
+	// move.
 	capacity btcutil.Amount
 
-	// incoming is the remote balance of the channel.
+	// incoming is the remote balance of the channel, less the remote
+	// party's channel reserve.
 	incoming btcutil.Amount
 
-	// outgoing is the local balance of the channel.
+	// outgoing is the local balance of the channel, less our channel
+	// reserve.
 	outgoing btcutil.Amount
 
 	// channels is the channel that has these balances represent. This may
@@ -30,13 +36,58 @@ type balances struct {
 
 // newBalances creates a balances struct from lndclient channel information.
 func newBalances(info lndclient.ChannelInfo) *balances {
-	return &balances{
-		capacity: info.Capacity,
-		incoming: info.RemoteBalance,
-		outgoing: info.LocalBalance,
-		channels: []lnwire.ShortChannelID{
-			lnwire.NewShortChanIDFromInt(info.ChannelID),
-		},
+	bal := &balances{
 		pubkey: info.PubKeyBytes,
 	}
+	bal.add(info)
+
+	return bal
+}
+
+// add adds the available balances of the channel provided to our balances.
+// Lnd does not include pending htlcs in a channel's local and remote
+// balances, but they are still part of its capacity, so we remove them from
+// the capacity that we count. Channel reserves can't be used by either party,
+// so we remove them from both the balance of the party that has to keep them
+// and from the capacity.
+func (b *balances) add(info lndclient.ChannelInfo) {
+	var localReserve, remoteReserve btcutil.Amount
+	if info.LocalConstraints != nil {
+		localReserve = info.LocalConstraints.Reserve
+	}
+
+	if info.RemoteConstraints != nil {
+		remoteReserve = info.RemoteConstraints.Reserve
+	}
+
+	var pending btcutil.Amount
+	for _, htlc := range info.PendingHtlcs {
+		pending += htlc.Amount
+	}
+
+	outgoing := availableBalance(info.LocalBalance, localReserve)
+	incoming := availableBalance(info.RemoteBalance, remoteReserve)
+
+	capacity := info.Capacity - pending -
+		(info.LocalBalance - outgoing) - (info.RemoteBalance - incoming)
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	b.capacity += capacity
+	b.incoming += incoming
+	b.outgoing += outgoing
+	b.channels = append(
+		b.channels, lnwire.NewShortChanIDFromInt(info.ChannelID),
+	)
+}
+
+// availableBalance returns the portion of a balance that is not locked in the
+// channel reserve provided.
+func availableBalance(balance, reserve btcutil.Amount) btcutil.Amount {
+	if balance <= reserve {
+		return 0
+	}
+
+	return balance - reserve
 }
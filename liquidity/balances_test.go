@@ -0,0 +1,95 @@
+package liquidity
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewBalances tests that pending htlcs and channel reserves are excluded
+// from the balances that we use for our rules.
+func TestNewBalances(t *testing.T) {
+	var (
+		localReserve = &lndclient.ChannelConstraints{
+			Reserve: 100,
+		}
+
+		remoteReserve = &lndclient.ChannelConstraints{
+			Reserve: 200,
+		}
+	)
+
+	tests := []struct {
+		name     string
+		channel  lndclient.ChannelInfo
+		expected *balances
+	}{
+		{
+			name:    "no reserves or htlcs",
+			channel: channel1,
+			expected: &balances{
+				capacity: 10000,
+				outgoing: 10000,
+				channels: []lnwire.ShortChannelID{chanID1},
+				pubkey:   peer1,
+			},
+		},
+		{
+			name: "reserves and htlcs",
+			channel: lndclient.ChannelInfo{
+				ChannelID:         chanID1.ToUint64(),
+				PubKeyBytes:       peer1,
+				Capacity:          10000,
+				LocalBalance:      5000,
+				RemoteBalance:     3000,
+				LocalConstraints:  localReserve,
+				RemoteConstraints: remoteReserve,
+				PendingHtlcs: []lndclient.PendingHtlc{
+					{
+						Incoming: true,
+						Amount:   1000,
+					},
+					{
+						Incoming: false,
+						Amount:   500,
+					},
+				},
+			},
+			expected: &balances{
+				capacity: 8200,
+				outgoing: 4900,
+				incoming: 2800,
+				channels: []lnwire.ShortChannelID{chanID1},
+				pubkey:   peer1,
+			},
+		},
+		{
+			name: "balance below reserve",
+			channel: lndclient.ChannelInfo{
+				ChannelID:        chanID1.ToUint64(),
+				PubKeyBytes:      peer1,
+				Capacity:         10000,
+				LocalBalance:     50,
+				RemoteBalance:    9950,
+				LocalConstraints: localReserve,
+			},
+			expected: &balances{
+				capacity: 9950,
+				incoming: 9950,
+				channels: []lnwire.ShortChannelID{chanID1},
+				pubkey:   peer1,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := newBalances(testCase.channel)
+			require.Equal(t, testCase.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package liquidity
+
+import (
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// AutoloopStatus describes the outcome of our most recent autoloop check, and
+// the conditions that are currently preventing swaps from being dispatched.
+type AutoloopStatus struct {
+	// LastCheck is the time of our most recent autoloop check. It is zero
+	// if we have not checked our liquidity yet.
+	LastCheck time.Time
+
+	// LastChange is the time at which the outcome of our autoloop checks
+	// last changed.
+	LastChange time.Time
+
+	// Err is the error that our most recent check failed with, if any.
+	Err error
+
+	// DisqualifiedChans maps the channels that swaps were not suggested
+	// for in our most recent check to the reason that they were excluded.
+	DisqualifiedChans map[lnwire.ShortChannelID]Reason
+
+	// DisqualifiedPeers maps the peers that swaps were not suggested for
+	// in our most recent check to the reason that they were excluded.
+	DisqualifiedPeers map[route.Vertex]Reason
+
+	// Budget summarizes the use of our fee budget at the time of our most
+	// recent check. It is nil if the check did not get as far as
+	// examining our budget.
+	Budget *Budget
+
+	// Events is the set of changes in the outcome of our checks and the
+	// swaps that were dispatched, ordered from oldest to newest. Only our
+	// most recent events are kept.
+	Events []Event
+}
+
+// decisionLog keeps track of the outcome of our autoloop checks. Because we
+// check our liquidity on every tick, the same outcome is usually repeated
+// many times, so we only log the channels and peers whose outcome changed and
+// make the current outcome available through our status instead. The changes
+// that we log are also recorded as events, so that they can be audited.
+type decisionLog struct {
+	status AutoloopStatus
+	events []Event
+	mu     sync.Mutex
+}
+
+// newDecisionLog creates a decision log with no recorded checks.
+func newDecisionLog() *decisionLog {
+	return &decisionLog{
+		status: AutoloopStatus{
+			DisqualifiedChans: make(
+				map[lnwire.ShortChannelID]Reason,
+			),
+			DisqualifiedPeers: make(map[route.Vertex]Reason),
+		},
+	}
+}
+
+// record updates our status with the outcome of an autoloop check, and logs
+// the parts of the outcome that differ from our previous check. Suggestions
+// may be nil if the check failed.
+func (d *decisionLog) record(now time.Time, suggestions *Suggestions,
+	err error) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	chans := make(map[lnwire.ShortChannelID]Reason)
+	peers := make(map[route.Vertex]Reason)
+	var budget *Budget
+	if suggestions != nil {
+		chans = suggestions.DisqualifiedChans
+		peers = suggestions.DisqualifiedPeers
+		budget = suggestions.Budget
+	}
+
+	d.recordEvents(now, chans, peers, err)
+	changed := d.logErr(err)
+
+	// If every rule was excluded for the same reason, we log a single
+	// line rather than one for every channel and peer.
+	if reason, ok := singleReason(chans, peers); ok {
+		if !sameReasons(d.status, chans, peers) {
+			log.Infof("Autoloop suggestions disqualified for all "+
+				"rules: %v", reason)
+
+			changed = true
+		}
+	} else {
+		for id, reason := range chans {
+			if prev, ok := d.status.DisqualifiedChans[id]; ok &&
+				prev == reason {
+
+				continue
+			}
+
+			log.Infof("Autoloop suggestions disqualified for "+
+				"channel: %v: %v", id, reason)
+			changed = true
+		}
+
+		for peer, reason := range peers {
+			if prev, ok := d.status.DisqualifiedPeers[peer]; ok &&
+				prev == reason {
+
+				continue
+			}
+
+			log.Infof("Autoloop suggestions disqualified for "+
+				"peer: %x: %v", peer, reason)
+			changed = true
+		}
+	}
+
+	for id := range d.status.DisqualifiedChans {
+		if _, ok := chans[id]; !ok {
+			log.Infof("Autoloop suggestions no longer "+
+				"disqualified for channel: %v", id)
+			changed = true
+		}
+	}
+
+	for peer := range d.status.DisqualifiedPeers {
+		if _, ok := peers[peer]; !ok {
+			log.Infof("Autoloop suggestions no longer "+
+				"disqualified for peer: %x", peer)
+			changed = true
+		}
+	}
+
+	if changed || d.status.LastChange.IsZero() {
+		d.status.LastChange = now
+	}
+
+	d.status.LastCheck = now
+	d.status.Err = err
+	d.status.DisqualifiedChans = chans
+	d.status.DisqualifiedPeers = peers
+	d.status.Budget = budget
+}
+
+// recordEvents adds an event for every change between the outcome of our
+// previous check and the outcome provided. It must be called before our
+// status is updated, and with our mutex held.
+func (d *decisionLog) recordEvents(now time.Time,
+	chans map[lnwire.ShortChannelID]Reason,
+	peers map[route.Vertex]Reason, err error) {
+
+	var prev, current string
+	if d.status.Err != nil {
+		prev = d.status.Err.Error()
+	}
+	if err != nil {
+		current = err.Error()
+	}
+
+	switch {
+	case prev == current:
+
+	case err == nil:
+		d.addEvent(Event{
+			Time: now,
+			Type: EventCheckRecovered,
+			Err:  prev,
+		})
+
+	default:
+		d.addEvent(Event{
+			Time: now,
+			Type: EventCheckFailed,
+			Err:  current,
+		})
+	}
+
+	for id, reason := range chans {
+		if prev, ok := d.status.DisqualifiedChans[id]; ok &&
+			prev == reason {
+
+			continue
+		}
+
+		d.addEvent(Event{
+			Time:    now,
+			Type:    EventDisqualified,
+			Channel: id,
+			Reason:  reason,
+		})
+	}
+
+	for peer, reason := range peers {
+		if prev, ok := d.status.DisqualifiedPeers[peer]; ok &&
+			prev == reason {
+
+			continue
+		}
+
+		d.addEvent(Event{
+			Time:   now,
+			Type:   EventDisqualified,
+			Peer:   peer,
+			Reason: reason,
+		})
+	}
+
+	for id := range d.status.DisqualifiedChans {
+		if _, ok := chans[id]; !ok {
+			d.addEvent(Event{
+				Time:    now,
+				Type:    EventRequalified,
+				Channel: id,
+			})
+		}
+	}
+
+	for peer := range d.status.DisqualifiedPeers {
+		if _, ok := peers[peer]; !ok {
+			d.addEvent(Event{
+				Time: now,
+				Type: EventRequalified,
+				Peer: peer,
+			})
+		}
+	}
+}
+
+// recordLoopOut adds an event for a loop out that autoloop dispatched.
+func (d *decisionLog) recordLoopOut(now time.Time, hash lntypes.Hash,
+	amount btcutil.Amount) {
+
+	d.recordDispatch(now, hash, swap.TypeOut, amount)
+}
+
+// recordLoopIn adds an event for a loop in that autoloop dispatched.
+func (d *decisionLog) recordLoopIn(now time.Time, hash lntypes.Hash,
+	amount btcutil.Amount) {
+
+	d.recordDispatch(now, hash, swap.TypeIn, amount)
+}
+
+// recordDispatch adds an event for a swap that autoloop dispatched.
+func (d *decisionLog) recordDispatch(now time.Time, hash lntypes.Hash,
+	swapType swap.Type, amount btcutil.Amount) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.addEvent(Event{
+		Time:     now,
+		Type:     EventSwapDispatched,
+		SwapHash: hash,
+		SwapType: swapType,
+		Amount:   amount,
+	})
+}
+
+// addEvent appends an event to our set of events, dropping our oldest event
+// if we have reached our maximum. It must be called with our mutex held.
+func (d *decisionLog) addEvent(event Event) {
+	d.events = append(d.events, event)
+
+	if len(d.events) > maxEvents {
+		d.events = d.events[len(d.events)-maxEvents:]
+	}
+}
+
+// logErr logs changes in the error that our checks fail with, and returns a
+// boolean that indicates whether the error changed. Errors are compared by
+// message because most of them are created anew on every check. Unexpected
+// errors are not logged here, because they are already logged every time
+// that autoloop fails.
+func (d *decisionLog) logErr(err error) bool {
+	var prev, current string
+	if d.status.Err != nil {
+		prev = d.status.Err.Error()
+	}
+	if err != nil {
+		current = err.Error()
+	}
+
+	if prev == current {
+		return false
+	}
+
+	switch err {
+	case nil:
+		log.Infof("Autoloop checks no longer failing: %v", prev)
+
+	case ErrNoRules:
+		log.Debugf("No rules configured for autoloop")
+
+	case ErrServerUnreachable:
+		log.Warnf("Swap server unreachable, autoloop paused")
+	}
+
+	return true
+}
+
+// getStatus returns a copy of our current status.
+func (d *decisionLog) getStatus() AutoloopStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := d.status
+	status.DisqualifiedChans = make(
+		map[lnwire.ShortChannelID]Reason, len(d.status.DisqualifiedChans),
+	)
+	for id, reason := range d.status.DisqualifiedChans {
+		status.DisqualifiedChans[id] = reason
+	}
+
+	status.DisqualifiedPeers = make(
+		map[route.Vertex]Reason, len(d.status.DisqualifiedPeers),
+	)
+	for peer, reason := range d.status.DisqualifiedPeers {
+		status.DisqualifiedPeers[peer] = reason
+	}
+
+	if d.status.Budget != nil {
+		budget := *d.status.Budget
+		status.Budget = &budget
+	}
+
+	status.Events = make([]Event, len(d.events))
+	copy(status.Events, d.events)
+
+	return status
+}
+
+// singleReason returns the reason that all of the channels and peers provided
+// were disqualified for, and a boolean that is false if they were not all
+// disqualified for the same reason, or there are fewer than two of them.
+func singleReason(chans map[lnwire.ShortChannelID]Reason,
+	peers map[route.Vertex]Reason) (Reason, bool) {
+
+	if len(chans)+len(peers) < 2 {
+		return ReasonNone, false
+	}
+
+	reasons := make(map[Reason]struct{})
+	for _, reason := range chans {
+		reasons[reason] = struct{}{}
+	}
+	for _, reason := range peers {
+		reasons[reason] = struct{}{}
+	}
+
+	if len(reasons) != 1 {
+		return ReasonNone, false
+	}
+
+	for reason := range reasons {
+		return reason, true
+	}
+
+	return ReasonNone, false
+}
+
+// sameReasons returns a boolean indicating whether a status has the same set
+// of disqualified channels and peers as the maps provided.
+func sameReasons(status AutoloopStatus,
+	chans map[lnwire.ShortChannelID]Reason,
+	peers map[route.Vertex]Reason) bool {
+
+	if len(status.DisqualifiedChans) != len(chans) ||
+		len(status.DisqualifiedPeers) != len(peers) {
+
+		return false
+	}
+
+	for id, reason := range chans {
+		if prev, ok := status.DisqualifiedChans[id]; !ok ||
+			prev != reason {
+
+			return false
+		}
+	}
+
+	for peer, reason := range peers {
+		if prev, ok := status.DisqualifiedPeers[peer]; !ok ||
+			prev != reason {
+
+			return false
+		}
+	}
+
+	return true
+}
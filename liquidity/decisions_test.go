@@ -0,0 +1,237 @@
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/ticker"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDecisionLog tests that our decision log only records a change in our
+// status when the outcome of our autoloop checks changes.
+func TestDecisionLog(t *testing.T) {
+	var (
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		chan2 = lnwire.NewShortChanIDFromInt(2)
+		peer1 = route.Vertex{1}
+
+		start = time.Unix(1000, 0)
+		tick1 = start.Add(time.Minute)
+		tick2 = tick1.Add(time.Minute)
+		tick3 = tick2.Add(time.Minute)
+		tick4 = tick3.Add(time.Minute)
+
+		errTest = errors.New("lnd unavailable")
+	)
+
+	inFlight := newSuggestions()
+	inFlight.DisqualifiedChans[chan1] = ReasonInFlight
+	inFlight.DisqualifiedChans[chan2] = ReasonInFlight
+	inFlight.DisqualifiedPeers[peer1] = ReasonInFlight
+
+	decisions := newDecisionLog()
+	require.True(t, decisions.getStatus().LastCheck.IsZero())
+
+	// Our first check is always a change.
+	decisions.record(start, inFlight, nil)
+	status := decisions.getStatus()
+	require.Equal(t, start, status.LastCheck)
+	require.Equal(t, start, status.LastChange)
+	require.Equal(t, inFlight.DisqualifiedChans, status.DisqualifiedChans)
+	require.Equal(t, inFlight.DisqualifiedPeers, status.DisqualifiedPeers)
+
+	// Repeating the same outcome should only update our last check.
+	repeat := newSuggestions()
+	repeat.DisqualifiedChans[chan1] = ReasonInFlight
+	repeat.DisqualifiedChans[chan2] = ReasonInFlight
+	repeat.DisqualifiedPeers[peer1] = ReasonInFlight
+
+	decisions.record(tick1, repeat, nil)
+	status = decisions.getStatus()
+	require.Equal(t, tick1, status.LastCheck)
+	require.Equal(t, start, status.LastChange)
+
+	// When one channel's reason changes, we record a change.
+	changed := newSuggestions()
+	changed.DisqualifiedChans[chan1] = ReasonLoopOut
+	changed.DisqualifiedChans[chan2] = ReasonInFlight
+
+	decisions.record(tick2, changed, nil)
+	status = decisions.getStatus()
+	require.Equal(t, tick2, status.LastChange)
+	require.Equal(t, changed.DisqualifiedChans, status.DisqualifiedChans)
+	require.Len(t, status.DisqualifiedPeers, 0)
+
+	// A failed check is a change, and clears our disqualified set.
+	decisions.record(tick3, nil, errTest)
+	status = decisions.getStatus()
+	require.Equal(t, tick3, status.LastChange)
+	require.Equal(t, errTest, status.Err)
+	require.Len(t, status.DisqualifiedChans, 0)
+
+	// Failing with an equivalent error is not a change.
+	decisions.record(tick4, nil, errors.New(errTest.Error()))
+	status = decisions.getStatus()
+	require.Equal(t, tick4, status.LastCheck)
+	require.Equal(t, tick3, status.LastChange)
+
+	// Modifying the status we returned should not affect our log.
+	status.DisqualifiedChans[chan1] = ReasonLoopIn
+	require.Len(t, decisions.getStatus().DisqualifiedChans, 0)
+}
+
+// TestDecisionLogEvents tests that our decision log records an event for
+// every change in the outcome of our checks, and limits the number of events
+// that it keeps.
+func TestDecisionLogEvents(t *testing.T) {
+	var (
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		peer1 = route.Vertex{1}
+
+		start = time.Unix(1000, 0)
+		tick1 = start.Add(time.Minute)
+		tick2 = tick1.Add(time.Minute)
+		tick3 = tick2.Add(time.Minute)
+
+		errTest = errors.New("lnd unavailable")
+	)
+
+	disqualified := newSuggestions()
+	disqualified.DisqualifiedChans[chan1] = ReasonLoopOut
+	disqualified.DisqualifiedPeers[peer1] = ReasonInFlight
+
+	decisions := newDecisionLog()
+	decisions.record(start, disqualified, nil)
+	require.ElementsMatch(t, []Event{
+		{
+			Time:    start,
+			Type:    EventDisqualified,
+			Channel: chan1,
+			Reason:  ReasonLoopOut,
+		},
+		{
+			Time:   start,
+			Type:   EventDisqualified,
+			Peer:   peer1,
+			Reason: ReasonInFlight,
+		},
+	}, decisions.getStatus().Events)
+
+	// Repeating the same outcome does not add any events.
+	decisions.record(tick1, disqualified, nil)
+	require.Len(t, decisions.getStatus().Events, 2)
+
+	// A failed check clears our disqualified set, so our channel and
+	// peer are requalified.
+	decisions.record(tick2, nil, errTest)
+	require.ElementsMatch(t, []Event{
+		{
+			Time: tick2,
+			Type: EventCheckFailed,
+			Err:  errTest.Error(),
+		},
+		{
+			Time:    tick2,
+			Type:    EventRequalified,
+			Channel: chan1,
+		},
+		{
+			Time: tick2,
+			Type: EventRequalified,
+			Peer: peer1,
+		},
+	}, decisions.getStatus().Events[2:])
+
+	// When our checks succeed again, we record a recovery.
+	decisions.record(tick3, newSuggestions(), nil)
+	events := decisions.getStatus().Events
+	require.Equal(t, Event{
+		Time: tick3,
+		Type: EventCheckRecovered,
+		Err:  errTest.Error(),
+	}, events[len(events)-1])
+
+	// Dispatched swaps are recorded, and we only keep our most recent
+	// events.
+	for i := 0; i < maxEvents; i++ {
+		decisions.recordLoopOut(tick3, lntypes.Hash{1}, 1000)
+	}
+
+	events = decisions.getStatus().Events
+	require.Len(t, events, maxEvents)
+	require.Equal(t, Event{
+		Time:     tick3,
+		Type:     EventSwapDispatched,
+		SwapHash: lntypes.Hash{1},
+		SwapType: swap.TypeOut,
+		Amount:   1000,
+	}, events[0])
+}
+
+// TestServerUnreachable tests that autoloop is paused, and reports that it is
+// paused, while the swap server is unreachable.
+func TestServerUnreachable(t *testing.T) {
+	cfg, _ := newTestConfig()
+
+	var serverErr error
+	cfg.CheckServerHealth = func(context.Context) error {
+		return serverErr
+	}
+
+	manager := NewManager(cfg)
+
+	serverErr = errors.New("unavailable")
+	err := manager.autoloop(context.Background())
+	require.Equal(t, ErrServerUnreachable, err)
+	require.Equal(t, ErrServerUnreachable, manager.decisions.getStatus().Err)
+
+	// Once the server is reachable again, we proceed to our suggestions,
+	// and fail because we have no rules set.
+	serverErr = nil
+	err = manager.autoloop(context.Background())
+	require.Equal(t, ErrNoRules, err)
+	require.Equal(t, ErrNoRules, manager.decisions.getStatus().Err)
+}
+
+// TestTriggerAutoloop tests triggering of immediate autoloop evaluations.
+func TestTriggerAutoloop(t *testing.T) {
+	cfg, _ := newTestConfig()
+
+	// We use a ticker that will not tick during our test, so that our
+	// evaluation can only be triggered.
+	cfg.AutoloopTicker = ticker.NewForce(time.Hour)
+	manager := NewManager(cfg)
+
+	// If our manager is not running, our trigger should fail once its
+	// context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := manager.TriggerAutoloop(ctx)
+	require.True(t, errors.Is(err, context.Canceled))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- manager.Run(ctx)
+	}()
+
+	// Once running, our evaluation is performed and we fail because we
+	// have no rules set.
+	status, err := manager.TriggerAutoloop(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ErrNoRules, status.Err)
+	require.Equal(t, testTime, status.LastCheck)
+
+	cancel()
+	require.True(t, errors.Is(<-errChan, context.Canceled))
+}
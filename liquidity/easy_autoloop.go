@@ -0,0 +1,104 @@
+package liquidity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// easyAutoloopTolerance is the percentage that our local balance may exceed
+// our local balance target by before we suggest swaps in easy autoloop mode.
+// This prevents us from swapping small amounts every time our balance moves
+// slightly above our target.
+const easyAutoloopTolerance = 5
+
+var (
+	// ErrInvalidLocalBalanceTarget is returned when our local balance
+	// target is not a percentage that leaves room for our tolerance.
+	ErrInvalidLocalBalanceTarget = fmt.Errorf("local balance target must "+
+		"be in [%v:%v]", easyAutoloopTolerance,
+		100-easyAutoloopTolerance)
+
+	// ErrEasyAutoloopRules is returned when a local balance target is set
+	// along with channel or peer rules.
+	ErrEasyAutoloopRules = errors.New("channel and peer rules cannot be " +
+		"set with a local balance target")
+)
+
+// newEasyAutoloopRule returns the threshold rule that we use for channels in
+// easy autoloop mode. The rule suggests loop outs when a channel's local
+// balance exceeds our target by more than our tolerance, and aims to restore
+// the channel's balance to our target.
+func newEasyAutoloopRule(target int) *ThresholdRule {
+	return NewThresholdRule(
+		100-target-easyAutoloopTolerance, target-easyAutoloopTolerance,
+	)
+}
+
+// easyAutoloopRules generates the channel rules that we use to move our total
+// local balance towards the target percentage provided. If our total local
+// balance exceeds our target by more than our tolerance, rules are generated
+// for our fullest channels until loop outs on them would cover our excess
+// balance. Disqualified channels are neither counted towards our balance nor
+// used for swaps.
+func easyAutoloopRules(channels []lndclient.ChannelInfo,
+	params Parameters) map[lnwire.ShortChannelID]*ThresholdRule {
+
+	var (
+		capacity, local btcutil.Amount
+		eligible        []lndclient.ChannelInfo
+		rules           = make(map[lnwire.ShortChannelID]*ThresholdRule)
+	)
+
+	for _, channel := range channels {
+		chanID := lnwire.NewShortChanIDFromInt(channel.ChannelID)
+		if params.disqualified(chanID, channel.PubKeyBytes) ||
+			params.inactiveExcluded(channel) {
+
+			continue
+		}
+
+		bal := newBalances(channel)
+		capacity += bal.capacity
+		local += bal.outgoing
+		eligible = append(eligible, channel)
+	}
+
+	target := params.LocalBalanceTarget
+	maxLocal := capacity * btcutil.Amount(target+easyAutoloopTolerance) /
+		100
+
+	if local <= maxLocal {
+		return rules
+	}
+
+	excess := local - capacity*btcutil.Amount(target)/100
+
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].LocalBalance > eligible[j].LocalBalance
+	})
+
+	for _, channel := range eligible {
+		if excess <= 0 {
+			break
+		}
+
+		// If our rule would not suggest a swap for this channel, its
+		// balance is already close enough to our target.
+		rule := newEasyAutoloopRule(target)
+		amount := rule.requiredAmount(newBalances(channel))
+		if amount == 0 {
+			continue
+		}
+
+		chanID := lnwire.NewShortChanIDFromInt(channel.ChannelID)
+		rules[chanID] = rule
+		excess -= amount
+	}
+
+	return rules
+}
@@ -0,0 +1,144 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEasyAutoloopRules tests generation of channel rules for our local
+// balance target.
+func TestEasyAutoloopRules(t *testing.T) {
+	newChannel := func(id lnwire.ShortChannelID,
+		local, remote btcutil.Amount) lndclient.ChannelInfo {
+
+		return lndclient.ChannelInfo{
+			ChannelID:     id.ToUint64(),
+			PubKeyBytes:   peer1,
+			LocalBalance:  local,
+			RemoteBalance: remote,
+			Capacity:      10000,
+		}
+	}
+
+	rule := newEasyAutoloopRule(50)
+
+	tests := []struct {
+		name         string
+		channels     []lndclient.ChannelInfo
+		disqualified map[lnwire.ShortChannelID]struct{}
+		expected     map[lnwire.ShortChannelID]*ThresholdRule
+	}{
+		{
+			name: "within tolerance",
+			channels: []lndclient.ChannelInfo{
+				newChannel(chanID1, 5500, 4500),
+			},
+			expected: map[lnwire.ShortChannelID]*ThresholdRule{},
+		},
+		{
+			name: "fullest channel covers excess",
+			channels: []lndclient.ChannelInfo{
+				newChannel(chanID1, 7000, 3000),
+				newChannel(chanID2, 9000, 1000),
+				newChannel(chanID3, 2000, 8000),
+			},
+			expected: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID2: rule,
+			},
+		},
+		{
+			name: "multiple channels required",
+			channels: []lndclient.ChannelInfo{
+				newChannel(chanID1, 8000, 2000),
+				newChannel(chanID2, 8000, 2000),
+				newChannel(chanID3, 4000, 6000),
+			},
+			expected: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: rule,
+				chanID2: rule,
+			},
+		},
+		{
+			name: "disqualified channel excluded",
+			channels: []lndclient.ChannelInfo{
+				newChannel(chanID1, 7000, 3000),
+				newChannel(chanID2, 9000, 1000),
+				newChannel(chanID3, 2000, 8000),
+			},
+			disqualified: map[lnwire.ShortChannelID]struct{}{
+				chanID2: {},
+			},
+			expected: map[lnwire.ShortChannelID]*ThresholdRule{},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			params := defaultParameters
+			params.LocalBalanceTarget = 50
+			params.DisqualifiedChannels = testCase.disqualified
+
+			rules := easyAutoloopRules(testCase.channels, params)
+			require.Equal(t, testCase.expected, rules)
+		})
+	}
+}
+
+// TestEasyAutoloopParameters tests validation of our local balance target.
+func TestEasyAutoloopParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		target int
+		rules  map[lnwire.ShortChannelID]*ThresholdRule
+		err    error
+	}{
+		{
+			name:   "valid target",
+			target: 50,
+			err:    nil,
+		},
+		{
+			name:   "target too low",
+			target: easyAutoloopTolerance - 1,
+			err:    ErrInvalidLocalBalanceTarget,
+		},
+		{
+			name:   "target too high",
+			target: 100 - easyAutoloopTolerance + 1,
+			err:    ErrInvalidLocalBalanceTarget,
+		},
+		{
+			name:   "target with rules",
+			target: 50,
+			rules: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: NewThresholdRule(10, 10),
+			},
+			err: ErrEasyAutoloopRules,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, _ := newTestConfig()
+			manager := NewManager(cfg)
+
+			params := defaultParameters
+			params.LocalBalanceTarget = testCase.target
+			params.ChannelRules = testCase.rules
+
+			err := manager.SetParameters(
+				context.Background(), params,
+			)
+			require.Equal(t, testCase.err, err)
+		})
+	}
+}
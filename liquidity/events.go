@@ -0,0 +1,153 @@
+package liquidity
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// maxEvents is the number of autoloop events that we keep in memory. Once
+// we reach this limit, our oldest events are dropped.
+const maxEvents = 100
+
+// EventType is an enum which describes the kinds of events that we record
+// for our autoloop checks.
+type EventType uint8
+
+const (
+	// EventNone is the zero value event type, added so that this enum can
+	// align with the numeric values used in our protobufs and avoid
+	// ambiguity around default zero values.
+	EventNone EventType = iota
+
+	// EventDisqualified indicates that a channel or peer was excluded
+	// from our suggestions, or that the reason it was excluded changed.
+	EventDisqualified
+
+	// EventRequalified indicates that a channel or peer that was
+	// previously excluded from our suggestions is no longer excluded.
+	EventRequalified
+
+	// EventCheckFailed indicates that our autoloop checks started failing,
+	// or that the error they fail with changed.
+	EventCheckFailed
+
+	// EventCheckRecovered indicates that our autoloop checks are no longer
+	// failing.
+	EventCheckRecovered
+
+	// EventSwapDispatched indicates that a swap was automatically
+	// dispatched.
+	EventSwapDispatched
+)
+
+// String returns a string representation of an event type.
+func (e EventType) String() string {
+	switch e {
+	case EventNone:
+		return "none"
+
+	case EventDisqualified:
+		return "disqualified"
+
+	case EventRequalified:
+		return "requalified"
+
+	case EventCheckFailed:
+		return "check failed"
+
+	case EventCheckRecovered:
+		return "check recovered"
+
+	case EventSwapDispatched:
+		return "swap dispatched"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Event records a change in the outcome of our autoloop checks, or an action
+// that autoloop took. Only the fields that are relevant to an event's type
+// are set.
+type Event struct {
+	// Time is the time at which the event occurred.
+	Time time.Time
+
+	// Type is the kind of event.
+	Type EventType
+
+	// Channel is the channel that a disqualified or requalified event
+	// applies to. It is zero if the event is for a peer.
+	Channel lnwire.ShortChannelID
+
+	// Peer is the peer that a disqualified or requalified event applies
+	// to. It is zero if the event is for a channel.
+	Peer route.Vertex
+
+	// Reason is the reason that a channel or peer was disqualified.
+	Reason Reason
+
+	// Err is the error that our checks started failing with for failed
+	// events, or the error that they were failing with for recovered
+	// events.
+	Err string
+
+	// SwapHash is the hash of a dispatched swap.
+	SwapHash lntypes.Hash
+
+	// SwapType is the type of a dispatched swap.
+	SwapType swap.Type
+
+	// Amount is the amount of a dispatched swap.
+	Amount btcutil.Amount
+}
+
+// Budget summarizes the use of our autoloop fee budget at the time of our
+// most recent check.
+type Budget struct {
+	// Total is the total fee budget for automatically dispatched swaps.
+	Total btcutil.Amount
+
+	// StartDate is the date from which we count fees against our budget.
+	StartDate time.Time
+
+	// Spent is the amount of fees that completed swaps have spent since
+	// our start date.
+	Spent btcutil.Amount
+
+	// Pending is the worst-case amount of fees that our in flight swaps
+	// may spend.
+	Pending btcutil.Amount
+
+	// InFlight is the number of automatically dispatched swaps that are
+	// currently in flight.
+	InFlight int
+}
+
+// Available returns the amount of our budget that has not been spent or
+// allocated to in flight swaps.
+func (b *Budget) Available() btcutil.Amount {
+	used := b.Spent + b.Pending
+	if used >= b.Total {
+		return 0
+	}
+
+	return b.Total - used
+}
+
+// budgetSummary creates a summary of our budget from our current parameters
+// and a summary of our existing autoloops.
+func (m *Manager) budgetSummary(summary *existingAutoLoopSummary) *Budget {
+	return &Budget{
+		Total:     m.params.AutoFeeBudget,
+		StartDate: m.params.AutoFeeStartDate,
+		Spent:     summary.spentFees,
+		Pending:   summary.pendingFees,
+		InFlight:  summary.inFlightCount,
+	}
+}
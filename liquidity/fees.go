@@ -238,6 +238,36 @@ func (f *FeeCategoryLimit) loopOutFees(amount btcutil.Amount,
 	return prepayMaxFee, routeMaxFee, f.MaximumMinerFee
 }
 
+// loopInLimits checks whether the quote provided is within our fee limits
+// for a loop in swap.
+func (f *FeeCategoryLimit) loopInLimits(amount btcutil.Amount,
+	quote *loop.LoopInQuote) error {
+
+	maxFee := ppmToSat(amount, f.MaximumSwapFeePPM)
+
+	if quote.SwapFee > maxFee {
+		log.Debugf("quoted swap fee: %v > maximum swap fee: %v",
+			quote.SwapFee, maxFee)
+
+		return newReasonError(ReasonSwapFee)
+	}
+
+	if quote.MinerFee > f.MaximumMinerFee {
+		log.Debugf("quoted miner fee: %v > maximum miner "+
+			"fee: %v", quote.MinerFee, f.MaximumMinerFee)
+
+		return newReasonError(ReasonMinerFee)
+	}
+
+	return nil
+}
+
+// loopInMinerFee returns the maximum miner fee we are willing to pay for a
+// loop in swap's htlc, which is our miner fee limit.
+func (f *FeeCategoryLimit) loopInMinerFee(_ *loop.LoopInQuote) btcutil.Amount {
+	return f.MaximumMinerFee
+}
+
 // Compile time assertion that FeePortion implements FeeLimit interface.
 var _ FeeLimit = (*FeePortion)(nil)
 
@@ -367,6 +397,49 @@ func (f *FeePortion) loopOutFees(amount btcutil.Amount,
 	return prepayMaxFee, routeMaxFee, minerFee
 }
 
+// loopInLimits checks whether the quote provided is within our fee limits for
+// a loop in swap of the amount provided.
+func (f *FeePortion) loopInLimits(swapAmt btcutil.Amount,
+	quote *loop.LoopInQuote) error {
+
+	feeLimit := ppmToSat(swapAmt, f.PartsPerMillion)
+	minerFee := scaleMinerFee(quote.MinerFee)
+
+	if minerFee > feeLimit {
+		log.Debugf("miner fee: %v greater than fee limit: %v, at "+
+			"%v ppm", minerFee, feeLimit, f.PartsPerMillion)
+
+		return newReasonError(ReasonMinerFee)
+	}
+
+	if quote.SwapFee > feeLimit {
+		log.Debugf("swap fee: %v greater than fee limit: %v, at "+
+			"%v ppm", quote.SwapFee, feeLimit, f.PartsPerMillion)
+
+		return newReasonError(ReasonSwapFee)
+	}
+
+	// Calculate the worst case fees that we could pay for this swap,
+	// ensuring that we are within our fee limit even if the swap times
+	// out.
+	fees := worstCaseInFees(minerFee, quote.SwapFee)
+	if fees > feeLimit {
+		log.Debugf("total fees for swap: %v > fee limit: %v, at "+
+			"%v ppm", fees, feeLimit, f.PartsPerMillion)
+
+		return newReasonError(ReasonFeePPMInsufficient)
+	}
+
+	return nil
+}
+
+// loopInMinerFee returns the maximum miner fee we are willing to pay for a
+// loop in swap's htlc, which is the quote's miner fee scaled up to allow for
+// fee fluctuations. We assume that this value has already been validated.
+func (f *FeePortion) loopInMinerFee(quote *loop.LoopInQuote) btcutil.Amount {
+	return scaleMinerFee(quote.MinerFee)
+}
+
 // splitOffChain takes an available fee budget and divides it among our prepay
 // and swap payments proportional to their volume.
 func splitOffChain(available, prepayAmt,
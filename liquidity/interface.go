@@ -29,6 +29,14 @@ type FeeLimit interface {
 	// a swap amount and quote.
 	loopOutFees(amount btcutil.Amount, quote *loop.LoopOutQuote) (
 		btcutil.Amount, btcutil.Amount, btcutil.Amount)
+
+	// loopInLimits checks whether the quote provided is within our fee
+	// limits for a loop in swap of the amount provided.
+	loopInLimits(amount btcutil.Amount, quote *loop.LoopInQuote) error
+
+	// loopInMinerFee returns the maximum miner fee that we are willing to
+	// pay for a loop in swap's htlc, given the quote provided.
+	loopInMinerFee(quote *loop.LoopInQuote) btcutil.Amount
 }
 
 // swapSuggestion is an interface implemented by suggested swaps for our
@@ -102,3 +110,36 @@ func (l *loopOutSwapSuggestion) peers(
 
 	return peerList
 }
+
+// Compile-time assertion that loopInSwapSuggestion satisfies the
+// swapSuggestion interface.
+var _ swapSuggestion = (*loopInSwapSuggestion)(nil)
+
+type loopInSwapSuggestion struct {
+	loop.LoopInRequest
+}
+
+func (l *loopInSwapSuggestion) amount() btcutil.Amount {
+	return l.Amount
+}
+
+func (l *loopInSwapSuggestion) fees() btcutil.Amount {
+	return worstCaseInFees(l.MaxMinerFee, l.MaxSwapFee)
+}
+
+// channels returns no channels, because loop in swaps are restricted to a
+// last hop peer rather than a set of channels.
+func (l *loopInSwapSuggestion) channels() []lnwire.ShortChannelID {
+	return nil
+}
+
+// peers returns the last hop peer that the loop in swap is restricted to.
+func (l *loopInSwapSuggestion) peers(
+	_ map[uint64]route.Vertex) []route.Vertex {
+
+	if l.LastHop == nil {
+		return nil
+	}
+
+	return []route.Vertex{*l.LastHop}
+}
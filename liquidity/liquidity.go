@@ -49,6 +49,7 @@ import (
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/funding"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
@@ -81,6 +82,11 @@ const (
 	// autoloopSwapInitiator is the value we send in the initiator field of
 	// a swap request when issuing an automatic swap.
 	autoloopSwapInitiator = "autoloop"
+
+	// rebalanceCheaperFactor is the factor by which a circular rebalance
+	// must be cheaper than a swap for us to consider it clearly cheaper,
+	// since rebalance estimates do not account for payment failures.
+	rebalanceCheaperFactor = 2
 )
 
 var (
@@ -102,6 +108,10 @@ var (
 		FailureBackOff:  defaultFailureBackoff,
 		SweepConfTarget: defaultConfTarget,
 		FeeLimit:        defaultFeePortion(),
+		DisqualifiedChannels: make(
+			map[lnwire.ShortChannelID]struct{},
+		),
+		DisqualifiedPeers: make(map[route.Vertex]struct{}),
 	}
 
 	// ErrZeroChannelID is returned if we get a rule for a 0 channel ID.
@@ -135,6 +145,23 @@ var (
 	// set together are specified.
 	ErrExclusiveRules = errors.New("channel and peer rules must be " +
 		"exclusive")
+
+	// ErrLoopInChannelRule is returned when a loop in rule is set for a
+	// channel. Loop in swaps can only be restricted to a last hop peer,
+	// so loop in rules may only be set for peers.
+	ErrLoopInChannelRule = errors.New("loop in rules may only be set " +
+		"for peers")
+
+	// ErrTargetChannelRule is returned when a target rule is set for a
+	// channel. Target rules suggest loop in swaps, so they may only be set
+	// for peers.
+	ErrTargetChannelRule = errors.New("target rules may only be set " +
+		"for peers")
+
+	// ErrServerUnreachable is returned when autoloop is paused because
+	// the swap server is unreachable.
+	ErrServerUnreachable = errors.New("autoloop paused, swap server " +
+		"unreachable")
 )
 
 // Config contains the external functionality required to run the
@@ -154,10 +181,10 @@ type Config struct {
 	Lnd *lndclient.LndServices
 
 	// ListLoopOut returns all of the loop our swaps stored on disk.
-	ListLoopOut func() ([]*loopdb.LoopOut, error)
+	ListLoopOut func(ctx context.Context) ([]*loopdb.LoopOut, error)
 
 	// ListLoopIn returns all of the loop in swaps stored on disk.
-	ListLoopIn func() ([]*loopdb.LoopIn, error)
+	ListLoopIn func(ctx context.Context) ([]*loopdb.LoopIn, error)
 
 	// LoopOutQuote gets swap fee, estimated miner fee and prepay amount for
 	// a loop out swap.
@@ -168,12 +195,116 @@ type Config struct {
 	LoopOut func(ctx context.Context, request *loop.OutRequest) (
 		*loop.LoopOutSwapInfo, error)
 
+	// LoopInQuote gets swap fee and estimated miner fee for a loop in
+	// swap.
+	LoopInQuote func(ctx context.Context,
+		request *loop.LoopInQuoteRequest) (*loop.LoopInQuote, error)
+
+	// LoopIn dispatches a loop in.
+	LoopIn func(ctx context.Context, request *loop.LoopInRequest) (
+		*loop.LoopInSwapInfo, error)
+
 	// Clock allows easy mocking of time in unit tests.
 	Clock clock.Clock
 
 	// MinimumConfirmations is the minimum number of confirmations we allow
 	// setting for sweep target.
 	MinimumConfirmations int32
+
+	// MaxLockedValue is the maximum total amount that may be locked in
+	// pending swaps at any one time. We do not suggest swaps that would
+	// take us above this value. If zero, no limit is applied.
+	MaxLockedValue btcutil.Amount
+
+	// MaxLoopInWalletFraction is the maximum fraction of our on-chain
+	// funds that may be committed to pending loop ins at once. We do not
+	// suggest loop ins that would take us above this fraction. If zero, no
+	// limit is applied.
+	MaxLoopInWalletFraction float64
+
+	// EstimateRebalance estimates the off-chain fees for a circular
+	// rebalance that has the same effect on a channel's balance as a swap
+	// of the type and amount provided. It is optional; if it is not set,
+	// rebalances are never considered.
+	EstimateRebalance func(ctx context.Context,
+		channel lnwire.ShortChannelID, amount btcutil.Amount,
+		swapType swap.Type) (btcutil.Amount, error)
+
+	// ParamsChangeDelay is the amount of time that parameter changes
+	// which increase the fees autoloop may spend are held for before they
+	// take effect, so that a compromised credential cannot instantly
+	// drain our budget. Pending changes may be cancelled during this time.
+	// If zero, all changes take effect immediately.
+	ParamsChangeDelay time.Duration
+
+	// SnapshotInterval is how often we record snapshots of our channel
+	// balances, which are used by rules that require their thresholds to
+	// be breached for a minimum duration. If zero, no snapshots are taken.
+	SnapshotInterval time.Duration
+
+	// AddSnapshots stores a set of channel balance snapshots.
+	AddSnapshots func(snapshots []*loopdb.ChannelSnapshot) error
+
+	// ListSnapshots returns all the channel balance snapshots taken at or
+	// after the time provided, in chronological order.
+	ListSnapshots func(ctx context.Context, since time.Time) (
+		[]*loopdb.ChannelSnapshot, error)
+
+	// PruneSnapshots deletes the channel balance snapshots taken before
+	// the time provided.
+	PruneSnapshots func(ctx context.Context, before time.Time) error
+
+	// AddPeerOutcome records the outcome of a completed swap for a peer
+	// that it was routed through. If it or ListPeerOutcomes is not set,
+	// we do not track peer reputations.
+	AddPeerOutcome func(outcome *loopdb.PeerOutcome) error
+
+	// ListPeerOutcomes returns all the swap outcomes that we have recorded
+	// for our peers.
+	ListPeerOutcomes func(ctx context.Context) ([]*loopdb.PeerOutcome,
+		error)
+
+	// AddRuleSwap attributes a swap that we dispatched to the rule that it
+	// was suggested for. If it or ListRuleSwaps is not set, rules may not
+	// set their own fee budget.
+	AddRuleSwap func(ruleSwap *loopdb.RuleSwap) error
+
+	// ListRuleSwaps returns all the swaps that we have attributed to
+	// rules.
+	ListRuleSwaps func(ctx context.Context) ([]*loopdb.RuleSwap, error)
+
+	// CheckServerHealth returns an error if the swap server is
+	// unreachable. If it is set, autoloop is paused while the server is
+	// unreachable.
+	CheckServerHealth func(ctx context.Context) error
+
+	// ConfPolicy scales the sweep confirmation target of the loop outs
+	// that we suggest with their amount, unless the rule that a swap is
+	// suggested for sets its own target. The htlc confirmations of swaps
+	// that do not set their own are scaled by the client.
+	ConfPolicy loop.ConfPolicy
+
+	// BudgetExhausted is called with our fee budget when autoloop is
+	// enabled, and stops being able to dispatch swaps because the budget
+	// has been used up. It is called once each time this happens, rather
+	// than on every autoloop check. It may be nil.
+	BudgetExhausted func(budget btcutil.Amount)
+
+	// ReconcileInterval is how often we reconcile our budget against the
+	// realized fees of our completed swaps. If a reconciliation finds
+	// that swaps released budget that our last autoloop check found to be
+	// exhausted, we perform another check straight away. If zero, we do
+	// not reconcile our budget on a schedule.
+	ReconcileInterval time.Duration
+
+	// StaleRuleAge is the amount of time that a rule's channel or peer
+	// must be closed or disconnected for before we report the rule as
+	// stale. If zero, we do not track stale rules.
+	StaleRuleAge time.Duration
+
+	// PruneStaleRules indicates that we should remove stale rules from our
+	// parameters, rather than only reporting them.
+	PruneStaleRules bool
 }
 
 // Parameters is a set of parameters provided by the user which guide
@@ -202,6 +333,20 @@ type Parameters struct {
 	// TODO(carla): add exponential backoff
 	FailureBackOff time.Duration
 
+	// SwapCooldown is the amount of time that we require passes after a
+	// channel or peer was part of a successful swap before we suggest
+	// another swap for it. This prevents us from repeatedly swapping when
+	// a channel's balance flaps around its thresholds. If zero, no
+	// cooldown is applied.
+	SwapCooldown time.Duration
+
+	// PublicationDeadline is the amount of time that we allow the server
+	// to wait before it publishes the on chain htlc of automatically
+	// dispatched loop outs. A later deadline allows the server to batch
+	// htlcs, which may result in a lower swap fee. If zero, the server is
+	// asked to publish htlcs immediately.
+	PublicationDeadline time.Duration
+
 	// SweepConfTarget is the number of blocks we aim to confirm our sweep
 	// transaction in. This value affects the on chain fees we will pay.
 	SweepConfTarget int32
@@ -223,6 +368,48 @@ type Parameters struct {
 	// ChannelRules are exclusively set to prevent overlap between peer
 	// and channel rules map to avoid ambiguity.
 	PeerRules map[route.Vertex]*ThresholdRule
+
+	// DisqualifiedChannels is a set of channels that we never use for
+	// swaps. Swaps are not suggested for rules set on these channels, and
+	// they are not used for swaps suggested for their peer's rule.
+	DisqualifiedChannels map[lnwire.ShortChannelID]struct{}
+
+	// DisqualifiedPeers is a set of peers whose channels we never use for
+	// swaps, regardless of any rules set for the peer or its channels.
+	DisqualifiedPeers map[route.Vertex]struct{}
+
+	// PreferRebalance indicates that we should not suggest swaps for
+	// channel rules when a circular rebalance of the channel is estimated
+	// to cost less than 1/rebalanceCheaperFactor of the swap.
+	PreferRebalance bool
+
+	// LocalBalanceTarget enables easy autoloop mode, in which we do not
+	// use channel or peer rules, and instead keep our total local balance
+	// at this percentage of our total capacity by looping out from our
+	// fullest channels. If zero, easy autoloop mode is disabled.
+	LocalBalanceTarget int
+
+	// Schedule restricts the times at which autoloop dispatches swaps.
+	Schedule Schedule
+
+	// SweepFeeRateLimit is the maximum estimated fee rate to sweep within
+	// our sweep confirmation target at which we suggest swaps, regardless
+	// of the type of fee limit that is set. This prevents us from swapping
+	// during mempool fee spikes. If zero, no limit is applied.
+	SweepFeeRateLimit chainfee.SatPerKWeight
+
+	// RoundUpToMinimum indicates that when the amount required to restore
+	// a rule's thresholds is below the minimum swap amount, we suggest a
+	// swap of the minimum amount if it does not breach the rule's
+	// threshold on the other side of the channel.
+	RoundUpToMinimum bool
+
+	// ExcludeInactiveChannels indicates that channels which are not
+	// currently active are not used for swaps, and are not counted in the
+	// balances that we use for peer rules or easy autoloop. Funds in
+	// inactive channels can't be moved until the channel comes back
+	// online, so they should not be the basis of our suggestions.
+	ExcludeInactiveChannels bool
 }
 
 // String returns the string representation of our parameters.
@@ -242,17 +429,80 @@ func (p Parameters) String() string {
 
 	}
 
-	return fmt.Sprintf("rules: %v, failure backoff: %v, sweep "+
-		"sweep conf target: %v, fees: %v, auto budget: %v, budget "+
-		"start: %v, max auto in flight: %v, minimum swap size=%v, "+
-		"maximum swap size=%v", strings.Join(ruleList, ","),
-		p.FailureBackOff, p.SweepConfTarget, p.FeeLimit,
-		p.AutoFeeBudget, p.AutoFeeStartDate, p.MaxAutoInFlight,
-		p.ClientRestrictions.Minimum, p.ClientRestrictions.Maximum)
+	disqualified := make(
+		[]string, 0, len(p.DisqualifiedChannels)+
+			len(p.DisqualifiedPeers),
+	)
+
+	for channel := range p.DisqualifiedChannels {
+		disqualified = append(
+			disqualified, fmt.Sprintf("Channel: %v", channel),
+		)
+	}
+
+	for peer := range p.DisqualifiedPeers {
+		disqualified = append(
+			disqualified, fmt.Sprintf("Peer: %v", peer),
+		)
+	}
+
+	return fmt.Sprintf("rules: %v, disqualified: %v, failure backoff: "+
+		"%v, swap cooldown: %v, publication deadline: %v, sweep sweep "+
+		"conf target: %v, fees: %v, auto budget: %v, budget start: %v, "+
+		"max auto in flight: %v, minimum swap size=%v, maximum swap "+
+		"size=%v, prefer rebalance: %v, local balance target: %v, "+
+		"schedule: %v, sweep fee rate limit: %v, round up to "+
+		"minimum: %v, exclude inactive channels: %v",
+		strings.Join(ruleList, ","), strings.Join(disqualified, ","),
+		p.FailureBackOff, p.SwapCooldown, p.PublicationDeadline,
+		p.SweepConfTarget, p.FeeLimit, p.AutoFeeBudget,
+		p.AutoFeeStartDate, p.MaxAutoInFlight,
+		p.ClientRestrictions.Minimum, p.ClientRestrictions.Maximum,
+		p.PreferRebalance, p.LocalBalanceTarget, p.Schedule,
+		p.SweepFeeRateLimit, p.RoundUpToMinimum,
+		p.ExcludeInactiveChannels)
+}
+
+// sweepConfTarget returns the sweep confirmation target to use for a swap of
+// the amount provided that is suggested for the rule provided. If the rule
+// does not override the target, the target that the confirmation policy sets
+// for the amount is used, falling back to our global target.
+func (p Parameters) sweepConfTarget(rule *ThresholdRule,
+	policy loop.ConfPolicy, amount btcutil.Amount) int32 {
+
+	if rule.SweepConfTarget != 0 {
+		return rule.SweepConfTarget
+	}
+
+	return policy.SweepConfTarget(amount, p.SweepConfTarget)
+}
+
+// disqualified returns a boolean indicating whether a channel that we have with
+// the peer provided may not be used for swaps.
+func (p Parameters) disqualified(channel lnwire.ShortChannelID,
+	peer route.Vertex) bool {
+
+	if _, ok := p.DisqualifiedChannels[channel]; ok {
+		return true
+	}
+
+	_, ok := p.DisqualifiedPeers[peer]
+	return ok
+}
+
+// inactiveExcluded returns a boolean indicating whether the channel provided
+// may not be used for swaps because it is inactive.
+func (p Parameters) inactiveExcluded(channel lndclient.ChannelInfo) bool {
+	return p.ExcludeInactiveChannels && !channel.Active
 }
 
-// haveRules returns a boolean indicating whether we have any rules configured.
+// haveRules returns a boolean indicating whether we have any rules configured,
+// or we are in easy autoloop mode and generate our rules.
 func (p Parameters) haveRules() bool {
+	if p.LocalBalanceTarget != 0 {
+		return true
+	}
+
 	if len(p.ChannelRules) != 0 {
 		return true
 	}
@@ -264,6 +514,54 @@ func (p Parameters) haveRules() bool {
 	return false
 }
 
+// haveReputationRules returns a boolean indicating whether any of our rules
+// require a minimum peer reputation.
+func (p Parameters) haveReputationRules() bool {
+	for _, rule := range p.ChannelRules {
+		if rule.MinimumReputation != 0 {
+			return true
+		}
+	}
+
+	for _, rule := range p.PeerRules {
+		if rule.MinimumReputation != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// haveRuleBudgets returns a boolean indicating whether any of our rules set
+// their own fee budget.
+func (p Parameters) haveRuleBudgets() bool {
+	for _, rule := range p.ChannelRules {
+		if rule.FeeBudget != 0 {
+			return true
+		}
+	}
+
+	for _, rule := range p.PeerRules {
+		if rule.FeeBudget != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// haveLoopIn returns a boolean indicating whether any of our rules suggest
+// loop in swaps. Loop in and target rules may only be set for peers.
+func (p Parameters) haveLoopIn() bool {
+	for _, rule := range p.PeerRules {
+		if rule.Type == swap.TypeIn || rule.isTarget() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // validate checks whether a set of parameters is valid. Our set of currently
 // open channels are required to check that there is no overlap between the
 // rules set on a per-peer level, and those set for specific channels. We can't
@@ -304,10 +602,23 @@ func (p Parameters) validate(minConfs int32, openChans []lndclient.ChannelInfo,
 			return ErrZeroChannelID
 		}
 
+		if rule.Type == swap.TypeIn {
+			return ErrLoopInChannelRule
+		}
+
+		if rule.isTarget() {
+			return ErrTargetChannelRule
+		}
+
 		if err := rule.validate(); err != nil {
 			return fmt.Errorf("channel: %v has invalid rule: %v",
 				channel.ToUint64(), err)
 		}
+
+		if err := rule.validateConfTargets(minConfs); err != nil {
+			return fmt.Errorf("channel: %v has invalid rule: %v",
+				channel.ToUint64(), err)
+		}
 	}
 
 	for peer, rule := range p.PeerRules {
@@ -315,6 +626,29 @@ func (p Parameters) validate(minConfs int32, openChans []lndclient.ChannelInfo,
 			return fmt.Errorf("peer: %v has invalid rule: %v",
 				peer, err)
 		}
+
+		if err := rule.validateConfTargets(minConfs); err != nil {
+			return fmt.Errorf("peer: %v has invalid rule: %v",
+				peer, err)
+		}
+	}
+
+	for channel := range p.DisqualifiedChannels {
+		if channel.ToUint64() == 0 {
+			return ErrZeroChannelID
+		}
+	}
+
+	if p.LocalBalanceTarget != 0 {
+		if p.LocalBalanceTarget < easyAutoloopTolerance ||
+			p.LocalBalanceTarget > 100-easyAutoloopTolerance {
+
+			return ErrInvalidLocalBalanceTarget
+		}
+
+		if len(p.ChannelRules) != 0 || len(p.PeerRules) != 0 {
+			return ErrEasyAutoloopRules
+		}
 	}
 
 	// Check that our confirmation target is above our required minimum.
@@ -331,6 +665,18 @@ func (p Parameters) validate(minConfs int32, openChans []lndclient.ChannelInfo,
 		return ErrNegativeBudget
 	}
 
+	if err := p.Schedule.validate(); err != nil {
+		return err
+	}
+
+	// We use the absolute fee floor for our sweep limit so that users may
+	// set a limit of 1 sat/vByte.
+	if p.SweepFeeRateLimit != 0 &&
+		p.SweepFeeRateLimit < chainfee.AbsoluteFeePerKwFloor {
+
+		return ErrInvalidSweepFeeRateLimit
+	}
+
 	if p.MaxAutoInFlight <= 0 {
 		return ErrZeroInFlight
 	}
@@ -390,8 +736,57 @@ type Manager struct {
 	// updated at runtime.
 	params Parameters
 
-	// paramsLock is a lock for our current set of parameters.
+	// pending is a parameter change that is waiting for our change delay
+	// to pass before it replaces params, or nil if no change is pending.
+	// Pending changes are not persisted, so they are lost on restart.
+	pending *PendingParameters
+
+	// version is incremented every time our current or pending
+	// parameters are changed by a caller, so that callers can detect
+	// concurrent changes to our parameters.
+	version uint64
+
+	// paramsLock is a lock for our current and pending parameters, and
+	// their version.
 	paramsLock sync.Mutex
+
+	// updateLock serializes changes to our parameters. It is held while
+	// new parameters are validated, so that read-modify-write updates are
+	// atomic without holding our params lock over calls to lnd and the
+	// server.
+	updateLock sync.Mutex
+
+	// decisions tracks the outcome of our autoloop checks so that we only
+	// log changes in them.
+	decisions *decisionLog
+
+	// recordedOutcomes is the set of swaps that we have recorded peer
+	// outcomes for. It is lazily loaded from our store, and is only
+	// accessed by our autoloop goroutine.
+	recordedOutcomes map[lntypes.Hash]struct{}
+
+	// budgetExhausted indicates whether our most recent autoloop check
+	// found that our budget was exhausted. It is only accessed by our
+	// autoloop goroutine.
+	budgetExhausted bool
+
+	// reconciled is the set of completed swaps that our most recent budget
+	// reconciliation covered. It is nil until our first reconciliation,
+	// and is only accessed by our autoloop goroutine.
+	reconciled map[lntypes.Hash]struct{}
+
+	// triggers receives requests for immediate autoloop evaluations. Each
+	// request provides a channel that the outcome of the evaluation is
+	// sent on.
+	triggers chan chan error
+
+	// inactiveRules tracks the rules that reference channels or peers
+	// that were inactive when we last checked, and when we first saw them
+	// inactive.
+	inactiveRules map[staleRuleKey]*inactiveRule
+
+	// staleLock is a lock for our set of inactive rules.
+	staleLock sync.Mutex
 }
 
 // Run periodically checks whether we should automatically dispatch a loop out.
@@ -401,31 +796,85 @@ func (m *Manager) Run(ctx context.Context) error {
 	m.cfg.AutoloopTicker.Resume()
 	defer m.cfg.AutoloopTicker.Stop()
 
+	// If we are taking channel balance snapshots, we start a ticker for
+	// them. Otherwise, we leave our snapshot channel nil so that it never
+	// fires.
+	var snapshotTicks <-chan time.Time
+	if m.snapshotsEnabled() {
+		snapshotTicker := ticker.New(m.cfg.SnapshotInterval)
+		snapshotTicker.Resume()
+		defer snapshotTicker.Stop()
+
+		snapshotTicks = snapshotTicker.Ticks()
+	}
+
+	// If we reconcile our budget on a schedule, we start a ticker for our
+	// reconciliations.
+	var reconcileTicks <-chan time.Time
+	if m.reconciliationEnabled() {
+		reconcileTicker := ticker.New(m.cfg.ReconcileInterval)
+		reconcileTicker.Resume()
+		defer reconcileTicker.Stop()
+
+		reconcileTicks = reconcileTicker.Ticks()
+	}
+
 	for {
 		select {
-		case <-m.cfg.AutoloopTicker.Ticks():
-			err := m.autoloop(ctx)
-			switch err {
-			case ErrNoRules:
-				log.Debugf("No rules configured for autoloop")
+		case <-snapshotTicks:
+			if err := m.takeSnapshot(ctx); err != nil {
+				log.Errorf("channel snapshot failed: %v", err)
+			}
 
-			case nil:
+		case <-reconcileTicks:
+			if err := m.runReconciliation(ctx); err != nil {
+				log.Errorf("budget reconciliation failed: %v",
+					err)
+			}
+
+		case <-m.cfg.AutoloopTicker.Ticks():
+			m.runAutoloop(ctx)
 
-			default:
-				log.Errorf("autoloop failed: %v", err)
+			if err := m.checkStaleRules(ctx); err != nil {
+				log.Errorf("stale rule check failed: %v", err)
 			}
 
+		case errChan := <-m.triggers:
+			log.Debugf("Autoloop evaluation triggered")
+			errChan <- m.runAutoloop(ctx)
+
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
+// runAutoloop performs a single autoloop evaluation, logging and returning
+// its error.
+func (m *Manager) runAutoloop(ctx context.Context) error {
+	err := m.autoloop(ctx)
+	switch err {
+	// We don't log that we have no rules or that the server is unreachable
+	// here, because our decision log reports it when it changes.
+	case ErrNoRules, ErrServerUnreachable:
+
+	case nil:
+
+	default:
+		log.Errorf("autoloop failed: %v", err)
+	}
+
+	return err
+}
+
 // NewManager creates a liquidity manager which has no rules set.
 func NewManager(cfg *Config) *Manager {
 	return &Manager{
-		cfg:    cfg,
-		params: defaultParameters,
+		cfg:           cfg,
+		params:        defaultParameters,
+		decisions:     newDecisionLog(),
+		triggers:      make(chan chan error),
+		inactiveRules: make(map[staleRuleKey]*inactiveRule),
 	}
 }
 
@@ -434,12 +883,73 @@ func (m *Manager) GetParameters() Parameters {
 	m.paramsLock.Lock()
 	defer m.paramsLock.Unlock()
 
+	m.applyPendingParams()
+
 	return cloneParameters(m.params)
 }
 
 // SetParameters updates our current set of parameters if the new parameters
-// provided are valid.
+// provided are valid. If we have a change delay configured and the new
+// parameters increase the fees that autoloop may spend, they are held as a
+// pending change until the delay has passed. Any previously pending change is
+// replaced.
 func (m *Manager) SetParameters(ctx context.Context, params Parameters) error {
+	m.updateLock.Lock()
+	defer m.updateLock.Unlock()
+
+	return m.setParameters(ctx, params)
+}
+
+// UpdateParameters atomically applies the update function provided to our
+// latest set of parameters, which is our pending change if we have one, and
+// sets the result as our parameters. If the version provided is non-zero and
+// our parameters have been changed since it was obtained, the update fails
+// with ErrParamsVersionMismatch. The new version of our parameters is
+// returned.
+func (m *Manager) UpdateParameters(ctx context.Context, version uint64,
+	update func(Parameters) (Parameters, error)) (uint64, error) {
+
+	m.updateLock.Lock()
+	defer m.updateLock.Unlock()
+
+	m.paramsLock.Lock()
+	m.applyPendingParams()
+
+	current, currentVersion := m.params, m.version
+	if m.pending != nil {
+		current = m.pending.Parameters
+	}
+	current = cloneParameters(current)
+	m.paramsLock.Unlock()
+
+	if version != 0 && version != currentVersion {
+		return 0, fmt.Errorf("%w: expected: %v, current: %v",
+			ErrParamsVersionMismatch, version, currentVersion)
+	}
+
+	params, err := update(current)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.setParameters(ctx, params); err != nil {
+		return 0, err
+	}
+
+	return m.ParametersVersion(), nil
+}
+
+// ParametersVersion returns the current version of our parameters.
+func (m *Manager) ParametersVersion() uint64 {
+	m.paramsLock.Lock()
+	defer m.paramsLock.Unlock()
+
+	return m.version
+}
+
+// setParameters validates and sets a new set of parameters. It must be called
+// with the update lock held.
+func (m *Manager) setParameters(ctx context.Context, params Parameters) error {
 	restrictions, err := m.cfg.Restrictions(ctx, swap.TypeOut)
 	if err != nil {
 		return err
@@ -455,10 +965,39 @@ func (m *Manager) SetParameters(ctx context.Context, params Parameters) error {
 		return err
 	}
 
+	if err := m.validateSnapshotRules(params); err != nil {
+		return err
+	}
+
+	if err := m.validateReputationRules(params); err != nil {
+		return err
+	}
+
+	if err := m.validateRuleBudgets(params); err != nil {
+		return err
+	}
+
 	m.paramsLock.Lock()
 	defer m.paramsLock.Unlock()
 
-	m.params = cloneParameters(params)
+	m.applyPendingParams()
+	m.version++
+
+	if m.cfg.ParamsChangeDelay == 0 || !increasesSpend(m.params, params) {
+		m.params = cloneParameters(params)
+		m.pending = nil
+
+		return nil
+	}
+
+	m.pending = &PendingParameters{
+		Parameters:  cloneParameters(params),
+		EffectiveAt: m.cfg.Clock.Now().Add(m.cfg.ParamsChangeDelay),
+	}
+
+	log.Infof("Parameter change increases autoloop fees, it will take "+
+		"effect at: %v", m.pending.EffectiveAt)
+
 	return nil
 }
 
@@ -487,18 +1026,83 @@ func cloneParameters(params Parameters) Parameters {
 		paramCopy.PeerRules[peer] = &ruleCopy
 	}
 
+	paramCopy.DisqualifiedChannels = make(
+		map[lnwire.ShortChannelID]struct{},
+		len(params.DisqualifiedChannels),
+	)
+
+	for channel := range params.DisqualifiedChannels {
+		paramCopy.DisqualifiedChannels[channel] = struct{}{}
+	}
+
+	paramCopy.DisqualifiedPeers = make(
+		map[route.Vertex]struct{}, len(params.DisqualifiedPeers),
+	)
+
+	for peer := range params.DisqualifiedPeers {
+		paramCopy.DisqualifiedPeers[peer] = struct{}{}
+	}
+
+	paramCopy.Schedule.Days = append(
+		[]time.Weekday(nil), params.Schedule.Days...,
+	)
+
 	return paramCopy
 }
 
 // autoloop gets a set of suggested swaps and dispatches them automatically if
 // we have automated looping enabled.
 func (m *Manager) autoloop(ctx context.Context) error {
+	// Before we get our suggestions, we record the outcomes of any swaps
+	// that have completed since our last check so that our peer
+	// reputations are up to date. This is not critical, so we only log
+	// failures.
+	if err := m.recordPeerOutcomes(ctx); err != nil {
+		log.Errorf("could not record peer outcomes: %v", err)
+	}
+
+	// If the swap server is unreachable, we pause autoloop until it can
+	// be reached again rather than attempting to get quotes for our
+	// suggestions. We record this in our decision log so that it is
+	// reported in our autoloop status.
+	if m.cfg.CheckServerHealth != nil {
+		if err := m.cfg.CheckServerHealth(ctx); err != nil {
+			log.Debugf("Swap server unreachable: %v", err)
+
+			m.decisions.record(
+				m.cfg.Clock.Now(), nil, ErrServerUnreachable,
+			)
+
+			return ErrServerUnreachable
+		}
+	}
+
 	suggestion, err := m.SuggestSwaps(ctx, true)
+	m.decisions.record(m.cfg.Clock.Now(), suggestion, err)
 	if err != nil {
 		return err
 	}
 
-	for _, swap := range suggestion.OutSwaps {
+	// If autoloop is enabled and our budget has just run out, we let our
+	// caller know.
+	exhausted := m.params.Autoloop && suggestion.budgetExhausted()
+	if exhausted && !m.budgetExhausted && m.cfg.BudgetExhausted != nil {
+		m.cfg.BudgetExhausted(m.params.AutoFeeBudget)
+	}
+	m.budgetExhausted = exhausted
+
+	// The server may have lowered its maximum swap amount since we made
+	// our suggestions, so we get its latest restrictions before we
+	// dispatch any swaps.
+	var restrictions *Restrictions
+	if m.params.Autoloop && len(suggestion.OutSwaps) > 0 {
+		restrictions, err = m.getSwapRestrictions(ctx, swap.TypeOut)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, swap := range suggestion.OutSwaps {
 		// If we don't actually have dispatch of swaps enabled, log
 		// suggestions.
 		if !m.params.Autoloop {
@@ -508,6 +1112,31 @@ func (m *Manager) autoloop(ctx context.Context) error {
 			continue
 		}
 
+		// If our swap now exceeds the server's maximum, we resize it
+		// rather than failing its dispatch. A smaller swap still moves
+		// us towards our rule's target, and its fees can only go down,
+		// so it stays within our budget.
+		if swap.Amount > restrictions.Maximum {
+			resized, err := m.resizeLoopOut(
+				ctx, swap, restrictions.Maximum,
+			)
+			if err != nil {
+				log.Infof("could not resize loop out over %v "+
+					"from %v to %v: %v",
+					swap.OutgoingChanSet, swap.Amount,
+					restrictions.Maximum, err)
+
+				continue
+			}
+
+			log.Infof("resized loop out over %v from %v to %v: "+
+				"server maximum decreased",
+				swap.OutgoingChanSet, swap.Amount,
+				resized.Amount)
+
+			swap = resized
+		}
+
 		// Create a copy of our range var so that we can reference it.
 		swap := swap
 		loopOut, err := m.cfg.LoopOut(ctx, &swap)
@@ -518,19 +1147,83 @@ func (m *Manager) autoloop(ctx context.Context) error {
 		log.Infof("loop out automatically dispatched: hash: %v, "+
 			"address: %v", loopOut.SwapHash,
 			loopOut.HtlcAddressP2WSH)
+
+		m.decisions.recordLoopOut(
+			m.cfg.Clock.Now(), loopOut.SwapHash, swap.Amount,
+		)
+
+		err = m.recordRuleSwap(suggestion.outRules, i, loopOut.SwapHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, in := range suggestion.InSwaps {
+		// If we don't actually have dispatch of swaps enabled, log
+		// suggestions.
+		if !m.params.Autoloop {
+			log.Debugf("recommended autoloop in: %v sats over "+
+				"%v", in.Amount, in.LastHop)
+
+			continue
+		}
+
+		// Create a copy of our range var so that we can reference it.
+		in := in
+		loopIn, err := m.cfg.LoopIn(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("loop in automatically dispatched: hash: %v, "+
+			"address: %v", loopIn.SwapHash,
+			loopIn.HtlcAddressP2WSH)
+
+		m.decisions.recordLoopIn(
+			m.cfg.Clock.Now(), loopIn.SwapHash, in.Amount,
+		)
+
+		err = m.recordRuleSwap(suggestion.inRules, i, loopIn.SwapHash)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// ForceAutoLoop force-ticks our auto-out ticker.
-func (m *Manager) ForceAutoLoop(ctx context.Context) error {
+// GetAutoloopStatus returns the outcome of our most recent autoloop check,
+// including the reasons that swaps are currently not being suggested.
+func (m *Manager) GetAutoloopStatus() AutoloopStatus {
+	return m.decisions.getStatus()
+}
+
+// TriggerAutoloop performs an autoloop evaluation immediately, rather than
+// waiting for our next tick, and returns our autoloop status once it has
+// completed. The evaluation is performed by our main goroutine, so it never
+// runs concurrently with a regular check, and is subject to the same budget
+// and limits. The status's error is set to the error that the evaluation
+// failed with, if any, so that failures to dispatch swaps are also reported.
+func (m *Manager) TriggerAutoloop(ctx context.Context) (AutoloopStatus,
+	error) {
+
+	errChan := make(chan error, 1)
 	select {
-	case m.cfg.AutoloopTicker.Force <- m.cfg.Clock.Now():
-		return nil
+	case m.triggers <- errChan:
 
 	case <-ctx.Done():
-		return ctx.Err()
+		return AutoloopStatus{}, ctx.Err()
+	}
+
+	select {
+	case err := <-errChan:
+		status := m.GetAutoloopStatus()
+		status.Err = err
+
+		return status, nil
+
+	case <-ctx.Done():
+		return AutoloopStatus{}, ctx.Err()
 	}
 }
 
@@ -540,6 +1233,9 @@ type Suggestions struct {
 	// OutSwaps is the set of loop out swaps that we suggest executing.
 	OutSwaps []loop.OutRequest
 
+	// InSwaps is the set of loop in swaps that we suggest executing.
+	InSwaps []loop.LoopInRequest
+
 	// DisqualifiedChans maps the set of channels that we do not recommend
 	// swaps on to the reason that we did not recommend a swap.
 	DisqualifiedChans map[lnwire.ShortChannelID]Reason
@@ -547,6 +1243,18 @@ type Suggestions struct {
 	// Disqualified peers maps the set of peers that we do not recommend
 	// swaps for to the reason that they were excluded.
 	DisqualifiedPeers map[route.Vertex]Reason
+
+	// Budget summarizes the use of our autoloop fee budget when the
+	// suggestions were made. It is nil if we did not get as far as
+	// examining our budget.
+	Budget *Budget
+
+	// outRules and inRules map the index of each suggested swap that was
+	// suggested for a rule with its own fee budget to that rule, so that
+	// the swap can be attributed to the rule when it is dispatched. They
+	// are nil if no such swaps were suggested.
+	outRules map[int]RuleID
+	inRules  map[int]RuleID
 }
 
 func newSuggestions() *Suggestions {
@@ -556,17 +1264,61 @@ func newSuggestions() *Suggestions {
 	}
 }
 
-func (s *Suggestions) addSwap(swap swapSuggestion) error {
-	out, ok := swap.(*loopOutSwapSuggestion)
-	if !ok {
-		return fmt.Errorf("unexpected swap type: %T", swap)
+// budgetExhausted returns a boolean indicating whether any channels or peers
+// were excluded from our suggestions because our budget is insufficient.
+func (s *Suggestions) budgetExhausted() bool {
+	for _, reason := range s.DisqualifiedChans {
+		if reason == ReasonBudgetInsufficient {
+			return true
+		}
+	}
+
+	for _, reason := range s.DisqualifiedPeers {
+		if reason == ReasonBudgetInsufficient {
+			return true
+		}
 	}
 
-	s.OutSwaps = append(s.OutSwaps, out.OutRequest)
+	return false
+}
+
+// addSwap adds a swap to our set of suggestions. If the swap was suggested
+// for a rule with its own fee budget, the rule is provided so that the swap
+// can be attributed to it when it is dispatched.
+func (s *Suggestions) addSwap(swap swapSuggestion, budgetRule *RuleID) error {
+	switch t := swap.(type) {
+	case *loopOutSwapSuggestion:
+		s.OutSwaps = append(s.OutSwaps, t.OutRequest)
+
+		if budgetRule != nil {
+			if s.outRules == nil {
+				s.outRules = make(map[int]RuleID)
+			}
+			s.outRules[len(s.OutSwaps)-1] = *budgetRule
+		}
+
+	case *loopInSwapSuggestion:
+		s.InSwaps = append(s.InSwaps, t.LoopInRequest)
+
+		if budgetRule != nil {
+			if s.inRules == nil {
+				s.inRules = make(map[int]RuleID)
+			}
+			s.inRules[len(s.InSwaps)-1] = *budgetRule
+		}
+
+	default:
+		return fmt.Errorf("unexpected swap type: %T", swap)
+	}
 
 	return nil
 }
 
+// count returns the total number of swaps that we suggest executing.
+func (s *Suggestions) count() int {
+	return len(s.OutSwaps) + len(s.InSwaps)
+}
+
 // singleReasonSuggestion is a helper function which returns a set of
 // suggestions where all of our rules are disqualified due to a reason that
 // applies to all of them (such as being out of budget).
@@ -596,6 +1348,8 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 	m.paramsLock.Lock()
 	defer m.paramsLock.Unlock()
 
+	m.applyPendingParams()
+
 	// If we have no rules set, exit early to avoid unnecessary calls to
 	// lnd and the server.
 	if !m.params.haveRules() {
@@ -606,12 +1360,16 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 	// we should start using our budget at this date. This means that we
 	// have no budget for the present, so we just return.
 	if m.params.AutoFeeStartDate.After(m.cfg.Clock.Now()) {
-		log.Debugf("autoloop fee budget start time: %v is in "+
-			"the future", m.params.AutoFeeStartDate)
-
 		return m.singleReasonSuggestion(ReasonBudgetNotStarted), nil
 	}
 
+	// If we are suggesting swaps for autoloop and we are outside of our
+	// schedule, we do not dispatch any swaps until our next window opens.
+	// Manual suggestions are not restricted by our schedule.
+	if autoloop && !m.params.Schedule.allowed(m.cfg.Clock.Now()) {
+		return m.singleReasonSuggestion(ReasonOutsideSchedule), nil
+	}
+
 	// Before we get any swap suggestions, we check what the current fee
 	// estimate is to sweep within our target number of confirmations. If
 	// This fee exceeds the fee limit we have set, we will not suggest any
@@ -633,51 +1391,83 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 		return nil, err
 	}
 
+	// Regardless of the fee limit we have set, we do not suggest swaps if
+	// the fee estimate exceeds our sweep fee rate limit, if set.
+	if m.params.SweepFeeRateLimit != 0 &&
+		estimate > m.params.SweepFeeRateLimit {
+
+		log.Debugf("Current fee estimate to sweep: %v sat/vByte "+
+			"exceeds autoloop limit of: %v sat/vByte",
+			satPerKwToSatPerVByte(estimate),
+			satPerKwToSatPerVByte(m.params.SweepFeeRateLimit))
+
+		return m.singleReasonSuggestion(ReasonSweepFees), nil
+	}
+
 	// Get the current server side restrictions, combined with the client
-	// set restrictions, if any.
-	restrictions, err := m.getSwapRestrictions(ctx, swap.TypeOut)
+	// set restrictions, if any. We only query the server for its loop in
+	// restrictions if we have rules that suggest loop in swaps.
+	outRestrictions, err := m.getSwapRestrictions(ctx, swap.TypeOut)
 	if err != nil {
 		return nil, err
 	}
 
+	restrictions := map[swap.Type]*Restrictions{
+		swap.TypeOut: outRestrictions,
+	}
+
+	if m.params.haveLoopIn() {
+		restrictions[swap.TypeIn], err = m.getSwapRestrictions(
+			ctx, swap.TypeIn,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// List our current set of swaps so that we can determine which channels
 	// are already being utilized by swaps. Note that these calls may race
 	// with manual initiation of swaps.
-	loopOut, err := m.cfg.ListLoopOut()
+	loopOut, err := m.cfg.ListLoopOut(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	loopIn, err := m.cfg.ListLoopIn()
+	loopIn, err := m.cfg.ListLoopIn(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get a summary of our existing swaps so that we can check our autoloop
 	// budget.
-	summary, err := m.checkExistingAutoLoops(ctx, loopOut)
+	summary, err := m.checkExistingAutoLoops(ctx, loopOut, loopIn)
 	if err != nil {
 		return nil, err
 	}
 
+	budget := m.budgetSummary(summary)
+
 	if summary.totalFees() >= m.params.AutoFeeBudget {
-		log.Debugf("autoloop fee budget: %v exhausted, %v spent on "+
+		log.Tracef("autoloop fee budget: %v exhausted, %v spent on "+
 			"completed swaps, %v reserved for ongoing swaps "+
 			"(upper limit)",
 			m.params.AutoFeeBudget, summary.spentFees,
 			summary.pendingFees)
 
-		return m.singleReasonSuggestion(ReasonBudgetElapsed), nil
+		resp := m.singleReasonSuggestion(ReasonBudgetElapsed)
+		resp.Budget = budget
+
+		return resp, nil
 	}
 
 	// If we have already reached our total allowed number of in flight
 	// swaps, we do not suggest any more at the moment.
 	allowedSwaps := m.params.MaxAutoInFlight - summary.inFlightCount
 	if allowedSwaps <= 0 {
-		log.Debugf("%v autoloops allowed, %v in flight",
-			m.params.MaxAutoInFlight, summary.inFlightCount)
+		resp := m.singleReasonSuggestion(ReasonInFlight)
+		resp.Budget = budget
 
-		return m.singleReasonSuggestion(ReasonInFlight), nil
+		return resp, nil
 	}
 
 	channels, err := m.cfg.Lnd.Client.ListChannels(ctx)
@@ -689,32 +1479,79 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 	// balances which we will use for peer-level liquidity rules.
 	channelPeers := make(map[uint64]route.Vertex)
 	peerChannels := make(map[route.Vertex]*balances)
+	inactivePeers := make(map[route.Vertex]bool)
 	for _, channel := range channels {
 		channelPeers[channel.ChannelID] = channel.PubKeyBytes
 
 		bal, ok := peerChannels[channel.PubKeyBytes]
 		if !ok {
-			bal = &balances{}
+			bal = &balances{
+				pubkey: channel.PubKeyBytes,
+			}
+			peerChannels[channel.PubKeyBytes] = bal
 		}
 
+		// Disqualified channels are not included in the balances that
+		// we use for peer-level rules, so that they will not be used
+		// for swaps.
 		chanID := lnwire.NewShortChanIDFromInt(channel.ChannelID)
-		bal.channels = append(bal.channels, chanID)
-		bal.capacity += channel.Capacity
-		bal.incoming += channel.RemoteBalance
-		bal.outgoing += channel.LocalBalance
-		bal.pubkey = channel.PubKeyBytes
+		if m.params.disqualified(chanID, channel.PubKeyBytes) {
+			continue
+		}
+
+		// Inactive channels are excluded in the same way if our
+		// parameters require it. We track the peers that we excluded
+		// channels for so that we can report why their rules can't be
+		// used.
+		if m.params.inactiveExcluded(channel) {
+			inactivePeers[channel.PubKeyBytes] = true
+			continue
+		}
 
-		peerChannels[channel.PubKeyBytes] = bal
+		bal.add(channel)
 	}
 
 	// Get a summary of the channels and peers that are not eligible due
 	// to ongoing swaps.
 	traffic := m.currentSwapTraffic(loopOut, loopIn)
 
+	// If any of our rules require a minimum peer reputation, we look up
+	// the reputations of our peers.
+	var reputations map[route.Vertex]*PeerReputation
+	if m.params.haveReputationRules() {
+		reputations, err = m.PeerReputations(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If any of our rules have their own fee budget, we look up how much
+	// of each budget has already been used. We track the rule that each
+	// suggestion is made for so that we can attribute the swap to it.
+	var (
+		ruleBudgets map[RuleID]*RuleBudget
+		budgetRules = make(map[swapSuggestion]RuleID)
+	)
+	if m.params.haveRuleBudgets() {
+		ruleBudgets, err = m.ruleBudgets(ctx, loopOut, loopIn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// In easy autoloop mode, we generate channel rules that move our total
+	// local balance towards our target, rather than using configured
+	// rules.
+	channelRules := m.params.ChannelRules
+	if m.params.LocalBalanceTarget != 0 {
+		channelRules = easyAutoloopRules(channels, m.params)
+	}
+
 	var (
 		suggestions []swapSuggestion
 		resp        = newSuggestions()
 	)
+	resp.Budget = budget
 
 	for peer, balances := range peerChannels {
 		rule, haveRule := m.params.PeerRules[peer]
@@ -722,8 +1559,21 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 			continue
 		}
 
+		// If all of our channels with the peer are disqualified or
+		// inactive, we cannot suggest any swaps for its rule.
+		if len(balances.channels) == 0 {
+			reason := ReasonDisqualified
+			if inactivePeers[peer] {
+				reason = ReasonInactive
+			}
+
+			resp.DisqualifiedPeers[peer] = reason
+			continue
+		}
+
 		suggestion, err := m.suggestSwap(
-			ctx, traffic, balances, rule, restrictions, autoloop,
+			ctx, traffic, reputations, balances, rule,
+			restrictions[rule.swapType(balances)], autoloop,
 		)
 		var reasonErr *reasonError
 		if errors.As(err, &reasonErr) {
@@ -735,6 +1585,17 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 			return nil, err
 		}
 
+		id := RuleID{Peer: peer}
+		budget, ok := ruleBudgets[id]
+		if ok {
+			if suggestion.fees() > budget.available() {
+				resp.DisqualifiedPeers[peer] = ReasonRuleBudget
+				continue
+			}
+
+			budgetRules[suggestion] = id
+		}
+
 		suggestions = append(suggestions, suggestion)
 	}
 
@@ -742,13 +1603,24 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 		balance := newBalances(channel)
 
 		channelID := lnwire.NewShortChanIDFromInt(channel.ChannelID)
-		rule, ok := m.params.ChannelRules[channelID]
+		rule, ok := channelRules[channelID]
 		if !ok {
 			continue
 		}
 
+		if m.params.disqualified(channelID, channel.PubKeyBytes) {
+			resp.DisqualifiedChans[channelID] = ReasonDisqualified
+			continue
+		}
+
+		if m.params.inactiveExcluded(channel) {
+			resp.DisqualifiedChans[channelID] = ReasonInactive
+			continue
+		}
+
 		suggestion, err := m.suggestSwap(
-			ctx, traffic, balance, rule, restrictions, autoloop,
+			ctx, traffic, reputations, balance, rule,
+			restrictions[rule.swapType(balance)], autoloop,
 		)
 
 		var reasonErr *reasonError
@@ -761,9 +1633,36 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 			return nil, err
 		}
 
+		id := RuleID{Channel: channelID}
+		budget, ok := ruleBudgets[id]
+		if ok {
+			if suggestion.fees() > budget.available() {
+				resp.DisqualifiedChans[channelID] =
+					ReasonRuleBudget
+				continue
+			}
+
+			budgetRules[suggestion] = id
+		}
+
 		suggestions = append(suggestions, suggestion)
 	}
 
+	// Rules for channels that have since closed, or peers that we no
+	// longer have channels with, will not have been examined above, so we
+	// add a reason for them so that every rule is accounted for.
+	for peer := range m.params.PeerRules {
+		if _, ok := peerChannels[peer]; !ok {
+			resp.DisqualifiedPeers[peer] = ReasonNoChannel
+		}
+	}
+
+	for channelID := range m.params.ChannelRules {
+		if _, ok := channelPeers[channelID.ToUint64()]; !ok {
+			resp.DisqualifiedChans[channelID] = ReasonNoChannel
+		}
+	}
+
 	// If we have no swaps to execute after we have applied all of our
 	// limits, just return our set of disqualified swaps.
 	if len(suggestions) == 0 {
@@ -779,6 +1678,27 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 	// return all of the swaps which will fit within our remaining budget.
 	available := m.params.AutoFeeBudget - summary.totalFees()
 
+	// If we have a maximum locked value, we also track the amount that
+	// may still be locked in swaps.
+	availableLocked := m.cfg.MaxLockedValue - loop.PendingValue(
+		loopOut, loopIn,
+	)
+
+	// If we limit the fraction of our on-chain funds that loop ins may
+	// commit, we track the amount that loop ins may still use.
+	var availableLoopIn btcutil.Amount
+	if m.cfg.MaxLoopInWalletFraction != 0 {
+		balance, err := m.cfg.Lnd.Client.ConfirmedWalletBalance(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		availableLoopIn = loop.AvailableLoopInValue(
+			balance, loop.PendingLoopInValue(loopIn),
+			m.cfg.MaxLoopInWalletFraction,
+		)
+	}
+
 	// setReason is a helper that adds a swap's channels to our disqualified
 	// list with the reason provided.
 	setReason := func(reason Reason, swap swapSuggestion) {
@@ -792,7 +1712,7 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 		}
 
 		for _, channel := range swap.channels() {
-			_, ok := m.params.ChannelRules[channel]
+			_, ok := channelRules[channel]
 			if !ok {
 				continue
 			}
@@ -812,7 +1732,7 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 		case available == 0:
 			reason = ReasonBudgetInsufficient
 
-		case len(resp.OutSwaps) == allowedSwaps:
+		case resp.count() == allowedSwaps:
 			reason = ReasonInFlight
 		}
 
@@ -821,6 +1741,26 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 			continue
 		}
 
+		// If this swap would take us over our maximum locked value, we
+		// skip it. We continue with the rest of our suggestions,
+		// because smaller swaps may still fit.
+		if m.cfg.MaxLockedValue != 0 {
+			if swap.amount() > availableLocked {
+				setReason(ReasonLockedValue, swap)
+				continue
+			}
+		}
+
+		// Likewise, we skip loop ins that would commit more than our
+		// maximum fraction of our on-chain funds.
+		_, isLoopIn := swap.(*loopInSwapSuggestion)
+		if isLoopIn && m.cfg.MaxLoopInWalletFraction != 0 {
+			if swap.amount() > availableLoopIn {
+				setReason(ReasonLoopInWalletFraction, swap)
+				continue
+			}
+		}
+
 		fees := swap.fees()
 
 		// If the maximum fee we expect our swap to use is less than the
@@ -828,8 +1768,17 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 		// fall within the budget and decrement our available amount.
 		if fees <= available {
 			available -= fees
+			availableLocked -= swap.amount()
+			if isLoopIn {
+				availableLoopIn -= swap.amount()
+			}
 
-			if err := resp.addSwap(swap); err != nil {
+			var budgetRule *RuleID
+			if id, ok := budgetRules[swap]; ok {
+				budgetRule = &id
+			}
+
+			if err := resp.addSwap(swap, budgetRule); err != nil {
 				return nil, err
 			}
 		} else {
@@ -841,9 +1790,11 @@ func (m *Manager) SuggestSwaps(ctx context.Context, autoloop bool) (
 }
 
 // suggestSwap checks whether we can currently perform a swap, and creates a
-// swap request for the rule provided.
+// swap request for the rule provided. The reputations of our peers are only
+// required if the rule sets a minimum reputation.
 func (m *Manager) suggestSwap(ctx context.Context, traffic *swapTraffic,
-	balance *balances, rule *ThresholdRule, restrictions *Restrictions,
+	reputations map[route.Vertex]*PeerReputation, balance *balances,
+	rule *ThresholdRule, restrictions *Restrictions,
 	autoloop bool) (swapSuggestion, error) {
 
 	// Check whether we can perform a swap.
@@ -854,33 +1805,125 @@ func (m *Manager) suggestSwap(ctx context.Context, traffic *swapTraffic,
 
 	// We can have nil suggestions in the case where no action is
 	// required, so we skip over them.
-	amount := rule.swapAmount(balance, restrictions)
-	if amount == 0 {
-		return nil, newReasonError(ReasonLiquidityOk)
+	amount, reason := rule.swapAmount(
+		balance, restrictions, m.params.RoundUpToMinimum,
+	)
+	if reason != ReasonNone {
+		return nil, newReasonError(reason)
 	}
 
-	swap, err := m.loopOutSwap(ctx, amount, balance, autoloop)
+	// If the rule requires that its thresholds have been breached for a
+	// minimum duration, we check our balance history before suggesting a
+	// swap so that we do not swap for transient flows.
+	if rule.MinimumImbalanceDuration != 0 {
+		persisted, err := m.imbalancePersisted(ctx, balance, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		if !persisted {
+			return nil, newReasonError(ReasonTransientImbalance)
+		}
+	}
+
+	// If the rule requires a minimum reputation for swaps of this size,
+	// we check the reputation of the peer that the swap is routed
+	// through. Peers that we have no history with have no reputation.
+	if rule.requiresReputation(amount) {
+		reputation, ok := reputations[balance.pubkey]
+		if !ok || reputation.Score() < rule.MinimumReputation {
+			return nil, newReasonError(ReasonReputation)
+		}
+	}
+
+	if rule.swapType(balance) == swap.TypeIn {
+		in, err := m.loopInSwap(ctx, amount, balance, rule, autoloop)
+		if err != nil {
+			return nil, err
+		}
+
+		return &loopInSwapSuggestion{
+			LoopInRequest: *in,
+		}, nil
+	}
+
+	out, err := m.loopOutSwap(ctx, amount, balance, rule, autoloop)
 	if err != nil {
 		return nil, err
 	}
 
 	return &loopOutSwapSuggestion{
-		OutRequest: *swap,
+		OutRequest: *out,
 	}, nil
 }
 
+// loopInSwap creates a loop in swap with the amount provided for the peer
+// described by the balance set provided. The swap is restricted to the peer
+// as its last hop, so that the incoming balance that we shift is with the
+// peer that the rule is set for.
+func (m *Manager) loopInSwap(ctx context.Context, amount btcutil.Amount,
+	balance *balances, rule *ThresholdRule,
+	autoloop bool) (*loop.LoopInRequest, error) {
+
+	htlcConfTarget := rule.HtlcConfTarget
+	if htlcConfTarget == 0 {
+		htlcConfTarget = loop.DefaultLoopInHtlcConfTarget(amount)
+	}
+
+	quote, err := m.cfg.LoopInQuote(
+		ctx, &loop.LoopInQuoteRequest{
+			Amount:         amount,
+			HtlcConfTarget: htlcConfTarget,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("loop in quote for suggestion: %v, swap fee: %v, "+
+		"miner fee: %v", amount, quote.SwapFee, quote.MinerFee)
+
+	// Check that the estimated fees for the suggested swap are below the
+	// fee limits configured by the manager.
+	if err := m.params.FeeLimit.loopInLimits(amount, quote); err != nil {
+		return nil, err
+	}
+
+	lastHop := balance.pubkey
+	request := &loop.LoopInRequest{
+		Amount:         amount,
+		MaxSwapFee:     quote.SwapFee,
+		MaxMinerFee:    m.params.FeeLimit.loopInMinerFee(quote),
+		HtlcConfTarget: htlcConfTarget,
+		LastHop:        &lastHop,
+		Initiator:      autoloopSwapInitiator,
+	}
+
+	if autoloop {
+		request.Label = labels.AutoloopLabel(swap.TypeIn)
+	}
+
+	return request, nil
+}
+
 // loopOutSwap creates a loop out swap with the amount provided for the balance
 // described by the balance set provided. A reason that indicates whether we
 // can swap is returned. If this value is not ReasonNone, there is no possible
-// swap and the loop out request returned will be nil.
+// swap and the loop out request returned will be nil. The rule that the swap
+// is suggested for determines the confirmation targets that are used.
 func (m *Manager) loopOutSwap(ctx context.Context, amount btcutil.Amount,
-	balance *balances, autoloop bool) (*loop.OutRequest, error) {
+	balance *balances, rule *ThresholdRule,
+	autoloop bool) (*loop.OutRequest, error) {
+
+	sweepConfTarget := m.params.sweepConfTarget(
+		rule, m.cfg.ConfPolicy, amount,
+	)
 
 	quote, err := m.cfg.LoopOutQuote(
 		ctx, &loop.LoopOutQuoteRequest{
 			Amount:                  amount,
-			SweepConfTarget:         m.params.SweepConfTarget,
-			SwapPublicationDeadline: m.cfg.Clock.Now(),
+			SweepConfTarget:         sweepConfTarget,
+			SwapPublicationDeadline: m.publicationDeadline(),
 		},
 	)
 	if err != nil {
@@ -897,8 +1940,12 @@ func (m *Manager) loopOutSwap(ctx context.Context, amount btcutil.Amount,
 		return nil, err
 	}
 
+	if m.rebalanceCheaper(ctx, amount, balance, quote) {
+		return nil, newReasonError(ReasonRebalanceCheaper)
+	}
+
 	outRequest, err := m.makeLoopOutRequest(
-		ctx, amount, balance, quote, autoloop,
+		ctx, amount, balance, rule, quote, autoloop,
 	)
 	if err != nil {
 		return nil, err
@@ -907,6 +1954,87 @@ func (m *Manager) loopOutSwap(ctx context.Context, amount btcutil.Amount,
 	return &outRequest, nil
 }
 
+// rebalanceCheaper returns a boolean indicating whether we prefer rebalances
+// and a circular rebalance of the single channel that a swap is suggested
+// for is estimated to be much cheaper than the swap. If we cannot estimate
+// the cost of a rebalance, we go ahead with the swap.
+func (m *Manager) rebalanceCheaper(ctx context.Context, amount btcutil.Amount,
+	balance *balances, quote *loop.LoopOutQuote) bool {
+
+	if !m.params.PreferRebalance || m.cfg.EstimateRebalance == nil {
+		return false
+	}
+
+	// Rebalances are estimated per channel, so we only consider them for
+	// swaps that are restricted to a single channel.
+	if len(balance.channels) != 1 {
+		return false
+	}
+
+	channel := balance.channels[0]
+	rebalanceFee, err := m.cfg.EstimateRebalance(
+		ctx, channel, amount, swap.TypeOut,
+	)
+	if err != nil {
+		log.Debugf("could not estimate rebalance over %v: %v",
+			channel, err)
+
+		return false
+	}
+
+	swapFee := quote.SwapFee + quote.MinerFee
+	if rebalanceFee*rebalanceCheaperFactor >= swapFee {
+		return false
+	}
+
+	log.Debugf("rebalance over %v estimated at %v, swap at %v", channel,
+		rebalanceFee, swapFee)
+
+	return true
+}
+
+// resizeLoopOut reduces a loop out request to the amount provided and
+// re-quotes it, so that the request's fee limits reflect the new amount. An
+// error is returned if the new quote exceeds our fee limits.
+func (m *Manager) resizeLoopOut(ctx context.Context, request loop.OutRequest,
+	amount btcutil.Amount) (loop.OutRequest, error) {
+
+	quote, err := m.cfg.LoopOutQuote(
+		ctx, &loop.LoopOutQuoteRequest{
+			Amount:                  amount,
+			SweepConfTarget:         request.SweepConfTarget,
+			SwapPublicationDeadline: m.publicationDeadline(),
+		},
+	)
+	if err != nil {
+		return loop.OutRequest{}, err
+	}
+
+	if err := m.params.FeeLimit.loopOutLimits(amount, quote); err != nil {
+		return loop.OutRequest{}, err
+	}
+
+	prepayMaxFee, routeMaxFee, minerFee := m.params.FeeLimit.loopOutFees(
+		amount, quote,
+	)
+
+	request.Amount = amount
+	request.MaxPrepayRoutingFee = prepayMaxFee
+	request.MaxSwapRoutingFee = routeMaxFee
+	request.MaxMinerFee = minerFee
+	request.MaxSwapFee = quote.SwapFee
+	request.MaxPrepayAmount = quote.PrepayAmount
+	request.SwapPaymentDest = quote.SwapPaymentDest
+
+	return request, nil
+}
+
+// publicationDeadline returns the deadline by which we require the server to
+// publish the htlcs of the loop outs that we suggest.
+func (m *Manager) publicationDeadline() time.Time {
+	return m.cfg.Clock.Now().Add(m.params.PublicationDeadline)
+}
+
 // getSwapRestrictions queries the server for its latest swap size restrictions,
 // validates client restrictions (if present) against these values and merges
 // the client's custom requirements with the server's limits to produce a single
@@ -954,8 +2082,8 @@ func (m *Manager) getSwapRestrictions(ctx context.Context, swapType swap.Type) (
 // dispatched, and decides whether we set a sweep address (we don't bother for
 // non-auto requests, because the client api will set it anyway).
 func (m *Manager) makeLoopOutRequest(ctx context.Context,
-	amount btcutil.Amount, balance *balances, quote *loop.LoopOutQuote,
-	autoloop bool) (loop.OutRequest, error) {
+	amount btcutil.Amount, balance *balances, rule *ThresholdRule,
+	quote *loop.LoopOutQuote, autoloop bool) (loop.OutRequest, error) {
 
 	prepayMaxFee, routeMaxFee, minerFee := m.params.FeeLimit.loopOutFees(
 		amount, quote,
@@ -977,13 +2105,25 @@ func (m *Manager) makeLoopOutRequest(ctx context.Context,
 		MaxMinerFee:         minerFee,
 		MaxSwapFee:          quote.SwapFee,
 		MaxPrepayAmount:     quote.PrepayAmount,
-		SweepConfTarget:     m.params.SweepConfTarget,
+		SwapPaymentDest:     quote.SwapPaymentDest,
+		SweepConfTarget:     sweepConfTarget,
+		HtlcConfirmations:   rule.HtlcConfTarget,
 		Initiator:           autoloopSwapInitiator,
 	}
 
+	// We only set a publication deadline if one is configured, because
+	// requests without a deadline are already published immediately.
+	if m.params.PublicationDeadline != 0 {
+		request.SwapPublicationDeadline = m.publicationDeadline()
+	}
+
 	if autoloop {
 		request.Label = labels.AutoloopLabel(swap.TypeOut)
 
+		// Autoloop swaps are not time sensitive, so we dispatch
+		// them with low priority.
+		request.Priority = loopdb.PriorityLow
+
 		addr, err := m.cfg.Lnd.WalletKit.NextAddr(ctx)
 		if err != nil {
 			return loop.OutRequest{}, err
@@ -1014,6 +2154,70 @@ func worstCaseOutFees(prepayRouting, swapRouting, swapFee, minerFee,
 	return successFees
 }
 
+// abandonedWithPrepay returns a boolean indicating whether a completed loop
+// out was abandoned without the server releasing its prepay.
+func abandonedWithPrepay(state loopdb.SwapStateData) bool {
+	return state.State == loopdb.StateFailAbandoned &&
+		state.FailureReason != loopdb.FailureReasonPrepayReleased
+}
+
+// completedOutFees calculates the fees that a completed loop out swap counts
+// towards our budget. This is the cost recorded for the swap, unless we may
+// have lost a prepay that was not recorded, in which case we use the larger
+// of the recorded cost and the cost of losing the prepay.
+func completedOutFees(cost loopdb.SwapCost, prepayRouting,
+	lostPrepay btcutil.Amount) btcutil.Amount {
+
+	fees := cost.Total()
+	if lostPrepay == 0 {
+		return fees
+	}
+
+	if noShowFees := prepayRouting + lostPrepay; noShowFees > fees {
+		return noShowFees
+	}
+
+	return fees
+}
+
+// lostPrepayAmount returns the amount of a loop out's prepay that we could
+// lose to the server. If our prepay is batched with our swap payment, it is
+// only settled with the swap, so we cannot lose it as a no-show fee.
+func (m *Manager) lostPrepayAmount(ctx context.Context,
+	contract *loopdb.LoopOutContract) (btcutil.Amount, error) {
+
+	if contract.BatchedPrepay() {
+		return 0, nil
+	}
+
+	prepay, err := m.cfg.Lnd.Client.DecodePaymentRequest(
+		ctx, contract.PrepayInvoice,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return mSatToSatoshis(prepay.Value), nil
+}
+
+// worstCaseInFees calculates the largest possible fees for a loop in swap. If
+// the swap succeeds, we pay the swap fee and the fee for our htlc. If the
+// server does not pay our invoice, we pay the fee for our htlc and sweep it
+// back to our wallet after it times out. We estimate the timeout sweep to
+// cost as much as publishing the htlc, since both use the same fee limit.
+func worstCaseInFees(maxMinerFee, swapFee btcutil.Amount) btcutil.Amount {
+	var (
+		successFees = maxMinerFee + swapFee
+		timeoutFees = maxMinerFee * 2
+	)
+
+	if timeoutFees > successFees {
+		return timeoutFees
+	}
+
+	return successFees
+}
+
 // existingAutoLoopSummary provides a summary of the existing autoloops which
 // were dispatched during our current budget period.
 type existingAutoLoopSummary struct {
@@ -1043,7 +2247,8 @@ func (e *existingAutoLoopSummary) totalFees() btcutil.Amount {
 // total for our set of ongoing, automatically dispatched swaps as well as a
 // current in-flight count.
 func (m *Manager) checkExistingAutoLoops(ctx context.Context,
-	loopOuts []*loopdb.LoopOut) (*existingAutoLoopSummary, error) {
+	loopOuts []*loopdb.LoopOut,
+	loopIns []*loopdb.LoopIn) (*existingAutoLoopSummary, error) {
 
 	var summary existingAutoLoopSummary
 
@@ -1052,39 +2257,124 @@ func (m *Manager) checkExistingAutoLoops(ctx context.Context,
 			continue
 		}
 
-		// If we have a pending swap, we are uncertain of the fees that
-		// it will end up paying. We use the worst-case estimate based
-		// on the maximum values we set for each fee category. This will
-		// likely over-estimate our fees (because we probably won't
-		// spend our maximum miner amount). If a swap is not pending,
-		// it has succeeded or failed so we just record our actual fees
-		// for the swap provided that the swap completed after our
-		// budget start date.
-		if out.State().State.Type() == loopdb.StateTypePending {
+		fees, pending, err := m.loopOutBudgetFees(
+			ctx, out, m.params.AutoFeeStartDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if pending {
 			summary.inFlightCount++
+			summary.pendingFees += fees
+		} else {
+			summary.spentFees += fees
+		}
+	}
 
-			prepay, err := m.cfg.Lnd.Client.DecodePaymentRequest(
-				ctx, out.Contract.PrepayInvoice,
-			)
-			if err != nil {
-				return nil, err
-			}
+	for _, in := range loopIns {
+		if in.Contract.Label != labels.AutoloopLabel(swap.TypeIn) {
+			continue
+		}
 
-			summary.pendingFees += worstCaseOutFees(
-				out.Contract.MaxPrepayRoutingFee,
-				out.Contract.MaxSwapRoutingFee,
-				out.Contract.MaxSwapFee,
-				out.Contract.MaxMinerFee,
-				mSatToSatoshis(prepay.Value),
-			)
-		} else if !out.LastUpdateTime().Before(m.params.AutoFeeStartDate) {
-			summary.spentFees += out.State().Cost.Total()
+		fees, pending := loopInBudgetFees(in, m.params.AutoFeeStartDate)
+		if pending {
+			summary.inFlightCount++
+			summary.pendingFees += fees
+		} else {
+			summary.spentFees += fees
 		}
 	}
 
 	return &summary, nil
 }
 
+// loopOutBudgetFees returns the fees that a loop out counts against a fee
+// budget that started at the time provided, and a boolean indicating whether
+// the swap is still pending.
+//
+// If we have a pending swap, we are uncertain of the fees that it will end up
+// paying. We use the worst-case estimate based on the maximum values we set
+// for each fee category. This will likely over-estimate our fees (because we
+// probably won't spend our maximum miner amount). If a swap is not pending, it
+// has succeeded or failed so we just record our actual fees for the swap
+// provided that the swap completed after our budget start date.
+func (m *Manager) loopOutBudgetFees(ctx context.Context, out *loopdb.LoopOut,
+	start time.Time) (btcutil.Amount, bool, error) {
+
+	state := out.State()
+
+	if state.State.Type() == loopdb.StateTypePending {
+		fees, err := m.loopOutReservedFees(ctx, out.Contract)
+		if err != nil {
+			return 0, false, err
+		}
+
+		return fees, true, nil
+	}
+
+	if out.LastUpdateTime().Before(start) {
+		return 0, false, nil
+	}
+
+	// If we abandoned a swap, we stopped tracking its payments, so the
+	// server may have settled our prepay without it being recorded in the
+	// swap's cost. We account for the loss of our prepay unless the server
+	// released it when we abandoned the swap.
+	var prepayAmt btcutil.Amount
+	if abandonedWithPrepay(state) {
+		var err error
+		prepayAmt, err = m.lostPrepayAmount(ctx, out.Contract)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	fees := completedOutFees(
+		state.Cost, out.Contract.MaxPrepayRoutingFee, prepayAmt,
+	)
+
+	return fees, false, nil
+}
+
+// loopOutReservedFees returns the worst-case fees that a loop out reserves
+// from a fee budget while it is in flight.
+func (m *Manager) loopOutReservedFees(ctx context.Context,
+	contract *loopdb.LoopOutContract) (btcutil.Amount, error) {
+
+	prepayAmt, err := m.lostPrepayAmount(ctx, contract)
+	if err != nil {
+		return 0, err
+	}
+
+	return worstCaseOutFees(
+		contract.MaxPrepayRoutingFee, contract.MaxSwapRoutingFee,
+		contract.MaxSwapFee, contract.MaxMinerFee, prepayAmt,
+	), nil
+}
+
+// loopInBudgetFees returns the fees that a loop in counts against a fee budget
+// that started at the time provided, and a boolean indicating whether the swap
+// is still pending. Pending swaps are counted at their worst-case fees, and
+// completed swaps only count if they completed after the budget started.
+func loopInBudgetFees(in *loopdb.LoopIn, start time.Time) (btcutil.Amount,
+	bool) {
+
+	if in.State().State.Type() == loopdb.StateTypePending {
+		fees := worstCaseInFees(
+			in.Contract.MaxMinerFee, in.Contract.MaxSwapFee,
+		)
+
+		return fees, true
+	}
+
+	if in.LastUpdateTime().Before(start) {
+		return 0, false
+	}
+
+	return in.State().Cost.Total(), false
+}
+
 // currentSwapTraffic examines our existing swaps and returns a summary of the
 // current activity which can be used to determine whether we should perform
 // any swaps.
@@ -1098,6 +2388,17 @@ func (m *Manager) currentSwapTraffic(loopOut []*loopdb.LoopOut,
 	// failed since this point will not be considered.
 	failureCutoff := m.cfg.Clock.Now().Add(m.params.FailureBackOff * -1)
 
+	// Cooldown cutoff is the earliest completion timestamp of a successful
+	// swap that we will still consider to be recent. Any channels or peers
+	// involved in swaps that succeeded since this point will not be
+	// considered. We only apply it if a cooldown is set.
+	var (
+		cooldown       = m.params.SwapCooldown != 0
+		cooldownCutoff = m.cfg.Clock.Now().Add(
+			m.params.SwapCooldown * -1,
+		)
+	)
+
 	for _, out := range loopOut {
 		var (
 			state   = out.State().State
@@ -1129,6 +2430,24 @@ func (m *Manager) currentSwapTraffic(loopOut []*loopdb.LoopOut,
 			}
 		}
 
+		// If a loop out swap succeeded within our cooldown period, we
+		// add all of its channels to a set of recently swapped
+		// channels, so that we do not swap them again until their
+		// balance has had time to settle.
+		if cooldown && state == loopdb.StateSuccess {
+			swappedAt := out.LastUpdate().Time
+
+			if swappedAt.After(cooldownCutoff) {
+				for _, id := range chanSet {
+					chanID := lnwire.NewShortChanIDFromInt(
+						id,
+					)
+
+					traffic.recentLoopOut[chanID] = swappedAt
+				}
+			}
+		}
+
 		// Skip completed swaps, they can't affect our channel balances.
 		// Swaps that fail temporarily are considered to be in a pending
 		// state, so we will also check that channels being used by
@@ -1146,27 +2465,46 @@ func (m *Manager) currentSwapTraffic(loopOut []*loopdb.LoopOut,
 	}
 
 	for _, in := range loopIn {
-		// Skip completed swaps, they can't affect our channel balances.
-		if in.State().State.Type() != loopdb.StateTypePending {
+		// Skip over swaps that may come through any peer.
+		if in.Contract.LastHop == nil {
 			continue
 		}
 
-		// Skip over swaps that may come through any peer.
-		if in.Contract.LastHop == nil {
+		var (
+			state   = in.State().State
+			lastHop = *in.Contract.LastHop
+		)
+
+		// If a loop in swap with a last hop succeeded within our
+		// cooldown period, we add its peer to a set of recently swapped
+		// peers.
+		if cooldown && state == loopdb.StateSuccess {
+			swappedAt := in.LastUpdate().Time
+
+			if swappedAt.After(cooldownCutoff) {
+				traffic.recentLoopIn[lastHop] = swappedAt
+			}
+		}
+
+		// Skip completed swaps, they can't affect our channel balances.
+		if state.Type() != loopdb.StateTypePending {
 			continue
 		}
 
-		traffic.ongoingLoopIn[*in.Contract.LastHop] = true
+		traffic.ongoingLoopIn[lastHop] = true
 	}
 
 	return traffic
 }
 
-// swapTraffic contains a summary of our current and previously failed swaps.
+// swapTraffic contains a summary of our current, previously failed and
+// recently successful swaps.
 type swapTraffic struct {
 	ongoingLoopOut map[lnwire.ShortChannelID]bool
 	ongoingLoopIn  map[route.Vertex]bool
 	failedLoopOut  map[lnwire.ShortChannelID]time.Time
+	recentLoopOut  map[lnwire.ShortChannelID]time.Time
+	recentLoopIn   map[route.Vertex]time.Time
 }
 
 func newSwapTraffic() *swapTraffic {
@@ -1174,6 +2512,8 @@ func newSwapTraffic() *swapTraffic {
 		ongoingLoopOut: make(map[lnwire.ShortChannelID]bool),
 		ongoingLoopIn:  make(map[route.Vertex]bool),
 		failedLoopOut:  make(map[lnwire.ShortChannelID]time.Time),
+		recentLoopOut:  make(map[lnwire.ShortChannelID]time.Time),
+		recentLoopIn:   make(map[route.Vertex]time.Time),
 	}
 }
 
@@ -1185,27 +2525,38 @@ func (s *swapTraffic) maySwap(peer route.Vertex,
 	for _, chanID := range channels {
 		lastFail, recentFail := s.failedLoopOut[chanID]
 		if recentFail {
-			log.Debugf("Channel: %v not eligible for suggestions, was "+
+			log.Tracef("Channel: %v not eligible for suggestions, was "+
 				"part of a failed swap at: %v", chanID, lastFail)
 
 			return newReasonError(ReasonFailureBackoff)
 		}
 
-		if s.ongoingLoopOut[chanID] {
-			log.Debugf("Channel: %v not eligible for suggestions, "+
-				"ongoing loop out utilizing channel", chanID)
+		lastSwap, recentSwap := s.recentLoopOut[chanID]
+		if recentSwap {
+			log.Tracef("Channel: %v not eligible for suggestions, was "+
+				"part of a successful swap at: %v", chanID,
+				lastSwap)
+
+			return newReasonError(ReasonCooldown)
+		}
 
+		if s.ongoingLoopOut[chanID] {
 			return newReasonError(ReasonLoopOut)
 		}
 	}
 
 	if s.ongoingLoopIn[peer] {
-		log.Debugf("Peer: %x not eligible for suggestions ongoing "+
-			"loop in utilizing peer", peer)
-
 		return newReasonError(ReasonLoopIn)
 	}
 
+	lastSwap, recentSwap := s.recentLoopIn[peer]
+	if recentSwap {
+		log.Tracef("Peer: %v not eligible for suggestions, was last hop "+
+			"of a successful swap at: %v", peer, lastSwap)
+
+		return newReasonError(ReasonCooldown)
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package liquidity
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -55,6 +56,11 @@ var (
 		MinerFee:     btcutil.Amount(1),
 	}
 
+	testInQuote = &loop.LoopInQuote{
+		SwapFee:  btcutil.Amount(5),
+		MinerFee: btcutil.Amount(1),
+	}
+
 	prepayFee, routingFee = testPPMFees(defaultFeePPM, testQuote, 7500)
 
 	// chan1Rec is the suggested swap for channel 1 when we use chanRule.
@@ -133,10 +139,12 @@ func newTestConfig() (*Config, *test.LndMockServices) {
 		},
 		Lnd:   &lnd.LndServices,
 		Clock: clock.NewTestClock(testTime),
-		ListLoopOut: func() ([]*loopdb.LoopOut, error) {
+		ListLoopOut: func(_ context.Context) (
+			[]*loopdb.LoopOut, error) {
+
 			return nil, nil
 		},
-		ListLoopIn: func() ([]*loopdb.LoopIn, error) {
+		ListLoopIn: func(_ context.Context) ([]*loopdb.LoopIn, error) {
 			return nil, nil
 		},
 		LoopOutQuote: func(_ context.Context,
@@ -145,6 +153,12 @@ func newTestConfig() (*Config, *test.LndMockServices) {
 
 			return testQuote, nil
 		},
+		LoopInQuote: func(_ context.Context,
+			_ *loop.LoopInQuoteRequest) (*loop.LoopInQuote,
+			error) {
+
+			return testInQuote, nil
+		},
 	}, lnd
 }
 
@@ -222,6 +236,56 @@ func TestParameters(t *testing.T) {
 	require.Equal(t, ErrZeroChannelID, err)
 }
 
+// TestUpdateParameters tests atomic updates of our parameters, and detection
+// of conflicting changes using our parameters' version.
+func TestUpdateParameters(t *testing.T) {
+	cfg, _ := newTestConfig()
+	manager := NewManager(cfg)
+	ctx := context.Background()
+
+	startVersion := manager.ParametersVersion()
+
+	setInFlight := func(inFlight int) func(Parameters) (Parameters,
+		error) {
+
+		return func(params Parameters) (Parameters, error) {
+			params.MaxAutoInFlight = inFlight
+			return params, nil
+		}
+	}
+
+	// Update our parameters based on our current version and assert that
+	// the update is applied and our version is bumped.
+	version, err := manager.UpdateParameters(
+		ctx, startVersion, setInFlight(2),
+	)
+	require.NoError(t, err)
+	require.Greater(t, version, startVersion)
+	require.Equal(t, version, manager.ParametersVersion())
+	require.Equal(t, 2, manager.GetParameters().MaxAutoInFlight)
+
+	// An update based on our old version conflicts with the change we
+	// just made, so it must fail and leave our parameters unchanged.
+	_, err = manager.UpdateParameters(ctx, startVersion, setInFlight(3))
+	require.True(t, errors.Is(err, ErrParamsVersionMismatch))
+	require.Equal(t, 2, manager.GetParameters().MaxAutoInFlight)
+
+	// Setting our parameters directly also bumps our version.
+	require.NoError(t, manager.SetParameters(ctx, manager.GetParameters()))
+	require.Greater(t, manager.ParametersVersion(), version)
+
+	// An update with a zero version is not checked for conflicts.
+	_, err = manager.UpdateParameters(ctx, 0, setInFlight(3))
+	require.NoError(t, err)
+	require.Equal(t, 3, manager.GetParameters().MaxAutoInFlight)
+
+	// Invalid updates fail without changing our version.
+	version = manager.ParametersVersion()
+	_, err = manager.UpdateParameters(ctx, version, setInFlight(-1))
+	require.Equal(t, ErrZeroInFlight, err)
+	require.Equal(t, version, manager.ParametersVersion())
+}
+
 // TestValidateRestrictions tests validating client restrictions against a set
 // of server restrictions.
 func TestValidateRestrictions(t *testing.T) {
@@ -314,6 +378,13 @@ func TestRestrictedSuggestions(t *testing.T) {
 			chanID1: chanRule,
 			chanID2: chanRule,
 		}
+
+		// noChannel2 is the set of disqualified channels that we
+		// expect when we only have channel1 open, because we have a
+		// rule for chanID2.
+		noChannel2 = map[lnwire.ShortChannelID]Reason{
+			chanID2: ReasonNoChannel,
+		}
 	)
 
 	tests := []struct {
@@ -337,7 +408,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 				OutSwaps: []loop.OutRequest{
 					chan1Rec,
 				},
-				DisqualifiedChans: noneDisqualified,
+				DisqualifiedChans: noChannel2,
 				DisqualifiedPeers: noPeersDisqualified,
 			},
 		},
@@ -358,7 +429,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 				OutSwaps: []loop.OutRequest{
 					chan1Rec,
 				},
-				DisqualifiedChans: noneDisqualified,
+				DisqualifiedChans: noChannel2,
 				DisqualifiedPeers: noPeersDisqualified,
 			},
 		},
@@ -379,7 +450,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 				OutSwaps: []loop.OutRequest{
 					chan1Rec,
 				},
-				DisqualifiedChans: noneDisqualified,
+				DisqualifiedChans: noChannel2,
 				DisqualifiedPeers: noPeersDisqualified,
 			},
 		},
@@ -446,6 +517,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 			expected: &Suggestions{
 				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
 					chanID1: ReasonFailureBackoff,
+					chanID2: ReasonNoChannel,
 				},
 				DisqualifiedPeers: noPeersDisqualified,
 			},
@@ -470,7 +542,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 				OutSwaps: []loop.OutRequest{
 					chan1Rec,
 				},
-				DisqualifiedChans: noneDisqualified,
+				DisqualifiedChans: noChannel2,
 				DisqualifiedPeers: noPeersDisqualified,
 			},
 		},
@@ -493,6 +565,7 @@ func TestRestrictedSuggestions(t *testing.T) {
 			expected: &Suggestions{
 				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
 					chanID1: ReasonLoopOut,
+					chanID2: ReasonNoChannel,
 				},
 				DisqualifiedPeers: noPeersDisqualified,
 			},
@@ -530,10 +603,14 @@ func TestRestrictedSuggestions(t *testing.T) {
 			// Create a manager config which will return the test
 			// case's set of existing swaps.
 			cfg, lnd := newTestConfig()
-			cfg.ListLoopOut = func() ([]*loopdb.LoopOut, error) {
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
 				return testCase.loopOut, nil
 			}
-			cfg.ListLoopIn = func() ([]*loopdb.LoopIn, error) {
+			cfg.ListLoopIn = func(_ context.Context) (
+				[]*loopdb.LoopIn, error) {
+
 				return testCase.loopIn, nil
 			}
 
@@ -556,6 +633,183 @@ func TestRestrictedSuggestions(t *testing.T) {
 	}
 }
 
+// TestSwapCooldown tests that channels and peers that were part of a
+// successful swap within our swap cooldown are not used for suggestions.
+func TestSwapCooldown(t *testing.T) {
+	const cooldown = time.Hour * 2
+
+	var (
+		succeededWithinCooldown = &loopdb.LoopEvent{
+			SwapStateData: loopdb.SwapStateData{
+				State: loopdb.StateSuccess,
+			},
+			Time: testTime.Add(cooldown / -2),
+		}
+
+		succeededBeforeCooldown = &loopdb.LoopEvent{
+			SwapStateData: loopdb.SwapStateData{
+				State: loopdb.StateSuccess,
+			},
+			Time: testTime.Add(cooldown * -2),
+		}
+
+		lastHop = peer1
+	)
+
+	tests := []struct {
+		name      string
+		loopOut   []*loopdb.LoopOut
+		loopIn    []*loopdb.LoopIn
+		peerRules map[route.Vertex]*ThresholdRule
+		expected  *Suggestions
+	}{
+		{
+			name: "loop out within cooldown",
+			loopOut: []*loopdb.LoopOut{
+				{
+					Contract: chan1Out,
+					Loop: loopdb.Loop{
+						Events: []*loopdb.LoopEvent{
+							succeededWithinCooldown,
+						},
+					},
+				},
+			},
+			expected: &Suggestions{
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID1: ReasonCooldown,
+				},
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name: "loop out before cooldown",
+			loopOut: []*loopdb.LoopOut{
+				{
+					Contract: chan1Out,
+					Loop: loopdb.Loop{
+						Events: []*loopdb.LoopEvent{
+							succeededBeforeCooldown,
+						},
+					},
+				},
+			},
+			expected: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name: "loop in within cooldown",
+			loopIn: []*loopdb.LoopIn{
+				{
+					Contract: &loopdb.LoopInContract{
+						LastHop: &lastHop,
+					},
+					Loop: loopdb.Loop{
+						Events: []*loopdb.LoopEvent{
+							succeededWithinCooldown,
+						},
+					},
+				},
+			},
+			peerRules: map[route.Vertex]*ThresholdRule{
+				peer1: chanRule,
+			},
+			expected: &Suggestions{
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer1: ReasonCooldown,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
+				return testCase.loopOut, nil
+			}
+			cfg.ListLoopIn = func(_ context.Context) (
+				[]*loopdb.LoopIn, error) {
+
+				return testCase.loopIn, nil
+			}
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			params := defaultParameters
+			params.SwapCooldown = cooldown
+
+			if testCase.peerRules != nil {
+				params.PeerRules = testCase.peerRules
+			} else {
+				params.ChannelRules =
+					map[lnwire.ShortChannelID]*ThresholdRule{
+						chanID1: chanRule,
+					}
+			}
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.expected, nil,
+			)
+		})
+	}
+}
+
+// TestPublicationDeadline tests that the publication deadline we configure is
+// used for our quotes and set on the loop outs that we suggest.
+func TestPublicationDeadline(t *testing.T) {
+	const publicationDeadline = time.Hour
+
+	var (
+		deadline = testTime.Add(publicationDeadline)
+		quoteReq *loop.LoopOutQuoteRequest
+	)
+
+	cfg, lnd := newTestConfig()
+	cfg.LoopOutQuote = func(_ context.Context,
+		req *loop.LoopOutQuoteRequest) (*loop.LoopOutQuote, error) {
+
+		quoteReq = req
+
+		return testQuote, nil
+	}
+
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	params := defaultParameters
+	params.PublicationDeadline = publicationDeadline
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: chanRule,
+	}
+
+	expected := chan1Rec
+	expected.SwapPublicationDeadline = deadline
+
+	manager := NewManager(cfg)
+	err := manager.SetParameters(context.Background(), params)
+	require.NoError(t, err)
+
+	suggestions, err := manager.SuggestSwaps(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, []loop.OutRequest{expected}, suggestions.OutSwaps)
+	require.Equal(t, deadline, quoteReq.SwapPublicationDeadline)
+}
+
 // TestSweepFeeLimit tests getting of swap suggestions when our estimated sweep
 // fee is above and below the configured limit.
 func TestSweepFeeLimit(t *testing.T) {
@@ -641,6 +895,73 @@ func TestSweepFeeLimit(t *testing.T) {
 	}
 }
 
+// TestSweepFeeRateLimit tests that our sweep fee rate limit prevents swap
+// suggestions when we use a fee portion, which does not limit sweep fees.
+func TestSweepFeeRateLimit(t *testing.T) {
+	limit := chainfee.SatPerKWeight(2500)
+
+	tests := []struct {
+		name    string
+		limit   chainfee.SatPerKWeight
+		feeRate chainfee.SatPerKWeight
+		reason  Reason
+	}{
+		{
+			name:    "no limit",
+			feeRate: limit + 1,
+		},
+		{
+			name:    "fee estimate ok",
+			limit:   limit,
+			feeRate: limit,
+		},
+		{
+			name:    "fee estimate above limit",
+			limit:   limit,
+			feeRate: limit + 1,
+			reason:  ReasonSweepFees,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			lnd.SetFeeEstimate(defaultConfTarget, testCase.feeRate)
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			params := defaultParameters
+			params.FeeLimit = NewFeePortion(defaultFeePPM)
+			params.SweepFeeRateLimit = testCase.limit
+			params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			}
+
+			ctx := context.Background()
+			manager := NewManager(cfg)
+			require.NoError(t, manager.SetParameters(ctx, params))
+
+			suggestions, err := manager.SuggestSwaps(ctx, true)
+			require.NoError(t, err)
+
+			if testCase.reason == ReasonNone {
+				require.Len(t, suggestions.OutSwaps, 1)
+				return
+			}
+
+			require.Empty(t, suggestions.OutSwaps)
+			require.Equal(
+				t, testCase.reason,
+				suggestions.DisqualifiedChans[chanID1],
+			)
+		})
+	}
+}
+
 // TestSuggestSwaps tests getting of swap suggestions based on the rules set for
 // the liquidity manager and the current set of channel balances.
 func TestSuggestSwaps(t *testing.T) {
@@ -651,13 +972,51 @@ func TestSuggestSwaps(t *testing.T) {
 	expectedAmt := btcutil.Amount(10000)
 	prepay, routing := testPPMFees(defaultFeePPM, testQuote, expectedAmt)
 
+	// confRule is a rule that produces chan1Rec, but overrides our
+	// default confirmation targets.
+	confRule := NewThresholdRule(50, 0)
+	confRule.SweepConfTarget = defaultConfTarget * 2
+	confRule.HtlcConfTarget = 3
+
+	confRec := chan1Rec
+	confRec.SweepConfTarget = confRule.SweepConfTarget
+	confRec.HtlcConfirmations = confRule.HtlcConfTarget
+
+	// peerChannels is a set of channels where we have two channels with
+	// peer1 and one with peer2.
+	peerChannels := []lndclient.ChannelInfo{
+		{
+			PubKeyBytes:   peer1,
+			ChannelID:     chanID1.ToUint64(),
+			Capacity:      20000,
+			LocalBalance:  8000,
+			RemoteBalance: 12000,
+		},
+		{
+			PubKeyBytes:   peer1,
+			ChannelID:     chanID2.ToUint64(),
+			Capacity:      10000,
+			LocalBalance:  9000,
+			RemoteBalance: 1000,
+		},
+		{
+			PubKeyBytes:   peer2,
+			ChannelID:     chanID3.ToUint64(),
+			Capacity:      5000,
+			LocalBalance:  2000,
+			RemoteBalance: 3000,
+		},
+	}
+
 	tests := []struct {
-		name        string
-		channels    []lndclient.ChannelInfo
-		rules       map[lnwire.ShortChannelID]*ThresholdRule
-		peerRules   map[route.Vertex]*ThresholdRule
-		suggestions *Suggestions
-		err         error
+		name              string
+		channels          []lndclient.ChannelInfo
+		rules             map[lnwire.ShortChannelID]*ThresholdRule
+		peerRules         map[route.Vertex]*ThresholdRule
+		disqualifiedChans map[lnwire.ShortChannelID]struct{}
+		disqualifiedPeers map[route.Vertex]struct{}
+		suggestions       *Suggestions
+		err               error
 	}{
 		{
 			name:     "no rules",
@@ -680,53 +1039,98 @@ func TestSuggestSwaps(t *testing.T) {
 			},
 		},
 		{
-			name:     "no rule for channel",
+			name:     "rule overrides conf targets",
 			channels: singleChannel,
 			rules: map[lnwire.ShortChannelID]*ThresholdRule{
-				chanID2: NewThresholdRule(10, 10),
+				chanID1: confRule,
 			},
 			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					confRec,
+				},
 				DisqualifiedChans: noneDisqualified,
 				DisqualifiedPeers: noPeersDisqualified,
 			},
 		},
 		{
-			name: "multiple peer rules",
-			channels: []lndclient.ChannelInfo{
-				{
-					PubKeyBytes:   peer1,
-					ChannelID:     chanID1.ToUint64(),
-					Capacity:      20000,
-					LocalBalance:  8000,
-					RemoteBalance: 12000,
+			name:     "no rule for channel",
+			channels: singleChannel,
+			rules: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID2: NewThresholdRule(10, 10),
+			},
+			suggestions: &Suggestions{
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID2: ReasonNoChannel,
 				},
-				{
-					PubKeyBytes:   peer1,
-					ChannelID:     chanID2.ToUint64(),
-					Capacity:      10000,
-					LocalBalance:  9000,
-					RemoteBalance: 1000,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:     "channel disqualified",
+			channels: singleChannel,
+			rules: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			},
+			disqualifiedChans: map[lnwire.ShortChannelID]struct{}{
+				chanID1: {},
+			},
+			suggestions: &Suggestions{
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID1: ReasonDisqualified,
 				},
-				{
-					PubKeyBytes:   peer2,
-					ChannelID:     chanID3.ToUint64(),
-					Capacity:      5000,
-					LocalBalance:  2000,
-					RemoteBalance: 3000,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:     "channel rule for disqualified peer",
+			channels: singleChannel,
+			rules: map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			},
+			disqualifiedPeers: map[route.Vertex]struct{}{
+				peer1: {},
+			},
+			suggestions: &Suggestions{
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID1: ReasonDisqualified,
 				},
+				DisqualifiedPeers: noPeersDisqualified,
 			},
+		},
+		{
+			name:     "peer rule with all channels disqualified",
+			channels: peerChannels,
 			peerRules: map[route.Vertex]*ThresholdRule{
 				peer1: NewThresholdRule(80, 0),
 				peer2: NewThresholdRule(40, 50),
 			},
+			disqualifiedChans: map[lnwire.ShortChannelID]struct{}{
+				chanID1: {},
+				chanID2: {},
+			},
 			suggestions: &Suggestions{
-				OutSwaps: []loop.OutRequest{
-					{
-						Amount: expectedAmt,
-						OutgoingChanSet: loopdb.ChannelSet{
-							chanID1.ToUint64(),
-							chanID2.ToUint64(),
-						},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer1: ReasonDisqualified,
+					peer2: ReasonLiquidityOk,
+				},
+			},
+		},
+		{
+			name:     "multiple peer rules",
+			channels: peerChannels,
+			peerRules: map[route.Vertex]*ThresholdRule{
+				peer1: NewThresholdRule(80, 0),
+				peer2: NewThresholdRule(40, 50),
+			},
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					{
+						Amount: expectedAmt,
+						OutgoingChanSet: loopdb.ChannelSet{
+							chanID1.ToUint64(),
+							chanID2.ToUint64(),
+						},
 						MaxPrepayRoutingFee: prepay,
 						MaxSwapRoutingFee:   routing,
 						MaxMinerFee:         scaleMinerFee(testQuote.MinerFee),
@@ -761,6 +1165,16 @@ func TestSuggestSwaps(t *testing.T) {
 				params.PeerRules = testCase.peerRules
 			}
 
+			if testCase.disqualifiedChans != nil {
+				params.DisqualifiedChannels =
+					testCase.disqualifiedChans
+			}
+
+			if testCase.disqualifiedPeers != nil {
+				params.DisqualifiedPeers =
+					testCase.disqualifiedPeers
+			}
+
 			testSuggestSwaps(
 				t, newSuggestSwapsSetup(cfg, lnd, params),
 				testCase.suggestions, testCase.err,
@@ -1043,7 +1457,9 @@ func TestFeeBudget(t *testing.T) {
 				})
 			}
 
-			cfg.ListLoopOut = func() ([]*loopdb.LoopOut, error) {
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
 				return swaps, nil
 			}
 
@@ -1091,6 +1507,298 @@ func TestFeeBudget(t *testing.T) {
 	}
 }
 
+// TestSuggestionsBudget tests that our suggestions include a summary of our
+// autoloop budget, including when our budget has been elapsed.
+func TestSuggestionsBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   btcutil.Amount
+		expected *Budget
+	}{
+		{
+			name:   "budget available",
+			budget: 10000,
+			expected: &Budget{
+				Total:     10000,
+				StartDate: testBudgetStart,
+				Spent:     500,
+			},
+		},
+		{
+			name:   "budget elapsed",
+			budget: 400,
+			expected: &Budget{
+				Total:     400,
+				StartDate: testBudgetStart,
+				Spent:     500,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+
+			// Add one completed swap that is in our budget period,
+			// and one that completed before it started.
+			var swaps []*loopdb.LoopOut
+			for ts, amt := range map[time.Time]btcutil.Amount{
+				testBudgetStart.Add(time.Hour):      500,
+				testBudgetStart.Add(time.Hour * -1): 200,
+			} {
+				event := &loopdb.LoopEvent{
+					SwapStateData: loopdb.SwapStateData{
+						Cost: loopdb.SwapCost{
+							Server: amt,
+						},
+						State: loopdb.StateSuccess,
+					},
+					Time: ts,
+				}
+
+				swaps = append(swaps, &loopdb.LoopOut{
+					Loop: loopdb.Loop{
+						Events: []*loopdb.LoopEvent{
+							event,
+						},
+					},
+					Contract: autoOutContract,
+				})
+			}
+
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
+				return swaps, nil
+			}
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			params := defaultParameters
+			params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			}
+			params.AutoFeeStartDate = testBudgetStart
+			params.AutoFeeBudget = testCase.budget
+
+			ctx := context.Background()
+			manager := NewManager(cfg)
+			require.NoError(t, manager.SetParameters(ctx, params))
+
+			suggestions, err := manager.SuggestSwaps(ctx, false)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, suggestions.Budget)
+		})
+	}
+}
+
+// TestMaxLockedValue tests that we do not suggest swaps that would take the
+// total value locked in pending swaps above our configured maximum.
+func TestMaxLockedValue(t *testing.T) {
+	// pendingSwap is a manually dispatched swap that is still pending,
+	// restricted to a channel that we do not use in our tests.
+	pendingSwap := &loopdb.LoopOut{
+		Contract: &loopdb.LoopOutContract{
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 5000,
+			},
+			OutgoingChanSet: loopdb.ChannelSet{999},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		maxLockedValue btcutil.Amount
+		existingSwaps  []*loopdb.LoopOut
+		suggestions    *Suggestions
+	}{
+		{
+			name: "no limit",
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec, chan2Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:           "room for both swaps",
+			maxLockedValue: 15000,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec, chan2Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:           "room for one swap",
+			maxLockedValue: 10000,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec,
+				},
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID2: ReasonLockedValue,
+				},
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:           "pending swap uses limit",
+			maxLockedValue: 15000,
+			existingSwaps:  []*loopdb.LoopOut{pendingSwap},
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec,
+				},
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID2: ReasonLockedValue,
+				},
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			cfg.MaxLockedValue = testCase.maxLockedValue
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
+				return testCase.existingSwaps, nil
+			}
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1, channel2,
+			}
+
+			params := defaultParameters
+			params.ChannelRules =
+				map[lnwire.ShortChannelID]*ThresholdRule{
+					chanID1: chanRule,
+					chanID2: chanRule,
+				}
+			params.MaxAutoInFlight = 2
+			params.AutoFeeBudget = defaultBudget * 2
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.suggestions, nil,
+			)
+		})
+	}
+}
+
+// TestPreferRebalance tests that we do not suggest swaps when we prefer
+// rebalances and a circular rebalance is estimated to be much cheaper than
+// the swap.
+func TestPreferRebalance(t *testing.T) {
+	// Our test quote has a total swap cost of 6 sats. A rebalance over
+	// channel 1 costs less than half of this, while a rebalance over
+	// channel 2 costs exactly half.
+	estimates := map[lnwire.ShortChannelID]btcutil.Amount{
+		chanID1: 2,
+		chanID2: 3,
+	}
+
+	estimateRebalance := func(_ context.Context,
+		channel lnwire.ShortChannelID, _ btcutil.Amount,
+		_ swap.Type) (btcutil.Amount, error) {
+
+		return estimates[channel], nil
+	}
+
+	failEstimate := func(context.Context, lnwire.ShortChannelID,
+		btcutil.Amount, swap.Type) (btcutil.Amount, error) {
+
+		return 0, errors.New("no route")
+	}
+
+	tests := []struct {
+		name              string
+		preferRebalance   bool
+		estimateRebalance func(context.Context, lnwire.ShortChannelID,
+			btcutil.Amount, swap.Type) (btcutil.Amount, error)
+		suggestions *Suggestions
+	}{
+		{
+			name:              "rebalances not preferred",
+			estimateRebalance: estimateRebalance,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec, chan2Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:              "rebalance cheaper",
+			preferRebalance:   true,
+			estimateRebalance: estimateRebalance,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan2Rec,
+				},
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID1: ReasonRebalanceCheaper,
+				},
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:              "estimate fails",
+			preferRebalance:   true,
+			estimateRebalance: failEstimate,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec, chan2Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			cfg.EstimateRebalance = testCase.estimateRebalance
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1, channel2,
+			}
+
+			params := defaultParameters
+			params.ChannelRules =
+				map[lnwire.ShortChannelID]*ThresholdRule{
+					chanID1: chanRule,
+					chanID2: chanRule,
+				}
+			params.MaxAutoInFlight = 2
+			params.AutoFeeBudget = defaultBudget * 2
+			params.PreferRebalance = testCase.preferRebalance
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.suggestions, nil,
+			)
+		})
+	}
+}
+
 // TestInFlightLimit tests the limit we place on the number of in-flight swaps
 // that are allowed.
 func TestInFlightLimit(t *testing.T) {
@@ -1210,7 +1918,9 @@ func TestInFlightLimit(t *testing.T) {
 
 		t.Run(testCase.name, func(t *testing.T) {
 			cfg, lnd := newTestConfig()
-			cfg.ListLoopOut = func() ([]*loopdb.LoopOut, error) {
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
 				return testCase.existingSwaps, nil
 			}
 
@@ -1245,6 +1955,154 @@ func TestInFlightLimit(t *testing.T) {
 	}
 }
 
+// TestLoopInSuggestions tests suggestion of loop in swaps for peer rules.
+func TestLoopInSuggestions(t *testing.T) {
+	// channelIn is a channel with peer 1 that has all of its balance on
+	// the remote side, so it needs a loop in to restore outgoing balance.
+	channelIn := lndclient.ChannelInfo{
+		ChannelID:     chanID1.ToUint64(),
+		PubKeyBytes:   peer1,
+		LocalBalance:  0,
+		RemoteBalance: 10000,
+		Capacity:      10000,
+	}
+
+	loopInRule := NewThresholdRule(0, 50)
+	loopInRule.Type = swap.TypeIn
+
+	lastHop := peer1
+	inRec := loop.LoopInRequest{
+		Amount:         7500,
+		MaxSwapFee:     testInQuote.SwapFee,
+		MaxMinerFee:    scaleMinerFee(testInQuote.MinerFee),
+		HtlcConfTarget: loop.SmallLoopInHtlcConfTarget,
+		LastHop:        &lastHop,
+		Initiator:      autoloopSwapInitiator,
+	}
+
+	tests := []struct {
+		name           string
+		existingIn     []*loopdb.LoopIn
+		walletFraction float64
+		suggestions    *Suggestions
+	}{
+		{
+			name: "loop in",
+			suggestions: &Suggestions{
+				InSwaps: []loop.LoopInRequest{
+					inRec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			// Our mock wallet has a confirmed balance of 1M sats,
+			// so this fraction allows loop ins of up to 10k sats.
+			name:           "loop in within wallet fraction",
+			walletFraction: 0.01,
+			suggestions: &Suggestions{
+				InSwaps: []loop.LoopInRequest{
+					inRec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+		{
+			name:           "loop in exceeds wallet fraction",
+			walletFraction: 0.005,
+			suggestions: &Suggestions{
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer1: ReasonLoopInWalletFraction,
+				},
+			},
+		},
+		{
+			name: "existing loop in with peer",
+			existingIn: []*loopdb.LoopIn{
+				{
+					Contract: &loopdb.LoopInContract{
+						LastHop: &lastHop,
+					},
+				},
+			},
+			suggestions: &Suggestions{
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer1: ReasonLoopIn,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			cfg.MaxLoopInWalletFraction = testCase.walletFraction
+			cfg.ListLoopIn = func(_ context.Context) (
+				[]*loopdb.LoopIn, error) {
+
+				return testCase.existingIn, nil
+			}
+
+			lnd.Channels = []lndclient.ChannelInfo{
+				channelIn,
+			}
+
+			params := defaultParameters
+			params.PeerRules = map[route.Vertex]*ThresholdRule{
+				peer1: loopInRule,
+			}
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.suggestions, nil,
+			)
+		})
+	}
+}
+
+// TestLoopInChannelRule tests that loop in rules may not be set for channels.
+func TestLoopInChannelRule(t *testing.T) {
+	cfg, lnd := newTestConfig()
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	rule := NewThresholdRule(0, 50)
+	rule.Type = swap.TypeIn
+
+	params := defaultParameters
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: rule,
+	}
+
+	manager := NewManager(cfg)
+	err := manager.SetParameters(context.Background(), params)
+	require.Equal(t, ErrLoopInChannelRule, err)
+}
+
+// TestTargetChannelRule tests that target rules may not be set for channels.
+func TestTargetChannelRule(t *testing.T) {
+	cfg, lnd := newTestConfig()
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	params := defaultParameters
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: NewTargetRulePPM(PercentToPPM(50), PercentToPPM(10)),
+	}
+
+	manager := NewManager(cfg)
+	err := manager.SetParameters(context.Background(), params)
+	require.Equal(t, ErrTargetChannelRule, err)
+}
+
 // TestSizeRestrictions tests the use of client-set size restrictions on swaps.
 func TestSizeRestrictions(t *testing.T) {
 	var (
@@ -1310,7 +2168,7 @@ func TestSizeRestrictions(t *testing.T) {
 			},
 			suggestions: &Suggestions{
 				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
-					chanID1: ReasonLiquidityOk,
+					chanID1: ReasonMinAmount,
 				},
 				DisqualifiedPeers: noPeersDisqualified,
 			},
@@ -1593,5 +2451,216 @@ func testSuggestSwaps(t *testing.T, setup *testSuggestSwapsSetup,
 
 	actual, err := manager.SuggestSwaps(context.Background(), false)
 	require.Equal(t, expectedErr, err)
+
+	// Our budget summary is covered by TestSuggestionsBudget, so we only
+	// compare the swaps and disqualified targets that were suggested.
+	if actual != nil {
+		actual.Budget = nil
+	}
 	require.Equal(t, expected, actual)
 }
+
+// TestSuggestionsBudgetExhausted tests detection of suggestions that were
+// limited by our budget.
+func TestSuggestionsBudgetExhausted(t *testing.T) {
+	tests := []struct {
+		name      string
+		chans     map[lnwire.ShortChannelID]Reason
+		peers     map[route.Vertex]Reason
+		exhausted bool
+	}{
+		{
+			name:      "no disqualified swaps",
+			exhausted: false,
+		},
+		{
+			name: "other reasons",
+			chans: map[lnwire.ShortChannelID]Reason{
+				chanID1: ReasonInFlight,
+			},
+			peers: map[route.Vertex]Reason{
+				peer1: ReasonLiquidityOk,
+			},
+			exhausted: false,
+		},
+		{
+			name: "channel budget insufficient",
+			chans: map[lnwire.ShortChannelID]Reason{
+				chanID1: ReasonInFlight,
+				chanID2: ReasonBudgetInsufficient,
+			},
+			exhausted: true,
+		},
+		{
+			name: "peer budget insufficient",
+			peers: map[route.Vertex]Reason{
+				peer1: ReasonBudgetInsufficient,
+			},
+			exhausted: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			suggestions := newSuggestions()
+			for channel, reason := range testCase.chans {
+				suggestions.DisqualifiedChans[channel] = reason
+			}
+			for peer, reason := range testCase.peers {
+				suggestions.DisqualifiedPeers[peer] = reason
+			}
+
+			require.Equal(
+				t, testCase.exhausted,
+				suggestions.budgetExhausted(),
+			)
+		})
+	}
+}
+
+// TestCompletedOutFees tests the fees that completed loop outs count towards
+// our budget, including abandoned swaps for which we may have lost a prepay
+// that was not recorded.
+func TestCompletedOutFees(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         loopdb.SwapStateData
+		prepayRouting btcutil.Amount
+		prepay        btcutil.Amount
+		expected      btcutil.Amount
+	}{
+		{
+			name: "successful swap",
+			state: loopdb.SwapStateData{
+				State: loopdb.StateSuccess,
+				Cost: loopdb.SwapCost{
+					Server:   100,
+					Onchain:  20,
+					Offchain: 3,
+				},
+			},
+			prepayRouting: 10,
+			prepay:        500,
+			expected:      123,
+		},
+		{
+			name: "abandoned, prepay released",
+			state: loopdb.SwapStateData{
+				State:         loopdb.StateFailAbandoned,
+				FailureReason: loopdb.FailureReasonPrepayReleased,
+			},
+			prepayRouting: 10,
+			prepay:        500,
+			expected:      0,
+		},
+		{
+			name: "abandoned, prepay not released",
+			state: loopdb.SwapStateData{
+				State: loopdb.StateFailAbandoned,
+			},
+			prepayRouting: 10,
+			prepay:        500,
+			expected:      510,
+		},
+		{
+			name: "abandoned, recorded cost exceeds prepay",
+			state: loopdb.SwapStateData{
+				State: loopdb.StateFailAbandoned,
+				Cost: loopdb.SwapCost{
+					Server:  500,
+					Onchain: 100,
+				},
+			},
+			prepayRouting: 10,
+			prepay:        500,
+			expected:      600,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			var prepay btcutil.Amount
+			if abandonedWithPrepay(testCase.state) {
+				prepay = testCase.prepay
+			}
+
+			fees := completedOutFees(
+				testCase.state.Cost, testCase.prepayRouting,
+				prepay,
+			)
+			require.Equal(t, testCase.expected, fees)
+		})
+	}
+}
+
+// TestExcludeInactiveChannels tests that inactive channels are not used for
+// suggestions when our parameters exclude them.
+func TestExcludeInactiveChannels(t *testing.T) {
+	tests := []struct {
+		name        string
+		exclude     bool
+		suggestions *Suggestions
+	}{
+		{
+			name:    "inactive channels included",
+			exclude: false,
+			suggestions: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer2: ReasonLiquidityOk,
+				},
+			},
+		},
+		{
+			name:    "inactive channels excluded",
+			exclude: true,
+			suggestions: &Suggestions{
+				DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+					chanID1: ReasonInactive,
+				},
+				DisqualifiedPeers: map[route.Vertex]Reason{
+					peer2: ReasonInactive,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+
+			// Both of our channels are inactive.
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1, channel2,
+			}
+
+			// We set a rule for our first channel, and a peer rule
+			// for the peer that we have our second channel with,
+			// which has no channels left to use when we exclude
+			// inactive channels. The peer rule's thresholds are
+			// not breached when the channel is included.
+			params := defaultParameters
+			params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+				chanID1: chanRule,
+			}
+			params.PeerRules = map[route.Vertex]*ThresholdRule{
+				peer2: NewThresholdRule(0, 0),
+			}
+			params.ExcludeInactiveChannels = testCase.exclude
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.suggestions, nil,
+			)
+		})
+	}
+}
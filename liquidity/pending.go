@@ -0,0 +1,198 @@
+package liquidity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoPendingParams is returned when we try to cancel a parameter change
+// but no change is pending.
+var ErrNoPendingParams = errors.New("no pending parameter change")
+
+// ErrParamsVersionMismatch is returned when we try to update our parameters
+// based on a version that is no longer current, because they were changed
+// concurrently.
+var ErrParamsVersionMismatch = errors.New("parameters have changed since " +
+	"version")
+
+// PendingParameters is a set of parameters that increases the fees that
+// autoloop may spend, and is held until our change delay has passed before
+// it replaces our current parameters.
+type PendingParameters struct {
+	// Parameters is the set of parameters that will take effect.
+	Parameters Parameters
+
+	// EffectiveAt is the time from which the parameters take effect.
+	EffectiveAt time.Time
+}
+
+// increasesSpend returns a boolean indicating whether moving from our current
+// set of parameters to the updated set provided increases the fees that
+// autoloop may spend. This is the case if our budget is increased, if our
+// budget's start date is moved forward (which excludes swaps that have already
+// spent from our budget), if any of our fee limits are raised, or if any of
+// our rules' fee budgets are relaxed.
+func increasesSpend(current, updated Parameters) bool {
+	if updated.AutoFeeBudget > current.AutoFeeBudget {
+		return true
+	}
+
+	if updated.AutoFeeStartDate.After(current.AutoFeeStartDate) {
+		return true
+	}
+
+	// A zero sweep fee rate limit is disabled, so removing our limit or
+	// raising it allows us to swap at higher on chain fees.
+	if current.SweepFeeRateLimit != 0 &&
+		(updated.SweepFeeRateLimit == 0 ||
+			updated.SweepFeeRateLimit > current.SweepFeeRateLimit) {
+
+		return true
+	}
+
+	if ruleBudgetsIncreased(current, updated) {
+		return true
+	}
+
+	return feeLimitIncreased(current.FeeLimit, updated.FeeLimit)
+}
+
+// ruleBudgetsIncreased returns a boolean indicating whether any rule that has
+// its own fee budget in our current parameters may spend more in our updated
+// parameters. This is the case if its budget is removed or raised, or if its
+// budget period changes in a way that may exclude swaps that have already
+// spent from its budget. Periods that fall back to our global start date
+// cannot be compared with rolling periods, so we treat any change to or from
+// them as an increase.
+func ruleBudgetsIncreased(current, updated Parameters) bool {
+	increased := func(current, updated *ThresholdRule) bool {
+		if current.FeeBudget == 0 {
+			return false
+		}
+
+		if updated.FeeBudget == 0 ||
+			updated.FeeBudget > current.FeeBudget {
+
+			return true
+		}
+
+		currentPeriod := current.FeeBudgetPeriod
+		updatedPeriod := updated.FeeBudgetPeriod
+
+		if currentPeriod == 0 || updatedPeriod == 0 {
+			return currentPeriod != updatedPeriod
+		}
+
+		return updatedPeriod < currentPeriod
+	}
+
+	for channel, rule := range current.ChannelRules {
+		updatedRule, ok := updated.ChannelRules[channel]
+		if ok && increased(rule, updatedRule) {
+			return true
+		}
+	}
+
+	for peer, rule := range current.PeerRules {
+		updatedRule, ok := updated.PeerRules[peer]
+		if ok && increased(rule, updatedRule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// feeLimitIncreased returns a boolean indicating whether the updated fee limit
+// provided allows any category of fees to be higher than our current limit.
+// If the limits are of different types, they cannot be compared, so we treat
+// the change as an increase.
+func feeLimitIncreased(current, updated FeeLimit) bool {
+	switch c := current.(type) {
+	case *FeePortion:
+		n, ok := updated.(*FeePortion)
+		if !ok {
+			return true
+		}
+
+		return n.PartsPerMillion > c.PartsPerMillion
+
+	case *FeeCategoryLimit:
+		n, ok := updated.(*FeeCategoryLimit)
+		if !ok {
+			return true
+		}
+
+		return n.MaximumPrepay > c.MaximumPrepay ||
+			n.MaximumSwapFeePPM > c.MaximumSwapFeePPM ||
+			n.MaximumRoutingFeePPM > c.MaximumRoutingFeePPM ||
+			n.MaximumPrepayRoutingFeePPM >
+				c.MaximumPrepayRoutingFeePPM ||
+			n.MaximumMinerFee > c.MaximumMinerFee ||
+			n.SweepFeeRateLimit > c.SweepFeeRateLimit
+
+	default:
+		return true
+	}
+}
+
+// GetPendingParameters returns a copy of the parameter change that is waiting
+// for our change delay to pass, or nil if no change is pending.
+func (m *Manager) GetPendingParameters() *PendingParameters {
+	m.paramsLock.Lock()
+	defer m.paramsLock.Unlock()
+
+	m.applyPendingParams()
+
+	if m.pending == nil {
+		return nil
+	}
+
+	return &PendingParameters{
+		Parameters:  cloneParameters(m.pending.Parameters),
+		EffectiveAt: m.pending.EffectiveAt,
+	}
+}
+
+// CancelPendingParameters cancels the parameter change that is waiting for our
+// change delay to pass, leaving our current parameters unchanged.
+func (m *Manager) CancelPendingParameters() error {
+	m.updateLock.Lock()
+	defer m.updateLock.Unlock()
+
+	m.paramsLock.Lock()
+	defer m.paramsLock.Unlock()
+
+	m.applyPendingParams()
+
+	if m.pending == nil {
+		return ErrNoPendingParams
+	}
+
+	log.Infof("Cancelled parameter change that would have taken effect "+
+		"at: %v", m.pending.EffectiveAt)
+
+	m.pending = nil
+	m.version++
+
+	return nil
+}
+
+// applyPendingParams replaces our current parameters with our pending change
+// if its change delay has passed. It must be called with the params lock
+// held.
+func (m *Manager) applyPendingParams() {
+	if m.pending == nil {
+		return
+	}
+
+	if m.cfg.Clock.Now().Before(m.pending.EffectiveAt) {
+		return
+	}
+
+	log.Infof("Applying parameter change that was pending until: %v",
+		m.pending.EffectiveAt)
+
+	m.params = m.pending.Parameters
+	m.pending = nil
+}
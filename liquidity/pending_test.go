@@ -0,0 +1,279 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeeLimitIncreased tests detection of fee limit changes that allow
+// autoloop to spend more.
+func TestFeeLimitIncreased(t *testing.T) {
+	categories := func() *FeeCategoryLimit {
+		return NewFeeCategoryLimit(100, 100, 100, 100, 100, 100)
+	}
+
+	higherMiner := categories()
+	higherMiner.MaximumMinerFee++
+
+	lowerSwap := categories()
+	lowerSwap.MaximumSwapFeePPM--
+
+	tests := []struct {
+		name      string
+		current   FeeLimit
+		updated   FeeLimit
+		increased bool
+	}{
+		{
+			name:      "portion increased",
+			current:   NewFeePortion(100),
+			updated:   NewFeePortion(101),
+			increased: true,
+		},
+		{
+			name:      "portion decreased",
+			current:   NewFeePortion(100),
+			updated:   NewFeePortion(99),
+			increased: false,
+		},
+		{
+			name:      "category increased",
+			current:   categories(),
+			updated:   higherMiner,
+			increased: true,
+		},
+		{
+			name:      "category decreased",
+			current:   categories(),
+			updated:   lowerSwap,
+			increased: false,
+		},
+		{
+			name:      "category unchanged",
+			current:   categories(),
+			updated:   categories(),
+			increased: false,
+		},
+		{
+			name:      "different types",
+			current:   NewFeePortion(100),
+			updated:   categories(),
+			increased: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.increased, feeLimitIncreased(
+				testCase.current, testCase.updated,
+			))
+		})
+	}
+}
+
+// TestSweepFeeRateLimitIncreased tests detection of changes to our sweep fee
+// rate limit that allow autoloop to spend more.
+func TestSweepFeeRateLimitIncreased(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   chainfee.SatPerKWeight
+		updated   chainfee.SatPerKWeight
+		increased bool
+	}{
+		{
+			name:      "limit set",
+			current:   0,
+			updated:   1000,
+			increased: false,
+		},
+		{
+			name:      "limit removed",
+			current:   1000,
+			updated:   0,
+			increased: true,
+		},
+		{
+			name:      "limit raised",
+			current:   1000,
+			updated:   1001,
+			increased: true,
+		},
+		{
+			name:      "limit lowered",
+			current:   1000,
+			updated:   999,
+			increased: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			current := defaultParameters
+			current.SweepFeeRateLimit = testCase.current
+
+			updated := defaultParameters
+			updated.SweepFeeRateLimit = testCase.updated
+
+			require.Equal(
+				t, testCase.increased,
+				increasesSpend(current, updated),
+			)
+		})
+	}
+}
+
+// TestRuleBudgetsIncreased tests detection of changes to our rules' fee
+// budgets that allow autoloop to spend more.
+func TestRuleBudgetsIncreased(t *testing.T) {
+	budgetRule := func(budget btcutil.Amount,
+		period time.Duration) *ThresholdRule {
+
+		rule := NewThresholdRule(10, 10)
+		rule.FeeBudget = budget
+		rule.FeeBudgetPeriod = period
+
+		return rule
+	}
+
+	tests := []struct {
+		name      string
+		current   *ThresholdRule
+		updated   *ThresholdRule
+		increased bool
+	}{
+		{
+			name:      "budget set",
+			current:   budgetRule(0, 0),
+			updated:   budgetRule(1000, time.Hour),
+			increased: false,
+		},
+		{
+			name:      "budget removed",
+			current:   budgetRule(1000, time.Hour),
+			updated:   budgetRule(0, 0),
+			increased: true,
+		},
+		{
+			name:      "budget raised",
+			current:   budgetRule(1000, time.Hour),
+			updated:   budgetRule(1001, time.Hour),
+			increased: true,
+		},
+		{
+			name:      "budget lowered",
+			current:   budgetRule(1000, time.Hour),
+			updated:   budgetRule(999, time.Hour),
+			increased: false,
+		},
+		{
+			name:      "period shortened",
+			current:   budgetRule(1000, time.Hour),
+			updated:   budgetRule(1000, time.Minute),
+			increased: true,
+		},
+		{
+			name:      "period lengthened",
+			current:   budgetRule(1000, time.Minute),
+			updated:   budgetRule(1000, time.Hour),
+			increased: false,
+		},
+		{
+			name:      "period removed",
+			current:   budgetRule(1000, time.Hour),
+			updated:   budgetRule(1000, 0),
+			increased: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			current := defaultParameters
+			current.PeerRules = map[route.Vertex]*ThresholdRule{
+				peer1: testCase.current,
+			}
+
+			updated := defaultParameters
+			updated.PeerRules = map[route.Vertex]*ThresholdRule{
+				peer1: testCase.updated,
+			}
+
+			require.Equal(
+				t, testCase.increased,
+				increasesSpend(current, updated),
+			)
+		})
+	}
+}
+
+// TestPendingParameters tests that changes which increase autoloop's fees are
+// held until our change delay has passed, while other changes apply
+// immediately.
+func TestPendingParameters(t *testing.T) {
+	cfg, _ := newTestConfig()
+
+	testClock := clock.NewTestClock(testTime)
+	cfg.Clock = testClock
+	cfg.ParamsChangeDelay = time.Hour
+
+	manager := NewManager(cfg)
+	ctx := context.Background()
+
+	require.Nil(t, manager.GetPendingParameters())
+	require.Equal(t, ErrNoPendingParams, manager.CancelPendingParameters())
+
+	// Decreasing our budget takes effect immediately.
+	lower := manager.GetParameters()
+	lower.AutoFeeBudget = defaultBudget / 2
+
+	require.NoError(t, manager.SetParameters(ctx, lower))
+	require.Equal(t, lower.AutoFeeBudget,
+		manager.GetParameters().AutoFeeBudget)
+	require.Nil(t, manager.GetPendingParameters())
+
+	// Increasing our budget is held until the delay has passed.
+	higher := manager.GetParameters()
+	higher.AutoFeeBudget = defaultBudget * 2
+
+	require.NoError(t, manager.SetParameters(ctx, higher))
+	require.Equal(t, lower.AutoFeeBudget,
+		manager.GetParameters().AutoFeeBudget)
+
+	pending := manager.GetPendingParameters()
+	require.NotNil(t, pending)
+	require.Equal(t, testTime.Add(time.Hour), pending.EffectiveAt)
+	require.Equal(t, higher.AutoFeeBudget,
+		pending.Parameters.AutoFeeBudget)
+
+	// Cancel the change and assert that it never takes effect.
+	require.NoError(t, manager.CancelPendingParameters())
+	require.Nil(t, manager.GetPendingParameters())
+
+	testClock.SetTime(testTime.Add(time.Hour))
+	require.Equal(t, lower.AutoFeeBudget,
+		manager.GetParameters().AutoFeeBudget)
+
+	// Raise our fee limit, and assert that it only applies once the delay
+	// has passed.
+	higherFees := manager.GetParameters()
+	higherFees.FeeLimit = NewFeePortion(defaultFeePPM * 2)
+
+	require.NoError(t, manager.SetParameters(ctx, higherFees))
+	require.Equal(t, defaultFeePortion(), manager.GetParameters().FeeLimit)
+
+	testClock.SetTime(testTime.Add(time.Hour * 2))
+	require.Equal(t, higherFees.FeeLimit, manager.GetParameters().FeeLimit)
+	require.Nil(t, manager.GetPendingParameters())
+}
@@ -65,6 +65,56 @@ const (
 	// ReasonFeePPMInsufficient indicates that the fees a swap would require
 	// are greater than the portion of swap amount allocated to fees.
 	ReasonFeePPMInsufficient
+
+	// ReasonLockedValue indicates that a swap would take the total value
+	// locked in pending swaps above the maximum that has been configured.
+	ReasonLockedValue
+
+	// ReasonRebalanceCheaper indicates that a circular rebalance of the
+	// channel is estimated to be much cheaper than the swap.
+	ReasonRebalanceCheaper
+
+	// ReasonDisqualified indicates that the channel or peer has been
+	// disqualified from swaps by the user.
+	ReasonDisqualified
+
+	// ReasonMinAmount indicates that the amount we need to swap to meet a
+	// rule's thresholds is below the server's minimum swap amount.
+	ReasonMinAmount
+
+	// ReasonNoChannel indicates that we do not currently have any open
+	// channels that a rule applies to.
+	ReasonNoChannel
+
+	// ReasonCooldown indicates that a channel or peer was part of a
+	// successful swap within our swap cooldown period.
+	ReasonCooldown
+
+	// ReasonTransientImbalance indicates that a rule's thresholds are
+	// breached, but have not been breached for the rule's minimum
+	// imbalance duration.
+	ReasonTransientImbalance
+
+	// ReasonReputation indicates that the peer a swap would be routed
+	// through does not have the minimum reputation required by the rule.
+	ReasonReputation
+
+	// ReasonOutsideSchedule indicates that autoloop is not dispatching
+	// swaps because the current time is outside of its schedule.
+	ReasonOutsideSchedule
+
+	// ReasonLoopInWalletFraction indicates that a loop in would commit
+	// more than the maximum fraction of our on-chain funds to pending loop
+	// ins.
+	ReasonLoopInWalletFraction
+
+	// ReasonRuleBudget indicates that a swap's worst-case fees exceed the
+	// remaining fee budget of the rule that it was suggested for.
+	ReasonRuleBudget
+
+	// ReasonInactive indicates that the channel, or all of our channels
+	// with the peer, are inactive and we exclude inactive channels.
+	ReasonInactive
 )
 
 // String returns a string representation of a reason.
@@ -112,6 +162,42 @@ func (r Reason) String() string {
 	case ReasonFeePPMInsufficient:
 		return "fee portion insufficient"
 
+	case ReasonLockedValue:
+		return "maximum locked value reached"
+
+	case ReasonRebalanceCheaper:
+		return "rebalance cheaper"
+
+	case ReasonDisqualified:
+		return "disqualified by user"
+
+	case ReasonMinAmount:
+		return "below minimum swap amount"
+
+	case ReasonNoChannel:
+		return "no open channel"
+
+	case ReasonCooldown:
+		return "swapped within cooldown"
+
+	case ReasonTransientImbalance:
+		return "imbalance not persistent"
+
+	case ReasonReputation:
+		return "peer reputation too low"
+
+	case ReasonOutsideSchedule:
+		return "outside of schedule"
+
+	case ReasonLoopInWalletFraction:
+		return "loop in wallet fraction reached"
+
+	case ReasonRuleBudget:
+		return "rule budget insufficient"
+
+	case ReasonInactive:
+		return "channel inactive"
+
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,261 @@
+package liquidity
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// ReconciledSwap compares the fees that a completed, automatically dispatched
+// swap reserved from our budget while it was in flight to the fees that it
+// realized once it completed.
+type ReconciledSwap struct {
+	// SwapHash is the hash of the swap.
+	SwapHash lntypes.Hash
+
+	// SwapType is the type of the swap.
+	SwapType swap.Type
+
+	// CompletedAt is the time at which the swap completed.
+	CompletedAt time.Time
+
+	// Reserved is the worst-case amount of fees that the swap counted
+	// against our budget while it was in flight.
+	Reserved btcutil.Amount
+
+	// Realized is the amount of fees that the swap counts against our
+	// budget now that it has completed.
+	Realized btcutil.Amount
+}
+
+// Released returns the amount of budget that the swap released when it
+// completed. It is negative if the swap spent more than it reserved, which
+// is possible if we had to bump its sweep fee past our limit.
+func (r *ReconciledSwap) Released() btcutil.Amount {
+	return r.Reserved - r.Realized
+}
+
+// BudgetReconciliation is a report of the fees that automatically dispatched
+// swaps reserved from our budget compared to the fees that they realized.
+type BudgetReconciliation struct {
+	// Time is the time at which the report was created.
+	Time time.Time
+
+	// StartDate is the start date of our budget. Only swaps that completed
+	// after this date are reconciled.
+	StartDate time.Time
+
+	// Swaps contains the swaps that completed since our budget's start
+	// date, ordered by completion time.
+	Swaps []*ReconciledSwap
+
+	// Reserved is the total worst-case amount of fees that our completed
+	// swaps reserved while they were in flight.
+	Reserved btcutil.Amount
+
+	// Realized is the total amount of fees that our completed swaps
+	// realized.
+	Realized btcutil.Amount
+
+	// Pending is the worst-case amount of fees that our in flight swaps
+	// currently reserve.
+	Pending btcutil.Amount
+
+	// InFlight is the number of automatically dispatched swaps that are
+	// currently in flight.
+	InFlight int
+}
+
+// Released returns the total amount of budget that our completed swaps
+// released by realizing less than their worst-case fees.
+func (b *BudgetReconciliation) Released() btcutil.Amount {
+	return b.Reserved - b.Realized
+}
+
+// reconciliationEnabled returns a boolean indicating whether we reconcile our
+// budget on a schedule.
+func (m *Manager) reconciliationEnabled() bool {
+	return m.cfg.ReconcileInterval != 0
+}
+
+// ReconcileBudget returns a report of the fees that our automatically
+// dispatched swaps reserved from our budget while they were in flight,
+// compared to the fees that they realized once they completed.
+func (m *Manager) ReconcileBudget(ctx context.Context) (*BudgetReconciliation,
+	error) {
+
+	m.paramsLock.Lock()
+	defer m.paramsLock.Unlock()
+
+	m.applyPendingParams()
+
+	loopOut, err := m.cfg.ListLoopOut(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loopIn, err := m.cfg.ListLoopIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.reconcileBudget(ctx, loopOut, loopIn)
+}
+
+// reconcileBudget creates a budget reconciliation report from the set of
+// swaps provided. Completed swaps are counted in the same way as they are for
+// our budget, so the total that they realize is the amount that our budget
+// reports as spent. It must be called with the params lock held.
+func (m *Manager) reconcileBudget(ctx context.Context,
+	loopOut []*loopdb.LoopOut,
+	loopIn []*loopdb.LoopIn) (*BudgetReconciliation, error) {
+
+	report := &BudgetReconciliation{
+		Time:      m.cfg.Clock.Now(),
+		StartDate: m.params.AutoFeeStartDate,
+	}
+
+	for _, out := range loopOut {
+		if out.Contract.Label != labels.AutoloopLabel(swap.TypeOut) {
+			continue
+		}
+
+		realized, pending, err := m.loopOutBudgetFees(
+			ctx, out, report.StartDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if pending {
+			report.InFlight++
+			report.Pending += realized
+			continue
+		}
+
+		if out.LastUpdateTime().Before(report.StartDate) {
+			continue
+		}
+
+		reserved, err := m.loopOutReservedFees(ctx, out.Contract)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Swaps = append(report.Swaps, &ReconciledSwap{
+			SwapHash:    out.Hash,
+			SwapType:    swap.TypeOut,
+			CompletedAt: out.LastUpdateTime(),
+			Reserved:    reserved,
+			Realized:    realized,
+		})
+	}
+
+	for _, in := range loopIn {
+		if in.Contract.Label != labels.AutoloopLabel(swap.TypeIn) {
+			continue
+		}
+
+		realized, pending := loopInBudgetFees(in, report.StartDate)
+		if pending {
+			report.InFlight++
+			report.Pending += realized
+			continue
+		}
+
+		if in.LastUpdateTime().Before(report.StartDate) {
+			continue
+		}
+
+		report.Swaps = append(report.Swaps, &ReconciledSwap{
+			SwapHash:    in.Hash,
+			SwapType:    swap.TypeIn,
+			CompletedAt: in.LastUpdateTime(),
+			Reserved: worstCaseInFees(
+				in.Contract.MaxMinerFee, in.Contract.MaxSwapFee,
+			),
+			Realized: realized,
+		})
+	}
+
+	sort.SliceStable(report.Swaps, func(i, j int) bool {
+		return report.Swaps[i].CompletedAt.Before(
+			report.Swaps[j].CompletedAt,
+		)
+	})
+
+	for _, reconciled := range report.Swaps {
+		report.Reserved += reconciled.Reserved
+		report.Realized += reconciled.Realized
+	}
+
+	return report, nil
+}
+
+// runReconciliation reconciles our budget against the realized fees of our
+// completed swaps, and logs the budget that swaps which completed since our
+// last reconciliation released. Our budget always counts completed swaps at
+// their realized fees, but if our last autoloop check found our budget to be
+// exhausted, we run another check straight away so that released budget can
+// be used without waiting for our next scheduled check. It must be called by
+// our autoloop goroutine.
+func (m *Manager) runReconciliation(ctx context.Context) error {
+	report, err := m.ReconcileBudget(ctx)
+	if err != nil {
+		return err
+	}
+
+	reconciled := make(map[lntypes.Hash]struct{}, len(report.Swaps))
+	for _, reconciledSwap := range report.Swaps {
+		reconciled[reconciledSwap.SwapHash] = struct{}{}
+	}
+
+	// If this is our first reconciliation, we have no record of the swaps
+	// that we have already reconciled, so we just record our set.
+	previous := m.reconciled
+	m.reconciled = reconciled
+
+	if previous == nil {
+		return nil
+	}
+
+	var (
+		released  btcutil.Amount
+		completed int
+	)
+
+	for _, reconciledSwap := range report.Swaps {
+		if _, ok := previous[reconciledSwap.SwapHash]; ok {
+			continue
+		}
+
+		completed++
+		released += reconciledSwap.Released()
+	}
+
+	if completed == 0 {
+		return nil
+	}
+
+	log.Infof("Autoloop budget reconciled: %v swaps completed, "+
+		"releasing %v of reserved fees. Since %v, %v reserved and "+
+		"%v realized by %v swaps, %v pending for %v in flight",
+		completed, released, report.StartDate, report.Reserved,
+		report.Realized, len(report.Swaps), report.Pending,
+		report.InFlight)
+
+	if released > 0 && m.budgetExhausted {
+		log.Infof("Autoloop budget released after being exhausted, " +
+			"performing autoloop check")
+
+		m.runAutoloop(ctx)
+	}
+
+	return nil
+}
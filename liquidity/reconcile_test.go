@@ -0,0 +1,181 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcileOut creates an automatically dispatched loop out with a single
+// event in the state provided.
+func reconcileOut(hash lntypes.Hash, state loopdb.SwapState,
+	cost loopdb.SwapCost, ts time.Time) *loopdb.LoopOut {
+
+	label := labels.AutoloopLabel(swap.TypeOut)
+
+	return &loopdb.LoopOut{
+		Loop: loopdb.Loop{
+			Hash: hash,
+			Events: []*loopdb.LoopEvent{
+				{
+					SwapStateData: loopdb.SwapStateData{
+						Cost:  cost,
+						State: state,
+					},
+					Time: ts,
+				},
+			},
+		},
+		Contract: &loopdb.LoopOutContract{
+			SwapContract: loopdb.SwapContract{
+				Label:          label,
+				InitiationTime: testBudgetStart,
+				MaxSwapFee:     100,
+				MaxMinerFee:    200,
+			},
+			MaxPrepayRoutingFee: 10,
+			MaxSwapRoutingFee:   20,
+		},
+	}
+}
+
+// TestReconcileBudget tests reconciliation of the fees that our automatically
+// dispatched swaps reserved against the fees that they realized.
+func TestReconcileBudget(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		outCost = loopdb.SwapCost{
+			Server:  100,
+			Onchain: 50,
+		}
+		inCost = loopdb.SwapCost{
+			Server:  60,
+			Onchain: 10,
+		}
+
+		// Our loop out reserves 10 + 20 + 100 + 200 while it is in
+		// flight, and our loop in reserves 50 + 60.
+		outReserved btcutil.Amount = 330
+		inReserved  btcutil.Amount = 110
+
+		outHash = lntypes.Hash{1}
+		inHash  = lntypes.Hash{2}
+	)
+
+	// A manually dispatched swap is not part of our budget.
+	manual := reconcileOut(
+		lntypes.Hash{3}, loopdb.StateSuccess, outCost, testTime,
+	)
+	manual.Contract.Label = ""
+
+	loopOut := []*loopdb.LoopOut{
+		reconcileOut(outHash, loopdb.StateSuccess, outCost, testTime),
+		reconcileOut(
+			lntypes.Hash{4}, loopdb.StateSuccess, outCost,
+			testBudgetStart.Add(time.Hour*-1),
+		),
+		reconcileOut(
+			lntypes.Hash{5}, loopdb.StateInitiated, outCost,
+			testTime,
+		),
+		manual,
+	}
+
+	inEvent := &loopdb.LoopEvent{
+		SwapStateData: loopdb.SwapStateData{
+			Cost:  inCost,
+			State: loopdb.StateSuccess,
+		},
+		Time: testBudgetStart,
+	}
+
+	loopIn := []*loopdb.LoopIn{
+		{
+			Loop: loopdb.Loop{
+				Hash:   inHash,
+				Events: []*loopdb.LoopEvent{inEvent},
+			},
+			Contract: &loopdb.LoopInContract{
+				SwapContract: loopdb.SwapContract{
+					Label: labels.AutoloopLabel(
+						swap.TypeIn,
+					),
+					MaxSwapFee:  60,
+					MaxMinerFee: 50,
+				},
+			},
+		},
+	}
+
+	cfg, _ := newTestConfig()
+	cfg.ListLoopOut = func(context.Context) ([]*loopdb.LoopOut, error) {
+		return loopOut, nil
+	}
+	cfg.ListLoopIn = func(context.Context) ([]*loopdb.LoopIn, error) {
+		return loopIn, nil
+	}
+
+	params := defaultParameters
+	params.AutoFeeStartDate = testBudgetStart
+
+	manager := NewManager(cfg)
+	require.NoError(t, manager.SetParameters(ctx, params))
+
+	report, err := manager.ReconcileBudget(ctx)
+	require.NoError(t, err)
+
+	expected := &BudgetReconciliation{
+		Time:      testTime,
+		StartDate: testBudgetStart,
+		Swaps: []*ReconciledSwap{
+			{
+				SwapHash:    inHash,
+				SwapType:    swap.TypeIn,
+				CompletedAt: testBudgetStart,
+				Reserved:    inReserved,
+				Realized:    inCost.Total(),
+			},
+			{
+				SwapHash:    outHash,
+				SwapType:    swap.TypeOut,
+				CompletedAt: testTime,
+				Reserved:    outReserved,
+				Realized:    outCost.Total(),
+			},
+		},
+		Reserved: outReserved + inReserved,
+		Realized: outCost.Total() + inCost.Total(),
+		Pending:  outReserved,
+		InFlight: 1,
+	}
+	require.Equal(t, expected, report)
+	require.Equal(t, btcutil.Amount(220), report.Released())
+
+	// Our first reconciliation records the swaps that it covers.
+	require.NoError(t, manager.runReconciliation(ctx))
+	require.Equal(t, map[lntypes.Hash]struct{}{
+		outHash: {},
+		inHash:  {},
+	}, manager.reconciled)
+
+	// When our in flight swap completes, it is added to our set of
+	// reconciled swaps.
+	completedHash := lntypes.Hash{5}
+	loopOut[2] = reconcileOut(
+		completedHash, loopdb.StateSuccess, outCost, testTime,
+	)
+
+	require.NoError(t, manager.runReconciliation(ctx))
+	require.Equal(t, map[lntypes.Hash]struct{}{
+		outHash:       {},
+		inHash:        {},
+		completedHash: {},
+	}, manager.reconciled)
+}
@@ -0,0 +1,270 @@
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// ErrReputationDisabled is returned when a rule requires a minimum peer
+// reputation, but we are not tracking swap outcomes for our peers.
+var ErrReputationDisabled = errors.New("rules with a minimum reputation " +
+	"require peer swap outcomes to be tracked")
+
+// PeerReputation summarizes the outcomes of the completed swaps that we have
+// routed through a peer.
+type PeerReputation struct {
+	// Swaps is the number of completed swaps that were routed through the
+	// peer.
+	Swaps int
+
+	// Successes is the number of those swaps that succeeded.
+	Successes int
+
+	// FeeRatioPPM is the average of the fees that successful swaps cost
+	// us, expressed as parts per million of the fees that they were quoted
+	// and allowed to spend.
+	FeeRatioPPM uint64
+
+	// AverageCompletion is the average amount of time that successful
+	// swaps took to complete.
+	AverageCompletion time.Duration
+}
+
+// Score returns the reputation score of a peer, which is the percentage of
+// swaps routed through the peer that succeeded. Peers that we have no swap
+// history with have a score of zero.
+func (p *PeerReputation) Score() int {
+	if p.Swaps == 0 {
+		return 0
+	}
+
+	return p.Successes * 100 / p.Swaps
+}
+
+// newPeerReputations aggregates a set of swap outcomes into a reputation for
+// each peer that they were recorded for.
+func newPeerReputations(
+	outcomes []*loopdb.PeerOutcome) map[route.Vertex]*PeerReputation {
+
+	var (
+		reputations = make(map[route.Vertex]*PeerReputation)
+		feeRatios   = make(map[route.Vertex]uint64)
+		completions = make(map[route.Vertex]time.Duration)
+	)
+
+	for _, outcome := range outcomes {
+		reputation, ok := reputations[outcome.Peer]
+		if !ok {
+			reputation = &PeerReputation{}
+			reputations[outcome.Peer] = reputation
+		}
+
+		reputation.Swaps++
+		if !outcome.Success {
+			continue
+		}
+
+		reputation.Successes++
+		completions[outcome.Peer] += outcome.Duration
+
+		if outcome.FeeLimit > 0 {
+			feeRatios[outcome.Peer] += uint64(
+				outcome.Cost * FeeBase / outcome.FeeLimit,
+			)
+		}
+	}
+
+	for peer, reputation := range reputations {
+		if reputation.Successes == 0 {
+			continue
+		}
+
+		successes := uint64(reputation.Successes)
+		reputation.FeeRatioPPM = feeRatios[peer] / successes
+		reputation.AverageCompletion = completions[peer] /
+			time.Duration(successes)
+	}
+
+	return reputations
+}
+
+// PeerReputations returns the reputations of all the peers that we have
+// recorded swap outcomes for. Outcomes are recorded on each autoloop tick, so
+// swaps that completed since our last tick are not yet included.
+func (m *Manager) PeerReputations(ctx context.Context) (
+	map[route.Vertex]*PeerReputation, error) {
+
+	if m.cfg.ListPeerOutcomes == nil {
+		return nil, ErrReputationDisabled
+	}
+
+	outcomes, err := m.cfg.ListPeerOutcomes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPeerReputations(outcomes), nil
+}
+
+// reputationEnabled returns a boolean indicating whether we are tracking the
+// swap outcomes of our peers.
+func (m *Manager) reputationEnabled() bool {
+	return m.cfg.AddPeerOutcome != nil && m.cfg.ListPeerOutcomes != nil
+}
+
+// validateReputationRules checks that we are tracking swap outcomes for our
+// peers if any of the rules in the set of parameters provided require a
+// minimum reputation.
+func (m *Manager) validateReputationRules(params Parameters) error {
+	if !m.reputationEnabled() && params.haveReputationRules() {
+		return ErrReputationDisabled
+	}
+
+	return nil
+}
+
+// recordPeerOutcomes records the outcomes of swaps that have completed since
+// we last checked for the peers that they were routed through. Loop outs are
+// attributed to the peers of their outgoing channels, and loop ins to their
+// last hop. We record outcomes as soon as we see them, because we can no
+// longer look up the peer for a loop out's channel once it has closed.
+func (m *Manager) recordPeerOutcomes(ctx context.Context) error {
+	if !m.reputationEnabled() {
+		return nil
+	}
+
+	// If we have not loaded the set of swaps that we have already recorded
+	// yet, we do so now.
+	if m.recordedOutcomes == nil {
+		outcomes, err := m.cfg.ListPeerOutcomes(ctx)
+		if err != nil {
+			return err
+		}
+
+		m.recordedOutcomes = make(map[lntypes.Hash]struct{})
+		for _, outcome := range outcomes {
+			m.recordedOutcomes[outcome.SwapHash] = struct{}{}
+		}
+	}
+
+	loopOut, err := m.cfg.ListLoopOut(ctx)
+	if err != nil {
+		return err
+	}
+
+	loopIn, err := m.cfg.ListLoopIn(ctx)
+	if err != nil {
+		return err
+	}
+
+	channels, err := m.cfg.Lnd.Client.ListChannels(ctx)
+	if err != nil {
+		return err
+	}
+
+	channelPeers := make(map[uint64]route.Vertex, len(channels))
+	for _, channel := range channels {
+		channelPeers[channel.ChannelID] = channel.PubKeyBytes
+	}
+
+	for _, out := range loopOut {
+		if !m.unrecordedOutcome(&out.Loop) {
+			continue
+		}
+
+		peers := make(map[route.Vertex]struct{})
+		for _, channel := range out.Contract.OutgoingChanSet {
+			peer, ok := channelPeers[channel]
+			if !ok {
+				shortID := lnwire.NewShortChanIDFromInt(channel)
+				log.Debugf("Could not find peer for channel: "+
+					"%v of swap: %v", shortID, out.Hash)
+
+				continue
+			}
+
+			peers[peer] = struct{}{}
+		}
+
+		feeLimit := out.Contract.MaxSwapFee + out.Contract.MaxMinerFee +
+			out.Contract.MaxSwapRoutingFee +
+			out.Contract.MaxPrepayRoutingFee
+
+		for peer := range peers {
+			outcome := newPeerOutcome(
+				peer, &out.Loop, swap.TypeOut,
+				out.Contract.AmountRequested, feeLimit,
+				out.Contract.InitiationTime,
+				out.LastUpdateTime(),
+			)
+
+			if err := m.cfg.AddPeerOutcome(outcome); err != nil {
+				return err
+			}
+		}
+
+		m.recordedOutcomes[out.Hash] = struct{}{}
+	}
+
+	for _, in := range loopIn {
+		if !m.unrecordedOutcome(&in.Loop) {
+			continue
+		}
+
+		if in.Contract.LastHop != nil {
+			outcome := newPeerOutcome(
+				*in.Contract.LastHop, &in.Loop, swap.TypeIn,
+				in.Contract.AmountRequested,
+				in.Contract.MaxSwapFee+in.Contract.MaxMinerFee,
+				in.Contract.InitiationTime, in.LastUpdateTime(),
+			)
+
+			if err := m.cfg.AddPeerOutcome(outcome); err != nil {
+				return err
+			}
+		}
+
+		m.recordedOutcomes[in.Hash] = struct{}{}
+	}
+
+	return nil
+}
+
+// unrecordedOutcome returns a boolean indicating whether a swap has completed
+// and we have not yet recorded its outcome.
+func (m *Manager) unrecordedOutcome(loop *loopdb.Loop) bool {
+	if loop.State().State.Type() == loopdb.StateTypePending {
+		return false
+	}
+
+	_, recorded := m.recordedOutcomes[loop.Hash]
+	return !recorded
+}
+
+// newPeerOutcome creates the outcome of a completed swap for a peer.
+func newPeerOutcome(peer route.Vertex, loop *loopdb.Loop,
+	swapType swap.Type, amount, feeLimit btcutil.Amount, initiated,
+	completed time.Time) *loopdb.PeerOutcome {
+
+	state := loop.State()
+
+	return &loopdb.PeerOutcome{
+		Peer:     peer,
+		SwapHash: loop.Hash,
+		Type:     swapType,
+		Success:  state.State == loopdb.StateSuccess,
+		Amount:   amount,
+		FeeLimit: feeLimit,
+		Cost:     state.Cost.Total(),
+		Duration: completed.Sub(initiated),
+		Time:     completed,
+	}
+}
@@ -0,0 +1,290 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// setOutcomeConfig configures the config provided to track peer outcomes
+// using the slice provided as an in-memory store.
+func setOutcomeConfig(cfg *Config, outcomes *[]*loopdb.PeerOutcome) {
+	cfg.AddPeerOutcome = func(outcome *loopdb.PeerOutcome) error {
+		*outcomes = append(*outcomes, outcome)
+		return nil
+	}
+
+	cfg.ListPeerOutcomes = func(_ context.Context) (
+		[]*loopdb.PeerOutcome, error) {
+
+		return *outcomes, nil
+	}
+}
+
+// TestPeerReputations tests aggregation of swap outcomes into peer
+// reputations.
+func TestPeerReputations(t *testing.T) {
+	outcomes := []*loopdb.PeerOutcome{
+		{
+			Peer:     peer1,
+			Success:  true,
+			FeeLimit: 100,
+			Cost:     50,
+			Duration: time.Minute,
+		},
+		{
+			Peer:     peer1,
+			Success:  true,
+			FeeLimit: 100,
+			Cost:     100,
+			Duration: time.Minute * 3,
+		},
+		{
+			Peer:     peer1,
+			Duration: time.Hour,
+		},
+		{
+			Peer: peer2,
+		},
+	}
+
+	require.Equal(t, map[route.Vertex]*PeerReputation{
+		peer1: {
+			Swaps:             3,
+			Successes:         2,
+			FeeRatioPPM:       750000,
+			AverageCompletion: time.Minute * 2,
+		},
+		peer2: {
+			Swaps: 1,
+		},
+	}, newPeerReputations(outcomes))
+
+	require.Equal(t, 66, (&PeerReputation{Swaps: 3, Successes: 2}).Score())
+	require.Equal(t, 0, (&PeerReputation{}).Score())
+}
+
+// TestRecordPeerOutcomes tests that we record the outcomes of completed swaps
+// for the peers that they were routed through, exactly once.
+func TestRecordPeerOutcomes(t *testing.T) {
+	var (
+		initiated = testTime.Add(time.Hour * -1)
+		completed = testTime.Add(time.Minute * -1)
+
+		lastHop = peer2
+
+		successOut = &loopdb.LoopOut{
+			Loop: loopdb.Loop{
+				Hash: lntypes.Hash{1},
+				Events: []*loopdb.LoopEvent{
+					{
+						SwapStateData: loopdb.SwapStateData{
+							State: loopdb.StateSuccess,
+							Cost: loopdb.SwapCost{
+								Server:  10,
+								Onchain: 5,
+							},
+						},
+						Time: completed,
+					},
+				},
+			},
+			Contract: &loopdb.LoopOutContract{
+				SwapContract: loopdb.SwapContract{
+					AmountRequested: 10000,
+					MaxSwapFee:      20,
+					MaxMinerFee:     10,
+					InitiationTime:  initiated,
+				},
+				OutgoingChanSet: loopdb.ChannelSet{
+					chanID1.ToUint64(),
+				},
+			},
+		}
+
+		pendingOut = &loopdb.LoopOut{
+			Loop: loopdb.Loop{
+				Hash: lntypes.Hash{2},
+			},
+			Contract: &loopdb.LoopOutContract{
+				OutgoingChanSet: loopdb.ChannelSet{
+					chanID1.ToUint64(),
+				},
+			},
+		}
+
+		failedIn = &loopdb.LoopIn{
+			Loop: loopdb.Loop{
+				Hash: lntypes.Hash{3},
+				Events: []*loopdb.LoopEvent{
+					{
+						SwapStateData: loopdb.SwapStateData{
+							State: loopdb.StateFailTimeout,
+						},
+						Time: completed,
+					},
+				},
+			},
+			Contract: &loopdb.LoopInContract{
+				SwapContract: loopdb.SwapContract{
+					AmountRequested: 20000,
+					MaxSwapFee:      30,
+					MaxMinerFee:     10,
+					InitiationTime:  initiated,
+				},
+				LastHop: &lastHop,
+			},
+		}
+	)
+
+	cfg, lnd := newTestConfig()
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	cfg.ListLoopOut = func(_ context.Context) ([]*loopdb.LoopOut, error) {
+		return []*loopdb.LoopOut{successOut, pendingOut}, nil
+	}
+	cfg.ListLoopIn = func(_ context.Context) ([]*loopdb.LoopIn, error) {
+		return []*loopdb.LoopIn{failedIn}, nil
+	}
+
+	var outcomes []*loopdb.PeerOutcome
+	setOutcomeConfig(cfg, &outcomes)
+
+	expected := []*loopdb.PeerOutcome{
+		{
+			Peer:     peer1,
+			SwapHash: successOut.Hash,
+			Type:     swap.TypeOut,
+			Success:  true,
+			Amount:   10000,
+			FeeLimit: 30,
+			Cost:     15,
+			Duration: completed.Sub(initiated),
+			Time:     completed,
+		},
+		{
+			Peer:     peer2,
+			SwapHash: failedIn.Hash,
+			Type:     swap.TypeIn,
+			Amount:   20000,
+			FeeLimit: 40,
+			Duration: completed.Sub(initiated),
+			Time:     completed,
+		},
+	}
+
+	manager := NewManager(cfg)
+	require.NoError(t, manager.recordPeerOutcomes(context.Background()))
+	require.Equal(t, expected, outcomes)
+
+	// Recording again should not add duplicate outcomes, including for a
+	// new manager that loads the outcomes already recorded.
+	require.NoError(t, manager.recordPeerOutcomes(context.Background()))
+	require.Equal(t, expected, outcomes)
+
+	manager = NewManager(cfg)
+	require.NoError(t, manager.recordPeerOutcomes(context.Background()))
+	require.Equal(t, expected, outcomes)
+}
+
+// TestMinimumReputation tests that rules which require a minimum reputation
+// only suggest large swaps for peers with a sufficient reputation.
+func TestMinimumReputation(t *testing.T) {
+	var (
+		success = &loopdb.PeerOutcome{
+			Peer:    peer1,
+			Success: true,
+		}
+
+		failure = &loopdb.PeerOutcome{
+			Peer: peer1,
+		}
+
+		lowReputation = &Suggestions{
+			DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+				chanID1: ReasonReputation,
+			},
+			DisqualifiedPeers: noPeersDisqualified,
+		}
+
+		suggested = &Suggestions{
+			OutSwaps: []loop.OutRequest{
+				chan1Rec,
+			},
+			DisqualifiedChans: noneDisqualified,
+			DisqualifiedPeers: noPeersDisqualified,
+		}
+	)
+
+	tests := []struct {
+		name        string
+		largeAmount btcutil.Amount
+		outcomes    []*loopdb.PeerOutcome
+		expected    *Suggestions
+	}{
+		{
+			name:     "no history",
+			expected: lowReputation,
+		},
+		{
+			name: "reputation too low",
+			outcomes: []*loopdb.PeerOutcome{
+				success, failure,
+			},
+			expected: lowReputation,
+		},
+		{
+			name: "sufficient reputation",
+			outcomes: []*loopdb.PeerOutcome{
+				success, success, success, failure,
+			},
+			expected: suggested,
+		},
+		{
+			name:        "swap below large amount",
+			largeAmount: chan1Rec.Amount + 1,
+			expected:    suggested,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			outcomes := testCase.outcomes
+			setOutcomeConfig(cfg, &outcomes)
+
+			rule := *chanRule
+			rule.MinimumReputation = 75
+			rule.LargeSwapAmount = testCase.largeAmount
+
+			params := defaultParameters
+			params.ChannelRules =
+				map[lnwire.ShortChannelID]*ThresholdRule{
+					chanID1: &rule,
+				}
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.expected, nil,
+			)
+		})
+	}
+}
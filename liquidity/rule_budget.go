@@ -0,0 +1,224 @@
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// ErrRuleBudgetsDisabled is returned when a rule sets its own fee budget, but
+// we are not tracking the swaps that we dispatch for rules.
+var ErrRuleBudgetsDisabled = errors.New("rules with a fee budget require " +
+	"rule swaps to be tracked")
+
+// RuleID identifies the channel or peer that a rule is set for. Only one of
+// its fields is set.
+type RuleID struct {
+	// Channel is the channel that a channel rule is set for.
+	Channel lnwire.ShortChannelID
+
+	// Peer is the peer that a peer rule is set for.
+	Peer route.Vertex
+}
+
+// RuleBudget summarizes the use of a rule's fee budget.
+type RuleBudget struct {
+	// Total is the rule's fee budget.
+	Total btcutil.Amount
+
+	// Spent is the amount that swaps dispatched for the rule have spent
+	// on fees, if they completed within the rule's budget period.
+	Spent btcutil.Amount
+
+	// Pending is the worst-case amount that swaps dispatched for the rule
+	// which are still in flight may spend on fees.
+	Pending btcutil.Amount
+}
+
+// available returns the amount of the budget that has not been spent or
+// reserved for in flight swaps.
+func (r *RuleBudget) available() btcutil.Amount {
+	used := r.Spent + r.Pending
+	if used >= r.Total {
+		return 0
+	}
+
+	return r.Total - used
+}
+
+// ruleBudgetsEnabled returns a boolean indicating whether we are tracking the
+// swaps that we dispatch for rules.
+func (m *Manager) ruleBudgetsEnabled() bool {
+	return m.cfg.AddRuleSwap != nil && m.cfg.ListRuleSwaps != nil
+}
+
+// validateRuleBudgets checks that we are tracking the swaps that we dispatch
+// for rules if any of the rules in the set of parameters provided set their
+// own fee budget.
+func (m *Manager) validateRuleBudgets(params Parameters) error {
+	if !m.ruleBudgetsEnabled() && params.haveRuleBudgets() {
+		return ErrRuleBudgetsDisabled
+	}
+
+	return nil
+}
+
+// RuleBudgets returns the use of the fee budget of each of our rules that sets
+// its own budget.
+func (m *Manager) RuleBudgets(ctx context.Context) (map[RuleID]*RuleBudget,
+	error) {
+
+	m.paramsLock.Lock()
+	defer m.paramsLock.Unlock()
+
+	if !m.params.haveRuleBudgets() {
+		return nil, nil
+	}
+
+	loopOut, err := m.cfg.ListLoopOut(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loopIn, err := m.cfg.ListLoopIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ruleBudgets(ctx, loopOut, loopIn)
+}
+
+// ruleBudgetStart returns the time from which swaps count against a rule's
+// fee budget. Rules with a budget period use a rolling window, and rules
+// without one share the start date of our global budget. It must be called
+// with the params lock held.
+func (m *Manager) ruleBudgetStart(rule *ThresholdRule) time.Time {
+	if rule.FeeBudgetPeriod == 0 {
+		return m.params.AutoFeeStartDate
+	}
+
+	return m.cfg.Clock.Now().Add(-rule.FeeBudgetPeriod)
+}
+
+// ruleBudgets returns the use of the fee budget of each of our rules that sets
+// its own budget, using the set of swaps provided. Swaps that were dispatched
+// for a rule are counted in the same way as they are for our global budget,
+// but only swaps that completed after the start of the rule's budget period
+// count as spent. It must be called with the params lock held.
+func (m *Manager) ruleBudgets(ctx context.Context, loopOut []*loopdb.LoopOut,
+	loopIn []*loopdb.LoopIn) (map[RuleID]*RuleBudget, error) {
+
+	var (
+		budgets = make(map[RuleID]*RuleBudget)
+		starts  = make(map[RuleID]time.Time)
+	)
+
+	for channel, rule := range m.params.ChannelRules {
+		if rule.FeeBudget == 0 {
+			continue
+		}
+
+		id := RuleID{Channel: channel}
+		budgets[id] = &RuleBudget{Total: rule.FeeBudget}
+		starts[id] = m.ruleBudgetStart(rule)
+	}
+
+	for peer, rule := range m.params.PeerRules {
+		if rule.FeeBudget == 0 {
+			continue
+		}
+
+		id := RuleID{Peer: peer}
+		budgets[id] = &RuleBudget{Total: rule.FeeBudget}
+		starts[id] = m.ruleBudgetStart(rule)
+	}
+
+	if len(budgets) == 0 {
+		return budgets, nil
+	}
+
+	ruleSwaps, err := m.cfg.ListRuleSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		outSwaps = make(map[lntypes.Hash]*loopdb.LoopOut, len(loopOut))
+		inSwaps  = make(map[lntypes.Hash]*loopdb.LoopIn, len(loopIn))
+	)
+
+	for _, out := range loopOut {
+		outSwaps[out.Hash] = out
+	}
+
+	for _, in := range loopIn {
+		inSwaps[in.Hash] = in
+	}
+
+	for _, ruleSwap := range ruleSwaps {
+		channel := lnwire.NewShortChanIDFromInt(ruleSwap.ChannelID)
+		id := RuleID{
+			Channel: channel,
+			Peer:    ruleSwap.Peer,
+		}
+
+		// Skip swaps for rules that have since been removed, or no
+		// longer set their own budget.
+		budget, ok := budgets[id]
+		if !ok {
+			continue
+		}
+
+		var (
+			fees    btcutil.Amount
+			pending bool
+		)
+
+		if out, ok := outSwaps[ruleSwap.SwapHash]; ok {
+			fees, pending, err = m.loopOutBudgetFees(
+				ctx, out, starts[id],
+			)
+			if err != nil {
+				return nil, err
+			}
+		} else if in, ok := inSwaps[ruleSwap.SwapHash]; ok {
+			fees, pending = loopInBudgetFees(in, starts[id])
+		} else {
+			log.Warnf("rule swap: %v not found", ruleSwap.SwapHash)
+			continue
+		}
+
+		if pending {
+			budget.Pending += fees
+		} else {
+			budget.Spent += fees
+		}
+	}
+
+	return budgets, nil
+}
+
+// recordRuleSwap attributes the swap dispatched for the suggestion at the
+// index provided to the rule that it was suggested for, if that rule has its
+// own fee budget.
+func (m *Manager) recordRuleSwap(rules map[int]RuleID, index int,
+	hash lntypes.Hash) error {
+
+	id, ok := rules[index]
+	if !ok {
+		return nil
+	}
+
+	return m.cfg.AddRuleSwap(&loopdb.RuleSwap{
+		SwapHash:  hash,
+		ChannelID: id.Channel.ToUint64(),
+		Peer:      id.Peer,
+		Time:      m.cfg.Clock.Now(),
+	})
+}
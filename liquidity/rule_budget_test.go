@@ -0,0 +1,240 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleBudgets tests that rules with their own fee budget only suggest
+// swaps that fit within the part of their budget that has not been used by
+// swaps that were dispatched for them.
+func TestRuleBudgets(t *testing.T) {
+	var (
+		quote = &loop.LoopOutQuote{
+			SwapFee:      btcutil.Amount(1),
+			PrepayAmount: btcutil.Amount(500),
+			MinerFee:     btcutil.Amount(50),
+		}
+
+		maxMinerFee = btcutil.Amount(5000)
+
+		chan1 = applyFeeCategoryQuote(
+			chan1Rec, maxMinerFee, defaultPrepayRoutingFeePPM,
+			defaultRoutingFeePPM, *quote,
+		)
+
+		// swapFees is the worst-case fee of the swap that our rule
+		// suggests.
+		swapFees = (&loopOutSwapSuggestion{
+			OutRequest: chan1,
+		}).fees()
+
+		ruleID = RuleID{Channel: chanID1}
+
+		existingHash  = lntypes.Hash{1}
+		unrelatedHash = lntypes.Hash{2}
+		existingCost  = btcutil.Amount(500)
+		inPeriod      = testTime.Add(time.Minute * -30)
+		beforePeriod  = testTime.Add(time.Hour * -2)
+
+		overBudget = &Suggestions{
+			DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+				chanID1: ReasonRuleBudget,
+			},
+			DisqualifiedPeers: noPeersDisqualified,
+		}
+		suggested = &Suggestions{
+			OutSwaps: []loop.OutRequest{
+				chan1,
+			},
+			DisqualifiedChans: noneDisqualified,
+			DisqualifiedPeers: noPeersDisqualified,
+			outRules: map[int]RuleID{
+				0: ruleID,
+			},
+		}
+	)
+
+	tests := []struct {
+		name string
+
+		// budget is our rule's fee budget.
+		budget btcutil.Amount
+
+		// existingTime is the last update time of an existing swap
+		// that cost existingCost, if it is non-zero.
+		existingTime time.Time
+
+		// ruleSwaps is the set of swaps that are attributed to our
+		// rule.
+		ruleSwaps []lntypes.Hash
+
+		expected *Suggestions
+		spent    btcutil.Amount
+	}{
+		{
+			name:     "budget sufficient",
+			budget:   swapFees,
+			expected: suggested,
+		},
+		{
+			name:     "budget insufficient",
+			budget:   swapFees - 1,
+			expected: overBudget,
+		},
+		{
+			name:         "existing swap in period",
+			budget:       swapFees + existingCost - 1,
+			existingTime: inPeriod,
+			ruleSwaps:    []lntypes.Hash{existingHash},
+			expected:     overBudget,
+			spent:        existingCost,
+		},
+		{
+			name:         "existing swap before period",
+			budget:       swapFees,
+			existingTime: beforePeriod,
+			ruleSwaps:    []lntypes.Hash{existingHash},
+			expected:     suggested,
+		},
+		{
+			name:         "existing swap not attributed to rule",
+			budget:       swapFees,
+			existingTime: inPeriod,
+			ruleSwaps:    []lntypes.Hash{unrelatedHash},
+			expected:     suggested,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			var swaps []*loopdb.LoopOut
+			if !testCase.existingTime.IsZero() {
+				event := &loopdb.LoopEvent{
+					SwapStateData: loopdb.SwapStateData{
+						Cost: loopdb.SwapCost{
+							Server: existingCost,
+						},
+						State: loopdb.StateSuccess,
+					},
+					Time: testCase.existingTime,
+				}
+
+				swaps = append(swaps, &loopdb.LoopOut{
+					Loop: loopdb.Loop{
+						Hash: existingHash,
+						Events: []*loopdb.LoopEvent{
+							event,
+						},
+					},
+					Contract: autoOutContract,
+				})
+			}
+
+			cfg.ListLoopOut = func(_ context.Context) (
+				[]*loopdb.LoopOut, error) {
+
+				return swaps, nil
+			}
+
+			cfg.LoopOutQuote = func(_ context.Context,
+				_ *loop.LoopOutQuoteRequest) (
+				*loop.LoopOutQuote, error) {
+
+				return quote, nil
+			}
+
+			var ruleSwaps []*loopdb.RuleSwap
+			for _, hash := range testCase.ruleSwaps {
+				ruleSwaps = append(ruleSwaps, &loopdb.RuleSwap{
+					SwapHash:  hash,
+					ChannelID: chanID1.ToUint64(),
+				})
+			}
+
+			cfg.AddRuleSwap = func(swap *loopdb.RuleSwap) error {
+				ruleSwaps = append(ruleSwaps, swap)
+				return nil
+			}
+
+			cfg.ListRuleSwaps = func(_ context.Context) (
+				[]*loopdb.RuleSwap, error) {
+
+				return ruleSwaps, nil
+			}
+
+			rule := *chanRule
+			rule.FeeBudget = testCase.budget
+			rule.FeeBudgetPeriod = time.Hour
+
+			params := defaultParameters
+			params.ChannelRules =
+				map[lnwire.ShortChannelID]*ThresholdRule{
+					chanID1: &rule,
+				}
+			params.AutoFeeStartDate = beforePeriod.Add(-time.Hour)
+			params.AutoFeeBudget = swapFees * 10
+			params.FeeLimit = NewFeeCategoryLimit(
+				defaultSwapFeePPM, defaultRoutingFeePPM,
+				defaultPrepayRoutingFeePPM, maxMinerFee,
+				defaultMaximumPrepay, defaultSweepFeeRateLimit,
+			)
+
+			ctx := context.Background()
+			manager := NewManager(cfg)
+			require.NoError(t, manager.SetParameters(ctx, params))
+
+			actual, err := manager.SuggestSwaps(ctx, false)
+			require.NoError(t, err)
+
+			actual.Budget = nil
+			require.Equal(t, testCase.expected, actual)
+
+			budgets, err := manager.RuleBudgets(ctx)
+			require.NoError(t, err)
+			require.Equal(t, map[RuleID]*RuleBudget{
+				ruleID: {
+					Total: testCase.budget,
+					Spent: testCase.spent,
+				},
+			}, budgets)
+		})
+	}
+}
+
+// TestRuleBudgetsDisabled tests that rules may only set a fee budget if we
+// are tracking the swaps that we dispatch for rules.
+func TestRuleBudgetsDisabled(t *testing.T) {
+	cfg, lnd := newTestConfig()
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	rule := *chanRule
+	rule.FeeBudget = 1000
+
+	params := defaultParameters
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: &rule,
+	}
+
+	manager := NewManager(cfg)
+	err := manager.SetParameters(context.Background(), params)
+	require.Equal(t, ErrRuleBudgetsDisabled, err)
+}
@@ -0,0 +1,110 @@
+package liquidity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidScheduleHour is returned when a schedule's start or end
+	// hour is not a valid hour of the day.
+	ErrInvalidScheduleHour = errors.New("schedule hours must be in [0:23]")
+
+	// ErrInvalidScheduleDay is returned when a schedule contains a day that
+	// is not a valid day of the week.
+	ErrInvalidScheduleDay = errors.New("schedule days must be in [0:6], " +
+		"where 0 is sunday")
+)
+
+// Schedule restricts the times at which autoloop dispatches swaps, so that
+// swaps may be limited to periods when on chain fees are usually low. All
+// times are evaluated in UTC. The zero value schedule allows swaps at any
+// time.
+type Schedule struct {
+	// Days is the set of days of the week that swaps may be dispatched
+	// on. If empty, swaps may be dispatched on any day.
+	Days []time.Weekday
+
+	// StartHour is the hour of the day, inclusive, from which swaps may
+	// be dispatched.
+	StartHour int
+
+	// EndHour is the hour of the day, exclusive, until which swaps may be
+	// dispatched. If it is less than StartHour, the window wraps past
+	// midnight. If it is equal to StartHour, swaps may be dispatched at
+	// any hour. Note that days are checked independently of hours, so a
+	// window that wraps past midnight also requires that the day after
+	// midnight is allowed.
+	EndHour int
+}
+
+// String returns a string representation of a schedule.
+func (s Schedule) String() string {
+	days := "all"
+	if len(s.Days) != 0 {
+		dayList := make([]string, 0, len(s.Days))
+		for _, day := range s.Days {
+			dayList = append(dayList, day.String())
+		}
+
+		days = strings.Join(dayList, ",")
+	}
+
+	return fmt.Sprintf("days: %v, hours: %02d:00-%02d:00 UTC", days,
+		s.StartHour, s.EndHour)
+}
+
+// validate checks that a schedule's days and hours are valid.
+func (s Schedule) validate() error {
+	if s.StartHour < 0 || s.StartHour > 23 || s.EndHour < 0 ||
+		s.EndHour > 23 {
+
+		return ErrInvalidScheduleHour
+	}
+
+	for _, day := range s.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return ErrInvalidScheduleDay
+		}
+	}
+
+	return nil
+}
+
+// allowed returns a boolean indicating whether swaps may be dispatched at the
+// time provided.
+func (s Schedule) allowed(now time.Time) bool {
+	now = now.UTC()
+
+	if len(s.Days) != 0 {
+		var dayAllowed bool
+		for _, day := range s.Days {
+			if day == now.Weekday() {
+				dayAllowed = true
+				break
+			}
+		}
+
+		if !dayAllowed {
+			return false
+		}
+	}
+
+	hour := now.Hour()
+
+	switch {
+	case s.StartHour == s.EndHour:
+		return true
+
+	// If our window does not wrap past midnight, our hour must fall
+	// between our start and end.
+	case s.StartHour < s.EndHour:
+		return hour >= s.StartHour && hour < s.EndHour
+
+	// Otherwise, our hour must be after our start or before our end.
+	default:
+		return hour >= s.StartHour || hour < s.EndHour
+	}
+}
@@ -0,0 +1,205 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScheduleAllowed tests checking whether swaps may be dispatched at a
+// given time for a variety of schedules.
+func TestScheduleAllowed(t *testing.T) {
+	// saturday is a saturday at 10:00 UTC.
+	saturday := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		now      time.Time
+		allowed  bool
+	}{
+		{
+			name:    "empty schedule",
+			now:     saturday,
+			allowed: true,
+		},
+		{
+			name: "day allowed",
+			schedule: Schedule{
+				Days: []time.Weekday{time.Saturday},
+			},
+			now:     saturday,
+			allowed: true,
+		},
+		{
+			name: "day not allowed",
+			schedule: Schedule{
+				Days: []time.Weekday{
+					time.Monday, time.Tuesday,
+				},
+			},
+			now:     saturday,
+			allowed: false,
+		},
+		{
+			name: "inside hours",
+			schedule: Schedule{
+				StartHour: 8,
+				EndHour:   12,
+			},
+			now:     saturday,
+			allowed: true,
+		},
+		{
+			name: "end hour exclusive",
+			schedule: Schedule{
+				StartHour: 8,
+				EndHour:   10,
+			},
+			now:     saturday,
+			allowed: false,
+		},
+		{
+			name: "wrapped hours, after start",
+			schedule: Schedule{
+				StartHour: 22,
+				EndHour:   4,
+			},
+			now:     saturday.Add(time.Hour * 13),
+			allowed: true,
+		},
+		{
+			name: "wrapped hours, before end",
+			schedule: Schedule{
+				StartHour: 22,
+				EndHour:   4,
+			},
+			now:     saturday.Add(time.Hour * -8),
+			allowed: true,
+		},
+		{
+			name: "wrapped hours, outside",
+			schedule: Schedule{
+				StartHour: 22,
+				EndHour:   4,
+			},
+			now:     saturday,
+			allowed: false,
+		},
+		{
+			name: "evaluated in utc",
+			schedule: Schedule{
+				StartHour: 10,
+				EndHour:   11,
+			},
+			now: saturday.In(
+				time.FixedZone("test", int(time.Hour*-5/time.Second)),
+			),
+			allowed: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t, testCase.allowed,
+				testCase.schedule.allowed(testCase.now),
+			)
+		})
+	}
+}
+
+// TestScheduleValidate tests validation of schedules.
+func TestScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		err      error
+	}{
+		{
+			name: "valid",
+			schedule: Schedule{
+				Days:      []time.Weekday{time.Sunday},
+				StartHour: 23,
+				EndHour:   2,
+			},
+		},
+		{
+			name: "start hour invalid",
+			schedule: Schedule{
+				StartHour: 24,
+			},
+			err: ErrInvalidScheduleHour,
+		},
+		{
+			name: "end hour invalid",
+			schedule: Schedule{
+				EndHour: -1,
+			},
+			err: ErrInvalidScheduleHour,
+		},
+		{
+			name: "day invalid",
+			schedule: Schedule{
+				Days: []time.Weekday{7},
+			},
+			err: ErrInvalidScheduleDay,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t, testCase.err, testCase.schedule.validate(),
+			)
+		})
+	}
+}
+
+// TestScheduleSuggestions tests that we do not suggest swaps for autoloop
+// outside of our schedule, but still suggest swaps for manual requests.
+func TestScheduleSuggestions(t *testing.T) {
+	cfg, lnd := newTestConfig()
+
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1,
+	}
+
+	// Our test time is at midnight, so we set a schedule that only allows
+	// swaps in the afternoon.
+	params := defaultParameters
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: chanRule,
+	}
+	params.Schedule = Schedule{
+		StartHour: 12,
+		EndHour:   18,
+	}
+
+	ctx := context.Background()
+	manager := NewManager(cfg)
+	require.NoError(t, manager.SetParameters(ctx, params))
+
+	suggestions, err := manager.SuggestSwaps(ctx, true)
+	require.NoError(t, err)
+
+	expected := &Suggestions{
+		DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+			chanID1: ReasonOutsideSchedule,
+		},
+		DisqualifiedPeers: noPeersDisqualified,
+	}
+	require.Equal(t, expected, suggestions)
+
+	suggestions, err = manager.SuggestSwaps(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, suggestions.OutSwaps, 1)
+}
@@ -0,0 +1,143 @@
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+)
+
+// snapshotRetention is the amount of time that we keep channel balance
+// snapshots for. This bounds the minimum imbalance duration that rules may
+// require.
+const snapshotRetention = time.Hour * 24 * 7
+
+// ErrSnapshotsDisabled is returned when a rule requires a minimum imbalance
+// duration, but we are not taking channel balance snapshots.
+var ErrSnapshotsDisabled = errors.New("rules with a minimum imbalance " +
+	"duration require channel balance snapshots to be enabled")
+
+// snapshotsEnabled returns a boolean indicating whether we are configured to
+// take channel balance snapshots.
+func (m *Manager) snapshotsEnabled() bool {
+	return m.cfg.SnapshotInterval != 0 && m.cfg.AddSnapshots != nil &&
+		m.cfg.ListSnapshots != nil && m.cfg.PruneSnapshots != nil
+}
+
+// validateSnapshotRules checks that we are taking channel balance snapshots if
+// any of the rules in the set of parameters provided require them.
+func (m *Manager) validateSnapshotRules(params Parameters) error {
+	if m.snapshotsEnabled() {
+		return nil
+	}
+
+	for _, rule := range params.ChannelRules {
+		if rule.MinimumImbalanceDuration != 0 {
+			return ErrSnapshotsDisabled
+		}
+	}
+
+	for _, rule := range params.PeerRules {
+		if rule.MinimumImbalanceDuration != 0 {
+			return ErrSnapshotsDisabled
+		}
+	}
+
+	return nil
+}
+
+// takeSnapshot records the current balances of all of our channels, and
+// prunes snapshots that are older than our retention period.
+func (m *Manager) takeSnapshot(ctx context.Context) error {
+	channels, err := m.cfg.Lnd.Client.ListChannels(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := m.cfg.Clock.Now()
+	snapshots := make([]*loopdb.ChannelSnapshot, 0, len(channels))
+
+	for _, channel := range channels {
+		snapshots = append(snapshots, &loopdb.ChannelSnapshot{
+			Time:          now,
+			ChannelID:     channel.ChannelID,
+			Capacity:      channel.Capacity,
+			LocalBalance:  channel.LocalBalance,
+			RemoteBalance: channel.RemoteBalance,
+		})
+	}
+
+	if err := m.cfg.AddSnapshots(snapshots); err != nil {
+		return err
+	}
+
+	return m.cfg.PruneSnapshots(ctx, now.Add(snapshotRetention*-1))
+}
+
+// imbalancePersisted returns a boolean indicating whether the balances
+// provided have breached the rule's thresholds in every snapshot taken over
+// the rule's minimum imbalance duration. If we do not have a snapshot from
+// the start of this period, we cannot tell how long the imbalance has lasted,
+// so we report that it has not persisted.
+func (m *Manager) imbalancePersisted(ctx context.Context, balance *balances,
+	rule *ThresholdRule) (bool, error) {
+
+	if !m.snapshotsEnabled() {
+		return false, ErrSnapshotsDisabled
+	}
+
+	// We look back an additional snapshot interval so that we include the
+	// snapshot that was taken closest to the start of our period.
+	start := m.cfg.Clock.Now().Add(rule.MinimumImbalanceDuration * -1)
+	snapshots, err := m.cfg.ListSnapshots(
+		ctx, start.Add(m.cfg.SnapshotInterval*-1),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	channels := make(map[uint64]struct{}, len(balance.channels))
+	for _, channel := range balance.channels {
+		channels[channel.ToUint64()] = struct{}{}
+	}
+
+	// Aggregate the balances of the channels that the rule applies to
+	// for each time that we took a snapshot, keyed by unix nano. Our
+	// snapshots are sorted chronologically, so we can track our times in
+	// order.
+	var (
+		times   []int64
+		samples = make(map[int64]*balances)
+	)
+
+	for _, snapshot := range snapshots {
+		if _, ok := channels[snapshot.ChannelID]; !ok {
+			continue
+		}
+
+		sampleTime := snapshot.Time.UnixNano()
+		sample, ok := samples[sampleTime]
+		if !ok {
+			sample = &balances{}
+			samples[sampleTime] = sample
+			times = append(times, sampleTime)
+		}
+
+		sample.capacity += snapshot.Capacity
+		sample.incoming += snapshot.RemoteBalance
+		sample.outgoing += snapshot.LocalBalance
+	}
+
+	if len(times) == 0 || times[0] > start.UnixNano() {
+		return false, nil
+	}
+
+	for _, sampleTime := range times {
+		if rule.requiredAmount(samples[sampleTime]) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,210 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// testSnapshotInterval is the snapshot interval used in our tests.
+const testSnapshotInterval = time.Minute * 10
+
+// snapshotStore is an in-memory store for channel balance snapshots.
+type snapshotStore struct {
+	snapshots []*loopdb.ChannelSnapshot
+}
+
+// setSnapshotConfig configures the config provided to take channel balance
+// snapshots using the in-memory store provided.
+func setSnapshotConfig(cfg *Config, store *snapshotStore) {
+	cfg.SnapshotInterval = testSnapshotInterval
+
+	cfg.AddSnapshots = func(snapshots []*loopdb.ChannelSnapshot) error {
+		store.snapshots = append(store.snapshots, snapshots...)
+		return nil
+	}
+
+	cfg.ListSnapshots = func(_ context.Context, since time.Time) (
+		[]*loopdb.ChannelSnapshot, error) {
+
+		var snapshots []*loopdb.ChannelSnapshot
+		for _, snapshot := range store.snapshots {
+			if !snapshot.Time.Before(since) {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+
+		return snapshots, nil
+	}
+
+	cfg.PruneSnapshots = func(ctx context.Context, before time.Time) error {
+		snapshots, err := cfg.ListSnapshots(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		store.snapshots = snapshots
+		return nil
+	}
+}
+
+// chan1Snapshot returns a snapshot of channel 1 at the time and local balance
+// provided.
+func chan1Snapshot(snapshotTime time.Time,
+	local btcutil.Amount) *loopdb.ChannelSnapshot {
+
+	return &loopdb.ChannelSnapshot{
+		Time:          snapshotTime,
+		ChannelID:     chanID1.ToUint64(),
+		Capacity:      channel1.Capacity,
+		LocalBalance:  local,
+		RemoteBalance: channel1.Capacity - local,
+	}
+}
+
+// TestTakeSnapshot tests that we record the balances of all our channels, and
+// prune snapshots that are older than our retention period.
+func TestTakeSnapshot(t *testing.T) {
+	cfg, lnd := newTestConfig()
+	lnd.Channels = []lndclient.ChannelInfo{
+		channel1, channel2,
+	}
+
+	expired := chan1Snapshot(testTime.Add(snapshotRetention*-1-1), 0)
+	store := &snapshotStore{
+		snapshots: []*loopdb.ChannelSnapshot{expired},
+	}
+	setSnapshotConfig(cfg, store)
+
+	manager := NewManager(cfg)
+	require.NoError(t, manager.takeSnapshot(context.Background()))
+
+	require.Equal(t, []*loopdb.ChannelSnapshot{
+		chan1Snapshot(testTime, channel1.LocalBalance),
+		{
+			Time:          testTime,
+			ChannelID:     chanID2.ToUint64(),
+			Capacity:      channel2.Capacity,
+			LocalBalance:  channel2.LocalBalance,
+			RemoteBalance: channel2.RemoteBalance,
+		},
+	}, store.snapshots)
+}
+
+// TestMinimumImbalanceDuration tests that we only suggest swaps for rules that
+// require a minimum imbalance duration once our snapshots show that their
+// thresholds have been breached for that duration.
+func TestMinimumImbalanceDuration(t *testing.T) {
+	const duration = time.Hour
+
+	var (
+		// Our channel is imbalanced when its local balance is above
+		// 5000, since chanRule requires 50% incoming liquidity.
+		imbalanced btcutil.Amount = 10000
+		balanced   btcutil.Amount = 5000
+
+		beforeStart = testTime.Add(duration*-1 - time.Minute)
+		midway      = testTime.Add(duration / -2)
+
+		transient = &Suggestions{
+			DisqualifiedChans: map[lnwire.ShortChannelID]Reason{
+				chanID1: ReasonTransientImbalance,
+			},
+			DisqualifiedPeers: noPeersDisqualified,
+		}
+	)
+
+	tests := []struct {
+		name      string
+		snapshots []*loopdb.ChannelSnapshot
+		expected  *Suggestions
+	}{
+		{
+			name:     "no history",
+			expected: transient,
+		},
+		{
+			name: "history too short",
+			snapshots: []*loopdb.ChannelSnapshot{
+				chan1Snapshot(midway, imbalanced),
+			},
+			expected: transient,
+		},
+		{
+			name: "balanced during period",
+			snapshots: []*loopdb.ChannelSnapshot{
+				chan1Snapshot(beforeStart, imbalanced),
+				chan1Snapshot(midway, balanced),
+			},
+			expected: transient,
+		},
+		{
+			name: "imbalance persisted",
+			snapshots: []*loopdb.ChannelSnapshot{
+				chan1Snapshot(beforeStart, imbalanced),
+				chan1Snapshot(midway, imbalanced),
+			},
+			expected: &Suggestions{
+				OutSwaps: []loop.OutRequest{
+					chan1Rec,
+				},
+				DisqualifiedChans: noneDisqualified,
+				DisqualifiedPeers: noPeersDisqualified,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, lnd := newTestConfig()
+			lnd.Channels = []lndclient.ChannelInfo{
+				channel1,
+			}
+
+			setSnapshotConfig(cfg, &snapshotStore{
+				snapshots: testCase.snapshots,
+			})
+
+			rule := *chanRule
+			rule.MinimumImbalanceDuration = duration
+
+			params := defaultParameters
+			params.ChannelRules =
+				map[lnwire.ShortChannelID]*ThresholdRule{
+					chanID1: &rule,
+				}
+
+			testSuggestSwaps(
+				t, newSuggestSwapsSetup(cfg, lnd, params),
+				testCase.expected, nil,
+			)
+		})
+	}
+}
+
+// TestSnapshotsDisabled tests that we do not allow rules that require a
+// minimum imbalance duration when we are not taking snapshots.
+func TestSnapshotsDisabled(t *testing.T) {
+	cfg, _ := newTestConfig()
+	manager := NewManager(cfg)
+
+	rule := *chanRule
+	rule.MinimumImbalanceDuration = time.Hour
+
+	params := defaultParameters
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: &rule,
+	}
+
+	err := manager.SetParameters(context.Background(), params)
+	require.Equal(t, ErrSnapshotsDisabled, err)
+}
@@ -0,0 +1,283 @@
+package liquidity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// ErrStaleRulesDisabled is returned when stale rules are requested, but we
+// are not tracking rules that reference inactive channels and peers.
+var ErrStaleRulesDisabled = errors.New("stale rule tracking is disabled")
+
+// StaleReason describes why a rule's channel or peer is inactive.
+type StaleReason uint8
+
+const (
+	// StaleReasonClosed indicates that a channel rule's channel is not in
+	// our set of open channels, or that we have no open channels with a
+	// peer rule's peer.
+	StaleReasonClosed StaleReason = iota
+
+	// StaleReasonDisconnected indicates that a channel rule's channel is
+	// open but inactive, or that none of our open channels with a peer
+	// rule's peer are active.
+	StaleReasonDisconnected
+)
+
+// String returns a string representation of a stale reason.
+func (s StaleReason) String() string {
+	switch s {
+	case StaleReasonClosed:
+		return "Closed"
+
+	case StaleReasonDisconnected:
+		return "Disconnected"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// StaleRule describes a rule that references a channel or peer that has been
+// inactive for at least our stale rule age.
+type StaleRule struct {
+	// Channel is the channel that the rule is set for. It is zero for
+	// peer rules.
+	Channel lnwire.ShortChannelID
+
+	// Peer is the peer that the rule is set for. It is empty for channel
+	// rules.
+	Peer route.Vertex
+
+	// Reason is the reason that the rule's channel or peer was inactive
+	// when we last checked.
+	Reason StaleReason
+
+	// InactiveSince is the time that we first saw the rule's channel or
+	// peer inactive. Since lnd does not report when channels were closed
+	// or went offline, this is the time of the first check that found it
+	// inactive, and is reset when we restart.
+	InactiveSince time.Time
+}
+
+// staleRuleKey identifies the channel or peer rule that an inactive entry is
+// tracked for.
+type staleRuleKey struct {
+	channel lnwire.ShortChannelID
+	peer    route.Vertex
+}
+
+// inactiveRule tracks how long a rule's channel or peer has been inactive.
+type inactiveRule struct {
+	reason StaleReason
+	since  time.Time
+}
+
+// staleRulesEnabled returns a boolean indicating whether we are tracking
+// rules that reference inactive channels and peers.
+func (m *Manager) staleRulesEnabled() bool {
+	return m.cfg.StaleRuleAge > 0
+}
+
+// StaleRules returns the rules that reference channels or peers that have
+// been inactive for at least our stale rule age, as of our last check. Rules
+// are checked on each autoloop tick, sorted with channel rules first.
+func (m *Manager) StaleRules() ([]*StaleRule, error) {
+	if !m.staleRulesEnabled() {
+		return nil, ErrStaleRulesDisabled
+	}
+
+	m.staleLock.Lock()
+	defer m.staleLock.Unlock()
+
+	return m.staleRules(), nil
+}
+
+// staleRules returns the set of tracked rules that have been inactive for at
+// least our stale rule age. It must be called with the stale lock held.
+func (m *Manager) staleRules() []*StaleRule {
+	cutoff := m.cfg.Clock.Now().Add(m.cfg.StaleRuleAge * -1)
+
+	var stale []*StaleRule
+	for key, inactive := range m.inactiveRules {
+		if inactive.since.After(cutoff) {
+			continue
+		}
+
+		stale = append(stale, &StaleRule{
+			Channel:       key.channel,
+			Peer:          key.peer,
+			Reason:        inactive.reason,
+			InactiveSince: inactive.since,
+		})
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		iChan, jChan := stale[i].Channel.ToUint64(),
+			stale[j].Channel.ToUint64()
+
+		if iChan != jChan {
+			// Peer rules have a zero channel, so we sort on the
+			// inverse to list our channel rules first.
+			return iChan > jChan
+		}
+
+		return bytes.Compare(stale[i].Peer[:], stale[j].Peer[:]) < 0
+	})
+
+	return stale
+}
+
+// checkStaleRules updates the set of rules that reference inactive channels
+// and peers, logs the rules that have been inactive for longer than our stale
+// rule age and, if configured to, removes them from our parameters.
+func (m *Manager) checkStaleRules(ctx context.Context) error {
+	if !m.staleRulesEnabled() {
+		return nil
+	}
+
+	channels, err := m.cfg.Lnd.Client.ListChannels(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		// openChannels maps the channels that we have open to whether
+		// they are active.
+		openChannels = make(map[lnwire.ShortChannelID]bool)
+
+		// peers maps the peers that we have open channels with to
+		// whether any of these channels are active.
+		peers = make(map[route.Vertex]bool)
+	)
+
+	for _, channel := range channels {
+		chanID := lnwire.NewShortChanIDFromInt(channel.ChannelID)
+		openChannels[chanID] = channel.Active
+		peers[channel.PubKeyBytes] = peers[channel.PubKeyBytes] ||
+			channel.Active
+	}
+
+	// We check our current parameters rather than any pending change,
+	// because these are the rules that autoloop is currently using.
+	params := m.GetParameters()
+	inactive := make(map[staleRuleKey]StaleReason)
+
+	for channel := range params.ChannelRules {
+		active, ok := openChannels[channel]
+		switch {
+		case !ok:
+			inactive[staleRuleKey{channel: channel}] =
+				StaleReasonClosed
+
+		case !active:
+			inactive[staleRuleKey{channel: channel}] =
+				StaleReasonDisconnected
+		}
+	}
+
+	for peer := range params.PeerRules {
+		active, ok := peers[peer]
+		switch {
+		case !ok:
+			inactive[staleRuleKey{peer: peer}] = StaleReasonClosed
+
+		case !active:
+			inactive[staleRuleKey{peer: peer}] =
+				StaleReasonDisconnected
+		}
+	}
+
+	m.staleLock.Lock()
+
+	now := m.cfg.Clock.Now()
+	for key := range m.inactiveRules {
+		if _, ok := inactive[key]; !ok {
+			delete(m.inactiveRules, key)
+		}
+	}
+
+	for key, reason := range inactive {
+		tracked, ok := m.inactiveRules[key]
+		if !ok {
+			m.inactiveRules[key] = &inactiveRule{
+				reason: reason,
+				since:  now,
+			}
+
+			continue
+		}
+
+		tracked.reason = reason
+	}
+
+	stale := m.staleRules()
+	m.staleLock.Unlock()
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	for _, rule := range stale {
+		if rule.Channel.ToUint64() != 0 {
+			log.Infof("Rule for channel: %v is stale, channel %v "+
+				"since: %v", rule.Channel, rule.Reason,
+				rule.InactiveSince)
+
+			continue
+		}
+
+		log.Infof("Rule for peer: %v is stale, peer %v since: %v",
+			rule.Peer, rule.Reason, rule.InactiveSince)
+	}
+
+	if !m.cfg.PruneStaleRules {
+		return nil
+	}
+
+	return m.pruneStaleRules(ctx, stale)
+}
+
+// pruneStaleRules removes the set of stale rules provided from our
+// parameters.
+func (m *Manager) pruneStaleRules(ctx context.Context,
+	stale []*StaleRule) error {
+
+	_, err := m.UpdateParameters(ctx, 0, func(params Parameters) (
+		Parameters, error) {
+
+		for _, rule := range stale {
+			if rule.Channel.ToUint64() != 0 {
+				delete(params.ChannelRules, rule.Channel)
+				continue
+			}
+
+			delete(params.PeerRules, rule.Peer)
+		}
+
+		return params, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.staleLock.Lock()
+	defer m.staleLock.Unlock()
+
+	for _, rule := range stale {
+		delete(m.inactiveRules, staleRuleKey{
+			channel: rule.Channel,
+			peer:    rule.Peer,
+		})
+	}
+
+	log.Infof("Removed %v stale rules", len(stale))
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStaleRules tests tracking and pruning of rules that reference channels
+// and peers that are closed or disconnected.
+func TestStaleRules(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		staleAge  = time.Hour * 24
+		peer3     = route.Vertex{3}
+		testClock = clock.NewTestClock(testTime)
+	)
+
+	cfg, lnd := newTestConfig()
+	cfg.Clock = testClock
+	cfg.StaleRuleAge = staleAge
+
+	active1, inactive2 := channel1, channel2
+	active1.Active = true
+	lnd.Channels = []lndclient.ChannelInfo{active1, inactive2}
+
+	manager := NewManager(cfg)
+
+	params := manager.GetParameters()
+	params.ChannelRules = map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: chanRule,
+		chanID2: chanRule,
+		chanID3: chanRule,
+	}
+	params.PeerRules = map[route.Vertex]*ThresholdRule{
+		peer3: chanRule,
+	}
+	require.NoError(t, manager.setParameters(ctx, params))
+
+	// When we first check our rules, none of them have been inactive for
+	// long enough to be stale.
+	require.NoError(t, manager.checkStaleRules(ctx))
+
+	stale, err := manager.StaleRules()
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	// Once our stale age has passed, the rules for our disconnected
+	// channel, closed channel and peer that we no longer have channels
+	// with are reported.
+	testClock.SetTime(testTime.Add(staleAge))
+	require.NoError(t, manager.checkStaleRules(ctx))
+
+	stale, err = manager.StaleRules()
+	require.NoError(t, err)
+	require.Equal(t, []*StaleRule{
+		{
+			Channel:       chanID3,
+			Reason:        StaleReasonClosed,
+			InactiveSince: testTime,
+		},
+		{
+			Channel:       chanID2,
+			Reason:        StaleReasonDisconnected,
+			InactiveSince: testTime,
+		},
+		{
+			Peer:          peer3,
+			Reason:        StaleReasonClosed,
+			InactiveSince: testTime,
+		},
+	}, stale)
+
+	// If our disconnected channel comes back online, it is no longer
+	// tracked, and will need to be inactive for our full stale age again
+	// before it is reported.
+	active2 := channel2
+	active2.Active = true
+	lnd.Channels = []lndclient.ChannelInfo{active1, active2}
+	require.NoError(t, manager.checkStaleRules(ctx))
+
+	stale, err = manager.StaleRules()
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+
+	// Reporting stale rules should not change our parameters.
+	require.Len(t, manager.GetParameters().ChannelRules, 3)
+	require.Len(t, manager.GetParameters().PeerRules, 1)
+
+	// When we prune stale rules, they are removed from our parameters and
+	// are no longer reported.
+	cfg.PruneStaleRules = true
+	require.NoError(t, manager.checkStaleRules(ctx))
+
+	current := manager.GetParameters()
+	require.Equal(t, map[lnwire.ShortChannelID]*ThresholdRule{
+		chanID1: chanRule,
+		chanID2: chanRule,
+	}, current.ChannelRules)
+	require.Empty(t, current.PeerRules)
+
+	stale, err = manager.StaleRules()
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	// If we are not tracking stale rules, we fail when they are requested.
+	cfg.StaleRuleAge = 0
+	_, err = manager.StaleRules()
+	require.Equal(t, ErrStaleRulesDisabled, err)
+}
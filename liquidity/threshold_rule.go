@@ -3,103 +3,444 @@ package liquidity
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
 )
 
+// ThresholdBase is the base that we use to express liquidity thresholds.
+// Thresholds are expressed in parts per million of capacity so that swap
+// amounts are calculated with fixed point arithmetic, which gives the same
+// results on every platform and allows thresholds that are not whole
+// percentages.
+const ThresholdBase = 1e6
+
 var (
 	// errInvalidLiquidityThreshold is returned when a liquidity threshold
 	// has an invalid value.
-	errInvalidLiquidityThreshold = errors.New("liquidity threshold must " +
-		"be in [0:100)")
+	errInvalidLiquidityThreshold = fmt.Errorf("liquidity threshold must "+
+		"be in [0:%v] ppm", int(ThresholdBase))
+
+	// errInvalidThresholdSum is returned when the sum of the thresholds
+	// provided for a threshold rule is >= 100%.
+	errInvalidThresholdSum = fmt.Errorf("sum of incoming and outgoing "+
+		"thresholds must be < %v ppm", int(ThresholdBase))
+
+	// errNegativeHtlcConfTarget is returned when a rule has a negative
+	// htlc confirmation target.
+	errNegativeHtlcConfTarget = errors.New("htlc confirmation target " +
+		"must be >= 0")
+
+	// errInvalidImbalanceDuration is returned when a rule's minimum
+	// imbalance duration is negative or longer than we keep channel
+	// balance snapshots for.
+	errInvalidImbalanceDuration = fmt.Errorf("minimum imbalance duration "+
+		"must be in [0:%v]", snapshotRetention)
+
+	// errInvalidReputation is returned when a rule's minimum reputation is
+	// not a valid percentage.
+	errInvalidReputation = errors.New("minimum reputation must be in " +
+		"[0:100]")
 
-	// errInvalidThresholdSum is returned when the sum of the percentages
-	// provided for a threshold rule is >= 100.
-	errInvalidThresholdSum = errors.New("sum of incoming and outgoing " +
-		"percentages must be < 100")
+	// errNegativeLargeSwapAmount is returned when a rule's large swap
+	// amount is negative.
+	errNegativeLargeSwapAmount = errors.New("large swap amount must be " +
+		">= 0")
+
+	// errInvalidTarget is returned when a target rule's tolerance band
+	// does not fit within a channel's capacity, or has no width.
+	errInvalidTarget = fmt.Errorf("target rule must have a tolerance > 0 "+
+		"and target +/- tolerance in [0:%v] ppm", int(ThresholdBase))
+
+	// errTargetThresholds is returned when a target rule's minimum
+	// thresholds do not match the thresholds that its target and
+	// tolerance describe.
+	errTargetThresholds = errors.New("target rule thresholds must be " +
+		"derived from its target and tolerance")
+
+	// errNegativeRuleBudget is returned when a rule has a negative fee
+	// budget or budget period.
+	errNegativeRuleBudget = errors.New("rule fee budget and budget " +
+		"period must be >= 0")
 )
 
 // ThresholdRule is a liquidity rule that implements minimum incoming and
 // outgoing liquidity threshold.
 type ThresholdRule struct {
-	// MinimumIncoming is the percentage of incoming liquidity that we do
-	// not want to drop below.
-	MinimumIncoming int
+	// Type is the type of swap that is suggested to restore the balance
+	// described by the rule's thresholds. Loop out swaps are suggested
+	// when we fall below our incoming threshold, and loop in swaps are
+	// suggested when we fall below our outgoing threshold.
+	Type swap.Type
+
+	// MinimumIncomingPPM is the portion of capacity, expressed in parts
+	// per million, that we do not want our incoming liquidity to drop
+	// below.
+	MinimumIncomingPPM int
+
+	// MinimumOutgoingPPM is the portion of capacity, expressed in parts
+	// per million, that we do not want our outgoing liquidity to drop
+	// below.
+	MinimumOutgoingPPM int
+
+	// SweepConfTarget is the confirmation target that is used for the
+	// sweeps of swaps that are suggested for this rule. If zero, the
+	// sweep confirmation target set in our parameters is used.
+	SweepConfTarget int32
+
+	// HtlcConfTarget is the number of confirmations that we require for
+	// the on chain htlc of swaps that are suggested for this rule. If zero,
+	// the default number of htlc confirmations is used.
+	HtlcConfTarget int32
+
+	// MinimumImbalanceDuration is the amount of time that the rule's
+	// thresholds must have been breached for, according to our channel
+	// balance snapshots, before we suggest a swap. This prevents swaps
+	// from being triggered by transient flows. If zero, swaps are
+	// suggested as soon as the thresholds are breached.
+	MinimumImbalanceDuration time.Duration
+
+	// MinimumReputation is the minimum reputation score, expressed as the
+	// percentage of swaps that succeeded, that the peer a swap is routed
+	// through must have for swaps of at least LargeSwapAmount to be
+	// suggested. If zero, no reputation is required.
+	MinimumReputation int
+
+	// LargeSwapAmount is the swap amount from which MinimumReputation
+	// applies. If zero, it applies to all swaps for the rule.
+	LargeSwapAmount btcutil.Amount
+
+	// TargetLocalPPM is the portion of capacity, expressed in parts per
+	// million, that we want our outgoing liquidity to be at. If it is
+	// non-zero, the rule is a target rule which suggests swaps in both
+	// directions: loop outs when our outgoing liquidity rises above the
+	// target plus TolerancePPM, and loop ins when it falls below the
+	// target minus TolerancePPM. The rule's minimum thresholds are
+	// derived from this band, and its Type is not used.
+	TargetLocalPPM int
+
+	// TolerancePPM is the portion of capacity, expressed in parts per
+	// million, that our outgoing liquidity may move away from a target
+	// rule's target before we suggest a swap.
+	TolerancePPM int
 
-	// MinimumOutgoing is the percentage of outgoing liquidity that we do
-	// not want to drop below.
-	MinimumOutgoing int
+	// FeeBudget is the total amount that automatically dispatched swaps
+	// for this rule may spend on fees within FeeBudgetPeriod. It applies
+	// in addition to our global budget. If zero, swaps for the rule are
+	// only limited by our global budget.
+	FeeBudget btcutil.Amount
+
+	// FeeBudgetPeriod is the rolling period that the rule's fee budget
+	// applies to. Swaps that completed longer ago than this period no
+	// longer count against the budget. If zero, swaps that completed
+	// since our global budget's start date are counted.
+	FeeBudgetPeriod time.Duration
 }
 
-// NewThresholdRule returns a new threshold rule.
+// NewThresholdRule returns a new threshold rule with thresholds expressed as
+// whole percentages.
 func NewThresholdRule(minIncoming, minOutgoing int) *ThresholdRule {
+	return NewThresholdRulePPM(
+		PercentToPPM(minIncoming), PercentToPPM(minOutgoing),
+	)
+}
+
+// NewThresholdRulePPM returns a new threshold rule with thresholds expressed
+// in parts per million.
+func NewThresholdRulePPM(minIncomingPPM, minOutgoingPPM int) *ThresholdRule {
+	return &ThresholdRule{
+		Type:               swap.TypeOut,
+		MinimumIncomingPPM: minIncomingPPM,
+		MinimumOutgoingPPM: minOutgoingPPM,
+	}
+}
+
+// NewTargetRulePPM returns a new target rule that aims to keep our outgoing
+// liquidity at the target provided, suggesting swaps once it moves more than
+// the tolerance provided away from it. Both values are expressed in parts per
+// million of capacity.
+func NewTargetRulePPM(targetPPM, tolerancePPM int) *ThresholdRule {
 	return &ThresholdRule{
-		MinimumIncoming: minIncoming,
-		MinimumOutgoing: minOutgoing,
+		Type:               swap.TypeOut,
+		MinimumIncomingPPM: ThresholdBase - targetPPM - tolerancePPM,
+		MinimumOutgoingPPM: targetPPM - tolerancePPM,
+		TargetLocalPPM:     targetPPM,
+		TolerancePPM:       tolerancePPM,
 	}
 }
 
+// PercentToPPM converts a whole percentage to parts per million.
+func PercentToPPM(percent int) int {
+	return percent * ThresholdBase / 100
+}
+
+// formatPPM formats a threshold that is expressed in parts per million as a
+// percentage, without using floating point arithmetic.
+func formatPPM(ppm int) string {
+	const partsPerPercent = ThresholdBase / 100
+
+	percent := fmt.Sprintf("%v", ppm/partsPerPercent)
+
+	if fraction := ppm % partsPerPercent; fraction != 0 {
+		percent += strings.TrimRight(
+			fmt.Sprintf(".%04d", fraction), "0",
+		)
+	}
+
+	return percent + "%"
+}
+
+// isTarget returns a boolean indicating whether a rule is a target rule,
+// which suggests swaps in both directions.
+func (r *ThresholdRule) isTarget() bool {
+	return r.TargetLocalPPM != 0
+}
+
 // String returns a string representation of a rule.
 func (r *ThresholdRule) String() string {
-	return fmt.Sprintf("threshold rule: minimum incoming: %v%%, minimum "+
-		"outgoing: %v%%", r.MinimumIncoming, r.MinimumOutgoing)
+	str := fmt.Sprintf("threshold rule: type: %v, minimum incoming: "+
+		"%v, minimum outgoing: %v", r.Type,
+		formatPPM(r.MinimumIncomingPPM),
+		formatPPM(r.MinimumOutgoingPPM))
+
+	if r.isTarget() {
+		str = fmt.Sprintf("target rule: target outgoing: %v +/- %v",
+			formatPPM(r.TargetLocalPPM), formatPPM(r.TolerancePPM))
+	}
+
+	if r.SweepConfTarget != 0 {
+		str += fmt.Sprintf(", sweep conf target: %v",
+			r.SweepConfTarget)
+	}
+
+	if r.HtlcConfTarget != 0 {
+		str += fmt.Sprintf(", htlc conf target: %v", r.HtlcConfTarget)
+	}
+
+	if r.MinimumImbalanceDuration != 0 {
+		str += fmt.Sprintf(", minimum imbalance duration: %v",
+			r.MinimumImbalanceDuration)
+	}
+
+	if r.MinimumReputation != 0 {
+		str += fmt.Sprintf(", minimum reputation: %v%% from: %v",
+			r.MinimumReputation, r.LargeSwapAmount)
+	}
+
+	if r.FeeBudget != 0 {
+		str += fmt.Sprintf(", fee budget: %v", r.FeeBudget)
+
+		if r.FeeBudgetPeriod != 0 {
+			str += fmt.Sprintf(" per %v", r.FeeBudgetPeriod)
+		}
+	}
+
+	return str
 }
 
 // validate validates the parameters that a rule was created with.
 func (r *ThresholdRule) validate() error {
-	if r.MinimumIncoming < 0 || r.MinimumIncoming > 100 {
+	if r.isTarget() {
+		if err := r.validateTarget(); err != nil {
+			return err
+		}
+	}
+
+	if r.MinimumIncomingPPM < 0 || r.MinimumIncomingPPM > ThresholdBase {
 		return errInvalidLiquidityThreshold
 	}
 
-	if r.MinimumOutgoing < 0 || r.MinimumOutgoing > 100 {
+	if r.MinimumOutgoingPPM < 0 || r.MinimumOutgoingPPM > ThresholdBase {
 		return errInvalidLiquidityThreshold
 	}
 
-	if r.MinimumIncoming+r.MinimumOutgoing >= 100 {
+	if r.MinimumIncomingPPM+r.MinimumOutgoingPPM >= ThresholdBase {
 		return errInvalidThresholdSum
 	}
 
+	if r.HtlcConfTarget < 0 {
+		return errNegativeHtlcConfTarget
+	}
+
+	if r.MinimumImbalanceDuration < 0 ||
+		r.MinimumImbalanceDuration > snapshotRetention {
+
+		return errInvalidImbalanceDuration
+	}
+
+	if r.MinimumReputation < 0 || r.MinimumReputation > 100 {
+		return errInvalidReputation
+	}
+
+	if r.LargeSwapAmount < 0 {
+		return errNegativeLargeSwapAmount
+	}
+
+	if r.FeeBudget < 0 || r.FeeBudgetPeriod < 0 {
+		return errNegativeRuleBudget
+	}
+
 	return nil
 }
 
-// swapAmount suggests a swap based on the liquidity thresholds configured,
-// returning zero if no swap is recommended.
+// validateTarget checks that a target rule's tolerance band lies within a
+// channel's capacity, and that its minimum thresholds describe this band.
+func (r *ThresholdRule) validateTarget() error {
+	if r.TolerancePPM <= 0 || r.TargetLocalPPM-r.TolerancePPM < 0 ||
+		r.TargetLocalPPM+r.TolerancePPM > ThresholdBase {
+
+		return errInvalidTarget
+	}
+
+	expected := NewTargetRulePPM(r.TargetLocalPPM, r.TolerancePPM)
+	if r.MinimumIncomingPPM != expected.MinimumIncomingPPM ||
+		r.MinimumOutgoingPPM != expected.MinimumOutgoingPPM {
+
+		return errTargetThresholds
+	}
+
+	return nil
+}
+
+// validateConfTargets checks that a rule's sweep confirmation target, if set,
+// is at least the minimum number of confirmations that we allow.
+func (r *ThresholdRule) validateConfTargets(minConfs int32) error {
+	if r.SweepConfTarget != 0 && r.SweepConfTarget < minConfs {
+		return fmt.Errorf("sweep confirmation target must be at "+
+			"least: %v", minConfs)
+	}
+
+	return nil
+}
+
+// swapType returns the type of swap that the rule suggests for the balances
+// provided. Target rules suggest loop outs when our outgoing liquidity is
+// above their tolerance band and loop ins when it is below it, and all other
+// rules suggest swaps of their configured type.
+func (r *ThresholdRule) swapType(channel *balances) swap.Type {
+	if !r.isTarget() {
+		return r.Type
+	}
+
+	minimumOutgoing := thresholdAmount(
+		channel.capacity, r.MinimumOutgoingPPM,
+	)
+	if channel.outgoing < minimumOutgoing {
+		return swap.TypeIn
+	}
+
+	return swap.TypeOut
+}
+
+// swapAmount suggests a swap of the rule's type for the balances provided
+// based on the liquidity thresholds configured. The restrictions provided
+// must be the restrictions for this swap type. If the amount required is below
+// the minimum swap amount and roundUp is set, a swap of the minimum amount is
+// suggested if it does not breach the rule's opposite threshold. If no swap is
+// recommended, it returns zero and the reason that we cannot swap.
 func (r *ThresholdRule) swapAmount(channel *balances,
-	outRestrictions *Restrictions) btcutil.Amount {
+	restrictions *Restrictions, roundUp bool) (btcutil.Amount, Reason) {
 
 	// Examine our total balance and required ratios to decide whether we
 	// need to swap.
-	amount := loopOutSwapAmount(
-		channel, r.MinimumIncoming, r.MinimumOutgoing,
-	)
+	amount := r.requiredAmount(channel)
 
 	// Limit our swap amount by the minimum/maximum thresholds set.
 	switch {
-	case amount < outRestrictions.Minimum:
-		return 0
+	case amount == 0:
+		return 0, ReasonLiquidityOk
 
-	case amount > outRestrictions.Maximum:
-		return outRestrictions.Maximum
+	case amount < restrictions.Minimum && roundUp &&
+		r.availableAmount(channel) >= restrictions.Minimum:
+
+		return restrictions.Minimum, ReasonNone
+
+	case amount < restrictions.Minimum:
+		return 0, ReasonMinAmount
+
+	case amount > restrictions.Maximum:
+		return restrictions.Maximum, ReasonNone
 
 	default:
-		return amount
+		return amount, ReasonNone
+	}
+}
+
+// requiresReputation returns a boolean indicating whether a swap of the amount
+// provided requires the peer it is routed through to have a minimum
+// reputation.
+func (r *ThresholdRule) requiresReputation(amount btcutil.Amount) bool {
+	return r.MinimumReputation != 0 && amount >= r.LargeSwapAmount
+}
+
+// requiredAmount returns the amount that we need to swap to restore the
+// balances provided to the rule's thresholds, or zero if the balances are
+// within the thresholds or no swap of the rule's type can help.
+func (r *ThresholdRule) requiredAmount(channel *balances) btcutil.Amount {
+	if r.swapType(channel) == swap.TypeIn {
+		return loopInSwapAmount(
+			channel, r.MinimumIncomingPPM, r.MinimumOutgoingPPM,
+		)
+	}
+
+	return loopOutSwapAmount(
+		channel, r.MinimumIncomingPPM, r.MinimumOutgoingPPM,
+	)
+}
+
+// availableAmount returns the largest amount that a swap of the rule's type
+// may shift for the balances provided without breaching the rule's threshold
+// on the other side of the channel.
+func (r *ThresholdRule) availableAmount(channel *balances) btcutil.Amount {
+	if r.swapType(channel) == swap.TypeIn {
+		minimumIncoming := thresholdAmount(
+			channel.capacity, r.MinimumIncomingPPM,
+		)
+		if channel.incoming <= minimumIncoming {
+			return 0
+		}
+
+		return channel.incoming - minimumIncoming
+	}
+
+	minimumOutgoing := thresholdAmount(
+		channel.capacity, r.MinimumOutgoingPPM,
+	)
+	if channel.outgoing <= minimumOutgoing {
+		return 0
 	}
+
+	return channel.outgoing - minimumOutgoing
+}
+
+// thresholdAmount returns the portion of the capacity provided that a
+// threshold expressed in parts per million represents, rounded down. The
+// capacity is split into whole millions and a remainder so that the
+// multiplication cannot overflow for any valid capacity.
+func thresholdAmount(capacity btcutil.Amount, ppm int) btcutil.Amount {
+	var (
+		base      = btcutil.Amount(ThresholdBase)
+		whole     = capacity / base
+		remainder = capacity % base
+	)
+
+	return whole*btcutil.Amount(ppm) +
+		remainder*btcutil.Amount(ppm)/base
 }
 
 // loopOutSwapAmount determines whether we can perform a loop out swap, and
 // returns the amount we need to swap to reach the desired liquidity balance
 // specified by the incoming and outgoing thresholds.
-func loopOutSwapAmount(balances *balances, incomingThresholdPercent,
-	outgoingThresholdPercent int) btcutil.Amount {
+func loopOutSwapAmount(balances *balances, incomingThresholdPPM,
+	outgoingThresholdPPM int) btcutil.Amount {
 
-	minimumIncoming := btcutil.Amount(uint64(
-		balances.capacity) *
-		uint64(incomingThresholdPercent) / 100,
+	minimumIncoming := thresholdAmount(
+		balances.capacity, incomingThresholdPPM,
 	)
-
-	minimumOutgoing := btcutil.Amount(
-		uint64(balances.capacity) *
-			uint64(outgoingThresholdPercent) / 100,
+	minimumOutgoing := thresholdAmount(
+		balances.capacity, outgoingThresholdPPM,
 	)
 
 	switch {
@@ -142,3 +483,46 @@ func loopOutSwapAmount(balances *balances, incomingThresholdPercent,
 
 	return required
 }
+
+// loopInSwapAmount determines whether we can perform a loop in swap, and
+// returns the amount we need to swap to reach the desired liquidity balance
+// specified by the incoming and outgoing thresholds. It mirrors
+// loopOutSwapAmount, shifting incoming balance to our side of the channel.
+func loopInSwapAmount(balances *balances, incomingThresholdPPM,
+	outgoingThresholdPPM int) btcutil.Amount {
+
+	minimumIncoming := thresholdAmount(
+		balances.capacity, incomingThresholdPPM,
+	)
+	minimumOutgoing := thresholdAmount(
+		balances.capacity, outgoingThresholdPPM,
+	)
+
+	switch {
+	// If we have sufficient outgoing capacity, we do not need to loop in.
+	case balances.outgoing >= minimumOutgoing:
+		return 0
+
+	// If we are already below the threshold set for incoming capacity, we
+	// cannot take any further action.
+	case balances.incoming <= minimumIncoming:
+		return 0
+	}
+
+	// Express our minimum incoming amount as a maximum outgoing amount,
+	// and aim for the midpoint between our minimum and maximum outgoing
+	// values so that we do not tip our incoming balance beneath the
+	// desired level.
+	maximumOutgoing := balances.capacity - minimumIncoming
+	midpoint := (minimumOutgoing + maximumOutgoing) / 2
+	required := midpoint - balances.outgoing
+
+	// If we do not have enough incoming balance available to reach our
+	// midpoint, we take no action.
+	available := balances.incoming - minimumIncoming
+	if available < required {
+		return 0
+	}
+
+	return required
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,67 +19,156 @@ func TestValidateThreshold(t *testing.T) {
 		{
 			name: "values ok",
 			threshold: ThresholdRule{
-				MinimumIncoming: 20,
-				MinimumOutgoing: 20,
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
 			},
 			err: nil,
 		},
 		{
 			name: "negative incoming",
 			threshold: ThresholdRule{
-				MinimumIncoming: -1,
-				MinimumOutgoing: 20,
+				MinimumIncomingPPM: -1,
+				MinimumOutgoingPPM: PercentToPPM(20),
 			},
 			err: errInvalidLiquidityThreshold,
 		},
 		{
 			name: "negative outgoing",
 			threshold: ThresholdRule{
-				MinimumIncoming: 20,
-				MinimumOutgoing: -1,
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: -1,
 			},
 			err: errInvalidLiquidityThreshold,
 		},
 		{
 			name: "incoming > 1",
 			threshold: ThresholdRule{
-				MinimumIncoming: 120,
-				MinimumOutgoing: 20,
+				MinimumIncomingPPM: PercentToPPM(120),
+				MinimumOutgoingPPM: PercentToPPM(20),
 			},
 			err: errInvalidLiquidityThreshold,
 		},
 		{
 			name: "outgoing >1",
 			threshold: ThresholdRule{
-				MinimumIncoming: 20,
-				MinimumOutgoing: 120,
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(120),
 			},
 			err: errInvalidLiquidityThreshold,
 		},
 		{
 			name: "sum < 100",
 			threshold: ThresholdRule{
-				MinimumIncoming: 60,
-				MinimumOutgoing: 39,
+				MinimumIncomingPPM: PercentToPPM(60),
+				MinimumOutgoingPPM: PercentToPPM(39),
 			},
 			err: nil,
 		},
 		{
 			name: "sum = 100",
 			threshold: ThresholdRule{
-				MinimumIncoming: 60,
-				MinimumOutgoing: 40,
+				MinimumIncomingPPM: PercentToPPM(60),
+				MinimumOutgoingPPM: PercentToPPM(40),
 			},
 			err: errInvalidThresholdSum,
 		},
 		{
 			name: "sum > 100",
 			threshold: ThresholdRule{
-				MinimumIncoming: 60,
-				MinimumOutgoing: 60,
+				MinimumIncomingPPM: PercentToPPM(60),
+				MinimumOutgoingPPM: PercentToPPM(60),
 			},
 			err: errInvalidThresholdSum,
 		},
+		{
+			name: "negative htlc conf target",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				HtlcConfTarget:     -1,
+			},
+			err: errNegativeHtlcConfTarget,
+		},
+		{
+			name: "imbalance duration too long",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM:       PercentToPPM(20),
+				MinimumOutgoingPPM:       PercentToPPM(20),
+				MinimumImbalanceDuration: snapshotRetention + 1,
+			},
+			err: errInvalidImbalanceDuration,
+		},
+		{
+			name: "reputation above 100",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				MinimumReputation:  101,
+			},
+			err: errInvalidReputation,
+		},
+		{
+			name: "negative large swap amount",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				LargeSwapAmount:    -1,
+			},
+			err: errNegativeLargeSwapAmount,
+		},
+		{
+			name: "negative fee budget",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				FeeBudget:          -1,
+			},
+			err: errNegativeRuleBudget,
+		},
+		{
+			name: "negative fee budget period",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				FeeBudget:          1000,
+				FeeBudgetPeriod:    -1,
+			},
+			err: errNegativeRuleBudget,
+		},
+		{
+			name:      "target ok",
+			threshold: *NewTargetRulePPM(PercentToPPM(50), 1),
+			err:       nil,
+		},
+		{
+			name:      "zero tolerance",
+			threshold: *NewTargetRulePPM(PercentToPPM(50), 0),
+			err:       errInvalidTarget,
+		},
+		{
+			name: "target below tolerance",
+			threshold: *NewTargetRulePPM(
+				PercentToPPM(10), PercentToPPM(20),
+			),
+			err: errInvalidTarget,
+		},
+		{
+			name: "target above tolerance",
+			threshold: *NewTargetRulePPM(
+				PercentToPPM(90), PercentToPPM(20),
+			),
+			err: errInvalidTarget,
+		},
+		{
+			name: "target thresholds not derived",
+			threshold: ThresholdRule{
+				MinimumIncomingPPM: PercentToPPM(20),
+				MinimumOutgoingPPM: PercentToPPM(20),
+				TargetLocalPPM:     PercentToPPM(50),
+				TolerancePPM:       PercentToPPM(10),
+			},
+			err: errTargetThresholds,
+		},
 	}
 
 	for _, testCase := range tests {
@@ -167,14 +257,142 @@ func TestLoopOutAmount(t *testing.T) {
 			t.Parallel()
 
 			amt := loopOutSwapAmount(
-				test.balances, test.minIncoming,
-				test.minOutgoing,
+				test.balances, PercentToPPM(test.minIncoming),
+				PercentToPPM(test.minOutgoing),
 			)
 			require.Equal(t, test.amt, amt)
 		})
 	}
 }
 
+// TestLoopInAmount tests calculation of the amount we recommend for a given
+// set of balances and threshold rule.
+func TestLoopInAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		balances    *balances
+		minIncoming int
+		minOutgoing int
+		amt         btcutil.Amount
+	}{
+		{
+			name: "outgoing ok",
+			balances: &balances{
+				capacity: 100,
+				incoming: 50,
+				outgoing: 50,
+			},
+			minOutgoing: 20,
+			minIncoming: 20,
+			amt:         0,
+		},
+		{
+			name: "loop in",
+			balances: &balances{
+				capacity: 100,
+				incoming: 100,
+				outgoing: 0,
+			},
+			minOutgoing: 40,
+			minIncoming: 20,
+			amt:         60,
+		},
+		{
+			name: "pending htlcs",
+			balances: &balances{
+				capacity: 100,
+				incoming: 30,
+				outgoing: 10,
+			},
+			minOutgoing: 40,
+			minIncoming: 20,
+			amt:         0,
+		},
+		{
+			name: "incoming below threshold",
+			balances: &balances{
+				capacity: 100,
+				incoming: 10,
+				outgoing: 10,
+			},
+			minOutgoing: 40,
+			minIncoming: 20,
+			amt:         0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			amt := loopInSwapAmount(
+				test.balances, PercentToPPM(test.minIncoming),
+				PercentToPPM(test.minOutgoing),
+			)
+			require.Equal(t, test.amt, amt)
+		})
+	}
+}
+
+// TestTargetRuleAmount tests the type and amount of swaps that target rules
+// suggest to restore their target.
+func TestTargetRuleAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		outgoing btcutil.Amount
+		swapType swap.Type
+		amt      btcutil.Amount
+	}{
+		{
+			name:     "within tolerance",
+			outgoing: 60,
+			swapType: swap.TypeOut,
+			amt:      0,
+		},
+		{
+			name:     "at lower bound",
+			outgoing: 35,
+			swapType: swap.TypeOut,
+			amt:      0,
+		},
+		{
+			name:     "above tolerance",
+			outgoing: 80,
+			swapType: swap.TypeOut,
+			amt:      30,
+		},
+		{
+			name:     "below tolerance",
+			outgoing: 20,
+			swapType: swap.TypeIn,
+			amt:      30,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// We use a target of 50% with a tolerance of 15%.
+			rule := NewTargetRulePPM(
+				PercentToPPM(50), PercentToPPM(15),
+			)
+			channel := &balances{
+				capacity: 100,
+				incoming: 100 - test.outgoing,
+				outgoing: test.outgoing,
+			}
+
+			require.Equal(t, test.swapType, rule.swapType(channel))
+			require.Equal(t, test.amt, rule.requiredAmount(channel))
+		})
+	}
+}
+
 // TestSuggestSwaps tests swap suggestions for the threshold rule. It does not
 // many different values because we have separate tests for swap amount
 // calculation.
@@ -184,7 +402,9 @@ func TestSuggestSwap(t *testing.T) {
 		rule            *ThresholdRule
 		channel         *balances
 		outRestrictions *Restrictions
+		roundUp         bool
 		swap            btcutil.Amount
+		reason          Reason
 	}{
 		{
 			name:            "liquidity ok",
@@ -195,6 +415,7 @@ func TestSuggestSwap(t *testing.T) {
 				incoming: 50,
 				outgoing: 50,
 			},
+			reason: ReasonLiquidityOk,
 		},
 		{
 			name:            "loop out",
@@ -216,7 +437,33 @@ func TestSuggestSwap(t *testing.T) {
 				incoming: 0,
 				outgoing: 100,
 			},
-			swap: 0,
+			swap:   0,
+			reason: ReasonMinAmount,
+		},
+		{
+			name:            "amount rounded up to minimum",
+			rule:            NewThresholdRule(40, 40),
+			outRestrictions: NewRestrictions(55, 300),
+			channel: &balances{
+				capacity: 100,
+				incoming: 0,
+				outgoing: 100,
+			},
+			roundUp: true,
+			swap:    55,
+		},
+		{
+			name:            "minimum breaches outgoing threshold",
+			rule:            NewThresholdRule(40, 40),
+			outRestrictions: NewRestrictions(61, 300),
+			channel: &balances{
+				capacity: 100,
+				incoming: 0,
+				outgoing: 100,
+			},
+			roundUp: true,
+			swap:    0,
+			reason:  ReasonMinAmount,
 		},
 		{
 			name:            "amount above maximum",
@@ -238,7 +485,8 @@ func TestSuggestSwap(t *testing.T) {
 				incoming: 100,
 				outgoing: 0,
 			},
-			swap: 0,
+			swap:   0,
+			reason: ReasonLiquidityOk,
 		},
 	}
 
@@ -246,10 +494,67 @@ func TestSuggestSwap(t *testing.T) {
 		test := test
 
 		t.Run(test.name, func(t *testing.T) {
-			swap := test.rule.swapAmount(
+			swap, reason := test.rule.swapAmount(
 				test.channel, test.outRestrictions,
+				test.roundUp,
 			)
 			require.Equal(t, test.swap, swap)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+// TestThresholdAmount tests calculation of the portion of a capacity that a
+// threshold represents.
+func TestThresholdAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity btcutil.Amount
+		ppm      int
+		amount   btcutil.Amount
+	}{
+		{
+			name:     "whole percentage",
+			capacity: 1000,
+			ppm:      PercentToPPM(25),
+			amount:   250,
+		},
+		{
+			name:     "fractional percentage",
+			capacity: 1000000,
+			ppm:      125000,
+			amount:   125000,
+		},
+		{
+			name:     "rounded down",
+			capacity: 999,
+			ppm:      333333,
+			amount:   332,
+		},
+		{
+			name:     "large capacity does not overflow",
+			capacity: btcutil.MaxSatoshi,
+			ppm:      ThresholdBase,
+			amount:   btcutil.MaxSatoshi,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			amount := thresholdAmount(test.capacity, test.ppm)
+			require.Equal(t, test.amount, amount)
 		})
 	}
 }
+
+// TestFormatPPM tests formatting of thresholds as percentages.
+func TestFormatPPM(t *testing.T) {
+	require.Equal(t, "0%", formatPPM(0))
+	require.Equal(t, "20%", formatPPM(PercentToPPM(20)))
+	require.Equal(t, "12.5%", formatPPM(125000))
+	require.Equal(t, "0.0001%", formatPPM(1))
+}
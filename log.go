@@ -5,14 +5,21 @@ import (
 	"github.com/lightningnetwork/lnd/build"
 )
 
-// log is a logger that is initialized with no output filters. This
-// means the package will not perform any logging by default until the
-// caller requests it.
-var log btclog.Logger
+var (
+	// log is a logger that is initialized with no output filters. This
+	// means the package will not perform any logging by default until the
+	// caller requests it.
+	log btclog.Logger
+
+	// serverLog is the logger used by the swap server client, so that
+	// its level can be set separately from the rest of the package.
+	serverLog btclog.Logger
+)
 
 // The default amount of logging is none.
 func init() {
 	UseLogger(build.NewSubLogger("LOOP", nil))
+	UseServerLogger(build.NewSubLogger("SRVR", nil))
 }
 
 // UseLogger uses a specified Logger to output package logging info.
@@ -21,3 +28,9 @@ func init() {
 func UseLogger(logger btclog.Logger) {
 	log = logger
 }
+
+// UseServerLogger uses a specified Logger to output logging info for the swap
+// server client.
+func UseServerLogger(logger btclog.Logger) {
+	serverLog = logger
+}
@@ -0,0 +1,45 @@
+package loopd
+
+import (
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// auditLog reports the entries in our audit log that belong to the swap
+// provided, if it is non-nil, and happened at or after our start time, if it
+// is non-zero. Entries are stored in the order that they were written, so no
+// sorting is required.
+func auditLog(entries []*loopdb.AuditEntry, swapHash *lntypes.Hash,
+	startTimeNs int64) *looprpc.AuditLogResponse {
+
+	resp := &looprpc.AuditLogResponse{}
+
+	for _, entry := range entries {
+		if swapHash != nil && entry.SwapHash != *swapHash {
+			continue
+		}
+
+		timestamp := entry.Time.UnixNano()
+		if startTimeNs != 0 && timestamp < startTimeNs {
+			continue
+		}
+
+		rpcType := looprpc.SwapType_LOOP_OUT
+		if entry.SwapType == swap.TypeIn {
+			rpcType = looprpc.SwapType_LOOP_IN
+		}
+
+		resp.Entries = append(resp.Entries, &looprpc.AuditEntry{
+			TimestampNs:   timestamp,
+			SwapHash:      entry.SwapHash[:],
+			Type:          rpcType,
+			PreviousState: entry.PreviousState.String(),
+			State:         entry.State.String(),
+			Component:     entry.Component.String(),
+		})
+	}
+
+	return resp
+}
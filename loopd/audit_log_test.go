@@ -0,0 +1,99 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditLog tests filtering of our audit log.
+func TestAuditLog(t *testing.T) {
+	var (
+		time1 = time.Unix(0, 100)
+		time2 = time.Unix(0, 200)
+		hash1 = lntypes.Hash{1}
+		hash2 = lntypes.Hash{2}
+	)
+
+	entries := []*loopdb.AuditEntry{
+		{
+			Time:          time1,
+			SwapHash:      hash1,
+			SwapType:      swap.TypeOut,
+			PreviousState: loopdb.StateInitiated,
+			State:         loopdb.StatePreimageRevealed,
+			Component:     loopdb.AuditComponentLoopOut,
+		},
+		{
+			Time:          time2,
+			SwapHash:      hash2,
+			SwapType:      swap.TypeIn,
+			PreviousState: loopdb.StateHtlcPublished,
+			State:         loopdb.StateFailAbandoned,
+			Component:     loopdb.AuditComponentAbandon,
+		},
+	}
+
+	rpcEntry1 := &looprpc.AuditEntry{
+		TimestampNs:   time1.UnixNano(),
+		SwapHash:      hash1[:],
+		Type:          looprpc.SwapType_LOOP_OUT,
+		PreviousState: loopdb.StateInitiated.String(),
+		State:         loopdb.StatePreimageRevealed.String(),
+		Component:     "loop out",
+	}
+
+	rpcEntry2 := &looprpc.AuditEntry{
+		TimestampNs:   time2.UnixNano(),
+		SwapHash:      hash2[:],
+		Type:          looprpc.SwapType_LOOP_IN,
+		PreviousState: loopdb.StateHtlcPublished.String(),
+		State:         loopdb.StateFailAbandoned.String(),
+		Component:     "abandon",
+	}
+
+	tests := []struct {
+		name        string
+		swapHash    *lntypes.Hash
+		startTimeNs int64
+		expected    []*looprpc.AuditEntry
+	}{
+		{
+			name: "no filters",
+			expected: []*looprpc.AuditEntry{
+				rpcEntry1, rpcEntry2,
+			},
+		},
+		{
+			name:     "filter by swap",
+			swapHash: &hash1,
+			expected: []*looprpc.AuditEntry{rpcEntry1},
+		},
+		{
+			name:        "filter by start time",
+			startTimeNs: time2.UnixNano(),
+			expected:    []*looprpc.AuditEntry{rpcEntry2},
+		},
+		{
+			name:        "no matches",
+			swapHash:    &hash1,
+			startTimeNs: time2.UnixNano(),
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := auditLog(
+				entries, testCase.swapHash, testCase.startTimeNs,
+			)
+			require.Equal(t, testCase.expected, resp.Entries)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -11,6 +13,8 @@ import (
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/aperture/lsat"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/notifications"
 	"github.com/lightningnetwork/lnd/cert"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -37,6 +41,36 @@ var (
 	defaultMaxLogFileSize  = 10
 	defaultLoopOutMaxParts = uint32(5)
 
+	// defaultSweepFeeBumpBlocks is the default number of blocks that we
+	// wait for a loop out sweep to confirm before we bump its fee rate.
+	defaultSweepFeeBumpBlocks = int32(3)
+
+	// defaultDuplicateWindow is the default amount of time after a swap
+	// request that an identical request is rejected as a duplicate.
+	defaultDuplicateWindow = time.Minute
+
+	// defaultBudgetReconcileInterval is the default interval at which we
+	// reconcile our autoloop budget against the realized fees of completed
+	// swaps.
+	defaultBudgetReconcileInterval = time.Hour
+
+	// defaultRPCMaxMsgSize is the default maximum size of the messages
+	// that our gRPC server sends and receives, set to 200MiB.
+	defaultRPCMaxMsgSize = 1 * 1024 * 1024 * 200
+
+	// defaultRPCKeepaliveTime is the default duration after which we ping
+	// idle client connections.
+	defaultRPCKeepaliveTime = time.Minute
+
+	// defaultRPCKeepaliveTimeout is the default time that clients have to
+	// respond to our keepalive pings.
+	defaultRPCKeepaliveTimeout = 20 * time.Second
+
+	// defaultRPCKeepaliveMinClientTime is the default minimum interval at
+	// which clients may ping us. This must be lower than the keepalive
+	// interval that the loop cli uses.
+	defaultRPCKeepaliveMinClientTime = 10 * time.Second
+
 	// DefaultTLSCertFilename is the default file name for the autogenerated
 	// TLS certificate.
 	DefaultTLSCertFilename = "tls.cert"
@@ -95,11 +129,105 @@ type loopServerConfig struct {
 	Host  string `long:"host" description:"Loop server address host:port"`
 	Proxy string `long:"proxy" description:"The host:port of a SOCKS proxy through which all connections to the loop server will be established over"`
 
+	FailoverHosts   []string      `long:"failoverhost" description:"The host:port of a loop server to fail over to if the active server is unreachable. May be specified multiple times, servers are tried in the order given. Failover servers must be endpoints of the same swap service as host, because pending swaps are continued through whichever server is active. They use the same proxy and tls settings as host."`
+	FailoverTimeout time.Duration `long:"failovertimeout" description:"How long the active loop server must be unreachable for before we fail over to the next server."`
+
 	NoTLS   bool   `long:"notls" description:"Disable tls for communication to the loop server [testing only]"`
 	TLSPath string `long:"tlspath" description:"Path to loop server tls certificate [testing only]"`
 }
 
-type viewParameters struct{}
+// rpcConfig contains the settings of loopd's gRPC server.
+type rpcConfig struct {
+	MaxMsgSize int `long:"maxmsgsize" description:"The maximum size in bytes of the messages that loopd's gRPC server and REST proxy send and receive. Increase this value if listing a large number of swaps fails."`
+
+	DefaultTimeout time.Duration `long:"defaulttimeout" description:"The deadline that is applied to unary rpc calls whose client did not set one. Streaming rpcs are not affected. Set to 0 to disable."`
+
+	KeepaliveTime time.Duration `long:"keepalivetime" description:"The duration after which the server pings a client connection that has been idle, to keep long running streams alive."`
+
+	KeepaliveTimeout time.Duration `long:"keepalivetimeout" description:"The time that a client has to respond to a keepalive ping before its connection is closed."`
+
+	KeepaliveMinClientTime time.Duration `long:"keepaliveminclienttime" description:"The minimum interval at which clients may send keepalive pings. Clients that ping more frequently are disconnected."`
+
+	LogCalls bool `long:"logcalls" description:"Log every rpc call with its result and latency. Requests and responses are logged at trace level."`
+
+	RateLimits []string `long:"ratelimit" description:"Limit the rate of calls to an rpc, in the form method=calls/interval, for example LoopOut=10/1m. Calls that exceed the limit fail with a resource exhausted error. Can be set multiple times."`
+}
+
+// confPolicyConfig contains the settings of the policy that scales the htlc
+// confirmations and sweep confirmation target of loop out swaps with the swap
+// amount.
+type confPolicyConfig struct {
+	SmallAmount          uint64 `long:"smallamount" description:"The loop out amount in satoshis below which the small swap confirmation values are used."`
+	SmallHtlcConfs       int32  `long:"smallhtlcconfs" description:"The number of htlc confirmations required for loop outs below smallamount. Set to 0 to use the default."`
+	SmallSweepConfTarget int32  `long:"smallsweepconftarget" description:"The sweep confirmation target for loop outs below smallamount. Set to 0 to use the default."`
+	LargeAmount          uint64 `long:"largeamount" description:"The loop out amount in satoshis at or above which the large swap confirmation values are used."`
+	LargeHtlcConfs       int32  `long:"largehtlcconfs" description:"The number of htlc confirmations required for loop outs of at least largeamount. Set to 0 to use the default."`
+	LargeSweepConfTarget int32  `long:"largesweepconftarget" description:"The sweep confirmation target for loop outs of at least largeamount. Set to 0 to use the default."`
+}
+
+// defaultConfPolicyConfig returns the config of our default confirmation
+// policy.
+func defaultConfPolicyConfig() *confPolicyConfig {
+	policy := loop.DefaultConfPolicy
+
+	return &confPolicyConfig{
+		SmallAmount:          uint64(policy.SmallAmount),
+		SmallHtlcConfs:       policy.SmallHtlcConfirmations,
+		SmallSweepConfTarget: policy.SmallSweepConfTarget,
+		LargeAmount:          uint64(policy.LargeAmount),
+		LargeHtlcConfs:       policy.LargeHtlcConfirmations,
+		LargeSweepConfTarget: policy.LargeSweepConfTarget,
+	}
+}
+
+// policy returns the confirmation policy that is configured.
+func (c *confPolicyConfig) policy() loop.ConfPolicy {
+	return loop.ConfPolicy{
+		SmallAmount:            btcutil.Amount(c.SmallAmount),
+		SmallHtlcConfirmations: c.SmallHtlcConfs,
+		SmallSweepConfTarget:   c.SmallSweepConfTarget,
+		LargeAmount:            btcutil.Amount(c.LargeAmount),
+		LargeHtlcConfirmations: c.LargeHtlcConfs,
+		LargeSweepConfTarget:   c.LargeSweepConfTarget,
+	}
+}
+
+// notificationsConfig contains the sinks that swap and autoloop events are
+// delivered to.
+type notificationsConfig struct {
+	WebhookURL string `long:"webhookurl" description:"A http(s) url that swap and autoloop events are posted to as json."`
+
+	Command string `long:"command" description:"The path to an executable that is run for every swap and autoloop event. The event is written to its stdin as json, and its type and swap hash are set in the LOOP_EVENT_TYPE and LOOP_SWAP_HASH environment variables."`
+
+	Timeout time.Duration `long:"timeout" description:"The amount of time that each sink has to handle an event before delivery is abandoned."`
+
+	SMTPServer string `long:"smtpserver" description:"The host:port of a smtp server that critical events are emailed through. Requires emailfrom and emailto."`
+
+	SMTPUser string `long:"smtpuser" description:"The username to authenticate with the smtp server. If not set, we do not authenticate."`
+
+	SMTPPassword string `long:"smtppassword" description:"The password to authenticate with the smtp server."`
+
+	EmailFrom string `long:"emailfrom" description:"The address that critical events are emailed from."`
+
+	EmailTo []string `long:"emailto" description:"An address that critical events are emailed to. May be set multiple times."`
+
+	TelegramToken string `long:"telegramtoken" description:"The token of a Telegram bot that critical events are sent from. Requires telegramchatid."`
+
+	TelegramChatID string `long:"telegramchatid" description:"The id of the Telegram chat that critical events are sent to."`
+
+	MatrixHomeserver string `long:"matrixhomeserver" description:"The url of a Matrix homeserver that critical events are sent through. Requires matrixtoken and matrixroom."`
+
+	MatrixToken string `long:"matrixtoken" description:"The access token of the Matrix user that critical events are sent as."`
+
+	MatrixRoom string `long:"matrixroom" description:"The id of the Matrix room that critical events are sent to."`
+
+	RateLimit time.Duration `long:"ratelimit" description:"The minimum amount of time between critical events of the same type that are sent by email, Telegram or Matrix. Events that occur sooner are not sent. Set to 0 to disable rate limiting."`
+}
+
+type viewParameters struct {
+	Prune     bool          `long:"prune" description:"Delete swaps that reached a final state more than the retention period ago, and compact the database."`
+	Retention time.Duration `long:"retention" description:"The amount of time that swaps are kept for after they reach a final state when pruning. Valid time units are {s, m, h}."`
+}
 
 type Config struct {
 	ShowVersion bool   `long:"version" description:"Display version information and exit"`
@@ -108,6 +236,12 @@ type Config struct {
 	RESTListen  string `long:"restlisten" description:"Address to listen on for REST clients"`
 	CORSOrigin  string `long:"corsorigin" description:"The value to send in the Access-Control-Allow-Origin header. Header will be omitted if empty."`
 
+	UIListen       string `long:"uilisten" description:"Address to serve a read-only web dashboard of pending swaps, autoloop status and swap history on. The dashboard does not require credentials, so it should only be reachable by trusted users. Disabled if empty."`
+	UIAllowActions bool   `long:"uiallowactions" description:"Allow autoloop checks to be triggered from the web dashboard."`
+
+	WSPingInterval time.Duration `long:"wspinginterval" description:"The interval at which ping messages are sent to clients that stream from the REST proxy over a websocket."`
+	WSPongWait     time.Duration `long:"wspongwait" description:"The time that websocket clients have to respond to a ping message before their connection is closed."`
+
 	LoopDir    string `long:"loopdir" description:"The directory for all of loop's data. If set, this option overwrites --datadir, --logdir, --tlscertpath, --tlskeypath and --macaroonpath."`
 	ConfigFile string `long:"configfile" description:"Path to configuration file."`
 	DataDir    string `long:"datadir" description:"Directory for loopdb."`
@@ -131,11 +265,57 @@ type Config struct {
 
 	LoopOutMaxParts uint32 `long:"loopoutmaxparts" description:"The maximum number of payment parts that may be used for a loop out swap."`
 
+	LoopOutPaymentTimeout time.Duration `long:"loopoutpaymenttimeout" description:"The timeout for the off-chain swap and prepay payments of loop out swaps, after which lnd stops searching for a route and the swap fails. Nodes with poor connectivity may need a longer timeout. Individual swaps may override this value."`
+
+	SweepFeeBumpBlocks int32 `long:"sweepfeebumpblocks" description:"The number of blocks to wait for a loop out sweep or loop in refund to confirm before republishing it with a higher fee rate, up to the swap's maximum miner fee for sweeps and loopinrefundmaxfee for refunds. Set to 0 to disable fee bumping."`
+
+	LoopInRefundConfTarget int32 `long:"loopinrefundconftarget" description:"The confirmation target for the transactions that refund the htlcs of timed out loop in swaps. Set to 0 to use the default."`
+
+	LoopInRefundMaxFee uint64 `long:"loopinrefundmaxfee" description:"The maximum fee in satoshis that loopd pays to refund the htlc of a timed out loop in swap. If a higher fee is required to reach the refund's confirmation target, the refund is published with this fee instead. Set to 0 to disable the limit."`
+
+	MinPreimageRevealDelta int32 `long:"minpreimagerevealdelta" description:"The minimum number of blocks that must remain before a loop out htlc expires for loopd to reveal the preimage and sweep it. If fewer blocks remain, the swap is failed rather than racing the server's timeout sweep."`
+
+	DestXpub string `long:"destxpub" description:"An optional extended public key (xpub/tpub) that loop out sweep addresses can be derived from. Addresses are derived as p2wkh outputs at xpub/0/i, with the index tracked in loopd's database."`
+
+	MaxLockedValue uint64 `long:"maxlockedvalue" description:"The maximum total amount in satoshis that may be locked in pending swaps at any one time. Manual swaps that would exceed this value are rejected, and autoloop will not suggest them. Set to 0 to disable the limit."`
+
+	MaxLoopInWalletFraction float64 `long:"maxloopinwalletfraction" description:"The maximum fraction of the node's on-chain funds, its confirmed wallet balance plus the amount of pending loop ins, that may be committed to pending loop in htlcs at once, so that the wallet can still pay fees such as those of force closes. Manual loop ins that would exceed this fraction are rejected, and autoloop will not suggest them. Loop ins published by an external wallet are not counted. Set to 0 to disable the limit."`
+
+	AutoloopChangeDelay time.Duration `long:"autoloopchangedelay" description:"The amount of time that autoloop parameter changes which increase the fees autoloop may spend (a higher budget, a later budget start date or higher fee limits) are held for before they take effect. Pending changes can be cancelled with loop cancelparams during this time, which protects unattended nodes from a compromised credential. Set to 0 to apply all changes immediately."`
+
+	LiquiditySnapshotInterval time.Duration `long:"liquiditysnapshotinterval" description:"How often to record snapshots of channel balances in loopd's database. Snapshots are kept for a week, and allow autoloop rules to require that their thresholds have been breached for a minimum duration before a swap is suggested, so that transient flows do not trigger swaps. Set to 0 to disable snapshots."`
+
+	BudgetReconcileInterval time.Duration `long:"budgetreconcileinterval" description:"How often to reconcile the fees that automatically dispatched swaps reserved from the autoloop budget while in flight against the fees that they realized once they completed. Completed swaps always count towards the budget at their realized fees, but if the last autoloop check found the budget exhausted and a reconciliation finds that budget was released, another autoloop check is performed straight away. Set to 0 to disable scheduled reconciliation."`
+
+	StaleRuleAge time.Duration `long:"staleruleage" description:"The amount of time that the channel or peer that an autoloop rule is set for must be closed or disconnected for before the rule is reported as stale. Stale rules are logged, and can be listed with loop stalerules. Set to 0 to disable stale rule tracking."`
+
+	PruneStaleRules bool `long:"prunestalerules" description:"Remove stale autoloop rules from the liquidity parameters, rather than only reporting them. Requires staleruleage to be set."`
+
+	TermsCacheTTL time.Duration `long:"termscachettl" description:"How long to cache the swap server's loop in and loop out terms for, so that they are not fetched from the server for every quote and autoloop check. Set to 0 to disable caching."`
+
+	NoTxLabels bool `long:"notxlabels" description:"Do not label the on-chain transactions that loopd publishes for swaps in lnd's wallet. By default, transactions are labelled with the type and hash of the swap that they belong to."`
+
+	MaxConcurrentSwaps int `long:"maxconcurrentswaps" description:"The maximum number of swaps that are executed at once. New swaps that are dispatched while this many are executing are queued, and started in the order they were received as executing swaps complete, so that large numbers of swaps do not overload lnd. Pending swaps that are resumed on startup are always started immediately. Set to 0 to execute all swaps at once."`
+
+	DuplicateWindow time.Duration `long:"duplicatewindow" description:"The amount of time after a loop out or loop in request that an identical request, with the same amount and channel or peer restriction, is rejected unless it sets allow_duplicate. This prevents accidental duplicate swaps, for example from double clicking in a user interface. Set to 0 to disable duplicate detection."`
+
+	BatchSweeps bool `long:"batchsweeps" description:"Sweep the htlcs of loop out swaps that confirm around the same time in a single transaction to save on chain fees."`
+
+	DBPasswordFile string `long:"dbpasswordfile" description:"Path to a file that contains the password, or key, used to encrypt swap preimages in loopd's database. If an unencrypted database is opened with a password, it is encrypted. Once encrypted, the database cannot be opened without this file."`
+
+	ForceStaleDB bool `long:"force-stale-db" description:"Start even though loopd's database is older than the backup taken the last time it was opened, which indicates that an old copy of the database was restored. Running with a stale database may reveal swap preimages or reuse addresses more than once, so only set this if you are sure that the database is current."`
+
 	Lnd *lndConfig `group:"lnd" namespace:"lnd"`
 
 	Server *loopServerConfig `group:"server" namespace:"server"`
 
-	View viewParameters `command:"view" alias:"v" description:"View all swaps in the database. This command can only be executed when loopd is not running."`
+	RPC *rpcConfig `group:"rpc" namespace:"rpc"`
+
+	ConfPolicy *confPolicyConfig `group:"confpolicy" namespace:"confpolicy"`
+
+	Notifications *notificationsConfig `group:"notifications" namespace:"notifications"`
+
+	View viewParameters `command:"view" alias:"v" description:"View all swaps in the database, or prune old swaps from it with --prune. This command can only be executed when loopd is not running."`
 }
 
 const (
@@ -150,21 +330,29 @@ func DefaultConfig() Config {
 		RPCListen:  "localhost:11010",
 		RESTListen: "localhost:8081",
 		Server: &loopServerConfig{
-			NoTLS: false,
+			NoTLS:           false,
+			FailoverTimeout: loop.DefaultServerFailoverTimeout,
 		},
-		LoopDir:         LoopDirBase,
-		ConfigFile:      defaultConfigFile,
-		DataDir:         LoopDirBase,
-		LogDir:          defaultLogDir,
-		MaxLogFiles:     defaultMaxLogFiles,
-		MaxLogFileSize:  defaultMaxLogFileSize,
-		DebugLevel:      defaultLogLevel,
-		TLSCertPath:     DefaultTLSCertPath,
-		TLSKeyPath:      DefaultTLSKeyPath,
-		MacaroonPath:    DefaultMacaroonPath,
-		MaxLSATCost:     lsat.DefaultMaxCostSats,
-		MaxLSATFee:      lsat.DefaultMaxRoutingFeeSats,
-		LoopOutMaxParts: defaultLoopOutMaxParts,
+		LoopDir:                LoopDirBase,
+		ConfigFile:             defaultConfigFile,
+		DataDir:                LoopDirBase,
+		LogDir:                 defaultLogDir,
+		MaxLogFiles:            defaultMaxLogFiles,
+		MaxLogFileSize:         defaultMaxLogFileSize,
+		DebugLevel:             defaultLogLevel,
+		TLSCertPath:            DefaultTLSCertPath,
+		TLSKeyPath:             DefaultTLSKeyPath,
+		MacaroonPath:           DefaultMacaroonPath,
+		MaxLSATCost:            lsat.DefaultMaxCostSats,
+		MaxLSATFee:             lsat.DefaultMaxRoutingFeeSats,
+		LoopOutMaxParts:        defaultLoopOutMaxParts,
+		LoopOutPaymentTimeout:  loop.DefaultPaymentTimeout,
+		TermsCacheTTL:          loop.DefaultTermsCacheTTL,
+		WSPingInterval:         defaultWSPingInterval,
+		WSPongWait:             defaultWSPongWait,
+		SweepFeeBumpBlocks:     defaultSweepFeeBumpBlocks,
+		MinPreimageRevealDelta: loop.MinLoopOutPreimageRevealDelta,
+		DuplicateWindow:        defaultDuplicateWindow,
 		Lnd: &lndConfig{
 			Host: "localhost:10009",
 			MacaroonPath: filepath.Join(
@@ -173,6 +361,21 @@ func DefaultConfig() Config {
 				"admin.macaroon",
 			),
 		},
+		RPC: &rpcConfig{
+			MaxMsgSize:             defaultRPCMaxMsgSize,
+			KeepaliveTime:          defaultRPCKeepaliveTime,
+			KeepaliveTimeout:       defaultRPCKeepaliveTimeout,
+			KeepaliveMinClientTime: defaultRPCKeepaliveMinClientTime,
+		},
+		ConfPolicy: defaultConfPolicyConfig(),
+		Notifications: &notificationsConfig{
+			Timeout:   notifications.DefaultTimeout,
+			RateLimit: notifications.DefaultRateLimit,
+		},
+		View: viewParameters{
+			Retention: defaultPruneRetention,
+		},
+		BudgetReconcileInterval: defaultBudgetReconcileInterval,
 	}
 }
 
@@ -185,6 +388,7 @@ func Validate(cfg *Config) error {
 	cfg.TLSCertPath = lncfg.CleanAndExpandPath(cfg.TLSCertPath)
 	cfg.TLSKeyPath = lncfg.CleanAndExpandPath(cfg.TLSKeyPath)
 	cfg.MacaroonPath = lncfg.CleanAndExpandPath(cfg.MacaroonPath)
+	cfg.DBPasswordFile = lncfg.CleanAndExpandPath(cfg.DBPasswordFile)
 
 	// Since our loop directory overrides our log/data dir values, make sure
 	// that they are not set when loop dir is set. We hard here rather than
@@ -256,6 +460,125 @@ func Validate(cfg *Config) error {
 		return err
 	}
 
+	if cfg.SweepFeeBumpBlocks < 0 {
+		return fmt.Errorf("sweepfeebumpblocks must not be negative")
+	}
+
+	if cfg.MinPreimageRevealDelta <= 0 {
+		return fmt.Errorf("minpreimagerevealdelta must be positive")
+	}
+
+	if cfg.WSPingInterval <= 0 || cfg.WSPongWait <= 0 {
+		return fmt.Errorf("wspinginterval and wspongwait must be " +
+			"positive")
+	}
+
+	if cfg.RPC.MaxMsgSize <= 0 {
+		return fmt.Errorf("rpc.maxmsgsize must be positive")
+	}
+
+	if cfg.RPC.DefaultTimeout < 0 {
+		return fmt.Errorf("rpc.defaulttimeout must not be negative")
+	}
+
+	if _, err := parseRateLimits(cfg.RPC.RateLimits); err != nil {
+		return fmt.Errorf("rpc.ratelimit: %v", err)
+	}
+
+	if cfg.RPC.KeepaliveTime <= 0 || cfg.RPC.KeepaliveTimeout <= 0 ||
+		cfg.RPC.KeepaliveMinClientTime <= 0 {
+
+		return fmt.Errorf("rpc.keepalivetime, rpc.keepalivetimeout and " +
+			"rpc.keepaliveminclienttime must be positive")
+	}
+
+	if cfg.LoopOutPaymentTimeout < time.Second {
+		return fmt.Errorf("loopoutpaymenttimeout must be at least one " +
+			"second")
+	}
+
+	if cfg.AutoloopChangeDelay < 0 {
+		return fmt.Errorf("autoloopchangedelay must not be negative")
+	}
+
+	if cfg.LiquiditySnapshotInterval < 0 {
+		return fmt.Errorf("liquiditysnapshotinterval must not be " +
+			"negative")
+	}
+
+	if cfg.MaxConcurrentSwaps < 0 {
+		return fmt.Errorf("maxconcurrentswaps must not be negative")
+	}
+
+	if cfg.DuplicateWindow < 0 {
+		return fmt.Errorf("duplicatewindow must not be negative")
+	}
+
+	if cfg.StaleRuleAge < 0 {
+		return fmt.Errorf("staleruleage must not be negative")
+	}
+
+	if cfg.BudgetReconcileInterval < 0 {
+		return fmt.Errorf("budgetreconcileinterval must not be " +
+			"negative")
+	}
+
+	if cfg.PruneStaleRules && cfg.StaleRuleAge == 0 {
+		return fmt.Errorf("prunestalerules requires staleruleage to " +
+			"be set")
+	}
+
+	if cfg.TermsCacheTTL < 0 {
+		return fmt.Errorf("termscachettl must not be negative")
+	}
+
+	if cfg.MaxLoopInWalletFraction < 0 || cfg.MaxLoopInWalletFraction > 1 {
+		return fmt.Errorf("maxloopinwalletfraction must be in [0, 1]")
+	}
+
+	if len(cfg.Server.FailoverHosts) > 0 && cfg.Server.FailoverTimeout <= 0 {
+		return fmt.Errorf("server.failovertimeout must be positive")
+	}
+
+	if cfg.UIAllowActions && cfg.UIListen == "" {
+		return fmt.Errorf("uiallowactions requires uilisten to be set")
+	}
+
+	if cfg.UIListen != "" && (cfg.UIListen == cfg.RPCListen ||
+		cfg.UIListen == cfg.RESTListen) {
+
+		return fmt.Errorf("uilisten must differ from rpclisten and " +
+			"restlisten")
+	}
+
+	confPolicy := cfg.ConfPolicy.policy()
+	if err := confPolicy.Validate(); err != nil {
+		return err
+	}
+
+	if !validPolicyConfTarget(confPolicy.SmallSweepConfTarget) ||
+		!validPolicyConfTarget(confPolicy.LargeSweepConfTarget) {
+
+		return fmt.Errorf("confpolicy sweep confirmation targets must "+
+			"be at least %v", minConfTarget)
+	}
+
+	if cfg.LoopInRefundConfTarget != 0 &&
+		cfg.LoopInRefundConfTarget < minConfTarget {
+
+		return fmt.Errorf("loopinrefundconftarget must be at least %v",
+			minConfTarget)
+	}
+
+	if err := validateNotifications(cfg.Notifications); err != nil {
+		return err
+	}
+
+	if cfg.DBPasswordFile != "" && !lnrpc.FileExists(cfg.DBPasswordFile) {
+		return fmt.Errorf("dbpasswordfile %v does not exist",
+			cfg.DBPasswordFile)
+	}
+
 	// Make sure only one of the macaroon options is used.
 	switch {
 	case cfg.Lnd.MacaroonPath != "" && cfg.Lnd.MacaroonDir != "":
@@ -283,6 +606,87 @@ func Validate(cfg *Config) error {
 	return nil
 }
 
+// validateNotifications cleans up the command path of our notifications config
+// and validates it.
+func validateNotifications(cfg *notificationsConfig) error {
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("notifications.timeout must be positive")
+	}
+
+	if cfg.WebhookURL != "" {
+		webhook, err := url.Parse(cfg.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid notifications.webhookurl: %v",
+				err)
+		}
+
+		if webhook.Scheme != "http" && webhook.Scheme != "https" {
+			return fmt.Errorf("notifications.webhookurl must be a " +
+				"http or https url")
+		}
+	}
+
+	if cfg.Command != "" {
+		cfg.Command = lncfg.CleanAndExpandPath(cfg.Command)
+
+		if !lnrpc.FileExists(cfg.Command) {
+			return fmt.Errorf("notifications.command %v does not "+
+				"exist", cfg.Command)
+		}
+	}
+
+	if cfg.RateLimit < 0 {
+		return fmt.Errorf("notifications.ratelimit may not be " +
+			"negative")
+	}
+
+	if cfg.SMTPServer != "" {
+		if _, _, err := net.SplitHostPort(cfg.SMTPServer); err != nil {
+			return fmt.Errorf("invalid notifications.smtpserver: "+
+				"%v", err)
+		}
+
+		if cfg.EmailFrom == "" || len(cfg.EmailTo) == 0 {
+			return fmt.Errorf("notifications.smtpserver requires " +
+				"notifications.emailfrom and " +
+				"notifications.emailto")
+		}
+	}
+
+	if (cfg.TelegramToken == "") != (cfg.TelegramChatID == "") {
+		return fmt.Errorf("notifications.telegramtoken and " +
+			"notifications.telegramchatid must be set together")
+	}
+
+	if cfg.MatrixHomeserver != "" {
+		homeserver, err := url.Parse(cfg.MatrixHomeserver)
+		if err != nil {
+			return fmt.Errorf("invalid "+
+				"notifications.matrixhomeserver: %v", err)
+		}
+
+		if homeserver.Scheme != "http" && homeserver.Scheme != "https" {
+			return fmt.Errorf("notifications.matrixhomeserver " +
+				"must be a http or https url")
+		}
+
+		if cfg.MatrixToken == "" || cfg.MatrixRoom == "" {
+			return fmt.Errorf("notifications.matrixhomeserver " +
+				"requires notifications.matrixtoken and " +
+				"notifications.matrixroom")
+		}
+	}
+
+	return nil
+}
+
+// validPolicyConfTarget returns a boolean indicating whether a sweep
+// confirmation target of our confirmation policy is either unset, or at least
+// our minimum confirmation target.
+func validPolicyConfTarget(target int32) bool {
+	return target == 0 || target >= minConfTarget
+}
+
 // getTLSConfig generates a new self signed certificate or refreshes an existing
 // one if necessary, then returns the full TLS configuration for initializing
 // a secure server interface.
@@ -0,0 +1,77 @@
+package loopd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate tests validation of the values in our config.
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(cfg *Config)
+		err    bool
+	}{
+		{
+			name:   "default config",
+			mutate: func(cfg *Config) {},
+		},
+		{
+			name: "fee bumping disabled",
+			mutate: func(cfg *Config) {
+				cfg.SweepFeeBumpBlocks = 0
+			},
+		},
+		{
+			name: "negative fee bump blocks",
+			mutate: func(cfg *Config) {
+				cfg.SweepFeeBumpBlocks = -1
+			},
+			err: true,
+		},
+		{
+			name: "minimum preimage reveal delta",
+			mutate: func(cfg *Config) {
+				cfg.MinPreimageRevealDelta = 1
+			},
+		},
+		{
+			name: "zero preimage reveal delta",
+			mutate: func(cfg *Config) {
+				cfg.MinPreimageRevealDelta = 0
+			},
+			err: true,
+		},
+		{
+			name: "negative preimage reveal delta",
+			mutate: func(cfg *Config) {
+				cfg.MinPreimageRevealDelta = -1
+			},
+			err: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			loopDir, err := ioutil.TempDir("", "loopdir")
+			require.NoError(t, err)
+			defer os.RemoveAll(loopDir)
+
+			cfg := DefaultConfig()
+			cfg.LoopDir = loopDir
+			testCase.mutate(&cfg)
+
+			err = Validate(&cfg)
+			if testCase.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
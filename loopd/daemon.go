@@ -23,10 +23,6 @@ import (
 )
 
 var (
-	// maxMsgRecvSize is the largest message our REST proxy will receive. We
-	// set this to 200MiB atm.
-	maxMsgRecvSize = grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200)
-
 	// errOnlyStartOnce is the error that is returned if the daemon is
 	// started more than once.
 	errOnlyStartOnce = fmt.Errorf("daemon can only be started once")
@@ -42,6 +38,11 @@ type listenerCfg struct {
 	// on the passed TLS configuration.
 	restListener func(*tls.Config) (net.Listener, error)
 
+	// uiListener returns a TLS listener to use for the web dashboard,
+	// based on the passed TLS configuration. A nil listener disables the
+	// dashboard.
+	uiListener func(*tls.Config) (net.Listener, error)
+
 	// getLnd returns a grpc connection to an lnd instance.
 	getLnd func(lndclient.Network, *lndConfig) (*lndclient.GrpcLndServices,
 		error)
@@ -77,11 +78,17 @@ type Daemon struct {
 	mainCtx       context.Context
 	mainCtxCancel func()
 
+	// shutdown stops our swap client and the goroutines that depend on
+	// it in dependency order.
+	shutdown *shutdownCoordinator
+
 	grpcServer    *grpc.Server
 	grpcListener  net.Listener
 	restServer    *http.Server
 	restListener  net.Listener
 	restCtxCancel func()
+	uiServer      *http.Server
+	uiListener    net.Listener
 
 	macaroonService *macaroons.Service
 }
@@ -99,10 +106,10 @@ func New(config *Config, lisCfg *listenerCfg) *Daemon {
 		cfg:         config,
 		listenerCfg: lisCfg,
 
-		// We have 4 goroutines that could potentially send an error.
+		// We have 8 goroutines that could potentially send an error.
 		// We react on the first error but in case more than one exits
 		// with an error we don't want them to block.
-		internalErrChan: make(chan error, 4),
+		internalErrChan: make(chan error, 8),
 	}
 }
 
@@ -212,6 +219,11 @@ func (d *Daemon) startWebServers() error {
 	if err != nil {
 		return fmt.Errorf("error with macaroon interceptor: %v", err)
 	}
+	rpcOpts, err := d.cfg.RPC.serverOptions()
+	if err != nil {
+		return err
+	}
+	serverOpts = append(serverOpts, rpcOpts...)
 	d.grpcServer = grpc.NewServer(serverOpts...)
 	looprpc.RegisterSwapClientServer(d.grpcServer, d)
 
@@ -247,13 +259,21 @@ func (d *Daemon) startWebServers() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	d.restCtxCancel = cancel
 	mux := proxy.NewServeMux(customMarshalerOption)
-	var restHandler http.Handler = mux
+
+	// Streaming rpcs are served over websockets, which are handled by the
+	// REST proxy once the connection has been upgraded.
+	restHandler := newWebSocketProxy(
+		mux, d.cfg.CORSOrigin, d.cfg.WSPingInterval, d.cfg.WSPongWait,
+	)
 	if d.cfg.CORSOrigin != "" {
 		restHandler = allowCORS(restHandler, d.cfg.CORSOrigin)
 	}
 	proxyOpts := []grpc.DialOption{
 		grpc.WithTransportCredentials(*restClientCreds),
-		grpc.WithDefaultCallOptions(maxMsgRecvSize),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(d.cfg.RPC.MaxMsgSize),
+			grpc.MaxCallSendMsgSize(d.cfg.RPC.MaxMsgSize),
+		),
 	}
 
 	// With TLS enabled by default, we cannot call 0.0.0.0 internally from
@@ -311,6 +331,10 @@ func (d *Daemon) startWebServers() error {
 		log.Infof("REST proxy disabled")
 	}
 
+	if err := d.startDashboard(mux, serverTLSCfg); err != nil {
+		return err
+	}
+
 	// Start the grpc server.
 	d.wg.Add(1)
 	go func() {
@@ -351,6 +375,10 @@ func (d *Daemon) initialize() error {
 	}
 
 	log.Infof("Swap server address: %v", d.cfg.Server.Host)
+	if len(d.cfg.Server.FailoverHosts) > 0 {
+		log.Infof("Failover swap server addresses: %v",
+			strings.Join(d.cfg.Server.FailoverHosts, ", "))
+	}
 
 	// Create an instance of the loop client library.
 	swapclient, clientCleanup, err := getClient(d.cfg, &d.lnd.LndServices)
@@ -374,20 +402,44 @@ func (d *Daemon) initialize() error {
 		return err
 	}
 
+	// Connect to lnd directly so that we can estimate the cost of circular
+	// rebalances. These estimates are advisory, so we only log a warning
+	// if we cannot connect.
+	rebalance, err := newRebalanceEstimator(
+		d.cfg.Lnd, d.cfg.Network, &d.lnd.LndServices,
+	)
+	if err != nil {
+		log.Warnf("Rebalance estimates unavailable: %v", err)
+		rebalance = nil
+	}
+
+	notifier := getNotifier(d.cfg.Notifications)
+	liquidityMgr := getLiquidityManager(
+		swapclient, rebalance, notifier, d.cfg,
+	)
+
 	// Now finally fully initialize the swap client RPC server instance.
 	d.swapClientServer = swapClientServer{
-		network:      lndclient.Network(d.cfg.Network),
-		impl:         swapclient,
-		liquidityMgr: getLiquidityManager(swapclient),
-		lnd:          &d.lnd.LndServices,
-		swaps:        make(map[lntypes.Hash]loop.SwapInfo),
-		subscribers:  make(map[int]chan<- interface{}),
-		statusChan:   make(chan loop.SwapInfo),
-		mainCtx:      d.mainCtx,
+		network:       lndclient.Network(d.cfg.Network),
+		impl:          swapclient,
+		liquidityMgr:  liquidityMgr,
+		staticAddrMgr: getStaticAddressManager(swapclient),
+		rebalance:     rebalance,
+		notifier:      notifier,
+		lnd:           &d.lnd.LndServices,
+		swaps:         make(map[lntypes.Hash]loop.SwapInfo),
+		subscribers:   make(map[int]chan<- interface{}),
+		statusChan:    make(chan loop.SwapInfo),
+		mainCtx:       d.mainCtx,
+		config:        d.cfg,
+		epoch:         newMonitorEpoch(swapclient.Clock),
+		duplicates: newDuplicateGuard(
+			d.cfg.DuplicateWindow, swapclient.Clock,
+		),
 	}
 
 	// Retrieve all currently existing swaps from the database.
-	swapsList, err := d.impl.FetchSwaps()
+	swapsList, err := d.impl.FetchSwaps(d.mainCtx)
 	if err != nil {
 		// The client and the macaroon service are the only things we
 		// started yet, so if we clean that up now, nothing else needs
@@ -404,13 +456,43 @@ func (d *Daemon) initialize() error {
 		d.swaps[s.SwapHash] = *s
 	}
 
-	// Start the swap client itself.
-	d.wg.Add(1)
-	go func() {
-		defer d.wg.Done()
+	// Our goroutines are stopped in the reverse of the order that their
+	// stages are added in. Swap updates are processed until our swaps
+	// have stopped, and swaps are only stopped once autoloop can no
+	// longer dispatch new ones. Before our swaps are stopped, we
+	// checkpoint the phase that each in-flight swap has reached.
+	d.shutdown = newShutdownCoordinator(d.mainCtx)
+
+	updates := d.shutdown.addStage("swap update handlers", nil)
+	swaps := d.shutdown.addStage("swap client", func() {
+		if err := d.impl.CheckpointSwaps(); err != nil {
+			log.Errorf("Could not checkpoint swaps: %v", err)
+		}
+	})
+	autoloop := d.shutdown.addStage("swap dispatchers", nil)
 
+	// Start a goroutine that broadcasts swap updates to clients.
+	updates.goroutine(func(ctx context.Context) {
+		log.Infof("Waiting for updates")
+		d.processStatusUpdates(ctx)
+	})
+
+	if d.notifier != nil {
+		updates.goroutine(func(ctx context.Context) {
+			log.Info("Starting notifications manager")
+			err := d.notifier.Run(ctx)
+			if err != nil && err != context.Canceled {
+				d.internalErrChan <- err
+			}
+
+			log.Info("Notifications manager stopped")
+		})
+	}
+
+	// Start the swap client itself.
+	swaps.goroutine(func(ctx context.Context) {
 		log.Infof("Starting swap client")
-		err := d.impl.Run(d.mainCtx, d.statusChan)
+		err := d.impl.Run(ctx, d.statusChan)
 		if err != nil {
 			// Notify the main error handler goroutine that
 			// we exited unexpectedly here. We don't have to
@@ -419,29 +501,27 @@ func (d *Daemon) initialize() error {
 			d.internalErrChan <- err
 		}
 		log.Infof("Swap client stopped")
-	}()
-
-	// Start a goroutine that broadcasts swap updates to clients.
-	d.wg.Add(1)
-	go func() {
-		defer d.wg.Done()
-
-		log.Infof("Waiting for updates")
-		d.processStatusUpdates(d.mainCtx)
-	}()
-
-	d.wg.Add(1)
-	go func() {
-		defer d.wg.Done()
+	})
 
+	autoloop.goroutine(func(ctx context.Context) {
 		log.Info("Starting liquidity manager")
-		err := d.liquidityMgr.Run(d.mainCtx)
+		err := d.liquidityMgr.Run(ctx)
 		if err != nil && err != context.Canceled {
 			d.internalErrChan <- err
 		}
 
 		log.Info("Liquidity manager stopped")
-	}()
+	})
+
+	autoloop.goroutine(func(ctx context.Context) {
+		log.Info("Starting static address deposit tracker")
+		err := d.staticAddrMgr.Run(ctx)
+		if err != nil && err != context.Canceled {
+			d.internalErrChan <- err
+		}
+
+		log.Info("Static address deposit tracker stopped")
+	})
 
 	// Last, start our internal error handler. This will return exactly one
 	// error or nil on the main error channel to inform the caller that
@@ -485,17 +565,57 @@ func (d *Daemon) Stop() {
 	})
 }
 
-// stop does the actual shutdown and blocks until all goroutines have exit.
-func (d *Daemon) stop() {
-	// First of all, we can cancel the main context that all event handlers
-	// are using. This should stop all swap activity and all event handlers
-	// should exit.
-	if d.mainCtxCancel != nil {
-		d.mainCtxCancel()
+// startDashboard starts serving our web dashboard if it is enabled. The
+// dashboard's calls are served by the REST handler provided, with a macaroon
+// that only grants the permissions that the dashboard requires.
+func (d *Daemon) startDashboard(rest http.Handler,
+	tlsCfg *tls.Config) error {
+
+	var err error
+	d.uiListener, err = d.listenerCfg.uiListener(tlsCfg)
+	if err != nil {
+		return fmt.Errorf("web dashboard unable to listen on %s: %v",
+			d.cfg.UIListen, err)
+	}
+
+	if d.uiListener == nil {
+		return nil
+	}
+
+	idCtx := macaroons.ContextWithRootKeyID(
+		context.Background(), macaroons.DefaultRootKeyID,
+	)
+	mac, err := d.bakeMacaroon(
+		idCtx, dashboardPermissions(d.cfg.UIAllowActions),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.uiServer = &http.Server{
+		Handler: newDashboardHandler(
+			rest, mac, d.cfg.UIAllowActions,
+		),
 	}
 
-	// As there is no swap activity anymore, we can forcefully shutdown the
-	// gRPC and HTTP servers now.
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		log.Infof("Web dashboard listening on %s", d.uiListener.Addr())
+		err := d.uiServer.Serve(d.uiListener)
+		if err != nil && err != http.ErrServerClosed {
+			d.internalErrChan <- err
+		}
+	}()
+
+	return nil
+}
+
+// stop does the actual shutdown and blocks until all goroutines have exit.
+func (d *Daemon) stop() {
+	// First of all, we shut down the gRPC and HTTP servers so that no new
+	// swaps can be requested while we are shutting down.
 	log.Infof("Stopping gRPC server")
 	if d.grpcServer != nil {
 		d.grpcServer.Stop()
@@ -509,16 +629,41 @@ func (d *Daemon) stop() {
 			log.Errorf("Error stopping REST server: %v", err)
 		}
 	}
+	if d.uiServer != nil {
+		log.Infof("Stopping web dashboard")
+		if err := d.uiServer.Close(); err != nil {
+			log.Errorf("Error stopping web dashboard: %v", err)
+		}
+	}
 	if d.restCtxCancel != nil {
 		d.restCtxCancel()
 	}
 
+	// Next, we stop all swap activity in dependency order, checkpointing
+	// our in-flight swaps before they are stopped. Once this returns, all
+	// of our event handlers have exited.
+	if d.shutdown != nil {
+		d.shutdown.stop()
+	}
+
+	// We can now cancel the main context, which releases anything else
+	// that is still using it.
+	if d.mainCtxCancel != nil {
+		d.mainCtxCancel()
+	}
+
 	err := d.macaroonService.Close()
 	if err != nil {
 		log.Errorf("Error stopping macaroon service: %v", err)
 	}
 
 	// Next, shut down the connections to lnd and the swap server.
+	if d.rebalance != nil {
+		if err := d.rebalance.close(); err != nil {
+			log.Errorf("Error closing rebalance connection: %v",
+				err)
+		}
+	}
 	if d.lnd != nil {
 		d.lnd.Close()
 	}
@@ -0,0 +1,124 @@
+package loopd
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// dashboardRoute is a REST endpoint that the dashboard may call.
+type dashboardRoute struct {
+	method string
+	path   string
+}
+
+var (
+	// dashboardReadRoutes are the REST endpoints that the dashboard uses
+	// to display our swaps and autoloop status.
+	dashboardReadRoutes = []dashboardRoute{
+		{method: http.MethodGet, path: "/v1/loop/swaps"},
+		{method: http.MethodGet, path: "/v1/auto/status"},
+		{method: http.MethodGet, path: "/v1/loop/stats"},
+	}
+
+	// dashboardActionRoutes are the REST endpoints that the dashboard may
+	// only call if actions are enabled.
+	dashboardActionRoutes = []dashboardRoute{
+		{method: http.MethodPost, path: "/v1/auto/trigger"},
+	}
+
+	// dashboardReadPermissions are the permissions that the dashboard's
+	// macaroon is baked with.
+	dashboardReadPermissions = []bakery.Op{{
+		Entity: "swap",
+		Action: "read",
+	}, {
+		Entity: "suggestions",
+		Action: "read",
+	}}
+
+	// dashboardActionPermissions are the additional permissions that the
+	// dashboard's macaroon is baked with if actions are enabled.
+	dashboardActionPermissions = []bakery.Op{{
+		Entity: "suggestions",
+		Action: "write",
+	}}
+)
+
+// dashboardPermissions returns the permissions that the dashboard requires.
+func dashboardPermissions(allowActions bool) []bakery.Op {
+	perms := append([]bakery.Op{}, dashboardReadPermissions...)
+	if allowActions {
+		perms = append(perms, dashboardActionPermissions...)
+	}
+
+	return perms
+}
+
+// newDashboardHandler returns a handler that serves our dashboard page, and
+// forwards the dashboard's calls to the REST handler provided. Only the routes
+// that the dashboard uses are forwarded, and they are authenticated with the
+// macaroon provided, so the dashboard cannot be used to reach any other part
+// of our api.
+func newDashboardHandler(rest http.Handler, macaroon []byte,
+	allowActions bool) http.Handler {
+
+	routes := append([]dashboardRoute{}, dashboardReadRoutes...)
+	if allowActions {
+		routes = append(routes, dashboardActionRoutes...)
+	}
+
+	var (
+		macHex = hex.EncodeToString(macaroon)
+		page   = dashboardHTML(allowActions)
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte(page))
+	})
+
+	for _, route := range routes {
+		route := route
+
+		mux.HandleFunc(route.path, func(w http.ResponseWriter,
+			r *http.Request) {
+
+			if r.Method != route.method {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+
+			// Requiring a json content type for actions means that
+			// they cannot be submitted by forms on other sites,
+			// because browsers do not send cross-origin json
+			// requests without a preflight that we do not answer.
+			if route.method != http.MethodGet &&
+				r.Header.Get("Content-Type") != "application/json" {
+
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+
+			// Replace any credentials that the caller set with
+			// the dashboard's macaroon.
+			r.Header.Set("Grpc-Metadata-Macaroon", macHex)
+			rest.ServeHTTP(w, r)
+		})
+	}
+
+	return mux
+}
@@ -0,0 +1,186 @@
+package loopd
+
+import "strings"
+
+// dashboardHTML returns our dashboard page. The trigger button is only shown
+// if actions are allowed.
+func dashboardHTML(allowActions bool) string {
+	actions := "false"
+	if allowActions {
+		actions = "true"
+	}
+
+	return strings.Replace(dashboardPage, "{{allowActions}}", actions, 1)
+}
+
+// dashboardPage is a minimal page that displays our pending swaps, autoloop
+// status and recent history using the REST api. It is self contained so that
+// it does not need to load any external resources.
+const dashboardPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>loopd</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.1em; margin-top: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.3em 0.6em; border-bottom: 1px solid #ddd; }
+td.num { text-align: right; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>loopd dashboard</h1>
+<p id="error" class="error"></p>
+
+<h2>Autoloop</h2>
+<table>
+<tr><th>Last check</th><td id="last-check"></td></tr>
+<tr><th>Last change</th><td id="last-change"></td></tr>
+<tr><th>Error</th><td id="auto-error"></td></tr>
+</table>
+<p><button id="trigger" hidden>Run autoloop check</button></p>
+
+<h2>Pending swaps</h2>
+<table id="pending"></table>
+
+<h2>Recent swaps</h2>
+<table id="recent"></table>
+
+<h2>Statistics (last 30 days)</h2>
+<table id="stats"></table>
+
+<script>
+var allowActions = {{allowActions}};
+var recentLimit = 20;
+
+function get(path) {
+	return fetch(path).then(function(resp) {
+		if (!resp.ok) {
+			throw new Error(path + ": " + resp.status);
+		}
+		return resp.json();
+	});
+}
+
+function formatTime(ns) {
+	var n = Number(ns);
+	if (!n) {
+		return "-";
+	}
+	return new Date(n / 1e6).toLocaleString();
+}
+
+function formatUnix(sec) {
+	var n = Number(sec);
+	if (!n) {
+		return "-";
+	}
+	return new Date(n * 1000).toLocaleString();
+}
+
+function fill(id, header, rows) {
+	var table = document.getElementById(id);
+	table.innerHTML = "";
+	var tr = table.insertRow();
+	header.forEach(function(h) {
+		var th = document.createElement("th");
+		th.textContent = h;
+		tr.appendChild(th);
+	});
+	rows.forEach(function(row) {
+		var tr = table.insertRow();
+		row.forEach(function(value) {
+			var td = tr.insertCell();
+			td.textContent = value;
+			if (typeof value === "number") {
+				td.className = "num";
+			}
+		});
+	});
+}
+
+function swapRow(swap) {
+	return [
+		swap.id, swap.type, swap.state, Number(swap.amt),
+		Number(swap.cost_server) + Number(swap.cost_onchain) +
+			Number(swap.cost_offchain),
+		swap.label, formatTime(swap.last_update_time)
+	];
+}
+
+var swapHeader = ["Id", "Type", "State", "Amount (sat)", "Cost (sat)",
+	"Label", "Last update"];
+
+function refresh() {
+	document.getElementById("error").textContent = "";
+
+	get("/v1/loop/swaps").then(function(resp) {
+		var swaps = resp.swaps || [];
+		swaps.sort(function(a, b) {
+			return Number(b.last_update_time) -
+				Number(a.last_update_time);
+		});
+
+		var pending = swaps.filter(function(swap) {
+			return swap.state !== "SUCCESS" &&
+				swap.state !== "FAILED";
+		});
+		var recent = swaps.filter(function(swap) {
+			return pending.indexOf(swap) === -1;
+		}).slice(0, recentLimit);
+
+		fill("pending", swapHeader, pending.map(swapRow));
+		fill("recent", swapHeader, recent.map(swapRow));
+	}).catch(showError);
+
+	get("/v1/auto/status").then(function(resp) {
+		document.getElementById("last-check").textContent =
+			formatUnix(resp.last_check);
+		document.getElementById("last-change").textContent =
+			formatUnix(resp.last_change);
+		document.getElementById("auto-error").textContent =
+			resp.error || "-";
+	}).catch(showError);
+
+	get("/v1/loop/stats?period=MONTH").then(function(resp) {
+		var stats = resp.stats || [];
+		fill("stats", ["Period start", "Swaps", "Succeeded", "Failed",
+			"Pending", "Volume (sat)", "Average fee (sat)"],
+			stats.map(function(s) {
+				return [
+					formatUnix(s.period_start),
+					s.total_swaps, s.successful_swaps,
+					s.failed_swaps, s.pending_swaps,
+					Number(s.volume_sat),
+					Number(s.avg_fee_sat)
+				];
+			}));
+	}).catch(showError);
+}
+
+function showError(err) {
+	document.getElementById("error").textContent = err.message;
+}
+
+if (allowActions) {
+	var button = document.getElementById("trigger");
+	button.hidden = false;
+	button.onclick = function() {
+		fetch("/v1/auto/trigger", {
+			method: "POST",
+			headers: {"Content-Type": "application/json"},
+			body: "{}"
+		}).
+			then(refresh).catch(showError);
+	};
+}
+
+refresh();
+setInterval(refresh, 30000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,85 @@
+package loopd
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestDashboardHandler tests that our dashboard only forwards the routes that
+// it uses, and that it replaces the caller's credentials with its macaroon.
+func TestDashboardHandler(t *testing.T) {
+	mac := []byte{1, 2, 3}
+
+	var forwarded []string
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(
+			t, hex.EncodeToString(mac),
+			r.Header.Get("Grpc-Metadata-Macaroon"),
+		)
+
+		forwarded = append(forwarded, r.URL.Path)
+	})
+
+	serve := func(handler http.Handler, method, path,
+		contentType string) int {
+
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Grpc-Metadata-Macaroon", "admin")
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	handler := newDashboardHandler(rest, mac, false)
+
+	// Our page is served without actions enabled.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "var allowActions = false;")
+
+	// Read routes are forwarded, other routes and methods are not.
+	require.Equal(t, http.StatusOK, serve(
+		handler, http.MethodGet, "/v1/loop/swaps", "",
+	))
+	require.Equal(t, http.StatusOK, serve(
+		handler, http.MethodGet, "/v1/loop/stats?period=MONTH", "",
+	))
+	require.Equal(t, http.StatusMethodNotAllowed, serve(
+		handler, http.MethodPost, "/v1/loop/swaps", "",
+	))
+	require.Equal(t, http.StatusNotFound, serve(
+		handler, http.MethodPost, "/v1/loop/out", "application/json",
+	))
+	require.Equal(t, http.StatusNotFound, serve(
+		handler, http.MethodPost, "/v1/auto/trigger",
+		"application/json",
+	))
+	require.Equal(t, []string{"/v1/loop/swaps", "/v1/loop/stats"},
+		forwarded)
+
+	// With actions enabled, autoloop may be triggered with json requests.
+	forwarded = nil
+	handler = newDashboardHandler(rest, mac, true)
+
+	require.Equal(t, http.StatusUnsupportedMediaType, serve(
+		handler, http.MethodPost, "/v1/auto/trigger",
+		"application/x-www-form-urlencoded",
+	))
+	require.Equal(t, http.StatusOK, serve(
+		handler, http.MethodPost, "/v1/auto/trigger",
+		"application/json",
+	))
+	require.Equal(t, []string{"/v1/auto/trigger"}, forwarded)
+}
@@ -0,0 +1,165 @@
+package loopd
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// swapRequestKey holds the parameters of a swap request that we compare to
+// detect duplicate requests.
+type swapRequestKey struct {
+	swapType swap.Type
+	amount   btcutil.Amount
+
+	// restriction describes the channels or peer that the swap is
+	// restricted to, and is empty if the swap is unrestricted.
+	restriction string
+}
+
+// newLoopOutKey returns the key of a loop out request for the amount and
+// outgoing channel set provided. The order in which channels are specified
+// does not matter.
+func newLoopOutKey(amount btcutil.Amount,
+	outgoingChanSet []uint64) swapRequestKey {
+
+	channels := make([]uint64, len(outgoingChanSet))
+	copy(channels, outgoingChanSet)
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i] < channels[j]
+	})
+
+	return swapRequestKey{
+		swapType:    swap.TypeOut,
+		amount:      amount,
+		restriction: loopdb.ChannelSet(channels).String(),
+	}
+}
+
+// newLoopInKey returns the key of a loop in request for the amount and last
+// hop provided.
+func newLoopInKey(amount btcutil.Amount,
+	lastHop *route.Vertex) swapRequestKey {
+
+	key := swapRequestKey{
+		swapType: swap.TypeIn,
+		amount:   amount,
+	}
+
+	if lastHop != nil {
+		key.restriction = lastHop.String()
+	}
+
+	return key
+}
+
+// recentRequest is a swap request that we recently received.
+type recentRequest struct {
+	// id identifies the request, so that a request only ever releases its
+	// own record.
+	id uint64
+
+	// received is the time at which we received the request.
+	received time.Time
+
+	// idempotencyKey is the idempotency key of the request, if any.
+	idempotencyKey string
+}
+
+// duplicateGuard detects swap requests that are identical to a request that
+// we received shortly before, which are usually the result of a request that
+// was accidentally submitted twice, for example by double clicking in a user
+// interface.
+type duplicateGuard struct {
+	// window is the amount of time after a request that an identical
+	// request is considered to be a duplicate. If it is zero, duplicate
+	// requests are not detected.
+	window time.Duration
+
+	clock clock.Clock
+
+	requests map[swapRequestKey]*recentRequest
+	nextID   uint64
+	mu       sync.Mutex
+}
+
+// newDuplicateGuard creates a guard that rejects swap requests which are
+// received within the window provided of an identical request.
+func newDuplicateGuard(window time.Duration,
+	clock clock.Clock) *duplicateGuard {
+
+	return &duplicateGuard{
+		window:   window,
+		clock:    clock,
+		requests: make(map[swapRequestKey]*recentRequest),
+	}
+}
+
+// check records a swap request, and fails with an AlreadyExists error if an
+// identical request was received within our window, unless the request
+// explicitly allows duplicates. Retries of a request with the same idempotency
+// key are not duplicates, because the existing swap is returned for them. The
+// function returned removes our record of the request, and should be called
+// if the request fails so that it can be retried straight away.
+func (g *duplicateGuard) check(key swapRequestKey, idempotencyKey string,
+	allowDuplicate bool) (func(), error) {
+
+	release := func() {}
+
+	if g == nil || g.window == 0 {
+		return release, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.clock.Now()
+
+	// Remove requests that are outside of our window, so that we only
+	// keep track of recent requests.
+	for requestKey, request := range g.requests {
+		if now.Sub(request.received) >= g.window {
+			delete(g.requests, requestKey)
+		}
+	}
+
+	existing, ok := g.requests[key]
+	isRetry := ok && idempotencyKey != "" &&
+		existing.idempotencyKey == idempotencyKey
+
+	if ok && !isRetry && !allowDuplicate {
+		return nil, status.Errorf(codes.AlreadyExists, "an identical "+
+			"swap request was received %v ago, set "+
+			"allow_duplicate to create another swap",
+			now.Sub(existing.received).Round(time.Second))
+	}
+
+	g.nextID++
+	request := &recentRequest{
+		id:             g.nextID,
+		received:       now,
+		idempotencyKey: idempotencyKey,
+	}
+	g.requests[key] = request
+
+	release = func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		if current, ok := g.requests[key]; ok &&
+			current.id == request.id {
+
+			delete(g.requests, key)
+		}
+	}
+
+	return release, nil
+}
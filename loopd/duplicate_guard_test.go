@@ -0,0 +1,103 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestDuplicateGuard tests detection of duplicate swap requests.
+func TestDuplicateGuard(t *testing.T) {
+	var (
+		now       = time.Unix(1000, 0)
+		window    = time.Minute
+		testClock = clock.NewTestClock(now)
+		guard     = newDuplicateGuard(window, testClock)
+
+		key     = newLoopOutKey(10000, []uint64{2, 1})
+		peer    = route.Vertex{1}
+		peerKey = newLoopInKey(10000, &peer)
+	)
+
+	requireDuplicate := func(err error) {
+		t.Helper()
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+	}
+
+	_, err := guard.check(key, "", false)
+	require.NoError(t, err)
+
+	// An identical request is rejected, regardless of the order that its
+	// channels are specified in.
+	_, err = guard.check(newLoopOutKey(10000, []uint64{1, 2}), "", false)
+	requireDuplicate(err)
+
+	// Requests with a different amount, channel set or swap type are not
+	// duplicates.
+	_, err = guard.check(newLoopOutKey(20000, []uint64{1, 2}), "", false)
+	require.NoError(t, err)
+
+	_, err = guard.check(newLoopOutKey(10000, []uint64{1}), "", false)
+	require.NoError(t, err)
+
+	_, err = guard.check(newLoopInKey(10000, nil), "", false)
+	require.NoError(t, err)
+
+	_, err = guard.check(peerKey, "", false)
+	require.NoError(t, err)
+
+	// A duplicate is allowed if the request explicitly allows it.
+	_, err = guard.check(key, "", true)
+	require.NoError(t, err)
+
+	// Once we are outside of the window, the request is no longer a
+	// duplicate.
+	testClock.SetTime(now.Add(window))
+	_, err = guard.check(key, "", false)
+	require.NoError(t, err)
+
+	// A retry with the same idempotency key is allowed, but a request with
+	// a different key is not.
+	_, err = guard.check(peerKey, "a", true)
+	require.NoError(t, err)
+
+	_, err = guard.check(peerKey, "a", false)
+	require.NoError(t, err)
+
+	_, err = guard.check(peerKey, "b", false)
+	requireDuplicate(err)
+
+	// Releasing a request allows it to be made again straight away, but
+	// a stale release does not remove a more recent request.
+	release, err := guard.check(newLoopInKey(5000, nil), "", false)
+	require.NoError(t, err)
+
+	release()
+	release, err = guard.check(newLoopInKey(5000, nil), "", false)
+	require.NoError(t, err)
+
+	_, err = guard.check(newLoopInKey(5000, nil), "", true)
+	require.NoError(t, err)
+
+	release()
+	_, err = guard.check(newLoopInKey(5000, nil), "", false)
+	requireDuplicate(err)
+}
+
+// TestDuplicateGuardDisabled tests that duplicates are not detected when the
+// window is zero.
+func TestDuplicateGuardDisabled(t *testing.T) {
+	guard := newDuplicateGuard(0, clock.NewTestClock(time.Unix(1000, 0)))
+	key := newLoopOutKey(10000, nil)
+
+	for i := 0; i < 2; i++ {
+		release, err := guard.check(key, "", false)
+		require.NoError(t, err)
+		release()
+	}
+}
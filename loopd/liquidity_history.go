@@ -0,0 +1,74 @@
+package loopd
+
+import (
+	"sort"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+)
+
+// liquidityHistory groups a set of channel balance snapshots by channel. If a
+// set of channel ids is provided, only snapshots of those channels are
+// included. Snapshots are stored in chronological order, so each channel's
+// snapshots are already ordered from oldest to newest.
+func liquidityHistory(snapshots []*loopdb.ChannelSnapshot,
+	channelIDs []uint64) *looprpc.LiquidityHistoryResponse {
+
+	include := make(map[uint64]bool, len(channelIDs))
+	for _, channelID := range channelIDs {
+		include[channelID] = true
+	}
+
+	channels := make(map[uint64]*looprpc.ChannelLiquidityHistory)
+	for _, snapshot := range snapshots {
+		if len(include) != 0 && !include[snapshot.ChannelID] {
+			continue
+		}
+
+		local := int64(snapshot.LocalBalance)
+
+		channel, ok := channels[snapshot.ChannelID]
+		if !ok {
+			channel = &looprpc.ChannelLiquidityHistory{
+				ChannelId:          snapshot.ChannelID,
+				MinLocalBalanceSat: local,
+				MaxLocalBalanceSat: local,
+			}
+			channels[snapshot.ChannelID] = channel
+		}
+
+		// We report the capacity from the most recent snapshot.
+		channel.CapacitySat = int64(snapshot.Capacity)
+
+		if local < channel.MinLocalBalanceSat {
+			channel.MinLocalBalanceSat = local
+		}
+
+		if local > channel.MaxLocalBalanceSat {
+			channel.MaxLocalBalanceSat = local
+		}
+
+		channel.Snapshots = append(
+			channel.Snapshots, &looprpc.LiquiditySnapshot{
+				TimestampNs:      snapshot.Time.UnixNano(),
+				LocalBalanceSat:  local,
+				RemoteBalanceSat: int64(snapshot.RemoteBalance),
+			},
+		)
+	}
+
+	resp := &looprpc.LiquidityHistoryResponse{
+		Channels: make(
+			[]*looprpc.ChannelLiquidityHistory, 0, len(channels),
+		),
+	}
+	for _, channel := range channels {
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	sort.Slice(resp.Channels, func(i, j int) bool {
+		return resp.Channels[i].ChannelId < resp.Channels[j].ChannelId
+	})
+
+	return resp
+}
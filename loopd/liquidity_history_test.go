@@ -0,0 +1,105 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLiquidityHistory tests grouping and filtering of channel balance
+// snapshots.
+func TestLiquidityHistory(t *testing.T) {
+	var (
+		time1 = time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)
+		time2 = time1.Add(time.Hour)
+	)
+
+	snapshots := []*loopdb.ChannelSnapshot{
+		{
+			Time:          time1,
+			ChannelID:     2,
+			Capacity:      1000,
+			LocalBalance:  800,
+			RemoteBalance: 200,
+		},
+		{
+			Time:          time1,
+			ChannelID:     1,
+			Capacity:      500,
+			LocalBalance:  100,
+			RemoteBalance: 400,
+		},
+		{
+			Time:          time2,
+			ChannelID:     2,
+			Capacity:      1000,
+			LocalBalance:  900,
+			RemoteBalance: 100,
+		},
+		{
+			Time:          time2,
+			ChannelID:     1,
+			Capacity:      500,
+			LocalBalance:  50,
+			RemoteBalance: 450,
+		},
+	}
+
+	chan1 := &looprpc.ChannelLiquidityHistory{
+		ChannelId:          1,
+		CapacitySat:        500,
+		MinLocalBalanceSat: 50,
+		MaxLocalBalanceSat: 100,
+		Snapshots: []*looprpc.LiquiditySnapshot{
+			{
+				TimestampNs:      time1.UnixNano(),
+				LocalBalanceSat:  100,
+				RemoteBalanceSat: 400,
+			},
+			{
+				TimestampNs:      time2.UnixNano(),
+				LocalBalanceSat:  50,
+				RemoteBalanceSat: 450,
+			},
+		},
+	}
+
+	chan2 := &looprpc.ChannelLiquidityHistory{
+		ChannelId:          2,
+		CapacitySat:        1000,
+		MinLocalBalanceSat: 800,
+		MaxLocalBalanceSat: 900,
+		Snapshots: []*looprpc.LiquiditySnapshot{
+			{
+				TimestampNs:      time1.UnixNano(),
+				LocalBalanceSat:  800,
+				RemoteBalanceSat: 200,
+			},
+			{
+				TimestampNs:      time2.UnixNano(),
+				LocalBalanceSat:  900,
+				RemoteBalanceSat: 100,
+			},
+		},
+	}
+
+	// With no filter, we expect all channels ordered by channel id.
+	history := liquidityHistory(snapshots, nil)
+	require.Equal(
+		t, []*looprpc.ChannelLiquidityHistory{chan1, chan2},
+		history.Channels,
+	)
+
+	// Filter by a single channel.
+	history = liquidityHistory(snapshots, []uint64{2})
+	require.Equal(
+		t, []*looprpc.ChannelLiquidityHistory{chan2}, history.Channels,
+	)
+
+	// With no snapshots, we expect an empty history.
+	history = liquidityHistory(nil, nil)
+	require.Len(t, history.Channels, 0)
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/liquidity"
 	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/notifications"
+	"github.com/lightninglabs/loop/staticaddr"
+	"github.com/lightninglabs/loop/sweep"
 	"github.com/lightningnetwork/lnd"
 	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/signal"
@@ -30,12 +33,21 @@ func SetupLoggers(root *build.RotatingLogWriter, intercept signal.Interceptor) {
 
 	lnd.SetSubLogger(root, Subsystem, log)
 	lnd.AddSubLogger(root, "LOOP", intercept, loop.UseLogger)
+	lnd.AddSubLogger(root, "SRVR", intercept, loop.UseServerLogger)
+	lnd.AddSubLogger(root, sweep.Subsystem, intercept, sweep.UseLogger)
 	lnd.AddSubLogger(root, "LNDC", intercept, lndclient.UseLogger)
 	lnd.AddSubLogger(root, "STORE", intercept, loopdb.UseLogger)
 	lnd.AddSubLogger(root, lsat.Subsystem, intercept, lsat.UseLogger)
 	lnd.AddSubLogger(
 		root, liquidity.Subsystem, intercept, liquidity.UseLogger,
 	)
+	lnd.AddSubLogger(
+		root, staticaddr.Subsystem, intercept, staticaddr.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, notifications.Subsystem, intercept,
+		notifications.UseLogger,
+	)
 }
 
 // genSubLogger creates a logger for a subsystem. We provide an instance of
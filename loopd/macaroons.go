@@ -2,16 +2,21 @@ package loopd
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 
 	"github.com/coreos/bbolt"
 	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/rpcperms"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
@@ -39,6 +44,13 @@ var (
 			Entity: "loop",
 			Action: "in",
 		}},
+		"/looprpc.SwapClient/NewLoopInPsbt": {{
+			Entity: "swap",
+			Action: "execute",
+		}, {
+			Entity: "loop",
+			Action: "in",
+		}},
 		"/looprpc.SwapClient/Monitor": {{
 			Entity: "swap",
 			Action: "read",
@@ -51,6 +63,38 @@ var (
 			Entity: "swap",
 			Action: "read",
 		}},
+		"/looprpc.SwapClient/AbandonSwap": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/RecoverSweep": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/PruneSwaps": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/ExportSwaps": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/ImportSwaps": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/SaveSwapTemplate": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/ListSwapTemplates": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/DeleteSwapTemplate": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
 		"/looprpc.SwapClient/LoopOutTerms": {{
 			Entity: "terms",
 			Action: "read",
@@ -87,14 +131,105 @@ var (
 			Entity: "suggestions",
 			Action: "read",
 		}},
+		"/looprpc.SwapClient/GetAutoloopStatus": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetBudgetReconciliation": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
 		"/looprpc.SwapClient/GetLiquidityParams": {{
 			Entity: "suggestions",
 			Action: "read",
 		}},
+		"/looprpc.SwapClient/GetLiquidityHistory": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
 		"/looprpc.SwapClient/SetLiquidityParams": {{
 			Entity: "suggestions",
 			Action: "write",
 		}},
+		"/looprpc.SwapClient/TriggerAutoloopEvaluation": {{
+			Entity: "suggestions",
+			Action: "write",
+		}},
+		"/looprpc.SwapClient/CancelLiquidityParams": {{
+			Entity: "suggestions",
+			Action: "write",
+		}},
+		"/looprpc.SwapClient/UpdateLiquidityParams": {{
+			Entity: "suggestions",
+			Action: "write",
+		}},
+		"/looprpc.SwapClient/GetPeerReputations": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetStaleRules": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetSwapStats": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetSwapCosts": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetQuoteHistory": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetAuditLog": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetSupportBundle": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/NewStaticAddress": {{
+			Entity: "swap",
+			Action: "execute",
+		}, {
+			Entity: "loop",
+			Action: "in",
+		}},
+		"/looprpc.SwapClient/ListStaticDeposits": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/CompareRebalance": {{
+			Entity: "suggestions",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetConfig": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/GetApiVersion": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/ServerHealth": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/looprpc.SwapClient/DebugLevel": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/TestNotification": {{
+			Entity: "swap",
+			Action: "execute",
+		}},
+		"/looprpc.SwapClient/BakeMacaroon": {{
+			Entity: "macaroon",
+			Action: "generate",
+		}},
 	}
 
 	// allPermissions is the list of all existing permissions that exist
@@ -125,6 +260,9 @@ var (
 	}, {
 		Entity: "suggestions",
 		Action: "write",
+	}, {
+		Entity: "macaroon",
+		Action: "generate",
 	}}
 
 	// macDbDefaultPw is the default encryption password used to encrypt the
@@ -180,17 +318,12 @@ func (d *Daemon) startMacaroonService() error {
 		// existing permissions (equivalent to the admin.macaroon in
 		// lnd). Custom macaroons can be created through the bakery
 		// RPC. Add our debug permissions if required.
-		allPermissions = append(allPermissions, debugPermissions...)
-		loopMac, err := d.macaroonService.Oven.NewMacaroon(
-			idCtx, bakery.LatestVersion, nil, allPermissions...,
+		loopMacBytes, err := d.bakeMacaroon(
+			idCtx, availablePermissions(),
 		)
 		if err != nil {
 			return err
 		}
-		loopMacBytes, err := loopMac.M().MarshalBinary()
-		if err != nil {
-			return err
-		}
 		err = ioutil.WriteFile(d.cfg.MacaroonPath, loopMacBytes, 0644)
 		if err != nil {
 			if err := os.Remove(d.cfg.MacaroonPath); err != nil {
@@ -204,6 +337,88 @@ func (d *Daemon) startMacaroonService() error {
 	return nil
 }
 
+// availablePermissions returns all the permissions that loopd's macaroons may
+// be baked with, including our debug permissions if they are compiled in.
+func availablePermissions() []bakery.Op {
+	perms := make([]bakery.Op, 0, len(allPermissions)+len(debugPermissions))
+	perms = append(perms, allPermissions...)
+
+	return append(perms, debugPermissions...)
+}
+
+// bakeMacaroon bakes a macaroon with the permissions provided and returns it
+// in its serialized form.
+func (d *Daemon) bakeMacaroon(ctx context.Context,
+	permissions []bakery.Op) ([]byte, error) {
+
+	mac, err := d.macaroonService.Oven.NewMacaroon(
+		ctx, bakery.LatestVersion, nil, permissions...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mac.M().MarshalBinary()
+}
+
+// BakeMacaroon bakes a new macaroon that is restricted to the permissions
+// requested, so that scoped credentials can be handed out.
+func (d *Daemon) BakeMacaroon(ctx context.Context,
+	req *looprpc.BakeMacaroonRequest) (*looprpc.BakeMacaroonResponse,
+	error) {
+
+	log.Infof("Bake macaroon request received")
+
+	permissions, err := validatePermissions(req.Permissions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	idCtx := macaroons.ContextWithRootKeyID(
+		ctx, macaroons.DefaultRootKeyID,
+	)
+	macBytes, err := d.bakeMacaroon(idCtx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.BakeMacaroonResponse{
+		Macaroon: hex.EncodeToString(macBytes),
+	}, nil
+}
+
+// validatePermissions converts a set of rpc permissions to bakery operations,
+// failing if any of them is not a permission that loopd knows about.
+func validatePermissions(
+	permissions []*looprpc.MacaroonPermission) ([]bakery.Op, error) {
+
+	if len(permissions) == 0 {
+		return nil, errors.New("at least one permission required")
+	}
+
+	available := make(map[bakery.Op]bool)
+	for _, op := range availablePermissions() {
+		available[op] = true
+	}
+
+	ops := make([]bakery.Op, 0, len(permissions))
+	for _, perm := range permissions {
+		op := bakery.Op{
+			Entity: perm.Entity,
+			Action: perm.Action,
+		}
+
+		if !available[op] {
+			return nil, fmt.Errorf("unknown permission: %v:%v",
+				perm.Entity, perm.Action)
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
 // stopMacaroonService closes the macaroon database.
 func (d *Daemon) stopMacaroonService() error {
 	return d.macaroonService.Close()
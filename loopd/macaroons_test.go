@@ -0,0 +1,68 @@
+package loopd
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestValidatePermissions tests validation of the permissions that a macaroon
+// is baked with.
+func TestValidatePermissions(t *testing.T) {
+	// At least one permission is required.
+	_, err := validatePermissions(nil)
+	require.Error(t, err)
+
+	// Unknown permissions are rejected.
+	_, err = validatePermissions([]*looprpc.MacaroonPermission{
+		{
+			Entity: "swap",
+			Action: "read",
+		},
+		{
+			Entity: "swap",
+			Action: "delete",
+		},
+	})
+	require.Error(t, err)
+
+	ops, err := validatePermissions([]*looprpc.MacaroonPermission{
+		{
+			Entity: "swap",
+			Action: "read",
+		},
+		{
+			Entity: "suggestions",
+			Action: "write",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []bakery.Op{
+		{
+			Entity: "swap",
+			Action: "read",
+		},
+		{
+			Entity: "suggestions",
+			Action: "write",
+		},
+	}, ops)
+}
+
+// TestRequiredPermissionsAvailable tests that every permission that our rpc
+// methods require can be baked into a macaroon.
+func TestRequiredPermissionsAvailable(t *testing.T) {
+	available := make(map[bakery.Op]bool)
+	for _, op := range availablePermissions() {
+		available[op] = true
+	}
+
+	for method, ops := range RequiredPermissions {
+		for _, op := range ops {
+			require.True(t, available[op], "method: %v, op: %v",
+				method, op)
+		}
+	}
+}
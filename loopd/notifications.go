@@ -0,0 +1,104 @@
+package loopd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/notifications"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// swapEvent returns the notification that should be emitted for a swap
+// update, or nil if the update does not warrant a notification. The swap's
+// previous update is provided if we have one, so that we only notify once for
+// each state that a swap reaches.
+func swapEvent(prev *loop.SwapInfo, update *loop.SwapInfo,
+	now time.Time) *notifications.Event {
+
+	// A loop in stays in the htlc published state while its refund is
+	// pending, so we check for its first refund before we compare states.
+	// Later fee bumps replace the refund, but we do not notify for them.
+	if prev != nil && prev.RefundTx == nil && update.RefundTx != nil {
+		event := newSwapEvent(
+			notifications.EventRefundPublished, update, now,
+		)
+		event.RefundTxid = update.RefundTx.TxHash.String()
+
+		return event
+	}
+
+	if prev != nil && prev.State == update.State {
+		return nil
+	}
+
+	var eventType notifications.EventType
+	switch {
+	// If we have not seen this swap before, it was just initiated. Swaps
+	// that exist when we start up are loaded before we receive updates,
+	// so we do not notify for them again.
+	case prev == nil:
+		eventType = notifications.EventSwapInitiated
+
+	case update.State == loopdb.StatePreimageRevealed &&
+		update.SwapType == swap.TypeOut:
+
+		eventType = notifications.EventPreimageRevealed
+
+	case update.State == loopdb.StateSuccess:
+		eventType = notifications.EventSwapSucceeded
+
+	case update.State.Type() == loopdb.StateTypeFail:
+		eventType = notifications.EventSwapFailed
+
+	default:
+		return nil
+	}
+
+	event := newSwapEvent(eventType, update, now)
+
+	if eventType == notifications.EventSwapFailed {
+		event.FailureReason = update.State.String()
+
+		if update.FailureReason != loopdb.FailureReasonNone {
+			event.FailureReason = fmt.Sprintf("%v (%v)",
+				update.State, update.FailureReason)
+		}
+
+		if update.ServerFailure != loopdb.ServerFailureNone {
+			event.FailureReason = fmt.Sprintf("%v, server: %v",
+				event.FailureReason, update.ServerFailure)
+		}
+
+		// If the swap failed after it could no longer be cancelled,
+		// its funds may have been lost or still need to be recovered,
+		// so we flag the failure for the node operator.
+		outcomes := loop.PendingOutcomes(prev)
+		event.FundsAtRisk = outcomes != nil && !outcomes.Cancellable
+	}
+
+	return event
+}
+
+// newSwapEvent creates an event of the type provided for a swap update.
+func newSwapEvent(eventType notifications.EventType, update *loop.SwapInfo,
+	now time.Time) *notifications.Event {
+
+	swapType := looprpc.SwapType_LOOP_OUT
+	if update.SwapType == swap.TypeIn {
+		swapType = looprpc.SwapType_LOOP_IN
+	}
+
+	event := notifications.NewEvent(eventType, now)
+	event.SwapHash = update.SwapHash.String()
+	event.SwapType = swapType.String()
+	event.State = update.State.String()
+	event.AmountSat = int64(update.AmountRequested)
+	event.ServerFeeSat = int64(update.Cost.Server)
+	event.OnchainFeeSat = int64(update.Cost.Onchain)
+	event.OffchainFeeSat = int64(update.Cost.Offchain)
+
+	return event
+}
@@ -0,0 +1,177 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/notifications"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapEvent tests the notifications that we emit for swap updates.
+func TestSwapEvent(t *testing.T) {
+	var (
+		now  = time.Unix(1000, 0)
+		hash = lntypes.Hash{1, 2, 3}
+	)
+
+	swapInfo := func(swapType swap.Type,
+		state loopdb.SwapState) *loop.SwapInfo {
+
+		return &loop.SwapInfo{
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+				Cost: loopdb.SwapCost{
+					Server:   10,
+					Onchain:  20,
+					Offchain: 30,
+				},
+			},
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 100000,
+			},
+			SwapHash: hash,
+			SwapType: swapType,
+		}
+	}
+
+	event := func(eventType notifications.EventType, swapType string,
+		state loopdb.SwapState) *notifications.Event {
+
+		return &notifications.Event{
+			Type:           eventType,
+			Timestamp:      now.Unix(),
+			SwapHash:       hash.String(),
+			SwapType:       swapType,
+			State:          state.String(),
+			AmountSat:      100000,
+			ServerFeeSat:   10,
+			OnchainFeeSat:  20,
+			OffchainFeeSat: 30,
+		}
+	}
+
+	failed := event(
+		notifications.EventSwapFailed, "LOOP_OUT", loopdb.StateFailTimeout,
+	)
+	failed.FailureReason = loopdb.StateFailTimeout.String()
+
+	lostSwap := event(
+		notifications.EventSwapFailed, "LOOP_OUT",
+		loopdb.StateFailSweepTimeout,
+	)
+	lostSwap.FailureReason = loopdb.StateFailSweepTimeout.String()
+	lostSwap.FundsAtRisk = true
+
+	refundTx := &loopdb.RefundTx{
+		TxHash: chainhash.Hash{4, 5, 6},
+	}
+
+	refunding := swapInfo(swap.TypeIn, loopdb.StateHtlcPublished)
+	refunding.RefundTx = refundTx
+
+	refunded := event(
+		notifications.EventRefundPublished, "LOOP_IN",
+		loopdb.StateHtlcPublished,
+	)
+	refunded.RefundTxid = refundTx.TxHash.String()
+
+	bumped := *refunding
+	bumped.RefundTx = &loopdb.RefundTx{
+		TxHash: chainhash.Hash{7, 8, 9},
+	}
+
+	tests := []struct {
+		name     string
+		prev     *loop.SwapInfo
+		update   *loop.SwapInfo
+		expected *notifications.Event
+	}{
+		{
+			name:   "new swap",
+			update: swapInfo(swap.TypeIn, loopdb.StateInitiated),
+			expected: event(
+				notifications.EventSwapInitiated, "LOOP_IN",
+				loopdb.StateInitiated,
+			),
+		},
+		{
+			name:     "state unchanged",
+			prev:     swapInfo(swap.TypeOut, loopdb.StateInitiated),
+			update:   swapInfo(swap.TypeOut, loopdb.StateInitiated),
+			expected: nil,
+		},
+		{
+			name:     "pending state",
+			prev:     swapInfo(swap.TypeIn, loopdb.StateInitiated),
+			update:   swapInfo(swap.TypeIn, loopdb.StateHtlcPublished),
+			expected: nil,
+		},
+		{
+			name: "loop out preimage revealed",
+			prev: swapInfo(swap.TypeOut, loopdb.StateInitiated),
+			update: swapInfo(
+				swap.TypeOut, loopdb.StatePreimageRevealed,
+			),
+			expected: event(
+				notifications.EventPreimageRevealed, "LOOP_OUT",
+				loopdb.StatePreimageRevealed,
+			),
+		},
+		{
+			name: "success",
+			prev: swapInfo(
+				swap.TypeOut, loopdb.StatePreimageRevealed,
+			),
+			update: swapInfo(swap.TypeOut, loopdb.StateSuccess),
+			expected: event(
+				notifications.EventSwapSucceeded, "LOOP_OUT",
+				loopdb.StateSuccess,
+			),
+		},
+		{
+			name:     "failure",
+			prev:     swapInfo(swap.TypeOut, loopdb.StateInitiated),
+			update:   swapInfo(swap.TypeOut, loopdb.StateFailTimeout),
+			expected: failed,
+		},
+		{
+			name: "failure with funds at risk",
+			prev: swapInfo(
+				swap.TypeOut, loopdb.StatePreimageRevealed,
+			),
+			update: swapInfo(
+				swap.TypeOut, loopdb.StateFailSweepTimeout,
+			),
+			expected: lostSwap,
+		},
+		{
+			name: "refund published",
+			prev: swapInfo(
+				swap.TypeIn, loopdb.StateHtlcPublished,
+			),
+			update:   refunding,
+			expected: refunded,
+		},
+		{
+			name:     "refund fee bumped",
+			prev:     refunding,
+			update:   &bumped,
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := swapEvent(testCase.prev, testCase.update, now)
+			require.Equal(t, testCase.expected, actual)
+		})
+	}
+}
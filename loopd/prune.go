@@ -0,0 +1,29 @@
+package loopd
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultPruneRetention is the default amount of time that we keep
+	// swaps for after they reach a final state when pruning.
+	defaultPruneRetention = time.Hour * 24 * 90
+
+	// minPruneRetention is the minimum amount of time that we keep swaps
+	// for after they reach a final state. Pruned swaps can no longer be
+	// recovered, so we do not allow swaps that may still need to be
+	// recovered to be pruned.
+	minPruneRetention = time.Hour * 24 * 7
+)
+
+// validatePruneRetention checks that a prune retention period is not below our
+// minimum.
+func validatePruneRetention(retention time.Duration) error {
+	if retention < minPruneRetention {
+		return fmt.Errorf("prune retention: %v must be at least %v",
+			retention, minPruneRetention)
+	}
+
+	return nil
+}
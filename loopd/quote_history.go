@@ -0,0 +1,49 @@
+package loopd
+
+import (
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// quoteHistory reports the quotes that were received within a time range. A
+// zero start or end time leaves that side of the range open. Quotes are
+// stored in the order that they were received, so no sorting is required.
+func quoteHistory(quotes []*loopdb.Quote, startTimeNs, endTimeNs int64,
+	swapTypes []looprpc.SwapType) *looprpc.QuoteHistoryResponse {
+
+	resp := &looprpc.QuoteHistoryResponse{}
+
+	for _, quote := range quotes {
+		swapType := swap.TypeOut
+		rpcType := looprpc.SwapType_LOOP_OUT
+		if quote.Type == loopdb.QuoteTypeLoopIn {
+			swapType = swap.TypeIn
+			rpcType = looprpc.SwapType_LOOP_IN
+		}
+
+		if !includeSwapType(swapType, swapTypes) {
+			continue
+		}
+
+		timestamp := quote.Time.UnixNano()
+		if startTimeNs != 0 && timestamp < startTimeNs {
+			continue
+		}
+
+		if endTimeNs != 0 && timestamp >= endTimeNs {
+			continue
+		}
+
+		resp.Quotes = append(resp.Quotes, &looprpc.QuoteRecord{
+			TimestampNs:  timestamp,
+			Type:         rpcType,
+			Amt:          int64(quote.Amount),
+			SwapFeeSat:   int64(quote.SwapFee),
+			PrepayAmtSat: int64(quote.PrepayAmount),
+			MinerFeeSat:  int64(quote.MinerFee),
+		})
+	}
+
+	return resp
+}
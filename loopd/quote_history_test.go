@@ -0,0 +1,88 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQuoteHistory tests filtering of our quote history.
+func TestQuoteHistory(t *testing.T) {
+	var (
+		day1 = time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)
+		day2 = day1.AddDate(0, 0, 1)
+		day3 = day2.AddDate(0, 0, 1)
+	)
+
+	quotes := []*loopdb.Quote{
+		{
+			Time:         day1,
+			Type:         loopdb.QuoteTypeLoopOut,
+			Amount:       100000,
+			SwapFee:      100,
+			PrepayAmount: 10,
+			MinerFee:     50,
+		},
+		{
+			Time:     day2,
+			Type:     loopdb.QuoteTypeLoopIn,
+			Amount:   200000,
+			SwapFee:  150,
+			MinerFee: 60,
+		},
+		{
+			Time:         day3,
+			Type:         loopdb.QuoteTypeLoopOut,
+			Amount:       300000,
+			SwapFee:      200,
+			PrepayAmount: 20,
+			MinerFee:     70,
+		},
+	}
+
+	// With no filters, we expect all quotes to be returned in order.
+	history := quoteHistory(quotes, 0, 0, nil)
+	require.Equal(t, []*looprpc.QuoteRecord{
+		{
+			TimestampNs:  day1.UnixNano(),
+			Type:         looprpc.SwapType_LOOP_OUT,
+			Amt:          100000,
+			SwapFeeSat:   100,
+			PrepayAmtSat: 10,
+			MinerFeeSat:  50,
+		},
+		{
+			TimestampNs: day2.UnixNano(),
+			Type:        looprpc.SwapType_LOOP_IN,
+			Amt:         200000,
+			SwapFeeSat:  150,
+			MinerFeeSat: 60,
+		},
+		{
+			TimestampNs:  day3.UnixNano(),
+			Type:         looprpc.SwapType_LOOP_OUT,
+			Amt:          300000,
+			SwapFeeSat:   200,
+			PrepayAmtSat: 20,
+			MinerFeeSat:  70,
+		},
+	}, history.Quotes)
+
+	// Filter on our time range, the end time is exclusive.
+	history = quoteHistory(
+		quotes, day2.UnixNano(), day3.UnixNano(), nil,
+	)
+	require.Len(t, history.Quotes, 1)
+	require.Equal(t, int64(200000), history.Quotes[0].Amt)
+
+	// Filter on swap type.
+	history = quoteHistory(
+		quotes, 0, 0, []looprpc.SwapType{looprpc.SwapType_LOOP_OUT},
+	)
+	require.Len(t, history.Quotes, 2)
+	require.Equal(t, int64(100000), history.Quotes[0].Amt)
+	require.Equal(t, int64(300000), history.Quotes[1].Amt)
+}
@@ -0,0 +1,121 @@
+package loopd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"google.golang.org/grpc"
+)
+
+var (
+	// errNoRebalanceRoute is returned when lnd cannot find a route for a
+	// circular rebalance.
+	errNoRebalanceRoute = errors.New("no route found for rebalance")
+)
+
+// rebalanceEstimator estimates the off-chain fees of circular rebalances
+// using lnd's QueryRoutes, which is not exposed by lndclient. It holds its
+// own connection to lnd for this purpose.
+type rebalanceEstimator struct {
+	conn   *grpc.ClientConn
+	client lnrpc.LightningClient
+	lnd    *lndclient.LndServices
+}
+
+// newRebalanceEstimator connects to the lnd instance described by the config
+// provided and returns an estimator that uses this connection.
+func newRebalanceEstimator(cfg *lndConfig, network string,
+	lnd *lndclient.LndServices) (*rebalanceEstimator, error) {
+
+	if cfg.Host == "" {
+		return nil, errors.New("no lnd host configured")
+	}
+
+	conn, err := lndclient.NewBasicConn(
+		cfg.Host, cfg.TLSPath, filepath.Dir(cfg.MacaroonPath), network,
+		lndclient.MacFilename(filepath.Base(cfg.MacaroonPath)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rebalanceEstimator{
+		conn:   conn,
+		client: lnrpc.NewLightningClient(conn),
+		lnd:    lnd,
+	}, nil
+}
+
+// estimate returns the off-chain fees that lnd expects to pay for a circular
+// rebalance that has the same effect on a channel as a swap of the type and
+// amount provided. A loop out moves balance out of the channel, so our
+// rebalance must leave over it. A loop in moves balance into the channel, so
+// our rebalance must arrive through the channel's peer.
+func (r *rebalanceEstimator) estimate(ctx context.Context,
+	channel lnwire.ShortChannelID, amount btcutil.Amount,
+	swapType swap.Type) (btcutil.Amount, error) {
+
+	req := &lnrpc.QueryRoutesRequest{
+		PubKey:            hex.EncodeToString(r.lnd.NodePubkey[:]),
+		Amt:               int64(amount),
+		UseMissionControl: true,
+	}
+
+	switch swapType {
+	case swap.TypeOut:
+		req.OutgoingChanId = channel.ToUint64()
+
+	case swap.TypeIn:
+		peer, err := r.channelPeer(ctx, channel)
+		if err != nil {
+			return 0, err
+		}
+
+		req.LastHopPubkey = peer
+
+	default:
+		return 0, fmt.Errorf("unknown swap type: %v", swapType)
+	}
+
+	resp, err := r.client.QueryRoutes(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(resp.Routes) == 0 {
+		return 0, errNoRebalanceRoute
+	}
+
+	return btcutil.Amount(resp.Routes[0].TotalFeesMsat / 1000), nil
+}
+
+// channelPeer returns the pubkey of the peer that we have a channel with.
+func (r *rebalanceEstimator) channelPeer(ctx context.Context,
+	channel lnwire.ShortChannelID) ([]byte, error) {
+
+	channels, err := r.lnd.Client.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range channels {
+		if c.ChannelID == channel.ToUint64() {
+			return c.PubKeyBytes[:], nil
+		}
+	}
+
+	return nil, fmt.Errorf("channel %v not found", channel)
+}
+
+// close closes the estimator's connection to lnd.
+func (r *rebalanceEstimator) close() error {
+	return r.conn.Close()
+}
@@ -0,0 +1,286 @@
+package loopd
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rpcServicePrefix is the prefix of the full method names of our rpcs, which
+// may be omitted when rate limits are configured.
+const rpcServicePrefix = "/looprpc.SwapClient/"
+
+// recoveryUnaryInterceptor returns a unary interceptor that recovers from
+// panics in rpc handlers, so that a bug in a single call does not take down
+// the daemon.
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		resp interface{}, err error) {
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor returns a stream interceptor that recovers from
+// panics in streaming rpc handlers.
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) (
+		err error) {
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+// recoverPanic logs a panic that occurred in an rpc handler and returns the
+// error that is sent to the client in its place.
+func recoverPanic(method string, r interface{}) error {
+	log.Errorf("Recovered from panic in %v: %v\n%s", method, r,
+		debug.Stack())
+
+	return status.Errorf(codes.Internal, "internal error in %v", method)
+}
+
+// loggingUnaryInterceptor returns a unary interceptor that logs every call
+// with its result and latency. Requests and responses are logged at trace
+// level.
+func loggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+
+		log.Tracef("rpc request: method=%v request=%v",
+			info.FullMethod, req)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(info.FullMethod, start, err)
+
+		if err == nil {
+			log.Tracef("rpc response: method=%v response=%v",
+				info.FullMethod, resp)
+		}
+
+		return resp, err
+	}
+}
+
+// loggingStreamInterceptor returns a stream interceptor that logs every
+// stream with its result and duration.
+func loggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// logCall logs the result of an rpc call.
+func logCall(method string, start time.Time, err error) {
+	code := status.Code(err)
+	latency := time.Since(start)
+
+	if err != nil {
+		log.Infof("rpc call: method=%v code=%v latency=%v error=%v",
+			method, code, latency, err)
+
+		return
+	}
+
+	log.Infof("rpc call: method=%v code=%v latency=%v", method, code,
+		latency)
+}
+
+// rateLimit limits the number of calls to an rpc to a number of calls per
+// interval.
+type rateLimit struct {
+	// calls is the number of calls that are allowed per interval. Up to
+	// this number of calls may be made at once.
+	calls int
+
+	// interval is the interval in which calls are allowed.
+	interval time.Duration
+}
+
+// parseRateLimits parses rate limits of the form method=calls/interval, for
+// example LoopOut=10/1m. Methods may be set with or without our service
+// prefix, and must be one of our rpcs.
+func parseRateLimits(limits []string) (map[string]rateLimit, error) {
+	parsed := make(map[string]rateLimit, len(limits))
+
+	for _, limit := range limits {
+		parts := strings.Split(limit, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("rate limit %v must be of the "+
+				"form method=calls/interval", limit)
+		}
+
+		method := parts[0]
+		if !strings.HasPrefix(method, rpcServicePrefix) {
+			method = rpcServicePrefix + method
+		}
+
+		if _, ok := RequiredPermissions[method]; !ok {
+			return nil, fmt.Errorf("rate limit for unknown rpc: %v",
+				parts[0])
+		}
+
+		if _, ok := parsed[method]; ok {
+			return nil, fmt.Errorf("duplicate rate limit for: %v",
+				parts[0])
+		}
+
+		rate := strings.Split(parts[1], "/")
+		if len(rate) != 2 {
+			return nil, fmt.Errorf("rate limit %v must be of the "+
+				"form method=calls/interval", limit)
+		}
+
+		calls, err := strconv.Atoi(rate[0])
+		if err != nil || calls <= 0 {
+			return nil, fmt.Errorf("rate limit %v must allow a "+
+				"positive number of calls", limit)
+		}
+
+		interval, err := time.ParseDuration(rate[1])
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("rate limit %v must have a "+
+				"positive interval", limit)
+		}
+
+		parsed[method] = rateLimit{
+			calls:    calls,
+			interval: interval,
+		}
+	}
+
+	return parsed, nil
+}
+
+// tokenBucket tracks the calls that are currently allowed for a rate limited
+// rpc. Tokens are replenished continuously, at a rate of the limit's calls
+// per interval.
+type tokenBucket struct {
+	limit      rateLimit
+	tokens     float64
+	lastRefill time.Time
+}
+
+// rateLimiter enforces per-method rate limits on rpc calls.
+type rateLimiter struct {
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// newRateLimiter creates a rate limiter for the limits provided. Every
+// method starts with its full number of calls available.
+func newRateLimiter(limits map[string]rateLimit,
+	now func() time.Time) *rateLimiter {
+
+	buckets := make(map[string]*tokenBucket, len(limits))
+	for method, limit := range limits {
+		buckets[method] = &tokenBucket{
+			limit:      limit,
+			tokens:     float64(limit.calls),
+			lastRefill: now(),
+		}
+	}
+
+	return &rateLimiter{
+		buckets: buckets,
+		now:     now,
+	}
+}
+
+// allow returns a boolean indicating whether a call to the method provided is
+// allowed, consuming a token if it is.
+func (r *rateLimiter) allow(method string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket, ok := r.buckets[method]
+	if !ok {
+		return true
+	}
+
+	now := r.now()
+	elapsed := now.Sub(bucket.lastRefill)
+	bucket.lastRefill = now
+
+	bucket.tokens += float64(bucket.limit.calls) *
+		float64(elapsed) / float64(bucket.limit.interval)
+
+	if capacity := float64(bucket.limit.calls); bucket.tokens > capacity {
+		bucket.tokens = capacity
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+// unaryInterceptor returns a unary interceptor that rejects calls that
+// exceed their rate limit.
+func (r *rateLimiter) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+
+		if !r.allow(info.FullMethod) {
+			return nil, errRateLimited(info.FullMethod)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamInterceptor returns a stream interceptor that rejects streams that
+// exceed their rate limit.
+func (r *rateLimiter) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if !r.allow(info.FullMethod) {
+			return errRateLimited(info.FullMethod)
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// errRateLimited returns the error that is sent to clients that exceed a rate
+// limit.
+func errRateLimited(method string) error {
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded "+
+		"for %v", method)
+}
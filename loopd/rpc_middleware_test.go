@@ -0,0 +1,146 @@
+package loopd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestParseRateLimits tests parsing of our rate limit config.
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   []string
+		expected map[string]rateLimit
+		err      bool
+	}{
+		{
+			name:     "no limits",
+			expected: map[string]rateLimit{},
+		},
+		{
+			name: "short and full method names",
+			limits: []string{
+				"LoopOut=10/1m",
+				"/looprpc.SwapClient/ListSwaps=2/1s",
+			},
+			expected: map[string]rateLimit{
+				"/looprpc.SwapClient/LoopOut": {
+					calls:    10,
+					interval: time.Minute,
+				},
+				"/looprpc.SwapClient/ListSwaps": {
+					calls:    2,
+					interval: time.Second,
+				},
+			},
+		},
+		{
+			name:   "unknown rpc",
+			limits: []string{"Unknown=10/1m"},
+			err:    true,
+		},
+		{
+			name:   "duplicate rpc",
+			limits: []string{"LoopOut=10/1m", "LoopOut=1/1s"},
+			err:    true,
+		},
+		{
+			name:   "missing interval",
+			limits: []string{"LoopOut=10"},
+			err:    true,
+		},
+		{
+			name:   "zero calls",
+			limits: []string{"LoopOut=0/1m"},
+			err:    true,
+		},
+		{
+			name:   "negative interval",
+			limits: []string{"LoopOut=1/-1m"},
+			err:    true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			limits, err := parseRateLimits(testCase.limits)
+			if testCase.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, limits)
+		})
+	}
+}
+
+// TestRateLimiter tests that calls are limited per method, and that calls are
+// allowed again as time passes.
+func TestRateLimiter(t *testing.T) {
+	const method = "/looprpc.SwapClient/LoopOut"
+
+	now := time.Unix(1000, 0)
+	limiter := newRateLimiter(
+		map[string]rateLimit{
+			method: {
+				calls:    2,
+				interval: time.Minute,
+			},
+		}, func() time.Time {
+			return now
+		},
+	)
+
+	// We can burst up to our limit, and are then limited.
+	require.True(t, limiter.allow(method))
+	require.True(t, limiter.allow(method))
+	require.False(t, limiter.allow(method))
+
+	// Methods without a limit are always allowed.
+	require.True(t, limiter.allow("/looprpc.SwapClient/ListSwaps"))
+
+	// After half our interval, we have replenished one call.
+	now = now.Add(time.Second * 30)
+	require.True(t, limiter.allow(method))
+	require.False(t, limiter.allow(method))
+
+	// Our calls do not accumulate beyond our limit.
+	now = now.Add(time.Hour)
+	require.True(t, limiter.allow(method))
+	require.True(t, limiter.allow(method))
+	require.False(t, limiter.allow(method))
+
+	// Limited calls fail with a resource exhausted error.
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	_, err := limiter.unaryInterceptor()(
+		context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: method}, handler,
+	)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// TestRecoveryInterceptor tests that panics in rpc handlers are returned as
+// errors.
+func TestRecoveryInterceptor(t *testing.T) {
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		panic("handler bug")
+	}
+
+	_, err := recoveryUnaryInterceptor()(
+		context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/test"}, handler,
+	)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
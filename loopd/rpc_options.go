@@ -0,0 +1,85 @@
+package loopd
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// serverOptions returns the gRPC server options that apply our rpc config.
+// Every call is protected against panics, and our optional logging, rate
+// limiting and default timeout interceptors are chained in that order.
+func (c *rpcConfig) serverOptions() ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(c.MaxMsgSize),
+		grpc.MaxSendMsgSize(c.MaxMsgSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    c.KeepaliveTime,
+			Timeout: c.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.KeepaliveMinClientTime,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	var (
+		unary = []grpc.UnaryServerInterceptor{
+			recoveryUnaryInterceptor(),
+		}
+		stream = []grpc.StreamServerInterceptor{
+			recoveryStreamInterceptor(),
+		}
+	)
+
+	if c.LogCalls {
+		unary = append(unary, loggingUnaryInterceptor())
+		stream = append(stream, loggingStreamInterceptor())
+	}
+
+	limits, err := parseRateLimits(c.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(limits) > 0 {
+		limiter := newRateLimiter(limits, time.Now)
+		unary = append(unary, limiter.unaryInterceptor())
+		stream = append(stream, limiter.streamInterceptor())
+	}
+
+	if c.DefaultTimeout > 0 {
+		unary = append(
+			unary, defaultTimeoutInterceptor(c.DefaultTimeout),
+		)
+	}
+
+	opts = append(
+		opts, grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	return opts, nil
+}
+
+// defaultTimeoutInterceptor returns a unary interceptor that applies a
+// deadline to calls whose context does not have one yet.
+func defaultTimeoutInterceptor(
+	timeout time.Duration) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{},
+		_ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+		error) {
+
+		if _, ok := ctx.Deadline(); ok {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,52 @@
+package loopd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestDefaultTimeoutInterceptor tests that our default deadline is only
+// applied to calls that do not have a deadline set.
+func TestDefaultTimeoutInterceptor(t *testing.T) {
+	const timeout = time.Hour
+
+	interceptor := defaultTimeoutInterceptor(timeout)
+
+	// deadlineHandler returns the deadline of the context it is called
+	// with.
+	deadlineHandler := func(ctx context.Context, _ interface{}) (
+		interface{}, error) {
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+
+		return deadline, nil
+	}
+
+	// A call without a deadline should have our default applied.
+	start := time.Now()
+	resp, err := interceptor(
+		context.Background(), nil, &grpc.UnaryServerInfo{},
+		deadlineHandler,
+	)
+	require.NoError(t, err)
+
+	deadline := resp.(time.Time)
+	require.False(t, deadline.Before(start.Add(timeout)))
+
+	// A call that has its own deadline should not be changed.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	expected, _ := ctx.Deadline()
+
+	resp, err = interceptor(
+		ctx, nil, &grpc.UnaryServerInfo{}, deadlineHandler,
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, resp.(time.Time))
+}
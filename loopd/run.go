@@ -79,6 +79,20 @@ func newListenerCfg(config *Config, rpcCfg RPCConfig) *listenerCfg {
 
 			return tls.NewListener(listener, tlsCfg), nil
 		},
+		uiListener: func(tlsCfg *tls.Config) (net.Listener, error) {
+			// The dashboard is disabled if no address is set, or if
+			// we are using a custom RPC listener.
+			if config.UIListen == "" || rpcCfg.RPCListener != nil {
+				return nil, nil
+			}
+
+			listener, err := net.Listen("tcp", config.UIListen)
+			if err != nil {
+				return nil, err
+			}
+
+			return tls.NewListener(listener, tlsCfg), nil
+		},
 		getLnd: func(network lndclient.Network, cfg *lndConfig) (
 			*lndclient.GrpcLndServices, error) {
 
@@ -0,0 +1,91 @@
+package loopd
+
+import (
+	"context"
+	"sync"
+)
+
+// shutdownStage is a group of goroutines that are stopped together.
+type shutdownStage struct {
+	name string
+
+	// beforeStop is called before the goroutines of the stage are
+	// stopped, if it is non-nil.
+	beforeStop func()
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// goroutine runs the function provided in a goroutine that belongs to the
+// stage. The function must exit once the context it is passed is cancelled.
+func (s *shutdownStage) goroutine(f func(ctx context.Context)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		f(s.ctx)
+	}()
+}
+
+// stop stops all the goroutines of the stage and waits for them to exit.
+func (s *shutdownStage) stop() {
+	if s.beforeStop != nil {
+		s.beforeStop()
+	}
+
+	log.Infof("Stopping %v", s.name)
+	s.cancel()
+	s.wg.Wait()
+	log.Infof("Stopped %v", s.name)
+}
+
+// shutdownCoordinator stops the goroutines of the daemon in dependency order.
+// Goroutines are grouped into stages, and each stage has its own context so
+// that it keeps running until the stages that depend on it have exited. Stages
+// are stopped in the reverse of the order that they were added in, so a stage
+// may only depend on the stages that were added before it.
+type shutdownCoordinator struct {
+	ctx    context.Context
+	stages []*shutdownStage
+
+	stopOnce sync.Once
+}
+
+// newShutdownCoordinator creates a shutdown coordinator for stages that run
+// until the parent context provided is cancelled, or until they are stopped.
+func newShutdownCoordinator(ctx context.Context) *shutdownCoordinator {
+	return &shutdownCoordinator{
+		ctx: ctx,
+	}
+}
+
+// addStage adds a stage that is stopped before all of the stages that have
+// already been added. If beforeStop is non-nil, it is called before the
+// stage's goroutines are stopped.
+func (c *shutdownCoordinator) addStage(name string,
+	beforeStop func()) *shutdownStage {
+
+	ctx, cancel := context.WithCancel(c.ctx)
+
+	stage := &shutdownStage{
+		name:       name,
+		beforeStop: beforeStop,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	c.stages = append(c.stages, stage)
+
+	return stage
+}
+
+// stop stops our stages in dependency order, waiting for the goroutines of
+// each stage to exit before stopping the next stage. It is safe to call more
+// than once.
+func (c *shutdownCoordinator) stop() {
+	c.stopOnce.Do(func() {
+		for i := len(c.stages) - 1; i >= 0; i-- {
+			c.stages[i].stop()
+		}
+	})
+}
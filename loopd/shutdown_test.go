@@ -0,0 +1,86 @@
+package loopd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShutdownCoordinator tests that stages are stopped in the reverse of the
+// order that they were added in, and that each stage keeps running until the
+// stages that depend on it have exited.
+func TestShutdownCoordinator(t *testing.T) {
+	var (
+		events []string
+		mu     sync.Mutex
+	)
+
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		events = append(events, event)
+	}
+
+	coordinator := newShutdownCoordinator(context.Background())
+
+	addStage := func(name string) *shutdownStage {
+		return coordinator.addStage(name, func() {
+			record("before " + name)
+		})
+	}
+
+	updates := addStage("updates")
+	swaps := addStage("swaps")
+	autoloop := addStage("autoloop")
+
+	for _, stage := range []*shutdownStage{updates, swaps, autoloop} {
+		stage := stage
+
+		stage.goroutine(func(ctx context.Context) {
+			<-ctx.Done()
+			record("exit " + stage.name)
+		})
+	}
+
+	coordinator.stop()
+
+	require.Equal(t, []string{
+		"before autoloop", "exit autoloop",
+		"before swaps", "exit swaps",
+		"before updates", "exit updates",
+	}, events)
+
+	// Stopping again should have no effect.
+	coordinator.stop()
+	require.Len(t, events, 6)
+}
+
+// TestShutdownCoordinatorParent tests that stages are stopped when the parent
+// context of the coordinator is cancelled.
+func TestShutdownCoordinatorParent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	coordinator := newShutdownCoordinator(ctx)
+	stage := coordinator.addStage("swaps", nil)
+
+	exited := make(chan struct{})
+	stage.goroutine(func(ctx context.Context) {
+		<-ctx.Done()
+		close(exited)
+	})
+
+	cancel()
+
+	select {
+	case <-exited:
+	case <-time.After(test.Timeout):
+		t.Fatalf("stage not stopped")
+	}
+
+	coordinator.stop()
+}
@@ -0,0 +1,307 @@
+package loopd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// defaultSupportLogBytes is the number of bytes from the end of our
+	// log file that we include in a support bundle by default.
+	defaultSupportLogBytes = 1024 * 1024
+
+	// maxSupportLogBytes is the maximum number of bytes of our log file
+	// that we include in a support bundle. This keeps the bundle within
+	// the default grpc message size once it is compressed.
+	maxSupportLogBytes = 8 * 1024 * 1024
+
+	// dbFileName is the name of loopd's database file in its data
+	// directory.
+	dbFileName = "loop.db"
+
+	// redacted is the string that sensitive values are replaced with.
+	redacted = "[redacted]"
+)
+
+var (
+	// redactPatterns matches values in our logs that should not be shared
+	// in a support bundle: lightning invoices, on chain addresses and ip
+	// addresses.
+	redactPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\bln(bc|tb|bcrt|sb)[0-9a-z]{20,}\b`),
+		regexp.MustCompile(`\b(bc|tb|bcrt|sb)1[02-9ac-hj-np-z]{20,}\b`),
+		regexp.MustCompile(`\b[13mn2][a-km-zA-HJ-NP-Z1-9]{25,34}\b`),
+		regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`),
+	}
+)
+
+// redact replaces sensitive values in the text provided.
+func redact(text []byte) []byte {
+	for _, pattern := range redactPatterns {
+		text = pattern.ReplaceAll(text, []byte(redacted))
+	}
+
+	return text
+}
+
+// supportStatus describes the state of loopd and its lnd node.
+type supportStatus struct {
+	Version     string    `json:"version"`
+	Network     string    `json:"network"`
+	LndVersion  string    `json:"lnd_version"`
+	BlockHeight uint32    `json:"block_height"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// supportSwap is a summary of a pending swap that does not include its
+// invoices or addresses.
+type supportSwap struct {
+	Hash           string    `json:"hash"`
+	Type           string    `json:"type"`
+	State          string    `json:"state"`
+	AmountSat      int64     `json:"amount_sat"`
+	CltvExpiry     int32     `json:"cltv_expiry"`
+	InitiationTime time.Time `json:"initiation_time"`
+	LastUpdate     time.Time `json:"last_update"`
+}
+
+// supportDBStats describes the contents of our database.
+type supportDBStats struct {
+	SizeBytes    int64          `json:"size_bytes"`
+	LoopOutSwaps int            `json:"loop_out_swaps"`
+	LoopInSwaps  int            `json:"loop_in_swaps"`
+	SwapStates   map[string]int `json:"swap_states"`
+}
+
+// bundleFile is a file that is included in a support bundle.
+type bundleFile struct {
+	name    string
+	content []byte
+}
+
+// supportBundle creates a gzipped tar archive with the information that we
+// need to debug issues with loopd, limiting the log file that it contains to
+// the number of bytes provided.
+func (s *swapClientServer) supportBundle(ctx context.Context,
+	logBytes int64) ([]byte, error) {
+
+	status, err := s.supportStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := protojson.MarshalOptions{
+		Multiline:       true,
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(marshallConfig(s.config))
+	if err != nil {
+		return nil, err
+	}
+
+	swaps, err := json.MarshalIndent(s.pendingSwapSummaries(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	dbStats, err := s.supportDBStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := readLogTail(
+		filepath.Join(s.config.LogDir, defaultLogFilename), logBytes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeBundle([]bundleFile{
+		{name: "status.json", content: status},
+		{name: "config.json", content: redact(config)},
+		{name: "pending_swaps.json", content: swaps},
+		{name: "db.json", content: dbStats},
+		{name: defaultLogFilename, content: redact(logs)},
+	})
+}
+
+// supportStatus returns a json encoded summary of the state of loopd.
+func (s *swapClientServer) supportStatus(ctx context.Context) ([]byte,
+	error) {
+
+	info, err := s.lnd.Client.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &supportStatus{
+		Version:     loop.Version(),
+		Network:     s.config.Network,
+		BlockHeight: info.BlockHeight,
+		CreatedAt:   time.Now(),
+	}
+
+	if s.lnd.Version != nil {
+		status.LndVersion = s.lnd.Version.Version
+	}
+
+	return json.MarshalIndent(status, "", "  ")
+}
+
+// pendingSwapSummaries returns summaries of the swaps that are currently
+// pending, sorted by initiation time.
+func (s *swapClientServer) pendingSwapSummaries() []*supportSwap {
+	s.swapsLock.Lock()
+	defer s.swapsLock.Unlock()
+
+	// We use an empty slice rather than nil so that we encode an empty
+	// list when there are no pending swaps.
+	swaps := make([]*supportSwap, 0)
+	for hash, swp := range s.swaps {
+		if swp.State.Type() != loopdb.StateTypePending {
+			continue
+		}
+
+		swaps = append(swaps, &supportSwap{
+			Hash:           hash.String(),
+			Type:           swp.SwapType.String(),
+			State:          swp.State.String(),
+			AmountSat:      int64(swp.AmountRequested),
+			CltvExpiry:     swp.CltvExpiry,
+			InitiationTime: swp.InitiationTime,
+			LastUpdate:     swp.LastUpdate,
+		})
+	}
+
+	sort.Slice(swaps, func(i, j int) bool {
+		return swaps[i].InitiationTime.Before(swaps[j].InitiationTime)
+	})
+
+	return swaps
+}
+
+// supportDBStats returns json encoded statistics about our database.
+func (s *swapClientServer) supportDBStats(ctx context.Context) ([]byte,
+	error) {
+
+	stats := &supportDBStats{
+		SwapStates: make(map[string]int),
+	}
+
+	info, err := os.Stat(filepath.Join(s.config.DataDir, dbFileName))
+	if err != nil {
+		return nil, err
+	}
+	stats.SizeBytes = info.Size()
+
+	swaps, err := s.impl.FetchSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, swp := range swaps {
+		if swp.SwapType == swap.TypeOut {
+			stats.LoopOutSwaps++
+		} else {
+			stats.LoopInSwaps++
+		}
+
+		stats.SwapStates[swp.State.String()]++
+	}
+
+	return json.MarshalIndent(stats, "", "  ")
+}
+
+// readLogTail reads up to the number of bytes provided from the end of the
+// log file at the path provided, starting from the first complete line. If the
+// file does not exist, no logs are returned.
+func readLogTail(path string, maxBytes int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := info.Size() - maxBytes
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	logs, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we started part way through the file, drop the partial line that
+	// we started in.
+	if offset > 0 {
+		if i := bytes.IndexByte(logs, '\n'); i >= 0 {
+			logs = logs[i+1:]
+		}
+	}
+
+	return logs, nil
+}
+
+// writeBundle writes the files provided to a gzipped tar archive.
+func writeBundle(files []bundleFile) ([]byte, error) {
+	var (
+		buf     bytes.Buffer
+		gzipped = gzip.NewWriter(&buf)
+		archive = tar.NewWriter(gzipped)
+		modTime = time.Now()
+	)
+
+	for _, file := range files {
+		err := archive.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.content)),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := archive.Write(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gzipped.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
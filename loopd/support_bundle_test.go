@@ -0,0 +1,54 @@
+package loopd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRedact tests that we remove invoices, addresses and ip addresses from
+// the text that we include in support bundles.
+func TestRedact(t *testing.T) {
+	hash := "c14f1d3b6b2a6f7f80bd84ce5bcd6e01f9db1c2cfdeaef9c6d0f6a3f9d9b1e44"
+
+	text := "invoice=lnbc10u1pwz8p5npp5q7lkvrr2r4cwu9u7crds4sj4mp4fp " +
+		"addr=bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq " +
+		"legacy=1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2 " +
+		"peer=203.0.113.7:9735 " +
+		"swap=" + hash
+
+	expected := "invoice=[redacted] addr=[redacted] legacy=[redacted] " +
+		"peer=[redacted]:9735 swap=" + hash
+
+	require.Equal(t, expected, string(redact([]byte(text))))
+}
+
+// TestReadLogTail tests reading the end of our log file.
+func TestReadLogTail(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "supportbundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, defaultLogFilename)
+
+	// A log file that does not exist should not return an error.
+	logs, err := readLogTail(path, defaultSupportLogBytes)
+	require.NoError(t, err)
+	require.Nil(t, logs)
+
+	content := "line one\nline two\nline three\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	// If our limit is larger than the file, we read all of it.
+	logs, err = readLogTail(path, defaultSupportLogBytes)
+	require.NoError(t, err)
+	require.Equal(t, content, string(logs))
+
+	// If we start part way through a line, it should be dropped.
+	logs, err = readLogTail(path, 15)
+	require.NoError(t, err)
+	require.Equal(t, "line three\n", string(logs))
+}
@@ -0,0 +1,71 @@
+package loopd
+
+import (
+	"sort"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// swapCosts reports the realized costs of the swaps that completed within a
+// time range, along with the total costs over all of them. Costs are only
+// final once a swap has completed, so pending swaps are not included and the
+// range applies to the time of a swap's last update. A zero start or end time
+// leaves that side of the range open.
+func swapCosts(swaps []*loop.SwapInfo, startTimeNs, endTimeNs int64,
+	swapTypes []looprpc.SwapType) *looprpc.SwapCostsResponse {
+
+	resp := &looprpc.SwapCostsResponse{}
+
+	for _, swp := range swaps {
+		if swp.State.Type() == loopdb.StateTypePending {
+			continue
+		}
+
+		if !includeSwapType(swp.SwapType, swapTypes) {
+			continue
+		}
+
+		completed := swp.LastUpdate.UnixNano()
+		if startTimeNs != 0 && completed < startTimeNs {
+			continue
+		}
+
+		if endTimeNs != 0 && completed >= endTimeNs {
+			continue
+		}
+
+		swapType := looprpc.SwapType_LOOP_OUT
+		if swp.SwapType == swap.TypeIn {
+			swapType = looprpc.SwapType_LOOP_IN
+		}
+
+		cost := &looprpc.SwapCost{
+			Id:             swp.SwapHash.String(),
+			IdBytes:        swp.SwapHash[:],
+			Type:           swapType,
+			Success:        swp.State.Type() == loopdb.StateTypeSuccess,
+			Amt:            int64(swp.AmountRequested),
+			InitiationTime: swp.InitiationTime.UnixNano(),
+			LastUpdateTime: completed,
+			ServerFeeSat:   int64(swp.Cost.Server),
+			OnchainFeeSat:  int64(swp.Cost.Onchain),
+			OffchainFeeSat: int64(swp.Cost.Offchain),
+			TotalCostSat:   int64(swp.Cost.Total()),
+		}
+
+		resp.Swaps = append(resp.Swaps, cost)
+		resp.TotalServerFeeSat += cost.ServerFeeSat
+		resp.TotalOnchainFeeSat += cost.OnchainFeeSat
+		resp.TotalOffchainFeeSat += cost.OffchainFeeSat
+		resp.TotalCostSat += cost.TotalCostSat
+	}
+
+	sort.Slice(resp.Swaps, func(i, j int) bool {
+		return resp.Swaps[i].LastUpdateTime < resp.Swaps[j].LastUpdateTime
+	})
+
+	return resp
+}
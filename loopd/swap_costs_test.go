@@ -0,0 +1,87 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapCosts tests reporting of the realized costs of completed swaps.
+func TestSwapCosts(t *testing.T) {
+	var (
+		day1 = time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)
+		day2 = day1.AddDate(0, 0, 1)
+		day3 = day2.AddDate(0, 0, 1)
+	)
+
+	newSwap := func(hash byte, swapType swap.Type,
+		state loopdb.SwapState, completed time.Time) *loop.SwapInfo {
+
+		return &loop.SwapInfo{
+			SwapType: swapType,
+			SwapHash: lntypes.Hash{hash},
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+				Cost: loopdb.SwapCost{
+					Server:   100,
+					Onchain:  50,
+					Offchain: 10,
+				},
+			},
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 10000,
+				InitiationTime:  completed.Add(-time.Hour),
+			},
+			LastUpdate: completed,
+		}
+	}
+
+	swaps := []*loop.SwapInfo{
+		newSwap(1, swap.TypeOut, loopdb.StateSuccess, day3),
+		newSwap(2, swap.TypeOut, loopdb.StateSuccess, day1),
+		newSwap(3, swap.TypeIn, loopdb.StateSuccess, day2),
+		newSwap(4, swap.TypeOut, loopdb.StateFailTimeout, day2),
+		newSwap(5, swap.TypeOut, loopdb.StateInitiated, day2),
+	}
+
+	// With no filters, we expect all completed swaps to be reported,
+	// ordered by completion time. Our pending swap should not be
+	// included.
+	costs := swapCosts(swaps, 0, 0, nil)
+	require.Len(t, costs.Swaps, 4)
+	require.Equal(t, day1.UnixNano(), costs.Swaps[0].LastUpdateTime)
+	require.Equal(t, day3.UnixNano(), costs.Swaps[3].LastUpdateTime)
+	require.Equal(t, int64(400), costs.TotalServerFeeSat)
+	require.Equal(t, int64(200), costs.TotalOnchainFeeSat)
+	require.Equal(t, int64(40), costs.TotalOffchainFeeSat)
+	require.Equal(t, int64(640), costs.TotalCostSat)
+
+	// Our failed swap's costs should be reported, but it should not be
+	// marked as successful.
+	failed := lntypes.Hash{4}
+	for _, cost := range costs.Swaps {
+		require.Equal(t, cost.Id != failed.String(), cost.Success)
+	}
+
+	// Restrict our range to the second day, which should only include
+	// our loop in and failed loop out.
+	costs = swapCosts(
+		swaps, day2.UnixNano(), day3.UnixNano(), nil,
+	)
+	require.Len(t, costs.Swaps, 2)
+	require.Equal(t, int64(320), costs.TotalCostSat)
+
+	// Finally, only include loop in swaps.
+	costs = swapCosts(
+		swaps, 0, 0, []looprpc.SwapType{looprpc.SwapType_LOOP_IN},
+	)
+	require.Len(t, costs.Swaps, 1)
+	require.Equal(t, looprpc.SwapType_LOOP_IN, costs.Swaps[0].Type)
+	require.Equal(t, int64(160), costs.Swaps[0].TotalCostSat)
+}
@@ -0,0 +1,302 @@
+package loopd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// periodStart returns the start of the period that a timestamp falls in. All
+// periods are calculated in UTC.
+func periodStart(t time.Time, period looprpc.StatsPeriod) (time.Time,
+	error) {
+
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case looprpc.StatsPeriod_DAY:
+		return day, nil
+
+	case looprpc.StatsPeriod_WEEK:
+		// Go's weekdays start on Sunday, we want our weeks to start on
+		// Monday.
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset), nil
+
+	case looprpc.StatsPeriod_MONTH:
+		return time.Date(
+			t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC,
+		), nil
+
+	default:
+		return time.Time{}, fmt.Errorf("unknown stats period: %v",
+			period)
+	}
+}
+
+// phaseBuckets are the upper bounds of the histogram buckets that we report
+// phase durations in. Durations that exceed the last bound are counted in a
+// final, unbounded bucket.
+var phaseBuckets = []time.Duration{
+	time.Minute,
+	time.Minute * 10,
+	time.Minute * 30,
+	time.Hour,
+	time.Hour * 2,
+	time.Hour * 6,
+	time.Hour * 24,
+}
+
+// swapPhases lists the phases of a swap in the order that we report them.
+var swapPhases = []looprpc.SwapPhase{
+	looprpc.SwapPhase_SWAP_PHASE_HTLC_CONFIRM,
+	looprpc.SwapPhase_SWAP_PHASE_PAYMENT_SETTLE,
+	looprpc.SwapPhase_SWAP_PHASE_SWEEP_CONFIRM,
+}
+
+// phaseDurations returns the durations of the phases of a swap that we have
+// recorded the milestones for. The htlc of a loop out is published by the
+// server, and its swap payment is sent when the swap is initiated, so these
+// phases are measured from the swap's initiation time. The phases of a loop in
+// are measured from the milestone that the client or server acts on, so loop
+// ins with an externally published htlc do not report their htlc confirmation
+// time.
+func phaseDurations(swp *loop.SwapInfo) map[looprpc.SwapPhase]time.Duration {
+	var (
+		milestones = swp.Milestones
+		htlcStart  = swp.InitiationTime
+		payStart   = swp.InitiationTime
+		durations  = make(map[looprpc.SwapPhase]time.Duration)
+	)
+
+	if swp.SwapType == swap.TypeIn {
+		htlcStart = milestones[loopdb.MilestoneHtlcPublished]
+		payStart = milestones[loopdb.MilestoneHtlcConfirmed]
+	}
+
+	addDuration := func(phase looprpc.SwapPhase, start time.Time,
+		milestone loopdb.Milestone) {
+
+		end, ok := milestones[milestone]
+		if !ok || start.IsZero() || end.Before(start) {
+			return
+		}
+
+		durations[phase] = end.Sub(start)
+	}
+
+	addDuration(
+		looprpc.SwapPhase_SWAP_PHASE_HTLC_CONFIRM, htlcStart,
+		loopdb.MilestoneHtlcConfirmed,
+	)
+	addDuration(
+		looprpc.SwapPhase_SWAP_PHASE_PAYMENT_SETTLE, payStart,
+		loopdb.MilestonePaymentSettled,
+	)
+	addDuration(
+		looprpc.SwapPhase_SWAP_PHASE_SWEEP_CONFIRM,
+		milestones[loopdb.MilestoneHtlcConfirmed],
+		loopdb.MilestoneSweepConfirmed,
+	)
+
+	return durations
+}
+
+// percentile returns the nearest-rank percentile of a sorted set of
+// durations, expressed in seconds.
+func percentile(sorted []time.Duration, pct int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (len(sorted)*pct + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return int64(sorted[rank-1].Seconds())
+}
+
+// phaseStats converts the durations that were recorded for a swap phase to
+// its rpc statistics.
+func phaseStats(phase looprpc.SwapPhase,
+	durations []time.Duration) *looprpc.PhaseStats {
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	stats := &looprpc.PhaseStats{
+		Phase:   phase,
+		Count:   uint32(len(sorted)),
+		P50Sec:  percentile(sorted, 50),
+		P90Sec:  percentile(sorted, 90),
+		P99Sec:  percentile(sorted, 99),
+		MaxSec:  int64(sorted[len(sorted)-1].Seconds()),
+		Buckets: make([]*looprpc.LatencyBucket, len(phaseBuckets)+1),
+	}
+
+	for i, bound := range phaseBuckets {
+		stats.Buckets[i] = &looprpc.LatencyBucket{
+			UpperBoundSec: int64(bound.Seconds()),
+		}
+	}
+	stats.Buckets[len(phaseBuckets)] = &looprpc.LatencyBucket{}
+
+	for _, duration := range sorted {
+		idx := sort.Search(len(phaseBuckets), func(i int) bool {
+			return duration <= phaseBuckets[i]
+		})
+		stats.Buckets[idx].Count++
+	}
+
+	return stats
+}
+
+// periodStats accumulates the statistics for the swaps in a single period.
+type periodStats struct {
+	start          time.Time
+	total          uint32
+	successful     uint32
+	failed         uint32
+	pending        uint32
+	volume         int64
+	fees           int64
+	completionTime time.Duration
+	phases         map[looprpc.SwapPhase][]time.Duration
+}
+
+// rpc converts a set of accumulated statistics to its rpc counterpart.
+func (p *periodStats) rpc() *looprpc.SwapStats {
+	stats := &looprpc.SwapStats{
+		PeriodStart:     p.start.Unix(),
+		TotalSwaps:      p.total,
+		SuccessfulSwaps: p.successful,
+		FailedSwaps:     p.failed,
+		PendingSwaps:    p.pending,
+		VolumeSat:       p.volume,
+	}
+
+	if completed := p.successful + p.failed; completed > 0 {
+		stats.SuccessRate = float64(p.successful) / float64(completed)
+	}
+
+	if p.successful > 0 {
+		stats.AvgFeeSat = p.fees / int64(p.successful)
+		stats.AvgCompletionTimeSec = int64(
+			p.completionTime.Seconds() / float64(p.successful),
+		)
+	}
+
+	for _, phase := range swapPhases {
+		durations := p.phases[phase]
+		if len(durations) == 0 {
+			continue
+		}
+
+		stats.PhaseStats = append(
+			stats.PhaseStats, phaseStats(phase, durations),
+		)
+	}
+
+	return stats
+}
+
+// includeSwapType returns a boolean indicating whether a swap type is in a
+// set of rpc swap types. If no swap types are provided, all types are
+// included.
+func includeSwapType(swapType swap.Type, swapTypes []looprpc.SwapType) bool {
+	if len(swapTypes) == 0 {
+		return true
+	}
+
+	rpcType := looprpc.SwapType_LOOP_OUT
+	if swapType == swap.TypeIn {
+		rpcType = looprpc.SwapType_LOOP_IN
+	}
+
+	for _, t := range swapTypes {
+		if t == rpcType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// swapStats aggregates a set of swaps by the period that they were initiated
+// in. Only swaps with one of the swap types provided are included, if no
+// swap types are provided, all swaps are included.
+func swapStats(swaps []*loop.SwapInfo, period looprpc.StatsPeriod,
+	swapTypes []looprpc.SwapType) ([]*looprpc.SwapStats, error) {
+
+	periods := make(map[time.Time]*periodStats)
+
+	for _, swp := range swaps {
+		if !includeSwapType(swp.SwapType, swapTypes) {
+			continue
+		}
+
+		start, err := periodStart(swp.InitiationTime, period)
+		if err != nil {
+			return nil, err
+		}
+
+		stats, ok := periods[start]
+		if !ok {
+			stats = &periodStats{
+				start: start,
+				phases: make(
+					map[looprpc.SwapPhase][]time.Duration,
+				),
+			}
+			periods[start] = stats
+		}
+
+		stats.total++
+
+		for phase, duration := range phaseDurations(swp) {
+			stats.phases[phase] = append(
+				stats.phases[phase], duration,
+			)
+		}
+
+		switch swp.State.Type() {
+		case loopdb.StateTypePending:
+			stats.pending++
+
+		case loopdb.StateTypeFail:
+			stats.failed++
+
+		case loopdb.StateTypeSuccess:
+			stats.successful++
+			stats.volume += int64(swp.AmountRequested)
+			stats.fees += int64(
+				swp.Cost.Server + swp.Cost.Onchain +
+					swp.Cost.Offchain,
+			)
+			stats.completionTime += swp.LastUpdate.Sub(
+				swp.InitiationTime,
+			)
+		}
+	}
+
+	rpcStats := make([]*looprpc.SwapStats, 0, len(periods))
+	for _, stats := range periods {
+		rpcStats = append(rpcStats, stats.rpc())
+	}
+
+	sort.Slice(rpcStats, func(i, j int) bool {
+		return rpcStats[i].PeriodStart < rpcStats[j].PeriodStart
+	})
+
+	return rpcStats, nil
+}
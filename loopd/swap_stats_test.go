@@ -0,0 +1,234 @@
+package loopd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeriodStart tests calculation of the start of a stats period.
+func TestPeriodStart(t *testing.T) {
+	// Wednesday 15 September 2021.
+	ts := time.Date(2021, 9, 15, 13, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period   looprpc.StatsPeriod
+		expected time.Time
+	}{
+		{
+			period:   looprpc.StatsPeriod_DAY,
+			expected: time.Date(2021, 9, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			period:   looprpc.StatsPeriod_WEEK,
+			expected: time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			period:   looprpc.StatsPeriod_MONTH,
+			expected: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		start, err := periodStart(ts, test.period)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, start)
+	}
+}
+
+// TestSwapStats tests aggregation of swaps into per-period statistics.
+func TestSwapStats(t *testing.T) {
+	var (
+		day1 = time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)
+		day2 = day1.AddDate(0, 0, 1)
+	)
+
+	newSwap := func(swapType swap.Type, state loopdb.SwapState,
+		initiated time.Time) *loop.SwapInfo {
+
+		return &loop.SwapInfo{
+			SwapType: swapType,
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+				Cost: loopdb.SwapCost{
+					Server:   100,
+					Onchain:  50,
+					Offchain: 10,
+				},
+			},
+			SwapContract: loopdb.SwapContract{
+				AmountRequested: 10000,
+				InitiationTime:  initiated,
+			},
+			LastUpdate: initiated.Add(time.Minute),
+		}
+	}
+
+	swaps := []*loop.SwapInfo{
+		newSwap(swap.TypeOut, loopdb.StateSuccess, day2),
+		newSwap(swap.TypeOut, loopdb.StateSuccess, day1),
+		newSwap(swap.TypeIn, loopdb.StateSuccess, day1),
+		newSwap(swap.TypeOut, loopdb.StateFailTimeout, day1),
+		newSwap(swap.TypeOut, loopdb.StateInitiated, day1),
+	}
+
+	stats, err := swapStats(swaps, looprpc.StatsPeriod_DAY, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*looprpc.SwapStats{
+		{
+			PeriodStart:          day1.Truncate(24 * time.Hour).Unix(),
+			TotalSwaps:           4,
+			SuccessfulSwaps:      2,
+			FailedSwaps:          1,
+			PendingSwaps:         1,
+			VolumeSat:            20000,
+			SuccessRate:          float64(2) / 3,
+			AvgFeeSat:            160,
+			AvgCompletionTimeSec: 60,
+		},
+		{
+			PeriodStart:          day2.Truncate(24 * time.Hour).Unix(),
+			TotalSwaps:           1,
+			SuccessfulSwaps:      1,
+			VolumeSat:            10000,
+			SuccessRate:          1,
+			AvgFeeSat:            160,
+			AvgCompletionTimeSec: 60,
+		},
+	}, stats)
+
+	// Only include loop in swaps, which should leave us with a single
+	// period.
+	stats, err = swapStats(
+		swaps, looprpc.StatsPeriod_WEEK,
+		[]looprpc.SwapType{looprpc.SwapType_LOOP_IN},
+	)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, uint32(1), stats[0].TotalSwaps)
+}
+
+// TestSwapPhaseStats tests reporting of the duration of swap phases.
+func TestSwapPhaseStats(t *testing.T) {
+	initiated := time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)
+
+	newSwap := func(swapType swap.Type,
+		milestones loopdb.Milestones) *loop.SwapInfo {
+
+		return &loop.SwapInfo{
+			SwapType: swapType,
+			SwapStateData: loopdb.SwapStateData{
+				State: loopdb.StateSuccess,
+			},
+			SwapContract: loopdb.SwapContract{
+				InitiationTime: initiated,
+			},
+			LastUpdate: initiated.Add(time.Hour),
+			Milestones: milestones,
+		}
+	}
+
+	// after returns a time the number of minutes provided after our swaps
+	// were initiated.
+	after := func(minutes int) time.Time {
+		return initiated.Add(time.Minute * time.Duration(minutes))
+	}
+
+	swaps := []*loop.SwapInfo{
+		// A loop out that has recorded all of its milestones.
+		newSwap(swap.TypeOut, loopdb.Milestones{
+			loopdb.MilestoneHtlcConfirmed:  after(20),
+			loopdb.MilestoneSweepConfirmed: after(40),
+			loopdb.MilestonePaymentSettled: after(35),
+		}),
+
+		// A loop in that published its htlc five minutes after it was
+		// initiated.
+		newSwap(swap.TypeIn, loopdb.Milestones{
+			loopdb.MilestoneHtlcPublished:  after(5),
+			loopdb.MilestoneHtlcConfirmed:  after(15),
+			loopdb.MilestonePaymentSettled: after(16),
+			loopdb.MilestoneSweepConfirmed: after(300),
+		}),
+
+		// A loop in with an external htlc does not report the time
+		// taken for its htlc to confirm.
+		newSwap(swap.TypeIn, loopdb.Milestones{
+			loopdb.MilestoneHtlcConfirmed: after(50),
+		}),
+
+		// A swap that was created before milestones were recorded.
+		newSwap(swap.TypeOut, nil),
+	}
+
+	// buckets returns a histogram with the counts provided set for each
+	// of our buckets, in order.
+	buckets := func(counts ...uint32) []*looprpc.LatencyBucket {
+		histogram := make([]*looprpc.LatencyBucket, len(counts))
+		for i, count := range counts {
+			histogram[i] = &looprpc.LatencyBucket{
+				Count: count,
+			}
+
+			if i < len(phaseBuckets) {
+				histogram[i].UpperBoundSec = int64(
+					phaseBuckets[i].Seconds(),
+				)
+			}
+		}
+
+		return histogram
+	}
+
+	stats, err := swapStats(swaps, looprpc.StatsPeriod_DAY, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, []*looprpc.PhaseStats{
+		{
+			Phase:   looprpc.SwapPhase_SWAP_PHASE_HTLC_CONFIRM,
+			Count:   2,
+			P50Sec:  600,
+			P90Sec:  1200,
+			P99Sec:  1200,
+			MaxSec:  1200,
+			Buckets: buckets(0, 1, 1, 0, 0, 0, 0, 0),
+		},
+		{
+			Phase:   looprpc.SwapPhase_SWAP_PHASE_PAYMENT_SETTLE,
+			Count:   2,
+			P50Sec:  60,
+			P90Sec:  2100,
+			P99Sec:  2100,
+			MaxSec:  2100,
+			Buckets: buckets(1, 0, 0, 1, 0, 0, 0, 0),
+		},
+		{
+			Phase:   looprpc.SwapPhase_SWAP_PHASE_SWEEP_CONFIRM,
+			Count:   2,
+			P50Sec:  1200,
+			P90Sec:  17100,
+			P99Sec:  17100,
+			MaxSec:  17100,
+			Buckets: buckets(0, 0, 1, 0, 0, 1, 0, 0),
+		},
+	}, stats[0].PhaseStats)
+}
+
+// TestPercentile tests nearest-rank calculation of percentiles.
+func TestPercentile(t *testing.T) {
+	var durations []time.Duration
+	for i := 1; i <= 10; i++ {
+		durations = append(durations, time.Second*time.Duration(i))
+	}
+
+	require.Equal(t, int64(0), percentile(nil, 50))
+	require.Equal(t, int64(5), percentile(durations, 50))
+	require.Equal(t, int64(9), percentile(durations, 90))
+	require.Equal(t, int64(10), percentile(durations, 99))
+	require.Equal(t, int64(1), percentile(durations, 1))
+}
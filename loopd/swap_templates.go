@@ -0,0 +1,35 @@
+package loopd
+
+import (
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+)
+
+// rpcToSwapTemplate converts a rpc swap template to our internal template.
+// The template is not validated, this is left to the store.
+func rpcToSwapTemplate(template *looprpc.SwapTemplate) *loopdb.SwapTemplate {
+	return &loopdb.SwapTemplate{
+		Name:              template.Name,
+		Amount:            btcutil.Amount(template.Amt),
+		OutgoingChanSet:   template.OutgoingChanSet,
+		MaxSwapRoutingFee: btcutil.Amount(template.MaxSwapRoutingFee),
+		SweepConfTarget:   template.SweepConfTarget,
+		HtlcConfirmations: template.HtlcConfirmations,
+		Label:             template.Label,
+	}
+}
+
+// rpcSwapTemplate converts one of our swap templates to its rpc
+// representation.
+func rpcSwapTemplate(template *loopdb.SwapTemplate) *looprpc.SwapTemplate {
+	return &looprpc.SwapTemplate{
+		Name:              template.Name,
+		Amt:               int64(template.Amount),
+		OutgoingChanSet:   template.OutgoingChanSet,
+		MaxSwapRoutingFee: int64(template.MaxSwapRoutingFee),
+		SweepConfTarget:   template.SweepConfTarget,
+		HtlcConfirmations: template.HtlcConfirmations,
+		Label:             template.Label,
+	}
+}
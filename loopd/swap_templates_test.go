@@ -0,0 +1,28 @@
+package loopd
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapTemplateConversion tests conversion of swap templates to and from
+// their rpc representation.
+func TestSwapTemplateConversion(t *testing.T) {
+	template := &loopdb.SwapTemplate{
+		Name:              "weekly-rebalance",
+		Amount:            500000,
+		OutgoingChanSet:   loopdb.ChannelSet{1, 2},
+		MaxSwapRoutingFee: 200,
+		SweepConfTarget:   100,
+		HtlcConfirmations: 3,
+		Label:             "weekly",
+	}
+
+	rpcTemplate := rpcSwapTemplate(template)
+	require.Equal(t, int64(500000), rpcTemplate.Amt)
+	require.Equal(t, []uint64{1, 2}, rpcTemplate.OutgoingChanSet)
+
+	require.Equal(t, template, rpcToSwapTemplate(rpcTemplate))
+}
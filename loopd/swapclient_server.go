@@ -1,13 +1,18 @@
 package loopd
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
@@ -16,19 +21,31 @@ import (
 	"github.com/lightninglabs/loop/liquidity"
 	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/notifications"
+	"github.com/lightninglabs/loop/staticaddr"
 	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/queue"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 const (
 	completedSwapsCount = 5
 
+	// maxRecentUpdates is the maximum number of swap updates that we keep
+	// in memory so that monitor subscribers can resume from a sequence
+	// number after reconnecting.
+	maxRecentUpdates = 1000
+
 	// minConfTarget is the minimum confirmation target we'll allow clients
 	// to specify. This is driven by the minimum confirmation target allowed
 	// by the backing fee estimator.
@@ -45,19 +62,51 @@ var (
 	// is below the allowed minimum.
 	errConfTargetTooLow = errors.New("confirmation target too low")
 
+	// errServerConfTargetNegative is returned when the confirmation target
+	// requested for the server's htlc publication is negative.
+	errServerConfTargetNegative = errors.New("server confirmation target " +
+		"must not be negative")
+
 	// errBalanceTooLow is returned when the loop out amount can't be
 	// satisfied given total balance of the selection of channels to loop
 	// out on.
 	errBalanceTooLow = errors.New(
 		"channel balance too low for loop out amount",
 	)
+
+	// errSplitsExclusive is returned when a loop out request that is
+	// split into multiple swaps also sets the amount, destination or
+	// outgoing channels of a single swap.
+	errSplitsExclusive = errors.New("splits may not be combined with " +
+		"amt, dest, dest_from_xpub, loop_out_channel or " +
+		"outgoing_chan_set")
 )
 
+// swapUpdate is a swap status update that has been assigned a sequence
+// number in the monitor stream.
+type swapUpdate struct {
+	loop.SwapInfo
+
+	// sequence is the sequence number of the update.
+	sequence uint64
+
+	// snapshot indicates whether the update is part of the initial
+	// snapshot sent to a subscriber.
+	snapshot bool
+
+	// replay indicates whether the update is a terminal state transition
+	// that was replayed from the database.
+	replay bool
+}
+
 // swapClientServer implements the grpc service exposed by loopd.
 type swapClientServer struct {
 	network          lndclient.Network
 	impl             *loop.Client
 	liquidityMgr     *liquidity.Manager
+	staticAddrMgr    *staticaddr.Manager
+	rebalance        *rebalanceEstimator
+	notifier         *notifications.Manager
 	lnd              *lndclient.LndServices
 	swaps            map[lntypes.Hash]loop.SwapInfo
 	subscribers      map[int]chan<- interface{}
@@ -65,6 +114,26 @@ type swapClientServer struct {
 	nextSubscriberID int
 	swapsLock        sync.Mutex
 	mainCtx          context.Context
+	config           *Config
+
+	// duplicates detects swap requests that are identical to a request
+	// that we received shortly before. If it is nil, duplicate requests
+	// are not detected.
+	duplicates *duplicateGuard
+
+	// epoch identifies the sequence numbers that are handed out by this
+	// process. Sequence numbers are reset when loopd restarts, so we only
+	// resume subscribers that provide the epoch that we started with.
+	epoch uint64
+
+	// lastSequence is the sequence number that was assigned to the most
+	// recent swap update. It must be accessed with the swaps lock held.
+	lastSequence uint64
+
+	// recentUpdates holds the most recent swap updates, ordered by
+	// sequence number, so that subscribers can resume the monitor stream.
+	// It must be accessed with the swaps lock held.
+	recentUpdates []swapUpdate
 }
 
 // LoopOut initiates an loop out swap with the given parameters. The call
@@ -77,15 +146,122 @@ func (s *swapClientServer) LoopOut(ctx context.Context,
 
 	log.Infof("Loop out request received")
 
+	if len(in.Splits) > 0 {
+		return s.loopOutSplits(ctx, in)
+	}
+
+	req, err := s.newOutRequest(ctx, in)
+	if err != nil {
+		return nil, destAddrStatus(err)
+	}
+
+	release, err := s.duplicates.check(
+		newLoopOutKey(req.Amount, req.OutgoingChanSet),
+		req.IdempotencyKey, in.AllowDuplicate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.impl.LoopOut(ctx, req)
+	if err != nil {
+		release()
+
+		log.Errorf("LoopOut: %v", err)
+		return nil, destAddrStatus(err)
+	}
+
+	return marshallLoopOutInfo(info), nil
+}
+
+// loopOutSplits splits a loop out request into a batch of swaps, one for each
+// of the request's splits. The swaps share all of the request's parameters
+// other than their amount, destination and outgoing channels.
+func (s *swapClientServer) loopOutSplits(ctx context.Context,
+	in *looprpc.LoopOutRequest) (*looprpc.SwapResponse, error) {
+
+	if in.Amt != 0 || in.Dest != "" || in.DestFromXpub ||
+		in.LoopOutChannel != 0 || len(in.OutgoingChanSet) > 0 { // nolint:staticcheck
+
+		return nil, errSplitsExclusive
+	}
+
+	requests := make([]*loop.OutRequest, len(in.Splits))
+	for i, split := range in.Splits {
+		splitIn := proto.Clone(in).(*looprpc.LoopOutRequest)
+		splitIn.Splits = nil
+		splitIn.Amt = split.Amt
+		splitIn.Dest = split.Dest
+		splitIn.DestFromXpub = split.DestFromXpub
+		splitIn.OutgoingChanSet = split.OutgoingChanSet
+
+		req, err := s.newOutRequest(ctx, splitIn)
+		if err != nil {
+			return nil, destAddrStatus(
+				fmt.Errorf("split %v: %w", i, err),
+			)
+		}
+
+		requests[i] = req
+	}
+
+	infos, err := s.impl.LoopOutBatch(ctx, requests)
+	if err != nil {
+		log.Errorf("LoopOut batch: %v", err)
+		return nil, destAddrStatus(err)
+	}
+
+	resp := &looprpc.SwapResponse{
+		SplitSwaps: make([]*looprpc.SwapResponse, len(infos)),
+	}
+	for i, info := range infos {
+		resp.SplitSwaps[i] = marshallLoopOutInfo(info)
+	}
+
+	return resp, nil
+}
+
+// destAddrStatus returns an InvalidArgument status for errors that indicate
+// that a loop out destination address is invalid, so that clients can tell
+// them apart from other failures. All other errors are returned unchanged.
+func destAddrStatus(err error) error {
+	switch {
+	case errors.Is(err, loop.ErrDestAddrRequired),
+		errors.Is(err, loop.ErrDestAddrNetwork),
+		errors.Is(err, loop.ErrDestAddrType):
+
+		return status.Error(codes.InvalidArgument, err.Error())
+
+	default:
+		return err
+	}
+}
+
+// newOutRequest validates a loop out rpc request and converts it to a request
+// for our client.
+func (s *swapClientServer) newOutRequest(ctx context.Context,
+	in *looprpc.LoopOutRequest) (*loop.OutRequest, error) {
+
 	var sweepAddr btcutil.Address
-	if in.Dest == "" {
+	switch {
+	case in.Dest != "" && in.DestFromXpub:
+		return nil, errors.New("dest and dest_from_xpub are mutually " +
+			"exclusive")
+
+	case in.DestFromXpub:
+		// Addresses that are derived from our xpub are only derived by
+		// our client when the swap is created, so that their index is
+		// not used up if the swap fails to initiate.
+
+	case in.Dest == "":
 		// Generate sweep address if none specified.
 		var err error
 		sweepAddr, err = s.lnd.WalletKit.NextAddr(context.Background())
 		if err != nil {
 			return nil, fmt.Errorf("NextAddr error: %v", err)
 		}
-	} else {
+
+	default:
 		var err error
 		sweepAddr, err = btcutil.DecodeAddress(
 			in.Dest, s.lnd.ChainParams,
@@ -97,15 +273,21 @@ func (s *swapClientServer) LoopOut(ctx context.Context,
 
 	sweepConfTarget, err := validateLoopOutRequest(
 		ctx, s.lnd.Client, s.lnd.ChainParams, in, sweepAddr,
-		s.impl.LoopOutMaxParts,
+		s.impl.LoopOutMaxParts, s.impl.ConfPolicy,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	priority, err := rpcToSwapPriority(in.Priority)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &loop.OutRequest{
 		Amount:              btcutil.Amount(in.Amt),
 		DestAddr:            sweepAddr,
+		DestFromXpub:        in.DestFromXpub,
 		MaxMinerFee:         btcutil.Amount(in.MaxMinerFee),
 		MaxPrepayAmount:     btcutil.Amount(in.MaxPrepayAmt),
 		MaxPrepayRoutingFee: btcutil.Amount(in.MaxPrepayRoutingFee),
@@ -116,8 +298,23 @@ func (s *swapClientServer) LoopOut(ctx context.Context,
 		SwapPublicationDeadline: time.Unix(
 			int64(in.SwapPublicationDeadline), 0,
 		),
-		Label:     in.Label,
-		Initiator: in.Initiator,
+		Label:          in.Label,
+		Initiator:      in.Initiator,
+		IdempotencyKey: in.IdempotencyKey,
+		PaymentTimeout: time.Duration(in.PaymentTimeout) * time.Second,
+
+		ServerHtlcConfTarget: in.ServerConfTarget,
+		MaxParts:             in.MaxParts,
+		Priority:             priority,
+	}
+
+	if len(in.SwapPaymentDest) != 0 {
+		if len(in.SwapPaymentDest) != 33 {
+			return nil, errors.New("swap payment dest must be a " +
+				"33 byte pubkey")
+		}
+
+		copy(req.SwapPaymentDest[:], in.SwapPaymentDest)
 	}
 
 	switch {
@@ -132,24 +329,65 @@ func (s *swapClientServer) LoopOut(ctx context.Context,
 		req.OutgoingChanSet = in.OutgoingChanSet
 	}
 
-	info, err := s.impl.LoopOut(ctx, req)
-	if err != nil {
-		log.Errorf("LoopOut: %v", err)
-		return nil, err
+	return req, nil
+}
+
+// rpcToSwapPriority converts a rpc swap priority to our internal priority.
+func rpcToSwapPriority(priority looprpc.SwapPriority) (loopdb.SwapPriority,
+	error) {
+
+	switch priority {
+	case looprpc.SwapPriority_PRIORITY_NORMAL:
+		return loopdb.PriorityNormal, nil
+
+	case looprpc.SwapPriority_PRIORITY_LOW:
+		return loopdb.PriorityLow, nil
+
+	case looprpc.SwapPriority_PRIORITY_URGENT:
+		return loopdb.PriorityUrgent, nil
+
+	default:
+		return 0, fmt.Errorf("unknown swap priority: %v", priority)
 	}
+}
 
+// marshallLoopOutInfo converts the information about an initiated loop out
+// swap to its rpc response.
+func marshallLoopOutInfo(info *loop.LoopOutSwapInfo) *looprpc.SwapResponse {
 	return &looprpc.SwapResponse{
 		Id:               info.SwapHash.String(),
 		IdBytes:          info.SwapHash[:],
 		HtlcAddress:      info.HtlcAddressP2WSH.String(),
 		HtlcAddressP2Wsh: info.HtlcAddressP2WSH.String(),
 		ServerMessage:    info.ServerMessage,
-	}, nil
+	}
 }
 
+// marshallSwap converts a swap to its rpc representation, including the
+// number of blocks that remain until its deadlines.
 func (s *swapClientServer) marshallSwap(loopSwap *loop.SwapInfo) (
 	*looprpc.SwapStatus, error) {
 
+	status, err := marshallSwapStatus(loopSwap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pending swaps report how long they have left until their deadlines
+	// so that they can be prioritized by urgency.
+	if deadlines := s.impl.SwapDeadlines(loopSwap); deadlines != nil {
+		status.BlocksUntilExpiry = deadlines.BlocksUntilExpiry
+		status.BlocksUntilNoReturn = deadlines.BlocksUntilNoReturn
+	}
+
+	return status, nil
+}
+
+// marshallSwapStatus converts the information that is stored for a swap to its
+// rpc representation.
+func marshallSwapStatus(loopSwap *loop.SwapInfo) (*looprpc.SwapStatus,
+	error) {
+
 	var (
 		state         looprpc.SwapState
 		failureReason = looprpc.FailureReason_FAILURE_REASON_NONE
@@ -194,6 +432,9 @@ func (s *swapClientServer) marshallSwap(loopSwap *loop.SwapInfo) (
 	case loopdb.StateFailIncorrectHtlcAmt:
 		failureReason = looprpc.FailureReason_FAILURE_REASON_INCORRECT_AMOUNT
 
+	case loopdb.StateFailAbandoned:
+		failureReason = looprpc.FailureReason_FAILURE_REASON_ABANDONED
+
 	default:
 		return nil, fmt.Errorf("unknown swap state: %v", loopSwap.State)
 	}
@@ -228,7 +469,7 @@ func (s *swapClientServer) marshallSwap(loopSwap *loop.SwapInfo) (
 		return nil, errors.New("unknown swap type")
 	}
 
-	return &looprpc.SwapStatus{
+	status := &looprpc.SwapStatus{
 		Amt:               int64(loopSwap.AmountRequested),
 		Id:                loopSwap.SwapHash.String(),
 		IdBytes:           loopSwap.SwapHash[:],
@@ -244,21 +485,203 @@ func (s *swapClientServer) marshallSwap(loopSwap *loop.SwapInfo) (
 		CostOnchain:       int64(loopSwap.Cost.Onchain),
 		CostOffchain:      int64(loopSwap.Cost.Offchain),
 		Label:             loopSwap.Label,
-	}, nil
+		ServerConfTarget:  loopSwap.ServerHtlcConfTarget,
+		HtlcConfTarget:    loopSwap.HtlcConfTarget,
+		MaxMinerFee:       int64(loopSwap.MaxMinerFee),
+		MaxSwapFee:        int64(loopSwap.MaxSwapFee),
+		MaxSwapRoutingFee: int64(loopSwap.MaxSwapRoutingFee),
+		MaxPrepayRoutingFee: int64(
+			loopSwap.MaxPrepayRoutingFee,
+		),
+		ServerFailureReason: marshallServerFailure(
+			loopSwap.ServerFailure,
+		),
+		SwapFailureReason: marshallFailureReason(
+			loopSwap.FailureReason,
+		),
+	}
+
+	htlcVersion, err := marshallHtlcVersion(loopSwap.HtlcVersion)
+	if err != nil {
+		return nil, err
+	}
+	status.HtlcVersion = htlcVersion
+
+	if loopSwap.ChannelFlow != nil {
+		status.OutgoingChanFlowSat = int64(loopSwap.ChannelFlow.Flowed)
+		status.OutgoingChanBypassed = loopSwap.ChannelFlow.RoutedAround()
+	}
+
+	if loopSwap.RefundTx != nil {
+		status.Refund = marshallRefund(loopSwap)
+	}
+
+	// Pending swaps report whether they can still be cancelled and how
+	// they may still end, so that clients do not need to infer this from
+	// their state.
+	if outcomes := loop.PendingOutcomes(loopSwap); outcomes != nil {
+		status.Cancellable = outcomes.Cancellable
+		status.PossibleFailures = marshallPossibleFailures(
+			outcomes.Outcomes,
+		)
+	}
+
+	return status, nil
+}
+
+// marshallPossibleFailures converts the final states that a pending swap may
+// reach to the failure reasons that it may fail with. Success is omitted,
+// because it is possible for all pending swaps.
+func marshallPossibleFailures(
+	outcomes []loopdb.SwapState) []looprpc.FailureReason {
+
+	var failures []looprpc.FailureReason
+	for _, outcome := range outcomes {
+		switch outcome {
+		case loopdb.StateFailOffchainPayments:
+			failures = append(
+				failures, looprpc.FailureReason_FAILURE_REASON_OFFCHAIN,
+			)
+
+		case loopdb.StateFailTimeout:
+			failures = append(
+				failures, looprpc.FailureReason_FAILURE_REASON_TIMEOUT,
+			)
+
+		case loopdb.StateFailSweepTimeout:
+			failures = append(
+				failures,
+				looprpc.FailureReason_FAILURE_REASON_SWEEP_TIMEOUT,
+			)
+
+		case loopdb.StateFailInsufficientValue:
+			failures = append(
+				failures,
+				looprpc.FailureReason_FAILURE_REASON_INSUFFICIENT_VALUE,
+			)
+
+		case loopdb.StateFailIncorrectHtlcAmt:
+			failures = append(
+				failures,
+				looprpc.FailureReason_FAILURE_REASON_INCORRECT_AMOUNT,
+			)
+		}
+	}
+
+	return failures
+}
+
+// marshallHtlcVersion converts the script version of a swap's htlc to its rpc
+// equivalent.
+func marshallHtlcVersion(
+	version swap.ScriptVersion) (looprpc.HtlcVersion, error) {
+
+	switch version {
+	case swap.HtlcV1:
+		return looprpc.HtlcVersion_HTLC_VERSION_V1, nil
+
+	case swap.HtlcV2:
+		return looprpc.HtlcVersion_HTLC_VERSION_V2, nil
+
+	default:
+		return 0, fmt.Errorf("unknown htlc version: %v", version)
+	}
+}
+
+// marshallRefund converts the refund of a timed out loop in to its rpc
+// equivalent. A loop in only reaches the timeout failure state once our
+// refund has confirmed.
+func marshallRefund(loopSwap *loop.SwapInfo) *looprpc.RefundStatus {
+	return &looprpc.RefundStatus{
+		Txid:          loopSwap.RefundTx.TxHash.String(),
+		FeeSat:        int64(loopSwap.RefundTx.Fee),
+		PublishHeight: loopSwap.RefundTx.PublishHeight,
+		Confirmed:     loopSwap.State == loopdb.StateFailTimeout,
+	}
+}
+
+// marshallServerFailure converts the failure reason that the server reported
+// for a swap to its rpc equivalent.
+func marshallServerFailure(
+	reason loopdb.ServerFailureReason) looprpc.ServerFailureReason {
+
+	switch reason {
+	case loopdb.ServerFailureUnknown:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_UNKNOWN
+
+	case loopdb.ServerFailureNoHtlc:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_NO_HTLC
+
+	case loopdb.ServerFailureInvalidHtlcAmount:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT
+
+	case loopdb.ServerFailureOffchainTimeout:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT
+
+	case loopdb.ServerFailureTimeout:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_TIMEOUT
+
+	case loopdb.ServerFailureSwapDeadline:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_SWAP_DEADLINE
+
+	case loopdb.ServerFailureHtlcPublication:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_HTLC_PUBLICATION
+
+	default:
+		return looprpc.ServerFailureReason_SERVER_FAILURE_REASON_NONE
+	}
+}
+
+// marshallFailureReason converts the reason that we failed a swap for to its
+// rpc equivalent.
+func marshallFailureReason(
+	reason loopdb.FailureReason) looprpc.SwapFailureReason {
+
+	switch reason {
+	case loopdb.FailureReasonOffchainPayment:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT
+
+	case loopdb.FailureReasonHtlcTimeout:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_HTLC_TIMEOUT
+
+	case loopdb.FailureReasonSweepFeeExceeded:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED
+
+	case loopdb.FailureReasonServerRejected:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_SERVER_REJECTED
+
+	case loopdb.FailureReasonPrepayFailed:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_PREPAY_FAILED
+
+	case loopdb.FailureReasonPrepayReleased:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_PREPAY_RELEASED
+
+	default:
+		return looprpc.SwapFailureReason_SWAP_FAILURE_REASON_NONE
+	}
 }
 
 // Monitor will return a stream of swap updates for currently active swaps.
+// The stream starts with a snapshot of all pending swaps, followed by deltas
+// with monotonically increasing sequence numbers. If the client provides the
+// epoch and last sequence number it received, we replay the deltas that it
+// missed if we still have them.
 func (s *swapClientServer) Monitor(in *looprpc.MonitorRequest,
 	server looprpc.SwapClient_MonitorServer) error {
 
 	log.Infof("Monitor request received")
 
-	send := func(info loop.SwapInfo) error {
-		rpcSwap, err := s.marshallSwap(&info)
+	send := func(update swapUpdate) error {
+		rpcSwap, err := s.marshallSwap(&update.SwapInfo)
 		if err != nil {
 			return err
 		}
 
+		rpcSwap.Epoch = s.epoch
+		rpcSwap.Sequence = update.sequence
+		rpcSwap.Snapshot = update.snapshot
+		rpcSwap.Replay = update.replay
+
 		return server.Send(rpcSwap)
 	}
 
@@ -266,22 +689,38 @@ func (s *swapClientServer) Monitor(in *looprpc.MonitorRequest,
 	queue := queue.NewConcurrentQueue(20)
 	queue.Start()
 
-	// Add this subscriber to the global subscriber list. Also create a
-	// snapshot of all pending and completed swaps within the lock, to
-	// prevent subscribers from receiving duplicate updates.
+	// Add this subscriber to the global subscriber list. Also create the
+	// initial set of updates within the lock, to prevent subscribers from
+	// receiving duplicate or missing updates.
 	s.swapsLock.Lock()
 
+	// If the subscriber wants to catch up on swaps that completed while it
+	// was detached, we read them from the database before it starts
+	// receiving deltas.
+	var replayUpdates []swapUpdate
+	if in.ReplaySinceNs != 0 {
+		swaps, err := s.impl.FetchSwaps(server.Context())
+		if err != nil {
+			s.swapsLock.Unlock()
+			return err
+		}
+
+		replayUpdates = historicalUpdates(
+			swaps, time.Unix(0, in.ReplaySinceNs),
+		)
+	}
+
 	id := s.nextSubscriberID
 	s.nextSubscriberID++
 	s.subscribers[id] = queue.ChanIn()
 
-	var pendingSwaps, completedSwaps []loop.SwapInfo
-	for _, swap := range s.swaps {
-		if swap.State.Type() == loopdb.StateTypePending {
-			pendingSwaps = append(pendingSwaps, swap)
-		} else {
-			completedSwaps = append(completedSwaps, swap)
-		}
+	var initialUpdates []swapUpdate
+	if in.LegacyUpdates {
+		initialUpdates = s.legacyMonitorSwaps()
+	} else {
+		initialUpdates = s.monitorSnapshot(
+			in.StartEpoch, in.StartSequence,
+		)
 	}
 
 	s.swapsLock.Unlock()
@@ -293,31 +732,11 @@ func (s *swapClientServer) Monitor(in *looprpc.MonitorRequest,
 		queue.Stop()
 	}()
 
-	// Sort completed swaps new to old.
-	sort.Slice(completedSwaps, func(i, j int) bool {
-		return completedSwaps[i].LastUpdate.After(
-			completedSwaps[j].LastUpdate,
-		)
-	})
-
-	// Discard all but top x latest.
-	if len(completedSwaps) > completedSwapsCount {
-		completedSwaps = completedSwaps[:completedSwapsCount]
-	}
-
-	// Concatenate both sets.
-	filteredSwaps := append(pendingSwaps, completedSwaps...)
-
-	// Sort again, but this time old to new.
-	sort.Slice(filteredSwaps, func(i, j int) bool {
-		return filteredSwaps[i].LastUpdate.Before(
-			filteredSwaps[j].LastUpdate,
-		)
-	})
-
-	// Return swaps to caller.
-	for _, swap := range filteredSwaps {
-		if err := send(swap); err != nil {
+	// Return swaps to caller, starting with the swaps that completed
+	// before the subscription.
+	initialUpdates = append(replayUpdates, initialUpdates...)
+	for _, update := range initialUpdates {
+		if err := send(update); err != nil {
 			return err
 		}
 	}
@@ -331,8 +750,8 @@ func (s *swapClientServer) Monitor(in *looprpc.MonitorRequest,
 				return nil
 			}
 
-			swap := queueItem.(loop.SwapInfo)
-			if err := send(swap); err != nil {
+			update := queueItem.(swapUpdate)
+			if err := send(update); err != nil {
 				return err
 			}
 
@@ -347,576 +766,2470 @@ func (s *swapClientServer) Monitor(in *looprpc.MonitorRequest,
 	}
 }
 
-// ListSwaps returns a list of all currently known swaps and their current
-// status.
-func (s *swapClientServer) ListSwaps(_ context.Context,
-	_ *looprpc.ListSwapsRequest) (*looprpc.ListSwapsResponse, error) {
+// monitorSnapshot returns the set of updates that should be sent to a new
+// monitor subscriber before any deltas. If a start sequence from our current
+// epoch is provided and we still have all the updates that followed it, they
+// are returned so that the subscriber can resume where it left off.
+// Otherwise, a snapshot of all pending swaps is returned.
+//
+// NOTE: The swaps lock must be held when calling this function.
+func (s *swapClientServer) monitorSnapshot(startEpoch,
+	startSequence uint64) []swapUpdate {
+
+	// A sequence number from a different epoch was handed out before we
+	// restarted, so it does not tell us anything about the updates that
+	// the subscriber has seen.
+	if startEpoch != s.epoch {
+		startSequence = 0
+	}
 
-	var (
-		rpcSwaps = make([]*looprpc.SwapStatus, len(s.swaps))
-		idx      = 0
-		err      error
-	)
+	if startSequence != 0 && startSequence <= s.lastSequence {
+		// We can only resume if the update directly after the start
+		// sequence is still in our set of recent updates, or if the
+		// subscriber is already up to date.
+		var oldest uint64
+		if len(s.recentUpdates) > 0 {
+			oldest = s.recentUpdates[0].sequence
+		}
 
-	s.swapsLock.Lock()
-	defer s.swapsLock.Unlock()
-
-	// We can just use the server's in-memory cache as that contains the
-	// most up-to-date state including temporary failures which aren't
-	// persisted to disk. The swaps field is a map, that's why we need an
-	// additional index.
-	for _, swp := range s.swaps {
-		swp := swp
-		rpcSwaps[idx], err = s.marshallSwap(&swp)
-		if err != nil {
-			return nil, err
+		if startSequence == s.lastSequence || startSequence+1 >= oldest {
+			var replay []swapUpdate
+			for _, update := range s.recentUpdates {
+				if update.sequence > startSequence {
+					replay = append(replay, update)
+				}
+			}
+
+			return replay
 		}
-		idx++
 	}
-	return &looprpc.ListSwapsResponse{Swaps: rpcSwaps}, nil
-}
 
-// SwapInfo returns all known details about a single swap.
-func (s *swapClientServer) SwapInfo(_ context.Context,
-	req *looprpc.SwapInfoRequest) (*looprpc.SwapStatus, error) {
+	var snapshot []swapUpdate
+	for _, swap := range s.swaps {
+		if swap.State.Type() != loopdb.StateTypePending {
+			continue
+		}
 
-	swapHash, err := lntypes.MakeHash(req.Id)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing swap hash: %v", err)
+		snapshot = append(snapshot, swapUpdate{
+			SwapInfo: swap,
+			sequence: s.lastSequence,
+			snapshot: true,
+		})
 	}
 
-	// Just return the server's in-memory cache here too as we also want to
-	// return temporary failures to the client.
-	swp, ok := s.swaps[swapHash]
-	if !ok {
-		return nil, fmt.Errorf("swap with hash %s not found", req.Id)
-	}
-	return s.marshallSwap(&swp)
+	// Sort our snapshot old to new.
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].LastUpdate.Before(snapshot[j].LastUpdate)
+	})
+
+	return snapshot
 }
 
-// LoopOutTerms returns the terms that the server enforces for loop out swaps.
-func (s *swapClientServer) LoopOutTerms(ctx context.Context,
-	req *looprpc.TermsRequest) (*looprpc.OutTermsResponse, error) {
+// newMonitorEpoch returns a new epoch for the sequence numbers of the monitor
+// stream. We use the startup time of the process according to the clock
+// provided, which differs across restarts.
+func newMonitorEpoch(clock clock.Clock) uint64 {
+	return uint64(clock.Now().UnixNano())
+}
 
-	log.Infof("Loop out terms request received")
+// historicalUpdates returns the terminal state transitions of the swaps
+// provided that happened at or after the time provided, sorted old to new.
+func historicalUpdates(swaps []*loop.SwapInfo,
+	since time.Time) []swapUpdate {
 
-	terms, err := s.impl.LoopOutTerms(ctx)
-	if err != nil {
-		log.Errorf("Terms request: %v", err)
-		return nil, err
+	var updates []swapUpdate
+	for _, swap := range swaps {
+		if swap.State.Type() == loopdb.StateTypePending {
+			continue
+		}
+
+		if swap.LastUpdate.Before(since) {
+			continue
+		}
+
+		updates = append(updates, swapUpdate{
+			SwapInfo: *swap,
+			replay:   true,
+		})
 	}
 
-	return &looprpc.OutTermsResponse{
-		MinSwapAmount: int64(terms.MinSwapAmount),
-		MaxSwapAmount: int64(terms.MaxSwapAmount),
-		MinCltvDelta:  terms.MinCltvDelta,
-		MaxCltvDelta:  terms.MaxCltvDelta,
-	}, nil
-}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].LastUpdate.Before(updates[j].LastUpdate)
+	})
 
-// LoopOutQuote returns a quote for a loop out swap with the provided
-// parameters.
-func (s *swapClientServer) LoopOutQuote(ctx context.Context,
-	req *looprpc.QuoteRequest) (*looprpc.OutQuoteResponse, error) {
+	return updates
+}
 
-	confTarget, err := validateConfTarget(
-		req.ConfTarget, loop.DefaultSweepConfTarget,
-	)
+// legacyMonitorSwaps returns all pending swaps and our most recently completed
+// swaps, as delivered by the monitor stream before snapshots were added.
+//
+// NOTE: The swaps lock must be held when calling this function.
+func (s *swapClientServer) legacyMonitorSwaps() []swapUpdate {
+	var pendingSwaps, completedSwaps []loop.SwapInfo
+	for _, swap := range s.swaps {
+		if swap.State.Type() == loopdb.StateTypePending {
+			pendingSwaps = append(pendingSwaps, swap)
+		} else {
+			completedSwaps = append(completedSwaps, swap)
+		}
+	}
+
+	// Sort completed swaps new to old.
+	sort.Slice(completedSwaps, func(i, j int) bool {
+		return completedSwaps[i].LastUpdate.After(
+			completedSwaps[j].LastUpdate,
+		)
+	})
+
+	// Discard all but top x latest.
+	if len(completedSwaps) > completedSwapsCount {
+		completedSwaps = completedSwaps[:completedSwapsCount]
+	}
+
+	// Concatenate both sets.
+	filteredSwaps := append(pendingSwaps, completedSwaps...)
+
+	// Sort again, but this time old to new.
+	sort.Slice(filteredSwaps, func(i, j int) bool {
+		return filteredSwaps[i].LastUpdate.Before(
+			filteredSwaps[j].LastUpdate,
+		)
+	})
+
+	updates := make([]swapUpdate, len(filteredSwaps))
+	for i, swap := range filteredSwaps {
+		updates[i] = swapUpdate{
+			SwapInfo: swap,
+		}
+	}
+
+	return updates
+}
+
+// ListSwaps returns a list of all currently known swaps that match the
+// request's filters and their current status.
+func (s *swapClientServer) ListSwaps(ctx context.Context,
+	req *looprpc.ListSwapsRequest) (*looprpc.ListSwapsResponse, error) {
+
+	if req.EndTimeNs != 0 && req.EndTimeNs < req.StartTimeNs {
+		return nil, fmt.Errorf("end time: %v before start time: %v",
+			req.EndTimeNs, req.StartTimeNs)
+	}
+
+	swapTypes, filter, err := unmarshallListSwapsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Our filters are applied while the store is scanned, so that we only
+	// load and marshal the swaps that match them.
+	swaps, err := s.impl.FilterSwaps(ctx, swapTypes, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	s.swapsLock.Lock()
+	swaps = mergeTemporaryFailures(swaps, s.swaps, swapTypes, filter)
+	s.swapsLock.Unlock()
+
+	rpcSwaps := make([]*looprpc.SwapStatus, 0, len(swaps))
+	for _, swp := range swaps {
+		rpcSwap, err := s.marshallSwap(swp)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcSwaps = append(rpcSwaps, rpcSwap)
+	}
+
+	// Sort our swaps by initiation time so that pagination is stable
+	// across calls. We break ties using the swap hash.
+	sort.Slice(rpcSwaps, func(i, j int) bool {
+		if rpcSwaps[i].InitiationTime == rpcSwaps[j].InitiationTime {
+			return rpcSwaps[i].Id < rpcSwaps[j].Id
+		}
+
+		return rpcSwaps[i].InitiationTime < rpcSwaps[j].InitiationTime
+	})
+
+	total := uint64(len(rpcSwaps))
+
+	start := req.Offset
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if req.Limit != 0 && start+req.Limit < end {
+		end = start + req.Limit
+	}
+
+	return &looprpc.ListSwapsResponse{
+		Swaps:      rpcSwaps[start:end],
+		TotalSwaps: total,
+	}, nil
+}
+
+// unmarshallListSwapsFilter converts the filters set in a list swaps request
+// to the swap types and store filter that they correspond to.
+func unmarshallListSwapsFilter(req *looprpc.ListSwapsRequest) ([]swap.Type,
+	*loopdb.SwapFilter, error) {
+
+	var swapTypes []swap.Type
+	for _, swapType := range req.SwapTypes {
+		switch swapType {
+		case looprpc.SwapType_LOOP_OUT:
+			swapTypes = append(swapTypes, swap.TypeOut)
+
+		case looprpc.SwapType_LOOP_IN:
+			swapTypes = append(swapTypes, swap.TypeIn)
+
+		default:
+			return nil, nil, fmt.Errorf("unknown swap type: %v",
+				swapType)
+		}
+	}
+
+	filter := &loopdb.SwapFilter{
+		Label: req.Label,
+	}
+
+	// All of our failure states are reported as a single failed state
+	// over rpc, so a failed state matches all of them.
+	for _, state := range req.States {
+		switch state {
+		case looprpc.SwapState_INITIATED:
+			filter.States = append(
+				filter.States, loopdb.StateInitiated,
+			)
+
+		case looprpc.SwapState_PREIMAGE_REVEALED:
+			filter.States = append(
+				filter.States, loopdb.StatePreimageRevealed,
+			)
+
+		case looprpc.SwapState_HTLC_PUBLISHED:
+			filter.States = append(
+				filter.States, loopdb.StateHtlcPublished,
+			)
+
+		case looprpc.SwapState_INVOICE_SETTLED:
+			filter.States = append(
+				filter.States, loopdb.StateInvoiceSettled,
+			)
+
+		case looprpc.SwapState_SUCCESS:
+			filter.States = append(
+				filter.States, loopdb.StateSuccess,
+			)
+
+		case looprpc.SwapState_FAILED:
+			filter.States = append(
+				filter.States,
+				loopdb.StateFailOffchainPayments,
+				loopdb.StateFailTimeout,
+				loopdb.StateFailSweepTimeout,
+				loopdb.StateFailInsufficientValue,
+				loopdb.StateFailTemporary,
+				loopdb.StateFailIncorrectHtlcAmt,
+				loopdb.StateFailAbandoned,
+			)
+
+		default:
+			return nil, nil, fmt.Errorf("unknown swap state: %v",
+				state)
+		}
+	}
+
+	if req.StartTimeNs != 0 {
+		filter.StartTime = time.Unix(0, req.StartTimeNs)
+	}
+
+	if req.EndTimeNs != 0 {
+		filter.EndTime = time.Unix(0, req.EndTimeNs)
+	}
+
+	return swapTypes, filter, nil
+}
+
+// mergeTemporaryFailures updates the swaps that were read from the store with
+// the temporary failures in our in-memory cache. Temporary failures are not
+// persisted, so the store filters these swaps on their last persisted state
+// instead. We replace the state of swaps that are temporarily failed, dropping
+// them if they no longer match our filter, and add the temporarily failed
+// swaps that the store left out.
+func mergeTemporaryFailures(stored []*loop.SwapInfo,
+	cached map[lntypes.Hash]loop.SwapInfo, swapTypes []swap.Type,
+	filter *loopdb.SwapFilter) []*loop.SwapInfo {
+
+	isTempFailed := func(hash lntypes.Hash) (*loop.SwapInfo, bool) {
+		swp, ok := cached[hash]
+		if !ok || swp.State != loopdb.StateFailTemporary {
+			return nil, false
+		}
+
+		return &swp, true
+	}
+
+	var (
+		swaps = make([]*loop.SwapInfo, 0, len(stored))
+		seen  = make(map[lntypes.Hash]struct{}, len(stored))
+	)
+	for _, swp := range stored {
+		seen[swp.SwapHash] = struct{}{}
+
+		tempFailed, ok := isTempFailed(swp.SwapHash)
+		if !ok {
+			swaps = append(swaps, swp)
+			continue
+		}
+
+		if filter.MatchesState(tempFailed.State) {
+			swaps = append(swaps, tempFailed)
+		}
+	}
+
+	for hash := range cached {
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+
+		tempFailed, ok := isTempFailed(hash)
+		if !ok || !loopdb.HasSwapType(swapTypes, tempFailed.SwapType) {
+			continue
+		}
+
+		if filter.Matches(&tempFailed.SwapContract, tempFailed.State) {
+			swaps = append(swaps, tempFailed)
+		}
+	}
+
+	return swaps
+}
+
+// SwapInfo returns all known details about a single swap.
+func (s *swapClientServer) SwapInfo(_ context.Context,
+	req *looprpc.SwapInfoRequest) (*looprpc.SwapStatus, error) {
+
+	swapHash, err := lntypes.MakeHash(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing swap hash: %v", err)
+	}
+
+	// Just return the server's in-memory cache here too as we also want to
+	// return temporary failures to the client.
+	swp, ok := s.swaps[swapHash]
+	if !ok {
+		return nil, fmt.Errorf("swap with hash %s not found", req.Id)
+	}
+	return s.marshallSwap(&swp)
+}
+
+// AbandonSwap stops execution of a pending swap and marks it as failed.
+func (s *swapClientServer) AbandonSwap(ctx context.Context,
+	req *looprpc.AbandonSwapRequest) (*looprpc.AbandonSwapResponse, error) {
+
+	if !req.IKnowWhatIAmDoing {
+		return nil, status.Error(codes.InvalidArgument, "abandoning a "+
+			"swap stops loopd from sweeping its on-chain htlc, "+
+			"confirm that you understand this by setting "+
+			"i_know_what_i_am_doing")
+	}
+
+	swapHash, err := lntypes.MakeHash(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing swap hash: %v", err)
+	}
+
+	log.Infof("Abandon swap request received for swap: %v", swapHash)
+
+	if err := s.impl.AbandonSwap(ctx, swapHash); err != nil {
+		return nil, err
+	}
+
+	return &looprpc.AbandonSwapResponse{}, nil
+}
+
+// RecoverSweep manually sweeps the on-chain htlc of a swap using the data in
+// our store.
+func (s *swapClientServer) RecoverSweep(ctx context.Context,
+	req *looprpc.RecoverSweepRequest) (*looprpc.RecoverSweepResponse,
+	error) {
+
+	swapHash, err := lntypes.MakeHash(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing swap hash: %v", err)
+	}
+
+	if req.SatPerVbyte == 0 {
+		return nil, status.Error(codes.InvalidArgument, "fee rate "+
+			"required")
+	}
+
+	var destAddr btcutil.Address
+	if req.Dest != "" {
+		destAddr, err = btcutil.DecodeAddress(
+			req.Dest, s.lnd.ChainParams,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("decode address: %v", err)
+		}
+
+		if !destAddr.IsForNet(s.lnd.ChainParams) {
+			return nil, fmt.Errorf("%w: Current active network "+
+				"is %s", errIncorrectChain,
+				s.lnd.ChainParams.Name)
+		}
+	}
+
+	log.Infof("Recover sweep request received for swap: %v", swapHash)
+
+	feeRate := chainfee.SatPerKVByte(req.SatPerVbyte * 1000)
+
+	result, err := s.impl.RecoverSweep(ctx, &loop.RecoverSweepRequest{
+		SwapHash: swapHash,
+		DestAddr: destAddr,
+		FeeRate:  feeRate.FeePerKWeight(),
+		Publish:  req.Publish,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sweepTx bytes.Buffer
+	if err := result.SweepTx.Serialize(&sweepTx); err != nil {
+		return nil, err
+	}
+
+	return &looprpc.RecoverSweepResponse{
+		Txid:         result.SweepTx.TxHash().String(),
+		SweepTx:      sweepTx.Bytes(),
+		HtlcOutpoint: result.HtlcOutpoint.String(),
+		HtlcValueSat: int64(result.HtlcValue),
+		FeeSat:       int64(result.Fee),
+		Dest:         result.DestAddr.String(),
+		Published:    result.Published,
+	}, nil
+}
+
+// PruneSwaps deletes all swaps that reached a final state more than the
+// retention period requested ago from our database.
+func (s *swapClientServer) PruneSwaps(ctx context.Context,
+	req *looprpc.PruneSwapsRequest) (*looprpc.PruneSwapsResponse, error) {
+
+	retention := defaultPruneRetention
+	if req.RetentionSec != 0 {
+		retention = time.Duration(req.RetentionSec) * time.Second
+	}
+
+	if err := validatePruneRetention(retention); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pruned, err := s.impl.PruneSwaps(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the swaps from the set of swaps that we serve, so that they
+	// are no longer listed.
+	resp := &looprpc.PruneSwapsResponse{
+		PrunedSwaps: make([][]byte, 0, len(pruned)),
+	}
+
+	s.swapsLock.Lock()
+	for _, hash := range pruned {
+		hash := hash
+
+		delete(s.swaps, hash)
+		resp.PrunedSwaps = append(resp.PrunedSwaps, hash[:])
+	}
+	s.swapsLock.Unlock()
+
+	log.Infof("Pruned %v swaps that completed more than %v ago",
+		len(pruned), retention)
+
+	return resp, nil
+}
+
+// ExportSwaps serializes all the swaps in our database to a portable JSON
+// format.
+func (s *swapClientServer) ExportSwaps(ctx context.Context,
+	_ *looprpc.ExportSwapsRequest) (*looprpc.ExportSwapsResponse, error) {
+
+	data, err := s.impl.ExportSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.ExportSwapsResponse{
+		Export: data,
+	}, nil
+}
+
+// ImportSwaps adds the swaps in an export to our database. Swaps that we
+// already have are skipped.
+func (s *swapClientServer) ImportSwaps(ctx context.Context,
+	req *looprpc.ImportSwapsRequest) (*looprpc.ImportSwapsResponse, error) {
+
+	result, err := s.impl.ImportSwaps(ctx, req.Export)
+	switch {
+	case errors.Is(err, loopdb.ErrExportChecksum),
+		errors.Is(err, loopdb.ErrExportVersion),
+		errors.Is(err, loopdb.ErrExportNetwork):
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+
+	case err != nil:
+		return nil, err
+	}
+
+	// Add the swaps that we imported to the set of swaps that we serve so
+	// that they are listed. Pending swaps are only executed once we have
+	// been restarted.
+	swaps, err := s.impl.FetchSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending int
+	s.swapsLock.Lock()
+	for _, swp := range swaps {
+		if _, ok := s.swaps[swp.SwapHash]; ok {
+			continue
+		}
+
+		s.swaps[swp.SwapHash] = *swp
+		if swp.State.Type() == loopdb.StateTypePending {
+			pending++
+		}
+	}
+	s.swapsLock.Unlock()
+
+	log.Infof("Imported %v swaps, skipped %v existing swaps",
+		result.Imported, result.Skipped)
+
+	if pending > 0 {
+		log.Warnf("Imported %v pending swaps, restart loopd to "+
+			"resume them", pending)
+	}
+
+	return &looprpc.ImportSwapsResponse{
+		Imported:        uint32(result.Imported),
+		Skipped:         uint32(result.Skipped),
+		PendingImported: uint32(pending),
+	}, nil
+}
+
+// SaveSwapTemplate stores a named loop out template, replacing any existing
+// template with the same name.
+func (s *swapClientServer) SaveSwapTemplate(_ context.Context,
+	req *looprpc.SaveSwapTemplateRequest) (*looprpc.SaveSwapTemplateResponse,
+	error) {
+
+	if req.Template == nil {
+		return nil, status.Error(
+			codes.InvalidArgument, "swap template required",
+		)
+	}
+
+	template := rpcToSwapTemplate(req.Template)
+	if err := template.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.impl.SaveSwapTemplate(template); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Saved swap template: %v", template.Name)
+
+	return &looprpc.SaveSwapTemplateResponse{}, nil
+}
+
+// ListSwapTemplates returns all of our loop out templates, ordered by name.
+func (s *swapClientServer) ListSwapTemplates(_ context.Context,
+	_ *looprpc.ListSwapTemplatesRequest) (*looprpc.ListSwapTemplatesResponse,
+	error) {
+
+	templates, err := s.impl.FetchSwapTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.ListSwapTemplatesResponse{
+		Templates: make([]*looprpc.SwapTemplate, 0, len(templates)),
+	}
+
+	for _, template := range templates {
+		resp.Templates = append(
+			resp.Templates, rpcSwapTemplate(template),
+		)
+	}
+
+	return resp, nil
+}
+
+// DeleteSwapTemplate deletes a loop out template.
+func (s *swapClientServer) DeleteSwapTemplate(_ context.Context,
+	req *looprpc.DeleteSwapTemplateRequest) (
+	*looprpc.DeleteSwapTemplateResponse, error) {
+
+	err := s.impl.DeleteSwapTemplate(req.Name)
+	switch {
+	case errors.Is(err, loopdb.ErrSwapTemplateNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+
+	case err != nil:
+		return nil, err
+	}
+
+	log.Infof("Deleted swap template: %v", req.Name)
+
+	return &looprpc.DeleteSwapTemplateResponse{}, nil
+}
+
+// LoopOutTerms returns the terms that the server enforces for loop out swaps.
+func (s *swapClientServer) LoopOutTerms(ctx context.Context,
+	req *looprpc.TermsRequest) (*looprpc.OutTermsResponse, error) {
+
+	log.Infof("Loop out terms request received")
+
+	terms, err := s.impl.LoopOutTerms(ctx)
+	if err != nil {
+		log.Errorf("Terms request: %v", err)
+		return nil, err
+	}
+
+	return &looprpc.OutTermsResponse{
+		MinSwapAmount: int64(terms.MinSwapAmount),
+		MaxSwapAmount: int64(terms.MaxSwapAmount),
+		MinCltvDelta:  terms.MinCltvDelta,
+		MaxCltvDelta:  terms.MaxCltvDelta,
+	}, nil
+}
+
+// LoopOutQuote returns a quote for a loop out swap with the provided
+// parameters.
+func (s *swapClientServer) LoopOutQuote(ctx context.Context,
+	req *looprpc.QuoteRequest) (*looprpc.OutQuoteResponse, error) {
+
+	confTarget, err := validateConfTarget(
+		req.ConfTarget, s.impl.ConfPolicy.SweepConfTarget(
+			btcutil.Amount(req.Amt), loop.DefaultSweepConfTarget,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if req.ServerConfTarget < 0 {
+		return nil, errServerConfTargetNegative
+	}
+
+	quote, err := s.impl.LoopOutQuote(ctx, &loop.LoopOutQuoteRequest{
+		Amount:          btcutil.Amount(req.Amt),
+		SweepConfTarget: confTarget,
+		SwapPublicationDeadline: time.Unix(
+			int64(req.SwapPublicationDeadline), 0,
+		),
+		ServerHtlcConfTarget: req.ServerConfTarget,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.OutQuoteResponse{
+		HtlcSweepFeeSat:  int64(quote.MinerFee),
+		PrepayAmtSat:     int64(quote.PrepayAmount),
+		SwapFeeSat:       int64(quote.SwapFee),
+		SwapPaymentDest:  quote.SwapPaymentDest[:],
+		ConfTarget:       confTarget,
+		ServerConfTarget: quote.ServerHtlcConfTarget,
+	}, nil
+}
+
+// GetTerms returns the terms that the server enforces for swaps.
+func (s *swapClientServer) GetLoopInTerms(ctx context.Context,
+	req *looprpc.TermsRequest) (*looprpc.InTermsResponse, error) {
+
+	log.Infof("Loop in terms request received")
+
+	terms, err := s.impl.LoopInTerms(ctx)
+	if err != nil {
+		log.Errorf("Terms request: %v", err)
+		return nil, err
+	}
+
+	return &looprpc.InTermsResponse{
+		MinSwapAmount: int64(terms.MinSwapAmount),
+		MaxSwapAmount: int64(terms.MaxSwapAmount),
+	}, nil
+}
+
+// GetQuote returns a quote for a swap with the provided parameters.
+func (s *swapClientServer) GetLoopInQuote(ctx context.Context,
+	req *looprpc.QuoteRequest) (*looprpc.InQuoteResponse, error) {
+
+	log.Infof("Loop in quote request received")
+
+	htlcConfTarget, err := validateLoopInQuoteRequest(
+		req.ConfTarget, btcutil.Amount(req.Amt), req.ExternalHtlc,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.impl.LoopInQuote(ctx, &loop.LoopInQuoteRequest{
+		Amount:         btcutil.Amount(req.Amt),
+		HtlcConfTarget: htlcConfTarget,
+		ExternalHtlc:   req.ExternalHtlc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &looprpc.InQuoteResponse{
+		HtlcPublishFeeSat: int64(quote.MinerFee),
+		SwapFeeSat:        int64(quote.SwapFee),
+		ConfTarget:        htlcConfTarget,
+	}, nil
+}
+
+func (s *swapClientServer) LoopIn(ctx context.Context,
+	in *looprpc.LoopInRequest) (
+	*looprpc.SwapResponse, error) {
+
+	log.Infof("Loop in request received")
+
+	req, err := unmarshallLoopInRequest(in, in.ExternalHtlc)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.duplicates.check(
+		newLoopInKey(req.Amount, req.LastHop), req.IdempotencyKey,
+		in.AllowDuplicate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	swapInfo, err := s.impl.LoopIn(ctx, req)
+	if err != nil {
+		release()
+
+		log.Errorf("Loop in: %v", err)
+		return nil, err
+	}
+
+	response := &looprpc.SwapResponse{
+		Id:               swapInfo.SwapHash.String(),
+		IdBytes:          swapInfo.SwapHash[:],
+		HtlcAddressP2Wsh: swapInfo.HtlcAddressP2WSH.String(),
+		ServerMessage:    swapInfo.ServerMessage,
+	}
+
+	if req.ExternalHtlc {
+		response.HtlcAddressNp2Wsh = swapInfo.HtlcAddressNP2WSH.String()
+		response.HtlcAddress = response.HtlcAddressNp2Wsh // nolint:staticcheck
+	} else {
+		response.HtlcAddress = response.HtlcAddressP2Wsh // nolint:staticcheck
+	}
+
+	return response, nil
+}
+
+// NewLoopInPsbt initiates a loop in swap that is funded by an external wallet
+// and returns a psbt template that funds its htlc.
+func (s *swapClientServer) NewLoopInPsbt(ctx context.Context,
+	in *looprpc.LoopInRequest) (*looprpc.LoopInPsbtResponse, error) {
+
+	log.Infof("Loop in psbt request received")
+
+	req, err := unmarshallLoopInRequest(in, true)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.duplicates.check(
+		newLoopInKey(req.Amount, req.LastHop), req.IdempotencyKey,
+		in.AllowDuplicate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.impl.LoopInPsbt(ctx, req)
+	if err != nil {
+		release()
+
+		log.Errorf("Loop in psbt: %v", err)
+		return nil, err
+	}
+
+	packet, err := loop.SerializePsbt(info.Psbt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.LoopInPsbtResponse{
+		IdBytes:          info.SwapHash[:],
+		HtlcAddressP2Wsh: info.HtlcAddressP2WSH.String(),
+		Amt:              int64(info.Amount),
+		Psbt:             packet,
+		ServerMessage:    info.ServerMessage,
+	}, nil
+}
+
+// unmarshallLoopInRequest validates a loop in rpc request and converts it to
+// our internal request. The external flag indicates whether the htlc of the
+// swap is published externally.
+func unmarshallLoopInRequest(in *looprpc.LoopInRequest,
+	external bool) (*loop.LoopInRequest, error) {
+
+	htlcConfTarget, err := validateLoopInRequest(
+		in.HtlcConfTarget, btcutil.Amount(in.Amt), external,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the label is valid.
+	if err := labels.Validate(in.Label); err != nil {
+		return nil, err
+	}
+
+	req := &loop.LoopInRequest{
+		Amount:         btcutil.Amount(in.Amt),
+		MaxMinerFee:    btcutil.Amount(in.MaxMinerFee),
+		MaxSwapFee:     btcutil.Amount(in.MaxSwapFee),
+		HtlcConfTarget: htlcConfTarget,
+		ExternalHtlc:   external,
+		Label:          in.Label,
+		Initiator:      in.Initiator,
+		IdempotencyKey: in.IdempotencyKey,
+	}
+	if in.LastHop != nil {
+		lastHop, err := route.NewVertexFromBytes(in.LastHop)
+		if err != nil {
+			return nil, err
+		}
+		req.LastHop = &lastHop
+	}
+
+	if in.Private && len(in.RouteHints) != 0 {
+		return nil, loop.ErrPrivateRouteHints
+	}
+	req.Private = in.Private
+
+	routeHints, err := unmarshallRouteHints(in.RouteHints)
+	if err != nil {
+		return nil, err
+	}
+	req.RouteHints = routeHints
+
+	return req, nil
+}
+
+// GetLsatTokens returns all tokens that are contained in the LSAT token store.
+func (s *swapClientServer) GetLsatTokens(ctx context.Context,
+	_ *looprpc.TokensRequest) (*looprpc.TokensResponse, error) {
+
+	log.Infof("Get LSAT tokens request received")
+
+	tokens, err := s.impl.LsatStore.AllTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcTokens := make([]*looprpc.LsatToken, len(tokens))
+	idx := 0
+	for key, token := range tokens {
+		macBytes, err := token.BaseMacaroon().MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		rpcTokens[idx] = &looprpc.LsatToken{
+			BaseMacaroon:       macBytes,
+			PaymentHash:        token.PaymentHash[:],
+			PaymentPreimage:    token.Preimage[:],
+			AmountPaidMsat:     int64(token.AmountPaid),
+			RoutingFeePaidMsat: int64(token.RoutingFeePaid),
+			TimeCreated:        token.TimeCreated.Unix(),
+			Expired:            !token.IsValid(),
+			StorageName:        key,
+		}
+		idx++
+	}
+
+	return &looprpc.TokensResponse{Tokens: rpcTokens}, nil
+}
+
+// GetLiquidityParams gets our current liquidity manager's parameters.
+func (s *swapClientServer) GetLiquidityParams(ctx context.Context,
+	_ *looprpc.GetLiquidityParamsRequest) (*looprpc.LiquidityParameters,
+	error) {
+
+	// We read our version before our parameters, so that a concurrent
+	// change leaves the caller with a stale version, which fails any
+	// update they make, rather than a version that is newer than the
+	// parameters they were given.
+	version := s.liquidityMgr.ParametersVersion()
+
+	rpcCfg, err := newRPCLiquidityParams(s.liquidityMgr.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	rpcCfg.Version = version
+
+	budgets, err := s.liquidityMgr.RuleBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setRuleBudgets(rpcCfg.Rules, budgets)
+
+	pending := s.liquidityMgr.GetPendingParameters()
+	if pending == nil {
+		return rpcCfg, nil
+	}
+
+	rpcCfg.PendingParams, err = newRPCLiquidityParams(pending.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	rpcCfg.PendingEffectiveSec = uint64(pending.EffectiveAt.Unix())
+
+	return rpcCfg, nil
+}
+
+// setRuleBudgets reports the use of the fee budget of each rule that sets its
+// own budget. Budgets are matched to rules by their channel or peer.
+func setRuleBudgets(rules []*looprpc.LiquidityRule,
+	budgets map[liquidity.RuleID]*liquidity.RuleBudget) {
+
+	for _, rule := range rules {
+		var id liquidity.RuleID
+		if rule.ChannelId != 0 {
+			id.Channel = lnwire.NewShortChanIDFromInt(
+				rule.ChannelId,
+			)
+		} else {
+			copy(id.Peer[:], rule.Pubkey)
+		}
+
+		budget, ok := budgets[id]
+		if !ok {
+			continue
+		}
+
+		rule.FeeBudgetSpentSat = uint64(budget.Spent)
+		rule.FeeBudgetPendingSat = uint64(budget.Pending)
+	}
+}
+
+// newRPCLiquidityParams converts a set of liquidity manager parameters to
+// their rpc counterpart.
+func newRPCLiquidityParams(cfg liquidity.Parameters) (
+	*looprpc.LiquidityParameters, error) {
+
+	totalRules := len(cfg.ChannelRules) + len(cfg.PeerRules)
+
+	rpcCfg := &looprpc.LiquidityParameters{
+		SweepConfTarget:   cfg.SweepConfTarget,
+		FailureBackoffSec: uint64(cfg.FailureBackOff.Seconds()),
+		SwapCooldownSec:   uint64(cfg.SwapCooldown.Seconds()),
+		PublicationDeadlineSec: uint64(
+			cfg.PublicationDeadline.Seconds(),
+		),
+		Autoloop:          cfg.Autoloop,
+		AutoloopBudgetSat: uint64(cfg.AutoFeeBudget),
+		AutoMaxInFlight:   uint64(cfg.MaxAutoInFlight),
+		Rules: make(
+			[]*looprpc.LiquidityRule, 0, totalRules,
+		),
+		MinSwapAmount:      uint64(cfg.ClientRestrictions.Minimum),
+		MaxSwapAmount:      uint64(cfg.ClientRestrictions.Maximum),
+		PreferRebalance:    cfg.PreferRebalance,
+		LocalBalanceTarget: uint32(cfg.LocalBalanceTarget),
+		ScheduleStartHour:  uint32(cfg.Schedule.StartHour),
+		ScheduleEndHour:    uint32(cfg.Schedule.EndHour),
+		RoundUpToMinimum:   cfg.RoundUpToMinimum,
+
+		ExcludeInactiveChannels: cfg.ExcludeInactiveChannels,
+	}
+
+	for _, day := range cfg.Schedule.Days {
+		rpcCfg.ScheduleDays = append(rpcCfg.ScheduleDays, uint32(day))
+	}
+
+	if cfg.SweepFeeRateLimit != 0 {
+		satPerByte := cfg.SweepFeeRateLimit.FeePerKVByte() / 1000
+		rpcCfg.SweepFeeRateLimitSatPerVbyte = uint64(satPerByte)
+	}
+
+	switch f := cfg.FeeLimit.(type) {
+	case *liquidity.FeeCategoryLimit:
+		satPerByte := f.SweepFeeRateLimit.FeePerKVByte() / 1000
+
+		rpcCfg.SweepFeeRateSatPerVbyte = uint64(satPerByte)
+
+		rpcCfg.MaxMinerFeeSat = uint64(f.MaximumMinerFee)
+		rpcCfg.MaxSwapFeePpm = f.MaximumSwapFeePPM
+		rpcCfg.MaxRoutingFeePpm = f.MaximumRoutingFeePPM
+		rpcCfg.MaxPrepayRoutingFeePpm = f.MaximumPrepayRoutingFeePPM
+		rpcCfg.MaxPrepaySat = uint64(f.MaximumPrepay)
+
+	case *liquidity.FeePortion:
+		rpcCfg.FeePpm = f.PartsPerMillion
+
+	default:
+		return nil, fmt.Errorf("unknown fee limit: %T", cfg.FeeLimit)
+	}
+
+	// Zero golang time is different to a zero unix time, so we only set
+	// our start date if it is non-zero.
+	if !cfg.AutoFeeStartDate.IsZero() {
+		rpcCfg.AutoloopBudgetStartSec = uint64(
+			cfg.AutoFeeStartDate.Unix(),
+		)
+	}
+
+	for channel, rule := range cfg.ChannelRules {
+		rpcRule := newRPCRule(channel.ToUint64(), nil, rule)
+		rpcCfg.Rules = append(rpcCfg.Rules, rpcRule)
+	}
+
+	for peer, rule := range cfg.PeerRules {
+		peer := peer
+		rpcRule := newRPCRule(0, peer[:], rule)
+		rpcCfg.Rules = append(rpcCfg.Rules, rpcRule)
+	}
+
+	for channel := range cfg.DisqualifiedChannels {
+		rpcCfg.DisqualifiedChannels = append(
+			rpcCfg.DisqualifiedChannels, channel.ToUint64(),
+		)
+	}
+
+	for peer := range cfg.DisqualifiedPeers {
+		peer := peer
+		rpcCfg.DisqualifiedPeers = append(
+			rpcCfg.DisqualifiedPeers, peer[:],
+		)
+	}
+
+	return rpcCfg, nil
+}
+
+func newRPCRule(channelID uint64, peer []byte,
+	rule *liquidity.ThresholdRule) *looprpc.LiquidityRule {
+
+	// We still report our thresholds as whole percentages for clients
+	// that do not know about our ppm thresholds.
+	var (
+		incomingPercent = rule.MinimumIncomingPPM * 100 /
+			liquidity.ThresholdBase
+		outgoingPercent = rule.MinimumOutgoingPPM * 100 /
+			liquidity.ThresholdBase
+	)
+
+	rpcRule := &looprpc.LiquidityRule{
+		ChannelId:            channelID,
+		Pubkey:               peer,
+		Type:                 looprpc.LiquidityRuleType_THRESHOLD,
+		IncomingThreshold:    uint32(incomingPercent),
+		OutgoingThreshold:    uint32(outgoingPercent),
+		IncomingThresholdPpm: uint32(rule.MinimumIncomingPPM),
+		OutgoingThresholdPpm: uint32(rule.MinimumOutgoingPPM),
+		SweepConfTarget:      rule.SweepConfTarget,
+		HtlcConfTarget:       rule.HtlcConfTarget,
+		SwapType:             looprpc.SwapType_LOOP_OUT,
+		MinImbalanceDurationSec: uint64(
+			rule.MinimumImbalanceDuration.Seconds(),
+		),
+		MinReputation:      uint32(rule.MinimumReputation),
+		LargeSwapAmountSat: uint64(rule.LargeSwapAmount),
+		FeeBudgetSat:       uint64(rule.FeeBudget),
+		FeeBudgetPeriodSec: uint64(rule.FeeBudgetPeriod.Seconds()),
+	}
+
+	if rule.Type == swap.TypeIn {
+		rpcRule.SwapType = looprpc.SwapType_LOOP_IN
+	}
+
+	if rule.TargetLocalPPM != 0 {
+		rpcRule.Type = looprpc.LiquidityRuleType_TARGET
+		rpcRule.TargetLocalPpm = uint32(rule.TargetLocalPPM)
+		rpcRule.TolerancePpm = uint32(rule.TolerancePPM)
+	}
+
+	return rpcRule
+}
+
+// SetLiquidityParams attempts to set our current liquidity manager's
+// parameters.
+func (s *swapClientServer) SetLiquidityParams(ctx context.Context,
+	in *looprpc.SetLiquidityParamsRequest) (*looprpc.SetLiquidityParamsResponse,
+	error) {
+
+	params, err := rpcToLiquidityParams(in.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.liquidityMgr.SetParameters(ctx, params); err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.SetLiquidityParamsResponse{}
+
+	// If our parameters were held as a pending change, we let the caller
+	// know when they will take effect.
+	if pending := s.liquidityMgr.GetPendingParameters(); pending != nil {
+		resp.EffectiveSec = uint64(pending.EffectiveAt.Unix())
+	}
+
+	return resp, nil
+}
+
+// rpcToLiquidityParams converts a set of rpc liquidity parameters to their
+// liquidity manager counterpart.
+func rpcToLiquidityParams(in *looprpc.LiquidityParameters) (
+	liquidity.Parameters, error) {
+
+	if in == nil {
+		return liquidity.Parameters{}, errors.New("parameters required")
+	}
+
+	feeLimit, err := rpcToFee(in)
+	if err != nil {
+		return liquidity.Parameters{}, err
+	}
+
+	params := liquidity.Parameters{
+		FeeLimit:        feeLimit,
+		SweepConfTarget: in.SweepConfTarget,
+		FailureBackOff:  time.Duration(in.FailureBackoffSec) * time.Second,
+		SwapCooldown:    time.Duration(in.SwapCooldownSec) * time.Second,
+		PublicationDeadline: time.Duration(
+			in.PublicationDeadlineSec,
+		) * time.Second,
+		Autoloop:        in.Autoloop,
+		AutoFeeBudget:   btcutil.Amount(in.AutoloopBudgetSat),
+		MaxAutoInFlight: int(in.AutoMaxInFlight),
+		ChannelRules: make(
+			map[lnwire.ShortChannelID]*liquidity.ThresholdRule,
+		),
+		PeerRules: make(
+			map[route.Vertex]*liquidity.ThresholdRule,
+		),
+		DisqualifiedChannels: make(
+			map[lnwire.ShortChannelID]struct{},
+		),
+		DisqualifiedPeers: make(map[route.Vertex]struct{}),
+		ClientRestrictions: liquidity.Restrictions{
+			Minimum: btcutil.Amount(in.MinSwapAmount),
+			Maximum: btcutil.Amount(in.MaxSwapAmount),
+		},
+		PreferRebalance:    in.PreferRebalance,
+		LocalBalanceTarget: int(in.LocalBalanceTarget),
+		Schedule: liquidity.Schedule{
+			StartHour: int(in.ScheduleStartHour),
+			EndHour:   int(in.ScheduleEndHour),
+		},
+		RoundUpToMinimum:        in.RoundUpToMinimum,
+		ExcludeInactiveChannels: in.ExcludeInactiveChannels,
+	}
+
+	for _, day := range in.ScheduleDays {
+		params.Schedule.Days = append(
+			params.Schedule.Days, time.Weekday(day),
+		)
+	}
+
+	if in.SweepFeeRateLimitSatPerVbyte != 0 {
+		satPerVbyte := chainfee.SatPerKVByte(
+			in.SweepFeeRateLimitSatPerVbyte * 1000,
+		)
+		params.SweepFeeRateLimit = satPerVbyte.FeePerKWeight()
+	}
+
+	// Zero unix time is different to zero golang time.
+	if in.AutoloopBudgetStartSec != 0 {
+		params.AutoFeeStartDate = time.Unix(
+			int64(in.AutoloopBudgetStartSec), 0,
+		)
+	}
+
+	for _, rule := range in.Rules {
+		peerRule := rule.Pubkey != nil
+		chanRule := rule.ChannelId != 0
+
+		liquidityRule, err := rpcToRule(rule)
+		if err != nil {
+			return liquidity.Parameters{}, err
+		}
+
+		switch {
+		case peerRule && chanRule:
+			return liquidity.Parameters{}, fmt.Errorf("cannot "+
+				"set channel: %v and peer: %v fields in rule",
+				rule.ChannelId, rule.Pubkey)
+
+		case peerRule:
+			pubkey, err := route.NewVertexFromBytes(rule.Pubkey)
+			if err != nil {
+				return liquidity.Parameters{}, err
+			}
+
+			if _, ok := params.PeerRules[pubkey]; ok {
+				return liquidity.Parameters{}, fmt.Errorf(
+					"multiple rules set for peer: %v",
+					pubkey,
+				)
+			}
+
+			params.PeerRules[pubkey] = liquidityRule
+
+		case chanRule:
+			shortID := lnwire.NewShortChanIDFromInt(rule.ChannelId)
+
+			if _, ok := params.ChannelRules[shortID]; ok {
+				return liquidity.Parameters{}, fmt.Errorf(
+					"multiple rules set for channel: %v",
+					shortID,
+				)
+			}
+
+			params.ChannelRules[shortID] = liquidityRule
+
+		default:
+			return liquidity.Parameters{}, errors.New("please " +
+				"set channel id or pubkey for rule")
+		}
+	}
+
+	for _, channel := range in.DisqualifiedChannels {
+		shortID := lnwire.NewShortChanIDFromInt(channel)
+		params.DisqualifiedChannels[shortID] = struct{}{}
+	}
+
+	for _, peer := range in.DisqualifiedPeers {
+		pubkey, err := route.NewVertexFromBytes(peer)
+		if err != nil {
+			return liquidity.Parameters{}, err
+		}
+
+		params.DisqualifiedPeers[pubkey] = struct{}{}
+	}
+
+	return params, nil
+}
+
+var (
+	// liquidityParamsReadOnly is the set of liquidity parameter fields
+	// that are only set in responses, and may not be updated.
+	liquidityParamsReadOnly = map[string]struct{}{
+		"pending_params":        {},
+		"pending_effective_sec": {},
+		"version":               {},
+	}
+
+	// feePortionField is the liquidity parameter field that sets our fee
+	// limit as a portion of the swap amount.
+	feePortionField = "fee_ppm"
+
+	// feeCategoryFields is the set of liquidity parameter fields that set
+	// our fee limits per fee category.
+	feeCategoryFields = map[string]struct{}{
+		"max_swap_fee_ppm":             {},
+		"max_routing_fee_ppm":          {},
+		"max_prepay_routing_fee_ppm":   {},
+		"max_miner_fee_sat":            {},
+		"max_prepay_sat":               {},
+		"sweep_fee_rate_sat_per_vbyte": {},
+	}
+)
+
+// UpdateLiquidityParams atomically updates the fields of our liquidity
+// manager's parameters that are listed in the request, leaving all other
+// parameters unchanged. If a version is provided, the update fails if our
+// parameters have changed since that version.
+func (s *swapClientServer) UpdateLiquidityParams(ctx context.Context,
+	in *looprpc.UpdateLiquidityParamsRequest) (
+	*looprpc.UpdateLiquidityParamsResponse, error) {
+
+	if len(in.UpdateFields) == 0 {
+		return nil, status.Error(codes.InvalidArgument,
+			"at least one field to update required")
+	}
+
+	// If no parameters are provided, the fields listed are cleared.
+	update := in.Parameters
+	if update == nil {
+		update = &looprpc.LiquidityParameters{}
+	}
+
+	version, err := s.liquidityMgr.UpdateParameters(
+		ctx, in.Version, func(current liquidity.Parameters) (
+			liquidity.Parameters, error) {
+
+			rpcParams, err := newRPCLiquidityParams(current)
+			if err != nil {
+				return liquidity.Parameters{}, err
+			}
+
+			err = mergeLiquidityParams(
+				rpcParams, update, in.UpdateFields,
+			)
+			if err != nil {
+				return liquidity.Parameters{}, err
+			}
+
+			return rpcToLiquidityParams(rpcParams)
+		},
+	)
+	if errors.Is(err, liquidity.ErrParamsVersionMismatch) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.UpdateLiquidityParamsResponse{
+		Version: version,
+	}
+
+	// If our parameters were held as a pending change, we let the caller
+	// know when they will take effect.
+	if pending := s.liquidityMgr.GetPendingParameters(); pending != nil {
+		resp.EffectiveSec = uint64(pending.EffectiveAt.Unix())
+	}
+
+	return resp, nil
+}
+
+// mergeLiquidityParams copies the fields listed from our update to our current
+// set of parameters. Fields that are listed but not set in our update are
+// cleared. Since our fee portion and fee categories are exclusive, updating
+// one type of fee limit clears the other type, unless it is also updated.
+func mergeLiquidityParams(current, update *looprpc.LiquidityParameters,
+	fields []string) error {
+
+	var (
+		currentMsg  = current.ProtoReflect()
+		updateMsg   = update.ProtoReflect()
+		descriptors = currentMsg.Descriptor().Fields()
+
+		portionSet, categoriesSet bool
+	)
+
+	for _, name := range fields {
+		field := descriptors.ByName(protoreflect.Name(name))
+		if field == nil {
+			return status.Errorf(codes.InvalidArgument, "unknown "+
+				"liquidity parameter: %v", name)
+		}
+
+		if _, ok := liquidityParamsReadOnly[name]; ok {
+			return status.Errorf(codes.InvalidArgument, "liquidity "+
+				"parameter: %v cannot be updated", name)
+		}
+
+		if name == feePortionField {
+			portionSet = true
+		}
+
+		if _, ok := feeCategoryFields[name]; ok {
+			categoriesSet = true
+		}
+
+		if updateMsg.Has(field) {
+			currentMsg.Set(field, updateMsg.Get(field))
+		} else {
+			currentMsg.Clear(field)
+		}
+	}
+
+	switch {
+	// If both types of fee limit are updated, we leave them as provided
+	// and rely on validation to reject them if they are both set.
+	case portionSet && categoriesSet:
+
+	case portionSet:
+		for name := range feeCategoryFields {
+			currentMsg.Clear(
+				descriptors.ByName(protoreflect.Name(name)),
+			)
+		}
+
+	case categoriesSet:
+		currentMsg.Clear(
+			descriptors.ByName(protoreflect.Name(feePortionField)),
+		)
+	}
+
+	return nil
+}
+
+// CancelLiquidityParams cancels a pending change to our liquidity manager's
+// parameters.
+func (s *swapClientServer) CancelLiquidityParams(_ context.Context,
+	_ *looprpc.CancelLiquidityParamsRequest) (
+	*looprpc.CancelLiquidityParamsResponse, error) {
+
+	err := s.liquidityMgr.CancelPendingParameters()
+	if err == liquidity.ErrNoPendingParams {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.CancelLiquidityParamsResponse{}, nil
+}
+
+// GetPeerReputations returns the reputations of the peers that we have
+// recorded swap outcomes for.
+func (s *swapClientServer) GetPeerReputations(ctx context.Context,
+	_ *looprpc.GetPeerReputationsRequest) (
+	*looprpc.GetPeerReputationsResponse, error) {
+
+	reputations, err := s.liquidityMgr.PeerReputations(ctx)
+	if err == liquidity.ErrReputationDisabled {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.GetPeerReputationsResponse{
+		Reputations: make(
+			[]*looprpc.PeerReputation, 0, len(reputations),
+		),
+	}
+
+	for peer, reputation := range reputations {
+		peer := peer
+
+		resp.Reputations = append(
+			resp.Reputations, &looprpc.PeerReputation{
+				Pubkey:      peer[:],
+				Swaps:       uint32(reputation.Swaps),
+				Successes:   uint32(reputation.Successes),
+				Score:       uint32(reputation.Score()),
+				FeeRatioPpm: reputation.FeeRatioPPM,
+				AvgCompletionSec: uint64(
+					reputation.AverageCompletion.Seconds(),
+				),
+			},
+		)
+	}
+
+	return resp, nil
+}
+
+// GetStaleRules returns the autoloop rules that are set for channels or peers
+// that have been inactive for at least our stale rule age.
+func (s *swapClientServer) GetStaleRules(_ context.Context,
+	_ *looprpc.GetStaleRulesRequest) (*looprpc.GetStaleRulesResponse,
+	error) {
+
+	rules, err := s.liquidityMgr.StaleRules()
+	if err == liquidity.ErrStaleRulesDisabled {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.GetStaleRulesResponse{
+		Rules: make([]*looprpc.StaleRule, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		rpcRule := &looprpc.StaleRule{
+			ChannelId:     rule.Channel.ToUint64(),
+			Reason:        looprpc.StaleReason_STALE_REASON_CLOSED,
+			InactiveSince: rule.InactiveSince.Unix(),
+		}
+
+		if rule.Reason == liquidity.StaleReasonDisconnected {
+			rpcRule.Reason =
+				looprpc.StaleReason_STALE_REASON_DISCONNECTED
+		}
+
+		// Channel rules do not set a peer, so we only set our pubkey
+		// for peer rules.
+		if rule.Channel.ToUint64() == 0 {
+			rpcRule.Pubkey = rule.Peer[:]
+		}
+
+		resp.Rules = append(resp.Rules, rpcRule)
+	}
+
+	return resp, nil
+}
+
+// rpcToFee converts the values provided over rpc to a fee limit interface,
+// failing if an inconsistent set of fields are set.
+func rpcToFee(req *looprpc.LiquidityParameters) (liquidity.FeeLimit,
+	error) {
+
+	// Check which fee limit type we have values set for. If any fields
+	// relevant to our individual categories are set, we count that type
+	// as set.
+	isFeePPM := req.FeePpm != 0
+	isCategories := req.MaxSwapFeePpm != 0 || req.MaxRoutingFeePpm != 0 ||
+		req.MaxPrepayRoutingFeePpm != 0 || req.MaxMinerFeeSat != 0 ||
+		req.MaxPrepaySat != 0 || req.SweepFeeRateSatPerVbyte != 0
+
+	switch {
+	case isFeePPM && isCategories:
+		return nil, errors.New("set either fee ppm, or individual " +
+			"fee categories")
+	case isFeePPM:
+		return liquidity.NewFeePortion(req.FeePpm), nil
+
+	case isCategories:
+		satPerVbyte := chainfee.SatPerKVByte(
+			req.SweepFeeRateSatPerVbyte * 1000,
+		)
+
+		return liquidity.NewFeeCategoryLimit(
+			req.MaxSwapFeePpm,
+			req.MaxRoutingFeePpm,
+			req.MaxPrepayRoutingFeePpm,
+			btcutil.Amount(req.MaxMinerFeeSat),
+			btcutil.Amount(req.MaxPrepaySat),
+			satPerVbyte.FeePerKWeight(),
+		), nil
+
+	default:
+		return nil, errors.New("no fee categories set")
+	}
+}
+
+// rpcToRule switches on rpc rule type to convert to our rule interface.
+func rpcToRule(rule *looprpc.LiquidityRule) (*liquidity.ThresholdRule, error) {
+	var thresholdRule *liquidity.ThresholdRule
+
+	switch rule.Type {
+	case looprpc.LiquidityRuleType_UNKNOWN:
+		return nil, fmt.Errorf("rule type field must be set")
+
+	case looprpc.LiquidityRuleType_THRESHOLD:
+		// Our ppm thresholds take precedence over the deprecated
+		// percentage thresholds, which are still accepted for backwards
+		// compatibility.
+		incoming := int(rule.IncomingThresholdPpm)
+		if incoming == 0 {
+			incoming = liquidity.PercentToPPM(
+				int(rule.IncomingThreshold),
+			)
+		}
+
+		outgoing := int(rule.OutgoingThresholdPpm)
+		if outgoing == 0 {
+			outgoing = liquidity.PercentToPPM(
+				int(rule.OutgoingThreshold),
+			)
+		}
+
+		thresholdRule = liquidity.NewThresholdRulePPM(
+			incoming, outgoing,
+		)
+
+		switch rule.SwapType {
+		case looprpc.SwapType_LOOP_OUT:
+			thresholdRule.Type = swap.TypeOut
+
+		case looprpc.SwapType_LOOP_IN:
+			thresholdRule.Type = swap.TypeIn
+
+		default:
+			return nil, fmt.Errorf("unknown swap type: %v",
+				rule.SwapType)
+		}
+
+	// Target rules choose the type of swap from our balance, so we do not
+	// use the rule's swap type.
+	case looprpc.LiquidityRuleType_TARGET:
+		if rule.TargetLocalPpm == 0 {
+			return nil, fmt.Errorf("target rules must set a " +
+				"target local ppm")
+		}
+
+		thresholdRule = liquidity.NewTargetRulePPM(
+			int(rule.TargetLocalPpm), int(rule.TolerancePpm),
+		)
+
+	default:
+		return nil, fmt.Errorf("unknown rule: %T", rule)
+	}
+
+	thresholdRule.SweepConfTarget = rule.SweepConfTarget
+	thresholdRule.HtlcConfTarget = rule.HtlcConfTarget
+	thresholdRule.MinimumImbalanceDuration = time.Duration(
+		rule.MinImbalanceDurationSec,
+	) * time.Second
+	thresholdRule.MinimumReputation = int(rule.MinReputation)
+	thresholdRule.LargeSwapAmount = btcutil.Amount(
+		rule.LargeSwapAmountSat,
+	)
+	thresholdRule.FeeBudget = btcutil.Amount(rule.FeeBudgetSat)
+	thresholdRule.FeeBudgetPeriod = time.Duration(
+		rule.FeeBudgetPeriodSec,
+	) * time.Second
+
+	return thresholdRule, nil
+}
+
+// SuggestSwaps provides a list of suggested swaps based on lnd's current
+// channel balances and rules set by the liquidity manager.
+func (s *swapClientServer) SuggestSwaps(ctx context.Context,
+	_ *looprpc.SuggestSwapsRequest) (*looprpc.SuggestSwapsResponse, error) {
+
+	suggestions, err := s.liquidityMgr.SuggestSwaps(ctx, false)
+	switch err {
+	case liquidity.ErrNoRules:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+
+	case nil:
+
+	default:
+		return nil, err
+	}
+
+	var loopOut []*looprpc.LoopOutRequest
+	for _, swap := range suggestions.OutSwaps {
+		loopOut = append(loopOut, &looprpc.LoopOutRequest{
+			Amt:                 int64(swap.Amount),
+			OutgoingChanSet:     swap.OutgoingChanSet,
+			MaxSwapFee:          int64(swap.MaxSwapFee),
+			MaxMinerFee:         int64(swap.MaxMinerFee),
+			MaxPrepayAmt:        int64(swap.MaxPrepayAmount),
+			MaxSwapRoutingFee:   int64(swap.MaxSwapRoutingFee),
+			MaxPrepayRoutingFee: int64(swap.MaxPrepayRoutingFee),
+			SweepConfTarget:     swap.SweepConfTarget,
+		})
+	}
+
+	var loopIn []*looprpc.LoopInRequest
+	for _, swap := range suggestions.InSwaps {
+		in := &looprpc.LoopInRequest{
+			Amt:            int64(swap.Amount),
+			MaxSwapFee:     int64(swap.MaxSwapFee),
+			MaxMinerFee:    int64(swap.MaxMinerFee),
+			HtlcConfTarget: swap.HtlcConfTarget,
+		}
+
+		if swap.LastHop != nil {
+			in.LastHop = swap.LastHop[:]
+		}
+
+		loopIn = append(loopIn, in)
+	}
+
+	disqualified, err := rpcDisqualified(
+		suggestions.DisqualifiedChans, suggestions.DisqualifiedPeers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.SuggestSwapsResponse{
+		LoopOut:      loopOut,
+		LoopIn:       loopIn,
+		Disqualified: disqualified,
+	}, nil
+}
+
+// GetAutoloopStatus returns the outcome of our most recent autoloop check.
+func (s *swapClientServer) GetAutoloopStatus(_ context.Context,
+	_ *looprpc.AutoloopStatusRequest) (*looprpc.AutoloopStatusResponse,
+	error) {
+
+	return rpcAutoloopStatus(s.liquidityMgr.GetAutoloopStatus())
+}
+
+// TriggerAutoloopEvaluation performs an autoloop evaluation immediately,
+// rather than waiting for the liquidity manager's next check, and returns its
+// outcome. The evaluation is subject to the same budget and limits as regular
+// checks, and only dispatches swaps if autoloop is enabled.
+func (s *swapClientServer) TriggerAutoloopEvaluation(ctx context.Context,
+	_ *looprpc.TriggerAutoloopEvaluationRequest) (
+	*looprpc.AutoloopStatusResponse, error) {
+
+	log.Infof("Autoloop evaluation triggered")
+
+	status, err := s.liquidityMgr.TriggerAutoloop(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcAutoloopStatus(status)
+}
+
+// rpcAutoloopStatus converts an autoloop status to its rpc representation.
+func rpcAutoloopStatus(status liquidity.AutoloopStatus) (
+	*looprpc.AutoloopStatusResponse, error) {
+
+	disqualified, err := rpcDisqualified(
+		status.DisqualifiedChans, status.DisqualifiedPeers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.AutoloopStatusResponse{
+		Disqualified: disqualified,
+	}
+
+	if !status.LastCheck.IsZero() {
+		resp.LastCheck = status.LastCheck.Unix()
+	}
+
+	if !status.LastChange.IsZero() {
+		resp.LastChange = status.LastChange.Unix()
+	}
+
+	if status.Err != nil {
+		resp.Error = status.Err.Error()
+	}
+
+	if status.Budget != nil {
+		resp.Budget = rpcAutoloopBudget(status.Budget)
+	}
+
+	resp.Events, err = rpcAutoloopEvents(status.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// rpcAutoloopBudget converts a summary of our autoloop budget to its rpc
+// representation.
+func rpcAutoloopBudget(budget *liquidity.Budget) *looprpc.AutoloopBudget {
+	rpcBudget := &looprpc.AutoloopBudget{
+		BudgetSat:    uint64(budget.Total),
+		SpentSat:     uint64(budget.Spent),
+		PendingSat:   uint64(budget.Pending),
+		AvailableSat: uint64(budget.Available()),
+		InFlight:     uint32(budget.InFlight),
+	}
+
+	if !budget.StartDate.IsZero() {
+		rpcBudget.StartDate = uint64(budget.StartDate.Unix())
+	}
+
+	return rpcBudget
+}
+
+// GetBudgetReconciliation returns a report of the worst-case fees that our
+// automatically dispatched swaps reserved from our budget while they were in
+// flight, compared to the fees that they realized once they completed.
+func (s *swapClientServer) GetBudgetReconciliation(ctx context.Context,
+	_ *looprpc.BudgetReconciliationRequest) (
+	*looprpc.BudgetReconciliationResponse, error) {
+
+	report, err := s.liquidityMgr.ReconcileBudget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &looprpc.BudgetReconciliationResponse{
+		Time: uint64(report.Time.Unix()),
+		Swaps: make(
+			[]*looprpc.ReconciledSwap, 0, len(report.Swaps),
+		),
+		ReservedSat: uint64(report.Reserved),
+		RealizedSat: uint64(report.Realized),
+		ReleasedSat: int64(report.Released()),
+		PendingSat:  uint64(report.Pending),
+		InFlight:    uint32(report.InFlight),
+	}
+
+	if !report.StartDate.IsZero() {
+		resp.StartDate = uint64(report.StartDate.Unix())
+	}
+
+	for _, reconciled := range report.Swaps {
+		swapType := looprpc.SwapType_LOOP_OUT
+		if reconciled.SwapType == swap.TypeIn {
+			swapType = looprpc.SwapType_LOOP_IN
+		}
+
+		resp.Swaps = append(resp.Swaps, &looprpc.ReconciledSwap{
+			Id:          reconciled.SwapHash[:],
+			Type:        swapType,
+			CompletedAt: uint64(reconciled.CompletedAt.Unix()),
+			ReservedSat: uint64(reconciled.Reserved),
+			RealizedSat: uint64(reconciled.Realized),
+			ReleasedSat: int64(reconciled.Released()),
+		})
+	}
+
+	return resp, nil
+}
+
+// rpcAutoloopEvents converts a set of autoloop events to their rpc
+// representation.
+func rpcAutoloopEvents(events []liquidity.Event) ([]*looprpc.AutoloopEvent,
+	error) {
+
+	rpcEvents := make([]*looprpc.AutoloopEvent, 0, len(events))
+	for _, event := range events {
+		eventType, err := rpcAutoloopEventType(event.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcEvent := &looprpc.AutoloopEvent{
+			Timestamp: event.Time.Unix(),
+			Type:      eventType,
+			Error:     event.Err,
+		}
+
+		switch event.Type {
+		case liquidity.EventDisqualified, liquidity.EventRequalified:
+			if event.Channel.ToUint64() != 0 {
+				rpcEvent.ChannelId = event.Channel.ToUint64()
+			} else {
+				rpcEvent.Pubkey = event.Peer[:]
+			}
+
+			rpcEvent.Reason, err = rpcAutoloopReason(event.Reason)
+			if err != nil {
+				return nil, err
+			}
+
+		case liquidity.EventSwapDispatched:
+			rpcEvent.SwapHash = event.SwapHash[:]
+			rpcEvent.AmountSat = uint64(event.Amount)
+
+			rpcEvent.SwapType = looprpc.SwapType_LOOP_OUT
+			if event.SwapType == swap.TypeIn {
+				rpcEvent.SwapType = looprpc.SwapType_LOOP_IN
+			}
+		}
+
+		rpcEvents = append(rpcEvents, rpcEvent)
+	}
+
+	return rpcEvents, nil
+}
+
+// rpcAutoloopEventType converts an autoloop event type to its rpc
+// representation.
+func rpcAutoloopEventType(eventType liquidity.EventType) (
+	looprpc.AutoloopEventType, error) {
+
+	switch eventType {
+	case liquidity.EventDisqualified:
+		return looprpc.AutoloopEventType_AUTOLOOP_EVENT_DISQUALIFIED,
+			nil
+
+	case liquidity.EventRequalified:
+		return looprpc.AutoloopEventType_AUTOLOOP_EVENT_REQUALIFIED, nil
+
+	case liquidity.EventCheckFailed:
+		return looprpc.AutoloopEventType_AUTOLOOP_EVENT_CHECK_FAILED,
+			nil
+
+	case liquidity.EventCheckRecovered:
+		return looprpc.AutoloopEventType_AUTOLOOP_EVENT_CHECK_RECOVERED,
+			nil
+
+	case liquidity.EventSwapDispatched:
+		return looprpc.AutoloopEventType_AUTOLOOP_EVENT_SWAP_DISPATCHED,
+			nil
+
+	default:
+		return 0, fmt.Errorf("unknown autoloop event type: %v",
+			eventType)
+	}
+}
+
+// rpcDisqualified converts the channels and peers that the liquidity manager
+// excluded from its suggestions to their rpc representation.
+func rpcDisqualified(chans map[lnwire.ShortChannelID]liquidity.Reason,
+	peers map[route.Vertex]liquidity.Reason) ([]*looprpc.Disqualified,
+	error) {
+
+	var disqualified []*looprpc.Disqualified
+	for id, reason := range chans {
+		autoloopReason, err := rpcAutoloopReason(reason)
+		if err != nil {
+			return nil, err
+		}
+
+		exclChan := &looprpc.Disqualified{
+			Reason:    autoloopReason,
+			ChannelId: id.ToUint64(),
+		}
+
+		disqualified = append(disqualified, exclChan)
+	}
+
+	for pubkey, reason := range peers {
+		autoloopReason, err := rpcAutoloopReason(reason)
+		if err != nil {
+			return nil, err
+		}
+
+		exclChan := &looprpc.Disqualified{
+			Reason: autoloopReason,
+			Pubkey: pubkey[:],
+		}
+
+		disqualified = append(disqualified, exclChan)
+	}
+
+	return disqualified, nil
+}
+
+func rpcAutoloopReason(reason liquidity.Reason) (looprpc.AutoReason, error) {
+	switch reason {
+	case liquidity.ReasonNone:
+		return looprpc.AutoReason_AUTO_REASON_UNKNOWN, nil
+
+	case liquidity.ReasonBudgetNotStarted:
+		return looprpc.AutoReason_AUTO_REASON_BUDGET_NOT_STARTED, nil
+
+	case liquidity.ReasonSweepFees:
+		return looprpc.AutoReason_AUTO_REASON_SWEEP_FEES, nil
+
+	case liquidity.ReasonBudgetElapsed:
+		return looprpc.AutoReason_AUTO_REASON_BUDGET_ELAPSED, nil
+
+	case liquidity.ReasonInFlight:
+		return looprpc.AutoReason_AUTO_REASON_IN_FLIGHT, nil
+
+	case liquidity.ReasonSwapFee:
+		return looprpc.AutoReason_AUTO_REASON_SWAP_FEE, nil
+
+	case liquidity.ReasonMinerFee:
+		return looprpc.AutoReason_AUTO_REASON_MINER_FEE, nil
+
+	case liquidity.ReasonPrepay:
+		return looprpc.AutoReason_AUTO_REASON_PREPAY, nil
+
+	case liquidity.ReasonFailureBackoff:
+		return looprpc.AutoReason_AUTO_REASON_FAILURE_BACKOFF, nil
+
+	case liquidity.ReasonLoopOut:
+		return looprpc.AutoReason_AUTO_REASON_LOOP_OUT, nil
+
+	case liquidity.ReasonLoopIn:
+		return looprpc.AutoReason_AUTO_REASON_LOOP_IN, nil
+
+	case liquidity.ReasonLiquidityOk:
+		return looprpc.AutoReason_AUTO_REASON_LIQUIDITY_OK, nil
+
+	case liquidity.ReasonBudgetInsufficient:
+		return looprpc.AutoReason_AUTO_REASON_BUDGET_INSUFFICIENT, nil
+
+	case liquidity.ReasonFeePPMInsufficient:
+		return looprpc.AutoReason_AUTO_REASON_SWAP_FEE, nil
+
+	case liquidity.ReasonLockedValue:
+		return looprpc.AutoReason_AUTO_REASON_LOCKED_VALUE, nil
+
+	case liquidity.ReasonRebalanceCheaper:
+		return looprpc.AutoReason_AUTO_REASON_REBALANCE_CHEAPER, nil
+
+	case liquidity.ReasonDisqualified:
+		return looprpc.AutoReason_AUTO_REASON_DISQUALIFIED, nil
+
+	case liquidity.ReasonMinAmount:
+		return looprpc.AutoReason_AUTO_REASON_MIN_AMOUNT, nil
+
+	case liquidity.ReasonNoChannel:
+		return looprpc.AutoReason_AUTO_REASON_NO_CHANNEL, nil
+
+	case liquidity.ReasonCooldown:
+		return looprpc.AutoReason_AUTO_REASON_COOLDOWN, nil
+
+	case liquidity.ReasonTransientImbalance:
+		return looprpc.AutoReason_AUTO_REASON_TRANSIENT_IMBALANCE, nil
+
+	case liquidity.ReasonReputation:
+		return looprpc.AutoReason_AUTO_REASON_REPUTATION, nil
+
+	case liquidity.ReasonOutsideSchedule:
+		return looprpc.AutoReason_AUTO_REASON_OUTSIDE_SCHEDULE, nil
+
+	case liquidity.ReasonLoopInWalletFraction:
+		return looprpc.AutoReason_AUTO_REASON_LOOP_IN_WALLET_FRACTION, nil
+
+	case liquidity.ReasonRuleBudget:
+		return looprpc.AutoReason_AUTO_REASON_RULE_BUDGET, nil
+
+	case liquidity.ReasonInactive:
+		return looprpc.AutoReason_AUTO_REASON_INACTIVE, nil
+
+	default:
+		return 0, fmt.Errorf("unknown autoloop reason: %v", reason)
+	}
+}
+
+// GetSwapStats returns statistics for the swaps in our database, aggregated by
+// the period requested.
+func (s *swapClientServer) GetSwapStats(ctx context.Context,
+	req *looprpc.SwapStatsRequest) (*looprpc.SwapStatsResponse, error) {
+
+	log.Infof("Get swap stats request received")
+
+	swaps, err := s.impl.FetchSwaps(ctx)
 	if err != nil {
 		return nil, err
 	}
-	quote, err := s.impl.LoopOutQuote(ctx, &loop.LoopOutQuoteRequest{
-		Amount:          btcutil.Amount(req.Amt),
-		SweepConfTarget: confTarget,
-		SwapPublicationDeadline: time.Unix(
-			int64(req.SwapPublicationDeadline), 0,
-		),
-	})
+
+	stats, err := swapStats(swaps, req.Period, req.SwapTypes)
 	if err != nil {
 		return nil, err
 	}
 
-	return &looprpc.OutQuoteResponse{
-		HtlcSweepFeeSat: int64(quote.MinerFee),
-		PrepayAmtSat:    int64(quote.PrepayAmount),
-		SwapFeeSat:      int64(quote.SwapFee),
-		SwapPaymentDest: quote.SwapPaymentDest[:],
-		ConfTarget:      confTarget,
+	return &looprpc.SwapStatsResponse{
+		Stats: stats,
 	}, nil
 }
 
-// GetTerms returns the terms that the server enforces for swaps.
-func (s *swapClientServer) GetLoopInTerms(ctx context.Context,
-	req *looprpc.TermsRequest) (*looprpc.InTermsResponse, error) {
+// GetSwapCosts returns the realized costs of the swaps in our database that
+// completed within the requested time range.
+func (s *swapClientServer) GetSwapCosts(ctx context.Context,
+	req *looprpc.SwapCostsRequest) (*looprpc.SwapCostsResponse, error) {
 
-	log.Infof("Loop in terms request received")
+	log.Infof("Get swap costs request received")
 
-	terms, err := s.impl.LoopInTerms(ctx)
+	if req.EndTimeNs != 0 && req.EndTimeNs < req.StartTimeNs {
+		return nil, fmt.Errorf("end time: %v before start time: %v",
+			req.EndTimeNs, req.StartTimeNs)
+	}
+
+	swaps, err := s.impl.FetchSwaps(ctx)
 	if err != nil {
-		log.Errorf("Terms request: %v", err)
 		return nil, err
 	}
 
-	return &looprpc.InTermsResponse{
-		MinSwapAmount: int64(terms.MinSwapAmount),
-		MaxSwapAmount: int64(terms.MaxSwapAmount),
-	}, nil
+	return swapCosts(
+		swaps, req.StartTimeNs, req.EndTimeNs, req.SwapTypes,
+	), nil
 }
 
-// GetQuote returns a quote for a swap with the provided parameters.
-func (s *swapClientServer) GetLoopInQuote(ctx context.Context,
-	req *looprpc.QuoteRequest) (*looprpc.InQuoteResponse, error) {
+// GetQuoteHistory returns the quotes that we have received from the server
+// within a time range.
+func (s *swapClientServer) GetQuoteHistory(ctx context.Context,
+	req *looprpc.QuoteHistoryRequest) (*looprpc.QuoteHistoryResponse,
+	error) {
 
-	log.Infof("Loop in quote request received")
+	log.Infof("Get quote history request received")
 
-	htlcConfTarget, err := validateLoopInRequest(
-		req.ConfTarget, req.ExternalHtlc,
-	)
-	if err != nil {
-		return nil, err
+	if req.EndTimeNs != 0 && req.EndTimeNs < req.StartTimeNs {
+		return nil, fmt.Errorf("end time: %v before start time: %v",
+			req.EndTimeNs, req.StartTimeNs)
 	}
 
-	quote, err := s.impl.LoopInQuote(ctx, &loop.LoopInQuoteRequest{
-		Amount:         btcutil.Amount(req.Amt),
-		HtlcConfTarget: htlcConfTarget,
-		ExternalHtlc:   req.ExternalHtlc,
-	})
+	quotes, err := s.impl.FetchQuoteHistory(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &looprpc.InQuoteResponse{
-		HtlcPublishFeeSat: int64(quote.MinerFee),
-		SwapFeeSat:        int64(quote.SwapFee),
-		ConfTarget:        htlcConfTarget,
-	}, nil
-}
 
-func (s *swapClientServer) LoopIn(ctx context.Context,
-	in *looprpc.LoopInRequest) (
-	*looprpc.SwapResponse, error) {
-
-	log.Infof("Loop in request received")
+	return quoteHistory(
+		quotes, req.StartTimeNs, req.EndTimeNs, req.SwapTypes,
+	), nil
+}
 
-	htlcConfTarget, err := validateLoopInRequest(
-		in.HtlcConfTarget, in.ExternalHtlc,
-	)
-	if err != nil {
-		return nil, err
-	}
+// GetAuditLog returns the swap state transitions that we have recorded in our
+// audit log.
+func (s *swapClientServer) GetAuditLog(ctx context.Context,
+	req *looprpc.AuditLogRequest) (*looprpc.AuditLogResponse, error) {
 
-	// Check that the label is valid.
-	if err := labels.Validate(in.Label); err != nil {
-		return nil, err
-	}
+	log.Infof("Get audit log request received")
 
-	req := &loop.LoopInRequest{
-		Amount:         btcutil.Amount(in.Amt),
-		MaxMinerFee:    btcutil.Amount(in.MaxMinerFee),
-		MaxSwapFee:     btcutil.Amount(in.MaxSwapFee),
-		HtlcConfTarget: htlcConfTarget,
-		ExternalHtlc:   in.ExternalHtlc,
-		Label:          in.Label,
-		Initiator:      in.Initiator,
-	}
-	if in.LastHop != nil {
-		lastHop, err := route.NewVertexFromBytes(in.LastHop)
+	var swapHash *lntypes.Hash
+	if len(req.SwapHash) != 0 {
+		hash, err := lntypes.MakeHash(req.SwapHash)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing swap hash: %v",
+				err)
 		}
-		req.LastHop = &lastHop
-	}
-	swapInfo, err := s.impl.LoopIn(ctx, req)
-	if err != nil {
-		log.Errorf("Loop in: %v", err)
-		return nil, err
-	}
 
-	response := &looprpc.SwapResponse{
-		Id:               swapInfo.SwapHash.String(),
-		IdBytes:          swapInfo.SwapHash[:],
-		HtlcAddressP2Wsh: swapInfo.HtlcAddressP2WSH.String(),
-		ServerMessage:    swapInfo.ServerMessage,
+		swapHash = &hash
 	}
 
-	if req.ExternalHtlc {
-		response.HtlcAddressNp2Wsh = swapInfo.HtlcAddressNP2WSH.String()
-		response.HtlcAddress = response.HtlcAddressNp2Wsh // nolint:staticcheck
-	} else {
-		response.HtlcAddress = response.HtlcAddressP2Wsh // nolint:staticcheck
+	entries, err := s.impl.FetchAuditLog(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	return auditLog(entries, swapHash, req.StartTimeNs), nil
 }
 
-// GetLsatTokens returns all tokens that are contained in the LSAT token store.
-func (s *swapClientServer) GetLsatTokens(ctx context.Context,
-	_ *looprpc.TokensRequest) (*looprpc.TokensResponse, error) {
+// GetLiquidityHistory returns the channel balance snapshots that we have
+// recorded, grouped by channel.
+func (s *swapClientServer) GetLiquidityHistory(ctx context.Context,
+	req *looprpc.LiquidityHistoryRequest) (*looprpc.LiquidityHistoryResponse,
+	error) {
 
-	log.Infof("Get LSAT tokens request received")
+	log.Infof("Get liquidity history request received")
 
-	tokens, err := s.impl.LsatStore.AllTokens()
+	snapshots, err := s.impl.FetchLiquidityHistory(
+		ctx, time.Unix(0, req.StartTimeNs),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	rpcTokens := make([]*looprpc.LsatToken, len(tokens))
-	idx := 0
-	for key, token := range tokens {
-		macBytes, err := token.BaseMacaroon().MarshalBinary()
-		if err != nil {
-			return nil, err
-		}
-		rpcTokens[idx] = &looprpc.LsatToken{
-			BaseMacaroon:       macBytes,
-			PaymentHash:        token.PaymentHash[:],
-			PaymentPreimage:    token.Preimage[:],
-			AmountPaidMsat:     int64(token.AmountPaid),
-			RoutingFeePaidMsat: int64(token.RoutingFeePaid),
-			TimeCreated:        token.TimeCreated.Unix(),
-			Expired:            !token.IsValid(),
-			StorageName:        key,
-		}
-		idx++
-	}
+	resp := liquidityHistory(snapshots, req.ChannelIds)
+	resp.SnapshotIntervalSec = uint32(
+		s.config.LiquiditySnapshotInterval.Seconds(),
+	)
 
-	return &looprpc.TokensResponse{Tokens: rpcTokens}, nil
+	return resp, nil
 }
 
-// GetLiquidityParams gets our current liquidity manager's parameters.
-func (s *swapClientServer) GetLiquidityParams(_ context.Context,
-	_ *looprpc.GetLiquidityParamsRequest) (*looprpc.LiquidityParameters,
+// CompareRebalance compares the quoted cost of a swap with lnd's estimate of
+// the off-chain fees for a circular rebalance that shifts the same balance.
+// Failure to estimate the rebalance is reported in the response rather than
+// failing the call, since the swap quote is still useful on its own.
+func (s *swapClientServer) CompareRebalance(ctx context.Context,
+	req *looprpc.CompareRebalanceRequest) (*looprpc.CompareRebalanceResponse,
 	error) {
 
-	cfg := s.liquidityMgr.GetParameters()
+	log.Infof("Compare rebalance request received")
 
-	totalRules := len(cfg.ChannelRules) + len(cfg.PeerRules)
-
-	rpcCfg := &looprpc.LiquidityParameters{
-		SweepConfTarget:   cfg.SweepConfTarget,
-		FailureBackoffSec: uint64(cfg.FailureBackOff.Seconds()),
-		Autoloop:          cfg.Autoloop,
-		AutoloopBudgetSat: uint64(cfg.AutoFeeBudget),
-		AutoMaxInFlight:   uint64(cfg.MaxAutoInFlight),
-		Rules: make(
-			[]*looprpc.LiquidityRule, 0, totalRules,
-		),
-		MinSwapAmount: uint64(cfg.ClientRestrictions.Minimum),
-		MaxSwapAmount: uint64(cfg.ClientRestrictions.Maximum),
+	if s.rebalance == nil {
+		return nil, status.Error(
+			codes.Unavailable, "rebalance estimates unavailable",
+		)
 	}
 
-	switch f := cfg.FeeLimit.(type) {
-	case *liquidity.FeeCategoryLimit:
-		satPerByte := f.SweepFeeRateLimit.FeePerKVByte() / 1000
-
-		rpcCfg.SweepFeeRateSatPerVbyte = uint64(satPerByte)
+	if req.ChannelId == 0 {
+		return nil, status.Error(
+			codes.InvalidArgument, "channel id required",
+		)
+	}
 
-		rpcCfg.MaxMinerFeeSat = uint64(f.MaximumMinerFee)
-		rpcCfg.MaxSwapFeePpm = f.MaximumSwapFeePPM
-		rpcCfg.MaxRoutingFeePpm = f.MaximumRoutingFeePPM
-		rpcCfg.MaxPrepayRoutingFeePpm = f.MaximumPrepayRoutingFeePPM
-		rpcCfg.MaxPrepaySat = uint64(f.MaximumPrepay)
+	if req.Amt <= 0 {
+		return nil, status.Error(
+			codes.InvalidArgument, "amount must be positive",
+		)
+	}
 
-	case *liquidity.FeePortion:
-		rpcCfg.FeePpm = f.PartsPerMillion
+	var (
+		amount   = btcutil.Amount(req.Amt)
+		swapType swap.Type
+		swapCost btcutil.Amount
+	)
 
-	default:
-		return nil, fmt.Errorf("unknown fee limit: %T", cfg.FeeLimit)
-	}
+	switch req.Type {
+	case looprpc.SwapType_LOOP_OUT:
+		swapType = swap.TypeOut
 
-	// Zero golang time is different to a zero unix time, so we only set
-	// our start date if it is non-zero.
-	if !cfg.AutoFeeStartDate.IsZero() {
-		rpcCfg.AutoloopBudgetStartSec = uint64(
-			cfg.AutoFeeStartDate.Unix(),
+		confTarget, err := validateConfTarget(
+			req.ConfTarget, s.impl.ConfPolicy.SweepConfTarget(
+				amount, loop.DefaultSweepConfTarget,
+			),
 		)
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	for channel, rule := range cfg.ChannelRules {
-		rpcRule := newRPCRule(channel.ToUint64(), nil, rule)
-		rpcCfg.Rules = append(rpcCfg.Rules, rpcRule)
-	}
+		quote, err := s.impl.LoopOutQuote(ctx, &loop.LoopOutQuoteRequest{
+			Amount:          amount,
+			SweepConfTarget: confTarget,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	for peer, rule := range cfg.PeerRules {
-		peer := peer
-		rpcRule := newRPCRule(0, peer[:], rule)
-		rpcCfg.Rules = append(rpcCfg.Rules, rpcRule)
-	}
+		swapCost = quote.SwapFee + quote.MinerFee
 
-	return rpcCfg, nil
-}
+	case looprpc.SwapType_LOOP_IN:
+		swapType = swap.TypeIn
 
-func newRPCRule(channelID uint64, peer []byte,
-	rule *liquidity.ThresholdRule) *looprpc.LiquidityRule {
+		confTarget, err := validateLoopInRequest(
+			req.ConfTarget, amount, false,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	return &looprpc.LiquidityRule{
-		ChannelId:         channelID,
-		Pubkey:            peer,
-		Type:              looprpc.LiquidityRuleType_THRESHOLD,
-		IncomingThreshold: uint32(rule.MinimumIncoming),
-		OutgoingThreshold: uint32(rule.MinimumOutgoing),
-	}
-}
+		quote, err := s.impl.LoopInQuote(ctx, &loop.LoopInQuoteRequest{
+			Amount:         amount,
+			HtlcConfTarget: confTarget,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-// SetLiquidityParams attempts to set our current liquidity manager's
-// parameters.
-func (s *swapClientServer) SetLiquidityParams(ctx context.Context,
-	in *looprpc.SetLiquidityParamsRequest) (*looprpc.SetLiquidityParamsResponse,
-	error) {
+		swapCost = quote.SwapFee + quote.MinerFee
 
-	feeLimit, err := rpcToFee(in.Parameters)
-	if err != nil {
-		return nil, err
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument, "unknown swap type: %v", req.Type,
+		)
 	}
 
-	params := liquidity.Parameters{
-		FeeLimit:        feeLimit,
-		SweepConfTarget: in.Parameters.SweepConfTarget,
-		FailureBackOff: time.Duration(in.Parameters.FailureBackoffSec) *
-			time.Second,
-		Autoloop:        in.Parameters.Autoloop,
-		AutoFeeBudget:   btcutil.Amount(in.Parameters.AutoloopBudgetSat),
-		MaxAutoInFlight: int(in.Parameters.AutoMaxInFlight),
-		ChannelRules: make(
-			map[lnwire.ShortChannelID]*liquidity.ThresholdRule,
-		),
-		PeerRules: make(
-			map[route.Vertex]*liquidity.ThresholdRule,
-		),
-		ClientRestrictions: liquidity.Restrictions{
-			Minimum: btcutil.Amount(in.Parameters.MinSwapAmount),
-			Maximum: btcutil.Amount(in.Parameters.MaxSwapAmount),
-		},
+	resp := &looprpc.CompareRebalanceResponse{
+		SwapCostSat: int64(swapCost),
 	}
 
-	// Zero unix time is different to zero golang time.
-	if in.Parameters.AutoloopBudgetStartSec != 0 {
-		params.AutoFeeStartDate = time.Unix(
-			int64(in.Parameters.AutoloopBudgetStartSec), 0,
-		)
+	rebalanceCost, err := s.rebalance.estimate(
+		ctx, lnwire.NewShortChanIDFromInt(req.ChannelId), amount,
+		swapType,
+	)
+	if err != nil {
+		resp.RebalanceError = err.Error()
+		return resp, nil
 	}
 
-	for _, rule := range in.Parameters.Rules {
-		peerRule := rule.Pubkey != nil
-		chanRule := rule.ChannelId != 0
+	resp.RebalanceCostSat = int64(rebalanceCost)
+	resp.RebalanceRouteFound = true
+	resp.RebalanceCheaper = rebalanceCost < swapCost
 
-		liquidityRule, err := rpcToRule(rule)
-		if err != nil {
-			return nil, err
-		}
+	return resp, nil
+}
 
-		switch {
-		case peerRule && chanRule:
-			return nil, fmt.Errorf("cannot set channel: %v and "+
-				"peer: %v fields in rule", rule.ChannelId,
-				rule.Pubkey)
+// GetConfig returns the daemon's effective configuration.
+func (s *swapClientServer) GetConfig(_ context.Context,
+	_ *looprpc.GetConfigRequest) (*looprpc.GetConfigResponse, error) {
 
-		case peerRule:
-			pubkey, err := route.NewVertexFromBytes(rule.Pubkey)
-			if err != nil {
-				return nil, err
-			}
+	log.Infof("Get config request received")
+
+	return marshallConfig(s.config), nil
+}
 
-			if _, ok := params.PeerRules[pubkey]; ok {
-				return nil, fmt.Errorf("multiple rules set "+
-					"for peer: %v", pubkey)
-			}
+// GetApiVersion returns the version of the rpc API that we serve, the rpcs
+// that we support and the deprecation metadata of the API.
+func (s *swapClientServer) GetApiVersion(_ context.Context,
+	_ *looprpc.GetApiVersionRequest) (*looprpc.GetApiVersionResponse,
+	error) {
 
-			params.PeerRules[pubkey] = liquidityRule
+	log.Infof("Get api version request received")
 
-		case chanRule:
-			shortID := lnwire.NewShortChanIDFromInt(rule.ChannelId)
+	return marshallAPIVersion(looprpc.File_client_proto), nil
+}
 
-			if _, ok := params.ChannelRules[shortID]; ok {
-				return nil, fmt.Errorf("multiple rules set "+
-					"for channel: %v", shortID)
-			}
+// marshallAPIVersion creates an api version response for the client api
+// described by the file descriptor provided.
+func marshallAPIVersion(
+	file protoreflect.FileDescriptor) *looprpc.GetApiVersionResponse {
 
-			params.ChannelRules[shortID] = liquidityRule
+	resp := &looprpc.GetApiVersionResponse{
+		Major:   looprpc.APIVersionMajor,
+		Minor:   looprpc.APIVersionMinor,
+		Patch:   looprpc.APIVersionPatch,
+		Version: looprpc.APIVersion(),
+	}
 
-		default:
-			return nil, errors.New("please set channel id or " +
-				"pubkey for rule")
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			resp.Methods = append(
+				resp.Methods, string(methods.Get(j).FullName()),
+			)
 		}
 	}
 
-	if err := s.liquidityMgr.SetParameters(ctx, params); err != nil {
-		return nil, err
+	for name, deprecation := range looprpc.Deprecations {
+		resp.Deprecations = append(
+			resp.Deprecations, &looprpc.Deprecation{
+				Name:         name,
+				Replacement:  deprecation.Replacement,
+				RemovalMajor: deprecation.RemovalMajor,
+			},
+		)
 	}
 
-	return &looprpc.SetLiquidityParamsResponse{}, nil
+	// Sort our deprecations so that our response is deterministic.
+	sort.Slice(resp.Deprecations, func(i, j int) bool {
+		return resp.Deprecations[i].Name < resp.Deprecations[j].Name
+	})
+
+	return resp
 }
 
-// rpcToFee converts the values provided over rpc to a fee limit interface,
-// failing if an inconsistent set of fields are set.
-func rpcToFee(req *looprpc.LiquidityParameters) (liquidity.FeeLimit,
+// ServerHealth returns the health of our connection to the swap server.
+func (s *swapClientServer) ServerHealth(ctx context.Context,
+	_ *looprpc.ServerHealthRequest) (*looprpc.ServerHealthResponse,
 	error) {
 
-	// Check which fee limit type we have values set for. If any fields
-	// relevant to our individual categories are set, we count that type
-	// as set.
-	isFeePPM := req.FeePpm != 0
-	isCategories := req.MaxSwapFeePpm != 0 || req.MaxRoutingFeePpm != 0 ||
-		req.MaxPrepayRoutingFeePpm != 0 || req.MaxMinerFeeSat != 0 ||
-		req.MaxPrepaySat != 0 || req.SweepFeeRateSatPerVbyte != 0
+	health := s.impl.ServerHealth()
 
-	switch {
-	case isFeePPM && isCategories:
-		return nil, errors.New("set either fee ppm, or individual " +
-			"fee categories")
-	case isFeePPM:
-		return liquidity.NewFeePortion(req.FeePpm), nil
+	resp := &looprpc.ServerHealthResponse{
+		Reachable:       health.Reachable,
+		ProtocolVersion: uint32(health.ProtocolVersion),
+	}
 
-	case isCategories:
-		satPerVbyte := chainfee.SatPerKVByte(
-			req.SweepFeeRateSatPerVbyte * 1000,
+	// Failure to negotiate features with the server is reported by our
+	// reachability, so we just omit them if they are not available.
+	features, err := s.impl.ServerFeatures(ctx)
+	if err != nil {
+		log.Debugf("Could not get server features: %v", err)
+	} else {
+		resp.NegotiatedProtocolVersion = uint32(
+			features.ProtocolVersion,
 		)
 
-		return liquidity.NewFeeCategoryLimit(
-			req.MaxSwapFeePpm,
-			req.MaxRoutingFeePpm,
-			req.MaxPrepayRoutingFeePpm,
-			btcutil.Amount(req.MaxMinerFeeSat),
-			btcutil.Amount(req.MaxPrepaySat),
-			satPerVbyte.FeePerKWeight(),
-		), nil
-
-	default:
-		return nil, errors.New("no fee categories set")
+		for _, feature := range features.Features() {
+			resp.Features = append(
+				resp.Features, feature.String(),
+			)
+		}
 	}
-}
 
-// rpcToRule switches on rpc rule type to convert to our rule interface.
-func rpcToRule(rule *looprpc.LiquidityRule) (*liquidity.ThresholdRule, error) {
-	switch rule.Type {
-	case looprpc.LiquidityRuleType_UNKNOWN:
-		return nil, fmt.Errorf("rule type field must be set")
+	if !health.LastSuccess.IsZero() {
+		resp.LastSuccess = health.LastSuccess.Unix()
+	}
 
-	case looprpc.LiquidityRuleType_THRESHOLD:
-		return liquidity.NewThresholdRule(
-			int(rule.IncomingThreshold),
-			int(rule.OutgoingThreshold),
-		), nil
+	if !health.LastFailure.IsZero() {
+		resp.LastFailure = health.LastFailure.Unix()
+	}
 
-	default:
-		return nil, fmt.Errorf("unknown rule: %T", rule)
+	if health.LastError != nil {
+		resp.LastError = health.LastError.Error()
 	}
 
+	return resp, nil
 }
 
-// SuggestSwaps provides a list of suggested swaps based on lnd's current
-// channel balances and rules set by the liquidity manager.
-func (s *swapClientServer) SuggestSwaps(ctx context.Context,
-	_ *looprpc.SuggestSwapsRequest) (*looprpc.SuggestSwapsResponse, error) {
-
-	suggestions, err := s.liquidityMgr.SuggestSwaps(ctx, false)
-	switch err {
-	case liquidity.ErrNoRules:
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
+// DebugLevel returns the supported log sub-systems if requested, otherwise it
+// applies the given log level specification.
+func (s *swapClientServer) DebugLevel(_ context.Context,
+	req *looprpc.DebugLevelRequest) (*looprpc.DebugLevelResponse, error) {
+
+	if req.Show {
+		return &looprpc.DebugLevelResponse{
+			SubSystems: strings.Join(
+				logWriter.SupportedSubsystems(), " ",
+			),
+		}, nil
+	}
 
-	case nil:
+	log.Infof("Debug level request received: %v", req.LevelSpec)
 
-	default:
-		return nil, err
+	err := build.ParseAndSetDebugLevels(req.LevelSpec, logWriter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	var (
-		loopOut      []*looprpc.LoopOutRequest
-		disqualified []*looprpc.Disqualified
-	)
+	return &looprpc.DebugLevelResponse{}, nil
+}
 
-	for _, swap := range suggestions.OutSwaps {
-		loopOut = append(loopOut, &looprpc.LoopOutRequest{
-			Amt:                 int64(swap.Amount),
-			OutgoingChanSet:     swap.OutgoingChanSet,
-			MaxSwapFee:          int64(swap.MaxSwapFee),
-			MaxMinerFee:         int64(swap.MaxMinerFee),
-			MaxPrepayAmt:        int64(swap.MaxPrepayAmount),
-			MaxSwapRoutingFee:   int64(swap.MaxSwapRoutingFee),
-			MaxPrepayRoutingFee: int64(swap.MaxPrepayRoutingFee),
-			SweepConfTarget:     swap.SweepConfTarget,
-		})
-	}
+// TestNotification delivers a test event to each of our notification sinks and
+// reports the outcome for each sink.
+func (s *swapClientServer) TestNotification(ctx context.Context,
+	_ *looprpc.TestNotificationRequest) (*looprpc.TestNotificationResponse,
+	error) {
 
-	for id, reason := range suggestions.DisqualifiedChans {
-		autoloopReason, err := rpcAutoloopReason(reason)
-		if err != nil {
-			return nil, err
-		}
+	log.Infof("Test notification request received")
 
-		exclChan := &looprpc.Disqualified{
-			Reason:    autoloopReason,
-			ChannelId: id.ToUint64(),
-		}
+	if s.notifier == nil {
+		return nil, status.Error(
+			codes.FailedPrecondition, "no notification sinks "+
+				"configured",
+		)
+	}
 
-		disqualified = append(disqualified, exclChan)
+	results := s.notifier.Test(ctx)
+
+	resp := &looprpc.TestNotificationResponse{
+		Results: make(
+			[]*looprpc.NotificationSinkResult, 0, len(results),
+		),
 	}
 
-	for pubkey, reason := range suggestions.DisqualifiedPeers {
-		autoloopReason, err := rpcAutoloopReason(reason)
-		if err != nil {
-			return nil, err
+	for _, result := range results {
+		rpcResult := &looprpc.NotificationSinkResult{
+			Sink:      result.Sink,
+			Delivered: result.Err == nil,
 		}
 
-		exclChan := &looprpc.Disqualified{
-			Reason: autoloopReason,
-			Pubkey: pubkey[:],
+		if result.Err != nil {
+			rpcResult.Error = result.Err.Error()
 		}
 
-		disqualified = append(disqualified, exclChan)
+		resp.Results = append(resp.Results, rpcResult)
 	}
 
-	return &looprpc.SuggestSwapsResponse{
-		LoopOut:      loopOut,
-		Disqualified: disqualified,
-	}, nil
+	return resp, nil
 }
 
-func rpcAutoloopReason(reason liquidity.Reason) (looprpc.AutoReason, error) {
-	switch reason {
-	case liquidity.ReasonNone:
-		return looprpc.AutoReason_AUTO_REASON_UNKNOWN, nil
-
-	case liquidity.ReasonBudgetNotStarted:
-		return looprpc.AutoReason_AUTO_REASON_BUDGET_NOT_STARTED, nil
-
-	case liquidity.ReasonSweepFees:
-		return looprpc.AutoReason_AUTO_REASON_SWEEP_FEES, nil
-
-	case liquidity.ReasonBudgetElapsed:
-		return looprpc.AutoReason_AUTO_REASON_BUDGET_ELAPSED, nil
-
-	case liquidity.ReasonInFlight:
-		return looprpc.AutoReason_AUTO_REASON_IN_FLIGHT, nil
-
-	case liquidity.ReasonSwapFee:
-		return looprpc.AutoReason_AUTO_REASON_SWAP_FEE, nil
-
-	case liquidity.ReasonMinerFee:
-		return looprpc.AutoReason_AUTO_REASON_MINER_FEE, nil
-
-	case liquidity.ReasonPrepay:
-		return looprpc.AutoReason_AUTO_REASON_PREPAY, nil
+// GetSupportBundle returns an archive with the information that we need to
+// debug issues with loopd.
+func (s *swapClientServer) GetSupportBundle(ctx context.Context,
+	req *looprpc.SupportBundleRequest) (*looprpc.SupportBundleResponse,
+	error) {
 
-	case liquidity.ReasonFailureBackoff:
-		return looprpc.AutoReason_AUTO_REASON_FAILURE_BACKOFF, nil
+	log.Infof("Support bundle request received")
 
-	case liquidity.ReasonLoopOut:
-		return looprpc.AutoReason_AUTO_REASON_LOOP_OUT, nil
+	logBytes := req.MaxLogBytes
+	switch {
+	case logBytes == 0:
+		logBytes = defaultSupportLogBytes
 
-	case liquidity.ReasonLoopIn:
-		return looprpc.AutoReason_AUTO_REASON_LOOP_IN, nil
+	case logBytes > maxSupportLogBytes:
+		return nil, status.Errorf(codes.InvalidArgument, "max log "+
+			"bytes: %v exceeds maximum: %v", logBytes,
+			maxSupportLogBytes)
+	}
 
-	case liquidity.ReasonLiquidityOk:
-		return looprpc.AutoReason_AUTO_REASON_LIQUIDITY_OK, nil
+	archive, err := s.supportBundle(ctx, int64(logBytes))
+	if err != nil {
+		return nil, err
+	}
 
-	case liquidity.ReasonBudgetInsufficient:
-		return looprpc.AutoReason_AUTO_REASON_BUDGET_INSUFFICIENT, nil
+	return &looprpc.SupportBundleResponse{
+		Archive: archive,
+		FileName: fmt.Sprintf("loop-support-%v.tar.gz",
+			time.Now().UTC().Format("20060102-150405")),
+	}, nil
+}
 
-	case liquidity.ReasonFeePPMInsufficient:
-		return looprpc.AutoReason_AUTO_REASON_SWAP_FEE, nil
+// marshallConfig converts our config to its rpc counterpart. Secrets and paths
+// to credentials are deliberately not included.
+func marshallConfig(cfg *Config) *looprpc.GetConfigResponse {
+	return &looprpc.GetConfigResponse{
+		Network:                cfg.Network,
+		RpcListen:              cfg.RPCListen,
+		RestListen:             cfg.RESTListen,
+		DataDir:                cfg.DataDir,
+		LndHost:                cfg.Lnd.Host,
+		ServerHost:             cfg.Server.Host,
+		ServerProxy:            cfg.Server.Proxy,
+		ServerNoTls:            cfg.Server.NoTLS,
+		MaxLsatCost:            cfg.MaxLSATCost,
+		MaxLsatFee:             cfg.MaxLSATFee,
+		LoopOutMaxParts:        cfg.LoopOutMaxParts,
+		LoopOutPaymentTimeout:  uint32(cfg.LoopOutPaymentTimeout.Seconds()),
+		SweepFeeBumpBlocks:     cfg.SweepFeeBumpBlocks,
+		BatchSweeps:            cfg.BatchSweeps,
+		MinPreimageRevealDelta: cfg.MinPreimageRevealDelta,
+		DefaultSweepConfTarget: loop.DefaultSweepConfTarget,
+		DefaultHtlcConfTarget:  loop.DefaultHtlcConfTarget,
+		MinConfTarget:          minConfTarget,
+		DebugLevel:             cfg.DebugLevel,
+		MaxLockedValue:         cfg.MaxLockedValue,
+		RpcMaxMsgSize:          int32(cfg.RPC.MaxMsgSize),
+		RpcDefaultTimeout:      uint32(cfg.RPC.DefaultTimeout.Seconds()),
+		AutoloopChangeDelay: uint32(
+			cfg.AutoloopChangeDelay.Seconds(),
+		),
+		LiquiditySnapshotInterval: uint32(
+			cfg.LiquiditySnapshotInterval.Seconds(),
+		),
+		TermsCacheTtl:      uint32(cfg.TermsCacheTTL.Seconds()),
+		NoTxLabels:         cfg.NoTxLabels,
+		MaxConcurrentSwaps: uint32(cfg.MaxConcurrentSwaps),
 
-	default:
-		return 0, fmt.Errorf("unknown autoloop reason: %v", reason)
+		MaxLoopInWalletFraction: cfg.MaxLoopInWalletFraction,
+		LoopInRefundConfTarget:  cfg.LoopInRefundConfTarget,
+		LoopInRefundMaxFee:      cfg.LoopInRefundMaxFee,
 	}
 }
 
@@ -931,11 +3244,26 @@ func (s *swapClientServer) processStatusUpdates(mainCtx context.Context) {
 		// subscribers about the changes.
 		case swp := <-s.statusChan:
 			s.swapsLock.Lock()
+			s.notifySwapUpdate(swp)
 			s.swaps[swp.SwapHash] = swp
 
+			// Assign the next sequence number to this update and
+			// add it to our set of recent updates, dropping the
+			// oldest update if we are at capacity.
+			s.lastSequence++
+			update := swapUpdate{
+				SwapInfo: swp,
+				sequence: s.lastSequence,
+			}
+
+			s.recentUpdates = append(s.recentUpdates, update)
+			if len(s.recentUpdates) > maxRecentUpdates {
+				s.recentUpdates = s.recentUpdates[1:]
+			}
+
 			for _, subscriber := range s.subscribers {
 				select {
-				case subscriber <- swp:
+				case subscriber <- update:
 				case <-mainCtx.Done():
 					s.swapsLock.Unlock()
 					return
@@ -951,6 +3279,25 @@ func (s *swapClientServer) processStatusUpdates(mainCtx context.Context) {
 	}
 }
 
+// notifySwapUpdate emits a notification for a swap update if one is warranted
+// and we have a notifier. It must be called with the swaps lock held, before
+// the update replaces the swap's previous state.
+func (s *swapClientServer) notifySwapUpdate(swp loop.SwapInfo) {
+	if s.notifier == nil {
+		return
+	}
+
+	var prev *loop.SwapInfo
+	if prevSwap, ok := s.swaps[swp.SwapHash]; ok {
+		prev = &prevSwap
+	}
+
+	event := swapEvent(prev, &swp, time.Now())
+	if event != nil {
+		s.notifier.Notify(event)
+	}
+}
+
 // validateConfTarget ensures the given confirmation target is valid. If one
 // isn't specified (0 value), then the default target is used.
 func validateConfTarget(target, defaultTarget int32) (int32, error) {
@@ -970,8 +3317,12 @@ func validateConfTarget(target, defaultTarget int32) (int32, error) {
 }
 
 // validateLoopInRequest fails if the mutually exclusive conf target and
-// external parameters are both set.
-func validateLoopInRequest(htlcConfTarget int32, external bool) (int32, error) {
+// external parameters are both set. If no conf target is set for an htlc
+// that we publish ourselves, a default target scaled by the swap amount is
+// returned.
+func validateLoopInRequest(htlcConfTarget int32, amount btcutil.Amount,
+	external bool) (int32, error) {
+
 	// If the htlc is going to be externally set, the htlcConfTarget should
 	// not be set, because it has no relevance when the htlc is external.
 	if external && htlcConfTarget != 0 {
@@ -985,7 +3336,24 @@ func validateLoopInRequest(htlcConfTarget int32, external bool) (int32, error) {
 		return 0, nil
 	}
 
-	return validateConfTarget(htlcConfTarget, loop.DefaultHtlcConfTarget)
+	return validateConfTarget(
+		htlcConfTarget, loop.DefaultLoopInHtlcConfTarget(amount),
+	)
+}
+
+// validateLoopInQuoteRequest validates the confirmation target of a loop in
+// quote request. Unlike loop in requests, quotes for externally published
+// htlcs may set a confirmation target, which is used to estimate the cost of
+// publishing the htlc. If no target is set for an external htlc, zero is
+// returned and no estimate is made.
+func validateLoopInQuoteRequest(htlcConfTarget int32, amount btcutil.Amount,
+	external bool) (int32, error) {
+
+	if external {
+		return validateConfTarget(htlcConfTarget, 0)
+	}
+
+	return validateLoopInRequest(htlcConfTarget, amount, false)
 }
 
 // validateLoopOutRequest validates the confirmation target, destination
@@ -993,13 +3361,17 @@ func validateLoopInRequest(htlcConfTarget int32, external bool) (int32, error) {
 // loop amount is valid given the available balance.
 func validateLoopOutRequest(ctx context.Context, lnd lndclient.LightningClient,
 	chainParams *chaincfg.Params, req *looprpc.LoopOutRequest,
-	sweepAddr btcutil.Address, maxParts uint32) (int32, error) {
-
-	// Check that the provided destination address has the correct format
-	// for the active network.
-	if !sweepAddr.IsForNet(chainParams) {
-		return 0, fmt.Errorf("%w: Current active network is %s",
-			errIncorrectChain, chainParams.Name)
+	sweepAddr btcutil.Address, maxParts uint32,
+	confPolicy loop.ConfPolicy) (int32, error) {
+
+	// Check that the provided destination address is for the active
+	// network, and that it is of a type that we sweep to. Addresses that
+	// are derived from our xpub are checked by our client.
+	if !req.DestFromXpub {
+		err := loop.ValidateDestAddr(sweepAddr, chainParams)
+		if err != nil {
+			return 0, err
+		}
 	}
 
 	// Check that the label is valid.
@@ -1007,6 +3379,10 @@ func validateLoopOutRequest(ctx context.Context, lnd lndclient.LightningClient,
 		return 0, err
 	}
 
+	if req.ServerConfTarget < 0 {
+		return 0, errServerConfTargetNegative
+	}
+
 	channels, err := lnd.ListChannels(ctx)
 	if err != nil {
 		return 0, err
@@ -1033,6 +3409,12 @@ func validateLoopOutRequest(ctx context.Context, lnd lndclient.LightningClient,
 		}
 	}
 
+	// If the request sets its own maximum number of payment parts, it
+	// takes precedence over our default.
+	if req.MaxParts != 0 {
+		maxParts = req.MaxParts
+	}
+
 	// Determine if the loop out request is theoretically possible given
 	// the amount requested, the maximum possible routing fees,
 	// the available channel set and the fact that equal splitting is
@@ -1048,8 +3430,12 @@ func validateLoopOutRequest(ctx context.Context, lnd lndclient.LightningClient,
 			req.MaxSwapRoutingFee)
 	}
 
+	// If the request does not set a sweep confirmation target, we use the
+	// target that our confirmation policy sets for its amount.
 	return validateConfTarget(
-		req.SweepConfTarget, loop.DefaultSweepConfTarget,
+		req.SweepConfTarget, confPolicy.SweepConfTarget(
+			btcutil.Amount(req.Amt), loop.DefaultSweepConfTarget,
+		),
 	)
 }
 
@@ -1101,3 +3487,140 @@ func hasBandwidth(channels []lndclient.ChannelInfo, amt btcutil.Amount,
 
 	return false, 0
 }
+
+// unmarshallRouteHints converts a set of rpc route hints to the hop hints
+// that are included in our invoices.
+func unmarshallRouteHints(rpcHints []*looprpc.RouteHint) ([][]zpay32.HopHint,
+	error) {
+
+	var hints [][]zpay32.HopHint
+	for _, rpcHint := range rpcHints {
+		hint := make([]zpay32.HopHint, 0, len(rpcHint.HopHints))
+		for _, rpcHop := range rpcHint.HopHints {
+			pubkeyBytes, err := hex.DecodeString(rpcHop.NodeId)
+			if err != nil {
+				return nil, fmt.Errorf("hop hint node id: %v",
+					err)
+			}
+
+			pubkey, err := btcec.ParsePubKey(
+				pubkeyBytes, btcec.S256(),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("hop hint node id: %v",
+					err)
+			}
+
+			if rpcHop.CltvExpiryDelta > math.MaxUint16 {
+				return nil, fmt.Errorf("hop hint cltv expiry "+
+					"delta: %v exceeds maximum",
+					rpcHop.CltvExpiryDelta)
+			}
+
+			hint = append(hint, zpay32.HopHint{
+				NodeID:      pubkey,
+				ChannelID:   rpcHop.ChanId,
+				FeeBaseMSat: rpcHop.FeeBaseMsat,
+				FeeProportionalMillionths: rpcHop.
+					FeeProportionalMillionths,
+				CLTVExpiryDelta: uint16(
+					rpcHop.CltvExpiryDelta,
+				),
+			})
+		}
+
+		hints = append(hints, hint)
+	}
+
+	return hints, nil
+}
+
+// NewStaticAddress returns our static address, creating it if it does not
+// exist yet.
+func (s *swapClientServer) NewStaticAddress(ctx context.Context,
+	_ *looprpc.NewStaticAddressRequest) (*looprpc.NewStaticAddressResponse,
+	error) {
+
+	addr, err := s.staticAddrMgr.NewAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &looprpc.NewStaticAddressResponse{
+		Address:      addr.Address,
+		CreationTime: addr.CreationTime.Unix(),
+	}, nil
+}
+
+// ListStaticDeposits returns the deposits that have been made to our static
+// address.
+func (s *swapClientServer) ListStaticDeposits(_ context.Context,
+	_ *looprpc.ListStaticDepositsRequest) (
+	*looprpc.ListStaticDepositsResponse, error) {
+
+	resp := &looprpc.ListStaticDepositsResponse{}
+
+	addr, err := s.staticAddrMgr.GetAddress()
+	switch err {
+	case nil:
+		resp.Address = addr.Address
+
+	// If we have not created our address yet, we can't have any deposits.
+	case loopdb.ErrNoStaticAddress:
+		return resp, nil
+
+	default:
+		return nil, err
+	}
+
+	deposits, err := s.staticAddrMgr.ListDeposits()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deposit := range deposits {
+		state, err := rpcDepositState(deposit.State)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcDeposit := &looprpc.StaticDeposit{
+			Outpoint:           deposit.OutPoint.String(),
+			Value:              int64(deposit.Value),
+			ConfirmationHeight: deposit.ConfirmationHeight,
+			State:              state,
+			LastUpdate:         deposit.LastUpdate.Unix(),
+		}
+
+		if deposit.SwapHash != nil {
+			rpcDeposit.SwapHash = deposit.SwapHash[:]
+		}
+
+		resp.Deposits = append(resp.Deposits, rpcDeposit)
+	}
+
+	return resp, nil
+}
+
+// rpcDepositState converts the state of a static address deposit to its rpc
+// representation.
+func rpcDepositState(state loopdb.DepositState) (looprpc.DepositState,
+	error) {
+
+	switch state {
+	case loopdb.DepositConfirmed:
+		return looprpc.DepositState_DEPOSIT_CONFIRMED, nil
+
+	case loopdb.DepositSwapInitiated:
+		return looprpc.DepositState_DEPOSIT_SWAP_INITIATED, nil
+
+	case loopdb.DepositTooSmall:
+		return looprpc.DepositState_DEPOSIT_TOO_SMALL, nil
+
+	case loopdb.DepositTooLarge:
+		return looprpc.DepositState_DEPOSIT_TOO_LARGE, nil
+
+	default:
+		return 0, fmt.Errorf("unknown deposit state: %v", state)
+	}
+}
@@ -35,7 +35,7 @@ func (d *Daemon) registerDebugServer() {
 
 // ForceAutoLoop triggers our liquidity manager to dispatch an automated swap,
 // if one is suggested. This endpoint is only for testing purposes and cannot be
-// used on mainnet.
+// used on mainnet, TriggerAutoloopEvaluation should be used instead.
 func (s *swapClientServer) ForceAutoLoop(ctx context.Context,
 	_ *looprpc.ForceAutoLoopRequest) (*looprpc.ForceAutoLoopResponse, error) {
 
@@ -43,6 +43,6 @@ func (s *swapClientServer) ForceAutoLoop(ctx context.Context,
 		return nil, fmt.Errorf("force autoloop not allowed on mainnet")
 	}
 
-	err := s.liquidityMgr.ForceAutoLoop(ctx)
+	_, err := s.liquidityMgr.TriggerAutoloop(ctx)
 	return &looprpc.ForceAutoLoopResponse{}, err
 }
@@ -3,26 +3,37 @@ package loopd
 import (
 	"context"
 	"errors"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/liquidity"
+	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/loop/swap"
 	mock_lnd "github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
-	testnetAddr, _ = btcutil.NewAddressScriptHash(
-		[]byte{123}, &chaincfg.TestNet3Params,
+	testnetAddr, _ = btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.TestNet3Params,
 	)
 
-	mainnetAddr, _ = btcutil.NewAddressScriptHash(
+	mainnetAddr, _ = btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.MainNetParams,
+	)
+
+	mainnetP2SHAddr, _ = btcutil.NewAddressScriptHash(
 		[]byte{123}, &chaincfg.MainNetParams,
 	)
 
@@ -145,6 +156,7 @@ func TestValidateLoopInRequest(t *testing.T) {
 	tests := []struct {
 		name           string
 		external       bool
+		amount         btcutil.Amount
 		confTarget     int32
 		expectErr      bool
 		expectedTarget int32
@@ -166,10 +178,27 @@ func TestValidateLoopInRequest(t *testing.T) {
 		{
 			name:           "not external, zero conf",
 			external:       false,
+			amount:         loop.SmallLoopInAmount,
 			confTarget:     0,
 			expectErr:      false,
 			expectedTarget: loop.DefaultHtlcConfTarget,
 		},
+		{
+			name:           "not external, zero conf, small swap",
+			external:       false,
+			amount:         loop.SmallLoopInAmount - 1,
+			confTarget:     0,
+			expectErr:      false,
+			expectedTarget: loop.SmallLoopInHtlcConfTarget,
+		},
+		{
+			name:           "not external, zero conf, large swap",
+			external:       false,
+			amount:         loop.LargeLoopInAmount,
+			confTarget:     0,
+			expectErr:      false,
+			expectedTarget: loop.LargeLoopInHtlcConfTarget,
+		},
 		{
 			name:           "not external, bad conf",
 			external:       false,
@@ -192,7 +221,7 @@ func TestValidateLoopInRequest(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			external := test.external
 			conf, err := validateLoopInRequest(
-				test.confTarget, external,
+				test.confTarget, test.amount, external,
 			)
 
 			haveErr := err != nil
@@ -209,6 +238,31 @@ func TestValidateLoopInRequest(t *testing.T) {
 	}
 }
 
+// TestValidateLoopInQuoteRequest tests validation of loop in quote requests,
+// which may set a confirmation target for external htlcs.
+func TestValidateLoopInQuoteRequest(t *testing.T) {
+	// External htlcs without a confirmation target are not estimated.
+	conf, err := validateLoopInQuoteRequest(0, loop.SmallLoopInAmount, true)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), conf)
+
+	// External htlcs may set a confirmation target for their estimate.
+	conf, err = validateLoopInQuoteRequest(
+		100, loop.SmallLoopInAmount, true,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int32(100), conf)
+
+	// The target for external htlcs must still meet our minimum.
+	_, err = validateLoopInQuoteRequest(1, loop.SmallLoopInAmount, true)
+	require.True(t, errors.Is(err, errConfTargetTooLow))
+
+	// Htlcs that we publish get a default target.
+	conf, err = validateLoopInQuoteRequest(0, loop.LargeLoopInAmount, false)
+	require.NoError(t, err)
+	require.Equal(t, loop.LargeLoopInHtlcConfTarget, conf)
+}
+
 // TestValidateLoopOutRequest tests validation of loop out requests.
 func TestValidateLoopOutRequest(t *testing.T) {
 	tests := []struct {
@@ -222,6 +276,8 @@ func TestValidateLoopOutRequest(t *testing.T) {
 		amount          int64
 		maxRoutingFee   int64
 		maxParts        uint32
+		requestParts    uint32
+		serverTarget    int32
 		err             error
 		expectedTarget  int32
 	}{
@@ -250,7 +306,7 @@ func TestValidateLoopOutRequest(t *testing.T) {
 			},
 			amount:         10000,
 			maxParts:       5,
-			err:            errIncorrectChain,
+			err:            loop.ErrDestAddrNetwork,
 			expectedTarget: 0,
 		},
 		{
@@ -278,7 +334,21 @@ func TestValidateLoopOutRequest(t *testing.T) {
 			},
 			amount:         10000,
 			maxParts:       5,
-			err:            errIncorrectChain,
+			err:            loop.ErrDestAddrNetwork,
+			expectedTarget: 0,
+		},
+		{
+			name:       "p2sh address",
+			chain:      chaincfg.MainNetParams,
+			destAddr:   mainnetP2SHAddr,
+			label:      "label ok",
+			confTarget: 2,
+			channels: []lndclient.ChannelInfo{
+				channel2,
+			},
+			amount:         10000,
+			maxParts:       5,
+			err:            loop.ErrDestAddrType,
 			expectedTarget: 0,
 		},
 		{
@@ -309,6 +379,21 @@ func TestValidateLoopOutRequest(t *testing.T) {
 			err:            errConfTargetTooLow,
 			expectedTarget: 0,
 		},
+		{
+			name:       "negative server conf target",
+			chain:      chaincfg.MainNetParams,
+			destAddr:   mainnetAddr,
+			label:      "label ok",
+			confTarget: 2,
+			channels: []lndclient.ChannelInfo{
+				channel2,
+			},
+			amount:         10000,
+			maxParts:       5,
+			serverTarget:   -1,
+			err:            errServerConfTargetNegative,
+			expectedTarget: 0,
+		},
 		{
 			name:       "default conf target",
 			chain:      chaincfg.MainNetParams,
@@ -445,6 +530,36 @@ func TestValidateLoopOutRequest(t *testing.T) {
 			err:            errBalanceTooLow,
 			expectedTarget: 0,
 		},
+		{
+			name:       "request max parts overrides default",
+			chain:      chaincfg.MainNetParams,
+			destAddr:   mainnetAddr,
+			label:      "label ok",
+			confTarget: 2,
+			channels: []lndclient.ChannelInfo{
+				channel2, channel4,
+			},
+			amount:         11000,
+			maxParts:       5,
+			requestParts:   16,
+			err:            nil,
+			expectedTarget: 2,
+		},
+		{
+			name:       "request max parts too low",
+			chain:      chaincfg.MainNetParams,
+			destAddr:   mainnetAddr,
+			label:      "label ok",
+			confTarget: 2,
+			channels: []lndclient.ChannelInfo{
+				channel2, channel4,
+			},
+			amount:         11000,
+			maxParts:       16,
+			requestParts:   1,
+			err:            errBalanceTooLow,
+			expectedTarget: 0,
+		},
 	}
 
 	for _, test := range tests {
@@ -463,11 +578,13 @@ func TestValidateLoopOutRequest(t *testing.T) {
 				OutgoingChanSet:   test.outgoingChanSet,
 				Label:             test.label,
 				SweepConfTarget:   test.confTarget,
+				ServerConfTarget:  test.serverTarget,
+				MaxParts:          test.requestParts,
 			}
 
 			conf, err := validateLoopOutRequest(
 				ctx, lnd.Client, &test.chain, req,
-				test.destAddr, test.maxParts,
+				test.destAddr, test.maxParts, loop.ConfPolicy{},
 			)
 			require.True(t, errors.Is(err, test.err))
 			require.Equal(t, test.expectedTarget, conf)
@@ -568,3 +685,745 @@ func TestHasBandwidth(t *testing.T) {
 		})
 	}
 }
+
+// TestMonitorSnapshot tests the set of updates that is sent to new monitor
+// subscribers, depending on the epoch and sequence number that they resume
+// from.
+func TestMonitorSnapshot(t *testing.T) {
+	var (
+		pending = loop.SwapInfo{
+			SwapStateData: loopdb.SwapStateData{
+				State: loopdb.StateInitiated,
+			},
+			SwapHash: lntypes.Hash{1},
+		}
+
+		completed = loop.SwapInfo{
+			SwapStateData: loopdb.SwapStateData{
+				State: loopdb.StateSuccess,
+			},
+			SwapHash: lntypes.Hash{2},
+		}
+	)
+
+	server := &swapClientServer{
+		swaps: map[lntypes.Hash]loop.SwapInfo{
+			pending.SwapHash:   pending,
+			completed.SwapHash: completed,
+		},
+		epoch:        10,
+		lastSequence: 3,
+		recentUpdates: []swapUpdate{
+			{SwapInfo: completed, sequence: 2},
+			{SwapInfo: pending, sequence: 3},
+		},
+	}
+
+	snapshot := []swapUpdate{
+		{SwapInfo: pending, sequence: 3, snapshot: true},
+	}
+
+	tests := []struct {
+		name          string
+		startEpoch    uint64
+		startSequence uint64
+		expected      []swapUpdate
+	}{
+		{
+			name:          "no start sequence",
+			startEpoch:    10,
+			startSequence: 0,
+			expected:      snapshot,
+		},
+		{
+			name:          "replay updates",
+			startEpoch:    10,
+			startSequence: 1,
+			expected:      server.recentUpdates,
+		},
+		{
+			name:          "replay last update",
+			startEpoch:    10,
+			startSequence: 2,
+			expected:      server.recentUpdates[1:],
+		},
+		{
+			name:          "up to date",
+			startEpoch:    10,
+			startSequence: 3,
+			expected:      nil,
+		},
+		{
+			name:          "unknown sequence",
+			startEpoch:    10,
+			startSequence: 4,
+			expected:      snapshot,
+		},
+		{
+			name:          "no epoch",
+			startEpoch:    0,
+			startSequence: 1,
+			expected:      snapshot,
+		},
+		{
+			name:          "previous epoch",
+			startEpoch:    9,
+			startSequence: 1,
+			expected:      snapshot,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			updates := server.monitorSnapshot(
+				test.startEpoch, test.startSequence,
+			)
+			require.Equal(t, test.expected, updates)
+		})
+	}
+}
+
+// TestHistoricalUpdates tests selecting the terminal state transitions that
+// are replayed to monitor subscribers.
+func TestHistoricalUpdates(t *testing.T) {
+	since := time.Unix(100, 0)
+
+	newSwap := func(hash byte, state loopdb.SwapState,
+		lastUpdate time.Time) *loop.SwapInfo {
+
+		return &loop.SwapInfo{
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+			},
+			SwapHash:   lntypes.Hash{hash},
+			LastUpdate: lastUpdate,
+		}
+	}
+
+	var (
+		pending    = newSwap(1, loopdb.StateInitiated, since.Add(1))
+		tooOld     = newSwap(2, loopdb.StateSuccess, since.Add(-1))
+		succeeded  = newSwap(3, loopdb.StateSuccess, since.Add(2))
+		failedSwap = newSwap(4, loopdb.StateFailTimeout, since)
+	)
+
+	updates := historicalUpdates(
+		[]*loop.SwapInfo{pending, tooOld, succeeded, failedSwap}, since,
+	)
+
+	expected := []swapUpdate{
+		{SwapInfo: *failedSwap, replay: true},
+		{SwapInfo: *succeeded, replay: true},
+	}
+	require.Equal(t, expected, updates)
+}
+
+// TestUnmarshallListSwapsFilter tests conversion of the filters set in a list
+// swaps request to the swap types and store filter that they correspond to.
+func TestUnmarshallListSwapsFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *looprpc.ListSwapsRequest
+		swapTypes []swap.Type
+		filter    *loopdb.SwapFilter
+		err       bool
+	}{
+		{
+			name:   "no filters",
+			req:    &looprpc.ListSwapsRequest{},
+			filter: &loopdb.SwapFilter{},
+		},
+		{
+			name: "all filters",
+			req: &looprpc.ListSwapsRequest{
+				SwapTypes: []looprpc.SwapType{
+					looprpc.SwapType_LOOP_IN,
+					looprpc.SwapType_LOOP_OUT,
+				},
+				States: []looprpc.SwapState{
+					looprpc.SwapState_SUCCESS,
+					looprpc.SwapState_HTLC_PUBLISHED,
+				},
+				Label:       "autoloop",
+				StartTimeNs: 100,
+				EndTimeNs:   200,
+			},
+			swapTypes: []swap.Type{swap.TypeIn, swap.TypeOut},
+			filter: &loopdb.SwapFilter{
+				States: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateHtlcPublished,
+				},
+				Label:     "autoloop",
+				StartTime: time.Unix(0, 100),
+				EndTime:   time.Unix(0, 200),
+			},
+		},
+		{
+			name: "failed matches all failure states",
+			req: &looprpc.ListSwapsRequest{
+				States: []looprpc.SwapState{
+					looprpc.SwapState_FAILED,
+				},
+			},
+			filter: &loopdb.SwapFilter{
+				States: []loopdb.SwapState{
+					loopdb.StateFailOffchainPayments,
+					loopdb.StateFailTimeout,
+					loopdb.StateFailSweepTimeout,
+					loopdb.StateFailInsufficientValue,
+					loopdb.StateFailTemporary,
+					loopdb.StateFailIncorrectHtlcAmt,
+					loopdb.StateFailAbandoned,
+				},
+			},
+		},
+		{
+			name: "unknown swap type",
+			req: &looprpc.ListSwapsRequest{
+				SwapTypes: []looprpc.SwapType{99},
+			},
+			err: true,
+		},
+		{
+			name: "unknown swap state",
+			req: &looprpc.ListSwapsRequest{
+				States: []looprpc.SwapState{99},
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			swapTypes, filter, err := unmarshallListSwapsFilter(
+				test.req,
+			)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.swapTypes, swapTypes)
+			require.Equal(t, test.filter, filter)
+		})
+	}
+}
+
+// TestMergeTemporaryFailures tests that the swaps we read from the store are
+// updated with the temporary failures in our in-memory cache, which are not
+// persisted.
+func TestMergeTemporaryFailures(t *testing.T) {
+	newSwap := func(hash byte, swapType swap.Type, label string,
+		state loopdb.SwapState) loop.SwapInfo {
+
+		return loop.SwapInfo{
+			SwapHash: lntypes.Hash{hash},
+			SwapType: swapType,
+			SwapContract: loopdb.SwapContract{
+				Label: label,
+			},
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+			},
+		}
+	}
+
+	var (
+		// stored is persisted as initiated but has temporarily
+		// failed since.
+		stored     = newSwap(1, swap.TypeOut, "", loopdb.StateInitiated)
+		storedFail = newSwap(
+			1, swap.TypeOut, "", loopdb.StateFailTemporary,
+		)
+
+		// tempFailed is temporarily failed and was left out by the
+		// store because its persisted state does not match.
+		tempFailed = newSwap(
+			2, swap.TypeOut, "", loopdb.StateFailTemporary,
+		)
+
+		// labelled is temporarily failed, but has a label.
+		labelled = newSwap(
+			3, swap.TypeOut, "label", loopdb.StateFailTemporary,
+		)
+
+		// loopIn is a temporarily failed loop in swap.
+		loopIn = newSwap(4, swap.TypeIn, "", loopdb.StateFailTemporary)
+
+		// success is a swap that is not temporarily failed.
+		success = newSwap(5, swap.TypeOut, "", loopdb.StateSuccess)
+	)
+
+	cached := map[lntypes.Hash]loop.SwapInfo{
+		storedFail.SwapHash: storedFail,
+		tempFailed.SwapHash: tempFailed,
+		labelled.SwapHash:   labelled,
+		loopIn.SwapHash:     loopIn,
+		success.SwapHash:    success,
+	}
+
+	tests := []struct {
+		name      string
+		stored    []loop.SwapInfo
+		swapTypes []swap.Type
+		filter    *loopdb.SwapFilter
+		expected  []loop.SwapInfo
+	}{
+		{
+			name:   "no filter",
+			stored: []loop.SwapInfo{stored, success},
+			expected: []loop.SwapInfo{
+				storedFail, success, tempFailed, labelled,
+				loopIn,
+			},
+		},
+		{
+			name:   "initiated swaps",
+			stored: []loop.SwapInfo{stored},
+			filter: &loopdb.SwapFilter{
+				States: []loopdb.SwapState{
+					loopdb.StateInitiated,
+				},
+			},
+			expected: []loop.SwapInfo{},
+		},
+		{
+			name: "failed loop out swaps",
+			filter: &loopdb.SwapFilter{
+				States: []loopdb.SwapState{
+					loopdb.StateFailTemporary,
+				},
+			},
+			swapTypes: []swap.Type{swap.TypeOut},
+			expected: []loop.SwapInfo{
+				storedFail, tempFailed, labelled,
+			},
+		},
+		{
+			name: "labelled swaps",
+			filter: &loopdb.SwapFilter{
+				Label: "label",
+			},
+			expected: []loop.SwapInfo{labelled},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			stored := make([]*loop.SwapInfo, len(test.stored))
+			for i := range test.stored {
+				stored[i] = &test.stored[i]
+			}
+
+			swaps := mergeTemporaryFailures(
+				stored, cached, test.swapTypes, test.filter,
+			)
+
+			actual := make([]loop.SwapInfo, len(swaps))
+			for i, swp := range swaps {
+				actual[i] = *swp
+			}
+			require.ElementsMatch(t, test.expected, actual)
+		})
+	}
+}
+
+// TestMarshallSwapFeeLimits tests that the fee limits that a swap committed
+// to are reported in its rpc status, for both loop out and loop in swaps.
+func TestMarshallSwapFeeLimits(t *testing.T) {
+	contract := loopdb.SwapContract{
+		AmountRequested: 100000,
+		MaxMinerFee:     1000,
+		MaxSwapFee:      2000,
+	}
+
+	tests := []struct {
+		name     string
+		swap     *loop.SwapInfo
+		expected *looprpc.SwapStatus
+	}{
+		{
+			name: "loop out",
+			swap: &loop.SwapInfo{
+				SwapType:            swap.TypeOut,
+				SwapContract:        contract,
+				HtlcAddressP2WSH:    testnetAddr,
+				MaxSwapRoutingFee:   3000,
+				MaxPrepayRoutingFee: 4000,
+			},
+			expected: &looprpc.SwapStatus{
+				MaxMinerFee:         1000,
+				MaxSwapFee:          2000,
+				MaxSwapRoutingFee:   3000,
+				MaxPrepayRoutingFee: 4000,
+			},
+		},
+		{
+			// Loop in swaps do not pay the server off-chain, so
+			// they have no routing fee limits.
+			name: "loop in",
+			swap: &loop.SwapInfo{
+				SwapType:         swap.TypeIn,
+				SwapContract:     contract,
+				HtlcAddressP2WSH: testnetAddr,
+			},
+			expected: &looprpc.SwapStatus{
+				MaxMinerFee: 1000,
+				MaxSwapFee:  2000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			status, err := marshallSwapStatus(test.swap)
+			require.NoError(t, err)
+
+			require.Equal(
+				t, test.expected.MaxMinerFee,
+				status.MaxMinerFee,
+			)
+			require.Equal(
+				t, test.expected.MaxSwapFee, status.MaxSwapFee,
+			)
+			require.Equal(
+				t, test.expected.MaxSwapRoutingFee,
+				status.MaxSwapRoutingFee,
+			)
+			require.Equal(
+				t, test.expected.MaxPrepayRoutingFee,
+				status.MaxPrepayRoutingFee,
+			)
+		})
+	}
+}
+
+// TestMarshallConfig tests that our config is correctly converted to its rpc
+// representation, including defaults.
+func TestMarshallConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RPC.DefaultTimeout = time.Minute
+	cfg.NoTxLabels = true
+	cfg.MaxConcurrentSwaps = 5
+
+	resp := marshallConfig(&cfg)
+	require.Equal(t, cfg.Network, resp.Network)
+	require.Equal(t, cfg.Server.Host, resp.ServerHost)
+	require.Equal(t, cfg.MaxLSATCost, resp.MaxLsatCost)
+	require.Equal(t, cfg.MinPreimageRevealDelta, resp.MinPreimageRevealDelta)
+	require.Equal(t, loop.DefaultHtlcConfTarget, resp.DefaultHtlcConfTarget)
+	require.Equal(t, int32(defaultRPCMaxMsgSize), resp.RpcMaxMsgSize)
+	require.Equal(t, uint32(60), resp.RpcDefaultTimeout)
+	require.Equal(
+		t, uint32(loop.DefaultTermsCacheTTL.Seconds()),
+		resp.TermsCacheTtl,
+	)
+	require.True(t, resp.NoTxLabels)
+	require.Equal(t, uint32(5), resp.MaxConcurrentSwaps)
+}
+
+// TestMergeLiquidityParams tests merging of partial updates into our current
+// liquidity parameters.
+func TestMergeLiquidityParams(t *testing.T) {
+	categories := func() *looprpc.LiquidityParameters {
+		return &looprpc.LiquidityParameters{
+			SweepConfTarget:         100,
+			AutoMaxInFlight:         2,
+			MaxSwapFeePpm:           10,
+			MaxRoutingFeePpm:        10,
+			MaxPrepayRoutingFeePpm:  10,
+			MaxMinerFeeSat:          10,
+			MaxPrepaySat:            10,
+			SweepFeeRateSatPerVbyte: 10,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		current  *looprpc.LiquidityParameters
+		update   *looprpc.LiquidityParameters
+		fields   []string
+		expected *looprpc.LiquidityParameters
+		err      bool
+	}{
+		{
+			name:    "update listed fields only",
+			current: categories(),
+			update: &looprpc.LiquidityParameters{
+				SweepConfTarget: 50,
+				AutoMaxInFlight: 5,
+			},
+			fields: []string{"sweep_conf_target"},
+			expected: func() *looprpc.LiquidityParameters {
+				params := categories()
+				params.SweepConfTarget = 50
+
+				return params
+			}(),
+		},
+		{
+			name:    "clear unset field",
+			current: categories(),
+			update:  &looprpc.LiquidityParameters{},
+			fields:  []string{"auto_max_in_flight"},
+			expected: func() *looprpc.LiquidityParameters {
+				params := categories()
+				params.AutoMaxInFlight = 0
+
+				return params
+			}(),
+		},
+		{
+			name:    "fee portion clears categories",
+			current: categories(),
+			update: &looprpc.LiquidityParameters{
+				FeePpm: 20,
+			},
+			fields: []string{"fee_ppm"},
+			expected: &looprpc.LiquidityParameters{
+				SweepConfTarget: 100,
+				AutoMaxInFlight: 2,
+				FeePpm:          20,
+			},
+		},
+		{
+			name: "category clears fee portion",
+			current: &looprpc.LiquidityParameters{
+				FeePpm: 20,
+			},
+			update: &looprpc.LiquidityParameters{
+				MaxMinerFeeSat: 30,
+			},
+			fields: []string{"max_miner_fee_sat"},
+			expected: &looprpc.LiquidityParameters{
+				MaxMinerFeeSat: 30,
+			},
+		},
+		{
+			name:    "unknown field",
+			current: categories(),
+			update:  &looprpc.LiquidityParameters{},
+			fields:  []string{"not_a_field"},
+			err:     true,
+		},
+		{
+			name:    "read only field",
+			current: categories(),
+			update:  &looprpc.LiquidityParameters{},
+			fields:  []string{"version"},
+			err:     true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			err := mergeLiquidityParams(
+				testCase.current, testCase.update,
+				testCase.fields,
+			)
+			if testCase.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(
+				testCase.expected, testCase.current,
+			))
+		})
+	}
+}
+
+// TestRuleThresholdConversion tests conversion of threshold rules' ppm
+// thresholds and the deprecated percentage thresholds.
+func TestRuleThresholdConversion(t *testing.T) {
+	// Percentage thresholds are used when no ppm thresholds are set.
+	rule, err := rpcToRule(&looprpc.LiquidityRule{
+		Type:              looprpc.LiquidityRuleType_THRESHOLD,
+		IncomingThreshold: 20,
+		OutgoingThreshold: 30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200000, rule.MinimumIncomingPPM)
+	require.Equal(t, 300000, rule.MinimumOutgoingPPM)
+
+	// Ppm thresholds take precedence over percentages.
+	rule, err = rpcToRule(&looprpc.LiquidityRule{
+		Type:                 looprpc.LiquidityRuleType_THRESHOLD,
+		IncomingThreshold:    20,
+		IncomingThresholdPpm: 125000,
+		OutgoingThresholdPpm: 300000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 125000, rule.MinimumIncomingPPM)
+	require.Equal(t, 300000, rule.MinimumOutgoingPPM)
+
+	// Percentages are rounded down when we return a rule.
+	rpcRule := newRPCRule(1, nil, rule)
+	require.Equal(t, uint32(12), rpcRule.IncomingThreshold)
+	require.Equal(t, uint32(125000), rpcRule.IncomingThresholdPpm)
+	require.Equal(t, uint32(30), rpcRule.OutgoingThreshold)
+	require.Equal(t, uint32(300000), rpcRule.OutgoingThresholdPpm)
+}
+
+// TestTargetRuleConversion tests conversion of target rules to and from their
+// rpc representation.
+func TestTargetRuleConversion(t *testing.T) {
+	rpcRule := &looprpc.LiquidityRule{
+		Pubkey:         peer1[:],
+		Type:           looprpc.LiquidityRuleType_TARGET,
+		TargetLocalPpm: 500000,
+		TolerancePpm:   150000,
+	}
+
+	rule, err := rpcToRule(rpcRule)
+	require.NoError(t, err)
+	require.Equal(t, 500000, rule.TargetLocalPPM)
+	require.Equal(t, 150000, rule.TolerancePPM)
+	require.Equal(t, 350000, rule.MinimumIncomingPPM)
+	require.Equal(t, 350000, rule.MinimumOutgoingPPM)
+
+	// When we return the rule, its thresholds are set to the bounds of its
+	// tolerance band.
+	resp := newRPCRule(0, peer1[:], rule)
+	require.Equal(t, looprpc.LiquidityRuleType_TARGET, resp.Type)
+	require.Equal(t, uint32(500000), resp.TargetLocalPpm)
+	require.Equal(t, uint32(150000), resp.TolerancePpm)
+	require.Equal(t, uint32(350000), resp.IncomingThresholdPpm)
+	require.Equal(t, uint32(350000), resp.OutgoingThresholdPpm)
+
+	// Target rules must set a target.
+	rpcRule.TargetLocalPpm = 0
+	_, err = rpcToRule(rpcRule)
+	require.Error(t, err)
+}
+
+// TestRPCAutoloopStatus tests conversion of our autoloop status, including
+// its budget and events, to its rpc representation.
+func TestRPCAutoloopStatus(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		hash  = lntypes.Hash{1}
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+	)
+
+	status := liquidity.AutoloopStatus{
+		LastCheck:         start,
+		LastChange:        start,
+		DisqualifiedChans: map[lnwire.ShortChannelID]liquidity.Reason{},
+		DisqualifiedPeers: map[route.Vertex]liquidity.Reason{},
+		Budget: &liquidity.Budget{
+			Total:     10000,
+			StartDate: start,
+			Spent:     2000,
+			Pending:   3000,
+			InFlight:  1,
+		},
+		Events: []liquidity.Event{
+			{
+				Time:    start,
+				Type:    liquidity.EventDisqualified,
+				Channel: chan1,
+				Reason:  liquidity.ReasonLoopOut,
+			},
+			{
+				Time: start,
+				Type: liquidity.EventRequalified,
+				Peer: peer1,
+			},
+			{
+				Time:     start,
+				Type:     liquidity.EventSwapDispatched,
+				SwapHash: hash,
+				SwapType: swap.TypeIn,
+				Amount:   50000,
+			},
+		},
+	}
+
+	resp, err := rpcAutoloopStatus(status)
+	require.NoError(t, err)
+
+	require.Equal(t, &looprpc.AutoloopBudget{
+		BudgetSat:    10000,
+		StartDate:    uint64(start.Unix()),
+		SpentSat:     2000,
+		PendingSat:   3000,
+		AvailableSat: 5000,
+		InFlight:     1,
+	}, resp.Budget)
+
+	var (
+		disqualified = looprpc.
+				AutoloopEventType_AUTOLOOP_EVENT_DISQUALIFIED
+		requalified = looprpc.
+				AutoloopEventType_AUTOLOOP_EVENT_REQUALIFIED
+		dispatched = looprpc.
+				AutoloopEventType_AUTOLOOP_EVENT_SWAP_DISPATCHED
+	)
+
+	require.Equal(t, []*looprpc.AutoloopEvent{
+		{
+			Timestamp: start.Unix(),
+			Type:      disqualified,
+			ChannelId: chan1.ToUint64(),
+			Reason:    looprpc.AutoReason_AUTO_REASON_LOOP_OUT,
+		},
+		{
+			Timestamp: start.Unix(),
+			Type:      requalified,
+			Pubkey:    peer1[:],
+			Reason:    looprpc.AutoReason_AUTO_REASON_UNKNOWN,
+		},
+		{
+			Timestamp: start.Unix(),
+			Type:      dispatched,
+			SwapHash:  hash[:],
+			SwapType:  looprpc.SwapType_LOOP_IN,
+			AmountSat: 50000,
+		},
+	}, resp.Events)
+
+	// Every reason and event type that we record must have an rpc
+	// representation, so that our api remains a stable contract.
+	lastReason := liquidity.ReasonInactive
+	for reason := liquidity.ReasonNone; reason <= lastReason; reason++ {
+		_, err := rpcAutoloopReason(reason)
+		require.NoError(t, err, reason)
+	}
+
+	lastEvent := liquidity.EventSwapDispatched
+	for event := liquidity.EventDisqualified; event <= lastEvent; event++ {
+		_, err := rpcAutoloopEventType(event)
+		require.NoError(t, err, event)
+	}
+}
+
+// TestMarshallAPIVersion tests creation of our api version response.
+func TestMarshallAPIVersion(t *testing.T) {
+	resp := marshallAPIVersion(looprpc.File_client_proto)
+
+	require.Equal(t, looprpc.APIVersion(), resp.Version)
+	require.Contains(t, resp.Methods, "looprpc.SwapClient.LoopOut")
+	require.Contains(t, resp.Methods, "looprpc.SwapClient.GetApiVersion")
+
+	require.Len(t, resp.Deprecations, len(looprpc.Deprecations))
+	require.True(t, sort.SliceIsSorted(
+		resp.Deprecations, func(i, j int) bool {
+			return resp.Deprecations[i].Name <
+				resp.Deprecations[j].Name
+		},
+	))
+}
@@ -1,12 +1,20 @@
 package loopd
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/liquidity"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/notifications"
+	"github.com/lightninglabs/loop/staticaddr"
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/ticker"
@@ -16,18 +24,45 @@ import (
 func getClient(config *Config, lnd *lndclient.LndServices) (*loop.Client,
 	func(), error) {
 
+	dbPassword, err := readDBPassword(config.DBPasswordFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	clientConfig := &loop.ClientConfig{
-		ServerAddress:   config.Server.Host,
-		ProxyAddress:    config.Server.Proxy,
-		SwapServerNoTLS: config.Server.NoTLS,
-		TLSPathServer:   config.Server.TLSPath,
-		Lnd:             lnd,
-		MaxLsatCost:     btcutil.Amount(config.MaxLSATCost),
-		MaxLsatFee:      btcutil.Amount(config.MaxLSATFee),
-		LoopOutMaxParts: config.LoopOutMaxParts,
+		ServerAddress:           config.Server.Host,
+		FailoverServerAddresses: config.Server.FailoverHosts,
+		ServerFailoverTimeout:   config.Server.FailoverTimeout,
+		ProxyAddress:            config.Server.Proxy,
+		SwapServerNoTLS:         config.Server.NoTLS,
+		TLSPathServer:           config.Server.TLSPath,
+		Lnd:                     lnd,
+		MaxLsatCost:             btcutil.Amount(config.MaxLSATCost),
+		MaxLsatFee:              btcutil.Amount(config.MaxLSATFee),
+		LoopOutMaxParts:         config.LoopOutMaxParts,
+		LoopOutPaymentTimeout:   config.LoopOutPaymentTimeout,
+		BatchSweeps:             config.BatchSweeps,
+		SweepFeeBumpBlocks:      config.SweepFeeBumpBlocks,
+		LoopInRefundConfTarget:  config.LoopInRefundConfTarget,
+		LoopInRefundMaxFee:      btcutil.Amount(config.LoopInRefundMaxFee),
+		MinPreimageRevealDelta:  config.MinPreimageRevealDelta,
+		DestXpub:                config.DestXpub,
+		MaxLockedValue:          btcutil.Amount(config.MaxLockedValue),
+		MaxLoopInWalletFraction: config.MaxLoopInWalletFraction,
+		DBPassword:              dbPassword,
+		ForceStaleDB:            config.ForceStaleDB,
+		TermsCacheTTL:           config.TermsCacheTTL,
+		ConfPolicy:              config.ConfPolicy.policy(),
+		NoTxLabels:              config.NoTxLabels,
+		MaxConcurrentSwaps:      config.MaxConcurrentSwaps,
 	}
 
 	swapClient, cleanUp, err := loop.NewClient(config.DataDir, clientConfig)
+	if errors.Is(err, loopdb.ErrStaleDB) {
+		return nil, nil, fmt.Errorf("%w: it may have been restored "+
+			"from an older copy, use --force-stale-db to start "+
+			"anyway if you are sure that it is current", err)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -35,7 +70,30 @@ func getClient(config *Config, lnd *lndclient.LndServices) (*loop.Client,
 	return swapClient, cleanUp, nil
 }
 
-func getLiquidityManager(client *loop.Client) *liquidity.Manager {
+// readDBPassword reads the password that our database is encrypted with from
+// the file provided, trimming any trailing newline. If no file is set, a nil
+// password is returned.
+func readDBPassword(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	password, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	password = bytes.TrimRight(password, "\r\n")
+	if len(password) == 0 {
+		return nil, errors.New("dbpasswordfile is empty")
+	}
+
+	return password, nil
+}
+
+func getLiquidityManager(client *loop.Client, rebalance *rebalanceEstimator,
+	notifier *notifications.Manager, config *Config) *liquidity.Manager {
+
 	mngrCfg := &liquidity.Config{
 		AutoloopTicker: ticker.NewForce(liquidity.DefaultAutoloopTicker),
 		LoopOut:        client.LoopOut,
@@ -62,13 +120,122 @@ func getLiquidityManager(client *loop.Client) *liquidity.Manager {
 				inTerms.MinSwapAmount, inTerms.MaxSwapAmount,
 			), nil
 		},
-		Lnd:                  client.LndServices,
-		Clock:                clock.NewDefaultClock(),
-		LoopOutQuote:         client.LoopOutQuote,
-		ListLoopOut:          client.Store.FetchLoopOutSwaps,
-		ListLoopIn:           client.Store.FetchLoopInSwaps,
-		MinimumConfirmations: minConfTarget,
+		Lnd:                     client.LndServices,
+		Clock:                   client.Clock,
+		LoopOutQuote:            client.LoopOutQuote,
+		LoopInQuote:             client.LoopInQuote,
+		LoopIn:                  client.LoopIn,
+		ListLoopOut:             client.Store.FetchLoopOutSwaps,
+		ListLoopIn:              client.Store.FetchLoopInSwaps,
+		MinimumConfirmations:    minConfTarget,
+		MaxLockedValue:          client.MaxLockedValue,
+		MaxLoopInWalletFraction: client.MaxLoopInWalletFraction,
+		ParamsChangeDelay:       config.AutoloopChangeDelay,
+		SnapshotInterval:        config.LiquiditySnapshotInterval,
+		AddSnapshots:            client.Store.AddLiquiditySnapshots,
+		ListSnapshots:           client.Store.FetchLiquiditySnapshots,
+		PruneSnapshots:          client.Store.PruneLiquiditySnapshots,
+		AddPeerOutcome:          client.Store.AddPeerOutcome,
+		ListPeerOutcomes:        client.Store.FetchPeerOutcomes,
+		AddRuleSwap:             client.Store.AddRuleSwap,
+		ListRuleSwaps:           client.Store.FetchRuleSwaps,
+		CheckServerHealth:       client.CheckServerHealth,
+		ConfPolicy:              client.ConfPolicy,
+		StaleRuleAge:            config.StaleRuleAge,
+		PruneStaleRules:         config.PruneStaleRules,
+		ReconcileInterval:       config.BudgetReconcileInterval,
+	}
+
+	if rebalance != nil {
+		mngrCfg.EstimateRebalance = rebalance.estimate
+	}
+
+	if notifier != nil {
+		mngrCfg.BudgetExhausted = func(budget btcutil.Amount) {
+			event := notifications.NewEvent(
+				notifications.EventBudgetExhausted, time.Now(),
+			)
+			event.BudgetSat = int64(budget)
+
+			notifier.Notify(event)
+		}
 	}
 
 	return liquidity.NewManager(mngrCfg)
 }
+
+// getNotifier returns a notifications manager that delivers events to the
+// sinks in our config, or nil if no sinks are configured.
+func getNotifier(cfg *notificationsConfig) *notifications.Manager {
+	var sinks []notifications.Sink
+	if cfg.WebhookURL != "" {
+		sinks = append(
+			sinks, notifications.NewWebhookSink(cfg.WebhookURL),
+		)
+	}
+
+	if cfg.Command != "" {
+		sinks = append(
+			sinks, notifications.NewCommandSink(cfg.Command),
+		)
+	}
+
+	var messaging []notifications.Sink
+	if cfg.SMTPServer != "" {
+		messaging = append(messaging, notifications.NewEmailSink(
+			cfg.SMTPServer, cfg.SMTPUser, cfg.SMTPPassword,
+			cfg.EmailFrom, cfg.EmailTo,
+		))
+	}
+
+	if cfg.TelegramToken != "" {
+		messaging = append(messaging, notifications.NewTelegramSink(
+			cfg.TelegramToken, cfg.TelegramChatID,
+		))
+	}
+
+	if cfg.MatrixHomeserver != "" {
+		messaging = append(messaging, notifications.NewMatrixSink(
+			cfg.MatrixHomeserver, cfg.MatrixToken, cfg.MatrixRoom,
+		))
+	}
+
+	if len(sinks) == 0 && len(messaging) == 0 {
+		return nil
+	}
+
+	return notifications.NewManager(&notifications.Config{
+		Sinks:          sinks,
+		MessagingSinks: messaging,
+		RateLimit:      cfg.RateLimit,
+		Timeout:        cfg.Timeout,
+		Clock:          clock.NewDefaultClock(),
+	})
+}
+
+// getStaticAddressManager returns a deposit tracker for our static address
+// that dispatches loop in swaps with the client provided.
+func getStaticAddressManager(client *loop.Client) *staticaddr.Manager {
+	lnd := client.LndServices
+
+	return staticaddr.NewManager(&staticaddr.Config{
+		Store:       client.Store,
+		ChainParams: lnd.ChainParams,
+		Ticker:      ticker.New(staticaddr.DefaultPollInterval),
+		Clock:       client.Clock,
+		NextAddr:    lnd.WalletKit.NextAddr,
+		ListUnspent: lnd.WalletKit.ListUnspent,
+		BestHeight: func(ctx context.Context) (int32, error) {
+			info, err := lnd.Client.GetInfo(ctx)
+			if err != nil {
+				return 0, err
+			}
+
+			return int32(info.BlockHeight), nil
+		},
+		LoopInTerms:    client.LoopInTerms,
+		LoopInQuote:    client.LoopInQuote,
+		LoopIn:         client.LoopIn,
+		HtlcConfTarget: loop.DefaultHtlcConfTarget,
+	})
+}
@@ -1,7 +1,9 @@
 package loopd
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/lightninglabs/lndclient"
@@ -24,6 +26,10 @@ func view(config *Config, lisCfg *listenerCfg) error {
 	if err != nil {
 		return err
 	}
+
+	if config.View.Prune {
+		return prune(config, swapClient, cleanup)
+	}
 	defer cleanup()
 
 	chainParams, err := network.ChainParams()
@@ -42,15 +48,48 @@ func view(config *Config, lisCfg *listenerCfg) error {
 	return nil
 }
 
+// prune deletes all swaps that reached a final state more than our retention
+// period ago, and then compacts our database to reclaim their space. Our
+// client is shut down with the cleanup function provided before compaction,
+// because compaction requires exclusive access to our database.
+func prune(config *Config, swapClient *loop.Client, cleanup func()) error {
+	retention := config.View.Retention
+	if err := validatePruneRetention(retention); err != nil {
+		cleanup()
+		return err
+	}
+
+	pruned, err := swapClient.PruneSwaps(
+		context.Background(), time.Now().Add(-retention),
+	)
+	cleanup()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pruned %v swaps that completed more than %v ago\n",
+		len(pruned), retention)
+
+	sizeBefore, sizeAfter, err := loopdb.CompactDB(config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Compacted database from %v to %v bytes\n", sizeBefore,
+		sizeAfter)
+
+	return nil
+}
+
 func viewOut(swapClient *loop.Client, chainParams *chaincfg.Params) error {
-	swaps, err := swapClient.Store.FetchLoopOutSwaps()
+	swaps, err := swapClient.Store.FetchLoopOutSwaps(context.Background())
 	if err != nil {
 		return err
 	}
 
 	for _, s := range swaps {
 		htlc, err := swap.NewHtlc(
-			loop.GetHtlcScriptVersion(s.Contract.ProtocolVersion),
+			s.Contract.HtlcVersion,
 			s.Contract.CltvExpiry,
 			s.Contract.SenderKey,
 			s.Contract.ReceiverKey,
@@ -66,6 +105,7 @@ func viewOut(swapClient *loop.Client, chainParams *chaincfg.Params) error {
 		)
 		fmt.Printf("   Preimage: %v\n", s.Contract.Preimage)
 		fmt.Printf("   Htlc address: %v\n", htlc.Address)
+		fmt.Printf("   Htlc version: %v\n", htlc.Version)
 
 		fmt.Printf("   Uncharge channels: %v\n",
 			s.Contract.OutgoingChanSet)
@@ -95,14 +135,14 @@ func viewOut(swapClient *loop.Client, chainParams *chaincfg.Params) error {
 }
 
 func viewIn(swapClient *loop.Client, chainParams *chaincfg.Params) error {
-	swaps, err := swapClient.Store.FetchLoopInSwaps()
+	swaps, err := swapClient.Store.FetchLoopInSwaps(context.Background())
 	if err != nil {
 		return err
 	}
 
 	for _, s := range swaps {
 		htlc, err := swap.NewHtlc(
-			loop.GetHtlcScriptVersion(s.Contract.ProtocolVersion),
+			s.Contract.HtlcVersion,
 			s.Contract.CltvExpiry,
 			s.Contract.SenderKey,
 			s.Contract.ReceiverKey,
@@ -118,6 +158,7 @@ func viewIn(swapClient *loop.Client, chainParams *chaincfg.Params) error {
 		)
 		fmt.Printf("   Preimage: %v\n", s.Contract.Preimage)
 		fmt.Printf("   Htlc address: %v\n", htlc.Address)
+		fmt.Printf("   Htlc version: %v\n", htlc.Version)
 		fmt.Printf("   Amt: %v, Expiry: %v\n",
 			s.Contract.AmountRequested, s.Contract.CltvExpiry,
 		)
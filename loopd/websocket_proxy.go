@@ -0,0 +1,334 @@
+package loopd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// defaultWSPingInterval is the default interval at which we send ping
+	// messages to websocket clients.
+	defaultWSPingInterval = 30 * time.Second
+
+	// defaultWSPongWait is the default amount of time that we wait for a
+	// websocket client to respond to a ping before we close its
+	// connection.
+	defaultWSPongWait = 5 * time.Second
+
+	// headerWebSocketProtocol is the header that browsers use to send the
+	// sub-protocols that a websocket client supports. Because browsers
+	// cannot set arbitrary headers on websocket requests, clients may use
+	// it to send headers, such as their macaroon, as
+	// <header name>+<header value>.
+	headerWebSocketProtocol = "Sec-Websocket-Protocol"
+
+	// webSocketProtocolDelimiter separates the name and value of a header
+	// that is sent as a websocket sub-protocol.
+	webSocketProtocolDelimiter = "+"
+
+	// maxWebSocketMsgSize is the largest message that we read from our
+	// REST proxy and forward to a websocket client.
+	maxWebSocketMsgSize = 1024 * 1024 * 200
+)
+
+var (
+	// forwardedWebSocketHeaders is the set of headers that clients may
+	// send as websocket sub-protocols, which are added to the request that
+	// we forward to our REST proxy.
+	forwardedWebSocketHeaders = map[string]struct{}{
+		"Grpc-Metadata-Macaroon": {},
+	}
+)
+
+// webSocketProxy wraps our REST proxy so that clients can consume streaming
+// rpcs over a websocket. Each message that the stream delivers is sent to
+// the client as a single text message, and clients are kept alive with
+// ping/pong messages. Requests that are not websocket upgrades are passed
+// through to the REST proxy unchanged.
+type webSocketProxy struct {
+	backend      http.Handler
+	upgrader     *websocket.Upgrader
+	pingInterval time.Duration
+	pongWait     time.Duration
+}
+
+// newWebSocketProxy returns a handler that serves websocket requests with the
+// REST proxy provided. If a CORS origin is set, websocket connections from
+// that origin are accepted, otherwise only connections from our own host are
+// allowed.
+func newWebSocketProxy(backend http.Handler, corsOrigin string,
+	pingInterval, pongWait time.Duration) http.Handler {
+
+	upgrader := &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+	}
+
+	if corsOrigin != "" {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return corsOrigin == "*" || origin == corsOrigin
+		}
+	}
+
+	return &webSocketProxy{
+		backend:      backend,
+		upgrader:     upgrader,
+		pingInterval: pingInterval,
+		pongWait:     pongWait,
+	}
+}
+
+// ServeHTTP serves websocket upgrade requests through our REST proxy, and
+// passes all other requests through to it.
+//
+// NOTE: This is part of the http.Handler interface.
+func (p *webSocketProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		p.backend.ServeHTTP(w, r)
+		return
+	}
+
+	p.serveWebSocket(w, r)
+}
+
+// serveWebSocket upgrades a request to a websocket and forwards the stream
+// that our REST proxy returns for it to the client.
+func (p *webSocketProxy) serveWebSocket(w http.ResponseWriter,
+	r *http.Request) {
+
+	// Browsers require that we echo one of the sub-protocols that they
+	// sent, so we pick the first header that we forward.
+	headers, protocol := webSocketHeaders(r)
+
+	var responseHeader http.Header
+	if protocol != "" {
+		responseHeader = http.Header{
+			headerWebSocketProtocol: []string{protocol},
+		}
+	}
+
+	conn, err := p.upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		log.Errorf("Could not upgrade websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Our backend request is cancelled when the client goes away.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	request, err := http.NewRequest(r.Method, r.URL.String(), nil)
+	if err != nil {
+		log.Errorf("Could not create websocket request: %v", err)
+		return
+	}
+	request = request.WithContext(ctx)
+
+	for name, values := range r.Header {
+		if isWebSocketHeader(name) {
+			continue
+		}
+
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	// Closing our end of the stream when we exit makes sure that the
+	// backend does not block writing to it.
+	response := newStreamWriter()
+	defer response.Close()
+
+	go func() {
+		defer response.closeWriter()
+		p.backend.ServeHTTP(response, request)
+	}()
+
+	// We need to read from the connection to process control messages
+	// and to notice when the client closes it. Clients only consume
+	// streams, so any other messages that they send are ignored.
+	readTimeout := p.pingInterval + p.pongWait
+	if err := conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+		log.Errorf("Could not set websocket read deadline: %v", err)
+		return
+	}
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go p.ping(ctx, conn)
+
+	scanner := bufio.NewScanner(response)
+	scanner.Buffer(make([]byte, 64*1024), maxWebSocketMsgSize)
+	for scanner.Scan() {
+		message := scanner.Bytes()
+		if len(message) == 0 {
+			continue
+		}
+
+		err := conn.WriteMessage(websocket.TextMessage, message)
+		if err != nil {
+			log.Debugf("Could not write websocket message: %v", err)
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Errorf("Could not read websocket stream: %v", err)
+	}
+
+	// Let the client know that the stream has ended.
+	err = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(p.pongWait),
+	)
+	if err != nil {
+		log.Debugf("Could not close websocket: %v", err)
+	}
+}
+
+// ping sends ping messages to a websocket client until the context provided
+// is cancelled. Clients that do not respond with a pong message will time out
+// on our read deadline.
+func (p *webSocketProxy) ping(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(p.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := conn.WriteControl(
+				websocket.PingMessage, nil,
+				time.Now().Add(p.pongWait),
+			)
+			if err != nil {
+				log.Debugf("Could not ping websocket: %v", err)
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// webSocketHeaders extracts the headers that a client sent as websocket
+// sub-protocols. It returns the headers and the first sub-protocol that
+// contained one of them.
+func webSocketHeaders(r *http.Request) (map[string]string, string) {
+	var (
+		headers  = make(map[string]string)
+		protocol string
+	)
+
+	for _, value := range r.Header.Values(headerWebSocketProtocol) {
+		for _, candidate := range strings.Split(value, ",") {
+			candidate = strings.TrimSpace(candidate)
+
+			parts := strings.SplitN(
+				candidate, webSocketProtocolDelimiter, 2,
+			)
+			if len(parts) != 2 {
+				continue
+			}
+
+			name := textproto.CanonicalMIMEHeaderKey(parts[0])
+			if _, ok := forwardedWebSocketHeaders[name]; !ok {
+				continue
+			}
+
+			headers[name] = parts[1]
+			if protocol == "" {
+				protocol = candidate
+			}
+		}
+	}
+
+	return headers, protocol
+}
+
+// isWebSocketHeader returns a boolean indicating whether a header is part of
+// the websocket handshake, and should not be forwarded to our REST proxy.
+func isWebSocketHeader(name string) bool {
+	name = textproto.CanonicalMIMEHeaderKey(name)
+	if name == "Upgrade" || name == "Connection" {
+		return true
+	}
+
+	return strings.HasPrefix(name, "Sec-Websocket-")
+}
+
+// streamWriter is a http.ResponseWriter that makes the body written by our
+// REST proxy available to be read as it is streamed.
+type streamWriter struct {
+	*io.PipeReader
+	writer *io.PipeWriter
+	header http.Header
+}
+
+// newStreamWriter creates a new stream writer.
+func newStreamWriter() *streamWriter {
+	reader, writer := io.Pipe()
+
+	return &streamWriter{
+		PipeReader: reader,
+		writer:     writer,
+		header:     make(http.Header),
+	}
+}
+
+// Header returns the headers of our response.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (s *streamWriter) Header() http.Header {
+	return s.header
+}
+
+// Write writes part of our response body.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (s *streamWriter) Write(b []byte) (int, error) {
+	return s.writer.Write(b)
+}
+
+// WriteHeader is a no-op, because websocket clients do not receive our
+// status code. Errors are delivered to them in the body of the stream.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (s *streamWriter) WriteHeader(int) {}
+
+// Flush is a no-op, because all writes are delivered to our reader
+// immediately.
+//
+// NOTE: This is part of the http.Flusher interface.
+func (s *streamWriter) Flush() {}
+
+// closeWriter signals that the response is complete.
+func (s *streamWriter) closeWriter() {
+	_ = s.writer.Close()
+}
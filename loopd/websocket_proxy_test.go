@@ -0,0 +1,75 @@
+package loopd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebSocketProxy tests that the messages of a stream are delivered to a
+// websocket client, and that headers sent as sub-protocols are forwarded.
+func TestWebSocketProxy(t *testing.T) {
+	macaroonHeader := make(chan string, 1)
+	backend := http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+
+		macaroonHeader <- r.Header.Get("Grpc-Metadata-Macaroon")
+
+		_, _ = w.Write([]byte("{\"result\":1}\n{\"result\":2}\n"))
+	})
+
+	server := httptest.NewServer(newWebSocketProxy(
+		backend, "", time.Second, time.Second,
+	))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/v1/loop/monitor"
+	protocol := "Grpc-Metadata-Macaroon+abcd"
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{
+		headerWebSocketProtocol: []string{protocol},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The sub-protocol that contained our macaroon should be echoed back,
+	// and the macaroon forwarded as a header.
+	require.Equal(t, protocol, resp.Header.Get(headerWebSocketProtocol))
+	require.Equal(t, "abcd", <-macaroonHeader)
+
+	for _, expected := range []string{"{\"result\":1}", "{\"result\":2}"} {
+		msgType, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, websocket.TextMessage, msgType)
+		require.Equal(t, expected, string(msg))
+	}
+
+	// Once the stream ends, the connection should be closed normally.
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(
+		err, websocket.CloseNormalClosure,
+	))
+}
+
+// TestWebSocketProxyPassthrough tests that requests which are not websocket
+// upgrades are served by the REST proxy directly.
+func TestWebSocketProxyPassthrough(t *testing.T) {
+	backend := http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	proxy := newWebSocketProxy(backend, "", time.Second, time.Second)
+
+	recorder := httptest.NewRecorder()
+	proxy.ServeHTTP(
+		recorder, httptest.NewRequest(http.MethodGet, "/v1/loop/swaps", nil),
+	)
+	require.Equal(t, http.StatusTeapot, recorder.Code)
+}
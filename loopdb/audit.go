@@ -0,0 +1,216 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// auditLogBucketKey is a bucket that contains an append-only log of
+	// every state transition of our swaps. Entries are written in the
+	// same transaction as the update that they record, and are never
+	// deleted, so that the log survives pruning of the swaps themselves.
+	// This bucket is keyed by a sequence number, so that entries are
+	// iterated in the order they were written.
+	//
+	// maps: sequence number -> serialized audit entry
+	auditLogBucketKey = []byte("audit-log")
+)
+
+// AuditComponent identifies the component of the client that triggered a
+// swap state transition.
+type AuditComponent uint8
+
+const (
+	// AuditComponentUnknown indicates that the component that triggered a
+	// state transition was not recorded.
+	AuditComponentUnknown AuditComponent = 0
+
+	// AuditComponentLoopOut indicates that a state transition was made by
+	// the loop out swap state machine.
+	AuditComponentLoopOut AuditComponent = 1
+
+	// AuditComponentLoopIn indicates that a state transition was made by
+	// the loop in swap state machine.
+	AuditComponentLoopIn AuditComponent = 2
+
+	// AuditComponentAbandon indicates that a state transition was made
+	// because the user abandoned the swap.
+	AuditComponentAbandon AuditComponent = 3
+)
+
+// String returns a string representation of an audit component.
+func (a AuditComponent) String() string {
+	switch a {
+	case AuditComponentLoopOut:
+		return "loop out"
+
+	case AuditComponentLoopIn:
+		return "loop in"
+
+	case AuditComponentAbandon:
+		return "abandon"
+
+	default:
+		return "unknown"
+	}
+}
+
+// AuditEntry is a record of a single swap state transition.
+type AuditEntry struct {
+	// Time is the time of the state transition.
+	Time time.Time
+
+	// SwapHash is the hash of the swap.
+	SwapHash lntypes.Hash
+
+	// SwapType is the type of the swap.
+	SwapType swap.Type
+
+	// PreviousState is the state that the swap was in before the
+	// transition.
+	PreviousState SwapState
+
+	// State is the state that the swap transitioned to.
+	State SwapState
+
+	// Component is the component of the client that triggered the
+	// transition.
+	Component AuditComponent
+}
+
+// addAuditEntry appends an entry to our audit log.
+func addAuditEntry(tx *bbolt.Tx, entry *AuditEntry) error {
+	bucket, err := tx.CreateBucketIfNotExists(auditLogBucketKey)
+	if err != nil {
+		return err
+	}
+
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	entryBytes, err := serializeAuditEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(itob(seq), entryBytes)
+}
+
+// lastState returns the state of the most recent update in a swap's updates
+// bucket, or StateInitiated if the swap has no updates yet.
+func lastState(updatesBucket *bbolt.Bucket) (SwapState, error) {
+	key, _ := updatesBucket.Cursor().Last()
+	if key == nil {
+		return StateInitiated, nil
+	}
+
+	updateBucket := updatesBucket.Bucket(key)
+	if updateBucket == nil {
+		return 0, fmt.Errorf("update %x not found", key)
+	}
+
+	event, err := deserializeLoopEvent(updateBucket.Get(basicStateKey))
+	if err != nil {
+		return 0, err
+	}
+
+	return event.State, nil
+}
+
+// FetchAuditLog returns all the entries in our audit log, in the order that
+// they were written.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchAuditLog(ctx context.Context) ([]*AuditEntry,
+	error) {
+
+	var entries []*AuditEntry
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(auditLogBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			entry, err := deserializeAuditEntry(v)
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, entry)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// serializeAuditEntry serializes an audit entry.
+func serializeAuditEntry(entry *AuditEntry) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		entry.Time.UnixNano(),
+		entry.SwapHash[:],
+		entry.SwapType,
+		entry.PreviousState,
+		entry.State,
+		entry.Component,
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeAuditEntry deserializes an audit entry.
+func deserializeAuditEntry(value []byte) (*AuditEntry, error) {
+	var (
+		r        = bytes.NewReader(value)
+		entry    AuditEntry
+		unixNano int64
+	)
+
+	fields := []interface{}{
+		&unixNano,
+		entry.SwapHash[:],
+		&entry.SwapType,
+		&entry.PreviousState,
+		&entry.State,
+		&entry.Component,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read audit entry: %v",
+				err)
+		}
+	}
+
+	entry.Time = time.Unix(0, unixNano)
+
+	return &entry, nil
+}
@@ -0,0 +1,91 @@
+package loopdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditLog tests that every swap state transition is recorded in our
+// audit log, and that the log is not affected by pruning swaps.
+func TestAuditLog(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	entries, err := store.FetchAuditLog(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 0)
+
+	hash := sha256.Sum256(testPreimage[:])
+	contract := &LoopInContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        testPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  time.Unix(0, 1),
+		},
+	}
+	require.NoError(t, store.CreateLoopIn(hash, contract))
+
+	published := time.Unix(0, 100)
+	err = store.UpdateLoopIn(hash, published, SwapStateData{
+		State:     StateHtlcPublished,
+		Component: AuditComponentLoopIn,
+	})
+	require.NoError(t, err)
+
+	abandoned := time.Unix(0, 200)
+	err = store.UpdateLoopIn(hash, abandoned, SwapStateData{
+		State:     StateFailAbandoned,
+		Component: AuditComponentAbandon,
+	})
+	require.NoError(t, err)
+
+	expected := []*AuditEntry{
+		{
+			Time:          published,
+			SwapHash:      hash,
+			SwapType:      swap.TypeIn,
+			PreviousState: StateInitiated,
+			State:         StateHtlcPublished,
+			Component:     AuditComponentLoopIn,
+		},
+		{
+			Time:          abandoned,
+			SwapHash:      hash,
+			SwapType:      swap.TypeIn,
+			PreviousState: StateHtlcPublished,
+			State:         StateFailAbandoned,
+			Component:     AuditComponentAbandon,
+		},
+	}
+
+	entries, err = store.FetchAuditLog(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+
+	// Pruning our swap should not remove its entries from the audit log.
+	pruned, err := store.PruneSwaps(ctx, time.Unix(0, 300))
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+
+	entries, err = store.FetchAuditLog(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+}
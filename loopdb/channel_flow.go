@@ -0,0 +1,244 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// outgoingChanBalancesKey is the key that stores the local balances
+	// of the channels in a loop out's outgoing channel set at the time
+	// that the swap was initiated.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> outgoingChanBalancesKey
+	//
+	// value: concatenation of uint64 channel id || int64 balance
+	outgoingChanBalancesKey = []byte("outgoing-chan-balances")
+
+	// channelFlowKey is the key that stores the amount of a loop out's
+	// swap payment that flowed through its outgoing channel set, as
+	// determined once the swap completed.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> channelFlowKey
+	//
+	// value: int64 expected || int64 flowed || balances
+	channelFlowKey = []byte("channel-flow")
+)
+
+// ChannelBalances maps the short channel ids of a set of channels to their
+// local balance.
+type ChannelBalances map[uint64]btcutil.Amount
+
+// ChannelFlow records how much of a loop out's swap payment flowed through
+// the channels that the swap was restricted to.
+type ChannelFlow struct {
+	// Balances contains the local balances of the outgoing channels once
+	// the swap completed.
+	Balances ChannelBalances
+
+	// Expected is the amount of the swap payment that we expected to flow
+	// through the outgoing channels.
+	Expected btcutil.Amount
+
+	// Flowed is the decrease in local balance of the outgoing channels
+	// over the course of the swap, capped at the expected amount.
+	Flowed btcutil.Amount
+}
+
+// RoutedAround returns a boolean indicating whether part of the swap payment
+// was not observed to flow through the outgoing channel set. Note that
+// balance changes are also affected by other payments and forwards in the
+// channels, so this is an indication rather than proof.
+func (c *ChannelFlow) RoutedAround() bool {
+	return c.Flowed < c.Expected
+}
+
+// NewChannelFlow calculates the flow through a set of channels from their
+// balances before and after a swap.
+func NewChannelFlow(before, after ChannelBalances,
+	expected btcutil.Amount) *ChannelFlow {
+
+	var flowed btcutil.Amount
+	for chanID, balance := range before {
+		// If the channel was closed, we cannot attribute its balance
+		// to the swap so we skip it.
+		current, ok := after[chanID]
+		if !ok {
+			continue
+		}
+
+		if current < balance {
+			flowed += balance - current
+		}
+	}
+
+	if flowed > expected {
+		flowed = expected
+	}
+
+	return &ChannelFlow{
+		Balances: after,
+		Expected: expected,
+		Flowed:   flowed,
+	}
+}
+
+// UpdateLoopOutChannelFlow stores the flow of a loop out's swap payment
+// through its outgoing channel set.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) UpdateLoopOutChannelFlow(hash lntypes.Hash,
+	flow ChannelFlow) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(loopOutBucketKey)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		swapBucket := rootBucket.Bucket(hash[:])
+		if swapBucket == nil {
+			return errors.New("swap not found")
+		}
+
+		flowBytes, err := serializeChannelFlow(flow)
+		if err != nil {
+			return err
+		}
+
+		return swapBucket.Put(channelFlowKey, flowBytes)
+	})
+}
+
+// putChannelBalances writes a set of channel balances to the bucket under the
+// key provided. If the set is empty, nothing is written.
+func putChannelBalances(bucket *bbolt.Bucket, key []byte,
+	balances ChannelBalances) error {
+
+	if len(balances) == 0 {
+		return nil
+	}
+
+	var b bytes.Buffer
+	if err := writeChannelBalances(&b, balances); err != nil {
+		return err
+	}
+
+	return bucket.Put(key, b.Bytes())
+}
+
+// getChannelBalances reads a set of channel balances from the bucket. If no
+// balances are stored, nil is returned.
+func getChannelBalances(bucket *bbolt.Bucket, key []byte) (ChannelBalances,
+	error) {
+
+	value := bucket.Get(key)
+	if value == nil {
+		return nil, nil
+	}
+
+	return readChannelBalances(bytes.NewReader(value))
+}
+
+// getChannelFlow reads the channel flow for a swap from its bucket. If no
+// flow is stored, nil is returned.
+func getChannelFlow(bucket *bbolt.Bucket) (*ChannelFlow, error) {
+	value := bucket.Get(channelFlowKey)
+	if value == nil {
+		return nil, nil
+	}
+
+	return deserializeChannelFlow(value)
+}
+
+// serializeChannelFlow serializes a channel flow.
+func serializeChannelFlow(flow ChannelFlow) ([]byte, error) {
+	var b bytes.Buffer
+
+	err := binary.Write(&b, byteOrder, int64(flow.Expected))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(&b, byteOrder, int64(flow.Flowed)); err != nil {
+		return nil, err
+	}
+
+	if err := writeChannelBalances(&b, flow.Balances); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeChannelFlow deserializes a channel flow.
+func deserializeChannelFlow(value []byte) (*ChannelFlow, error) {
+	r := bytes.NewReader(value)
+
+	var expected, flowed int64
+	if err := binary.Read(r, byteOrder, &expected); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Read(r, byteOrder, &flowed); err != nil {
+		return nil, err
+	}
+
+	balances, err := readChannelBalances(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelFlow{
+		Balances: balances,
+		Expected: btcutil.Amount(expected),
+		Flowed:   btcutil.Amount(flowed),
+	}, nil
+}
+
+// writeChannelBalances writes a set of channel balances as a concatenation of
+// channel ids and balances.
+func writeChannelBalances(w io.Writer, balances ChannelBalances) error {
+	for chanID, balance := range balances {
+		if err := binary.Write(w, byteOrder, chanID); err != nil {
+			return err
+		}
+
+		if err := binary.Write(w, byteOrder, int64(balance)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readChannelBalances reads a set of channel balances until the reader is
+// exhausted.
+func readChannelBalances(r io.Reader) (ChannelBalances, error) {
+	balances := make(ChannelBalances)
+
+	for {
+		var chanID uint64
+		err := binary.Read(r, byteOrder, &chanID)
+		switch {
+		case err == io.EOF:
+			return balances, nil
+
+		case err != nil:
+			return nil, err
+		}
+
+		var balance int64
+		if err := binary.Read(r, byteOrder, &balance); err != nil {
+			return nil, err
+		}
+
+		balances[chanID] = btcutil.Amount(balance)
+	}
+}
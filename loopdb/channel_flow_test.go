@@ -0,0 +1,82 @@
+package loopdb
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewChannelFlow tests calculation of the flow of a swap payment through
+// a set of channels.
+func TestNewChannelFlow(t *testing.T) {
+	tests := []struct {
+		name         string
+		before       ChannelBalances
+		after        ChannelBalances
+		expected     btcutil.Amount
+		flowed       btcutil.Amount
+		routedAround bool
+	}{
+		{
+			name:     "all through set",
+			before:   ChannelBalances{1: 500, 2: 500},
+			after:    ChannelBalances{1: 300, 2: 400},
+			expected: 300,
+			flowed:   300,
+		},
+		{
+			name:         "partially routed around",
+			before:       ChannelBalances{1: 500, 2: 500},
+			after:        ChannelBalances{1: 400, 2: 500},
+			expected:     300,
+			flowed:       100,
+			routedAround: true,
+		},
+		{
+			name:     "capped at expected",
+			before:   ChannelBalances{1: 500},
+			after:    ChannelBalances{1: 0},
+			expected: 300,
+			flowed:   300,
+		},
+		{
+			name:         "balance increase ignored",
+			before:       ChannelBalances{1: 500, 2: 500},
+			after:        ChannelBalances{1: 200, 2: 900},
+			expected:     300,
+			flowed:       300,
+			routedAround: false,
+		},
+		{
+			name:         "closed channel skipped",
+			before:       ChannelBalances{1: 500, 2: 500},
+			after:        ChannelBalances{1: 400},
+			expected:     300,
+			flowed:       100,
+			routedAround: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			flow := NewChannelFlow(
+				testCase.before, testCase.after,
+				testCase.expected,
+			)
+			require.Equal(t, testCase.flowed, flow.Flowed)
+			require.Equal(t, testCase.routedAround,
+				flow.RoutedAround())
+
+			// Assert that our flow survives serialization.
+			flowBytes, err := serializeChannelFlow(*flow)
+			require.NoError(t, err)
+
+			decoded, err := deserializeChannelFlow(flowBytes)
+			require.NoError(t, err)
+			require.Equal(t, flow, decoded)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// checkpointBucketKey is a bucket that holds the execution phase that
+	// each of our in-flight swaps had reached when we last shut down, so
+	// that swaps can resume from their last known phase.
+	//
+	// maps: swap hash -> uint8 phase || int64 time
+	checkpointBucketKey = []byte("checkpoints")
+)
+
+// ExecutionPhase describes how far the execution of a swap has progressed.
+// Phases are ordered, so a swap never moves to a lower phase. Unlike swap
+// states, phases are not persisted on every transition. They are only
+// checkpointed when we shut down, and used as a lower bound for the progress
+// of a swap when it is resumed.
+type ExecutionPhase uint8
+
+const (
+	// PhaseNone indicates that no execution phase has been reached, or
+	// that no phase was checkpointed for a swap.
+	PhaseNone ExecutionPhase = iota
+
+	// PhasePaymentSent indicates that we have dispatched the off-chain
+	// payments of a loop out swap.
+	PhasePaymentSent
+
+	// PhaseAwaitingConf indicates that we have published the on-chain
+	// htlc of a loop in swap, and are waiting for it to confirm.
+	PhaseAwaitingConf
+
+	// PhaseSweepPublished indicates that we have published a transaction
+	// that spends the swap's htlc, either the sweep of a loop out swap or
+	// the refund of a loop in swap.
+	PhaseSweepPublished
+)
+
+// String returns a string representation of an execution phase.
+func (p ExecutionPhase) String() string {
+	switch p {
+	case PhaseNone:
+		return "None"
+
+	case PhasePaymentSent:
+		return "PaymentSent"
+
+	case PhaseAwaitingConf:
+		return "AwaitingConf"
+
+	case PhaseSweepPublished:
+		return "SweepPublished"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// Checkpoint records the execution phase that an in-flight swap had reached
+// when we shut down.
+type Checkpoint struct {
+	// SwapHash is the hash of the swap.
+	SwapHash lntypes.Hash
+
+	// Phase is the execution phase that the swap had reached.
+	Phase ExecutionPhase
+
+	// Time is the time at which the checkpoint was taken.
+	Time time.Time
+}
+
+// StoreCheckpoints replaces our set of checkpoints with the set provided.
+// Checkpoints are taken for all of our in-flight swaps at once, so the
+// checkpoints of swaps that have since completed are removed.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) StoreCheckpoints(checkpoints []*Checkpoint) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket(checkpointBucketKey)
+		if err != nil && err != bbolt.ErrBucketNotFound {
+			return err
+		}
+
+		bucket, err := tx.CreateBucket(checkpointBucketKey)
+		if err != nil {
+			return err
+		}
+
+		for _, checkpoint := range checkpoints {
+			value, err := serializeCheckpoint(checkpoint)
+			if err != nil {
+				return err
+			}
+
+			err = bucket.Put(checkpoint.SwapHash[:], value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchCheckpoints returns the checkpoints that we took for our in-flight
+// swaps when we last shut down, keyed by swap hash.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchCheckpoints(ctx context.Context) (
+	map[lntypes.Hash]*Checkpoint, error) {
+
+	checkpoints := make(map[lntypes.Hash]*Checkpoint)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(checkpointBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			checkpoint, err := deserializeCheckpoint(k, v)
+			if err != nil {
+				return err
+			}
+
+			checkpoints[checkpoint.SwapHash] = checkpoint
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+// serializeCheckpoint serializes a checkpoint. Its swap hash is stored in its
+// key.
+func serializeCheckpoint(checkpoint *Checkpoint) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		uint8(checkpoint.Phase),
+		checkpoint.Time.UnixNano(),
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeCheckpoint deserializes a checkpoint from its key and value.
+func deserializeCheckpoint(key, value []byte) (*Checkpoint, error) {
+	var checkpoint Checkpoint
+
+	if len(key) != len(checkpoint.SwapHash) {
+		return nil, fmt.Errorf("invalid checkpoint key length: %v",
+			len(key))
+	}
+	copy(checkpoint.SwapHash[:], key)
+
+	var (
+		r        = bytes.NewReader(value)
+		phase    uint8
+		unixNano int64
+	)
+
+	fields := []interface{}{
+		&phase,
+		&unixNano,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read checkpoint: %v",
+				err)
+		}
+	}
+
+	checkpoint.Phase = ExecutionPhase(phase)
+	checkpoint.Time = time.Unix(0, unixNano)
+
+	return &checkpoint, nil
+}
@@ -0,0 +1,83 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckpoints tests persistence of the execution phases that we
+// checkpoint for in-flight swaps.
+func TestCheckpoints(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	checkpoints, err := store.FetchCheckpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 0)
+
+	var (
+		paymentSent = &Checkpoint{
+			SwapHash: lntypes.Hash{1},
+			Phase:    PhasePaymentSent,
+			Time:     time.Unix(0, 100),
+		}
+
+		sweepPublished = &Checkpoint{
+			SwapHash: lntypes.Hash{2},
+			Phase:    PhaseSweepPublished,
+			Time:     time.Unix(0, 100),
+		}
+
+		awaitingConf = &Checkpoint{
+			SwapHash: lntypes.Hash{3},
+			Phase:    PhaseAwaitingConf,
+			Time:     time.Unix(0, 200),
+		}
+	)
+
+	err = store.StoreCheckpoints([]*Checkpoint{paymentSent, sweepPublished})
+	require.NoError(t, err)
+
+	checkpoints, err = store.FetchCheckpoints(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[lntypes.Hash]*Checkpoint{
+		paymentSent.SwapHash:    paymentSent,
+		sweepPublished.SwapHash: sweepPublished,
+	}, checkpoints)
+
+	// Storing a new set of checkpoints should replace our existing set,
+	// removing the checkpoints of swaps that are no longer in flight.
+	paymentSent.Phase = PhaseSweepPublished
+	paymentSent.Time = time.Unix(0, 200)
+
+	err = store.StoreCheckpoints([]*Checkpoint{paymentSent, awaitingConf})
+	require.NoError(t, err)
+
+	checkpoints, err = store.FetchCheckpoints(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[lntypes.Hash]*Checkpoint{
+		paymentSent.SwapHash:  paymentSent,
+		awaitingConf.SwapHash: awaitingConf,
+	}, checkpoints)
+
+	// Storing an empty set should remove all of our checkpoints.
+	require.NoError(t, store.StoreCheckpoints(nil))
+
+	checkpoints, err = store.FetchCheckpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 0)
+}
@@ -0,0 +1,180 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/snacl"
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// encryptionKeyKey is the key in the meta bucket that stores the
+	// parameters of the key that swap contracts are encrypted with. These
+	// parameters contain the salt and scrypt parameters used to derive the
+	// key from the database password, and a digest that is used to verify
+	// the password. The key itself is never stored. If this key is not
+	// present, the database is not encrypted.
+	//
+	// path: metaBucket -> encryptionKeyKey
+	//
+	// value: marshaled snacl secret key parameters
+	encryptionKeyKey = []byte("encryption-key")
+
+	// ErrDBEncrypted is returned when we open an encrypted database without
+	// a password.
+	ErrDBEncrypted = errors.New("database is encrypted, a password is " +
+		"required to open it")
+
+	// ErrInvalidDBPassword is returned when the password provided for an
+	// encrypted database is incorrect.
+	ErrInvalidDBPassword = errors.New("invalid database password")
+)
+
+// unlockEncryption derives the key that our swap contracts are encrypted with
+// from the password provided. If the database is not encrypted yet and a
+// password is provided, a new key is created and all existing contracts are
+// encrypted with it. A nil key is returned if the database is not encrypted
+// and no password is provided.
+//
+// NOTE: this is called after our migrations have run, so any future migration
+// that reads swap contracts must handle encrypted databases.
+func unlockEncryption(db *bbolt.DB, password []byte) (*snacl.SecretKey,
+	error) {
+
+	var key *snacl.SecretKey
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		metaBucket := tx.Bucket(metaBucketKey)
+		if metaBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		keyParams := metaBucket.Get(encryptionKeyKey)
+
+		switch {
+		// If the database is not encrypted and we have no password,
+		// there is nothing to do.
+		case keyParams == nil && len(password) == 0:
+			return nil
+
+		// If the database is encrypted, we require the password that it
+		// was encrypted with.
+		case keyParams != nil && len(password) == 0:
+			return ErrDBEncrypted
+
+		case keyParams != nil:
+			key = &snacl.SecretKey{}
+			if err := key.Unmarshal(keyParams); err != nil {
+				return err
+			}
+
+			err := key.DeriveKey(&password)
+			if err == snacl.ErrInvalidPassword {
+				return ErrInvalidDBPassword
+			}
+
+			return err
+		}
+
+		// Otherwise, this is the first time that we are opening the
+		// database with a password, so we create a new key and
+		// encrypt our existing contracts in the same transaction that
+		// we store the key's parameters. This way, we cannot end up
+		// with a partially encrypted database.
+		log.Infof("Encrypting swap contracts")
+
+		var err error
+		key, err = snacl.NewSecretKey(
+			&password, snacl.DefaultN, snacl.DefaultR,
+			snacl.DefaultP,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, bucketKey := range swapRootBuckets {
+			err := encryptContracts(tx, bucketKey, key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return metaBucket.Put(encryptionKeyKey, key.Marshal())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// encryptContracts encrypts the contracts of all the swaps in the root bucket
+// provided.
+func encryptContracts(tx *bbolt.Tx, bucketKey []byte,
+	key *snacl.SecretKey) error {
+
+	rootBucket := tx.Bucket(bucketKey)
+	if rootBucket == nil {
+		return errors.New("bucket does not exist")
+	}
+
+	// We collect our swap hashes before we update our contracts because
+	// bolt does not allow modification of a bucket while it is being
+	// iterated.
+	var swapHashes [][]byte
+	err := rootBucket.ForEach(func(swapHash, v []byte) error {
+		// Only go into things that we know are sub-bucket keys.
+		if v == nil {
+			swapHashes = append(swapHashes, swapHash)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, swapHash := range swapHashes {
+		swapBucket := rootBucket.Bucket(swapHash)
+		if swapBucket == nil {
+			return fmt.Errorf("swap bucket %x not found", swapHash)
+		}
+
+		contractBytes := swapBucket.Get(contractKey)
+		if contractBytes == nil {
+			return errors.New("contract not found")
+		}
+
+		encrypted, err := key.Encrypt(contractBytes)
+		if err != nil {
+			return err
+		}
+
+		if err := swapBucket.Put(contractKey, encrypted); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encryptContract encrypts a serialized contract if the store is encrypted,
+// otherwise it is returned unchanged.
+func (s *boltSwapStore) encryptContract(contract []byte) ([]byte, error) {
+	if s.encryptionKey == nil {
+		return contract, nil
+	}
+
+	return s.encryptionKey.Encrypt(contract)
+}
+
+// decryptContract decrypts a serialized contract if the store is encrypted,
+// otherwise it is returned unchanged.
+func (s *boltSwapStore) decryptContract(contract []byte) ([]byte, error) {
+	if s.encryptionKey == nil {
+		return contract, nil
+	}
+
+	return s.encryptionKey.Decrypt(contract)
+}
@@ -0,0 +1,128 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncryptedStore tests encryption of an existing store, and that an
+// encrypted store can only be opened with its password.
+func TestEncryptedStore(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	password := []byte("password")
+
+	loopOut := &LoopOutContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        testPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  time.Unix(0, testTime.UnixNano()),
+		},
+		DestAddr:                test.GetDestAddr(t, 0),
+		SwapInvoice:             "swapinvoice",
+		PrepayInvoice:           "prepayinvoice",
+		SwapPublicationDeadline: time.Unix(0, testTime.UnixNano()),
+	}
+
+	// Create an unencrypted store with a single swap in it.
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	hash := testPreimage.Hash()
+	require.NoError(t, store.CreateLoopOut(hash, loopOut))
+	require.True(t, contractContains(t, store, hash, testPreimage[:]))
+	require.NoError(t, store.Close())
+
+	// Now, open the store with a password. Our existing contract should be
+	// encrypted, but still readable.
+	store, err = NewEncryptedBoltSwapStore(
+		tempDirName, &chaincfg.MainNetParams, password,
+	)
+	require.NoError(t, err)
+	require.False(t, contractContains(t, store, hash, testPreimage[:]))
+
+	swaps, err := store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, swaps, 1)
+	require.Equal(t, testPreimage, swaps[0].Contract.Preimage)
+
+	// Swaps created in the encrypted store should also be encrypted.
+	loopIn := &LoopInContract{
+		SwapContract: loopOut.SwapContract,
+	}
+	loopIn.Preimage[0] = 9
+	loopInHash := loopIn.Preimage.Hash()
+
+	require.NoError(t, store.CreateLoopIn(loopInHash, loopIn))
+	require.False(t, contractContains(
+		t, store, loopInHash, loopIn.Preimage[:],
+	))
+
+	inSwaps, err := store.FetchLoopInSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, inSwaps, 1)
+	require.Equal(t, loopIn.Preimage, inSwaps[0].Contract.Preimage)
+	require.NoError(t, store.Close())
+
+	// We should not be able to open the store without a password, or with
+	// the wrong password.
+	_, err = NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.Equal(t, ErrDBEncrypted, err)
+
+	_, err = NewEncryptedBoltSwapStore(
+		tempDirName, &chaincfg.MainNetParams, []byte("wrong"),
+	)
+	require.Equal(t, ErrInvalidDBPassword, err)
+
+	// Finally, our original password should still open the store.
+	store, err = NewEncryptedBoltSwapStore(
+		tempDirName, &chaincfg.MainNetParams, password,
+	)
+	require.NoError(t, err)
+
+	swaps, err = store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, swaps, 1)
+	require.Equal(t, testPreimage, swaps[0].Contract.Preimage)
+	require.NoError(t, store.Close())
+}
+
+// contractContains returns a boolean indicating whether the raw contract
+// stored for a swap contains the bytes provided.
+func contractContains(t *testing.T, store *boltSwapStore,
+	hash [32]byte, value []byte) bool {
+
+	var contains bool
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		for _, bucketKey := range [][]byte{
+			loopOutBucketKey, loopInBucketKey,
+		} {
+			swapBucket := tx.Bucket(bucketKey).Bucket(hash[:])
+			if swapBucket == nil {
+				continue
+			}
+
+			contract := swapBucket.Get(contractKey)
+			contains = bytes.Contains(contract, value)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return contains
+}
@@ -0,0 +1,857 @@
+package loopdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// ExportVersion is the version of the format that swaps are exported in. It
+// must be bumped whenever the format changes in a way that older versions can
+// not import.
+const ExportVersion = 1
+
+var (
+	// ErrExportChecksum is returned when the checksum of an export does not
+	// match its contents.
+	ErrExportChecksum = errors.New("export checksum mismatch")
+
+	// ErrExportVersion is returned when we try to import an export with a
+	// version that we do not know.
+	ErrExportVersion = errors.New("unknown export version")
+
+	// ErrExportNetwork is returned when we try to import an export that
+	// was created for a different network.
+	ErrExportNetwork = errors.New("export network mismatch")
+)
+
+// swapExport is a portable representation of all the swaps in a store. It does
+// not depend on the layout of any particular store, so that it can be used to
+// move swaps between machines and database backends.
+type swapExport struct {
+	Version   uint32             `json:"version"`
+	Network   string             `json:"network"`
+	CreatedAt time.Time          `json:"created_at"`
+	LoopOuts  []*exportedLoopOut `json:"loop_outs"`
+	LoopIns   []*exportedLoopIn  `json:"loop_ins"`
+
+	// Checksum is the hex encoded sha256 hash of the export, serialized
+	// with an empty checksum.
+	Checksum string `json:"checksum"`
+}
+
+// exportedContract is the portable representation of a SwapContract.
+type exportedContract struct {
+	Hash             string    `json:"hash"`
+	Preimage         string    `json:"preimage"`
+	AmountRequested  int64     `json:"amount_requested"`
+	SenderKey        string    `json:"sender_key"`
+	ReceiverKey      string    `json:"receiver_key"`
+	CltvExpiry       int32     `json:"cltv_expiry"`
+	MaxSwapFee       int64     `json:"max_swap_fee"`
+	MaxMinerFee      int64     `json:"max_miner_fee"`
+	InitiationHeight int32     `json:"initiation_height"`
+	InitiationTime   time.Time `json:"initiation_time"`
+	Label            string    `json:"label"`
+	IdempotencyKey   string    `json:"idempotency_key"`
+	ProtocolVersion  uint32    `json:"protocol_version"`
+	KeyDerivation    uint8     `json:"key_derivation"`
+	KeyFamily        uint32    `json:"key_family"`
+	KeyIndex         uint32    `json:"key_index"`
+
+	// HtlcVersion is omitted by exports that were created before it was
+	// added, in which case the version is derived from the protocol
+	// version.
+	HtlcVersion *uint8 `json:"htlc_version,omitempty"`
+}
+
+// exportedEvent is the portable representation of a LoopEvent.
+type exportedEvent struct {
+	Time          time.Time `json:"time"`
+	State         uint8     `json:"state"`
+	CostServer    int64     `json:"cost_server"`
+	CostOnchain   int64     `json:"cost_onchain"`
+	CostOffchain  int64     `json:"cost_offchain"`
+	HtlcTxHash    string    `json:"htlc_tx_hash,omitempty"`
+	ServerFailure uint8     `json:"server_failure"`
+
+	// FailureReason is omitted when it is not set, so that exports that
+	// were created before it was added keep their checksum.
+	FailureReason uint8 `json:"failure_reason,omitempty"`
+}
+
+// exportedLoopOut is the portable representation of a LoopOut.
+type exportedLoopOut struct {
+	exportedContract
+
+	DestAddr                      string           `json:"dest_addr"`
+	SwapInvoice                   string           `json:"swap_invoice"`
+	MaxSwapRoutingFee             int64            `json:"max_swap_routing_fee"`
+	SweepConfTarget               int32            `json:"sweep_conf_target"`
+	HtlcConfirmations             uint32           `json:"htlc_confirmations"`
+	OutgoingChanSet               []uint64         `json:"outgoing_chan_set"`
+	OutgoingChanBalances          map[uint64]int64 `json:"outgoing_chan_balances"`
+	PrepayInvoice                 string           `json:"prepay_invoice"`
+	MaxPrepayRoutingFee           int64            `json:"max_prepay_routing_fee"`
+	SwapPublicationDeadline       time.Time        `json:"swap_publication_deadline"`
+	PaymentTimeoutSec             int64            `json:"payment_timeout_sec"`
+	RequestedServerHtlcConfTarget int32            `json:"requested_server_htlc_conf_target"`
+	ServerHtlcConfTarget          int32            `json:"server_htlc_conf_target"`
+	MaxParts                      uint32           `json:"max_parts"`
+	Priority                      uint8            `json:"priority"`
+
+	Events      []*exportedEvent     `json:"events"`
+	SweepFee    *exportedSweepFee    `json:"sweep_fee,omitempty"`
+	ChannelFlow *exportedChannelFlow `json:"channel_flow,omitempty"`
+	Milestones  map[uint8]time.Time  `json:"milestones,omitempty"`
+}
+
+// exportedSweepFee is the portable representation of a SweepFee.
+type exportedSweepFee struct {
+	FeeRateSatPerKw int64 `json:"fee_rate_sat_per_kw"`
+	PublishHeight   int32 `json:"publish_height"`
+}
+
+// exportedChannelFlow is the portable representation of a ChannelFlow.
+type exportedChannelFlow struct {
+	Balances map[uint64]int64 `json:"balances"`
+	Expected int64            `json:"expected"`
+	Flowed   int64            `json:"flowed"`
+}
+
+// exportedLoopIn is the portable representation of a LoopIn.
+type exportedLoopIn struct {
+	exportedContract
+
+	HtlcConfTarget int32  `json:"htlc_conf_target"`
+	LastHop        string `json:"last_hop,omitempty"`
+	ExternalHtlc   bool   `json:"external_htlc"`
+
+	Events     []*exportedEvent    `json:"events"`
+	RefundTx   *exportedRefundTx   `json:"refund_tx,omitempty"`
+	Milestones map[uint8]time.Time `json:"milestones,omitempty"`
+}
+
+// exportedRefundTx is the portable representation of a RefundTx.
+type exportedRefundTx struct {
+	TxHash          string `json:"tx_hash"`
+	FeeRateSatPerKw int64  `json:"fee_rate_sat_per_kw"`
+	Fee             int64  `json:"fee"`
+	PublishHeight   int32  `json:"publish_height"`
+}
+
+// ImportResult describes the outcome of a swap import.
+type ImportResult struct {
+	// Imported is the number of swaps that were added to the store.
+	Imported int
+
+	// Skipped is the number of swaps that were not imported because the
+	// store already contains them.
+	Skipped int
+}
+
+// ExportSwaps serializes all the swaps in the store provided, including their
+// updates, to a versioned JSON format that can be imported with ImportSwaps.
+// The export contains our swap preimages, so it must be stored securely.
+func ExportSwaps(ctx context.Context, store SwapStore,
+	chainParams *chaincfg.Params) ([]byte, error) {
+
+	loopOuts, err := store.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loopIns, err := store.FetchLoopInSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &swapExport{
+		Version:   ExportVersion,
+		Network:   chainParams.Name,
+		CreatedAt: time.Now().UTC(),
+		LoopOuts:  make([]*exportedLoopOut, 0, len(loopOuts)),
+		LoopIns:   make([]*exportedLoopIn, 0, len(loopIns)),
+	}
+
+	for _, loopOut := range loopOuts {
+		export.LoopOuts = append(
+			export.LoopOuts, newExportedLoopOut(loopOut),
+		)
+	}
+
+	for _, loopIn := range loopIns {
+		export.LoopIns = append(
+			export.LoopIns, newExportedLoopIn(loopIn),
+		)
+	}
+
+	export.Checksum, err = export.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(export, "", "  ")
+}
+
+// ImportSwaps adds the swaps in an export created by ExportSwaps to the store
+// provided. The checksum, version and network of the export are verified
+// before any swaps are imported. Swaps that the store already contains are
+// skipped.
+func ImportSwaps(ctx context.Context, store SwapStore,
+	chainParams *chaincfg.Params, data []byte) (*ImportResult, error) {
+
+	export := &swapExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("invalid export: %v", err)
+	}
+
+	checksum, err := export.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum != export.Checksum {
+		return nil, ErrExportChecksum
+	}
+
+	if export.Version != ExportVersion {
+		return nil, fmt.Errorf("%w: %v", ErrExportVersion,
+			export.Version)
+	}
+
+	if export.Network != chainParams.Name {
+		return nil, fmt.Errorf("%w: export for %v, store on %v",
+			ErrExportNetwork, export.Network, chainParams.Name)
+	}
+
+	// Decode all of our swaps before we write any of them, so that we do
+	// not partially import an export that is invalid.
+	loopOuts := make([]*LoopOut, 0, len(export.LoopOuts))
+	for _, exported := range export.LoopOuts {
+		loopOut, err := exported.toLoopOut(chainParams)
+		if err != nil {
+			return nil, fmt.Errorf("loop out %v: %v", exported.Hash,
+				err)
+		}
+
+		loopOuts = append(loopOuts, loopOut)
+	}
+
+	loopIns := make([]*LoopIn, 0, len(export.LoopIns))
+	for _, exported := range export.LoopIns {
+		loopIn, err := exported.toLoopIn()
+		if err != nil {
+			return nil, fmt.Errorf("loop in %v: %v", exported.Hash,
+				err)
+		}
+
+		loopIns = append(loopIns, loopIn)
+	}
+
+	existing, err := existingSwaps(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for _, loopOut := range loopOuts {
+		if _, ok := existing[loopOut.Hash]; ok {
+			result.Skipped++
+			continue
+		}
+
+		if err := importLoopOut(store, loopOut); err != nil {
+			return nil, fmt.Errorf("loop out %v: %v", loopOut.Hash,
+				err)
+		}
+		result.Imported++
+	}
+
+	for _, loopIn := range loopIns {
+		if _, ok := existing[loopIn.Hash]; ok {
+			result.Skipped++
+			continue
+		}
+
+		if err := importLoopIn(store, loopIn); err != nil {
+			return nil, fmt.Errorf("loop in %v: %v", loopIn.Hash,
+				err)
+		}
+		result.Imported++
+	}
+
+	return result, nil
+}
+
+// checksum returns the hex encoded sha256 hash of our export, serialized with
+// an empty checksum.
+func (s *swapExport) checksum() (string, error) {
+	exportCopy := *s
+	exportCopy.Checksum = ""
+
+	data, err := json.Marshal(&exportCopy)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// existingSwaps returns the set of swap hashes that a store contains.
+func existingSwaps(ctx context.Context, store SwapStore) (
+	map[lntypes.Hash]struct{}, error) {
+
+	loopOuts, err := store.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loopIns, err := store.FetchLoopInSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(
+		map[lntypes.Hash]struct{}, len(loopOuts)+len(loopIns),
+	)
+	for _, loopOut := range loopOuts {
+		existing[loopOut.Hash] = struct{}{}
+	}
+
+	for _, loopIn := range loopIns {
+		existing[loopIn.Hash] = struct{}{}
+	}
+
+	return existing, nil
+}
+
+// importLoopOut writes a loop out and all of its updates to a store.
+func importLoopOut(store SwapStore, loopOut *LoopOut) error {
+	err := store.CreateLoopOut(loopOut.Hash, loopOut.Contract)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range loopOut.Events {
+		err := store.UpdateLoopOut(
+			loopOut.Hash, event.Time, event.SwapStateData,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if loopOut.SweepFee != nil {
+		err := store.UpdateLoopOutSweepFee(
+			loopOut.Hash, *loopOut.SweepFee,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if loopOut.ChannelFlow != nil {
+		err := store.UpdateLoopOutChannelFlow(
+			loopOut.Hash, *loopOut.ChannelFlow,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for milestone, reached := range loopOut.Milestones {
+		err := store.UpdateLoopOutMilestone(
+			loopOut.Hash, milestone, reached,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importLoopIn writes a loop in and all of its updates to a store.
+func importLoopIn(store SwapStore, loopIn *LoopIn) error {
+	if err := store.CreateLoopIn(loopIn.Hash, loopIn.Contract); err != nil {
+		return err
+	}
+
+	for _, event := range loopIn.Events {
+		err := store.UpdateLoopIn(
+			loopIn.Hash, event.Time, event.SwapStateData,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if loopIn.RefundTx != nil {
+		err := store.UpdateLoopInRefundTx(loopIn.Hash, *loopIn.RefundTx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for milestone, reached := range loopIn.Milestones {
+		err := store.UpdateLoopInMilestone(
+			loopIn.Hash, milestone, reached,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newExportedContract(hash lntypes.Hash,
+	contract *SwapContract) exportedContract {
+
+	htlcVersion := uint8(contract.HtlcVersion)
+
+	return exportedContract{
+		Hash:             hash.String(),
+		Preimage:         contract.Preimage.String(),
+		AmountRequested:  int64(contract.AmountRequested),
+		SenderKey:        hex.EncodeToString(contract.SenderKey[:]),
+		ReceiverKey:      hex.EncodeToString(contract.ReceiverKey[:]),
+		CltvExpiry:       contract.CltvExpiry,
+		MaxSwapFee:       int64(contract.MaxSwapFee),
+		MaxMinerFee:      int64(contract.MaxMinerFee),
+		InitiationHeight: contract.InitiationHeight,
+		InitiationTime:   contract.InitiationTime.UTC(),
+		Label:            contract.Label,
+		IdempotencyKey:   contract.IdempotencyKey,
+		ProtocolVersion:  uint32(contract.ProtocolVersion),
+		KeyDerivation:    uint8(contract.KeyDerivation),
+		KeyFamily:        uint32(contract.KeyLocator.Family),
+		KeyIndex:         contract.KeyLocator.Index,
+		HtlcVersion:      &htlcVersion,
+	}
+}
+
+// toContract decodes an exported contract, returning the swap hash and
+// contract.
+func (e *exportedContract) toContract() (lntypes.Hash, SwapContract, error) {
+	hash, err := lntypes.MakeHashFromStr(e.Hash)
+	if err != nil {
+		return lntypes.Hash{}, SwapContract{}, err
+	}
+
+	preimage, err := lntypes.MakePreimageFromStr(e.Preimage)
+	if err != nil {
+		return lntypes.Hash{}, SwapContract{}, err
+	}
+
+	if !preimage.Matches(hash) {
+		return lntypes.Hash{}, SwapContract{}, errors.New("preimage " +
+			"does not match hash")
+	}
+
+	senderKey, err := decodeKey(e.SenderKey)
+	if err != nil {
+		return lntypes.Hash{}, SwapContract{}, err
+	}
+
+	receiverKey, err := decodeKey(e.ReceiverKey)
+	if err != nil {
+		return lntypes.Hash{}, SwapContract{}, err
+	}
+
+	protocolVersion := ProtocolVersion(e.ProtocolVersion)
+
+	htlcVersion := HtlcVersionForProtocol(protocolVersion)
+	if e.HtlcVersion != nil {
+		htlcVersion = swap.ScriptVersion(*e.HtlcVersion)
+	}
+
+	if err := validateHtlcVersion(htlcVersion); err != nil {
+		return lntypes.Hash{}, SwapContract{}, err
+	}
+
+	return hash, SwapContract{
+		Preimage:         preimage,
+		AmountRequested:  btcutil.Amount(e.AmountRequested),
+		SenderKey:        senderKey,
+		ReceiverKey:      receiverKey,
+		CltvExpiry:       e.CltvExpiry,
+		MaxSwapFee:       btcutil.Amount(e.MaxSwapFee),
+		MaxMinerFee:      btcutil.Amount(e.MaxMinerFee),
+		InitiationHeight: e.InitiationHeight,
+		InitiationTime:   e.InitiationTime,
+		Label:            e.Label,
+		IdempotencyKey:   e.IdempotencyKey,
+		ProtocolVersion:  protocolVersion,
+		HtlcVersion:      htlcVersion,
+		KeyDerivation:    KeyDerivation(e.KeyDerivation),
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamily(e.KeyFamily),
+			Index:  e.KeyIndex,
+		},
+	}, nil
+}
+
+// decodeKey decodes a hex encoded compressed public key.
+func decodeKey(keyStr string) ([33]byte, error) {
+	var key [33]byte
+
+	keyBytes, err := hex.DecodeString(keyStr)
+	if err != nil {
+		return key, err
+	}
+
+	if len(keyBytes) != len(key) {
+		return key, fmt.Errorf("invalid key length: %v",
+			len(keyBytes))
+	}
+	copy(key[:], keyBytes)
+
+	return key, nil
+}
+
+func newExportedEvents(events []*LoopEvent) []*exportedEvent {
+	exported := make([]*exportedEvent, 0, len(events))
+	for _, event := range events {
+		exportedEvent := &exportedEvent{
+			Time:          event.Time.UTC(),
+			State:         uint8(event.State),
+			CostServer:    int64(event.Cost.Server),
+			CostOnchain:   int64(event.Cost.Onchain),
+			CostOffchain:  int64(event.Cost.Offchain),
+			ServerFailure: uint8(event.ServerFailure),
+			FailureReason: uint8(event.FailureReason),
+		}
+
+		if event.HtlcTxHash != nil {
+			exportedEvent.HtlcTxHash = event.HtlcTxHash.String()
+		}
+
+		exported = append(exported, exportedEvent)
+	}
+
+	return exported
+}
+
+func toLoopEvents(exported []*exportedEvent) ([]*LoopEvent, error) {
+	events := make([]*LoopEvent, 0, len(exported))
+	for _, e := range exported {
+		event := &LoopEvent{
+			SwapStateData: SwapStateData{
+				State: SwapState(e.State),
+				Cost: SwapCost{
+					Server:   btcutil.Amount(e.CostServer),
+					Onchain:  btcutil.Amount(e.CostOnchain),
+					Offchain: btcutil.Amount(e.CostOffchain),
+				},
+				ServerFailure: ServerFailureReason(
+					e.ServerFailure,
+				),
+				FailureReason: FailureReason(e.FailureReason),
+			},
+			Time: e.Time,
+		}
+
+		if e.HtlcTxHash != "" {
+			hash, err := chainhash.NewHashFromStr(e.HtlcTxHash)
+			if err != nil {
+				return nil, err
+			}
+			event.HtlcTxHash = hash
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func newExportedLoopOut(loopOut *LoopOut) *exportedLoopOut {
+	contract := loopOut.Contract
+
+	exported := &exportedLoopOut{
+		exportedContract: newExportedContract(
+			loopOut.Hash, &contract.SwapContract,
+		),
+		SwapInvoice:       contract.SwapInvoice,
+		MaxSwapRoutingFee: int64(contract.MaxSwapRoutingFee),
+		SweepConfTarget:   contract.SweepConfTarget,
+		HtlcConfirmations: contract.HtlcConfirmations,
+		OutgoingChanSet:   contract.OutgoingChanSet,
+		OutgoingChanBalances: exportBalances(
+			contract.OutgoingChanBalances,
+		),
+		PrepayInvoice:       contract.PrepayInvoice,
+		MaxPrepayRoutingFee: int64(contract.MaxPrepayRoutingFee),
+		SwapPublicationDeadline: contract.SwapPublicationDeadline.
+			UTC(),
+		PaymentTimeoutSec: int64(contract.PaymentTimeout.Seconds()),
+		RequestedServerHtlcConfTarget: contract.
+			RequestedServerHtlcConfTarget,
+		ServerHtlcConfTarget: contract.ServerHtlcConfTarget,
+		MaxParts:             contract.MaxParts,
+		Priority:             uint8(contract.Priority),
+		Events:               newExportedEvents(loopOut.Events),
+	}
+
+	if contract.DestAddr != nil {
+		exported.DestAddr = contract.DestAddr.String()
+	}
+
+	if loopOut.SweepFee != nil {
+		exported.SweepFee = &exportedSweepFee{
+			FeeRateSatPerKw: int64(loopOut.SweepFee.FeeRate),
+			PublishHeight:   loopOut.SweepFee.PublishHeight,
+		}
+	}
+
+	if loopOut.ChannelFlow != nil {
+		exported.ChannelFlow = &exportedChannelFlow{
+			Balances: exportBalances(loopOut.ChannelFlow.Balances),
+			Expected: int64(loopOut.ChannelFlow.Expected),
+			Flowed:   int64(loopOut.ChannelFlow.Flowed),
+		}
+	}
+
+	exported.Milestones = exportMilestones(loopOut.Milestones)
+
+	return exported
+}
+
+func (e *exportedLoopOut) toLoopOut(chainParams *chaincfg.Params) (*LoopOut,
+	error) {
+
+	hash, swapContract, err := e.toContract()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := toLoopEvents(e.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &LoopOutContract{
+		SwapContract:            swapContract,
+		SwapInvoice:             e.SwapInvoice,
+		MaxSwapRoutingFee:       btcutil.Amount(e.MaxSwapRoutingFee),
+		SweepConfTarget:         e.SweepConfTarget,
+		HtlcConfirmations:       e.HtlcConfirmations,
+		OutgoingChanSet:         e.OutgoingChanSet,
+		OutgoingChanBalances:    importBalances(e.OutgoingChanBalances),
+		PrepayInvoice:           e.PrepayInvoice,
+		MaxPrepayRoutingFee:     btcutil.Amount(e.MaxPrepayRoutingFee),
+		SwapPublicationDeadline: e.SwapPublicationDeadline,
+		PaymentTimeout: time.Duration(e.PaymentTimeoutSec) *
+			time.Second,
+		RequestedServerHtlcConfTarget: e.RequestedServerHtlcConfTarget,
+		ServerHtlcConfTarget:          e.ServerHtlcConfTarget,
+		MaxParts:                      e.MaxParts,
+		Priority:                      SwapPriority(e.Priority),
+	}
+
+	if err := contract.Priority.Validate(); err != nil {
+		return nil, err
+	}
+
+	if e.DestAddr != "" {
+		contract.DestAddr, err = btcutil.DecodeAddress(
+			e.DestAddr, chainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loopOut := &LoopOut{
+		Loop: Loop{
+			Hash:   hash,
+			Events: events,
+		},
+		Contract: contract,
+	}
+
+	if e.SweepFee != nil {
+		loopOut.SweepFee = &SweepFee{
+			FeeRate: chainfee.SatPerKWeight(
+				e.SweepFee.FeeRateSatPerKw,
+			),
+			PublishHeight: e.SweepFee.PublishHeight,
+		}
+	}
+
+	if e.ChannelFlow != nil {
+		loopOut.ChannelFlow = &ChannelFlow{
+			Balances: importBalances(e.ChannelFlow.Balances),
+			Expected: btcutil.Amount(e.ChannelFlow.Expected),
+			Flowed:   btcutil.Amount(e.ChannelFlow.Flowed),
+		}
+	}
+
+	loopOut.Milestones = importMilestones(e.Milestones)
+
+	return loopOut, nil
+}
+
+func newExportedLoopIn(loopIn *LoopIn) *exportedLoopIn {
+	contract := loopIn.Contract
+
+	exported := &exportedLoopIn{
+		exportedContract: newExportedContract(
+			loopIn.Hash, &contract.SwapContract,
+		),
+		HtlcConfTarget: contract.HtlcConfTarget,
+		ExternalHtlc:   contract.ExternalHtlc,
+		Events:         newExportedEvents(loopIn.Events),
+	}
+
+	// The loop in contract's label shadows the label of its swap
+	// contract, so we export it explicitly.
+	exported.Label = contract.Label
+
+	if contract.LastHop != nil {
+		exported.LastHop = contract.LastHop.String()
+	}
+
+	if loopIn.RefundTx != nil {
+		exported.RefundTx = &exportedRefundTx{
+			TxHash:          loopIn.RefundTx.TxHash.String(),
+			FeeRateSatPerKw: int64(loopIn.RefundTx.FeeRate),
+			Fee:             int64(loopIn.RefundTx.Fee),
+			PublishHeight:   loopIn.RefundTx.PublishHeight,
+		}
+	}
+
+	exported.Milestones = exportMilestones(loopIn.Milestones)
+
+	return exported
+}
+
+func (e *exportedLoopIn) toLoopIn() (*LoopIn, error) {
+	hash, swapContract, err := e.toContract()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := toLoopEvents(e.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &LoopInContract{
+		SwapContract:   swapContract,
+		HtlcConfTarget: e.HtlcConfTarget,
+		ExternalHtlc:   e.ExternalHtlc,
+		Label:          e.Label,
+	}
+
+	if e.LastHop != "" {
+		lastHop, err := route.NewVertexFromStr(e.LastHop)
+		if err != nil {
+			return nil, err
+		}
+		contract.LastHop = &lastHop
+	}
+
+	loopIn := &LoopIn{
+		Loop: Loop{
+			Hash:   hash,
+			Events: events,
+		},
+		Contract: contract,
+	}
+
+	if e.RefundTx != nil {
+		txHash, err := chainhash.NewHashFromStr(e.RefundTx.TxHash)
+		if err != nil {
+			return nil, err
+		}
+
+		loopIn.RefundTx = &RefundTx{
+			TxHash: *txHash,
+			FeeRate: chainfee.SatPerKWeight(
+				e.RefundTx.FeeRateSatPerKw,
+			),
+			Fee:           btcutil.Amount(e.RefundTx.Fee),
+			PublishHeight: e.RefundTx.PublishHeight,
+		}
+	}
+
+	loopIn.Milestones = importMilestones(e.Milestones)
+
+	return loopIn, nil
+}
+
+// exportMilestones converts a set of swap milestones to a portable map.
+func exportMilestones(milestones Milestones) map[uint8]time.Time {
+	if len(milestones) == 0 {
+		return nil
+	}
+
+	exported := make(map[uint8]time.Time, len(milestones))
+	for milestone, reached := range milestones {
+		exported[uint8(milestone)] = reached.UTC()
+	}
+
+	return exported
+}
+
+// importMilestones converts a portable map of milestones to a set of swap
+// milestones.
+func importMilestones(exported map[uint8]time.Time) Milestones {
+	if len(exported) == 0 {
+		return nil
+	}
+
+	milestones := make(Milestones, len(exported))
+	for milestone, reached := range exported {
+		milestones[Milestone(milestone)] = reached
+	}
+
+	return milestones
+}
+
+// exportBalances converts a set of channel balances to a portable map.
+func exportBalances(balances ChannelBalances) map[uint64]int64 {
+	if balances == nil {
+		return nil
+	}
+
+	exported := make(map[uint64]int64, len(balances))
+	for chanID, balance := range balances {
+		exported[chanID] = int64(balance)
+	}
+
+	return exported
+}
+
+// importBalances converts a portable map of channel balances back to a set of
+// channel balances.
+func importBalances(exported map[uint64]int64) ChannelBalances {
+	if exported == nil {
+		return nil
+	}
+
+	balances := make(ChannelBalances, len(exported))
+	for chanID, balance := range exported {
+		balances[chanID] = btcutil.Amount(balance)
+	}
+
+	return balances
+}
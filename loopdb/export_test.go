@@ -0,0 +1,172 @@
+package loopdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore creates a bolt store in a temporary directory, returning the
+// store and a cleanup function.
+func newTestStore(t *testing.T) (*boltSwapStore, func()) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	return store, func() {
+		require.NoError(t, store.Close())
+		os.RemoveAll(tempDirName)
+	}
+}
+
+// TestExportImportSwaps tests that swaps exported from one store are imported
+// to another store unchanged, and that invalid exports are rejected.
+func TestExportImportSwaps(t *testing.T) {
+	ctx := context.Background()
+
+	source, cleanup := newTestStore(t)
+	defer cleanup()
+
+	outPreimage := lntypes.Preimage{1}
+	outHash := outPreimage.Hash()
+
+	loopOut := &LoopOutContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        outPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			MaxMinerFee:     10,
+			MaxSwapFee:      20,
+			InitiationTime:  testTime,
+			Label:           "test label",
+		},
+		DestAddr:          test.GetDestAddr(t, 0),
+		SwapInvoice:       "swapinvoice",
+		SweepConfTarget:   2,
+		HtlcConfirmations: 2,
+		OutgoingChanSet:   ChannelSet{1, 2},
+		OutgoingChanBalances: ChannelBalances{
+			1: 100,
+			2: 200,
+		},
+		PrepayInvoice:           "prepayinvoice",
+		SwapPublicationDeadline: testTime,
+		MaxParts:                5,
+		Priority:                PriorityUrgent,
+	}
+	require.NoError(t, source.CreateLoopOut(outHash, loopOut))
+
+	htlcTxHash := chainhash.Hash{1, 2, 3}
+	require.NoError(t, source.UpdateLoopOut(
+		outHash, testTime, SwapStateData{
+			State:      StatePreimageRevealed,
+			HtlcTxHash: &htlcTxHash,
+		},
+	))
+	require.NoError(t, source.UpdateLoopOutSweepFee(outHash, SweepFee{
+		FeeRate:       253,
+		PublishHeight: 100,
+	}))
+	require.NoError(t, source.UpdateLoopOut(
+		outHash, testTime, SwapStateData{
+			State: StateSuccess,
+			Cost: SwapCost{
+				Server:   1,
+				Onchain:  2,
+				Offchain: 3,
+			},
+		},
+	))
+
+	inPreimage := lntypes.Preimage{2}
+	inHash := inPreimage.Hash()
+	lastHop := route.Vertex{1, 2, 3}
+
+	require.NoError(t, source.CreateLoopIn(inHash, &LoopInContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        inPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+		},
+		HtlcConfTarget: 2,
+		LastHop:        &lastHop,
+		ExternalHtlc:   true,
+	}))
+	require.NoError(t, source.UpdateLoopIn(
+		inHash, testTime, SwapStateData{
+			State:         StateFailTimeout,
+			ServerFailure: ServerFailureTimeout,
+			FailureReason: FailureReasonHtlcTimeout,
+		},
+	))
+	require.NoError(t, source.UpdateLoopInRefundTx(inHash, RefundTx{
+		TxHash:        chainhash.Hash{4, 5, 6},
+		FeeRate:       253,
+		Fee:           150,
+		PublishHeight: 200,
+	}))
+	require.NoError(t, source.UpdateLoopInMilestone(
+		inHash, MilestoneHtlcConfirmed, testTime,
+	))
+
+	data, err := ExportSwaps(ctx, source, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	// Importing to a store on a different network fails.
+	target, cleanupTarget := newTestStore(t)
+	defer cleanupTarget()
+
+	_, err = ImportSwaps(ctx, target, &chaincfg.TestNet3Params, data)
+	require.True(t, errors.Is(err, ErrExportNetwork))
+
+	// Tampering with the export invalidates its checksum.
+	export := &swapExport{}
+	require.NoError(t, json.Unmarshal(data, export))
+	export.LoopOuts[0].AmountRequested++
+
+	tampered, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	_, err = ImportSwaps(ctx, target, &chaincfg.MainNetParams, tampered)
+	require.Equal(t, ErrExportChecksum, err)
+
+	// Import our export and assert that our swaps are unchanged.
+	result, err := ImportSwaps(ctx, target, &chaincfg.MainNetParams, data)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{Imported: 2}, result)
+
+	sourceOuts, err := source.FetchLoopOutSwaps(ctx)
+	require.NoError(t, err)
+	targetOuts, err := target.FetchLoopOutSwaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sourceOuts, targetOuts)
+
+	sourceIns, err := source.FetchLoopInSwaps(ctx)
+	require.NoError(t, err)
+	targetIns, err := target.FetchLoopInSwaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sourceIns, targetIns)
+
+	// Importing the same export again skips the swaps that we already
+	// have.
+	result, err = ImportSwaps(ctx, target, &chaincfg.MainNetParams, data)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{Skipped: 2}, result)
+}
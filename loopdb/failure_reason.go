@@ -0,0 +1,68 @@
+package loopdb
+
+// FailureReason is the cause of a swap's failure, as determined by the
+// client when it failed the swap. It provides more detail than the failed
+// state that the swap ends in.
+type FailureReason uint8
+
+const (
+	// FailureReasonNone indicates that we did not record a reason for the
+	// failure of the swap, either because it has not failed, or because
+	// it failed before reasons were recorded.
+	FailureReasonNone FailureReason = 0
+
+	// FailureReasonOffchainPayment indicates that a loop out's off chain
+	// swap payment failed.
+	FailureReasonOffchainPayment FailureReason = 1
+
+	// FailureReasonHtlcTimeout indicates that the swap's htlc was not
+	// confirmed or claimed before its timeout.
+	FailureReasonHtlcTimeout FailureReason = 2
+
+	// FailureReasonSweepFeeExceeded indicates that a loop out failed
+	// because the fee required to sweep its htlc exceeded the swap's
+	// maximum miner fee until it was too late to reveal our preimage.
+	FailureReasonSweepFeeExceeded FailureReason = 3
+
+	// FailureReasonServerRejected indicates that the swap failed after
+	// the server reported that it would not complete the swap.
+	FailureReasonServerRejected FailureReason = 4
+
+	// FailureReasonPrepayFailed indicates that a loop out's off chain
+	// prepay payment failed.
+	FailureReasonPrepayFailed FailureReason = 5
+
+	// FailureReasonPrepayReleased indicates that a loop out was abandoned
+	// before the server published its htlc, and that the server released
+	// our prepay rather than settling it.
+	FailureReasonPrepayReleased FailureReason = 6
+)
+
+// String returns a string representation of a failure reason.
+func (r FailureReason) String() string {
+	switch r {
+	case FailureReasonNone:
+		return "None"
+
+	case FailureReasonOffchainPayment:
+		return "OffchainPayment"
+
+	case FailureReasonHtlcTimeout:
+		return "HtlcTimeout"
+
+	case FailureReasonSweepFeeExceeded:
+		return "SweepFeeExceeded"
+
+	case FailureReasonServerRejected:
+		return "ServerRejected"
+
+	case FailureReasonPrepayFailed:
+		return "PrepayFailed"
+
+	case FailureReasonPrepayReleased:
+		return "PrepayReleased"
+
+	default:
+		return "Invalid"
+	}
+}
@@ -0,0 +1,63 @@
+package loopdb
+
+import (
+	"fmt"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// HtlcVersionForProtocol returns the htlc script version that swaps created
+// with the protocol version provided use. Swaps without a recorded protocol
+// version were created before htlc v2 was introduced, so they use htlc v1.
+func HtlcVersionForProtocol(version ProtocolVersion) swap.ScriptVersion {
+	if version != ProtocolVersionUnrecorded &&
+		version >= ProtocolVersionHtlcV2 {
+
+		return swap.HtlcV2
+	}
+
+	return swap.HtlcV1
+}
+
+// putHtlcVersion writes the htlc script version of a swap to the swap bucket
+// provided.
+func putHtlcVersion(bucket *bbolt.Bucket, version swap.ScriptVersion) error {
+	return bucket.Put(htlcVersionKey, []byte{byte(version)})
+}
+
+// getHtlcVersion reads the htlc script version of a swap from the swap bucket
+// provided. If no version is present, we fall back to the version implied by
+// the swap's protocol version, which is how the version was determined before
+// it was stored.
+func getHtlcVersion(bucket *bbolt.Bucket,
+	protocolVersion ProtocolVersion) (swap.ScriptVersion, error) {
+
+	value := bucket.Get(htlcVersionKey)
+	if value == nil {
+		return HtlcVersionForProtocol(protocolVersion), nil
+	}
+
+	if len(value) != 1 {
+		return 0, fmt.Errorf("invalid htlc version length: %v",
+			len(value))
+	}
+
+	version := swap.ScriptVersion(value[0])
+	if err := validateHtlcVersion(version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// validateHtlcVersion checks that an htlc script version is known to us.
+func validateHtlcVersion(version swap.ScriptVersion) error {
+	switch version {
+	case swap.HtlcV1, swap.HtlcV2:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown htlc version: %d", uint8(version))
+	}
+}
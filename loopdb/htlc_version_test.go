@@ -0,0 +1,129 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrationHtlcVersion tests that swaps created before the htlc version
+// was stored fall back to the version implied by their protocol version, and
+// that the migration records that version.
+func TestMigrationHtlcVersion(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	contract := func(preimage lntypes.Preimage) SwapContract {
+		return SwapContract{
+			AmountRequested: 100,
+			Preimage:        preimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+			ProtocolVersion: ProtocolVersionHtlcV2,
+			HtlcVersion:     swap.HtlcV2,
+		}
+	}
+
+	// Create a loop out that we will turn into a legacy swap without a
+	// protocol version, and a loop in that uses htlc v2.
+	outPreimage := lntypes.Preimage{1}
+	outHash := outPreimage.Hash()
+	require.NoError(t, store.CreateLoopOut(outHash, &LoopOutContract{
+		SwapContract:    contract(outPreimage),
+		DestAddr:        test.GetDestAddr(t, 0),
+		SwapInvoice:     "swapinvoice",
+		SweepConfTarget: 2,
+	}))
+
+	inPreimage := lntypes.Preimage{2}
+	inHash := inPreimage.Hash()
+	require.NoError(t, store.CreateLoopIn(inHash, &LoopInContract{
+		SwapContract:   contract(inPreimage),
+		HtlcConfTarget: 2,
+	}))
+
+	// Remove the htlc versions, and the protocol version of our loop out,
+	// so that our swaps look like they were created before the htlc
+	// version was stored.
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		outBucket := tx.Bucket(loopOutBucketKey).Bucket(outHash[:])
+		if err := outBucket.Delete(htlcVersionKey); err != nil {
+			return err
+		}
+
+		if err := outBucket.Delete(protocolVersionKey); err != nil {
+			return err
+		}
+
+		inBucket := tx.Bucket(loopInBucketKey).Bucket(inHash[:])
+		return inBucket.Delete(htlcVersionKey)
+	})
+	require.NoError(t, err)
+
+	assertVersions := func() {
+		loopOuts, err := store.FetchLoopOutSwaps(context.Background())
+		require.NoError(t, err)
+		require.Len(t, loopOuts, 1)
+		require.Equal(t, swap.HtlcV1, loopOuts[0].Contract.HtlcVersion)
+
+		loopIns, err := store.FetchLoopInSwaps(context.Background())
+		require.NoError(t, err)
+		require.Len(t, loopIns, 1)
+		require.Equal(t, swap.HtlcV2, loopIns[0].Contract.HtlcVersion)
+	}
+
+	// Before we migrate, the versions are derived from the protocol
+	// versions of our swaps.
+	assertVersions()
+
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		return migrateHtlcVersion(tx, &chaincfg.MainNetParams)
+	})
+	require.NoError(t, err)
+
+	// After the migration, the versions are stored explicitly.
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		outBucket := tx.Bucket(loopOutBucketKey).Bucket(outHash[:])
+		require.Equal(
+			t, []byte{byte(swap.HtlcV1)},
+			outBucket.Get(htlcVersionKey),
+		)
+
+		inBucket := tx.Bucket(loopInBucketKey).Bucket(inHash[:])
+		require.Equal(
+			t, []byte{byte(swap.HtlcV2)},
+			inBucket.Get(htlcVersionKey),
+		)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assertVersions()
+
+	// A swap with an htlc version that we do not know about can't be
+	// read.
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		inBucket := tx.Bucket(loopInBucketKey).Bucket(inHash[:])
+		return inBucket.Put(htlcVersionKey, []byte{99})
+	})
+	require.NoError(t, err)
+
+	_, err = store.FetchLoopInSwaps(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package loopdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// idempotencyIndexBucketKey is a root bucket that indexes the swaps
+	// that were created with an idempotency key, so that retried requests
+	// can find their swap without scanning all of our swaps.
+	//
+	// maps: idempotencyKey -> swapHash || swapRootBucketKey
+	idempotencyIndexBucketKey = []byte("idempotency-index")
+
+	// ErrIdempotencyKeyExists is returned when a swap is created with an
+	// idempotency key that was already used for another swap.
+	ErrIdempotencyKeyExists = errors.New("idempotency key already used " +
+		"for another swap")
+
+	// ErrIdempotencyKeyNotFound is returned when no swap was created with
+	// an idempotency key.
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+)
+
+// indexIdempotencyKey adds a swap that was created with an idempotency key to
+// our index. The root bucket provided is the bucket that the swap is stored
+// in, which identifies its type. It fails with ErrIdempotencyKeyExists if the
+// key is already indexed.
+func indexIdempotencyKey(tx *bbolt.Tx, rootBucketKey []byte,
+	hash lntypes.Hash, key string) error {
+
+	if len(key) == 0 {
+		return nil
+	}
+
+	bucket, err := tx.CreateBucketIfNotExists(idempotencyIndexBucketKey)
+	if err != nil {
+		return err
+	}
+
+	if bucket.Get([]byte(key)) != nil {
+		return ErrIdempotencyKeyExists
+	}
+
+	value := make([]byte, 0, lntypes.HashSize+len(rootBucketKey))
+	value = append(value, hash[:]...)
+	value = append(value, rootBucketKey...)
+
+	return bucket.Put([]byte(key), value)
+}
+
+// lookupIdempotencyKey returns the hash of the swap that was created with an
+// idempotency key, along with the key of the root bucket that the swap is
+// stored in. Nil values are returned if no swap was created with the key.
+func lookupIdempotencyKey(tx *bbolt.Tx, key string) ([]byte, []byte) {
+	bucket := tx.Bucket(idempotencyIndexBucketKey)
+	if bucket == nil {
+		return nil, nil
+	}
+
+	value := bucket.Get([]byte(key))
+	if len(value) < lntypes.HashSize {
+		return nil, nil
+	}
+
+	return value[:lntypes.HashSize], value[lntypes.HashSize:]
+}
+
+// LookupIdempotencyKey returns the hash and type of the swap that was created
+// with an idempotency key, regardless of the type of swap that the key is
+// looked up for. ErrIdempotencyKeyNotFound is returned if no swap was created
+// with the key.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) LookupIdempotencyKey(key string) (lntypes.Hash,
+	swap.Type, error) {
+
+	var (
+		hash     lntypes.Hash
+		swapType swap.Type
+	)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		swapHash, rootBucketKey := lookupIdempotencyKey(tx, key)
+		if swapHash == nil {
+			return ErrIdempotencyKeyNotFound
+		}
+
+		switch {
+		case bytes.Equal(rootBucketKey, loopOutBucketKey):
+			swapType = swap.TypeOut
+
+		case bytes.Equal(rootBucketKey, loopInBucketKey):
+			swapType = swap.TypeIn
+
+		default:
+			return fmt.Errorf("unknown swap bucket %x for "+
+				"idempotency key %v", rootBucketKey, key)
+		}
+
+		copy(hash[:], swapHash)
+
+		return nil
+	})
+	if err != nil {
+		return lntypes.Hash{}, 0, err
+	}
+
+	return hash, swapType, nil
+}
+
+// removeIdempotencyKey removes a swap's idempotency key from our index, if it
+// has one.
+func removeIdempotencyKey(tx *bbolt.Tx, swapBucket *bbolt.Bucket) error {
+	key := getIdempotencyKey(swapBucket)
+	if key == "" {
+		return nil
+	}
+
+	bucket := tx.Bucket(idempotencyIndexBucketKey)
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.Delete([]byte(key))
+}
@@ -0,0 +1,174 @@
+package loopdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIdempotencyIndex tests that swaps created with an idempotency key are
+// indexed, that keys cannot be reused and that pruned swaps are removed from
+// our index.
+func TestIdempotencyIndex(t *testing.T) {
+	ctx := context.Background()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	contract := func(preimage lntypes.Preimage, key string) SwapContract {
+		return SwapContract{
+			AmountRequested: 100,
+			Preimage:        preimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+			IdempotencyKey:  key,
+		}
+	}
+
+	outPreimage := lntypes.Preimage{1}
+	outHash := outPreimage.Hash()
+	require.NoError(t, store.CreateLoopOut(outHash, &LoopOutContract{
+		SwapContract:            contract(outPreimage, "out-key"),
+		DestAddr:                test.GetDestAddr(t, 0),
+		SwapInvoice:             "swapinvoice",
+		PrepayInvoice:           "prepayinvoice",
+		SwapPublicationDeadline: testTime,
+	}))
+
+	// A swap without an idempotency key is not indexed.
+	unkeyedPreimage := lntypes.Preimage{2}
+	require.NoError(t, store.CreateLoopIn(
+		unkeyedPreimage.Hash(), &LoopInContract{
+			SwapContract: contract(unkeyedPreimage, ""),
+		},
+	))
+
+	// Creating a swap with a key that was already used fails, even if it
+	// is a different type of swap.
+	inPreimage := lntypes.Preimage{3}
+	err := store.CreateLoopIn(inPreimage.Hash(), &LoopInContract{
+		SwapContract: contract(inPreimage, "out-key"),
+	})
+	require.Equal(t, ErrIdempotencyKeyExists, err)
+
+	assertKeyed := func(key string, loopOut, loopIn []lntypes.Hash) {
+		t.Helper()
+
+		filter := &SwapFilter{
+			IdempotencyKey: key,
+		}
+
+		loopOutSwaps, err := store.FilterLoopOutSwaps(ctx, filter)
+		require.NoError(t, err)
+
+		var loopOutHashes []lntypes.Hash
+		for _, swap := range loopOutSwaps {
+			loopOutHashes = append(loopOutHashes, swap.Hash)
+		}
+		require.Equal(t, loopOut, loopOutHashes)
+
+		loopInSwaps, err := store.FilterLoopInSwaps(ctx, filter)
+		require.NoError(t, err)
+
+		var loopInHashes []lntypes.Hash
+		for _, swap := range loopInSwaps {
+			loopInHashes = append(loopInHashes, swap.Hash)
+		}
+		require.Equal(t, loopIn, loopInHashes)
+	}
+
+	assertKeyed("out-key", []lntypes.Hash{outHash}, nil)
+	assertKeyed("unknown-key", nil, nil)
+
+	// Looking a key up directly returns its swap regardless of type.
+	hash, swapType, err := store.LookupIdempotencyKey("out-key")
+	require.NoError(t, err)
+	require.Equal(t, outHash, hash)
+	require.Equal(t, swap.TypeOut, swapType)
+
+	_, _, err = store.LookupIdempotencyKey("unknown-key")
+	require.Equal(t, ErrIdempotencyKeyNotFound, err)
+
+	// Once our swap has completed and been pruned, its key is removed
+	// from our index and can be used again.
+	require.NoError(t, store.UpdateLoopOut(
+		outHash, testTime, SwapStateData{
+			State: StateSuccess,
+		},
+	))
+
+	pruned, err := store.PruneSwaps(ctx, testTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []lntypes.Hash{outHash}, pruned)
+
+	assertKeyed("out-key", nil, nil)
+
+	_, _, err = store.LookupIdempotencyKey("out-key")
+	require.Equal(t, ErrIdempotencyKeyNotFound, err)
+
+	inHash := inPreimage.Hash()
+	require.NoError(t, store.CreateLoopIn(inHash, &LoopInContract{
+		SwapContract: contract(inPreimage, "out-key"),
+	}))
+	assertKeyed("out-key", nil, []lntypes.Hash{inHash})
+
+	hash, swapType, err = store.LookupIdempotencyKey("out-key")
+	require.NoError(t, err)
+	require.Equal(t, inHash, hash)
+	require.Equal(t, swap.TypeIn, swapType)
+}
+
+// TestMigrationIdempotencyIndex tests that our migration adds the idempotency
+// keys of existing swaps to our index.
+func TestMigrationIdempotencyIndex(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	preimage := lntypes.Preimage{1}
+	hash := preimage.Hash()
+	require.NoError(t, store.CreateLoopIn(hash, &LoopInContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        preimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+			IdempotencyKey:  "key",
+		},
+	}))
+
+	// Remove our index so that our swap looks like it was created before
+	// idempotency keys were indexed.
+	err := store.db.Update(func(tx *bbolt.Tx) error {
+		return tx.DeleteBucket(idempotencyIndexBucketKey)
+	})
+	require.NoError(t, err)
+
+	filter := &SwapFilter{
+		IdempotencyKey: "key",
+	}
+
+	swaps, err := store.FilterLoopInSwaps(context.Background(), filter)
+	require.NoError(t, err)
+	require.Empty(t, swaps)
+
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		return migrateIdempotencyIndex(tx, &chaincfg.MainNetParams)
+	})
+	require.NoError(t, err)
+
+	swaps, err = store.FilterLoopInSwaps(context.Background(), filter)
+	require.NoError(t, err)
+	require.Len(t, swaps, 1)
+	require.Equal(t, hash, swaps[0].Hash)
+}
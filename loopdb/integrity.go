@@ -0,0 +1,169 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// writeSequenceKey is the key in the meta bucket that stores the number
+	// of writes that have been made to the store. It is used to detect
+	// that a database is older than its backup.
+	//
+	// path: metaBucket -> writeSequenceKey
+	//
+	// value: uint64 write count
+	writeSequenceKey = []byte("write-sequence")
+
+	// backupFileName is the name of the copy of our database that we make
+	// every time the store is opened.
+	backupFileName = "loop.db.bak"
+
+	// ErrStaleDB is returned when our backup is newer than the database we
+	// are opening, which indicates that an older database was restored.
+	// Running with a stale database is dangerous, because we may reveal
+	// preimages again or reuse addresses for swaps that we have no record
+	// of.
+	ErrStaleDB = errors.New("database is older than its backup")
+)
+
+// dbState describes how up to date a database is.
+type dbState struct {
+	// version is the database's schema version.
+	version uint32
+
+	// sequence is the number of writes that have been made to the
+	// database.
+	sequence uint64
+}
+
+// newerThan returns a boolean indicating whether a database is more up to date
+// than the other database provided.
+func (d *dbState) newerThan(other *dbState) bool {
+	return d.version > other.version || d.sequence > other.sequence
+}
+
+// readDBState reads the version and write sequence of a database.
+func readDBState(db *bbolt.DB) (*dbState, error) {
+	state := &dbState{}
+
+	err := db.View(func(tx *bbolt.Tx) error {
+		metaBucket := tx.Bucket(metaBucketKey)
+		if metaBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		// If no version or sequence is found, we assume they are zero.
+		if data := metaBucket.Get(dbVersionKey); data != nil {
+			state.version = byteOrder.Uint32(data)
+		}
+
+		if data := metaBucket.Get(writeSequenceKey); data != nil {
+			state.sequence = byteOrder.Uint64(data)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// bumpWriteSequence increments the write sequence of the database.
+func bumpWriteSequence(tx *bbolt.Tx) error {
+	metaBucket := tx.Bucket(metaBucketKey)
+	if metaBucket == nil {
+		return errors.New("bucket does not exist")
+	}
+
+	var sequence uint64
+	if data := metaBucket.Get(writeSequenceKey); data != nil {
+		sequence = byteOrder.Uint64(data)
+	}
+
+	scratch := make([]byte, 8)
+	byteOrder.PutUint64(scratch, sequence+1)
+
+	return metaBucket.Put(writeSequenceKey, scratch)
+}
+
+// update executes the function provided in a read-write transaction and bumps
+// our write sequence in the same transaction.
+func (s *boltSwapStore) update(f func(tx *bbolt.Tx) error) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := f(tx); err != nil {
+			return err
+		}
+
+		return bumpWriteSequence(tx)
+	})
+}
+
+// checkBackup compares our database with the backup made the last time that it
+// was opened, and fails with ErrStaleDB if the backup is newer, unless force is
+// set. Once we are satisfied that the database is current, we replace the
+// backup with a copy of it.
+func checkBackup(db *bbolt.DB, dbPath string, force bool) error {
+	backupPath := filepath.Join(dbPath, backupFileName)
+
+	if fileExists(backupPath) {
+		backupState, err := readBackupState(backupPath)
+		if err != nil {
+			return fmt.Errorf("unable to read backup %v: %v",
+				backupPath, err)
+		}
+
+		liveState, err := readDBState(db)
+		if err != nil {
+			return err
+		}
+
+		if backupState.newerThan(liveState) {
+			if !force {
+				log.Errorf("Backup (version=%v, sequence=%v) "+
+					"is newer than database (version=%v, "+
+					"sequence=%v)", backupState.version,
+					backupState.sequence, liveState.version,
+					liveState.sequence)
+
+				return ErrStaleDB
+			}
+
+			log.Warnf("Opening stale database (sequence=%v), "+
+				"backup is at sequence=%v", liveState.sequence,
+				backupState.sequence)
+		}
+	}
+
+	// We write our backup to a temporary file and then rename it so that
+	// we never leave a partially written backup in place.
+	tempPath := backupPath + ".tmp"
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(tempPath, 0600)
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, backupPath)
+}
+
+// readBackupState opens the backup at the path provided and reads its state.
+func readBackupState(path string) (*dbState, error) {
+	backup, err := bbolt.Open(path, 0600, &bbolt.Options{
+		Timeout:  DefaultLoopDBTimeout,
+		ReadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer backup.Close()
+
+	return readDBState(backup)
+}
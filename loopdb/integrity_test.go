@@ -0,0 +1,69 @@
+package loopdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStaleDB tests that we refuse to open a database that is older than its
+// backup unless we are forced to.
+func TestStaleDB(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	dbPath := filepath.Join(tempDirName, dbFileName)
+
+	openStore := func(opts ...StoreOption) error {
+		store, err := NewBoltSwapStore(
+			tempDirName, &chaincfg.MainNetParams, opts...,
+		)
+		if err != nil {
+			return err
+		}
+
+		return store.Close()
+	}
+
+	// Create a new database and save a copy of it before we have any
+	// swaps.
+	require.NoError(t, openStore())
+
+	oldDB, err := ioutil.ReadFile(dbPath)
+	require.NoError(t, err)
+
+	// Add a swap to our store, which will move our write sequence past
+	// our old copy.
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	err = store.CreateLoopOut(testPreimage.Hash(), &LoopOutContract{
+		SwapContract: SwapContract{
+			Preimage:       testPreimage,
+			InitiationTime: time.Unix(0, testTime.UnixNano()),
+		},
+		DestAddr:                test.GetDestAddr(t, 0),
+		SwapPublicationDeadline: time.Unix(0, testTime.UnixNano()),
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// Opening the current database should succeed, and back it up.
+	require.NoError(t, openStore())
+
+	// Now we restore our old copy, which should be detected as stale.
+	require.NoError(t, ioutil.WriteFile(dbPath, oldDB, 0600))
+	require.Equal(t, ErrStaleDB, openStore())
+
+	// If we force the store to open, it should succeed, and replace our
+	// backup so that we no longer need to force it.
+	require.NoError(t, openStore(WithForceStaleDB()))
+	require.NoError(t, openStore())
+}
@@ -1,16 +1,25 @@
 package loopdb
 
 import (
+	"context"
 	"time"
 
+	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
 
 // SwapStore is the primary database interface used by the loopd system. It
 // houses information for all pending completed/failed swaps.
 type SwapStore interface {
-	// FetchLoopOutSwaps returns all swaps currently in the store.
-	FetchLoopOutSwaps() ([]*LoopOut, error)
+	// FetchLoopOutSwaps returns all swaps currently in the store. The scan
+	// is aborted if the context provided is cancelled.
+	FetchLoopOutSwaps(ctx context.Context) ([]*LoopOut, error)
+
+	// FilterLoopOutSwaps returns the swaps in the store that match the
+	// filter provided. Swaps that do not match are skipped during the
+	// scan, so that they do not need to be read in full.
+	FilterLoopOutSwaps(ctx context.Context, filter *SwapFilter) (
+		[]*LoopOut, error)
 
 	// CreateLoopOut adds an initiated swap to the store.
 	CreateLoopOut(hash lntypes.Hash, swap *LoopOutContract) error
@@ -21,8 +30,29 @@ type SwapStore interface {
 	UpdateLoopOut(hash lntypes.Hash, time time.Time,
 		state SwapStateData) error
 
-	// FetchLoopInSwaps returns all swaps currently in the store.
-	FetchLoopInSwaps() ([]*LoopIn, error)
+	// UpdateLoopOutSweepFee stores the fee rate that the most recent sweep
+	// for a loop out swap was published with.
+	UpdateLoopOutSweepFee(hash lntypes.Hash, fee SweepFee) error
+
+	// UpdateLoopOutChannelFlow stores the flow of a loop out's swap
+	// payment through its outgoing channel set.
+	UpdateLoopOutChannelFlow(hash lntypes.Hash, flow ChannelFlow) error
+
+	// FetchLoopInSwaps returns all swaps currently in the store. The scan
+	// is aborted if the context provided is cancelled.
+	FetchLoopInSwaps(ctx context.Context) ([]*LoopIn, error)
+
+	// FilterLoopInSwaps returns the swaps in the store that match the
+	// filter provided. Swaps that do not match are skipped during the
+	// scan, so that they do not need to be read in full.
+	FilterLoopInSwaps(ctx context.Context, filter *SwapFilter) (
+		[]*LoopIn, error)
+
+	// LookupIdempotencyKey returns the hash and type of the swap that was
+	// created with an idempotency key, regardless of its type.
+	// ErrIdempotencyKeyNotFound is returned if no swap was created with
+	// the key.
+	LookupIdempotencyKey(key string) (lntypes.Hash, swap.Type, error)
 
 	// CreateLoopIn adds an initiated swap to the store.
 	CreateLoopIn(hash lntypes.Hash, swap *LoopInContract) error
@@ -33,6 +63,112 @@ type SwapStore interface {
 	UpdateLoopIn(hash lntypes.Hash, time time.Time,
 		state SwapStateData) error
 
+	// UpdateLoopInRefundTx stores the most recent transaction that we
+	// published to refund a loop in's htlc.
+	UpdateLoopInRefundTx(hash lntypes.Hash, refund RefundTx) error
+
+	// UpdateLoopOutMilestone records the time at which a loop out swap
+	// reached a milestone, keeping the first time that was recorded.
+	UpdateLoopOutMilestone(hash lntypes.Hash, milestone Milestone,
+		time time.Time) error
+
+	// UpdateLoopInMilestone records the time at which a loop in swap
+	// reached a milestone, keeping the first time that was recorded.
+	UpdateLoopInMilestone(hash lntypes.Hash, milestone Milestone,
+		time time.Time) error
+
+	// PutSweepBatch persists a sweep batch, assigning it a new id if it
+	// does not have one yet.
+	PutSweepBatch(batch *SweepBatch) error
+
+	// FetchSweepBatches returns all the sweep batches in the store.
+	FetchSweepBatches() ([]*SweepBatch, error)
+
+	// FetchXpubIndex returns the next unused derivation index for an
+	// extended public key. The index is marked as used when a loop out
+	// that sweeps to it is created.
+	FetchXpubIndex(xpub string) (uint32, error)
+
+	// CreateStaticAddress persists our static address, failing if one
+	// already exists.
+	CreateStaticAddress(addr *StaticAddress) error
+
+	// FetchStaticAddress returns our static address, failing with
+	// ErrNoStaticAddress if it has not been created.
+	FetchStaticAddress() (*StaticAddress, error)
+
+	// PutDeposit persists a deposit to our static address, overwriting
+	// any existing entry for its outpoint.
+	PutDeposit(deposit *Deposit) error
+
+	// FetchDeposits returns all deposits to our static address.
+	FetchDeposits() ([]*Deposit, error)
+
+	// AddQuote appends a quote that we received from the server to our
+	// quote history.
+	AddQuote(quote *Quote) error
+
+	// FetchQuotes returns all the quotes in our quote history, in the
+	// order that they were added.
+	FetchQuotes(ctx context.Context) ([]*Quote, error)
+
+	// AddLiquiditySnapshots stores a set of channel balance snapshots.
+	AddLiquiditySnapshots(snapshots []*ChannelSnapshot) error
+
+	// FetchLiquiditySnapshots returns all the channel balance snapshots
+	// taken at or after the time provided, in chronological order.
+	FetchLiquiditySnapshots(ctx context.Context, since time.Time) (
+		[]*ChannelSnapshot, error)
+
+	// PruneLiquiditySnapshots deletes all the channel balance snapshots
+	// taken before the time provided.
+	PruneLiquiditySnapshots(ctx context.Context, before time.Time) error
+
+	// AddPeerOutcome records the outcome of a swap for a peer that it
+	// was routed through.
+	AddPeerOutcome(outcome *PeerOutcome) error
+
+	// FetchPeerOutcomes returns all the swap outcomes that we have
+	// recorded for our peers.
+	FetchPeerOutcomes(ctx context.Context) ([]*PeerOutcome, error)
+
+	// AddRuleSwap attributes a swap to the autoloop rule that it was
+	// dispatched for.
+	AddRuleSwap(ruleSwap *RuleSwap) error
+
+	// FetchRuleSwaps returns all the swaps that we have attributed to
+	// autoloop rules.
+	FetchRuleSwaps(ctx context.Context) ([]*RuleSwap, error)
+
+	// StoreCheckpoints replaces our set of in-flight swap checkpoints with
+	// the set provided.
+	StoreCheckpoints(checkpoints []*Checkpoint) error
+
+	// FetchCheckpoints returns the checkpoints that we took for our
+	// in-flight swaps when we last shut down, keyed by swap hash.
+	FetchCheckpoints(ctx context.Context) (map[lntypes.Hash]*Checkpoint,
+		error)
+
+	// FetchAuditLog returns all the entries in our audit log of swap state
+	// transitions, in the order that they were written.
+	FetchAuditLog(ctx context.Context) ([]*AuditEntry, error)
+
+	// PruneSwaps deletes all swaps that reached a final state before the
+	// time provided, and returns the hashes of the swaps deleted.
+	PruneSwaps(ctx context.Context, before time.Time) ([]lntypes.Hash,
+		error)
+
+	// PutSwapTemplate stores a swap template, replacing any existing
+	// template with the same name.
+	PutSwapTemplate(template *SwapTemplate) error
+
+	// FetchSwapTemplates returns all of our swap templates, ordered by
+	// name.
+	FetchSwapTemplates() ([]*SwapTemplate, error)
+
+	// DeleteSwapTemplate deletes the swap template with the name provided.
+	DeleteSwapTemplate(name string) error
+
 	// Close closes the underlying database.
 	Close() error
 }
@@ -0,0 +1,100 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// KeyDerivation describes how our htlc key for a swap was derived.
+type KeyDerivation uint8
+
+const (
+	// KeyDerivationNextIndex indicates that our htlc key was derived at
+	// the next unused index of the swap key family. The index itself was
+	// not recorded, so the key cannot be reconstructed from the swap hash.
+	// All swaps created before hash-based derivation use this scheme.
+	KeyDerivationNextIndex KeyDerivation = 0
+
+	// KeyDerivationSwapHash indicates that our htlc key was derived at an
+	// index that is determined by the swap hash, so that it can be
+	// reconstructed from our seed and the swap hash.
+	KeyDerivationSwapHash KeyDerivation = 1
+)
+
+// String returns a string representation of a key derivation scheme.
+func (k KeyDerivation) String() string {
+	switch k {
+	case KeyDerivationNextIndex:
+		return "next index"
+
+	case KeyDerivationSwapHash:
+		return "swap hash"
+
+	default:
+		return "unknown"
+	}
+}
+
+// putKeyDerivation writes the derivation scheme and key locator of our htlc
+// key to the swap bucket provided.
+func putKeyDerivation(bucket *bbolt.Bucket, derivation KeyDerivation,
+	locator keychain.KeyLocator) error {
+
+	var b bytes.Buffer
+	if err := binary.Write(&b, byteOrder, derivation); err != nil {
+		return err
+	}
+
+	if err := binary.Write(&b, byteOrder, locator.Family); err != nil {
+		return err
+	}
+
+	if err := binary.Write(&b, byteOrder, locator.Index); err != nil {
+		return err
+	}
+
+	return bucket.Put(keyDerivationKey, b.Bytes())
+}
+
+// getKeyDerivation reads the derivation scheme and key locator of our htlc key
+// from the swap bucket provided. If no derivation information is present, we
+// fall back to the legacy next index scheme.
+func getKeyDerivation(bucket *bbolt.Bucket) (KeyDerivation,
+	keychain.KeyLocator, error) {
+
+	var (
+		derivation KeyDerivation
+		locator    keychain.KeyLocator
+	)
+
+	value := bucket.Get(keyDerivationKey)
+	if value == nil {
+		return KeyDerivationNextIndex, locator, nil
+	}
+
+	r := bytes.NewReader(value)
+	if err := binary.Read(r, byteOrder, &derivation); err != nil {
+		return 0, locator, err
+	}
+
+	if err := binary.Read(r, byteOrder, &locator.Family); err != nil {
+		return 0, locator, err
+	}
+
+	if err := binary.Read(r, byteOrder, &locator.Index); err != nil {
+		return 0, locator, err
+	}
+
+	switch derivation {
+	case KeyDerivationNextIndex, KeyDerivationSwapHash:
+		return derivation, locator, nil
+
+	default:
+		return 0, locator, fmt.Errorf("unknown key derivation: %v",
+			derivation)
+	}
+}
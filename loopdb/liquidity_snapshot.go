@@ -0,0 +1,222 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// liquiditySnapshotBucketKey is a bucket that contains snapshots of
+	// the balances of our channels, taken at regular intervals. The
+	// bucket is keyed by the time of the snapshot followed by the channel
+	// id, so that snapshots are iterated in chronological order.
+	//
+	// maps: int64 unix nano || uint64 channel id -> serialized snapshot
+	liquiditySnapshotBucketKey = []byte("liquidity-snapshots")
+)
+
+// ChannelSnapshot is a record of the balances of a channel at a point in
+// time.
+type ChannelSnapshot struct {
+	// Time is the time at which the snapshot was taken.
+	Time time.Time
+
+	// ChannelID is the short channel id of the channel.
+	ChannelID uint64
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// LocalBalance is our balance in the channel.
+	LocalBalance btcutil.Amount
+
+	// RemoteBalance is our peer's balance in the channel.
+	RemoteBalance btcutil.Amount
+}
+
+// AddLiquiditySnapshots stores a set of channel balance snapshots.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) AddLiquiditySnapshots(
+	snapshots []*ChannelSnapshot) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(
+			liquiditySnapshotBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, snapshot := range snapshots {
+			snapshotBytes, err := serializeSnapshot(snapshot)
+			if err != nil {
+				return err
+			}
+
+			err = bucket.Put(snapshotKey(snapshot), snapshotBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchLiquiditySnapshots returns all the channel balance snapshots that were
+// taken at or after the time provided, in chronological order.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchLiquiditySnapshots(ctx context.Context,
+	since time.Time) ([]*ChannelSnapshot, error) {
+
+	var snapshots []*ChannelSnapshot
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(liquiditySnapshotBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		start := snapshotTimeKey(since)
+
+		for k, v := cursor.Seek(start); k != nil; k, v = cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			snapshot, err := deserializeSnapshot(k, v)
+			if err != nil {
+				return err
+			}
+
+			snapshots = append(snapshots, snapshot)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// PruneLiquiditySnapshots deletes all the channel balance snapshots that were
+// taken before the time provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) PruneLiquiditySnapshots(ctx context.Context,
+	before time.Time) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(liquiditySnapshotBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		// We collect our keys before deleting them, because deleting
+		// while iterating with a cursor may skip keys.
+		var (
+			end    = snapshotTimeKey(before)
+			cursor = bucket.Cursor()
+			keys   [][]byte
+		)
+
+		for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if bytes.Compare(k, end) >= 0 {
+				break
+			}
+
+			keys = append(keys, k)
+		}
+
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// snapshotTimeKey returns the key prefix for snapshots taken at the time
+// provided. Snapshots are not expected to be taken before the unix epoch, so
+// we can use the unsigned representation of their time for ordering.
+func snapshotTimeKey(t time.Time) []byte {
+	var key [8]byte
+	byteOrder.PutUint64(key[:], uint64(t.UnixNano()))
+
+	return key[:]
+}
+
+// snapshotKey returns the key that a snapshot is stored under.
+func snapshotKey(snapshot *ChannelSnapshot) []byte {
+	key := make([]byte, 16)
+	copy(key, snapshotTimeKey(snapshot.Time))
+	byteOrder.PutUint64(key[8:], snapshot.ChannelID)
+
+	return key
+}
+
+// serializeSnapshot serializes the balances of a snapshot. The snapshot's time
+// and channel id are stored in its key.
+func serializeSnapshot(snapshot *ChannelSnapshot) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		snapshot.Capacity,
+		snapshot.LocalBalance,
+		snapshot.RemoteBalance,
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeSnapshot deserializes a snapshot from its key and value.
+func deserializeSnapshot(key, value []byte) (*ChannelSnapshot, error) {
+	if len(key) != 16 {
+		return nil, fmt.Errorf("invalid snapshot key length: %v",
+			len(key))
+	}
+
+	snapshot := &ChannelSnapshot{
+		Time:      time.Unix(0, int64(byteOrder.Uint64(key[:8]))),
+		ChannelID: byteOrder.Uint64(key[8:]),
+	}
+
+	r := bytes.NewReader(value)
+	fields := []interface{}{
+		&snapshot.Capacity,
+		&snapshot.LocalBalance,
+		&snapshot.RemoteBalance,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read snapshot: %v",
+				err)
+		}
+	}
+
+	return snapshot, nil
+}
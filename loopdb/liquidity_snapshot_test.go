@@ -0,0 +1,99 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLiquiditySnapshots tests persistence, fetching and pruning of channel
+// balance snapshots.
+func TestLiquiditySnapshots(t *testing.T) {
+	ctx := context.Background()
+
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	snapshots, err := store.FetchLiquiditySnapshots(ctx, time.Unix(0, 0))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 0)
+
+	var (
+		time1 = time.Unix(100, 0)
+		time2 = time.Unix(200, 0)
+		time3 = time.Unix(300, 0)
+
+		snapshot1 = &ChannelSnapshot{
+			Time:          time1,
+			ChannelID:     2,
+			Capacity:      1000,
+			LocalBalance:  600,
+			RemoteBalance: 400,
+		}
+		snapshot2 = &ChannelSnapshot{
+			Time:          time2,
+			ChannelID:     1,
+			Capacity:      2000,
+			LocalBalance:  100,
+			RemoteBalance: 1900,
+		}
+		snapshot3 = &ChannelSnapshot{
+			Time:          time2,
+			ChannelID:     2,
+			Capacity:      1000,
+			LocalBalance:  500,
+			RemoteBalance: 500,
+		}
+		snapshot4 = &ChannelSnapshot{
+			Time:          time3,
+			ChannelID:     1,
+			Capacity:      2000,
+			LocalBalance:  200,
+			RemoteBalance: 1800,
+		}
+	)
+
+	// Add our snapshots out of order to assert that they are returned in
+	// chronological order.
+	err = store.AddLiquiditySnapshots([]*ChannelSnapshot{
+		snapshot4, snapshot3,
+	})
+	require.NoError(t, err)
+
+	err = store.AddLiquiditySnapshots([]*ChannelSnapshot{
+		snapshot2, snapshot1,
+	})
+	require.NoError(t, err)
+
+	snapshots, err = store.FetchLiquiditySnapshots(ctx, time.Unix(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelSnapshot{
+		snapshot1, snapshot2, snapshot3, snapshot4,
+	}, snapshots)
+
+	// Fetching from a time that we have snapshots for should include
+	// them.
+	snapshots, err = store.FetchLiquiditySnapshots(ctx, time2)
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelSnapshot{
+		snapshot2, snapshot3, snapshot4,
+	}, snapshots)
+
+	// Prune all snapshots before our last one and assert that only it
+	// remains.
+	require.NoError(t, store.PruneLiquiditySnapshots(ctx, time3))
+
+	snapshots, err = store.FetchLiquiditySnapshots(ctx, time.Unix(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelSnapshot{snapshot4}, snapshots)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
 
@@ -47,15 +49,36 @@ type SwapContract struct {
 	// Label contains an optional label for the swap.
 	Label string
 
+	// IdempotencyKey is an optional client-generated key that was provided
+	// when the swap was created, used to deduplicate retried requests.
+	IdempotencyKey string
+
 	// ProtocolVersion stores the protocol version when the swap was
 	// created.
 	ProtocolVersion ProtocolVersion
+
+	// HtlcVersion is the script version of the swap's htlc.
+	HtlcVersion swap.ScriptVersion
+
+	// KeyDerivation is the scheme that was used to derive our htlc key.
+	KeyDerivation KeyDerivation
+
+	// KeyLocator is the locator of our htlc key. It is empty for swaps
+	// that use KeyDerivationNextIndex, because the index of their key was
+	// not recorded.
+	KeyLocator keychain.KeyLocator
 }
 
 // Loop contains fields shared between LoopIn and LoopOut
 type Loop struct {
 	Hash   lntypes.Hash
 	Events []*LoopEvent
+
+	// Milestones holds the times at which the swap reached each of the
+	// milestones that have been recorded for it. It is nil for swaps that
+	// have not recorded any milestones, which includes swaps that were
+	// created before milestones were recorded.
+	Milestones Milestones
 }
 
 // LoopEvent contains the dynamic data of a swap.
@@ -37,6 +37,11 @@ type LoopIn struct {
 	Loop
 
 	Contract *LoopInContract
+
+	// RefundTx is the most recent transaction that we published to refund
+	// the swap's htlc after it timed out. It is nil if we have not
+	// published a refund.
+	RefundTx *RefundTx
 }
 
 // LastUpdateTime returns the last update time of this swap.
@@ -144,6 +149,27 @@ func getLabel(bucket *bbolt.Bucket) string {
 	return string(label)
 }
 
+// putIdempotencyKey writes an idempotency key to the bucket provided if it is
+// non-empty.
+func putIdempotencyKey(bucket *bbolt.Bucket, key string) error {
+	if len(key) == 0 {
+		return nil
+	}
+
+	return bucket.Put(idempotencyKeyKey, []byte(key))
+}
+
+// getIdempotencyKey gets an optional idempotency key from a bucket. If it is
+// not present, an empty key is returned.
+func getIdempotencyKey(bucket *bbolt.Bucket) string {
+	key := bucket.Get(idempotencyKeyKey)
+	if key == nil {
+		return ""
+	}
+
+	return string(key)
+}
+
 // deserializeLoopInContract deserializes the loop in contract from a byte slice.
 func deserializeLoopInContract(value []byte) (*LoopInContract, error) {
 	r := bytes.NewReader(value)
@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
 // LoopOutContract contains the data that is serialized to persistent storage
@@ -44,8 +45,16 @@ type LoopOutContract struct {
 	// If empty, any channel may be used.
 	OutgoingChanSet ChannelSet
 
+	// OutgoingChanBalances contains the local balances of the channels in
+	// the outgoing channel set at the time the swap was initiated. It is
+	// used to verify how much of the swap payment flowed through the
+	// channels once the swap completes. It is nil if the swap was not
+	// restricted to a set of channels.
+	OutgoingChanBalances ChannelBalances
+
 	// PrepayInvoice is the invoice that the client should pay to the
-	// server that will be returned if the swap is complete.
+	// server that will be returned if the swap is complete. It is empty
+	// if the server included the prepay amount in the swap invoice.
 	PrepayInvoice string
 
 	// MaxPrepayRoutingFee is the maximum off-chain fee in msat that may be
@@ -57,6 +66,83 @@ type LoopOutContract struct {
 	// allow the server to delay the publication in exchange for possibly
 	// lower fees.
 	SwapPublicationDeadline time.Time
+
+	// PaymentTimeout is the timeout for the off-chain swap and prepay
+	// payments that was requested for this swap. If zero, loopd's
+	// configured payment timeout is used.
+	PaymentTimeout time.Duration
+
+	// RequestedServerHtlcConfTarget is the confirmation target that we
+	// asked the server to use when publishing the on-chain htlc. It is
+	// zero if we did not express a preference.
+	RequestedServerHtlcConfTarget int32
+
+	// ServerHtlcConfTarget is the confirmation target that the server
+	// committed to using when publishing the on-chain htlc. It is zero if
+	// the server did not take our preference into account.
+	ServerHtlcConfTarget int32
+
+	// MaxParts is the maximum number of parts that the off-chain swap and
+	// prepay payments may be split into that was requested for this swap.
+	// If zero, loopd's configured maximum is used.
+	MaxParts uint32
+
+	// Priority is the execution priority of the swap, which determines
+	// how aggressively we pay and sweep it.
+	Priority SwapPriority
+
+	// DestXpubIndex is the derivation index of our destination xpub that
+	// DestAddr was derived from. It is nil if the address was not derived
+	// from our xpub. The index is marked as used in the same transaction
+	// that stores the swap.
+	DestXpubIndex *XpubIndex
+}
+
+// SwapPriority describes how urgently a swap should be completed. Higher
+// priority swaps pay more to sweep quickly, and spend more time trying to
+// complete their off-chain payments.
+type SwapPriority uint8
+
+const (
+	// PriorityNormal is the default priority, which is also used for swaps
+	// that were created before priorities were introduced.
+	PriorityNormal SwapPriority = 0
+
+	// PriorityLow is the priority for swaps that are not time sensitive,
+	// such as swaps dispatched by autoloop.
+	PriorityLow SwapPriority = 1
+
+	// PriorityUrgent is the priority for swaps that should be completed
+	// as soon as possible, even if that comes at a higher cost.
+	PriorityUrgent SwapPriority = 2
+)
+
+// String returns the string representation of a swap priority.
+func (p SwapPriority) String() string {
+	switch p {
+	case PriorityNormal:
+		return "normal"
+
+	case PriorityLow:
+		return "low"
+
+	case PriorityUrgent:
+		return "urgent"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Validate checks that a swap priority is known.
+func (p SwapPriority) Validate() error {
+	switch p {
+	case PriorityNormal, PriorityLow, PriorityUrgent:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown swap priority: %d", p)
+	}
 }
 
 // ChannelSet stores a set of channels.
@@ -87,6 +173,17 @@ func NewChannelSet(set []uint64) (ChannelSet, error) {
 	return ChannelSet(set), nil
 }
 
+// SweepFee records the fee rate that the most recent sweep for a swap was
+// published with, so that fee bumping can be resumed after a restart.
+type SweepFee struct {
+	// FeeRate is the fee rate that the sweep was published with.
+	FeeRate chainfee.SatPerKWeight
+
+	// PublishHeight is the height at which we first published a sweep
+	// with this fee rate.
+	PublishHeight int32
+}
+
 // LoopOut is a combination of the contract and the updates.
 type LoopOut struct {
 	Loop
@@ -95,6 +192,22 @@ type LoopOut struct {
 	// precise details of the swap including the final fee, CLTV value,
 	// etc.
 	Contract *LoopOutContract
+
+	// SweepFee is the fee rate that we last published a sweep for this
+	// swap with. It is nil if we have not yet published a sweep.
+	SweepFee *SweepFee
+
+	// ChannelFlow records how much of the swap payment flowed through the
+	// outgoing channel set. It is nil if the swap was not restricted to a
+	// set of channels, or has not completed successfully.
+	ChannelFlow *ChannelFlow
+}
+
+// BatchedPrepay returns a boolean indicating whether the swap's prepay is
+// paid as part of its swap payment, because the server included the prepay
+// amount in the swap invoice rather than providing a prepay invoice.
+func (c *LoopOutContract) BatchedPrepay() bool {
+	return c.PrepayInvoice == ""
 }
 
 // LastUpdateTime returns the last update time of this swap.
@@ -296,3 +409,38 @@ func serializeLoopOutContract(swap *LoopOutContract) (
 
 	return b.Bytes(), nil
 }
+
+// serializeSweepFee serializes the fee rate and publish height of a sweep.
+func serializeSweepFee(fee SweepFee) ([]byte, error) {
+	var b bytes.Buffer
+
+	if err := binary.Write(&b, byteOrder, uint64(fee.FeeRate)); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(&b, byteOrder, fee.PublishHeight); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeSweepFee deserializes the fee rate and publish height of a sweep.
+func deserializeSweepFee(value []byte) (*SweepFee, error) {
+	r := bytes.NewReader(value)
+
+	var feeRate uint64
+	if err := binary.Read(r, byteOrder, &feeRate); err != nil {
+		return nil, err
+	}
+
+	fee := &SweepFee{
+		FeeRate: chainfee.SatPerKWeight(feeRate),
+	}
+
+	if err := binary.Read(r, byteOrder, &fee.PublishHeight); err != nil {
+		return nil, err
+	}
+
+	return fee, nil
+}
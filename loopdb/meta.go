@@ -37,6 +37,9 @@ var (
 		migrateSwapPublicationDeadline,
 		migrateLastHop,
 		migrateUpdates,
+		migrateKeyDerivation,
+		migrateHtlcVersion,
+		migrateIdempotencyIndex,
 	}
 
 	latestDBVersion = uint32(len(migrations))
@@ -1,6 +1,7 @@
 package loopdb
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -69,7 +70,7 @@ func TestMigrationUpdates(t *testing.T) {
 
 	// Fetch the legacy loop out swap and assert that the updates are still
 	// there.
-	outSwaps, err := store.FetchLoopOutSwaps()
+	outSwaps, err := store.FetchLoopOutSwaps(context.Background())
 	require.NoError(t, err)
 
 	outSwap := outSwaps[0]
@@ -78,7 +79,7 @@ func TestMigrationUpdates(t *testing.T) {
 
 	// Fetch the legacy loop in swap and assert that the updates are still
 	// there.
-	inSwaps, err := store.FetchLoopInSwaps()
+	inSwaps, err := store.FetchLoopInSwaps(context.Background())
 	require.NoError(t, err)
 
 	inSwap := inSwaps[0]
@@ -0,0 +1,50 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// migrateKeyDerivation migrates the database to v05, explicitly recording
+// that the htlc keys of all existing swaps were derived at the next available
+// index, so that they can be distinguished from swaps that derive their keys
+// from the swap hash.
+func migrateKeyDerivation(tx *bbolt.Tx, _ *chaincfg.Params) error {
+	for _, key := range swapRootBuckets {
+		rootBucket := tx.Bucket(key)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		err := rootBucket.ForEach(func(swapHash, v []byte) error {
+			// Only go into things that we know are sub-bucket
+			// keys.
+			if v != nil {
+				return nil
+			}
+
+			swapBucket := rootBucket.Bucket(swapHash)
+			if swapBucket == nil {
+				return fmt.Errorf("swap bucket %x not found",
+					swapHash)
+			}
+
+			// The index at which these keys were derived was
+			// never stored, so we record an empty locator which
+			// makes lnd look the key up by its public key.
+			return putKeyDerivation(
+				swapBucket, KeyDerivationNextIndex,
+				keychain.KeyLocator{},
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+)
+
+// migrateHtlcVersion migrates the database to v06, explicitly recording the
+// htlc script version of all existing swaps. The version was previously
+// derived from the protocol version of the swap, so we record the version
+// that it implies. This ensures that swaps that are pending when a new script
+// version is introduced keep resolving with the script that they were created
+// with.
+func migrateHtlcVersion(tx *bbolt.Tx, _ *chaincfg.Params) error {
+	for _, key := range swapRootBuckets {
+		rootBucket := tx.Bucket(key)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		err := rootBucket.ForEach(func(swapHash, v []byte) error {
+			// Only go into things that we know are sub-bucket
+			// keys.
+			if v != nil {
+				return nil
+			}
+
+			swapBucket := rootBucket.Bucket(swapHash)
+			if swapBucket == nil {
+				return fmt.Errorf("swap bucket %x not found",
+					swapHash)
+			}
+
+			protocolVersion, err := UnmarshalProtocolVersion(
+				swapBucket.Get(protocolVersionKey),
+			)
+			if err != nil {
+				return fmt.Errorf("swap %x: %v", swapHash, err)
+			}
+
+			return putHtlcVersion(
+				swapBucket, HtlcVersionForProtocol(
+					protocolVersion,
+				),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
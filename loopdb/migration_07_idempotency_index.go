@@ -0,0 +1,60 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// migrateIdempotencyIndex migrates the database to v07, adding all existing
+// swaps that were created with an idempotency key to our index of idempotency
+// keys. Swaps that reuse the idempotency key of a swap that we have already
+// indexed are logged and skipped, so that they do not prevent loopd from
+// starting.
+func migrateIdempotencyIndex(tx *bbolt.Tx, _ *chaincfg.Params) error {
+	for _, key := range swapRootBuckets {
+		rootBucket := tx.Bucket(key)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		err := rootBucket.ForEach(func(swapHash, v []byte) error {
+			// Only go into things that we know are sub-bucket
+			// keys.
+			if v != nil {
+				return nil
+			}
+
+			swapBucket := rootBucket.Bucket(swapHash)
+			if swapBucket == nil {
+				return fmt.Errorf("swap bucket %x not found",
+					swapHash)
+			}
+
+			hash, err := lntypes.MakeHash(swapHash)
+			if err != nil {
+				return err
+			}
+
+			idempotencyKey := getIdempotencyKey(swapBucket)
+			err = indexIdempotencyKey(tx, key, hash, idempotencyKey)
+			if err == ErrIdempotencyKeyExists {
+				log.Warnf("Not indexing idempotency key %v "+
+					"of swap %v: key already used",
+					idempotencyKey, hash)
+
+				return nil
+			}
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// milestonesBucketKey is the key of a sub-bucket of a swap's bucket
+	// that stores the times at which the swap reached each of its
+	// milestones.
+	//
+	// path: loopOutBucket/loopInBucket -> swapBucket[hash] ->
+	// milestonesBucketKey -> milestone
+	//
+	// value: int64 unix nano time
+	milestonesBucketKey = []byte("milestones")
+)
+
+// Milestone is a point in the progress of a swap that we record the time of,
+// so that we can report how long each phase of a swap takes.
+type Milestone uint8
+
+const (
+	// MilestoneHtlcPublished is reached when we publish the htlc of a loop
+	// in swap. It is not recorded for loop out swaps, because their htlc
+	// is published by the server, or for loop in swaps with an externally
+	// published htlc.
+	MilestoneHtlcPublished Milestone = iota
+
+	// MilestoneHtlcConfirmed is reached when the swap's htlc confirms.
+	MilestoneHtlcConfirmed
+
+	// MilestonePaymentSettled is reached when the swap's off-chain
+	// payment settles. This is our swap payment for loop out swaps, and
+	// the server's payment of our invoice for loop in swaps.
+	MilestonePaymentSettled
+
+	// MilestoneSweepConfirmed is reached when the transaction that sweeps
+	// the swap's htlc confirms. This is our sweep for loop out swaps, and
+	// the server's sweep for loop in swaps.
+	MilestoneSweepConfirmed
+)
+
+// String returns a string representation of a milestone.
+func (m Milestone) String() string {
+	switch m {
+	case MilestoneHtlcPublished:
+		return "HtlcPublished"
+
+	case MilestoneHtlcConfirmed:
+		return "HtlcConfirmed"
+
+	case MilestonePaymentSettled:
+		return "PaymentSettled"
+
+	case MilestoneSweepConfirmed:
+		return "SweepConfirmed"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// Milestones maps the milestones that a swap has reached to the time at which
+// it first reached them.
+type Milestones map[Milestone]time.Time
+
+// UpdateLoopOutMilestone records the time at which a loop out swap reached a
+// milestone. If the milestone has already been recorded for the swap, the
+// time that was first recorded is kept.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) UpdateLoopOutMilestone(hash lntypes.Hash,
+	milestone Milestone, time time.Time) error {
+
+	return s.putMilestone(loopOutBucketKey, hash, milestone, time)
+}
+
+// UpdateLoopInMilestone records the time at which a loop in swap reached a
+// milestone. If the milestone has already been recorded for the swap, the
+// time that was first recorded is kept.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) UpdateLoopInMilestone(hash lntypes.Hash,
+	milestone Milestone, time time.Time) error {
+
+	return s.putMilestone(loopInBucketKey, hash, milestone, time)
+}
+
+// putMilestone records the time at which a swap reached a milestone, unless
+// the milestone has already been recorded. It takes in a bucket key so that it
+// can be used for both in and out swaps.
+func (s *boltSwapStore) putMilestone(bucketKey []byte, hash lntypes.Hash,
+	milestone Milestone, time time.Time) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(bucketKey)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		swapBucket := rootBucket.Bucket(hash[:])
+		if swapBucket == nil {
+			return errors.New("swap not found")
+		}
+
+		bucket, err := swapBucket.CreateBucketIfNotExists(
+			milestonesBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		key := []byte{byte(milestone)}
+		if bucket.Get(key) != nil {
+			return nil
+		}
+
+		var value [8]byte
+		byteOrder.PutUint64(value[:], uint64(time.UnixNano()))
+
+		return bucket.Put(key, value[:])
+	})
+}
+
+// getMilestones reads the milestones that a swap has reached from its bucket.
+// If no milestones are stored, nil is returned.
+func getMilestones(swapBucket *bbolt.Bucket) (Milestones, error) {
+	bucket := swapBucket.Bucket(milestonesBucketKey)
+	if bucket == nil {
+		return nil, nil
+	}
+
+	milestones := make(Milestones)
+	err := bucket.ForEach(func(k, v []byte) error {
+		if len(k) != 1 {
+			return fmt.Errorf("invalid milestone key length: %v",
+				len(k))
+		}
+
+		if len(v) != 8 {
+			return fmt.Errorf("invalid milestone value length: %v",
+				len(v))
+		}
+
+		unixNano := int64(byteOrder.Uint64(v))
+		milestones[Milestone(k[0])] = time.Unix(0, unixNano)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return milestones, nil
+}
@@ -0,0 +1,98 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMilestones tests storing and retrieving the milestones of swaps.
+func TestMilestones(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	contract := func(preimage lntypes.Preimage) SwapContract {
+		return SwapContract{
+			AmountRequested: 100,
+			Preimage:        preimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+			ProtocolVersion: ProtocolVersionHtlcV2,
+		}
+	}
+
+	outPreimage := lntypes.Preimage{1}
+	outHash := outPreimage.Hash()
+	require.NoError(t, store.CreateLoopOut(outHash, &LoopOutContract{
+		SwapContract:    contract(outPreimage),
+		DestAddr:        test.GetDestAddr(t, 0),
+		SwapInvoice:     "swapinvoice",
+		SweepConfTarget: 2,
+	}))
+
+	inPreimage := lntypes.Preimage{2}
+	inHash := inPreimage.Hash()
+	require.NoError(t, store.CreateLoopIn(inHash, &LoopInContract{
+		SwapContract:   contract(inPreimage),
+		HtlcConfTarget: 2,
+	}))
+
+	// Swaps that have not reached any milestones report none.
+	loopOuts, err := store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, loopOuts, 1)
+	require.Nil(t, loopOuts[0].Milestones)
+
+	confTime := testTime.Add(time.Minute * 10)
+	settleTime := testTime.Add(time.Minute * 20)
+
+	require.NoError(t, store.UpdateLoopOutMilestone(
+		outHash, MilestoneHtlcConfirmed, confTime,
+	))
+
+	// Recording a milestone again should keep the time that it was first
+	// reached.
+	require.NoError(t, store.UpdateLoopOutMilestone(
+		outHash, MilestoneHtlcConfirmed, settleTime,
+	))
+
+	require.NoError(t, store.UpdateLoopInMilestone(
+		inHash, MilestonePaymentSettled, settleTime,
+	))
+
+	// We can't record milestones for swaps that we don't know about, or
+	// for swaps of the wrong type.
+	require.Error(t, store.UpdateLoopOutMilestone(
+		inHash, MilestoneHtlcConfirmed, confTime,
+	))
+
+	loopOuts, err = store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, loopOuts, 1)
+	require.Len(t, loopOuts[0].Milestones, 1)
+	require.True(t, confTime.Equal(
+		loopOuts[0].Milestones[MilestoneHtlcConfirmed],
+	))
+
+	loopIns, err := store.FetchLoopInSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, loopIns, 1)
+	require.Len(t, loopIns[0].Milestones, 1)
+	require.True(t, settleTime.Equal(
+		loopIns[0].Milestones[MilestonePaymentSettled],
+	))
+}
@@ -0,0 +1,181 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// peerOutcomeBucketKey is a bucket that contains the outcomes of
+	// completed swaps, attributed to the peers that the swaps were routed
+	// through. A swap may be recorded for more than one peer.
+	//
+	// maps: peer pubkey || swap hash -> serialized outcome
+	peerOutcomeBucketKey = []byte("peer-outcomes")
+)
+
+// PeerOutcome is a record of the outcome of a completed swap that was routed
+// through a peer.
+type PeerOutcome struct {
+	// Peer is the peer that the swap was routed through.
+	Peer route.Vertex
+
+	// SwapHash is the hash of the swap.
+	SwapHash lntypes.Hash
+
+	// Type is the type of the swap.
+	Type swap.Type
+
+	// Success indicates whether the swap succeeded.
+	Success bool
+
+	// Amount is the amount of the swap.
+	Amount btcutil.Amount
+
+	// FeeLimit is the total fee that we were quoted, and allowed the swap
+	// to spend.
+	FeeLimit btcutil.Amount
+
+	// Cost is the total fee that the swap actually cost us.
+	Cost btcutil.Amount
+
+	// Duration is the amount of time between the swap's initiation and
+	// its completion.
+	Duration time.Duration
+
+	// Time is the time at which the swap completed.
+	Time time.Time
+}
+
+// AddPeerOutcome records the outcome of a swap for a peer. Adding the outcome
+// of a swap for a peer more than once overwrites the existing record.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) AddPeerOutcome(outcome *PeerOutcome) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(peerOutcomeBucketKey)
+		if err != nil {
+			return err
+		}
+
+		outcomeBytes, err := serializePeerOutcome(outcome)
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, 0, len(outcome.Peer)+len(outcome.SwapHash))
+		key = append(key, outcome.Peer[:]...)
+		key = append(key, outcome.SwapHash[:]...)
+
+		return bucket.Put(key, outcomeBytes)
+	})
+}
+
+// FetchPeerOutcomes returns all the swap outcomes that we have recorded for
+// our peers, ordered by peer.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchPeerOutcomes(ctx context.Context) (
+	[]*PeerOutcome, error) {
+
+	var outcomes []*PeerOutcome
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(peerOutcomeBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			outcome, err := deserializePeerOutcome(k, v)
+			if err != nil {
+				return err
+			}
+
+			outcomes = append(outcomes, outcome)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// serializePeerOutcome serializes a peer outcome. The outcome's peer and swap
+// hash are stored in its key.
+func serializePeerOutcome(outcome *PeerOutcome) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		outcome.Type,
+		outcome.Success,
+		outcome.Amount,
+		outcome.FeeLimit,
+		outcome.Cost,
+		outcome.Duration,
+		outcome.Time.UnixNano(),
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializePeerOutcome deserializes a peer outcome from its key and value.
+func deserializePeerOutcome(key, value []byte) (*PeerOutcome, error) {
+	var outcome PeerOutcome
+
+	if len(key) != len(outcome.Peer)+len(outcome.SwapHash) {
+		return nil, fmt.Errorf("invalid peer outcome key length: %v",
+			len(key))
+	}
+
+	copy(outcome.Peer[:], key[:len(outcome.Peer)])
+	copy(outcome.SwapHash[:], key[len(outcome.Peer):])
+
+	var (
+		r        = bytes.NewReader(value)
+		unixNano int64
+	)
+
+	fields := []interface{}{
+		&outcome.Type,
+		&outcome.Success,
+		&outcome.Amount,
+		&outcome.FeeLimit,
+		&outcome.Cost,
+		&outcome.Duration,
+		&unixNano,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read peer outcome: "+
+				"%v", err)
+		}
+	}
+
+	outcome.Time = time.Unix(0, unixNano)
+
+	return &outcome, nil
+}
@@ -0,0 +1,71 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerOutcomes tests persistence of the swap outcomes that we record for
+// our peers.
+func TestPeerOutcomes(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	outcomes, err := store.FetchPeerOutcomes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, outcomes, 0)
+
+	var (
+		outcome1 = &PeerOutcome{
+			Peer:     route.Vertex{1},
+			SwapHash: lntypes.Hash{1},
+			Type:     swap.TypeOut,
+			Success:  true,
+			Amount:   100000,
+			FeeLimit: 500,
+			Cost:     300,
+			Duration: time.Minute * 30,
+			Time:     time.Unix(0, 100),
+		}
+
+		outcome2 = &PeerOutcome{
+			Peer:     route.Vertex{2},
+			SwapHash: lntypes.Hash{2},
+			Type:     swap.TypeIn,
+			Amount:   200000,
+			FeeLimit: 800,
+			Duration: time.Hour,
+			Time:     time.Unix(0, 200),
+		}
+	)
+
+	require.NoError(t, store.AddPeerOutcome(outcome2))
+	require.NoError(t, store.AddPeerOutcome(outcome1))
+
+	outcomes, err = store.FetchPeerOutcomes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*PeerOutcome{outcome1, outcome2}, outcomes)
+
+	// Adding an outcome for the same peer and swap again should overwrite
+	// our existing record.
+	outcome1.Cost = 400
+	require.NoError(t, store.AddPeerOutcome(outcome1))
+
+	outcomes, err = store.FetchPeerOutcomes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*PeerOutcome{outcome1, outcome2}, outcomes)
+}
@@ -43,13 +43,18 @@ const (
 	// canceling loop out swaps.
 	ProtocolVersionLoopOutCancel = 7
 
+	// ProtocolVersionBatchedPrepay indicates that the client can pay a
+	// loop out's prepay as part of its swap payment if the server includes
+	// the prepay amount in the swap invoice.
+	ProtocolVersionBatchedPrepay ProtocolVersion = 8
+
 	// ProtocolVersionUnrecorded is set for swaps were created before we
 	// started saving protocol version with swaps.
 	ProtocolVersionUnrecorded ProtocolVersion = math.MaxUint32
 
 	// CurrentRPCProtocolVersion defines the version of the RPC protocol
 	// that is currently supported by the loop client.
-	CurrentRPCProtocolVersion = looprpc.ProtocolVersion_LOOP_OUT_CANCEL
+	CurrentRPCProtocolVersion = looprpc.ProtocolVersion_BATCHED_PREPAY_LOOP_OUT
 
 	// CurrentInternalProtocolVersion defines the RPC current protocol in
 	// the internal representation.
@@ -88,6 +93,9 @@ func (p ProtocolVersion) String() string {
 	case ProtocolVersionLoopOutCancel:
 		return "Loop Out Cancel"
 
+	case ProtocolVersionBatchedPrepay:
+		return "Batched Prepay"
+
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,236 @@
+package loopdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// compactFileName is the name of the file that we write a compacted copy of
+// our database to before it replaces our database.
+const compactFileName = "loop.db.compact"
+
+// PruneSwaps deletes all swaps that reached a final state before the time
+// provided, and returns the hashes of the swaps that were deleted. Pending
+// swaps are never deleted. Note that all information about the swaps is
+// deleted, including the preimages and keys that would be required to recover
+// their funds.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) PruneSwaps(ctx context.Context, before time.Time) (
+	[]lntypes.Hash, error) {
+
+	var pruned []lntypes.Hash
+
+	err := s.update(func(tx *bbolt.Tx) error {
+		// Reset our result in case the transaction is retried.
+		pruned = nil
+
+		for _, bucketKey := range swapRootBuckets {
+			rootBucket := tx.Bucket(bucketKey)
+			if rootBucket == nil {
+				return fmt.Errorf("bucket %s does not exist",
+					bucketKey)
+			}
+
+			hashes, err := pruneSwapBucket(ctx, rootBucket, before)
+			if err != nil {
+				return err
+			}
+
+			pruned = append(pruned, hashes...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pruned, nil
+}
+
+// pruneSwapBucket deletes the swaps in the root bucket provided that reached a
+// final state before the time provided. If the context provided is cancelled
+// during our scan, we fail so that the transaction is rolled back.
+func pruneSwapBucket(ctx context.Context, rootBucket *bbolt.Bucket,
+	before time.Time) ([]lntypes.Hash, error) {
+
+	// We collect our keys before deleting them, because deleting while
+	// iterating may skip keys.
+	var keys [][]byte
+	err := rootBucket.ForEach(func(swapHash, v []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Only go into things that we know are sub-bucket keys.
+		swapBucket := rootBucket.Bucket(swapHash)
+		if swapBucket == nil {
+			return nil
+		}
+
+		updates, err := deserializeUpdates(swapBucket)
+		if err != nil {
+			return err
+		}
+
+		// Swaps without any updates have not reached a final state.
+		if len(updates) == 0 {
+			return nil
+		}
+
+		// Our updates are ordered by their sequence number, so the
+		// last update holds our current state.
+		last := updates[len(updates)-1]
+		if last.State.Type() == StateTypePending {
+			return nil
+		}
+
+		if !last.Time.Before(before) {
+			return nil
+		}
+
+		keys = append(keys, swapHash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]lntypes.Hash, 0, len(keys))
+	for _, key := range keys {
+		hash, err := lntypes.MakeHash(key)
+		if err != nil {
+			return nil, err
+		}
+
+		// Remove the swap's idempotency key from our index, so that
+		// the key can be used again once its swap has been pruned.
+		err = removeIdempotencyKey(
+			rootBucket.Tx(), rootBucket.Bucket(key),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := rootBucket.DeleteBucket(key); err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// CompactDB rewrites the database in the directory provided to a new file and
+// replaces the database with it. Bbolt does not shrink its file when records
+// are deleted, so this is required to reclaim the space of deleted swaps. The
+// database must not be opened by anybody else while it is compacted. The size
+// of the database before and after compaction is returned.
+func CompactDB(dbPath string) (int64, int64, error) {
+	srcPath := filepath.Join(dbPath, dbFileName)
+	dstPath := filepath.Join(dbPath, compactFileName)
+
+	srcInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Remove any compacted copy that was left behind by an earlier
+	// attempt that did not complete.
+	if err := os.Remove(dstPath); err != nil && !os.IsNotExist(err) {
+		return 0, 0, err
+	}
+
+	// Opening our database obtains an exclusive lock on it, so we know
+	// that it is not in use while we compact it.
+	src, err := bbolt.Open(srcPath, 0600, &bbolt.Options{
+		Timeout: DefaultLoopDBTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return 0, 0, fmt.Errorf("%w: couldn't obtain exclusive lock on "+
+			"%s, timed out after %v", bbolt.ErrTimeout, srcPath,
+			DefaultLoopDBTimeout)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer src.Close()
+
+	dst, err := bbolt.Open(dstPath, 0600, &bbolt.Options{
+		Timeout: DefaultLoopDBTimeout,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = src.View(func(srcTx *bbolt.Tx) error {
+		return dst.Update(func(dstTx *bbolt.Tx) error {
+			return srcTx.ForEach(func(name []byte,
+				srcBucket *bbolt.Bucket) error {
+
+				dstBucket, err := dstTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+
+				return copyBucket(srcBucket, dstBucket)
+			})
+		})
+	})
+	if err != nil {
+		_ = dst.Close()
+		_ = os.Remove(dstPath)
+
+		return 0, 0, err
+	}
+
+	if err := dst.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	dstInfo, err := os.Stat(dstPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// We replace our database while we still hold its lock, so that
+	// nobody can open it before the compacted copy is in place.
+	if err := os.Rename(dstPath, srcPath); err != nil {
+		return 0, 0, err
+	}
+
+	return srcInfo.Size(), dstInfo.Size(), nil
+}
+
+// copyBucket recursively copies all the values and nested buckets of a bucket
+// to another bucket.
+func copyBucket(src, dst *bbolt.Bucket) error {
+	// We insert our keys in order, so we can fill our pages completely.
+	dst.FillPercent = 1.0
+
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+
+	return src.ForEach(func(k, v []byte) error {
+		// Nested buckets have a nil value.
+		if v != nil {
+			return dst.Put(k, v)
+		}
+
+		nestedDst, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+
+		return copyBucket(src.Bucket(k), nestedDst)
+	})
+}
@@ -0,0 +1,138 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPruneSwaps tests that only swaps which reached a final state before our
+// cutoff are pruned, and that the remaining swaps survive compaction.
+func TestPruneSwaps(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	var (
+		cutoff = testTime
+		before = cutoff.Add(time.Hour * -1)
+		after  = cutoff.Add(time.Hour)
+	)
+
+	outContract := func(preimage lntypes.Preimage) *LoopOutContract {
+		return &LoopOutContract{
+			SwapContract: SwapContract{
+				AmountRequested: 100,
+				Preimage:        preimage,
+				CltvExpiry:      144,
+				SenderKey:       senderKey,
+				ReceiverKey:     receiverKey,
+				InitiationTime:  before,
+			},
+			DestAddr:        test.GetDestAddr(t, 0),
+			SwapInvoice:     "swapinvoice",
+			SweepConfTarget: 2,
+		}
+	}
+
+	// addLoopOut creates a loop out that is updated to the state provided
+	// at the time provided.
+	addLoopOut := func(id byte, state SwapState,
+		updateTime time.Time) lntypes.Hash {
+
+		preimage := lntypes.Preimage{id}
+		hash := preimage.Hash()
+
+		require.NoError(t, store.CreateLoopOut(
+			hash, outContract(preimage),
+		))
+		require.NoError(t, store.UpdateLoopOut(
+			hash, updateTime, SwapStateData{State: state},
+		))
+
+		return hash
+	}
+
+	var (
+		oldSuccess = addLoopOut(1, StateSuccess, before)
+		newSuccess = addLoopOut(2, StateSuccess, after)
+		oldPending = addLoopOut(3, StatePreimageRevealed, before)
+	)
+
+	// Add a loop in that failed before our cutoff.
+	inPreimage := lntypes.Preimage{4}
+	oldFailedIn := inPreimage.Hash()
+	require.NoError(t, store.CreateLoopIn(oldFailedIn, &LoopInContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        inPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  before,
+		},
+		HtlcConfTarget: 2,
+	}))
+	require.NoError(t, store.UpdateLoopIn(
+		oldFailedIn, before, SwapStateData{State: StateFailTimeout},
+	))
+
+	// A prune with a cancelled context fails, and does not delete any
+	// of our swaps.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = store.PruneSwaps(ctx, cutoff)
+	require.Equal(t, context.Canceled, err)
+
+	loopOuts, err := store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, loopOuts, 3)
+
+	pruned, err := store.PruneSwaps(context.Background(), cutoff)
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t, []lntypes.Hash{oldSuccess, oldFailedIn}, pruned,
+	)
+
+	assertSwaps := func(store *boltSwapStore) {
+		loopOuts, err := store.FetchLoopOutSwaps(context.Background())
+		require.NoError(t, err)
+
+		var hashes []lntypes.Hash
+		for _, swap := range loopOuts {
+			hashes = append(hashes, swap.Hash)
+		}
+		require.ElementsMatch(
+			t, []lntypes.Hash{newSuccess, oldPending}, hashes,
+		)
+
+		loopIns, err := store.FetchLoopInSwaps(context.Background())
+		require.NoError(t, err)
+		require.Len(t, loopIns, 0)
+	}
+	assertSwaps(store)
+
+	// Compact our database, which requires that our store is closed.
+	require.NoError(t, store.Close())
+
+	_, _, err = CompactDB(tempDirName)
+	require.NoError(t, err)
+
+	// Reopen our store and assert that our swaps were retained.
+	store, err = NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	assertSwaps(store)
+}
@@ -0,0 +1,183 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// quoteHistoryBucketKey is a bucket that contains every quote that we
+	// have received from the server. This bucket is keyed by a sequence
+	// number, so that quotes are iterated in the order they were stored.
+	//
+	// maps: sequence number -> serialized quote
+	quoteHistoryBucketKey = []byte("quote-history")
+)
+
+// QuoteType indicates the type of swap that a quote was obtained for.
+type QuoteType uint8
+
+const (
+	// QuoteTypeLoopOut indicates that a quote was obtained for a loop out
+	// swap.
+	QuoteTypeLoopOut QuoteType = 0
+
+	// QuoteTypeLoopIn indicates that a quote was obtained for a loop in
+	// swap.
+	QuoteTypeLoopIn QuoteType = 1
+)
+
+// String returns a string representation of a quote type.
+func (q QuoteType) String() string {
+	switch q {
+	case QuoteTypeLoopOut:
+		return "loop out"
+
+	case QuoteTypeLoopIn:
+		return "loop in"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Quote is a record of a quote that we received from the server.
+type Quote struct {
+	// Time is the time at which we obtained the quote.
+	Time time.Time
+
+	// Type is the type of swap that the quote was for.
+	Type QuoteType
+
+	// Amount is the swap amount that was quoted.
+	Amount btcutil.Amount
+
+	// SwapFee is the fee that the server quoted for the swap.
+	SwapFee btcutil.Amount
+
+	// PrepayAmount is the part of the swap fee that the server requested
+	// as a prepayment. This is always zero for loop in quotes.
+	PrepayAmount btcutil.Amount
+
+	// MinerFee is our estimate of the on-chain fee for the swap at the
+	// time of the quote.
+	MinerFee btcutil.Amount
+}
+
+// AddQuote appends a quote to our quote history.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) AddQuote(quote *Quote) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(quoteHistoryBucketKey)
+		if err != nil {
+			return err
+		}
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		quoteBytes, err := serializeQuote(quote)
+		if err != nil {
+			return err
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], seq)
+
+		return bucket.Put(key[:], quoteBytes)
+	})
+}
+
+// FetchQuotes returns all of the quotes in our quote history, in the order
+// that they were added.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchQuotes(ctx context.Context) ([]*Quote, error) {
+	var quotes []*Quote
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(quoteHistoryBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			quote, err := deserializeQuote(v)
+			if err != nil {
+				return err
+			}
+
+			quotes = append(quotes, quote)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return quotes, nil
+}
+
+// serializeQuote serializes a quote.
+func serializeQuote(quote *Quote) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		quote.Time.UnixNano(),
+		quote.Type,
+		quote.Amount,
+		quote.SwapFee,
+		quote.PrepayAmount,
+		quote.MinerFee,
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeQuote deserializes a quote.
+func deserializeQuote(value []byte) (*Quote, error) {
+	var (
+		r        = bytes.NewReader(value)
+		quote    Quote
+		unixNano int64
+	)
+
+	fields := []interface{}{
+		&unixNano,
+		&quote.Type,
+		&quote.Amount,
+		&quote.SwapFee,
+		&quote.PrepayAmount,
+		&quote.MinerFee,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read quote: %v", err)
+		}
+	}
+
+	quote.Time = time.Unix(0, unixNano)
+
+	return &quote, nil
+}
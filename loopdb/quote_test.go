@@ -0,0 +1,55 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQuoteHistory tests persistence of our quote history.
+func TestQuoteHistory(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	quotes, err := store.FetchQuotes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, quotes, 0)
+
+	// Add quotes with decreasing timestamps so that we can assert that
+	// quotes are returned in the order they were added.
+	expected := []*Quote{
+		{
+			Time:         time.Unix(0, 200),
+			Type:         QuoteTypeLoopOut,
+			Amount:       100000,
+			SwapFee:      100,
+			PrepayAmount: 10,
+			MinerFee:     50,
+		},
+		{
+			Time:     time.Unix(0, 100),
+			Type:     QuoteTypeLoopIn,
+			Amount:   200000,
+			SwapFee:  150,
+			MinerFee: 60,
+		},
+	}
+
+	for _, quote := range expected {
+		require.NoError(t, store.AddQuote(quote))
+	}
+
+	quotes, err = store.FetchQuotes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, quotes)
+}
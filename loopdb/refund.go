@@ -0,0 +1,133 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+var (
+	// refundTxKey is the key that stores the most recent timeout
+	// transaction that we published to refund a loop in's htlc.
+	//
+	// path: loopInBucket -> swapBucket[hash] -> refundTxKey
+	//
+	// value: txid || uint64 fee rate || int64 fee || int32 publish height
+	refundTxKey = []byte("refund-tx")
+)
+
+// RefundTx records the most recent timeout transaction that we published to
+// refund the htlc of a loop in, so that we can report its progress and resume
+// fee bumping after a restart.
+type RefundTx struct {
+	// TxHash is the hash of the refund transaction.
+	TxHash chainhash.Hash
+
+	// FeeRate is the fee rate that the refund was published with.
+	FeeRate chainfee.SatPerKWeight
+
+	// Fee is the fee that the refund transaction pays.
+	Fee btcutil.Amount
+
+	// PublishHeight is the height at which we first published a refund
+	// with this fee rate.
+	PublishHeight int32
+}
+
+// UpdateLoopInRefundTx stores the most recent refund transaction that we
+// published for a loop in, overwriting any previous value.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) UpdateLoopInRefundTx(hash lntypes.Hash,
+	refund RefundTx) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(loopInBucketKey)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		swapBucket := rootBucket.Bucket(hash[:])
+		if swapBucket == nil {
+			return errors.New("swap not found")
+		}
+
+		refundBytes, err := serializeRefundTx(refund)
+		if err != nil {
+			return err
+		}
+
+		return swapBucket.Put(refundTxKey, refundBytes)
+	})
+}
+
+// getRefundTx reads the refund transaction for a loop in from its bucket. If
+// no refund has been published, nil is returned.
+func getRefundTx(bucket *bbolt.Bucket) (*RefundTx, error) {
+	value := bucket.Get(refundTxKey)
+	if value == nil {
+		return nil, nil
+	}
+
+	return deserializeRefundTx(value)
+}
+
+// serializeRefundTx serializes a refund transaction record.
+func serializeRefundTx(refund RefundTx) ([]byte, error) {
+	var b bytes.Buffer
+
+	if _, err := b.Write(refund.TxHash[:]); err != nil {
+		return nil, err
+	}
+
+	err := binary.Write(&b, byteOrder, uint64(refund.FeeRate))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(&b, byteOrder, int64(refund.Fee)); err != nil {
+		return nil, err
+	}
+
+	err = binary.Write(&b, byteOrder, refund.PublishHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeRefundTx deserializes a refund transaction record.
+func deserializeRefundTx(value []byte) (*RefundTx, error) {
+	r := bytes.NewReader(value)
+
+	refund := &RefundTx{}
+	if err := binary.Read(r, byteOrder, &refund.TxHash); err != nil {
+		return nil, err
+	}
+
+	var feeRate uint64
+	if err := binary.Read(r, byteOrder, &feeRate); err != nil {
+		return nil, err
+	}
+	refund.FeeRate = chainfee.SatPerKWeight(feeRate)
+
+	var fee int64
+	if err := binary.Read(r, byteOrder, &fee); err != nil {
+		return nil, err
+	}
+	refund.Fee = btcutil.Amount(fee)
+
+	err := binary.Read(r, byteOrder, &refund.PublishHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
@@ -0,0 +1,151 @@
+package loopdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// ruleSwapBucketKey is a bucket that attributes automatically
+	// dispatched swaps to the autoloop rule that they were suggested for,
+	// so that their fees can be counted against the rule's budget.
+	//
+	// maps: swap hash -> uint64 channel id || peer pubkey || int64 time
+	ruleSwapBucketKey = []byte("rule-swaps")
+)
+
+// RuleSwap attributes a swap to the autoloop rule that it was dispatched for.
+// Exactly one of ChannelID and Peer is set, depending on whether the rule is
+// a channel or peer rule.
+type RuleSwap struct {
+	// SwapHash is the hash of the swap.
+	SwapHash lntypes.Hash
+
+	// ChannelID is the short channel id of the channel that the rule is
+	// set for, if it is a channel rule.
+	ChannelID uint64
+
+	// Peer is the pubkey of the peer that the rule is set for, if it is a
+	// peer rule.
+	Peer route.Vertex
+
+	// Time is the time at which the swap was dispatched.
+	Time time.Time
+}
+
+// AddRuleSwap attributes a swap to the autoloop rule that it was dispatched
+// for. Adding a swap more than once overwrites its existing record.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) AddRuleSwap(ruleSwap *RuleSwap) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(ruleSwapBucketKey)
+		if err != nil {
+			return err
+		}
+
+		ruleSwapBytes, err := serializeRuleSwap(ruleSwap)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(ruleSwap.SwapHash[:], ruleSwapBytes)
+	})
+}
+
+// FetchRuleSwaps returns all the swaps that we have attributed to autoloop
+// rules, ordered by swap hash.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchRuleSwaps(ctx context.Context) ([]*RuleSwap,
+	error) {
+
+	var ruleSwaps []*RuleSwap
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleSwapBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			ruleSwap, err := deserializeRuleSwap(k, v)
+			if err != nil {
+				return err
+			}
+
+			ruleSwaps = append(ruleSwaps, ruleSwap)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ruleSwaps, nil
+}
+
+// serializeRuleSwap serializes a rule swap. Its swap hash is stored in its
+// key.
+func serializeRuleSwap(ruleSwap *RuleSwap) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		ruleSwap.ChannelID,
+		ruleSwap.Peer,
+		ruleSwap.Time.UnixNano(),
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeRuleSwap deserializes a rule swap from its key and value.
+func deserializeRuleSwap(key, value []byte) (*RuleSwap, error) {
+	var ruleSwap RuleSwap
+
+	if len(key) != len(ruleSwap.SwapHash) {
+		return nil, fmt.Errorf("invalid rule swap key length: %v",
+			len(key))
+	}
+	copy(ruleSwap.SwapHash[:], key)
+
+	var (
+		r        = bytes.NewReader(value)
+		unixNano int64
+	)
+
+	fields := []interface{}{
+		&ruleSwap.ChannelID,
+		&ruleSwap.Peer,
+		&unixNano,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read rule swap: %v",
+				err)
+		}
+	}
+
+	ruleSwap.Time = time.Unix(0, unixNano)
+
+	return &ruleSwap, nil
+}
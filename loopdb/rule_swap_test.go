@@ -0,0 +1,59 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleSwaps tests persistence of the swaps that we attribute to autoloop
+// rules.
+func TestRuleSwaps(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	ruleSwaps, err := store.FetchRuleSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ruleSwaps, 0)
+
+	var (
+		channelSwap = &RuleSwap{
+			SwapHash:  lntypes.Hash{1},
+			ChannelID: 123,
+			Time:      time.Unix(0, 100),
+		}
+
+		peerSwap = &RuleSwap{
+			SwapHash: lntypes.Hash{2},
+			Peer:     route.Vertex{2},
+			Time:     time.Unix(0, 200),
+		}
+	)
+
+	require.NoError(t, store.AddRuleSwap(peerSwap))
+	require.NoError(t, store.AddRuleSwap(channelSwap))
+
+	ruleSwaps, err = store.FetchRuleSwaps(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*RuleSwap{channelSwap, peerSwap}, ruleSwaps)
+
+	// Adding the same swap again should overwrite our existing record.
+	channelSwap.ChannelID = 456
+	require.NoError(t, store.AddRuleSwap(channelSwap))
+
+	ruleSwaps, err = store.FetchRuleSwaps(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*RuleSwap{channelSwap, peerSwap}, ruleSwaps)
+}
@@ -0,0 +1,71 @@
+package loopdb
+
+// ServerFailureReason is the reason that the swap server reported for the
+// failure of a swap.
+type ServerFailureReason uint8
+
+const (
+	// ServerFailureNone indicates that the server did not report a
+	// failure for the swap.
+	ServerFailureNone ServerFailureReason = 0
+
+	// ServerFailureUnknown indicates that the server reported a failure
+	// without a specific reason.
+	ServerFailureUnknown ServerFailureReason = 1
+
+	// ServerFailureNoHtlc indicates that no loop in htlc confirmed in
+	// time for the server to complete the swap.
+	ServerFailureNoHtlc ServerFailureReason = 2
+
+	// ServerFailureInvalidHtlcAmount indicates that a loop in htlc
+	// confirmed, but did not have the amount that the server expected.
+	ServerFailureInvalidHtlcAmount ServerFailureReason = 3
+
+	// ServerFailureOffchainTimeout indicates that the server could not
+	// complete a loop in's off chain payment before its timeout.
+	ServerFailureOffchainTimeout ServerFailureReason = 4
+
+	// ServerFailureTimeout indicates that the server claimed, or published
+	// its claim of, the on chain timeout of the swap's htlc.
+	ServerFailureTimeout ServerFailureReason = 5
+
+	// ServerFailureSwapDeadline indicates that the server could not
+	// publish a loop out htlc before the swap's publication deadline.
+	ServerFailureSwapDeadline ServerFailureReason = 6
+
+	// ServerFailureHtlcPublication indicates that the server could not
+	// publish a loop out htlc.
+	ServerFailureHtlcPublication ServerFailureReason = 7
+)
+
+// String returns a string representation of a server failure reason.
+func (r ServerFailureReason) String() string {
+	switch r {
+	case ServerFailureNone:
+		return "None"
+
+	case ServerFailureUnknown:
+		return "Unknown"
+
+	case ServerFailureNoHtlc:
+		return "NoHtlc"
+
+	case ServerFailureInvalidHtlcAmount:
+		return "InvalidHtlcAmount"
+
+	case ServerFailureOffchainTimeout:
+		return "OffchainTimeout"
+
+	case ServerFailureTimeout:
+		return "Timeout"
+
+	case ServerFailureSwapDeadline:
+		return "SwapDeadline"
+
+	case ServerFailureHtlcPublication:
+		return "HtlcPublication"
+
+	default:
+		return "Invalid"
+	}
+}
@@ -0,0 +1,321 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// staticAddressBucketKey is a bucket that contains the static address
+	// that deposits for loop in swaps are made to.
+	staticAddressBucketKey = []byte("static-address")
+
+	// staticAddressKey is the key that stores our static address.
+	//
+	// path: staticAddressBucket -> staticAddressKey
+	//
+	// value: encoded address string
+	staticAddressKey = []byte("address")
+
+	// staticAddressCreatedKey is the key that stores the time at which our
+	// static address was created.
+	//
+	// path: staticAddressBucket -> staticAddressCreatedKey
+	//
+	// value: unix nano timestamp
+	staticAddressCreatedKey = []byte("created")
+
+	// depositsBucketKey is a bucket that contains all of the deposits that
+	// have been made to our static address. This bucket is keyed by the
+	// outpoint of the deposit.
+	//
+	// maps: outpoint -> serialized deposit
+	depositsBucketKey = []byte("static-deposits")
+
+	// ErrNoStaticAddress is returned when our static address is requested,
+	// but it has not been created yet.
+	ErrNoStaticAddress = errors.New("no static address created")
+
+	// ErrStaticAddressExists is returned when we try to create a static
+	// address, but one already exists.
+	ErrStaticAddressExists = errors.New("static address already exists")
+)
+
+// StaticAddress is a long-lived on-chain address. Deposits to this address
+// automatically trigger loop in swaps once they confirm.
+type StaticAddress struct {
+	// Address is the encoded on-chain address.
+	Address string
+
+	// CreationTime is the time at which the address was created.
+	CreationTime time.Time
+}
+
+// DepositState describes the progress of a deposit to our static address.
+type DepositState uint8
+
+const (
+	// DepositConfirmed indicates that a deposit has confirmed, but that we
+	// have not dispatched a loop in swap for it yet.
+	DepositConfirmed DepositState = 0
+
+	// DepositSwapInitiated indicates that we have dispatched a loop in
+	// swap for a deposit.
+	DepositSwapInitiated DepositState = 1
+
+	// DepositTooSmall indicates that a deposit is too small to be swapped,
+	// so it was left in our wallet.
+	DepositTooSmall DepositState = 2
+
+	// DepositTooLarge indicates that a deposit exceeds the server's maximum
+	// swap amount, so it was left in our wallet.
+	DepositTooLarge DepositState = 3
+)
+
+// String returns a string representation of a deposit state.
+func (d DepositState) String() string {
+	switch d {
+	case DepositConfirmed:
+		return "Confirmed"
+
+	case DepositSwapInitiated:
+		return "SwapInitiated"
+
+	case DepositTooSmall:
+		return "TooSmall"
+
+	case DepositTooLarge:
+		return "TooLarge"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// Deposit is an on-chain output that was paid to our static address.
+type Deposit struct {
+	// OutPoint is the outpoint of the deposit.
+	OutPoint wire.OutPoint
+
+	// Value is the value of the deposit.
+	Value btcutil.Amount
+
+	// ConfirmationHeight is the height at which we first saw the deposit
+	// with sufficient confirmations.
+	ConfirmationHeight int32
+
+	// State is the current state of the deposit.
+	State DepositState
+
+	// SwapHash is the hash of the loop in swap that was dispatched for
+	// the deposit. It is nil if no swap has been dispatched.
+	SwapHash *lntypes.Hash
+
+	// LastUpdate is the time of the deposit's most recent state change.
+	LastUpdate time.Time
+}
+
+// CreateStaticAddress persists our static address. It fails with
+// ErrStaticAddressExists if we already have a static address, because deposits
+// may still be made to it.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) CreateStaticAddress(addr *StaticAddress) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(
+			staticAddressBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		if bucket.Get(staticAddressKey) != nil {
+			return ErrStaticAddressExists
+		}
+
+		err = bucket.Put(staticAddressKey, []byte(addr.Address))
+		if err != nil {
+			return err
+		}
+
+		var created [8]byte
+		byteOrder.PutUint64(
+			created[:], uint64(addr.CreationTime.UnixNano()),
+		)
+
+		return bucket.Put(staticAddressCreatedKey, created[:])
+	})
+}
+
+// FetchStaticAddress returns our static address, failing with
+// ErrNoStaticAddress if we have not created one.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchStaticAddress() (*StaticAddress, error) {
+	var addr *StaticAddress
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(staticAddressBucketKey)
+		if bucket == nil {
+			return ErrNoStaticAddress
+		}
+
+		addrBytes := bucket.Get(staticAddressKey)
+		if addrBytes == nil {
+			return ErrNoStaticAddress
+		}
+
+		addr = &StaticAddress{
+			Address: string(addrBytes),
+		}
+
+		created := bucket.Get(staticAddressCreatedKey)
+		if len(created) != 8 {
+			return fmt.Errorf("invalid static address creation "+
+				"time: %x", created)
+		}
+
+		addr.CreationTime = time.Unix(
+			0, int64(byteOrder.Uint64(created)),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// PutDeposit persists a deposit to our static address, overwriting any
+// existing entry for its outpoint.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) PutDeposit(deposit *Deposit) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(depositsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		depositBytes, err := serializeDeposit(deposit)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(outpointKey(deposit.OutPoint), depositBytes)
+	})
+}
+
+// FetchDeposits returns all of the deposits that have been made to our static
+// address.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchDeposits() ([]*Deposit, error) {
+	var deposits []*Deposit
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(depositsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			deposit, err := deserializeDeposit(k, v)
+			if err != nil {
+				return err
+			}
+
+			deposits = append(deposits, deposit)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+// outpointKey returns the key that a deposit is stored under, which is the
+// concatenation of its transaction hash and output index.
+func outpointKey(outpoint wire.OutPoint) []byte {
+	key := make([]byte, 36)
+	copy(key, outpoint.Hash[:])
+	byteOrder.PutUint32(key[32:], outpoint.Index)
+
+	return key
+}
+
+// serializeDeposit serializes the values of a deposit, except for its
+// outpoint, which is used as its key.
+func serializeDeposit(deposit *Deposit) ([]byte, error) {
+	var (
+		b        bytes.Buffer
+		swapHash lntypes.Hash
+	)
+
+	if deposit.SwapHash != nil {
+		swapHash = *deposit.SwapHash
+	}
+
+	for _, value := range []interface{}{
+		int64(deposit.Value), deposit.ConfirmationHeight,
+		uint8(deposit.State), swapHash[:],
+		deposit.LastUpdate.UnixNano(),
+	} {
+		if err := binary.Write(&b, byteOrder, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeDeposit deserializes a deposit from its key and value.
+func deserializeDeposit(key, value []byte) (*Deposit, error) {
+	if len(key) != 36 {
+		return nil, fmt.Errorf("invalid deposit key: %x", key)
+	}
+
+	deposit := &Deposit{}
+	copy(deposit.OutPoint.Hash[:], key[:32])
+	deposit.OutPoint.Index = byteOrder.Uint32(key[32:])
+
+	var (
+		r          = bytes.NewReader(value)
+		amount     int64
+		state      uint8
+		swapHash   lntypes.Hash
+		lastUpdate int64
+	)
+
+	for _, value := range []interface{}{
+		&amount, &deposit.ConfirmationHeight, &state, swapHash[:],
+		&lastUpdate,
+	} {
+		if err := binary.Read(r, byteOrder, value); err != nil {
+			return nil, err
+		}
+	}
+
+	deposit.Value = btcutil.Amount(amount)
+	deposit.State = DepositState(state)
+	deposit.LastUpdate = time.Unix(0, lastUpdate)
+
+	if swapHash != (lntypes.Hash{}) {
+		deposit.SwapHash = &swapHash
+	}
+
+	return deposit, nil
+}
@@ -0,0 +1,84 @@
+package loopdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStaticAddressStore tests persistence of our static address and the
+// deposits that are made to it.
+func TestStaticAddressStore(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	_, err = store.FetchStaticAddress()
+	require.Equal(t, ErrNoStaticAddress, err)
+
+	deposits, err := store.FetchDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 0)
+
+	addr := &StaticAddress{
+		Address:      "bc1qstatic",
+		CreationTime: time.Unix(0, 100),
+	}
+	require.NoError(t, store.CreateStaticAddress(addr))
+
+	stored, err := store.FetchStaticAddress()
+	require.NoError(t, err)
+	require.Equal(t, addr.Address, stored.Address)
+	require.True(t, addr.CreationTime.Equal(stored.CreationTime))
+
+	// We should not be able to replace our static address, because
+	// deposits may still be made to it.
+	err = store.CreateStaticAddress(&StaticAddress{
+		Address: "bc1qother",
+	})
+	require.Equal(t, ErrStaticAddressExists, err)
+
+	deposit := &Deposit{
+		OutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{1},
+			Index: 2,
+		},
+		Value:              100000,
+		ConfirmationHeight: 600,
+		State:              DepositConfirmed,
+		LastUpdate:         time.Unix(0, 200),
+	}
+	require.NoError(t, store.PutDeposit(deposit))
+
+	deposits, err = store.FetchDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.True(t, deposit.LastUpdate.Equal(deposits[0].LastUpdate))
+
+	deposits[0].LastUpdate = deposit.LastUpdate
+	require.Equal(t, deposit, deposits[0])
+
+	// Update our deposit with the swap that was dispatched for it.
+	hash := lntypes.Hash{3}
+	deposit.State = DepositSwapInitiated
+	deposit.SwapHash = &hash
+	deposit.LastUpdate = time.Unix(0, 300)
+	require.NoError(t, store.PutDeposit(deposit))
+
+	deposits, err = store.FetchDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.Equal(t, DepositSwapInitiated, deposits[0].State)
+	require.Equal(t, &hash, deposits[0].SwapHash)
+}
@@ -2,6 +2,7 @@ package loopdb
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcwallet/snacl"
 	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
 
@@ -37,6 +40,13 @@ var (
 	// maps: swapHash -> swapBucket
 	loopInBucketKey = []byte("loop-in")
 
+	// swapRootBuckets is the set of root buckets that swaps are stored in,
+	// one for each type of swap. The buckets for new swap types should be
+	// added here so that they are created when the store is opened, and
+	// so that their contracts are encrypted along with those of our other
+	// swaps.
+	swapRootBuckets = [][]byte{loopOutBucketKey, loopInBucketKey}
+
 	// updatesBucketKey is a bucket that contains all updates pertaining to
 	// a swap. This is a sub-bucket of the swap bucket for a particular
 	// swap. This list only ever grows.
@@ -52,6 +62,13 @@ var (
 	// htlcTxHashKey contains the confirmed htlc tx id.
 	htlcTxHashKey = []byte{1}
 
+	// serverFailureKey contains the failure reason reported by the server
+	// for a failed swap.
+	serverFailureKey = []byte{2}
+
+	// failureReasonKey contains the reason that we failed a swap for.
+	failureReasonKey = []byte{3}
+
 	// contractKey is the key that stores the serialized swap contract. It
 	// is nested within the sub-bucket for each active swap.
 	//
@@ -69,6 +86,15 @@ var (
 	// value: string label
 	labelKey = []byte("label")
 
+	// idempotencyKeyKey is the key that stores an optional idempotency key
+	// that was provided by the client when the swap was created. If no key
+	// was provided, this key will not be present.
+	//
+	// path: loopInBucket/loopOutBucket -> swapBucket[hash] -> idempotencyKeyKey
+	//
+	// value: string idempotency key
+	idempotencyKeyKey = []byte("idempotency-key")
+
 	// protocolVersionKey is used to optionally store the protocol version
 	// for the serialized swap contract. It is nested within the sub-bucket
 	// for each active swap.
@@ -94,6 +120,69 @@ var (
 	// value: uint32 confirmation value
 	confirmationsKey = []byte("confirmations")
 
+	// sweepFeeKey is the key that stores the fee rate that the most recent
+	// sweep of a loop out swap was published with.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> sweepFeeKey
+	//
+	// value: uint64 fee rate || int32 publish height
+	sweepFeeKey = []byte("sweep-fee")
+
+	// paymentTimeoutKey is the key that stores the payment timeout that
+	// was requested for a loop out swap. It is only set if the swap
+	// overrides our default timeout.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> paymentTimeoutKey
+	//
+	// value: int64 timeout in nanoseconds
+	paymentTimeoutKey = []byte("payment-timeout")
+
+	// serverConfTargetKey is the key that stores the htlc publication
+	// confirmation target that we requested from the server for a loop out
+	// swap, along with the target that the server committed to. It is only
+	// set if we expressed a preference.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> serverConfTargetKey
+	//
+	// value: int32 requested target || int32 committed target
+	serverConfTargetKey = []byte("server-conf-target")
+
+	// maxPartsKey is the key that stores the maximum number of payment
+	// parts that was requested for a loop out swap. It is only set if the
+	// swap overrides our default.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> maxPartsKey
+	//
+	// value: uint32 max parts
+	maxPartsKey = []byte("max-parts")
+
+	// priorityKey is the key that stores the execution priority of a loop
+	// out swap. It is only set if the swap does not have normal priority.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> priorityKey
+	//
+	// value: uint8 priority
+	priorityKey = []byte("priority")
+
+	// keyDerivationKey is the key that stores how our htlc key for the
+	// swap was derived.
+	//
+	// path: loopInBucket/loopOutBucket -> swapBucket[hash] ->
+	// keyDerivationKey
+	//
+	// value: derivation scheme (uint8) || key family (uint32) || key index
+	// (uint32)
+	keyDerivationKey = []byte("key-derivation")
+
+	// htlcVersionKey is the key that stores the script version of the
+	// swap's htlc. Swaps that were created before the version was stored
+	// derive it from their protocol version.
+	//
+	// path: loopInBucket/loopOutBucket -> swapBucket[hash] -> htlcVersionKey
+	//
+	// value: uint8 script version
+	htlcVersionKey = []byte("htlc-version")
+
 	byteOrder = binary.BigEndian
 
 	keyLength = 33
@@ -127,15 +216,72 @@ func fileExists(path string) bool {
 type boltSwapStore struct {
 	db          *bbolt.DB
 	chainParams *chaincfg.Params
+
+	// encryptionKey is the key that swap contracts are encrypted with. It
+	// is nil if the store is not encrypted.
+	encryptionKey *snacl.SecretKey
 }
 
 // A compile-time flag to ensure that boltSwapStore implements the SwapStore
 // interface.
 var _ = (*boltSwapStore)(nil)
 
+// storeOptions holds the optional settings of a swap store.
+type storeOptions struct {
+	// password is the password that swap contracts are encrypted with. If
+	// it is empty, the store is not encrypted.
+	password []byte
+
+	// forceStaleDB allows opening a database that is older than its
+	// backup.
+	forceStaleDB bool
+}
+
+// StoreOption is a functional option that modifies a swap store.
+type StoreOption func(*storeOptions)
+
+// WithForceStaleDB allows the store to be opened when its backup is newer than
+// the database, which indicates that an older copy of the database has been
+// restored.
+func WithForceStaleDB() StoreOption {
+	return func(o *storeOptions) {
+		o.forceStaleDB = true
+	}
+}
+
 // NewBoltSwapStore creates a new client swap store.
-func NewBoltSwapStore(dbPath string, chainParams *chaincfg.Params) (
-	*boltSwapStore, error) {
+func NewBoltSwapStore(dbPath string, chainParams *chaincfg.Params,
+	opts ...StoreOption) (*boltSwapStore, error) {
+
+	return newBoltSwapStore(dbPath, chainParams, nil, opts)
+}
+
+// NewEncryptedBoltSwapStore creates a new client swap store that encrypts swap
+// contracts, which contain our swap preimages, with a key derived from the
+// password provided. If an existing store is not encrypted yet, its contracts
+// are encrypted when it is opened. An encrypted store cannot be opened without
+// its password.
+func NewEncryptedBoltSwapStore(dbPath string, chainParams *chaincfg.Params,
+	password []byte, opts ...StoreOption) (*boltSwapStore, error) {
+
+	if len(password) == 0 {
+		return nil, errors.New("database password required")
+	}
+
+	return newBoltSwapStore(dbPath, chainParams, password, opts)
+}
+
+// newBoltSwapStore creates a new client swap store, encrypting its contracts
+// if a password is provided.
+func newBoltSwapStore(dbPath string, chainParams *chaincfg.Params,
+	password []byte, opts []StoreOption) (*boltSwapStore, error) {
+
+	options := &storeOptions{
+		password: password,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
 
 	// If the target path for the swap store doesn't exist, then we'll
 	// create it now before we proceed.
@@ -180,12 +326,16 @@ func NewBoltSwapStore(dbPath string, chainParams *chaincfg.Params) (
 
 		// Try creating these buckets, because loop in was added without
 		// bumping the db version number.
-		_, err = tx.CreateBucketIfNotExists(loopOutBucketKey)
-		if err != nil {
-			return err
+		for _, bucketKey := range swapRootBuckets {
+			_, err = tx.CreateBucketIfNotExists(bucketKey)
+			if err != nil {
+				return err
+			}
 		}
 
-		_, err = tx.CreateBucketIfNotExists(loopInBucketKey)
+		// The sweep batch bucket was added without a migration, so we
+		// also create it here.
+		_, err = tx.CreateBucketIfNotExists(sweepBatchBucketKey)
 		if err != nil {
 			return err
 		}
@@ -203,16 +353,46 @@ func NewBoltSwapStore(dbPath string, chainParams *chaincfg.Params) (
 		return nil, err
 	}
 
+	// Once our database is up to date, we derive the key that our
+	// contracts are encrypted with, if any. We close the database if this
+	// fails so that the caller can retry with a different password.
+	encryptionKey, err := unlockEncryption(bdb, options.password)
+	if err != nil {
+		_ = bdb.Close()
+		return nil, err
+	}
+
+	// Before we use our database, we check that it is not older than the
+	// backup we made the last time it was opened, and take a new backup.
+	err = checkBackup(bdb, dbPath, options.forceStaleDB)
+	if err != nil {
+		_ = bdb.Close()
+		return nil, err
+	}
+
 	return &boltSwapStore{
-		db:          bdb,
-		chainParams: chainParams,
+		db:            bdb,
+		chainParams:   chainParams,
+		encryptionKey: encryptionKey,
 	}, nil
 }
 
 // FetchLoopOutSwaps returns all loop out swaps currently in the store.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
-func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
+func (s *boltSwapStore) FetchLoopOutSwaps(ctx context.Context) ([]*LoopOut,
+	error) {
+
+	return s.FilterLoopOutSwaps(ctx, nil)
+}
+
+// FilterLoopOutSwaps returns the loop out swaps in the store that match
+// the filter provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FilterLoopOutSwaps(ctx context.Context,
+	filter *SwapFilter) ([]*LoopOut, error) {
+
 	var swaps []*LoopOut
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
@@ -222,9 +402,15 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 			return errors.New("bucket does not exist")
 		}
 
-		// We'll now traverse the root bucket for all active swaps. The
-		// primary key is the swap hash itself.
-		return rootBucket.ForEach(func(swapHash, v []byte) error {
+		// fetchSwap reads the swap stored under the swap hash
+		// provided, skipping it if it does not match our filter.
+		fetchSwap := func(swapHash, v []byte) error {
+			// Abort our scan if our caller is no longer
+			// interested in the result.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			// Only go into things that we know are sub-bucket
 			// keys.
 			if v != nil {
@@ -246,6 +432,11 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 				return errors.New("contract not found")
 			}
 
+			contractBytes, err := s.decryptContract(contractBytes)
+			if err != nil {
+				return err
+			}
+
 			contract, err := deserializeLoopOutContract(
 				contractBytes, s.chainParams,
 			)
@@ -256,6 +447,15 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 			// Get our label for this swap, if it is present.
 			contract.Label = getLabel(swapBucket)
 
+			// Get the idempotency key for this swap, if present.
+			contract.IdempotencyKey = getIdempotencyKey(swapBucket)
+
+			// Skip swaps that do not match our filter before we
+			// read the rest of their data.
+			if !filter.MatchesContract(&contract.SwapContract) {
+				return nil
+			}
+
 			// Read the list of concatenated outgoing channel ids
 			// that form the outgoing set.
 			setBytes := swapBucket.Get(outgoingChanSetKey)
@@ -279,6 +479,13 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 				}
 			}
 
+			contract.OutgoingChanBalances, err = getChannelBalances(
+				swapBucket, outgoingChanBalancesKey,
+			)
+			if err != nil {
+				return err
+			}
+
 			// Set our default number of confirmations for the swap.
 			contract.HtlcConfirmations = DefaultLoopOutHtlcConfirmations
 
@@ -296,11 +503,78 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 				}
 			}
 
+			// If the swap requested its own payment timeout, we
+			// restore it. Otherwise it is left as zero so that our
+			// default is used.
+			timeoutBytes := swapBucket.Get(paymentTimeoutKey)
+			if timeoutBytes != nil {
+				r := bytes.NewReader(timeoutBytes)
+				err := binary.Read(
+					r, byteOrder, &contract.PaymentTimeout,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Restore the htlc conf target that we negotiated with
+			// the server, if any.
+			targetBytes := swapBucket.Get(serverConfTargetKey)
+			if targetBytes != nil {
+				r := bytes.NewReader(targetBytes)
+				targets := []interface{}{
+					&contract.RequestedServerHtlcConfTarget,
+					&contract.ServerHtlcConfTarget,
+				}
+
+				for _, target := range targets {
+					err := binary.Read(r, byteOrder, target)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			// If the swap requested its own maximum number of
+			// payment parts, we restore it.
+			partsBytes := swapBucket.Get(maxPartsKey)
+			if partsBytes != nil {
+				r := bytes.NewReader(partsBytes)
+				err := binary.Read(
+					r, byteOrder, &contract.MaxParts,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Swaps without a stored priority have normal
+			// priority.
+			priorityBytes := swapBucket.Get(priorityKey)
+			if len(priorityBytes) == 1 {
+				contract.Priority = SwapPriority(
+					priorityBytes[0],
+				)
+			}
+
+			contract.DestXpubIndex, err = getXpubIndex(swapBucket)
+			if err != nil {
+				return err
+			}
+
 			updates, err := deserializeUpdates(swapBucket)
 			if err != nil {
 				return err
 			}
 
+			// Our updates are enough to determine the swap's
+			// current state, so we can skip the swap if it does
+			// not match our filter.
+			state := (&Loop{Events: updates}).State().State
+			if !filter.MatchesState(state) {
+				return nil
+			}
+
 			// Try to unmarshal the protocol version for the swap.
 			// If the protocol version is not stored (which is
 			// the case for old clients), we'll assume the
@@ -313,6 +587,19 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 				return err
 			}
 
+			contract.KeyDerivation, contract.KeyLocator, err =
+				getKeyDerivation(swapBucket)
+			if err != nil {
+				return err
+			}
+
+			contract.HtlcVersion, err = getHtlcVersion(
+				swapBucket, contract.ProtocolVersion,
+			)
+			if err != nil {
+				return err
+			}
+
 			loop := LoopOut{
 				Loop: Loop{
 					Events: updates,
@@ -320,6 +607,28 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 				Contract: contract,
 			}
 
+			// If we have published a sweep for this swap, we
+			// restore the fee rate that we last used.
+			sweepFeeBytes := swapBucket.Get(sweepFeeKey)
+			if sweepFeeBytes != nil {
+				loop.SweepFee, err = deserializeSweepFee(
+					sweepFeeBytes,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			loop.ChannelFlow, err = getChannelFlow(swapBucket)
+			if err != nil {
+				return err
+			}
+
+			loop.Milestones, err = getMilestones(swapBucket)
+			if err != nil {
+				return err
+			}
+
 			loop.Hash, err = lntypes.MakeHash(swapHash)
 			if err != nil {
 				return err
@@ -328,7 +637,27 @@ func (s *boltSwapStore) FetchLoopOutSwaps() ([]*LoopOut, error) {
 			swaps = append(swaps, &loop)
 
 			return nil
-		})
+		}
+
+		// If we are looking for the swap that was created with an
+		// idempotency key, we look it up in our index rather than
+		// scanning all of our swaps.
+		if filter != nil && filter.IdempotencyKey != "" {
+			swapHash, rootBucketKey := lookupIdempotencyKey(
+				tx, filter.IdempotencyKey,
+			)
+			if swapHash == nil ||
+				!bytes.Equal(rootBucketKey, loopOutBucketKey) {
+
+				return nil
+			}
+
+			return fetchSwap(swapHash, nil)
+		}
+
+		// We'll now traverse the root bucket for all active swaps. The
+		// primary key is the swap hash itself.
+		return rootBucket.ForEach(fetchSwap)
 	})
 	if err != nil {
 		return nil, err
@@ -376,6 +705,23 @@ func deserializeUpdates(swapBucket *bbolt.Bucket) ([]*LoopEvent, error) {
 			event.HtlcTxHash = htlcTxHash
 		}
 
+		// Deserialize the server's failure reason if this update
+		// contains one.
+		serverFailureBytes := updateBucket.Get(serverFailureKey)
+		if len(serverFailureBytes) == 1 {
+			event.ServerFailure = ServerFailureReason(
+				serverFailureBytes[0],
+			)
+		}
+
+		// Deserialize our failure reason if this update contains one.
+		failureReasonBytes := updateBucket.Get(failureReasonKey)
+		if len(failureReasonBytes) == 1 {
+			event.FailureReason = FailureReason(
+				failureReasonBytes[0],
+			)
+		}
+
 		updates = append(updates, event)
 		return nil
 	})
@@ -389,7 +735,19 @@ func deserializeUpdates(swapBucket *bbolt.Bucket) ([]*LoopEvent, error) {
 // FetchLoopInSwaps returns all loop in swaps currently in the store.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
-func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
+func (s *boltSwapStore) FetchLoopInSwaps(ctx context.Context) ([]*LoopIn,
+	error) {
+
+	return s.FilterLoopInSwaps(ctx, nil)
+}
+
+// FilterLoopInSwaps returns the loop in swaps in the store that match
+// the filter provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FilterLoopInSwaps(ctx context.Context,
+	filter *SwapFilter) ([]*LoopIn, error) {
+
 	var swaps []*LoopIn
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
@@ -399,9 +757,15 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 			return errors.New("bucket does not exist")
 		}
 
-		// We'll now traverse the root bucket for all active swaps. The
-		// primary key is the swap hash itself.
-		return rootBucket.ForEach(func(swapHash, v []byte) error {
+		// fetchSwap reads the swap stored under the swap hash
+		// provided, skipping it if it does not match our filter.
+		fetchSwap := func(swapHash, v []byte) error {
+			// Abort our scan if our caller is no longer
+			// interested in the result.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			// Only go into things that we know are sub-bucket
 			// keys.
 			if v != nil {
@@ -423,6 +787,11 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 				return errors.New("contract not found")
 			}
 
+			contractBytes, err := s.decryptContract(contractBytes)
+			if err != nil {
+				return err
+			}
+
 			contract, err := deserializeLoopInContract(
 				contractBytes,
 			)
@@ -433,11 +802,28 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 			// Get our label for this swap, if it is present.
 			contract.Label = getLabel(swapBucket)
 
+			// Get the idempotency key for this swap, if present.
+			contract.IdempotencyKey = getIdempotencyKey(swapBucket)
+
+			// Skip swaps that do not match our filter before we
+			// read the rest of their data.
+			if !filter.MatchesContract(&contract.SwapContract) {
+				return nil
+			}
+
 			updates, err := deserializeUpdates(swapBucket)
 			if err != nil {
 				return err
 			}
 
+			// Our updates are enough to determine the swap's
+			// current state, so we can skip the swap if it does
+			// not match our filter.
+			state := (&Loop{Events: updates}).State().State
+			if !filter.MatchesState(state) {
+				return nil
+			}
+
 			// Try to unmarshal the protocol version for the swap.
 			// If the protocol version is not stored (which is
 			// the case for old clients), we'll assume the
@@ -450,6 +836,19 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 				return err
 			}
 
+			contract.KeyDerivation, contract.KeyLocator, err =
+				getKeyDerivation(swapBucket)
+			if err != nil {
+				return err
+			}
+
+			contract.HtlcVersion, err = getHtlcVersion(
+				swapBucket, contract.ProtocolVersion,
+			)
+			if err != nil {
+				return err
+			}
+
 			loop := LoopIn{
 				Loop: Loop{
 					Events: updates,
@@ -457,6 +856,16 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 				Contract: contract,
 			}
 
+			loop.RefundTx, err = getRefundTx(swapBucket)
+			if err != nil {
+				return err
+			}
+
+			loop.Milestones, err = getMilestones(swapBucket)
+			if err != nil {
+				return err
+			}
+
 			loop.Hash, err = lntypes.MakeHash(swapHash)
 			if err != nil {
 				return err
@@ -465,7 +874,27 @@ func (s *boltSwapStore) FetchLoopInSwaps() ([]*LoopIn, error) {
 			swaps = append(swaps, &loop)
 
 			return nil
-		})
+		}
+
+		// If we are looking for the swap that was created with an
+		// idempotency key, we look it up in our index rather than
+		// scanning all of our swaps.
+		if filter != nil && filter.IdempotencyKey != "" {
+			swapHash, rootBucketKey := lookupIdempotencyKey(
+				tx, filter.IdempotencyKey,
+			)
+			if swapHash == nil ||
+				!bytes.Equal(rootBucketKey, loopInBucketKey) {
+
+				return nil
+			}
+
+			return fetchSwap(swapHash, nil)
+		}
+
+		// We'll now traverse the root bucket for all active swaps. The
+		// primary key is the swap hash itself.
+		return rootBucket.ForEach(fetchSwap)
 	})
 	if err != nil {
 		return nil, err
@@ -509,7 +938,7 @@ func (s *boltSwapStore) CreateLoopOut(hash lntypes.Hash,
 	}
 
 	// Otherwise, we'll create a new swap within the database.
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.update(func(tx *bbolt.Tx) error {
 		// Create the swap bucket.
 		swapBucket, err := createLoopBucket(tx, loopOutBucketKey, hash)
 		if err != nil {
@@ -522,6 +951,11 @@ func (s *boltSwapStore) CreateLoopOut(hash lntypes.Hash,
 			return err
 		}
 
+		contractBytes, err = s.encryptContract(contractBytes)
+		if err != nil {
+			return err
+		}
+
 		err = swapBucket.Put(contractKey, contractBytes)
 		if err != nil {
 			return err
@@ -544,11 +978,35 @@ func (s *boltSwapStore) CreateLoopOut(hash lntypes.Hash,
 			return err
 		}
 
+		// Write the balances of our outgoing channels if we have them.
+		err = putChannelBalances(
+			swapBucket, outgoingChanBalancesKey,
+			swap.OutgoingChanBalances,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Write label to disk if we have one.
 		if err := putLabel(swapBucket, swap.Label); err != nil {
 			return err
 		}
 
+		// Write our idempotency key to disk if we have one.
+		err = putIdempotencyKey(swapBucket, swap.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+
+		// Index our idempotency key so that retries of the request
+		// can find this swap, failing if it was already used.
+		err = indexIdempotencyKey(
+			tx, loopOutBucketKey, hash, swap.IdempotencyKey,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Write our confirmation target under its own key.
 		var buf bytes.Buffer
 		err = binary.Write(&buf, byteOrder, swap.HtlcConfirmations)
@@ -561,6 +1019,70 @@ func (s *boltSwapStore) CreateLoopOut(hash lntypes.Hash,
 			return err
 		}
 
+		// Write our payment timeout if the swap overrides the default.
+		if swap.PaymentTimeout != 0 {
+			var timeout bytes.Buffer
+			err := binary.Write(
+				&timeout, byteOrder, swap.PaymentTimeout,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = swapBucket.Put(paymentTimeoutKey, timeout.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		// Write the htlc conf target that we negotiated with the
+		// server if we expressed a preference.
+		if swap.RequestedServerHtlcConfTarget != 0 {
+			var targets bytes.Buffer
+			err := binary.Write(
+				&targets, byteOrder, []int32{
+					swap.RequestedServerHtlcConfTarget,
+					swap.ServerHtlcConfTarget,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = swapBucket.Put(
+				serverConfTargetKey, targets.Bytes(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Write our max payment parts if the swap overrides the
+		// default.
+		if swap.MaxParts != 0 {
+			var parts bytes.Buffer
+			err := binary.Write(&parts, byteOrder, swap.MaxParts)
+			if err != nil {
+				return err
+			}
+
+			err = swapBucket.Put(maxPartsKey, parts.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		// Write our priority if the swap does not have normal
+		// priority.
+		if swap.Priority != PriorityNormal {
+			err := swapBucket.Put(
+				priorityKey, []byte{byte(swap.Priority)},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Store the current protocol version.
 		err = swapBucket.Put(protocolVersionKey,
 			MarshalProtocolVersion(swap.ProtocolVersion),
@@ -569,6 +1091,32 @@ func (s *boltSwapStore) CreateLoopOut(hash lntypes.Hash,
 			return err
 		}
 
+		// Record how our htlc key was derived.
+		err = putKeyDerivation(
+			swapBucket, swap.KeyDerivation, swap.KeyLocator,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Record the script version of the swap's htlc.
+		err = putHtlcVersion(swapBucket, swap.HtlcVersion)
+		if err != nil {
+			return err
+		}
+
+		// If we sweep to an address derived from our xpub, we record
+		// its index and mark it as used. Doing this along with storing
+		// the swap means that swaps that fail to initiate do not use
+		// up indexes.
+		if err := putXpubIndex(swapBucket, swap.DestXpubIndex); err != nil {
+			return err
+		}
+
+		if err := reserveXpubIndex(tx, swap.DestXpubIndex); err != nil {
+			return err
+		}
+
 		// Finally, we'll create an empty updates bucket for this swap
 		// to track any future updates to the swap itself.
 		_, err = swapBucket.CreateBucket(updatesBucketKey)
@@ -589,7 +1137,7 @@ func (s *boltSwapStore) CreateLoopIn(hash lntypes.Hash,
 	}
 
 	// Otherwise, we'll create a new swap within the database.
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.update(func(tx *bbolt.Tx) error {
 		// Create the swap bucket.
 		swapBucket, err := createLoopBucket(tx, loopInBucketKey, hash)
 		if err != nil {
@@ -602,6 +1150,11 @@ func (s *boltSwapStore) CreateLoopIn(hash lntypes.Hash,
 			return err
 		}
 
+		contractBytes, err = s.encryptContract(contractBytes)
+		if err != nil {
+			return err
+		}
+
 		err = swapBucket.Put(contractKey, contractBytes)
 		if err != nil {
 			return err
@@ -615,11 +1168,40 @@ func (s *boltSwapStore) CreateLoopIn(hash lntypes.Hash,
 			return err
 		}
 
+		// Record how our htlc key was derived.
+		err = putKeyDerivation(
+			swapBucket, swap.KeyDerivation, swap.KeyLocator,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Record the script version of the swap's htlc.
+		err = putHtlcVersion(swapBucket, swap.HtlcVersion)
+		if err != nil {
+			return err
+		}
+
 		// Write label to disk if we have one.
 		if err := putLabel(swapBucket, swap.Label); err != nil {
 			return err
 		}
 
+		// Write our idempotency key to disk if we have one.
+		err = putIdempotencyKey(swapBucket, swap.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+
+		// Index our idempotency key so that retries of the request
+		// can find this swap, failing if it was already used.
+		err = indexIdempotencyKey(
+			tx, loopInBucketKey, hash, swap.IdempotencyKey,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Finally, we'll create an empty updates bucket for this swap
 		// to track any future updates to the swap itself.
 		_, err = swapBucket.CreateBucket(updatesBucketKey)
@@ -632,7 +1214,7 @@ func (s *boltSwapStore) CreateLoopIn(hash lntypes.Hash,
 func (s *boltSwapStore) updateLoop(bucketKey []byte, hash lntypes.Hash,
 	time time.Time, state SwapStateData) error {
 
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.update(func(tx *bbolt.Tx) error {
 		// Starting from the root bucket, we'll traverse the bucket
 		// hierarchy all the way down to the swap bucket, and the
 		// update sub-bucket within that.
@@ -649,6 +1231,13 @@ func (s *boltSwapStore) updateLoop(bucketKey []byte, hash lntypes.Hash,
 			return errors.New("udpate bucket not found")
 		}
 
+		// Before we add our update, we look up the swap's current
+		// state so that we can record the transition in our audit log.
+		prevState, err := lastState(updatesBucket)
+		if err != nil {
+			return err
+		}
+
 		// Each update for this swap will get a new monotonically
 		// increasing ID number that we'll obtain now.
 		id, err := updatesBucket.NextSequence()
@@ -682,7 +1271,44 @@ func (s *boltSwapStore) updateLoop(bucketKey []byte, hash lntypes.Hash,
 			}
 		}
 
-		return nil
+		// Write the server's failure reason if it reported one.
+		if state.ServerFailure != ServerFailureNone {
+			err := nextUpdateBucket.Put(
+				serverFailureKey,
+				[]byte{byte(state.ServerFailure)},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Write the reason that we failed the swap for if we have one.
+		if state.FailureReason != FailureReasonNone {
+			err := nextUpdateBucket.Put(
+				failureReasonKey,
+				[]byte{byte(state.FailureReason)},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Finally, we record the state transition in our audit log in
+		// the same transaction, so that the log never misses an
+		// update.
+		swapType := swap.TypeOut
+		if bytes.Equal(bucketKey, loopInBucketKey) {
+			swapType = swap.TypeIn
+		}
+
+		return addAuditEntry(tx, &AuditEntry{
+			Time:          time,
+			SwapHash:      hash,
+			SwapType:      swapType,
+			PreviousState: prevState,
+			State:         state.State,
+			Component:     state.Component,
+		})
 	})
 }
 
@@ -706,6 +1332,33 @@ func (s *boltSwapStore) UpdateLoopIn(hash lntypes.Hash, time time.Time,
 	return s.updateLoop(loopInBucketKey, hash, time, state)
 }
 
+// UpdateLoopOutSweepFee stores the fee rate that the most recent sweep for a
+// loop out swap was published with, overwriting any previous value.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) UpdateLoopOutSweepFee(hash lntypes.Hash,
+	fee SweepFee) error {
+
+	return s.update(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(loopOutBucketKey)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		swapBucket := rootBucket.Bucket(hash[:])
+		if swapBucket == nil {
+			return errors.New("swap not found")
+		}
+
+		feeBytes, err := serializeSweepFee(fee)
+		if err != nil {
+			return err
+		}
+
+		return swapBucket.Put(sweepFeeKey, feeBytes)
+	})
+}
+
 // Close closes the underlying database.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
@@ -0,0 +1,167 @@
+package loopdb
+
+import (
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// benchSwapCount is the number of swaps that are pre-populated in the store
+// for benchmarks that operate on existing swaps.
+const benchSwapCount = 500
+
+// newBenchStore creates a bolt store in a temporary directory and returns it
+// along with a cleanup function.
+func newBenchStore(b *testing.B) (*boltSwapStore, func()) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(b, err)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(b, err)
+
+	return store, func() {
+		require.NoError(b, store.Close())
+		os.RemoveAll(tempDirName)
+	}
+}
+
+// benchLoopOut returns a loop out contract with a unique preimage derived
+// from the index provided.
+func benchLoopOut(b *testing.B, index uint64) (lntypes.Hash,
+	*LoopOutContract) {
+
+	var preimage lntypes.Preimage
+	binary.BigEndian.PutUint64(preimage[:], index)
+
+	initiationTime := time.Unix(0, testTime.UnixNano())
+
+	contract := &LoopOutContract{
+		SwapContract: SwapContract{
+			AmountRequested:  100000,
+			Preimage:         preimage,
+			CltvExpiry:       144,
+			SenderKey:        senderKey,
+			ReceiverKey:      receiverKey,
+			MaxMinerFee:      10,
+			MaxSwapFee:       20,
+			InitiationHeight: 99,
+			InitiationTime:   initiationTime,
+		},
+		MaxPrepayRoutingFee:     40,
+		PrepayInvoice:           "prepayinvoice",
+		DestAddr:                test.GetDestAddr(b, 0),
+		SwapInvoice:             "swapinvoice",
+		MaxSwapRoutingFee:       30,
+		SweepConfTarget:         2,
+		HtlcConfirmations:       2,
+		SwapPublicationDeadline: initiationTime,
+		OutgoingChanSet:         ChannelSet{1, 2},
+	}
+
+	return preimage.Hash(), contract
+}
+
+// populateBenchStore adds the number of loop out swaps provided to the store
+// and returns their hashes.
+func populateBenchStore(b *testing.B, store *boltSwapStore,
+	count int) []lntypes.Hash {
+
+	hashes := make([]lntypes.Hash, count)
+	for i := 0; i < count; i++ {
+		hash, contract := benchLoopOut(b, uint64(i))
+		require.NoError(b, store.CreateLoopOut(hash, contract))
+
+		hashes[i] = hash
+	}
+
+	return hashes
+}
+
+// BenchmarkCreateLoopOut measures the write throughput of loop out creation
+// when swaps are created concurrently, as they are when many swaps are
+// dispatched at once.
+func BenchmarkCreateLoopOut(b *testing.B) {
+	store, cleanup := newBenchStore(b)
+	defer cleanup()
+
+	var index uint64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			hash, contract := benchLoopOut(
+				b, atomic.AddUint64(&index, 1),
+			)
+
+			if err := store.CreateLoopOut(hash, contract); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkUpdateLoopOut measures the throughput of concurrent state updates
+// for a store that contains a set of in-flight swaps.
+func BenchmarkUpdateLoopOut(b *testing.B) {
+	store, cleanup := newBenchStore(b)
+	defer cleanup()
+
+	hashes := populateBenchStore(b, store, benchSwapCount)
+
+	var index uint64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := atomic.AddUint64(&index, 1)
+			hash := hashes[i%uint64(len(hashes))]
+
+			err := store.UpdateLoopOut(
+				hash, testTime, SwapStateData{
+					State: StatePreimageRevealed,
+				},
+			)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkFetchLoopOutSwaps measures the time and memory required to load
+// all loop out swaps from a store with a large number of swaps, which is
+// done on every startup.
+func BenchmarkFetchLoopOutSwaps(b *testing.B) {
+	store, cleanup := newBenchStore(b)
+	defer cleanup()
+
+	populateBenchStore(b, store, benchSwapCount)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		swaps, err := store.FetchLoopOutSwaps(context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if len(swaps) != benchSwapCount {
+			b.Fatalf("expected: %v swaps, got: %v", benchSwapCount,
+				len(swaps))
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package loopdb
 
 import (
+	"context"
 	"crypto/sha256"
 	"io/ioutil"
 	"os"
@@ -12,7 +13,9 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/swap"
 	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/stretchr/testify/require"
@@ -85,12 +88,69 @@ func TestLoopOutStore(t *testing.T) {
 		testLoopOutStore(t, &restrictedSwap)
 	})
 
+	balancesSwap := restrictedSwap
+	balancesSwap.OutgoingChanBalances = ChannelBalances{
+		1: 100000,
+		2: 0,
+	}
+	t.Run("outgoing set with balances", func(t *testing.T) {
+		testLoopOutStore(t, &balancesSwap)
+	})
+
 	labelledSwap := unrestrictedSwap
 	labelledSwap.Label = "test label"
 	t.Run("labelled swap", func(t *testing.T) {
 		testLoopOutStore(t, &labelledSwap)
 	})
 
+	idempotentSwap := unrestrictedSwap
+	idempotentSwap.IdempotencyKey = "request-1"
+	t.Run("swap with idempotency key", func(t *testing.T) {
+		testLoopOutStore(t, &idempotentSwap)
+	})
+
+	timeoutSwap := unrestrictedSwap
+	timeoutSwap.PaymentTimeout = time.Hour
+	t.Run("swap with payment timeout", func(t *testing.T) {
+		testLoopOutStore(t, &timeoutSwap)
+	})
+
+	derivedKeySwap := unrestrictedSwap
+	derivedKeySwap.KeyDerivation = KeyDerivationSwapHash
+	derivedKeySwap.KeyLocator = keychain.KeyLocator{
+		Family: 99,
+		Index:  123456,
+	}
+	t.Run("swap with hash derived key", func(t *testing.T) {
+		testLoopOutStore(t, &derivedKeySwap)
+	})
+
+	confTargetSwap := unrestrictedSwap
+	confTargetSwap.RequestedServerHtlcConfTarget = 50
+	confTargetSwap.ServerHtlcConfTarget = 40
+	t.Run("swap with server conf target", func(t *testing.T) {
+		testLoopOutStore(t, &confTargetSwap)
+	})
+
+	maxPartsSwap := unrestrictedSwap
+	maxPartsSwap.MaxParts = 20
+	t.Run("swap with max parts", func(t *testing.T) {
+		testLoopOutStore(t, &maxPartsSwap)
+	})
+
+	lowPrioritySwap := unrestrictedSwap
+	lowPrioritySwap.Priority = PriorityLow
+	t.Run("swap with low priority", func(t *testing.T) {
+		testLoopOutStore(t, &lowPrioritySwap)
+	})
+
+	htlcV2Swap := unrestrictedSwap
+	htlcV2Swap.ProtocolVersion = ProtocolVersionHtlcV2
+	htlcV2Swap.HtlcVersion = swap.HtlcV2
+	t.Run("swap with htlc v2", func(t *testing.T) {
+		testLoopOutStore(t, &htlcV2Swap)
+	})
+
 }
 
 // testLoopOutStore tests the basic functionality of the current bbolt
@@ -108,7 +168,7 @@ func testLoopOutStore(t *testing.T, pendingSwap *LoopOutContract) {
 	}
 
 	// First, verify that an empty database has no active swaps.
-	swaps, err := store.FetchLoopOutSwaps()
+	swaps, err := store.FetchLoopOutSwaps(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -121,7 +181,7 @@ func testLoopOutStore(t *testing.T, pendingSwap *LoopOutContract) {
 	checkSwap := func(expectedState SwapState) {
 		t.Helper()
 
-		swaps, err := store.FetchLoopOutSwaps()
+		swaps, err := store.FetchLoopOutSwaps(context.Background())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -144,6 +204,23 @@ func testLoopOutStore(t *testing.T, pendingSwap *LoopOutContract) {
 		if expectedState == StatePreimageRevealed {
 			require.NotNil(t, swaps[0].State().HtlcTxHash)
 		}
+
+		// Our failed state records the server's failure reason and
+		// our own reason for failing the swap.
+		var (
+			expectedFailure = ServerFailureNone
+			expectedReason  = FailureReasonNone
+		)
+		if expectedState == StateFailInsufficientValue {
+			expectedFailure = ServerFailureTimeout
+			expectedReason = FailureReasonServerRejected
+		}
+		require.Equal(
+			t, expectedFailure, swaps[0].State().ServerFailure,
+		)
+		require.Equal(
+			t, expectedReason, swaps[0].State().FailureReason,
+		)
 	}
 
 	hash := pendingSwap.Preimage.Hash()
@@ -180,7 +257,9 @@ func testLoopOutStore(t *testing.T, pendingSwap *LoopOutContract) {
 	err = store.UpdateLoopOut(
 		hash, testTime,
 		SwapStateData{
-			State: StateFailInsufficientValue,
+			State:         StateFailInsufficientValue,
+			ServerFailure: ServerFailureTimeout,
+			FailureReason: FailureReasonServerRejected,
 		},
 	)
 	if err != nil {
@@ -239,6 +318,22 @@ func TestLoopInStore(t *testing.T) {
 	t.Run("loop in with label", func(t *testing.T) {
 		testLoopInStore(t, labelledSwap)
 	})
+
+	idempotentSwap := pendingSwap
+	idempotentSwap.IdempotencyKey = "request-1"
+	t.Run("loop in with idempotency key", func(t *testing.T) {
+		testLoopInStore(t, idempotentSwap)
+	})
+
+	derivedKeySwap := pendingSwap
+	derivedKeySwap.KeyDerivation = KeyDerivationSwapHash
+	derivedKeySwap.KeyLocator = keychain.KeyLocator{
+		Family: 99,
+		Index:  123456,
+	}
+	t.Run("loop in with hash derived key", func(t *testing.T) {
+		testLoopInStore(t, derivedKeySwap)
+	})
 }
 
 func testLoopInStore(t *testing.T, pendingSwap LoopInContract) {
@@ -254,7 +349,7 @@ func testLoopInStore(t *testing.T, pendingSwap LoopInContract) {
 	}
 
 	// First, verify that an empty database has no active swaps.
-	swaps, err := store.FetchLoopInSwaps()
+	swaps, err := store.FetchLoopInSwaps(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -267,7 +362,7 @@ func testLoopInStore(t *testing.T, pendingSwap LoopInContract) {
 	checkSwap := func(expectedState SwapState) {
 		t.Helper()
 
-		swaps, err := store.FetchLoopInSwaps()
+		swaps, err := store.FetchLoopInSwaps(context.Background())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -461,7 +556,7 @@ func TestLegacyOutgoingChannel(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	swaps, err := store.FetchLoopOutSwaps()
+	swaps, err := store.FetchLoopOutSwaps(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
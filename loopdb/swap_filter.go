@@ -0,0 +1,104 @@
+package loopdb
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/loop/swap"
+)
+
+// SwapFilter restricts the swaps that are read from the store, so that callers
+// that are only interested in a subset of swaps do not need to load all of
+// them. A nil or empty filter matches all swaps.
+type SwapFilter struct {
+	// States only matches swaps whose latest persisted state is one of the
+	// states provided. Swaps in any state match if it is empty.
+	States []SwapState
+
+	// Label only matches swaps whose label contains this substring.
+	Label string
+
+	// StartTime only matches swaps that were initiated at or after this
+	// time. It is ignored if it is zero.
+	StartTime time.Time
+
+	// EndTime only matches swaps that were initiated before this time. It
+	// is ignored if it is zero.
+	EndTime time.Time
+
+	// IdempotencyKey only matches the swap that was created with this
+	// idempotency key. The store looks this swap up in its index of
+	// idempotency keys rather than scanning all swaps.
+	IdempotencyKey string
+}
+
+// MatchesContract returns a boolean indicating whether a swap contract
+// matches the label, initiation time and idempotency key of our filter. The
+// store checks this before reading the rest of a swap, so that swaps that do
+// not match are skipped cheaply.
+func (f *SwapFilter) MatchesContract(contract *SwapContract) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Label != "" && !strings.Contains(contract.Label, f.Label) {
+		return false
+	}
+
+	if !f.StartTime.IsZero() &&
+		contract.InitiationTime.Before(f.StartTime) {
+
+		return false
+	}
+
+	if !f.EndTime.IsZero() && !contract.InitiationTime.Before(f.EndTime) {
+		return false
+	}
+
+	if f.IdempotencyKey != "" &&
+		contract.IdempotencyKey != f.IdempotencyKey {
+
+		return false
+	}
+
+	return true
+}
+
+// MatchesState returns a boolean indicating whether a swap state matches the
+// states of our filter.
+func (f *SwapFilter) MatchesState(state SwapState) bool {
+	if f == nil || len(f.States) == 0 {
+		return true
+	}
+
+	for _, s := range f.States {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns a boolean indicating whether a swap with the contract and
+// state provided matches our filter.
+func (f *SwapFilter) Matches(contract *SwapContract, state SwapState) bool {
+	return f.MatchesContract(contract) && f.MatchesState(state)
+}
+
+// HasSwapType returns a boolean indicating whether a swap type is in the set
+// of types provided. An empty set contains all swap types, so that callers
+// that filter swaps by type can treat no types as no restriction.
+func HasSwapType(swapTypes []swap.Type, swapType swap.Type) bool {
+	if len(swapTypes) == 0 {
+		return true
+	}
+
+	for _, t := range swapTypes {
+		if t == swapType {
+			return true
+		}
+	}
+
+	return false
+}
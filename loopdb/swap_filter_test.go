@@ -0,0 +1,174 @@
+package loopdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFilterSwaps tests that the store only returns the swaps that match the
+// filter provided.
+func TestFilterSwaps(t *testing.T) {
+	ctx := context.Background()
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	// Create a loop out swap that has succeeded, and a labelled loop out
+	// swap that is still pending, an hour later.
+	loopOut := func(preimage lntypes.Preimage, label string,
+		initiation time.Time) lntypes.Hash {
+
+		hash := preimage.Hash()
+		require.NoError(t, store.CreateLoopOut(hash, &LoopOutContract{
+			SwapContract: SwapContract{
+				AmountRequested: 100,
+				Preimage:        preimage,
+				CltvExpiry:      144,
+				SenderKey:       senderKey,
+				ReceiverKey:     receiverKey,
+				InitiationTime:  initiation,
+				Label:           label,
+			},
+			DestAddr:                test.GetDestAddr(t, 0),
+			SwapInvoice:             "swapinvoice",
+			PrepayInvoice:           "prepayinvoice",
+			SwapPublicationDeadline: initiation,
+		}))
+
+		return hash
+	}
+
+	successHash := loopOut(lntypes.Preimage{1}, "", testTime)
+	require.NoError(t, store.UpdateLoopOut(
+		successHash, testTime, SwapStateData{
+			State: StateSuccess,
+		},
+	))
+
+	laterTime := testTime.Add(time.Hour)
+	pendingHash := loopOut(lntypes.Preimage{2}, "autoloop-out", laterTime)
+
+	// Create a loop in swap that has failed.
+	inPreimage := lntypes.Preimage{3}
+	failedHash := inPreimage.Hash()
+	require.NoError(t, store.CreateLoopIn(failedHash, &LoopInContract{
+		SwapContract: SwapContract{
+			AmountRequested: 100,
+			Preimage:        inPreimage,
+			CltvExpiry:      144,
+			SenderKey:       senderKey,
+			ReceiverKey:     receiverKey,
+			InitiationTime:  testTime,
+			Label:           "autoloop-in",
+		},
+		HtlcConfTarget: 2,
+	}))
+	require.NoError(t, store.UpdateLoopIn(
+		failedHash, testTime, SwapStateData{
+			State: StateFailTimeout,
+		},
+	))
+
+	tests := []struct {
+		name    string
+		filter  *SwapFilter
+		loopOut []lntypes.Hash
+		loopIn  []lntypes.Hash
+	}{
+		{
+			name:    "nil filter",
+			loopOut: []lntypes.Hash{successHash, pendingHash},
+			loopIn:  []lntypes.Hash{failedHash},
+		},
+		{
+			name:    "empty filter",
+			filter:  &SwapFilter{},
+			loopOut: []lntypes.Hash{successHash, pendingHash},
+			loopIn:  []lntypes.Hash{failedHash},
+		},
+		{
+			name: "states",
+			filter: &SwapFilter{
+				States: []SwapState{
+					StateInitiated, StateFailTimeout,
+				},
+			},
+			loopOut: []lntypes.Hash{pendingHash},
+			loopIn:  []lntypes.Hash{failedHash},
+		},
+		{
+			name: "label",
+			filter: &SwapFilter{
+				Label: "autoloop",
+			},
+			loopOut: []lntypes.Hash{pendingHash},
+			loopIn:  []lntypes.Hash{failedHash},
+		},
+		{
+			name: "start time is inclusive",
+			filter: &SwapFilter{
+				StartTime: laterTime,
+			},
+			loopOut: []lntypes.Hash{pendingHash},
+		},
+		{
+			name: "end time is exclusive",
+			filter: &SwapFilter{
+				EndTime: laterTime,
+			},
+			loopOut: []lntypes.Hash{successHash},
+			loopIn:  []lntypes.Hash{failedHash},
+		},
+		{
+			name: "no matches",
+			filter: &SwapFilter{
+				States: []SwapState{StateSuccess},
+				Label:  "autoloop",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			loopOutSwaps, err := store.FilterLoopOutSwaps(
+				ctx, testCase.filter,
+			)
+			require.NoError(t, err)
+
+			var loopOut []lntypes.Hash
+			for _, swp := range loopOutSwaps {
+				loopOut = append(loopOut, swp.Hash)
+			}
+			require.ElementsMatch(t, testCase.loopOut, loopOut)
+
+			loopInSwaps, err := store.FilterLoopInSwaps(
+				ctx, testCase.filter,
+			)
+			require.NoError(t, err)
+
+			var loopIn []lntypes.Hash
+			for _, swp := range loopInSwaps {
+				loopIn = append(loopIn, swp.Hash)
+			}
+			require.ElementsMatch(t, testCase.loopIn, loopIn)
+		})
+	}
+}
+
+// TestHasSwapType tests that an empty set of swap types contains all types.
+func TestHasSwapType(t *testing.T) {
+	require.True(t, HasSwapType(nil, swap.TypeOut))
+	require.True(t, HasSwapType(nil, swap.TypeIn))
+
+	out := []swap.Type{swap.TypeOut}
+	require.True(t, HasSwapType(out, swap.TypeOut))
+	require.False(t, HasSwapType(out, swap.TypeIn))
+}
@@ -0,0 +1,250 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/loop/labels"
+)
+
+// MaxTemplateNameLength is the maximum length of a swap template's name.
+const MaxTemplateNameLength = 64
+
+var (
+	// swapTemplateBucketKey is a bucket that contains our named loop out
+	// templates.
+	//
+	// maps: template name -> serialized template
+	swapTemplateBucketKey = []byte("swap-templates")
+
+	// templateNameRegex matches the names that we allow for templates.
+	templateNameRegex = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+	// ErrSwapTemplateNotFound is returned when a swap template that does
+	// not exist is requested.
+	ErrSwapTemplateNotFound = errors.New("swap template not found")
+
+	// ErrInvalidTemplateName is returned when a swap template's name is
+	// empty, too long or contains characters that we do not allow.
+	ErrInvalidTemplateName = fmt.Errorf("swap template name must be 1-%v "+
+		"letters, digits, dashes or underscores", MaxTemplateNameLength)
+
+	// ErrInvalidTemplateAmount is returned when a swap template does not
+	// have a positive amount.
+	ErrInvalidTemplateAmount = errors.New("swap template amount must be " +
+		"positive")
+)
+
+// SwapTemplate is a named set of loop out parameters that can be reused to
+// dispatch the same swap repeatedly.
+type SwapTemplate struct {
+	// Name is the unique name of the template.
+	Name string
+
+	// Amount is the amount to loop out.
+	Amount btcutil.Amount
+
+	// OutgoingChanSet is the set of channels that the swap may be paid
+	// through. If empty, any channel may be used.
+	OutgoingChanSet ChannelSet
+
+	// MaxSwapRoutingFee is the maximum routing fee for the swap payment.
+	// If zero, a default fee limit based on the quote is used.
+	MaxSwapRoutingFee btcutil.Amount
+
+	// SweepConfTarget is the confirmation target for the swap's sweep. If
+	// zero, the daemon's confirmation policy picks a target.
+	SweepConfTarget int32
+
+	// HtlcConfirmations is the number of confirmations that we require
+	// for the swap's htlc. If zero, the daemon's confirmation policy picks
+	// a value.
+	HtlcConfirmations int32
+
+	// Label is the label that the swap is created with.
+	Label string
+}
+
+// Validate checks that a swap template is valid.
+func (t *SwapTemplate) Validate() error {
+	if len(t.Name) > MaxTemplateNameLength ||
+		!templateNameRegex.MatchString(t.Name) {
+
+		return ErrInvalidTemplateName
+	}
+
+	if t.Amount <= 0 {
+		return ErrInvalidTemplateAmount
+	}
+
+	if _, err := NewChannelSet(t.OutgoingChanSet); err != nil {
+		return err
+	}
+
+	if t.MaxSwapRoutingFee < 0 {
+		return errors.New("swap template routing fee must not be " +
+			"negative")
+	}
+
+	if t.SweepConfTarget < 0 || t.HtlcConfirmations < 0 {
+		return errors.New("swap template confirmation values must " +
+			"not be negative")
+	}
+
+	return labels.Validate(t.Label)
+}
+
+// PutSwapTemplate stores a swap template, replacing any existing template with
+// the same name.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) PutSwapTemplate(template *SwapTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	templateBytes, err := serializeSwapTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(swapTemplateBucketKey)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(template.Name), templateBytes)
+	})
+}
+
+// FetchSwapTemplates returns all of our swap templates. Bolt iterates over keys
+// in byte order, so the templates are ordered by name.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchSwapTemplates() ([]*SwapTemplate, error) {
+	var templates []*SwapTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(swapTemplateBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			template, err := deserializeSwapTemplate(k, v)
+			if err != nil {
+				return err
+			}
+
+			templates = append(templates, template)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// DeleteSwapTemplate deletes the swap template with the name provided, failing
+// with ErrSwapTemplateNotFound if it does not exist.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) DeleteSwapTemplate(name string) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(swapTemplateBucketKey)
+		if bucket == nil || bucket.Get([]byte(name)) == nil {
+			return ErrSwapTemplateNotFound
+		}
+
+		return bucket.Delete([]byte(name))
+	})
+}
+
+// serializeSwapTemplate serializes a swap template. The template's name is
+// stored in its key, and its label is stored last so that it does not need a
+// length prefix.
+func serializeSwapTemplate(template *SwapTemplate) ([]byte, error) {
+	var b bytes.Buffer
+
+	fields := []interface{}{
+		template.Amount,
+		template.MaxSwapRoutingFee,
+		template.SweepConfTarget,
+		template.HtlcConfirmations,
+		uint32(len(template.OutgoingChanSet)),
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(&b, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, chanID := range template.OutgoingChanSet {
+		if err := binary.Write(&b, byteOrder, chanID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := b.WriteString(template.Label); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeSwapTemplate deserializes a swap template from its key and value.
+func deserializeSwapTemplate(key, value []byte) (*SwapTemplate, error) {
+	var (
+		template = SwapTemplate{
+			Name: string(key),
+		}
+		r        = bytes.NewReader(value)
+		numChans uint32
+	)
+
+	fields := []interface{}{
+		&template.Amount,
+		&template.MaxSwapRoutingFee,
+		&template.SweepConfTarget,
+		&template.HtlcConfirmations,
+		&numChans,
+	}
+
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, fmt.Errorf("could not read swap template: "+
+				"%v", err)
+		}
+	}
+
+	for i := uint32(0); i < numChans; i++ {
+		var chanID uint64
+		if err := binary.Read(r, byteOrder, &chanID); err != nil {
+			return nil, fmt.Errorf("could not read swap template "+
+				"channel: %v", err)
+		}
+
+		template.OutgoingChanSet = append(
+			template.OutgoingChanSet, chanID,
+		)
+	}
+
+	label, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	template.Label = string(label)
+
+	return &template, nil
+}
@@ -0,0 +1,77 @@
+package loopdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapTemplateStore tests creation, replacement and deletion of swap
+// templates.
+func TestSwapTemplateStore(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	templates, err := store.FetchSwapTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 0)
+
+	weekly := &SwapTemplate{
+		Name:              "weekly-rebalance",
+		Amount:            500000,
+		OutgoingChanSet:   ChannelSet{1, 2},
+		MaxSwapRoutingFee: 1000,
+		SweepConfTarget:   100,
+		HtlcConfirmations: 3,
+		Label:             "weekly",
+	}
+	daily := &SwapTemplate{
+		Name:   "daily",
+		Amount: 100000,
+	}
+
+	require.NoError(t, store.PutSwapTemplate(weekly))
+	require.NoError(t, store.PutSwapTemplate(daily))
+
+	templates, err = store.FetchSwapTemplates()
+	require.NoError(t, err)
+	require.Equal(t, []*SwapTemplate{daily, weekly}, templates)
+
+	// Replace our weekly template.
+	weekly.Amount = 600000
+	weekly.OutgoingChanSet = nil
+	require.NoError(t, store.PutSwapTemplate(weekly))
+
+	templates, err = store.FetchSwapTemplates()
+	require.NoError(t, err)
+	require.Equal(t, []*SwapTemplate{daily, weekly}, templates)
+
+	// Invalid templates should not be stored.
+	err = store.PutSwapTemplate(&SwapTemplate{
+		Name:   "invalid name",
+		Amount: 100000,
+	})
+	require.Equal(t, ErrInvalidTemplateName, err)
+
+	err = store.PutSwapTemplate(&SwapTemplate{
+		Name: "zero",
+	})
+	require.Equal(t, ErrInvalidTemplateAmount, err)
+
+	require.NoError(t, store.DeleteSwapTemplate(daily.Name))
+	require.Equal(
+		t, ErrSwapTemplateNotFound, store.DeleteSwapTemplate(daily.Name),
+	)
+
+	templates, err = store.FetchSwapTemplates()
+	require.NoError(t, err)
+	require.Equal(t, []*SwapTemplate{weekly}, templates)
+}
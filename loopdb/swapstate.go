@@ -64,6 +64,12 @@ const (
 	// StateFailIncorrectHtlcAmt indicates that the amount of an externally
 	// published loop in htlc didn't match the swap amount.
 	StateFailIncorrectHtlcAmt SwapState = 10
+
+	// StateFailAbandoned indicates that the swap was manually abandoned by
+	// the user because it was permanently stuck. The swap is no longer
+	// executed, so any on-chain funds locked in its htlc need to be
+	// recovered manually.
+	StateFailAbandoned SwapState = 11
 )
 
 // SwapStateType defines the types of swap states that exist. Every swap state
@@ -134,6 +140,9 @@ func (s SwapState) String() string {
 	case StateFailIncorrectHtlcAmt:
 		return "IncorrectHtlcAmt"
 
+	case StateFailAbandoned:
+		return "FailAbandoned"
+
 	default:
 		return "Unknown"
 	}
@@ -166,4 +175,18 @@ type SwapStateData struct {
 
 	// HtlcTxHash is the tx id of the confirmed htlc.
 	HtlcTxHash *chainhash.Hash
+
+	// ServerFailure is the reason that the server reported for the
+	// failure of the swap. It is only recorded with failed states, and
+	// only if the server reported a failure before we failed the swap.
+	ServerFailure ServerFailureReason
+
+	// FailureReason is the reason that we failed the swap for. It is only
+	// recorded with failed states.
+	FailureReason FailureReason
+
+	// Component is the component of the client that triggered the update.
+	// It is only recorded in our audit log, not with the swap's updates,
+	// so it is not set for updates that are read from the store.
+	Component AuditComponent
 }
@@ -0,0 +1,456 @@
+package loopdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// sweepBatchBucketKey is a bucket that contains all the sweep batches
+	// that we have created. This bucket is keyed by the batch id, and
+	// leads to a nested sub-bucket that houses information for that
+	// batch.
+	//
+	// maps: batchID -> batchBucket
+	sweepBatchBucketKey = []byte("sweep-batches")
+
+	// batchInputsKey is the key that stores the htlc outpoints of all the
+	// swaps that are swept in a batch.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchInputsKey
+	//
+	// value: concatenation of swapHash || outpoint
+	batchInputsKey = []byte("inputs")
+
+	// batchTxHashKey is the key that stores the hash of the most recently
+	// published transaction for a batch.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchTxHashKey
+	//
+	// value: tx hash
+	batchTxHashKey = []byte("tx-hash")
+
+	// batchConfirmedKey is the key that is set once the sweep transaction
+	// for a batch has confirmed. If it is not present, the batch is still
+	// pending.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchConfirmedKey
+	//
+	// value: single byte flag
+	batchConfirmedKey = []byte("confirmed")
+
+	// batchFeeKey is the key that stores the fee rate that the most
+	// recent transaction for a batch was published with.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchFeeKey
+	//
+	// value: uint64 fee rate || int32 publish height
+	batchFeeKey = []byte("fee")
+
+	// batchPublishedKey is the key that stores the hashes of the swaps
+	// whose htlcs were included in the most recently published
+	// transaction for a batch.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchPublishedKey
+	//
+	// value: concatenation of swap hashes
+	batchPublishedKey = []byte("published")
+
+	// batchPublishedTxsKey is the key that stores every transaction that
+	// we have published for a batch, along with the swaps whose htlcs it
+	// included. We republish our batch every block, so any one of these
+	// transactions may be the one that confirms.
+	//
+	// path: sweepBatchBucket -> batchBucket[id] -> batchPublishedTxsKey
+	//
+	// value: concatenation of
+	// tx hash || uint32 swap count || swap hashes
+	batchPublishedTxsKey = []byte("published-txs")
+)
+
+// SweepBatch contains the persisted information for a batch sweep transaction
+// that spends the htlcs of multiple loop out swaps.
+type SweepBatch struct {
+	// ID is the unique identifier for the batch. It is assigned by the
+	// store when the batch is first persisted.
+	ID uint64
+
+	// Inputs maps the hash of each swap that is part of the batch to the
+	// outpoint of its htlc.
+	Inputs map[lntypes.Hash]wire.OutPoint
+
+	// TxHash is the hash of the most recently published transaction for
+	// the batch. It is nil if the batch has not been published yet.
+	TxHash *chainhash.Hash
+
+	// Fee is the fee rate that the most recent transaction for the batch
+	// was published with. It is nil if the batch has not been published
+	// yet.
+	Fee *SweepFee
+
+	// Published is the set of swaps whose htlcs were included in the most
+	// recently published transaction for the batch. This may be a subset
+	// of Inputs if some inputs could not be swept economically.
+	Published map[lntypes.Hash]struct{}
+
+	// PublishedTxs maps the hash of every transaction that was published
+	// for the batch to the set of swaps whose htlcs it included.
+	PublishedTxs map[chainhash.Hash]map[lntypes.Hash]struct{}
+
+	// Confirmed indicates whether the sweep transaction for the batch has
+	// confirmed.
+	Confirmed bool
+}
+
+// NewSweepBatch returns an empty sweep batch that has not yet been assigned an
+// id.
+func NewSweepBatch() *SweepBatch {
+	return &SweepBatch{
+		Inputs:    make(map[lntypes.Hash]wire.OutPoint),
+		Published: make(map[lntypes.Hash]struct{}),
+		PublishedTxs: make(
+			map[chainhash.Hash]map[lntypes.Hash]struct{},
+		),
+	}
+}
+
+// PutSweepBatch persists a sweep batch. If the batch does not have an id yet,
+// a new one is assigned and set on the batch that is passed in. Otherwise, the
+// existing batch is overwritten.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) PutSweepBatch(batch *SweepBatch) error {
+	return s.update(func(tx *bbolt.Tx) error {
+		rootBucket, err := tx.CreateBucketIfNotExists(
+			sweepBatchBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		// If we do not have an id for this batch yet, we obtain the
+		// next one in our sequence.
+		id := batch.ID
+		if id == 0 {
+			id, err = rootBucket.NextSequence()
+			if err != nil {
+				return err
+			}
+		}
+
+		batchBucket, err := rootBucket.CreateBucketIfNotExists(itob(id))
+		if err != nil {
+			return err
+		}
+
+		inputBytes, err := serializeBatchInputs(batch.Inputs)
+		if err != nil {
+			return err
+		}
+
+		if err := batchBucket.Put(batchInputsKey, inputBytes); err != nil {
+			return err
+		}
+
+		if batch.TxHash != nil {
+			err := batchBucket.Put(batchTxHashKey, batch.TxHash[:])
+			if err != nil {
+				return err
+			}
+		}
+
+		if batch.Fee != nil {
+			feeBytes, err := serializeSweepFee(*batch.Fee)
+			if err != nil {
+				return err
+			}
+
+			err = batchBucket.Put(batchFeeKey, feeBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = batchBucket.Put(
+			batchPublishedKey, serializeSwapHashes(batch.Published),
+		)
+		if err != nil {
+			return err
+		}
+
+		txBytes, err := serializePublishedTxs(batch.PublishedTxs)
+		if err != nil {
+			return err
+		}
+
+		err = batchBucket.Put(batchPublishedTxsKey, txBytes)
+		if err != nil {
+			return err
+		}
+
+		if batch.Confirmed {
+			err := batchBucket.Put(batchConfirmedKey, []byte{1})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Only set our id once the transaction has no more chance of
+		// failing, so that we do not leave the caller with an id that
+		// was never committed.
+		batch.ID = id
+
+		return nil
+	})
+}
+
+// FetchSweepBatches returns all the sweep batches in the store, ordered by
+// their id.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchSweepBatches() ([]*SweepBatch, error) {
+	var batches []*SweepBatch
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(sweepBatchBucketKey)
+		if rootBucket == nil {
+			return errors.New("bucket does not exist")
+		}
+
+		return rootBucket.ForEach(func(k, v []byte) error {
+			// Only go into things that we know are sub-bucket
+			// keys.
+			if v != nil {
+				return nil
+			}
+
+			batchBucket := rootBucket.Bucket(k)
+			if batchBucket == nil {
+				return fmt.Errorf("batch bucket %x not found", k)
+			}
+
+			inputs, err := deserializeBatchInputs(
+				batchBucket.Get(batchInputsKey),
+			)
+			if err != nil {
+				return err
+			}
+
+			published, err := deserializeSwapHashes(
+				batchBucket.Get(batchPublishedKey),
+			)
+			if err != nil {
+				return err
+			}
+
+			batch := &SweepBatch{
+				ID:        byteOrder.Uint64(k),
+				Inputs:    inputs,
+				Published: published,
+				Confirmed: batchBucket.Get(batchConfirmedKey) != nil,
+			}
+
+			txHashBytes := batchBucket.Get(batchTxHashKey)
+			if txHashBytes != nil {
+				batch.TxHash, err = chainhash.NewHash(txHashBytes)
+				if err != nil {
+					return err
+				}
+			}
+
+			feeBytes := batchBucket.Get(batchFeeKey)
+			if feeBytes != nil {
+				batch.Fee, err = deserializeSweepFee(feeBytes)
+				if err != nil {
+					return err
+				}
+			}
+
+			batch.PublishedTxs, err = deserializePublishedTxs(
+				batchBucket.Get(batchPublishedTxsKey),
+			)
+			if err != nil {
+				return err
+			}
+
+			// Batches that were persisted before we tracked all of
+			// their transactions only know about the most recent
+			// one.
+			if len(batch.PublishedTxs) == 0 && batch.TxHash != nil {
+				batch.PublishedTxs[*batch.TxHash] = published
+			}
+
+			batches = append(batches, batch)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+// serializeBatchInputs serializes the set of inputs in a batch as a
+// concatenation of swap hashes and htlc outpoints.
+func serializeBatchInputs(inputs map[lntypes.Hash]wire.OutPoint) ([]byte,
+	error) {
+
+	var b bytes.Buffer
+	for hash, outpoint := range inputs {
+		if _, err := b.Write(hash[:]); err != nil {
+			return nil, err
+		}
+
+		if _, err := b.Write(outpoint.Hash[:]); err != nil {
+			return nil, err
+		}
+
+		err := binary.Write(&b, byteOrder, outpoint.Index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeBatchInputs deserializes a set of batch inputs.
+func deserializeBatchInputs(value []byte) (map[lntypes.Hash]wire.OutPoint,
+	error) {
+
+	inputs := make(map[lntypes.Hash]wire.OutPoint)
+
+	r := bytes.NewReader(value)
+	for {
+		var hash lntypes.Hash
+		_, err := io.ReadFull(r, hash[:])
+		switch {
+		case err == io.EOF:
+			return inputs, nil
+
+		case err != nil:
+			return nil, err
+		}
+
+		var outpoint wire.OutPoint
+		if _, err := io.ReadFull(r, outpoint.Hash[:]); err != nil {
+			return nil, err
+		}
+
+		err = binary.Read(r, byteOrder, &outpoint.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs[hash] = outpoint
+	}
+}
+
+// serializeSwapHashes serializes a set of swap hashes as their concatenation.
+func serializeSwapHashes(hashes map[lntypes.Hash]struct{}) []byte {
+	b := make([]byte, 0, len(hashes)*lntypes.HashSize)
+	for hash := range hashes {
+		b = append(b, hash[:]...)
+	}
+
+	return b
+}
+
+// deserializeSwapHashes deserializes a set of swap hashes. A nil value, which
+// is stored for batches that were persisted before we tracked their published
+// swaps, results in an empty set.
+func deserializeSwapHashes(value []byte) (map[lntypes.Hash]struct{},
+	error) {
+
+	if len(value)%lntypes.HashSize != 0 {
+		return nil, fmt.Errorf("invalid swap hashes length: %v",
+			len(value))
+	}
+
+	hashes := make(map[lntypes.Hash]struct{}, len(value)/lntypes.HashSize)
+	for i := 0; i < len(value); i += lntypes.HashSize {
+		var hash lntypes.Hash
+		copy(hash[:], value[i:i+lntypes.HashSize])
+
+		hashes[hash] = struct{}{}
+	}
+
+	return hashes, nil
+}
+
+// serializePublishedTxs serializes the transactions that were published for a
+// batch, each followed by the number of swaps it included and their hashes.
+func serializePublishedTxs(
+	txs map[chainhash.Hash]map[lntypes.Hash]struct{}) ([]byte, error) {
+
+	var b bytes.Buffer
+	for txHash, swaps := range txs {
+		if _, err := b.Write(txHash[:]); err != nil {
+			return nil, err
+		}
+
+		err := binary.Write(&b, byteOrder, uint32(len(swaps)))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := b.Write(serializeSwapHashes(swaps)); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializePublishedTxs deserializes the transactions that were published
+// for a batch. A nil value, which is stored for batches that were persisted
+// before we tracked all of their transactions, results in an empty map.
+func deserializePublishedTxs(value []byte) (
+	map[chainhash.Hash]map[lntypes.Hash]struct{}, error) {
+
+	txs := make(map[chainhash.Hash]map[lntypes.Hash]struct{})
+
+	r := bytes.NewReader(value)
+	for {
+		var txHash chainhash.Hash
+		_, err := io.ReadFull(r, txHash[:])
+		switch {
+		case err == io.EOF:
+			return txs, nil
+
+		case err != nil:
+			return nil, err
+		}
+
+		var count uint32
+		if err := binary.Read(r, byteOrder, &count); err != nil {
+			return nil, err
+		}
+
+		if int(count) > r.Len()/lntypes.HashSize {
+			return nil, fmt.Errorf("invalid swap count: %v", count)
+		}
+
+		hashBytes := make([]byte, int(count)*lntypes.HashSize)
+		if _, err := io.ReadFull(r, hashBytes); err != nil {
+			return nil, err
+		}
+
+		swaps, err := deserializeSwapHashes(hashBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		txs[txHash] = swaps
+	}
+}
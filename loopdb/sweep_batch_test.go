@@ -0,0 +1,78 @@
+package loopdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSweepBatchStore tests storing, updating and fetching of sweep batches.
+func TestSweepBatchStore(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	// An empty store should not have any batches.
+	batches, err := store.FetchSweepBatches()
+	require.NoError(t, err)
+	require.Len(t, batches, 0)
+
+	// Create a batch with a single input and assert that it is assigned
+	// an id when we store it.
+	batch := NewSweepBatch()
+	batch.Inputs[lntypes.Hash{1}] = wire.OutPoint{
+		Hash:  chainhash.Hash{2},
+		Index: 1,
+	}
+
+	require.NoError(t, store.PutSweepBatch(batch))
+	require.Equal(t, uint64(1), batch.ID)
+
+	batches, err = store.FetchSweepBatches()
+	require.NoError(t, err)
+	require.Equal(t, []*SweepBatch{batch}, batches)
+
+	// Add another input to our batch, set a tx hash, record the swaps
+	// that it and an earlier transaction were published with and mark
+	// it as confirmed. We expect our
+	// existing batch to be updated.
+	batch.Inputs[lntypes.Hash{3}] = wire.OutPoint{
+		Hash:  chainhash.Hash{4},
+		Index: 0,
+	}
+	batch.TxHash = &chainhash.Hash{5}
+	batch.Fee = &SweepFee{
+		FeeRate:       2500,
+		PublishHeight: 100,
+	}
+	batch.Published[lntypes.Hash{1}] = struct{}{}
+	batch.PublishedTxs[chainhash.Hash{6}] = map[lntypes.Hash]struct{}{
+		{1}: {},
+		{3}: {},
+	}
+	batch.PublishedTxs[chainhash.Hash{5}] = map[lntypes.Hash]struct{}{
+		{1}: {},
+	}
+	batch.Confirmed = true
+
+	require.NoError(t, store.PutSweepBatch(batch))
+	require.Equal(t, uint64(1), batch.ID)
+
+	// Finally, add a second, empty batch.
+	secondBatch := NewSweepBatch()
+	require.NoError(t, store.PutSweepBatch(secondBatch))
+	require.Equal(t, uint64(2), secondBatch.ID)
+
+	batches, err = store.FetchSweepBatches()
+	require.NoError(t, err)
+	require.Equal(t, []*SweepBatch{batch, secondBatch}, batches)
+}
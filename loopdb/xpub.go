@@ -0,0 +1,130 @@
+package loopdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// xpubIndexBucketKey is a bucket that tracks the next unused
+	// derivation index for each of the extended public keys that loop out
+	// sweep addresses have been derived from.
+	//
+	// maps: xpub -> uint32 next index
+	xpubIndexBucketKey = []byte("xpub-indexes")
+
+	// destXpubIndexKey is the key that stores the derivation index of the
+	// extended public key that a loop out's destination address was
+	// derived from. It is only set for swaps that sweep to our xpub.
+	//
+	// path: loopOutBucket -> swapBucket[hash] -> destXpubIndexKey
+	//
+	// value: uint32 index || xpub
+	destXpubIndexKey = []byte("dest-xpub-index")
+
+	// ErrXpubIndexUsed is returned when a swap is created with a
+	// derivation index that was already used for another swap.
+	ErrXpubIndexUsed = errors.New("xpub derivation index already used")
+)
+
+// XpubIndex identifies the address that was derived from an extended public
+// key at a derivation index.
+type XpubIndex struct {
+	// Xpub is the extended public key that the address was derived from.
+	Xpub string
+
+	// Index is the derivation index of the address.
+	Index uint32
+}
+
+// FetchXpubIndex returns the next unused derivation index for the extended
+// public key provided. The index is only marked as used once a swap that
+// sweeps to it is created, so that swaps that fail to initiate do not use up
+// indexes.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *boltSwapStore) FetchXpubIndex(xpub string) (uint32, error) {
+	var index uint32
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(xpubIndexBucketKey)
+		if rootBucket == nil {
+			return nil
+		}
+
+		// If we have not derived from this key before, we start at
+		// index zero.
+		key := []byte(xpub)
+		if indexBytes := rootBucket.Get(key); indexBytes != nil {
+			index = byteOrder.Uint32(indexBytes)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// reserveXpubIndex marks the derivation index provided as used, so that it is
+// never handed out again. It fails with ErrXpubIndexUsed if the index, or an
+// index after it, has already been used.
+func reserveXpubIndex(tx *bbolt.Tx, index *XpubIndex) error {
+	if index == nil {
+		return nil
+	}
+
+	rootBucket, err := tx.CreateBucketIfNotExists(xpubIndexBucketKey)
+	if err != nil {
+		return err
+	}
+
+	key := []byte(index.Xpub)
+	if indexBytes := rootBucket.Get(key); indexBytes != nil {
+		if byteOrder.Uint32(indexBytes) > index.Index {
+			return ErrXpubIndexUsed
+		}
+	}
+
+	var next [4]byte
+	byteOrder.PutUint32(next[:], index.Index+1)
+
+	return rootBucket.Put(key, next[:])
+}
+
+// putXpubIndex stores the derivation index that a swap's destination address
+// was derived from, if it has one.
+func putXpubIndex(bucket *bbolt.Bucket, index *XpubIndex) error {
+	if index == nil {
+		return nil
+	}
+
+	value := make([]byte, 4, 4+len(index.Xpub))
+	byteOrder.PutUint32(value, index.Index)
+	value = append(value, index.Xpub...)
+
+	return bucket.Put(destXpubIndexKey, value)
+}
+
+// getXpubIndex gets the derivation index that a swap's destination address was
+// derived from. If it is not present, nil is returned.
+func getXpubIndex(bucket *bbolt.Bucket) (*XpubIndex, error) {
+	value := bucket.Get(destXpubIndexKey)
+	if value == nil {
+		return nil, nil
+	}
+
+	if len(value) < 4 {
+		return nil, fmt.Errorf("invalid xpub index length: %v",
+			len(value))
+	}
+
+	return &XpubIndex{
+		Xpub:  string(value[4:]),
+		Index: byteOrder.Uint32(value[:4]),
+	}, nil
+}
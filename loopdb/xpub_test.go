@@ -0,0 +1,90 @@
+package loopdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestXpubIndex tests that derivation indexes are only marked as used once a
+// swap that sweeps to them is stored, that they are tracked separately per
+// extended public key and that an index cannot be used twice.
+func TestXpubIndex(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "clientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDirName)
+
+	store, err := NewBoltSwapStore(tempDirName, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	defer store.Close()
+
+	createSwap := func(preimage lntypes.Preimage,
+		index *XpubIndex) error {
+
+		return store.CreateLoopOut(preimage.Hash(), &LoopOutContract{
+			SwapContract: SwapContract{
+				AmountRequested: 100,
+				Preimage:        preimage,
+				CltvExpiry:      144,
+				SenderKey:       senderKey,
+				ReceiverKey:     receiverKey,
+				InitiationTime:  testTime,
+			},
+			DestAddr:                test.GetDestAddr(t, 0),
+			SwapInvoice:             "swapinvoice",
+			PrepayInvoice:           "prepayinvoice",
+			SwapPublicationDeadline: testTime,
+			DestXpubIndex:           index,
+		})
+	}
+
+	assertNext := func(xpub string, expected uint32) {
+		t.Helper()
+
+		index, err := store.FetchXpubIndex(xpub)
+		require.NoError(t, err)
+		require.Equal(t, expected, index)
+	}
+
+	// Fetching our next index does not mark it as used.
+	assertNext("xpub1", 0)
+	assertNext("xpub1", 0)
+
+	// Once a swap that sweeps to the index is stored, the next index is
+	// handed out.
+	first := &XpubIndex{Xpub: "xpub1", Index: 0}
+	require.NoError(t, createSwap(lntypes.Preimage{1}, first))
+	assertNext("xpub1", 1)
+	assertNext("xpub2", 0)
+
+	// A swap that does not sweep to our xpub does not affect our index.
+	require.NoError(t, createSwap(lntypes.Preimage{2}, nil))
+	assertNext("xpub1", 1)
+
+	// An index that was already used cannot be used again, and the swap
+	// is not stored.
+	reused := lntypes.Preimage{3}
+	err = createSwap(reused, &XpubIndex{Xpub: "xpub1", Index: 0})
+	require.Equal(t, ErrXpubIndexUsed, err)
+
+	swaps, err := store.FetchLoopOutSwaps(context.Background())
+	require.NoError(t, err)
+	require.Len(t, swaps, 2)
+
+	// The index that a swap's address was derived from is stored with
+	// the swap.
+	for _, swap := range swaps {
+		if swap.Hash == (lntypes.Preimage{1}).Hash() {
+			require.Equal(t, first, swap.Contract.DestXpubIndex)
+			continue
+		}
+
+		require.Nil(t, swap.Contract.DestXpubIndex)
+	}
+}
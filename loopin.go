@@ -2,12 +2,11 @@ package loop
 
 import (
 	"context"
-	"crypto/rand"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
-	"time"
 
 	"github.com/btcsuite/btcutil"
 
@@ -18,6 +17,7 @@ import (
 	"github.com/lightninglabs/loop/labels"
 	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/sweep"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
@@ -45,8 +45,45 @@ var (
 	// TimeoutTxConfTarget defines the confirmation target for the loop in
 	// timeout tx.
 	TimeoutTxConfTarget = int32(2)
+
+	// SmallLoopInAmount is the swap amount below which loop in swaps that
+	// do not specify an htlc confirmation target use the relaxed
+	// SmallLoopInHtlcConfTarget, because on-chain fees make up a larger
+	// share of the value of small swaps.
+	SmallLoopInAmount = btcutil.Amount(250000)
+
+	// SmallLoopInHtlcConfTarget is the default htlc confirmation target
+	// for loop in swaps below SmallLoopInAmount.
+	SmallLoopInHtlcConfTarget = int32(12)
+
+	// LargeLoopInAmount is the swap amount at or above which loop in swaps
+	// that do not specify an htlc confirmation target use the faster
+	// LargeLoopInHtlcConfTarget, so that large amounts are not left
+	// waiting on-chain.
+	LargeLoopInAmount = btcutil.Amount(5000000)
+
+	// LargeLoopInHtlcConfTarget is the default htlc confirmation target
+	// for loop in swaps of at least LargeLoopInAmount.
+	LargeLoopInHtlcConfTarget = int32(3)
 )
 
+// DefaultLoopInHtlcConfTarget returns the default confirmation target for
+// the on-chain htlc of a loop in swap of the amount provided. Small swaps
+// use a slower, cheaper target and large swaps a faster one. Swaps between
+// the two thresholds use DefaultHtlcConfTarget.
+func DefaultLoopInHtlcConfTarget(amount btcutil.Amount) int32 {
+	switch {
+	case amount < SmallLoopInAmount:
+		return SmallLoopInHtlcConfTarget
+
+	case amount >= LargeLoopInAmount:
+		return LargeLoopInHtlcConfTarget
+
+	default:
+		return DefaultHtlcConfTarget
+	}
+}
+
 // loopInSwap contains all the in-memory state related to a pending loop in
 // swap.
 type loopInSwap struct {
@@ -67,6 +104,10 @@ type loopInSwap struct {
 
 	timeoutAddr btcutil.Address
 
+	// refundTx is the most recent refund of our htlc that we published.
+	// It is nil if we have not published a refund.
+	refundTx *loopdb.RefundTx
+
 	wg sync.WaitGroup
 }
 
@@ -81,6 +122,10 @@ func newLoopInSwap(globalCtx context.Context, cfg *swapConfig,
 	currentHeight int32, request *LoopInRequest) (*loopInInitResult,
 	error) {
 
+	if request.Private && len(request.RouteHints) != 0 {
+		return nil, ErrPrivateRouteHints
+	}
+
 	// Request current server loop in terms and use these to calculate the
 	// swap fee that we should subtract from the swap amount in the payment
 	// request that we send to the server.
@@ -105,28 +150,42 @@ func newLoopInSwap(globalCtx context.Context, cfg *swapConfig,
 
 	// Generate random preimage.
 	var swapPreimage lntypes.Preimage
-	if _, err := rand.Read(swapPreimage[:]); err != nil {
+	if _, err := io.ReadFull(cfg.rand, swapPreimage[:]); err != nil {
 		log.Error("Cannot generate preimage")
 	}
 	swapHash := lntypes.Hash(sha256.Sum256(swapPreimage[:]))
 
-	// Derive a sender key for this swap.
-	keyDesc, err := cfg.lnd.WalletKit.DeriveNextKey(
-		globalCtx, swap.KeyFamily,
-	)
+	// Derive a sender key for this swap at an index determined by the
+	// swap hash, so that it can be recovered from our seed.
+	keyLocator := swap.KeyLocatorFromHash(swapHash)
+	keyDesc, err := cfg.lnd.WalletKit.DeriveKey(globalCtx, &keyLocator)
 	if err != nil {
 		return nil, err
 	}
 	var senderKey [33]byte
 	copy(senderKey[:], keyDesc.PubKey.SerializeCompressed())
 
+	// If our swap should be routed to us over our private channels, we
+	// select hop hints for them so that the server can find a route.
+	routeHints := request.RouteHints
+	if request.Private {
+		routeHints, err = selectHopHints(
+			globalCtx, cfg.lnd.Client, swapInvoiceAmt,
+			request.LastHop,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the swap invoice in lnd.
 	_, swapInvoice, err := cfg.lnd.Client.AddInvoice(
 		globalCtx, &invoicesrpc.AddInvoiceData{
-			Preimage: &swapPreimage,
-			Value:    lnwire.NewMSatFromSatoshis(swapInvoiceAmt),
-			Memo:     "swap",
-			Expiry:   3600 * 24 * 365,
+			Preimage:   &swapPreimage,
+			Value:      lnwire.NewMSatFromSatoshis(swapInvoiceAmt),
+			Memo:       "swap",
+			Expiry:     3600 * 24 * 365,
+			RouteHints: routeHints,
 		},
 	)
 	if err != nil {
@@ -142,10 +201,11 @@ func newLoopInSwap(globalCtx context.Context, cfg *swapConfig,
 	log.Infof("Creating probe invoice %v", probeHash)
 	probeInvoice, err := cfg.lnd.Invoices.AddHoldInvoice(
 		globalCtx, &invoicesrpc.AddInvoiceData{
-			Hash:   &probeHash,
-			Value:  lnwire.NewMSatFromSatoshis(swapInvoiceAmt),
-			Memo:   "loop in probe",
-			Expiry: 3600,
+			Hash:       &probeHash,
+			Value:      lnwire.NewMSatFromSatoshis(swapInvoiceAmt),
+			Memo:       "loop in probe",
+			Expiry:     3600,
+			RouteHints: routeHints,
 		},
 	)
 	if err != nil {
@@ -191,7 +251,7 @@ func newLoopInSwap(globalCtx context.Context, cfg *swapConfig,
 
 	// Instantiate a struct that contains all required data to start the
 	// swap.
-	initiationTime := time.Now()
+	initiationTime := cfg.clock.Now()
 
 	contract := loopdb.LoopInContract{
 		HtlcConfTarget: request.HtlcConfTarget,
@@ -208,7 +268,13 @@ func newLoopInSwap(globalCtx context.Context, cfg *swapConfig,
 			MaxMinerFee:      request.MaxMinerFee,
 			MaxSwapFee:       request.MaxSwapFee,
 			Label:            request.Label,
+			IdempotencyKey:   request.IdempotencyKey,
 			ProtocolVersion:  loopdb.CurrentInternalProtocolVersion,
+			HtlcVersion: GetHtlcScriptVersion(
+				loopdb.CurrentInternalProtocolVersion,
+			),
+			KeyDerivation: loopdb.KeyDerivationSwapHash,
+			KeyLocator:    keyLocator,
 		},
 	}
 
@@ -328,6 +394,7 @@ func resumeLoopInSwap(_ context.Context, cfg *swapConfig,
 	swap := &loopInSwap{
 		LoopInContract: *pend.Contract,
 		swapKit:        *swapKit,
+		refundTx:       pend.RefundTx,
 	}
 
 	if err := swap.initHtlcs(); err != nil {
@@ -394,6 +461,8 @@ func (s *loopInSwap) sendUpdate(ctx context.Context) error {
 	info.HtlcAddressP2WSH = s.htlcP2WSH.Address
 	info.HtlcAddressNP2WSH = s.htlcNP2WSH.Address
 	info.ExternalHtlc = s.ExternalHtlc
+	info.HtlcConfTarget = s.HtlcConfTarget
+	info.RefundTx = s.refundTx
 
 	select {
 	case s.statusChan <- *info:
@@ -425,6 +494,7 @@ func (s *loopInSwap) execute(mainCtx context.Context,
 		defer s.wg.Done()
 		subscribeAndLogUpdates(
 			subCtx, s.hash, s.log, s.server.SubscribeLoopInUpdates,
+			s.setServerFailure,
 		)
 	}()
 
@@ -504,6 +574,8 @@ func (s *loopInSwap) executeSwap(globalCtx context.Context) error {
 		return err
 	}
 
+	s.recordMilestone(loopdb.MilestoneHtlcConfirmed)
+
 	// Determine the htlc outpoint by inspecting the htlc tx.
 	htlcOutpoint, htlcValue, err := swap.GetScriptOutput(
 		conf.Tx, s.htlc.PkScript,
@@ -632,6 +704,8 @@ func (s *loopInSwap) publishOnChainHtlc(ctx context.Context) (bool, error) {
 	// Verify whether it still makes sense to publish the htlc.
 	if blocksRemaining < MinLoopInPublishDelta {
 		s.setState(loopdb.StateFailTimeout)
+		s.failure = loopdb.FailureReasonHtlcTimeout
+
 		return false, s.persistAndAnnounceState(ctx)
 	}
 
@@ -651,6 +725,8 @@ func (s *loopInSwap) publishOnChainHtlc(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	s.recordMilestone(loopdb.MilestoneHtlcPublished)
+
 	s.log.Infof("Publishing on chain HTLC with fee rate %v", feeRate)
 
 	// Internal loop-in is always P2WSH.
@@ -658,12 +734,16 @@ func (s *loopInSwap) publishOnChainHtlc(ctx context.Context) (bool, error) {
 		ctx, []*wire.TxOut{{
 			PkScript: s.htlcP2WSH.PkScript,
 			Value:    int64(s.LoopInContract.AmountRequested),
-		}}, feeRate, labels.LoopInHtlcLabel(swap.ShortHash(&s.hash)),
+		}}, feeRate, s.sweeper.TxLabel(
+			labels.LoopInHtlcLabel(swap.ShortHash(&s.hash)),
+		),
 	)
 	if err != nil {
 		return false, fmt.Errorf("send outputs: %v", err)
 	}
 
+	s.setPhase(loopdb.PhaseAwaitingConf)
+
 	txHash := tx.TxHash()
 	fee := getTxFee(tx, feeRate.FeePerKVByte())
 
@@ -682,7 +762,7 @@ func (s *loopInSwap) publishOnChainHtlc(ctx context.Context) (bool, error) {
 	// the fee for publishing the htlc.
 	s.cost.Onchain = fee
 
-	s.lastUpdateTime = time.Now()
+	s.lastUpdateTime = s.clock.Now()
 	if err := s.persistState(); err != nil {
 		return false, fmt.Errorf("persist htlc tx: %v", err)
 	}
@@ -811,6 +891,9 @@ func (s *loopInSwap) waitForSwapComplete(ctx context.Context,
 			// Swap invoice was paid, so update server cost balance.
 			case channeldb.ContractSettled:
 				s.cost.Server -= update.AmtPaid
+				s.recordMilestone(
+					loopdb.MilestonePaymentSettled,
+				)
 
 				// If invoice settlement and htlc spend happen
 				// in the expected order, move the swap to an
@@ -851,6 +934,7 @@ func (s *loopInSwap) processHtlcSpend(ctx context.Context,
 	htlcInput := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
 
 	if s.htlc.IsSuccessWitness(htlcInput.Witness) {
+		s.recordMilestone(loopdb.MilestoneSweepConfirmed)
 		s.setState(loopdb.StateSuccess)
 
 		// Server swept the htlc. The htlc value can be added to the
@@ -858,9 +942,16 @@ func (s *loopInSwap) processHtlcSpend(ctx context.Context,
 		s.cost.Server += htlcValue
 	} else {
 		// We needed another on chain tx to sweep the timeout clause,
-		// which we now include in our costs.
+		// which we now include in our costs. If the refund that we
+		// persisted confirmed, we use its fee, because our latest
+		// attempt to replace it may not have been published.
+		spender := spend.SpendingTx.TxHash()
+		if s.refundTx != nil && s.refundTx.TxHash == spender {
+			sweepFee = s.refundTx.Fee
+		}
 		s.cost.Onchain += sweepFee
 		s.setState(loopdb.StateFailTimeout)
+		s.failure = loopdb.FailureReasonHtlcTimeout
 
 		// Now that the timeout tx confirmed, we can safely cancel the
 		// swap invoice. We still need to query the final invoice state.
@@ -879,7 +970,8 @@ func (s *loopInSwap) processHtlcSpend(ctx context.Context,
 // publishTimeoutTx publishes a timeout tx after the on-chain htlc has expired,
 // returning the fee that is paid by the sweep tx. We cannot update our swap
 // costs in this function because it is called multiple times. The swap failed
-// and we are reclaiming our funds.
+// and we are reclaiming our funds. If a previous refund has not confirmed
+// within our fee bump interval, it is replaced with a higher fee rate.
 func (s *loopInSwap) publishTimeoutTx(ctx context.Context,
 	htlcOutpoint *wire.OutPoint, htlcValue btcutil.Amount) (btcutil.Amount,
 	error) {
@@ -892,15 +984,39 @@ func (s *loopInSwap) publishTimeoutTx(ctx context.Context,
 		}
 	}
 
+	confTarget := s.refundConfTarget
+	if confTarget == 0 {
+		confTarget = TimeoutTxConfTarget
+	}
+
 	// Calculate sweep tx fee
-	fee, err := s.sweeper.GetSweepFee(
-		ctx, s.htlc.AddTimeoutToEstimator, s.timeoutAddr,
-		TimeoutTxConfTarget,
+	fee, feeRate, weight, err := s.sweeper.GetSweepFeeDetails(
+		ctx, s.htlc.AddTimeoutToEstimator, s.timeoutAddr, confTarget,
 	)
 	if err != nil {
 		return 0, err
 	}
 
+	// If we have already published a refund, we make sure that we don't
+	// lower our fee rate, and bump it if our refund has not confirmed in
+	// time so that our new refund replaces it.
+	if s.refundTx != nil {
+		feeRate = sweep.BumpFeeRate(
+			feeRate, s.refundTx.FeeRate,
+			s.height-s.refundTx.PublishHeight, s.sweepFeeBumpBlocks,
+		)
+		fee = feeRate.FeeForWeight(weight)
+	}
+
+	// We always need to reclaim our funds, so if the required fee exceeds
+	// our maximum, we still publish our refund with the maximum fee.
+	if s.refundMaxFee != 0 && fee > s.refundMaxFee {
+		s.log.Warnf("Required refund fee %v exceeds max refund fee "+
+			"of %v", fee, s.refundMaxFee)
+
+		fee = s.refundMaxFee
+	}
+
 	witnessFunc := func(sig []byte) (wire.TxWitness, error) {
 		return s.htlc.GenTimeoutWitness(sig), nil
 	}
@@ -908,7 +1024,7 @@ func (s *loopInSwap) publishTimeoutTx(ctx context.Context,
 	sequence := uint32(0)
 	timeoutTx, err := s.sweeper.CreateSweepTx(
 		ctx, s.height, sequence, s.htlc, *htlcOutpoint, s.SenderKey,
-		witnessFunc, htlcValue, fee, s.timeoutAddr,
+		s.KeyLocator, witnessFunc, htlcValue, fee, s.timeoutAddr,
 	)
 	if err != nil {
 		return 0, err
@@ -919,11 +1035,44 @@ func (s *loopInSwap) publishTimeoutTx(ctx context.Context,
 		timeoutTxHash, fee, s.timeoutAddr)
 
 	err = s.lnd.WalletKit.PublishTransaction(
-		ctx, timeoutTx,
-		labels.LoopInSweepTimeout(swap.ShortHash(&s.hash)),
+		ctx, timeoutTx, s.sweeper.TxLabel(
+			labels.LoopInSweepTimeout(swap.ShortHash(&s.hash)),
+		),
 	)
 	if err != nil {
 		s.log.Warnf("publish timeout: %v", err)
+
+		return fee, nil
+	}
+
+	s.setPhase(loopdb.PhaseSweepPublished)
+
+	// If we published a new refund, we persist it so that we can report
+	// it and resume our fee bumping schedule after restart. Our publish
+	// height is only reset when our fee rate changes.
+	if s.refundTx == nil || s.refundTx.TxHash != timeoutTxHash {
+		publishHeight := s.height
+		if s.refundTx != nil && s.refundTx.FeeRate == feeRate {
+			publishHeight = s.refundTx.PublishHeight
+		}
+
+		refundTx := loopdb.RefundTx{
+			TxHash:        timeoutTxHash,
+			FeeRate:       feeRate,
+			Fee:           fee,
+			PublishHeight: publishHeight,
+		}
+
+		err := s.store.UpdateLoopInRefundTx(s.hash, refundTx)
+		if err != nil {
+			return 0, err
+		}
+
+		s.refundTx = &refundTx
+
+		if err := s.sendUpdate(ctx); err != nil {
+			return 0, err
+		}
 	}
 
 	return fee, nil
@@ -946,15 +1095,18 @@ func (s *loopInSwap) persistState() error {
 	return s.store.UpdateLoopIn(
 		s.hash, s.lastUpdateTime,
 		loopdb.SwapStateData{
-			State:      s.state,
-			Cost:       s.cost,
-			HtlcTxHash: s.htlcTxHash,
+			State:         s.state,
+			Cost:          s.cost,
+			HtlcTxHash:    s.htlcTxHash,
+			ServerFailure: s.serverFailureReason(),
+			FailureReason: s.failureReason(),
+			Component:     loopdb.AuditComponentLoopIn,
 		},
 	)
 }
 
 // setState updates the swap state and last update timestamp.
 func (s *loopInSwap) setState(state loopdb.SwapState) {
-	s.lastUpdateTime = time.Now()
+	s.lastUpdateTime = s.clock.Now()
 	s.state = state
 }
@@ -0,0 +1,82 @@
+package loop
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/psbt"
+)
+
+// LoopInPsbtInfo contains the information required to fund a loop in from an
+// external wallet.
+type LoopInPsbtInfo struct {
+	*LoopInSwapInfo
+
+	// Amount is the amount that the htlc must be funded with.
+	Amount btcutil.Amount
+
+	// Psbt is an unsigned funding template with a single output that pays
+	// the swap amount to the native segwit htlc address. It has no
+	// inputs, so that the external wallet can add its own inputs and
+	// change before signing and broadcasting it.
+	Psbt *psbt.Packet
+}
+
+// LoopInPsbt initiates a loop in swap that is funded by an external wallet,
+// and returns a psbt template that funds the swap's htlc. Once the external
+// wallet has broadcast the funding transaction, the swap picks it up through
+// its regular htlc confirmation flow.
+func (s *Client) LoopInPsbt(globalCtx context.Context,
+	request *LoopInRequest) (*LoopInPsbtInfo, error) {
+
+	// Our htlc is published externally, so we copy our request rather
+	// than altering the caller's.
+	externalRequest := *request
+	externalRequest.ExternalHtlc = true
+
+	swapInfo, err := s.LoopIn(globalCtx, &externalRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := fundingPsbt(swapInfo.HtlcAddressP2WSH, request.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoopInPsbtInfo{
+		LoopInSwapInfo: swapInfo,
+		Amount:         request.Amount,
+		Psbt:           packet,
+	}, nil
+}
+
+// fundingPsbt creates an unsigned psbt without inputs that pays the amount
+// provided to an htlc address.
+func fundingPsbt(htlcAddr btcutil.Address,
+	amount btcutil.Amount) (*psbt.Packet, error) {
+
+	pkScript, err := txscript.PayToAddrScript(htlcAddr)
+	if err != nil {
+		return nil, fmt.Errorf("htlc pkscript: %v", err)
+	}
+
+	return psbt.New(
+		nil, []*wire.TxOut{wire.NewTxOut(int64(amount), pkScript)}, 2,
+		0, nil,
+	)
+}
+
+// SerializePsbt returns the binary serialization of a psbt.
+func SerializePsbt(packet *psbt.Packet) ([]byte, error) {
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
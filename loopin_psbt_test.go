@@ -0,0 +1,41 @@
+package loop
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/psbt"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFundingPsbt tests creation of the psbt template that external wallets
+// use to fund a loop in htlc.
+func TestFundingPsbt(t *testing.T) {
+	htlcAddr, err := btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	packet, err := fundingPsbt(htlcAddr, 100000)
+	require.NoError(t, err)
+
+	serialized, err := SerializePsbt(packet)
+	require.NoError(t, err)
+
+	// The template should survive a round trip, and contain a single
+	// output that pays our amount to the htlc without any inputs.
+	decoded, err := psbt.NewFromRawBytes(bytes.NewReader(serialized), false)
+	require.NoError(t, err)
+
+	tx := decoded.UnsignedTx
+	require.Len(t, tx.TxIn, 0)
+	require.Len(t, tx.TxOut, 1)
+	require.EqualValues(t, 100000, tx.TxOut[0].Value)
+
+	pkScript, err := txscript.PayToAddrScript(htlcAddr)
+	require.NoError(t, err)
+	require.Equal(t, pkScript, tx.TxOut[0].PkScript)
+}
@@ -274,6 +274,15 @@ func testLoopInTimeout(t *testing.T,
 	require.NoError(t, err)
 	cost.Onchain += fee
 
+	// Publishing our refund is announced, and the refund is persisted so
+	// that we can continue to bump its fee after restart.
+	ctx.assertState(loopdb.StateHtlcPublished)
+	refund, ok := ctx.store.refundTxs[s.hash]
+	require.True(t, ok)
+	require.Equal(t, timeoutTx.TxHash(), refund.TxHash)
+	require.Equal(t, fee, refund.Fee)
+	require.Equal(t, s.LoopInContract.CltvExpiry, refund.PublishHeight)
+
 	// Confirm timeout tx.
 	ctx.lnd.SpendChannel <- &chainntnfs.SpendDetail{
 		SpendingTx:        timeoutTx,
@@ -360,6 +369,7 @@ func testLoopInResume(t *testing.T, state loopdb.SwapState, expired bool,
 			MaxSwapFee:      60000,
 			MaxMinerFee:     50000,
 			ProtocolVersion: storedVersion,
+			HtlcVersion:     scriptVersion,
 		},
 	}
 	pendSwap := &loopdb.LoopIn{
@@ -2,14 +2,15 @@ package loop
 
 import (
 	"context"
-	"crypto/rand"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
@@ -22,6 +23,7 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/zpay32"
 )
 
@@ -34,8 +36,8 @@ import (
 const loopInternalHops = 2
 
 var (
-	// MinLoopOutPreimageRevealDelta configures the minimum number of
-	// remaining blocks before htlc expiry required to reveal preimage.
+	// MinLoopOutPreimageRevealDelta configures the default minimum number
+	// of remaining blocks before htlc expiry required to reveal preimage.
 	MinLoopOutPreimageRevealDelta int32 = 20
 
 	// DefaultSweepConfTarget is the default confirmation target we'll use
@@ -53,9 +55,13 @@ var (
 	// TODO(wilmer): tune?
 	DefaultSweepConfTargetDelta = DefaultSweepConfTarget * 2
 
-	// paymentTimeout is the timeout for the loop out payment loop as
-	// communicated to lnd.
-	paymentTimeout = time.Minute * 30
+	// UrgentSweepConfTarget is the maximum confirmation target that we
+	// sweep the htlcs of urgent swaps with.
+	UrgentSweepConfTarget int32 = 2
+
+	// DefaultPaymentTimeout is the default timeout for the loop out
+	// payment loop as communicated to lnd.
+	DefaultPaymentTimeout = time.Minute * 30
 )
 
 // loopOutSwap contains all the in-memory state related to a pending loop out
@@ -72,20 +78,60 @@ type loopOutSwap struct {
 	// htlcTxHash is the confirmed htlc tx id.
 	htlcTxHash *chainhash.Hash
 
+	// sweepFee is the fee rate that we last published our sweep with. It
+	// is nil if we have not published a sweep yet.
+	sweepFee *loopdb.SweepFee
+
+	// sweepFeeExceeded is set if we held back our preimage because the
+	// fee required to sweep exceeded our maximum miner fee.
+	sweepFeeExceeded bool
+
+	// channelFlow records how much of the swap payment flowed through our
+	// outgoing channel set. It is set once the swap has succeeded, if the
+	// swap was restricted to a set of channels.
+	channelFlow *loopdb.ChannelFlow
+
 	swapPaymentChan chan paymentResult
 	prePaymentChan  chan paymentResult
 
+	// paymentsSent is set if we checkpointed that our off-chain payments
+	// were dispatched before we last shut down, in which case we track
+	// the existing payments rather than sending them again.
+	paymentsSent bool
+
 	wg sync.WaitGroup
 }
 
 // executeConfig contains extra configuration to execute the swap.
 type executeConfig struct {
 	sweeper         *sweep.Sweeper
+	batcher         *sweep.Batcher
 	statusChan      chan<- SwapInfo
 	blockEpochChan  <-chan interface{}
 	timerFactory    func(d time.Duration) <-chan time.Time
 	loopOutMaxParts uint32
 	cancelSwap      func(context.Context, *outCancelDetails) error
+
+	// loopOutPaymentTimeout is the timeout for the off-chain payments of
+	// loop out swaps that do not request their own timeout.
+	loopOutPaymentTimeout time.Duration
+
+	// sweepFeeBumpBlocks is the number of blocks that we wait for a sweep
+	// or refund to confirm before we bump its fee rate. A zero value
+	// disables fee bumping.
+	sweepFeeBumpBlocks int32
+
+	// refundConfTarget is the confirmation target that loop in refunds
+	// are published with.
+	refundConfTarget int32
+
+	// refundMaxFee is the maximum fee that we pay for a loop in refund. If
+	// zero, no limit is applied.
+	refundMaxFee btcutil.Amount
+
+	// minPreimageRevealDelta is the minimum number of blocks that must
+	// remain before the htlc expires for us to reveal our preimage.
+	minPreimageRevealDelta int32
 }
 
 // loopOutInitResult contains information about a just-initiated loop out swap.
@@ -101,15 +147,15 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 
 	// Generate random preimage.
 	var swapPreimage [32]byte
-	if _, err := rand.Read(swapPreimage[:]); err != nil {
+	if _, err := io.ReadFull(cfg.rand, swapPreimage[:]); err != nil {
 		log.Error("Cannot generate preimage")
 	}
 	swapHash := lntypes.Hash(sha256.Sum256(swapPreimage[:]))
 
-	// Derive a receiver key for this swap.
-	keyDesc, err := cfg.lnd.WalletKit.DeriveNextKey(
-		globalCtx, swap.KeyFamily,
-	)
+	// Derive a receiver key for this swap at an index determined by the
+	// swap hash, so that it can be recovered from our seed.
+	keyLocator := swap.KeyLocatorFromHash(swapHash)
+	keyDesc, err := cfg.lnd.WalletKit.DeriveKey(globalCtx, &keyLocator)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +171,8 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 	// latest swap publication time.
 	swapResp, err := cfg.server.NewLoopOutSwap(
 		globalCtx, swapHash, request.Amount, request.Expiry,
-		receiverKey, request.SwapPublicationDeadline, request.Initiator,
+		receiverKey, request.SwapPublicationDeadline,
+		request.ServerHtlcConfTarget, request.Initiator,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("cannot initiate swap: %v", err)
@@ -144,6 +191,21 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 		return nil, err
 	}
 
+	// If the swap is restricted to a set of channels, we snapshot their
+	// balances so that we can verify how much of the swap payment flowed
+	// through them once the swap completes. This is best-effort, so we do
+	// not fail the swap if we cannot obtain the balances.
+	var chanBalances loopdb.ChannelBalances
+	if len(chanSet) > 0 {
+		chanBalances, err = outgoingChanBalances(
+			globalCtx, cfg.lnd.Client, chanSet,
+		)
+		if err != nil {
+			log.Warnf("Could not obtain outgoing channel "+
+				"balances: %v", err)
+		}
+	}
+
 	// If a htlc confirmation target was not provided, we use the default
 	// number of confirmations. We overwrite this value rather than failing
 	// it because the field is a new addition to the rpc, and we don't want
@@ -155,7 +217,7 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 
 	// Instantiate a struct that contains all required data to start the
 	// swap.
-	initiationTime := time.Now()
+	initiationTime := cfg.clock.Now()
 
 	contract := loopdb.LoopOutContract{
 		SwapInvoice:             swapResp.swapInvoice,
@@ -166,6 +228,10 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 		PrepayInvoice:           swapResp.prepayInvoice,
 		MaxPrepayRoutingFee:     request.MaxPrepayRoutingFee,
 		SwapPublicationDeadline: request.SwapPublicationDeadline,
+		PaymentTimeout:          request.PaymentTimeout,
+		MaxParts:                request.MaxParts,
+		Priority:                request.Priority,
+		DestXpubIndex:           request.destXpubIndex,
 		SwapContract: loopdb.SwapContract{
 			InitiationHeight: currentHeight,
 			InitiationTime:   initiationTime,
@@ -177,9 +243,31 @@ func newLoopOutSwap(globalCtx context.Context, cfg *swapConfig,
 			MaxMinerFee:      request.MaxMinerFee,
 			MaxSwapFee:       request.MaxSwapFee,
 			Label:            request.Label,
+			IdempotencyKey:   request.IdempotencyKey,
 			ProtocolVersion:  loopdb.CurrentInternalProtocolVersion,
+			HtlcVersion: GetHtlcScriptVersion(
+				loopdb.CurrentInternalProtocolVersion,
+			),
+			KeyDerivation: loopdb.KeyDerivationSwapHash,
+			KeyLocator:    keyLocator,
 		},
-		OutgoingChanSet: chanSet,
+		OutgoingChanSet:      chanSet,
+		OutgoingChanBalances: chanBalances,
+	}
+
+	// Record the htlc publication conf target that we asked for along
+	// with the target that the server committed to, so that we can tell
+	// whether our preference was honoured.
+	if request.ServerHtlcConfTarget != 0 {
+		contract.RequestedServerHtlcConfTarget =
+			request.ServerHtlcConfTarget
+		contract.ServerHtlcConfTarget = swapResp.htlcConfTarget
+
+		if swapResp.htlcConfTarget != request.ServerHtlcConfTarget {
+			log.Infof("Server committed to htlc conf target %v, "+
+				"requested %v", swapResp.htlcConfTarget,
+				request.ServerHtlcConfTarget)
+		}
 	}
 
 	swapKit := newSwapKit(
@@ -249,6 +337,8 @@ func resumeLoopOutSwap(reqContext context.Context, cfg *swapConfig,
 		LoopOutContract: *pend.Contract,
 		swapKit:         *swapKit,
 		htlc:            htlc,
+		sweepFee:        pend.SweepFee,
+		channelFlow:     pend.ChannelFlow,
 	}
 
 	lastUpdate := pend.LastUpdate()
@@ -269,6 +359,10 @@ func (s *loopOutSwap) sendUpdate(ctx context.Context) error {
 	s.log.Infof("Loop out swap state: %v", info.State)
 
 	info.HtlcAddressP2WSH = s.htlc.Address
+	info.ChannelFlow = s.channelFlow
+	info.ServerHtlcConfTarget = s.ServerHtlcConfTarget
+	info.MaxSwapRoutingFee = s.MaxSwapRoutingFee
+	info.MaxPrepayRoutingFee = s.MaxPrepayRoutingFee
 
 	select {
 	case s.statusChan <- *info:
@@ -300,6 +394,7 @@ func (s *loopOutSwap) execute(mainCtx context.Context,
 		defer s.wg.Done()
 		subscribeAndLogUpdates(
 			subCtx, s.hash, s.log, s.server.SubscribeLoopOutUpdates,
+			s.setServerFailure,
 		)
 	}()
 
@@ -365,6 +460,8 @@ func (s *loopOutSwap) executeAndFinalize(globalCtx context.Context) error {
 				continue
 			}
 
+			s.recordMilestone(loopdb.MilestonePaymentSettled)
+
 		case result := <-s.prePaymentChan:
 			s.prePaymentChan = nil
 
@@ -386,6 +483,12 @@ func (s *loopOutSwap) executeAndFinalize(globalCtx context.Context) error {
 		}
 	}
 
+	// Now that our payments have completed, we can check how much of the
+	// swap payment went through the channels we restricted it to.
+	if s.state == loopdb.StateSuccess && len(s.OutgoingChanBalances) > 0 {
+		s.recordChannelFlow(globalCtx)
+	}
+
 	// Mark swap completed in store.
 	s.log.Infof("Swap completed: %v "+
 		"(final cost: server %v, onchain %v, offchain %v)",
@@ -428,6 +531,7 @@ func (s *loopOutSwap) executeSwap(globalCtx context.Context) error {
 	// TODO: We shouldn't pay the invoices if it is already too late to
 	// start the swap. But because we don't know if we already fired the
 	// payments in a previous run, we cannot just abandon here.
+	s.paymentsSent = s.executionPhase() >= loopdb.PhasePaymentSent
 	s.payInvoices(globalCtx)
 
 	// Wait for confirmation of the on-chain htlc by watching for a tx
@@ -443,6 +547,8 @@ func (s *loopOutSwap) executeSwap(globalCtx context.Context) error {
 		return nil
 	}
 
+	s.recordMilestone(loopdb.MilestoneHtlcConfirmed)
+
 	// TODO: Off-chain payments can be canceled here. Most probably the HTLC
 	// is accepted by the server, but in case there are not for whatever
 	// reason, we don't need to have mission control start another payment
@@ -494,24 +600,109 @@ func (s *loopOutSwap) executeSwap(globalCtx context.Context) error {
 		return err
 	}
 
+	// If we are sweeping in batches, our htlc no longer needs to be
+	// included in the batch now that it has been spent.
+	if s.batcher != nil {
+		err := s.batcher.RemoveInput(s.hash, *spendDetails.SpenderTxHash)
+		if err != nil {
+			return err
+		}
+	}
+
 	sweepSuccessful := s.htlc.IsSuccessWitness(htlcInput.Witness)
 	if sweepSuccessful {
 		s.cost.Server -= htlcValue
 
-		s.cost.Onchain = htlcValue -
-			btcutil.Amount(spendDetails.SpendingTx.TxOut[0].Value)
+		// Our sweep output is always located at the same index as our
+		// htlc input, so that we can identify our share of a batch
+		// sweep.
+		sweepOutput := spendDetails.SpendingTx.TxOut[0]
+		outputIdx := int(spendDetails.SpenderInputIndex)
+		if outputIdx < len(spendDetails.SpendingTx.TxOut) {
+			sweepOutput = spendDetails.SpendingTx.TxOut[outputIdx]
+		}
+
+		s.cost.Onchain = htlcValue - btcutil.Amount(sweepOutput.Value)
 
+		s.recordMilestone(loopdb.MilestoneSweepConfirmed)
 		s.state = loopdb.StateSuccess
 	} else {
 		s.state = loopdb.StateFailSweepTimeout
+		s.failure = loopdb.FailureReasonHtlcTimeout
 	}
 
 	return nil
 }
 
+// recordChannelFlow compares the balances of our outgoing channels to their
+// balances when the swap was initiated, and records how much of the swap
+// payment flowed through them. This lets us flag swaps where lnd routed part
+// of the payment around our channel restriction. Failures are only logged,
+// because the swap itself has already completed.
+func (s *loopOutSwap) recordChannelFlow(ctx context.Context) {
+	_, expected, err := swap.DecodeInvoice(
+		s.lnd.ChainParams, s.SwapInvoice,
+	)
+	if err != nil {
+		s.log.Warnf("Could not decode swap invoice: %v", err)
+		return
+	}
+
+	balances, err := outgoingChanBalances(
+		ctx, s.lnd.Client, s.OutgoingChanSet,
+	)
+	if err != nil {
+		s.log.Warnf("Could not obtain outgoing channel balances: %v",
+			err)
+		return
+	}
+
+	flow := loopdb.NewChannelFlow(
+		s.OutgoingChanBalances, balances, expected,
+	)
+	if flow.RoutedAround() {
+		s.log.Warnf("Only %v of swap payment of %v observed to flow "+
+			"through outgoing channels %v", flow.Flowed,
+			flow.Expected, s.OutgoingChanSet)
+	}
+
+	err = s.store.UpdateLoopOutChannelFlow(s.hash, *flow)
+	if err != nil {
+		s.log.Warnf("Could not store channel flow: %v", err)
+		return
+	}
+
+	s.channelFlow = flow
+}
+
+// outgoingChanBalances returns the local balances of the channels in a loop
+// out's outgoing channel set. Channels that are no longer open are omitted.
+func outgoingChanBalances(ctx context.Context, lnd lndclient.LightningClient,
+	chanSet loopdb.ChannelSet) (loopdb.ChannelBalances, error) {
+
+	channels, err := lnd.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inSet := make(map[uint64]bool, len(chanSet))
+	for _, chanID := range chanSet {
+		inSet[chanID] = true
+	}
+
+	balances := make(loopdb.ChannelBalances, len(chanSet))
+	for _, channel := range channels {
+		if inSet[channel.ChannelID] {
+			balances[channel.ChannelID] = channel.LocalBalance
+		}
+	}
+
+	return balances, nil
+}
+
 // persistState updates the swap state and sends out an update notification.
 func (s *loopOutSwap) persistState(ctx context.Context) error {
-	updateTime := time.Now()
+	updateTime := s.clock.Now()
 
 	s.lastUpdateTime = updateTime
 
@@ -519,9 +710,12 @@ func (s *loopOutSwap) persistState(ctx context.Context) error {
 	err := s.store.UpdateLoopOut(
 		s.hash, updateTime,
 		loopdb.SwapStateData{
-			State:      s.state,
-			Cost:       s.cost,
-			HtlcTxHash: s.htlcTxHash,
+			State:         s.state,
+			Cost:          s.cost,
+			HtlcTxHash:    s.htlcTxHash,
+			ServerFailure: s.serverFailureReason(),
+			FailureReason: s.failureReason(),
+			Component:     loopdb.AuditComponentLoopOut,
 		},
 	)
 	if err != nil {
@@ -534,6 +728,23 @@ func (s *loopOutSwap) persistState(ctx context.Context) error {
 
 // payInvoices pays both swap invoices.
 func (s *loopOutSwap) payInvoices(ctx context.Context) {
+	// If the server included our prepay in the swap invoice, we pay both
+	// in a single payment, so that we can't end up with a prepay that
+	// succeeded while the swap payment failed. This payment may use the
+	// routing fees that we allowed for both payments.
+	if s.BatchedPrepay() {
+		s.log.Infof("Sending swap payment with batched prepay %v",
+			s.SwapInvoice)
+
+		s.swapPaymentChan = s.payInvoice(
+			ctx, s.SwapInvoice,
+			s.MaxSwapRoutingFee+s.MaxPrepayRoutingFee,
+			s.LoopOutContract.OutgoingChanSet,
+		)
+
+		return
+	}
+
 	// Pay the swap invoice.
 	s.log.Infof("Sending swap payment %v", s.SwapInvoice)
 
@@ -613,6 +824,77 @@ func (s *loopOutSwap) payInvoice(ctx context.Context, invoice string,
 	return resultChan
 }
 
+// paymentTimeout returns the timeout for the swap's off-chain payments. The
+// timeout requested for the swap takes precedence over our configured timeout,
+// which is scaled by the swap's priority. Lnd keeps retrying the payments
+// until this timeout is reached, so urgent swaps get more attempts and low
+// priority swaps give up sooner.
+func (s *loopOutSwap) paymentTimeout() time.Duration {
+	if s.PaymentTimeout != 0 {
+		return s.PaymentTimeout
+	}
+
+	timeout := s.executeConfig.loopOutPaymentTimeout
+	if timeout == 0 {
+		timeout = DefaultPaymentTimeout
+	}
+
+	switch s.Priority {
+	case loopdb.PriorityLow:
+		return timeout / 2
+
+	case loopdb.PriorityUrgent:
+		return timeout * 2
+
+	default:
+		return timeout
+	}
+}
+
+// feeBumpBlocks returns the number of blocks that we wait for our sweep to
+// confirm before bumping its fee rate, scaled by the swap's priority.
+func (s *loopOutSwap) feeBumpBlocks() int32 {
+	switch s.Priority {
+	case loopdb.PriorityLow:
+		return s.sweepFeeBumpBlocks * 2
+
+	case loopdb.PriorityUrgent:
+		// We round up so that we still bump if we are configured to
+		// bump every block.
+		return (s.sweepFeeBumpBlocks + 1) / 2
+
+	default:
+		return s.sweepFeeBumpBlocks
+	}
+}
+
+// republishDelay returns the delay after a new block that we wait before we
+// retry publishing our sweep and pushing our preimage, scaled by the swap's
+// priority.
+func (s *loopOutSwap) republishDelay() time.Duration {
+	switch s.Priority {
+	case loopdb.PriorityLow:
+		return republishDelay * 2
+
+	case loopdb.PriorityUrgent:
+		return republishDelay / 2
+
+	default:
+		return republishDelay
+	}
+}
+
+// maxParts returns the maximum number of parts that the swap's off-chain
+// payments may be split into. The maximum requested for the swap takes
+// precedence over our configured maximum.
+func (s *loopOutSwap) maxParts() uint32 {
+	if s.MaxParts != 0 {
+		return s.MaxParts
+	}
+
+	return s.executeConfig.loopOutMaxParts
+}
+
 // payInvoiceAsync is the asynchronously executed part of paying an invoice.
 func (s *loopOutSwap) payInvoiceAsync(ctx context.Context,
 	invoice string, maxFee btcutil.Amount,
@@ -630,21 +912,35 @@ func (s *loopOutSwap) payInvoiceAsync(ctx context.Context,
 		MaxFee:          maxFee,
 		Invoice:         invoice,
 		OutgoingChanIds: outgoingChanIds,
-		Timeout:         paymentTimeout,
-		MaxParts:        s.executeConfig.loopOutMaxParts,
+		Timeout:         s.paymentTimeout(),
+		MaxParts:        s.maxParts(),
 	}
 
 	// Lookup state of the swap payment.
 	paymentStateCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	payStatusChan, payErrChan, err := s.lnd.Router.SendPayment(
-		paymentStateCtx, req,
+	// If our payments were sent before we last shut down, we track the
+	// existing payment rather than sending it again.
+	var (
+		payStatusChan chan lndclient.PaymentStatus
+		payErrChan    chan error
 	)
+	if s.paymentsSent {
+		payStatusChan, payErrChan, err = s.lnd.Router.TrackPayment(
+			paymentStateCtx, hash,
+		)
+	} else {
+		payStatusChan, payErrChan, err = s.lnd.Router.SendPayment(
+			paymentStateCtx, req,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	s.setPhase(loopdb.PhasePaymentSent)
+
 	for {
 		select {
 		// Payment advanced to the next state.
@@ -665,15 +961,33 @@ func (s *loopOutSwap) payInvoiceAsync(ctx context.Context,
 				return nil, errors.New("unknown payment state")
 			}
 
-		// Abort the swap in case of an error. An unknown payment error
-		// from TrackPayment is no longer expected here.
+		// Abort the swap in case of an error, unless we can recover
+		// by switching between sending and tracking the payment.
 		case err := <-payErrChan:
-			if err != channeldb.ErrAlreadyPaid {
+			switch err {
+			// If the invoice has already been paid, we track the
+			// existing payment.
+			case channeldb.ErrAlreadyPaid:
+				payStatusChan, payErrChan, err =
+					s.lnd.Router.TrackPayment(
+						paymentStateCtx, hash,
+					)
+
+			// If we tracked a payment that lnd does not know
+			// about, it was not sent before we shut down after
+			// all, so we send it now.
+			case channeldb.ErrPaymentNotInitiated:
+				s.log.Infof("Payment %v not initiated, "+
+					"sending", hash)
+
+				payStatusChan, payErrChan, err =
+					s.lnd.Router.SendPayment(
+						paymentStateCtx, req,
+					)
+
+			default:
 				return nil, err
 			}
-
-			payStatusChan, payErrChan, err =
-				s.lnd.Router.TrackPayment(paymentStateCtx, hash)
 			if err != nil {
 				return nil, err
 			}
@@ -724,7 +1038,7 @@ func (s *loopOutSwap) waitForConfirmedHtlc(globalCtx context.Context) (
 		// already revealed the preimage, this check is irrelevant and
 		// we need to sweep in any case.
 		maxPreimageRevealHeight := s.CltvExpiry -
-			MinLoopOutPreimageRevealDelta
+			s.minPreimageRevealDelta
 
 		checkMaxRevealHeightExceeded := func() bool {
 			s.log.Infof("Checking preimage reveal height %v "+
@@ -740,6 +1054,7 @@ func (s *loopOutSwap) waitForConfirmedHtlc(globalCtx context.Context) (
 				maxPreimageRevealHeight, s.height)
 
 			s.state = loopdb.StateFailTimeout
+			s.failure = loopdb.FailureReasonHtlcTimeout
 
 			return true
 		}
@@ -776,6 +1091,10 @@ func (s *loopOutSwap) waitForConfirmedHtlc(globalCtx context.Context) (
 					return nil, nil
 				}
 
+				s.recordMilestone(
+					loopdb.MilestonePaymentSettled,
+				)
+
 			// If the prepay fails, abandon the swap. Because we
 			// didn't reveal the preimage, the swap payment will be
 			// canceled or time out.
@@ -792,7 +1111,7 @@ func (s *loopOutSwap) waitForConfirmedHtlc(globalCtx context.Context) (
 						result.failure())
 
 					s.failOffChain(
-						ctx, paymentTypeInvoice,
+						ctx, paymentTypePrepay,
 						result.status,
 					)
 
@@ -882,7 +1201,7 @@ func (s *loopOutSwap) waitForHtlcSpendConfirmed(globalCtx context.Context,
 	// to decide whether we need to push our preimage to the server.
 	var paymentComplete bool
 
-	timerChan := s.timerFactory(republishDelay)
+	timerChan := s.timerFactory(s.republishDelay())
 	for {
 		select {
 		// Htlc spend, break loop.
@@ -938,7 +1257,7 @@ func (s *loopOutSwap) waitForHtlcSpendConfirmed(globalCtx context.Context,
 		// timer.
 		case notification := <-s.blockEpochChan:
 			s.height = notification.(int32)
-			timerChan = s.timerFactory(republishDelay)
+			timerChan = s.timerFactory(s.republishDelay())
 
 		// Some time after start or after arrival of a new block, try
 		// to spend again.
@@ -997,22 +1316,22 @@ func (s *loopOutSwap) failOffChain(ctx context.Context, paymentType paymentType,
 	// Set our state to failed off chain timeout.
 	s.state = loopdb.StateFailOffchainPayments
 
-	swapPayReq, err := zpay32.Decode(
+	s.failure = loopdb.FailureReasonOffchainPayment
+	if paymentType == paymentTypePrepay {
+		s.failure = loopdb.FailureReasonPrepayFailed
+	}
+
+	paymentAddr, err := swapPaymentAddr(
 		s.LoopOutContract.SwapInvoice, s.swapConfig.lnd.ChainParams,
 	)
 	if err != nil {
-		s.log.Errorf("could not decode swap invoice: %v", err)
-		return
-	}
-
-	if swapPayReq.PaymentAddr == nil {
-		s.log.Errorf("expected payment address for invoice")
+		s.log.Errorf("could not get swap payment address: %v", err)
 		return
 	}
 
 	details := &outCancelDetails{
 		hash:        s.hash,
-		paymentAddr: *swapPayReq.PaymentAddr,
+		paymentAddr: paymentAddr,
 		metadata: routeCancelMetadata{
 			paymentType:   paymentType,
 			failureReason: status.FailureReason,
@@ -1081,6 +1400,25 @@ func (s *loopOutSwap) failOffChain(ctx context.Context, paymentType paymentType,
 	}
 }
 
+// swapPaymentAddr returns the payment address of a loop out's swap invoice,
+// which the server requires to authenticate cancelation of the swap.
+func swapPaymentAddr(swapInvoice string,
+	chainParams *chaincfg.Params) ([32]byte, error) {
+
+	swapPayReq, err := zpay32.Decode(swapInvoice, chainParams)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("could not decode swap "+
+			"invoice: %v", err)
+	}
+
+	if swapPayReq.PaymentAddr == nil {
+		return [32]byte{}, errors.New("expected payment address for " +
+			"invoice")
+	}
+
+	return *swapPayReq.PaymentAddr, nil
+}
+
 // sweep tries to sweep the given htlc to a destination address. It takes into
 // account the max miner fee and marks the preimage as revealed when it
 // published the tx. If the preimage has not yet been revealed, and the time
@@ -1097,7 +1435,7 @@ func (s *loopOutSwap) sweep(ctx context.Context,
 	}
 
 	remainingBlocks := s.CltvExpiry - s.height
-	blocksToLastReveal := remainingBlocks - MinLoopOutPreimageRevealDelta
+	blocksToLastReveal := remainingBlocks - s.minPreimageRevealDelta
 	preimageRevealed := s.state == loopdb.StatePreimageRevealed
 
 	// If we have not revealed our preimage, and we don't have time left
@@ -1111,6 +1449,15 @@ func (s *loopOutSwap) sweep(ctx context.Context,
 			s.height)
 
 		s.state = loopdb.StateFailTimeout
+		s.failure = loopdb.FailureReasonHtlcTimeout
+
+		// If we held back our preimage because the fee required
+		// to sweep exceeded our maximum, that is the reason that
+		// we ran out of time.
+		if s.sweepFeeExceeded {
+			s.failure = loopdb.FailureReasonSweepFeeExceeded
+		}
+
 		return nil
 	}
 
@@ -1126,13 +1473,33 @@ func (s *loopOutSwap) sweep(ctx context.Context,
 		confTarget = DefaultSweepConfTarget
 	}
 
-	fee, err := s.sweeper.GetSweepFee(
+	// Urgent swaps are always swept with a low confirmation target, so
+	// that they complete quickly.
+	if s.Priority == loopdb.PriorityUrgent &&
+		confTarget > UrgentSweepConfTarget {
+
+		confTarget = UrgentSweepConfTarget
+	}
+
+	fee, feeRate, weight, err := s.sweeper.GetSweepFeeDetails(
 		ctx, s.htlc.AddSuccessToEstimator, s.DestAddr, confTarget,
 	)
 	if err != nil {
 		return err
 	}
 
+	// If we have already published a sweep, we make sure that we don't
+	// lower our fee rate, and bump it if our sweep has not confirmed in
+	// time.
+	if s.sweepFee != nil {
+		feeRate = sweep.BumpFeeRate(
+			feeRate, s.sweepFee.FeeRate,
+			s.height-s.sweepFee.PublishHeight,
+			s.feeBumpBlocks(),
+		)
+		fee = feeRate.FeeForWeight(weight)
+	}
+
 	// Ensure it doesn't exceed our maximum fee allowed.
 	if fee > s.MaxMinerFee {
 		s.log.Warnf("Required fee %v exceeds max miner fee of %v",
@@ -1141,18 +1508,31 @@ func (s *loopOutSwap) sweep(ctx context.Context,
 		if preimageRevealed {
 			// The currently required fee exceeds the max, but we
 			// already revealed the preimage. The best we can do now
-			// is to republish with the max fee.
+			// is to republish with the max fee. We persist the rate
+			// that our max fee pays rather than the rate we wanted,
+			// so that future bumps start from the rate that our
+			// sweep was actually published with.
 			fee = s.MaxMinerFee
+			feeRate = feeRateForFee(fee, weight)
 		} else {
 			s.log.Warnf("Not revealing preimage")
+			s.sweepFeeExceeded = true
+
 			return nil
 		}
 	}
 
+	// If we are batching our sweeps, we hand our htlc over to the batcher
+	// rather than publishing our own sweep transaction.
+	if s.batcher != nil {
+		return s.batchSweep(ctx, htlcOutpoint, htlcValue, confTarget)
+	}
+
 	// Create sweep tx.
 	sweepTx, err := s.sweeper.CreateSweepTx(
 		ctx, s.height, s.htlc.SuccessSequence(), s.htlc, htlcOutpoint,
-		s.ReceiverKey, witnessFunc, htlcValue, fee, s.DestAddr,
+		s.ReceiverKey, s.KeyLocator, witnessFunc, htlcValue, fee,
+		s.DestAddr,
 	)
 	if err != nil {
 		return err
@@ -1175,16 +1555,95 @@ func (s *loopOutSwap) sweep(ctx context.Context,
 		s.DestAddr, fee, sweepTx.TxHash())
 
 	err = s.lnd.WalletKit.PublishTransaction(
-		ctx, sweepTx,
-		labels.LoopOutSweepSuccess(swap.ShortHash(&s.hash)),
+		ctx, sweepTx, s.sweeper.TxLabel(
+			labels.LoopOutSweepSuccess(swap.ShortHash(&s.hash)),
+		),
 	)
 	if err != nil {
 		s.log.Warnf("Publish sweep: %v", err)
+
+		return nil
+	}
+
+	s.setPhase(loopdb.PhaseSweepPublished)
+
+	// If we published with a new fee rate, we persist it along with the
+	// current height so that we can resume our fee bumping schedule after
+	// restart.
+	if s.sweepFee == nil || s.sweepFee.FeeRate != feeRate {
+		sweepFee := loopdb.SweepFee{
+			FeeRate:       feeRate,
+			PublishHeight: s.height,
+		}
+
+		err := s.store.UpdateLoopOutSweepFee(s.hash, sweepFee)
+		if err != nil {
+			return err
+		}
+
+		s.sweepFee = &sweepFee
 	}
 
 	return nil
 }
 
+// feeRateForFee returns the fee rate that a transaction of the weight provided
+// pays when it is published with the given fee. The rate is rounded down so
+// that it never overstates the fee that was paid.
+func feeRateForFee(fee btcutil.Amount, weight int64) chainfee.SatPerKWeight {
+	return chainfee.SatPerKWeight(fee * 1000 / btcutil.Amount(weight))
+}
+
+// batchSweep adds our htlc to the sweep batcher and publishes the current
+// batch. The batch may use up to our max miner fee for our share of the batch
+// transaction.
+func (s *loopOutSwap) batchSweep(ctx context.Context,
+	htlcOutpoint wire.OutPoint, htlcValue btcutil.Amount,
+	confTarget int32) error {
+
+	err := s.batcher.AddInput(&sweep.BatchInput{
+		SwapHash:   s.hash,
+		Htlc:       s.htlc,
+		Outpoint:   htlcOutpoint,
+		Value:      htlcValue,
+		KeyBytes:   s.ReceiverKey,
+		KeyLocator: s.KeyLocator,
+		WitnessFunc: func(sig []byte) (wire.TxWitness, error) {
+			return s.htlc.GenSuccessWitness(sig, s.Preimage)
+		},
+		DestAddr:   s.DestAddr,
+		ConfTarget: confTarget,
+		MaxFee:     s.MaxMinerFee,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Before publishing the batch, mark the preimage as revealed for the
+	// same reasons as we do for individual sweeps.
+	if s.state != loopdb.StatePreimageRevealed {
+		s.state = loopdb.StatePreimageRevealed
+
+		err := s.persistState(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.log.Infof("Sweep on chain HTLC to address %v in batch with max "+
+		"fee %v", s.DestAddr, s.MaxMinerFee)
+
+	if err := s.batcher.Publish(ctx, s.height); err != nil {
+		s.log.Warnf("Publish batch sweep: %v", err)
+
+		return nil
+	}
+
+	s.setPhase(loopdb.PhaseSweepPublished)
+
+	return nil
+}
+
 // validateLoopOutContract validates the contract parameters against our
 // request.
 func validateLoopOutContract(lnd *lndclient.LndServices,
@@ -1202,18 +1661,70 @@ func validateLoopOutContract(lnd *lndclient.LndServices,
 	}
 
 	if swapInvoiceHash != swapHash {
-		return fmt.Errorf(
-			"cannot initiate swap, swap invoice hash %v not equal generated swap hash %v",
-			swapInvoiceHash, swapHash)
+		return fmt.Errorf("%w: swap invoice hash %v, generated swap "+
+			"hash %v", ErrInvoiceHashMismatch, swapInvoiceHash,
+			swapHash)
+	}
+
+	// If the server included our prepay in the swap invoice, we do not
+	// have a prepay invoice to check, and the swap invoice alone must
+	// cover the swap amount and fees.
+	var prepayInvoiceAmt btcutil.Amount
+	if response.batchedPrepay {
+		if response.prepayInvoice != "" {
+			return ErrUnexpectedPrepayInvoice
+		}
+	} else {
+		_, prepayInvoiceAmt, err = swap.DecodeInvoice(
+			chainParams, response.prepayInvoice,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
-	_, prepayInvoiceAmt, err := swap.DecodeInvoice(
-		chainParams, response.prepayInvoice,
+	// The server's invoices should at least cover the amount that we are
+	// swapping, otherwise they do not belong to the swap that we agreed
+	// on.
+	if swapInvoiceAmt+prepayInvoiceAmt < request.Amount {
+		return fmt.Errorf("%w: swap invoice %v, prepay invoice %v, "+
+			"swap amount %v", ErrInvoiceAmountMismatch,
+			swapInvoiceAmt, prepayInvoiceAmt, request.Amount)
+	}
+
+	// Both invoices must pay to the same node, and to the node that the
+	// server quoted if we know it.
+	swapDest, err := swap.DecodeInvoiceDestination(
+		chainParams, response.swapInvoice,
 	)
 	if err != nil {
 		return err
 	}
 
+	if !response.batchedPrepay {
+		prepayDest, err := swap.DecodeInvoiceDestination(
+			chainParams, response.prepayInvoice,
+		)
+		if err != nil {
+			return err
+		}
+
+		if swapDest != prepayDest {
+			return fmt.Errorf("%w: swap invoice pays to %x, prepay "+
+				"invoice pays to %x", ErrInvoiceDestMismatch,
+				swapDest, prepayDest)
+		}
+	}
+
+	var noDest [33]byte
+	if request.SwapPaymentDest != noDest &&
+		swapDest != request.SwapPaymentDest {
+
+		return fmt.Errorf("%w: invoices pay to %x, quoted destination "+
+			"%x", ErrInvoiceDestMismatch, swapDest,
+			request.SwapPaymentDest)
+	}
+
 	swapFee := swapInvoiceAmt + prepayInvoiceAmt - request.Amount
 	if swapFee > request.MaxSwapFee {
 		log.Warnf("Swap fee %v exceeding maximum of %v",
@@ -0,0 +1,155 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+var (
+	// ErrBatchTooSmall is returned when a loop out batch does not contain
+	// at least two swaps.
+	ErrBatchTooSmall = errors.New("loop out batch must contain at least " +
+		"two swaps")
+
+	// ErrBatchChannelOverlap is returned when the same outgoing channel
+	// is used by more than one swap in a loop out batch.
+	ErrBatchChannelOverlap = errors.New("outgoing channel used by more " +
+		"than one swap in batch")
+
+	// ErrBatchNoChannels is returned when a swap in a loop out batch does
+	// not restrict its outgoing channels.
+	ErrBatchNoChannels = errors.New("each swap in a loop out batch must " +
+		"set its outgoing channels")
+)
+
+// LoopOutBatchError is returned when a swap in a loop out batch could not be
+// initiated. Swaps in the batch that were initiated before the failure are
+// not canceled, so that the caller is able to track them.
+type LoopOutBatchError struct {
+	// Index is the index of the swap in the batch that failed.
+	Index int
+
+	// Initiated holds the hashes of the swaps in the batch that were
+	// initiated before the failure.
+	Initiated []lntypes.Hash
+
+	// Err is the error that the swap failed with.
+	Err error
+}
+
+// Error returns a string representation of the batch error.
+func (e *LoopOutBatchError) Error() string {
+	return fmt.Sprintf("swap %v of batch failed after %v swaps were "+
+		"initiated (%v): %v", e.Index, len(e.Initiated), e.Initiated,
+		e.Err)
+}
+
+// Unwrap returns the error that the failed swap returned.
+func (e *LoopOutBatchError) Unwrap() error {
+	return e.Err
+}
+
+// validateLoopOutBatch checks that a set of loop out requests can be
+// dispatched as a batch. Every swap in the batch must be restricted to a set
+// of outgoing channels, and no channel may be used by more than one swap so
+// that the swaps do not compete for the same liquidity.
+func validateLoopOutBatch(requests []*OutRequest) error {
+	if len(requests) < 2 {
+		return ErrBatchTooSmall
+	}
+
+	channels := make(map[uint64]int)
+	for i, request := range requests {
+		if len(request.OutgoingChanSet) == 0 {
+			return fmt.Errorf("swap %v: %w", i, ErrBatchNoChannels)
+		}
+
+		for _, chanID := range request.OutgoingChanSet {
+			if other, ok := channels[chanID]; ok {
+				return fmt.Errorf("%w: channel %v used by swaps "+
+					"%v and %v", ErrBatchChannelOverlap,
+					chanID, other, i)
+			}
+
+			channels[chanID] = i
+		}
+	}
+
+	return nil
+}
+
+// LoopOutBatch initiates a set of loop out swaps that each drain a different
+// set of outgoing channels. The batch is validated as a whole before any swap
+// is initiated, and swaps are then initiated one at a time. If a swap fails
+// to initiate, no further swaps are initiated and a *LoopOutBatchError is
+// returned along with the swaps that were already initiated.
+//
+// If the batch has an idempotency key, each swap uses the key suffixed with
+// its index in the batch, so that retrying the batch does not create the
+// swaps that were already initiated again.
+func (s *Client) LoopOutBatch(ctx context.Context,
+	requests []*OutRequest) ([]*LoopOutSwapInfo, error) {
+
+	if err := validateLoopOutBatch(requests); err != nil {
+		return nil, err
+	}
+
+	// Check all of our destination addresses up front, so that we do not
+	// initiate part of a batch that cannot be completed.
+	for i, request := range requests {
+		if err := s.validateOutDest(request); err != nil {
+			return nil, fmt.Errorf("swap %v: %w", i, err)
+		}
+	}
+
+	var total btcutil.Amount
+	for _, request := range requests {
+		total += request.Amount
+	}
+
+	log.Infof("LoopOut batch of %v swaps, total amount: %v",
+		len(requests), total)
+
+	// Check our maximum locked value for the batch as a whole, so that we
+	// do not start part of a batch that cannot be completed. Each swap is
+	// checked again when it is initiated.
+	if s.MaxLockedValue != 0 {
+		if err := s.checkLockedValue(ctx, total); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		infos     = make([]*LoopOutSwapInfo, 0, len(requests))
+		initiated = make([]lntypes.Hash, 0, len(requests))
+	)
+	for i, request := range requests {
+		// Each swap is keyed by its index in the batch, which we set on
+		// a copy so that the caller's requests are not modified.
+		if request.IdempotencyKey != "" {
+			requestCopy := *request
+			requestCopy.IdempotencyKey = fmt.Sprintf(
+				"%v/%v", request.IdempotencyKey, i,
+			)
+			request = &requestCopy
+		}
+
+		info, err := s.LoopOut(ctx, request)
+		if err != nil {
+			return infos, &LoopOutBatchError{
+				Index:     i,
+				Initiated: initiated,
+				Err:       err,
+			}
+		}
+
+		infos = append(infos, info)
+		initiated = append(initiated, info.SwapHash)
+	}
+
+	return infos, nil
+}
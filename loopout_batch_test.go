@@ -0,0 +1,59 @@
+package loop
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateLoopOutBatch tests validation of the swaps in a loop out batch.
+func TestValidateLoopOutBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []*OutRequest
+		err      error
+	}{
+		{
+			name: "single swap",
+			requests: []*OutRequest{
+				{OutgoingChanSet: loopdb.ChannelSet{1}},
+			},
+			err: ErrBatchTooSmall,
+		},
+		{
+			name: "swap without channels",
+			requests: []*OutRequest{
+				{OutgoingChanSet: loopdb.ChannelSet{1}},
+				{},
+			},
+			err: ErrBatchNoChannels,
+		},
+		{
+			name: "overlapping channels",
+			requests: []*OutRequest{
+				{OutgoingChanSet: loopdb.ChannelSet{1, 2}},
+				{OutgoingChanSet: loopdb.ChannelSet{3, 2}},
+			},
+			err: ErrBatchChannelOverlap,
+		},
+		{
+			name: "valid batch",
+			requests: []*OutRequest{
+				{OutgoingChanSet: loopdb.ChannelSet{1, 2}},
+				{OutgoingChanSet: loopdb.ChannelSet{3}},
+				{OutgoingChanSet: loopdb.ChannelSet{4}},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateLoopOutBatch(testCase.requests)
+			require.True(t, errors.Is(err, testCase.err))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package loop
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"math"
 	"reflect"
@@ -13,9 +15,13 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
 	"github.com/lightninglabs/loop/sweep"
 	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/stretchr/testify/require"
@@ -39,11 +45,7 @@ func TestLoopOutPaymentParameters(t *testing.T) {
 
 	height := int32(600)
 
-	cfg := &swapConfig{
-		lnd:    &lnd.LndServices,
-		store:  store,
-		server: server,
-	}
+	cfg := newSwapConfig(&lnd.LndServices, store, server)
 
 	sweeper := &sweep.Sweeper{Lnd: &lnd.LndServices}
 
@@ -55,6 +57,7 @@ func TestLoopOutPaymentParameters(t *testing.T) {
 	// Initiate the swap.
 	req := *testRequest
 	req.OutgoingChanSet = loopdb.ChannelSet{2, 3}
+	req.Expiry = height + testLoopOutMinOnChainCltvDelta
 
 	initResult, err := newLoopOutSwap(
 		context.Background(), cfg, height, &req,
@@ -70,12 +73,13 @@ func TestLoopOutPaymentParameters(t *testing.T) {
 
 	go func() {
 		err := swap.execute(swapCtx, &executeConfig{
-			statusChan:      statusChan,
-			sweeper:         sweeper,
-			blockEpochChan:  blockEpochChan,
-			timerFactory:    timerFactory,
-			loopOutMaxParts: maxParts,
-			cancelSwap:      server.CancelLoopOutSwap,
+			statusChan:             statusChan,
+			sweeper:                sweeper,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			loopOutMaxParts:        maxParts,
+			cancelSwap:             server.CancelLoopOutSwap,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}, height)
 		if err != nil {
 			log.Error(err)
@@ -172,11 +176,12 @@ func TestLateHtlcPublish(t *testing.T) {
 	errChan := make(chan error)
 	go func() {
 		err := swap.execute(context.Background(), &executeConfig{
-			statusChan:     statusChan,
-			sweeper:        sweeper,
-			blockEpochChan: blockEpochChan,
-			timerFactory:   timerFactory,
-			cancelSwap:     server.CancelLoopOutSwap,
+			statusChan:             statusChan,
+			sweeper:                sweeper,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			cancelSwap:             server.CancelLoopOutSwap,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}, height)
 		if err != nil {
 			log.Error(err)
@@ -232,6 +237,7 @@ func TestCustomSweepConfTarget(t *testing.T) {
 	// Use the highest sweep confirmation target before we attempt to use
 	// the default.
 	testReq := *testRequest
+	testReq.Expiry = ctx.Lnd.Height + testLoopOutMinOnChainCltvDelta
 
 	testReq.SweepConfTarget = testLoopOutMinOnChainCltvDelta -
 		DefaultSweepConfTargetDelta - 1
@@ -267,11 +273,12 @@ func TestCustomSweepConfTarget(t *testing.T) {
 	errChan := make(chan error)
 	go func() {
 		err := swap.execute(context.Background(), &executeConfig{
-			statusChan:     statusChan,
-			blockEpochChan: blockEpochChan,
-			timerFactory:   timerFactory,
-			sweeper:        sweeper,
-			cancelSwap:     server.CancelLoopOutSwap,
+			statusChan:             statusChan,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			sweeper:                sweeper,
+			cancelSwap:             server.CancelLoopOutSwap,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}, ctx.Lnd.Height)
 		if err != nil {
 			log.Error(err)
@@ -462,11 +469,12 @@ func TestPreimagePush(t *testing.T) {
 	errChan := make(chan error)
 	go func() {
 		err := swap.execute(context.Background(), &executeConfig{
-			statusChan:     statusChan,
-			blockEpochChan: blockEpochChan,
-			timerFactory:   timerFactory,
-			sweeper:        sweeper,
-			cancelSwap:     server.CancelLoopOutSwap,
+			statusChan:             statusChan,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			sweeper:                sweeper,
+			cancelSwap:             server.CancelLoopOutSwap,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}, ctx.Lnd.Height)
 		if err != nil {
 			log.Error(err)
@@ -547,7 +555,12 @@ func TestPreimagePush(t *testing.T) {
 	// To mock a server failure, we do not send a payment settled update
 	// for our off chain payment yet. We also do not confirm our sweep on
 	// chain yet so we can test our preimage push retry logic. Instead, we
-	// tick the expiry chan again to prompt another sweep.
+	// raise fees above our max miner fee and tick the expiry chan again
+	// to prompt another sweep. Since our preimage is already revealed, we
+	// expect this sweep to be published with our max miner fee.
+	ctx.Lnd.SetFeeEstimate(testReq.SweepConfTarget, chainfee.SatPerKWeight(
+		testReq.MaxMinerFee*2,
+	))
 	expiryChan <- testTime
 
 	// We expect another signing request for out sweep, and publish of our
@@ -561,6 +574,20 @@ func TestPreimagePush(t *testing.T) {
 	preimage = <-server.preimagePush
 	require.Equal(t, swap.Preimage, preimage)
 
+	// The fee rate that we persist for our sweep should be the rate that
+	// our max miner fee pays, not the rate that we were aiming for.
+	sweepFee := cfg.store.(*storeMock).sweepFees[swap.hash]
+	require.Less(
+		t, int64(sweepFee.FeeRate), int64(testReq.MaxMinerFee*2),
+	)
+
+	maxFee, err := sweeper.GetSweepFeeForRate(
+		swap.htlc.AddSuccessToEstimator, swap.DestAddr,
+		sweepFee.FeeRate,
+	)
+	require.NoError(t, err)
+	require.LessOrEqual(t, int64(maxFee), int64(testReq.MaxMinerFee))
+
 	// This time, we send a payment succeeded update into our payment stream
 	// to reflect that the server received our preimage push and settled off
 	// chain.
@@ -630,10 +657,11 @@ func TestExpiryBeforeReveal(t *testing.T) {
 	errChan := make(chan error)
 	go func() {
 		err := swap.execute(context.Background(), &executeConfig{
-			statusChan:     statusChan,
-			blockEpochChan: blockEpochChan,
-			timerFactory:   timerFactory,
-			sweeper:        sweeper,
+			statusChan:             statusChan,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			sweeper:                sweeper,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}, ctx.Lnd.Height)
 		if err != nil {
 			log.Error(err)
@@ -704,6 +732,82 @@ func TestExpiryBeforeReveal(t *testing.T) {
 	require.Nil(t, <-errChan)
 }
 
+// TestLoopOutResumePaymentsSent tests that a swap that we checkpointed after
+// its off-chain payments were sent tracks those payments when it is resumed,
+// rather than sending them again, and that payments which lnd does not know
+// about are sent.
+func TestLoopOutResumePaymentsSent(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	ctx := test.NewContext(t, lnd)
+	server := newServerMock(lnd)
+
+	cfg := newSwapConfig(
+		&lnd.LndServices, newStoreMock(t), server,
+	)
+	initResult, err := newLoopOutSwap(
+		context.Background(), cfg, ctx.Lnd.Height, testRequest,
+	)
+	require.NoError(t, err)
+	loopOut := initResult.swap
+
+	prepayHash, _, err := swap.DecodeInvoice(
+		lnd.ChainParams, loopOut.PrepayInvoice,
+	)
+	require.NoError(t, err)
+
+	// Restore the phase that we checkpointed for the swap, as we do when
+	// swaps are resumed.
+	loopOut.setPhase(loopdb.PhasePaymentSent)
+
+	statusChan := make(chan SwapInfo)
+	execCtx, cancel := context.WithCancel(context.Background())
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- loopOut.execute(execCtx, &executeConfig{
+			statusChan:     statusChan,
+			blockEpochChan: make(chan interface{}),
+			timerFactory: func(_ time.Duration) <-chan time.Time {
+				return nil
+			},
+			sweeper:                &sweep.Sweeper{Lnd: &lnd.LndServices},
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
+		}, ctx.Lnd.Height)
+	}()
+
+	cfg.store.(*storeMock).assertLoopOutStored()
+	state := <-statusChan
+	require.Equal(t, loopdb.StateInitiated, state.State)
+
+	// Both of our payments should be tracked rather than sent.
+	tracked := make(map[lntypes.Hash]test.TrackPaymentMessage)
+	for i := 0; i < 2; i++ {
+		msg := ctx.AssertTrackPayment()
+		tracked[msg.Hash] = msg
+	}
+	require.Contains(t, tracked, loopOut.hash)
+	require.Contains(t, tracked, prepayHash)
+
+	ctx.AssertRegisterConf(false, defaultConfirmations)
+
+	tracked[loopOut.hash].Updates <- lndclient.PaymentStatus{
+		State: lnrpc.Payment_SUCCEEDED,
+	}
+
+	// If lnd does not know about our prepay, it should be sent.
+	tracked[prepayHash].Errors <- channeldb.ErrPaymentNotInitiated
+
+	signalPrepaymentResult := ctx.AssertPaid(prepayInvoiceDesc)
+	signalPrepaymentResult(nil)
+
+	require.Equal(t, loopdb.PhasePaymentSent, loopOut.executionPhase())
+
+	cancel()
+	require.Equal(t, context.Canceled, <-errChan)
+}
+
 // TestFailedOffChainCancelation tests sending of a cancelation message to
 // the server when a swap fails due to off-chain routing.
 func TestFailedOffChainCancelation(t *testing.T) {
@@ -738,11 +842,12 @@ func TestFailedOffChainCancelation(t *testing.T) {
 	errChan := make(chan error)
 	go func() {
 		cfg := &executeConfig{
-			statusChan:     statusChan,
-			sweeper:        sweeper,
-			blockEpochChan: blockEpochChan,
-			timerFactory:   timerFactory,
-			cancelSwap:     server.CancelLoopOutSwap,
+			statusChan:             statusChan,
+			sweeper:                sweeper,
+			blockEpochChan:         blockEpochChan,
+			timerFactory:           timerFactory,
+			cancelSwap:             server.CancelLoopOutSwap,
+			minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 		}
 
 		err := swap.execute(context.Background(), cfg, ctx.Lnd.Height)
@@ -838,5 +943,269 @@ func TestFailedOffChainCancelation(t *testing.T) {
 	)
 	state = <-statusChan
 	require.Equal(t, state.State, loopdb.StateFailOffchainPayments)
+	require.Equal(
+		t, loopdb.FailureReasonOffchainPayment, state.FailureReason,
+	)
 	require.NoError(t, <-errChan)
 }
+
+// TestValidateLoopOutContract tests validation of the invoices that the server
+// provides for a loop out swap.
+func TestValidateLoopOutContract(t *testing.T) {
+	lnd := test.NewMockLnd()
+
+	hash := lntypes.Hash(sha256.Sum256(testPreimage[:]))
+
+	// The mock's invoices are signed by the same key, so they pay to the
+	// same destination.
+	_, serverPubKey := test.CreateKey(5)
+	var serverDest [33]byte
+	copy(serverDest[:], serverPubKey.SerializeCompressed())
+
+	newResponse := func(swapHash lntypes.Hash, swapAmt,
+		prepayAmt btcutil.Amount) *newLoopOutResponse {
+
+		swapInvoice, err := getInvoice(
+			swapHash, swapAmt, swapInvoiceDesc,
+		)
+		require.NoError(t, err)
+
+		prepayInvoice, err := getInvoice(
+			swapHash, prepayAmt, prepayInvoiceDesc,
+		)
+		require.NoError(t, err)
+
+		return &newLoopOutResponse{
+			swapInvoice:   swapInvoice,
+			prepayInvoice: prepayInvoice,
+		}
+	}
+
+	// newBatchedResponse creates a response in which the server has
+	// included our prepay in the swap invoice.
+	newBatchedResponse := func(swapAmt btcutil.Amount) *newLoopOutResponse {
+		swapInvoice, err := getInvoice(hash, swapAmt, swapInvoiceDesc)
+		require.NoError(t, err)
+
+		return &newLoopOutResponse{
+			swapInvoice:   swapInvoice,
+			batchedPrepay: true,
+		}
+	}
+
+	unexpectedPrepay := newResponse(hash, 50950, 100)
+	unexpectedPrepay.batchedPrepay = true
+
+	tests := []struct {
+		name     string
+		dest     [33]byte
+		response *newLoopOutResponse
+		err      error
+	}{
+		{
+			name:     "valid contract",
+			response: newResponse(hash, 50950, 100),
+		},
+		{
+			name:     "valid contract with quoted destination",
+			dest:     serverDest,
+			response: newResponse(hash, 50950, 100),
+		},
+		{
+			name:     "swap hash mismatch",
+			response: newResponse(lntypes.Hash{1}, 50950, 100),
+			err:      ErrInvoiceHashMismatch,
+		},
+		{
+			name:     "amount does not cover swap",
+			response: newResponse(hash, 40000, 100),
+			err:      ErrInvoiceAmountMismatch,
+		},
+		{
+			name:     "destination mismatch",
+			dest:     [33]byte{1, 2, 3},
+			response: newResponse(hash, 50950, 100),
+			err:      ErrInvoiceDestMismatch,
+		},
+		{
+			name:     "valid batched prepay",
+			dest:     serverDest,
+			response: newBatchedResponse(51050),
+		},
+		{
+			name:     "batched prepay does not cover swap",
+			response: newBatchedResponse(40000),
+			err:      ErrInvoiceAmountMismatch,
+		},
+		{
+			name:     "batched prepay with prepay invoice",
+			response: unexpectedPrepay,
+			err:      ErrUnexpectedPrepayInvoice,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			request := *testRequest
+			request.SwapPaymentDest = testCase.dest
+
+			err := validateLoopOutContract(
+				&lnd.LndServices, lnd.Height, &request, hash,
+				testCase.response,
+			)
+			require.True(t, errors.Is(err, testCase.err))
+		})
+	}
+}
+
+// TestLoopOutMaxParts tests that a swap's requested maximum number of payment
+// parts takes precedence over our configured maximum.
+func TestLoopOutMaxParts(t *testing.T) {
+	swap := &loopOutSwap{
+		executeConfig: executeConfig{
+			loopOutMaxParts: 5,
+		},
+	}
+	require.Equal(t, uint32(5), swap.maxParts())
+
+	swap.MaxParts = 20
+	require.Equal(t, uint32(20), swap.maxParts())
+}
+
+// TestLoopOutPriority tests that a swap's priority scales our default payment
+// timeout, fee bumping schedule and republish delay, and that an explicitly
+// requested payment timeout is not scaled.
+func TestLoopOutPriority(t *testing.T) {
+	tests := []struct {
+		name           string
+		priority       loopdb.SwapPriority
+		paymentTimeout time.Duration
+		bumpBlocks     int32
+		republishDelay time.Duration
+	}{
+		{
+			name:           "normal",
+			priority:       loopdb.PriorityNormal,
+			paymentTimeout: time.Minute * 10,
+			bumpBlocks:     3,
+			republishDelay: republishDelay,
+		},
+		{
+			name:           "low",
+			priority:       loopdb.PriorityLow,
+			paymentTimeout: time.Minute * 5,
+			bumpBlocks:     6,
+			republishDelay: republishDelay * 2,
+		},
+		{
+			name:           "urgent",
+			priority:       loopdb.PriorityUrgent,
+			paymentTimeout: time.Minute * 20,
+			bumpBlocks:     2,
+			republishDelay: republishDelay / 2,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			swap := &loopOutSwap{
+				executeConfig: executeConfig{
+					loopOutPaymentTimeout: time.Minute * 10,
+					sweepFeeBumpBlocks:    3,
+				},
+			}
+			swap.Priority = testCase.priority
+
+			require.Equal(
+				t, testCase.paymentTimeout,
+				swap.paymentTimeout(),
+			)
+			require.Equal(
+				t, testCase.bumpBlocks, swap.feeBumpBlocks(),
+			)
+			require.Equal(
+				t, testCase.republishDelay,
+				swap.republishDelay(),
+			)
+
+			swap.PaymentTimeout = time.Hour
+			require.Equal(t, time.Hour, swap.paymentTimeout())
+		})
+	}
+}
+
+// TestFeeRateForFee tests that the fee rate we derive from a fee never pays
+// more than the fee it was derived from.
+func TestFeeRateForFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		fee      btcutil.Amount
+		weight   int64
+		expected chainfee.SatPerKWeight
+	}{
+		{
+			name:     "exact",
+			fee:      500,
+			weight:   500,
+			expected: 1000,
+		},
+		{
+			name:     "rounded down",
+			fee:      1000,
+			weight:   600,
+			expected: 1666,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			feeRate := feeRateForFee(testCase.fee, testCase.weight)
+			require.Equal(t, testCase.expected, feeRate)
+			require.LessOrEqual(
+				t, int64(feeRate.FeeForWeight(testCase.weight)),
+				int64(testCase.fee),
+			)
+		})
+	}
+}
+
+// TestLoopOutDeterministic tests that a loop out swap that is created with a
+// test clock and a fixed source of randomness has a predictable preimage and
+// initiation time.
+func TestLoopOutDeterministic(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	server := newServerMock(lnd)
+	store := newStoreMock(t)
+
+	startTime := time.Unix(1000, 0)
+
+	cfg := newSwapConfig(&lnd.LndServices, store, server)
+	cfg.clock = clock.NewTestClock(startTime)
+	cfg.rand = bytes.NewReader(testPreimage[:])
+
+	height := int32(600)
+
+	req := *testRequest
+	req.Expiry = height + testLoopOutMinOnChainCltvDelta
+
+	initResult, err := newLoopOutSwap(
+		context.Background(), cfg, height, &req,
+	)
+	require.NoError(t, err)
+
+	swap := initResult.swap
+	require.Equal(t, testPreimage, swap.Preimage)
+	require.Equal(
+		t, lntypes.Hash(sha256.Sum256(testPreimage[:])), swap.hash,
+	)
+	require.Equal(t, startTime, swap.InitiationTime)
+	require.Equal(t, startTime, swap.lastUpdateTime)
+}
@@ -0,0 +1,66 @@
+package looprpc
+
+import "fmt"
+
+// These constants define the version of the SwapClient rpc API, which is
+// versioned independently of the daemon so that integrations can detect the
+// features that are available to them. The version follows the semantic
+// versioning 2.0.0 spec (http://semver.org/):
+//   - The minor version is increased when rpcs, fields or enum values are
+//     added.
+//   - The major version is increased when rpcs, fields or enum values are
+//     removed, or their semantics change in a way that is not backwards
+//     compatible. The numbers and names of removed fields must be reserved in
+//     their message so that they are never reused.
+//   - The patch version is increased for changes that do not affect the wire
+//     format or semantics of the API, such as documentation fixes.
+const (
+	APIVersionMajor uint32 = 1
+	APIVersionMinor uint32 = 8
+	APIVersionPatch uint32 = 0
+)
+
+// APIVersion returns the version of the rpc API as a semantic version string.
+func APIVersion() string {
+	return fmt.Sprintf("%d.%d.%d", APIVersionMajor, APIVersionMinor,
+		APIVersionPatch)
+}
+
+// DeprecationInfo holds the metadata of an element of the rpc API that is
+// deprecated. It is named so that it does not clash with the Deprecation
+// message that reports this metadata over rpc.
+type DeprecationInfo struct {
+	// Replacement is the fully qualified name of the element that should
+	// be used instead of the deprecated element. It is empty if the
+	// element has no replacement.
+	Replacement string
+
+	// RemovalMajor is the major API version in which the element will be
+	// removed.
+	RemovalMajor uint32
+}
+
+// Deprecations holds the deprecation metadata of every element of the rpc API
+// that has the deprecated option set, keyed by its fully qualified name.
+var Deprecations = map[string]DeprecationInfo{
+	"looprpc.LoopOutRequest.loop_out_channel": {
+		Replacement:  "looprpc.LoopOutRequest.outgoing_chan_set",
+		RemovalMajor: 2,
+	},
+	"looprpc.SwapResponse.id": {
+		Replacement:  "looprpc.SwapResponse.id_bytes",
+		RemovalMajor: 2,
+	},
+	"looprpc.SwapResponse.htlc_address": {
+		Replacement:  "looprpc.SwapResponse.htlc_address_p2wsh",
+		RemovalMajor: 2,
+	},
+	"looprpc.SwapStatus.id": {
+		Replacement:  "looprpc.SwapStatus.id_bytes",
+		RemovalMajor: 2,
+	},
+	"looprpc.SwapStatus.htlc_address": {
+		Replacement:  "looprpc.SwapStatus.htlc_address_p2wsh",
+		RemovalMajor: 2,
+	},
+}
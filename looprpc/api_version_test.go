@@ -0,0 +1,85 @@
+package looprpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// deprecatedOption is implemented by the options of all descriptors that may
+// be deprecated.
+type deprecatedOption interface {
+	GetDeprecated() bool
+}
+
+// isDeprecated returns a boolean indicating whether the descriptor provided
+// has the deprecated option set.
+func isDeprecated(desc protoreflect.Descriptor) bool {
+	opts, ok := desc.Options().(deprecatedOption)
+	return ok && opts.GetDeprecated()
+}
+
+// deprecatedElements returns the fully qualified names of all fields, enum
+// values and methods in the file provided that have the deprecated option
+// set.
+func deprecatedElements(file protoreflect.FileDescriptor) []string {
+	var deprecated []string
+
+	add := func(desc protoreflect.Descriptor) {
+		if isDeprecated(desc) {
+			deprecated = append(deprecated, string(desc.FullName()))
+		}
+	}
+
+	addEnums := func(enums protoreflect.EnumDescriptors) {
+		for i := 0; i < enums.Len(); i++ {
+			values := enums.Get(i).Values()
+			for j := 0; j < values.Len(); j++ {
+				add(values.Get(j))
+			}
+		}
+	}
+
+	var addMessages func(messages protoreflect.MessageDescriptors)
+	addMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			msg := messages.Get(i)
+
+			fields := msg.Fields()
+			for j := 0; j < fields.Len(); j++ {
+				add(fields.Get(j))
+			}
+
+			addEnums(msg.Enums())
+			addMessages(msg.Messages())
+		}
+	}
+
+	addEnums(file.Enums())
+	addMessages(file.Messages())
+
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			add(methods.Get(j))
+		}
+	}
+
+	return deprecated
+}
+
+// TestDeprecations tests that every deprecated element of the client API has
+// deprecation metadata, and that we have no metadata for elements that are
+// not deprecated.
+func TestDeprecations(t *testing.T) {
+	deprecated := deprecatedElements(File_client_proto)
+
+	expected := make([]string, 0, len(Deprecations))
+	for name := range Deprecations {
+		expected = append(expected, name)
+	}
+
+	require.ElementsMatch(t, expected, deprecated)
+}
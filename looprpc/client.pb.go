@@ -29,6 +29,118 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type SwapPriority int32
+
+const (
+	//
+	//PRIORITY_NORMAL is the default priority for swaps, which uses loopd's
+	//configured payment timeout and fee bumping schedule.
+	SwapPriority_PRIORITY_NORMAL SwapPriority = 0
+	//
+	//PRIORITY_LOW is for swaps that are not time sensitive. The swap gives up
+	//on its off-chain payments sooner, and bumps its sweep fee and republishes
+	//its sweep less often. Autoloop dispatches swaps with this priority.
+	SwapPriority_PRIORITY_LOW SwapPriority = 1
+	//
+	//PRIORITY_URGENT is for swaps that should complete as soon as possible. The
+	//swap retries its off-chain payments for longer, sweeps with a low
+	//confirmation target, and bumps its sweep fee and republishes its sweep more
+	//often.
+	SwapPriority_PRIORITY_URGENT SwapPriority = 2
+)
+
+// Enum value maps for SwapPriority.
+var (
+	SwapPriority_name = map[int32]string{
+		0: "PRIORITY_NORMAL",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_URGENT",
+	}
+	SwapPriority_value = map[string]int32{
+		"PRIORITY_NORMAL": 0,
+		"PRIORITY_LOW":    1,
+		"PRIORITY_URGENT": 2,
+	}
+)
+
+func (x SwapPriority) Enum() *SwapPriority {
+	p := new(SwapPriority)
+	*p = x
+	return p
+}
+
+func (x SwapPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwapPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[0].Descriptor()
+}
+
+func (SwapPriority) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[0]
+}
+
+func (x SwapPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwapPriority.Descriptor instead.
+func (SwapPriority) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{0}
+}
+
+type HtlcVersion int32
+
+const (
+	//
+	//HTLC_VERSION_V1 is the original version of the HTLC script.
+	HtlcVersion_HTLC_VERSION_V1 HtlcVersion = 0
+	//
+	//HTLC_VERSION_V2 is the improved version of the HTLC script, which is used
+	//by all swaps created with protocol version HTLC_V2 or later.
+	HtlcVersion_HTLC_VERSION_V2 HtlcVersion = 1
+)
+
+// Enum value maps for HtlcVersion.
+var (
+	HtlcVersion_name = map[int32]string{
+		0: "HTLC_VERSION_V1",
+		1: "HTLC_VERSION_V2",
+	}
+	HtlcVersion_value = map[string]int32{
+		"HTLC_VERSION_V1": 0,
+		"HTLC_VERSION_V2": 1,
+	}
+)
+
+func (x HtlcVersion) Enum() *HtlcVersion {
+	p := new(HtlcVersion)
+	*p = x
+	return p
+}
+
+func (x HtlcVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HtlcVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[1].Descriptor()
+}
+
+func (HtlcVersion) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[1]
+}
+
+func (x HtlcVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HtlcVersion.Descriptor instead.
+func (HtlcVersion) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{1}
+}
+
 type SwapType int32
 
 const (
@@ -61,11 +173,11 @@ func (x SwapType) String() string {
 }
 
 func (SwapType) Descriptor() protoreflect.EnumDescriptor {
-	return file_client_proto_enumTypes[0].Descriptor()
+	return file_client_proto_enumTypes[2].Descriptor()
 }
 
 func (SwapType) Type() protoreflect.EnumType {
-	return &file_client_proto_enumTypes[0]
+	return &file_client_proto_enumTypes[2]
 }
 
 func (x SwapType) Number() protoreflect.EnumNumber {
@@ -74,7 +186,7 @@ func (x SwapType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SwapType.Descriptor instead.
 func (SwapType) EnumDescriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{0}
+	return file_client_proto_rawDescGZIP(), []int{2}
 }
 
 type SwapState int32
@@ -141,11 +253,11 @@ func (x SwapState) String() string {
 }
 
 func (SwapState) Descriptor() protoreflect.EnumDescriptor {
-	return file_client_proto_enumTypes[1].Descriptor()
+	return file_client_proto_enumTypes[3].Descriptor()
 }
 
 func (SwapState) Type() protoreflect.EnumType {
-	return &file_client_proto_enumTypes[1]
+	return &file_client_proto_enumTypes[3]
 }
 
 func (x SwapState) Number() protoreflect.EnumNumber {
@@ -154,7 +266,7 @@ func (x SwapState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SwapState.Descriptor instead.
 func (SwapState) EnumDescriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{1}
+	return file_client_proto_rawDescGZIP(), []int{3}
 }
 
 type FailureReason int32
@@ -191,6 +303,10 @@ const (
 	//FAILURE_REASON_INCORRECT_AMOUNT indicates that a loop in permanently failed
 	//because the amount extended by an external loop in htlc is insufficient.
 	FailureReason_FAILURE_REASON_INCORRECT_AMOUNT FailureReason = 6
+	//
+	//FAILURE_REASON_ABANDONED indicates that the swap was permanently stuck and
+	//was manually abandoned by the user.
+	FailureReason_FAILURE_REASON_ABANDONED FailureReason = 7
 )
 
 // Enum value maps for FailureReason.
@@ -203,6 +319,7 @@ var (
 		4: "FAILURE_REASON_INSUFFICIENT_VALUE",
 		5: "FAILURE_REASON_TEMPORARY",
 		6: "FAILURE_REASON_INCORRECT_AMOUNT",
+		7: "FAILURE_REASON_ABANDONED",
 	}
 	FailureReason_value = map[string]int32{
 		"FAILURE_REASON_NONE":               0,
@@ -212,6 +329,7 @@ var (
 		"FAILURE_REASON_INSUFFICIENT_VALUE": 4,
 		"FAILURE_REASON_TEMPORARY":          5,
 		"FAILURE_REASON_INCORRECT_AMOUNT":   6,
+		"FAILURE_REASON_ABANDONED":          7,
 	}
 )
 
@@ -226,11 +344,11 @@ func (x FailureReason) String() string {
 }
 
 func (FailureReason) Descriptor() protoreflect.EnumDescriptor {
-	return file_client_proto_enumTypes[2].Descriptor()
+	return file_client_proto_enumTypes[4].Descriptor()
 }
 
 func (FailureReason) Type() protoreflect.EnumType {
-	return &file_client_proto_enumTypes[2]
+	return &file_client_proto_enumTypes[4]
 }
 
 func (x FailureReason) Number() protoreflect.EnumNumber {
@@ -239,7 +357,179 @@ func (x FailureReason) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FailureReason.Descriptor instead.
 func (FailureReason) EnumDescriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{2}
+	return file_client_proto_rawDescGZIP(), []int{4}
+}
+
+type ServerFailureReason int32
+
+const (
+	//
+	//SERVER_FAILURE_REASON_NONE is set when the server did not report a failure
+	//for the swap.
+	ServerFailureReason_SERVER_FAILURE_REASON_NONE ServerFailureReason = 0
+	//
+	//SERVER_FAILURE_REASON_UNKNOWN indicates that the server reported a failure
+	//without a specific reason.
+	ServerFailureReason_SERVER_FAILURE_REASON_UNKNOWN ServerFailureReason = 1
+	//
+	//SERVER_FAILURE_REASON_NO_HTLC indicates that no loop in htlc confirmed in
+	//time for the server to complete the swap.
+	ServerFailureReason_SERVER_FAILURE_REASON_NO_HTLC ServerFailureReason = 2
+	//
+	//SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT indicates that a loop in htlc
+	//confirmed, but did not have the amount that the server expected.
+	ServerFailureReason_SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT ServerFailureReason = 3
+	//
+	//SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT indicates that the server could not
+	//complete a loop in's off chain payment before its timeout.
+	ServerFailureReason_SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT ServerFailureReason = 4
+	//
+	//SERVER_FAILURE_REASON_TIMEOUT indicates that the server claimed, or
+	//published its claim of, the on chain timeout of the swap's htlc.
+	ServerFailureReason_SERVER_FAILURE_REASON_TIMEOUT ServerFailureReason = 5
+	//
+	//SERVER_FAILURE_REASON_SWAP_DEADLINE indicates that the server could not
+	//publish a loop out htlc before the swap's publication deadline.
+	ServerFailureReason_SERVER_FAILURE_REASON_SWAP_DEADLINE ServerFailureReason = 6
+	//
+	//SERVER_FAILURE_REASON_HTLC_PUBLICATION indicates that the server could not
+	//publish a loop out htlc.
+	ServerFailureReason_SERVER_FAILURE_REASON_HTLC_PUBLICATION ServerFailureReason = 7
+)
+
+// Enum value maps for ServerFailureReason.
+var (
+	ServerFailureReason_name = map[int32]string{
+		0: "SERVER_FAILURE_REASON_NONE",
+		1: "SERVER_FAILURE_REASON_UNKNOWN",
+		2: "SERVER_FAILURE_REASON_NO_HTLC",
+		3: "SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT",
+		4: "SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT",
+		5: "SERVER_FAILURE_REASON_TIMEOUT",
+		6: "SERVER_FAILURE_REASON_SWAP_DEADLINE",
+		7: "SERVER_FAILURE_REASON_HTLC_PUBLICATION",
+	}
+	ServerFailureReason_value = map[string]int32{
+		"SERVER_FAILURE_REASON_NONE":                0,
+		"SERVER_FAILURE_REASON_UNKNOWN":             1,
+		"SERVER_FAILURE_REASON_NO_HTLC":             2,
+		"SERVER_FAILURE_REASON_INVALID_HTLC_AMOUNT": 3,
+		"SERVER_FAILURE_REASON_OFFCHAIN_TIMEOUT":    4,
+		"SERVER_FAILURE_REASON_TIMEOUT":             5,
+		"SERVER_FAILURE_REASON_SWAP_DEADLINE":       6,
+		"SERVER_FAILURE_REASON_HTLC_PUBLICATION":    7,
+	}
+)
+
+func (x ServerFailureReason) Enum() *ServerFailureReason {
+	p := new(ServerFailureReason)
+	*p = x
+	return p
+}
+
+func (x ServerFailureReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerFailureReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[5].Descriptor()
+}
+
+func (ServerFailureReason) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[5]
+}
+
+func (x ServerFailureReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerFailureReason.Descriptor instead.
+func (ServerFailureReason) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{5}
+}
+
+type SwapFailureReason int32
+
+const (
+	//
+	//SWAP_FAILURE_REASON_NONE is set when the swap did not fail, or the client
+	//did not record a reason for its failure.
+	SwapFailureReason_SWAP_FAILURE_REASON_NONE SwapFailureReason = 0
+	//
+	//SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT indicates that a loop out failed
+	//because its off chain swap payment failed.
+	SwapFailureReason_SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT SwapFailureReason = 1
+	//
+	//SWAP_FAILURE_REASON_HTLC_TIMEOUT indicates that the swap's on chain htlc
+	//was not confirmed or claimed before its timeout.
+	SwapFailureReason_SWAP_FAILURE_REASON_HTLC_TIMEOUT SwapFailureReason = 2
+	//
+	//SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED indicates that a loop out failed
+	//because the fee required to sweep its htlc exceeded the swap's maximum
+	//miner fee until it was too late to reveal the preimage.
+	SwapFailureReason_SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED SwapFailureReason = 3
+	//
+	//SWAP_FAILURE_REASON_SERVER_REJECTED indicates that the swap failed after
+	//the server reported that it would not complete the swap.
+	SwapFailureReason_SWAP_FAILURE_REASON_SERVER_REJECTED SwapFailureReason = 4
+	//
+	//SWAP_FAILURE_REASON_PREPAY_FAILED indicates that a loop out failed because
+	//its off chain prepay payment failed.
+	SwapFailureReason_SWAP_FAILURE_REASON_PREPAY_FAILED SwapFailureReason = 5
+	//
+	//SWAP_FAILURE_REASON_PREPAY_RELEASED indicates that a loop out was abandoned
+	//before the server published its htlc, and that the server released the
+	//swap's prepay rather than settling it.
+	SwapFailureReason_SWAP_FAILURE_REASON_PREPAY_RELEASED SwapFailureReason = 6
+)
+
+// Enum value maps for SwapFailureReason.
+var (
+	SwapFailureReason_name = map[int32]string{
+		0: "SWAP_FAILURE_REASON_NONE",
+		1: "SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT",
+		2: "SWAP_FAILURE_REASON_HTLC_TIMEOUT",
+		3: "SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED",
+		4: "SWAP_FAILURE_REASON_SERVER_REJECTED",
+		5: "SWAP_FAILURE_REASON_PREPAY_FAILED",
+		6: "SWAP_FAILURE_REASON_PREPAY_RELEASED",
+	}
+	SwapFailureReason_value = map[string]int32{
+		"SWAP_FAILURE_REASON_NONE":               0,
+		"SWAP_FAILURE_REASON_OFFCHAIN_PAYMENT":   1,
+		"SWAP_FAILURE_REASON_HTLC_TIMEOUT":       2,
+		"SWAP_FAILURE_REASON_SWEEP_FEE_EXCEEDED": 3,
+		"SWAP_FAILURE_REASON_SERVER_REJECTED":    4,
+		"SWAP_FAILURE_REASON_PREPAY_FAILED":      5,
+		"SWAP_FAILURE_REASON_PREPAY_RELEASED":    6,
+	}
+)
+
+func (x SwapFailureReason) Enum() *SwapFailureReason {
+	p := new(SwapFailureReason)
+	*p = x
+	return p
+}
+
+func (x SwapFailureReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwapFailureReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[6].Descriptor()
+}
+
+func (SwapFailureReason) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[6]
+}
+
+func (x SwapFailureReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwapFailureReason.Descriptor instead.
+func (SwapFailureReason) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{6}
 }
 
 type LiquidityRuleType int32
@@ -247,6 +537,11 @@ type LiquidityRuleType int32
 const (
 	LiquidityRuleType_UNKNOWN   LiquidityRuleType = 0
 	LiquidityRuleType_THRESHOLD LiquidityRuleType = 1
+	//
+	//TARGET rules keep a peer's local balance within a tolerance band around a
+	//target, suggesting loop outs when local balance rises above the band and
+	//loop ins when it falls below it. Target rules may only be set for peers.
+	LiquidityRuleType_TARGET LiquidityRuleType = 2
 )
 
 // Enum value maps for LiquidityRuleType.
@@ -254,10 +549,12 @@ var (
 	LiquidityRuleType_name = map[int32]string{
 		0: "UNKNOWN",
 		1: "THRESHOLD",
+		2: "TARGET",
 	}
 	LiquidityRuleType_value = map[string]int32{
 		"UNKNOWN":   0,
 		"THRESHOLD": 1,
+		"TARGET":    2,
 	}
 )
 
@@ -272,11 +569,11 @@ func (x LiquidityRuleType) String() string {
 }
 
 func (LiquidityRuleType) Descriptor() protoreflect.EnumDescriptor {
-	return file_client_proto_enumTypes[3].Descriptor()
+	return file_client_proto_enumTypes[7].Descriptor()
 }
 
 func (LiquidityRuleType) Type() protoreflect.EnumType {
-	return &file_client_proto_enumTypes[3]
+	return &file_client_proto_enumTypes[7]
 }
 
 func (x LiquidityRuleType) Number() protoreflect.EnumNumber {
@@ -285,7 +582,60 @@ func (x LiquidityRuleType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LiquidityRuleType.Descriptor instead.
 func (LiquidityRuleType) EnumDescriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{3}
+	return file_client_proto_rawDescGZIP(), []int{7}
+}
+
+type StaleReason int32
+
+const (
+	//
+	//STALE_REASON_CLOSED indicates that a channel rule's channel is not open,
+	//or that there are no open channels with a peer rule's peer.
+	StaleReason_STALE_REASON_CLOSED StaleReason = 0
+	//
+	//STALE_REASON_DISCONNECTED indicates that a channel rule's channel is open
+	//but inactive, or that none of the open channels with a peer rule's peer
+	//are active.
+	StaleReason_STALE_REASON_DISCONNECTED StaleReason = 1
+)
+
+// Enum value maps for StaleReason.
+var (
+	StaleReason_name = map[int32]string{
+		0: "STALE_REASON_CLOSED",
+		1: "STALE_REASON_DISCONNECTED",
+	}
+	StaleReason_value = map[string]int32{
+		"STALE_REASON_CLOSED":       0,
+		"STALE_REASON_DISCONNECTED": 1,
+	}
+)
+
+func (x StaleReason) Enum() *StaleReason {
+	p := new(StaleReason)
+	*p = x
+	return p
+}
+
+func (x StaleReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StaleReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[8].Descriptor()
+}
+
+func (StaleReason) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[8]
+}
+
+func (x StaleReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StaleReason.Descriptor instead.
+func (StaleReason) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{8}
 }
 
 type AutoReason int32
@@ -343,6 +693,56 @@ const (
 	//Fee insufficient indicates that the fee estimate for a swap is higher than
 	//the portion of total swap amount that we allow fees to consume.
 	AutoReason_AUTO_REASON_FEE_INSUFFICIENT AutoReason = 13
+	//
+	//Locked value indicates that a swap would take the total value locked in
+	//pending swaps above the maximum configured for loopd.
+	AutoReason_AUTO_REASON_LOCKED_VALUE AutoReason = 14
+	//
+	//Rebalance cheaper indicates that we did not perform a swap because a
+	//circular rebalance would shift the same balance for much lower fees.
+	AutoReason_AUTO_REASON_REBALANCE_CHEAPER AutoReason = 15
+	//
+	//Disqualified indicates that the channel or peer has been disqualified from
+	//swaps in the liquidity parameters.
+	AutoReason_AUTO_REASON_DISQUALIFIED AutoReason = 16
+	//
+	//Min amount indicates that the amount we would need to swap to meet a rule's
+	//thresholds is below the server's minimum swap amount.
+	AutoReason_AUTO_REASON_MIN_AMOUNT AutoReason = 17
+	//
+	//No channel indicates that we do not currently have any open channels that a
+	//rule applies to.
+	AutoReason_AUTO_REASON_NO_CHANNEL AutoReason = 18
+	//
+	//Cooldown indicates that the channel or peer was part of a successful swap
+	//recently, and the swap cooldown period has not yet passed.
+	AutoReason_AUTO_REASON_COOLDOWN AutoReason = 19
+	//
+	//Transient imbalance indicates that the rule's thresholds are breached, but
+	//have not been breached for the rule's minimum imbalance duration.
+	AutoReason_AUTO_REASON_TRANSIENT_IMBALANCE AutoReason = 20
+	//
+	//Reputation indicates that the swap is large enough to require a minimum
+	//peer reputation, and the peer that it would be routed through does not
+	//have a sufficient reputation.
+	AutoReason_AUTO_REASON_REPUTATION AutoReason = 21
+	//
+	//Outside schedule indicates that autoloop does not dispatch swaps at the
+	//current time, because it is outside of the configured schedule.
+	AutoReason_AUTO_REASON_OUTSIDE_SCHEDULE AutoReason = 22
+	//
+	//Loop in wallet fraction indicates that a loop in would commit more than
+	//the maximum fraction of the node's on-chain funds configured for loopd to
+	//pending loop ins.
+	AutoReason_AUTO_REASON_LOOP_IN_WALLET_FRACTION AutoReason = 23
+	//
+	//Rule budget indicates that a swap's worst-case fees exceed the remaining
+	//fee budget of the rule that it was suggested for.
+	AutoReason_AUTO_REASON_RULE_BUDGET AutoReason = 24
+	//
+	//Inactive indicates that the channel, or all of the channels with the peer,
+	//are inactive, and inactive channels are excluded from autoloop.
+	AutoReason_AUTO_REASON_INACTIVE AutoReason = 25
 )
 
 // Enum value maps for AutoReason.
@@ -362,22 +762,46 @@ var (
 		11: "AUTO_REASON_LIQUIDITY_OK",
 		12: "AUTO_REASON_BUDGET_INSUFFICIENT",
 		13: "AUTO_REASON_FEE_INSUFFICIENT",
+		14: "AUTO_REASON_LOCKED_VALUE",
+		15: "AUTO_REASON_REBALANCE_CHEAPER",
+		16: "AUTO_REASON_DISQUALIFIED",
+		17: "AUTO_REASON_MIN_AMOUNT",
+		18: "AUTO_REASON_NO_CHANNEL",
+		19: "AUTO_REASON_COOLDOWN",
+		20: "AUTO_REASON_TRANSIENT_IMBALANCE",
+		21: "AUTO_REASON_REPUTATION",
+		22: "AUTO_REASON_OUTSIDE_SCHEDULE",
+		23: "AUTO_REASON_LOOP_IN_WALLET_FRACTION",
+		24: "AUTO_REASON_RULE_BUDGET",
+		25: "AUTO_REASON_INACTIVE",
 	}
 	AutoReason_value = map[string]int32{
-		"AUTO_REASON_UNKNOWN":             0,
-		"AUTO_REASON_BUDGET_NOT_STARTED":  1,
-		"AUTO_REASON_SWEEP_FEES":          2,
-		"AUTO_REASON_BUDGET_ELAPSED":      3,
-		"AUTO_REASON_IN_FLIGHT":           4,
-		"AUTO_REASON_SWAP_FEE":            5,
-		"AUTO_REASON_MINER_FEE":           6,
-		"AUTO_REASON_PREPAY":              7,
-		"AUTO_REASON_FAILURE_BACKOFF":     8,
-		"AUTO_REASON_LOOP_OUT":            9,
-		"AUTO_REASON_LOOP_IN":             10,
-		"AUTO_REASON_LIQUIDITY_OK":        11,
-		"AUTO_REASON_BUDGET_INSUFFICIENT": 12,
-		"AUTO_REASON_FEE_INSUFFICIENT":    13,
+		"AUTO_REASON_UNKNOWN":                 0,
+		"AUTO_REASON_BUDGET_NOT_STARTED":      1,
+		"AUTO_REASON_SWEEP_FEES":              2,
+		"AUTO_REASON_BUDGET_ELAPSED":          3,
+		"AUTO_REASON_IN_FLIGHT":               4,
+		"AUTO_REASON_SWAP_FEE":                5,
+		"AUTO_REASON_MINER_FEE":               6,
+		"AUTO_REASON_PREPAY":                  7,
+		"AUTO_REASON_FAILURE_BACKOFF":         8,
+		"AUTO_REASON_LOOP_OUT":                9,
+		"AUTO_REASON_LOOP_IN":                 10,
+		"AUTO_REASON_LIQUIDITY_OK":            11,
+		"AUTO_REASON_BUDGET_INSUFFICIENT":     12,
+		"AUTO_REASON_FEE_INSUFFICIENT":        13,
+		"AUTO_REASON_LOCKED_VALUE":            14,
+		"AUTO_REASON_REBALANCE_CHEAPER":       15,
+		"AUTO_REASON_DISQUALIFIED":            16,
+		"AUTO_REASON_MIN_AMOUNT":              17,
+		"AUTO_REASON_NO_CHANNEL":              18,
+		"AUTO_REASON_COOLDOWN":                19,
+		"AUTO_REASON_TRANSIENT_IMBALANCE":     20,
+		"AUTO_REASON_REPUTATION":              21,
+		"AUTO_REASON_OUTSIDE_SCHEDULE":        22,
+		"AUTO_REASON_LOOP_IN_WALLET_FRACTION": 23,
+		"AUTO_REASON_RULE_BUDGET":             24,
+		"AUTO_REASON_INACTIVE":                25,
 	}
 )
 
@@ -392,11 +816,11 @@ func (x AutoReason) String() string {
 }
 
 func (AutoReason) Descriptor() protoreflect.EnumDescriptor {
-	return file_client_proto_enumTypes[4].Descriptor()
+	return file_client_proto_enumTypes[9].Descriptor()
 }
 
 func (AutoReason) Type() protoreflect.EnumType {
-	return &file_client_proto_enumTypes[4]
+	return &file_client_proto_enumTypes[9]
 }
 
 func (x AutoReason) Number() protoreflect.EnumNumber {
@@ -405,79 +829,332 @@ func (x AutoReason) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AutoReason.Descriptor instead.
 func (AutoReason) EnumDescriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{4}
+	return file_client_proto_rawDescGZIP(), []int{9}
 }
 
-type LoopOutRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type AutoloopEventType int32
 
+const (
 	//
-	//Requested swap amount in sat. This does not include the swap and miner fee.
-	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
-	//
-	//Base58 encoded destination address for the swap.
-	Dest string `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
-	//
-	//Maximum off-chain fee in sat that may be paid for swap payment to the
-	//server. This limit is applied during path finding. Typically this value is
-	//taken from the response of the GetQuote call.
-	MaxSwapRoutingFee int64 `protobuf:"varint,3,opt,name=max_swap_routing_fee,json=maxSwapRoutingFee,proto3" json:"max_swap_routing_fee,omitempty"`
-	//
-	//Maximum off-chain fee in sat that may be paid for the prepay to the server.
-	//This limit is applied during path finding. Typically this value is taken
-	//from the response of the GetQuote call.
-	MaxPrepayRoutingFee int64 `protobuf:"varint,4,opt,name=max_prepay_routing_fee,json=maxPrepayRoutingFee,proto3" json:"max_prepay_routing_fee,omitempty"`
-	//
-	//Maximum we are willing to pay the server for the swap. This value is not
-	//disclosed in the swap initiation call, but if the server asks for a
-	//higher fee, we abort the swap. Typically this value is taken from the
-	//response of the GetQuote call. It includes the prepay amount.
-	MaxSwapFee int64 `protobuf:"varint,5,opt,name=max_swap_fee,json=maxSwapFee,proto3" json:"max_swap_fee,omitempty"`
-	//
-	//Maximum amount of the swap fee that may be charged as a prepayment.
-	MaxPrepayAmt int64 `protobuf:"varint,6,opt,name=max_prepay_amt,json=maxPrepayAmt,proto3" json:"max_prepay_amt,omitempty"`
-	//
-	//Maximum in on-chain fees that we are willing to spend. If we want to
-	//sweep the on-chain htlc and the fee estimate turns out higher than this
-	//value, we cancel the swap. If the fee estimate is lower, we publish the
-	//sweep tx.
-	//
-	//If the sweep tx is not confirmed, we are forced to ratchet up fees until it
-	//is swept. Possibly even exceeding max_miner_fee if we get close to the htlc
-	//timeout. Because the initial publication revealed the preimage, we have no
-	//other choice. The server may already have pulled the off-chain htlc. Only
-	//when the fee becomes higher than the swap amount, we can only wait for fees
-	//to come down and hope - if we are past the timeout - that the server is not
-	//publishing the revocation.
-	//
-	//max_miner_fee is typically taken from the response of the GetQuote call.
-	MaxMinerFee int64 `protobuf:"varint,7,opt,name=max_miner_fee,json=maxMinerFee,proto3" json:"max_miner_fee,omitempty"`
-	//
-	//Deprecated, use outgoing_chan_set. The channel to loop out, the channel
-	//to loop out is selected based on the lowest routing fee for the swap
-	//payment to the server.
+	//An unknown event type, which is never set for recorded events.
+	AutoloopEventType_AUTOLOOP_EVENT_UNKNOWN AutoloopEventType = 0
 	//
-	// Deprecated: Do not use.
-	LoopOutChannel uint64 `protobuf:"varint,8,opt,name=loop_out_channel,json=loopOutChannel,proto3" json:"loop_out_channel,omitempty"`
+	//A channel or peer was excluded from autoloop's suggestions, or the reason
+	//that it was excluded changed.
+	AutoloopEventType_AUTOLOOP_EVENT_DISQUALIFIED AutoloopEventType = 1
 	//
-	//A restriction on the channel set that may be used to loop out. The actual
-	//channel(s) that will be used are selected based on the lowest routing fee
-	//for the swap payment to the server.
-	OutgoingChanSet []uint64 `protobuf:"varint,11,rep,packed,name=outgoing_chan_set,json=outgoingChanSet,proto3" json:"outgoing_chan_set,omitempty"`
+	//A channel or peer that was previously excluded from autoloop's suggestions
+	//is no longer excluded.
+	AutoloopEventType_AUTOLOOP_EVENT_REQUALIFIED AutoloopEventType = 2
 	//
-	//The number of blocks from the on-chain HTLC's confirmation height that it
-	//should be swept within.
-	SweepConfTarget int32 `protobuf:"varint,9,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
+	//Autoloop checks started failing, or the error that they fail with changed.
+	AutoloopEventType_AUTOLOOP_EVENT_CHECK_FAILED AutoloopEventType = 3
 	//
-	//The number of confirmations that we require for the on chain htlc that will
-	//be published by the server before we reveal the preimage.
-	HtlcConfirmations int32 `protobuf:"varint,13,opt,name=htlc_confirmations,json=htlcConfirmations,proto3" json:"htlc_confirmations,omitempty"`
+	//Autoloop checks are no longer failing.
+	AutoloopEventType_AUTOLOOP_EVENT_CHECK_RECOVERED AutoloopEventType = 4
 	//
-	//The latest time (in unix seconds) we allow the server to wait before
-	//publishing the HTLC on chain. Setting this to a larger value will give the
-	//server the opportunity to batch multiple swaps together, and wait for
+	//A swap was automatically dispatched.
+	AutoloopEventType_AUTOLOOP_EVENT_SWAP_DISPATCHED AutoloopEventType = 5
+)
+
+// Enum value maps for AutoloopEventType.
+var (
+	AutoloopEventType_name = map[int32]string{
+		0: "AUTOLOOP_EVENT_UNKNOWN",
+		1: "AUTOLOOP_EVENT_DISQUALIFIED",
+		2: "AUTOLOOP_EVENT_REQUALIFIED",
+		3: "AUTOLOOP_EVENT_CHECK_FAILED",
+		4: "AUTOLOOP_EVENT_CHECK_RECOVERED",
+		5: "AUTOLOOP_EVENT_SWAP_DISPATCHED",
+	}
+	AutoloopEventType_value = map[string]int32{
+		"AUTOLOOP_EVENT_UNKNOWN":         0,
+		"AUTOLOOP_EVENT_DISQUALIFIED":    1,
+		"AUTOLOOP_EVENT_REQUALIFIED":     2,
+		"AUTOLOOP_EVENT_CHECK_FAILED":    3,
+		"AUTOLOOP_EVENT_CHECK_RECOVERED": 4,
+		"AUTOLOOP_EVENT_SWAP_DISPATCHED": 5,
+	}
+)
+
+func (x AutoloopEventType) Enum() *AutoloopEventType {
+	p := new(AutoloopEventType)
+	*p = x
+	return p
+}
+
+func (x AutoloopEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AutoloopEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[10].Descriptor()
+}
+
+func (AutoloopEventType) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[10]
+}
+
+func (x AutoloopEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AutoloopEventType.Descriptor instead.
+func (AutoloopEventType) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{10}
+}
+
+type StatsPeriod int32
+
+const (
+	// DAY aggregates swap statistics per calendar day (UTC).
+	StatsPeriod_DAY StatsPeriod = 0
+	// WEEK aggregates swap statistics per calendar week, starting on Monday
+	// (UTC).
+	StatsPeriod_WEEK StatsPeriod = 1
+	// MONTH aggregates swap statistics per calendar month (UTC).
+	StatsPeriod_MONTH StatsPeriod = 2
+)
+
+// Enum value maps for StatsPeriod.
+var (
+	StatsPeriod_name = map[int32]string{
+		0: "DAY",
+		1: "WEEK",
+		2: "MONTH",
+	}
+	StatsPeriod_value = map[string]int32{
+		"DAY":   0,
+		"WEEK":  1,
+		"MONTH": 2,
+	}
+)
+
+func (x StatsPeriod) Enum() *StatsPeriod {
+	p := new(StatsPeriod)
+	*p = x
+	return p
+}
+
+func (x StatsPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[11].Descriptor()
+}
+
+func (StatsPeriod) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[11]
+}
+
+func (x StatsPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsPeriod.Descriptor instead.
+func (StatsPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{11}
+}
+
+type SwapPhase int32
+
+const (
+	//
+	//SWAP_PHASE_HTLC_CONFIRM is the time taken for a swap's htlc to confirm.
+	//It is measured from initiation for loop out swaps, and from publication of
+	//the htlc for loop in swaps. Loop in swaps with an externally published htlc
+	//do not report this phase.
+	SwapPhase_SWAP_PHASE_HTLC_CONFIRM SwapPhase = 0
+	//
+	//SWAP_PHASE_PAYMENT_SETTLE is the time taken for a swap's off-chain payment
+	//to settle. It is measured from initiation for loop out swaps, and from
+	//confirmation of the htlc for loop in swaps.
+	SwapPhase_SWAP_PHASE_PAYMENT_SETTLE SwapPhase = 1
+	//
+	//SWAP_PHASE_SWEEP_CONFIRM is the time between confirmation of a swap's htlc
+	//and confirmation of the transaction that successfully sweeps it.
+	SwapPhase_SWAP_PHASE_SWEEP_CONFIRM SwapPhase = 2
+)
+
+// Enum value maps for SwapPhase.
+var (
+	SwapPhase_name = map[int32]string{
+		0: "SWAP_PHASE_HTLC_CONFIRM",
+		1: "SWAP_PHASE_PAYMENT_SETTLE",
+		2: "SWAP_PHASE_SWEEP_CONFIRM",
+	}
+	SwapPhase_value = map[string]int32{
+		"SWAP_PHASE_HTLC_CONFIRM":   0,
+		"SWAP_PHASE_PAYMENT_SETTLE": 1,
+		"SWAP_PHASE_SWEEP_CONFIRM":  2,
+	}
+)
+
+func (x SwapPhase) Enum() *SwapPhase {
+	p := new(SwapPhase)
+	*p = x
+	return p
+}
+
+func (x SwapPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwapPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[12].Descriptor()
+}
+
+func (SwapPhase) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[12]
+}
+
+func (x SwapPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwapPhase.Descriptor instead.
+func (SwapPhase) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{12}
+}
+
+type DepositState int32
+
+const (
+	//
+	//DEPOSIT_CONFIRMED indicates that a deposit has confirmed, but no swap has
+	//been dispatched for it yet. Swaps that fail to be dispatched are retried.
+	DepositState_DEPOSIT_CONFIRMED DepositState = 0
+	//
+	//DEPOSIT_SWAP_INITIATED indicates that a loop in swap has been dispatched
+	//for the deposit.
+	DepositState_DEPOSIT_SWAP_INITIATED DepositState = 1
+	//
+	//DEPOSIT_TOO_SMALL indicates that the deposit was too small to be swapped
+	//after fees, so it was left in the wallet.
+	DepositState_DEPOSIT_TOO_SMALL DepositState = 2
+	//
+	//DEPOSIT_TOO_LARGE indicates that the deposit exceeds the server's maximum
+	//swap amount, so it was left in the wallet.
+	DepositState_DEPOSIT_TOO_LARGE DepositState = 3
+)
+
+// Enum value maps for DepositState.
+var (
+	DepositState_name = map[int32]string{
+		0: "DEPOSIT_CONFIRMED",
+		1: "DEPOSIT_SWAP_INITIATED",
+		2: "DEPOSIT_TOO_SMALL",
+		3: "DEPOSIT_TOO_LARGE",
+	}
+	DepositState_value = map[string]int32{
+		"DEPOSIT_CONFIRMED":      0,
+		"DEPOSIT_SWAP_INITIATED": 1,
+		"DEPOSIT_TOO_SMALL":      2,
+		"DEPOSIT_TOO_LARGE":      3,
+	}
+)
+
+func (x DepositState) Enum() *DepositState {
+	p := new(DepositState)
+	*p = x
+	return p
+}
+
+func (x DepositState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DepositState) Descriptor() protoreflect.EnumDescriptor {
+	return file_client_proto_enumTypes[13].Descriptor()
+}
+
+func (DepositState) Type() protoreflect.EnumType {
+	return &file_client_proto_enumTypes[13]
+}
+
+func (x DepositState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DepositState.Descriptor instead.
+func (DepositState) EnumDescriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{13}
+}
+
+type LoopOutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Requested swap amount in sat. This does not include the swap and miner fee.
+	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//Destination address for the swap, which must be a p2wkh or p2wsh address
+	//for the network that loopd is running on.
+	Dest string `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
+	//
+	//Maximum off-chain fee in sat that may be paid for swap payment to the
+	//server. This limit is applied during path finding. Typically this value is
+	//taken from the response of the GetQuote call.
+	MaxSwapRoutingFee int64 `protobuf:"varint,3,opt,name=max_swap_routing_fee,json=maxSwapRoutingFee,proto3" json:"max_swap_routing_fee,omitempty"`
+	//
+	//Maximum off-chain fee in sat that may be paid for the prepay to the server.
+	//This limit is applied during path finding. Typically this value is taken
+	//from the response of the GetQuote call.
+	MaxPrepayRoutingFee int64 `protobuf:"varint,4,opt,name=max_prepay_routing_fee,json=maxPrepayRoutingFee,proto3" json:"max_prepay_routing_fee,omitempty"`
+	//
+	//Maximum we are willing to pay the server for the swap. This value is not
+	//disclosed in the swap initiation call, but if the server asks for a
+	//higher fee, we abort the swap. Typically this value is taken from the
+	//response of the GetQuote call. It includes the prepay amount.
+	MaxSwapFee int64 `protobuf:"varint,5,opt,name=max_swap_fee,json=maxSwapFee,proto3" json:"max_swap_fee,omitempty"`
+	//
+	//Maximum amount of the swap fee that may be charged as a prepayment.
+	MaxPrepayAmt int64 `protobuf:"varint,6,opt,name=max_prepay_amt,json=maxPrepayAmt,proto3" json:"max_prepay_amt,omitempty"`
+	//
+	//Maximum in on-chain fees that we are willing to spend. If we want to
+	//sweep the on-chain htlc and the fee estimate turns out higher than this
+	//value, we cancel the swap. If the fee estimate is lower, we publish the
+	//sweep tx.
+	//
+	//If the sweep tx is not confirmed, we are forced to ratchet up fees until it
+	//is swept. Possibly even exceeding max_miner_fee if we get close to the htlc
+	//timeout. Because the initial publication revealed the preimage, we have no
+	//other choice. The server may already have pulled the off-chain htlc. Only
+	//when the fee becomes higher than the swap amount, we can only wait for fees
+	//to come down and hope - if we are past the timeout - that the server is not
+	//publishing the revocation.
+	//
+	//max_miner_fee is typically taken from the response of the GetQuote call.
+	MaxMinerFee int64 `protobuf:"varint,7,opt,name=max_miner_fee,json=maxMinerFee,proto3" json:"max_miner_fee,omitempty"`
+	//
+	//Deprecated, use outgoing_chan_set. The channel to loop out, the channel
+	//to loop out is selected based on the lowest routing fee for the swap
+	//payment to the server.
+	//
+	// Deprecated: Do not use.
+	LoopOutChannel uint64 `protobuf:"varint,8,opt,name=loop_out_channel,json=loopOutChannel,proto3" json:"loop_out_channel,omitempty"`
+	//
+	//A restriction on the channel set that may be used to loop out. The actual
+	//channel(s) that will be used are selected based on the lowest routing fee
+	//for the swap payment to the server.
+	OutgoingChanSet []uint64 `protobuf:"varint,11,rep,packed,name=outgoing_chan_set,json=outgoingChanSet,proto3" json:"outgoing_chan_set,omitempty"`
+	//
+	//The number of blocks from the on-chain HTLC's confirmation height that it
+	//should be swept within. If not set, loopd's confirmation policy picks a
+	//target based on the swap amount.
+	SweepConfTarget int32 `protobuf:"varint,9,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
+	//
+	//The number of confirmations that we require for the on chain htlc that will
+	//be published by the server before we reveal the preimage. If not set,
+	//loopd's confirmation policy picks a value based on the swap amount.
+	HtlcConfirmations int32 `protobuf:"varint,13,opt,name=htlc_confirmations,json=htlcConfirmations,proto3" json:"htlc_confirmations,omitempty"`
+	//
+	//The latest time (in unix seconds) we allow the server to wait before
+	//publishing the HTLC on chain. Setting this to a larger value will give the
+	//server the opportunity to batch multiple swaps together, and wait for
 	//low-fee periods before publishing the HTLC, potentially resulting in a
 	//lower total swap fee.
 	SwapPublicationDeadline uint64 `protobuf:"varint,10,opt,name=swap_publication_deadline,json=swapPublicationDeadline,proto3" json:"swap_publication_deadline,omitempty"`
@@ -493,6 +1170,60 @@ type LoopOutRequest struct {
 	//full picture of the binary used (loopd, LiT) and the method used for
 	//triggering the swap (loop CLI, autolooper, LiT UI, other 3rd party UI).
 	Initiator string `protobuf:"bytes,14,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	//
+	//An optional client-generated key that identifies this request. If a swap
+	//was already created with the same key, that swap is returned instead of
+	//creating a new one, so that requests can safely be retried. Reusing a key
+	//for a request with a different swap type or amount results in an error.
+	IdempotencyKey string `protobuf:"bytes,15,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	//
+	//Sweep the swap to a fresh address derived from the xpub that loopd is
+	//configured with (--destxpub), rather than to an address from lnd's wallet.
+	//This option is mutually exclusive with dest.
+	DestFromXpub bool `protobuf:"varint,16,opt,name=dest_from_xpub,json=destFromXpub,proto3" json:"dest_from_xpub,omitempty"`
+	//
+	//An optional timeout in seconds for the off-chain swap and prepay payments.
+	//If the payments have not succeeded within this time, lnd stops searching
+	//for routes and the swap fails. If not set, loopd's configured payment
+	//timeout is used.
+	PaymentTimeout uint32 `protobuf:"varint,17,opt,name=payment_timeout,json=paymentTimeout,proto3" json:"payment_timeout,omitempty"`
+	//
+	//An optional confirmation target that the server is asked to use when
+	//publishing the on-chain HTLC. A higher target allows the server to publish
+	//the HTLC in a cheaper fee band. This is only a hint, the target that the
+	//server commits to is reported in the swap's status. If not set, no
+	//preference is expressed.
+	ServerConfTarget int32 `protobuf:"varint,18,opt,name=server_conf_target,json=serverConfTarget,proto3" json:"server_conf_target,omitempty"`
+	//
+	//The node pubkey that the server quoted as the destination of the swap
+	//payment, as returned in the swap_payment_dest field of the quote. If set,
+	//the swap is aborted if the server's invoices do not pay to this node.
+	SwapPaymentDest []byte `protobuf:"bytes,19,opt,name=swap_payment_dest,json=swapPaymentDest,proto3" json:"swap_payment_dest,omitempty"`
+	//
+	//The maximum number of parts that the off-chain swap and prepay payments may
+	//be split into. If not set, loopd's configured loopoutmaxparts value is
+	//used.
+	MaxParts uint32 `protobuf:"varint,20,opt,name=max_parts,json=maxParts,proto3" json:"max_parts,omitempty"`
+	//
+	//An optional list of splits that the loop out is divided into. If set, one
+	//swap is created for each split, using the split's amount, destination and
+	//outgoing channels. All other fields of the request, including the fee
+	//limits, apply to each of the swaps individually. Every split must set its
+	//outgoing channels, and a channel may not be used by more than one split.
+	//This field may not be combined with amt, dest, dest_from_xpub,
+	//loop_out_channel or outgoing_chan_set.
+	Splits []*LoopOutSplit `protobuf:"bytes,21,rep,name=splits,proto3" json:"splits,omitempty"`
+	//
+	//The execution priority of the swap, which adjusts loopd's default payment
+	//timeout, how aggressively the sweep's fee is bumped and how often the sweep
+	//is republished. Swaps have normal priority if this is not set.
+	Priority SwapPriority `protobuf:"varint,22,opt,name=priority,proto3,enum=looprpc.SwapPriority" json:"priority,omitempty"`
+	//
+	//Loopd rejects loop out requests that have the same amount and outgoing
+	//channel restriction as a request that it received shortly before, because
+	//these are usually accidental duplicates. If set, the swap is created even
+	//if an identical request was received recently.
+	AllowDuplicate bool `protobuf:"varint,23,opt,name=allow_duplicate,json=allowDuplicate,proto3" json:"allow_duplicate,omitempty"`
 }
 
 func (x *LoopOutRequest) Reset() {
@@ -626,183 +1357,224 @@ func (x *LoopOutRequest) GetInitiator() string {
 	return ""
 }
 
-type LoopInRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//
-	//Requested swap amount in sat. This does not include the swap and miner
-	//fee.
-	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
-	//
-	//Maximum we are willing to pay the server for the swap. This value is not
-	//disclosed in the swap initiation call, but if the server asks for a
-	//higher fee, we abort the swap. Typically this value is taken from the
-	//response of the GetQuote call.
-	MaxSwapFee int64 `protobuf:"varint,2,opt,name=max_swap_fee,json=maxSwapFee,proto3" json:"max_swap_fee,omitempty"`
-	//
-	//Maximum in on-chain fees that we are willing to spend. If we want to
-	//publish the on-chain htlc and the fee estimate turns out higher than this
-	//value, we cancel the swap.
-	//
-	//max_miner_fee is typically taken from the response of the GetQuote call.
-	MaxMinerFee int64 `protobuf:"varint,3,opt,name=max_miner_fee,json=maxMinerFee,proto3" json:"max_miner_fee,omitempty"`
-	//
-	//The last hop to use for the loop in swap. If empty, the last hop is selected
-	//based on the lowest routing fee for the swap payment from the server.
-	LastHop []byte `protobuf:"bytes,4,opt,name=last_hop,json=lastHop,proto3" json:"last_hop,omitempty"`
-	//
-	//If external_htlc is true, we expect the htlc to be published by an external
-	//actor.
-	ExternalHtlc bool `protobuf:"varint,5,opt,name=external_htlc,json=externalHtlc,proto3" json:"external_htlc,omitempty"`
-	//
-	//The number of blocks that the on chain htlc should confirm within.
-	HtlcConfTarget int32 `protobuf:"varint,6,opt,name=htlc_conf_target,json=htlcConfTarget,proto3" json:"htlc_conf_target,omitempty"`
-	//
-	//An optional label for this swap. This field is limited to 500 characters
-	//and may not be one of the reserved values in loop/labels Reserved list.
-	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
-	//
-	//An optional identification string that will be appended to the user agent
-	//string sent to the server to give information about the usage of loop. This
-	//initiator part is meant for user interfaces to add their name to give the
-	//full picture of the binary used (loopd, LiT) and the method used for
-	//triggering the swap (loop CLI, autolooper, LiT UI, other 3rd party UI).
-	Initiator string `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
+func (x *LoopOutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
 }
 
-func (x *LoopInRequest) Reset() {
-	*x = LoopInRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *LoopOutRequest) GetDestFromXpub() bool {
+	if x != nil {
+		return x.DestFromXpub
 	}
+	return false
 }
 
-func (x *LoopInRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LoopOutRequest) GetPaymentTimeout() uint32 {
+	if x != nil {
+		return x.PaymentTimeout
+	}
+	return 0
 }
 
-func (*LoopInRequest) ProtoMessage() {}
-
-func (x *LoopInRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *LoopOutRequest) GetServerConfTarget() int32 {
+	if x != nil {
+		return x.ServerConfTarget
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use LoopInRequest.ProtoReflect.Descriptor instead.
-func (*LoopInRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *LoopInRequest) GetAmt() int64 {
+func (x *LoopOutRequest) GetSwapPaymentDest() []byte {
 	if x != nil {
-		return x.Amt
+		return x.SwapPaymentDest
 	}
-	return 0
+	return nil
 }
 
-func (x *LoopInRequest) GetMaxSwapFee() int64 {
+func (x *LoopOutRequest) GetMaxParts() uint32 {
 	if x != nil {
-		return x.MaxSwapFee
+		return x.MaxParts
 	}
 	return 0
 }
 
-func (x *LoopInRequest) GetMaxMinerFee() int64 {
+func (x *LoopOutRequest) GetSplits() []*LoopOutSplit {
 	if x != nil {
-		return x.MaxMinerFee
+		return x.Splits
 	}
-	return 0
+	return nil
 }
 
-func (x *LoopInRequest) GetLastHop() []byte {
+func (x *LoopOutRequest) GetPriority() SwapPriority {
 	if x != nil {
-		return x.LastHop
+		return x.Priority
 	}
-	return nil
+	return SwapPriority_PRIORITY_NORMAL
 }
 
-func (x *LoopInRequest) GetExternalHtlc() bool {
+func (x *LoopOutRequest) GetAllowDuplicate() bool {
 	if x != nil {
-		return x.ExternalHtlc
+		return x.AllowDuplicate
 	}
 	return false
 }
 
-func (x *LoopInRequest) GetHtlcConfTarget() int32 {
+type LoopOutSplit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Requested swap amount in sat for this split. This does not include the
+	//swap and miner fee.
+	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//Base58 encoded destination address for this split. If not set, a new
+	//address is generated for the split.
+	Dest string `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
+	//
+	//The channels that the swap payment of this split must be routed through.
+	OutgoingChanSet []uint64 `protobuf:"varint,3,rep,packed,name=outgoing_chan_set,json=outgoingChanSet,proto3" json:"outgoing_chan_set,omitempty"`
+	//
+	//If set, the split is swept to a fresh address derived from the xpub that
+	//loopd is configured with. This option may not be combined with dest.
+	DestFromXpub bool `protobuf:"varint,4,opt,name=dest_from_xpub,json=destFromXpub,proto3" json:"dest_from_xpub,omitempty"`
+}
+
+func (x *LoopOutSplit) Reset() {
+	*x = LoopOutSplit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoopOutSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoopOutSplit) ProtoMessage() {}
+
+func (x *LoopOutSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoopOutSplit.ProtoReflect.Descriptor instead.
+func (*LoopOutSplit) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoopOutSplit) GetAmt() int64 {
 	if x != nil {
-		return x.HtlcConfTarget
+		return x.Amt
 	}
 	return 0
 }
 
-func (x *LoopInRequest) GetLabel() string {
+func (x *LoopOutSplit) GetDest() string {
 	if x != nil {
-		return x.Label
+		return x.Dest
 	}
 	return ""
 }
 
-func (x *LoopInRequest) GetInitiator() string {
+func (x *LoopOutSplit) GetOutgoingChanSet() []uint64 {
 	if x != nil {
-		return x.Initiator
+		return x.OutgoingChanSet
 	}
-	return ""
+	return nil
 }
 
-type SwapResponse struct {
+func (x *LoopOutSplit) GetDestFromXpub() bool {
+	if x != nil {
+		return x.DestFromXpub
+	}
+	return false
+}
+
+type LoopInRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//Swap identifier to track status in the update stream that is returned from
-	//the Start() call. Currently this is the hash that locks the htlcs.
-	//DEPRECATED: To make the API more consistent, this field is deprecated in
-	//favor of id_bytes and will be removed in a future release.
+	//Requested swap amount in sat. This does not include the swap and miner
+	//fee.
+	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
 	//
-	// Deprecated: Do not use.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//Maximum we are willing to pay the server for the swap. This value is not
+	//disclosed in the swap initiation call, but if the server asks for a
+	//higher fee, we abort the swap. Typically this value is taken from the
+	//response of the GetQuote call.
+	MaxSwapFee int64 `protobuf:"varint,2,opt,name=max_swap_fee,json=maxSwapFee,proto3" json:"max_swap_fee,omitempty"`
 	//
-	//Swap identifier to track status in the update stream that is returned from
-	//the Start() call. Currently this is the hash that locks the htlcs.
-	IdBytes []byte `protobuf:"bytes,3,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	//Maximum in on-chain fees that we are willing to spend. If we want to
+	//publish the on-chain htlc and the fee estimate turns out higher than this
+	//value, we cancel the swap.
 	//
-	//DEPRECATED. This field stores the address of the onchain htlc, but
-	//depending on the request, the semantics are different.
-	//- For internal loop-in htlc_address contains the address of the
-	//native segwit (P2WSH) htlc.
-	//- For external loop-in htlc_address contains the address of the
-	//nested segwit (NP2WSH) htlc.
-	//- For loop-out htlc_address always contains the native segwit (P2WSH)
-	//htlc address.
+	//max_miner_fee is typically taken from the response of the GetQuote call.
+	MaxMinerFee int64 `protobuf:"varint,3,opt,name=max_miner_fee,json=maxMinerFee,proto3" json:"max_miner_fee,omitempty"`
 	//
-	// Deprecated: Do not use.
-	HtlcAddress string `protobuf:"bytes,2,opt,name=htlc_address,json=htlcAddress,proto3" json:"htlc_address,omitempty"`
+	//The last hop to use for the loop in swap. If empty, the last hop is selected
+	//based on the lowest routing fee for the swap payment from the server.
+	LastHop []byte `protobuf:"bytes,4,opt,name=last_hop,json=lastHop,proto3" json:"last_hop,omitempty"`
 	//
-	//The nested segwit address of the on-chain htlc.
-	//This field remains empty for loop-out.
-	HtlcAddressNp2Wsh string `protobuf:"bytes,4,opt,name=htlc_address_np2wsh,json=htlcAddressNp2wsh,proto3" json:"htlc_address_np2wsh,omitempty"`
+	//If external_htlc is true, we expect the htlc to be published by an external
+	//actor.
+	ExternalHtlc bool `protobuf:"varint,5,opt,name=external_htlc,json=externalHtlc,proto3" json:"external_htlc,omitempty"`
 	//
-	//The native segwit address of the on-chain htlc.
-	//Used for both loop-in and loop-out.
-	HtlcAddressP2Wsh string `protobuf:"bytes,5,opt,name=htlc_address_p2wsh,json=htlcAddressP2wsh,proto3" json:"htlc_address_p2wsh,omitempty"`
-	// A human-readable message received from the loop server.
-	ServerMessage string `protobuf:"bytes,6,opt,name=server_message,json=serverMessage,proto3" json:"server_message,omitempty"`
+	//The number of blocks that the on chain htlc should confirm within. If not
+	//set, a default is chosen based on the swap amount: small swaps use a slower,
+	//cheaper target and large swaps a faster one.
+	HtlcConfTarget int32 `protobuf:"varint,6,opt,name=htlc_conf_target,json=htlcConfTarget,proto3" json:"htlc_conf_target,omitempty"`
+	//
+	//An optional label for this swap. This field is limited to 500 characters
+	//and may not be one of the reserved values in loop/labels Reserved list.
+	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//An optional identification string that will be appended to the user agent
+	//string sent to the server to give information about the usage of loop. This
+	//initiator part is meant for user interfaces to add their name to give the
+	//full picture of the binary used (loopd, LiT) and the method used for
+	//triggering the swap (loop CLI, autolooper, LiT UI, other 3rd party UI).
+	Initiator string `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	//
+	//An optional client-generated key that identifies this request. If a swap
+	//was already created with the same key, that swap is returned instead of
+	//creating a new one, so that requests can safely be retried. Reusing a key
+	//for a request with a different swap type or amount results in an error.
+	IdempotencyKey string `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	//
+	//Optional route hints to include in the swap and probe invoices, so that
+	//the server can reach us over channels that are not announced. This field
+	//may not be combined with private.
+	RouteHints []*RouteHint `protobuf:"bytes,10,rep,name=route_hints,json=routeHints,proto3" json:"route_hints,omitempty"`
+	//
+	//If set, route hints are selected from our active private channels that
+	//have enough inbound liquidity for the swap. If last_hop is set, only
+	//channels with that peer are used. This option may not be combined with
+	//route_hints.
+	Private bool `protobuf:"varint,11,opt,name=private,proto3" json:"private,omitempty"`
+	//
+	//Loopd rejects loop in requests that have the same amount and last hop as
+	//a request that it received shortly before, because these are usually
+	//accidental duplicates. If set, the swap is created even if an identical
+	//request was received recently.
+	AllowDuplicate bool `protobuf:"varint,12,opt,name=allow_duplicate,json=allowDuplicate,proto3" json:"allow_duplicate,omitempty"`
 }
 
-func (x *SwapResponse) Reset() {
-	*x = SwapResponse{}
+func (x *LoopInRequest) Reset() {
+	*x = LoopInRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -810,13 +1582,13 @@ func (x *SwapResponse) Reset() {
 	}
 }
 
-func (x *SwapResponse) String() string {
+func (x *LoopInRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwapResponse) ProtoMessage() {}
+func (*LoopInRequest) ProtoMessage() {}
 
-func (x *SwapResponse) ProtoReflect() protoreflect.Message {
+func (x *LoopInRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -828,63 +1600,108 @@ func (x *SwapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
-func (*SwapResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoopInRequest.ProtoReflect.Descriptor instead.
+func (*LoopInRequest) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{2}
 }
 
-// Deprecated: Do not use.
-func (x *SwapResponse) GetId() string {
+func (x *LoopInRequest) GetAmt() int64 {
 	if x != nil {
-		return x.Id
+		return x.Amt
 	}
-	return ""
+	return 0
 }
 
-func (x *SwapResponse) GetIdBytes() []byte {
+func (x *LoopInRequest) GetMaxSwapFee() int64 {
 	if x != nil {
-		return x.IdBytes
+		return x.MaxSwapFee
+	}
+	return 0
+}
+
+func (x *LoopInRequest) GetMaxMinerFee() int64 {
+	if x != nil {
+		return x.MaxMinerFee
+	}
+	return 0
+}
+
+func (x *LoopInRequest) GetLastHop() []byte {
+	if x != nil {
+		return x.LastHop
 	}
 	return nil
 }
 
-// Deprecated: Do not use.
-func (x *SwapResponse) GetHtlcAddress() string {
+func (x *LoopInRequest) GetExternalHtlc() bool {
 	if x != nil {
-		return x.HtlcAddress
+		return x.ExternalHtlc
 	}
-	return ""
+	return false
 }
 
-func (x *SwapResponse) GetHtlcAddressNp2Wsh() string {
+func (x *LoopInRequest) GetHtlcConfTarget() int32 {
 	if x != nil {
-		return x.HtlcAddressNp2Wsh
+		return x.HtlcConfTarget
+	}
+	return 0
+}
+
+func (x *LoopInRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
 	return ""
 }
 
-func (x *SwapResponse) GetHtlcAddressP2Wsh() string {
+func (x *LoopInRequest) GetInitiator() string {
 	if x != nil {
-		return x.HtlcAddressP2Wsh
+		return x.Initiator
 	}
 	return ""
 }
 
-func (x *SwapResponse) GetServerMessage() string {
+func (x *LoopInRequest) GetIdempotencyKey() string {
 	if x != nil {
-		return x.ServerMessage
+		return x.IdempotencyKey
 	}
 	return ""
 }
 
-type MonitorRequest struct {
+func (x *LoopInRequest) GetRouteHints() []*RouteHint {
+	if x != nil {
+		return x.RouteHints
+	}
+	return nil
+}
+
+func (x *LoopInRequest) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *LoopInRequest) GetAllowDuplicate() bool {
+	if x != nil {
+		return x.AllowDuplicate
+	}
+	return false
+}
+
+type RouteHint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//A list of hop hints that when chained together can assist in reaching a
+	//specific destination.
+	HopHints []*HopHint `protobuf:"bytes,1,rep,name=hop_hints,json=hopHints,proto3" json:"hop_hints,omitempty"`
 }
 
-func (x *MonitorRequest) Reset() {
-	*x = MonitorRequest{}
+func (x *RouteHint) Reset() {
+	*x = RouteHint{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -892,13 +1709,13 @@ func (x *MonitorRequest) Reset() {
 	}
 }
 
-func (x *MonitorRequest) String() string {
+func (x *RouteHint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonitorRequest) ProtoMessage() {}
+func (*RouteHint) ProtoMessage() {}
 
-func (x *MonitorRequest) ProtoReflect() protoreflect.Message {
+func (x *RouteHint) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -910,74 +1727,43 @@ func (x *MonitorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonitorRequest.ProtoReflect.Descriptor instead.
-func (*MonitorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RouteHint.ProtoReflect.Descriptor instead.
+func (*RouteHint) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{3}
 }
 
-type SwapStatus struct {
+func (x *RouteHint) GetHopHints() []*HopHint {
+	if x != nil {
+		return x.HopHints
+	}
+	return nil
+}
+
+type HopHint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//Requested swap amount in sat. This does not include the swap and miner
-	//fee.
-	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
-	//
-	//Swap identifier to track status in the update stream that is returned from
-	//the Start() call. Currently this is the hash that locks the htlcs.
-	//DEPRECATED: To make the API more consistent, this field is deprecated in
-	//favor of id_bytes and will be removed in a future release.
-	//
-	// Deprecated: Do not use.
-	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	//
-	//Swap identifier to track status in the update stream that is returned from
-	//the Start() call. Currently this is the hash that locks the htlcs.
-	IdBytes []byte `protobuf:"bytes,11,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
-	//
-	//The type of the swap.
-	Type SwapType `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//The public key of the node at the start of the channel, hex encoded.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	//
-	//State the swap is currently in, see State enum.
-	State SwapState `protobuf:"varint,4,opt,name=state,proto3,enum=looprpc.SwapState" json:"state,omitempty"`
+	//The unique identifier of the channel.
+	ChanId uint64 `protobuf:"varint,2,opt,name=chan_id,json=chanId,proto3" json:"chan_id,omitempty"`
 	//
-	//A failure reason for the swap, only set if the swap has failed.
-	FailureReason FailureReason `protobuf:"varint,14,opt,name=failure_reason,json=failureReason,proto3,enum=looprpc.FailureReason" json:"failure_reason,omitempty"`
+	//The base fee of the channel denominated in millisatoshis.
+	FeeBaseMsat uint32 `protobuf:"varint,3,opt,name=fee_base_msat,json=feeBaseMsat,proto3" json:"fee_base_msat,omitempty"`
 	//
-	//Initiation time of the swap.
-	InitiationTime int64 `protobuf:"varint,5,opt,name=initiation_time,json=initiationTime,proto3" json:"initiation_time,omitempty"`
+	//The fee rate of the channel for sending one satoshi across it denominated
+	//in millionths of a satoshi.
+	FeeProportionalMillionths uint32 `protobuf:"varint,4,opt,name=fee_proportional_millionths,json=feeProportionalMillionths,proto3" json:"fee_proportional_millionths,omitempty"`
 	//
-	//Initiation time of the swap.
-	LastUpdateTime int64 `protobuf:"varint,6,opt,name=last_update_time,json=lastUpdateTime,proto3" json:"last_update_time,omitempty"`
-	//
-	//DEPRECATED:  This field stores the address of the onchain htlc.
-	//- For internal loop-in htlc_address contains the address of the
-	//native segwit (P2WSH) htlc.
-	//- For external loop-in htlc_address contains the nested segwit (NP2WSH)
-	//address.
-	//- For loop-out htlc_address always contains the native segwit (P2WSH)
-	//htlc address.
-	//
-	// Deprecated: Do not use.
-	HtlcAddress string `protobuf:"bytes,7,opt,name=htlc_address,json=htlcAddress,proto3" json:"htlc_address,omitempty"`
-	// HTLC address (native segwit), used in loop-in and loop-out swaps.
-	HtlcAddressP2Wsh string `protobuf:"bytes,12,opt,name=htlc_address_p2wsh,json=htlcAddressP2wsh,proto3" json:"htlc_address_p2wsh,omitempty"`
-	// HTLC address (nested segwit), used in loop-in swaps only.
-	HtlcAddressNp2Wsh string `protobuf:"bytes,13,opt,name=htlc_address_np2wsh,json=htlcAddressNp2wsh,proto3" json:"htlc_address_np2wsh,omitempty"`
-	// Swap server cost
-	CostServer int64 `protobuf:"varint,8,opt,name=cost_server,json=costServer,proto3" json:"cost_server,omitempty"`
-	// On-chain transaction cost
-	CostOnchain int64 `protobuf:"varint,9,opt,name=cost_onchain,json=costOnchain,proto3" json:"cost_onchain,omitempty"`
-	// Off-chain routing fees
-	CostOffchain int64 `protobuf:"varint,10,opt,name=cost_offchain,json=costOffchain,proto3" json:"cost_offchain,omitempty"`
-	// An optional label given to the swap on creation.
-	Label string `protobuf:"bytes,15,opt,name=label,proto3" json:"label,omitempty"`
+	//The time-lock delta of the channel.
+	CltvExpiryDelta uint32 `protobuf:"varint,5,opt,name=cltv_expiry_delta,json=cltvExpiryDelta,proto3" json:"cltv_expiry_delta,omitempty"`
 }
 
-func (x *SwapStatus) Reset() {
-	*x = SwapStatus{}
+func (x *HopHint) Reset() {
+	*x = HopHint{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -985,13 +1771,13 @@ func (x *SwapStatus) Reset() {
 	}
 }
 
-func (x *SwapStatus) String() string {
+func (x *HopHint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwapStatus) ProtoMessage() {}
+func (*HopHint) ProtoMessage() {}
 
-func (x *SwapStatus) ProtoReflect() protoreflect.Message {
+func (x *HopHint) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1003,141 +1789,218 @@ func (x *SwapStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwapStatus.ProtoReflect.Descriptor instead.
-func (*SwapStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use HopHint.ProtoReflect.Descriptor instead.
+func (*HopHint) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SwapStatus) GetAmt() int64 {
+func (x *HopHint) GetNodeId() string {
 	if x != nil {
-		return x.Amt
+		return x.NodeId
 	}
-	return 0
+	return ""
 }
 
-// Deprecated: Do not use.
-func (x *SwapStatus) GetId() string {
+func (x *HopHint) GetChanId() uint64 {
 	if x != nil {
-		return x.Id
+		return x.ChanId
 	}
-	return ""
+	return 0
 }
 
-func (x *SwapStatus) GetIdBytes() []byte {
+func (x *HopHint) GetFeeBaseMsat() uint32 {
 	if x != nil {
-		return x.IdBytes
+		return x.FeeBaseMsat
 	}
-	return nil
+	return 0
 }
 
-func (x *SwapStatus) GetType() SwapType {
+func (x *HopHint) GetFeeProportionalMillionths() uint32 {
 	if x != nil {
-		return x.Type
+		return x.FeeProportionalMillionths
 	}
-	return SwapType_LOOP_OUT
+	return 0
 }
 
-func (x *SwapStatus) GetState() SwapState {
+func (x *HopHint) GetCltvExpiryDelta() uint32 {
 	if x != nil {
-		return x.State
+		return x.CltvExpiryDelta
 	}
-	return SwapState_INITIATED
+	return 0
 }
 
-func (x *SwapStatus) GetFailureReason() FailureReason {
-	if x != nil {
-		return x.FailureReason
-	}
-	return FailureReason_FAILURE_REASON_NONE
+type SwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	//DEPRECATED: To make the API more consistent, this field is deprecated in
+	//favor of id_bytes and will be removed in a future release.
+	//
+	// Deprecated: Do not use.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	IdBytes []byte `protobuf:"bytes,3,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	//
+	//DEPRECATED. This field stores the address of the onchain htlc, but
+	//depending on the request, the semantics are different.
+	//- For internal loop-in htlc_address contains the address of the
+	//native segwit (P2WSH) htlc.
+	//- For external loop-in htlc_address contains the address of the
+	//nested segwit (NP2WSH) htlc.
+	//- For loop-out htlc_address always contains the native segwit (P2WSH)
+	//htlc address.
+	//
+	// Deprecated: Do not use.
+	HtlcAddress string `protobuf:"bytes,2,opt,name=htlc_address,json=htlcAddress,proto3" json:"htlc_address,omitempty"`
+	//
+	//The nested segwit address of the on-chain htlc.
+	//This field remains empty for loop-out.
+	HtlcAddressNp2Wsh string `protobuf:"bytes,4,opt,name=htlc_address_np2wsh,json=htlcAddressNp2wsh,proto3" json:"htlc_address_np2wsh,omitempty"`
+	//
+	//The native segwit address of the on-chain htlc.
+	//Used for both loop-in and loop-out.
+	HtlcAddressP2Wsh string `protobuf:"bytes,5,opt,name=htlc_address_p2wsh,json=htlcAddressP2wsh,proto3" json:"htlc_address_p2wsh,omitempty"`
+	// A human-readable message received from the loop server.
+	ServerMessage string `protobuf:"bytes,6,opt,name=server_message,json=serverMessage,proto3" json:"server_message,omitempty"`
+	//
+	//For loop out requests that were split into multiple swaps, the responses
+	//for each of the swaps that were created, in the order of the request's
+	//splits. The other fields of the response are not set for split requests.
+	SplitSwaps []*SwapResponse `protobuf:"bytes,7,rep,name=split_swaps,json=splitSwaps,proto3" json:"split_swaps,omitempty"`
 }
 
-func (x *SwapStatus) GetInitiationTime() int64 {
-	if x != nil {
-		return x.InitiationTime
+func (x *SwapResponse) Reset() {
+	*x = SwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *SwapStatus) GetLastUpdateTime() int64 {
-	if x != nil {
-		return x.LastUpdateTime
+func (x *SwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapResponse) ProtoMessage() {}
+
+func (x *SwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
+func (*SwapResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{5}
 }
 
 // Deprecated: Do not use.
-func (x *SwapStatus) GetHtlcAddress() string {
+func (x *SwapResponse) GetId() string {
 	if x != nil {
-		return x.HtlcAddress
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SwapStatus) GetHtlcAddressP2Wsh() string {
+func (x *SwapResponse) GetIdBytes() []byte {
 	if x != nil {
-		return x.HtlcAddressP2Wsh
+		return x.IdBytes
 	}
-	return ""
+	return nil
 }
 
-func (x *SwapStatus) GetHtlcAddressNp2Wsh() string {
+// Deprecated: Do not use.
+func (x *SwapResponse) GetHtlcAddress() string {
 	if x != nil {
-		return x.HtlcAddressNp2Wsh
+		return x.HtlcAddress
 	}
 	return ""
 }
 
-func (x *SwapStatus) GetCostServer() int64 {
+func (x *SwapResponse) GetHtlcAddressNp2Wsh() string {
 	if x != nil {
-		return x.CostServer
+		return x.HtlcAddressNp2Wsh
 	}
-	return 0
+	return ""
 }
 
-func (x *SwapStatus) GetCostOnchain() int64 {
+func (x *SwapResponse) GetHtlcAddressP2Wsh() string {
 	if x != nil {
-		return x.CostOnchain
+		return x.HtlcAddressP2Wsh
 	}
-	return 0
+	return ""
 }
 
-func (x *SwapStatus) GetCostOffchain() int64 {
+func (x *SwapResponse) GetServerMessage() string {
 	if x != nil {
-		return x.CostOffchain
+		return x.ServerMessage
 	}
-	return 0
+	return ""
 }
 
-func (x *SwapStatus) GetLabel() string {
+func (x *SwapResponse) GetSplitSwaps() []*SwapResponse {
 	if x != nil {
-		return x.Label
+		return x.SplitSwaps
 	}
-	return ""
+	return nil
 }
 
-type ListSwapsRequest struct {
+type LoopInPsbtResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	IdBytes []byte `protobuf:"bytes,1,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	//
+	//The native segwit address of the on-chain htlc that the funding psbt pays
+	//to.
+	HtlcAddressP2Wsh string `protobuf:"bytes,2,opt,name=htlc_address_p2wsh,json=htlcAddressP2wsh,proto3" json:"htlc_address_p2wsh,omitempty"`
+	//
+	//The amount in satoshis that the htlc must be funded with. Htlcs that are
+	//funded with a different amount fail the swap.
+	Amt int64 `protobuf:"varint,3,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//An unsigned psbt without inputs that pays amt to the htlc address. Inputs
+	//and change should be added by the external wallet before it signs and
+	//broadcasts the transaction.
+	Psbt []byte `protobuf:"bytes,4,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	// A human-readable message received from the loop server.
+	ServerMessage string `protobuf:"bytes,5,opt,name=server_message,json=serverMessage,proto3" json:"server_message,omitempty"`
 }
 
-func (x *ListSwapsRequest) Reset() {
-	*x = ListSwapsRequest{}
+func (x *LoopInPsbtResponse) Reset() {
+	*x = LoopInPsbtResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[5]
+		mi := &file_client_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListSwapsRequest) String() string {
+func (x *LoopInPsbtResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSwapsRequest) ProtoMessage() {}
+func (*LoopInPsbtResponse) ProtoMessage() {}
 
-func (x *ListSwapsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[5]
+func (x *LoopInPsbtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1148,38 +2011,96 @@ func (x *ListSwapsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSwapsRequest.ProtoReflect.Descriptor instead.
-func (*ListSwapsRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use LoopInPsbtResponse.ProtoReflect.Descriptor instead.
+func (*LoopInPsbtResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{6}
 }
 
-type ListSwapsResponse struct {
+func (x *LoopInPsbtResponse) GetIdBytes() []byte {
+	if x != nil {
+		return x.IdBytes
+	}
+	return nil
+}
+
+func (x *LoopInPsbtResponse) GetHtlcAddressP2Wsh() string {
+	if x != nil {
+		return x.HtlcAddressP2Wsh
+	}
+	return ""
+}
+
+func (x *LoopInPsbtResponse) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *LoopInPsbtResponse) GetPsbt() []byte {
+	if x != nil {
+		return x.Psbt
+	}
+	return nil
+}
+
+func (x *LoopInPsbtResponse) GetServerMessage() string {
+	if x != nil {
+		return x.ServerMessage
+	}
+	return ""
+}
+
+type MonitorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The list of all currently known swaps and their status.
-	Swaps []*SwapStatus `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	//If set, the last sequence number that the client received in a previous
+	//subscription. If the daemon still has all updates that followed this
+	//sequence number, they are replayed instead of a snapshot being sent.
+	//Otherwise, a full snapshot of pending swaps is delivered. Sequence numbers
+	//are reset when the daemon restarts, so they are only resumed if
+	//start_epoch is also set.
+	StartSequence uint64 `protobuf:"varint,1,opt,name=start_sequence,json=startSequence,proto3" json:"start_sequence,omitempty"`
+	//
+	//Set to true to use the legacy behavior of the stream, which delivers all
+	//pending swaps and the most recently completed swaps before streaming
+	//updates, without snapshot markers.
+	LegacyUpdates bool `protobuf:"varint,2,opt,name=legacy_updates,json=legacyUpdates,proto3" json:"legacy_updates,omitempty"`
+	//
+	//If set, the terminal state transitions of swaps that completed at or after
+	//this time, expressed in unix nanoseconds, are read from the database and
+	//replayed before any other updates are sent. Replayed updates have the
+	//replay flag set. A swap that completes while the subscription is being
+	//set up may be delivered both as a replayed update and as a delta.
+	ReplaySinceNs int64 `protobuf:"varint,3,opt,name=replay_since_ns,json=replaySinceNs,proto3" json:"replay_since_ns,omitempty"`
+	//
+	//The epoch of the stream that start_sequence was received on, as reported
+	//in the epoch field of its updates. The daemon picks a new epoch whenever
+	//it restarts. If this does not match the daemon's current epoch, a full
+	//snapshot of pending swaps is delivered instead of replayed updates.
+	StartEpoch uint64 `protobuf:"varint,4,opt,name=start_epoch,json=startEpoch,proto3" json:"start_epoch,omitempty"`
 }
 
-func (x *ListSwapsResponse) Reset() {
-	*x = ListSwapsResponse{}
+func (x *MonitorRequest) Reset() {
+	*x = MonitorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[6]
+		mi := &file_client_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListSwapsResponse) String() string {
+func (x *MonitorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSwapsResponse) ProtoMessage() {}
+func (*MonitorRequest) ProtoMessage() {}
 
-func (x *ListSwapsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[6]
+func (x *MonitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,76 +2111,207 @@ func (x *ListSwapsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSwapsResponse.ProtoReflect.Descriptor instead.
-func (*ListSwapsResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use MonitorRequest.ProtoReflect.Descriptor instead.
+func (*MonitorRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListSwapsResponse) GetSwaps() []*SwapStatus {
+func (x *MonitorRequest) GetStartSequence() uint64 {
 	if x != nil {
-		return x.Swaps
+		return x.StartSequence
 	}
-	return nil
+	return 0
 }
 
-type SwapInfoRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//
-	//The swap identifier which currently is the hash that locks the HTLCs. When
-	//using REST, this field must be encoded as URL safe base64.
-	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-}
-
-func (x *SwapInfoRequest) Reset() {
-	*x = SwapInfoRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *MonitorRequest) GetLegacyUpdates() bool {
+	if x != nil {
+		return x.LegacyUpdates
 	}
+	return false
 }
 
-func (x *SwapInfoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SwapInfoRequest) ProtoMessage() {}
-
-func (x *SwapInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *MonitorRequest) GetReplaySinceNs() int64 {
+	if x != nil {
+		return x.ReplaySinceNs
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SwapInfoRequest.ProtoReflect.Descriptor instead.
-func (*SwapInfoRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{7}
+	return 0
 }
 
-func (x *SwapInfoRequest) GetId() []byte {
+func (x *MonitorRequest) GetStartEpoch() uint64 {
 	if x != nil {
-		return x.Id
+		return x.StartEpoch
 	}
-	return nil
+	return 0
 }
 
-type TermsRequest struct {
+type SwapStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//Requested swap amount in sat. This does not include the swap and miner
+	//fee.
+	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	//DEPRECATED: To make the API more consistent, this field is deprecated in
+	//favor of id_bytes and will be removed in a future release.
+	//
+	// Deprecated: Do not use.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	IdBytes []byte `protobuf:"bytes,11,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	//
+	//The type of the swap.
+	Type SwapType `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//State the swap is currently in, see State enum.
+	State SwapState `protobuf:"varint,4,opt,name=state,proto3,enum=looprpc.SwapState" json:"state,omitempty"`
+	//
+	//A failure reason for the swap, only set if the swap has failed.
+	FailureReason FailureReason `protobuf:"varint,14,opt,name=failure_reason,json=failureReason,proto3,enum=looprpc.FailureReason" json:"failure_reason,omitempty"`
+	//
+	//Initiation time of the swap.
+	InitiationTime int64 `protobuf:"varint,5,opt,name=initiation_time,json=initiationTime,proto3" json:"initiation_time,omitempty"`
+	//
+	//Initiation time of the swap.
+	LastUpdateTime int64 `protobuf:"varint,6,opt,name=last_update_time,json=lastUpdateTime,proto3" json:"last_update_time,omitempty"`
+	//
+	//DEPRECATED:  This field stores the address of the onchain htlc.
+	//- For internal loop-in htlc_address contains the address of the
+	//native segwit (P2WSH) htlc.
+	//- For external loop-in htlc_address contains the nested segwit (NP2WSH)
+	//address.
+	//- For loop-out htlc_address always contains the native segwit (P2WSH)
+	//htlc address.
+	//
+	// Deprecated: Do not use.
+	HtlcAddress string `protobuf:"bytes,7,opt,name=htlc_address,json=htlcAddress,proto3" json:"htlc_address,omitempty"`
+	// HTLC address (native segwit), used in loop-in and loop-out swaps.
+	HtlcAddressP2Wsh string `protobuf:"bytes,12,opt,name=htlc_address_p2wsh,json=htlcAddressP2wsh,proto3" json:"htlc_address_p2wsh,omitempty"`
+	// HTLC address (nested segwit), used in loop-in swaps only.
+	HtlcAddressNp2Wsh string `protobuf:"bytes,13,opt,name=htlc_address_np2wsh,json=htlcAddressNp2wsh,proto3" json:"htlc_address_np2wsh,omitempty"`
+	// Swap server cost
+	CostServer int64 `protobuf:"varint,8,opt,name=cost_server,json=costServer,proto3" json:"cost_server,omitempty"`
+	// On-chain transaction cost
+	CostOnchain int64 `protobuf:"varint,9,opt,name=cost_onchain,json=costOnchain,proto3" json:"cost_onchain,omitempty"`
+	// Off-chain routing fees
+	CostOffchain int64 `protobuf:"varint,10,opt,name=cost_offchain,json=costOffchain,proto3" json:"cost_offchain,omitempty"`
+	// An optional label given to the swap on creation.
+	Label string `protobuf:"bytes,15,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//The sequence number of this update in the monitor stream. Updates that are
+	//part of the initial snapshot carry the sequence number of the last delta
+	//that was processed before the snapshot was taken. This field is only set
+	//for updates delivered by Monitor.
+	Sequence uint64 `protobuf:"varint,16,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	//
+	//Set to true if this update is part of the initial snapshot of pending
+	//swaps that is delivered by Monitor, rather than a delta.
+	Snapshot bool `protobuf:"varint,17,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	//
+	//For successful loop outs that were restricted to a set of outgoing
+	//channels, the amount in satoshis that the local balance of those channels
+	//decreased by over the course of the swap, capped at the swap payment
+	//amount.
+	OutgoingChanFlowSat int64 `protobuf:"varint,18,opt,name=outgoing_chan_flow_sat,json=outgoingChanFlowSat,proto3" json:"outgoing_chan_flow_sat,omitempty"`
+	//
+	//Set to true if outgoing_chan_flow_sat is less than the swap payment
+	//amount, indicating that part of the payment may have been routed around
+	//the outgoing channel restriction. Note that other payments and forwards
+	//through the channels also affect their balances.
+	OutgoingChanBypassed bool `protobuf:"varint,19,opt,name=outgoing_chan_bypassed,json=outgoingChanBypassed,proto3" json:"outgoing_chan_bypassed,omitempty"`
+	//
+	//For pending swaps, the number of blocks that remained until the swap's
+	//on-chain htlc expires when this status was created. Zero if the htlc has
+	//expired, the swap is no longer pending or the current block height is not
+	//known yet.
+	BlocksUntilExpiry int32 `protobuf:"varint,20,opt,name=blocks_until_expiry,json=blocksUntilExpiry,proto3" json:"blocks_until_expiry,omitempty"`
+	//
+	//For pending swaps, the number of blocks that remained until the swap's
+	//point of no return when this status was created. For loop out swaps, this
+	//is the last height at which the preimage will be revealed. For loop in
+	//swaps, this is the last height at which the htlc will be published. Zero
+	//once the swap has proceeded past this point, or once this height has been
+	//reached.
+	BlocksUntilNoReturn int32 `protobuf:"varint,21,opt,name=blocks_until_no_return,json=blocksUntilNoReturn,proto3" json:"blocks_until_no_return,omitempty"`
+	//
+	//For loop out swaps, the confirmation target that the server committed to
+	//for publication of the on-chain HTLC. Zero if no target was requested or
+	//the server did not take it into account.
+	ServerConfTarget int32 `protobuf:"varint,22,opt,name=server_conf_target,json=serverConfTarget,proto3" json:"server_conf_target,omitempty"`
+	//
+	//For loop in swaps, the confirmation target that was used to publish the
+	//on-chain HTLC. This is the default target for the swap amount if none was
+	//requested. Zero for loop out swaps and external loop in swaps.
+	HtlcConfTarget int32 `protobuf:"varint,23,opt,name=htlc_conf_target,json=htlcConfTarget,proto3" json:"htlc_conf_target,omitempty"`
+	//
+	//For failed swaps, the failure reason that the swap server reported for the
+	//swap, if it reported one before the swap failed.
+	ServerFailureReason ServerFailureReason `protobuf:"varint,24,opt,name=server_failure_reason,json=serverFailureReason,proto3,enum=looprpc.ServerFailureReason" json:"server_failure_reason,omitempty"`
+	//
+	//For failed swaps, the reason that the client failed the swap for. This
+	//provides more detail than failure_reason, and is not set for swaps that
+	//failed before it was recorded.
+	SwapFailureReason SwapFailureReason `protobuf:"varint,25,opt,name=swap_failure_reason,json=swapFailureReason,proto3,enum=looprpc.SwapFailureReason" json:"swap_failure_reason,omitempty"`
+	//
+	//Set to true if this update is a terminal state transition that was
+	//replayed from the database by Monitor, rather than a live update.
+	Replay bool `protobuf:"varint,26,opt,name=replay,proto3" json:"replay,omitempty"`
+	//
+	//For loop in swaps that timed out, the most recent transaction that was
+	//published to refund the on-chain HTLC. Not set if no refund has been
+	//published.
+	Refund *RefundStatus `protobuf:"bytes,27,opt,name=refund,proto3" json:"refund,omitempty"`
+	//
+	//Set to true if the swap has not reached its point of no return, so it can
+	//be abandoned without putting the swap amount at risk. For loop out swaps,
+	//this is until the preimage is revealed. For loop in swaps, this is until
+	//the on-chain HTLC is published. Always false for completed swaps.
+	Cancellable bool `protobuf:"varint,28,opt,name=cancellable,proto3" json:"cancellable,omitempty"`
+	//
+	//For pending swaps, the reasons that the swap may still fail with. Pending
+	//swaps may always still succeed, and may always be abandoned. Temporary
+	//failures are not included, because swaps are resumed after them. Empty for
+	//completed swaps.
+	PossibleFailures []FailureReason `protobuf:"varint,29,rep,packed,name=possible_failures,json=possibleFailures,proto3,enum=looprpc.FailureReason" json:"possible_failures,omitempty"`
+	//
+	//The script version of the swap's on-chain HTLC. Swaps keep the version
+	//that they were created with, so pending swaps that were created before a
+	//new version was introduced continue to use their original script.
+	HtlcVersion HtlcVersion `protobuf:"varint,30,opt,name=htlc_version,json=htlcVersion,proto3,enum=looprpc.HtlcVersion" json:"htlc_version,omitempty"`
+	//
+	//The maximum in on-chain fees in sat that we committed to spend on the
+	//swap when it was created. For loop out swaps, this is the limit for the
+	//sweep of the htlc. For loop in swaps, this is the limit for publishing the
+	//htlc.
+	MaxMinerFee int64 `protobuf:"varint,31,opt,name=max_miner_fee,json=maxMinerFee,proto3" json:"max_miner_fee,omitempty"`
+	//
+	//The maximum swap fee in sat that we committed to pay the server when the
+	//swap was created. For loop out swaps, this includes the prepay amount.
+	MaxSwapFee int64 `protobuf:"varint,32,opt,name=max_swap_fee,json=maxSwapFee,proto3" json:"max_swap_fee,omitempty"`
+	//
+	//The maximum off-chain fee in sat that may be paid to route the swap
+	//payment of a loop out swap. Zero for loop in swaps.
+	MaxSwapRoutingFee int64 `protobuf:"varint,33,opt,name=max_swap_routing_fee,json=maxSwapRoutingFee,proto3" json:"max_swap_routing_fee,omitempty"`
+	//
+	//The maximum off-chain fee in sat that may be paid to route the prepay of
+	//a loop out swap. Zero for loop in swaps.
+	MaxPrepayRoutingFee int64 `protobuf:"varint,34,opt,name=max_prepay_routing_fee,json=maxPrepayRoutingFee,proto3" json:"max_prepay_routing_fee,omitempty"`
+	//
+	//The epoch of the monitor stream that the sequence number of this update
+	//belongs to. The daemon picks a new epoch whenever it restarts, which
+	//resets sequence numbers. This field is only set for updates delivered by
+	//Monitor.
+	Epoch uint64 `protobuf:"varint,35,opt,name=epoch,proto3" json:"epoch,omitempty"`
 }
 
-func (x *TermsRequest) Reset() {
-	*x = TermsRequest{}
+func (x *SwapStatus) Reset() {
+	*x = SwapStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1267,13 +2319,13 @@ func (x *TermsRequest) Reset() {
 	}
 }
 
-func (x *TermsRequest) String() string {
+func (x *SwapStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TermsRequest) ProtoMessage() {}
+func (*SwapStatus) ProtoMessage() {}
 
-func (x *TermsRequest) ProtoReflect() protoreflect.Message {
+func (x *SwapStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1285,275 +2337,297 @@ func (x *TermsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TermsRequest.ProtoReflect.Descriptor instead.
-func (*TermsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SwapStatus.ProtoReflect.Descriptor instead.
+func (*SwapStatus) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{8}
 }
 
-type InTermsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//
-	//Minimum swap amount (sat)
-	MinSwapAmount int64 `protobuf:"varint,5,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
-	//
-	//Maximum swap amount (sat)
-	MaxSwapAmount int64 `protobuf:"varint,6,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
-}
-
-func (x *InTermsResponse) Reset() {
-	*x = InTermsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SwapStatus) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
 	}
+	return 0
 }
 
-func (x *InTermsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*InTermsResponse) ProtoMessage() {}
-
-func (x *InTermsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+// Deprecated: Do not use.
+func (x *SwapStatus) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use InTermsResponse.ProtoReflect.Descriptor instead.
-func (*InTermsResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{9}
+	return ""
 }
 
-func (x *InTermsResponse) GetMinSwapAmount() int64 {
+func (x *SwapStatus) GetIdBytes() []byte {
 	if x != nil {
-		return x.MinSwapAmount
+		return x.IdBytes
 	}
-	return 0
+	return nil
 }
 
-func (x *InTermsResponse) GetMaxSwapAmount() int64 {
+func (x *SwapStatus) GetType() SwapType {
 	if x != nil {
-		return x.MaxSwapAmount
+		return x.Type
 	}
-	return 0
-}
-
-type OutTermsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//
-	//Minimum swap amount (sat)
-	MinSwapAmount int64 `protobuf:"varint,5,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
-	//
-	//Maximum swap amount (sat)
-	MaxSwapAmount int64 `protobuf:"varint,6,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
-	// The minimally accepted cltv delta of the on-chain htlc.
-	MinCltvDelta int32 `protobuf:"varint,8,opt,name=min_cltv_delta,json=minCltvDelta,proto3" json:"min_cltv_delta,omitempty"`
-	// The maximally accepted cltv delta of the on-chain htlc.
-	MaxCltvDelta int32 `protobuf:"varint,9,opt,name=max_cltv_delta,json=maxCltvDelta,proto3" json:"max_cltv_delta,omitempty"`
+	return SwapType_LOOP_OUT
 }
 
-func (x *OutTermsResponse) Reset() {
-	*x = OutTermsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SwapStatus) GetState() SwapState {
+	if x != nil {
+		return x.State
 	}
+	return SwapState_INITIATED
 }
 
-func (x *OutTermsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OutTermsResponse) ProtoMessage() {}
-
-func (x *OutTermsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *SwapStatus) GetFailureReason() FailureReason {
+	if x != nil {
+		return x.FailureReason
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OutTermsResponse.ProtoReflect.Descriptor instead.
-func (*OutTermsResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{10}
+	return FailureReason_FAILURE_REASON_NONE
 }
 
-func (x *OutTermsResponse) GetMinSwapAmount() int64 {
+func (x *SwapStatus) GetInitiationTime() int64 {
 	if x != nil {
-		return x.MinSwapAmount
+		return x.InitiationTime
 	}
 	return 0
 }
 
-func (x *OutTermsResponse) GetMaxSwapAmount() int64 {
+func (x *SwapStatus) GetLastUpdateTime() int64 {
 	if x != nil {
-		return x.MaxSwapAmount
+		return x.LastUpdateTime
 	}
 	return 0
 }
 
-func (x *OutTermsResponse) GetMinCltvDelta() int32 {
+// Deprecated: Do not use.
+func (x *SwapStatus) GetHtlcAddress() string {
 	if x != nil {
-		return x.MinCltvDelta
+		return x.HtlcAddress
 	}
-	return 0
+	return ""
 }
 
-func (x *OutTermsResponse) GetMaxCltvDelta() int32 {
+func (x *SwapStatus) GetHtlcAddressP2Wsh() string {
 	if x != nil {
-		return x.MaxCltvDelta
+		return x.HtlcAddressP2Wsh
+	}
+	return ""
+}
+
+func (x *SwapStatus) GetHtlcAddressNp2Wsh() string {
+	if x != nil {
+		return x.HtlcAddressNp2Wsh
+	}
+	return ""
+}
+
+func (x *SwapStatus) GetCostServer() int64 {
+	if x != nil {
+		return x.CostServer
 	}
 	return 0
 }
 
-type QuoteRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *SwapStatus) GetCostOnchain() int64 {
+	if x != nil {
+		return x.CostOnchain
+	}
+	return 0
+}
 
-	//
-	//The amount to swap in satoshis.
-	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
-	//
-	//The confirmation target that should be used either for the sweep of the
-	//on-chain HTLC broadcast by the swap server in the case of a Loop Out, or for
-	//the confirmation of the on-chain HTLC broadcast by the swap client in the
-	//case of a Loop In.
-	ConfTarget int32 `protobuf:"varint,2,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
-	//
-	//If external_htlc is true, we expect the htlc to be published by an external
-	//actor.
-	ExternalHtlc bool `protobuf:"varint,3,opt,name=external_htlc,json=externalHtlc,proto3" json:"external_htlc,omitempty"`
-	//
-	//The latest time (in unix seconds) we allow the server to wait before
-	//publishing the HTLC on chain. Setting this to a larger value will give the
-	//server the opportunity to batch multiple swaps together, and wait for
-	//low-fee periods before publishing the HTLC, potentially resulting in a
-	//lower total swap fee. This only has an effect on loop out quotes.
-	SwapPublicationDeadline uint64 `protobuf:"varint,4,opt,name=swap_publication_deadline,json=swapPublicationDeadline,proto3" json:"swap_publication_deadline,omitempty"`
+func (x *SwapStatus) GetCostOffchain() int64 {
+	if x != nil {
+		return x.CostOffchain
+	}
+	return 0
 }
 
-func (x *QuoteRequest) Reset() {
-	*x = QuoteRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SwapStatus) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
+	return ""
 }
 
-func (x *QuoteRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SwapStatus) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
 }
 
-func (*QuoteRequest) ProtoMessage() {}
+func (x *SwapStatus) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
 
-func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *SwapStatus) GetOutgoingChanFlowSat() int64 {
+	if x != nil {
+		return x.OutgoingChanFlowSat
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
-func (*QuoteRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{11}
+func (x *SwapStatus) GetOutgoingChanBypassed() bool {
+	if x != nil {
+		return x.OutgoingChanBypassed
+	}
+	return false
 }
 
-func (x *QuoteRequest) GetAmt() int64 {
+func (x *SwapStatus) GetBlocksUntilExpiry() int32 {
 	if x != nil {
-		return x.Amt
+		return x.BlocksUntilExpiry
 	}
 	return 0
 }
 
-func (x *QuoteRequest) GetConfTarget() int32 {
+func (x *SwapStatus) GetBlocksUntilNoReturn() int32 {
 	if x != nil {
-		return x.ConfTarget
+		return x.BlocksUntilNoReturn
 	}
 	return 0
 }
 
-func (x *QuoteRequest) GetExternalHtlc() bool {
+func (x *SwapStatus) GetServerConfTarget() int32 {
 	if x != nil {
-		return x.ExternalHtlc
+		return x.ServerConfTarget
+	}
+	return 0
+}
+
+func (x *SwapStatus) GetHtlcConfTarget() int32 {
+	if x != nil {
+		return x.HtlcConfTarget
+	}
+	return 0
+}
+
+func (x *SwapStatus) GetServerFailureReason() ServerFailureReason {
+	if x != nil {
+		return x.ServerFailureReason
+	}
+	return ServerFailureReason_SERVER_FAILURE_REASON_NONE
+}
+
+func (x *SwapStatus) GetSwapFailureReason() SwapFailureReason {
+	if x != nil {
+		return x.SwapFailureReason
+	}
+	return SwapFailureReason_SWAP_FAILURE_REASON_NONE
+}
+
+func (x *SwapStatus) GetReplay() bool {
+	if x != nil {
+		return x.Replay
 	}
 	return false
 }
 
-func (x *QuoteRequest) GetSwapPublicationDeadline() uint64 {
+func (x *SwapStatus) GetRefund() *RefundStatus {
 	if x != nil {
-		return x.SwapPublicationDeadline
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *SwapStatus) GetCancellable() bool {
+	if x != nil {
+		return x.Cancellable
+	}
+	return false
+}
+
+func (x *SwapStatus) GetPossibleFailures() []FailureReason {
+	if x != nil {
+		return x.PossibleFailures
+	}
+	return nil
+}
+
+func (x *SwapStatus) GetHtlcVersion() HtlcVersion {
+	if x != nil {
+		return x.HtlcVersion
+	}
+	return HtlcVersion_HTLC_VERSION_V1
+}
+
+func (x *SwapStatus) GetMaxMinerFee() int64 {
+	if x != nil {
+		return x.MaxMinerFee
 	}
 	return 0
 }
 
-type InQuoteResponse struct {
+func (x *SwapStatus) GetMaxSwapFee() int64 {
+	if x != nil {
+		return x.MaxSwapFee
+	}
+	return 0
+}
+
+func (x *SwapStatus) GetMaxSwapRoutingFee() int64 {
+	if x != nil {
+		return x.MaxSwapRoutingFee
+	}
+	return 0
+}
+
+func (x *SwapStatus) GetMaxPrepayRoutingFee() int64 {
+	if x != nil {
+		return x.MaxPrepayRoutingFee
+	}
+	return 0
+}
+
+func (x *SwapStatus) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type RefundStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The fee that the swap server is charging for the swap.
-	SwapFeeSat int64 `protobuf:"varint,1,opt,name=swap_fee_sat,json=swapFeeSat,proto3" json:"swap_fee_sat,omitempty"`
+	//The id of the most recent refund transaction that was published. The
+	//refund is replaced with a higher fee rate if it does not confirm in time.
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
 	//
-	//An estimate of the on-chain fee that needs to be paid to publish the HTLC
-	//If a miner fee of 0 is returned, it means the external_htlc flag was set for
-	//a loop in and the fee estimation was skipped. If a miner fee of -1 is
-	//returned, it means lnd's wallet tried to estimate the fee but was unable to
-	//create a sample estimation transaction because not enough funds are
-	//available. An information message should be shown to the user in this case.
-	HtlcPublishFeeSat int64 `protobuf:"varint,3,opt,name=htlc_publish_fee_sat,json=htlcPublishFeeSat,proto3" json:"htlc_publish_fee_sat,omitempty"`
+	//The fee in satoshis that the refund transaction pays.
+	FeeSat int64 `protobuf:"varint,2,opt,name=fee_sat,json=feeSat,proto3" json:"fee_sat,omitempty"`
 	//
-	//On-chain cltv expiry delta
-	CltvDelta int32 `protobuf:"varint,5,opt,name=cltv_delta,json=cltvDelta,proto3" json:"cltv_delta,omitempty"`
+	//The height at which a refund with the current fee rate was first
+	//published.
+	PublishHeight int32 `protobuf:"varint,3,opt,name=publish_height,json=publishHeight,proto3" json:"publish_height,omitempty"`
 	//
-	//The confirmation target to be used to publish the on-chain HTLC.
-	ConfTarget int32 `protobuf:"varint,6,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+	//Set to true once the refund has confirmed and the swap amount has been
+	//returned to the wallet.
+	Confirmed bool `protobuf:"varint,4,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
 }
 
-func (x *InQuoteResponse) Reset() {
-	*x = InQuoteResponse{}
+func (x *RefundStatus) Reset() {
+	*x = RefundStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[12]
+		mi := &file_client_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InQuoteResponse) String() string {
+func (x *RefundStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InQuoteResponse) ProtoMessage() {}
+func (*RefundStatus) ProtoMessage() {}
 
-func (x *InQuoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[12]
+func (x *RefundStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1564,83 +2638,91 @@ func (x *InQuoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InQuoteResponse.ProtoReflect.Descriptor instead.
-func (*InQuoteResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RefundStatus.ProtoReflect.Descriptor instead.
+func (*RefundStatus) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *InQuoteResponse) GetSwapFeeSat() int64 {
+func (x *RefundStatus) GetTxid() string {
 	if x != nil {
-		return x.SwapFeeSat
+		return x.Txid
 	}
-	return 0
+	return ""
 }
 
-func (x *InQuoteResponse) GetHtlcPublishFeeSat() int64 {
+func (x *RefundStatus) GetFeeSat() int64 {
 	if x != nil {
-		return x.HtlcPublishFeeSat
+		return x.FeeSat
 	}
 	return 0
 }
 
-func (x *InQuoteResponse) GetCltvDelta() int32 {
+func (x *RefundStatus) GetPublishHeight() int32 {
 	if x != nil {
-		return x.CltvDelta
+		return x.PublishHeight
 	}
 	return 0
 }
 
-func (x *InQuoteResponse) GetConfTarget() int32 {
+func (x *RefundStatus) GetConfirmed() bool {
 	if x != nil {
-		return x.ConfTarget
+		return x.Confirmed
 	}
-	return 0
+	return false
 }
 
-type OutQuoteResponse struct {
+type ListSwapsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The fee that the swap server is charging for the swap.
-	SwapFeeSat int64 `protobuf:"varint,1,opt,name=swap_fee_sat,json=swapFeeSat,proto3" json:"swap_fee_sat,omitempty"`
+	//Only return swaps of the given types. If no types are set, swaps of all
+	//types are returned.
+	SwapTypes []SwapType `protobuf:"varint,1,rep,packed,name=swap_types,json=swapTypes,proto3,enum=looprpc.SwapType" json:"swap_types,omitempty"`
 	//
-	//The part of the swap fee that is requested as a prepayment.
-	PrepayAmtSat int64 `protobuf:"varint,2,opt,name=prepay_amt_sat,json=prepayAmtSat,proto3" json:"prepay_amt_sat,omitempty"`
+	//Only return swaps that are in one of the given states. If no states are
+	//set, swaps in all states are returned.
+	States []SwapState `protobuf:"varint,2,rep,packed,name=states,proto3,enum=looprpc.SwapState" json:"states,omitempty"`
 	//
-	//An estimate of the on-chain fee that needs to be paid to sweep the HTLC for
-	//a loop out.
-	HtlcSweepFeeSat int64 `protobuf:"varint,3,opt,name=htlc_sweep_fee_sat,json=htlcSweepFeeSat,proto3" json:"htlc_sweep_fee_sat,omitempty"`
+	//Only return swaps with a label that contains this substring. If empty,
+	//swaps are not filtered by label.
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
 	//
-	//The node pubkey where the swap payment needs to be paid
-	//to. This can be used to test connectivity before initiating the swap.
-	SwapPaymentDest []byte `protobuf:"bytes,4,opt,name=swap_payment_dest,json=swapPaymentDest,proto3" json:"swap_payment_dest,omitempty"`
+	//Only return swaps that were initiated at or after this time, expressed in
+	//unix nanoseconds. If zero, swaps are not filtered by start time.
+	StartTimeNs int64 `protobuf:"varint,4,opt,name=start_time_ns,json=startTimeNs,proto3" json:"start_time_ns,omitempty"`
 	//
-	//On-chain cltv expiry delta
-	CltvDelta int32 `protobuf:"varint,5,opt,name=cltv_delta,json=cltvDelta,proto3" json:"cltv_delta,omitempty"`
+	//Only return swaps that were initiated before this time, expressed in unix
+	//nanoseconds. If zero, swaps are not filtered by end time.
+	EndTimeNs int64 `protobuf:"varint,5,opt,name=end_time_ns,json=endTimeNs,proto3" json:"end_time_ns,omitempty"`
 	//
-	//The confirmation target to be used for the sweep of the on-chain HTLC.
-	ConfTarget int32 `protobuf:"varint,6,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+	//The number of swaps that match the filters to skip. Swaps are ordered by
+	//initiation time, oldest first.
+	Offset uint64 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	//
+	//The maximum number of swaps to return. If zero, all swaps that match the
+	//filters after the offset are returned.
+	Limit uint64 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *OutQuoteResponse) Reset() {
-	*x = OutQuoteResponse{}
+func (x *ListSwapsRequest) Reset() {
+	*x = ListSwapsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[13]
+		mi := &file_client_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutQuoteResponse) String() string {
+func (x *ListSwapsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutQuoteResponse) ProtoMessage() {}
+func (*ListSwapsRequest) ProtoMessage() {}
 
-func (x *OutQuoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[13]
+func (x *ListSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1651,76 +2733,92 @@ func (x *OutQuoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutQuoteResponse.ProtoReflect.Descriptor instead.
-func (*OutQuoteResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ListSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *OutQuoteResponse) GetSwapFeeSat() int64 {
+func (x *ListSwapsRequest) GetSwapTypes() []SwapType {
 	if x != nil {
-		return x.SwapFeeSat
+		return x.SwapTypes
 	}
-	return 0
+	return nil
 }
 
-func (x *OutQuoteResponse) GetPrepayAmtSat() int64 {
+func (x *ListSwapsRequest) GetStates() []SwapState {
 	if x != nil {
-		return x.PrepayAmtSat
+		return x.States
 	}
-	return 0
+	return nil
 }
 
-func (x *OutQuoteResponse) GetHtlcSweepFeeSat() int64 {
+func (x *ListSwapsRequest) GetLabel() string {
 	if x != nil {
-		return x.HtlcSweepFeeSat
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ListSwapsRequest) GetStartTimeNs() int64 {
+	if x != nil {
+		return x.StartTimeNs
 	}
 	return 0
 }
 
-func (x *OutQuoteResponse) GetSwapPaymentDest() []byte {
+func (x *ListSwapsRequest) GetEndTimeNs() int64 {
 	if x != nil {
-		return x.SwapPaymentDest
+		return x.EndTimeNs
 	}
-	return nil
+	return 0
 }
 
-func (x *OutQuoteResponse) GetCltvDelta() int32 {
+func (x *ListSwapsRequest) GetOffset() uint64 {
 	if x != nil {
-		return x.CltvDelta
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *OutQuoteResponse) GetConfTarget() int32 {
+func (x *ListSwapsRequest) GetLimit() uint64 {
 	if x != nil {
-		return x.ConfTarget
+		return x.Limit
 	}
 	return 0
 }
 
-type TokensRequest struct {
+type ListSwapsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//The list of all currently known swaps and their status that match the
+	//request's filters, ordered by initiation time.
+	Swaps []*SwapStatus `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	//
+	//The total number of swaps that match the request's filters, before the
+	//offset and limit were applied.
+	TotalSwaps uint64 `protobuf:"varint,2,opt,name=total_swaps,json=totalSwaps,proto3" json:"total_swaps,omitempty"`
 }
 
-func (x *TokensRequest) Reset() {
-	*x = TokensRequest{}
+func (x *ListSwapsResponse) Reset() {
+	*x = ListSwapsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[14]
+		mi := &file_client_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TokensRequest) String() string {
+func (x *ListSwapsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TokensRequest) ProtoMessage() {}
+func (*ListSwapsResponse) ProtoMessage() {}
 
-func (x *TokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[14]
+func (x *ListSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1731,38 +2829,57 @@ func (x *TokensRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TokensRequest.ProtoReflect.Descriptor instead.
-func (*TokensRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ListSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{11}
 }
 
-type TokensResponse struct {
+func (x *ListSwapsResponse) GetSwaps() []*SwapStatus {
+	if x != nil {
+		return x.Swaps
+	}
+	return nil
+}
+
+func (x *ListSwapsResponse) GetTotalSwaps() uint64 {
+	if x != nil {
+		return x.TotalSwaps
+	}
+	return 0
+}
+
+type AbandonSwapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//List of all tokens the daemon knows of, including old/expired tokens.
-	Tokens []*LsatToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	//The swap identifier which currently is the hash that locks the HTLCs.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	//A confirmation that the caller understands that loopd will stop executing
+	//the swap, and that any funds locked in its on-chain htlc will need to be
+	//recovered manually. The request is rejected if this is not set.
+	IKnowWhatIAmDoing bool `protobuf:"varint,2,opt,name=i_know_what_i_am_doing,json=iKnowWhatIAmDoing,proto3" json:"i_know_what_i_am_doing,omitempty"`
 }
 
-func (x *TokensResponse) Reset() {
-	*x = TokensResponse{}
+func (x *AbandonSwapRequest) Reset() {
+	*x = AbandonSwapRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[15]
+		mi := &file_client_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TokensResponse) String() string {
+func (x *AbandonSwapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TokensResponse) ProtoMessage() {}
+func (*AbandonSwapRequest) ProtoMessage() {}
 
-func (x *TokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[15]
+func (x *AbandonSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1773,69 +2890,189 @@ func (x *TokensResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TokensResponse.ProtoReflect.Descriptor instead.
-func (*TokensResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use AbandonSwapRequest.ProtoReflect.Descriptor instead.
+func (*AbandonSwapRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *TokensResponse) GetTokens() []*LsatToken {
+func (x *AbandonSwapRequest) GetId() []byte {
 	if x != nil {
-		return x.Tokens
+		return x.Id
 	}
 	return nil
 }
 
-type LsatToken struct {
+func (x *AbandonSwapRequest) GetIKnowWhatIAmDoing() bool {
+	if x != nil {
+		return x.IKnowWhatIAmDoing
+	}
+	return false
+}
+
+type AbandonSwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AbandonSwapResponse) Reset() {
+	*x = AbandonSwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbandonSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonSwapResponse) ProtoMessage() {}
+
+func (x *AbandonSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonSwapResponse.ProtoReflect.Descriptor instead.
+func (*AbandonSwapResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{13}
+}
+
+type RecoverSweepRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The base macaroon that was baked by the auth server.
-	BaseMacaroon []byte `protobuf:"bytes,1,opt,name=base_macaroon,json=baseMacaroon,proto3" json:"base_macaroon,omitempty"`
+	//The swap identifier which currently is the hash that locks the HTLCs.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	//
-	//The payment hash of the payment that was paid to obtain the token.
-	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	//The address to sweep the htlc to. If not set, an address is obtained from
+	//the backing lnd node's wallet.
+	Dest string `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
 	//
-	//The preimage of the payment hash, knowledge of this is proof that the
-	//payment has been paid. If the preimage is set to all zeros, this means the
-	//payment is still pending and the token is not yet fully valid.
-	PaymentPreimage []byte `protobuf:"bytes,3,opt,name=payment_preimage,json=paymentPreimage,proto3" json:"payment_preimage,omitempty"`
+	//The fee rate in sat/vbyte to publish the sweep with.
+	SatPerVbyte uint64 `protobuf:"varint,3,opt,name=sat_per_vbyte,json=satPerVbyte,proto3" json:"sat_per_vbyte,omitempty"`
 	//
-	//The amount of millisatoshis that was paid to get the token.
-	AmountPaidMsat int64 `protobuf:"varint,4,opt,name=amount_paid_msat,json=amountPaidMsat,proto3" json:"amount_paid_msat,omitempty"`
+	//Whether the sweep should be published. If not set, the sweep is only
+	//created and returned so that it can be inspected.
+	Publish bool `protobuf:"varint,4,opt,name=publish,proto3" json:"publish,omitempty"`
+}
+
+func (x *RecoverSweepRequest) Reset() {
+	*x = RecoverSweepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecoverSweepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverSweepRequest) ProtoMessage() {}
+
+func (x *RecoverSweepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoverSweepRequest.ProtoReflect.Descriptor instead.
+func (*RecoverSweepRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecoverSweepRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *RecoverSweepRequest) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *RecoverSweepRequest) GetSatPerVbyte() uint64 {
+	if x != nil {
+		return x.SatPerVbyte
+	}
+	return 0
+}
+
+func (x *RecoverSweepRequest) GetPublish() bool {
+	if x != nil {
+		return x.Publish
+	}
+	return false
+}
+
+type RecoverSweepResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	//
-	//The amount of millisatoshis paid in routing fee to pay for the token.
-	RoutingFeePaidMsat int64 `protobuf:"varint,5,opt,name=routing_fee_paid_msat,json=routingFeePaidMsat,proto3" json:"routing_fee_paid_msat,omitempty"`
+	//The txid of the sweep transaction.
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
 	//
-	//The creation time of the token as UNIX timestamp in seconds.
-	TimeCreated int64 `protobuf:"varint,6,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	//The serialized sweep transaction.
+	SweepTx []byte `protobuf:"bytes,2,opt,name=sweep_tx,json=sweepTx,proto3" json:"sweep_tx,omitempty"`
 	//
-	//Indicates whether the token is expired or still valid.
-	Expired bool `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	//The outpoint of the htlc that is swept, formatted as txid:index.
+	HtlcOutpoint string `protobuf:"bytes,3,opt,name=htlc_outpoint,json=htlcOutpoint,proto3" json:"htlc_outpoint,omitempty"`
 	//
-	//Identifying attribute of this token in the store. Currently represents the
-	//file name of the token where it's stored on the file system.
-	StorageName string `protobuf:"bytes,8,opt,name=storage_name,json=storageName,proto3" json:"storage_name,omitempty"`
+	//The value of the htlc that is swept.
+	HtlcValueSat int64 `protobuf:"varint,4,opt,name=htlc_value_sat,json=htlcValueSat,proto3" json:"htlc_value_sat,omitempty"`
+	//
+	//The on-chain fee paid by the sweep.
+	FeeSat int64 `protobuf:"varint,5,opt,name=fee_sat,json=feeSat,proto3" json:"fee_sat,omitempty"`
+	//
+	//The address that the htlc is swept to.
+	Dest string `protobuf:"bytes,6,opt,name=dest,proto3" json:"dest,omitempty"`
+	//
+	//Whether the sweep was published.
+	Published bool `protobuf:"varint,7,opt,name=published,proto3" json:"published,omitempty"`
 }
 
-func (x *LsatToken) Reset() {
-	*x = LsatToken{}
+func (x *RecoverSweepResponse) Reset() {
+	*x = RecoverSweepResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[16]
+		mi := &file_client_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LsatToken) String() string {
+func (x *RecoverSweepResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LsatToken) ProtoMessage() {}
+func (*RecoverSweepResponse) ProtoMessage() {}
 
-func (x *LsatToken) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[16]
+func (x *RecoverSweepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1846,75 +3083,122 @@ func (x *LsatToken) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LsatToken.ProtoReflect.Descriptor instead.
-func (*LsatToken) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use RecoverSweepResponse.ProtoReflect.Descriptor instead.
+func (*RecoverSweepResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *LsatToken) GetBaseMacaroon() []byte {
+func (x *RecoverSweepResponse) GetTxid() string {
 	if x != nil {
-		return x.BaseMacaroon
+		return x.Txid
 	}
-	return nil
+	return ""
 }
 
-func (x *LsatToken) GetPaymentHash() []byte {
+func (x *RecoverSweepResponse) GetSweepTx() []byte {
 	if x != nil {
-		return x.PaymentHash
+		return x.SweepTx
 	}
 	return nil
 }
 
-func (x *LsatToken) GetPaymentPreimage() []byte {
+func (x *RecoverSweepResponse) GetHtlcOutpoint() string {
 	if x != nil {
-		return x.PaymentPreimage
+		return x.HtlcOutpoint
 	}
-	return nil
+	return ""
 }
 
-func (x *LsatToken) GetAmountPaidMsat() int64 {
+func (x *RecoverSweepResponse) GetHtlcValueSat() int64 {
 	if x != nil {
-		return x.AmountPaidMsat
+		return x.HtlcValueSat
 	}
 	return 0
 }
 
-func (x *LsatToken) GetRoutingFeePaidMsat() int64 {
+func (x *RecoverSweepResponse) GetFeeSat() int64 {
 	if x != nil {
-		return x.RoutingFeePaidMsat
+		return x.FeeSat
 	}
 	return 0
 }
 
-func (x *LsatToken) GetTimeCreated() int64 {
+func (x *RecoverSweepResponse) GetDest() string {
 	if x != nil {
-		return x.TimeCreated
+		return x.Dest
 	}
-	return 0
+	return ""
 }
 
-func (x *LsatToken) GetExpired() bool {
+func (x *RecoverSweepResponse) GetPublished() bool {
 	if x != nil {
-		return x.Expired
+		return x.Published
 	}
 	return false
 }
 
-func (x *LsatToken) GetStorageName() string {
+type PruneSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The amount of time in seconds that swaps are kept for after they reach a
+	//final state. If zero, swaps are kept for 90 days. Must be at least 7 days.
+	RetentionSec uint64 `protobuf:"varint,1,opt,name=retention_sec,json=retentionSec,proto3" json:"retention_sec,omitempty"`
+}
+
+func (x *PruneSwapsRequest) Reset() {
+	*x = PruneSwapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PruneSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneSwapsRequest) ProtoMessage() {}
+
+func (x *PruneSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneSwapsRequest.ProtoReflect.Descriptor instead.
+func (*PruneSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PruneSwapsRequest) GetRetentionSec() uint64 {
 	if x != nil {
-		return x.StorageName
+		return x.RetentionSec
 	}
-	return ""
+	return 0
 }
 
-type GetLiquidityParamsRequest struct {
+type PruneSwapsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//The identifiers of the swaps that were deleted.
+	PrunedSwaps [][]byte `protobuf:"bytes,1,rep,name=pruned_swaps,json=prunedSwaps,proto3" json:"pruned_swaps,omitempty"`
 }
 
-func (x *GetLiquidityParamsRequest) Reset() {
-	*x = GetLiquidityParamsRequest{}
+func (x *PruneSwapsResponse) Reset() {
+	*x = PruneSwapsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1922,13 +3206,13 @@ func (x *GetLiquidityParamsRequest) Reset() {
 	}
 }
 
-func (x *GetLiquidityParamsRequest) String() string {
+func (x *PruneSwapsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLiquidityParamsRequest) ProtoMessage() {}
+func (*PruneSwapsResponse) ProtoMessage() {}
 
-func (x *GetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
+func (x *PruneSwapsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1940,94 +3224,26 @@ func (x *GetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLiquidityParamsRequest.ProtoReflect.Descriptor instead.
-func (*GetLiquidityParamsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PruneSwapsResponse.ProtoReflect.Descriptor instead.
+func (*PruneSwapsResponse) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{17}
 }
 
-type LiquidityParameters struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//
-	//A set of liquidity rules that describe the desired liquidity balance.
-	Rules []*LiquidityRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
-	//
-	//The parts per million of swap amount that is allowed to be allocated to swap
-	//fees. This value is applied across swap categories and may not be set in
-	//conjunction with sweep fee rate, swap fee ppm, routing fee ppm, prepay
-	//routing, max prepay and max miner fee.
-	FeePpm uint64 `protobuf:"varint,16,opt,name=fee_ppm,json=feePpm,proto3" json:"fee_ppm,omitempty"`
-	//
-	//The limit we place on our estimated sweep cost for a swap in sat/vByte. If
-	//the estimated fee for our sweep transaction within the specified
-	//confirmation target is above this value, we will not suggest any swaps.
-	SweepFeeRateSatPerVbyte uint64 `protobuf:"varint,2,opt,name=sweep_fee_rate_sat_per_vbyte,json=sweepFeeRateSatPerVbyte,proto3" json:"sweep_fee_rate_sat_per_vbyte,omitempty"`
-	//
-	//The maximum fee paid to the server for facilitating the swap, expressed
-	//as parts per million of the swap volume.
-	MaxSwapFeePpm uint64 `protobuf:"varint,3,opt,name=max_swap_fee_ppm,json=maxSwapFeePpm,proto3" json:"max_swap_fee_ppm,omitempty"`
-	//
-	//The maximum fee paid to route the swap invoice off chain, expressed as
-	//parts per million of the volume being routed.
-	MaxRoutingFeePpm uint64 `protobuf:"varint,4,opt,name=max_routing_fee_ppm,json=maxRoutingFeePpm,proto3" json:"max_routing_fee_ppm,omitempty"`
-	//
-	//The maximum fee paid to route the prepay invoice off chain, expressed as
-	//parts per million of the volume being routed.
-	MaxPrepayRoutingFeePpm uint64 `protobuf:"varint,5,opt,name=max_prepay_routing_fee_ppm,json=maxPrepayRoutingFeePpm,proto3" json:"max_prepay_routing_fee_ppm,omitempty"`
-	//
-	//The maximum no-show penalty in satoshis paid for a swap.
-	MaxPrepaySat uint64 `protobuf:"varint,6,opt,name=max_prepay_sat,json=maxPrepaySat,proto3" json:"max_prepay_sat,omitempty"`
-	//
-	//The maximum miner fee we will pay to sweep the swap on chain. Note that we
-	//will not suggest a swap if the estimate is above the sweep limit set by
-	//these parameters, and we use the current fee estimate to sweep on chain so
-	//this value is only a cap placed on the amount we spend on fees in the case
-	//where the swap needs to be claimed on chain, but fees have suddenly spiked.
-	MaxMinerFeeSat uint64 `protobuf:"varint,7,opt,name=max_miner_fee_sat,json=maxMinerFeeSat,proto3" json:"max_miner_fee_sat,omitempty"`
-	//
-	//The number of blocks from the on-chain HTLC's confirmation height that it
-	//should be swept within.
-	SweepConfTarget int32 `protobuf:"varint,8,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
-	//
-	//The amount of time we require pass since a channel was part of a failed
-	//swap due to off chain payment failure until it will be considered for swap
-	//suggestions again, expressed in seconds.
-	FailureBackoffSec uint64 `protobuf:"varint,9,opt,name=failure_backoff_sec,json=failureBackoffSec,proto3" json:"failure_backoff_sec,omitempty"`
-	//
-	//Set to true to enable automatic dispatch of swaps. All swaps will be limited
-	//to the fee categories set by these parameters, and total expenditure will
-	//be limited to the autoloop budget.
-	Autoloop bool `protobuf:"varint,10,opt,name=autoloop,proto3" json:"autoloop,omitempty"`
-	//
-	//The total budget for automatically dispatched swaps since the budget start
-	//time, expressed in satoshis.
-	AutoloopBudgetSat uint64 `protobuf:"varint,11,opt,name=autoloop_budget_sat,json=autoloopBudgetSat,proto3" json:"autoloop_budget_sat,omitempty"`
-	//
-	//The start time for autoloop budget, expressed as a unix timestamp in
-	//seconds. If this value is 0, the budget will be applied for all
-	//automatically dispatched swaps. Swaps that were completed before this date
-	//will not be included in budget calculations.
-	AutoloopBudgetStartSec uint64 `protobuf:"varint,12,opt,name=autoloop_budget_start_sec,json=autoloopBudgetStartSec,proto3" json:"autoloop_budget_start_sec,omitempty"`
-	//
-	//The maximum number of automatically dispatched swaps that we allow to be in
-	//flight at any point in time.
-	AutoMaxInFlight uint64 `protobuf:"varint,13,opt,name=auto_max_in_flight,json=autoMaxInFlight,proto3" json:"auto_max_in_flight,omitempty"`
-	//
-	//The minimum amount, expressed in satoshis, that the autoloop client will
-	//dispatch a swap for. This value is subject to the server-side limits
-	//specified by the LoopOutTerms endpoint.
-	MinSwapAmount uint64 `protobuf:"varint,14,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
-	//
-	//The maximum amount, expressed in satoshis, that the autoloop client will
-	//dispatch a swap for. This value is subject to the server-side limits
-	//specified by the LoopOutTerms endpoint.
-	MaxSwapAmount uint64 `protobuf:"varint,15,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
+func (x *PruneSwapsResponse) GetPrunedSwaps() [][]byte {
+	if x != nil {
+		return x.PrunedSwaps
+	}
+	return nil
 }
 
-func (x *LiquidityParameters) Reset() {
-	*x = LiquidityParameters{}
+type ExportSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ExportSwapsRequest) Reset() {
+	*x = ExportSwapsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_client_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2035,13 +3251,13 @@ func (x *LiquidityParameters) Reset() {
 	}
 }
 
-func (x *LiquidityParameters) String() string {
+func (x *ExportSwapsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LiquidityParameters) ProtoMessage() {}
+func (*ExportSwapsRequest) ProtoMessage() {}
 
-func (x *LiquidityParameters) ProtoReflect() protoreflect.Message {
+func (x *ExportSwapsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_client_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2053,169 +3269,143 @@ func (x *LiquidityParameters) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LiquidityParameters.ProtoReflect.Descriptor instead.
-func (*LiquidityParameters) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ExportSwapsRequest) Descriptor() ([]byte, []int) {
 	return file_client_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LiquidityParameters) GetRules() []*LiquidityRule {
-	if x != nil {
-		return x.Rules
-	}
-	return nil
-}
+type ExportSwapsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *LiquidityParameters) GetFeePpm() uint64 {
-	if x != nil {
-		return x.FeePpm
-	}
-	return 0
+	//
+	//The JSON encoded export of all swaps.
+	Export []byte `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
 }
 
-func (x *LiquidityParameters) GetSweepFeeRateSatPerVbyte() uint64 {
-	if x != nil {
-		return x.SweepFeeRateSatPerVbyte
+func (x *ExportSwapsResponse) Reset() {
+	*x = ExportSwapsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *LiquidityParameters) GetMaxSwapFeePpm() uint64 {
-	if x != nil {
-		return x.MaxSwapFeePpm
-	}
-	return 0
+func (x *ExportSwapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *LiquidityParameters) GetMaxRoutingFeePpm() uint64 {
-	if x != nil {
-		return x.MaxRoutingFeePpm
-	}
-	return 0
-}
+func (*ExportSwapsResponse) ProtoMessage() {}
 
-func (x *LiquidityParameters) GetMaxPrepayRoutingFeePpm() uint64 {
-	if x != nil {
-		return x.MaxPrepayRoutingFeePpm
+func (x *ExportSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *LiquidityParameters) GetMaxPrepaySat() uint64 {
-	if x != nil {
-		return x.MaxPrepaySat
-	}
-	return 0
+// Deprecated: Use ExportSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ExportSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *LiquidityParameters) GetMaxMinerFeeSat() uint64 {
+func (x *ExportSwapsResponse) GetExport() []byte {
 	if x != nil {
-		return x.MaxMinerFeeSat
+		return x.Export
 	}
-	return 0
+	return nil
 }
 
-func (x *LiquidityParameters) GetSweepConfTarget() int32 {
-	if x != nil {
-		return x.SweepConfTarget
-	}
-	return 0
-}
+type ImportSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *LiquidityParameters) GetFailureBackoffSec() uint64 {
-	if x != nil {
-		return x.FailureBackoffSec
-	}
-	return 0
+	//
+	//The JSON encoded export to import, as returned by ExportSwaps.
+	Export []byte `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
 }
 
-func (x *LiquidityParameters) GetAutoloop() bool {
-	if x != nil {
-		return x.Autoloop
+func (x *ImportSwapsRequest) Reset() {
+	*x = ImportSwapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *LiquidityParameters) GetAutoloopBudgetSat() uint64 {
-	if x != nil {
-		return x.AutoloopBudgetSat
-	}
-	return 0
+func (x *ImportSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *LiquidityParameters) GetAutoloopBudgetStartSec() uint64 {
-	if x != nil {
-		return x.AutoloopBudgetStartSec
-	}
-	return 0
-}
+func (*ImportSwapsRequest) ProtoMessage() {}
 
-func (x *LiquidityParameters) GetAutoMaxInFlight() uint64 {
-	if x != nil {
-		return x.AutoMaxInFlight
+func (x *ImportSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *LiquidityParameters) GetMinSwapAmount() uint64 {
-	if x != nil {
-		return x.MinSwapAmount
-	}
-	return 0
+// Deprecated: Use ImportSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ImportSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *LiquidityParameters) GetMaxSwapAmount() uint64 {
+func (x *ImportSwapsRequest) GetExport() []byte {
 	if x != nil {
-		return x.MaxSwapAmount
+		return x.Export
 	}
-	return 0
+	return nil
 }
 
-type LiquidityRule struct {
+type ImportSwapsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The short channel ID of the channel that this rule should be applied to.
-	//This field may not be set when the pubkey field is set.
-	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	//
-	//The public key of the peer that this rule should be applied to. This field
-	//may not be set when the channel id field is set.
-	Pubkey []byte `protobuf:"bytes,5,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
-	//
-	//Type indicates the type of rule that this message rule represents. Setting
-	//this value will determine which fields are used in the message. The comments
-	//on each field in this message will be prefixed with the LiquidityRuleType
-	//they belong to.
-	Type LiquidityRuleType `protobuf:"varint,2,opt,name=type,proto3,enum=looprpc.LiquidityRuleType" json:"type,omitempty"`
+	//The number of swaps that were imported.
+	Imported uint32 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
 	//
-	//THRESHOLD: The percentage of total capacity that incoming capacity should
-	//not drop beneath.
-	IncomingThreshold uint32 `protobuf:"varint,3,opt,name=incoming_threshold,json=incomingThreshold,proto3" json:"incoming_threshold,omitempty"`
+	//The number of swaps that were skipped because they already exist.
+	Skipped uint32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
 	//
-	//THRESHOLD: The percentage of total capacity that outgoing capacity should
-	//not drop beneath.
-	OutgoingThreshold uint32 `protobuf:"varint,4,opt,name=outgoing_threshold,json=outgoingThreshold,proto3" json:"outgoing_threshold,omitempty"`
+	//The number of imported swaps that are pending, which are only resumed once
+	//loopd is restarted.
+	PendingImported uint32 `protobuf:"varint,3,opt,name=pending_imported,json=pendingImported,proto3" json:"pending_imported,omitempty"`
 }
 
-func (x *LiquidityRule) Reset() {
-	*x = LiquidityRule{}
+func (x *ImportSwapsResponse) Reset() {
+	*x = ImportSwapsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[19]
+		mi := &file_client_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LiquidityRule) String() string {
+func (x *ImportSwapsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LiquidityRule) ProtoMessage() {}
+func (*ImportSwapsResponse) ProtoMessage() {}
 
-func (x *LiquidityRule) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[19]
+func (x *ImportSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2226,76 +3416,82 @@ func (x *LiquidityRule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LiquidityRule.ProtoReflect.Descriptor instead.
-func (*LiquidityRule) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ImportSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ImportSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *LiquidityRule) GetChannelId() uint64 {
+func (x *ImportSwapsResponse) GetImported() uint32 {
 	if x != nil {
-		return x.ChannelId
+		return x.Imported
 	}
 	return 0
 }
 
-func (x *LiquidityRule) GetPubkey() []byte {
-	if x != nil {
-		return x.Pubkey
-	}
-	return nil
-}
-
-func (x *LiquidityRule) GetType() LiquidityRuleType {
-	if x != nil {
-		return x.Type
-	}
-	return LiquidityRuleType_UNKNOWN
-}
-
-func (x *LiquidityRule) GetIncomingThreshold() uint32 {
+func (x *ImportSwapsResponse) GetSkipped() uint32 {
 	if x != nil {
-		return x.IncomingThreshold
+		return x.Skipped
 	}
 	return 0
 }
 
-func (x *LiquidityRule) GetOutgoingThreshold() uint32 {
+func (x *ImportSwapsResponse) GetPendingImported() uint32 {
 	if x != nil {
-		return x.OutgoingThreshold
+		return x.PendingImported
 	}
 	return 0
 }
 
-type SetLiquidityParamsRequest struct {
+type SwapTemplate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//Parameters is the desired new set of parameters for the liquidity management
-	//subsystem. Note that the current set of parameters will be completely
-	//overwritten by the parameters provided (if they are valid), so the full set
-	//of parameters should be provided for each call.
-	Parameters *LiquidityParameters `protobuf:"bytes,1,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	//The unique name of the template. Names may contain letters, digits, dashes
+	//and underscores.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	//
+	//The amount to loop out in satoshis.
+	Amt int64 `protobuf:"varint,2,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//The set of short channel ids that the swap may be paid through. If empty,
+	//any channel may be used.
+	OutgoingChanSet []uint64 `protobuf:"varint,3,rep,packed,name=outgoing_chan_set,json=outgoingChanSet,proto3" json:"outgoing_chan_set,omitempty"`
+	//
+	//The maximum off-chain routing fee in satoshis for the swap payment. If
+	//zero, a fee limit based on the swap's quote is used.
+	MaxSwapRoutingFee int64 `protobuf:"varint,4,opt,name=max_swap_routing_fee,json=maxSwapRoutingFee,proto3" json:"max_swap_routing_fee,omitempty"`
+	//
+	//The confirmation target for the swap's sweep. If zero, loopd's
+	//confirmation policy picks a target.
+	SweepConfTarget int32 `protobuf:"varint,5,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
+	//
+	//The number of confirmations that the swap's htlc requires. If zero,
+	//loopd's confirmation policy picks a value.
+	HtlcConfirmations int32 `protobuf:"varint,6,opt,name=htlc_confirmations,json=htlcConfirmations,proto3" json:"htlc_confirmations,omitempty"`
+	//
+	//The label that swaps dispatched with the template are created with.
+	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
 }
 
-func (x *SetLiquidityParamsRequest) Reset() {
-	*x = SetLiquidityParamsRequest{}
+func (x *SwapTemplate) Reset() {
+	*x = SwapTemplate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[20]
+		mi := &file_client_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetLiquidityParamsRequest) String() string {
+func (x *SwapTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetLiquidityParamsRequest) ProtoMessage() {}
+func (*SwapTemplate) ProtoMessage() {}
 
-func (x *SetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[20]
+func (x *SwapTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2306,41 +3502,87 @@ func (x *SetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetLiquidityParamsRequest.ProtoReflect.Descriptor instead.
-func (*SetLiquidityParamsRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SwapTemplate.ProtoReflect.Descriptor instead.
+func (*SwapTemplate) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *SetLiquidityParamsRequest) GetParameters() *LiquidityParameters {
+func (x *SwapTemplate) GetName() string {
 	if x != nil {
-		return x.Parameters
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SwapTemplate) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *SwapTemplate) GetOutgoingChanSet() []uint64 {
+	if x != nil {
+		return x.OutgoingChanSet
 	}
 	return nil
 }
 
-type SetLiquidityParamsResponse struct {
+func (x *SwapTemplate) GetMaxSwapRoutingFee() int64 {
+	if x != nil {
+		return x.MaxSwapRoutingFee
+	}
+	return 0
+}
+
+func (x *SwapTemplate) GetSweepConfTarget() int32 {
+	if x != nil {
+		return x.SweepConfTarget
+	}
+	return 0
+}
+
+func (x *SwapTemplate) GetHtlcConfirmations() int32 {
+	if x != nil {
+		return x.HtlcConfirmations
+	}
+	return 0
+}
+
+func (x *SwapTemplate) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type SaveSwapTemplateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	//
+	//The template to save.
+	Template *SwapTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
 }
 
-func (x *SetLiquidityParamsResponse) Reset() {
-	*x = SetLiquidityParamsResponse{}
+func (x *SaveSwapTemplateRequest) Reset() {
+	*x = SaveSwapTemplateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[21]
+		mi := &file_client_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetLiquidityParamsResponse) String() string {
+func (x *SaveSwapTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetLiquidityParamsResponse) ProtoMessage() {}
+func (*SaveSwapTemplateRequest) ProtoMessage() {}
 
-func (x *SetLiquidityParamsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[21]
+func (x *SaveSwapTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2351,34 +3593,41 @@ func (x *SetLiquidityParamsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetLiquidityParamsResponse.ProtoReflect.Descriptor instead.
-func (*SetLiquidityParamsResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SaveSwapTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SaveSwapTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{23}
 }
 
-type SuggestSwapsRequest struct {
+func (x *SaveSwapTemplateRequest) GetTemplate() *SwapTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type SaveSwapTemplateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SuggestSwapsRequest) Reset() {
-	*x = SuggestSwapsRequest{}
+func (x *SaveSwapTemplateResponse) Reset() {
+	*x = SaveSwapTemplateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[22]
+		mi := &file_client_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SuggestSwapsRequest) String() string {
+func (x *SaveSwapTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuggestSwapsRequest) ProtoMessage() {}
+func (*SaveSwapTemplateResponse) ProtoMessage() {}
 
-func (x *SuggestSwapsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[22]
+func (x *SaveSwapTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2389,44 +3638,34 @@ func (x *SuggestSwapsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuggestSwapsRequest.ProtoReflect.Descriptor instead.
-func (*SuggestSwapsRequest) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SaveSwapTemplateResponse.ProtoReflect.Descriptor instead.
+func (*SaveSwapTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{24}
 }
 
-type Disqualified struct {
+type ListSwapTemplatesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	//
-	//The short channel ID of the channel that was excluded from our suggestions.
-	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	//
-	//The public key of the peer that was excluded from our suggestions.
-	Pubkey []byte `protobuf:"bytes,3,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
-	//
-	//The reason that we excluded the channel from the our suggestions.
-	Reason AutoReason `protobuf:"varint,2,opt,name=reason,proto3,enum=looprpc.AutoReason" json:"reason,omitempty"`
 }
 
-func (x *Disqualified) Reset() {
-	*x = Disqualified{}
+func (x *ListSwapTemplatesRequest) Reset() {
+	*x = ListSwapTemplatesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[23]
+		mi := &file_client_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Disqualified) String() string {
+func (x *ListSwapTemplatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Disqualified) ProtoMessage() {}
+func (*ListSwapTemplatesRequest) ProtoMessage() {}
 
-func (x *Disqualified) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[23]
+func (x *ListSwapTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2437,63 +3676,87 @@ func (x *Disqualified) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Disqualified.ProtoReflect.Descriptor instead.
-func (*Disqualified) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ListSwapTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListSwapTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Disqualified) GetChannelId() uint64 {
-	if x != nil {
-		return x.ChannelId
+type ListSwapTemplatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//All of our swap templates, ordered by name.
+	Templates []*SwapTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+}
+
+func (x *ListSwapTemplatesResponse) Reset() {
+	*x = ListSwapTemplatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Disqualified) GetPubkey() []byte {
-	if x != nil {
-		return x.Pubkey
+func (x *ListSwapTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwapTemplatesResponse) ProtoMessage() {}
+
+func (x *ListSwapTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Disqualified) GetReason() AutoReason {
+// Deprecated: Use ListSwapTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListSwapTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListSwapTemplatesResponse) GetTemplates() []*SwapTemplate {
 	if x != nil {
-		return x.Reason
+		return x.Templates
 	}
-	return AutoReason_AUTO_REASON_UNKNOWN
+	return nil
 }
 
-type SuggestSwapsResponse struct {
+type DeleteSwapTemplateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	//
-	//The set of recommended loop outs.
-	LoopOut []*LoopOutRequest `protobuf:"bytes,1,rep,name=loop_out,json=loopOut,proto3" json:"loop_out,omitempty"`
-	//
-	//Disqualified contains the set of channels that swaps are not recommended
-	//for.
-	Disqualified []*Disqualified `protobuf:"bytes,2,rep,name=disqualified,proto3" json:"disqualified,omitempty"`
+	//The name of the template to delete.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *SuggestSwapsResponse) Reset() {
-	*x = SuggestSwapsResponse{}
+func (x *DeleteSwapTemplateRequest) Reset() {
+	*x = DeleteSwapTemplateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_client_proto_msgTypes[24]
+		mi := &file_client_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SuggestSwapsResponse) String() string {
+func (x *DeleteSwapTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuggestSwapsResponse) ProtoMessage() {}
+func (*DeleteSwapTemplateRequest) ProtoMessage() {}
 
-func (x *SuggestSwapsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_client_proto_msgTypes[24]
+func (x *DeleteSwapTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2504,1402 +3767,11311 @@ func (x *SuggestSwapsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuggestSwapsResponse.ProtoReflect.Descriptor instead.
-func (*SuggestSwapsResponse) Descriptor() ([]byte, []int) {
-	return file_client_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use DeleteSwapTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSwapTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SuggestSwapsResponse) GetLoopOut() []*LoopOutRequest {
+func (x *DeleteSwapTemplateRequest) GetName() string {
 	if x != nil {
-		return x.LoopOut
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *SuggestSwapsResponse) GetDisqualified() []*Disqualified {
-	if x != nil {
-		return x.Disqualified
-	}
-	return nil
+type DeleteSwapTemplateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-var File_client_proto protoreflect.FileDescriptor
+func (x *DeleteSwapTemplateResponse) Reset() {
+	*x = DeleteSwapTemplateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-var file_client_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
-	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x22, 0xad, 0x04, 0x0a, 0x0e, 0x4c, 0x6f, 0x6f, 0x70,
-	0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x74,
-	0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
-	0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65,
-	0x65, 0x12, 0x33, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f,
-	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x52, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61,
-	0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
-	0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f, 0x61, 0x6d, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x41, 0x6d, 0x74, 0x12, 0x22,
-	0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x46,
-	0x65, 0x65, 0x12, 0x2c, 0x0a, 0x10, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x42, 0x02, 0x18, 0x01,
-	0x52, 0x0e, 0x6c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61,
-	0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x75, 0x74,
-	0x67, 0x6f, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x11,
-	0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x43, 0x6f,
-	0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63,
-	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
-	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x77, 0x61, 0x70, 0x5f,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64,
-	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x61, 0x70,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c,
-	0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x85, 0x02, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x70,
-	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x6d,
-	0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x12, 0x22, 0x0a,
-	0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x65,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x23, 0x0a, 0x0d,
-	0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x48, 0x74, 0x6c,
-	0x63, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x68, 0x74, 0x6c,
-	0x63, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x22,
-	0xe9, 0x01, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
-	0x25, 0x0a, 0x0c, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x68, 0x74, 0x6c, 0x63, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6e, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x4e, 0x70, 0x32, 0x77, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50,
-	0x32, 0x77, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x4d,
-	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb4, 0x04,
-	0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03,
-	0x61, 0x6d, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x12,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f,
-	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77,
-	0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3d,
-	0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
-	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
-	0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0d,
-	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
-	0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x25, 0x0a, 0x0c, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x68, 0x74, 0x6c, 0x63,
-	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x50, 0x32, 0x77, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6e, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4e,
-	0x70, 0x32, 0x77, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x73, 0x74,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x6f,
-	0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f,
-	0x73, 0x74, 0x4f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x73,
-	0x74, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0c, 0x63, 0x6f, 0x73, 0x74, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14,
-	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
-	0x61, 0x62, 0x65, 0x6c, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74,
-	0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
-	0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c,
-	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x22, 0x21, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x54,
-	0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7f, 0x0a, 0x0f, 0x49,
-	0x6e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26,
-	0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61, 0x70,
-	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4a, 0x04,
-	0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04,
-	0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0xcc, 0x01, 0x0a,
-	0x10, 0x4f, 0x75, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x53,
-	0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6c, 0x74, 0x76, 0x5f, 0x64, 0x65,
-	0x6c, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x43, 0x6c,
-	0x74, 0x76, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x63,
-	0x6c, 0x74, 0x76, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0c, 0x6d, 0x61, 0x78, 0x43, 0x6c, 0x74, 0x76, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x4a, 0x04, 0x08,
-	0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a,
-	0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0xa2, 0x01, 0x0a, 0x0c,
-	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x61, 0x6d, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x1f,
-	0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
-	0x23, 0x0a, 0x0d, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x6c, 0x63,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x48, 0x74, 0x6c, 0x63, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x61, 0x70, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
-	0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65,
-	0x5f, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70,
-	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x70,
-	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
-	0x68, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x74, 0x76, 0x5f,
-	0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6c, 0x74,
-	0x76, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
-	0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08,
-	0x04, 0x10, 0x05, 0x22, 0xf3, 0x01, 0x0a, 0x10, 0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x77, 0x61, 0x70,
-	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x73, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72,
-	0x65, 0x70, 0x61, 0x79, 0x5f, 0x61, 0x6d, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x41, 0x6d, 0x74, 0x53, 0x61, 0x74,
-	0x12, 0x2b, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x66,
-	0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x68, 0x74,
-	0x6c, 0x63, 0x53, 0x77, 0x65, 0x65, 0x70, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2a, 0x0a,
-	0x11, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65,
-	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x77, 0x61, 0x70, 0x50, 0x61,
-	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x74,
-	0x76, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63,
-	0x6c, 0x74, 0x76, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66,
-	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63,
-	0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x0e, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c,
-	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x73, 0x61, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0xbb, 0x02, 0x0a, 0x09, 0x4c, 0x73, 0x61,
-	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6d,
-	0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x62,
-	0x61, 0x73, 0x65, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70,
-	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x29,
-	0x0a, 0x10, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61,
-	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
-	0x74, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x69, 0x64, 0x4d,
-	0x73, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66,
-	0x65, 0x65, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x12, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x61,
-	0x69, 0x64, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69,
-	0x6d, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70,
-	0x69, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x71,
-	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0xe0, 0x05, 0x0a, 0x13, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
-	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x72,
-	0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f,
-	0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x52, 0x75,
-	0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x65,
-	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x65, 0x65, 0x50,
-	0x70, 0x6d, 0x12, 0x3d, 0x0a, 0x1c, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f,
-	0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79,
-	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x65, 0x65, 0x70, 0x46,
-	0x65, 0x65, 0x52, 0x61, 0x74, 0x65, 0x53, 0x61, 0x74, 0x50, 0x65, 0x72, 0x56, 0x62, 0x79, 0x74,
-	0x65, 0x12, 0x27, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65,
-	0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78,
-	0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61,
-	0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70,
-	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x3a, 0x0a, 0x1a, 0x6d, 0x61, 0x78,
-	0x5f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f,
-	0x66, 0x65, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d,
-	0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46,
-	0x65, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65,
-	0x70, 0x61, 0x79, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d,
-	0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x53, 0x61, 0x74, 0x12, 0x29, 0x0a, 0x11, 0x6d,
-	0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65, 0x72,
-	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x61,
-	0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x11, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x53,
-	0x65, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x12, 0x2e,
-	0x0a, 0x13, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65,
-	0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x61, 0x75, 0x74,
-	0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x53, 0x61, 0x74, 0x12, 0x39,
-	0x0a, 0x19, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65,
-	0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x16, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x75, 0x64, 0x67, 0x65,
-	0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x63, 0x12, 0x2b, 0x0a, 0x12, 0x61, 0x75, 0x74,
-	0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x61, 0x75, 0x74, 0x6f, 0x4d, 0x61, 0x78, 0x49, 0x6e,
-	0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0d, 0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26,
-	0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70,
-	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd4, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x71, 0x75, 0x69,
-	0x64, 0x69, 0x74, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12,
-	0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
-	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
-	0x79, 0x52, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65,
-	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x69, 0x6e, 0x63,
-	0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2d,
-	0x0a, 0x12, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6f, 0x75, 0x74, 0x67,
-	0x6f, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x59, 0x0a,
-	0x19, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
-	0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x53, 0x65, 0x74, 0x4c,
-	0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
-	0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a,
-	0x0c, 0x44, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41,
-	0x75, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x22, 0x85, 0x01, 0x0a, 0x14, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61,
-	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6c, 0x6f,
-	0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c,
-	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x6c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x39,
-	0x0a, 0x0c, 0x64, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x44,
-	0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x0c, 0x64, 0x69, 0x73,
-	0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x2a, 0x25, 0x0a, 0x08, 0x53, 0x77, 0x61,
-	0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x4f, 0x55,
-	0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x01,
-	0x2a, 0x73, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x0a,
-	0x09, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
-	0x50, 0x52, 0x45, 0x49, 0x4d, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x56, 0x45, 0x41, 0x4c, 0x45,
-	0x44, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x48, 0x54, 0x4c, 0x43, 0x5f, 0x50, 0x55, 0x42, 0x4c,
-	0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45,
-	0x53, 0x53, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04,
-	0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x53, 0x45, 0x54, 0x54,
-	0x4c, 0x45, 0x44, 0x10, 0x05, 0x2a, 0xed, 0x01, 0x0a, 0x0d, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
-	0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x46, 0x41, 0x49, 0x4c, 0x55,
-	0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
-	0x12, 0x1b, 0x0a, 0x17, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53,
-	0x4f, 0x4e, 0x5f, 0x4f, 0x46, 0x46, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x1a, 0x0a,
-	0x16, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
-	0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x46, 0x41, 0x49,
-	0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x45, 0x45,
-	0x50, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x25, 0x0a, 0x21, 0x46,
-	0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e,
-	0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45,
-	0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45,
-	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x45, 0x4d, 0x50, 0x4f, 0x52, 0x41, 0x52, 0x59, 0x10, 0x05,
-	0x12, 0x23, 0x0a, 0x1f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53,
-	0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43, 0x54, 0x5f, 0x41, 0x4d, 0x4f,
-	0x55, 0x4e, 0x54, 0x10, 0x06, 0x2a, 0x2f, 0x0a, 0x11, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
-	0x74, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x48, 0x52, 0x45, 0x53,
-	0x48, 0x4f, 0x4c, 0x44, 0x10, 0x01, 0x2a, 0xa6, 0x03, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x6f, 0x52,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45,
-	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x22,
-	0x0a, 0x1e, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x55,
-	0x44, 0x47, 0x45, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44,
-	0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
-	0x4e, 0x5f, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x46, 0x45, 0x45, 0x53, 0x10, 0x02, 0x12, 0x1e,
-	0x0a, 0x1a, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x55,
-	0x44, 0x47, 0x45, 0x54, 0x5f, 0x45, 0x4c, 0x41, 0x50, 0x53, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19,
-	0x0a, 0x15, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e,
-	0x5f, 0x46, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54,
-	0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x45,
-	0x45, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53,
-	0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x4e, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x45, 0x10, 0x06, 0x12, 0x16,
-	0x0a, 0x12, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x50, 0x52,
-	0x45, 0x50, 0x41, 0x59, 0x10, 0x07, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52,
-	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x42, 0x41,
-	0x43, 0x4b, 0x4f, 0x46, 0x46, 0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54, 0x4f, 0x5f,
-	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x4f, 0x55, 0x54, 0x10,
-	0x09, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
-	0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x0a, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x55,
-	0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x51, 0x55, 0x49, 0x44,
-	0x49, 0x54, 0x59, 0x5f, 0x4f, 0x4b, 0x10, 0x0b, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x55, 0x54, 0x4f,
-	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x55, 0x44, 0x47, 0x45, 0x54, 0x5f, 0x49,
-	0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x0c, 0x12, 0x20, 0x0a,
-	0x1c, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x45, 0x45,
-	0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x0d, 0x32,
-	0x8a, 0x07, 0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x39,
-	0x0a, 0x07, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x17, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
-	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x4c, 0x6f, 0x6f,
-	0x70, 0x49, 0x6e, 0x12, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f,
-	0x6f, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x6f,
-	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x2e,
-	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
-	0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x30, 0x01, 0x12, 0x42, 0x0a,
-	0x09, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x6f,
-	0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x39, 0x0a, 0x08, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x2e,
-	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
-	0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x40, 0x0a, 0x0c,
-	0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x12, 0x15, 0x2e, 0x6c,
-	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x75,
-	0x74, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40,
-	0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x15,
-	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
-	0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x54, 0x65, 0x72,
-	0x6d, 0x73, 0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x72,
-	0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
-	0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e,
-	0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
-	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6c,
-	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x73, 0x61,
-	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
-	0x63, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x17, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c,
-	0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x22,
-	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75,
-	0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71,
-	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
-	0x12, 0x5d, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
-	0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x6f, 0x6f,
-	0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
-	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x4b, 0x0a, 0x0c, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12,
-	0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
-	0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x53,
-	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x27, 0x5a, 0x25,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74,
-	0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6c, 0x6f, 0x6f, 0x70, 0x2f, 0x6c, 0x6f,
-	0x6f, 0x70, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *DeleteSwapTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var (
-	file_client_proto_rawDescOnce sync.Once
-	file_client_proto_rawDescData = file_client_proto_rawDesc
-)
+func (*DeleteSwapTemplateResponse) ProtoMessage() {}
 
-func file_client_proto_rawDescGZIP() []byte {
-	file_client_proto_rawDescOnce.Do(func() {
-		file_client_proto_rawDescData = protoimpl.X.CompressGZIP(file_client_proto_rawDescData)
-	})
-	return file_client_proto_rawDescData
+func (x *DeleteSwapTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_client_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_client_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
-var file_client_proto_goTypes = []interface{}{
-	(SwapType)(0),                      // 0: looprpc.SwapType
-	(SwapState)(0),                     // 1: looprpc.SwapState
-	(FailureReason)(0),                 // 2: looprpc.FailureReason
-	(LiquidityRuleType)(0),             // 3: looprpc.LiquidityRuleType
-	(AutoReason)(0),                    // 4: looprpc.AutoReason
-	(*LoopOutRequest)(nil),             // 5: looprpc.LoopOutRequest
-	(*LoopInRequest)(nil),              // 6: looprpc.LoopInRequest
-	(*SwapResponse)(nil),               // 7: looprpc.SwapResponse
-	(*MonitorRequest)(nil),             // 8: looprpc.MonitorRequest
-	(*SwapStatus)(nil),                 // 9: looprpc.SwapStatus
-	(*ListSwapsRequest)(nil),           // 10: looprpc.ListSwapsRequest
-	(*ListSwapsResponse)(nil),          // 11: looprpc.ListSwapsResponse
-	(*SwapInfoRequest)(nil),            // 12: looprpc.SwapInfoRequest
-	(*TermsRequest)(nil),               // 13: looprpc.TermsRequest
-	(*InTermsResponse)(nil),            // 14: looprpc.InTermsResponse
-	(*OutTermsResponse)(nil),           // 15: looprpc.OutTermsResponse
-	(*QuoteRequest)(nil),               // 16: looprpc.QuoteRequest
-	(*InQuoteResponse)(nil),            // 17: looprpc.InQuoteResponse
-	(*OutQuoteResponse)(nil),           // 18: looprpc.OutQuoteResponse
-	(*TokensRequest)(nil),              // 19: looprpc.TokensRequest
-	(*TokensResponse)(nil),             // 20: looprpc.TokensResponse
-	(*LsatToken)(nil),                  // 21: looprpc.LsatToken
-	(*GetLiquidityParamsRequest)(nil),  // 22: looprpc.GetLiquidityParamsRequest
-	(*LiquidityParameters)(nil),        // 23: looprpc.LiquidityParameters
-	(*LiquidityRule)(nil),              // 24: looprpc.LiquidityRule
-	(*SetLiquidityParamsRequest)(nil),  // 25: looprpc.SetLiquidityParamsRequest
-	(*SetLiquidityParamsResponse)(nil), // 26: looprpc.SetLiquidityParamsResponse
-	(*SuggestSwapsRequest)(nil),        // 27: looprpc.SuggestSwapsRequest
-	(*Disqualified)(nil),               // 28: looprpc.Disqualified
-	(*SuggestSwapsResponse)(nil),       // 29: looprpc.SuggestSwapsResponse
+// Deprecated: Use DeleteSwapTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSwapTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{28}
 }
-var file_client_proto_depIdxs = []int32{
-	0,  // 0: looprpc.SwapStatus.type:type_name -> looprpc.SwapType
-	1,  // 1: looprpc.SwapStatus.state:type_name -> looprpc.SwapState
-	2,  // 2: looprpc.SwapStatus.failure_reason:type_name -> looprpc.FailureReason
-	9,  // 3: looprpc.ListSwapsResponse.swaps:type_name -> looprpc.SwapStatus
-	21, // 4: looprpc.TokensResponse.tokens:type_name -> looprpc.LsatToken
-	24, // 5: looprpc.LiquidityParameters.rules:type_name -> looprpc.LiquidityRule
-	3,  // 6: looprpc.LiquidityRule.type:type_name -> looprpc.LiquidityRuleType
-	23, // 7: looprpc.SetLiquidityParamsRequest.parameters:type_name -> looprpc.LiquidityParameters
-	4,  // 8: looprpc.Disqualified.reason:type_name -> looprpc.AutoReason
-	5,  // 9: looprpc.SuggestSwapsResponse.loop_out:type_name -> looprpc.LoopOutRequest
-	28, // 10: looprpc.SuggestSwapsResponse.disqualified:type_name -> looprpc.Disqualified
-	5,  // 11: looprpc.SwapClient.LoopOut:input_type -> looprpc.LoopOutRequest
-	6,  // 12: looprpc.SwapClient.LoopIn:input_type -> looprpc.LoopInRequest
-	8,  // 13: looprpc.SwapClient.Monitor:input_type -> looprpc.MonitorRequest
-	10, // 14: looprpc.SwapClient.ListSwaps:input_type -> looprpc.ListSwapsRequest
-	12, // 15: looprpc.SwapClient.SwapInfo:input_type -> looprpc.SwapInfoRequest
-	13, // 16: looprpc.SwapClient.LoopOutTerms:input_type -> looprpc.TermsRequest
-	16, // 17: looprpc.SwapClient.LoopOutQuote:input_type -> looprpc.QuoteRequest
-	13, // 18: looprpc.SwapClient.GetLoopInTerms:input_type -> looprpc.TermsRequest
-	16, // 19: looprpc.SwapClient.GetLoopInQuote:input_type -> looprpc.QuoteRequest
-	19, // 20: looprpc.SwapClient.GetLsatTokens:input_type -> looprpc.TokensRequest
-	22, // 21: looprpc.SwapClient.GetLiquidityParams:input_type -> looprpc.GetLiquidityParamsRequest
-	25, // 22: looprpc.SwapClient.SetLiquidityParams:input_type -> looprpc.SetLiquidityParamsRequest
-	27, // 23: looprpc.SwapClient.SuggestSwaps:input_type -> looprpc.SuggestSwapsRequest
-	7,  // 24: looprpc.SwapClient.LoopOut:output_type -> looprpc.SwapResponse
-	7,  // 25: looprpc.SwapClient.LoopIn:output_type -> looprpc.SwapResponse
-	9,  // 26: looprpc.SwapClient.Monitor:output_type -> looprpc.SwapStatus
-	11, // 27: looprpc.SwapClient.ListSwaps:output_type -> looprpc.ListSwapsResponse
-	9,  // 28: looprpc.SwapClient.SwapInfo:output_type -> looprpc.SwapStatus
-	15, // 29: looprpc.SwapClient.LoopOutTerms:output_type -> looprpc.OutTermsResponse
-	18, // 30: looprpc.SwapClient.LoopOutQuote:output_type -> looprpc.OutQuoteResponse
-	14, // 31: looprpc.SwapClient.GetLoopInTerms:output_type -> looprpc.InTermsResponse
-	17, // 32: looprpc.SwapClient.GetLoopInQuote:output_type -> looprpc.InQuoteResponse
-	20, // 33: looprpc.SwapClient.GetLsatTokens:output_type -> looprpc.TokensResponse
-	23, // 34: looprpc.SwapClient.GetLiquidityParams:output_type -> looprpc.LiquidityParameters
-	26, // 35: looprpc.SwapClient.SetLiquidityParams:output_type -> looprpc.SetLiquidityParamsResponse
-	29, // 36: looprpc.SwapClient.SuggestSwaps:output_type -> looprpc.SuggestSwapsResponse
-	24, // [24:37] is the sub-list for method output_type
-	11, // [11:24] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+type SwapInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The swap identifier which currently is the hash that locks the HTLCs. When
+	//using REST, this field must be encoded as URL safe base64.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func init() { file_client_proto_init() }
-func file_client_proto_init() {
-	if File_client_proto != nil {
-		return
+func (x *SwapInfoRequest) Reset() {
+	*x = SwapInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_client_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoopOutRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoopInRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonitorRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *SwapInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapInfoRequest) ProtoMessage() {}
+
+func (x *SwapInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapStatus); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapInfoRequest.ProtoReflect.Descriptor instead.
+func (*SwapInfoRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SwapInfoRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type TermsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TermsRequest) Reset() {
+	*x = TermsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsRequest) ProtoMessage() {}
+
+func (x *TermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSwapsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsRequest.ProtoReflect.Descriptor instead.
+func (*TermsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{30}
+}
+
+type InTermsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Minimum swap amount (sat)
+	MinSwapAmount int64 `protobuf:"varint,5,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
+	//
+	//Maximum swap amount (sat)
+	MaxSwapAmount int64 `protobuf:"varint,6,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
+}
+
+func (x *InTermsResponse) Reset() {
+	*x = InTermsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InTermsResponse) ProtoMessage() {}
+
+func (x *InTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSwapsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InTermsResponse.ProtoReflect.Descriptor instead.
+func (*InTermsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *InTermsResponse) GetMinSwapAmount() int64 {
+	if x != nil {
+		return x.MinSwapAmount
+	}
+	return 0
+}
+
+func (x *InTermsResponse) GetMaxSwapAmount() int64 {
+	if x != nil {
+		return x.MaxSwapAmount
+	}
+	return 0
+}
+
+type OutTermsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Minimum swap amount (sat)
+	MinSwapAmount int64 `protobuf:"varint,5,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
+	//
+	//Maximum swap amount (sat)
+	MaxSwapAmount int64 `protobuf:"varint,6,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
+	// The minimally accepted cltv delta of the on-chain htlc.
+	MinCltvDelta int32 `protobuf:"varint,8,opt,name=min_cltv_delta,json=minCltvDelta,proto3" json:"min_cltv_delta,omitempty"`
+	// The maximally accepted cltv delta of the on-chain htlc.
+	MaxCltvDelta int32 `protobuf:"varint,9,opt,name=max_cltv_delta,json=maxCltvDelta,proto3" json:"max_cltv_delta,omitempty"`
+}
+
+func (x *OutTermsResponse) Reset() {
+	*x = OutTermsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutTermsResponse) ProtoMessage() {}
+
+func (x *OutTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapInfoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TermsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InTermsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutTermsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuoteRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InQuoteResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutQuoteResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutTermsResponse.ProtoReflect.Descriptor instead.
+func (*OutTermsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OutTermsResponse) GetMinSwapAmount() int64 {
+	if x != nil {
+		return x.MinSwapAmount
+	}
+	return 0
+}
+
+func (x *OutTermsResponse) GetMaxSwapAmount() int64 {
+	if x != nil {
+		return x.MaxSwapAmount
+	}
+	return 0
+}
+
+func (x *OutTermsResponse) GetMinCltvDelta() int32 {
+	if x != nil {
+		return x.MinCltvDelta
+	}
+	return 0
+}
+
+func (x *OutTermsResponse) GetMaxCltvDelta() int32 {
+	if x != nil {
+		return x.MaxCltvDelta
+	}
+	return 0
+}
+
+type QuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The amount to swap in satoshis.
+	Amt int64 `protobuf:"varint,1,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//The confirmation target that should be used either for the sweep of the
+	//on-chain HTLC broadcast by the swap server in the case of a Loop Out, or for
+	//the confirmation of the on-chain HTLC broadcast by the swap client in the
+	//case of a Loop In.
+	ConfTarget int32 `protobuf:"varint,2,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+	//
+	//If external_htlc is true, we expect the htlc to be published by an external
+	//actor. A conf_target may still be set for loop in quotes, in which case the
+	//cost of publishing the htlc from a wallet with a single input and a change
+	//output is estimated.
+	ExternalHtlc bool `protobuf:"varint,3,opt,name=external_htlc,json=externalHtlc,proto3" json:"external_htlc,omitempty"`
+	//
+	//The latest time (in unix seconds) we allow the server to wait before
+	//publishing the HTLC on chain. Setting this to a larger value will give the
+	//server the opportunity to batch multiple swaps together, and wait for
+	//low-fee periods before publishing the HTLC, potentially resulting in a
+	//lower total swap fee. This only has an effect on loop out quotes.
+	SwapPublicationDeadline uint64 `protobuf:"varint,4,opt,name=swap_publication_deadline,json=swapPublicationDeadline,proto3" json:"swap_publication_deadline,omitempty"`
+	//
+	//An optional confirmation target that the server is asked to use when
+	//publishing the on-chain HTLC. A higher target may result in a lower swap
+	//fee. This only has an effect on loop out quotes.
+	ServerConfTarget int32 `protobuf:"varint,5,opt,name=server_conf_target,json=serverConfTarget,proto3" json:"server_conf_target,omitempty"`
+}
+
+func (x *QuoteRequest) Reset() {
+	*x = QuoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRequest) ProtoMessage() {}
+
+func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokensRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *QuoteRequest) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetConfTarget() int32 {
+	if x != nil {
+		return x.ConfTarget
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetExternalHtlc() bool {
+	if x != nil {
+		return x.ExternalHtlc
+	}
+	return false
+}
+
+func (x *QuoteRequest) GetSwapPublicationDeadline() uint64 {
+	if x != nil {
+		return x.SwapPublicationDeadline
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetServerConfTarget() int32 {
+	if x != nil {
+		return x.ServerConfTarget
+	}
+	return 0
+}
+
+type InQuoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The fee that the swap server is charging for the swap.
+	SwapFeeSat int64 `protobuf:"varint,1,opt,name=swap_fee_sat,json=swapFeeSat,proto3" json:"swap_fee_sat,omitempty"`
+	//
+	//An estimate of the on-chain fee that needs to be paid to publish the HTLC
+	//If a miner fee of 0 is returned, it means the external_htlc flag was set for
+	//a loop in without a conf_target and the fee estimation was skipped. If a
+	//miner fee of -1 is returned, it means lnd's wallet tried to estimate the fee
+	//but was unable to create a sample estimation transaction because not enough
+	//funds are available. An information message should be shown to the user in
+	//this case.
+	HtlcPublishFeeSat int64 `protobuf:"varint,3,opt,name=htlc_publish_fee_sat,json=htlcPublishFeeSat,proto3" json:"htlc_publish_fee_sat,omitempty"`
+	//
+	//On-chain cltv expiry delta
+	CltvDelta int32 `protobuf:"varint,5,opt,name=cltv_delta,json=cltvDelta,proto3" json:"cltv_delta,omitempty"`
+	//
+	//The confirmation target to be used to publish the on-chain HTLC. If no
+	//target was requested, this is the default target for the swap amount.
+	ConfTarget int32 `protobuf:"varint,6,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+}
+
+func (x *InQuoteResponse) Reset() {
+	*x = InQuoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InQuoteResponse) ProtoMessage() {}
+
+func (x *InQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokensResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LsatToken); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InQuoteResponse.ProtoReflect.Descriptor instead.
+func (*InQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *InQuoteResponse) GetSwapFeeSat() int64 {
+	if x != nil {
+		return x.SwapFeeSat
+	}
+	return 0
+}
+
+func (x *InQuoteResponse) GetHtlcPublishFeeSat() int64 {
+	if x != nil {
+		return x.HtlcPublishFeeSat
+	}
+	return 0
+}
+
+func (x *InQuoteResponse) GetCltvDelta() int32 {
+	if x != nil {
+		return x.CltvDelta
+	}
+	return 0
+}
+
+func (x *InQuoteResponse) GetConfTarget() int32 {
+	if x != nil {
+		return x.ConfTarget
+	}
+	return 0
+}
+
+type OutQuoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The fee that the swap server is charging for the swap.
+	SwapFeeSat int64 `protobuf:"varint,1,opt,name=swap_fee_sat,json=swapFeeSat,proto3" json:"swap_fee_sat,omitempty"`
+	//
+	//The part of the swap fee that is requested as a prepayment.
+	PrepayAmtSat int64 `protobuf:"varint,2,opt,name=prepay_amt_sat,json=prepayAmtSat,proto3" json:"prepay_amt_sat,omitempty"`
+	//
+	//An estimate of the on-chain fee that needs to be paid to sweep the HTLC for
+	//a loop out.
+	HtlcSweepFeeSat int64 `protobuf:"varint,3,opt,name=htlc_sweep_fee_sat,json=htlcSweepFeeSat,proto3" json:"htlc_sweep_fee_sat,omitempty"`
+	//
+	//The node pubkey where the swap payment needs to be paid
+	//to. This can be used to test connectivity before initiating the swap.
+	SwapPaymentDest []byte `protobuf:"bytes,4,opt,name=swap_payment_dest,json=swapPaymentDest,proto3" json:"swap_payment_dest,omitempty"`
+	//
+	//On-chain cltv expiry delta
+	CltvDelta int32 `protobuf:"varint,5,opt,name=cltv_delta,json=cltvDelta,proto3" json:"cltv_delta,omitempty"`
+	//
+	//The confirmation target to be used for the sweep of the on-chain HTLC.
+	ConfTarget int32 `protobuf:"varint,6,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+	//
+	//The confirmation target that the server quoted for publication of the
+	//on-chain HTLC. Zero if the server did not take the requested target into
+	//account.
+	ServerConfTarget int32 `protobuf:"varint,7,opt,name=server_conf_target,json=serverConfTarget,proto3" json:"server_conf_target,omitempty"`
+}
+
+func (x *OutQuoteResponse) Reset() {
+	*x = OutQuoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutQuoteResponse) ProtoMessage() {}
+
+func (x *OutQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLiquidityParamsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutQuoteResponse.ProtoReflect.Descriptor instead.
+func (*OutQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OutQuoteResponse) GetSwapFeeSat() int64 {
+	if x != nil {
+		return x.SwapFeeSat
+	}
+	return 0
+}
+
+func (x *OutQuoteResponse) GetPrepayAmtSat() int64 {
+	if x != nil {
+		return x.PrepayAmtSat
+	}
+	return 0
+}
+
+func (x *OutQuoteResponse) GetHtlcSweepFeeSat() int64 {
+	if x != nil {
+		return x.HtlcSweepFeeSat
+	}
+	return 0
+}
+
+func (x *OutQuoteResponse) GetSwapPaymentDest() []byte {
+	if x != nil {
+		return x.SwapPaymentDest
+	}
+	return nil
+}
+
+func (x *OutQuoteResponse) GetCltvDelta() int32 {
+	if x != nil {
+		return x.CltvDelta
+	}
+	return 0
+}
+
+func (x *OutQuoteResponse) GetConfTarget() int32 {
+	if x != nil {
+		return x.ConfTarget
+	}
+	return 0
+}
+
+func (x *OutQuoteResponse) GetServerConfTarget() int32 {
+	if x != nil {
+		return x.ServerConfTarget
+	}
+	return 0
+}
+
+type TokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TokensRequest) Reset() {
+	*x = TokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokensRequest) ProtoMessage() {}
+
+func (x *TokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LiquidityParameters); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokensRequest.ProtoReflect.Descriptor instead.
+func (*TokensRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{36}
+}
+
+type TokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//List of all tokens the daemon knows of, including old/expired tokens.
+	Tokens []*LsatToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+}
+
+func (x *TokensResponse) Reset() {
+	*x = TokensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokensResponse) ProtoMessage() {}
+
+func (x *TokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LiquidityRule); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLiquidityParamsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLiquidityParamsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SuggestSwapsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_client_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Disqualified); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokensResponse.ProtoReflect.Descriptor instead.
+func (*TokensResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TokensResponse) GetTokens() []*LsatToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type LsatToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The base macaroon that was baked by the auth server.
+	BaseMacaroon []byte `protobuf:"bytes,1,opt,name=base_macaroon,json=baseMacaroon,proto3" json:"base_macaroon,omitempty"`
+	//
+	//The payment hash of the payment that was paid to obtain the token.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	//
+	//The preimage of the payment hash, knowledge of this is proof that the
+	//payment has been paid. If the preimage is set to all zeros, this means the
+	//payment is still pending and the token is not yet fully valid.
+	PaymentPreimage []byte `protobuf:"bytes,3,opt,name=payment_preimage,json=paymentPreimage,proto3" json:"payment_preimage,omitempty"`
+	//
+	//The amount of millisatoshis that was paid to get the token.
+	AmountPaidMsat int64 `protobuf:"varint,4,opt,name=amount_paid_msat,json=amountPaidMsat,proto3" json:"amount_paid_msat,omitempty"`
+	//
+	//The amount of millisatoshis paid in routing fee to pay for the token.
+	RoutingFeePaidMsat int64 `protobuf:"varint,5,opt,name=routing_fee_paid_msat,json=routingFeePaidMsat,proto3" json:"routing_fee_paid_msat,omitempty"`
+	//
+	//The creation time of the token as UNIX timestamp in seconds.
+	TimeCreated int64 `protobuf:"varint,6,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	//
+	//Indicates whether the token is expired or still valid.
+	Expired bool `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	//
+	//Identifying attribute of this token in the store. Currently represents the
+	//file name of the token where it's stored on the file system.
+	StorageName string `protobuf:"bytes,8,opt,name=storage_name,json=storageName,proto3" json:"storage_name,omitempty"`
+}
+
+func (x *LsatToken) Reset() {
+	*x = LsatToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LsatToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LsatToken) ProtoMessage() {}
+
+func (x *LsatToken) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_client_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SuggestSwapsResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LsatToken.ProtoReflect.Descriptor instead.
+func (*LsatToken) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *LsatToken) GetBaseMacaroon() []byte {
+	if x != nil {
+		return x.BaseMacaroon
+	}
+	return nil
+}
+
+func (x *LsatToken) GetPaymentHash() []byte {
+	if x != nil {
+		return x.PaymentHash
+	}
+	return nil
+}
+
+func (x *LsatToken) GetPaymentPreimage() []byte {
+	if x != nil {
+		return x.PaymentPreimage
+	}
+	return nil
+}
+
+func (x *LsatToken) GetAmountPaidMsat() int64 {
+	if x != nil {
+		return x.AmountPaidMsat
+	}
+	return 0
+}
+
+func (x *LsatToken) GetRoutingFeePaidMsat() int64 {
+	if x != nil {
+		return x.RoutingFeePaidMsat
+	}
+	return 0
+}
+
+func (x *LsatToken) GetTimeCreated() int64 {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return 0
+}
+
+func (x *LsatToken) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+func (x *LsatToken) GetStorageName() string {
+	if x != nil {
+		return x.StorageName
+	}
+	return ""
+}
+
+type GetLiquidityParamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLiquidityParamsRequest) Reset() {
+	*x = GetLiquidityParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLiquidityParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiquidityParamsRequest) ProtoMessage() {}
+
+func (x *GetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiquidityParamsRequest.ProtoReflect.Descriptor instead.
+func (*GetLiquidityParamsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{39}
+}
+
+type LiquidityParameters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//A set of liquidity rules that describe the desired liquidity balance.
+	Rules []*LiquidityRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	//
+	//The parts per million of swap amount that is allowed to be allocated to swap
+	//fees. This value is applied across swap categories and may not be set in
+	//conjunction with sweep fee rate, swap fee ppm, routing fee ppm, prepay
+	//routing, max prepay and max miner fee.
+	FeePpm uint64 `protobuf:"varint,16,opt,name=fee_ppm,json=feePpm,proto3" json:"fee_ppm,omitempty"`
+	//
+	//The limit we place on our estimated sweep cost for a swap in sat/vByte. If
+	//the estimated fee for our sweep transaction within the specified
+	//confirmation target is above this value, we will not suggest any swaps.
+	SweepFeeRateSatPerVbyte uint64 `protobuf:"varint,2,opt,name=sweep_fee_rate_sat_per_vbyte,json=sweepFeeRateSatPerVbyte,proto3" json:"sweep_fee_rate_sat_per_vbyte,omitempty"`
+	//
+	//The maximum fee paid to the server for facilitating the swap, expressed
+	//as parts per million of the swap volume.
+	MaxSwapFeePpm uint64 `protobuf:"varint,3,opt,name=max_swap_fee_ppm,json=maxSwapFeePpm,proto3" json:"max_swap_fee_ppm,omitempty"`
+	//
+	//The maximum fee paid to route the swap invoice off chain, expressed as
+	//parts per million of the volume being routed.
+	MaxRoutingFeePpm uint64 `protobuf:"varint,4,opt,name=max_routing_fee_ppm,json=maxRoutingFeePpm,proto3" json:"max_routing_fee_ppm,omitempty"`
+	//
+	//The maximum fee paid to route the prepay invoice off chain, expressed as
+	//parts per million of the volume being routed.
+	MaxPrepayRoutingFeePpm uint64 `protobuf:"varint,5,opt,name=max_prepay_routing_fee_ppm,json=maxPrepayRoutingFeePpm,proto3" json:"max_prepay_routing_fee_ppm,omitempty"`
+	//
+	//The maximum no-show penalty in satoshis paid for a swap.
+	MaxPrepaySat uint64 `protobuf:"varint,6,opt,name=max_prepay_sat,json=maxPrepaySat,proto3" json:"max_prepay_sat,omitempty"`
+	//
+	//The maximum miner fee we will pay to sweep the swap on chain. Note that we
+	//will not suggest a swap if the estimate is above the sweep limit set by
+	//these parameters, and we use the current fee estimate to sweep on chain so
+	//this value is only a cap placed on the amount we spend on fees in the case
+	//where the swap needs to be claimed on chain, but fees have suddenly spiked.
+	MaxMinerFeeSat uint64 `protobuf:"varint,7,opt,name=max_miner_fee_sat,json=maxMinerFeeSat,proto3" json:"max_miner_fee_sat,omitempty"`
+	//
+	//The number of blocks from the on-chain HTLC's confirmation height that it
+	//should be swept within.
+	SweepConfTarget int32 `protobuf:"varint,8,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
+	//
+	//The amount of time we require pass since a channel was part of a failed
+	//swap due to off chain payment failure until it will be considered for swap
+	//suggestions again, expressed in seconds.
+	FailureBackoffSec uint64 `protobuf:"varint,9,opt,name=failure_backoff_sec,json=failureBackoffSec,proto3" json:"failure_backoff_sec,omitempty"`
+	//
+	//Set to true to enable automatic dispatch of swaps. All swaps will be limited
+	//to the fee categories set by these parameters, and total expenditure will
+	//be limited to the autoloop budget.
+	Autoloop bool `protobuf:"varint,10,opt,name=autoloop,proto3" json:"autoloop,omitempty"`
+	//
+	//The total budget for automatically dispatched swaps since the budget start
+	//time, expressed in satoshis.
+	AutoloopBudgetSat uint64 `protobuf:"varint,11,opt,name=autoloop_budget_sat,json=autoloopBudgetSat,proto3" json:"autoloop_budget_sat,omitempty"`
+	//
+	//The start time for autoloop budget, expressed as a unix timestamp in
+	//seconds. If this value is 0, the budget will be applied for all
+	//automatically dispatched swaps. Swaps that were completed before this date
+	//will not be included in budget calculations.
+	AutoloopBudgetStartSec uint64 `protobuf:"varint,12,opt,name=autoloop_budget_start_sec,json=autoloopBudgetStartSec,proto3" json:"autoloop_budget_start_sec,omitempty"`
+	//
+	//The maximum number of automatically dispatched swaps that we allow to be in
+	//flight at any point in time.
+	AutoMaxInFlight uint64 `protobuf:"varint,13,opt,name=auto_max_in_flight,json=autoMaxInFlight,proto3" json:"auto_max_in_flight,omitempty"`
+	//
+	//The minimum amount, expressed in satoshis, that the autoloop client will
+	//dispatch a swap for. This value is subject to the server-side limits
+	//specified by the LoopOutTerms endpoint.
+	MinSwapAmount uint64 `protobuf:"varint,14,opt,name=min_swap_amount,json=minSwapAmount,proto3" json:"min_swap_amount,omitempty"`
+	//
+	//The maximum amount, expressed in satoshis, that the autoloop client will
+	//dispatch a swap for. This value is subject to the server-side limits
+	//specified by the LoopOutTerms endpoint.
+	MaxSwapAmount uint64 `protobuf:"varint,15,opt,name=max_swap_amount,json=maxSwapAmount,proto3" json:"max_swap_amount,omitempty"`
+	//
+	//Set to true to skip swaps for single-channel rules when lnd estimates that
+	//a circular rebalance would shift the same balance for a fraction of the
+	//swap's fees.
+	PreferRebalance bool `protobuf:"varint,17,opt,name=prefer_rebalance,json=preferRebalance,proto3" json:"prefer_rebalance,omitempty"`
+	//
+	//A set of short channel IDs of channels that should never be used for
+	//swaps, even if they are covered by a rule for their peer.
+	DisqualifiedChannels []uint64 `protobuf:"varint,18,rep,packed,name=disqualified_channels,json=disqualifiedChannels,proto3" json:"disqualified_channels,omitempty"`
+	//
+	//A set of public keys of peers whose channels should never be used for
+	//swaps, even if a rule is set for the peer or one of its channels.
+	DisqualifiedPeers [][]byte `protobuf:"bytes,19,rep,name=disqualified_peers,json=disqualifiedPeers,proto3" json:"disqualified_peers,omitempty"`
+	//
+	//The amount of time we require pass since a channel or peer was part of a
+	//successful swap until it will be considered for swap suggestions again,
+	//expressed in seconds. If zero, no cooldown is applied.
+	SwapCooldownSec uint64 `protobuf:"varint,20,opt,name=swap_cooldown_sec,json=swapCooldownSec,proto3" json:"swap_cooldown_sec,omitempty"`
+	//
+	//The amount of time that the server may wait before it publishes the on
+	//chain htlc of automatically dispatched loop outs, expressed in seconds. A
+	//later deadline allows the server to batch htlcs, which may result in a lower
+	//swap fee. If zero, the server is asked to publish htlcs immediately.
+	PublicationDeadlineSec uint64 `protobuf:"varint,21,opt,name=publication_deadline_sec,json=publicationDeadlineSec,proto3" json:"publication_deadline_sec,omitempty"`
+	//
+	//A set of parameters that increases the fees autoloop may spend, which is
+	//waiting for the daemon's change delay to pass before it replaces the
+	//current parameters. This field is only set by GetLiquidityParams, and is
+	//ignored by SetLiquidityParams.
+	PendingParams *LiquidityParameters `protobuf:"bytes,22,opt,name=pending_params,json=pendingParams,proto3" json:"pending_params,omitempty"`
+	//
+	//The unix time in seconds at which the pending parameters take effect. Zero
+	//if no change is pending.
+	PendingEffectiveSec uint64 `protobuf:"varint,23,opt,name=pending_effective_sec,json=pendingEffectiveSec,proto3" json:"pending_effective_sec,omitempty"`
+	//
+	//The version of the parameters, which is incremented every time the
+	//parameters or pending parameters change. It may be provided to
+	//UpdateLiquidityParams to detect concurrent changes. This field is only set
+	//by GetLiquidityParams, and is ignored by SetLiquidityParams.
+	Version uint64 `protobuf:"varint,24,opt,name=version,proto3" json:"version,omitempty"`
+	//
+	//Enables easy autoloop mode when set. Rather than using rules, autoloop
+	//keeps the node's total local balance at this percentage of its total
+	//channel capacity, by looping out from the channels with the highest local
+	//balance. Swaps are suggested once the local balance exceeds the target by
+	//more than 5%. This target cannot be set with rules, and must be between 5
+	//and 95.
+	LocalBalanceTarget uint32 `protobuf:"varint,25,opt,name=local_balance_target,json=localBalanceTarget,proto3" json:"local_balance_target,omitempty"`
+	//
+	//The days of the week that autoloop may dispatch swaps on, where 0 is
+	//Sunday and 6 is Saturday. If empty, swaps may be dispatched on any day.
+	ScheduleDays []uint32 `protobuf:"varint,26,rep,packed,name=schedule_days,json=scheduleDays,proto3" json:"schedule_days,omitempty"`
+	//
+	//The hour of the day in UTC, inclusive, from which autoloop may dispatch
+	//swaps.
+	ScheduleStartHour uint32 `protobuf:"varint,27,opt,name=schedule_start_hour,json=scheduleStartHour,proto3" json:"schedule_start_hour,omitempty"`
+	//
+	//The hour of the day in UTC, exclusive, until which autoloop may dispatch
+	//swaps. If it is less than schedule_start_hour, the window wraps past
+	//midnight. If it is equal to schedule_start_hour, swaps may be dispatched
+	//at any hour.
+	ScheduleEndHour uint32 `protobuf:"varint,28,opt,name=schedule_end_hour,json=scheduleEndHour,proto3" json:"schedule_end_hour,omitempty"`
+	//
+	//The maximum estimated fee rate, in sat/vByte, to sweep within our sweep
+	//confirmation target at which autoloop suggests swaps. Unlike
+	//sweep_fee_rate_sat_per_vbyte, this limit applies with any type of fee
+	//limit, so that swaps are not dispatched during fee spikes. If zero, no
+	//limit is applied.
+	SweepFeeRateLimitSatPerVbyte uint64 `protobuf:"varint,29,opt,name=sweep_fee_rate_limit_sat_per_vbyte,json=sweepFeeRateLimitSatPerVbyte,proto3" json:"sweep_fee_rate_limit_sat_per_vbyte,omitempty"`
+	//
+	//Set to true to suggest swaps of the server's minimum swap amount when the
+	//amount required to restore a rule's thresholds is below it, provided that
+	//a swap of the minimum amount does not breach the rule's threshold on the
+	//other side of the channel.
+	RoundUpToMinimum bool `protobuf:"varint,30,opt,name=round_up_to_minimum,json=roundUpToMinimum,proto3" json:"round_up_to_minimum,omitempty"`
+	//
+	//Set to true to exclude channels that are not currently active from
+	//autoloop. Inactive channels are not used for swaps, and are not counted
+	//in the balances used for peer rules or easy autoloop, because their funds
+	//can't be moved until they come back online.
+	ExcludeInactiveChannels bool `protobuf:"varint,31,opt,name=exclude_inactive_channels,json=excludeInactiveChannels,proto3" json:"exclude_inactive_channels,omitempty"`
+}
+
+func (x *LiquidityParameters) Reset() {
+	*x = LiquidityParameters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquidityParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityParameters) ProtoMessage() {}
+
+func (x *LiquidityParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityParameters.ProtoReflect.Descriptor instead.
+func (*LiquidityParameters) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LiquidityParameters) GetRules() []*LiquidityRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *LiquidityParameters) GetFeePpm() uint64 {
+	if x != nil {
+		return x.FeePpm
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetSweepFeeRateSatPerVbyte() uint64 {
+	if x != nil {
+		return x.SweepFeeRateSatPerVbyte
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxSwapFeePpm() uint64 {
+	if x != nil {
+		return x.MaxSwapFeePpm
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxRoutingFeePpm() uint64 {
+	if x != nil {
+		return x.MaxRoutingFeePpm
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxPrepayRoutingFeePpm() uint64 {
+	if x != nil {
+		return x.MaxPrepayRoutingFeePpm
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxPrepaySat() uint64 {
+	if x != nil {
+		return x.MaxPrepaySat
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxMinerFeeSat() uint64 {
+	if x != nil {
+		return x.MaxMinerFeeSat
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetSweepConfTarget() int32 {
+	if x != nil {
+		return x.SweepConfTarget
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetFailureBackoffSec() uint64 {
+	if x != nil {
+		return x.FailureBackoffSec
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetAutoloop() bool {
+	if x != nil {
+		return x.Autoloop
+	}
+	return false
+}
+
+func (x *LiquidityParameters) GetAutoloopBudgetSat() uint64 {
+	if x != nil {
+		return x.AutoloopBudgetSat
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetAutoloopBudgetStartSec() uint64 {
+	if x != nil {
+		return x.AutoloopBudgetStartSec
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetAutoMaxInFlight() uint64 {
+	if x != nil {
+		return x.AutoMaxInFlight
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMinSwapAmount() uint64 {
+	if x != nil {
+		return x.MinSwapAmount
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetMaxSwapAmount() uint64 {
+	if x != nil {
+		return x.MaxSwapAmount
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetPreferRebalance() bool {
+	if x != nil {
+		return x.PreferRebalance
+	}
+	return false
+}
+
+func (x *LiquidityParameters) GetDisqualifiedChannels() []uint64 {
+	if x != nil {
+		return x.DisqualifiedChannels
+	}
+	return nil
+}
+
+func (x *LiquidityParameters) GetDisqualifiedPeers() [][]byte {
+	if x != nil {
+		return x.DisqualifiedPeers
+	}
+	return nil
+}
+
+func (x *LiquidityParameters) GetSwapCooldownSec() uint64 {
+	if x != nil {
+		return x.SwapCooldownSec
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetPublicationDeadlineSec() uint64 {
+	if x != nil {
+		return x.PublicationDeadlineSec
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetPendingParams() *LiquidityParameters {
+	if x != nil {
+		return x.PendingParams
+	}
+	return nil
+}
+
+func (x *LiquidityParameters) GetPendingEffectiveSec() uint64 {
+	if x != nil {
+		return x.PendingEffectiveSec
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetLocalBalanceTarget() uint32 {
+	if x != nil {
+		return x.LocalBalanceTarget
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetScheduleDays() []uint32 {
+	if x != nil {
+		return x.ScheduleDays
+	}
+	return nil
+}
+
+func (x *LiquidityParameters) GetScheduleStartHour() uint32 {
+	if x != nil {
+		return x.ScheduleStartHour
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetScheduleEndHour() uint32 {
+	if x != nil {
+		return x.ScheduleEndHour
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetSweepFeeRateLimitSatPerVbyte() uint64 {
+	if x != nil {
+		return x.SweepFeeRateLimitSatPerVbyte
+	}
+	return 0
+}
+
+func (x *LiquidityParameters) GetRoundUpToMinimum() bool {
+	if x != nil {
+		return x.RoundUpToMinimum
+	}
+	return false
+}
+
+func (x *LiquidityParameters) GetExcludeInactiveChannels() bool {
+	if x != nil {
+		return x.ExcludeInactiveChannels
+	}
+	return false
+}
+
+type LiquidityRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The short channel ID of the channel that this rule should be applied to.
+	//This field may not be set when the pubkey field is set.
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The public key of the peer that this rule should be applied to. This field
+	//may not be set when the channel id field is set.
+	Pubkey []byte `protobuf:"bytes,5,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	//
+	//Type indicates the type of rule that this message rule represents. Setting
+	//this value will determine which fields are used in the message. The comments
+	//on each field in this message will be prefixed with the LiquidityRuleType
+	//they belong to.
+	Type LiquidityRuleType `protobuf:"varint,2,opt,name=type,proto3,enum=looprpc.LiquidityRuleType" json:"type,omitempty"`
+	//
+	//THRESHOLD: The percentage of total capacity that incoming capacity should
+	//not drop beneath. Deprecated, use incoming_threshold_ppm. This value is
+	//only used if incoming_threshold_ppm is not set, and is rounded down to a
+	//whole percentage when the rule is returned.
+	IncomingThreshold uint32 `protobuf:"varint,3,opt,name=incoming_threshold,json=incomingThreshold,proto3" json:"incoming_threshold,omitempty"`
+	//
+	//THRESHOLD: The percentage of total capacity that outgoing capacity should
+	//not drop beneath. Deprecated, use outgoing_threshold_ppm. This value is
+	//only used if outgoing_threshold_ppm is not set, and is rounded down to a
+	//whole percentage when the rule is returned.
+	OutgoingThreshold uint32 `protobuf:"varint,4,opt,name=outgoing_threshold,json=outgoingThreshold,proto3" json:"outgoing_threshold,omitempty"`
+	//
+	//The confirmation target to use for the sweeps of swaps that are suggested
+	//for this rule. If not set, the sweep_conf_target of the liquidity
+	//parameters is used.
+	SweepConfTarget int32 `protobuf:"varint,6,opt,name=sweep_conf_target,json=sweepConfTarget,proto3" json:"sweep_conf_target,omitempty"`
+	//
+	//The number of confirmations to require for the on chain htlc of swaps
+	//that are suggested for this rule. If not set, the default number of htlc
+	//confirmations is used.
+	HtlcConfTarget int32 `protobuf:"varint,7,opt,name=htlc_conf_target,json=htlcConfTarget,proto3" json:"htlc_conf_target,omitempty"`
+	//
+	//The type of swap that is suggested to restore the balance described by the
+	//rule. Loop out swaps are suggested when incoming liquidity falls below the
+	//incoming threshold, and loop in swaps are suggested when outgoing liquidity
+	//falls below the outgoing threshold. Loop in rules may only be set for peers.
+	SwapType SwapType `protobuf:"varint,8,opt,name=swap_type,json=swapType,proto3,enum=looprpc.SwapType" json:"swap_type,omitempty"`
+	//
+	//The amount of time in seconds that the rule's thresholds must have been
+	//breached for, according to the daemon's channel balance snapshots, before a
+	//swap is suggested. This requires channel balance snapshots to be enabled.
+	//If zero, swaps are suggested as soon as the thresholds are breached.
+	MinImbalanceDurationSec uint64 `protobuf:"varint,9,opt,name=min_imbalance_duration_sec,json=minImbalanceDurationSec,proto3" json:"min_imbalance_duration_sec,omitempty"`
+	//
+	//The minimum reputation score, as a percentage of swaps that succeeded, that
+	//the peer a swap is routed through must have before autoloop will dispatch
+	//swaps of at least large_swap_amount_sat. If zero, the peer's reputation is
+	//not considered.
+	MinReputation uint32 `protobuf:"varint,10,opt,name=min_reputation,json=minReputation,proto3" json:"min_reputation,omitempty"`
+	//
+	//The swap amount in satoshis at or above which the rule's minimum
+	//reputation applies. If zero, the minimum reputation applies to all swaps.
+	LargeSwapAmountSat uint64 `protobuf:"varint,11,opt,name=large_swap_amount_sat,json=largeSwapAmountSat,proto3" json:"large_swap_amount_sat,omitempty"`
+	//
+	//THRESHOLD: The portion of total capacity, expressed in parts per million,
+	//that incoming capacity should not drop beneath. Takes precedence over
+	//incoming_threshold if set.
+	IncomingThresholdPpm uint32 `protobuf:"varint,12,opt,name=incoming_threshold_ppm,json=incomingThresholdPpm,proto3" json:"incoming_threshold_ppm,omitempty"`
+	//
+	//THRESHOLD: The portion of total capacity, expressed in parts per million,
+	//that outgoing capacity should not drop beneath. Takes precedence over
+	//outgoing_threshold if set.
+	OutgoingThresholdPpm uint32 `protobuf:"varint,13,opt,name=outgoing_threshold_ppm,json=outgoingThresholdPpm,proto3" json:"outgoing_threshold_ppm,omitempty"`
+	//
+	//TARGET: The portion of total capacity, expressed in parts per million,
+	//that local balance should be kept at. When a target rule is returned, its
+	//incoming and outgoing thresholds are set to the bounds of its tolerance
+	//band.
+	TargetLocalPpm uint32 `protobuf:"varint,14,opt,name=target_local_ppm,json=targetLocalPpm,proto3" json:"target_local_ppm,omitempty"`
+	//
+	//TARGET: The portion of total capacity, expressed in parts per million,
+	//that local balance may move away from the target before a swap is
+	//suggested to restore it. Must be greater than zero.
+	TolerancePpm uint32 `protobuf:"varint,15,opt,name=tolerance_ppm,json=tolerancePpm,proto3" json:"tolerance_ppm,omitempty"`
+	//
+	//The maximum amount, in satoshis, that swaps dispatched for this rule may
+	//spend on fees, in addition to the global autoloop budget. If zero, the
+	//rule is only limited by the global budget.
+	FeeBudgetSat uint64 `protobuf:"varint,16,opt,name=fee_budget_sat,json=feeBudgetSat,proto3" json:"fee_budget_sat,omitempty"`
+	//
+	//The rolling period, in seconds, over which the rule's fee budget applies.
+	//If zero, the budget applies to swaps since the global autoloop budget's
+	//start date.
+	FeeBudgetPeriodSec uint64 `protobuf:"varint,17,opt,name=fee_budget_period_sec,json=feeBudgetPeriodSec,proto3" json:"fee_budget_period_sec,omitempty"`
+	//
+	//The fees, in satoshis, that completed swaps dispatched for the rule have
+	//spent within its budget period. Only set by GetLiquidityParams, and
+	//ignored by SetLiquidityParams.
+	FeeBudgetSpentSat uint64 `protobuf:"varint,18,opt,name=fee_budget_spent_sat,json=feeBudgetSpentSat,proto3" json:"fee_budget_spent_sat,omitempty"`
+	//
+	//The worst-case fees, in satoshis, that in flight swaps dispatched for the
+	//rule may spend. Only set by GetLiquidityParams, and ignored by
+	//SetLiquidityParams.
+	FeeBudgetPendingSat uint64 `protobuf:"varint,19,opt,name=fee_budget_pending_sat,json=feeBudgetPendingSat,proto3" json:"fee_budget_pending_sat,omitempty"`
+}
+
+func (x *LiquidityRule) Reset() {
+	*x = LiquidityRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquidityRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityRule) ProtoMessage() {}
+
+func (x *LiquidityRule) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityRule.ProtoReflect.Descriptor instead.
+func (*LiquidityRule) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LiquidityRule) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *LiquidityRule) GetType() LiquidityRuleType {
+	if x != nil {
+		return x.Type
+	}
+	return LiquidityRuleType_UNKNOWN
+}
+
+func (x *LiquidityRule) GetIncomingThreshold() uint32 {
+	if x != nil {
+		return x.IncomingThreshold
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetOutgoingThreshold() uint32 {
+	if x != nil {
+		return x.OutgoingThreshold
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetSweepConfTarget() int32 {
+	if x != nil {
+		return x.SweepConfTarget
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetHtlcConfTarget() int32 {
+	if x != nil {
+		return x.HtlcConfTarget
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetSwapType() SwapType {
+	if x != nil {
+		return x.SwapType
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *LiquidityRule) GetMinImbalanceDurationSec() uint64 {
+	if x != nil {
+		return x.MinImbalanceDurationSec
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetMinReputation() uint32 {
+	if x != nil {
+		return x.MinReputation
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetLargeSwapAmountSat() uint64 {
+	if x != nil {
+		return x.LargeSwapAmountSat
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetIncomingThresholdPpm() uint32 {
+	if x != nil {
+		return x.IncomingThresholdPpm
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetOutgoingThresholdPpm() uint32 {
+	if x != nil {
+		return x.OutgoingThresholdPpm
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetTargetLocalPpm() uint32 {
+	if x != nil {
+		return x.TargetLocalPpm
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetTolerancePpm() uint32 {
+	if x != nil {
+		return x.TolerancePpm
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetFeeBudgetSat() uint64 {
+	if x != nil {
+		return x.FeeBudgetSat
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetFeeBudgetPeriodSec() uint64 {
+	if x != nil {
+		return x.FeeBudgetPeriodSec
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetFeeBudgetSpentSat() uint64 {
+	if x != nil {
+		return x.FeeBudgetSpentSat
+	}
+	return 0
+}
+
+func (x *LiquidityRule) GetFeeBudgetPendingSat() uint64 {
+	if x != nil {
+		return x.FeeBudgetPendingSat
+	}
+	return 0
+}
+
+type SetLiquidityParamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Parameters is the desired new set of parameters for the liquidity management
+	//subsystem. Note that the current set of parameters will be completely
+	//overwritten by the parameters provided (if they are valid), so the full set
+	//of parameters should be provided for each call.
+	Parameters *LiquidityParameters `protobuf:"bytes,1,opt,name=parameters,proto3" json:"parameters,omitempty"`
+}
+
+func (x *SetLiquidityParamsRequest) Reset() {
+	*x = SetLiquidityParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLiquidityParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLiquidityParamsRequest) ProtoMessage() {}
+
+func (x *SetLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLiquidityParamsRequest.ProtoReflect.Descriptor instead.
+func (*SetLiquidityParamsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetLiquidityParamsRequest) GetParameters() *LiquidityParameters {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type SetLiquidityParamsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//If the parameters increase the fees autoloop may spend and the daemon has
+	//a change delay configured, the unix time in seconds at which they take
+	//effect. Zero if the parameters took effect immediately.
+	EffectiveSec uint64 `protobuf:"varint,1,opt,name=effective_sec,json=effectiveSec,proto3" json:"effective_sec,omitempty"`
+}
+
+func (x *SetLiquidityParamsResponse) Reset() {
+	*x = SetLiquidityParamsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLiquidityParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLiquidityParamsResponse) ProtoMessage() {}
+
+func (x *SetLiquidityParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLiquidityParamsResponse.ProtoReflect.Descriptor instead.
+func (*SetLiquidityParamsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetLiquidityParamsResponse) GetEffectiveSec() uint64 {
+	if x != nil {
+		return x.EffectiveSec
+	}
+	return 0
+}
+
+type UpdateLiquidityParamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The values of the parameters that are updated. Only the fields listed in
+	//update_fields are read.
+	Parameters *LiquidityParameters `protobuf:"bytes,1,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	//
+	//The names of the liquidity parameter fields to update, for example
+	//sweep_conf_target. Fields that are listed but not set in parameters are
+	//cleared. Rules and disqualified channels and peers are replaced as a
+	//whole. Updating the fee percentage clears the individual fee categories,
+	//and vice versa, unless both are listed.
+	UpdateFields []string `protobuf:"bytes,2,rep,name=update_fields,json=updateFields,proto3" json:"update_fields,omitempty"`
+	//
+	//The version of the parameters that the update is based on, as returned by
+	//GetLiquidityParams. If non-zero and the parameters have changed since this
+	//version, the update fails. If zero, the update is always applied.
+	Version uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpdateLiquidityParamsRequest) Reset() {
+	*x = UpdateLiquidityParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLiquidityParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLiquidityParamsRequest) ProtoMessage() {}
+
+func (x *UpdateLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLiquidityParamsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLiquidityParamsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateLiquidityParamsRequest) GetParameters() *LiquidityParameters {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *UpdateLiquidityParamsRequest) GetUpdateFields() []string {
+	if x != nil {
+		return x.UpdateFields
+	}
+	return nil
+}
+
+func (x *UpdateLiquidityParamsRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateLiquidityParamsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The version of the parameters after the update was applied.
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	//
+	//If the parameters increase the fees autoloop may spend and the daemon has
+	//a change delay configured, the unix time in seconds at which they take
+	//effect. Zero if the parameters took effect immediately.
+	EffectiveSec uint64 `protobuf:"varint,2,opt,name=effective_sec,json=effectiveSec,proto3" json:"effective_sec,omitempty"`
+}
+
+func (x *UpdateLiquidityParamsResponse) Reset() {
+	*x = UpdateLiquidityParamsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLiquidityParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLiquidityParamsResponse) ProtoMessage() {}
+
+func (x *UpdateLiquidityParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLiquidityParamsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLiquidityParamsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateLiquidityParamsResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateLiquidityParamsResponse) GetEffectiveSec() uint64 {
+	if x != nil {
+		return x.EffectiveSec
+	}
+	return 0
+}
+
+type CancelLiquidityParamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelLiquidityParamsRequest) Reset() {
+	*x = CancelLiquidityParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelLiquidityParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLiquidityParamsRequest) ProtoMessage() {}
+
+func (x *CancelLiquidityParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLiquidityParamsRequest.ProtoReflect.Descriptor instead.
+func (*CancelLiquidityParamsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{46}
+}
+
+type CancelLiquidityParamsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelLiquidityParamsResponse) Reset() {
+	*x = CancelLiquidityParamsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelLiquidityParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLiquidityParamsResponse) ProtoMessage() {}
+
+func (x *CancelLiquidityParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLiquidityParamsResponse.ProtoReflect.Descriptor instead.
+func (*CancelLiquidityParamsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{47}
+}
+
+type GetPeerReputationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetPeerReputationsRequest) Reset() {
+	*x = GetPeerReputationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPeerReputationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerReputationsRequest) ProtoMessage() {}
+
+func (x *GetPeerReputationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerReputationsRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerReputationsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{48}
+}
+
+type GetPeerReputationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The reputations of all the peers that we have recorded swap outcomes for.
+	Reputations []*PeerReputation `protobuf:"bytes,1,rep,name=reputations,proto3" json:"reputations,omitempty"`
+}
+
+func (x *GetPeerReputationsResponse) Reset() {
+	*x = GetPeerReputationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPeerReputationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerReputationsResponse) ProtoMessage() {}
+
+func (x *GetPeerReputationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerReputationsResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerReputationsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetPeerReputationsResponse) GetReputations() []*PeerReputation {
+	if x != nil {
+		return x.Reputations
+	}
+	return nil
+}
+
+type PeerReputation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The public key of the peer.
+	Pubkey []byte `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	//
+	//The number of completed swaps that were routed through the peer.
+	Swaps uint32 `protobuf:"varint,2,opt,name=swaps,proto3" json:"swaps,omitempty"`
+	//
+	//The number of completed swaps routed through the peer that succeeded.
+	Successes uint32 `protobuf:"varint,3,opt,name=successes,proto3" json:"successes,omitempty"`
+	//
+	//The reputation score of the peer, which is the percentage of swaps routed
+	//through the peer that succeeded.
+	Score uint32 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	//
+	//The average fees that successful swaps cost, expressed as parts per
+	//million of the fees that they were quoted.
+	FeeRatioPpm uint64 `protobuf:"varint,5,opt,name=fee_ratio_ppm,json=feeRatioPpm,proto3" json:"fee_ratio_ppm,omitempty"`
+	//
+	//The average amount of time in seconds that successful swaps took to
+	//complete.
+	AvgCompletionSec uint64 `protobuf:"varint,6,opt,name=avg_completion_sec,json=avgCompletionSec,proto3" json:"avg_completion_sec,omitempty"`
+}
+
+func (x *PeerReputation) Reset() {
+	*x = PeerReputation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerReputation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReputation) ProtoMessage() {}
+
+func (x *PeerReputation) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReputation.ProtoReflect.Descriptor instead.
+func (*PeerReputation) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PeerReputation) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *PeerReputation) GetSwaps() uint32 {
+	if x != nil {
+		return x.Swaps
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetSuccesses() uint32 {
+	if x != nil {
+		return x.Successes
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetFeeRatioPpm() uint64 {
+	if x != nil {
+		return x.FeeRatioPpm
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetAvgCompletionSec() uint64 {
+	if x != nil {
+		return x.AvgCompletionSec
+	}
+	return 0
+}
+
+type GetStaleRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStaleRulesRequest) Reset() {
+	*x = GetStaleRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStaleRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleRulesRequest) ProtoMessage() {}
+
+func (x *GetStaleRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleRulesRequest.ProtoReflect.Descriptor instead.
+func (*GetStaleRulesRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{51}
+}
+
+type GetStaleRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The rules that are set for channels or peers that have been inactive for
+	//at least the daemon's stale rule age.
+	Rules []*StaleRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *GetStaleRulesResponse) Reset() {
+	*x = GetStaleRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStaleRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleRulesResponse) ProtoMessage() {}
+
+func (x *GetStaleRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleRulesResponse.ProtoReflect.Descriptor instead.
+func (*GetStaleRulesResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetStaleRulesResponse) GetRules() []*StaleRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type StaleRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The short channel ID of the channel that the rule is set for. This value
+	//is zero for peer rules.
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The public key of the peer that the rule is set for. This value is empty
+	//for channel rules.
+	Pubkey []byte `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	//
+	//The reason that the rule's channel or peer was inactive when it was last
+	//checked.
+	Reason StaleReason `protobuf:"varint,3,opt,name=reason,proto3,enum=looprpc.StaleReason" json:"reason,omitempty"`
+	//
+	//The unix timestamp in seconds at which the daemon first saw the rule's
+	//channel or peer inactive. This time is reset when the daemon restarts.
+	InactiveSince int64 `protobuf:"varint,4,opt,name=inactive_since,json=inactiveSince,proto3" json:"inactive_since,omitempty"`
+}
+
+func (x *StaleRule) Reset() {
+	*x = StaleRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StaleRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaleRule) ProtoMessage() {}
+
+func (x *StaleRule) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaleRule.ProtoReflect.Descriptor instead.
+func (*StaleRule) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *StaleRule) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *StaleRule) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *StaleRule) GetReason() StaleReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StaleReason_STALE_REASON_CLOSED
+}
+
+func (x *StaleRule) GetInactiveSince() int64 {
+	if x != nil {
+		return x.InactiveSince
+	}
+	return 0
+}
+
+type SuggestSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SuggestSwapsRequest) Reset() {
+	*x = SuggestSwapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestSwapsRequest) ProtoMessage() {}
+
+func (x *SuggestSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestSwapsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{54}
+}
+
+type Disqualified struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The short channel ID of the channel that was excluded from our suggestions.
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The public key of the peer that was excluded from our suggestions.
+	Pubkey []byte `protobuf:"bytes,3,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	//
+	//The reason that we excluded the channel from the our suggestions.
+	Reason AutoReason `protobuf:"varint,2,opt,name=reason,proto3,enum=looprpc.AutoReason" json:"reason,omitempty"`
+}
+
+func (x *Disqualified) Reset() {
+	*x = Disqualified{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Disqualified) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disqualified) ProtoMessage() {}
+
+func (x *Disqualified) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disqualified.ProtoReflect.Descriptor instead.
+func (*Disqualified) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Disqualified) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *Disqualified) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *Disqualified) GetReason() AutoReason {
+	if x != nil {
+		return x.Reason
+	}
+	return AutoReason_AUTO_REASON_UNKNOWN
+}
+
+type SuggestSwapsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The set of recommended loop outs.
+	LoopOut []*LoopOutRequest `protobuf:"bytes,1,rep,name=loop_out,json=loopOut,proto3" json:"loop_out,omitempty"`
+	//
+	//Disqualified contains the set of channels that swaps are not recommended
+	//for.
+	Disqualified []*Disqualified `protobuf:"bytes,2,rep,name=disqualified,proto3" json:"disqualified,omitempty"`
+	//
+	//The set of recommended loop in swaps.
+	LoopIn []*LoopInRequest `protobuf:"bytes,3,rep,name=loop_in,json=loopIn,proto3" json:"loop_in,omitempty"`
+}
+
+func (x *SuggestSwapsResponse) Reset() {
+	*x = SuggestSwapsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestSwapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestSwapsResponse) ProtoMessage() {}
+
+func (x *SuggestSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestSwapsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SuggestSwapsResponse) GetLoopOut() []*LoopOutRequest {
+	if x != nil {
+		return x.LoopOut
+	}
+	return nil
+}
+
+func (x *SuggestSwapsResponse) GetDisqualified() []*Disqualified {
+	if x != nil {
+		return x.Disqualified
+	}
+	return nil
+}
+
+func (x *SuggestSwapsResponse) GetLoopIn() []*LoopInRequest {
+	if x != nil {
+		return x.LoopIn
+	}
+	return nil
+}
+
+type AutoloopStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AutoloopStatusRequest) Reset() {
+	*x = AutoloopStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoloopStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoloopStatusRequest) ProtoMessage() {}
+
+func (x *AutoloopStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoloopStatusRequest.ProtoReflect.Descriptor instead.
+func (*AutoloopStatusRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{57}
+}
+
+type TriggerAutoloopEvaluationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TriggerAutoloopEvaluationRequest) Reset() {
+	*x = TriggerAutoloopEvaluationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TriggerAutoloopEvaluationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerAutoloopEvaluationRequest) ProtoMessage() {}
+
+func (x *TriggerAutoloopEvaluationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerAutoloopEvaluationRequest.ProtoReflect.Descriptor instead.
+func (*TriggerAutoloopEvaluationRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{58}
+}
+
+type AutoloopStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The unix timestamp of the most recent autoloop check, zero if no check has
+	//been performed yet.
+	LastCheck int64 `protobuf:"varint,1,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	//
+	//The unix timestamp at which the outcome of autoloop checks last changed.
+	LastChange int64 `protobuf:"varint,2,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+	//
+	//The error that the most recent check failed with, if any.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	//
+	//The set of channels and peers that swaps were not suggested for in the
+	//most recent check, along with the reason that they were excluded.
+	Disqualified []*Disqualified `protobuf:"bytes,4,rep,name=disqualified,proto3" json:"disqualified,omitempty"`
+	//
+	//The use of the autoloop fee budget at the time of the most recent check.
+	//This field is not set if the check did not get as far as examining the
+	//budget.
+	Budget *AutoloopBudget `protobuf:"bytes,5,opt,name=budget,proto3" json:"budget,omitempty"`
+	//
+	//The most recent autoloop events, ordered from oldest to newest. Events are
+	//only kept in memory, so they are cleared when the daemon restarts.
+	Events []*AutoloopEvent `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *AutoloopStatusResponse) Reset() {
+	*x = AutoloopStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoloopStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoloopStatusResponse) ProtoMessage() {}
+
+func (x *AutoloopStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoloopStatusResponse.ProtoReflect.Descriptor instead.
+func (*AutoloopStatusResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AutoloopStatusResponse) GetLastCheck() int64 {
+	if x != nil {
+		return x.LastCheck
+	}
+	return 0
+}
+
+func (x *AutoloopStatusResponse) GetLastChange() int64 {
+	if x != nil {
+		return x.LastChange
+	}
+	return 0
+}
+
+func (x *AutoloopStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AutoloopStatusResponse) GetDisqualified() []*Disqualified {
+	if x != nil {
+		return x.Disqualified
+	}
+	return nil
+}
+
+func (x *AutoloopStatusResponse) GetBudget() *AutoloopBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *AutoloopStatusResponse) GetEvents() []*AutoloopEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type AutoloopBudget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The total fee budget for automatically dispatched swaps.
+	BudgetSat uint64 `protobuf:"varint,1,opt,name=budget_sat,json=budgetSat,proto3" json:"budget_sat,omitempty"`
+	//
+	//The unix timestamp from which fees are counted against the budget.
+	StartDate uint64 `protobuf:"varint,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	//
+	//The amount of fees that completed swaps have spent since the start date.
+	SpentSat uint64 `protobuf:"varint,3,opt,name=spent_sat,json=spentSat,proto3" json:"spent_sat,omitempty"`
+	//
+	//The worst-case amount of fees that in flight swaps may spend.
+	PendingSat uint64 `protobuf:"varint,4,opt,name=pending_sat,json=pendingSat,proto3" json:"pending_sat,omitempty"`
+	//
+	//The amount of the budget that has not been spent or allocated to in flight
+	//swaps.
+	AvailableSat uint64 `protobuf:"varint,5,opt,name=available_sat,json=availableSat,proto3" json:"available_sat,omitempty"`
+	//
+	//The number of automatically dispatched swaps that are currently in
+	//flight.
+	InFlight uint32 `protobuf:"varint,6,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+}
+
+func (x *AutoloopBudget) Reset() {
+	*x = AutoloopBudget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoloopBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoloopBudget) ProtoMessage() {}
+
+func (x *AutoloopBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoloopBudget.ProtoReflect.Descriptor instead.
+func (*AutoloopBudget) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AutoloopBudget) GetBudgetSat() uint64 {
+	if x != nil {
+		return x.BudgetSat
+	}
+	return 0
+}
+
+func (x *AutoloopBudget) GetStartDate() uint64 {
+	if x != nil {
+		return x.StartDate
+	}
+	return 0
+}
+
+func (x *AutoloopBudget) GetSpentSat() uint64 {
+	if x != nil {
+		return x.SpentSat
+	}
+	return 0
+}
+
+func (x *AutoloopBudget) GetPendingSat() uint64 {
+	if x != nil {
+		return x.PendingSat
+	}
+	return 0
+}
+
+func (x *AutoloopBudget) GetAvailableSat() uint64 {
+	if x != nil {
+		return x.AvailableSat
+	}
+	return 0
+}
+
+func (x *AutoloopBudget) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+type BudgetReconciliationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BudgetReconciliationRequest) Reset() {
+	*x = BudgetReconciliationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BudgetReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetReconciliationRequest) ProtoMessage() {}
+
+func (x *BudgetReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*BudgetReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{61}
+}
+
+type BudgetReconciliationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The unix timestamp at which the report was created.
+	Time uint64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	//
+	//The unix timestamp of the budget's start date. Only swaps that completed
+	//after this date are reconciled.
+	StartDate uint64 `protobuf:"varint,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	//
+	//The automatically dispatched swaps that completed since the start date,
+	//ordered by completion time.
+	Swaps []*ReconciledSwap `protobuf:"bytes,3,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	//
+	//The total worst-case amount of fees that the completed swaps reserved
+	//while they were in flight.
+	ReservedSat uint64 `protobuf:"varint,4,opt,name=reserved_sat,json=reservedSat,proto3" json:"reserved_sat,omitempty"`
+	//
+	//The total amount of fees that the completed swaps realized, which is the
+	//amount that they count against the budget.
+	RealizedSat uint64 `protobuf:"varint,5,opt,name=realized_sat,json=realizedSat,proto3" json:"realized_sat,omitempty"`
+	//
+	//The amount of budget that the completed swaps released by realizing less
+	//than their worst-case fees.
+	ReleasedSat int64 `protobuf:"varint,6,opt,name=released_sat,json=releasedSat,proto3" json:"released_sat,omitempty"`
+	//
+	//The worst-case amount of fees that in flight swaps currently reserve.
+	PendingSat uint64 `protobuf:"varint,7,opt,name=pending_sat,json=pendingSat,proto3" json:"pending_sat,omitempty"`
+	//
+	//The number of automatically dispatched swaps that are currently in
+	//flight.
+	InFlight uint32 `protobuf:"varint,8,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+}
+
+func (x *BudgetReconciliationResponse) Reset() {
+	*x = BudgetReconciliationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BudgetReconciliationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetReconciliationResponse) ProtoMessage() {}
+
+func (x *BudgetReconciliationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetReconciliationResponse.ProtoReflect.Descriptor instead.
+func (*BudgetReconciliationResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BudgetReconciliationResponse) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetStartDate() uint64 {
+	if x != nil {
+		return x.StartDate
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetSwaps() []*ReconciledSwap {
+	if x != nil {
+		return x.Swaps
+	}
+	return nil
+}
+
+func (x *BudgetReconciliationResponse) GetReservedSat() uint64 {
+	if x != nil {
+		return x.ReservedSat
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetRealizedSat() uint64 {
+	if x != nil {
+		return x.RealizedSat
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetReleasedSat() int64 {
+	if x != nil {
+		return x.ReleasedSat
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetPendingSat() uint64 {
+	if x != nil {
+		return x.PendingSat
+	}
+	return 0
+}
+
+func (x *BudgetReconciliationResponse) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+type ReconciledSwap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The swap hash of the swap.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	//The type of the swap.
+	Type SwapType `protobuf:"varint,2,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//The unix timestamp at which the swap completed.
+	CompletedAt uint64 `protobuf:"varint,3,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	//
+	//The worst-case amount of fees that the swap reserved from the budget
+	//while it was in flight.
+	ReservedSat uint64 `protobuf:"varint,4,opt,name=reserved_sat,json=reservedSat,proto3" json:"reserved_sat,omitempty"`
+	//
+	//The amount of fees that the swap realized.
+	RealizedSat uint64 `protobuf:"varint,5,opt,name=realized_sat,json=realizedSat,proto3" json:"realized_sat,omitempty"`
+	//
+	//The amount of budget that the swap released when it completed. This is
+	//negative if the swap realized more than it reserved.
+	ReleasedSat int64 `protobuf:"varint,6,opt,name=released_sat,json=releasedSat,proto3" json:"released_sat,omitempty"`
+}
+
+func (x *ReconciledSwap) Reset() {
+	*x = ReconciledSwap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconciledSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciledSwap) ProtoMessage() {}
+
+func (x *ReconciledSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciledSwap.ProtoReflect.Descriptor instead.
+func (*ReconciledSwap) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ReconciledSwap) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ReconciledSwap) GetType() SwapType {
+	if x != nil {
+		return x.Type
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *ReconciledSwap) GetCompletedAt() uint64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *ReconciledSwap) GetReservedSat() uint64 {
+	if x != nil {
+		return x.ReservedSat
+	}
+	return 0
+}
+
+func (x *ReconciledSwap) GetRealizedSat() uint64 {
+	if x != nil {
+		return x.RealizedSat
+	}
+	return 0
+}
+
+func (x *ReconciledSwap) GetReleasedSat() int64 {
+	if x != nil {
+		return x.ReleasedSat
+	}
+	return 0
+}
+
+type AutoloopEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The unix timestamp at which the event occurred.
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	//
+	//The kind of event.
+	Type AutoloopEventType `protobuf:"varint,2,opt,name=type,proto3,enum=looprpc.AutoloopEventType" json:"type,omitempty"`
+	//
+	//The short channel ID of the channel that a disqualified or requalified
+	//event applies to.
+	ChannelId uint64 `protobuf:"varint,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The public key of the peer that a disqualified or requalified event
+	//applies to.
+	Pubkey []byte `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	//
+	//The reason that a channel or peer was disqualified.
+	Reason AutoReason `protobuf:"varint,5,opt,name=reason,proto3,enum=looprpc.AutoReason" json:"reason,omitempty"`
+	//
+	//The error that checks started failing with for failed events, or the error
+	//that they were failing with for recovered events.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	//
+	//The hash of a dispatched swap.
+	SwapHash []byte `protobuf:"bytes,7,opt,name=swap_hash,json=swapHash,proto3" json:"swap_hash,omitempty"`
+	//
+	//The type of a dispatched swap.
+	SwapType SwapType `protobuf:"varint,8,opt,name=swap_type,json=swapType,proto3,enum=looprpc.SwapType" json:"swap_type,omitempty"`
+	//
+	//The amount of a dispatched swap.
+	AmountSat uint64 `protobuf:"varint,9,opt,name=amount_sat,json=amountSat,proto3" json:"amount_sat,omitempty"`
+}
+
+func (x *AutoloopEvent) Reset() {
+	*x = AutoloopEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoloopEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoloopEvent) ProtoMessage() {}
+
+func (x *AutoloopEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoloopEvent.ProtoReflect.Descriptor instead.
+func (*AutoloopEvent) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AutoloopEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AutoloopEvent) GetType() AutoloopEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AutoloopEventType_AUTOLOOP_EVENT_UNKNOWN
+}
+
+func (x *AutoloopEvent) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *AutoloopEvent) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *AutoloopEvent) GetReason() AutoReason {
+	if x != nil {
+		return x.Reason
+	}
+	return AutoReason_AUTO_REASON_UNKNOWN
+}
+
+func (x *AutoloopEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AutoloopEvent) GetSwapHash() []byte {
+	if x != nil {
+		return x.SwapHash
+	}
+	return nil
+}
+
+func (x *AutoloopEvent) GetSwapType() SwapType {
+	if x != nil {
+		return x.SwapType
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *AutoloopEvent) GetAmountSat() uint64 {
+	if x != nil {
+		return x.AmountSat
+	}
+	return 0
+}
+
+type SwapStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The period that swap statistics should be aggregated by.
+	Period StatsPeriod `protobuf:"varint,1,opt,name=period,proto3,enum=looprpc.StatsPeriod" json:"period,omitempty"`
+	//
+	//Only include swaps of the given types in the statistics. If no types are
+	//set, swaps of all types are included.
+	SwapTypes []SwapType `protobuf:"varint,2,rep,packed,name=swap_types,json=swapTypes,proto3,enum=looprpc.SwapType" json:"swap_types,omitempty"`
+}
+
+func (x *SwapStatsRequest) Reset() {
+	*x = SwapStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapStatsRequest) ProtoMessage() {}
+
+func (x *SwapStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapStatsRequest.ProtoReflect.Descriptor instead.
+func (*SwapStatsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SwapStatsRequest) GetPeriod() StatsPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return StatsPeriod_DAY
+}
+
+func (x *SwapStatsRequest) GetSwapTypes() []SwapType {
+	if x != nil {
+		return x.SwapTypes
+	}
+	return nil
+}
+
+type SwapStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The swap statistics for each period that swaps were initiated in, ordered
+	//from oldest to newest. Periods in which no swaps were initiated are
+	//omitted.
+	Stats []*SwapStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *SwapStatsResponse) Reset() {
+	*x = SwapStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapStatsResponse) ProtoMessage() {}
+
+func (x *SwapStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapStatsResponse.ProtoReflect.Descriptor instead.
+func (*SwapStatsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SwapStatsResponse) GetStats() []*SwapStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type SwapStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The start of the period that these statistics cover, expressed in unix
+	//seconds.
+	PeriodStart int64 `protobuf:"varint,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	//
+	//The total number of swaps that were initiated in this period.
+	TotalSwaps uint32 `protobuf:"varint,2,opt,name=total_swaps,json=totalSwaps,proto3" json:"total_swaps,omitempty"`
+	//
+	//The number of swaps that were initiated in this period and succeeded.
+	SuccessfulSwaps uint32 `protobuf:"varint,3,opt,name=successful_swaps,json=successfulSwaps,proto3" json:"successful_swaps,omitempty"`
+	//
+	//The number of swaps that were initiated in this period and failed.
+	FailedSwaps uint32 `protobuf:"varint,4,opt,name=failed_swaps,json=failedSwaps,proto3" json:"failed_swaps,omitempty"`
+	//
+	//The number of swaps that were initiated in this period and are still
+	//pending.
+	PendingSwaps uint32 `protobuf:"varint,5,opt,name=pending_swaps,json=pendingSwaps,proto3" json:"pending_swaps,omitempty"`
+	//
+	//The total amount of the successful swaps in this period, expressed in
+	//satoshis.
+	VolumeSat int64 `protobuf:"varint,6,opt,name=volume_sat,json=volumeSat,proto3" json:"volume_sat,omitempty"`
+	//
+	//The fraction of completed (successful or failed) swaps that succeeded,
+	//between 0 and 1.
+	SuccessRate float64 `protobuf:"fixed64,7,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	//
+	//The average total fee (server, on-chain and off-chain) paid by successful
+	//swaps in this period, expressed in satoshis.
+	AvgFeeSat int64 `protobuf:"varint,8,opt,name=avg_fee_sat,json=avgFeeSat,proto3" json:"avg_fee_sat,omitempty"`
+	//
+	//The average time that successful swaps in this period took to complete,
+	//expressed in seconds.
+	AvgCompletionTimeSec int64 `protobuf:"varint,9,opt,name=avg_completion_time_sec,json=avgCompletionTimeSec,proto3" json:"avg_completion_time_sec,omitempty"`
+	//
+	//The duration statistics of each phase of the swaps that were initiated in
+	//this period. Phases are only included if at least one swap recorded their
+	//duration. Swaps that were created before phase milestones were recorded do
+	//not report phase durations.
+	PhaseStats []*PhaseStats `protobuf:"bytes,10,rep,name=phase_stats,json=phaseStats,proto3" json:"phase_stats,omitempty"`
+}
+
+func (x *SwapStats) Reset() {
+	*x = SwapStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapStats) ProtoMessage() {}
+
+func (x *SwapStats) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapStats.ProtoReflect.Descriptor instead.
+func (*SwapStats) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SwapStats) GetPeriodStart() int64 {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return 0
+}
+
+func (x *SwapStats) GetTotalSwaps() uint32 {
+	if x != nil {
+		return x.TotalSwaps
+	}
+	return 0
+}
+
+func (x *SwapStats) GetSuccessfulSwaps() uint32 {
+	if x != nil {
+		return x.SuccessfulSwaps
+	}
+	return 0
+}
+
+func (x *SwapStats) GetFailedSwaps() uint32 {
+	if x != nil {
+		return x.FailedSwaps
+	}
+	return 0
+}
+
+func (x *SwapStats) GetPendingSwaps() uint32 {
+	if x != nil {
+		return x.PendingSwaps
+	}
+	return 0
+}
+
+func (x *SwapStats) GetVolumeSat() int64 {
+	if x != nil {
+		return x.VolumeSat
+	}
+	return 0
+}
+
+func (x *SwapStats) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *SwapStats) GetAvgFeeSat() int64 {
+	if x != nil {
+		return x.AvgFeeSat
+	}
+	return 0
+}
+
+func (x *SwapStats) GetAvgCompletionTimeSec() int64 {
+	if x != nil {
+		return x.AvgCompletionTimeSec
+	}
+	return 0
+}
+
+func (x *SwapStats) GetPhaseStats() []*PhaseStats {
+	if x != nil {
+		return x.PhaseStats
+	}
+	return nil
+}
+
+type PhaseStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The swap phase that these statistics describe.
+	Phase SwapPhase `protobuf:"varint,1,opt,name=phase,proto3,enum=looprpc.SwapPhase" json:"phase,omitempty"`
+	//
+	//The number of swaps that recorded a duration for this phase.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	//
+	//The median duration of the phase, expressed in seconds.
+	P50Sec int64 `protobuf:"varint,3,opt,name=p50_sec,json=p50Sec,proto3" json:"p50_sec,omitempty"`
+	//
+	//The 90th percentile duration of the phase, expressed in seconds.
+	P90Sec int64 `protobuf:"varint,4,opt,name=p90_sec,json=p90Sec,proto3" json:"p90_sec,omitempty"`
+	//
+	//The 99th percentile duration of the phase, expressed in seconds.
+	P99Sec int64 `protobuf:"varint,5,opt,name=p99_sec,json=p99Sec,proto3" json:"p99_sec,omitempty"`
+	//
+	//The longest duration of the phase, expressed in seconds.
+	MaxSec int64 `protobuf:"varint,6,opt,name=max_sec,json=maxSec,proto3" json:"max_sec,omitempty"`
+	//
+	//A histogram of the durations of the phase, ordered by upper bound. Each
+	//bucket counts the durations that exceed the previous bucket's upper bound.
+	Buckets []*LatencyBucket `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *PhaseStats) Reset() {
+	*x = PhaseStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhaseStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseStats) ProtoMessage() {}
+
+func (x *PhaseStats) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseStats.ProtoReflect.Descriptor instead.
+func (*PhaseStats) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PhaseStats) GetPhase() SwapPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return SwapPhase_SWAP_PHASE_HTLC_CONFIRM
+}
+
+func (x *PhaseStats) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PhaseStats) GetP50Sec() int64 {
+	if x != nil {
+		return x.P50Sec
+	}
+	return 0
+}
+
+func (x *PhaseStats) GetP90Sec() int64 {
+	if x != nil {
+		return x.P90Sec
+	}
+	return 0
+}
+
+func (x *PhaseStats) GetP99Sec() int64 {
+	if x != nil {
+		return x.P99Sec
+	}
+	return 0
+}
+
+func (x *PhaseStats) GetMaxSec() int64 {
+	if x != nil {
+		return x.MaxSec
+	}
+	return 0
+}
+
+func (x *PhaseStats) GetBuckets() []*LatencyBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type LatencyBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The inclusive upper bound of the bucket, expressed in seconds. The last
+	//bucket has no upper bound, and reports zero.
+	UpperBoundSec int64 `protobuf:"varint,1,opt,name=upper_bound_sec,json=upperBoundSec,proto3" json:"upper_bound_sec,omitempty"`
+	//
+	//The number of durations that fall in the bucket.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *LatencyBucket) Reset() {
+	*x = LatencyBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyBucket) ProtoMessage() {}
+
+func (x *LatencyBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyBucket.ProtoReflect.Descriptor instead.
+func (*LatencyBucket) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *LatencyBucket) GetUpperBoundSec() int64 {
+	if x != nil {
+		return x.UpperBoundSec
+	}
+	return 0
+}
+
+func (x *LatencyBucket) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ServerHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ServerHealthRequest) Reset() {
+	*x = ServerHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerHealthRequest) ProtoMessage() {}
+
+func (x *ServerHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerHealthRequest.ProtoReflect.Descriptor instead.
+func (*ServerHealthRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{70}
+}
+
+type ServerHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Whether the server was reachable on the daemon's most recent call to it.
+	//False if the daemon has not called the server yet.
+	Reachable bool `protobuf:"varint,1,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	//
+	//The rpc protocol version that the daemon uses to talk to the server.
+	ProtocolVersion uint32 `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	//
+	//The unix timestamp of the daemon's last call that reached the server, zero
+	//if the server has not been reached yet.
+	LastSuccess int64 `protobuf:"varint,3,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	//
+	//The unix timestamp of the daemon's last call that could not reach the
+	//server, zero if the server has always been reached.
+	LastFailure int64 `protobuf:"varint,4,opt,name=last_failure,json=lastFailure,proto3" json:"last_failure,omitempty"`
+	//
+	//The error returned by the daemon's last call that could not reach the
+	//server.
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	//
+	//The highest rpc protocol version that both the daemon and the server
+	//support. Zero if features have not been negotiated with the server yet.
+	NegotiatedProtocolVersion uint32 `protobuf:"varint,6,opt,name=negotiated_protocol_version,json=negotiatedProtocolVersion,proto3" json:"negotiated_protocol_version,omitempty"`
+	//
+	//The optional protocol features that both the daemon and the server
+	//support, which the daemon uses to decide which swap features it can use.
+	Features []string `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (x *ServerHealthResponse) Reset() {
+	*x = ServerHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerHealthResponse) ProtoMessage() {}
+
+func (x *ServerHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerHealthResponse.ProtoReflect.Descriptor instead.
+func (*ServerHealthResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ServerHealthResponse) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *ServerHealthResponse) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerHealthResponse) GetLastSuccess() int64 {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return 0
+}
+
+func (x *ServerHealthResponse) GetLastFailure() int64 {
+	if x != nil {
+		return x.LastFailure
+	}
+	return 0
+}
+
+func (x *ServerHealthResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ServerHealthResponse) GetNegotiatedProtocolVersion() uint32 {
+	if x != nil {
+		return x.NegotiatedProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerHealthResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{72}
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The bitcoin network that loopd is running on.
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	//
+	//The address that loopd listens on for gRPC clients.
+	RpcListen string `protobuf:"bytes,2,opt,name=rpc_listen,json=rpcListen,proto3" json:"rpc_listen,omitempty"`
+	//
+	//The address that loopd listens on for REST clients.
+	RestListen string `protobuf:"bytes,3,opt,name=rest_listen,json=restListen,proto3" json:"rest_listen,omitempty"`
+	//
+	//The directory that loopd stores its database in.
+	DataDir string `protobuf:"bytes,4,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	//
+	//The address of the lnd backend that loopd is connected to.
+	LndHost string `protobuf:"bytes,5,opt,name=lnd_host,json=lndHost,proto3" json:"lnd_host,omitempty"`
+	//
+	//The address of the loop server that loopd is connected to.
+	ServerHost string `protobuf:"bytes,6,opt,name=server_host,json=serverHost,proto3" json:"server_host,omitempty"`
+	//
+	//The SOCKS proxy that is used to connect to the loop server, if any.
+	ServerProxy string `protobuf:"bytes,7,opt,name=server_proxy,json=serverProxy,proto3" json:"server_proxy,omitempty"`
+	//
+	//Whether tls is disabled for the connection to the loop server.
+	ServerNoTls bool `protobuf:"varint,8,opt,name=server_no_tls,json=serverNoTls,proto3" json:"server_no_tls,omitempty"`
+	//
+	//The maximum amount in satoshis that loopd will pay for an LSAT token.
+	MaxLsatCost uint32 `protobuf:"varint,9,opt,name=max_lsat_cost,json=maxLsatCost,proto3" json:"max_lsat_cost,omitempty"`
+	//
+	//The maximum routing fee in satoshis that loopd will pay for an LSAT
+	//token.
+	MaxLsatFee uint32 `protobuf:"varint,10,opt,name=max_lsat_fee,json=maxLsatFee,proto3" json:"max_lsat_fee,omitempty"`
+	//
+	//The maximum number of payment parts that may be used for a loop out swap.
+	LoopOutMaxParts uint32 `protobuf:"varint,11,opt,name=loop_out_max_parts,json=loopOutMaxParts,proto3" json:"loop_out_max_parts,omitempty"`
+	//
+	//The number of blocks that loopd waits for a loop out sweep to confirm
+	//before it bumps the sweep's fee rate. Zero if fee bumping is disabled.
+	SweepFeeBumpBlocks int32 `protobuf:"varint,12,opt,name=sweep_fee_bump_blocks,json=sweepFeeBumpBlocks,proto3" json:"sweep_fee_bump_blocks,omitempty"`
+	//
+	//Whether loop out sweeps are batched.
+	BatchSweeps bool `protobuf:"varint,13,opt,name=batch_sweeps,json=batchSweeps,proto3" json:"batch_sweeps,omitempty"`
+	//
+	//The minimum number of blocks that must remain before a loop out htlc
+	//expires for loopd to reveal the preimage.
+	MinPreimageRevealDelta int32 `protobuf:"varint,14,opt,name=min_preimage_reveal_delta,json=minPreimageRevealDelta,proto3" json:"min_preimage_reveal_delta,omitempty"`
+	//
+	//The confirmation target that is used for loop out sweeps if none is
+	//provided in the request.
+	DefaultSweepConfTarget int32 `protobuf:"varint,15,opt,name=default_sweep_conf_target,json=defaultSweepConfTarget,proto3" json:"default_sweep_conf_target,omitempty"`
+	//
+	//The confirmation target that is used for loop in htlcs if none is provided
+	//in the request, for swaps that are neither small nor large enough to use a
+	//slower or faster default target.
+	DefaultHtlcConfTarget int32 `protobuf:"varint,16,opt,name=default_htlc_conf_target,json=defaultHtlcConfTarget,proto3" json:"default_htlc_conf_target,omitempty"`
+	//
+	//The minimum confirmation target that may be provided for swaps.
+	MinConfTarget int32 `protobuf:"varint,17,opt,name=min_conf_target,json=minConfTarget,proto3" json:"min_conf_target,omitempty"`
+	//
+	//The log level configuration of the daemon.
+	DebugLevel string `protobuf:"bytes,19,opt,name=debug_level,json=debugLevel,proto3" json:"debug_level,omitempty"`
+	//
+	//The maximum total amount in satoshis that may be locked in pending swaps.
+	//Zero if no limit is configured.
+	MaxLockedValue uint64 `protobuf:"varint,20,opt,name=max_locked_value,json=maxLockedValue,proto3" json:"max_locked_value,omitempty"`
+	//
+	//The timeout in seconds for the off-chain payments of loop out swaps that do
+	//not set their own timeout.
+	LoopOutPaymentTimeout uint32 `protobuf:"varint,21,opt,name=loop_out_payment_timeout,json=loopOutPaymentTimeout,proto3" json:"loop_out_payment_timeout,omitempty"`
+	//
+	//The maximum size in bytes of the messages that the daemon's rpc server
+	//sends and receives.
+	RpcMaxMsgSize int32 `protobuf:"varint,22,opt,name=rpc_max_msg_size,json=rpcMaxMsgSize,proto3" json:"rpc_max_msg_size,omitempty"`
+	//
+	//The deadline in seconds that is applied to unary rpc calls that do not set
+	//their own deadline. Zero if no default deadline is applied.
+	RpcDefaultTimeout uint32 `protobuf:"varint,23,opt,name=rpc_default_timeout,json=rpcDefaultTimeout,proto3" json:"rpc_default_timeout,omitempty"`
+	//
+	//The delay in seconds before parameter changes that increase the fees
+	//autoloop may spend take effect. Zero if changes take effect immediately.
+	AutoloopChangeDelay uint32 `protobuf:"varint,24,opt,name=autoloop_change_delay,json=autoloopChangeDelay,proto3" json:"autoloop_change_delay,omitempty"`
+	//
+	//The interval in seconds at which channel balance snapshots are recorded.
+	//Zero if snapshots are disabled.
+	LiquiditySnapshotInterval uint32 `protobuf:"varint,25,opt,name=liquidity_snapshot_interval,json=liquiditySnapshotInterval,proto3" json:"liquidity_snapshot_interval,omitempty"`
+	//
+	//The amount of time in seconds that the swap server's terms are cached for.
+	//Zero if terms are not cached.
+	TermsCacheTtl uint32 `protobuf:"varint,26,opt,name=terms_cache_ttl,json=termsCacheTtl,proto3" json:"terms_cache_ttl,omitempty"`
+	//
+	//Whether labelling of the on-chain transactions of swaps in lnd's wallet is
+	//disabled.
+	NoTxLabels bool `protobuf:"varint,27,opt,name=no_tx_labels,json=noTxLabels,proto3" json:"no_tx_labels,omitempty"`
+	//
+	//The maximum number of swaps that the daemon executes at once, with further
+	//swaps queued until executing swaps complete. Zero if the number of swaps
+	//executed at once is not limited.
+	MaxConcurrentSwaps uint32 `protobuf:"varint,28,opt,name=max_concurrent_swaps,json=maxConcurrentSwaps,proto3" json:"max_concurrent_swaps,omitempty"`
+	//
+	//The maximum fraction of the node's on-chain funds that may be committed to
+	//pending loop ins at once. Zero if no limit is configured.
+	MaxLoopInWalletFraction float64 `protobuf:"fixed64,29,opt,name=max_loop_in_wallet_fraction,json=maxLoopInWalletFraction,proto3" json:"max_loop_in_wallet_fraction,omitempty"`
+	//
+	//The confirmation target that the refunds of timed out loop in swaps are
+	//published with. Zero if the default target is used.
+	LoopInRefundConfTarget int32 `protobuf:"varint,30,opt,name=loop_in_refund_conf_target,json=loopInRefundConfTarget,proto3" json:"loop_in_refund_conf_target,omitempty"`
+	//
+	//The maximum fee in satoshis that is paid to refund a timed out loop in
+	//swap. Zero if no limit is configured.
+	LoopInRefundMaxFee uint64 `protobuf:"varint,31,opt,name=loop_in_refund_max_fee,json=loopInRefundMaxFee,proto3" json:"loop_in_refund_max_fee,omitempty"`
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetConfigResponse) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetRpcListen() string {
+	if x != nil {
+		return x.RpcListen
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetRestListen() string {
+	if x != nil {
+		return x.RestListen
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetLndHost() string {
+	if x != nil {
+		return x.LndHost
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetServerHost() string {
+	if x != nil {
+		return x.ServerHost
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetServerProxy() string {
+	if x != nil {
+		return x.ServerProxy
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetServerNoTls() bool {
+	if x != nil {
+		return x.ServerNoTls
+	}
+	return false
+}
+
+func (x *GetConfigResponse) GetMaxLsatCost() uint32 {
+	if x != nil {
+		return x.MaxLsatCost
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxLsatFee() uint32 {
+	if x != nil {
+		return x.MaxLsatFee
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetLoopOutMaxParts() uint32 {
+	if x != nil {
+		return x.LoopOutMaxParts
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetSweepFeeBumpBlocks() int32 {
+	if x != nil {
+		return x.SweepFeeBumpBlocks
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetBatchSweeps() bool {
+	if x != nil {
+		return x.BatchSweeps
+	}
+	return false
+}
+
+func (x *GetConfigResponse) GetMinPreimageRevealDelta() int32 {
+	if x != nil {
+		return x.MinPreimageRevealDelta
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetDefaultSweepConfTarget() int32 {
+	if x != nil {
+		return x.DefaultSweepConfTarget
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetDefaultHtlcConfTarget() int32 {
+	if x != nil {
+		return x.DefaultHtlcConfTarget
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMinConfTarget() int32 {
+	if x != nil {
+		return x.MinConfTarget
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetDebugLevel() string {
+	if x != nil {
+		return x.DebugLevel
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetMaxLockedValue() uint64 {
+	if x != nil {
+		return x.MaxLockedValue
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetLoopOutPaymentTimeout() uint32 {
+	if x != nil {
+		return x.LoopOutPaymentTimeout
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetRpcMaxMsgSize() int32 {
+	if x != nil {
+		return x.RpcMaxMsgSize
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetRpcDefaultTimeout() uint32 {
+	if x != nil {
+		return x.RpcDefaultTimeout
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetAutoloopChangeDelay() uint32 {
+	if x != nil {
+		return x.AutoloopChangeDelay
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetLiquiditySnapshotInterval() uint32 {
+	if x != nil {
+		return x.LiquiditySnapshotInterval
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetTermsCacheTtl() uint32 {
+	if x != nil {
+		return x.TermsCacheTtl
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetNoTxLabels() bool {
+	if x != nil {
+		return x.NoTxLabels
+	}
+	return false
+}
+
+func (x *GetConfigResponse) GetMaxConcurrentSwaps() uint32 {
+	if x != nil {
+		return x.MaxConcurrentSwaps
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxLoopInWalletFraction() float64 {
+	if x != nil {
+		return x.MaxLoopInWalletFraction
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetLoopInRefundConfTarget() int32 {
+	if x != nil {
+		return x.LoopInRefundConfTarget
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetLoopInRefundMaxFee() uint64 {
+	if x != nil {
+		return x.LoopInRefundMaxFee
+	}
+	return 0
+}
+
+type GetApiVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetApiVersionRequest) Reset() {
+	*x = GetApiVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetApiVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiVersionRequest) ProtoMessage() {}
+
+func (x *GetApiVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetApiVersionRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{74}
+}
+
+type GetApiVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The major version of the rpc API. It is increased when rpcs, fields or enum
+	//values are removed, or their semantics change in a way that is not
+	//backwards compatible.
+	Major uint32 `protobuf:"varint,1,opt,name=major,proto3" json:"major,omitempty"`
+	//
+	//The minor version of the rpc API. It is increased when rpcs, fields or enum
+	//values are added.
+	Minor uint32 `protobuf:"varint,2,opt,name=minor,proto3" json:"minor,omitempty"`
+	//
+	//The patch version of the rpc API. It is increased for changes that do not
+	//affect the wire format or semantics of the API.
+	Patch uint32 `protobuf:"varint,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	//
+	//The version of the rpc API as a semantic version string.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	//
+	//The fully qualified names of the rpcs that the daemon supports, for
+	//example looprpc.SwapClient.LoopOut.
+	Methods []string `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	//
+	//The elements of the rpc API that are deprecated.
+	Deprecations []*Deprecation `protobuf:"bytes,6,rep,name=deprecations,proto3" json:"deprecations,omitempty"`
+}
+
+func (x *GetApiVersionResponse) Reset() {
+	*x = GetApiVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetApiVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiVersionResponse) ProtoMessage() {}
+
+func (x *GetApiVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetApiVersionResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetApiVersionResponse) GetMajor() uint32 {
+	if x != nil {
+		return x.Major
+	}
+	return 0
+}
+
+func (x *GetApiVersionResponse) GetMinor() uint32 {
+	if x != nil {
+		return x.Minor
+	}
+	return 0
+}
+
+func (x *GetApiVersionResponse) GetPatch() uint32 {
+	if x != nil {
+		return x.Patch
+	}
+	return 0
+}
+
+func (x *GetApiVersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetApiVersionResponse) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *GetApiVersionResponse) GetDeprecations() []*Deprecation {
+	if x != nil {
+		return x.Deprecations
+	}
+	return nil
+}
+
+type Deprecation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The fully qualified name of the deprecated rpc, field or enum value, for
+	//example looprpc.SwapStatus.id.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	//
+	//The fully qualified name of the element that should be used instead of the
+	//deprecated element. Empty if the element has no replacement.
+	Replacement string `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	//
+	//The major version of the rpc API in which the element will be removed.
+	RemovalMajor uint32 `protobuf:"varint,3,opt,name=removal_major,json=removalMajor,proto3" json:"removal_major,omitempty"`
+}
+
+func (x *Deprecation) Reset() {
+	*x = Deprecation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Deprecation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deprecation) ProtoMessage() {}
+
+func (x *Deprecation) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deprecation.ProtoReflect.Descriptor instead.
+func (*Deprecation) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Deprecation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Deprecation) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+func (x *Deprecation) GetRemovalMajor() uint32 {
+	if x != nil {
+		return x.RemovalMajor
+	}
+	return 0
+}
+
+type DebugLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//If set, the supported log sub-systems are returned and no levels are
+	//changed.
+	Show bool `protobuf:"varint,1,opt,name=show,proto3" json:"show,omitempty"`
+	//
+	//The log level specification to apply, either a single level for all
+	//sub-systems or a comma separated list of <subsystem>=<level> pairs, for
+	//example LQDY=trace,SWEEP=debug.
+	LevelSpec string `protobuf:"bytes,2,opt,name=level_spec,json=levelSpec,proto3" json:"level_spec,omitempty"`
+}
+
+func (x *DebugLevelRequest) Reset() {
+	*x = DebugLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugLevelRequest) ProtoMessage() {}
+
+func (x *DebugLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugLevelRequest.ProtoReflect.Descriptor instead.
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *DebugLevelRequest) GetShow() bool {
+	if x != nil {
+		return x.Show
+	}
+	return false
+}
+
+func (x *DebugLevelRequest) GetLevelSpec() string {
+	if x != nil {
+		return x.LevelSpec
+	}
+	return ""
+}
+
+type DebugLevelResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//A space separated list of the supported log sub-systems.
+	SubSystems string `protobuf:"bytes,1,opt,name=sub_systems,json=subSystems,proto3" json:"sub_systems,omitempty"`
+}
+
+func (x *DebugLevelResponse) Reset() {
+	*x = DebugLevelResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugLevelResponse) ProtoMessage() {}
+
+func (x *DebugLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugLevelResponse.ProtoReflect.Descriptor instead.
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *DebugLevelResponse) GetSubSystems() string {
+	if x != nil {
+		return x.SubSystems
+	}
+	return ""
+}
+
+type TestNotificationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TestNotificationRequest) Reset() {
+	*x = TestNotificationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNotificationRequest) ProtoMessage() {}
+
+func (x *TestNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNotificationRequest.ProtoReflect.Descriptor instead.
+func (*TestNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{79}
+}
+
+type TestNotificationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The outcome of delivering the test event to each configured sink.
+	Results []*NotificationSinkResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TestNotificationResponse) Reset() {
+	*x = TestNotificationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNotificationResponse) ProtoMessage() {}
+
+func (x *TestNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNotificationResponse.ProtoReflect.Descriptor instead.
+func (*TestNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *TestNotificationResponse) GetResults() []*NotificationSinkResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type NotificationSinkResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The name of the sink, one of webhook, command, email, telegram or matrix.
+	Sink string `protobuf:"bytes,1,opt,name=sink,proto3" json:"sink,omitempty"`
+	//
+	//Whether the test event was delivered to the sink.
+	Delivered bool `protobuf:"varint,2,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	//
+	//The error that delivery failed with, if the event was not delivered.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *NotificationSinkResult) Reset() {
+	*x = NotificationSinkResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotificationSinkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSinkResult) ProtoMessage() {}
+
+func (x *NotificationSinkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSinkResult.ProtoReflect.Descriptor instead.
+func (*NotificationSinkResult) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *NotificationSinkResult) GetSink() string {
+	if x != nil {
+		return x.Sink
+	}
+	return ""
+}
+
+func (x *NotificationSinkResult) GetDelivered() bool {
+	if x != nil {
+		return x.Delivered
+	}
+	return false
+}
+
+func (x *NotificationSinkResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SwapCostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Only include swaps that completed at or after this time, expressed in
+	//unix nanoseconds. If zero, swaps are not filtered by start time.
+	StartTimeNs int64 `protobuf:"varint,1,opt,name=start_time_ns,json=startTimeNs,proto3" json:"start_time_ns,omitempty"`
+	//
+	//Only include swaps that completed before this time, expressed in unix
+	//nanoseconds. If zero, swaps are not filtered by end time.
+	EndTimeNs int64 `protobuf:"varint,2,opt,name=end_time_ns,json=endTimeNs,proto3" json:"end_time_ns,omitempty"`
+	//
+	//Only include swaps of the given types. If no types are set, swaps of all
+	//types are included.
+	SwapTypes []SwapType `protobuf:"varint,3,rep,packed,name=swap_types,json=swapTypes,proto3,enum=looprpc.SwapType" json:"swap_types,omitempty"`
+}
+
+func (x *SwapCostsRequest) Reset() {
+	*x = SwapCostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapCostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapCostsRequest) ProtoMessage() {}
+
+func (x *SwapCostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapCostsRequest.ProtoReflect.Descriptor instead.
+func (*SwapCostsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *SwapCostsRequest) GetStartTimeNs() int64 {
+	if x != nil {
+		return x.StartTimeNs
+	}
+	return 0
+}
+
+func (x *SwapCostsRequest) GetEndTimeNs() int64 {
+	if x != nil {
+		return x.EndTimeNs
+	}
+	return 0
+}
+
+func (x *SwapCostsRequest) GetSwapTypes() []SwapType {
+	if x != nil {
+		return x.SwapTypes
+	}
+	return nil
+}
+
+type SwapCostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The costs of each swap that completed in the requested range, ordered
+	//from oldest to newest completion time.
+	Swaps []*SwapCost `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	//
+	//The total amount paid to the server over all swaps.
+	TotalServerFeeSat int64 `protobuf:"varint,2,opt,name=total_server_fee_sat,json=totalServerFeeSat,proto3" json:"total_server_fee_sat,omitempty"`
+	//
+	//The total amount paid in on-chain fees over all swaps.
+	TotalOnchainFeeSat int64 `protobuf:"varint,3,opt,name=total_onchain_fee_sat,json=totalOnchainFeeSat,proto3" json:"total_onchain_fee_sat,omitempty"`
+	//
+	//The total amount paid in off-chain routing fees over all swaps.
+	TotalOffchainFeeSat int64 `protobuf:"varint,4,opt,name=total_offchain_fee_sat,json=totalOffchainFeeSat,proto3" json:"total_offchain_fee_sat,omitempty"`
+	//
+	//The total cost of all swaps.
+	TotalCostSat int64 `protobuf:"varint,5,opt,name=total_cost_sat,json=totalCostSat,proto3" json:"total_cost_sat,omitempty"`
+}
+
+func (x *SwapCostsResponse) Reset() {
+	*x = SwapCostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapCostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapCostsResponse) ProtoMessage() {}
+
+func (x *SwapCostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapCostsResponse.ProtoReflect.Descriptor instead.
+func (*SwapCostsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *SwapCostsResponse) GetSwaps() []*SwapCost {
+	if x != nil {
+		return x.Swaps
+	}
+	return nil
+}
+
+func (x *SwapCostsResponse) GetTotalServerFeeSat() int64 {
+	if x != nil {
+		return x.TotalServerFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCostsResponse) GetTotalOnchainFeeSat() int64 {
+	if x != nil {
+		return x.TotalOnchainFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCostsResponse) GetTotalOffchainFeeSat() int64 {
+	if x != nil {
+		return x.TotalOffchainFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCostsResponse) GetTotalCostSat() int64 {
+	if x != nil {
+		return x.TotalCostSat
+	}
+	return 0
+}
+
+type QuoteHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Only include quotes that were received at or after this time, expressed
+	//in unix nanoseconds. If zero, quotes are not filtered by start time.
+	StartTimeNs int64 `protobuf:"varint,1,opt,name=start_time_ns,json=startTimeNs,proto3" json:"start_time_ns,omitempty"`
+	//
+	//Only include quotes that were received before this time, expressed in
+	//unix nanoseconds. If zero, quotes are not filtered by end time.
+	EndTimeNs int64 `protobuf:"varint,2,opt,name=end_time_ns,json=endTimeNs,proto3" json:"end_time_ns,omitempty"`
+	//
+	//Only include quotes for the given swap types. If no types are set, quotes
+	//of all types are included.
+	SwapTypes []SwapType `protobuf:"varint,3,rep,packed,name=swap_types,json=swapTypes,proto3,enum=looprpc.SwapType" json:"swap_types,omitempty"`
+}
+
+func (x *QuoteHistoryRequest) Reset() {
+	*x = QuoteHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteHistoryRequest) ProtoMessage() {}
+
+func (x *QuoteHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteHistoryRequest.ProtoReflect.Descriptor instead.
+func (*QuoteHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *QuoteHistoryRequest) GetStartTimeNs() int64 {
+	if x != nil {
+		return x.StartTimeNs
+	}
+	return 0
+}
+
+func (x *QuoteHistoryRequest) GetEndTimeNs() int64 {
+	if x != nil {
+		return x.EndTimeNs
+	}
+	return 0
+}
+
+func (x *QuoteHistoryRequest) GetSwapTypes() []SwapType {
+	if x != nil {
+		return x.SwapTypes
+	}
+	return nil
+}
+
+type QuoteHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The quotes that were received in the requested range, ordered from oldest
+	//to newest.
+	Quotes []*QuoteRecord `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+}
+
+func (x *QuoteHistoryResponse) Reset() {
+	*x = QuoteHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteHistoryResponse) ProtoMessage() {}
+
+func (x *QuoteHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteHistoryResponse.ProtoReflect.Descriptor instead.
+func (*QuoteHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *QuoteHistoryResponse) GetQuotes() []*QuoteRecord {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+type QuoteRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The time at which the quote was received, expressed in unix nanoseconds.
+	TimestampNs int64 `protobuf:"varint,1,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"`
+	//
+	//The type of swap that was quoted.
+	Type SwapType `protobuf:"varint,2,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//The swap amount that was quoted in sat.
+	Amt int64 `protobuf:"varint,3,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//The fee that the server quoted for the swap in sat.
+	SwapFeeSat int64 `protobuf:"varint,4,opt,name=swap_fee_sat,json=swapFeeSat,proto3" json:"swap_fee_sat,omitempty"`
+	//
+	//The part of the swap fee that the server requested as a prepayment. This
+	//is always zero for loop in quotes.
+	PrepayAmtSat int64 `protobuf:"varint,5,opt,name=prepay_amt_sat,json=prepayAmtSat,proto3" json:"prepay_amt_sat,omitempty"`
+	//
+	//The estimated on-chain fee for the swap at the time of the quote in sat.
+	MinerFeeSat int64 `protobuf:"varint,6,opt,name=miner_fee_sat,json=minerFeeSat,proto3" json:"miner_fee_sat,omitempty"`
+}
+
+func (x *QuoteRecord) Reset() {
+	*x = QuoteRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRecord) ProtoMessage() {}
+
+func (x *QuoteRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRecord.ProtoReflect.Descriptor instead.
+func (*QuoteRecord) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *QuoteRecord) GetTimestampNs() int64 {
+	if x != nil {
+		return x.TimestampNs
+	}
+	return 0
+}
+
+func (x *QuoteRecord) GetType() SwapType {
+	if x != nil {
+		return x.Type
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *QuoteRecord) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *QuoteRecord) GetSwapFeeSat() int64 {
+	if x != nil {
+		return x.SwapFeeSat
+	}
+	return 0
+}
+
+func (x *QuoteRecord) GetPrepayAmtSat() int64 {
+	if x != nil {
+		return x.PrepayAmtSat
+	}
+	return 0
+}
+
+func (x *QuoteRecord) GetMinerFeeSat() int64 {
+	if x != nil {
+		return x.MinerFeeSat
+	}
+	return 0
+}
+
+type AuditLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Only include state transitions of the swap with this hash. If empty, the
+	//transitions of all swaps are included.
+	SwapHash []byte `protobuf:"bytes,1,opt,name=swap_hash,json=swapHash,proto3" json:"swap_hash,omitempty"`
+	//
+	//Only include state transitions that happened at or after this time,
+	//expressed in unix nanoseconds. If zero, transitions are not filtered by
+	//time.
+	StartTimeNs int64 `protobuf:"varint,2,opt,name=start_time_ns,json=startTimeNs,proto3" json:"start_time_ns,omitempty"`
+}
+
+func (x *AuditLogRequest) Reset() {
+	*x = AuditLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogRequest) ProtoMessage() {}
+
+func (x *AuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogRequest.ProtoReflect.Descriptor instead.
+func (*AuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *AuditLogRequest) GetSwapHash() []byte {
+	if x != nil {
+		return x.SwapHash
+	}
+	return nil
+}
+
+func (x *AuditLogRequest) GetStartTimeNs() int64 {
+	if x != nil {
+		return x.StartTimeNs
+	}
+	return 0
+}
+
+type AuditLogResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The state transitions that were recorded, ordered from oldest to newest.
+	Entries []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *AuditLogResponse) Reset() {
+	*x = AuditLogResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogResponse) ProtoMessage() {}
+
+func (x *AuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogResponse.ProtoReflect.Descriptor instead.
+func (*AuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *AuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The time of the state transition, expressed in unix nanoseconds.
+	TimestampNs int64 `protobuf:"varint,1,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"`
+	//
+	//The hash of the swap.
+	SwapHash []byte `protobuf:"bytes,2,opt,name=swap_hash,json=swapHash,proto3" json:"swap_hash,omitempty"`
+	//
+	//The type of the swap.
+	Type SwapType `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//The state that the swap was in before the transition.
+	PreviousState string `protobuf:"bytes,4,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	//
+	//The state that the swap transitioned to.
+	State string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	//
+	//The component of loopd that triggered the transition.
+	Component string `protobuf:"bytes,6,opt,name=component,proto3" json:"component,omitempty"`
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *AuditEntry) GetTimestampNs() int64 {
+	if x != nil {
+		return x.TimestampNs
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetSwapHash() []byte {
+	if x != nil {
+		return x.SwapHash
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetType() SwapType {
+	if x != nil {
+		return x.Type
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *AuditEntry) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+type LiquidityHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Only include snapshots that were taken at or after this time, expressed
+	//in unix nanoseconds. If zero, all stored snapshots are included.
+	StartTimeNs int64 `protobuf:"varint,1,opt,name=start_time_ns,json=startTimeNs,proto3" json:"start_time_ns,omitempty"`
+	//
+	//Only include snapshots of the channels with these short channel ids. If
+	//no channels are set, snapshots of all channels are included.
+	ChannelIds []uint64 `protobuf:"varint,2,rep,packed,name=channel_ids,json=channelIds,proto3" json:"channel_ids,omitempty"`
+}
+
+func (x *LiquidityHistoryRequest) Reset() {
+	*x = LiquidityHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquidityHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityHistoryRequest) ProtoMessage() {}
+
+func (x *LiquidityHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityHistoryRequest.ProtoReflect.Descriptor instead.
+func (*LiquidityHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *LiquidityHistoryRequest) GetStartTimeNs() int64 {
+	if x != nil {
+		return x.StartTimeNs
+	}
+	return 0
+}
+
+func (x *LiquidityHistoryRequest) GetChannelIds() []uint64 {
+	if x != nil {
+		return x.ChannelIds
+	}
+	return nil
+}
+
+type LiquidityHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The balance history of each channel, ordered by channel id.
+	Channels []*ChannelLiquidityHistory `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	//
+	//The interval in seconds at which channel balance snapshots are recorded.
+	//Zero if snapshots are disabled.
+	SnapshotIntervalSec uint32 `protobuf:"varint,2,opt,name=snapshot_interval_sec,json=snapshotIntervalSec,proto3" json:"snapshot_interval_sec,omitempty"`
+}
+
+func (x *LiquidityHistoryResponse) Reset() {
+	*x = LiquidityHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquidityHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityHistoryResponse) ProtoMessage() {}
+
+func (x *LiquidityHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityHistoryResponse.ProtoReflect.Descriptor instead.
+func (*LiquidityHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *LiquidityHistoryResponse) GetChannels() []*ChannelLiquidityHistory {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *LiquidityHistoryResponse) GetSnapshotIntervalSec() uint32 {
+	if x != nil {
+		return x.SnapshotIntervalSec
+	}
+	return 0
+}
+
+type ChannelLiquidityHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The short channel id of the channel.
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The capacity of the channel in sat.
+	CapacitySat int64 `protobuf:"varint,2,opt,name=capacity_sat,json=capacitySat,proto3" json:"capacity_sat,omitempty"`
+	//
+	//The lowest local balance of the channel across all of its snapshots in sat.
+	MinLocalBalanceSat int64 `protobuf:"varint,3,opt,name=min_local_balance_sat,json=minLocalBalanceSat,proto3" json:"min_local_balance_sat,omitempty"`
+	//
+	//The highest local balance of the channel across all of its snapshots in
+	//sat.
+	MaxLocalBalanceSat int64 `protobuf:"varint,4,opt,name=max_local_balance_sat,json=maxLocalBalanceSat,proto3" json:"max_local_balance_sat,omitempty"`
+	//
+	//The snapshots of the channel's balances, ordered from oldest to newest.
+	Snapshots []*LiquiditySnapshot `protobuf:"bytes,5,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ChannelLiquidityHistory) Reset() {
+	*x = ChannelLiquidityHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelLiquidityHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelLiquidityHistory) ProtoMessage() {}
+
+func (x *ChannelLiquidityHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelLiquidityHistory.ProtoReflect.Descriptor instead.
+func (*ChannelLiquidityHistory) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ChannelLiquidityHistory) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *ChannelLiquidityHistory) GetCapacitySat() int64 {
+	if x != nil {
+		return x.CapacitySat
+	}
+	return 0
+}
+
+func (x *ChannelLiquidityHistory) GetMinLocalBalanceSat() int64 {
+	if x != nil {
+		return x.MinLocalBalanceSat
+	}
+	return 0
+}
+
+func (x *ChannelLiquidityHistory) GetMaxLocalBalanceSat() int64 {
+	if x != nil {
+		return x.MaxLocalBalanceSat
+	}
+	return 0
+}
+
+func (x *ChannelLiquidityHistory) GetSnapshots() []*LiquiditySnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type LiquiditySnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The time at which the snapshot was taken, expressed in unix nanoseconds.
+	TimestampNs int64 `protobuf:"varint,1,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"`
+	//
+	//Our balance in the channel at the time of the snapshot in sat.
+	LocalBalanceSat int64 `protobuf:"varint,2,opt,name=local_balance_sat,json=localBalanceSat,proto3" json:"local_balance_sat,omitempty"`
+	//
+	//Our peer's balance in the channel at the time of the snapshot in sat.
+	RemoteBalanceSat int64 `protobuf:"varint,3,opt,name=remote_balance_sat,json=remoteBalanceSat,proto3" json:"remote_balance_sat,omitempty"`
+}
+
+func (x *LiquiditySnapshot) Reset() {
+	*x = LiquiditySnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquiditySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquiditySnapshot) ProtoMessage() {}
+
+func (x *LiquiditySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquiditySnapshot.ProtoReflect.Descriptor instead.
+func (*LiquiditySnapshot) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *LiquiditySnapshot) GetTimestampNs() int64 {
+	if x != nil {
+		return x.TimestampNs
+	}
+	return 0
+}
+
+func (x *LiquiditySnapshot) GetLocalBalanceSat() int64 {
+	if x != nil {
+		return x.LocalBalanceSat
+	}
+	return 0
+}
+
+func (x *LiquiditySnapshot) GetRemoteBalanceSat() int64 {
+	if x != nil {
+		return x.RemoteBalanceSat
+	}
+	return 0
+}
+
+type SwapCost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	//Swap identifier to track status in the update stream that is returned from
+	//the Start() call. Currently this is the hash that locks the htlcs.
+	IdBytes []byte `protobuf:"bytes,2,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	//
+	//The type of the swap.
+	Type SwapType `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//Whether the swap succeeded. Failed swaps may also have incurred costs,
+	//for example a paid prepayment or a timeout sweep.
+	Success bool `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	//
+	//Requested swap amount in sat.
+	Amt int64 `protobuf:"varint,5,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//Initiation time of the swap, expressed in unix nanoseconds.
+	InitiationTime int64 `protobuf:"varint,6,opt,name=initiation_time,json=initiationTime,proto3" json:"initiation_time,omitempty"`
+	//
+	//The time that the swap completed, expressed in unix nanoseconds.
+	LastUpdateTime int64 `protobuf:"varint,7,opt,name=last_update_time,json=lastUpdateTime,proto3" json:"last_update_time,omitempty"`
+	//
+	//The amount paid to the server.
+	ServerFeeSat int64 `protobuf:"varint,8,opt,name=server_fee_sat,json=serverFeeSat,proto3" json:"server_fee_sat,omitempty"`
+	//
+	//The amount paid in on-chain fees.
+	OnchainFeeSat int64 `protobuf:"varint,9,opt,name=onchain_fee_sat,json=onchainFeeSat,proto3" json:"onchain_fee_sat,omitempty"`
+	//
+	//The amount paid in off-chain routing fees.
+	OffchainFeeSat int64 `protobuf:"varint,10,opt,name=offchain_fee_sat,json=offchainFeeSat,proto3" json:"offchain_fee_sat,omitempty"`
+	//
+	//The total cost of the swap.
+	TotalCostSat int64 `protobuf:"varint,11,opt,name=total_cost_sat,json=totalCostSat,proto3" json:"total_cost_sat,omitempty"`
+}
+
+func (x *SwapCost) Reset() {
+	*x = SwapCost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapCost) ProtoMessage() {}
+
+func (x *SwapCost) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapCost.ProtoReflect.Descriptor instead.
+func (*SwapCost) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *SwapCost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SwapCost) GetIdBytes() []byte {
+	if x != nil {
+		return x.IdBytes
+	}
+	return nil
+}
+
+func (x *SwapCost) GetType() SwapType {
+	if x != nil {
+		return x.Type
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *SwapCost) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SwapCost) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *SwapCost) GetInitiationTime() int64 {
+	if x != nil {
+		return x.InitiationTime
+	}
+	return 0
+}
+
+func (x *SwapCost) GetLastUpdateTime() int64 {
+	if x != nil {
+		return x.LastUpdateTime
+	}
+	return 0
+}
+
+func (x *SwapCost) GetServerFeeSat() int64 {
+	if x != nil {
+		return x.ServerFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCost) GetOnchainFeeSat() int64 {
+	if x != nil {
+		return x.OnchainFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCost) GetOffchainFeeSat() int64 {
+	if x != nil {
+		return x.OffchainFeeSat
+	}
+	return 0
+}
+
+func (x *SwapCost) GetTotalCostSat() int64 {
+	if x != nil {
+		return x.TotalCostSat
+	}
+	return 0
+}
+
+type CompareRebalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The short channel ID of the channel that we want to shift balance in.
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	//
+	//The amount, expressed in satoshis, that we want to shift.
+	Amt int64 `protobuf:"varint,2,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//The type of swap that we compare a rebalance with. A loop out shifts
+	//balance out of the channel and a loop in shifts balance into it.
+	Type SwapType `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType" json:"type,omitempty"`
+	//
+	//The confirmation target that is used for the swap's fee estimate.
+	ConfTarget int32 `protobuf:"varint,4,opt,name=conf_target,json=confTarget,proto3" json:"conf_target,omitempty"`
+}
+
+func (x *CompareRebalanceRequest) Reset() {
+	*x = CompareRebalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareRebalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRebalanceRequest) ProtoMessage() {}
+
+func (x *CompareRebalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRebalanceRequest.ProtoReflect.Descriptor instead.
+func (*CompareRebalanceRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *CompareRebalanceRequest) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *CompareRebalanceRequest) GetAmt() int64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *CompareRebalanceRequest) GetType() SwapType {
+	if x != nil {
+		return x.Type
+	}
+	return SwapType_LOOP_OUT
+}
+
+func (x *CompareRebalanceRequest) GetConfTarget() int32 {
+	if x != nil {
+		return x.ConfTarget
+	}
+	return 0
+}
+
+type CompareRebalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The total swap cost quoted, being the server fee and on-chain fee
+	//estimate, expressed in satoshis.
+	SwapCostSat int64 `protobuf:"varint,1,opt,name=swap_cost_sat,json=swapCostSat,proto3" json:"swap_cost_sat,omitempty"`
+	//
+	//The off-chain fees that lnd estimates for a circular rebalance, expressed
+	//in satoshis.
+	RebalanceCostSat int64 `protobuf:"varint,2,opt,name=rebalance_cost_sat,json=rebalanceCostSat,proto3" json:"rebalance_cost_sat,omitempty"`
+	//
+	//Whether lnd found a route for the rebalance. If this value is false, the
+	//rebalance cost is not set.
+	RebalanceRouteFound bool `protobuf:"varint,3,opt,name=rebalance_route_found,json=rebalanceRouteFound,proto3" json:"rebalance_route_found,omitempty"`
+	//
+	//Whether the rebalance is cheaper than the swap.
+	RebalanceCheaper bool `protobuf:"varint,4,opt,name=rebalance_cheaper,json=rebalanceCheaper,proto3" json:"rebalance_cheaper,omitempty"`
+	//
+	//The error returned when estimating the rebalance, if any.
+	RebalanceError string `protobuf:"bytes,5,opt,name=rebalance_error,json=rebalanceError,proto3" json:"rebalance_error,omitempty"`
+}
+
+func (x *CompareRebalanceResponse) Reset() {
+	*x = CompareRebalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareRebalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRebalanceResponse) ProtoMessage() {}
+
+func (x *CompareRebalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRebalanceResponse.ProtoReflect.Descriptor instead.
+func (*CompareRebalanceResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *CompareRebalanceResponse) GetSwapCostSat() int64 {
+	if x != nil {
+		return x.SwapCostSat
+	}
+	return 0
+}
+
+func (x *CompareRebalanceResponse) GetRebalanceCostSat() int64 {
+	if x != nil {
+		return x.RebalanceCostSat
+	}
+	return 0
+}
+
+func (x *CompareRebalanceResponse) GetRebalanceRouteFound() bool {
+	if x != nil {
+		return x.RebalanceRouteFound
+	}
+	return false
+}
+
+func (x *CompareRebalanceResponse) GetRebalanceCheaper() bool {
+	if x != nil {
+		return x.RebalanceCheaper
+	}
+	return false
+}
+
+func (x *CompareRebalanceResponse) GetRebalanceError() string {
+	if x != nil {
+		return x.RebalanceError
+	}
+	return ""
+}
+
+type SupportBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The maximum number of bytes from the end of loopd's log file to include
+	//in the bundle. If zero, the last 1 MiB of the log is included. This value
+	//may not exceed 8 MiB.
+	MaxLogBytes uint64 `protobuf:"varint,1,opt,name=max_log_bytes,json=maxLogBytes,proto3" json:"max_log_bytes,omitempty"`
+}
+
+func (x *SupportBundleRequest) Reset() {
+	*x = SupportBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SupportBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportBundleRequest) ProtoMessage() {}
+
+func (x *SupportBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportBundleRequest.ProtoReflect.Descriptor instead.
+func (*SupportBundleRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *SupportBundleRequest) GetMaxLogBytes() uint64 {
+	if x != nil {
+		return x.MaxLogBytes
+	}
+	return 0
+}
+
+type SupportBundleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The gzipped tar archive.
+	Archive []byte `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	//
+	//A suggested file name for the archive.
+	FileName string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+}
+
+func (x *SupportBundleResponse) Reset() {
+	*x = SupportBundleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SupportBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportBundleResponse) ProtoMessage() {}
+
+func (x *SupportBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportBundleResponse.ProtoReflect.Descriptor instead.
+func (*SupportBundleResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SupportBundleResponse) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type NewStaticAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NewStaticAddressRequest) Reset() {
+	*x = NewStaticAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewStaticAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewStaticAddressRequest) ProtoMessage() {}
+
+func (x *NewStaticAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewStaticAddressRequest.ProtoReflect.Descriptor instead.
+func (*NewStaticAddressRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{99}
+}
+
+type NewStaticAddressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The static address that deposits can be made to.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	//
+	//The unix timestamp at which the static address was created.
+	CreationTime int64 `protobuf:"varint,2,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
+}
+
+func (x *NewStaticAddressResponse) Reset() {
+	*x = NewStaticAddressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewStaticAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewStaticAddressResponse) ProtoMessage() {}
+
+func (x *NewStaticAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewStaticAddressResponse.ProtoReflect.Descriptor instead.
+func (*NewStaticAddressResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *NewStaticAddressResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NewStaticAddressResponse) GetCreationTime() int64 {
+	if x != nil {
+		return x.CreationTime
+	}
+	return 0
+}
+
+type ListStaticDepositsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListStaticDepositsRequest) Reset() {
+	*x = ListStaticDepositsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStaticDepositsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStaticDepositsRequest) ProtoMessage() {}
+
+func (x *ListStaticDepositsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStaticDepositsRequest.ProtoReflect.Descriptor instead.
+func (*ListStaticDepositsRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{101}
+}
+
+type ListStaticDepositsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The static address, empty if it has not been created yet.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	//
+	//The deposits that have been made to the static address, ordered by
+	//confirmation height.
+	Deposits []*StaticDeposit `protobuf:"bytes,2,rep,name=deposits,proto3" json:"deposits,omitempty"`
+}
+
+func (x *ListStaticDepositsResponse) Reset() {
+	*x = ListStaticDepositsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStaticDepositsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStaticDepositsResponse) ProtoMessage() {}
+
+func (x *ListStaticDepositsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStaticDepositsResponse.ProtoReflect.Descriptor instead.
+func (*ListStaticDepositsResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ListStaticDepositsResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListStaticDepositsResponse) GetDeposits() []*StaticDeposit {
+	if x != nil {
+		return x.Deposits
+	}
+	return nil
+}
+
+type StaticDeposit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The outpoint of the deposit, formatted as txid:index.
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	//
+	//The value of the deposit in satoshis.
+	Value int64 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	//
+	//The height at which the deposit confirmed.
+	ConfirmationHeight int32 `protobuf:"varint,3,opt,name=confirmation_height,json=confirmationHeight,proto3" json:"confirmation_height,omitempty"`
+	//
+	//The current state of the deposit.
+	State DepositState `protobuf:"varint,4,opt,name=state,proto3,enum=looprpc.DepositState" json:"state,omitempty"`
+	//
+	//The hash of the loop in swap that was dispatched for the deposit, if any.
+	SwapHash []byte `protobuf:"bytes,5,opt,name=swap_hash,json=swapHash,proto3" json:"swap_hash,omitempty"`
+	//
+	//The unix timestamp of the deposit's most recent state change.
+	LastUpdate int64 `protobuf:"varint,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+}
+
+func (x *StaticDeposit) Reset() {
+	*x = StaticDeposit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StaticDeposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaticDeposit) ProtoMessage() {}
+
+func (x *StaticDeposit) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaticDeposit.ProtoReflect.Descriptor instead.
+func (*StaticDeposit) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *StaticDeposit) GetOutpoint() string {
+	if x != nil {
+		return x.Outpoint
+	}
+	return ""
+}
+
+func (x *StaticDeposit) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *StaticDeposit) GetConfirmationHeight() int32 {
+	if x != nil {
+		return x.ConfirmationHeight
+	}
+	return 0
+}
+
+func (x *StaticDeposit) GetState() DepositState {
+	if x != nil {
+		return x.State
+	}
+	return DepositState_DEPOSIT_CONFIRMED
+}
+
+func (x *StaticDeposit) GetSwapHash() []byte {
+	if x != nil {
+		return x.SwapHash
+	}
+	return nil
+}
+
+func (x *StaticDeposit) GetLastUpdate() int64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+type MacaroonPermission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The entity that the permission grants access to, for example swap or
+	//suggestions.
+	Entity string `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	//
+	//The action that may be performed on the entity, for example read or
+	//write.
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *MacaroonPermission) Reset() {
+	*x = MacaroonPermission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MacaroonPermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MacaroonPermission) ProtoMessage() {}
+
+func (x *MacaroonPermission) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MacaroonPermission.ProtoReflect.Descriptor instead.
+func (*MacaroonPermission) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *MacaroonPermission) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *MacaroonPermission) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type BakeMacaroonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The permissions that the new macaroon grants. At least one permission is
+	//required.
+	Permissions []*MacaroonPermission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (x *BakeMacaroonRequest) Reset() {
+	*x = BakeMacaroonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BakeMacaroonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BakeMacaroonRequest) ProtoMessage() {}
+
+func (x *BakeMacaroonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BakeMacaroonRequest.ProtoReflect.Descriptor instead.
+func (*BakeMacaroonRequest) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *BakeMacaroonRequest) GetPermissions() []*MacaroonPermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type BakeMacaroonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	//The hex encoded macaroon.
+	Macaroon string `protobuf:"bytes,1,opt,name=macaroon,proto3" json:"macaroon,omitempty"`
+}
+
+func (x *BakeMacaroonResponse) Reset() {
+	*x = BakeMacaroonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_client_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BakeMacaroonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BakeMacaroonResponse) ProtoMessage() {}
+
+func (x *BakeMacaroonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_client_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BakeMacaroonResponse.ProtoReflect.Descriptor instead.
+func (*BakeMacaroonResponse) Descriptor() ([]byte, []int) {
+	return file_client_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *BakeMacaroonResponse) GetMacaroon() string {
+	if x != nil {
+		return x.Macaroon
+	}
+	return ""
+}
+
+var File_client_proto protoreflect.FileDescriptor
+
+var file_client_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x22, 0xa7, 0x07, 0x0a, 0x0e, 0x4c, 0x6f, 0x6f, 0x70,
+	0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x74,
+	0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65,
+	0x65, 0x12, 0x33, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x52, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77,
+	0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f, 0x61, 0x6d, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x41, 0x6d, 0x74, 0x12, 0x22,
+	0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x46,
+	0x65, 0x65, 0x12, 0x2c, 0x0a, 0x10, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x0e, 0x6c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x75, 0x74,
+	0x67, 0x6f, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x11,
+	0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x43, 0x6f,
+	0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x77, 0x61, 0x70, 0x5f,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x61, 0x70,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79,
+	0x12, 0x24, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x78, 0x70,
+	0x75, 0x62, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x46, 0x72,
+	0x6f, 0x6d, 0x58, 0x70, 0x75, 0x62, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2a, 0x0a,
+	0x11, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65,
+	0x73, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x77, 0x61, 0x70, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x50, 0x61, 0x72, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73,
+	0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x06, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x22, 0x86, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x61, 0x6d, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x67,
+	0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61,
+	0x6e, 0x53, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x72, 0x6f,
+	0x6d, 0x5f, 0x78, 0x70, 0x75, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x65,
+	0x73, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x58, 0x70, 0x75, 0x62, 0x22, 0xa6, 0x03, 0x0a, 0x0d, 0x4c,
+	0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x61, 0x6d, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x20,
+	0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65,
+	0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65,
+	0x72, 0x46, 0x65, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x70,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x6f, 0x70, 0x12,
+	0x23, 0x0a, 0x0d, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x6c, 0x63,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x48, 0x74, 0x6c, 0x63, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x68, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x0b, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x48, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x22, 0x3a, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x48, 0x69, 0x6e, 0x74,
+	0x12, 0x2d, 0x0a, 0x09, 0x68, 0x6f, 0x70, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x48, 0x6f,
+	0x70, 0x48, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x68, 0x6f, 0x70, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x22,
+	0xcb, 0x01, 0x0a, 0x07, 0x48, 0x6f, 0x70, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a,
+	0x0d, 0x66, 0x65, 0x65, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x42, 0x61, 0x73, 0x65, 0x4d, 0x73, 0x61,
+	0x74, 0x12, 0x3e, 0x0a, 0x1b, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x74, 0x68, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x66, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x74, 0x68,
+	0x73, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6c, 0x74, 0x76, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x63, 0x6c,
+	0x74, 0x76, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x22, 0xa1, 0x02,
+	0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x0c, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x5f, 0x6e, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4e, 0x70,
+	0x32, 0x77, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x32, 0x77,
+	0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x73, 0x70, 0x6c,
+	0x69, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0a, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x22, 0xaa, 0x01, 0x0a, 0x12, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x50, 0x73, 0x62, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x5f, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x32, 0x77, 0x73,
+	0x68, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x61, 0x6d, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x73, 0x62, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x70, 0x73, 0x62, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa7,
+	0x01, 0x0a, 0x0e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x65, 0x67, 0x61,
+	0x63, 0x79, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79,
+	0x53, 0x69, 0x6e, 0x63, 0x65, 0x4e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0xdb, 0x0b, 0x0a, 0x0a, 0x53, 0x77, 0x61,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x12, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x69, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x69, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3d, 0x0a, 0x0e, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0c, 0x68,
+	0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x68, 0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x32, 0x77, 0x73, 0x68,
+	0x12, 0x2e, 0x0a, 0x13, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x5f, 0x6e, 0x70, 0x32, 0x77, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x68,
+	0x74, 0x6c, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4e, 0x70, 0x32, 0x77, 0x73, 0x68,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x73, 0x74, 0x4f, 0x6e, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x73,
+	0x74, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x6f, 0x75, 0x74, 0x67, 0x6f,
+	0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x46, 0x6c, 0x6f, 0x77, 0x53, 0x61, 0x74, 0x12, 0x34, 0x0a, 0x16,
+	0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x62, 0x79,
+	0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6f, 0x75,
+	0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73,
+	0x65, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x75, 0x6e, 0x74,
+	0x69, 0x6c, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x79, 0x12, 0x33, 0x0a, 0x16, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x75, 0x6e, 0x74,
+	0x69, 0x6c, 0x5f, 0x6e, 0x6f, 0x5f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x13, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x4e,
+	0x6f, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x68, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x50, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x13, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x4a, 0x0a, 0x13, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x11, 0x73, 0x77, 0x61, 0x70,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x70, 0x6f, 0x73, 0x73, 0x69, 0x62,
+	0x6c, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x10, 0x70, 0x6f, 0x73, 0x73, 0x69,
+	0x62, 0x6c, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x68,
+	0x74, 0x6c, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x1e, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x48, 0x74, 0x6c, 0x63,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x68, 0x74, 0x6c, 0x63, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x4d, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x65, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61,
+	0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66,
+	0x65, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61,
+	0x70, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x12, 0x33, 0x0a, 0x16, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x23, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x66,
+	0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x65,
+	0x65, 0x53, 0x61, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x5f,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x22, 0xf8, 0x01, 0x0a, 0x10, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30,
+	0x0a, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61,
+	0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x5f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x77,
+	0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x53, 0x77, 0x61, 0x70, 0x73, 0x22, 0x57, 0x0a, 0x12, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x16, 0x69,
+	0x5f, 0x6b, 0x6e, 0x6f, 0x77, 0x5f, 0x77, 0x68, 0x61, 0x74, 0x5f, 0x69, 0x5f, 0x61, 0x6d, 0x5f,
+	0x64, 0x6f, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x4b, 0x6e,
+	0x6f, 0x77, 0x57, 0x68, 0x61, 0x74, 0x49, 0x41, 0x6d, 0x44, 0x6f, 0x69, 0x6e, 0x67, 0x22, 0x15,
+	0x0a, 0x13, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x77, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x53, 0x77, 0x65, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0d, 0x73, 0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x61, 0x74, 0x50, 0x65, 0x72, 0x56,
+	0x62, 0x79, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x22, 0xdb,
+	0x01, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x77, 0x65, 0x65, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73,
+	0x77, 0x65, 0x65, 0x70, 0x5f, 0x74, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73,
+	0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x6f,
+	0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x68,
+	0x74, 0x6c, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x68,
+	0x74, 0x6c, 0x63, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x74, 0x6c, 0x63, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x61,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x66, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0x38, 0x0a, 0x11,
+	0x50, 0x72, 0x75, 0x6e, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x22, 0x37, 0x0a, 0x12, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x22,
+	0x14, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x22, 0x2c, 0x0a, 0x12, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77,
+	0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x22, 0x76, 0x0a, 0x13, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12,
+	0x29, 0x0a, 0x10, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22, 0x82, 0x02, 0x0a, 0x0c, 0x53,
+	0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68,
+	0x61, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x75,
+	0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x2f, 0x0a,
+	0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x61, 0x78,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x12, 0x2a,
+	0x0a, 0x11, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x65, 0x70,
+	0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x74,
+	0x6c, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22,
+	0x4c, 0x0a, 0x17, 0x53, 0x61, 0x76, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c,
+	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x1a, 0x0a,
+	0x18, 0x53, 0x61, 0x76, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x50, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x09, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x22, 0x2f, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x54, 0x65, 0x72,
+	0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7f, 0x0a, 0x0f, 0x49, 0x6e, 0x54,
+	0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x0f,
+	0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d,
+	0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04,
+	0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0xcc, 0x01, 0x0a, 0x10, 0x4f,
+	0x75, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61,
+	0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6c, 0x74, 0x76, 0x5f, 0x64, 0x65, 0x6c, 0x74,
+	0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x74, 0x76,
+	0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6c, 0x74,
+	0x76, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d,
+	0x61, 0x78, 0x43, 0x6c, 0x74, 0x76, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08,
+	0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0xd0, 0x01, 0x0a, 0x0c, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x48, 0x74,
+	0x6c, 0x63, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x61, 0x70, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2c,
+	0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xb0, 0x01, 0x0a,
+	0x0f, 0x49, 0x6e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x20, 0x0a, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x53,
+	0x61, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x68, 0x74, 0x6c, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x46, 0x65, 0x65,
+	0x53, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x74, 0x76, 0x5f, 0x64, 0x65, 0x6c, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6c, 0x74, 0x76, 0x44, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22,
+	0xa1, 0x02, 0x0a, 0x10, 0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65,
+	0x5f, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70,
+	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79,
+	0x5f, 0x61, 0x6d, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x41, 0x6d, 0x74, 0x53, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x12,
+	0x68, 0x74, 0x6c, 0x63, 0x5f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x68, 0x74, 0x6c, 0x63, 0x53, 0x77,
+	0x65, 0x65, 0x70, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x61,
+	0x70, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x77, 0x61, 0x70, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x74, 0x76, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6c, 0x74, 0x76, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x0e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x73, 0x61, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x22, 0xbb, 0x02, 0x0a, 0x09, 0x4c, 0x73, 0x61, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x4d, 0x61, 0x63,
+	0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61,
+	0x69, 0x64, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x69, 0x64, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x31, 0x0a,
+	0x15, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x61, 0x69,
+	0x64, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x61, 0x69, 0x64, 0x4d, 0x73, 0x61, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd0, 0x0b,
+	0x0a, 0x13, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c,
+	0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x65, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x3d, 0x0a, 0x1c,
+	0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x17, 0x73, 0x77, 0x65, 0x65, 0x70, 0x46, 0x65, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x53, 0x61, 0x74, 0x50, 0x65, 0x72, 0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x10, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65,
+	0x65, 0x50, 0x70, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65,
+	0x50, 0x70, 0x6d, 0x12, 0x3a, 0x0a, 0x1a, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x61,
+	0x79, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70,
+	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70,
+	0x61, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x70, 0x6d, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70,
+	0x61, 0x79, 0x53, 0x61, 0x74, 0x12, 0x29, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e,
+	0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74,
+	0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x77, 0x65,
+	0x65, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f,
+	0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x53, 0x65, 0x63, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x75, 0x74, 0x6f,
+	0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x53, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x19, 0x61, 0x75, 0x74, 0x6f,
+	0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x75, 0x74,
+	0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x63, 0x12, 0x2b, 0x0a, 0x12, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x61, 0x75, 0x74, 0x6f, 0x4d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x53, 0x77,
+	0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x52, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x15, 0x64,
+	0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x04, 0x52, 0x14, 0x64, 0x69, 0x73, 0x71,
+	0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x64, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x11, 0x64, 0x69,
+	0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
+	0x5f, 0x73, 0x65, 0x63, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73, 0x77, 0x61, 0x70,
+	0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x53, 0x65, 0x63, 0x12, 0x38, 0x0a, 0x18, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69,
+	0x6e, 0x65, 0x53, 0x65, 0x63, 0x12, 0x43, 0x0a, 0x0e, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0d, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x73, 0x65, 0x63, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x63, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x44, 0x61, 0x79, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x12,
+	0x2a, 0x0a, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x5f,
+	0x68, 0x6f, 0x75, 0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x45, 0x6e, 0x64, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x48, 0x0a, 0x22, 0x73,
+	0x77, 0x65, 0x65, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79, 0x74,
+	0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x73, 0x77, 0x65, 0x65, 0x70, 0x46, 0x65,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x61, 0x74, 0x50, 0x65, 0x72,
+	0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x75,
+	0x70, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x18, 0x1e, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x55, 0x70, 0x54, 0x6f, 0x4d, 0x69, 0x6e,
+	0x69, 0x6d, 0x75, 0x6d, 0x12, 0x3a, 0x0a, 0x19, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x49, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x22, 0xeb, 0x06, 0x0a, 0x0d, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x52, 0x75,
+	0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63,
+	0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x75, 0x74, 0x67,
+	0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x65, 0x65, 0x70,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x68,
+	0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a,
+	0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a,
+	0x1a, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x6d, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x17, 0x6d, 0x69, 0x6e, 0x49, 0x6d, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69,
+	0x6e, 0x5f, 0x72, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x12, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x61, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x70, 0x6d, 0x12, 0x34, 0x0a, 0x16, 0x6f, 0x75,
+	0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6f, 0x75, 0x74, 0x67,
+	0x6f, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x70, 0x6d,
+	0x12, 0x28, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x50, 0x70, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f,
+	0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x70, 0x6d, 0x12,
+	0x24, 0x0a, 0x0e, 0x66, 0x65, 0x65, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x42, 0x75, 0x64, 0x67,
+	0x65, 0x74, 0x53, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x66, 0x65, 0x65, 0x5f, 0x62, 0x75, 0x64,
+	0x67, 0x65, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x66, 0x65, 0x65, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x12, 0x2f, 0x0a, 0x14, 0x66, 0x65, 0x65, 0x5f,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x66, 0x65, 0x65, 0x42, 0x75, 0x64, 0x67, 0x65,
+	0x74, 0x53, 0x70, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x66, 0x65, 0x65,
+	0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x73, 0x61, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x66, 0x65, 0x65, 0x42, 0x75,
+	0x64, 0x67, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x61, 0x74, 0x22, 0x59,
+	0x0a, 0x19, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x41, 0x0a, 0x1a, 0x53, 0x65, 0x74,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x63, 0x22, 0x9b, 0x01, 0x0a,
+	0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a,
+	0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x1d, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x63, 0x22, 0x1e, 0x0a, 0x1c, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1f, 0x0a, 0x1d, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x47,
+	0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x57, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x75, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0xc4, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66,
+	0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x50, 0x70, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x76,
+	0x67, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x61, 0x76, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x41, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x15, 0x0a,
+	0x13, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x72, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xb6, 0x01, 0x0a, 0x14, 0x53, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x32, 0x0a, 0x08, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f,
+	0x6f, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x6c, 0x6f,
+	0x6f, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x12, 0x2f, 0x0a, 0x07, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70,
+	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x6c, 0x6f, 0x6f, 0x70, 0x49,
+	0x6e, 0x22, 0x17, 0x0a, 0x15, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x22, 0x0a, 0x20, 0x54, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8a,
+	0x02, 0x0a, 0x16, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x39, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x44, 0x69, 0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x0c, 0x64, 0x69,
+	0x73, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x62, 0x75,
+	0x64, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x75, 0x64,
+	0x67, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xce, 0x01, 0x0a, 0x0e,
+	0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x53, 0x61, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x61, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x61, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0x1d, 0x0a, 0x1b,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa7, 0x02, 0x0a, 0x1c,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x2d, 0x0a, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x52, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x53, 0x61,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x53, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64,
+	0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x64, 0x53, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x66,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x46,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0xd3, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x73,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x64, 0x53, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x53, 0x61, 0x74, 0x22, 0xc3, 0x02, 0x0a, 0x0d,
+	0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2e, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75,
+	0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b,
+	0x65, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74,
+	0x6f, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x2e, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61,
+	0x74, 0x22, 0x72, 0x0a, 0x10, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x11, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x22, 0x91, 0x03, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x66, 0x75, 0x6c, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x61,
+	0x76, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x61, 0x76, 0x67, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x35, 0x0a, 0x17, 0x61,
+	0x76, 0x67, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x61, 0x76,
+	0x67, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x53,
+	0x65, 0x63, 0x12, 0x34, 0x0a, 0x0b, 0x70, 0x68, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0a, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x77, 0x61, 0x70, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x35, 0x30, 0x5f, 0x73,
+	0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x35, 0x30, 0x53, 0x65, 0x63,
+	0x12, 0x17, 0x0a, 0x07, 0x70, 0x39, 0x30, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x70, 0x39, 0x30, 0x53, 0x65, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x39, 0x39,
+	0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x39, 0x39, 0x53,
+	0x65, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x63, 0x12, 0x30, 0x0a, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c,
+	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x4d, 0x0a,
+	0x0d, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x26,
+	0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x73, 0x65,
+	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x70, 0x70, 0x65, 0x72, 0x42, 0x6f,
+	0x75, 0x6e, 0x64, 0x53, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x15, 0x0a, 0x13,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xa0, 0x02, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x1b, 0x6e, 0x65,
+	0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x19, 0x6e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbe, 0x0a, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x70,
+	0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61,
+	0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6e, 0x64, 0x5f, 0x68, 0x6f, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6e, 0x64, 0x48, 0x6f, 0x73, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x6f, 0x73,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x78, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e,
+	0x6f, 0x5f, 0x74, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x4e, 0x6f, 0x54, 0x6c, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f,
+	0x6c, 0x73, 0x61, 0x74, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x73, 0x61, 0x74, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c,
+	0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x73, 0x61, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x4c, 0x73, 0x61, 0x74, 0x46, 0x65, 0x65, 0x12, 0x2b,
+	0x0a, 0x12, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x70,
+	0x61, 0x72, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6c, 0x6f, 0x6f, 0x70,
+	0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x50, 0x61, 0x72, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x73,
+	0x77, 0x65, 0x65, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x62, 0x75, 0x6d, 0x70, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x73, 0x77, 0x65, 0x65,
+	0x70, 0x46, 0x65, 0x65, 0x42, 0x75, 0x6d, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x73, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x77, 0x65, 0x65, 0x70,
+	0x73, 0x12, 0x39, 0x0a, 0x19, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x19,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x16, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x77, 0x65, 0x65, 0x70, 0x43, 0x6f, 0x6e,
+	0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x37, 0x0a, 0x18, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78,
+	0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x6f, 0x75, 0x74, 0x5f,
+	0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x10,
+	0x72, 0x70, 0x63, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x73, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72, 0x70, 0x63, 0x4d, 0x61, 0x78, 0x4d, 0x73,
+	0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x70, 0x63, 0x5f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x11, 0x72, 0x70, 0x63, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f,
+	0x70, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x3e, 0x0a, 0x1b, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19,
+	0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x65, 0x72,
+	0x6d, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x74,
+	0x6c, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x6f, 0x5f, 0x74, 0x78, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6e, 0x6f, 0x54, 0x78, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x3c, 0x0a, 0x1b, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x6f, 0x6f,
+	0x70, 0x5f, 0x69, 0x6e, 0x5f, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x66, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x6d, 0x61, 0x78, 0x4c,
+	0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x1a, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x69, 0x6e, 0x5f, 0x72,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x6c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x52,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x32, 0x0a, 0x16, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x12, 0x6c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4d, 0x61, 0x78,
+	0x46, 0x65, 0x65, 0x4a, 0x04, 0x08, 0x12, 0x10, 0x13, 0x52, 0x10, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x41, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xc7, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x70, 0x69, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x61,
+	0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0c, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x68, 0x0a,
+	0x0b, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x61,
+	0x6a, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x61, 0x6c, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x22, 0x46, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x68, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x68, 0x6f, 0x77,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x53, 0x70, 0x65, 0x63, 0x22,
+	0x35, 0x0a, 0x12, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x5f, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x55, 0x0a, 0x18, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x16, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x53,
+	0x77, 0x61, 0x70, 0x43, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x4e, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x4e, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0xfb, 0x01, 0x0a, 0x11, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x46,
+	0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f,
+	0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x6e, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f,
+	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x24, 0x0a,
+	0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74,
+	0x53, 0x61, 0x74, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12,
+	0x1e, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12,
+	0x30, 0x0a, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77,
+	0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x22, 0x44, 0x0a, 0x14, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x61, 0x6d, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x46,
+	0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x5f,
+	0x61, 0x6d, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70,
+	0x72, 0x65, 0x70, 0x61, 0x79, 0x41, 0x6d, 0x74, 0x53, 0x61, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6d,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x22,
+	0x52, 0x0a, 0x0f, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x4e, 0x73, 0x22, 0x41, 0x0a, 0x10, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x77, 0x61,
+	0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77,
+	0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x22, 0x5e, 0x0a, 0x17, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x13, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x22, 0xfb, 0x01, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x53, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x53, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x61, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x53, 0x61, 0x74, 0x22, 0xf9, 0x02, 0x0a, 0x08, 0x53, 0x77, 0x61, 0x70,
+	0x43, 0x6f, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61,
+	0x6d, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6f,
+	0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65,
+	0x53, 0x61, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f,
+	0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f,
+	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x24, 0x0a,
+	0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74,
+	0x53, 0x61, 0x74, 0x22, 0x92, 0x01, 0x0a, 0x17, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x6d, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x6d, 0x74,
+	0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x5f,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xf6, 0x01, 0x0a, 0x18, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x63, 0x6f,
+	0x73, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x77,
+	0x61, 0x70, 0x43, 0x6f, 0x73, 0x74, 0x53, 0x61, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x43, 0x6f, 0x73, 0x74, 0x53, 0x61, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x72,
+	0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x61, 0x70, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x43, 0x68, 0x65, 0x61, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x3a, 0x0a, 0x14, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
+	0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x4e, 0x0a,
+	0x15, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x19, 0x0a,
+	0x17, 0x4e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x18, 0x4e, 0x65, 0x77, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x63, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x6a, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x64, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x52, 0x08, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x22, 0xdd, 0x01, 0x0a,
+	0x0d, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x2f, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x44, 0x0a, 0x12,
+	0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x54, 0x0a, 0x13, 0x42, 0x61, 0x6b, 0x65, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f,
+	0x6e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x32, 0x0a, 0x14, 0x42, 0x61, 0x6b, 0x65,
+	0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x2a, 0x4a, 0x0a, 0x0c,
+	0x53, 0x77, 0x61, 0x70, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x13, 0x0a, 0x0f,
+	0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f,
+	0x57, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x55, 0x52, 0x47, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x2a, 0x37, 0x0a, 0x0b, 0x48, 0x74, 0x6c, 0x63,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x0f, 0x48, 0x54, 0x4c, 0x43, 0x5f,
+	0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x31, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
+	0x48, 0x54, 0x4c, 0x43, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x32, 0x10,
+	0x01, 0x2a, 0x25, 0x0a, 0x08, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a,
+	0x08, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4c,
+	0x4f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x01, 0x2a, 0x73, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x54,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x45, 0x49, 0x4d, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x45, 0x56, 0x45, 0x41, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x48,
+	0x54, 0x4c, 0x43, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x4f,
+	0x49, 0x43, 0x45, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x8b, 0x02,
+	0x0a, 0x0d, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x13, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x41, 0x49, 0x4c,
+	0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4f, 0x46, 0x46, 0x43, 0x48,
+	0x41, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10,
+	0x02, 0x12, 0x20, 0x0a, 0x1c, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55,
+	0x54, 0x10, 0x03, 0x12, 0x25, 0x0a, 0x21, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52,
+	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45,
+	0x4e, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x45, 0x4d,
+	0x50, 0x4f, 0x52, 0x41, 0x52, 0x59, 0x10, 0x05, 0x12, 0x23, 0x0a, 0x1f, 0x46, 0x41, 0x49, 0x4c,
+	0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x43, 0x4f, 0x52,
+	0x52, 0x45, 0x43, 0x54, 0x5f, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x06, 0x12, 0x1c, 0x0a,
+	0x18, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x41, 0x42, 0x41, 0x4e, 0x44, 0x4f, 0x4e, 0x45, 0x44, 0x10, 0x07, 0x2a, 0xce, 0x02, 0x0a, 0x13,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x4e, 0x4f, 0x5f, 0x48, 0x54, 0x4c, 0x43, 0x10, 0x02, 0x12, 0x2d, 0x0a, 0x29, 0x53, 0x45, 0x52,
+	0x56, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x48, 0x54, 0x4c, 0x43, 0x5f,
+	0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x2a, 0x0a, 0x26, 0x53, 0x45, 0x52, 0x56,
+	0x45, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x4f, 0x46, 0x46, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f,
+	0x55, 0x54, 0x10, 0x04, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x49,
+	0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x05, 0x12, 0x27, 0x0a, 0x23, 0x53, 0x45, 0x52, 0x56, 0x45,
+	0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x06,
+	0x12, 0x2a, 0x0a, 0x26, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55,
+	0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x48, 0x54, 0x4c, 0x43, 0x5f, 0x50,
+	0x55, 0x42, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x07, 0x2a, 0xa6, 0x02, 0x0a,
+	0x11, 0x53, 0x77, 0x61, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55,
+	0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x28, 0x0a, 0x24, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4f, 0x46, 0x46, 0x43, 0x48, 0x41, 0x49, 0x4e,
+	0x5f, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x57,
+	0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x48, 0x54, 0x4c, 0x43, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x02,
+	0x12, 0x2a, 0x0a, 0x26, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x46, 0x45,
+	0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12, 0x27, 0x0a, 0x23,
+	0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43,
+	0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x25, 0x0a, 0x21, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x45,
+	0x50, 0x41, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x27, 0x0a, 0x23,
+	0x53, 0x57, 0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x45, 0x50, 0x41, 0x59, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41,
+	0x53, 0x45, 0x44, 0x10, 0x06, 0x2a, 0x3b, 0x0a, 0x11, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x48, 0x52, 0x45, 0x53,
+	0x48, 0x4f, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
+	0x10, 0x02, 0x2a, 0x45, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x54, 0x41, 0x4c, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x54,
+	0x41, 0x4c, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f,
+	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x01, 0x2a, 0x9a, 0x06, 0x0a, 0x0a, 0x41, 0x75,
+	0x74, 0x6f, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x54, 0x4f,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x22, 0x0a, 0x1e, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x42, 0x55, 0x44, 0x47, 0x45, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x52,
+	0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x46, 0x45, 0x45, 0x53, 0x10,
+	0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x42, 0x55, 0x44, 0x47, 0x45, 0x54, 0x5f, 0x45, 0x4c, 0x41, 0x50, 0x53, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x49, 0x4e, 0x5f, 0x46, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14,
+	0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x57, 0x41, 0x50,
+	0x5f, 0x46, 0x45, 0x45, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52,
+	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x4e, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x45, 0x10,
+	0x06, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x50, 0x52, 0x45, 0x50, 0x41, 0x59, 0x10, 0x07, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x55, 0x54,
+	0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x5f, 0x42, 0x41, 0x43, 0x4b, 0x4f, 0x46, 0x46, 0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55,
+	0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x4f,
+	0x55, 0x54, 0x10, 0x09, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x0a, 0x12, 0x1c, 0x0a,
+	0x18, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x51,
+	0x55, 0x49, 0x44, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x4b, 0x10, 0x0b, 0x12, 0x23, 0x0a, 0x1f, 0x41,
+	0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x55, 0x44, 0x47, 0x45,
+	0x54, 0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x0c,
+	0x12, 0x20, 0x0a, 0x1c, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x46, 0x45, 0x45, 0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54,
+	0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x0e,
+	0x12, 0x21, 0x0a, 0x1d, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x52, 0x45, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x43, 0x48, 0x45, 0x41, 0x50, 0x45,
+	0x52, 0x10, 0x0f, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x10, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x4d, 0x49, 0x4e, 0x5f, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x11, 0x12, 0x1a, 0x0a,
+	0x16, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x12, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54,
+	0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x4f, 0x4c, 0x44, 0x4f, 0x57,
+	0x4e, 0x10, 0x13, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x49, 0x4d, 0x42,
+	0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x14, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x55, 0x54, 0x4f,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x50, 0x55, 0x54, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x15, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41,
+	0x53, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x53, 0x43, 0x48, 0x45,
+	0x44, 0x55, 0x4c, 0x45, 0x10, 0x16, 0x12, 0x27, 0x0a, 0x23, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52,
+	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x5f, 0x57, 0x41,
+	0x4c, 0x4c, 0x45, 0x54, 0x5f, 0x46, 0x52, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x17, 0x12,
+	0x1b, 0x0a, 0x17, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x52,
+	0x55, 0x4c, 0x45, 0x5f, 0x42, 0x55, 0x44, 0x47, 0x45, 0x54, 0x10, 0x18, 0x12, 0x18, 0x0a, 0x14,
+	0x41, 0x55, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x41, 0x43,
+	0x54, 0x49, 0x56, 0x45, 0x10, 0x19, 0x2a, 0xd9, 0x01, 0x0a, 0x11, 0x41, 0x75, 0x74, 0x6f, 0x6c,
+	0x6f, 0x6f, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16,
+	0x41, 0x55, 0x54, 0x4f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x55, 0x54, 0x4f,
+	0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x51, 0x55,
+	0x41, 0x4c, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54,
+	0x4f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55,
+	0x41, 0x4c, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x55, 0x54,
+	0x4f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x45, 0x43,
+	0x4b, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x41, 0x55,
+	0x54, 0x4f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x45, 0x44, 0x10, 0x04, 0x12, 0x22,
+	0x0a, 0x1e, 0x41, 0x55, 0x54, 0x4f, 0x4c, 0x4f, 0x4f, 0x50, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x41, 0x54, 0x43, 0x48, 0x45, 0x44,
+	0x10, 0x05, 0x2a, 0x2b, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x41, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x45,
+	0x45, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x10, 0x02, 0x2a,
+	0x65, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x17,
+	0x53, 0x57, 0x41, 0x50, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x48, 0x54, 0x4c, 0x43, 0x5f,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x57, 0x41,
+	0x50, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f,
+	0x53, 0x45, 0x54, 0x54, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x57, 0x41, 0x50,
+	0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x43, 0x4f, 0x4e,
+	0x46, 0x49, 0x52, 0x4d, 0x10, 0x02, 0x2a, 0x6f, 0x0a, 0x0c, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x45, 0x50, 0x4f, 0x53, 0x49,
+	0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a,
+	0x16, 0x44, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x49, 0x4e,
+	0x49, 0x54, 0x49, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x45, 0x50,
+	0x4f, 0x53, 0x49, 0x54, 0x5f, 0x54, 0x4f, 0x4f, 0x5f, 0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x10, 0x02,
+	0x12, 0x15, 0x0a, 0x11, 0x44, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x5f, 0x54, 0x4f, 0x4f, 0x5f,
+	0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x03, 0x32, 0xac, 0x1b, 0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x07, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75,
+	0x74, 0x12, 0x17, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70,
+	0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x37, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x12, 0x16, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x4e, 0x65,
+	0x77, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x50, 0x73, 0x62, 0x74, 0x12, 0x16, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f, 0x6f, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f,
+	0x6f, 0x70, 0x49, 0x6e, 0x50, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x09, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x39, 0x0a, 0x08, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x48, 0x0a, 0x0b, 0x41, 0x62,
+	0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x12, 0x1b, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53,
+	0x77, 0x65, 0x65, 0x70, 0x12, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x77, 0x65, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x53, 0x77, 0x65, 0x65, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x45, 0x0a, 0x0a, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12,
+	0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x1b, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x12, 0x1b, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10,
+	0x53, 0x61, 0x76, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53,
+	0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x61, 0x76,
+	0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5d, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x40, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x73,
+	0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x4f, 0x75, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x70, 0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6f, 0x70, 0x49,
+	0x6e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x6f,
+	0x6f, 0x70, 0x49, 0x6e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x4c, 0x73, 0x61, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x5d, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x25, 0x2e, 0x6c,
+	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x15, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x25, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65,
+	0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x12, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x54, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x19, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x29, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x69,
+	0x67, 0x67, 0x65, 0x72, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70, 0x45, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x6f, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x19, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x73, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12,
+	0x18, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x6f,
+	0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x41,
+	0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x12, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45,
+	0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1a, 0x2e, 0x6c,
+	0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72,
+	0x70, 0x63, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f,
+	0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x57, 0x0a, 0x10, 0x4e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e,
+	0x4e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73,
+	0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x42, 0x61, 0x6b,
+	0x65, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x6c, 0x6f, 0x6f, 0x70,
+	0x72, 0x70, 0x63, 0x2e, 0x42, 0x61, 0x6b, 0x65, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70,
+	0x63, 0x2e, 0x42, 0x61, 0x6b, 0x65, 0x4d, 0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61,
+	0x62, 0x73, 0x2f, 0x6c, 0x6f, 0x6f, 0x70, 0x2f, 0x6c, 0x6f, 0x6f, 0x70, 0x72, 0x70, 0x63, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_client_proto_rawDescOnce sync.Once
+	file_client_proto_rawDescData = file_client_proto_rawDesc
+)
+
+func file_client_proto_rawDescGZIP() []byte {
+	file_client_proto_rawDescOnce.Do(func() {
+		file_client_proto_rawDescData = protoimpl.X.CompressGZIP(file_client_proto_rawDescData)
+	})
+	return file_client_proto_rawDescData
+}
+
+var file_client_proto_enumTypes = make([]protoimpl.EnumInfo, 14)
+var file_client_proto_msgTypes = make([]protoimpl.MessageInfo, 107)
+var file_client_proto_goTypes = []interface{}{
+	(SwapPriority)(0),                        // 0: looprpc.SwapPriority
+	(HtlcVersion)(0),                         // 1: looprpc.HtlcVersion
+	(SwapType)(0),                            // 2: looprpc.SwapType
+	(SwapState)(0),                           // 3: looprpc.SwapState
+	(FailureReason)(0),                       // 4: looprpc.FailureReason
+	(ServerFailureReason)(0),                 // 5: looprpc.ServerFailureReason
+	(SwapFailureReason)(0),                   // 6: looprpc.SwapFailureReason
+	(LiquidityRuleType)(0),                   // 7: looprpc.LiquidityRuleType
+	(StaleReason)(0),                         // 8: looprpc.StaleReason
+	(AutoReason)(0),                          // 9: looprpc.AutoReason
+	(AutoloopEventType)(0),                   // 10: looprpc.AutoloopEventType
+	(StatsPeriod)(0),                         // 11: looprpc.StatsPeriod
+	(SwapPhase)(0),                           // 12: looprpc.SwapPhase
+	(DepositState)(0),                        // 13: looprpc.DepositState
+	(*LoopOutRequest)(nil),                   // 14: looprpc.LoopOutRequest
+	(*LoopOutSplit)(nil),                     // 15: looprpc.LoopOutSplit
+	(*LoopInRequest)(nil),                    // 16: looprpc.LoopInRequest
+	(*RouteHint)(nil),                        // 17: looprpc.RouteHint
+	(*HopHint)(nil),                          // 18: looprpc.HopHint
+	(*SwapResponse)(nil),                     // 19: looprpc.SwapResponse
+	(*LoopInPsbtResponse)(nil),               // 20: looprpc.LoopInPsbtResponse
+	(*MonitorRequest)(nil),                   // 21: looprpc.MonitorRequest
+	(*SwapStatus)(nil),                       // 22: looprpc.SwapStatus
+	(*RefundStatus)(nil),                     // 23: looprpc.RefundStatus
+	(*ListSwapsRequest)(nil),                 // 24: looprpc.ListSwapsRequest
+	(*ListSwapsResponse)(nil),                // 25: looprpc.ListSwapsResponse
+	(*AbandonSwapRequest)(nil),               // 26: looprpc.AbandonSwapRequest
+	(*AbandonSwapResponse)(nil),              // 27: looprpc.AbandonSwapResponse
+	(*RecoverSweepRequest)(nil),              // 28: looprpc.RecoverSweepRequest
+	(*RecoverSweepResponse)(nil),             // 29: looprpc.RecoverSweepResponse
+	(*PruneSwapsRequest)(nil),                // 30: looprpc.PruneSwapsRequest
+	(*PruneSwapsResponse)(nil),               // 31: looprpc.PruneSwapsResponse
+	(*ExportSwapsRequest)(nil),               // 32: looprpc.ExportSwapsRequest
+	(*ExportSwapsResponse)(nil),              // 33: looprpc.ExportSwapsResponse
+	(*ImportSwapsRequest)(nil),               // 34: looprpc.ImportSwapsRequest
+	(*ImportSwapsResponse)(nil),              // 35: looprpc.ImportSwapsResponse
+	(*SwapTemplate)(nil),                     // 36: looprpc.SwapTemplate
+	(*SaveSwapTemplateRequest)(nil),          // 37: looprpc.SaveSwapTemplateRequest
+	(*SaveSwapTemplateResponse)(nil),         // 38: looprpc.SaveSwapTemplateResponse
+	(*ListSwapTemplatesRequest)(nil),         // 39: looprpc.ListSwapTemplatesRequest
+	(*ListSwapTemplatesResponse)(nil),        // 40: looprpc.ListSwapTemplatesResponse
+	(*DeleteSwapTemplateRequest)(nil),        // 41: looprpc.DeleteSwapTemplateRequest
+	(*DeleteSwapTemplateResponse)(nil),       // 42: looprpc.DeleteSwapTemplateResponse
+	(*SwapInfoRequest)(nil),                  // 43: looprpc.SwapInfoRequest
+	(*TermsRequest)(nil),                     // 44: looprpc.TermsRequest
+	(*InTermsResponse)(nil),                  // 45: looprpc.InTermsResponse
+	(*OutTermsResponse)(nil),                 // 46: looprpc.OutTermsResponse
+	(*QuoteRequest)(nil),                     // 47: looprpc.QuoteRequest
+	(*InQuoteResponse)(nil),                  // 48: looprpc.InQuoteResponse
+	(*OutQuoteResponse)(nil),                 // 49: looprpc.OutQuoteResponse
+	(*TokensRequest)(nil),                    // 50: looprpc.TokensRequest
+	(*TokensResponse)(nil),                   // 51: looprpc.TokensResponse
+	(*LsatToken)(nil),                        // 52: looprpc.LsatToken
+	(*GetLiquidityParamsRequest)(nil),        // 53: looprpc.GetLiquidityParamsRequest
+	(*LiquidityParameters)(nil),              // 54: looprpc.LiquidityParameters
+	(*LiquidityRule)(nil),                    // 55: looprpc.LiquidityRule
+	(*SetLiquidityParamsRequest)(nil),        // 56: looprpc.SetLiquidityParamsRequest
+	(*SetLiquidityParamsResponse)(nil),       // 57: looprpc.SetLiquidityParamsResponse
+	(*UpdateLiquidityParamsRequest)(nil),     // 58: looprpc.UpdateLiquidityParamsRequest
+	(*UpdateLiquidityParamsResponse)(nil),    // 59: looprpc.UpdateLiquidityParamsResponse
+	(*CancelLiquidityParamsRequest)(nil),     // 60: looprpc.CancelLiquidityParamsRequest
+	(*CancelLiquidityParamsResponse)(nil),    // 61: looprpc.CancelLiquidityParamsResponse
+	(*GetPeerReputationsRequest)(nil),        // 62: looprpc.GetPeerReputationsRequest
+	(*GetPeerReputationsResponse)(nil),       // 63: looprpc.GetPeerReputationsResponse
+	(*PeerReputation)(nil),                   // 64: looprpc.PeerReputation
+	(*GetStaleRulesRequest)(nil),             // 65: looprpc.GetStaleRulesRequest
+	(*GetStaleRulesResponse)(nil),            // 66: looprpc.GetStaleRulesResponse
+	(*StaleRule)(nil),                        // 67: looprpc.StaleRule
+	(*SuggestSwapsRequest)(nil),              // 68: looprpc.SuggestSwapsRequest
+	(*Disqualified)(nil),                     // 69: looprpc.Disqualified
+	(*SuggestSwapsResponse)(nil),             // 70: looprpc.SuggestSwapsResponse
+	(*AutoloopStatusRequest)(nil),            // 71: looprpc.AutoloopStatusRequest
+	(*TriggerAutoloopEvaluationRequest)(nil), // 72: looprpc.TriggerAutoloopEvaluationRequest
+	(*AutoloopStatusResponse)(nil),           // 73: looprpc.AutoloopStatusResponse
+	(*AutoloopBudget)(nil),                   // 74: looprpc.AutoloopBudget
+	(*BudgetReconciliationRequest)(nil),      // 75: looprpc.BudgetReconciliationRequest
+	(*BudgetReconciliationResponse)(nil),     // 76: looprpc.BudgetReconciliationResponse
+	(*ReconciledSwap)(nil),                   // 77: looprpc.ReconciledSwap
+	(*AutoloopEvent)(nil),                    // 78: looprpc.AutoloopEvent
+	(*SwapStatsRequest)(nil),                 // 79: looprpc.SwapStatsRequest
+	(*SwapStatsResponse)(nil),                // 80: looprpc.SwapStatsResponse
+	(*SwapStats)(nil),                        // 81: looprpc.SwapStats
+	(*PhaseStats)(nil),                       // 82: looprpc.PhaseStats
+	(*LatencyBucket)(nil),                    // 83: looprpc.LatencyBucket
+	(*ServerHealthRequest)(nil),              // 84: looprpc.ServerHealthRequest
+	(*ServerHealthResponse)(nil),             // 85: looprpc.ServerHealthResponse
+	(*GetConfigRequest)(nil),                 // 86: looprpc.GetConfigRequest
+	(*GetConfigResponse)(nil),                // 87: looprpc.GetConfigResponse
+	(*GetApiVersionRequest)(nil),             // 88: looprpc.GetApiVersionRequest
+	(*GetApiVersionResponse)(nil),            // 89: looprpc.GetApiVersionResponse
+	(*Deprecation)(nil),                      // 90: looprpc.Deprecation
+	(*DebugLevelRequest)(nil),                // 91: looprpc.DebugLevelRequest
+	(*DebugLevelResponse)(nil),               // 92: looprpc.DebugLevelResponse
+	(*TestNotificationRequest)(nil),          // 93: looprpc.TestNotificationRequest
+	(*TestNotificationResponse)(nil),         // 94: looprpc.TestNotificationResponse
+	(*NotificationSinkResult)(nil),           // 95: looprpc.NotificationSinkResult
+	(*SwapCostsRequest)(nil),                 // 96: looprpc.SwapCostsRequest
+	(*SwapCostsResponse)(nil),                // 97: looprpc.SwapCostsResponse
+	(*QuoteHistoryRequest)(nil),              // 98: looprpc.QuoteHistoryRequest
+	(*QuoteHistoryResponse)(nil),             // 99: looprpc.QuoteHistoryResponse
+	(*QuoteRecord)(nil),                      // 100: looprpc.QuoteRecord
+	(*AuditLogRequest)(nil),                  // 101: looprpc.AuditLogRequest
+	(*AuditLogResponse)(nil),                 // 102: looprpc.AuditLogResponse
+	(*AuditEntry)(nil),                       // 103: looprpc.AuditEntry
+	(*LiquidityHistoryRequest)(nil),          // 104: looprpc.LiquidityHistoryRequest
+	(*LiquidityHistoryResponse)(nil),         // 105: looprpc.LiquidityHistoryResponse
+	(*ChannelLiquidityHistory)(nil),          // 106: looprpc.ChannelLiquidityHistory
+	(*LiquiditySnapshot)(nil),                // 107: looprpc.LiquiditySnapshot
+	(*SwapCost)(nil),                         // 108: looprpc.SwapCost
+	(*CompareRebalanceRequest)(nil),          // 109: looprpc.CompareRebalanceRequest
+	(*CompareRebalanceResponse)(nil),         // 110: looprpc.CompareRebalanceResponse
+	(*SupportBundleRequest)(nil),             // 111: looprpc.SupportBundleRequest
+	(*SupportBundleResponse)(nil),            // 112: looprpc.SupportBundleResponse
+	(*NewStaticAddressRequest)(nil),          // 113: looprpc.NewStaticAddressRequest
+	(*NewStaticAddressResponse)(nil),         // 114: looprpc.NewStaticAddressResponse
+	(*ListStaticDepositsRequest)(nil),        // 115: looprpc.ListStaticDepositsRequest
+	(*ListStaticDepositsResponse)(nil),       // 116: looprpc.ListStaticDepositsResponse
+	(*StaticDeposit)(nil),                    // 117: looprpc.StaticDeposit
+	(*MacaroonPermission)(nil),               // 118: looprpc.MacaroonPermission
+	(*BakeMacaroonRequest)(nil),              // 119: looprpc.BakeMacaroonRequest
+	(*BakeMacaroonResponse)(nil),             // 120: looprpc.BakeMacaroonResponse
+}
+var file_client_proto_depIdxs = []int32{
+	15,  // 0: looprpc.LoopOutRequest.splits:type_name -> looprpc.LoopOutSplit
+	0,   // 1: looprpc.LoopOutRequest.priority:type_name -> looprpc.SwapPriority
+	17,  // 2: looprpc.LoopInRequest.route_hints:type_name -> looprpc.RouteHint
+	18,  // 3: looprpc.RouteHint.hop_hints:type_name -> looprpc.HopHint
+	19,  // 4: looprpc.SwapResponse.split_swaps:type_name -> looprpc.SwapResponse
+	2,   // 5: looprpc.SwapStatus.type:type_name -> looprpc.SwapType
+	3,   // 6: looprpc.SwapStatus.state:type_name -> looprpc.SwapState
+	4,   // 7: looprpc.SwapStatus.failure_reason:type_name -> looprpc.FailureReason
+	5,   // 8: looprpc.SwapStatus.server_failure_reason:type_name -> looprpc.ServerFailureReason
+	6,   // 9: looprpc.SwapStatus.swap_failure_reason:type_name -> looprpc.SwapFailureReason
+	23,  // 10: looprpc.SwapStatus.refund:type_name -> looprpc.RefundStatus
+	4,   // 11: looprpc.SwapStatus.possible_failures:type_name -> looprpc.FailureReason
+	1,   // 12: looprpc.SwapStatus.htlc_version:type_name -> looprpc.HtlcVersion
+	2,   // 13: looprpc.ListSwapsRequest.swap_types:type_name -> looprpc.SwapType
+	3,   // 14: looprpc.ListSwapsRequest.states:type_name -> looprpc.SwapState
+	22,  // 15: looprpc.ListSwapsResponse.swaps:type_name -> looprpc.SwapStatus
+	36,  // 16: looprpc.SaveSwapTemplateRequest.template:type_name -> looprpc.SwapTemplate
+	36,  // 17: looprpc.ListSwapTemplatesResponse.templates:type_name -> looprpc.SwapTemplate
+	52,  // 18: looprpc.TokensResponse.tokens:type_name -> looprpc.LsatToken
+	55,  // 19: looprpc.LiquidityParameters.rules:type_name -> looprpc.LiquidityRule
+	54,  // 20: looprpc.LiquidityParameters.pending_params:type_name -> looprpc.LiquidityParameters
+	7,   // 21: looprpc.LiquidityRule.type:type_name -> looprpc.LiquidityRuleType
+	2,   // 22: looprpc.LiquidityRule.swap_type:type_name -> looprpc.SwapType
+	54,  // 23: looprpc.SetLiquidityParamsRequest.parameters:type_name -> looprpc.LiquidityParameters
+	54,  // 24: looprpc.UpdateLiquidityParamsRequest.parameters:type_name -> looprpc.LiquidityParameters
+	64,  // 25: looprpc.GetPeerReputationsResponse.reputations:type_name -> looprpc.PeerReputation
+	67,  // 26: looprpc.GetStaleRulesResponse.rules:type_name -> looprpc.StaleRule
+	8,   // 27: looprpc.StaleRule.reason:type_name -> looprpc.StaleReason
+	9,   // 28: looprpc.Disqualified.reason:type_name -> looprpc.AutoReason
+	14,  // 29: looprpc.SuggestSwapsResponse.loop_out:type_name -> looprpc.LoopOutRequest
+	69,  // 30: looprpc.SuggestSwapsResponse.disqualified:type_name -> looprpc.Disqualified
+	16,  // 31: looprpc.SuggestSwapsResponse.loop_in:type_name -> looprpc.LoopInRequest
+	69,  // 32: looprpc.AutoloopStatusResponse.disqualified:type_name -> looprpc.Disqualified
+	74,  // 33: looprpc.AutoloopStatusResponse.budget:type_name -> looprpc.AutoloopBudget
+	78,  // 34: looprpc.AutoloopStatusResponse.events:type_name -> looprpc.AutoloopEvent
+	77,  // 35: looprpc.BudgetReconciliationResponse.swaps:type_name -> looprpc.ReconciledSwap
+	2,   // 36: looprpc.ReconciledSwap.type:type_name -> looprpc.SwapType
+	10,  // 37: looprpc.AutoloopEvent.type:type_name -> looprpc.AutoloopEventType
+	9,   // 38: looprpc.AutoloopEvent.reason:type_name -> looprpc.AutoReason
+	2,   // 39: looprpc.AutoloopEvent.swap_type:type_name -> looprpc.SwapType
+	11,  // 40: looprpc.SwapStatsRequest.period:type_name -> looprpc.StatsPeriod
+	2,   // 41: looprpc.SwapStatsRequest.swap_types:type_name -> looprpc.SwapType
+	81,  // 42: looprpc.SwapStatsResponse.stats:type_name -> looprpc.SwapStats
+	82,  // 43: looprpc.SwapStats.phase_stats:type_name -> looprpc.PhaseStats
+	12,  // 44: looprpc.PhaseStats.phase:type_name -> looprpc.SwapPhase
+	83,  // 45: looprpc.PhaseStats.buckets:type_name -> looprpc.LatencyBucket
+	90,  // 46: looprpc.GetApiVersionResponse.deprecations:type_name -> looprpc.Deprecation
+	95,  // 47: looprpc.TestNotificationResponse.results:type_name -> looprpc.NotificationSinkResult
+	2,   // 48: looprpc.SwapCostsRequest.swap_types:type_name -> looprpc.SwapType
+	108, // 49: looprpc.SwapCostsResponse.swaps:type_name -> looprpc.SwapCost
+	2,   // 50: looprpc.QuoteHistoryRequest.swap_types:type_name -> looprpc.SwapType
+	100, // 51: looprpc.QuoteHistoryResponse.quotes:type_name -> looprpc.QuoteRecord
+	2,   // 52: looprpc.QuoteRecord.type:type_name -> looprpc.SwapType
+	103, // 53: looprpc.AuditLogResponse.entries:type_name -> looprpc.AuditEntry
+	2,   // 54: looprpc.AuditEntry.type:type_name -> looprpc.SwapType
+	106, // 55: looprpc.LiquidityHistoryResponse.channels:type_name -> looprpc.ChannelLiquidityHistory
+	107, // 56: looprpc.ChannelLiquidityHistory.snapshots:type_name -> looprpc.LiquiditySnapshot
+	2,   // 57: looprpc.SwapCost.type:type_name -> looprpc.SwapType
+	2,   // 58: looprpc.CompareRebalanceRequest.type:type_name -> looprpc.SwapType
+	117, // 59: looprpc.ListStaticDepositsResponse.deposits:type_name -> looprpc.StaticDeposit
+	13,  // 60: looprpc.StaticDeposit.state:type_name -> looprpc.DepositState
+	118, // 61: looprpc.BakeMacaroonRequest.permissions:type_name -> looprpc.MacaroonPermission
+	14,  // 62: looprpc.SwapClient.LoopOut:input_type -> looprpc.LoopOutRequest
+	16,  // 63: looprpc.SwapClient.LoopIn:input_type -> looprpc.LoopInRequest
+	16,  // 64: looprpc.SwapClient.NewLoopInPsbt:input_type -> looprpc.LoopInRequest
+	21,  // 65: looprpc.SwapClient.Monitor:input_type -> looprpc.MonitorRequest
+	24,  // 66: looprpc.SwapClient.ListSwaps:input_type -> looprpc.ListSwapsRequest
+	43,  // 67: looprpc.SwapClient.SwapInfo:input_type -> looprpc.SwapInfoRequest
+	26,  // 68: looprpc.SwapClient.AbandonSwap:input_type -> looprpc.AbandonSwapRequest
+	28,  // 69: looprpc.SwapClient.RecoverSweep:input_type -> looprpc.RecoverSweepRequest
+	30,  // 70: looprpc.SwapClient.PruneSwaps:input_type -> looprpc.PruneSwapsRequest
+	32,  // 71: looprpc.SwapClient.ExportSwaps:input_type -> looprpc.ExportSwapsRequest
+	34,  // 72: looprpc.SwapClient.ImportSwaps:input_type -> looprpc.ImportSwapsRequest
+	37,  // 73: looprpc.SwapClient.SaveSwapTemplate:input_type -> looprpc.SaveSwapTemplateRequest
+	39,  // 74: looprpc.SwapClient.ListSwapTemplates:input_type -> looprpc.ListSwapTemplatesRequest
+	41,  // 75: looprpc.SwapClient.DeleteSwapTemplate:input_type -> looprpc.DeleteSwapTemplateRequest
+	44,  // 76: looprpc.SwapClient.LoopOutTerms:input_type -> looprpc.TermsRequest
+	47,  // 77: looprpc.SwapClient.LoopOutQuote:input_type -> looprpc.QuoteRequest
+	44,  // 78: looprpc.SwapClient.GetLoopInTerms:input_type -> looprpc.TermsRequest
+	47,  // 79: looprpc.SwapClient.GetLoopInQuote:input_type -> looprpc.QuoteRequest
+	50,  // 80: looprpc.SwapClient.GetLsatTokens:input_type -> looprpc.TokensRequest
+	53,  // 81: looprpc.SwapClient.GetLiquidityParams:input_type -> looprpc.GetLiquidityParamsRequest
+	56,  // 82: looprpc.SwapClient.SetLiquidityParams:input_type -> looprpc.SetLiquidityParamsRequest
+	60,  // 83: looprpc.SwapClient.CancelLiquidityParams:input_type -> looprpc.CancelLiquidityParamsRequest
+	58,  // 84: looprpc.SwapClient.UpdateLiquidityParams:input_type -> looprpc.UpdateLiquidityParamsRequest
+	62,  // 85: looprpc.SwapClient.GetPeerReputations:input_type -> looprpc.GetPeerReputationsRequest
+	65,  // 86: looprpc.SwapClient.GetStaleRules:input_type -> looprpc.GetStaleRulesRequest
+	68,  // 87: looprpc.SwapClient.SuggestSwaps:input_type -> looprpc.SuggestSwapsRequest
+	71,  // 88: looprpc.SwapClient.GetAutoloopStatus:input_type -> looprpc.AutoloopStatusRequest
+	72,  // 89: looprpc.SwapClient.TriggerAutoloopEvaluation:input_type -> looprpc.TriggerAutoloopEvaluationRequest
+	75,  // 90: looprpc.SwapClient.GetBudgetReconciliation:input_type -> looprpc.BudgetReconciliationRequest
+	79,  // 91: looprpc.SwapClient.GetSwapStats:input_type -> looprpc.SwapStatsRequest
+	96,  // 92: looprpc.SwapClient.GetSwapCosts:input_type -> looprpc.SwapCostsRequest
+	98,  // 93: looprpc.SwapClient.GetQuoteHistory:input_type -> looprpc.QuoteHistoryRequest
+	104, // 94: looprpc.SwapClient.GetLiquidityHistory:input_type -> looprpc.LiquidityHistoryRequest
+	101, // 95: looprpc.SwapClient.GetAuditLog:input_type -> looprpc.AuditLogRequest
+	109, // 96: looprpc.SwapClient.CompareRebalance:input_type -> looprpc.CompareRebalanceRequest
+	86,  // 97: looprpc.SwapClient.GetConfig:input_type -> looprpc.GetConfigRequest
+	88,  // 98: looprpc.SwapClient.GetApiVersion:input_type -> looprpc.GetApiVersionRequest
+	84,  // 99: looprpc.SwapClient.ServerHealth:input_type -> looprpc.ServerHealthRequest
+	91,  // 100: looprpc.SwapClient.DebugLevel:input_type -> looprpc.DebugLevelRequest
+	93,  // 101: looprpc.SwapClient.TestNotification:input_type -> looprpc.TestNotificationRequest
+	111, // 102: looprpc.SwapClient.GetSupportBundle:input_type -> looprpc.SupportBundleRequest
+	113, // 103: looprpc.SwapClient.NewStaticAddress:input_type -> looprpc.NewStaticAddressRequest
+	115, // 104: looprpc.SwapClient.ListStaticDeposits:input_type -> looprpc.ListStaticDepositsRequest
+	119, // 105: looprpc.SwapClient.BakeMacaroon:input_type -> looprpc.BakeMacaroonRequest
+	19,  // 106: looprpc.SwapClient.LoopOut:output_type -> looprpc.SwapResponse
+	19,  // 107: looprpc.SwapClient.LoopIn:output_type -> looprpc.SwapResponse
+	20,  // 108: looprpc.SwapClient.NewLoopInPsbt:output_type -> looprpc.LoopInPsbtResponse
+	22,  // 109: looprpc.SwapClient.Monitor:output_type -> looprpc.SwapStatus
+	25,  // 110: looprpc.SwapClient.ListSwaps:output_type -> looprpc.ListSwapsResponse
+	22,  // 111: looprpc.SwapClient.SwapInfo:output_type -> looprpc.SwapStatus
+	27,  // 112: looprpc.SwapClient.AbandonSwap:output_type -> looprpc.AbandonSwapResponse
+	29,  // 113: looprpc.SwapClient.RecoverSweep:output_type -> looprpc.RecoverSweepResponse
+	31,  // 114: looprpc.SwapClient.PruneSwaps:output_type -> looprpc.PruneSwapsResponse
+	33,  // 115: looprpc.SwapClient.ExportSwaps:output_type -> looprpc.ExportSwapsResponse
+	35,  // 116: looprpc.SwapClient.ImportSwaps:output_type -> looprpc.ImportSwapsResponse
+	38,  // 117: looprpc.SwapClient.SaveSwapTemplate:output_type -> looprpc.SaveSwapTemplateResponse
+	40,  // 118: looprpc.SwapClient.ListSwapTemplates:output_type -> looprpc.ListSwapTemplatesResponse
+	42,  // 119: looprpc.SwapClient.DeleteSwapTemplate:output_type -> looprpc.DeleteSwapTemplateResponse
+	46,  // 120: looprpc.SwapClient.LoopOutTerms:output_type -> looprpc.OutTermsResponse
+	49,  // 121: looprpc.SwapClient.LoopOutQuote:output_type -> looprpc.OutQuoteResponse
+	45,  // 122: looprpc.SwapClient.GetLoopInTerms:output_type -> looprpc.InTermsResponse
+	48,  // 123: looprpc.SwapClient.GetLoopInQuote:output_type -> looprpc.InQuoteResponse
+	51,  // 124: looprpc.SwapClient.GetLsatTokens:output_type -> looprpc.TokensResponse
+	54,  // 125: looprpc.SwapClient.GetLiquidityParams:output_type -> looprpc.LiquidityParameters
+	57,  // 126: looprpc.SwapClient.SetLiquidityParams:output_type -> looprpc.SetLiquidityParamsResponse
+	61,  // 127: looprpc.SwapClient.CancelLiquidityParams:output_type -> looprpc.CancelLiquidityParamsResponse
+	59,  // 128: looprpc.SwapClient.UpdateLiquidityParams:output_type -> looprpc.UpdateLiquidityParamsResponse
+	63,  // 129: looprpc.SwapClient.GetPeerReputations:output_type -> looprpc.GetPeerReputationsResponse
+	66,  // 130: looprpc.SwapClient.GetStaleRules:output_type -> looprpc.GetStaleRulesResponse
+	70,  // 131: looprpc.SwapClient.SuggestSwaps:output_type -> looprpc.SuggestSwapsResponse
+	73,  // 132: looprpc.SwapClient.GetAutoloopStatus:output_type -> looprpc.AutoloopStatusResponse
+	73,  // 133: looprpc.SwapClient.TriggerAutoloopEvaluation:output_type -> looprpc.AutoloopStatusResponse
+	76,  // 134: looprpc.SwapClient.GetBudgetReconciliation:output_type -> looprpc.BudgetReconciliationResponse
+	80,  // 135: looprpc.SwapClient.GetSwapStats:output_type -> looprpc.SwapStatsResponse
+	97,  // 136: looprpc.SwapClient.GetSwapCosts:output_type -> looprpc.SwapCostsResponse
+	99,  // 137: looprpc.SwapClient.GetQuoteHistory:output_type -> looprpc.QuoteHistoryResponse
+	105, // 138: looprpc.SwapClient.GetLiquidityHistory:output_type -> looprpc.LiquidityHistoryResponse
+	102, // 139: looprpc.SwapClient.GetAuditLog:output_type -> looprpc.AuditLogResponse
+	110, // 140: looprpc.SwapClient.CompareRebalance:output_type -> looprpc.CompareRebalanceResponse
+	87,  // 141: looprpc.SwapClient.GetConfig:output_type -> looprpc.GetConfigResponse
+	89,  // 142: looprpc.SwapClient.GetApiVersion:output_type -> looprpc.GetApiVersionResponse
+	85,  // 143: looprpc.SwapClient.ServerHealth:output_type -> looprpc.ServerHealthResponse
+	92,  // 144: looprpc.SwapClient.DebugLevel:output_type -> looprpc.DebugLevelResponse
+	94,  // 145: looprpc.SwapClient.TestNotification:output_type -> looprpc.TestNotificationResponse
+	112, // 146: looprpc.SwapClient.GetSupportBundle:output_type -> looprpc.SupportBundleResponse
+	114, // 147: looprpc.SwapClient.NewStaticAddress:output_type -> looprpc.NewStaticAddressResponse
+	116, // 148: looprpc.SwapClient.ListStaticDeposits:output_type -> looprpc.ListStaticDepositsResponse
+	120, // 149: looprpc.SwapClient.BakeMacaroon:output_type -> looprpc.BakeMacaroonResponse
+	106, // [106:150] is the sub-list for method output_type
+	62,  // [62:106] is the sub-list for method input_type
+	62,  // [62:62] is the sub-list for extension type_name
+	62,  // [62:62] is the sub-list for extension extendee
+	0,   // [0:62] is the sub-list for field type_name
+}
+
+func init() { file_client_proto_init() }
+func file_client_proto_init() {
+	if File_client_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_client_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoopOutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoopOutSplit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoopInRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteHint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HopHint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoopInPsbtResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefundStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbandonSwapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbandonSwapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecoverSweepRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecoverSweepResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneSwapsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneSwapsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSwapsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSwapsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportSwapsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportSwapsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapTemplate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSwapTemplateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSwapTemplateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapTemplatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapTemplatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSwapTemplateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSwapTemplateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TermsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InTermsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutTermsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InQuoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutQuoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LsatToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLiquidityParamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquidityParameters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquidityRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLiquidityParamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLiquidityParamsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLiquidityParamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLiquidityParamsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelLiquidityParamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelLiquidityParamsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeerReputationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeerReputationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerReputation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStaleRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStaleRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StaleRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestSwapsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Disqualified); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestSwapsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoloopStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TriggerAutoloopEvaluationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoloopStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoloopBudget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BudgetReconciliationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BudgetReconciliationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconciledSwap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoloopEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhaseStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetApiVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetApiVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Deprecation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugLevelResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestNotificationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestNotificationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationSinkResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapCostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapCostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquidityHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquidityHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelLiquidityHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquiditySnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapCost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareRebalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareRebalanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SupportBundleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SupportBundleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewStaticAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewStaticAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStaticDepositsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStaticDepositsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StaticDeposit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MacaroonPermission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BakeMacaroonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_client_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BakeMacaroonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_client_proto_rawDesc,
+			NumEnums:      14,
+			NumMessages:   107,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_client_proto_goTypes,
+		DependencyIndexes: file_client_proto_depIdxs,
+		EnumInfos:         file_client_proto_enumTypes,
+		MessageInfos:      file_client_proto_msgTypes,
+	}.Build()
+	File_client_proto = out.File
+	file_client_proto_rawDesc = nil
+	file_client_proto_goTypes = nil
+	file_client_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SwapClientClient is the client API for SwapClient service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SwapClientClient interface {
+	// loop: `out`
+	//LoopOut initiates an loop out swap with the given parameters. The call
+	//returns after the swap has been set up with the swap server. From that
+	//point onwards, progress can be tracked via the SwapStatus stream that is
+	//returned from Monitor().
+	LoopOut(ctx context.Context, in *LoopOutRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	// loop: `in`
+	//LoopIn initiates a loop in swap with the given parameters. The call
+	//returns after the swap has been set up with the swap server. From that
+	//point onwards, progress can be tracked via the SwapStatus stream
+	//that is returned from Monitor().
+	LoopIn(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	// loop: `in --psbt`
+	//NewLoopInPsbt initiates a loop in swap that is funded by an external
+	//wallet. The external_htlc field of the request is implied. The call returns
+	//an unsigned funding psbt that pays the swap amount to the htlc, which the
+	//external wallet should fund, sign and broadcast. The swap then proceeds
+	//once the funding transaction confirms, and can be tracked via the
+	//SwapStatus stream that is returned from Monitor().
+	NewLoopInPsbt(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*LoopInPsbtResponse, error)
+	// loop: `monitor`
+	//Monitor will return a stream of swap updates for currently active swaps.
+	//The stream first delivers a snapshot of all currently pending swaps,
+	//followed by deltas that are assigned monotonically increasing sequence
+	//numbers. A reconnecting client can set start_epoch and start_sequence to
+	//the epoch and last sequence number it received to resume the stream
+	//without missing any transitions.
+	Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (SwapClient_MonitorClient, error)
+	// loop: `listswaps`
+	//ListSwaps returns a list of all currently known swaps and their current
+	//status.
+	ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error)
+	// loop: `swapinfo`
+	//SwapInfo returns all known details about a single swap.
+	SwapInfo(ctx context.Context, in *SwapInfoRequest, opts ...grpc.CallOption) (*SwapStatus, error)
+	// loop: `abandonswap`
+	//AbandonSwap marks a pending swap that is permanently stuck as failed and
+	//stops executing it. This should only be used as a last resort, because
+	//loopd will no longer sweep any on-chain htlc for the swap, so funds that
+	//are locked in it need to be recovered manually.
+	AbandonSwap(ctx context.Context, in *AbandonSwapRequest, opts ...grpc.CallOption) (*AbandonSwapResponse, error)
+	// loop: `recover`
+	//RecoverSweep manually sweeps the on-chain htlc of a swap using the swap
+	//data in loopd's database, bypassing the swap's state machine. Loop out
+	//htlcs are swept with the swap preimage, loop in htlcs are reclaimed through
+	//the timeout path once they have expired. This is intended for recovery
+	//when the automatic sweep fails, for example due to corrupted swap state.
+	RecoverSweep(ctx context.Context, in *RecoverSweepRequest, opts ...grpc.CallOption) (*RecoverSweepResponse, error)
+	// loop: `pruneswaps`
+	//PruneSwaps deletes all swaps that reached a final state more than the
+	//retention period ago from loopd's database. This reduces the size of the
+	//database for long-running nodes, but pruned swaps can no longer be listed,
+	//and funds that are left in their htlcs can no longer be recovered. Use
+	//loopd view --prune while loopd is not running to also compact the database
+	//file.
+	PruneSwaps(ctx context.Context, in *PruneSwapsRequest, opts ...grpc.CallOption) (*PruneSwapsResponse, error)
+	// loop: `db export`
+	//ExportSwaps exports all swaps in loopd's database, including their updates,
+	//to a versioned JSON format that can be imported by another loopd instance
+	//with ImportSwaps. The export contains swap preimages, so it must be stored
+	//securely.
+	ExportSwaps(ctx context.Context, in *ExportSwapsRequest, opts ...grpc.CallOption) (*ExportSwapsResponse, error)
+	// loop: `db import`
+	//ImportSwaps adds the swaps in an export created by ExportSwaps to loopd's
+	//database. The export's checksum, version and network are verified before any
+	//swaps are imported, and swaps that already exist are skipped. Pending swaps
+	//that are imported are only resumed once loopd is restarted.
+	ImportSwaps(ctx context.Context, in *ImportSwapsRequest, opts ...grpc.CallOption) (*ImportSwapsResponse, error)
+	// loop: `templates save`
+	//SaveSwapTemplate stores a named loop out template in loopd's database,
+	//replacing any existing template with the same name. Templates can be
+	//dispatched with loop out --template.
+	SaveSwapTemplate(ctx context.Context, in *SaveSwapTemplateRequest, opts ...grpc.CallOption) (*SaveSwapTemplateResponse, error)
+	// loop: `templates list`
+	//ListSwapTemplates returns all loop out templates, ordered by name.
+	ListSwapTemplates(ctx context.Context, in *ListSwapTemplatesRequest, opts ...grpc.CallOption) (*ListSwapTemplatesResponse, error)
+	// loop: `templates delete`
+	//DeleteSwapTemplate deletes a loop out template.
+	DeleteSwapTemplate(ctx context.Context, in *DeleteSwapTemplateRequest, opts ...grpc.CallOption) (*DeleteSwapTemplateResponse, error)
+	// loop: `terms`
+	//LoopOutTerms returns the terms that the server enforces for a loop out swap.
+	LoopOutTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*OutTermsResponse, error)
+	// loop: `quote`
+	//LoopOutQuote returns a quote for a loop out swap with the provided
+	//parameters.
+	LoopOutQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*OutQuoteResponse, error)
+	// loop: `terms`
+	//GetTerms returns the terms that the server enforces for swaps.
+	GetLoopInTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*InTermsResponse, error)
+	// loop: `quote`
+	//GetQuote returns a quote for a swap with the provided parameters.
+	GetLoopInQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*InQuoteResponse, error)
+	// loop: `listauth`
+	//GetLsatTokens returns all LSAT tokens the daemon ever paid for.
+	GetLsatTokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	// loop: `getparams`
+	//GetLiquidityParams gets the parameters that the daemon's liquidity manager
+	//is currently configured with. This may be nil if nothing is configured.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetLiquidityParams(ctx context.Context, in *GetLiquidityParamsRequest, opts ...grpc.CallOption) (*LiquidityParameters, error)
+	// loop: `setparams`
+	//SetLiquidityParams sets a new set of parameters for the daemon's liquidity
+	//manager. Note that the full set of parameters must be provided, because
+	//this call fully overwrites our existing parameters. If the daemon has a
+	//change delay configured, parameters that increase the fees autoloop may
+	//spend only take effect once the delay has passed.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	SetLiquidityParams(ctx context.Context, in *SetLiquidityParamsRequest, opts ...grpc.CallOption) (*SetLiquidityParamsResponse, error)
+	// loop: `cancelparams`
+	//CancelLiquidityParams cancels a pending parameter change that is waiting
+	//for the daemon's change delay to pass, leaving the current parameters
+	//unchanged.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	CancelLiquidityParams(ctx context.Context, in *CancelLiquidityParamsRequest, opts ...grpc.CallOption) (*CancelLiquidityParamsResponse, error)
+	// loop: `setparams`
+	//UpdateLiquidityParams atomically updates the listed fields of the daemon's
+	//liquidity manager parameters, leaving all other parameters unchanged. If a
+	//version obtained from GetLiquidityParams is provided, the update fails if
+	//the parameters have been changed since. Like SetLiquidityParams, parameters
+	//that increase the fees autoloop may spend are subject to the daemon's
+	//change delay.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	UpdateLiquidityParams(ctx context.Context, in *UpdateLiquidityParamsRequest, opts ...grpc.CallOption) (*UpdateLiquidityParamsResponse, error)
+	// loop: `reputation`
+	//GetPeerReputations returns the reputations of the peers that the daemon
+	//has recorded swap outcomes for, based on the swaps that were routed
+	//through them.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetPeerReputations(ctx context.Context, in *GetPeerReputationsRequest, opts ...grpc.CallOption) (*GetPeerReputationsResponse, error)
+	// loop: `stalerules`
+	//GetStaleRules returns the autoloop rules that are set for channels or
+	//peers that have been closed or disconnected for at least the daemon's
+	//stale rule age.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetStaleRules(ctx context.Context, in *GetStaleRulesRequest, opts ...grpc.CallOption) (*GetStaleRulesResponse, error)
+	// loop: `suggestswaps`
+	//SuggestSwaps returns a list of recommended swaps based on the current
+	//state of your node's channels and it's liquidity manager parameters.
+	//Note that only loop out suggestions are currently supported.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	SuggestSwaps(ctx context.Context, in *SuggestSwapsRequest, opts ...grpc.CallOption) (*SuggestSwapsResponse, error)
+	// loop: `autostatus`
+	//GetAutoloopStatus returns the outcome of the liquidity manager's most
+	//recent autoloop check, including the reasons that swaps are currently not
+	//being suggested for each rule. Changes in this outcome are logged, rather
+	//than every check.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetAutoloopStatus(ctx context.Context, in *AutoloopStatusRequest, opts ...grpc.CallOption) (*AutoloopStatusResponse, error)
+	// loop: `autotrigger`
+	//TriggerAutoloopEvaluation performs an autoloop evaluation immediately,
+	//rather than waiting for the next scheduled check, and returns its outcome.
+	//The evaluation honors the configured budget and limits, and only
+	//dispatches swaps if autoloop is enabled.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	TriggerAutoloopEvaluation(ctx context.Context, in *TriggerAutoloopEvaluationRequest, opts ...grpc.CallOption) (*AutoloopStatusResponse, error)
+	// loop: `budgetreport`
+	//GetBudgetReconciliation returns a report of the worst-case fees that
+	//automatically dispatched swaps reserved from the autoloop budget while
+	//they were in flight, compared to the fees that they realized once they
+	//completed.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetBudgetReconciliation(ctx context.Context, in *BudgetReconciliationRequest, opts ...grpc.CallOption) (*BudgetReconciliationResponse, error)
+	// loop: `stats`
+	//GetSwapStats returns statistics for the swaps in the local database,
+	//aggregated by day, week or month.
+	GetSwapStats(ctx context.Context, in *SwapStatsRequest, opts ...grpc.CallOption) (*SwapStatsResponse, error)
+	// loop: `costs`
+	//GetSwapCosts returns the realized server, on-chain and off-chain costs of
+	//the swaps that completed within a time range, along with their totals.
+	GetSwapCosts(ctx context.Context, in *SwapCostsRequest, opts ...grpc.CallOption) (*SwapCostsResponse, error)
+	// loop: `quotehistory`
+	//GetQuoteHistory returns the loop in and loop out quotes that the client
+	//has received from the server within a time range, so that the fees
+	//offered by the server can be evaluated over time.
+	GetQuoteHistory(ctx context.Context, in *QuoteHistoryRequest, opts ...grpc.CallOption) (*QuoteHistoryResponse, error)
+	// loop: `liquidityhistory`
+	//GetLiquidityHistory returns the channel balance snapshots that loopd has
+	//recorded, grouped by channel, so that persistent imbalances can be told
+	//apart from transient spikes before a swap is dispatched. Snapshots are only
+	//recorded if loopd's liquiditysnapshotinterval is set, and are kept for a
+	//week.
+	GetLiquidityHistory(ctx context.Context, in *LiquidityHistoryRequest, opts ...grpc.CallOption) (*LiquidityHistoryResponse, error)
+	// loop: `audit`
+	//GetAuditLog returns the swap state transitions that loopd has recorded in
+	//its append-only audit log, ordered from oldest to newest. Entries are kept
+	//when swaps are pruned, so that failed swaps can be investigated without
+	//debug logs.
+	GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error)
+	// loop: `comparerebalance`
+	//CompareRebalance compares the cost of a swap with the off-chain fees that
+	//lnd estimates for a circular rebalance that has the same effect on a
+	//channel's balance.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	CompareRebalance(ctx context.Context, in *CompareRebalanceRequest, opts ...grpc.CallOption) (*CompareRebalanceResponse, error)
+	// loop: `getconfig`
+	//GetConfig returns the daemon's effective configuration, after defaults and
+	//overrides have been applied. Secrets and paths to credentials are not
+	//included.
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// loop: `apiversion`
+	//GetApiVersion returns the version of the rpc API that the daemon serves,
+	//the rpcs that it supports and metadata for the deprecated elements of the
+	//API, so that integrations can detect the features that are available
+	//instead of relying on the daemon's version.
+	GetApiVersion(ctx context.Context, in *GetApiVersionRequest, opts ...grpc.CallOption) (*GetApiVersionResponse, error)
+	// loop: `serverhealth`
+	//ServerHealth returns the health of the daemon's connection to the swap
+	//server, based on the outcomes of its most recent calls to the server.
+	//Autoloop is paused while the server is unreachable.
+	ServerHealth(ctx context.Context, in *ServerHealthRequest, opts ...grpc.CallOption) (*ServerHealthResponse, error)
+	// loop: `debuglevel`
+	//DebugLevel allows a caller to programmatically set the logging verbosity
+	//of loopd. The logging can be targeted according to a coarse daemon-wide
+	//logging level, or in a granular fashion to specify the logging for a
+	//target sub-system.
+	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	// loop: `testnotification`
+	//TestNotification sends a test event to each of the notification sinks
+	//configured for loopd, bypassing the rate limit of its email, Telegram and
+	//Matrix sinks, and reports whether delivery to each sink succeeded.
+	TestNotification(ctx context.Context, in *TestNotificationRequest, opts ...grpc.CallOption) (*TestNotificationResponse, error)
+	// loop: `support-bundle`
+	//GetSupportBundle returns a gzipped tar archive that contains loopd's
+	//status, configuration, pending swaps, database statistics and the end of
+	//its log file, with invoices, addresses and ip addresses redacted. This
+	//archive can be attached to bug reports.
+	GetSupportBundle(ctx context.Context, in *SupportBundleRequest, opts ...grpc.CallOption) (*SupportBundleResponse, error)
+	// loop: `static new`
+	//NewStaticAddress returns loopd's static address, creating it if it does not
+	//exist yet. Once a deposit to this address has confirmed, loopd automatically
+	//dispatches a loop in swap for its value, less the fees for publishing the
+	//swap's htlc. The address is never replaced, so it can be reused for any
+	//number of deposits.
+	NewStaticAddress(ctx context.Context, in *NewStaticAddressRequest, opts ...grpc.CallOption) (*NewStaticAddressResponse, error)
+	// loop: `static deposits`
+	//ListStaticDeposits returns the deposits that have been made to loopd's
+	//static address, along with the loop in swaps that were dispatched for them.
+	ListStaticDeposits(ctx context.Context, in *ListStaticDepositsRequest, opts ...grpc.CallOption) (*ListStaticDepositsResponse, error)
+	// loop: `bakemacaroon`
+	//BakeMacaroon bakes a new macaroon that is restricted to the permissions
+	//requested. This can be used to create scoped credentials, for example a
+	//readonly macaroon or one that can only manage autoloop.
+	BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error)
+}
+
+type swapClientClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSwapClientClient(cc grpc.ClientConnInterface) SwapClientClient {
+	return &swapClientClient{cc}
+}
+
+func (c *swapClientClient) LoopOut(ctx context.Context, in *LoopOutRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
+	out := new(SwapResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) LoopIn(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
+	out := new(SwapResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) NewLoopInPsbt(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*LoopInPsbtResponse, error) {
+	out := new(LoopInPsbtResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/NewLoopInPsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (SwapClient_MonitorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SwapClient_serviceDesc.Streams[0], "/looprpc.SwapClient/Monitor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &swapClientMonitorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SwapClient_MonitorClient interface {
+	Recv() (*SwapStatus, error)
+	grpc.ClientStream
+}
+
+type swapClientMonitorClient struct {
+	grpc.ClientStream
+}
+
+func (x *swapClientMonitorClient) Recv() (*SwapStatus, error) {
+	m := new(SwapStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *swapClientClient) ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error) {
+	out := new(ListSwapsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ListSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) SwapInfo(ctx context.Context, in *SwapInfoRequest, opts ...grpc.CallOption) (*SwapStatus, error) {
+	out := new(SwapStatus)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SwapInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) AbandonSwap(ctx context.Context, in *AbandonSwapRequest, opts ...grpc.CallOption) (*AbandonSwapResponse, error) {
+	out := new(AbandonSwapResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/AbandonSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) RecoverSweep(ctx context.Context, in *RecoverSweepRequest, opts ...grpc.CallOption) (*RecoverSweepResponse, error) {
+	out := new(RecoverSweepResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/RecoverSweep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) PruneSwaps(ctx context.Context, in *PruneSwapsRequest, opts ...grpc.CallOption) (*PruneSwapsResponse, error) {
+	out := new(PruneSwapsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/PruneSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) ExportSwaps(ctx context.Context, in *ExportSwapsRequest, opts ...grpc.CallOption) (*ExportSwapsResponse, error) {
+	out := new(ExportSwapsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ExportSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) ImportSwaps(ctx context.Context, in *ImportSwapsRequest, opts ...grpc.CallOption) (*ImportSwapsResponse, error) {
+	out := new(ImportSwapsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ImportSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) SaveSwapTemplate(ctx context.Context, in *SaveSwapTemplateRequest, opts ...grpc.CallOption) (*SaveSwapTemplateResponse, error) {
+	out := new(SaveSwapTemplateResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SaveSwapTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) ListSwapTemplates(ctx context.Context, in *ListSwapTemplatesRequest, opts ...grpc.CallOption) (*ListSwapTemplatesResponse, error) {
+	out := new(ListSwapTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ListSwapTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) DeleteSwapTemplate(ctx context.Context, in *DeleteSwapTemplateRequest, opts ...grpc.CallOption) (*DeleteSwapTemplateResponse, error) {
+	out := new(DeleteSwapTemplateResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/DeleteSwapTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) LoopOutTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*OutTermsResponse, error) {
+	out := new(OutTermsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOutTerms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) LoopOutQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*OutQuoteResponse, error) {
+	out := new(OutQuoteResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOutQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetLoopInTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*InTermsResponse, error) {
+	out := new(InTermsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLoopInTerms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetLoopInQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*InQuoteResponse, error) {
+	out := new(InQuoteResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLoopInQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetLsatTokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLsatTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetLiquidityParams(ctx context.Context, in *GetLiquidityParamsRequest, opts ...grpc.CallOption) (*LiquidityParameters, error) {
+	out := new(LiquidityParameters)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLiquidityParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) SetLiquidityParams(ctx context.Context, in *SetLiquidityParamsRequest, opts ...grpc.CallOption) (*SetLiquidityParamsResponse, error) {
+	out := new(SetLiquidityParamsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SetLiquidityParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) CancelLiquidityParams(ctx context.Context, in *CancelLiquidityParamsRequest, opts ...grpc.CallOption) (*CancelLiquidityParamsResponse, error) {
+	out := new(CancelLiquidityParamsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/CancelLiquidityParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) UpdateLiquidityParams(ctx context.Context, in *UpdateLiquidityParamsRequest, opts ...grpc.CallOption) (*UpdateLiquidityParamsResponse, error) {
+	out := new(UpdateLiquidityParamsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/UpdateLiquidityParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetPeerReputations(ctx context.Context, in *GetPeerReputationsRequest, opts ...grpc.CallOption) (*GetPeerReputationsResponse, error) {
+	out := new(GetPeerReputationsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetPeerReputations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetStaleRules(ctx context.Context, in *GetStaleRulesRequest, opts ...grpc.CallOption) (*GetStaleRulesResponse, error) {
+	out := new(GetStaleRulesResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetStaleRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) SuggestSwaps(ctx context.Context, in *SuggestSwapsRequest, opts ...grpc.CallOption) (*SuggestSwapsResponse, error) {
+	out := new(SuggestSwapsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SuggestSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetAutoloopStatus(ctx context.Context, in *AutoloopStatusRequest, opts ...grpc.CallOption) (*AutoloopStatusResponse, error) {
+	out := new(AutoloopStatusResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetAutoloopStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) TriggerAutoloopEvaluation(ctx context.Context, in *TriggerAutoloopEvaluationRequest, opts ...grpc.CallOption) (*AutoloopStatusResponse, error) {
+	out := new(AutoloopStatusResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/TriggerAutoloopEvaluation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetBudgetReconciliation(ctx context.Context, in *BudgetReconciliationRequest, opts ...grpc.CallOption) (*BudgetReconciliationResponse, error) {
+	out := new(BudgetReconciliationResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetBudgetReconciliation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetSwapStats(ctx context.Context, in *SwapStatsRequest, opts ...grpc.CallOption) (*SwapStatsResponse, error) {
+	out := new(SwapStatsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetSwapStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetSwapCosts(ctx context.Context, in *SwapCostsRequest, opts ...grpc.CallOption) (*SwapCostsResponse, error) {
+	out := new(SwapCostsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetSwapCosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetQuoteHistory(ctx context.Context, in *QuoteHistoryRequest, opts ...grpc.CallOption) (*QuoteHistoryResponse, error) {
+	out := new(QuoteHistoryResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetQuoteHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetLiquidityHistory(ctx context.Context, in *LiquidityHistoryRequest, opts ...grpc.CallOption) (*LiquidityHistoryResponse, error) {
+	out := new(LiquidityHistoryResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLiquidityHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error) {
+	out := new(AuditLogResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) CompareRebalance(ctx context.Context, in *CompareRebalanceRequest, opts ...grpc.CallOption) (*CompareRebalanceResponse, error) {
+	out := new(CompareRebalanceResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/CompareRebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetApiVersion(ctx context.Context, in *GetApiVersionRequest, opts ...grpc.CallOption) (*GetApiVersionResponse, error) {
+	out := new(GetApiVersionResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetApiVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) ServerHealth(ctx context.Context, in *ServerHealthRequest, opts ...grpc.CallOption) (*ServerHealthResponse, error) {
+	out := new(ServerHealthResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ServerHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
+	out := new(DebugLevelResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/DebugLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) TestNotification(ctx context.Context, in *TestNotificationRequest, opts ...grpc.CallOption) (*TestNotificationResponse, error) {
+	out := new(TestNotificationResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/TestNotification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) GetSupportBundle(ctx context.Context, in *SupportBundleRequest, opts ...grpc.CallOption) (*SupportBundleResponse, error) {
+	out := new(SupportBundleResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetSupportBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) NewStaticAddress(ctx context.Context, in *NewStaticAddressRequest, opts ...grpc.CallOption) (*NewStaticAddressResponse, error) {
+	out := new(NewStaticAddressResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/NewStaticAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) ListStaticDeposits(ctx context.Context, in *ListStaticDepositsRequest, opts ...grpc.CallOption) (*ListStaticDepositsResponse, error) {
+	out := new(ListStaticDepositsResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ListStaticDeposits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapClientClient) BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error) {
+	out := new(BakeMacaroonResponse)
+	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/BakeMacaroon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SwapClientServer is the server API for SwapClient service.
+type SwapClientServer interface {
+	// loop: `out`
+	//LoopOut initiates an loop out swap with the given parameters. The call
+	//returns after the swap has been set up with the swap server. From that
+	//point onwards, progress can be tracked via the SwapStatus stream that is
+	//returned from Monitor().
+	LoopOut(context.Context, *LoopOutRequest) (*SwapResponse, error)
+	// loop: `in`
+	//LoopIn initiates a loop in swap with the given parameters. The call
+	//returns after the swap has been set up with the swap server. From that
+	//point onwards, progress can be tracked via the SwapStatus stream
+	//that is returned from Monitor().
+	LoopIn(context.Context, *LoopInRequest) (*SwapResponse, error)
+	// loop: `in --psbt`
+	//NewLoopInPsbt initiates a loop in swap that is funded by an external
+	//wallet. The external_htlc field of the request is implied. The call returns
+	//an unsigned funding psbt that pays the swap amount to the htlc, which the
+	//external wallet should fund, sign and broadcast. The swap then proceeds
+	//once the funding transaction confirms, and can be tracked via the
+	//SwapStatus stream that is returned from Monitor().
+	NewLoopInPsbt(context.Context, *LoopInRequest) (*LoopInPsbtResponse, error)
+	// loop: `monitor`
+	//Monitor will return a stream of swap updates for currently active swaps.
+	//The stream first delivers a snapshot of all currently pending swaps,
+	//followed by deltas that are assigned monotonically increasing sequence
+	//numbers. A reconnecting client can set start_epoch and start_sequence to
+	//the epoch and last sequence number it received to resume the stream
+	//without missing any transitions.
+	Monitor(*MonitorRequest, SwapClient_MonitorServer) error
+	// loop: `listswaps`
+	//ListSwaps returns a list of all currently known swaps and their current
+	//status.
+	ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error)
+	// loop: `swapinfo`
+	//SwapInfo returns all known details about a single swap.
+	SwapInfo(context.Context, *SwapInfoRequest) (*SwapStatus, error)
+	// loop: `abandonswap`
+	//AbandonSwap marks a pending swap that is permanently stuck as failed and
+	//stops executing it. This should only be used as a last resort, because
+	//loopd will no longer sweep any on-chain htlc for the swap, so funds that
+	//are locked in it need to be recovered manually.
+	AbandonSwap(context.Context, *AbandonSwapRequest) (*AbandonSwapResponse, error)
+	// loop: `recover`
+	//RecoverSweep manually sweeps the on-chain htlc of a swap using the swap
+	//data in loopd's database, bypassing the swap's state machine. Loop out
+	//htlcs are swept with the swap preimage, loop in htlcs are reclaimed through
+	//the timeout path once they have expired. This is intended for recovery
+	//when the automatic sweep fails, for example due to corrupted swap state.
+	RecoverSweep(context.Context, *RecoverSweepRequest) (*RecoverSweepResponse, error)
+	// loop: `pruneswaps`
+	//PruneSwaps deletes all swaps that reached a final state more than the
+	//retention period ago from loopd's database. This reduces the size of the
+	//database for long-running nodes, but pruned swaps can no longer be listed,
+	//and funds that are left in their htlcs can no longer be recovered. Use
+	//loopd view --prune while loopd is not running to also compact the database
+	//file.
+	PruneSwaps(context.Context, *PruneSwapsRequest) (*PruneSwapsResponse, error)
+	// loop: `db export`
+	//ExportSwaps exports all swaps in loopd's database, including their updates,
+	//to a versioned JSON format that can be imported by another loopd instance
+	//with ImportSwaps. The export contains swap preimages, so it must be stored
+	//securely.
+	ExportSwaps(context.Context, *ExportSwapsRequest) (*ExportSwapsResponse, error)
+	// loop: `db import`
+	//ImportSwaps adds the swaps in an export created by ExportSwaps to loopd's
+	//database. The export's checksum, version and network are verified before any
+	//swaps are imported, and swaps that already exist are skipped. Pending swaps
+	//that are imported are only resumed once loopd is restarted.
+	ImportSwaps(context.Context, *ImportSwapsRequest) (*ImportSwapsResponse, error)
+	// loop: `templates save`
+	//SaveSwapTemplate stores a named loop out template in loopd's database,
+	//replacing any existing template with the same name. Templates can be
+	//dispatched with loop out --template.
+	SaveSwapTemplate(context.Context, *SaveSwapTemplateRequest) (*SaveSwapTemplateResponse, error)
+	// loop: `templates list`
+	//ListSwapTemplates returns all loop out templates, ordered by name.
+	ListSwapTemplates(context.Context, *ListSwapTemplatesRequest) (*ListSwapTemplatesResponse, error)
+	// loop: `templates delete`
+	//DeleteSwapTemplate deletes a loop out template.
+	DeleteSwapTemplate(context.Context, *DeleteSwapTemplateRequest) (*DeleteSwapTemplateResponse, error)
+	// loop: `terms`
+	//LoopOutTerms returns the terms that the server enforces for a loop out swap.
+	LoopOutTerms(context.Context, *TermsRequest) (*OutTermsResponse, error)
+	// loop: `quote`
+	//LoopOutQuote returns a quote for a loop out swap with the provided
+	//parameters.
+	LoopOutQuote(context.Context, *QuoteRequest) (*OutQuoteResponse, error)
+	// loop: `terms`
+	//GetTerms returns the terms that the server enforces for swaps.
+	GetLoopInTerms(context.Context, *TermsRequest) (*InTermsResponse, error)
+	// loop: `quote`
+	//GetQuote returns a quote for a swap with the provided parameters.
+	GetLoopInQuote(context.Context, *QuoteRequest) (*InQuoteResponse, error)
+	// loop: `listauth`
+	//GetLsatTokens returns all LSAT tokens the daemon ever paid for.
+	GetLsatTokens(context.Context, *TokensRequest) (*TokensResponse, error)
+	// loop: `getparams`
+	//GetLiquidityParams gets the parameters that the daemon's liquidity manager
+	//is currently configured with. This may be nil if nothing is configured.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetLiquidityParams(context.Context, *GetLiquidityParamsRequest) (*LiquidityParameters, error)
+	// loop: `setparams`
+	//SetLiquidityParams sets a new set of parameters for the daemon's liquidity
+	//manager. Note that the full set of parameters must be provided, because
+	//this call fully overwrites our existing parameters. If the daemon has a
+	//change delay configured, parameters that increase the fees autoloop may
+	//spend only take effect once the delay has passed.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	SetLiquidityParams(context.Context, *SetLiquidityParamsRequest) (*SetLiquidityParamsResponse, error)
+	// loop: `cancelparams`
+	//CancelLiquidityParams cancels a pending parameter change that is waiting
+	//for the daemon's change delay to pass, leaving the current parameters
+	//unchanged.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	CancelLiquidityParams(context.Context, *CancelLiquidityParamsRequest) (*CancelLiquidityParamsResponse, error)
+	// loop: `setparams`
+	//UpdateLiquidityParams atomically updates the listed fields of the daemon's
+	//liquidity manager parameters, leaving all other parameters unchanged. If a
+	//version obtained from GetLiquidityParams is provided, the update fails if
+	//the parameters have been changed since. Like SetLiquidityParams, parameters
+	//that increase the fees autoloop may spend are subject to the daemon's
+	//change delay.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	UpdateLiquidityParams(context.Context, *UpdateLiquidityParamsRequest) (*UpdateLiquidityParamsResponse, error)
+	// loop: `reputation`
+	//GetPeerReputations returns the reputations of the peers that the daemon
+	//has recorded swap outcomes for, based on the swaps that were routed
+	//through them.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetPeerReputations(context.Context, *GetPeerReputationsRequest) (*GetPeerReputationsResponse, error)
+	// loop: `stalerules`
+	//GetStaleRules returns the autoloop rules that are set for channels or
+	//peers that have been closed or disconnected for at least the daemon's
+	//stale rule age.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetStaleRules(context.Context, *GetStaleRulesRequest) (*GetStaleRulesResponse, error)
+	// loop: `suggestswaps`
+	//SuggestSwaps returns a list of recommended swaps based on the current
+	//state of your node's channels and it's liquidity manager parameters.
+	//Note that only loop out suggestions are currently supported.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	SuggestSwaps(context.Context, *SuggestSwapsRequest) (*SuggestSwapsResponse, error)
+	// loop: `autostatus`
+	//GetAutoloopStatus returns the outcome of the liquidity manager's most
+	//recent autoloop check, including the reasons that swaps are currently not
+	//being suggested for each rule. Changes in this outcome are logged, rather
+	//than every check.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetAutoloopStatus(context.Context, *AutoloopStatusRequest) (*AutoloopStatusResponse, error)
+	// loop: `autotrigger`
+	//TriggerAutoloopEvaluation performs an autoloop evaluation immediately,
+	//rather than waiting for the next scheduled check, and returns its outcome.
+	//The evaluation honors the configured budget and limits, and only
+	//dispatches swaps if autoloop is enabled.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	TriggerAutoloopEvaluation(context.Context, *TriggerAutoloopEvaluationRequest) (*AutoloopStatusResponse, error)
+	// loop: `budgetreport`
+	//GetBudgetReconciliation returns a report of the worst-case fees that
+	//automatically dispatched swaps reserved from the autoloop budget while
+	//they were in flight, compared to the fees that they realized once they
+	//completed.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	GetBudgetReconciliation(context.Context, *BudgetReconciliationRequest) (*BudgetReconciliationResponse, error)
+	// loop: `stats`
+	//GetSwapStats returns statistics for the swaps in the local database,
+	//aggregated by day, week or month.
+	GetSwapStats(context.Context, *SwapStatsRequest) (*SwapStatsResponse, error)
+	// loop: `costs`
+	//GetSwapCosts returns the realized server, on-chain and off-chain costs of
+	//the swaps that completed within a time range, along with their totals.
+	GetSwapCosts(context.Context, *SwapCostsRequest) (*SwapCostsResponse, error)
+	// loop: `quotehistory`
+	//GetQuoteHistory returns the loop in and loop out quotes that the client
+	//has received from the server within a time range, so that the fees
+	//offered by the server can be evaluated over time.
+	GetQuoteHistory(context.Context, *QuoteHistoryRequest) (*QuoteHistoryResponse, error)
+	// loop: `liquidityhistory`
+	//GetLiquidityHistory returns the channel balance snapshots that loopd has
+	//recorded, grouped by channel, so that persistent imbalances can be told
+	//apart from transient spikes before a swap is dispatched. Snapshots are only
+	//recorded if loopd's liquiditysnapshotinterval is set, and are kept for a
+	//week.
+	GetLiquidityHistory(context.Context, *LiquidityHistoryRequest) (*LiquidityHistoryResponse, error)
+	// loop: `audit`
+	//GetAuditLog returns the swap state transitions that loopd has recorded in
+	//its append-only audit log, ordered from oldest to newest. Entries are kept
+	//when swaps are pruned, so that failed swaps can be investigated without
+	//debug logs.
+	GetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error)
+	// loop: `comparerebalance`
+	//CompareRebalance compares the cost of a swap with the off-chain fees that
+	//lnd estimates for a circular rebalance that has the same effect on a
+	//channel's balance.
+	//[EXPERIMENTAL]: endpoint is subject to change.
+	CompareRebalance(context.Context, *CompareRebalanceRequest) (*CompareRebalanceResponse, error)
+	// loop: `getconfig`
+	//GetConfig returns the daemon's effective configuration, after defaults and
+	//overrides have been applied. Secrets and paths to credentials are not
+	//included.
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// loop: `apiversion`
+	//GetApiVersion returns the version of the rpc API that the daemon serves,
+	//the rpcs that it supports and metadata for the deprecated elements of the
+	//API, so that integrations can detect the features that are available
+	//instead of relying on the daemon's version.
+	GetApiVersion(context.Context, *GetApiVersionRequest) (*GetApiVersionResponse, error)
+	// loop: `serverhealth`
+	//ServerHealth returns the health of the daemon's connection to the swap
+	//server, based on the outcomes of its most recent calls to the server.
+	//Autoloop is paused while the server is unreachable.
+	ServerHealth(context.Context, *ServerHealthRequest) (*ServerHealthResponse, error)
+	// loop: `debuglevel`
+	//DebugLevel allows a caller to programmatically set the logging verbosity
+	//of loopd. The logging can be targeted according to a coarse daemon-wide
+	//logging level, or in a granular fashion to specify the logging for a
+	//target sub-system.
+	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	// loop: `testnotification`
+	//TestNotification sends a test event to each of the notification sinks
+	//configured for loopd, bypassing the rate limit of its email, Telegram and
+	//Matrix sinks, and reports whether delivery to each sink succeeded.
+	TestNotification(context.Context, *TestNotificationRequest) (*TestNotificationResponse, error)
+	// loop: `support-bundle`
+	//GetSupportBundle returns a gzipped tar archive that contains loopd's
+	//status, configuration, pending swaps, database statistics and the end of
+	//its log file, with invoices, addresses and ip addresses redacted. This
+	//archive can be attached to bug reports.
+	GetSupportBundle(context.Context, *SupportBundleRequest) (*SupportBundleResponse, error)
+	// loop: `static new`
+	//NewStaticAddress returns loopd's static address, creating it if it does not
+	//exist yet. Once a deposit to this address has confirmed, loopd automatically
+	//dispatches a loop in swap for its value, less the fees for publishing the
+	//swap's htlc. The address is never replaced, so it can be reused for any
+	//number of deposits.
+	NewStaticAddress(context.Context, *NewStaticAddressRequest) (*NewStaticAddressResponse, error)
+	// loop: `static deposits`
+	//ListStaticDeposits returns the deposits that have been made to loopd's
+	//static address, along with the loop in swaps that were dispatched for them.
+	ListStaticDeposits(context.Context, *ListStaticDepositsRequest) (*ListStaticDepositsResponse, error)
+	// loop: `bakemacaroon`
+	//BakeMacaroon bakes a new macaroon that is restricted to the permissions
+	//requested. This can be used to create scoped credentials, for example a
+	//readonly macaroon or one that can only manage autoloop.
+	BakeMacaroon(context.Context, *BakeMacaroonRequest) (*BakeMacaroonResponse, error)
+}
+
+// UnimplementedSwapClientServer can be embedded to have forward compatible implementations.
+type UnimplementedSwapClientServer struct {
+}
+
+func (*UnimplementedSwapClientServer) LoopOut(context.Context, *LoopOutRequest) (*SwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoopOut not implemented")
+}
+func (*UnimplementedSwapClientServer) LoopIn(context.Context, *LoopInRequest) (*SwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoopIn not implemented")
+}
+func (*UnimplementedSwapClientServer) NewLoopInPsbt(context.Context, *LoopInRequest) (*LoopInPsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewLoopInPsbt not implemented")
+}
+func (*UnimplementedSwapClientServer) Monitor(*MonitorRequest, SwapClient_MonitorServer) error {
+	return status.Errorf(codes.Unimplemented, "method Monitor not implemented")
+}
+func (*UnimplementedSwapClientServer) ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSwaps not implemented")
+}
+func (*UnimplementedSwapClientServer) SwapInfo(context.Context, *SwapInfoRequest) (*SwapStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapInfo not implemented")
+}
+func (*UnimplementedSwapClientServer) AbandonSwap(context.Context, *AbandonSwapRequest) (*AbandonSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbandonSwap not implemented")
+}
+func (*UnimplementedSwapClientServer) RecoverSweep(context.Context, *RecoverSweepRequest) (*RecoverSweepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverSweep not implemented")
+}
+func (*UnimplementedSwapClientServer) PruneSwaps(context.Context, *PruneSwapsRequest) (*PruneSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneSwaps not implemented")
+}
+func (*UnimplementedSwapClientServer) ExportSwaps(context.Context, *ExportSwapsRequest) (*ExportSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSwaps not implemented")
+}
+func (*UnimplementedSwapClientServer) ImportSwaps(context.Context, *ImportSwapsRequest) (*ImportSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSwaps not implemented")
+}
+func (*UnimplementedSwapClientServer) SaveSwapTemplate(context.Context, *SaveSwapTemplateRequest) (*SaveSwapTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSwapTemplate not implemented")
+}
+func (*UnimplementedSwapClientServer) ListSwapTemplates(context.Context, *ListSwapTemplatesRequest) (*ListSwapTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSwapTemplates not implemented")
+}
+func (*UnimplementedSwapClientServer) DeleteSwapTemplate(context.Context, *DeleteSwapTemplateRequest) (*DeleteSwapTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSwapTemplate not implemented")
+}
+func (*UnimplementedSwapClientServer) LoopOutTerms(context.Context, *TermsRequest) (*OutTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoopOutTerms not implemented")
+}
+func (*UnimplementedSwapClientServer) LoopOutQuote(context.Context, *QuoteRequest) (*OutQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoopOutQuote not implemented")
+}
+func (*UnimplementedSwapClientServer) GetLoopInTerms(context.Context, *TermsRequest) (*InTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoopInTerms not implemented")
+}
+func (*UnimplementedSwapClientServer) GetLoopInQuote(context.Context, *QuoteRequest) (*InQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoopInQuote not implemented")
+}
+func (*UnimplementedSwapClientServer) GetLsatTokens(context.Context, *TokensRequest) (*TokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLsatTokens not implemented")
+}
+func (*UnimplementedSwapClientServer) GetLiquidityParams(context.Context, *GetLiquidityParamsRequest) (*LiquidityParameters, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidityParams not implemented")
+}
+func (*UnimplementedSwapClientServer) SetLiquidityParams(context.Context, *SetLiquidityParamsRequest) (*SetLiquidityParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLiquidityParams not implemented")
+}
+func (*UnimplementedSwapClientServer) CancelLiquidityParams(context.Context, *CancelLiquidityParamsRequest) (*CancelLiquidityParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelLiquidityParams not implemented")
+}
+func (*UnimplementedSwapClientServer) UpdateLiquidityParams(context.Context, *UpdateLiquidityParamsRequest) (*UpdateLiquidityParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLiquidityParams not implemented")
+}
+func (*UnimplementedSwapClientServer) GetPeerReputations(context.Context, *GetPeerReputationsRequest) (*GetPeerReputationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerReputations not implemented")
+}
+func (*UnimplementedSwapClientServer) GetStaleRules(context.Context, *GetStaleRulesRequest) (*GetStaleRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStaleRules not implemented")
+}
+func (*UnimplementedSwapClientServer) SuggestSwaps(context.Context, *SuggestSwapsRequest) (*SuggestSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestSwaps not implemented")
+}
+func (*UnimplementedSwapClientServer) GetAutoloopStatus(context.Context, *AutoloopStatusRequest) (*AutoloopStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutoloopStatus not implemented")
+}
+func (*UnimplementedSwapClientServer) TriggerAutoloopEvaluation(context.Context, *TriggerAutoloopEvaluationRequest) (*AutoloopStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerAutoloopEvaluation not implemented")
+}
+func (*UnimplementedSwapClientServer) GetBudgetReconciliation(context.Context, *BudgetReconciliationRequest) (*BudgetReconciliationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBudgetReconciliation not implemented")
+}
+func (*UnimplementedSwapClientServer) GetSwapStats(context.Context, *SwapStatsRequest) (*SwapStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwapStats not implemented")
+}
+func (*UnimplementedSwapClientServer) GetSwapCosts(context.Context, *SwapCostsRequest) (*SwapCostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwapCosts not implemented")
+}
+func (*UnimplementedSwapClientServer) GetQuoteHistory(context.Context, *QuoteHistoryRequest) (*QuoteHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuoteHistory not implemented")
+}
+func (*UnimplementedSwapClientServer) GetLiquidityHistory(context.Context, *LiquidityHistoryRequest) (*LiquidityHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidityHistory not implemented")
+}
+func (*UnimplementedSwapClientServer) GetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (*UnimplementedSwapClientServer) CompareRebalance(context.Context, *CompareRebalanceRequest) (*CompareRebalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareRebalance not implemented")
+}
+func (*UnimplementedSwapClientServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedSwapClientServer) GetApiVersion(context.Context, *GetApiVersionRequest) (*GetApiVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApiVersion not implemented")
+}
+func (*UnimplementedSwapClientServer) ServerHealth(context.Context, *ServerHealthRequest) (*ServerHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerHealth not implemented")
+}
+func (*UnimplementedSwapClientServer) DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugLevel not implemented")
+}
+func (*UnimplementedSwapClientServer) TestNotification(context.Context, *TestNotificationRequest) (*TestNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestNotification not implemented")
+}
+func (*UnimplementedSwapClientServer) GetSupportBundle(context.Context, *SupportBundleRequest) (*SupportBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportBundle not implemented")
+}
+func (*UnimplementedSwapClientServer) NewStaticAddress(context.Context, *NewStaticAddressRequest) (*NewStaticAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewStaticAddress not implemented")
+}
+func (*UnimplementedSwapClientServer) ListStaticDeposits(context.Context, *ListStaticDepositsRequest) (*ListStaticDepositsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStaticDeposits not implemented")
+}
+func (*UnimplementedSwapClientServer) BakeMacaroon(context.Context, *BakeMacaroonRequest) (*BakeMacaroonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BakeMacaroon not implemented")
+}
+
+func RegisterSwapClientServer(s *grpc.Server, srv SwapClientServer) {
+	s.RegisterService(&_SwapClient_serviceDesc, srv)
+}
+
+func _SwapClient_LoopOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoopOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).LoopOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/LoopOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).LoopOut(ctx, req.(*LoopOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapClient_LoopIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoopInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_client_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   25,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_client_proto_goTypes,
-		DependencyIndexes: file_client_proto_depIdxs,
-		EnumInfos:         file_client_proto_enumTypes,
-		MessageInfos:      file_client_proto_msgTypes,
-	}.Build()
-	File_client_proto = out.File
-	file_client_proto_rawDesc = nil
-	file_client_proto_goTypes = nil
-	file_client_proto_depIdxs = nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).LoopIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/LoopIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).LoopIn(ctx, req.(*LoopInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func _SwapClient_NewLoopInPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoopInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).NewLoopInPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/NewLoopInPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).NewLoopInPsbt(ctx, req.(*LoopInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func _SwapClient_Monitor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SwapClientServer).Monitor(m, &swapClientMonitorServer{stream})
+}
 
-// SwapClientClient is the client API for SwapClient service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SwapClientClient interface {
-	// loop: `out`
-	//LoopOut initiates an loop out swap with the given parameters. The call
-	//returns after the swap has been set up with the swap server. From that
-	//point onwards, progress can be tracked via the SwapStatus stream that is
-	//returned from Monitor().
-	LoopOut(ctx context.Context, in *LoopOutRequest, opts ...grpc.CallOption) (*SwapResponse, error)
-	// loop: `in`
-	//LoopIn initiates a loop in swap with the given parameters. The call
-	//returns after the swap has been set up with the swap server. From that
-	//point onwards, progress can be tracked via the SwapStatus stream
-	//that is returned from Monitor().
-	LoopIn(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*SwapResponse, error)
-	// loop: `monitor`
-	//Monitor will return a stream of swap updates for currently active swaps.
-	Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (SwapClient_MonitorClient, error)
-	// loop: `listswaps`
-	//ListSwaps returns a list of all currently known swaps and their current
-	//status.
-	ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error)
-	// loop: `swapinfo`
-	//SwapInfo returns all known details about a single swap.
-	SwapInfo(ctx context.Context, in *SwapInfoRequest, opts ...grpc.CallOption) (*SwapStatus, error)
-	// loop: `terms`
-	//LoopOutTerms returns the terms that the server enforces for a loop out swap.
-	LoopOutTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*OutTermsResponse, error)
-	// loop: `quote`
-	//LoopOutQuote returns a quote for a loop out swap with the provided
-	//parameters.
-	LoopOutQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*OutQuoteResponse, error)
-	// loop: `terms`
-	//GetTerms returns the terms that the server enforces for swaps.
-	GetLoopInTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*InTermsResponse, error)
-	// loop: `quote`
-	//GetQuote returns a quote for a swap with the provided parameters.
-	GetLoopInQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*InQuoteResponse, error)
-	// loop: `listauth`
-	//GetLsatTokens returns all LSAT tokens the daemon ever paid for.
-	GetLsatTokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error)
-	// loop: `getparams`
-	//GetLiquidityParams gets the parameters that the daemon's liquidity manager
-	//is currently configured with. This may be nil if nothing is configured.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	GetLiquidityParams(ctx context.Context, in *GetLiquidityParamsRequest, opts ...grpc.CallOption) (*LiquidityParameters, error)
-	// loop: `setparams`
-	//SetLiquidityParams sets a new set of parameters for the daemon's liquidity
-	//manager. Note that the full set of parameters must be provided, because
-	//this call fully overwrites our existing parameters.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	SetLiquidityParams(ctx context.Context, in *SetLiquidityParamsRequest, opts ...grpc.CallOption) (*SetLiquidityParamsResponse, error)
-	// loop: `suggestswaps`
-	//SuggestSwaps returns a list of recommended swaps based on the current
-	//state of your node's channels and it's liquidity manager parameters.
-	//Note that only loop out suggestions are currently supported.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	SuggestSwaps(ctx context.Context, in *SuggestSwapsRequest, opts ...grpc.CallOption) (*SuggestSwapsResponse, error)
+type SwapClient_MonitorServer interface {
+	Send(*SwapStatus) error
+	grpc.ServerStream
 }
 
-type swapClientClient struct {
-	cc grpc.ClientConnInterface
+type swapClientMonitorServer struct {
+	grpc.ServerStream
 }
 
-func NewSwapClientClient(cc grpc.ClientConnInterface) SwapClientClient {
-	return &swapClientClient{cc}
+func (x *swapClientMonitorServer) Send(m *SwapStatus) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *swapClientClient) LoopOut(ctx context.Context, in *LoopOutRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
-	out := new(SwapResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOut", in, out, opts...)
-	if err != nil {
+func _SwapClient_ListSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSwapsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).ListSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/ListSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).ListSwaps(ctx, req.(*ListSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) LoopIn(ctx context.Context, in *LoopInRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
-	out := new(SwapResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopIn", in, out, opts...)
-	if err != nil {
+func _SwapClient_SwapInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapInfoRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).SwapInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/SwapInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).SwapInfo(ctx, req.(*SwapInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (SwapClient_MonitorClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SwapClient_serviceDesc.Streams[0], "/looprpc.SwapClient/Monitor", opts...)
-	if err != nil {
+func _SwapClient_AbandonSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonSwapRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &swapClientMonitorClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	if interceptor == nil {
+		return srv.(SwapClientServer).AbandonSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/AbandonSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).AbandonSwap(ctx, req.(*AbandonSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapClient_RecoverSweep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverSweepRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	if interceptor == nil {
+		return srv.(SwapClientServer).RecoverSweep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/RecoverSweep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).RecoverSweep(ctx, req.(*RecoverSweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapClient_PruneSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneSwapsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return x, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).PruneSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/PruneSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).PruneSwaps(ctx, req.(*PruneSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type SwapClient_MonitorClient interface {
-	Recv() (*SwapStatus, error)
-	grpc.ClientStream
+func _SwapClient_ExportSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).ExportSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/ExportSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).ExportSwaps(ctx, req.(*ExportSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type swapClientMonitorClient struct {
-	grpc.ClientStream
+func _SwapClient_ImportSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).ImportSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/ImportSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).ImportSwaps(ctx, req.(*ImportSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *swapClientMonitorClient) Recv() (*SwapStatus, error) {
-	m := new(SwapStatus)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _SwapClient_SaveSwapTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSwapTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).SaveSwapTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/SaveSwapTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).SaveSwapTemplate(ctx, req.(*SaveSwapTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error) {
-	out := new(ListSwapsResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/ListSwaps", in, out, opts...)
-	if err != nil {
+func _SwapClient_ListSwapTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSwapTemplatesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).ListSwapTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/ListSwapTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).ListSwapTemplates(ctx, req.(*ListSwapTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) SwapInfo(ctx context.Context, in *SwapInfoRequest, opts ...grpc.CallOption) (*SwapStatus, error) {
-	out := new(SwapStatus)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SwapInfo", in, out, opts...)
-	if err != nil {
+func _SwapClient_DeleteSwapTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSwapTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).DeleteSwapTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/DeleteSwapTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).DeleteSwapTemplate(ctx, req.(*DeleteSwapTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) LoopOutTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*OutTermsResponse, error) {
-	out := new(OutTermsResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOutTerms", in, out, opts...)
-	if err != nil {
+func _SwapClient_LoopOutTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TermsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).LoopOutTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/LoopOutTerms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).LoopOutTerms(ctx, req.(*TermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) LoopOutQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*OutQuoteResponse, error) {
-	out := new(OutQuoteResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/LoopOutQuote", in, out, opts...)
-	if err != nil {
+func _SwapClient_LoopOutQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).LoopOutQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/LoopOutQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).LoopOutQuote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) GetLoopInTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*InTermsResponse, error) {
-	out := new(InTermsResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLoopInTerms", in, out, opts...)
-	if err != nil {
+func _SwapClient_GetLoopInTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TermsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetLoopInTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetLoopInTerms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetLoopInTerms(ctx, req.(*TermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) GetLoopInQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*InQuoteResponse, error) {
-	out := new(InQuoteResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLoopInQuote", in, out, opts...)
-	if err != nil {
+func _SwapClient_GetLoopInQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetLoopInQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetLoopInQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetLoopInQuote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) GetLsatTokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
-	out := new(TokensResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLsatTokens", in, out, opts...)
-	if err != nil {
+func _SwapClient_GetLsatTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokensRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetLsatTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetLsatTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetLsatTokens(ctx, req.(*TokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) GetLiquidityParams(ctx context.Context, in *GetLiquidityParamsRequest, opts ...grpc.CallOption) (*LiquidityParameters, error) {
-	out := new(LiquidityParameters)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/GetLiquidityParams", in, out, opts...)
-	if err != nil {
+func _SwapClient_GetLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiquidityParamsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetLiquidityParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetLiquidityParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetLiquidityParams(ctx, req.(*GetLiquidityParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) SetLiquidityParams(ctx context.Context, in *SetLiquidityParamsRequest, opts ...grpc.CallOption) (*SetLiquidityParamsResponse, error) {
-	out := new(SetLiquidityParamsResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SetLiquidityParams", in, out, opts...)
-	if err != nil {
+func _SwapClient_SetLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLiquidityParamsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SwapClientServer).SetLiquidityParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/SetLiquidityParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).SetLiquidityParams(ctx, req.(*SetLiquidityParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *swapClientClient) SuggestSwaps(ctx context.Context, in *SuggestSwapsRequest, opts ...grpc.CallOption) (*SuggestSwapsResponse, error) {
-	out := new(SuggestSwapsResponse)
-	err := c.cc.Invoke(ctx, "/looprpc.SwapClient/SuggestSwaps", in, out, opts...)
-	if err != nil {
+func _SwapClient_CancelLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelLiquidityParamsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// SwapClientServer is the server API for SwapClient service.
-type SwapClientServer interface {
-	// loop: `out`
-	//LoopOut initiates an loop out swap with the given parameters. The call
-	//returns after the swap has been set up with the swap server. From that
-	//point onwards, progress can be tracked via the SwapStatus stream that is
-	//returned from Monitor().
-	LoopOut(context.Context, *LoopOutRequest) (*SwapResponse, error)
-	// loop: `in`
-	//LoopIn initiates a loop in swap with the given parameters. The call
-	//returns after the swap has been set up with the swap server. From that
-	//point onwards, progress can be tracked via the SwapStatus stream
-	//that is returned from Monitor().
-	LoopIn(context.Context, *LoopInRequest) (*SwapResponse, error)
-	// loop: `monitor`
-	//Monitor will return a stream of swap updates for currently active swaps.
-	Monitor(*MonitorRequest, SwapClient_MonitorServer) error
-	// loop: `listswaps`
-	//ListSwaps returns a list of all currently known swaps and their current
-	//status.
-	ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error)
-	// loop: `swapinfo`
-	//SwapInfo returns all known details about a single swap.
-	SwapInfo(context.Context, *SwapInfoRequest) (*SwapStatus, error)
-	// loop: `terms`
-	//LoopOutTerms returns the terms that the server enforces for a loop out swap.
-	LoopOutTerms(context.Context, *TermsRequest) (*OutTermsResponse, error)
-	// loop: `quote`
-	//LoopOutQuote returns a quote for a loop out swap with the provided
-	//parameters.
-	LoopOutQuote(context.Context, *QuoteRequest) (*OutQuoteResponse, error)
-	// loop: `terms`
-	//GetTerms returns the terms that the server enforces for swaps.
-	GetLoopInTerms(context.Context, *TermsRequest) (*InTermsResponse, error)
-	// loop: `quote`
-	//GetQuote returns a quote for a swap with the provided parameters.
-	GetLoopInQuote(context.Context, *QuoteRequest) (*InQuoteResponse, error)
-	// loop: `listauth`
-	//GetLsatTokens returns all LSAT tokens the daemon ever paid for.
-	GetLsatTokens(context.Context, *TokensRequest) (*TokensResponse, error)
-	// loop: `getparams`
-	//GetLiquidityParams gets the parameters that the daemon's liquidity manager
-	//is currently configured with. This may be nil if nothing is configured.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	GetLiquidityParams(context.Context, *GetLiquidityParamsRequest) (*LiquidityParameters, error)
-	// loop: `setparams`
-	//SetLiquidityParams sets a new set of parameters for the daemon's liquidity
-	//manager. Note that the full set of parameters must be provided, because
-	//this call fully overwrites our existing parameters.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	SetLiquidityParams(context.Context, *SetLiquidityParamsRequest) (*SetLiquidityParamsResponse, error)
-	// loop: `suggestswaps`
-	//SuggestSwaps returns a list of recommended swaps based on the current
-	//state of your node's channels and it's liquidity manager parameters.
-	//Note that only loop out suggestions are currently supported.
-	//[EXPERIMENTAL]: endpoint is subject to change.
-	SuggestSwaps(context.Context, *SuggestSwapsRequest) (*SuggestSwapsResponse, error)
-}
-
-// UnimplementedSwapClientServer can be embedded to have forward compatible implementations.
-type UnimplementedSwapClientServer struct {
+	if interceptor == nil {
+		return srv.(SwapClientServer).CancelLiquidityParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/CancelLiquidityParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).CancelLiquidityParams(ctx, req.(*CancelLiquidityParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedSwapClientServer) LoopOut(context.Context, *LoopOutRequest) (*SwapResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoopOut not implemented")
-}
-func (*UnimplementedSwapClientServer) LoopIn(context.Context, *LoopInRequest) (*SwapResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoopIn not implemented")
-}
-func (*UnimplementedSwapClientServer) Monitor(*MonitorRequest, SwapClient_MonitorServer) error {
-	return status.Errorf(codes.Unimplemented, "method Monitor not implemented")
-}
-func (*UnimplementedSwapClientServer) ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSwaps not implemented")
-}
-func (*UnimplementedSwapClientServer) SwapInfo(context.Context, *SwapInfoRequest) (*SwapStatus, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SwapInfo not implemented")
-}
-func (*UnimplementedSwapClientServer) LoopOutTerms(context.Context, *TermsRequest) (*OutTermsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoopOutTerms not implemented")
-}
-func (*UnimplementedSwapClientServer) LoopOutQuote(context.Context, *QuoteRequest) (*OutQuoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoopOutQuote not implemented")
-}
-func (*UnimplementedSwapClientServer) GetLoopInTerms(context.Context, *TermsRequest) (*InTermsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLoopInTerms not implemented")
-}
-func (*UnimplementedSwapClientServer) GetLoopInQuote(context.Context, *QuoteRequest) (*InQuoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLoopInQuote not implemented")
+func _SwapClient_UpdateLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLiquidityParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).UpdateLiquidityParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/UpdateLiquidityParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).UpdateLiquidityParams(ctx, req.(*UpdateLiquidityParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSwapClientServer) GetLsatTokens(context.Context, *TokensRequest) (*TokensResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLsatTokens not implemented")
+
+func _SwapClient_GetPeerReputations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerReputationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetPeerReputations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetPeerReputations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetPeerReputations(ctx, req.(*GetPeerReputationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSwapClientServer) GetLiquidityParams(context.Context, *GetLiquidityParamsRequest) (*LiquidityParameters, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidityParams not implemented")
+
+func _SwapClient_GetStaleRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStaleRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetStaleRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetStaleRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetStaleRules(ctx, req.(*GetStaleRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSwapClientServer) SetLiquidityParams(context.Context, *SetLiquidityParamsRequest) (*SetLiquidityParamsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetLiquidityParams not implemented")
+
+func _SwapClient_SuggestSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).SuggestSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/SuggestSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).SuggestSwaps(ctx, req.(*SuggestSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSwapClientServer) SuggestSwaps(context.Context, *SuggestSwapsRequest) (*SuggestSwapsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SuggestSwaps not implemented")
+
+func _SwapClient_GetAutoloopStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutoloopStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetAutoloopStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetAutoloopStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetAutoloopStatus(ctx, req.(*AutoloopStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSwapClientServer(s *grpc.Server, srv SwapClientServer) {
-	s.RegisterService(&_SwapClient_serviceDesc, srv)
+func _SwapClient_TriggerAutoloopEvaluation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerAutoloopEvaluationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).TriggerAutoloopEvaluation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/TriggerAutoloopEvaluation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).TriggerAutoloopEvaluation(ctx, req.(*TriggerAutoloopEvaluationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_LoopOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoopOutRequest)
+func _SwapClient_GetBudgetReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BudgetReconciliationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).LoopOut(ctx, in)
+		return srv.(SwapClientServer).GetBudgetReconciliation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/LoopOut",
+		FullMethod: "/looprpc.SwapClient/GetBudgetReconciliation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).LoopOut(ctx, req.(*LoopOutRequest))
+		return srv.(SwapClientServer).GetBudgetReconciliation(ctx, req.(*BudgetReconciliationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_LoopIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoopInRequest)
+func _SwapClient_GetSwapStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).LoopIn(ctx, in)
+		return srv.(SwapClientServer).GetSwapStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/LoopIn",
+		FullMethod: "/looprpc.SwapClient/GetSwapStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).LoopIn(ctx, req.(*LoopInRequest))
+		return srv.(SwapClientServer).GetSwapStats(ctx, req.(*SwapStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_Monitor_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(MonitorRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SwapClient_GetSwapCosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapCostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SwapClientServer).Monitor(m, &swapClientMonitorServer{stream})
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetSwapCosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetSwapCosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetSwapCosts(ctx, req.(*SwapCostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type SwapClient_MonitorServer interface {
-	Send(*SwapStatus) error
-	grpc.ServerStream
+func _SwapClient_GetQuoteHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetQuoteHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetQuoteHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetQuoteHistory(ctx, req.(*QuoteHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type swapClientMonitorServer struct {
-	grpc.ServerStream
+func _SwapClient_GetLiquidityHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiquidityHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetLiquidityHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetLiquidityHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetLiquidityHistory(ctx, req.(*LiquidityHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *swapClientMonitorServer) Send(m *SwapStatus) error {
-	return x.ServerStream.SendMsg(m)
+func _SwapClient_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapClientServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/looprpc.SwapClient/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapClientServer).GetAuditLog(ctx, req.(*AuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_ListSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSwapsRequest)
+func _SwapClient_CompareRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRebalanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).ListSwaps(ctx, in)
+		return srv.(SwapClientServer).CompareRebalance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/ListSwaps",
+		FullMethod: "/looprpc.SwapClient/CompareRebalance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).ListSwaps(ctx, req.(*ListSwapsRequest))
+		return srv.(SwapClientServer).CompareRebalance(ctx, req.(*CompareRebalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_SwapInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SwapInfoRequest)
+func _SwapClient_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).SwapInfo(ctx, in)
+		return srv.(SwapClientServer).GetConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/SwapInfo",
+		FullMethod: "/looprpc.SwapClient/GetConfig",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).SwapInfo(ctx, req.(*SwapInfoRequest))
+		return srv.(SwapClientServer).GetConfig(ctx, req.(*GetConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_LoopOutTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TermsRequest)
+func _SwapClient_GetApiVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiVersionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).LoopOutTerms(ctx, in)
+		return srv.(SwapClientServer).GetApiVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/LoopOutTerms",
+		FullMethod: "/looprpc.SwapClient/GetApiVersion",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).LoopOutTerms(ctx, req.(*TermsRequest))
+		return srv.(SwapClientServer).GetApiVersion(ctx, req.(*GetApiVersionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_LoopOutQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QuoteRequest)
+func _SwapClient_ServerHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerHealthRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).LoopOutQuote(ctx, in)
+		return srv.(SwapClientServer).ServerHealth(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/LoopOutQuote",
+		FullMethod: "/looprpc.SwapClient/ServerHealth",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).LoopOutQuote(ctx, req.(*QuoteRequest))
+		return srv.(SwapClientServer).ServerHealth(ctx, req.(*ServerHealthRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_GetLoopInTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TermsRequest)
+func _SwapClient_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugLevelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).GetLoopInTerms(ctx, in)
+		return srv.(SwapClientServer).DebugLevel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/GetLoopInTerms",
+		FullMethod: "/looprpc.SwapClient/DebugLevel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).GetLoopInTerms(ctx, req.(*TermsRequest))
+		return srv.(SwapClientServer).DebugLevel(ctx, req.(*DebugLevelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_GetLoopInQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QuoteRequest)
+func _SwapClient_TestNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestNotificationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).GetLoopInQuote(ctx, in)
+		return srv.(SwapClientServer).TestNotification(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/GetLoopInQuote",
+		FullMethod: "/looprpc.SwapClient/TestNotification",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).GetLoopInQuote(ctx, req.(*QuoteRequest))
+		return srv.(SwapClientServer).TestNotification(ctx, req.(*TestNotificationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_GetLsatTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TokensRequest)
+func _SwapClient_GetSupportBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupportBundleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).GetLsatTokens(ctx, in)
+		return srv.(SwapClientServer).GetSupportBundle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/GetLsatTokens",
+		FullMethod: "/looprpc.SwapClient/GetSupportBundle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).GetLsatTokens(ctx, req.(*TokensRequest))
+		return srv.(SwapClientServer).GetSupportBundle(ctx, req.(*SupportBundleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_GetLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLiquidityParamsRequest)
+func _SwapClient_NewStaticAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewStaticAddressRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).GetLiquidityParams(ctx, in)
+		return srv.(SwapClientServer).NewStaticAddress(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/GetLiquidityParams",
+		FullMethod: "/looprpc.SwapClient/NewStaticAddress",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).GetLiquidityParams(ctx, req.(*GetLiquidityParamsRequest))
+		return srv.(SwapClientServer).NewStaticAddress(ctx, req.(*NewStaticAddressRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_SetLiquidityParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetLiquidityParamsRequest)
+func _SwapClient_ListStaticDeposits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStaticDepositsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).SetLiquidityParams(ctx, in)
+		return srv.(SwapClientServer).ListStaticDeposits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/SetLiquidityParams",
+		FullMethod: "/looprpc.SwapClient/ListStaticDeposits",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).SetLiquidityParams(ctx, req.(*SetLiquidityParamsRequest))
+		return srv.(SwapClientServer).ListStaticDeposits(ctx, req.(*ListStaticDepositsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SwapClient_SuggestSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SuggestSwapsRequest)
+func _SwapClient_BakeMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BakeMacaroonRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SwapClientServer).SuggestSwaps(ctx, in)
+		return srv.(SwapClientServer).BakeMacaroon(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/looprpc.SwapClient/SuggestSwaps",
+		FullMethod: "/looprpc.SwapClient/BakeMacaroon",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SwapClientServer).SuggestSwaps(ctx, req.(*SuggestSwapsRequest))
+		return srv.(SwapClientServer).BakeMacaroon(ctx, req.(*BakeMacaroonRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3916,6 +15088,10 @@ var _SwapClient_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoopIn",
 			Handler:    _SwapClient_LoopIn_Handler,
 		},
+		{
+			MethodName: "NewLoopInPsbt",
+			Handler:    _SwapClient_NewLoopInPsbt_Handler,
+		},
 		{
 			MethodName: "ListSwaps",
 			Handler:    _SwapClient_ListSwaps_Handler,
@@ -3924,6 +15100,38 @@ var _SwapClient_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SwapInfo",
 			Handler:    _SwapClient_SwapInfo_Handler,
 		},
+		{
+			MethodName: "AbandonSwap",
+			Handler:    _SwapClient_AbandonSwap_Handler,
+		},
+		{
+			MethodName: "RecoverSweep",
+			Handler:    _SwapClient_RecoverSweep_Handler,
+		},
+		{
+			MethodName: "PruneSwaps",
+			Handler:    _SwapClient_PruneSwaps_Handler,
+		},
+		{
+			MethodName: "ExportSwaps",
+			Handler:    _SwapClient_ExportSwaps_Handler,
+		},
+		{
+			MethodName: "ImportSwaps",
+			Handler:    _SwapClient_ImportSwaps_Handler,
+		},
+		{
+			MethodName: "SaveSwapTemplate",
+			Handler:    _SwapClient_SaveSwapTemplate_Handler,
+		},
+		{
+			MethodName: "ListSwapTemplates",
+			Handler:    _SwapClient_ListSwapTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteSwapTemplate",
+			Handler:    _SwapClient_DeleteSwapTemplate_Handler,
+		},
 		{
 			MethodName: "LoopOutTerms",
 			Handler:    _SwapClient_LoopOutTerms_Handler,
@@ -3952,10 +15160,98 @@ var _SwapClient_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLiquidityParams",
 			Handler:    _SwapClient_SetLiquidityParams_Handler,
 		},
+		{
+			MethodName: "CancelLiquidityParams",
+			Handler:    _SwapClient_CancelLiquidityParams_Handler,
+		},
+		{
+			MethodName: "UpdateLiquidityParams",
+			Handler:    _SwapClient_UpdateLiquidityParams_Handler,
+		},
+		{
+			MethodName: "GetPeerReputations",
+			Handler:    _SwapClient_GetPeerReputations_Handler,
+		},
+		{
+			MethodName: "GetStaleRules",
+			Handler:    _SwapClient_GetStaleRules_Handler,
+		},
 		{
 			MethodName: "SuggestSwaps",
 			Handler:    _SwapClient_SuggestSwaps_Handler,
 		},
+		{
+			MethodName: "GetAutoloopStatus",
+			Handler:    _SwapClient_GetAutoloopStatus_Handler,
+		},
+		{
+			MethodName: "TriggerAutoloopEvaluation",
+			Handler:    _SwapClient_TriggerAutoloopEvaluation_Handler,
+		},
+		{
+			MethodName: "GetBudgetReconciliation",
+			Handler:    _SwapClient_GetBudgetReconciliation_Handler,
+		},
+		{
+			MethodName: "GetSwapStats",
+			Handler:    _SwapClient_GetSwapStats_Handler,
+		},
+		{
+			MethodName: "GetSwapCosts",
+			Handler:    _SwapClient_GetSwapCosts_Handler,
+		},
+		{
+			MethodName: "GetQuoteHistory",
+			Handler:    _SwapClient_GetQuoteHistory_Handler,
+		},
+		{
+			MethodName: "GetLiquidityHistory",
+			Handler:    _SwapClient_GetLiquidityHistory_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _SwapClient_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "CompareRebalance",
+			Handler:    _SwapClient_CompareRebalance_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _SwapClient_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetApiVersion",
+			Handler:    _SwapClient_GetApiVersion_Handler,
+		},
+		{
+			MethodName: "ServerHealth",
+			Handler:    _SwapClient_ServerHealth_Handler,
+		},
+		{
+			MethodName: "DebugLevel",
+			Handler:    _SwapClient_DebugLevel_Handler,
+		},
+		{
+			MethodName: "TestNotification",
+			Handler:    _SwapClient_TestNotification_Handler,
+		},
+		{
+			MethodName: "GetSupportBundle",
+			Handler:    _SwapClient_GetSupportBundle_Handler,
+		},
+		{
+			MethodName: "NewStaticAddress",
+			Handler:    _SwapClient_NewStaticAddress_Handler,
+		},
+		{
+			MethodName: "ListStaticDeposits",
+			Handler:    _SwapClient_ListStaticDeposits_Handler,
+		},
+		{
+			MethodName: "BakeMacaroon",
+			Handler:    _SwapClient_BakeMacaroon_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
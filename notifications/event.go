@@ -0,0 +1,139 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType describes the kind of event that a notification is sent for.
+type EventType string
+
+const (
+	// EventSwapInitiated is emitted when a new swap has been initiated.
+	EventSwapInitiated EventType = "swap_initiated"
+
+	// EventPreimageRevealed is emitted when we reveal the preimage of a
+	// loop out swap to sweep its htlc.
+	EventPreimageRevealed EventType = "preimage_revealed"
+
+	// EventSwapSucceeded is emitted when a swap completes successfully.
+	EventSwapSucceeded EventType = "swap_succeeded"
+
+	// EventSwapFailed is emitted when a swap fails.
+	EventSwapFailed EventType = "swap_failed"
+
+	// EventBudgetExhausted is emitted when autoloop is unable to dispatch
+	// swaps because its fee budget has been used up.
+	EventBudgetExhausted EventType = "budget_exhausted"
+
+	// EventRefundPublished is emitted when we first publish a transaction
+	// that refunds the htlc of a loop in swap.
+	EventRefundPublished EventType = "refund_published"
+
+	// EventTest is emitted on request, to check that our sinks are
+	// configured correctly.
+	EventTest EventType = "test"
+)
+
+// Event is a notification that is delivered to our sinks. It is encoded as
+// json, so the field names form part of our external api.
+type Event struct {
+	// Type is the kind of event.
+	Type EventType `json:"type"`
+
+	// Timestamp is the unix time in seconds at which the event occurred.
+	Timestamp int64 `json:"timestamp"`
+
+	// SwapHash is the hex encoded hash of the swap that the event is for.
+	// It is not set for events that do not relate to a single swap.
+	SwapHash string `json:"swap_hash,omitempty"`
+
+	// SwapType is the type of the swap, either "LOOP_OUT" or "LOOP_IN".
+	SwapType string `json:"swap_type,omitempty"`
+
+	// State is the state that the swap is in.
+	State string `json:"state,omitempty"`
+
+	// AmountSat is the amount of the swap.
+	AmountSat int64 `json:"amount_sat,omitempty"`
+
+	// ServerFeeSat is the fee paid to the server so far.
+	ServerFeeSat int64 `json:"server_fee_sat,omitempty"`
+
+	// OnchainFeeSat is the fee paid to miners so far.
+	OnchainFeeSat int64 `json:"onchain_fee_sat,omitempty"`
+
+	// OffchainFeeSat is the routing fee paid so far.
+	OffchainFeeSat int64 `json:"offchain_fee_sat,omitempty"`
+
+	// FailureReason describes why a swap failed.
+	FailureReason string `json:"failure_reason,omitempty"`
+
+	// FundsAtRisk is set for swap failures that happened after the swap
+	// could no longer be safely cancelled, so its funds may have been
+	// lost or may still need to be recovered.
+	FundsAtRisk bool `json:"funds_at_risk,omitempty"`
+
+	// RefundTxid is the txid of the transaction that refunds a loop in's
+	// htlc, set for refund published events.
+	RefundTxid string `json:"refund_txid,omitempty"`
+
+	// BudgetSat is autoloop's fee budget, set for budget exhausted events.
+	BudgetSat int64 `json:"budget_sat,omitempty"`
+}
+
+// NewEvent creates an event of the type provided, timestamped with the time
+// given.
+func NewEvent(eventType EventType, timestamp time.Time) *Event {
+	return &Event{
+		Type:      eventType,
+		Timestamp: timestamp.Unix(),
+	}
+}
+
+// Critical returns a boolean indicating whether the event requires the
+// attention of the node operator. Only critical events are delivered to our
+// messaging sinks.
+func (e *Event) Critical() bool {
+	switch e.Type {
+	case EventSwapFailed:
+		return e.FundsAtRisk
+
+	case EventRefundPublished, EventBudgetExhausted, EventTest:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Summary returns a short human readable description of the event, for sinks
+// that deliver messages rather than json.
+func (e *Event) Summary() string {
+	switch e.Type {
+	case EventSwapFailed:
+		summary := fmt.Sprintf("%v swap %v failed: %v", e.SwapType,
+			e.SwapHash, e.FailureReason)
+
+		if e.FundsAtRisk {
+			summary += ", funds may be at risk"
+		}
+
+		return summary
+
+	case EventRefundPublished:
+		return fmt.Sprintf("%v swap %v refund published: %v",
+			e.SwapType, e.SwapHash, e.RefundTxid)
+
+	case EventBudgetExhausted:
+		return fmt.Sprintf("autoloop fee budget of %v sat exhausted",
+			e.BudgetSat)
+
+	case EventTest:
+		return "test notification"
+
+	default:
+		return fmt.Sprintf("%v swap %v: %v", e.SwapType, e.SwapHash,
+			e.Type)
+	}
+}
@@ -0,0 +1,178 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// DefaultTimeout is the default amount of time that a sink has to
+	// handle an event.
+	DefaultTimeout = time.Second * 10
+
+	// DefaultRateLimit is the default minimum amount of time between
+	// critical events of the same type that we deliver to our messaging
+	// sinks.
+	DefaultRateLimit = time.Minute * 10
+
+	// eventBufferSize is the number of events that we buffer for delivery
+	// before we start dropping them.
+	eventBufferSize = 100
+)
+
+// Config contains the configuration of the notifications manager.
+type Config struct {
+	// Sinks are the destinations that events are delivered to.
+	Sinks []Sink
+
+	// MessagingSinks are destinations that only critical events are
+	// delivered to, because they notify the node operator directly.
+	MessagingSinks []Sink
+
+	// RateLimit is the minimum amount of time between critical events of
+	// the same type that are delivered to our messaging sinks. Events that
+	// arrive sooner are dropped. If zero, events are not rate limited.
+	RateLimit time.Duration
+
+	// Timeout is the amount of time that each sink has to handle an
+	// event.
+	Timeout time.Duration
+
+	// Clock is used to rate limit the events delivered to our messaging
+	// sinks.
+	Clock clock.Clock
+}
+
+// SinkResult is the outcome of delivering an event to a sink.
+type SinkResult struct {
+	// Sink is the name of the sink.
+	Sink string
+
+	// Err is the error that delivery failed with, or nil if it succeeded.
+	Err error
+}
+
+// Manager delivers events to a set of sinks. Events are queued and delivered
+// in order by a single goroutine, so that slow sinks never block the
+// subsystems that emit events.
+type Manager struct {
+	cfg *Config
+
+	events chan *Event
+
+	// lastMessages tracks the time that we last delivered each type of
+	// event to our messaging sinks. It is only accessed by our main
+	// goroutine.
+	lastMessages map[EventType]time.Time
+}
+
+// NewManager creates a notifications manager.
+func NewManager(cfg *Config) *Manager {
+	return &Manager{
+		cfg:          cfg,
+		events:       make(chan *Event, eventBufferSize),
+		lastMessages: make(map[EventType]time.Time),
+	}
+}
+
+// Notify queues an event for delivery to our sinks. This call does not block,
+// if our buffer of events is full the event is dropped.
+func (m *Manager) Notify(event *Event) {
+	select {
+	case m.events <- event:
+
+	default:
+		log.Warnf("Notification buffer full, dropping %v event for "+
+			"swap: %v", event.Type, event.SwapHash)
+	}
+}
+
+// Run delivers queued events to our sinks until the context provided is
+// cancelled.
+func (m *Manager) Run(ctx context.Context) error {
+	for {
+		select {
+		case event := <-m.events:
+			m.deliver(ctx, event)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// deliver sends an event to each of our sinks, and to our messaging sinks if
+// it is critical and not rate limited. Delivery is best-effort, so failures
+// are logged rather than retried.
+func (m *Manager) deliver(ctx context.Context, event *Event) {
+	sinks := append([]Sink(nil), m.cfg.Sinks...)
+	if len(m.cfg.MessagingSinks) > 0 && event.Critical() {
+		if m.rateLimited(event) {
+			log.Infof("Rate limiting %v event for messaging sinks",
+				event.Type)
+		} else {
+			sinks = append(sinks, m.cfg.MessagingSinks...)
+		}
+	}
+
+	for _, sink := range sinks {
+		err := m.send(ctx, sink, event)
+		if err != nil {
+			log.Errorf("Could not deliver %v event to %v: %v",
+				event.Type, sink.Name(), err)
+
+			continue
+		}
+
+		log.Debugf("Delivered %v event to %v", event.Type, sink.Name())
+	}
+}
+
+// rateLimited returns a boolean indicating whether an event should not be
+// delivered to our messaging sinks because we recently delivered an event of
+// the same type. If the event is not rate limited, it is recorded as the most
+// recent event of its type.
+func (m *Manager) rateLimited(event *Event) bool {
+	if m.cfg.RateLimit == 0 {
+		return false
+	}
+
+	now := m.cfg.Clock.Now()
+	last, ok := m.lastMessages[event.Type]
+	if ok && now.Sub(last) < m.cfg.RateLimit {
+		return true
+	}
+
+	m.lastMessages[event.Type] = now
+
+	return false
+}
+
+// send delivers an event to a single sink, bounded by our timeout.
+func (m *Manager) send(ctx context.Context, sink Sink, event *Event) error {
+	sinkCtx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	return sink.Send(sinkCtx, event)
+}
+
+// Test delivers a test event to all of our sinks immediately, bypassing our
+// queue and rate limit, and returns the outcome of delivery to each sink.
+func (m *Manager) Test(ctx context.Context) []SinkResult {
+	event := NewEvent(EventTest, time.Now())
+
+	sinks := append([]Sink(nil), m.cfg.Sinks...)
+	sinks = append(sinks, m.cfg.MessagingSinks...)
+
+	results := make([]SinkResult, 0, len(sinks))
+	for _, sink := range sinks {
+		results = append(results, SinkResult{
+			Sink: sink.Name(),
+			Err:  m.send(ctx, sink, event),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,209 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSink is a sink that delivers events to a channel.
+type mockSink struct {
+	events chan *Event
+	err    error
+}
+
+func (m *mockSink) Name() string {
+	return "mock"
+}
+
+func (m *mockSink) Send(_ context.Context, event *Event) error {
+	m.events <- event
+	return m.err
+}
+
+// TestManager tests delivery of events to multiple sinks, including sinks that
+// fail.
+func TestManager(t *testing.T) {
+	failing := &mockSink{
+		events: make(chan *Event, 1),
+		err:    errors.New("sink failed"),
+	}
+	working := &mockSink{
+		events: make(chan *Event, 1),
+	}
+
+	mgr := NewManager(&Config{
+		Sinks:   []Sink{failing, working},
+		Timeout: time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error)
+	go func() {
+		errChan <- mgr.Run(ctx)
+	}()
+
+	event := NewEvent(EventSwapSucceeded, time.Unix(100, 0))
+	event.SwapHash = "abcd"
+	mgr.Notify(event)
+
+	// The event should be delivered to both sinks, even though the first
+	// one fails.
+	require.Equal(t, event, <-failing.events)
+	require.Equal(t, event, <-working.events)
+
+	cancel()
+	require.True(t, errors.Is(<-errChan, context.Canceled))
+}
+
+// TestMessagingSinks tests that only critical events are delivered to our
+// messaging sinks, and that they are rate limited by event type.
+func TestMessagingSinks(t *testing.T) {
+	var (
+		testClock = clock.NewTestClock(time.Unix(100, 0))
+		sink      = &mockSink{
+			events: make(chan *Event, 10),
+		}
+		messaging = &mockSink{
+			events: make(chan *Event, 10),
+		}
+	)
+
+	mgr := NewManager(&Config{
+		Sinks:          []Sink{sink},
+		MessagingSinks: []Sink{messaging},
+		RateLimit:      time.Minute,
+		Timeout:        time.Second,
+		Clock:          testClock,
+	})
+
+	var (
+		ctx       = context.Background()
+		succeeded = NewEvent(EventSwapSucceeded, testClock.Now())
+		exhausted = NewEvent(EventBudgetExhausted, testClock.Now())
+		refund    = NewEvent(EventRefundPublished, testClock.Now())
+		failed    = NewEvent(EventSwapFailed, testClock.Now())
+		atRisk    = NewEvent(EventSwapFailed, testClock.Now())
+	)
+	atRisk.FundsAtRisk = true
+
+	// Events that are not critical are only delivered to our regular
+	// sinks.
+	mgr.deliver(ctx, succeeded)
+	mgr.deliver(ctx, failed)
+	require.Len(t, sink.events, 2)
+	require.Len(t, messaging.events, 0)
+
+	// Critical events are delivered to both.
+	mgr.deliver(ctx, exhausted)
+	mgr.deliver(ctx, atRisk)
+	require.Len(t, sink.events, 4)
+	require.Equal(t, exhausted, <-messaging.events)
+	require.Equal(t, atRisk, <-messaging.events)
+
+	// A second event of the same type within our rate limit is only
+	// delivered to our regular sinks, but events of other types are not
+	// affected.
+	mgr.deliver(ctx, exhausted)
+	mgr.deliver(ctx, refund)
+	require.Len(t, sink.events, 6)
+	require.Equal(t, refund, <-messaging.events)
+	require.Len(t, messaging.events, 0)
+
+	// Once our rate limit has passed, the event type is delivered again.
+	testClock.SetTime(testClock.Now().Add(time.Minute))
+	mgr.deliver(ctx, exhausted)
+	require.Equal(t, exhausted, <-messaging.events)
+}
+
+// TestManagerTest tests delivery of test events to all of our sinks.
+func TestManagerTest(t *testing.T) {
+	var (
+		sinkErr = errors.New("sink failed")
+		failing = &mockSink{
+			events: make(chan *Event, 1),
+			err:    sinkErr,
+		}
+		messaging = &mockSink{
+			events: make(chan *Event, 1),
+		}
+	)
+
+	mgr := NewManager(&Config{
+		Sinks:          []Sink{failing},
+		MessagingSinks: []Sink{messaging},
+		RateLimit:      time.Minute,
+		Timeout:        time.Second,
+		Clock:          clock.NewTestClock(time.Unix(100, 0)),
+	})
+
+	results := mgr.Test(context.Background())
+	require.Equal(t, []SinkResult{
+		{Sink: "mock", Err: sinkErr},
+		{Sink: "mock"},
+	}, results)
+
+	require.Equal(t, EventTest, (<-failing.events).Type)
+	require.Equal(t, EventTest, (<-messaging.events).Type)
+}
+
+// TestNotifyFull tests that notify does not block when our buffer is full.
+func TestNotifyFull(t *testing.T) {
+	mgr := NewManager(&Config{
+		Timeout: time.Second,
+	})
+
+	for i := 0; i < eventBufferSize+1; i++ {
+		mgr.Notify(NewEvent(EventSwapInitiated, time.Now()))
+	}
+
+	require.Len(t, mgr.events, eventBufferSize)
+}
+
+// TestWebhookSink tests posting of events to a webhook.
+func TestWebhookSink(t *testing.T) {
+	received := make(chan *Event, 1)
+
+	// Our server fails requests for events that do not have a swap hash.
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var event Event
+			err := json.NewDecoder(r.Body).Decode(&event)
+			require.NoError(t, err)
+
+			received <- &event
+
+			if event.SwapHash == "" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		},
+	))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+
+	event := NewEvent(EventSwapFailed, time.Unix(100, 0))
+	event.SwapHash = "abcd"
+	event.AmountSat = 100000
+	event.FailureReason = "Timeout"
+
+	err := sink.Send(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, event, <-received)
+
+	// A non-2xx status should be reported as an error.
+	event = NewEvent(EventBudgetExhausted, time.Unix(100, 0))
+	err = sink.Send(context.Background(), event)
+	require.Error(t, err)
+	<-received
+}
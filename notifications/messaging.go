@@ -0,0 +1,256 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// telegramAPI is the base url of the Telegram bot api.
+	telegramAPI = "https://api.telegram.org"
+
+	// messagePrefix is prepended to the summaries of the events that we
+	// deliver as messages, so that they can be identified at a glance.
+	messagePrefix = "loop: "
+)
+
+// EmailSink delivers events by email, using a smtp server. Connections to the
+// server are upgraded with STARTTLS if the server supports it.
+type EmailSink struct {
+	addr     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// A compile time assertion to ensure that EmailSink meets the Sink interface.
+var _ Sink = (*EmailSink)(nil)
+
+// NewEmailSink creates a sink that sends events from the address provided to
+// a set of recipients, using the smtp server at the host:port address given.
+// If a username is set, we authenticate with the server using plain auth.
+func NewEmailSink(addr, username, password, from string,
+	to []string) *EmailSink {
+
+	return &EmailSink{
+		addr:     addr,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// Name returns the name of the sink.
+func (e *EmailSink) Name() string {
+	return "email"
+}
+
+// Send emails an event to our recipients. The event's summary is used as the
+// subject, and its json encoding is included in the body.
+func (e *EmailSink) Send(ctx context.Context, event *Event) error {
+	host, _, err := net.SplitHostPort(e.addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", e.addr)
+	if err != nil {
+		return err
+	}
+
+	// The smtp client does not accept a context, so we bound the whole
+	// exchange by our context's deadline instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err := client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.username != "" {
+		auth := smtp.PlainAuth("", e.username, e.password, host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(e.from); err != nil {
+		return err
+	}
+
+	for _, to := range e.to {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	msg, err := e.message(event)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message formats an event as an email message.
+func (e *EmailSink) message(event *Event) ([]byte, error) {
+	body, err := json.MarshalIndent(event, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %v\r\n", e.from)
+	fmt.Fprintf(&b, "To: %v\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&b, "Subject: %v%v\r\n", messagePrefix, event.Summary())
+	fmt.Fprintf(&b, "Date: %v\r\n",
+		time.Unix(event.Timestamp, 0).Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%v\r\n\r\n", event.Summary())
+	b.Write(bytes.ReplaceAll(body, []byte("\n"), []byte("\r\n")))
+	b.WriteString("\r\n")
+
+	return b.Bytes(), nil
+}
+
+// TelegramSink delivers events as messages from a Telegram bot.
+type TelegramSink struct {
+	apiURL string
+	token  string
+	chatID string
+	client *http.Client
+}
+
+// A compile time assertion to ensure that TelegramSink meets the Sink
+// interface.
+var _ Sink = (*TelegramSink)(nil)
+
+// NewTelegramSink creates a sink that sends events to the chat provided, from
+// the bot that the token belongs to.
+func NewTelegramSink(token, chatID string) *TelegramSink {
+	return &TelegramSink{
+		apiURL: telegramAPI,
+		token:  token,
+		chatID: chatID,
+		client: &http.Client{},
+	}
+}
+
+// Name returns the name of the sink.
+func (t *TelegramSink) Name() string {
+	return "telegram"
+}
+
+// Send sends the summary of an event to our chat.
+func (t *TelegramSink) Send(ctx context.Context, event *Event) error {
+	msg := struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}{
+		ChatID: t.chatID,
+		Text:   messagePrefix + event.Summary(),
+	}
+
+	sendURL := fmt.Sprintf("%v/bot%v/sendMessage", t.apiURL, t.token)
+
+	return sendJSON(ctx, t.client, http.MethodPost, sendURL, nil, msg)
+}
+
+// MatrixSink delivers events as messages to a Matrix room.
+type MatrixSink struct {
+	// txnCount is used to create a unique transaction id for each message
+	// that we send, which the homeserver uses to deduplicate retries. It
+	// must be used atomically, so it is kept first in the struct to ensure
+	// 64-bit alignment.
+	txnCount uint64
+
+	homeserver  string
+	accessToken string
+	room        string
+	client      *http.Client
+}
+
+// A compile time assertion to ensure that MatrixSink meets the Sink
+// interface.
+var _ Sink = (*MatrixSink)(nil)
+
+// NewMatrixSink creates a sink that sends events to a room on the homeserver
+// provided, as the user that the access token belongs to.
+func NewMatrixSink(homeserver, accessToken, room string) *MatrixSink {
+	return &MatrixSink{
+		homeserver:  strings.TrimSuffix(homeserver, "/"),
+		accessToken: accessToken,
+		room:        room,
+		client:      &http.Client{},
+	}
+}
+
+// Name returns the name of the sink.
+func (m *MatrixSink) Name() string {
+	return "matrix"
+}
+
+// Send sends the summary of an event to our room.
+func (m *MatrixSink) Send(ctx context.Context, event *Event) error {
+	msg := struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	}{
+		MsgType: "m.text",
+		Body:    messagePrefix + event.Summary(),
+	}
+
+	txnID := fmt.Sprintf("loop-%v-%v", time.Now().UnixNano(),
+		atomic.AddUint64(&m.txnCount, 1))
+
+	sendURL := fmt.Sprintf(
+		"%v/_matrix/client/v3/rooms/%v/send/m.room.message/%v",
+		m.homeserver, url.PathEscape(m.room), txnID,
+	)
+
+	header := http.Header{
+		"Authorization": []string{"Bearer " + m.accessToken},
+	}
+
+	return sendJSON(ctx, m.client, http.MethodPut, sendURL, header, msg)
+}
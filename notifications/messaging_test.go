@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTelegramSink tests sending of event summaries to a Telegram chat.
+func TestTelegramSink(t *testing.T) {
+	type message struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+
+	received := make(chan *message, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/bottoken/sendMessage", r.URL.Path)
+
+			var msg message
+			err := json.NewDecoder(r.Body).Decode(&msg)
+			require.NoError(t, err)
+
+			received <- &msg
+		},
+	))
+	defer server.Close()
+
+	sink := NewTelegramSink("token", "1234")
+	sink.apiURL = server.URL
+
+	event := NewEvent(EventBudgetExhausted, time.Unix(100, 0))
+	event.BudgetSat = 5000
+
+	err := sink.Send(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, &message{
+		ChatID: "1234",
+		Text:   "loop: autoloop fee budget of 5000 sat exhausted",
+	}, <-received)
+}
+
+// TestMatrixSink tests sending of event summaries to a Matrix room, using a
+// unique transaction id for each message.
+func TestMatrixSink(t *testing.T) {
+	type message struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	}
+
+	var (
+		received = make(chan *message, 2)
+		txnIDs   = make(chan string, 2)
+		prefix   = "/_matrix/client/v3/rooms/!room:example.org/send/" +
+			"m.room.message/"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			auth := r.Header.Get("Authorization")
+			require.Equal(t, "Bearer token", auth)
+			require.True(t, strings.HasPrefix(r.URL.Path, prefix))
+
+			var msg message
+			err := json.NewDecoder(r.Body).Decode(&msg)
+			require.NoError(t, err)
+
+			received <- &msg
+			txnIDs <- strings.TrimPrefix(r.URL.Path, prefix)
+		},
+	))
+	defer server.Close()
+
+	sink := NewMatrixSink(server.URL+"/", "token", "!room:example.org")
+
+	event := NewEvent(EventRefundPublished, time.Unix(100, 0))
+	event.SwapType = "LOOP_IN"
+	event.SwapHash = "abcd"
+	event.RefundTxid = "efgh"
+
+	for i := 0; i < 2; i++ {
+		err := sink.Send(context.Background(), event)
+		require.NoError(t, err)
+		require.Equal(t, &message{
+			MsgType: "m.text",
+			Body: "loop: LOOP_IN swap abcd refund published: " +
+				"efgh",
+		}, <-received)
+	}
+
+	require.NotEqual(t, <-txnIDs, <-txnIDs)
+}
@@ -0,0 +1,140 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+// Sink is a destination that events are delivered to.
+type Sink interface {
+	// Name returns a human readable name for the sink, used for logging.
+	Name() string
+
+	// Send delivers an event to the sink.
+	Send(ctx context.Context, event *Event) error
+}
+
+// WebhookSink delivers events by posting them as json to a http endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// A compile time assertion to ensure that WebhookSink meets the Sink
+// interface.
+var _ Sink = (*WebhookSink)(nil)
+
+// NewWebhookSink creates a sink that posts events to the url provided.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+// Name returns the name of the sink.
+func (w *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Send posts an event to our webhook, failing if the endpoint does not
+// respond with a 2xx status code.
+func (w *WebhookSink) Send(ctx context.Context, event *Event) error {
+	return sendJSON(ctx, w.client, http.MethodPost, w.url, nil, event)
+}
+
+// sendJSON sends a value to the url provided as json, with any additional
+// headers provided, failing if the endpoint does not respond with a 2xx
+// status code.
+func sendJSON(ctx context.Context, client *http.Client, method, url string,
+	header http.Header, value interface{}) error {
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, method, url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status: %v",
+			resp.Status)
+	}
+
+	return nil
+}
+
+// CommandSink delivers events by executing a command. The event is written to
+// the command's stdin as json, and its type and swap hash are set in the
+// LOOP_EVENT_TYPE and LOOP_SWAP_HASH environment variables.
+type CommandSink struct {
+	path string
+}
+
+// A compile time assertion to ensure that CommandSink meets the Sink
+// interface.
+var _ Sink = (*CommandSink)(nil)
+
+// NewCommandSink creates a sink that executes the command at the path
+// provided for every event.
+func NewCommandSink(path string) *CommandSink {
+	return &CommandSink{
+		path: path,
+	}
+}
+
+// Name returns the name of the sink.
+func (c *CommandSink) Name() string {
+	return "command"
+}
+
+// Send executes our command for an event, failing if it exits with a non-zero
+// exit code.
+func (c *CommandSink) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, c.path)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(
+		os.Environ(),
+		fmt.Sprintf("LOOP_EVENT_TYPE=%v", event.Type),
+		fmt.Sprintf("LOOP_SWAP_HASH=%v", event.SwapHash),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command failed: %w, output: %s", err,
+			output)
+	}
+
+	return nil
+}
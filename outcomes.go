@@ -0,0 +1,89 @@
+package loop
+
+import (
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+)
+
+// SwapOutcomes describes whether a pending swap can still be safely cancelled,
+// and the final states that it may still reach.
+type SwapOutcomes struct {
+	// Cancellable is true if the swap has not reached its point of no
+	// return, so it can be abandoned without putting the swap amount at
+	// risk. For loop out swaps, this is until we reveal our preimage. For
+	// loop in swaps, this is until the htlc is published.
+	Cancellable bool
+
+	// Outcomes are the final states that the swap may still reach, other
+	// than being abandoned. Temporary failures are not included, because
+	// swaps are resumed after them.
+	Outcomes []loopdb.SwapState
+}
+
+// PendingOutcomes returns whether the swap provided can still be cancelled and
+// the final states that it may reach. It returns nil if the swap is no longer
+// pending.
+func PendingOutcomes(info *SwapInfo) *SwapOutcomes {
+	if info.State.Type() != loopdb.StateTypePending {
+		return nil
+	}
+
+	switch info.SwapType {
+	case swap.TypeOut:
+		return loopOutOutcomes(info.State)
+
+	case swap.TypeIn:
+		return loopInOutcomes(info.State, info.ExternalHtlc)
+
+	default:
+		return nil
+	}
+}
+
+// loopOutOutcomes returns the outcomes of a pending loop out. Once we have
+// revealed our preimage, the swap either completes or the server sweeps its
+// htlc with the timeout path.
+func loopOutOutcomes(state loopdb.SwapState) *SwapOutcomes {
+	if state == loopdb.StatePreimageRevealed {
+		return &SwapOutcomes{
+			Outcomes: []loopdb.SwapState{
+				loopdb.StateSuccess,
+				loopdb.StateFailSweepTimeout,
+			},
+		}
+	}
+
+	return &SwapOutcomes{
+		Cancellable: true,
+		Outcomes: []loopdb.SwapState{
+			loopdb.StateSuccess,
+			loopdb.StateFailOffchainPayments,
+			loopdb.StateFailTimeout,
+			loopdb.StateFailInsufficientValue,
+			loopdb.StateFailSweepTimeout,
+		},
+	}
+}
+
+// loopInOutcomes returns the outcomes of a pending loop in. Once our htlc has
+// been published, the swap either completes or we refund the htlc once it
+// times out. Loop ins with an external htlc move to the published state
+// immediately, because we cannot tell when the htlc is published, and may fail
+// if it is funded with the wrong amount.
+func loopInOutcomes(state loopdb.SwapState, external bool) *SwapOutcomes {
+	outcomes := &SwapOutcomes{
+		Cancellable: state == loopdb.StateInitiated,
+		Outcomes: []loopdb.SwapState{
+			loopdb.StateSuccess,
+			loopdb.StateFailTimeout,
+		},
+	}
+
+	if external && state != loopdb.StateInvoiceSettled {
+		outcomes.Outcomes = append(
+			outcomes.Outcomes, loopdb.StateFailIncorrectHtlcAmt,
+		)
+	}
+
+	return outcomes
+}
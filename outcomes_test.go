@@ -0,0 +1,129 @@
+package loop
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPendingOutcomes tests reporting of whether swaps can be cancelled, and
+// the final states that they may still reach.
+func TestPendingOutcomes(t *testing.T) {
+	newSwap := func(swapType swap.Type, state loopdb.SwapState,
+		external bool) *SwapInfo {
+
+		return &SwapInfo{
+			SwapType: swapType,
+			SwapStateData: loopdb.SwapStateData{
+				State: state,
+			},
+			ExternalHtlc: external,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		swap     *SwapInfo
+		outcomes *SwapOutcomes
+	}{
+		{
+			name: "swap completed",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateSuccess, false,
+			),
+			outcomes: nil,
+		},
+		{
+			name: "loop out initiated",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StateInitiated, false,
+			),
+			outcomes: &SwapOutcomes{
+				Cancellable: true,
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailOffchainPayments,
+					loopdb.StateFailTimeout,
+					loopdb.StateFailInsufficientValue,
+					loopdb.StateFailSweepTimeout,
+				},
+			},
+		},
+		{
+			name: "loop out preimage revealed",
+			swap: newSwap(
+				swap.TypeOut, loopdb.StatePreimageRevealed,
+				false,
+			),
+			outcomes: &SwapOutcomes{
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailSweepTimeout,
+				},
+			},
+		},
+		{
+			name: "loop in initiated",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateInitiated, false,
+			),
+			outcomes: &SwapOutcomes{
+				Cancellable: true,
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailTimeout,
+				},
+			},
+		},
+		{
+			name: "loop in htlc published",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateHtlcPublished, false,
+			),
+			outcomes: &SwapOutcomes{
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailTimeout,
+				},
+			},
+		},
+		{
+			name: "external loop in htlc published",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateHtlcPublished, true,
+			),
+			outcomes: &SwapOutcomes{
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailTimeout,
+					loopdb.StateFailIncorrectHtlcAmt,
+				},
+			},
+		},
+		{
+			name: "external loop in invoice settled",
+			swap: newSwap(
+				swap.TypeIn, loopdb.StateInvoiceSettled, true,
+			),
+			outcomes: &SwapOutcomes{
+				Outcomes: []loopdb.SwapState{
+					loopdb.StateSuccess,
+					loopdb.StateFailTimeout,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t, testCase.outcomes,
+				PendingOutcomes(testCase.swap),
+			)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package loop
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+var (
+	// recoveryConfTimeout is the maximum amount of time that we wait for
+	// lnd to find the confirmed htlc of a swap that we are recovering.
+	recoveryConfTimeout = time.Minute
+
+	// ErrRecoverySwapNotFound is returned when we try to recover a swap
+	// that is not in our store.
+	ErrRecoverySwapNotFound = errors.New("swap not found")
+
+	// ErrRecoveryHtlcNotFound is returned when we could not find a
+	// confirmed htlc for the swap that we are recovering.
+	ErrRecoveryHtlcNotFound = errors.New("confirmed swap htlc not found")
+
+	// ErrRecoveryHtlcNotExpired is returned when we try to recover a loop
+	// in swap before its htlc has expired, so the timeout path cannot be
+	// spent yet.
+	ErrRecoveryHtlcNotExpired = errors.New("loop in htlc has not " +
+		"expired yet")
+
+	// ErrRecoveryFeeTooHigh is returned when the fee for a recovery sweep
+	// at the requested fee rate exceeds the value of the htlc.
+	ErrRecoveryFeeTooHigh = errors.New("sweep fee exceeds htlc value")
+)
+
+// RecoverSweepRequest contains the parameters for a manual sweep of a swap's
+// on-chain htlc.
+type RecoverSweepRequest struct {
+	// SwapHash is the hash of the swap to recover.
+	SwapHash lntypes.Hash
+
+	// DestAddr is the address to sweep the htlc to. If nil, an address is
+	// obtained from lnd's wallet.
+	DestAddr btcutil.Address
+
+	// FeeRate is the fee rate to publish the sweep with.
+	FeeRate chainfee.SatPerKWeight
+
+	// Publish indicates whether the sweep should be published. If false,
+	// the sweep is only created and returned.
+	Publish bool
+}
+
+// RecoverSweepResult contains the sweep that was created for a swap in
+// recovery mode.
+type RecoverSweepResult struct {
+	// SweepTx is the transaction that sweeps the swap's htlc.
+	SweepTx *wire.MsgTx
+
+	// HtlcOutpoint is the outpoint of the htlc that is swept.
+	HtlcOutpoint wire.OutPoint
+
+	// HtlcValue is the value of the htlc that is swept.
+	HtlcValue btcutil.Amount
+
+	// Fee is the on-chain fee paid by the sweep.
+	Fee btcutil.Amount
+
+	// DestAddr is the address that the htlc is swept to.
+	DestAddr btcutil.Address
+
+	// Published is true if the sweep was published.
+	Published bool
+}
+
+// recoverySpend describes how the htlc of a swap that we are recovering is
+// spent.
+type recoverySpend struct {
+	// contract is the contract of the swap.
+	contract *loopdb.SwapContract
+
+	// htlcs contains the htlcs that the swap's funds may have been
+	// published to.
+	htlcs []*swap.Htlc
+
+	// success is true if we spend the success path of the htlc with our
+	// preimage (loop out), and false if we spend the timeout path (loop
+	// in).
+	success bool
+}
+
+// RecoverSweep manually sweeps the on-chain htlc of a swap using the data in
+// our store, bypassing the swap's state machine. For loop out swaps the htlc
+// is swept using the preimage, for loop in swaps it is reclaimed using the
+// timeout path once the htlc has expired. This is intended for recovery when
+// the automatic sweep fails, for example due to corrupted swap state.
+func (s *Client) RecoverSweep(ctx context.Context,
+	req *RecoverSweepRequest) (*RecoverSweepResult, error) {
+
+	if req.FeeRate < chainfee.FeePerKwFloor {
+		return nil, fmt.Errorf("fee rate %v below minimum %v",
+			req.FeeRate, chainfee.FeePerKwFloor)
+	}
+
+	spend, err := s.getRecoverySpend(ctx, req.SwapHash)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.lndServices.Client.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	height := int32(info.BlockHeight)
+
+	if !spend.success && height < spend.contract.CltvExpiry {
+		return nil, fmt.Errorf("%w: expires at height %v, current "+
+			"height %v", ErrRecoveryHtlcNotExpired,
+			spend.contract.CltvExpiry, height)
+	}
+
+	htlc, htlcOutpoint, htlcValue, err := s.findRecoveryHtlc(ctx, spend)
+	if err != nil {
+		return nil, err
+	}
+
+	destAddr := req.DestAddr
+	if destAddr == nil {
+		destAddr, err = s.lndServices.WalletKit.NextAddr(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		addInputEstimate func(*input.TxWeightEstimator)
+		witnessFunc      func(sig []byte) (wire.TxWitness, error)
+		sequence         uint32
+		key              [33]byte
+	)
+
+	if spend.success {
+		addInputEstimate = htlc.AddSuccessToEstimator
+		witnessFunc = func(sig []byte) (wire.TxWitness, error) {
+			return htlc.GenSuccessWitness(
+				sig, spend.contract.Preimage,
+			)
+		}
+		sequence = htlc.SuccessSequence()
+		key = spend.contract.ReceiverKey
+	} else {
+		addInputEstimate = htlc.AddTimeoutToEstimator
+		witnessFunc = func(sig []byte) (wire.TxWitness, error) {
+			return htlc.GenTimeoutWitness(sig), nil
+		}
+		key = spend.contract.SenderKey
+	}
+
+	fee, err := s.sweeper.GetSweepFeeForRate(
+		addInputEstimate, destAddr, req.FeeRate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fee >= htlcValue {
+		return nil, fmt.Errorf("%w: fee %v, htlc value %v",
+			ErrRecoveryFeeTooHigh, fee, htlcValue)
+	}
+
+	sweepTx, err := s.sweeper.CreateSweepTx(
+		ctx, height, sequence, htlc, htlcOutpoint, key,
+		spend.contract.KeyLocator, witnessFunc, htlcValue, fee,
+		destAddr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecoverSweepResult{
+		SweepTx:      sweepTx,
+		HtlcOutpoint: htlcOutpoint,
+		HtlcValue:    htlcValue,
+		Fee:          fee,
+		DestAddr:     destAddr,
+	}
+
+	if !req.Publish {
+		return result, nil
+	}
+
+	log.Infof("Publishing recovery sweep %v for swap %v to %v with fee %v",
+		sweepTx.TxHash(), req.SwapHash, destAddr, fee)
+
+	err = s.lndServices.WalletKit.PublishTransaction(
+		ctx, sweepTx, s.sweeper.TxLabel(
+			labels.RecoverySweep(swap.ShortHash(&req.SwapHash)),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Published = true
+
+	return result, nil
+}
+
+// getRecoverySpend looks up the swap with the hash provided in our store and
+// returns how its htlc should be spent.
+func (s *Client) getRecoverySpend(ctx context.Context,
+	hash lntypes.Hash) (*recoverySpend, error) {
+
+	loopOuts, err := s.Store.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, loopOut := range loopOuts {
+		if loopOut.Hash != hash {
+			continue
+		}
+
+		contract := &loopOut.Contract.SwapContract
+		htlc, err := s.recoveryHtlc(hash, contract, swap.HtlcP2WSH)
+		if err != nil {
+			return nil, err
+		}
+
+		return &recoverySpend{
+			contract: contract,
+			htlcs:    []*swap.Htlc{htlc},
+			success:  true,
+		}, nil
+	}
+
+	loopIns, err := s.Store.FetchLoopInSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, loopIn := range loopIns {
+		if loopIn.Hash != hash {
+			continue
+		}
+
+		// Loop in htlcs may have been published to either output
+		// type, so we look for both.
+		contract := &loopIn.Contract.SwapContract
+		spend := &recoverySpend{
+			contract: contract,
+		}
+
+		outputTypes := []swap.HtlcOutputType{
+			swap.HtlcP2WSH, swap.HtlcNP2WSH,
+		}
+		for _, outputType := range outputTypes {
+			htlc, err := s.recoveryHtlc(hash, contract, outputType)
+			if err != nil {
+				return nil, err
+			}
+
+			spend.htlcs = append(spend.htlcs, htlc)
+		}
+
+		return spend, nil
+	}
+
+	return nil, ErrRecoverySwapNotFound
+}
+
+// recoveryHtlc composes the htlc of the output type provided for a swap.
+func (s *Client) recoveryHtlc(hash lntypes.Hash, contract *loopdb.SwapContract,
+	outputType swap.HtlcOutputType) (*swap.Htlc, error) {
+
+	return swap.NewHtlc(
+		contract.HtlcVersion,
+		contract.CltvExpiry, contract.SenderKey, contract.ReceiverKey,
+		hash, outputType, s.lndServices.ChainParams,
+	)
+}
+
+// findRecoveryHtlc waits for lnd to report the confirmation of any of the
+// htlcs of the swap we are recovering, and returns the htlc that confirmed
+// along with its outpoint and value.
+func (s *Client) findRecoveryHtlc(ctx context.Context,
+	spend *recoverySpend) (*swap.Htlc, wire.OutPoint, btcutil.Amount,
+	error) {
+
+	ctx, cancel := context.WithTimeout(ctx, recoveryConfTimeout)
+	defer cancel()
+
+	type htlcConf struct {
+		htlc *swap.Htlc
+		conf *chainntnfs.TxConfirmation
+	}
+
+	var (
+		confChan = make(chan *htlcConf, len(spend.htlcs))
+		errChan  = make(chan error, len(spend.htlcs))
+		notifier = s.lndServices.ChainNotifier
+	)
+
+	for _, htlc := range spend.htlcs {
+		htlcConfChan, htlcErrChan, err :=
+			notifier.RegisterConfirmationsNtfn(
+				ctx, nil, htlc.PkScript, 1,
+				spend.contract.InitiationHeight,
+			)
+		if err != nil {
+			return nil, wire.OutPoint{}, 0, err
+		}
+
+		go func(htlc *swap.Htlc) {
+			select {
+			case conf := <-htlcConfChan:
+				confChan <- &htlcConf{htlc: htlc, conf: conf}
+
+			case err := <-htlcErrChan:
+				errChan <- err
+
+			case <-ctx.Done():
+			}
+		}(htlc)
+	}
+
+	var confirmed *htlcConf
+	select {
+	case confirmed = <-confChan:
+
+	case err := <-errChan:
+		return nil, wire.OutPoint{}, 0, err
+
+	case <-ctx.Done():
+		return nil, wire.OutPoint{}, 0, ErrRecoveryHtlcNotFound
+	}
+
+	for i, txOut := range confirmed.conf.Tx.TxOut {
+		if !bytes.Equal(txOut.PkScript, confirmed.htlc.PkScript) {
+			continue
+		}
+
+		outpoint := wire.OutPoint{
+			Hash:  confirmed.conf.Tx.TxHash(),
+			Index: uint32(i),
+		}
+
+		return confirmed.htlc, outpoint, btcutil.Amount(txOut.Value),
+			nil
+	}
+
+	return nil, wire.OutPoint{}, 0, ErrRecoveryHtlcNotFound
+}
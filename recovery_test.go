@@ -0,0 +1,172 @@
+package loop
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// newRecoveryTestContract returns a swap contract that can be used to test
+// recovery sweeps.
+func newRecoveryTestContract(cltvExpiry int32) loopdb.SwapContract {
+	_, senderPubKey := test.CreateKey(1)
+	var senderKey [33]byte
+	copy(senderKey[:], senderPubKey.SerializeCompressed())
+
+	_, receiverPubKey := test.CreateKey(2)
+	var receiverKey [33]byte
+	copy(receiverKey[:], receiverPubKey.SerializeCompressed())
+
+	return loopdb.SwapContract{
+		Preimage:         testPreimage,
+		AmountRequested:  50000,
+		CltvExpiry:       cltvExpiry,
+		ReceiverKey:      receiverKey,
+		SenderKey:        senderKey,
+		InitiationHeight: 500,
+		ProtocolVersion:  loopdb.CurrentInternalProtocolVersion,
+		HtlcVersion:      swap.HtlcV2,
+	}
+}
+
+// TestRecoverSweepErrors tests the cases in which we refuse to create a
+// recovery sweep.
+func TestRecoverSweepErrors(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	store := newStoreMock(t)
+
+	// Add a loop in swap that only expires after the mock's current
+	// height.
+	hash := lntypes.Hash(sha256.Sum256(testPreimage[:]))
+	store.loopInSwaps[hash] = &loopdb.LoopInContract{
+		SwapContract: newRecoveryTestContract(lnd.Height + 100),
+	}
+
+	client := newSwapClient(&clientConfig{
+		LndServices: &lnd.LndServices,
+		Server:      newServerMock(lnd),
+		Store:       store,
+	})
+
+	ctx := context.Background()
+
+	_, err := client.RecoverSweep(ctx, &RecoverSweepRequest{
+		SwapHash: lntypes.Hash{9, 9, 9},
+		FeeRate:  chainfee.FeePerKwFloor,
+	})
+	require.True(t, errors.Is(err, ErrRecoverySwapNotFound))
+
+	_, err = client.RecoverSweep(ctx, &RecoverSweepRequest{
+		SwapHash: hash,
+		FeeRate:  chainfee.FeePerKwFloor,
+	})
+	require.True(t, errors.Is(err, ErrRecoveryHtlcNotExpired))
+
+	_, err = client.RecoverSweep(ctx, &RecoverSweepRequest{
+		SwapHash: hash,
+		FeeRate:  chainfee.FeePerKwFloor - 1,
+	})
+	require.Error(t, err)
+}
+
+// TestRecoverSweepLoopOut tests manually sweeping the htlc of a loop out swap
+// with our preimage.
+func TestRecoverSweepLoopOut(t *testing.T) {
+	defer test.Guard(t)()
+
+	lnd := test.NewMockLnd()
+	store := newStoreMock(t)
+
+	hash := lntypes.Hash(sha256.Sum256(testPreimage[:]))
+	contract := newRecoveryTestContract(lnd.Height + 100)
+	store.loopOutSwaps[hash] = &loopdb.LoopOutContract{
+		SwapContract: contract,
+		DestAddr:     testAddr,
+	}
+
+	client := newSwapClient(&clientConfig{
+		LndServices: &lnd.LndServices,
+		Server:      newServerMock(lnd),
+		Store:       store,
+	})
+
+	dest := test.GetDestAddr(t, 0)
+	feeRate := chainfee.SatPerKWeight(1000)
+
+	type result struct {
+		sweep *RecoverSweepResult
+		err   error
+	}
+	resultChan := make(chan result, 1)
+
+	go func() {
+		sweep, err := client.RecoverSweep(
+			context.Background(), &RecoverSweepRequest{
+				SwapHash: hash,
+				DestAddr: dest,
+				FeeRate:  feeRate,
+				Publish:  true,
+			},
+		)
+		resultChan <- result{sweep: sweep, err: err}
+	}()
+
+	// The htlc that we look for is the P2WSH htlc of our swap.
+	htlc, err := swap.NewHtlc(
+		contract.HtlcVersion, contract.CltvExpiry, contract.SenderKey,
+		contract.ReceiverKey, hash, swap.HtlcP2WSH, lnd.ChainParams,
+	)
+	require.NoError(t, err)
+
+	reg := <-lnd.RegisterConfChannel
+	require.Equal(t, htlc.PkScript, reg.PkScript)
+	require.Equal(t, contract.InitiationHeight, reg.HeightHint)
+
+	htlcValue := btcutil.Amount(50000)
+	htlcTx := &wire.MsgTx{}
+	htlcTx.AddTxIn(&wire.TxIn{})
+	htlcTx.AddTxOut(&wire.TxOut{
+		PkScript: htlc.PkScript,
+		Value:    int64(htlcValue),
+	})
+
+	lnd.ConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: htlcTx,
+	}
+
+	<-lnd.SignOutputRawChannel
+	sweepTx := <-lnd.TxPublishChannel
+
+	res := <-resultChan
+	require.NoError(t, res.err)
+	require.True(t, res.sweep.Published)
+	require.Equal(t, sweepTx.TxHash(), res.sweep.SweepTx.TxHash())
+
+	expectedOutpoint := wire.OutPoint{
+		Hash:  htlcTx.TxHash(),
+		Index: 0,
+	}
+	require.Equal(t, expectedOutpoint, res.sweep.HtlcOutpoint)
+	require.Equal(
+		t, expectedOutpoint, sweepTx.TxIn[0].PreviousOutPoint,
+	)
+
+	require.Len(t, sweepTx.TxOut, 1)
+	require.Equal(
+		t, int64(htlcValue-res.sweep.Fee), sweepTx.TxOut[0].Value,
+	)
+	require.NotZero(t, res.sweep.Fee)
+}
@@ -0,0 +1,266 @@
+package loop
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTermsCacheTTL is the default amount of time that we cache the swap
+// server's terms for.
+const DefaultTermsCacheTTL = time.Minute * 5
+
+// ServerHealth describes the health of our connection to the swap server,
+// based on the outcomes of our most recent calls to it.
+type ServerHealth struct {
+	// Reachable indicates whether we reached the server on our most
+	// recent call to it. It is false if we have not called the server
+	// yet.
+	Reachable bool
+
+	// ProtocolVersion is the rpc protocol version that we use to talk to
+	// the server.
+	ProtocolVersion looprpc.ProtocolVersion
+
+	// LastSuccess is the time of our last call that reached the server.
+	// It is zero if we have not reached the server yet.
+	LastSuccess time.Time
+
+	// LastFailure is the time of our last call that could not reach the
+	// server. It is zero if we have always reached the server.
+	LastFailure time.Time
+
+	// LastError is the error returned by our last call that could not
+	// reach the server.
+	LastError error
+}
+
+// cachedSwapServerClient wraps a swap server client, caching the server's
+// terms for a limited period of time and tracking the outcomes of our calls
+// to the server so that we can report on its health.
+type cachedSwapServerClient struct {
+	swapServerClient
+
+	// termsTTL is the amount of time that we cache terms for. If it is
+	// zero, terms are not cached.
+	termsTTL time.Duration
+
+	clock clock.Clock
+
+	mu             sync.Mutex
+	outTerms       *LoopOutTerms
+	outTermsExpiry time.Time
+	inTerms        *LoopInTerms
+	inTermsExpiry  time.Time
+	health         ServerHealth
+}
+
+// newCachedSwapServerClient wraps the server client provided.
+func newCachedSwapServerClient(server swapServerClient,
+	termsTTL time.Duration, clock clock.Clock) *cachedSwapServerClient {
+
+	return &cachedSwapServerClient{
+		swapServerClient: server,
+		termsTTL:         termsTTL,
+		clock:            clock,
+		health: ServerHealth{
+			ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		},
+	}
+}
+
+var _ swapServerClient = (*cachedSwapServerClient)(nil)
+
+// serverUnreachable returns a boolean indicating whether an error returned
+// by a call to the server indicates that we could not reach it. Other errors
+// are returned by the server itself, so it is reachable.
+func serverUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// recordCall updates the health of our server connection with the outcome of
+// a call to the server.
+func (s *cachedSwapServerClient) recordCall(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	if serverUnreachable(err) {
+		s.health.Reachable = false
+		s.health.LastFailure = now
+		s.health.LastError = err
+
+		return
+	}
+
+	s.health.Reachable = true
+	s.health.LastSuccess = now
+}
+
+// serverHealth returns the current health of our server connection.
+func (s *cachedSwapServerClient) serverHealth() ServerHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.health
+}
+
+// checkHealth returns an error if the server was unreachable on our last
+// call to it. If it was, we call the server again first so that we notice
+// once it becomes reachable again, even if nothing else is calling it.
+func (s *cachedSwapServerClient) checkHealth(ctx context.Context) error {
+	health := s.serverHealth()
+	if health.Reachable || health.LastFailure.IsZero() {
+		return nil
+	}
+
+	_, err := s.swapServerClient.GetLoopOutTerms(ctx)
+	s.recordCall(err)
+
+	if serverUnreachable(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GetLoopOutTerms returns our cached loop out terms if they have not expired
+// yet, and queries the server for them otherwise.
+func (s *cachedSwapServerClient) GetLoopOutTerms(ctx context.Context) (
+	*LoopOutTerms, error) {
+
+	s.mu.Lock()
+	if s.outTerms != nil && s.clock.Now().Before(s.outTermsExpiry) {
+		terms := *s.outTerms
+		s.mu.Unlock()
+
+		return &terms, nil
+	}
+	s.mu.Unlock()
+
+	terms, err := s.swapServerClient.GetLoopOutTerms(ctx)
+	s.recordCall(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.termsTTL != 0 {
+		s.mu.Lock()
+		cached := *terms
+		s.outTerms = &cached
+		s.outTermsExpiry = s.clock.Now().Add(s.termsTTL)
+		s.mu.Unlock()
+	}
+
+	return terms, nil
+}
+
+// GetLoopInTerms returns our cached loop in terms if they have not expired
+// yet, and queries the server for them otherwise.
+func (s *cachedSwapServerClient) GetLoopInTerms(ctx context.Context) (
+	*LoopInTerms, error) {
+
+	s.mu.Lock()
+	if s.inTerms != nil && s.clock.Now().Before(s.inTermsExpiry) {
+		terms := *s.inTerms
+		s.mu.Unlock()
+
+		return &terms, nil
+	}
+	s.mu.Unlock()
+
+	terms, err := s.swapServerClient.GetLoopInTerms(ctx)
+	s.recordCall(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.termsTTL != 0 {
+		s.mu.Lock()
+		cached := *terms
+		s.inTerms = &cached
+		s.inTermsExpiry = s.clock.Now().Add(s.termsTTL)
+		s.mu.Unlock()
+	}
+
+	return terms, nil
+}
+
+// GetLoopOutQuote queries the server for a loop out quote.
+func (s *cachedSwapServerClient) GetLoopOutQuote(ctx context.Context,
+	amt btcutil.Amount, expiry int32, swapPublicationDeadline time.Time,
+	htlcConfTarget int32) (*LoopOutQuote, error) {
+
+	quote, err := s.swapServerClient.GetLoopOutQuote(
+		ctx, amt, expiry, swapPublicationDeadline, htlcConfTarget,
+	)
+	s.recordCall(err)
+
+	return quote, err
+}
+
+// GetLoopInQuote queries the server for a loop in quote.
+func (s *cachedSwapServerClient) GetLoopInQuote(ctx context.Context,
+	amt btcutil.Amount) (*LoopInQuote, error) {
+
+	quote, err := s.swapServerClient.GetLoopInQuote(ctx, amt)
+	s.recordCall(err)
+
+	return quote, err
+}
+
+// NewLoopOutSwap registers a new loop out swap with the server.
+func (s *cachedSwapServerClient) NewLoopOutSwap(ctx context.Context,
+	swapHash lntypes.Hash, amount btcutil.Amount, expiry int32,
+	receiverKey [33]byte, swapPublicationDeadline time.Time,
+	htlcConfTarget int32, initiator string) (*newLoopOutResponse, error) {
+
+	resp, err := s.swapServerClient.NewLoopOutSwap(
+		ctx, swapHash, amount, expiry, receiverKey,
+		swapPublicationDeadline, htlcConfTarget, initiator,
+	)
+	s.recordCall(err)
+
+	return resp, err
+}
+
+// NewLoopInSwap registers a new loop in swap with the server.
+func (s *cachedSwapServerClient) NewLoopInSwap(ctx context.Context,
+	swapHash lntypes.Hash, amount btcutil.Amount, senderKey [33]byte,
+	swapInvoice, probeInvoice string, lastHop *route.Vertex,
+	initiator string) (*newLoopInResponse, error) {
+
+	resp, err := s.swapServerClient.NewLoopInSwap(
+		ctx, swapHash, amount, senderKey, swapInvoice, probeInvoice,
+		lastHop, initiator,
+	)
+	s.recordCall(err)
+
+	return resp, err
+}
+
+// PushLoopOutPreimage pushes the preimage of a loop out swap to the server.
+func (s *cachedSwapServerClient) PushLoopOutPreimage(ctx context.Context,
+	preimage lntypes.Preimage) error {
+
+	err := s.swapServerClient.PushLoopOutPreimage(ctx, preimage)
+	s.recordCall(err)
+
+	return err
+}
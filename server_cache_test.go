@@ -0,0 +1,170 @@
+package loop
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// termsServerMock is a swap server client that counts the calls made for its
+// terms, and fails them with the error set.
+type termsServerMock struct {
+	swapServerClient
+
+	outCalls int
+	inCalls  int
+	err      error
+}
+
+func (s *termsServerMock) GetLoopOutTerms(context.Context) (*LoopOutTerms,
+	error) {
+
+	s.outCalls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &LoopOutTerms{
+		MinSwapAmount: testMinSwapAmount,
+		MaxSwapAmount: testMaxSwapAmount,
+	}, nil
+}
+
+func (s *termsServerMock) GetLoopInTerms(context.Context) (*LoopInTerms,
+	error) {
+
+	s.inCalls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &LoopInTerms{
+		MinSwapAmount: testMinSwapAmount,
+		MaxSwapAmount: testMaxSwapAmount,
+	}, nil
+}
+
+// TestTermsCache tests that we only query the server for its terms once our
+// cached terms have expired.
+func TestTermsCache(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		startTime = time.Unix(100000, 0)
+		ttl       = time.Minute
+		testClock = clock.NewTestClock(startTime)
+		server    = &termsServerMock{}
+	)
+
+	cache := newCachedSwapServerClient(server, ttl, testClock)
+
+	_, err := cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	_, err = cache.GetLoopInTerms(ctx)
+	require.NoError(t, err)
+
+	// Our second calls should be served from our cache.
+	outTerms, err := cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testMaxSwapAmount, outTerms.MaxSwapAmount)
+
+	_, err = cache.GetLoopInTerms(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, server.outCalls)
+	require.Equal(t, 1, server.inCalls)
+
+	// Modifying the terms that we returned should not affect our cache.
+	outTerms.MaxSwapAmount = 0
+	outTerms, err = cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testMaxSwapAmount, outTerms.MaxSwapAmount)
+
+	// Once our ttl has passed, we should query the server again.
+	testClock.SetTime(startTime.Add(ttl))
+
+	_, err = cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	_, err = cache.GetLoopInTerms(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, server.outCalls)
+	require.Equal(t, 2, server.inCalls)
+
+	// With caching disabled, we query the server on every call.
+	server = &termsServerMock{}
+	cache = newCachedSwapServerClient(server, 0, testClock)
+
+	_, err = cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	_, err = cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, server.outCalls)
+}
+
+// TestServerHealth tests tracking of the health of our server connection.
+func TestServerHealth(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		startTime   = time.Unix(100000, 0)
+		testClock   = clock.NewTestClock(startTime)
+		server      = &termsServerMock{}
+		unavailable = status.Error(codes.Unavailable, "unavailable")
+	)
+
+	cache := newCachedSwapServerClient(server, 0, testClock)
+
+	// Before we have called the server, we do not know whether it is
+	// reachable, so we do not report it as unhealthy.
+	require.Equal(t, ServerHealth{
+		ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+	}, cache.serverHealth())
+	require.NoError(t, cache.checkHealth(ctx))
+	require.Equal(t, 0, server.outCalls)
+
+	// A successful call marks the server as reachable.
+	_, err := cache.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ServerHealth{
+		Reachable:       true,
+		ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		LastSuccess:     startTime,
+	}, cache.serverHealth())
+
+	// An error returned by the server itself does not mean that it is
+	// unreachable.
+	server.err = status.Error(codes.InvalidArgument, "invalid")
+	_, err = cache.GetLoopInTerms(ctx)
+	require.Error(t, err)
+	require.True(t, cache.serverHealth().Reachable)
+
+	// Once we fail to reach the server, it is reported as unreachable.
+	failTime := startTime.Add(time.Minute)
+	testClock.SetTime(failTime)
+
+	server.err = unavailable
+	_, err = cache.GetLoopInTerms(ctx)
+	require.Equal(t, unavailable, err)
+	require.Equal(t, ServerHealth{
+		ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		LastSuccess:     startTime,
+		LastFailure:     failTime,
+		LastError:       unavailable,
+	}, cache.serverHealth())
+
+	// Checking our health should call the server again, and fail while it
+	// is unreachable.
+	outCalls := server.outCalls
+	require.Equal(t, unavailable, cache.checkHealth(ctx))
+	require.Equal(t, outCalls+1, server.outCalls)
+
+	// Once the server is reachable again, our health check succeeds.
+	server.err = nil
+	require.NoError(t, cache.checkHealth(ctx))
+	require.True(t, cache.serverHealth().Reachable)
+}
@@ -0,0 +1,185 @@
+package loop
+
+import (
+	"sort"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+)
+
+// ServerFeature is an optional feature of the swap protocol. Features are
+// negotiated with each server that we connect to, so that new swap features
+// can be gated on the server's support for them rather than on its protocol
+// version.
+type ServerFeature uint32
+
+const (
+	// FeatureTaprootHtlc indicates that swaps use taproot htlcs.
+	FeatureTaprootHtlc ServerFeature = 1
+
+	// FeatureBatchedPrepay indicates that the prepay of a loop out may be
+	// included in its swap invoice.
+	FeatureBatchedPrepay ServerFeature = 2
+
+	// FeatureMuSig2Sweep indicates that htlcs are swept cooperatively
+	// using a MuSig2 key spend.
+	FeatureMuSig2Sweep ServerFeature = 3
+
+	// FeaturePrepayRelease indicates that the server releases the prepay
+	// of a loop out that we abandon before it has published the htlc.
+	FeaturePrepayRelease ServerFeature = 4
+)
+
+// String returns the string representation of a server feature.
+func (f ServerFeature) String() string {
+	switch f {
+	case FeatureTaprootHtlc:
+		return "taproot htlc"
+
+	case FeatureBatchedPrepay:
+		return "batched prepay"
+
+	case FeatureMuSig2Sweep:
+		return "musig2 sweep"
+
+	case FeaturePrepayRelease:
+		return "prepay release"
+
+	default:
+		return "unknown"
+	}
+}
+
+// clientFeatures is the set of optional features that this client supports.
+// Features that are defined but not listed here are not yet implemented by the
+// client, so they are never negotiated.
+var clientFeatures = []ServerFeature{
+	FeatureBatchedPrepay,
+	FeaturePrepayRelease,
+}
+
+// ServerFeatures holds the outcome of feature negotiation with a swap server.
+type ServerFeatures struct {
+	// ProtocolVersion is the highest protocol version that both we and
+	// the server support.
+	ProtocolVersion loopdb.ProtocolVersion
+
+	// features is the set of optional features that both we and the
+	// server support.
+	features map[ServerFeature]struct{}
+}
+
+// negotiateFeatures returns the protocol version and set of features that we
+// share with a server that supports the version and features provided.
+func negotiateFeatures(serverVersion loopdb.ProtocolVersion,
+	serverFeatures []ServerFeature) *ServerFeatures {
+
+	negotiated := &ServerFeatures{
+		ProtocolVersion: serverVersion,
+		features:        make(map[ServerFeature]struct{}),
+	}
+
+	if serverVersion > loopdb.CurrentInternalProtocolVersion {
+		negotiated.ProtocolVersion =
+			loopdb.CurrentInternalProtocolVersion
+	}
+
+	supported := make(map[ServerFeature]struct{}, len(serverFeatures))
+	for _, feature := range serverFeatures {
+		supported[feature] = struct{}{}
+	}
+
+	for _, feature := range clientFeatures {
+		if _, ok := supported[feature]; ok {
+			negotiated.features[feature] = struct{}{}
+		}
+	}
+
+	return negotiated
+}
+
+// legacyFeatures returns the features that we share with a server that does
+// not support feature negotiation. We assume that the server supports our
+// current protocol version, as we did before features were negotiated, and
+// only use the features implied by that version.
+func legacyFeatures() *ServerFeatures {
+	var features []ServerFeature
+	if loopdb.CurrentInternalProtocolVersion >=
+		loopdb.ProtocolVersionBatchedPrepay {
+
+		features = append(features, FeatureBatchedPrepay)
+	}
+
+	return negotiateFeatures(
+		loopdb.CurrentInternalProtocolVersion, features,
+	)
+}
+
+// Supports returns a boolean indicating whether both we and the server
+// support the feature provided.
+func (s *ServerFeatures) Supports(feature ServerFeature) bool {
+	_, ok := s.features[feature]
+	return ok
+}
+
+// SupportsTaproot returns a boolean indicating whether swaps with the server
+// may use taproot htlcs.
+func (s *ServerFeatures) SupportsTaproot() bool {
+	return s.Supports(FeatureTaprootHtlc)
+}
+
+// SupportsBatchedPrepay returns a boolean indicating whether the server may
+// include the prepay of a loop out in its swap invoice.
+func (s *ServerFeatures) SupportsBatchedPrepay() bool {
+	return s.Supports(FeatureBatchedPrepay)
+}
+
+// SupportsMuSig2Sweeps returns a boolean indicating whether htlcs may be
+// swept cooperatively with the server using a MuSig2 key spend.
+func (s *ServerFeatures) SupportsMuSig2Sweeps() bool {
+	return s.Supports(FeatureMuSig2Sweep)
+}
+
+// SupportsPrepayRelease returns a boolean indicating whether the server
+// releases the prepay of loop outs that we abandon early.
+func (s *ServerFeatures) SupportsPrepayRelease() bool {
+	return s.Supports(FeaturePrepayRelease)
+}
+
+// Features returns the features that both we and the server support, in
+// ascending order.
+func (s *ServerFeatures) Features() []ServerFeature {
+	features := make([]ServerFeature, 0, len(s.features))
+	for feature := range s.features {
+		features = append(features, feature)
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		return features[i] < features[j]
+	})
+
+	return features
+}
+
+// rpcServerFeatures converts a set of features to their rpc representation.
+func rpcServerFeatures(features []ServerFeature) []looprpc.ServerFeature {
+	rpcFeatures := make([]looprpc.ServerFeature, len(features))
+	for i, feature := range features {
+		rpcFeatures[i] = looprpc.ServerFeature(feature)
+	}
+
+	return rpcFeatures
+}
+
+// unmarshallServerFeatures converts a set of rpc features to our internal
+// representation.
+func unmarshallServerFeatures(
+	rpcFeatures []looprpc.ServerFeature) []ServerFeature {
+
+	features := make([]ServerFeature, len(rpcFeatures))
+	for i, feature := range rpcFeatures {
+		features[i] = ServerFeature(feature)
+	}
+
+	return features
+}
@@ -0,0 +1,158 @@
+package loop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestNegotiateFeatures tests negotiation of our protocol version and
+// features with a server.
+func TestNegotiateFeatures(t *testing.T) {
+	tests := []struct {
+		name            string
+		serverVersion   loopdb.ProtocolVersion
+		serverFeatures  []ServerFeature
+		expectedVersion loopdb.ProtocolVersion
+		expected        []ServerFeature
+	}{
+		{
+			name:            "older server, no features",
+			serverVersion:   loopdb.ProtocolVersionHtlcV2,
+			expectedVersion: loopdb.ProtocolVersionHtlcV2,
+			expected:        []ServerFeature{},
+		},
+		{
+			name: "newer server, all features",
+			serverVersion: loopdb.CurrentInternalProtocolVersion +
+				1,
+			serverFeatures: []ServerFeature{
+				FeatureMuSig2Sweep, FeatureTaprootHtlc,
+				FeaturePrepayRelease, FeatureBatchedPrepay,
+			},
+			expectedVersion: loopdb.CurrentInternalProtocolVersion,
+			expected: []ServerFeature{
+				FeatureBatchedPrepay, FeaturePrepayRelease,
+			},
+		},
+		{
+			name:          "unknown server features",
+			serverVersion: loopdb.CurrentInternalProtocolVersion,
+			serverFeatures: []ServerFeature{
+				FeaturePrepayRelease, 100,
+			},
+			expectedVersion: loopdb.CurrentInternalProtocolVersion,
+			expected: []ServerFeature{
+				FeaturePrepayRelease,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			features := negotiateFeatures(
+				testCase.serverVersion, testCase.serverFeatures,
+			)
+
+			require.Equal(
+				t, testCase.expectedVersion,
+				features.ProtocolVersion,
+			)
+			require.Equal(t, testCase.expected, features.Features())
+		})
+	}
+}
+
+// TestLegacyFeatures tests that servers which do not support feature
+// negotiation are only used with the features implied by our protocol
+// version.
+func TestLegacyFeatures(t *testing.T) {
+	features := legacyFeatures()
+
+	require.Equal(
+		t, loopdb.CurrentInternalProtocolVersion,
+		features.ProtocolVersion,
+	)
+	require.True(t, features.SupportsBatchedPrepay())
+	require.False(t, features.SupportsPrepayRelease())
+	require.False(t, features.SupportsTaproot())
+	require.False(t, features.SupportsMuSig2Sweeps())
+}
+
+// featuresServerMock mocks the feature negotiation rpc of the swap server.
+type featuresServerMock struct {
+	looprpc.SwapServerClient
+
+	calls int
+	resp  *looprpc.ServerFeaturesResponse
+	err   error
+}
+
+// GetFeatures counts our calls and returns the mock's response.
+func (s *featuresServerMock) GetFeatures(context.Context,
+	*looprpc.ServerFeaturesRequest, ...grpc.CallOption) (
+	*looprpc.ServerFeaturesResponse, error) {
+
+	s.calls++
+	return s.resp, s.err
+}
+
+// TestServerFeatures tests that features are negotiated once per connection,
+// and that we fall back to our legacy features for servers that do not
+// support negotiation.
+func TestServerFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	// If negotiation fails, we do not store our result so that we retry
+	// on our next call.
+	server := &featuresServerMock{
+		err: status.Error(codes.Unavailable, "unavailable"),
+	}
+	client := &grpcSwapServerClient{server: server}
+
+	_, err := client.Features(ctx)
+	require.Error(t, err)
+
+	server.err = nil
+	server.resp = &looprpc.ServerFeaturesResponse{
+		ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		Features: []looprpc.ServerFeature{
+			looprpc.ServerFeature_PREPAY_RELEASE,
+		},
+	}
+
+	features, err := client.Features(ctx)
+	require.NoError(t, err)
+	require.True(t, features.SupportsPrepayRelease())
+	require.False(t, features.SupportsBatchedPrepay())
+
+	// Once negotiated, our features are not negotiated again.
+	_, err = client.Features(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, server.calls)
+
+	// Servers that do not implement negotiation get our legacy features.
+	server = &featuresServerMock{
+		err: status.Error(codes.Unimplemented, "unimplemented"),
+	}
+	client = &grpcSwapServerClient{server: server}
+
+	features, err = client.Features(ctx)
+	require.NoError(t, err)
+	require.Equal(t, legacyFeatures(), features)
+
+	// Since legacy servers do not support prepay release, we do not ask
+	// them to release our prepay.
+	released, err := client.AbandonLoopOutSwap(ctx, testPreimage.Hash(),
+		[32]byte{})
+	require.NoError(t, err)
+	require.False(t, released)
+}
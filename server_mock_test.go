@@ -10,6 +10,7 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/test"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lntypes"
@@ -48,6 +49,13 @@ type serverMock struct {
 	// cancelSwap is a channel that swap cancelations are sent into.
 	cancelSwap chan *outCancelDetails
 
+	// abandonSwap is a channel that the hashes of abandoned swaps are sent
+	// into.
+	abandonSwap chan lntypes.Hash
+
+	// prepayReleased is returned when a swap is abandoned.
+	prepayReleased bool
+
 	lnd *test.LndMockServices
 }
 
@@ -65,6 +73,7 @@ func newServerMock(lnd *test.LndMockServices) *serverMock {
 
 		preimagePush: make(chan lntypes.Preimage),
 		cancelSwap:   make(chan *outCancelDetails),
+		abandonSwap:  make(chan lntypes.Hash),
 
 		lnd: lnd,
 	}
@@ -72,7 +81,7 @@ func newServerMock(lnd *test.LndMockServices) *serverMock {
 
 func (s *serverMock) NewLoopOutSwap(_ context.Context, swapHash lntypes.Hash,
 	amount btcutil.Amount, _ int32, _ [33]byte, _ time.Time,
-	_ string) (*newLoopOutResponse, error) {
+	htlcConfTarget int32, _ string) (*newLoopOutResponse, error) {
 
 	_, senderKey := test.CreateKey(100)
 
@@ -96,9 +105,10 @@ func (s *serverMock) NewLoopOutSwap(_ context.Context, swapHash lntypes.Hash,
 	copy(senderKeyArray[:], senderKey.SerializeCompressed())
 
 	return &newLoopOutResponse{
-		senderKey:     senderKeyArray,
-		swapInvoice:   swapPayReqString,
-		prepayInvoice: prePayReqString,
+		senderKey:      senderKeyArray,
+		swapInvoice:    swapPayReqString,
+		prepayInvoice:  prePayReqString,
+		htlcConfTarget: htlcConfTarget,
 	}, nil
 }
 
@@ -114,14 +124,16 @@ func (s *serverMock) GetLoopOutTerms(ctx context.Context) (
 }
 
 func (s *serverMock) GetLoopOutQuote(ctx context.Context, amt btcutil.Amount,
-	expiry int32, _ time.Time) (*LoopOutQuote, error) {
+	expiry int32, _ time.Time, htlcConfTarget int32) (*LoopOutQuote,
+	error) {
 
 	dest := [33]byte{1, 2, 3}
 
 	return &LoopOutQuote{
-		SwapFee:         testSwapFee,
-		SwapPaymentDest: dest,
-		PrepayAmount:    testFixedPrepayAmount,
+		SwapFee:              testSwapFee,
+		SwapPaymentDest:      dest,
+		PrepayAmount:         testFixedPrepayAmount,
+		ServerHtlcConfTarget: htlcConfTarget,
 	}, nil
 }
 
@@ -199,6 +211,22 @@ func (s *serverMock) CancelLoopOutSwap(ctx context.Context,
 	return nil
 }
 
+// AbandonLoopOutSwap pushes the hash of an abandoned swap into our mock's
+// channel and reports whether we released its prepay.
+func (s *serverMock) AbandonLoopOutSwap(ctx context.Context,
+	hash lntypes.Hash, _ [32]byte) (bool, error) {
+
+	s.abandonSwap <- hash
+	return s.prepayReleased, nil
+}
+
+// Features returns all of the features that our client supports.
+func (s *serverMock) Features(context.Context) (*ServerFeatures, error) {
+	return negotiateFeatures(
+		loopdb.CurrentInternalProtocolVersion, clientFeatures,
+	), nil
+}
+
 func (s *serverMock) assertSwapCanceled(t *testing.T, details *outCancelDetails) {
 	require.Equal(t, details, <-s.cancelSwap)
 }
@@ -0,0 +1,365 @@
+package staticaddr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/ticker"
+)
+
+const (
+	// MinDepositConfs is the number of confirmations that a deposit to
+	// our static address requires before we loop it in.
+	MinDepositConfs = 3
+
+	// DefaultPollInterval is the default interval at which we check our
+	// wallet for new deposits.
+	DefaultPollInterval = time.Minute
+
+	// depositInitiator is the initiator that we set for loop in swaps that
+	// are dispatched for deposits.
+	depositInitiator = "static-address"
+
+	// minerFeeMultiplier is the multiplier that we apply to the quoted
+	// htlc publication fee to get the maximum miner fee of a swap, so that
+	// it is not canceled if fees increase in the mean time. This matches
+	// the headroom that loop in applies to its quotes.
+	minerFeeMultiplier = 3
+)
+
+// Store is the subset of our swap store that the deposit tracker requires.
+type Store interface {
+	// CreateStaticAddress persists our static address, failing if one
+	// already exists.
+	CreateStaticAddress(addr *loopdb.StaticAddress) error
+
+	// FetchStaticAddress returns our static address, failing with
+	// loopdb.ErrNoStaticAddress if it has not been created.
+	FetchStaticAddress() (*loopdb.StaticAddress, error)
+
+	// PutDeposit persists a deposit to our static address.
+	PutDeposit(deposit *loopdb.Deposit) error
+
+	// FetchDeposits returns all deposits to our static address.
+	FetchDeposits() ([]*loopdb.Deposit, error)
+}
+
+// Config contains the external functionality that the deposit tracker
+// requires.
+type Config struct {
+	// Store persists our static address and its deposits.
+	Store Store
+
+	// ChainParams are the parameters of the chain that we are running on.
+	ChainParams *chaincfg.Params
+
+	// Ticker determines how often we check our wallet for new deposits.
+	Ticker ticker.Ticker
+
+	// Clock provides the current time.
+	Clock clock.Clock
+
+	// NextAddr returns a new address from our wallet.
+	NextAddr func(ctx context.Context) (btcutil.Address, error)
+
+	// ListUnspent returns the unspent outputs in our wallet that have a
+	// number of confirmations within the range provided.
+	ListUnspent func(ctx context.Context, minConfs,
+		maxConfs int32) ([]*lnwallet.Utxo, error)
+
+	// BestHeight returns the current block height.
+	BestHeight func(ctx context.Context) (int32, error)
+
+	// LoopInTerms returns the server's current loop in terms.
+	LoopInTerms func(ctx context.Context) (*loop.LoopInTerms, error)
+
+	// LoopInQuote returns a quote for a loop in swap.
+	LoopInQuote func(ctx context.Context,
+		request *loop.LoopInQuoteRequest) (*loop.LoopInQuote, error)
+
+	// LoopIn dispatches a loop in swap.
+	LoopIn func(ctx context.Context,
+		request *loop.LoopInRequest) (*loop.LoopInSwapInfo, error)
+
+	// HtlcConfTarget is the confirmation target that we use for the htlcs
+	// of the loop in swaps that we dispatch for deposits.
+	HtlcConfTarget int32
+}
+
+// Manager maintains a long-lived on-chain address for our wallet, and
+// dispatches a loop in swap for every deposit that is made to it once the
+// deposit has confirmed. Because the deposit is paid to our wallet, the swap's
+// htlc is funded by our wallet as usual, so the deposit and the htlc are not
+// necessarily the same coins.
+type Manager struct {
+	cfg *Config
+
+	// mu serializes creation of our static address with our checks for
+	// deposits.
+	mu sync.Mutex
+}
+
+// NewManager creates a new deposit tracker.
+func NewManager(cfg *Config) *Manager {
+	return &Manager{
+		cfg: cfg,
+	}
+}
+
+// Run periodically checks our wallet for new deposits to our static address,
+// and dispatches loop in swaps for them.
+func (m *Manager) Run(ctx context.Context) error {
+	m.cfg.Ticker.Resume()
+	defer m.cfg.Ticker.Stop()
+
+	for {
+		select {
+		case <-m.cfg.Ticker.Ticks():
+			if err := m.checkDeposits(ctx); err != nil {
+				log.Errorf("Could not check static address "+
+					"deposits: %v", err)
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// NewAddress returns our static address, creating it if we do not have one
+// yet. Because deposits may still be made to an existing address, we never
+// replace it.
+func (m *Manager) NewAddress(ctx context.Context) (*loopdb.StaticAddress,
+	error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	addr, err := m.cfg.Store.FetchStaticAddress()
+	switch err {
+	case nil:
+		return addr, nil
+
+	case loopdb.ErrNoStaticAddress:
+
+	default:
+		return nil, err
+	}
+
+	walletAddr, err := m.cfg.NextAddr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr = &loopdb.StaticAddress{
+		Address:      walletAddr.String(),
+		CreationTime: m.cfg.Clock.Now(),
+	}
+
+	if err := m.cfg.Store.CreateStaticAddress(addr); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Created static address: %v", addr.Address)
+
+	return addr, nil
+}
+
+// GetAddress returns our static address, failing with
+// loopdb.ErrNoStaticAddress if it has not been created.
+func (m *Manager) GetAddress() (*loopdb.StaticAddress, error) {
+	return m.cfg.Store.FetchStaticAddress()
+}
+
+// ListDeposits returns all deposits to our static address, ordered by the
+// height at which they confirmed.
+func (m *Manager) ListDeposits() ([]*loopdb.Deposit, error) {
+	deposits, err := m.cfg.Store.FetchDeposits()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(deposits, func(i, j int) bool {
+		return deposits[i].ConfirmationHeight <
+			deposits[j].ConfirmationHeight
+	})
+
+	return deposits, nil
+}
+
+// checkDeposits records any new confirmed deposits to our static address and
+// dispatches loop in swaps for all deposits that do not have one yet.
+func (m *Manager) checkDeposits(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	addr, err := m.cfg.Store.FetchStaticAddress()
+	if errors.Is(err, loopdb.ErrNoStaticAddress) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pkScript, err := addressScript(addr.Address, m.cfg.ChainParams)
+	if err != nil {
+		return err
+	}
+
+	deposits, err := m.cfg.Store.FetchDeposits()
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]struct{}, len(deposits))
+	for _, deposit := range deposits {
+		known[deposit.OutPoint.String()] = struct{}{}
+	}
+
+	utxos, err := m.cfg.ListUnspent(ctx, MinDepositConfs, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+
+	var height int32
+	for _, utxo := range utxos {
+		if !bytes.Equal(utxo.PkScript, pkScript) {
+			continue
+		}
+
+		if _, ok := known[utxo.OutPoint.String()]; ok {
+			continue
+		}
+
+		// We only look up our height once we have a new deposit,
+		// because we usually don't.
+		if height == 0 {
+			height, err = m.cfg.BestHeight(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		deposit := &loopdb.Deposit{
+			OutPoint: utxo.OutPoint,
+			Value:    utxo.Value,
+			ConfirmationHeight: height -
+				int32(utxo.Confirmations) + 1,
+			State:      loopdb.DepositConfirmed,
+			LastUpdate: m.cfg.Clock.Now(),
+		}
+
+		if err := m.cfg.Store.PutDeposit(deposit); err != nil {
+			return err
+		}
+
+		log.Infof("Static address deposit confirmed: %v (%v)",
+			deposit.OutPoint, deposit.Value)
+
+		deposits = append(deposits, deposit)
+	}
+
+	// Dispatch swaps for all of our deposits that do not have one yet,
+	// which includes deposits that we failed to swap on a previous check.
+	for _, deposit := range deposits {
+		if deposit.State != loopdb.DepositConfirmed {
+			continue
+		}
+
+		if err := m.swapDeposit(ctx, deposit); err != nil {
+			log.Errorf("Could not loop in deposit %v, will retry: "+
+				"%v", deposit.OutPoint, err)
+		}
+	}
+
+	return nil
+}
+
+// swapDeposit dispatches a loop in swap for a deposit. The swap's amount is
+// the value of the deposit less the estimated fee for publishing its htlc, so
+// that the deposit covers the full cost of moving it off-chain. Deposits that
+// are too small to be swapped or that exceed the server's maximum are left in
+// our wallet, because a swap can only spend the deposit in full.
+func (m *Manager) swapDeposit(ctx context.Context,
+	deposit *loopdb.Deposit) error {
+
+	terms, err := m.cfg.LoopInTerms(ctx)
+	if err != nil {
+		return err
+	}
+
+	quote, err := m.cfg.LoopInQuote(ctx, &loop.LoopInQuoteRequest{
+		Amount:         deposit.Value,
+		HtlcConfTarget: m.cfg.HtlcConfTarget,
+	})
+	if err != nil {
+		return err
+	}
+
+	amount := deposit.Value - quote.MinerFee
+	if amount < terms.MinSwapAmount {
+		log.Infof("Static address deposit %v is too small to swap: "+
+			"%v after htlc fees, minimum %v", deposit.OutPoint,
+			amount, terms.MinSwapAmount)
+
+		deposit.State = loopdb.DepositTooSmall
+		deposit.LastUpdate = m.cfg.Clock.Now()
+
+		return m.cfg.Store.PutDeposit(deposit)
+	}
+
+	if amount > terms.MaxSwapAmount {
+		log.Infof("Static address deposit %v is too large to swap: "+
+			"%v after htlc fees, maximum %v", deposit.OutPoint,
+			amount, terms.MaxSwapAmount)
+
+		deposit.State = loopdb.DepositTooLarge
+		deposit.LastUpdate = m.cfg.Clock.Now()
+
+		return m.cfg.Store.PutDeposit(deposit)
+	}
+
+	swapInfo, err := m.cfg.LoopIn(ctx, &loop.LoopInRequest{
+		Amount:         amount,
+		MaxSwapFee:     quote.SwapFee,
+		MaxMinerFee:    quote.MinerFee * minerFeeMultiplier,
+		HtlcConfTarget: m.cfg.HtlcConfTarget,
+		Label:          labels.StaticAddressLabel(),
+		Initiator:      depositInitiator,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Loop in dispatched for static address deposit %v: %v",
+		deposit.OutPoint, swapInfo.SwapHash)
+
+	deposit.State = loopdb.DepositSwapInitiated
+	deposit.SwapHash = &swapInfo.SwapHash
+	deposit.LastUpdate = m.cfg.Clock.Now()
+
+	return m.cfg.Store.PutDeposit(deposit)
+}
+
+// addressScript returns the output script for an encoded address.
+func addressScript(addr string, params *chaincfg.Params) ([]byte, error) {
+	decoded, err := btcutil.DecodeAddress(addr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.PayToAddrScript(decoded)
+}
@@ -0,0 +1,243 @@
+package staticaddr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/ticker"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is an in-memory implementation of our store.
+type mockStore struct {
+	addr     *loopdb.StaticAddress
+	deposits map[wire.OutPoint]loopdb.Deposit
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{
+		deposits: make(map[wire.OutPoint]loopdb.Deposit),
+	}
+}
+
+func (s *mockStore) CreateStaticAddress(addr *loopdb.StaticAddress) error {
+	if s.addr != nil {
+		return loopdb.ErrStaticAddressExists
+	}
+
+	stored := *addr
+	s.addr = &stored
+
+	return nil
+}
+
+func (s *mockStore) FetchStaticAddress() (*loopdb.StaticAddress, error) {
+	if s.addr == nil {
+		return nil, loopdb.ErrNoStaticAddress
+	}
+
+	addr := *s.addr
+
+	return &addr, nil
+}
+
+func (s *mockStore) PutDeposit(deposit *loopdb.Deposit) error {
+	s.deposits[deposit.OutPoint] = *deposit
+
+	return nil
+}
+
+func (s *mockStore) FetchDeposits() ([]*loopdb.Deposit, error) {
+	deposits := make([]*loopdb.Deposit, 0, len(s.deposits))
+	for _, deposit := range s.deposits {
+		deposit := deposit
+		deposits = append(deposits, &deposit)
+	}
+
+	return deposits, nil
+}
+
+// TestDeposits tests that we dispatch loop in swaps for confirmed deposits to
+// our static address.
+func TestDeposits(t *testing.T) {
+	const (
+		height   = 1000
+		minerFee = 1000
+		swapFee  = 500
+	)
+
+	var (
+		ctx    = context.Background()
+		params = &chaincfg.TestNet3Params
+		now    = time.Unix(100, 0)
+		hash   = lntypes.Hash{9}
+	)
+
+	walletAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), params,
+	)
+	require.NoError(t, err)
+
+	pkScript, err := txscript.PayToAddrScript(walletAddr)
+	require.NoError(t, err)
+
+	deposit := &lnwallet.Utxo{
+		OutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{1},
+		},
+		Value:         40000,
+		Confirmations: 5,
+		PkScript:      pkScript,
+	}
+
+	small := &lnwallet.Utxo{
+		OutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{2},
+		},
+		Value:         minerFee + 100,
+		Confirmations: 3,
+		PkScript:      pkScript,
+	}
+
+	large := &lnwallet.Utxo{
+		OutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{4},
+		},
+		Value:         100000,
+		Confirmations: 4,
+		PkScript:      pkScript,
+	}
+
+	other := &lnwallet.Utxo{
+		OutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{3},
+		},
+		Value:         100000,
+		Confirmations: 3,
+		PkScript:      []byte{1, 2, 3},
+	}
+
+	var (
+		store    = newMockStore()
+		requests []*loop.LoopInRequest
+		loopErr  = errors.New("server unavailable")
+	)
+
+	cfg := &Config{
+		Store:       store,
+		ChainParams: params,
+		Ticker:      ticker.NewForce(DefaultPollInterval),
+		Clock:       clock.NewTestClock(now),
+		NextAddr: func(context.Context) (btcutil.Address, error) {
+			return walletAddr, nil
+		},
+		ListUnspent: func(_ context.Context, minConfs,
+			_ int32) ([]*lnwallet.Utxo, error) {
+
+			require.EqualValues(t, MinDepositConfs, minConfs)
+
+			return []*lnwallet.Utxo{
+				deposit, small, large, other,
+			}, nil
+		},
+		BestHeight: func(context.Context) (int32, error) {
+			return height, nil
+		},
+		LoopInTerms: func(context.Context) (*loop.LoopInTerms, error) {
+			return &loop.LoopInTerms{
+				MinSwapAmount: 1000,
+				MaxSwapAmount: 50000,
+			}, nil
+		},
+		LoopInQuote: func(context.Context,
+			*loop.LoopInQuoteRequest) (*loop.LoopInQuote, error) {
+
+			return &loop.LoopInQuote{
+				SwapFee:  swapFee,
+				MinerFee: minerFee,
+			}, nil
+		},
+		LoopIn: func(_ context.Context,
+			req *loop.LoopInRequest) (*loop.LoopInSwapInfo, error) {
+
+			if loopErr != nil {
+				return nil, loopErr
+			}
+
+			requests = append(requests, req)
+
+			return &loop.LoopInSwapInfo{
+				SwapHash: hash,
+			}, nil
+		},
+		HtlcConfTarget: 6,
+	}
+
+	mgr := NewManager(cfg)
+
+	// Without a static address, we should not look for deposits.
+	require.NoError(t, mgr.checkDeposits(ctx))
+	require.Len(t, store.deposits, 0)
+
+	_, err = mgr.GetAddress()
+	require.Equal(t, loopdb.ErrNoStaticAddress, err)
+
+	addr, err := mgr.NewAddress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, walletAddr.String(), addr.Address)
+
+	// Asking for an address again should return the same one.
+	addr2, err := mgr.NewAddress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, addr.Address, addr2.Address)
+
+	// When we fail to dispatch a swap, our deposit should be recorded
+	// so that we retry it.
+	require.NoError(t, mgr.checkDeposits(ctx))
+	require.Len(t, requests, 0)
+
+	deposits, err := mgr.ListDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 3)
+	require.Equal(t, loopdb.DepositConfirmed, deposits[0].State)
+	require.EqualValues(t, height-4, deposits[0].ConfirmationHeight)
+	require.Equal(t, loopdb.DepositTooLarge, deposits[1].State)
+	require.Equal(t, loopdb.DepositTooSmall, deposits[2].State)
+
+	// Once the server is available, we should dispatch a swap for the
+	// deposit less its htlc fee, with headroom on the quoted miner fee.
+	loopErr = nil
+	require.NoError(t, mgr.checkDeposits(ctx))
+	require.Equal(t, []*loop.LoopInRequest{
+		{
+			Amount:         40000 - minerFee,
+			MaxSwapFee:     swapFee,
+			MaxMinerFee:    minerFee * minerFeeMultiplier,
+			HtlcConfTarget: 6,
+			Label:          labels.StaticAddressLabel(),
+			Initiator:      depositInitiator,
+		},
+	}, requests)
+
+	deposits, err = mgr.ListDeposits()
+	require.NoError(t, err)
+	require.Equal(t, loopdb.DepositSwapInitiated, deposits[0].State)
+	require.Equal(t, &hash, deposits[0].SwapHash)
+
+	// Checking again should not dispatch another swap.
+	require.NoError(t, mgr.checkDeposits(ctx))
+	require.Len(t, requests, 1)
+}
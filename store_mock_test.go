@@ -1,11 +1,15 @@
 package loop
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
 	"github.com/lightninglabs/loop/test"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
@@ -22,6 +26,26 @@ type storeMock struct {
 	loopInStoreChan  chan loopdb.LoopInContract
 	loopInUpdateChan chan loopdb.SwapStateData
 
+	sweepBatches map[uint64]*loopdb.SweepBatch
+	sweepFees    map[lntypes.Hash]loopdb.SweepFee
+	refundTxs    map[lntypes.Hash]loopdb.RefundTx
+	xpubIndexes  map[string]uint32
+	channelFlows map[lntypes.Hash]loopdb.ChannelFlow
+	milestones   map[lntypes.Hash]loopdb.Milestones
+
+	staticAddress *loopdb.StaticAddress
+	deposits      map[wire.OutPoint]loopdb.Deposit
+
+	quotes []*loopdb.Quote
+
+	snapshots []*loopdb.ChannelSnapshot
+
+	peerOutcomes []*loopdb.PeerOutcome
+	ruleSwaps    []*loopdb.RuleSwap
+	checkpoints  map[lntypes.Hash]*loopdb.Checkpoint
+
+	templates map[string]*loopdb.SwapTemplate
+
 	t *testing.T
 }
 
@@ -37,6 +61,15 @@ func newStoreMock(t *testing.T) *storeMock {
 		loopInUpdateChan: make(chan loopdb.SwapStateData, 1),
 		loopInSwaps:      make(map[lntypes.Hash]*loopdb.LoopInContract),
 		loopInUpdates:    make(map[lntypes.Hash][]loopdb.SwapStateData),
+		sweepBatches:     make(map[uint64]*loopdb.SweepBatch),
+		sweepFees:        make(map[lntypes.Hash]loopdb.SweepFee),
+		refundTxs:        make(map[lntypes.Hash]loopdb.RefundTx),
+		xpubIndexes:      make(map[string]uint32),
+		channelFlows:     make(map[lntypes.Hash]loopdb.ChannelFlow),
+		milestones:       make(map[lntypes.Hash]loopdb.Milestones),
+		deposits:         make(map[wire.OutPoint]loopdb.Deposit),
+		templates:        make(map[string]*loopdb.SwapTemplate),
+		checkpoints:      make(map[lntypes.Hash]*loopdb.Checkpoint),
 		t:                t,
 	}
 }
@@ -44,7 +77,9 @@ func newStoreMock(t *testing.T) *storeMock {
 // FetchLoopOutSwaps returns all swaps currently in the store.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
-func (s *storeMock) FetchLoopOutSwaps() ([]*loopdb.LoopOut, error) {
+func (s *storeMock) FetchLoopOutSwaps(_ context.Context) ([]*loopdb.LoopOut,
+	error) {
+
 	result := []*loopdb.LoopOut{}
 
 	for hash, contract := range s.loopOutSwaps {
@@ -63,12 +98,46 @@ func (s *storeMock) FetchLoopOutSwaps() ([]*loopdb.LoopOut, error) {
 			},
 			Contract: contract,
 		}
+
+		if fee, ok := s.sweepFees[hash]; ok {
+			swap.SweepFee = &fee
+		}
+
+		if flow, ok := s.channelFlows[hash]; ok {
+			swap.ChannelFlow = &flow
+		}
+
+		swap.Milestones = s.milestones[hash]
+
 		result = append(result, swap)
 	}
 
 	return result, nil
 }
 
+// FilterLoopOutSwaps returns the loop out swaps in the store that match
+// the filter provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FilterLoopOutSwaps(ctx context.Context,
+	filter *loopdb.SwapFilter) ([]*loopdb.LoopOut, error) {
+
+	swaps, err := s.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*loopdb.LoopOut{}
+	for _, swap := range swaps {
+		state := swap.State().State
+		if filter.Matches(&swap.Contract.SwapContract, state) {
+			result = append(result, swap)
+		}
+	}
+
+	return result, nil
+}
+
 // CreateLoopOut adds an initiated swap to the store.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
@@ -80,6 +149,14 @@ func (s *storeMock) CreateLoopOut(hash lntypes.Hash,
 		return errors.New("swap already exists")
 	}
 
+	if index := swap.DestXpubIndex; index != nil {
+		if s.xpubIndexes[index.Xpub] > index.Index {
+			return loopdb.ErrXpubIndexUsed
+		}
+
+		s.xpubIndexes[index.Xpub] = index.Index + 1
+	}
+
 	s.loopOutSwaps[hash] = swap
 	s.loopOutUpdates[hash] = []loopdb.SwapStateData{}
 	s.loopOutStoreChan <- *swap
@@ -88,7 +165,9 @@ func (s *storeMock) CreateLoopOut(hash lntypes.Hash,
 }
 
 // FetchLoopInSwaps returns all in swaps currently in the store.
-func (s *storeMock) FetchLoopInSwaps() ([]*loopdb.LoopIn, error) {
+func (s *storeMock) FetchLoopInSwaps(_ context.Context) ([]*loopdb.LoopIn,
+	error) {
+
 	result := []*loopdb.LoopIn{}
 
 	for hash, contract := range s.loopInSwaps {
@@ -107,12 +186,64 @@ func (s *storeMock) FetchLoopInSwaps() ([]*loopdb.LoopIn, error) {
 			},
 			Contract: contract,
 		}
+
+		if refund, ok := s.refundTxs[hash]; ok {
+			swap.RefundTx = &refund
+		}
+
+		swap.Milestones = s.milestones[hash]
+
 		result = append(result, swap)
 	}
 
 	return result, nil
 }
 
+// FilterLoopInSwaps returns the loop in swaps in the store that match
+// the filter provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FilterLoopInSwaps(ctx context.Context,
+	filter *loopdb.SwapFilter) ([]*loopdb.LoopIn, error) {
+
+	swaps, err := s.FetchLoopInSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*loopdb.LoopIn{}
+	for _, swap := range swaps {
+		state := swap.State().State
+		if filter.Matches(&swap.Contract.SwapContract, state) {
+			result = append(result, swap)
+		}
+	}
+
+	return result, nil
+}
+
+// LookupIdempotencyKey returns the hash and type of the swap that was created
+// with an idempotency key, regardless of its type.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) LookupIdempotencyKey(key string) (lntypes.Hash,
+	swap.Type, error) {
+
+	for hash, contract := range s.loopOutSwaps {
+		if contract.IdempotencyKey == key {
+			return hash, swap.TypeOut, nil
+		}
+	}
+
+	for hash, contract := range s.loopInSwaps {
+		if contract.IdempotencyKey == key {
+			return hash, swap.TypeIn, nil
+		}
+	}
+
+	return lntypes.Hash{}, 0, loopdb.ErrIdempotencyKeyNotFound
+}
+
 // CreateLoopIn adds an initiated loop in swap to the store.
 //
 // NOTE: Part of the loopdb.SwapStore interface.
@@ -151,6 +282,102 @@ func (s *storeMock) UpdateLoopOut(hash lntypes.Hash, time time.Time,
 	return nil
 }
 
+// UpdateLoopOutSweepFee stores the fee rate that the most recent sweep for a
+// loop out swap was published with.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) UpdateLoopOutSweepFee(hash lntypes.Hash,
+	fee loopdb.SweepFee) error {
+
+	if _, ok := s.loopOutSwaps[hash]; !ok {
+		return errors.New("swap does not exists")
+	}
+
+	s.sweepFees[hash] = fee
+
+	return nil
+}
+
+// UpdateLoopOutChannelFlow stores the flow of a loop out's swap payment
+// through its outgoing channel set.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) UpdateLoopOutChannelFlow(hash lntypes.Hash,
+	flow loopdb.ChannelFlow) error {
+
+	if _, ok := s.loopOutSwaps[hash]; !ok {
+		return errors.New("swap does not exists")
+	}
+
+	s.channelFlows[hash] = flow
+
+	return nil
+}
+
+// UpdateLoopInRefundTx stores the most recent transaction that we published
+// to refund a loop in's htlc.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) UpdateLoopInRefundTx(hash lntypes.Hash,
+	refund loopdb.RefundTx) error {
+
+	if _, ok := s.loopInSwaps[hash]; !ok {
+		return errors.New("swap does not exists")
+	}
+
+	s.refundTxs[hash] = refund
+
+	return nil
+}
+
+// UpdateLoopOutMilestone records the time at which a loop out swap reached a
+// milestone.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) UpdateLoopOutMilestone(hash lntypes.Hash,
+	milestone loopdb.Milestone, time time.Time) error {
+
+	if _, ok := s.loopOutSwaps[hash]; !ok {
+		return errors.New("swap does not exists")
+	}
+
+	s.putMilestone(hash, milestone, time)
+
+	return nil
+}
+
+// UpdateLoopInMilestone records the time at which a loop in swap reached a
+// milestone.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) UpdateLoopInMilestone(hash lntypes.Hash,
+	milestone loopdb.Milestone, time time.Time) error {
+
+	if _, ok := s.loopInSwaps[hash]; !ok {
+		return errors.New("swap does not exists")
+	}
+
+	s.putMilestone(hash, milestone, time)
+
+	return nil
+}
+
+// putMilestone records a milestone for a swap if it has not already been
+// recorded.
+func (s *storeMock) putMilestone(hash lntypes.Hash,
+	milestone loopdb.Milestone, time time.Time) {
+
+	milestones, ok := s.milestones[hash]
+	if !ok {
+		milestones = make(loopdb.Milestones)
+		s.milestones[hash] = milestones
+	}
+
+	if _, ok := milestones[milestone]; !ok {
+		milestones[milestone] = time
+	}
+}
+
 // UpdateLoopIn stores a new event for a target loop in swap. This appends to
 // the event log for a particular swap as it goes through the various stages in
 // its lifetime.
@@ -171,6 +398,296 @@ func (s *storeMock) UpdateLoopIn(hash lntypes.Hash, time time.Time,
 	return nil
 }
 
+// PutSweepBatch persists a sweep batch, assigning it a new id if it does not
+// have one yet.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) PutSweepBatch(batch *loopdb.SweepBatch) error {
+	if batch.ID == 0 {
+		batch.ID = uint64(len(s.sweepBatches) + 1)
+	}
+
+	stored := *batch
+	s.sweepBatches[batch.ID] = &stored
+
+	return nil
+}
+
+// FetchSweepBatches returns all the sweep batches in the store.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchSweepBatches() ([]*loopdb.SweepBatch, error) {
+	batches := make([]*loopdb.SweepBatch, 0, len(s.sweepBatches))
+	for id := uint64(1); id <= uint64(len(s.sweepBatches)); id++ {
+		batch := *s.sweepBatches[id]
+		batches = append(batches, &batch)
+	}
+
+	return batches, nil
+}
+
+// FetchXpubIndex returns the next unused derivation index for an extended
+// public key.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchXpubIndex(xpub string) (uint32, error) {
+	return s.xpubIndexes[xpub], nil
+}
+
+// CreateStaticAddress persists our static address.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) CreateStaticAddress(addr *loopdb.StaticAddress) error {
+	if s.staticAddress != nil {
+		return loopdb.ErrStaticAddressExists
+	}
+
+	stored := *addr
+	s.staticAddress = &stored
+
+	return nil
+}
+
+// FetchStaticAddress returns our static address.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchStaticAddress() (*loopdb.StaticAddress, error) {
+	if s.staticAddress == nil {
+		return nil, loopdb.ErrNoStaticAddress
+	}
+
+	addr := *s.staticAddress
+
+	return &addr, nil
+}
+
+// PutDeposit persists a deposit to our static address.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) PutDeposit(deposit *loopdb.Deposit) error {
+	s.deposits[deposit.OutPoint] = *deposit
+
+	return nil
+}
+
+// FetchDeposits returns all deposits to our static address.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchDeposits() ([]*loopdb.Deposit, error) {
+	deposits := make([]*loopdb.Deposit, 0, len(s.deposits))
+	for _, deposit := range s.deposits {
+		deposit := deposit
+		deposits = append(deposits, &deposit)
+	}
+
+	return deposits, nil
+}
+
+// AddQuote appends a quote to our quote history.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) AddQuote(quote *loopdb.Quote) error {
+	s.quotes = append(s.quotes, quote)
+
+	return nil
+}
+
+// FetchQuotes returns all the quotes in our quote history.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchQuotes(_ context.Context) ([]*loopdb.Quote, error) {
+	return s.quotes, nil
+}
+
+// AddLiquiditySnapshots stores a set of channel balance snapshots.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) AddLiquiditySnapshots(
+	snapshots []*loopdb.ChannelSnapshot) error {
+
+	s.snapshots = append(s.snapshots, snapshots...)
+
+	return nil
+}
+
+// FetchLiquiditySnapshots returns all the channel balance snapshots taken at
+// or after the time provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchLiquiditySnapshots(_ context.Context,
+	since time.Time) ([]*loopdb.ChannelSnapshot, error) {
+
+	var snapshots []*loopdb.ChannelSnapshot
+	for _, snapshot := range s.snapshots {
+		if !snapshot.Time.Before(since) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	return snapshots, nil
+}
+
+// PruneLiquiditySnapshots deletes all the channel balance snapshots taken
+// before the time provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) PruneLiquiditySnapshots(ctx context.Context,
+	before time.Time) error {
+
+	snapshots, err := s.FetchLiquiditySnapshots(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	s.snapshots = snapshots
+
+	return nil
+}
+
+// AddPeerOutcome records the outcome of a swap for a peer.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) AddPeerOutcome(outcome *loopdb.PeerOutcome) error {
+	s.peerOutcomes = append(s.peerOutcomes, outcome)
+
+	return nil
+}
+
+// FetchPeerOutcomes returns all the swap outcomes recorded for our peers.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchPeerOutcomes(_ context.Context) (
+	[]*loopdb.PeerOutcome, error) {
+
+	return s.peerOutcomes, nil
+}
+
+// AddRuleSwap attributes a swap to the autoloop rule that it was dispatched
+// for.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) AddRuleSwap(ruleSwap *loopdb.RuleSwap) error {
+	s.ruleSwaps = append(s.ruleSwaps, ruleSwap)
+
+	return nil
+}
+
+// FetchRuleSwaps returns all the swaps attributed to autoloop rules.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchRuleSwaps(_ context.Context) ([]*loopdb.RuleSwap,
+	error) {
+
+	return s.ruleSwaps, nil
+}
+
+// StoreCheckpoints replaces our set of checkpoints with the set provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) StoreCheckpoints(checkpoints []*loopdb.Checkpoint) error {
+	s.checkpoints = make(map[lntypes.Hash]*loopdb.Checkpoint)
+	for _, checkpoint := range checkpoints {
+		s.checkpoints[checkpoint.SwapHash] = checkpoint
+	}
+
+	return nil
+}
+
+// FetchCheckpoints returns our set of checkpoints.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchCheckpoints(_ context.Context) (
+	map[lntypes.Hash]*loopdb.Checkpoint, error) {
+
+	return s.checkpoints, nil
+}
+
+// FetchAuditLog returns our audit log. Our mock does not keep an audit log,
+// so it is always empty.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchAuditLog(_ context.Context) ([]*loopdb.AuditEntry,
+	error) {
+
+	return nil, nil
+}
+
+// PruneSwaps deletes all swaps in a final state. Our mock does not record the
+// time of swap updates, so the time provided is ignored.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) PruneSwaps(_ context.Context, _ time.Time) (
+	[]lntypes.Hash, error) {
+
+	var pruned []lntypes.Hash
+
+	final := func(updates []loopdb.SwapStateData) bool {
+		return len(updates) > 0 && updates[len(updates)-1].State.Type() !=
+			loopdb.StateTypePending
+	}
+
+	for hash, updates := range s.loopOutUpdates {
+		if final(updates) {
+			delete(s.loopOutSwaps, hash)
+			delete(s.loopOutUpdates, hash)
+			pruned = append(pruned, hash)
+		}
+	}
+
+	for hash, updates := range s.loopInUpdates {
+		if final(updates) {
+			delete(s.loopInSwaps, hash)
+			delete(s.loopInUpdates, hash)
+			pruned = append(pruned, hash)
+		}
+	}
+
+	return pruned, nil
+}
+
+// PutSwapTemplate stores a swap template, replacing any existing template with
+// the same name.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) PutSwapTemplate(template *loopdb.SwapTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	s.templates[template.Name] = template
+
+	return nil
+}
+
+// FetchSwapTemplates returns all of our swap templates, ordered by name.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) FetchSwapTemplates() ([]*loopdb.SwapTemplate, error) {
+	templates := make([]*loopdb.SwapTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+// DeleteSwapTemplate deletes the swap template with the name provided.
+//
+// NOTE: Part of the loopdb.SwapStore interface.
+func (s *storeMock) DeleteSwapTemplate(name string) error {
+	if _, ok := s.templates[name]; !ok {
+		return loopdb.ErrSwapTemplateNotFound
+	}
+
+	delete(s.templates, name)
+
+	return nil
+}
+
 func (s *storeMock) Close() error {
 	return nil
 }
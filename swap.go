@@ -2,15 +2,27 @@ package loop
 
 import (
 	"context"
+	"crypto/rand"
+	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopdb"
 	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
 
 type swapKit struct {
+	// serverFailure is the failure reason that the server reported for
+	// the swap, if any. It is set by our server update subscription.
+	serverFailure uint32 // To be used atomically.
+
+	// phase is the execution phase that the swap has reached. It is
+	// restored from our last checkpoint when the swap is resumed.
+	phase uint32 // To be used atomically.
+
 	hash lntypes.Hash
 
 	height int32
@@ -23,6 +35,11 @@ type swapKit struct {
 
 	state loopdb.SwapState
 
+	// failure is the reason that we failed the swap for, which is set
+	// along with our failed state. It is only reported once the swap is
+	// in a failed state.
+	failure loopdb.FailureReason
+
 	contract *loopdb.SwapContract
 
 	swapType swap.Type
@@ -49,30 +66,19 @@ func newSwapKit(hash lntypes.Hash, swapType swap.Type, cfg *swapConfig,
 }
 
 // GetHtlcScriptVersion returns the correct HTLC script version for the passed
-// protocol version.
+// protocol version. New swaps record the version that they are created with,
+// so this should only be used to pick the version of new swaps.
 func GetHtlcScriptVersion(
 	protocolVersion loopdb.ProtocolVersion) swap.ScriptVersion {
 
-	if protocolVersion != loopdb.ProtocolVersionUnrecorded &&
-		protocolVersion >= loopdb.ProtocolVersionHtlcV2 {
-
-		// Use HTLC v2 script only if we know the swap was initiated
-		// with a client that supports HTLC v2. Unrecorded protocol
-		// version implies that there was no protocol version stored
-		// along side a serialized swap that we're resuming in which
-		// case the swap was initiated with HTLC v1 script.
-		return swap.HtlcV2
-	}
-
-	return swap.HtlcV1
+	return loopdb.HtlcVersionForProtocol(protocolVersion)
 }
 
 // getHtlc composes and returns the on-chain swap script.
 func (s *swapKit) getHtlc(outputType swap.HtlcOutputType) (*swap.Htlc, error) {
 	return swap.NewHtlc(
-		GetHtlcScriptVersion(s.contract.ProtocolVersion),
-		s.contract.CltvExpiry, s.contract.SenderKey,
-		s.contract.ReceiverKey, s.hash, outputType,
+		s.contract.HtlcVersion, s.contract.CltvExpiry,
+		s.contract.SenderKey, s.contract.ReceiverKey, s.hash, outputType,
 		s.swapConfig.lnd.ChainParams,
 	)
 }
@@ -86,23 +92,134 @@ func (s *swapKit) swapInfo() *SwapInfo {
 		SwapType:     s.swapType,
 		LastUpdate:   s.lastUpdateTime,
 		SwapStateData: loopdb.SwapStateData{
-			State: s.state,
-			Cost:  s.cost,
+			State:         s.state,
+			Cost:          s.cost,
+			ServerFailure: s.serverFailureReason(),
+			FailureReason: s.failureReason(),
 		},
 	}
 }
 
+// setServerFailure records the failure reason that the server reported for
+// the swap.
+func (s *swapKit) setServerFailure(reason loopdb.ServerFailureReason) {
+	atomic.StoreUint32(&s.serverFailure, uint32(reason))
+}
+
+// serverFailureReason returns the failure reason that the server reported
+// for the swap if it is in a failed state, and ServerFailureNone otherwise.
+func (s *swapKit) serverFailureReason() loopdb.ServerFailureReason {
+	if s.state.Type() != loopdb.StateTypeFail {
+		return loopdb.ServerFailureNone
+	}
+
+	return loopdb.ServerFailureReason(atomic.LoadUint32(&s.serverFailure))
+}
+
+// failureReason returns the reason that we failed the swap for if it is in a
+// failed state, and FailureReasonNone otherwise. If we did not fail the swap
+// for a specific reason, but the server reported a failure, we attribute our
+// failure to the server. Temporary failures are internal errors, so they are
+// never attributed to the server.
+func (s *swapKit) failureReason() loopdb.FailureReason {
+	if s.state.Type() != loopdb.StateTypeFail {
+		return loopdb.FailureReasonNone
+	}
+
+	if s.failure != loopdb.FailureReasonNone {
+		return s.failure
+	}
+
+	if s.state != loopdb.StateFailTemporary &&
+		s.serverFailureReason() != loopdb.ServerFailureNone {
+
+		return loopdb.FailureReasonServerRejected
+	}
+
+	return loopdb.FailureReasonNone
+}
+
+// swapHash returns the hash that identifies the swap.
+func (s *swapKit) swapHash() lntypes.Hash {
+	return s.hash
+}
+
+// setPhase advances the execution phase of the swap. Phases never move
+// backwards, so phases lower than the swap's current phase are ignored.
+func (s *swapKit) setPhase(phase loopdb.ExecutionPhase) {
+	for {
+		current := atomic.LoadUint32(&s.phase)
+		if uint32(phase) <= current {
+			return
+		}
+
+		if atomic.CompareAndSwapUint32(&s.phase, current,
+			uint32(phase)) {
+
+			return
+		}
+	}
+}
+
+// executionPhase returns the execution phase that the swap has reached.
+func (s *swapKit) executionPhase() loopdb.ExecutionPhase {
+	return loopdb.ExecutionPhase(atomic.LoadUint32(&s.phase))
+}
+
+// recordMilestone records the time at which the swap reached a milestone.
+// Milestones are only used to report the duration of swap phases, so failing
+// to record one is logged rather than failing the swap.
+func (s *swapKit) recordMilestone(milestone loopdb.Milestone) {
+	var (
+		now = s.clock.Now()
+		err error
+	)
+
+	switch s.swapType {
+	case swap.TypeOut:
+		err = s.store.UpdateLoopOutMilestone(s.hash, milestone, now)
+
+	case swap.TypeIn:
+		err = s.store.UpdateLoopInMilestone(s.hash, milestone, now)
+	}
+
+	if err != nil {
+		s.log.Warnf("Could not record milestone %v: %v", milestone,
+			err)
+	}
+}
+
 type genericSwap interface {
 	execute(mainCtx context.Context, cfg *executeConfig,
 		height int32) error
+
+	// swapHash returns the hash that identifies the swap.
+	swapHash() lntypes.Hash
+
+	// setPhase advances the execution phase of the swap.
+	setPhase(phase loopdb.ExecutionPhase)
+
+	// executionPhase returns the execution phase that the swap has
+	// reached.
+	executionPhase() loopdb.ExecutionPhase
 }
 
 type swapConfig struct {
 	lnd    *lndclient.LndServices
 	store  loopdb.SwapStore
 	server swapServerClient
+
+	// clock is used to timestamp swaps.
+	clock clock.Clock
+
+	// rand is the source of randomness that swap preimages are generated
+	// from.
+	rand io.Reader
 }
 
+// newSwapConfig creates a swap config that uses the system clock and a
+// cryptographically secure source of randomness. Tests may override these
+// to make swaps deterministic.
 func newSwapConfig(lnd *lndclient.LndServices, store loopdb.SwapStore,
 	server swapServerClient) *swapConfig {
 
@@ -110,5 +227,7 @@ func newSwapConfig(lnd *lndclient.LndServices, store loopdb.SwapStore,
 		lnd:    lnd,
 		store:  store,
 		server: server,
+		clock:  clock.NewDefaultClock(),
+		rand:   rand.Reader,
 	}
 }
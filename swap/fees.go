@@ -48,3 +48,24 @@ func DecodeInvoice(params *chaincfg.Params,
 
 	return hash, swapPayReq.MilliSat.ToSatoshis(), nil
 }
+
+// DecodeInvoiceDestination returns the pubkey of the node that an invoice
+// pays to.
+func DecodeInvoiceDestination(params *chaincfg.Params,
+	payReq string) ([33]byte, error) {
+
+	var dest [33]byte
+
+	invoice, err := zpay32.Decode(payReq, params)
+	if err != nil {
+		return dest, err
+	}
+
+	if invoice.Destination == nil {
+		return dest, errors.New("no destination in invoice")
+	}
+
+	copy(dest[:], invoice.Destination.SerializeCompressed())
+
+	return dest, nil
+}
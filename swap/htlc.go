@@ -111,6 +111,20 @@ func (h HtlcOutputType) String() string {
 	}
 }
 
+// String returns the string value of ScriptVersion.
+func (v ScriptVersion) String() string {
+	switch v {
+	case HtlcV1:
+		return "V1"
+
+	case HtlcV2:
+		return "V2"
+
+	default:
+		return "unknown"
+	}
+}
+
 // NewHtlc returns a new instance.
 func NewHtlc(version ScriptVersion, cltvExpiry int32,
 	senderKey, receiverKey [33]byte,
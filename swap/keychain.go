@@ -1,5 +1,13 @@
 package swap
 
+import (
+	"encoding/binary"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
 var (
 	// KeyFamily is the key family used to generate keys that allow
 	// spending of the htlc.
@@ -7,3 +15,16 @@ var (
 	// TODO(joost): decide on actual value
 	KeyFamily = int32(99)
 )
+
+// KeyLocatorFromHash returns the key locator that our htlc key for the swap
+// with the hash provided is derived at. The index is taken from the first
+// four bytes of the swap hash, limited to the non-hardened range, so that our
+// key can be reconstructed from our seed and the swap hash alone.
+func KeyLocatorFromHash(hash lntypes.Hash) keychain.KeyLocator {
+	index := binary.BigEndian.Uint32(hash[:4])
+
+	return keychain.KeyLocator{
+		Family: keychain.KeyFamily(KeyFamily),
+		Index:  index % hdkeychain.HardenedKeyStart,
+	}
+}
@@ -0,0 +1,54 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKeyLocatorFromHash tests that key locators are derived from the prefix
+// of the swap hash, and that the index is always non-hardened.
+func TestKeyLocatorFromHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		hash  lntypes.Hash
+		index uint32
+	}{
+		{
+			name:  "zero hash",
+			hash:  lntypes.Hash{},
+			index: 0,
+		},
+		{
+			name:  "non-hardened prefix",
+			hash:  lntypes.Hash{0x01, 0x02, 0x03, 0x04, 0xff},
+			index: 0x01020304,
+		},
+		{
+			name:  "hardened prefix",
+			hash:  lntypes.Hash{0x81, 0x02, 0x03, 0x04},
+			index: 0x01020304,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			locator := KeyLocatorFromHash(testCase.hash)
+
+			require.Equal(
+				t, keychain.KeyFamily(KeyFamily),
+				locator.Family,
+			)
+			require.Equal(t, testCase.index, locator.Index)
+			require.Less(
+				t, locator.Index,
+				uint32(hdkeychain.HardenedKeyStart),
+			)
+		})
+	}
+}
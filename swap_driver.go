@@ -0,0 +1,375 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// swapDriver implements the client side of a swap protocol. It restores the
+// protocol's swaps from the store so that they can be reported on and handed
+// to the executor, which allows the client to handle every swap protocol the
+// same way rather than switching on the swap type.
+type swapDriver interface {
+	// swapType returns the type of swap that the driver handles.
+	swapType() swap.Type
+
+	// fetchSwaps returns information about the driver's swaps in the
+	// store that match the filter provided. A nil filter returns all of
+	// the driver's swaps.
+	fetchSwaps(ctx context.Context, cfg *swapConfig,
+		filter *loopdb.SwapFilter) ([]*SwapInfo, error)
+
+	// resumePending returns all of the driver's swaps that are still
+	// pending, ready to be executed. Swaps that cannot be resumed are
+	// logged and skipped so that they do not prevent other swaps from
+	// resuming.
+	resumePending(ctx context.Context, cfg *swapConfig) ([]genericSwap,
+		error)
+
+	// abandon persists the abandoned state of one of the driver's swaps,
+	// which has already been stopped by the executor provided, and
+	// releases any resources the executor holds for it.
+	abandon(ctx context.Context, exec *executor, info *SwapInfo) error
+}
+
+var (
+	// swapDrivers holds all of the swap drivers that have been registered,
+	// keyed by the type of swap that they handle.
+	swapDrivers = make(map[swap.Type]swapDriver)
+
+	// swapDriversMtx protects swapDrivers.
+	swapDriversMtx sync.Mutex
+)
+
+func init() {
+	for _, driver := range []swapDriver{
+		&loopOutDriver{}, &loopInDriver{},
+	} {
+		if err := registerSwapDriver(driver); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// registerSwapDriver registers the driver for a swap protocol. New swap
+// protocols should register their driver in an init function. It fails if a
+// driver is already registered for the driver's swap type.
+func registerSwapDriver(driver swapDriver) error {
+	swapDriversMtx.Lock()
+	defer swapDriversMtx.Unlock()
+
+	swapType := driver.swapType()
+	if _, ok := swapDrivers[swapType]; ok {
+		return fmt.Errorf("swap driver already registered for: %v",
+			swapType)
+	}
+
+	swapDrivers[swapType] = driver
+
+	return nil
+}
+
+// swapDriverFor returns the driver registered for the swap type provided.
+func swapDriverFor(swapType swap.Type) (swapDriver, error) {
+	swapDriversMtx.Lock()
+	defer swapDriversMtx.Unlock()
+
+	driver, ok := swapDrivers[swapType]
+	if !ok {
+		return nil, fmt.Errorf("unknown swap type: %v", swapType)
+	}
+
+	return driver, nil
+}
+
+// registeredSwapDrivers returns all registered swap drivers, ordered by swap
+// type so that swaps are always loaded in the same order.
+func registeredSwapDrivers() []swapDriver {
+	swapDriversMtx.Lock()
+	defer swapDriversMtx.Unlock()
+
+	drivers := make([]swapDriver, 0, len(swapDrivers))
+	for _, driver := range swapDrivers {
+		drivers = append(drivers, driver)
+	}
+
+	sort.Slice(drivers, func(i, j int) bool {
+		return drivers[i].swapType() < drivers[j].swapType()
+	})
+
+	return drivers
+}
+
+// loopOutDriver is the swap driver for loop out swaps.
+type loopOutDriver struct{}
+
+// swapType returns the type of swap that the driver handles.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopOutDriver) swapType() swap.Type {
+	return swap.TypeOut
+}
+
+// fetchSwaps returns information about the loop out swaps in the store
+// that match the filter provided.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopOutDriver) fetchSwaps(ctx context.Context, cfg *swapConfig,
+	filter *loopdb.SwapFilter) ([]*SwapInfo, error) {
+
+	loopOutSwaps, err := cfg.store.FilterLoopOutSwaps(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	swaps := make([]*SwapInfo, 0, len(loopOutSwaps))
+	for _, swp := range loopOutSwaps {
+		htlc, err := swap.NewHtlc(
+			swp.Contract.HtlcVersion,
+			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
+			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcP2WSH,
+			cfg.lnd.ChainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		swaps = append(swaps, &SwapInfo{
+			SwapType:         swap.TypeOut,
+			SwapContract:     swp.Contract.SwapContract,
+			SwapStateData:    swp.State(),
+			SwapHash:         swp.Hash,
+			LastUpdate:       swp.LastUpdateTime(),
+			HtlcAddressP2WSH: htlc.Address,
+			ChannelFlow:      swp.ChannelFlow,
+			ServerHtlcConfTarget: swp.Contract.
+				ServerHtlcConfTarget,
+			MaxSwapRoutingFee:   swp.Contract.MaxSwapRoutingFee,
+			MaxPrepayRoutingFee: swp.Contract.MaxPrepayRoutingFee,
+			Milestones:          swp.Milestones,
+		})
+	}
+
+	return swaps, nil
+}
+
+// resumePending returns all pending loop out swaps.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopOutDriver) resumePending(ctx context.Context,
+	cfg *swapConfig) ([]genericSwap, error) {
+
+	loopOutSwaps, err := cfg.store.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var swaps []genericSwap
+	for _, pend := range loopOutSwaps {
+		if pend.State().State.Type() != loopdb.StateTypePending {
+			continue
+		}
+
+		swap, err := resumeLoopOutSwap(ctx, cfg, pend)
+		if err != nil {
+			log.Errorf("resuming loop out swap: %v", err)
+			continue
+		}
+
+		swaps = append(swaps, swap)
+	}
+
+	return swaps, nil
+}
+
+// abandon persists the abandoned state of a loop out swap and removes its htlc
+// from our sweep batches so that we stop trying to sweep it. If we have not
+// revealed our preimage yet, we ask the server to cancel the swap so that it
+// releases our prepay rather than settling it, and record the release with
+// the swap's final state.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopOutDriver) abandon(ctx context.Context, exec *executor,
+	info *SwapInfo) error {
+
+	// Releasing our prepay is best effort. The swap has already been
+	// stopped, so we still persist its abandoned state if the server can't
+	// be reached or refuses, we just keep our failure reason unchanged.
+	released, err := releaseLoopOutPrepay(ctx, exec, info.SwapHash)
+	if err != nil {
+		log.Warnf("Could not release prepay of abandoned swap %v: %v",
+			info.SwapHash, err)
+	}
+
+	if released {
+		log.Infof("Server released prepay of abandoned swap %v",
+			info.SwapHash)
+
+		info.FailureReason = loopdb.FailureReasonPrepayReleased
+	}
+
+	state := info.SwapStateData
+	state.Component = loopdb.AuditComponentAbandon
+
+	err = exec.store.UpdateLoopOut(info.SwapHash, info.LastUpdate, state)
+	if err != nil {
+		return err
+	}
+
+	if exec.batcher == nil {
+		return nil
+	}
+
+	return exec.batcher.RemoveInput(info.SwapHash, chainhash.Hash{})
+}
+
+// releaseLoopOutPrepay asks the server to cancel a loop out swap that is being
+// abandoned, so that it releases our prepay rather than settling it. We can
+// only do so while the preimage has not been revealed, and the server will
+// only agree to it if it has not yet published the swap's htlc. It returns a
+// boolean indicating whether our prepay was released.
+func releaseLoopOutPrepay(ctx context.Context, exec *executor,
+	hash lntypes.Hash) (bool, error) {
+
+	if exec.abandonLoopOut == nil {
+		return false, nil
+	}
+
+	loopOutSwaps, err := exec.store.FetchLoopOutSwaps(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, swp := range loopOutSwaps {
+		if swp.Hash != hash {
+			continue
+		}
+
+		// We read our state from the store rather than using the
+		// state of the swap info, because the latter has already
+		// been updated to abandoned.
+		if swp.State().State != loopdb.StateInitiated {
+			return false, nil
+		}
+
+		paymentAddr, err := swapPaymentAddr(
+			swp.Contract.SwapInvoice, exec.lnd.ChainParams,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		return exec.abandonLoopOut(ctx, hash, paymentAddr)
+	}
+
+	return false, fmt.Errorf("loop out swap %v not found", hash)
+}
+
+// loopInDriver is the swap driver for loop in swaps.
+type loopInDriver struct{}
+
+// swapType returns the type of swap that the driver handles.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopInDriver) swapType() swap.Type {
+	return swap.TypeIn
+}
+
+// fetchSwaps returns information about the loop in swaps in the store
+// that match the filter provided.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopInDriver) fetchSwaps(ctx context.Context, cfg *swapConfig,
+	filter *loopdb.SwapFilter) ([]*SwapInfo, error) {
+
+	loopInSwaps, err := cfg.store.FilterLoopInSwaps(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	swaps := make([]*SwapInfo, 0, len(loopInSwaps))
+	for _, swp := range loopInSwaps {
+		htlcNP2WSH, err := swap.NewHtlc(
+			swp.Contract.HtlcVersion,
+			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
+			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcNP2WSH,
+			cfg.lnd.ChainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		htlcP2WSH, err := swap.NewHtlc(
+			swp.Contract.HtlcVersion,
+			swp.Contract.CltvExpiry, swp.Contract.SenderKey,
+			swp.Contract.ReceiverKey, swp.Hash, swap.HtlcP2WSH,
+			cfg.lnd.ChainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		swaps = append(swaps, &SwapInfo{
+			SwapType:          swap.TypeIn,
+			SwapContract:      swp.Contract.SwapContract,
+			SwapStateData:     swp.State(),
+			SwapHash:          swp.Hash,
+			LastUpdate:        swp.LastUpdateTime(),
+			HtlcAddressP2WSH:  htlcP2WSH.Address,
+			HtlcAddressNP2WSH: htlcNP2WSH.Address,
+			HtlcConfTarget:    swp.Contract.HtlcConfTarget,
+			RefundTx:          swp.RefundTx,
+			Milestones:        swp.Milestones,
+		})
+	}
+
+	return swaps, nil
+}
+
+// resumePending returns all pending loop in swaps.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopInDriver) resumePending(ctx context.Context,
+	cfg *swapConfig) ([]genericSwap, error) {
+
+	loopInSwaps, err := cfg.store.FetchLoopInSwaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var swaps []genericSwap
+	for _, pend := range loopInSwaps {
+		if pend.State().State.Type() != loopdb.StateTypePending {
+			continue
+		}
+
+		swap, err := resumeLoopInSwap(ctx, cfg, pend)
+		if err != nil {
+			log.Errorf("resuming loop in swap: %v", err)
+			continue
+		}
+
+		swaps = append(swaps, swap)
+	}
+
+	return swaps, nil
+}
+
+// abandon persists the abandoned state of a loop in swap.
+//
+// NOTE: This is part of the swapDriver interface.
+func (d *loopInDriver) abandon(_ context.Context, exec *executor,
+	info *SwapInfo) error {
+
+	state := info.SwapStateData
+	state.Component = loopdb.AuditComponentAbandon
+
+	return exec.store.UpdateLoopIn(info.SwapHash, info.LastUpdate, state)
+}
@@ -0,0 +1,27 @@
+package loop
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/loop/swap"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwapDrivers tests that our built-in swap drivers are registered, and
+// that a second driver cannot be registered for the same swap type.
+func TestSwapDrivers(t *testing.T) {
+	drivers := registeredSwapDrivers()
+	require.Len(t, drivers, 2)
+	require.Equal(t, swap.TypeIn, drivers[0].swapType())
+	require.Equal(t, swap.TypeOut, drivers[1].swapType())
+
+	driver, err := swapDriverFor(swap.TypeOut)
+	require.NoError(t, err)
+	require.Equal(t, swap.TypeOut, driver.swapType())
+
+	_, err = swapDriverFor(swap.Type(100))
+	require.Error(t, err)
+
+	require.Error(t, registerSwapDriver(&loopOutDriver{}))
+	require.Len(t, registeredSwapDrivers(), 2)
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/tor"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -45,7 +47,7 @@ type swapServerClient interface {
 		*LoopOutTerms, error)
 
 	GetLoopOutQuote(ctx context.Context, amt btcutil.Amount, expiry int32,
-		swapPublicationDeadline time.Time) (
+		swapPublicationDeadline time.Time, htlcConfTarget int32) (
 		*LoopOutQuote, error)
 
 	GetLoopInTerms(ctx context.Context) (
@@ -57,7 +59,8 @@ type swapServerClient interface {
 	NewLoopOutSwap(ctx context.Context,
 		swapHash lntypes.Hash, amount btcutil.Amount, expiry int32,
 		receiverKey [33]byte, swapPublicationDeadline time.Time,
-		initiator string) (*newLoopOutResponse, error)
+		htlcConfTarget int32, initiator string) (*newLoopOutResponse,
+		error)
 
 	PushLoopOutPreimage(ctx context.Context,
 		preimage lntypes.Preimage) error
@@ -79,12 +82,31 @@ type swapServerClient interface {
 	// CancelLoopOutSwap cancels a loop out swap.
 	CancelLoopOutSwap(ctx context.Context,
 		details *outCancelDetails) error
+
+	// AbandonLoopOutSwap cancels a loop out swap that the client has
+	// abandoned before revealing its preimage. It returns a boolean
+	// indicating whether the server released the swap's prepay rather
+	// than settling it, which it only does if it has not yet published
+	// the swap's htlc.
+	AbandonLoopOutSwap(ctx context.Context, hash lntypes.Hash,
+		paymentAddr [32]byte) (bool, error)
+
+	// Features returns the protocol version and optional features that
+	// we negotiated with the server.
+	Features(ctx context.Context) (*ServerFeatures, error)
 }
 
 type grpcSwapServerClient struct {
 	server looprpc.SwapServerClient
 	conn   *grpc.ClientConn
 
+	// features holds the features that we negotiated with the server. It
+	// is nil until negotiation has succeeded, and is stored per
+	// connection because different servers may support different
+	// features.
+	features    *ServerFeatures
+	featuresMtx sync.Mutex
+
 	wg sync.WaitGroup
 }
 
@@ -92,7 +114,7 @@ type grpcSwapServerClient struct {
 // them to complete.
 func (s *grpcSwapServerClient) stop() {
 	if err := s.conn.Close(); err != nil {
-		log.Warnf("could not close connection: %v", err)
+		serverLog.Warnf("could not close connection: %v", err)
 	}
 
 	s.wg.Wait()
@@ -100,8 +122,8 @@ func (s *grpcSwapServerClient) stop() {
 
 var _ swapServerClient = (*grpcSwapServerClient)(nil)
 
-func newSwapServerClient(cfg *ClientConfig, lsatStore lsat.Store) (
-	*grpcSwapServerClient, error) {
+func newSwapServerClient(cfg *ClientConfig, address string,
+	lsatStore lsat.Store) (*grpcSwapServerClient, error) {
 
 	// Create the server connection with the interceptor that will handle
 	// the LSAT protocol for us.
@@ -110,7 +132,7 @@ func newSwapServerClient(cfg *ClientConfig, lsatStore lsat.Store) (
 		cfg.MaxLsatFee, false,
 	)
 	serverConn, err := getSwapServerConn(
-		cfg.ServerAddress, cfg.ProxyAddress, cfg.SwapServerNoTLS,
+		address, cfg.ProxyAddress, cfg.SwapServerNoTLS,
 		cfg.TLSPathServer, clientInterceptor,
 	)
 	if err != nil {
@@ -148,8 +170,8 @@ func (s *grpcSwapServerClient) GetLoopOutTerms(ctx context.Context) (
 }
 
 func (s *grpcSwapServerClient) GetLoopOutQuote(ctx context.Context,
-	amt btcutil.Amount, expiry int32, swapPublicationDeadline time.Time) (
-	*LoopOutQuote, error) {
+	amt btcutil.Amount, expiry int32, swapPublicationDeadline time.Time,
+	htlcConfTarget int32) (*LoopOutQuote, error) {
 
 	rpcCtx, rpcCancel := context.WithTimeout(ctx, globalCallTimeout)
 	defer rpcCancel()
@@ -159,6 +181,7 @@ func (s *grpcSwapServerClient) GetLoopOutQuote(ctx context.Context,
 			SwapPublicationDeadline: swapPublicationDeadline.Unix(),
 			ProtocolVersion:         loopdb.CurrentRPCProtocolVersion,
 			Expiry:                  expiry,
+			HtlcConfTarget:          htlcConfTarget,
 		},
 	)
 	if err != nil {
@@ -176,9 +199,10 @@ func (s *grpcSwapServerClient) GetLoopOutQuote(ctx context.Context,
 	copy(destArray[:], dest)
 
 	return &LoopOutQuote{
-		PrepayAmount:    btcutil.Amount(quoteResp.PrepayAmt),
-		SwapFee:         btcutil.Amount(quoteResp.SwapFee),
-		SwapPaymentDest: destArray,
+		PrepayAmount:         btcutil.Amount(quoteResp.PrepayAmt),
+		SwapFee:              btcutil.Amount(quoteResp.SwapFee),
+		SwapPaymentDest:      destArray,
+		ServerHtlcConfTarget: quoteResp.HtlcConfTarget,
 	}, nil
 }
 
@@ -226,7 +250,7 @@ func (s *grpcSwapServerClient) GetLoopInQuote(ctx context.Context,
 func (s *grpcSwapServerClient) NewLoopOutSwap(ctx context.Context,
 	swapHash lntypes.Hash, amount btcutil.Amount, expiry int32,
 	receiverKey [33]byte, swapPublicationDeadline time.Time,
-	initiator string) (*newLoopOutResponse, error) {
+	htlcConfTarget int32, initiator string) (*newLoopOutResponse, error) {
 
 	rpcCtx, rpcCancel := context.WithTimeout(ctx, globalCallTimeout)
 	defer rpcCancel()
@@ -239,6 +263,7 @@ func (s *grpcSwapServerClient) NewLoopOutSwap(ctx context.Context,
 			ProtocolVersion:         loopdb.CurrentRPCProtocolVersion,
 			Expiry:                  expiry,
 			UserAgent:               UserAgent(initiator),
+			HtlcConfTarget:          htlcConfTarget,
 		},
 	)
 	if err != nil {
@@ -255,10 +280,12 @@ func (s *grpcSwapServerClient) NewLoopOutSwap(ctx context.Context,
 	}
 
 	return &newLoopOutResponse{
-		swapInvoice:   swapResp.SwapInvoice,
-		prepayInvoice: swapResp.PrepayInvoice,
-		senderKey:     senderKey,
-		serverMessage: swapResp.ServerMessage,
+		swapInvoice:    swapResp.SwapInvoice,
+		prepayInvoice:  swapResp.PrepayInvoice,
+		senderKey:      senderKey,
+		serverMessage:  swapResp.ServerMessage,
+		htlcConfTarget: swapResp.HtlcConfTarget,
+		batchedPrepay:  swapResp.BatchedPrepay,
 	}, nil
 }
 
@@ -522,6 +549,83 @@ func (s *grpcSwapServerClient) CancelLoopOutSwap(ctx context.Context,
 	return err
 }
 
+// AbandonLoopOutSwap sends an instruction to the server to cancel a loop out
+// swap that we have abandoned, and reports whether the server released our
+// prepay.
+func (s *grpcSwapServerClient) AbandonLoopOutSwap(ctx context.Context,
+	hash lntypes.Hash, paymentAddr [32]byte) (bool, error) {
+
+	// Servers that do not support prepay release would treat our request
+	// as a regular cancelation, so we only send it if they support it.
+	features, err := s.Features(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !features.SupportsPrepayRelease() {
+		return false, nil
+	}
+
+	req := &looprpc.CancelLoopOutSwapRequest{
+		ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+		SwapHash:        hash[:],
+		PaymentAddress:  paymentAddr[:],
+		CancelInfo: &looprpc.CancelLoopOutSwapRequest_ClientAbandon{
+			ClientAbandon: &looprpc.ClientAbandon{},
+		},
+	}
+
+	resp, err := s.server.CancelLoopOutSwap(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.PrepayReleased, nil
+}
+
+// Features returns the features that we negotiated with the server,
+// negotiating them on first use. Servers that do not support feature
+// negotiation are assumed to support the features implied by our current
+// protocol version.
+func (s *grpcSwapServerClient) Features(ctx context.Context) (*ServerFeatures,
+	error) {
+
+	s.featuresMtx.Lock()
+	defer s.featuresMtx.Unlock()
+
+	if s.features != nil {
+		return s.features, nil
+	}
+
+	rpcCtx, rpcCancel := context.WithTimeout(ctx, globalCallTimeout)
+	defer rpcCancel()
+
+	resp, err := s.server.GetFeatures(rpcCtx,
+		&looprpc.ServerFeaturesRequest{
+			ProtocolVersion: loopdb.CurrentRPCProtocolVersion,
+			Features:        rpcServerFeatures(clientFeatures),
+		},
+	)
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		s.features = legacyFeatures()
+
+	case err != nil:
+		return nil, err
+
+	default:
+		s.features = negotiateFeatures(
+			loopdb.ProtocolVersion(resp.ProtocolVersion),
+			unmarshallServerFeatures(resp.Features),
+		)
+	}
+
+	serverLog.Infof("Negotiated protocol version %v with features: %v",
+		s.features.ProtocolVersion, s.features.Features())
+
+	return s.features, nil
+}
+
 func rpcRouteCancel(details *outCancelDetails) (
 	*looprpc.CancelLoopOutSwapRequest_RouteCancel, error) {
 
@@ -600,7 +704,7 @@ func getSwapServerConn(address, proxyAddress string, insecure bool,
 	// If a SOCKS proxy address was specified, then we should dial through
 	// it.
 	if proxyAddress != "" {
-		log.Infof("Proxying connection to %v over Tor SOCKS proxy %v",
+		serverLog.Infof("Proxying connection to %v over Tor SOCKS proxy %v",
 			address, proxyAddress)
 		torDialer := func(_ context.Context, addr string) (net.Conn, error) {
 			return tor.Dial(
@@ -633,10 +737,15 @@ func isErrConClosing(err error) bool {
 }
 
 type newLoopOutResponse struct {
-	swapInvoice   string
-	prepayInvoice string
-	senderKey     [33]byte
-	serverMessage string
+	swapInvoice    string
+	prepayInvoice  string
+	senderKey      [33]byte
+	serverMessage  string
+	htlcConfTarget int32
+
+	// batchedPrepay is set if the server included the prepay amount in
+	// the swap invoice, in which case prepayInvoice is empty.
+	batchedPrepay bool
 }
 
 type newLoopInResponse struct {
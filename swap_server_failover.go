@@ -0,0 +1,361 @@
+package loop
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// DefaultServerFailoverTimeout is the default amount of time that a
+	// swap server must be unreachable for before we fail over to the next
+	// server.
+	DefaultServerFailoverTimeout = time.Minute * 5
+
+	// serverFailoverRetryInterval is the minimum amount of time between
+	// our calls to a server that we have failed over from, so that we
+	// notice once it is reachable again.
+	serverFailoverRetryInterval = time.Minute
+)
+
+// failoverLsatDir returns the directory that we store the lsat tokens for a
+// failover swap server in. Tokens are scoped per server because a token that
+// was issued by one server is not valid for another.
+func failoverLsatDir(dbDir, address string) string {
+	dirName := strings.NewReplacer(":", "_", "/", "_").Replace(address)
+
+	return filepath.Join(dbDir, "lsat", dirName)
+}
+
+// failoverServer is a swap server that we may fail over to.
+type failoverServer struct {
+	// address is the host:port of the server.
+	address string
+
+	client swapServerClient
+
+	// failingSince is the time of the first of our current run of calls
+	// that could not reach the server. It is zero if our last call
+	// reached the server.
+	failingSince time.Time
+
+	// lastAttempt is the time of our last call to the server.
+	lastAttempt time.Time
+}
+
+// failoverSwapServerClient dispatches calls to the first of an ordered list
+// of swap servers that is reachable. If the active server has been
+// unreachable for longer than our failover timeout, calls are transparently
+// retried against the next server. Servers that we have failed over from are
+// called again periodically, so that we switch back to them once they are
+// reachable.
+type failoverSwapServerClient struct {
+	// timeout is the amount of time that a server must be unreachable for
+	// before we fail over from it.
+	timeout time.Duration
+
+	clock clock.Clock
+
+	mu      sync.Mutex
+	servers []*failoverServer
+	active  int
+}
+
+// newFailoverSwapServerClient creates a client that fails over between the
+// servers provided, in order of preference.
+func newFailoverSwapServerClient(servers []*failoverServer,
+	timeout time.Duration, clock clock.Clock) *failoverSwapServerClient {
+
+	return &failoverSwapServerClient{
+		timeout: timeout,
+		clock:   clock,
+		servers: servers,
+	}
+}
+
+var _ swapServerClient = (*failoverSwapServerClient)(nil)
+
+// down returns a boolean indicating whether a server has been unreachable
+// for long enough that we should fail over from it.
+//
+// NOTE: The mutex must be held when calling this function.
+func (s *failoverSwapServerClient) down(server *failoverServer,
+	now time.Time) bool {
+
+	return !server.failingSince.IsZero() &&
+		now.Sub(server.failingSince) >= s.timeout
+}
+
+// selectServer returns the index of the most preferred server that is not
+// down. Servers that are down are selected if we have not called them for
+// our retry interval, so that we notice when they recover. If all servers are
+// down, we stick with the active server.
+func (s *failoverSwapServerClient) selectServer() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	selected := s.active
+	for i, server := range s.servers {
+		if !s.down(server, now) ||
+			now.Sub(server.lastAttempt) >= serverFailoverRetryInterval {
+
+			selected = i
+			break
+		}
+	}
+
+	if selected != s.active {
+		serverLog.Infof("Switching from swap server %v to %v",
+			s.servers[s.active].address,
+			s.servers[selected].address)
+
+		s.active = selected
+	}
+
+	return selected
+}
+
+// recordCall records the outcome of a call to the server at the index
+// provided, and returns a boolean indicating whether the server is down, so
+// that the call should be retried against the next server.
+func (s *failoverSwapServerClient) recordCall(idx int, err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	server := s.servers[idx]
+	server.lastAttempt = now
+
+	if !serverUnreachable(err) {
+		server.failingSince = time.Time{}
+		return false
+	}
+
+	if server.failingSince.IsZero() {
+		server.failingSince = now
+	}
+
+	down := s.down(server, now)
+	if down {
+		serverLog.Warnf("Swap server %v unreachable since %v: %v",
+			server.address, server.failingSince, err)
+	}
+
+	return down
+}
+
+// call makes a call to the active server, retrying it against the next
+// server if the active server is down.
+func (s *failoverSwapServerClient) call(
+	makeCall func(server swapServerClient) error) error {
+
+	var err error
+	for range s.servers {
+		idx := s.selectServer()
+
+		err = makeCall(s.servers[idx].client)
+		if !s.recordCall(idx, err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// GetLoopOutTerms queries the active server for its loop out terms.
+func (s *failoverSwapServerClient) GetLoopOutTerms(ctx context.Context) (
+	*LoopOutTerms, error) {
+
+	var terms *LoopOutTerms
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		terms, err = server.GetLoopOutTerms(ctx)
+		return err
+	})
+
+	return terms, err
+}
+
+// GetLoopOutQuote queries the active server for a loop out quote.
+func (s *failoverSwapServerClient) GetLoopOutQuote(ctx context.Context,
+	amt btcutil.Amount, expiry int32, swapPublicationDeadline time.Time,
+	htlcConfTarget int32) (*LoopOutQuote, error) {
+
+	var quote *LoopOutQuote
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		quote, err = server.GetLoopOutQuote(
+			ctx, amt, expiry, swapPublicationDeadline,
+			htlcConfTarget,
+		)
+		return err
+	})
+
+	return quote, err
+}
+
+// GetLoopInTerms queries the active server for its loop in terms.
+func (s *failoverSwapServerClient) GetLoopInTerms(ctx context.Context) (
+	*LoopInTerms, error) {
+
+	var terms *LoopInTerms
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		terms, err = server.GetLoopInTerms(ctx)
+		return err
+	})
+
+	return terms, err
+}
+
+// GetLoopInQuote queries the active server for a loop in quote.
+func (s *failoverSwapServerClient) GetLoopInQuote(ctx context.Context,
+	amt btcutil.Amount) (*LoopInQuote, error) {
+
+	var quote *LoopInQuote
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		quote, err = server.GetLoopInQuote(ctx, amt)
+		return err
+	})
+
+	return quote, err
+}
+
+// NewLoopOutSwap registers a new loop out swap with the active server.
+func (s *failoverSwapServerClient) NewLoopOutSwap(ctx context.Context,
+	swapHash lntypes.Hash, amount btcutil.Amount, expiry int32,
+	receiverKey [33]byte, swapPublicationDeadline time.Time,
+	htlcConfTarget int32, initiator string) (*newLoopOutResponse, error) {
+
+	var resp *newLoopOutResponse
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		resp, err = server.NewLoopOutSwap(
+			ctx, swapHash, amount, expiry, receiverKey,
+			swapPublicationDeadline, htlcConfTarget, initiator,
+		)
+		return err
+	})
+
+	return resp, err
+}
+
+// PushLoopOutPreimage pushes the preimage of a loop out swap to the active
+// server.
+func (s *failoverSwapServerClient) PushLoopOutPreimage(ctx context.Context,
+	preimage lntypes.Preimage) error {
+
+	return s.call(func(server swapServerClient) error {
+		return server.PushLoopOutPreimage(ctx, preimage)
+	})
+}
+
+// NewLoopInSwap registers a new loop in swap with the active server.
+func (s *failoverSwapServerClient) NewLoopInSwap(ctx context.Context,
+	swapHash lntypes.Hash, amount btcutil.Amount, senderKey [33]byte,
+	swapInvoice, probeInvoice string, lastHop *route.Vertex,
+	initiator string) (*newLoopInResponse, error) {
+
+	var resp *newLoopInResponse
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		resp, err = server.NewLoopInSwap(
+			ctx, swapHash, amount, senderKey, swapInvoice,
+			probeInvoice, lastHop, initiator,
+		)
+		return err
+	})
+
+	return resp, err
+}
+
+// SubscribeLoopOutUpdates subscribes to loop out server state on the active
+// server.
+func (s *failoverSwapServerClient) SubscribeLoopOutUpdates(
+	ctx context.Context, hash lntypes.Hash) (<-chan *ServerUpdate,
+	<-chan error, error) {
+
+	var (
+		updates <-chan *ServerUpdate
+		errChan <-chan error
+	)
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		updates, errChan, err = server.SubscribeLoopOutUpdates(
+			ctx, hash,
+		)
+		return err
+	})
+
+	return updates, errChan, err
+}
+
+// SubscribeLoopInUpdates subscribes to loop in server state on the active
+// server.
+func (s *failoverSwapServerClient) SubscribeLoopInUpdates(
+	ctx context.Context, hash lntypes.Hash) (<-chan *ServerUpdate,
+	<-chan error, error) {
+
+	var (
+		updates <-chan *ServerUpdate
+		errChan <-chan error
+	)
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		updates, errChan, err = server.SubscribeLoopInUpdates(
+			ctx, hash,
+		)
+		return err
+	})
+
+	return updates, errChan, err
+}
+
+// CancelLoopOutSwap cancels a loop out swap on the active server.
+func (s *failoverSwapServerClient) CancelLoopOutSwap(ctx context.Context,
+	details *outCancelDetails) error {
+
+	return s.call(func(server swapServerClient) error {
+		return server.CancelLoopOutSwap(ctx, details)
+	})
+}
+
+// AbandonLoopOutSwap cancels an abandoned loop out swap on the active server.
+func (s *failoverSwapServerClient) AbandonLoopOutSwap(ctx context.Context,
+	hash lntypes.Hash, paymentAddr [32]byte) (bool, error) {
+
+	var released bool
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		released, err = server.AbandonLoopOutSwap(
+			ctx, hash, paymentAddr,
+		)
+		return err
+	})
+
+	return released, err
+}
+
+// Features returns the features that we negotiated with the active server.
+func (s *failoverSwapServerClient) Features(ctx context.Context) (
+	*ServerFeatures, error) {
+
+	var features *ServerFeatures
+	err := s.call(func(server swapServerClient) error {
+		var err error
+		features, err = server.Features(ctx)
+		return err
+	})
+
+	return features, err
+}
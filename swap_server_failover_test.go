@@ -0,0 +1,129 @@
+package loop
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestServerFailover tests that we fail over to our next server once our
+// active server has been unreachable for our failover timeout, and that we
+// switch back to our primary server once it is reachable again.
+func TestServerFailover(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		startTime = time.Unix(100000, 0)
+		timeout   = time.Minute * 5
+		testClock = clock.NewTestClock(startTime)
+
+		unavailable = status.Error(codes.Unavailable, "unavailable")
+
+		primary = &termsServerMock{err: unavailable}
+		backup  = &termsServerMock{}
+	)
+
+	client := newFailoverSwapServerClient(
+		[]*failoverServer{
+			{address: "primary", client: primary},
+			{address: "backup", client: backup},
+		}, timeout, testClock,
+	)
+
+	// Our primary server has not been unreachable for long enough to fail
+	// over, so we expect its error to be returned.
+	_, err := client.GetLoopOutTerms(ctx)
+	require.Equal(t, unavailable, err)
+	require.Equal(t, 1, primary.outCalls)
+	require.Equal(t, 0, backup.outCalls)
+
+	// Non-availability errors do not count towards failover, and reset
+	// our failure timer.
+	primary.err = status.Error(codes.InvalidArgument, "invalid")
+	_, err = client.GetLoopOutTerms(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, backup.outCalls)
+
+	primary.err = unavailable
+	_, err = client.GetLoopOutTerms(ctx)
+	require.Equal(t, unavailable, err)
+
+	// Once our primary has been unreachable for our timeout, our call is
+	// transparently retried against our backup.
+	testClock.SetTime(startTime.Add(timeout))
+	_, err = client.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, primary.outCalls)
+	require.Equal(t, 1, backup.outCalls)
+
+	// Subsequent calls go straight to our backup until our retry interval
+	// has passed.
+	_, err = client.GetLoopInTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, primary.inCalls)
+	require.Equal(t, 1, backup.inCalls)
+
+	// Once our retry interval has passed, we try our primary again. If it
+	// is reachable, we switch back to it.
+	primary.err = nil
+	testClock.SetTime(
+		startTime.Add(timeout + serverFailoverRetryInterval),
+	)
+
+	_, err = client.GetLoopInTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.inCalls)
+	require.Equal(t, 1, backup.inCalls)
+
+	_, err = client.GetLoopOutTerms(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, primary.outCalls)
+	require.Equal(t, 1, backup.outCalls)
+}
+
+// TestServerFailoverAllDown tests that we return the error of the last server
+// that we tried if none of our servers are reachable.
+func TestServerFailoverAllDown(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		startTime = time.Unix(100000, 0)
+		timeout   = time.Minute
+		testClock = clock.NewTestClock(startTime)
+
+		primaryErr = status.Error(codes.Unavailable, "primary")
+		backupErr  = status.Error(codes.Unavailable, "backup")
+
+		primary = &termsServerMock{err: primaryErr}
+		backup  = &termsServerMock{err: backupErr}
+	)
+
+	client := newFailoverSwapServerClient(
+		[]*failoverServer{
+			{address: "primary", client: primary},
+			{address: "backup", client: backup},
+		}, timeout, testClock,
+	)
+
+	_, err := client.GetLoopOutTerms(ctx)
+	require.Equal(t, primaryErr, err)
+
+	// Once our primary is down, our call is retried against our backup.
+	// Since our backup is unreachable as well, we return its error.
+	testClock.SetTime(startTime.Add(timeout))
+	_, err = client.GetLoopOutTerms(ctx)
+	require.Equal(t, backupErr, err)
+	require.Equal(t, 2, primary.outCalls)
+	require.Equal(t, 1, backup.outCalls)
+}
+
+// TestFailoverLsatDir tests that lsat directories are scoped per server.
+func TestFailoverLsatDir(t *testing.T) {
+	require.Equal(
+		t, "/loop/lsat/swap.example.com_11010",
+		failoverLsatDir("/loop", "swap.example.com:11010"),
+	)
+}
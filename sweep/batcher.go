@@ -0,0 +1,328 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/labels"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// BatchInput contains all the information required to sweep a swap's htlc as
+// part of a batch sweep transaction.
+type BatchInput struct {
+	// SwapHash is the hash of the swap that the htlc belongs to.
+	SwapHash lntypes.Hash
+
+	// Htlc is the swap's on chain htlc.
+	Htlc *swap.Htlc
+
+	// Outpoint is the outpoint of the htlc.
+	Outpoint wire.OutPoint
+
+	// Value is the value of the htlc output.
+	Value btcutil.Amount
+
+	// KeyBytes is the serialized key that we use to sign the htlc spend.
+	KeyBytes [33]byte
+
+	// KeyLocator is the locator of the key that we use to sign the htlc
+	// spend. It may be empty, in which case lnd looks the key up by its
+	// public key.
+	KeyLocator keychain.KeyLocator
+
+	// WitnessFunc produces the witness for the htlc input given our
+	// signature.
+	WitnessFunc func(sig []byte) (wire.TxWitness, error)
+
+	// DestAddr is the address that the swept funds are sent to.
+	DestAddr btcutil.Address
+
+	// ConfTarget is the confirmation target that the swap would like its
+	// sweep to confirm within. The batch uses the lowest target of all its
+	// inputs.
+	ConfTarget int32
+
+	// MaxFee is the maximum on chain fee that the swap is willing to
+	// contribute to the batch.
+	MaxFee btcutil.Amount
+}
+
+// BatchStore is the subset of the swap store that is required to persist
+// sweep batches.
+type BatchStore interface {
+	// PutSweepBatch persists a sweep batch, assigning it a new id if it
+	// does not have one yet.
+	PutSweepBatch(batch *loopdb.SweepBatch) error
+
+	// FetchSweepBatches returns all the sweep batches in the store.
+	FetchSweepBatches() ([]*loopdb.SweepBatch, error)
+}
+
+// Batcher aggregates the htlcs of multiple loop out swaps into a single sweep
+// transaction to save on chain fees. Swaps add their htlc to the current batch
+// once it has confirmed, and remove it once they have observed its spend. Each
+// time the set of inputs changes or a new block arrives, the batch is
+// republished so that it includes all currently unswept htlcs.
+type Batcher struct {
+	sweeper *Sweeper
+	store   BatchStore
+
+	// bumpBlocks is the number of blocks that we wait for a batch to
+	// confirm before we bump its fee rate.
+	bumpBlocks int32
+
+	// batch is the batch that we are currently adding inputs to.
+	batch *loopdb.SweepBatch
+
+	// inputs contains the spending information for each of the inputs
+	// in our current batch. Inputs that were restored from disk will only
+	// be present in this map once their swap has added them again.
+	inputs map[lntypes.Hash]*BatchInput
+
+	// publishHeight is the height at which we last published the current
+	// batch.
+	publishHeight int32
+
+	// changed indicates whether the set of inputs has changed since we
+	// last published the batch.
+	changed bool
+
+	mu sync.Mutex
+}
+
+// NewBatcher creates a batcher, restoring the last unconfirmed batch from the
+// store so that swaps that are resumed after a restart continue to be swept in
+// the same batch. If a batch does not confirm within bumpBlocks blocks, its fee
+// rate is increased. A zero value disables fee bumping.
+func NewBatcher(sweeper *Sweeper, store BatchStore, bumpBlocks int32) (
+	*Batcher, error) {
+
+	batches, err := store.FetchSweepBatches()
+	if err != nil {
+		return nil, err
+	}
+
+	batch := loopdb.NewSweepBatch()
+	for _, b := range batches {
+		if !b.Confirmed {
+			batch = b
+		}
+	}
+
+	if batch.ID != 0 {
+		log.Infof("Restored sweep batch %v with %v inputs", batch.ID,
+			len(batch.Inputs))
+	}
+
+	return &Batcher{
+		sweeper:    sweeper,
+		store:      store,
+		bumpBlocks: bumpBlocks,
+		batch:      batch,
+		inputs:     make(map[lntypes.Hash]*BatchInput),
+	}, nil
+}
+
+// AddInput adds a swap's htlc to the current batch. It is safe to call this
+// function repeatedly for the same swap, the input will only be persisted
+// the first time it is added.
+func (b *Batcher) AddInput(in *BatchInput) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// We always update our in-memory input, because it contains the
+	// swap's latest spending parameters.
+	_, known := b.inputs[in.SwapHash]
+	b.inputs[in.SwapHash] = in
+
+	if known {
+		return nil
+	}
+
+	b.changed = true
+
+	// If this input was restored from disk with the same outpoint, we
+	// do not need to persist it again.
+	outpoint, ok := b.batch.Inputs[in.SwapHash]
+	if ok && outpoint == in.Outpoint {
+		return nil
+	}
+
+	b.batch.Inputs[in.SwapHash] = in.Outpoint
+
+	log.Debugf("Adding htlc %v of swap %v to sweep batch", in.Outpoint,
+		in.SwapHash)
+
+	return b.store.PutSweepBatch(b.batch)
+}
+
+// RemoveInput removes a swap's htlc from the batcher once its spend has been
+// observed. If the htlc was spent by any of the transactions we published for
+// the current batch, the batch is marked as confirmed and any inputs that were
+// not included in that transaction are moved to a new batch.
+func (b *Batcher) RemoveInput(swapHash lntypes.Hash,
+	spendTx chainhash.Hash) error {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.inputs, swapHash)
+
+	// If the swap is not part of our current batch, it was part of a
+	// batch that has already confirmed, so there is nothing left to do.
+	if _, ok := b.batch.Inputs[swapHash]; !ok {
+		return nil
+	}
+
+	// If our htlc was spent by another transaction, for example by the
+	// server's timeout path, we just remove it from the batch. We check
+	// all of the transactions that we published for the batch, because
+	// an earlier one may have confirmed before its replacement
+	// propagated.
+	swept, ok := b.batch.PublishedTxs[spendTx]
+	if !ok {
+		delete(b.batch.Inputs, swapHash)
+		b.changed = true
+
+		return b.store.PutSweepBatch(b.batch)
+	}
+
+	// Otherwise, our batch has confirmed. We mark it as such and start
+	// a new batch with any inputs that were not included in the
+	// transaction that confirmed. We rely on the persisted set of swaps
+	// for each published transaction here, so that inputs that were left
+	// out of the transaction are not lost across restarts.
+	log.Infof("Sweep batch %v confirmed in tx %v", b.batch.ID, spendTx)
+
+	b.batch.Confirmed = true
+	if err := b.store.PutSweepBatch(b.batch); err != nil {
+		return err
+	}
+
+	next := loopdb.NewSweepBatch()
+	for hash, outpoint := range b.batch.Inputs {
+		if _, ok := swept[hash]; ok {
+			continue
+		}
+
+		next.Inputs[hash] = outpoint
+	}
+
+	b.batch = next
+	b.changed = len(next.Inputs) > 0
+
+	if !b.changed {
+		return nil
+	}
+
+	return b.store.PutSweepBatch(b.batch)
+}
+
+// Publish creates and publishes a sweep transaction for the current batch if
+// its inputs have changed or we have reached a new height since we last
+// published it.
+func (b *Batcher) Publish(ctx context.Context, height int32) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.changed && height == b.publishHeight {
+		return nil
+	}
+
+	// Collect all the inputs in our current batch that we have spending
+	// information for. Inputs that were restored from disk but have not
+	// yet been added again by their swap are skipped.
+	var (
+		inputs     []*BatchInput
+		confTarget int32
+	)
+	for hash := range b.batch.Inputs {
+		in, ok := b.inputs[hash]
+		if !ok {
+			continue
+		}
+
+		if confTarget == 0 || in.ConfTarget < confTarget {
+			confTarget = in.ConfTarget
+		}
+
+		inputs = append(inputs, in)
+	}
+
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	// Sort our inputs so that we produce the same transaction for the
+	// same set of inputs.
+	sort.Slice(inputs, func(i, j int) bool {
+		return inputs[i].Outpoint.String() < inputs[j].Outpoint.String()
+	})
+
+	feeRate, err := b.sweeper.Lnd.WalletKit.EstimateFee(ctx, confTarget)
+	if err != nil {
+		return fmt.Errorf("estimate fee: %v", err)
+	}
+
+	// If we have already published this batch, we need to make sure
+	// that we do not decrease our fee rate, and bump it if the batch has
+	// not confirmed in time.
+	if b.batch.Fee != nil {
+		feeRate = BumpFeeRate(
+			feeRate, b.batch.Fee.FeeRate,
+			height-b.batch.Fee.PublishHeight, b.bumpBlocks,
+		)
+	}
+
+	// Inputs that cannot afford their share of the fee are left out of
+	// the transaction, and will be retried the next time we publish.
+	sweepTx, included, err := b.sweeper.CreateBatchSweepTx(
+		ctx, height, inputs, feeRate,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Publishing sweep batch %v with %v of %v inputs at fee "+
+		"rate %v", b.batch.ID, len(included), len(inputs), feeRate)
+
+	err = b.sweeper.Lnd.WalletKit.PublishTransaction(
+		ctx, sweepTx,
+		b.sweeper.TxLabel(labels.LoopOutBatchSweep(b.batch.ID)),
+	)
+	if err != nil {
+		return err
+	}
+
+	txHash := sweepTx.TxHash()
+	b.batch.TxHash = &txHash
+
+	// Record the height at which we started publishing with this fee rate
+	// so that our bumping schedule can be resumed after restart.
+	if b.batch.Fee == nil || b.batch.Fee.FeeRate != feeRate {
+		b.batch.Fee = &loopdb.SweepFee{
+			FeeRate:       feeRate,
+			PublishHeight: height,
+		}
+	}
+
+	b.batch.Published = make(map[lntypes.Hash]struct{}, len(included))
+	for _, in := range included {
+		b.batch.Published[in.SwapHash] = struct{}{}
+	}
+	b.batch.PublishedTxs[txHash] = b.batch.Published
+
+	b.publishHeight = height
+	b.changed = false
+
+	return b.store.PutSweepBatch(b.batch)
+}
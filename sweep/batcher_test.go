@@ -0,0 +1,357 @@
+package sweep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBatchStore is an in-memory implementation of the BatchStore interface.
+type mockBatchStore struct {
+	batches []*loopdb.SweepBatch
+}
+
+// copyBatch returns a deep copy of a sweep batch so that the batches held by
+// our mock store are not altered by the batcher.
+func copyBatch(batch *loopdb.SweepBatch) *loopdb.SweepBatch {
+	cp := *batch
+
+	cp.Inputs = make(map[lntypes.Hash]wire.OutPoint, len(batch.Inputs))
+	for hash, outpoint := range batch.Inputs {
+		cp.Inputs[hash] = outpoint
+	}
+
+	cp.Published = make(map[lntypes.Hash]struct{}, len(batch.Published))
+	for hash := range batch.Published {
+		cp.Published[hash] = struct{}{}
+	}
+
+	cp.PublishedTxs = make(
+		map[chainhash.Hash]map[lntypes.Hash]struct{},
+		len(batch.PublishedTxs),
+	)
+	for txHash, swaps := range batch.PublishedTxs {
+		cp.PublishedTxs[txHash] = make(
+			map[lntypes.Hash]struct{}, len(swaps),
+		)
+		for hash := range swaps {
+			cp.PublishedTxs[txHash][hash] = struct{}{}
+		}
+	}
+
+	return &cp
+}
+
+// PutSweepBatch persists a sweep batch, assigning it a new id if it does not
+// have one yet.
+func (s *mockBatchStore) PutSweepBatch(batch *loopdb.SweepBatch) error {
+	if batch.ID == 0 {
+		batch.ID = uint64(len(s.batches) + 1)
+		s.batches = append(s.batches, nil)
+	}
+
+	s.batches[batch.ID-1] = copyBatch(batch)
+
+	return nil
+}
+
+// FetchSweepBatches returns all the sweep batches in the store.
+func (s *mockBatchStore) FetchSweepBatches() ([]*loopdb.SweepBatch, error) {
+	batches := make([]*loopdb.SweepBatch, 0, len(s.batches))
+	for _, batch := range s.batches {
+		batches = append(batches, copyBatch(batch))
+	}
+
+	return batches, nil
+}
+
+// batcherTestContext contains the components required to test the batcher.
+type batcherTestContext struct {
+	t       *testing.T
+	lnd     *test.LndMockServices
+	store   *mockBatchStore
+	sweeper *Sweeper
+	batcher *Batcher
+}
+
+// newBatcherTestContext creates a batcher that is backed by an empty store and
+// a mock lnd.
+func newBatcherTestContext(t *testing.T,
+	bumpBlocks int32) *batcherTestContext {
+
+	lnd := test.NewMockLnd()
+	store := &mockBatchStore{}
+	sweeper := &Sweeper{Lnd: &lnd.LndServices}
+
+	batcher, err := NewBatcher(sweeper, store, bumpBlocks)
+	require.NoError(t, err)
+
+	return &batcherTestContext{
+		t:       t,
+		lnd:     lnd,
+		store:   store,
+		sweeper: sweeper,
+		batcher: batcher,
+	}
+}
+
+// restart recreates the batcher from our store, as happens when loopd is
+// restarted.
+func (c *batcherTestContext) restart() {
+	batcher, err := NewBatcher(c.sweeper, c.store, c.batcher.bumpBlocks)
+	require.NoError(c.t, err)
+
+	c.batcher = batcher
+}
+
+// publish publishes the current batch at the height provided and returns the
+// transaction that was published.
+func (c *batcherTestContext) publish(height int32) *wire.MsgTx {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.batcher.Publish(context.Background(), height)
+	}()
+
+	select {
+	case <-c.lnd.SignOutputRawChannel:
+	case <-time.After(test.Timeout):
+		c.t.Fatalf("batch not signed")
+	}
+
+	var tx *wire.MsgTx
+	select {
+	case tx = <-c.lnd.TxPublishChannel:
+	case <-time.After(test.Timeout):
+		c.t.Fatalf("batch not published")
+	}
+
+	require.NoError(c.t, <-errChan)
+
+	return tx
+}
+
+// assertSpends asserts that a transaction spends exactly the inputs provided,
+// in order.
+func assertSpends(t *testing.T, tx *wire.MsgTx, inputs ...*BatchInput) {
+	require.Len(t, tx.TxIn, len(inputs))
+	for i, in := range inputs {
+		require.Equal(t, in.Outpoint, tx.TxIn[i].PreviousOutPoint)
+	}
+}
+
+// assertStoredBatch asserts that the last batch in our store contains the
+// inputs and published swaps provided.
+func (c *batcherTestContext) assertStoredBatch(inputs,
+	published []*BatchInput) {
+
+	require.NotEmpty(c.t, c.store.batches)
+	batch := c.store.batches[len(c.store.batches)-1]
+
+	expectedInputs := make(map[lntypes.Hash]wire.OutPoint, len(inputs))
+	for _, in := range inputs {
+		expectedInputs[in.SwapHash] = in.Outpoint
+	}
+	require.Equal(c.t, expectedInputs, batch.Inputs)
+
+	expectedPublished := make(map[lntypes.Hash]struct{}, len(published))
+	for _, in := range published {
+		expectedPublished[in.SwapHash] = struct{}{}
+	}
+	require.Equal(c.t, expectedPublished, batch.Published)
+}
+
+// TestBatcherComposition tests that the batcher publishes all of its inputs
+// in a single transaction, leaving out inputs that cannot afford their share
+// of the fee.
+func TestBatcherComposition(t *testing.T) {
+	c := newBatcherTestContext(t, 0)
+	c.lnd.SetFeeEstimate(6, chainfee.SatPerKWeight(10000))
+
+	// Inputs are sorted by outpoint, so our transactions spend them in
+	// the order that they are numbered.
+	first := newTestBatchInput(t, 1, 100000, 20000)
+	second := newTestBatchInput(t, 2, 100000, 20000)
+	dust := newTestBatchInput(t, 3, 4000, 20000)
+
+	require.NoError(t, c.batcher.AddInput(first))
+	require.NoError(t, c.batcher.AddInput(second))
+	require.NoError(t, c.batcher.AddInput(dust))
+
+	// Adding an input again should not change our batch.
+	require.NoError(t, c.batcher.AddInput(first))
+
+	// Our dust input cannot afford its share of the fee, so it is left
+	// out of the transaction, but kept in the batch.
+	tx := c.publish(100)
+	assertSpends(t, tx, first, second)
+	c.assertStoredBatch(
+		[]*BatchInput{first, second, dust},
+		[]*BatchInput{first, second},
+	)
+
+	txHash := tx.TxHash()
+	require.Equal(t, &txHash, c.store.batches[0].TxHash)
+
+	// Publishing again at the same height without any changes to our
+	// inputs should not produce a new transaction.
+	require.NoError(t, c.batcher.Publish(context.Background(), 100))
+}
+
+// TestBatcherRepublish tests that the batcher republishes its batch when its
+// inputs change or a new block arrives, and that it bumps the fee rate of a
+// batch that does not confirm in time.
+func TestBatcherRepublish(t *testing.T) {
+	c := newBatcherTestContext(t, 2)
+	c.lnd.SetFeeEstimate(6, chainfee.SatPerKWeight(1000))
+
+	first := newTestBatchInput(t, 1, 100000, 20000)
+	second := newTestBatchInput(t, 2, 100000, 20000)
+
+	require.NoError(t, c.batcher.AddInput(first))
+	tx := c.publish(100)
+	assertSpends(t, tx, first)
+
+	require.Equal(t, &loopdb.SweepFee{
+		FeeRate:       1000,
+		PublishHeight: 100,
+	}, c.store.batches[0].Fee)
+
+	// Adding an input at the same height republishes the batch.
+	require.NoError(t, c.batcher.AddInput(second))
+	tx = c.publish(100)
+	assertSpends(t, tx, first, second)
+
+	// A new block republishes the batch at the same fee rate.
+	c.publish(101)
+	require.Equal(t, &loopdb.SweepFee{
+		FeeRate:       1000,
+		PublishHeight: 100,
+	}, c.store.batches[0].Fee)
+
+	// Once the batch has not confirmed for our bump interval, its fee
+	// rate is increased.
+	tx = c.publish(102)
+	assertSpends(t, tx, first, second)
+	require.Equal(t, &loopdb.SweepFee{
+		FeeRate:       1250,
+		PublishHeight: 102,
+	}, c.store.batches[0].Fee)
+
+	// After a restart, our bumping schedule is resumed and we do not
+	// publish below our last fee rate.
+	c.restart()
+	require.NoError(t, c.batcher.AddInput(first))
+	require.NoError(t, c.batcher.AddInput(second))
+
+	tx = c.publish(103)
+	assertSpends(t, tx, first, second)
+	require.Equal(t, &loopdb.SweepFee{
+		FeeRate:       1250,
+		PublishHeight: 102,
+	}, c.store.batches[0].Fee)
+}
+
+// TestBatcherRemoveInput tests removal of inputs from a batch, both when they
+// are spent by another transaction and when the batch confirms. Inputs that
+// were not included in the confirmed transaction should be moved to the next
+// batch, including after a restart.
+func TestBatcherRemoveInput(t *testing.T) {
+	c := newBatcherTestContext(t, 0)
+	c.lnd.SetFeeEstimate(6, chainfee.SatPerKWeight(10000))
+
+	first := newTestBatchInput(t, 1, 100000, 20000)
+	second := newTestBatchInput(t, 2, 100000, 20000)
+	timedOut := newTestBatchInput(t, 3, 100000, 20000)
+	dust := newTestBatchInput(t, 4, 4000, 20000)
+
+	for _, in := range []*BatchInput{first, second, timedOut, dust} {
+		require.NoError(t, c.batcher.AddInput(in))
+	}
+
+	tx := c.publish(100)
+	assertSpends(t, tx, first, second, timedOut)
+
+	// If an htlc is spent by a transaction other than our batch, it is
+	// removed from the batch and the batch is republished without it.
+	require.NoError(t, c.batcher.RemoveInput(
+		timedOut.SwapHash, chainhash.Hash{9},
+	))
+	c.assertStoredBatch(
+		[]*BatchInput{first, second, dust},
+		[]*BatchInput{first, second, timedOut},
+	)
+
+	tx = c.publish(100)
+	assertSpends(t, tx, first, second)
+
+	// Restart before our batch confirms. Our swaps are only resumed once
+	// the batch's spend has been detected, so our restored batcher does
+	// not have any inputs yet.
+	c.restart()
+
+	// When our batch confirms, the batch is marked as confirmed and our
+	// dust input, which was not included in the transaction, is moved to
+	// a new batch.
+	txHash := tx.TxHash()
+	require.NoError(t, c.batcher.RemoveInput(first.SwapHash, txHash))
+
+	require.Len(t, c.store.batches, 2)
+	require.True(t, c.store.batches[0].Confirmed)
+	c.assertStoredBatch([]*BatchInput{dust}, nil)
+
+	// Removing the other input of our confirmed batch does not affect
+	// our new batch.
+	require.NoError(t, c.batcher.RemoveInput(second.SwapHash, txHash))
+	c.assertStoredBatch([]*BatchInput{dust}, nil)
+
+	// Once our dust swap adds its input again and fees drop, it is swept
+	// in the new batch.
+	c.lnd.SetFeeEstimate(6, chainfee.SatPerKWeight(1000))
+	require.NoError(t, c.batcher.AddInput(dust))
+
+	tx = c.publish(101)
+	assertSpends(t, tx, dust)
+	c.assertStoredBatch([]*BatchInput{dust}, []*BatchInput{dust})
+}
+
+// TestBatcherEarlierTxConfirms tests that we detect the confirmation of our
+// batch if a transaction that we published before our latest one confirms,
+// and that only the inputs that it did not include are moved to a new batch.
+func TestBatcherEarlierTxConfirms(t *testing.T) {
+	c := newBatcherTestContext(t, 0)
+	c.lnd.SetFeeEstimate(6, chainfee.SatPerKWeight(1000))
+
+	first := newTestBatchInput(t, 1, 100000, 20000)
+	second := newTestBatchInput(t, 2, 100000, 20000)
+
+	require.NoError(t, c.batcher.AddInput(first))
+	earlierTx := c.publish(100)
+	assertSpends(t, earlierTx, first)
+
+	require.NoError(t, c.batcher.AddInput(second))
+	latestTx := c.publish(101)
+	assertSpends(t, latestTx, first, second)
+
+	// Both of our transactions are persisted, so a restart does not
+	// affect our ability to recognize the earlier one.
+	c.restart()
+
+	// When our earlier transaction confirms, our batch is marked as
+	// confirmed and our second input, which it did not include, is moved
+	// to a new batch.
+	require.NoError(t, c.batcher.RemoveInput(
+		first.SwapHash, earlierTx.TxHash(),
+	))
+
+	require.Len(t, c.store.batches, 2)
+	require.True(t, c.store.batches[0].Confirmed)
+	c.assertStoredBatch([]*BatchInput{second}, nil)
+}
@@ -2,6 +2,7 @@ package sweep
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -12,18 +13,38 @@ import (
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
+// FeeRateBumpPercent is the percentage by which we increase the fee rate of
+// a sweep that has not confirmed within the configured number of blocks.
+const FeeRateBumpPercent = 25
+
 // Sweeper creates htlc sweep txes.
 type Sweeper struct {
 	Lnd *lndclient.LndServices
+
+	// NoTxLabels disables the labels that identify the swaps that our
+	// transactions belong to in lnd's wallet.
+	NoTxLabels bool
+}
+
+// TxLabel returns the label provided, or an empty label if transaction labels
+// are disabled.
+func (s *Sweeper) TxLabel(label string) string {
+	if s.NoTxLabels {
+		return ""
+	}
+
+	return label
 }
 
 // CreateSweepTx creates an htlc sweep tx.
 func (s *Sweeper) CreateSweepTx(
 	globalCtx context.Context, height int32, sequence uint32,
 	htlc *swap.Htlc, htlcOutpoint wire.OutPoint,
-	keyBytes [33]byte,
+	keyBytes [33]byte, keyLocator keychain.KeyLocator,
 	witnessFunc func(sig []byte) (wire.TxWitness, error),
 	amount, fee btcutil.Amount,
 	destAddr btcutil.Address) (*wire.MsgTx, error) {
@@ -66,7 +87,8 @@ func (s *Sweeper) CreateSweepTx(
 		HashType:   txscript.SigHashAll,
 		InputIndex: 0,
 		KeyDesc: keychain.KeyDescriptor{
-			PubKey: key,
+			KeyLocator: keyLocator,
+			PubKey:     key,
 		},
 	}
 
@@ -87,6 +109,158 @@ func (s *Sweeper) CreateSweepTx(
 	return sweepTx, nil
 }
 
+// CreateBatchSweepTx creates a single transaction that sweeps the htlcs of
+// the inputs provided. Each input is paired with an output at the same index
+// that pays to the input's destination address, so that the swept value of
+// each swap can be identified in the transaction. The total fee for the
+// transaction is split evenly between the inputs, and is capped at each
+// input's maximum fee. Inputs that cannot afford their share of the fee while
+// leaving an output above the dust limit are left out of the transaction
+// rather than failing the whole batch, so the inputs that were swept are
+// returned along with the transaction.
+func (s *Sweeper) CreateBatchSweepTx(globalCtx context.Context, height int32,
+	inputs []*BatchInput, feeRate chainfee.SatPerKWeight) (*wire.MsgTx,
+	[]*BatchInput, error) {
+
+	if len(inputs) == 0 {
+		return nil, nil, errors.New("no inputs for batch sweep")
+	}
+
+	// Leaving an input out of the transaction changes the fee share of
+	// the inputs that remain, so we repeat our fee calculation until all
+	// of our inputs can afford their share. Each input pays to its own
+	// output, so an input that would leave a dust output would make our
+	// whole transaction non-standard.
+	dustLimit := lnwallet.DefaultDustLimit()
+
+	var fees []btcutil.Amount
+	for {
+		var err error
+		fees, err = batchInputFees(inputs, feeRate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var economical []*BatchInput
+		for i, in := range inputs {
+			if in.Value-fees[i] < dustLimit {
+				log.Infof("Deferring htlc %v of swap %v: fee %v "+
+					"leaves htlc value %v below dust limit "+
+					"%v", in.Outpoint, in.SwapHash, fees[i],
+					in.Value, dustLimit)
+
+				continue
+			}
+
+			economical = append(economical, in)
+		}
+
+		if len(economical) == len(inputs) {
+			break
+		}
+
+		if len(economical) == 0 {
+			return nil, nil, errors.New("no inputs can afford their " +
+				"share of the batch sweep fee")
+		}
+
+		inputs = economical
+	}
+
+	// Compose tx.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = uint32(height)
+
+	signDescs := make([]*lndclient.SignDescriptor, len(inputs))
+	for i, in := range inputs {
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: in.Outpoint,
+			SignatureScript:  in.Htlc.SigScript,
+			Sequence:         in.Htlc.SuccessSequence(),
+		})
+
+		sweepPkScript, err := txscript.PayToAddrScript(in.DestAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sweepTx.AddTxOut(&wire.TxOut{
+			PkScript: sweepPkScript,
+			Value:    int64(in.Value - fees[i]),
+		})
+
+		key, err := btcec.ParsePubKey(in.KeyBytes[:], btcec.S256())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		signDescs[i] = &lndclient.SignDescriptor{
+			WitnessScript: in.Htlc.Script(),
+			Output: &wire.TxOut{
+				Value: int64(in.Value),
+			},
+			HashType:   txscript.SigHashAll,
+			InputIndex: i,
+			KeyDesc: keychain.KeyDescriptor{
+				KeyLocator: in.KeyLocator,
+				PubKey:     key,
+			},
+		}
+	}
+
+	// Generate signatures for all of the htlcs in the transaction.
+	rawSigs, err := s.Lnd.Signer.SignOutputRaw(
+		globalCtx, sweepTx, signDescs,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing: %v", err)
+	}
+
+	if len(rawSigs) != len(inputs) {
+		return nil, nil, fmt.Errorf("expected %v signatures, got %v",
+			len(inputs), len(rawSigs))
+	}
+
+	// Add witness stacks to each of our tx inputs.
+	for i, in := range inputs {
+		sweepTx.TxIn[i].Witness, err = in.WitnessFunc(rawSigs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return sweepTx, inputs, nil
+}
+
+// batchInputFees returns the fee that each of the inputs provided contributes
+// to a batch sweep transaction at the given fee rate. The total fee is split
+// evenly between the inputs, and is capped at each input's maximum fee.
+func batchInputFees(inputs []*BatchInput,
+	feeRate chainfee.SatPerKWeight) ([]btcutil.Amount, error) {
+
+	var weightEstimate input.TxWeightEstimator
+	for _, in := range inputs {
+		in.Htlc.AddSuccessToEstimator(&weightEstimate)
+
+		if err := addOutputEstimate(&weightEstimate, in.DestAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	totalFee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	feeShare := totalFee / btcutil.Amount(len(inputs))
+
+	fees := make([]btcutil.Amount, len(inputs))
+	for i, in := range inputs {
+		fees[i] = feeShare
+		if fees[i] > in.MaxFee {
+			fees[i] = in.MaxFee
+		}
+	}
+
+	return fees, nil
+}
+
 // GetSweepFee calculates the required tx fee to spend to P2WKH. It takes a
 // function that is expected to add the weight of the input to the weight
 // estimator.
@@ -95,14 +269,83 @@ func (s *Sweeper) GetSweepFee(ctx context.Context,
 	destAddr btcutil.Address, sweepConfTarget int32) (
 	btcutil.Amount, error) {
 
+	fee, _, _, err := s.GetSweepFeeDetails(
+		ctx, addInputEstimate, destAddr, sweepConfTarget,
+	)
+	return fee, err
+}
+
+// GetSweepFeeDetails calculates the required tx fee to spend to P2WKH, and
+// also returns the fee rate and weight that were used to calculate it. It takes
+// a function that is expected to add the weight of the input to the weight
+// estimator.
+func (s *Sweeper) GetSweepFeeDetails(ctx context.Context,
+	addInputEstimate func(*input.TxWeightEstimator),
+	destAddr btcutil.Address, sweepConfTarget int32) (
+	btcutil.Amount, chainfee.SatPerKWeight, int64, error) {
+
 	// Get fee estimate from lnd.
 	feeRate, err := s.Lnd.WalletKit.EstimateFee(ctx, sweepConfTarget)
 	if err != nil {
-		return 0, fmt.Errorf("estimate fee: %v", err)
+		return 0, 0, 0, fmt.Errorf("estimate fee: %v", err)
 	}
 
 	// Calculate weight for this tx.
 	var weightEstimate input.TxWeightEstimator
+	if err := addOutputEstimate(&weightEstimate, destAddr); err != nil {
+		return 0, 0, 0, err
+	}
+
+	addInputEstimate(&weightEstimate)
+	weight := int64(weightEstimate.Weight())
+
+	return feeRate.FeeForWeight(weight), feeRate, weight, nil
+}
+
+// GetSweepFeeForRate calculates the tx fee to spend to the destination
+// address provided at the given fee rate. It takes a function that is expected
+// to add the weight of the input to the weight estimator.
+func (s *Sweeper) GetSweepFeeForRate(
+	addInputEstimate func(*input.TxWeightEstimator),
+	destAddr btcutil.Address, feeRate chainfee.SatPerKWeight) (
+	btcutil.Amount, error) {
+
+	var weightEstimate input.TxWeightEstimator
+	if err := addOutputEstimate(&weightEstimate, destAddr); err != nil {
+		return 0, err
+	}
+
+	addInputEstimate(&weightEstimate)
+
+	return feeRate.FeeForWeight(int64(weightEstimate.Weight())), nil
+}
+
+// BumpFeeRate returns the fee rate that a sweep should be published with,
+// given the current fee estimate and the fee rate that it was last published
+// with. We never return a fee rate lower than the last published rate,
+// because a replacement transaction with a lower fee would be rejected. If the
+// last published sweep has not confirmed after bumpBlocks blocks, the rate is
+// increased by FeeRateBumpPercent.
+func BumpFeeRate(estimate, lastFeeRate chainfee.SatPerKWeight,
+	blocksSincePublish, bumpBlocks int32) chainfee.SatPerKWeight {
+
+	feeRate := lastFeeRate
+	if bumpBlocks > 0 && blocksSincePublish >= bumpBlocks {
+		feeRate += lastFeeRate * FeeRateBumpPercent / 100
+	}
+
+	if estimate > feeRate {
+		return estimate
+	}
+
+	return feeRate
+}
+
+// addOutputEstimate adds the weight of an output paying to the destination
+// address provided to a weight estimator.
+func addOutputEstimate(weightEstimate *input.TxWeightEstimator,
+	destAddr btcutil.Address) error {
+
 	switch destAddr.(type) {
 	case *btcutil.AddressWitnessScriptHash:
 		weightEstimate.AddP2WSHOutput()
@@ -113,11 +356,8 @@ func (s *Sweeper) GetSweepFee(ctx context.Context,
 	case *btcutil.AddressPubKeyHash:
 		weightEstimate.AddP2PKHOutput()
 	default:
-		return 0, fmt.Errorf("unknown address type %T", destAddr)
+		return fmt.Errorf("unknown address type %T", destAddr)
 	}
 
-	addInputEstimate(&weightEstimate)
-	weight := weightEstimate.Weight()
-
-	return feeRate.FeeForWeight(int64(weight)), nil
+	return nil
 }
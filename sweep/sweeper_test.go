@@ -0,0 +1,249 @@
+package sweep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/swap"
+	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBatchInput creates a batch input for the htlc of a test swap that is
+// identified by the number provided.
+func newTestBatchInput(t *testing.T, nr byte, value,
+	maxFee btcutil.Amount) *BatchInput {
+
+	_, senderKey := test.CreateKey(1)
+	_, receiverKey := test.CreateKey(2)
+
+	var senderKeyBytes, receiverKeyBytes [33]byte
+	copy(senderKeyBytes[:], senderKey.SerializeCompressed())
+	copy(receiverKeyBytes[:], receiverKey.SerializeCompressed())
+
+	preimage := lntypes.Preimage{nr}
+
+	htlc, err := swap.NewHtlc(
+		swap.HtlcV2, 1000, senderKeyBytes, receiverKeyBytes,
+		preimage.Hash(), swap.HtlcP2WSH, &chaincfg.TestNet3Params,
+	)
+	require.NoError(t, err)
+
+	return &BatchInput{
+		SwapHash: preimage.Hash(),
+		Htlc:     htlc,
+		Outpoint: wire.OutPoint{
+			Hash:  chainhash.Hash{nr},
+			Index: uint32(nr),
+		},
+		Value:    value,
+		KeyBytes: receiverKeyBytes,
+		WitnessFunc: func(sig []byte) (wire.TxWitness, error) {
+			return htlc.GenSuccessWitness(sig, preimage)
+		},
+		DestAddr:   test.GetDestAddr(t, nr),
+		ConfTarget: 6,
+		MaxFee:     maxFee,
+	}
+}
+
+// drainSignRequests consumes the sign requests of our mock signer until the
+// function that it returns is called.
+func drainSignRequests(lnd *test.LndMockServices) func() {
+	quit := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-lnd.SignOutputRawChannel:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(quit)
+	}
+}
+
+// TestBumpFeeRate tests selection of the fee rate that a sweep is republished
+// with.
+func TestBumpFeeRate(t *testing.T) {
+	tests := []struct {
+		name               string
+		estimate           chainfee.SatPerKWeight
+		lastFeeRate        chainfee.SatPerKWeight
+		blocksSincePublish int32
+		bumpBlocks         int32
+		expected           chainfee.SatPerKWeight
+	}{
+		{
+			name:               "estimate above last rate",
+			estimate:           2000,
+			lastFeeRate:        1000,
+			blocksSincePublish: 1,
+			bumpBlocks:         3,
+			expected:           2000,
+		},
+		{
+			name:               "estimate below last rate",
+			estimate:           500,
+			lastFeeRate:        1000,
+			blocksSincePublish: 1,
+			bumpBlocks:         3,
+			expected:           1000,
+		},
+		{
+			name:               "bump after blocks",
+			estimate:           500,
+			lastFeeRate:        1000,
+			blocksSincePublish: 3,
+			bumpBlocks:         3,
+			expected:           1250,
+		},
+		{
+			name:               "estimate above bumped rate",
+			estimate:           1500,
+			lastFeeRate:        1000,
+			blocksSincePublish: 3,
+			bumpBlocks:         3,
+			expected:           1500,
+		},
+		{
+			name:               "bumping disabled",
+			estimate:           500,
+			lastFeeRate:        1000,
+			blocksSincePublish: 10,
+			bumpBlocks:         0,
+			expected:           1000,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			feeRate := BumpFeeRate(
+				testCase.estimate, testCase.lastFeeRate,
+				testCase.blocksSincePublish, testCase.bumpBlocks,
+			)
+			require.Equal(t, testCase.expected, feeRate)
+		})
+	}
+}
+
+// TestTxLabel tests that transaction labels are omitted when they are
+// disabled.
+func TestTxLabel(t *testing.T) {
+	sweeper := &Sweeper{}
+	require.Equal(t, "label", sweeper.TxLabel("label"))
+
+	sweeper.NoTxLabels = true
+	require.Equal(t, "", sweeper.TxLabel("label"))
+}
+
+// TestCreateBatchSweepTx tests the composition of batch sweep transactions,
+// including leaving out inputs that cannot afford their share of the fee or
+// that would leave a dust output.
+func TestCreateBatchSweepTx(t *testing.T) {
+	lnd := test.NewMockLnd()
+	defer drainSignRequests(lnd)()
+
+	sweeper := &Sweeper{Lnd: &lnd.LndServices}
+	feeRate := chainfee.SatPerKWeight(10000)
+
+	var (
+		// large can comfortably afford its share of the fee.
+		large = newTestBatchInput(t, 1, 100000, 20000)
+
+		// capped cannot afford the full fee share, but its maximum fee
+		// leaves it with a positive output.
+		capped = newTestBatchInput(t, 2, 4000, 1000)
+
+		// dust would spend its full value on its fee share.
+		dust = newTestBatchInput(t, 3, 4000, 20000)
+
+		// nearDust can afford its maximum fee, but the output that it
+		// leaves is below the dust limit.
+		nearDust = newTestBatchInput(t, 4, 1200, 1000)
+	)
+
+	tests := []struct {
+		name     string
+		inputs   []*BatchInput
+		included []*BatchInput
+		err      bool
+	}{
+		{
+			name:     "all inputs swept",
+			inputs:   []*BatchInput{large, capped},
+			included: []*BatchInput{large, capped},
+		},
+		{
+			name:     "uneconomical input deferred",
+			inputs:   []*BatchInput{large, dust, capped},
+			included: []*BatchInput{large, capped},
+		},
+		{
+			name:     "dust output deferred",
+			inputs:   []*BatchInput{large, nearDust},
+			included: []*BatchInput{large},
+		},
+		{
+			name:   "no economical inputs",
+			inputs: []*BatchInput{dust},
+			err:    true,
+		},
+		{
+			name: "no inputs",
+			err:  true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			tx, included, err := sweeper.CreateBatchSweepTx(
+				context.Background(), 100, testCase.inputs,
+				feeRate,
+			)
+			if testCase.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.included, included)
+
+			fees, err := batchInputFees(testCase.included, feeRate)
+			require.NoError(t, err)
+
+			require.Len(t, tx.TxIn, len(testCase.included))
+			require.Len(t, tx.TxOut, len(testCase.included))
+			require.Equal(t, uint32(100), tx.LockTime)
+
+			for i, in := range testCase.included {
+				require.Equal(
+					t, in.Outpoint, tx.TxIn[i].PreviousOutPoint,
+				)
+				require.NotEmpty(t, tx.TxIn[i].Witness)
+				require.Equal(
+					t, int64(in.Value-fees[i]),
+					tx.TxOut[i].Value,
+				)
+			}
+		})
+	}
+
+	// Our capped input should only have paid its maximum fee.
+	fees, err := batchInputFees([]*BatchInput{large, capped}, feeRate)
+	require.NoError(t, err)
+	require.Equal(t, capped.MaxFee, fees[1])
+	require.Greater(t, int64(fees[0]), int64(capped.MaxFee))
+}
@@ -74,9 +74,11 @@ func (s *mockInvoices) AddHoldInvoice(ctx context.Context,
 
 	payReq, err := zpay32.NewInvoice(
 		s.lnd.ChainParams, *hash, creationDate,
-		zpay32.Description(in.Memo),
-		zpay32.CLTVExpiry(in.CltvExpiry),
-		zpay32.Amount(in.Value),
+		routeHintOptions(in.RouteHints,
+			zpay32.Description(in.Memo),
+			zpay32.CLTVExpiry(in.CltvExpiry),
+			zpay32.Amount(in.Value),
+		)...,
 	)
 	if err != nil {
 		return "", err
@@ -105,9 +105,11 @@ func (h *mockLightningClient) AddInvoice(ctx context.Context,
 
 	payReq, err := zpay32.NewInvoice(
 		h.lnd.ChainParams, hash, creationDate,
-		zpay32.Description(in.Memo),
-		zpay32.CLTVExpiry(in.CltvExpiry),
-		zpay32.Amount(in.Value),
+		routeHintOptions(in.RouteHints,
+			zpay32.Description(in.Memo),
+			zpay32.CLTVExpiry(in.CltvExpiry),
+			zpay32.Amount(in.Value),
+		)...,
 	)
 	if err != nil {
 		return lntypes.Hash{}, "", err
@@ -187,6 +189,21 @@ func (h *mockLightningClient) ListChannels(ctx context.Context) (
 	return h.lnd.Channels, nil
 }
 
+// GetChanInfo returns the channel edge that is set for a channel in our mock.
+func (h *mockLightningClient) GetChanInfo(_ context.Context,
+	chanID uint64) (*lndclient.ChannelEdge, error) {
+
+	h.lnd.lock.Lock()
+	defer h.lnd.lock.Unlock()
+
+	edge, ok := h.lnd.ChannelEdges[chanID]
+	if !ok {
+		return nil, fmt.Errorf("channel %v not found", chanID)
+	}
+
+	return edge, nil
+}
+
 // ClosedChannels returns a list of our closed channels.
 func (h *mockLightningClient) ClosedChannels(_ context.Context) ([]lndclient.ClosedChannel,
 	error) {
@@ -242,3 +259,15 @@ func (h *mockLightningClient) ChannelBackup(context.Context, wire.OutPoint) ([]b
 func (h *mockLightningClient) ChannelBackups(ctx context.Context) ([]byte, error) {
 	return nil, nil
 }
+
+// routeHintOptions appends an invoice option for each of the route hints
+// provided to a set of invoice options.
+func routeHintOptions(hints [][]zpay32.HopHint,
+	options ...func(*zpay32.Invoice)) []func(*zpay32.Invoice) {
+
+	for _, hint := range hints {
+		options = append(options, zpay32.RouteHint(hint))
+	}
+
+	return options
+}
@@ -24,7 +24,10 @@ func (s *mockSigner) SignOutputRaw(ctx context.Context, tx *wire.MsgTx,
 		SignDescriptors: signDescriptors,
 	}
 
-	rawSigs := [][]byte{{1, 2, 3}}
+	rawSigs := make([][]byte, len(signDescriptors))
+	for i := range rawSigs {
+		rawSigs[i] = []byte{1, 2, 3}
+	}
 
 	return rawSigs, nil
 }
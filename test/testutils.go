@@ -28,7 +28,7 @@ var (
 )
 
 // GetDestAddr deterministically generates a sweep address for testing.
-func GetDestAddr(t *testing.T, nr byte) btcutil.Address {
+func GetDestAddr(t testing.TB, nr byte) btcutil.Address {
 	destAddr, err := btcutil.NewAddressScriptHash([]byte{nr},
 		&chaincfg.MainNetParams)
 	if err != nil {
@@ -2,6 +2,7 @@ package loop
 
 import (
 	"context"
+	"crypto/rand"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/lightninglabs/loop/sweep"
 	"github.com/lightninglabs/loop/test"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/stretchr/testify/require"
@@ -47,12 +49,25 @@ func newSwapClient(config *clientConfig) *Client {
 
 	lndServices := config.LndServices
 
+	// Fall back to the system clock and secure randomness if the test does
+	// not need to control them.
+	if config.Clock == nil {
+		config.Clock = clock.NewDefaultClock()
+	}
+
+	if config.Rand == nil {
+		config.Rand = rand.Reader
+	}
+
 	executor := newExecutor(&executorConfig{
-		lnd:               lndServices,
-		store:             config.Store,
-		sweeper:           sweeper,
-		createExpiryTimer: config.CreateExpiryTimer,
-		cancelSwap:        config.Server.CancelLoopOutSwap,
+		lnd:                    lndServices,
+		store:                  config.Store,
+		sweeper:                sweeper,
+		createExpiryTimer:      config.CreateExpiryTimer,
+		cancelSwap:             config.Server.CancelLoopOutSwap,
+		abandonLoopOut:         config.Server.AbandonLoopOutSwap,
+		clock:                  config.Clock,
+		minPreimageRevealDelta: MinLoopOutPreimageRevealDelta,
 	})
 
 	return &Client{
@@ -62,6 +77,8 @@ func newSwapClient(config *clientConfig) *Client {
 		sweeper:      sweeper,
 		executor:     executor,
 		resumeReady:  make(chan struct{}),
+
+		idempotencyKeys: make(map[string]chan struct{}),
 	}
 }
 
@@ -3,17 +3,57 @@ package loop
 import (
 	"context"
 
+	"github.com/lightninglabs/loop/loopdb"
+	"github.com/lightninglabs/loop/looprpc"
 	"github.com/lightninglabs/loop/swap"
 	"github.com/lightningnetwork/lnd/lntypes"
 )
 
+// serverFailureReason returns the failure reason that a server swap state
+// represents, or ServerFailureNone if the state is not a failure.
+func serverFailureReason(
+	state looprpc.ServerSwapState) loopdb.ServerFailureReason {
+
+	switch state {
+	case looprpc.ServerSwapState_SERVER_FAILED_UNKNOWN,
+		looprpc.ServerSwapState_SERVER_UNEXPECTED_FAILURE:
+
+		return loopdb.ServerFailureUnknown
+
+	case looprpc.ServerSwapState_SERVER_FAILED_NO_HTLC:
+		return loopdb.ServerFailureNoHtlc
+
+	case looprpc.ServerSwapState_SERVER_FAILED_INVALID_HTLC_AMOUNT:
+		return loopdb.ServerFailureInvalidHtlcAmount
+
+	case looprpc.ServerSwapState_SERVER_FAILED_OFF_CHAIN_TIMEOUT:
+		return loopdb.ServerFailureOffchainTimeout
+
+	case looprpc.ServerSwapState_SERVER_FAILED_TIMEOUT,
+		looprpc.ServerSwapState_SERVER_TIMEOUT_PUBLISHED:
+
+		return loopdb.ServerFailureTimeout
+
+	case looprpc.ServerSwapState_SERVER_FAILED_SWAP_DEADLINE:
+		return loopdb.ServerFailureSwapDeadline
+
+	case looprpc.ServerSwapState_SERVER_FAILED_HTLC_PUBLICATION:
+		return loopdb.ServerFailureHtlcPublication
+
+	default:
+		return loopdb.ServerFailureNone
+	}
+}
+
 // subscribeAndLogUpdates subscribes to updates for a swap and logs them. This
 // function will block, so should run as a goroutine. Note that our subscription
 // does not survive server restarts; we will simply not have update logs if the
-// server restarts during swap execution.
+// server restarts during swap execution. If the server reports that the swap
+// has failed, its failure reason is passed to the recordFailure function.
 func subscribeAndLogUpdates(ctx context.Context, hash lntypes.Hash,
 	log *swap.PrefixLog, subscribe func(context.Context,
-		lntypes.Hash) (<-chan *ServerUpdate, <-chan error, error)) {
+		lntypes.Hash) (<-chan *ServerUpdate, <-chan error, error),
+	recordFailure func(loopdb.ServerFailureReason)) {
 
 	subscribeChan, errChan, err := subscribe(ctx, hash)
 	if err != nil {
@@ -28,6 +68,11 @@ func subscribeAndLogUpdates(ctx context.Context, hash lntypes.Hash,
 			log.Infof("Server update: %v received, "+
 				"timestamp: %v", update.State, update.Timestamp)
 
+			reason := serverFailureReason(update.State)
+			if reason != loopdb.ServerFailureNone {
+				recordFailure(reason)
+			}
+
 		// If we get an error from the server, we check whether it is
 		// due to server exit, or restart, and log this information
 		// for the client. Otherwise, we just log non-nil errors.
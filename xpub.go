@@ -0,0 +1,90 @@
+package loop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/lightninglabs/loop/loopdb"
+)
+
+// ErrNoDestXpub is returned when a sweep address is requested from our
+// destination xpub, but no xpub is configured.
+var ErrNoDestXpub = errors.New("no destination xpub configured")
+
+// parseDestXpub parses an extended public key that loop out sweep addresses
+// are derived from and checks that it belongs to the network we are running
+// on.
+func parseDestXpub(xpub string, params *chaincfg.Params) (
+	*hdkeychain.ExtendedKey, error) {
+
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination xpub: %v", err)
+	}
+
+	if key.IsPrivate() {
+		return nil, errors.New("destination xpub must be an extended " +
+			"public key, not a private key")
+	}
+
+	if !key.IsForNet(params) {
+		return nil, fmt.Errorf("destination xpub is not for network "+
+			"%v", params.Name)
+	}
+
+	return key, nil
+}
+
+// nextXpubAddress derives a fresh p2wkh address from the configured
+// destination xpub. Addresses are derived from the external branch of the
+// key (xpub/0/i) at the next index that our store has not seen used. The
+// index is only marked as used when a swap that sweeps to the address is
+// stored, so the caller must hold xpubLock until then to make sure that the
+// address is not handed out twice.
+func (s *Client) nextXpubAddress() (btcutil.Address, *loopdb.XpubIndex,
+	error) {
+
+	if s.destXpub == nil {
+		return nil, nil, ErrNoDestXpub
+	}
+
+	external, err := s.destXpub.Derive(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	xpub := s.destXpub.String()
+	index, err := s.Store.FetchXpubIndex(xpub)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	child, err := external.Derive(index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()),
+		s.lndServices.ChainParams,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Infof("Derived sweep address %v from destination xpub at index %v",
+		addr, index)
+
+	return addr, &loopdb.XpubIndex{
+		Xpub:  xpub,
+		Index: index,
+	}, nil
+}